@@ -315,7 +315,7 @@ func (out *output) printPkgDetails(pkg *hub.Package) {
 	// Values specific to a repository kind
 	switch pkg.Repository.Kind {
 	case hub.Helm:
-		if pkg.ValuesSchema != nil {
+		if pkg.ValuesSchema != nil && !pkg.ValuesSchemaInferred {
 			fmt.Fprintf(out, "%c Values schema: %s\n", success, provided)
 		} else {
 			fmt.Fprintf(out, "%c Values schema: %s\n", warning, notProvided)
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/artifacthub/hub/internal/util"
+)
+
+const configCmdUsage = "usage: hub config validate [hub|scanner|tracker]"
+
+// runConfigCmd runs the config subcommand with the arguments provided,
+// returning the exit code the process should use. Only the validate action is
+// supported at the moment, which checks the configuration of the cmd provided
+// (hub by default) and prints all the issues found.
+func runConfigCmd(args []string) int {
+	if len(args) == 0 || len(args) > 2 || args[0] != "validate" {
+		fmt.Fprintln(os.Stderr, configCmdUsage)
+		return 2
+	}
+	cmd := "hub"
+	if len(args) == 2 {
+		cmd = args[1]
+	}
+	switch cmd {
+	case "hub", "scanner", "tracker":
+	default:
+		fmt.Fprintln(os.Stderr, configCmdUsage)
+		return 2
+	}
+
+	cfg, err := util.SetupConfig(cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading %s configuration: %v\n", cmd, err)
+		return 1
+	}
+	if err := util.ValidateConfig(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid %s configuration: %v\n", cmd, err)
+		return 1
+	}
+	fmt.Printf("%s configuration is valid\n", cmd)
+	return 0
+}
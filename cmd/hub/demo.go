@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/artifacthub/hub/internal/demo"
+	"github.com/artifacthub/hub/internal/img/pg"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/util"
+)
+
+const demoCmdUsage = "usage: hub demo seed [-password PASSWORD]"
+
+// runDemoCmd runs the demo subcommand with the arguments provided, returning
+// the exit code the process should use. Only the seed action is supported at
+// the moment, which populates a fresh instance with some generated demo data
+// (no external services are reached).
+func runDemoCmd(args []string) int {
+	if len(args) == 0 || args[0] != "seed" {
+		fmt.Fprintln(os.Stderr, demoCmdUsage)
+		return 2
+	}
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	password := fs.String("password", "changeme", "password of the demo users")
+	if err := fs.Parse(args[1:]); err != nil || fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, demoCmdUsage)
+		return 2
+	}
+
+	// Setup services
+	cfg, err := util.SetupConfig("hub")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading hub configuration: %v\n", err)
+		return 1
+	}
+	db, err := util.SetupDB(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error setting up database: %v\n", err)
+		return 1
+	}
+	pm := pkg.NewManager(db)
+	is := pg.NewImageStore(cfg, db, nil, nil)
+
+	// Seed demo data
+	summary, err := demo.NewSeeder(db, pm, is).Seed(context.Background(), *password)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error seeding demo data: %v\n", err)
+		return 1
+	}
+	fmt.Printf(
+		"demo data seeded: %d users, %d organizations, %d repositories, %d packages (%d versions)\n",
+		summary.Users,
+		summary.Organizations,
+		summary.Repositories,
+		summary.Packages,
+		summary.Versions,
+	)
+	fmt.Println("you can log in as demo (or alice, bob) using the password provided")
+	return 0
+}
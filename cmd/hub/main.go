@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -13,14 +14,21 @@ import (
 	"github.com/artifacthub/hub/internal/apikey"
 	"github.com/artifacthub/hub/internal/authz"
 	"github.com/artifacthub/hub/internal/email"
+	"github.com/artifacthub/hub/internal/emailspool"
 	"github.com/artifacthub/hub/internal/event"
 	"github.com/artifacthub/hub/internal/handlers"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/imageupload"
 	"github.com/artifacthub/hub/internal/img/pg"
+	"github.com/artifacthub/hub/internal/legalhold"
+	"github.com/artifacthub/hub/internal/moderator"
 	"github.com/artifacthub/hub/internal/notification"
 	"github.com/artifacthub/hub/internal/org"
 	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/promotion"
 	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/reports"
+	"github.com/artifacthub/hub/internal/serviceaccount"
 	"github.com/artifacthub/hub/internal/stats"
 	"github.com/artifacthub/hub/internal/subscription"
 	"github.com/artifacthub/hub/internal/user"
@@ -30,7 +38,23 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+const (
+	// secretsCheckInterval represents how often the secrets files are checked
+	// for changes.
+	secretsCheckInterval = 30 * time.Second
+)
+
 func main() {
+	// Run config subcommand when requested (i.e. hub config validate)
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfigCmd(os.Args[2:]))
+	}
+
+	// Run demo subcommand when requested (i.e. hub demo seed)
+	if len(os.Args) > 1 && os.Args[1] == "demo" {
+		os.Exit(runDemoCmd(os.Args[2:]))
+	}
+
 	// Setup configuration and logger
 	cfg, err := util.SetupConfig("hub")
 	if err != nil {
@@ -40,6 +64,9 @@ func main() {
 	if err := util.SetupLogger(cfg, fields); err != nil {
 		log.Fatal().Err(err).Msg("logger setup failed")
 	}
+	if err := util.ValidateConfig(cfg); err != nil {
+		log.Fatal().Err(err).Msg("invalid configuration")
+	}
 
 	// Setup services
 	db, err := util.SetupDB(cfg)
@@ -47,35 +74,64 @@ func main() {
 		log.Fatal().Err(err).Msg("database setup failed")
 	}
 	var es hub.EmailSender
+	var rawES hub.EmailSender
 	if s := email.NewSender(cfg); s != nil {
-		es = s
+		rawES = s
+	}
+	esp := emailspool.NewSpool(cfg, db, rawES)
+	if rawES != nil {
+		es = esp
 	}
 	az, err := authz.NewAuthorizer(db)
 	if err != nil {
 		log.Fatal().Err(err).Msg("authorizer setup failed")
 	}
 	hc := util.SetupHTTPClient(cfg.GetBool("restrictedHTTPClient"))
+	akm := apikey.NewManager(cfg, db)
+	rs, err := reports.NewStore(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("reports store setup failed")
+	}
+	pm := pkg.NewManager(db, pkg.WithReportsStore(rs))
+	is := pg.NewImageStore(cfg, db, hc, nil)
 
 	// Setup and launch http server
 	ctx, stop := context.WithCancel(context.Background())
 	hSvc := &handlers.Services{
-		OrganizationManager: org.NewManager(cfg, db, es, az),
-		UserManager:         user.NewManager(cfg, db, es),
-		RepositoryManager:   repo.NewManager(cfg, db, az, hc),
-		PackageManager:      pkg.NewManager(db),
-		SubscriptionManager: subscription.NewManager(db),
-		WebhookManager:      webhook.NewManager(db),
-		APIKeyManager:       apikey.NewManager(db),
-		StatsManager:        stats.NewManager(db),
-		ImageStore:          pg.NewImageStore(cfg, db, hc, nil),
-		Authorizer:          az,
-		HTTPClient:          hc,
+		OrganizationManager:    org.NewManager(cfg, db, es, az),
+		UserManager:            user.NewManager(cfg, db, es),
+		RepositoryManager:      repo.NewManager(cfg, db, az, hc),
+		PackageManager:         pm,
+		SubscriptionManager:    subscription.NewManager(db),
+		WebhookManager:         webhook.NewManager(cfg, db, az),
+		APIKeyManager:          akm,
+		ServiceAccountManager:  serviceaccount.NewManager(db, az, akm),
+		PromotedPackageManager: promotion.NewManager(db),
+		LegalHoldManager:       legalhold.NewManager(db),
+		EmailSpoolManager:      esp,
+		ModeratorManager:       moderator.NewManager(db),
+		StatsManager:           stats.NewManager(db),
+		ImageUploadManager:     imageupload.NewManager(cfg, db, az, is),
+		ImageStore:             is,
+		Authorizer:             az,
+		HTTPClient:             hc,
 	}
 	h, err := handlers.Setup(ctx, cfg, hSvc)
 	if err != nil {
 		log.Fatal().Err(err).Msg("handlers setup failed")
 	}
 	addr := cfg.GetString("server.addr")
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatal().Err(err).Msg("hub server listen failed")
+	}
+	if cfg.GetBool("server.proxyProtocol") {
+		trustedProxies, err := util.NewTrustedProxies(cfg.GetStringSlice("server.trustedProxies"))
+		if err != nil {
+			log.Fatal().Err(err).Msg("trusted proxies setup failed")
+		}
+		l = &util.ProxyProtocolListener{Listener: l, TrustedProxies: trustedProxies}
+	}
 	srv := &http.Server{
 		Addr:         addr,
 		ReadTimeout:  5 * time.Second,
@@ -84,8 +140,8 @@ func main() {
 		Handler:      h.Router,
 	}
 	go func() {
-		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-			log.Fatal().Err(err).Msg("hub server ListenAndServe failed")
+		if err := srv.Serve(l); !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal().Err(err).Msg("hub server Serve failed")
 		}
 	}()
 	log.Info().Str("addr", addr).Int("pid", os.Getpid()).Msg("hub server running!")
@@ -105,7 +161,7 @@ func main() {
 		DB:                  db,
 		EventManager:        event.NewManager(),
 		SubscriptionManager: subscription.NewManager(db),
-		WebhookManager:      webhook.NewManager(db),
+		WebhookManager:      webhook.NewManager(cfg, db, az),
 		NotificationManager: notification.NewManager(),
 	}
 	eventsDispatcher := event.NewDispatcher(eSvc)
@@ -120,13 +176,25 @@ func main() {
 		NotificationManager: notification.NewManager(),
 		SubscriptionManager: subscription.NewManager(db),
 		RepositoryManager:   repo.NewManager(cfg, db, az, hc),
-		PackageManager:      pkg.NewManager(db),
+		PackageManager:      pm,
 		HTTPClient:          hc,
 	}
 	notificationsDispatcher := notification.NewDispatcher(nSvc)
 	wg.Add(1)
 	go notificationsDispatcher.Run(ctx, &wg)
 
+	// Launch email spool worker
+	if rawES != nil {
+		wg.Add(1)
+		go esp.Run(ctx, &wg)
+	}
+
+	// Watch secrets files to warn when they change (i.e. when a Kubernetes
+	// secret is rotated), as the server must be restarted to use them
+	go util.NewSecretsWatcher(cfg).Run(ctx, secretsCheckInterval, func(keys []string) {
+		log.Warn().Strs("keys", keys).Msg("secrets changed, hub server must be restarted to apply them")
+	})
+
 	// Shutdown server gracefully when SIGINT or SIGTERM signal is received
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
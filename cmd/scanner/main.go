@@ -12,6 +12,7 @@ import (
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/reports"
 	"github.com/artifacthub/hub/internal/scanner"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/rs/zerolog/log"
@@ -27,6 +28,9 @@ func main() {
 	if err := util.SetupLogger(cfg, fields); err != nil {
 		log.Fatal().Err(err).Msg("logger setup failed")
 	}
+	if err := util.ValidateConfig(cfg); err != nil {
+		log.Fatal().Err(err).Msg("invalid configuration")
+	}
 
 	// Shutdown gracefully when SIGINT or SIGTERM signal is received
 	log.Info().Int("pid", os.Getpid()).Msg("scanner started")
@@ -55,10 +59,27 @@ func main() {
 	}
 	hc := util.SetupHTTPClient(cfg.GetBool("restrictedHTTPClient"))
 	rm := repo.NewManager(cfg, db, az, hc)
-	pm := pkg.NewManager(db)
+	rs, err := reports.NewStore(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("reports store setup failed")
+	}
+	pm := pkg.NewManager(db, pkg.WithReportsStore(rs))
 	ec := repo.NewErrorsCollector(rm, repo.Scanner)
 	s := scanner.New(ctx, cfg, ec)
 
+	// Move security reports stored in the database to the reports store. A
+	// batch of reports is migrated on each run until none are left.
+	if rs != nil {
+		cfg.SetDefault("reports.migrationBatchSize", 100)
+		migrated, err := pm.MigrateSecurityReports(ctx, cfg.GetInt("reports.migrationBatchSize"))
+		if err != nil {
+			log.Error().Err(err).Msg("error migrating security reports")
+		}
+		if migrated > 0 {
+			log.Info().Int("migrated", migrated).Msg("security reports migrated to reports store")
+		}
+	}
+
 	// Scan pending snapshots
 	snapshots, err := pm.GetSnapshotsToScan(ctx)
 	if err != nil {
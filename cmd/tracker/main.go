@@ -12,9 +12,12 @@ import (
 	"time"
 
 	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/chartscache"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/imageupload"
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/reports"
 	"github.com/artifacthub/hub/internal/tracker"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/rs/zerolog/log"
@@ -41,6 +44,9 @@ func main() {
 	if err := util.SetupLogger(cfg, fields); err != nil {
 		log.Fatal().Err(err).Msg("logger setup failed")
 	}
+	if err := util.ValidateConfig(cfg); err != nil {
+		log.Fatal().Err(err).Msg("invalid configuration")
+	}
 
 	// Shutdown gracefully when SIGINT or SIGTERM signal is received
 	log.Info().Int("pid", os.Getpid()).Msg("tracker started")
@@ -69,7 +75,11 @@ func main() {
 	}
 	hc := util.SetupHTTPClient(cfg.GetBool("restrictedHTTPClient"))
 	rm := repo.NewManager(cfg, db, az, hc)
-	pm := pkg.NewManager(db)
+	rs, err := reports.NewStore(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("reports store setup failed")
+	}
+	pm := pkg.NewManager(db, pkg.WithReportsStore(rs))
 	githubMaxRequestsPerHour := githubMaxRequestsPerHourUnauthenticated
 	if cfg.GetString("creds.githubToken") != "" {
 		githubMaxRequestsPerHour = githubMaxRequestsPerHourAuthenticated
@@ -79,31 +89,82 @@ func main() {
 		<-time.After(1 * time.Hour)
 		githubRL.SetLimit(rate.Every(1 * time.Hour / time.Duration(githubMaxRequestsPerHour)))
 	}()
+	rl, err := util.SetupLimiterRegistry(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("limiter registry setup failed")
+	}
+	rl.SetHostRateLimiter("github.com", githubRL)
+	rl.SetHostRateLimiter("raw.githubusercontent.com", githubRL)
 	is, err := util.SetupImageStore(cfg, db, hc, githubRL)
 	if err != nil {
 		log.Fatal().Err(err).Msg("image store setup failed")
 	}
+	ium := imageupload.NewManager(cfg, db, az, is)
+	gts, err := repo.SetupGithubAppTokenSource(cfg, hc)
+	if err != nil {
+		log.Fatal().Err(err).Msg("github app token source setup failed")
+	}
+	cc, err := chartscache.NewCache(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("charts cache setup failed")
+	}
 	ec := repo.NewErrorsCollector(rm, repo.Tracker)
 	svc := &hub.TrackerServices{
 		Ctx:                ctx,
 		Cfg:                cfg,
 		Rm:                 rm,
 		Pm:                 pm,
-		Rc:                 &repo.Cloner{},
-		Oe:                 &repo.OLMOCIExporter{},
+		Rc:                 repo.NewCloner(cfg, gts),
+		Oe:                 &repo.OLMOCIExporter{Cfg: cfg},
 		Ec:                 ec,
 		Hc:                 hc,
 		Is:                 is,
-		GithubRL:           githubRL,
+		Rl:                 rl,
+		Cc:                 cc,
+		Dr:                 &repo.OCIImageDigestResolver{Cfg: cfg, Rl: rl},
 		SetupTrackerSource: tracker.SetupSource,
 	}
 
+	cfg.SetDefault("tracker.concurrency", 1)
+	cfg.SetDefault("tracker.generateIdenticons", false)
+
+	// When listening for tracking requests, the repositories are tracked as
+	// soon as their tracking is requested (i.e. via webhook) until the tracker
+	// is shut down
+	if cfg.GetBool("tracker.listenForTrackingRequests") {
+		log.Info().Msg("listening for tracking requests")
+		tracker.ListenForTrackingRequests(ctx, cfg, db, rm, func(r *hub.Repository) {
+			// Each request gets its own errors collector so that only the
+			// results of the repository tracked are flushed
+			ec := repo.NewErrorsCollector(rm, repo.Tracker)
+			reqSvc := *svc
+			reqSvc.Ec = ec
+			trackRepository(&reqSvc, r)
+			ec.Flush()
+		})
+		log.Info().Msg("tracker finished")
+		return
+	}
+
+	// Set an identicon as logo of the packages registered without one before
+	// identicons were enabled. A batch of packages is processed on each run
+	// until none are left.
+	if cfg.GetBool("tracker.generateIdenticons") {
+		cfg.SetDefault("tracker.identiconsBatchSize", 100)
+		generated, err := tracker.GenerateMissingIdenticons(ctx, pm, is, cfg.GetInt("tracker.identiconsBatchSize"))
+		if err != nil {
+			log.Error().Err(err).Msg("error generating missing identicons")
+		}
+		if generated > 0 {
+			log.Info().Int("generated", generated).Msg("identicons generated for packages without logo")
+		}
+	}
+
 	// Track registered repositories
 	repos, err := tracker.GetRepositories(ctx, cfg, rm)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error getting repositories")
 	}
-	cfg.SetDefault("tracker.concurrency", 1)
 	limiter := make(chan struct{}, cfg.GetInt("tracker.concurrency"))
 	var wg sync.WaitGroup
 L:
@@ -121,32 +182,53 @@ L:
 				<-limiter
 				wg.Done()
 			}()
-			logger := log.With().Str("repo", r.Name).Str("kind", hub.GetKindName(r.Kind)).Logger()
-			done := make(chan struct{})
-			go func() {
-				defer func() {
-					done <- struct{}{}
-				}()
-				defer func() {
-					if r := recover(); r != nil {
-						logger.Error().Bytes("stacktrace", debug.Stack()).Interface("recover", r).Send()
-					}
-				}()
-				t := tracker.New(svc, r, logger)
-				if err := t.Run(); err != nil {
-					logger.Error().Err(err).Send()
-					svc.Ec.Append(r.RepositoryID, err.Error())
-				}
-			}()
-			select {
-			case <-done:
-			case <-time.After(repositoryTimeout):
-				logger.Error().Err(errTimeout).Send()
-				svc.Ec.Append(r.RepositoryID, errTimeout.Error())
-			}
+			trackRepository(svc, r)
 		}(r)
 	}
 	wg.Wait()
 	ec.Flush()
+
+	// Refresh packages freshness information
+	if err := pm.UpdateFreshness(ctx); err != nil {
+		log.Error().Err(err).Msg("error updating packages freshness")
+	}
+
+	// Refresh packages discovery categories
+	if err := pm.RefreshDiscovery(ctx); err != nil {
+		log.Error().Err(err).Msg("error refreshing packages discovery")
+	}
+
+	// Delete images uploads abandoned before completion
+	if err := ium.DeleteAbandoned(ctx); err != nil {
+		log.Error().Err(err).Msg("error deleting abandoned images uploads")
+	}
 	log.Info().Msg("tracker finished")
 }
+
+// trackRepository tracks the repository provided, giving up when it takes
+// longer than the repository timeout. Errors are sent to the errors collector.
+func trackRepository(svc *hub.TrackerServices, r *hub.Repository) {
+	logger := log.With().Str("repo", r.Name).Str("kind", hub.GetKindName(r.Kind)).Logger()
+	done := make(chan struct{})
+	go func() {
+		defer func() {
+			done <- struct{}{}
+		}()
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error().Bytes("stacktrace", debug.Stack()).Interface("recover", r).Send()
+			}
+		}()
+		t := tracker.New(svc, r, logger)
+		if err := t.Run(); err != nil {
+			logger.Error().Err(err).Send()
+			svc.Ec.Append(r.RepositoryID, err.Error())
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(repositoryTimeout):
+		logger.Error().Err(errTimeout).Send()
+		svc.Ec.Append(r.RepositoryID, errTimeout.Error())
+	}
+}
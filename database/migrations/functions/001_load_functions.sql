@@ -7,10 +7,22 @@
 {{ template "api_keys/get_user_api_keys.sql" }}
 {{ template "api_keys/update_api_key.sql" }}
 
+{{ template "email_spool/flush_email_spool.sql" }}
+
 {{ template "events/get_pending_event.sql" }}
 
 {{ template "images/get_image.sql" }}
+{{ template "images/get_image_source.sql" }}
 {{ template "images/register_image.sql" }}
+{{ template "images/register_image_source.sql" }}
+
+{{ template "legal_holds/add_legal_hold.sql" }}
+{{ template "legal_holds/get_legal_holds.sql" }}
+{{ template "legal_holds/release_legal_hold.sql" }}
+
+{{ template "moderators/add_moderator.sql" }}
+{{ template "moderators/get_moderators.sql" }}
+{{ template "moderators/remove_moderator.sql" }}
 
 {{ template "notifications/add_notification.sql" }}
 {{ template "notifications/get_pending_notification.sql" }}
@@ -18,48 +30,104 @@
 
 {{ template "organizations/add_organization_member.sql" }}
 {{ template "organizations/add_organization.sql" }}
+{{ template "organizations/cancel_organization_invitation.sql" }}
 {{ template "organizations/confirm_organization_membership.sql" }}
 {{ template "organizations/delete_organization.sql" }}
 {{ template "organizations/delete_organization_member.sql" }}
 {{ template "organizations/get_authorization_policies.sql" }}
 {{ template "organizations/get_authorization_policy.sql" }}
 {{ template "organizations/get_organization.sql" }}
+{{ template "organizations/get_organization_idp_groups.sql" }}
+{{ template "organizations/get_organization_invitations.sql" }}
+{{ template "organizations/get_organization_links.sql" }}
 {{ template "organizations/get_organization_members.sql" }}
+{{ template "organizations/get_organization_quotas.sql" }}
+{{ template "organizations/get_user_organization_role.sql" }}
 {{ template "organizations/get_user_organizations.sql" }}
+{{ template "organizations/resend_organization_invitation.sql" }}
 {{ template "organizations/update_authorization_policy.sql" }}
 {{ template "organizations/update_organization.sql" }}
+{{ template "organizations/update_organization_idp_groups.sql" }}
+{{ template "organizations/update_organization_links.sql" }}
+{{ template "organizations/update_organization_member_role.sql" }}
 {{ template "organizations/user_belongs_to_organization.sql" }}
+{{ template "organizations/verify_organization_links.sql" }}
 
 {{ template "packages/are_all_containers_images_whitelisted.sql" }}
+{{ template "packages/build_package_document.sql" }}
+{{ template "packages/build_package_level_document.sql" }}
+{{ template "packages/generate_package_search_data.sql" }}
 {{ template "packages/generate_package_tsdoc.sql" }}
 {{ template "packages/get_harbor_replication_dump.sql" }}
+{{ template "packages/get_org_stale_packages.sql" }}
 {{ template "packages/get_package.sql" }}
+{{ template "packages/get_package_by_digest.sql" }}
 {{ template "packages/get_package_changelog.sql" }}
+{{ template "packages/get_package_events.sql" }}
+{{ template "packages/get_package_head.sql" }}
 {{ template "packages/get_package_summary.sql" }}
+{{ template "packages/get_packages_changes.sql" }}
+{{ template "packages/get_packages_comparison.sql" }}
+{{ template "packages/get_packages_discovery.sql" }}
 {{ template "packages/get_packages_starred_by_user.sql" }}
 {{ template "packages/get_package_stars.sql" }}
 {{ template "packages/get_packages_stats.sql" }}
 {{ template "packages/get_random_packages.sql" }}
+{{ template "packages/get_security_reports_to_migrate.sql" }}
 {{ template "packages/get_snapshots_to_scan.sql" }}
+{{ template "packages/get_snapshots_without_logo.sql" }}
+{{ template "packages/get_user_stale_packages.sql" }}
+{{ template "packages/refresh_package_documents.sql" }}
+{{ template "packages/refresh_package_level_document.sql" }}
+{{ template "packages/refresh_packages_discovery.sql" }}
 {{ template "packages/register_package.sql" }}
 {{ template "packages/search_packages.sql" }}
 {{ template "packages/search_packages_monocular.sql" }}
 {{ template "packages/semver_gt.sql" }}
+{{ template "packages/set_snapshot_generated_logo.sql" }}
+{{ template "packages/set_snapshot_test_results.sql" }}
 {{ template "packages/semver_gte.sql" }}
 {{ template "packages/toggle_star.sql" }}
 {{ template "packages/update_snapshot_security_report.sql" }}
+{{ template "packages/update_packages_freshness.sql" }}
 {{ template "packages/unregister_package.sql" }}
 
+{{ template "promoted_packages/add_promoted_package.sql" }}
+{{ template "promoted_packages/delete_promoted_package.sql" }}
+{{ template "promoted_packages/get_promoted_packages.sql" }}
+{{ template "promoted_packages/get_promoted_packages_stats.sql" }}
+{{ template "promoted_packages/register_promoted_package_event.sql" }}
+
+{{ template "repositories/accept_repository_transfer.sql" }}
 {{ template "repositories/add_repository.sql" }}
+{{ template "repositories/add_repository_publish_token.sql" }}
 {{ template "repositories/delete_repository.sql" }}
+{{ template "repositories/delete_repository_publish_token.sql" }}
 {{ template "repositories/get_repository_by_name.sql" }}
+{{ template "repositories/get_repository_head.sql" }}
 {{ template "repositories/get_repository_packages_digest.sql" }}
+{{ template "repositories/get_repository_publish_tokens.sql" }}
+{{ template "repositories/get_repository_stats.sql" }}
+{{ template "repositories/get_repository_tracking_runs.sql" }}
+{{ template "repositories/get_user_repository_transfers.sql" }}
+{{ template "repositories/reject_repository_transfer.sql" }}
+{{ template "repositories/request_repository_tracking.sql" }}
+{{ template "repositories/request_repository_transfer.sql" }}
 {{ template "repositories/search_repositories.sql" }}
 {{ template "repositories/set_last_scanning_results.sql" }}
 {{ template "repositories/set_last_tracking_results.sql" }}
+{{ template "repositories/set_repository_archived.sql" }}
+{{ template "repositories/set_repository_tracking_paused.sql" }}
+{{ template "repositories/set_packages_overrides.sql" }}
+{{ template "repositories/set_repository_webhook_secret.sql" }}
 {{ template "repositories/set_verified_publisher.sql" }}
 {{ template "repositories/transfer_repository.sql" }}
 {{ template "repositories/update_repository.sql" }}
+{{ template "repositories/verify_repository_domain.sql" }}
+
+{{ template "service_accounts/add_service_account.sql" }}
+{{ template "service_accounts/delete_service_account.sql" }}
+{{ template "service_accounts/get_org_service_accounts.sql" }}
 
 {{ template "stats/get_stats.sql" }}
 
@@ -75,25 +143,37 @@
 
 {{ template "users/approve_session.sql" }}
 {{ template "users/check_user_alias_availability.sql" }}
+{{ template "users/consume_webauthn_ceremony.sql" }}
 {{ template "users/delete_user.sql" }}
 {{ template "users/get_user_profile.sql" }}
+{{ template "users/get_user_site_role.sql" }}
 {{ template "users/get_user_tfa_config.sql" }}
+{{ template "users/get_user_webauthn_credentials.sql" }}
+{{ template "users/get_user_webauthn_info.sql" }}
 {{ template "users/register_delete_user_code.sql" }}
+{{ template "users/register_login_code.sql" }}
 {{ template "users/register_password_reset_code.sql" }}
 {{ template "users/register_session.sql" }}
 {{ template "users/register_user.sql" }}
+{{ template "users/register_webauthn_ceremony.sql" }}
+{{ template "users/register_webauthn_credential.sql" }}
 {{ template "users/reset_user_password.sql" }}
+{{ template "users/sync_user_idp_groups.sql" }}
 {{ template "users/update_user_password.sql" }}
 {{ template "users/update_user_profile.sql" }}
+{{ template "users/user_is_site_admin.sql" }}
 {{ template "users/verify_email.sql" }}
+{{ template "users/verify_login_code.sql" }}
 {{ template "users/verify_password_reset_code.sql" }}
 
 {{ template "webhooks/add_webhook.sql" }}
 {{ template "webhooks/delete_webhook.sql" }}
 {{ template "webhooks/get_webhook.sql" }}
+{{ template "webhooks/get_webhook_template_errors.sql" }}
 {{ template "webhooks/get_org_webhooks.sql" }}
 {{ template "webhooks/get_user_webhooks.sql" }}
 {{ template "webhooks/get_webhooks_subscribed_to_package.sql" }}
+{{ template "webhooks/register_webhook_template_error.sql" }}
 {{ template "webhooks/update_webhook.sql" }}
 {{ template "webhooks/user_has_access_to_webhook.sql" }}
 
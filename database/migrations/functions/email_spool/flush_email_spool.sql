@@ -0,0 +1,27 @@
+-- flush_email_spool makes all the spooled emails (including the ones that
+-- exhausted their delivery attempts) eligible for delivery right away,
+-- returning the number of emails flushed. Only site admins are allowed to
+-- flush the email spool.
+create or replace function flush_email_spool(p_user_id uuid)
+returns int as $$
+declare
+    v_flushed int;
+begin
+    if not user_is_site_admin(p_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    update email_spool set
+        attempts = 0,
+        next_attempt_at = current_timestamp;
+    get diagnostics v_flushed = row_count;
+
+    -- Record action in audit log
+    insert into audit_log (user_id, action, details)
+    values (p_user_id, 'flush-email-spool', jsonb_build_object(
+        'flushed', v_flushed
+    ));
+
+    return v_flushed;
+end
+$$ language plpgsql;
@@ -1,9 +1,16 @@
 -- get_image returns the image identified by the id and version provided.
+-- Images uploaded by publishers are only returned once a moderator has
+-- approved them.
 create or replace function get_image(p_image_id uuid, p_version text)
 returns setof bytea as $$
     select data
     from image_version
     where image_id = p_image_id
+    and not exists (
+        select 1 from image_upload
+        where image_id = p_image_id
+        and moderation_status <> 'approved'
+    )
     and
         case when p_version <> '' and exists (
             select data from image_version
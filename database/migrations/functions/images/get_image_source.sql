@@ -0,0 +1,12 @@
+-- get_image_source returns some information about the image downloaded from
+-- the url provided, including if it was checked within the ttl (in seconds)
+-- provided.
+create or replace function get_image_source(p_url text, p_ttl int)
+returns table(image_id uuid, original_hash bytea, fresh boolean) as $$
+    select
+        image_id,
+        original_hash,
+        last_checked_at > current_timestamp - make_interval(secs => p_ttl)
+    from image_source
+    where url = p_url;
+$$ language sql;
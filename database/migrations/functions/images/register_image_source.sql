@@ -0,0 +1,12 @@
+-- register_image_source registers the image downloaded from the url provided,
+-- updating the last time it was checked.
+create or replace function register_image_source(p_url text, p_original_hash bytea, p_image_id uuid)
+returns void as $$
+    insert into image_source (url, original_hash, image_id)
+    values (p_url, p_original_hash, p_image_id)
+    on conflict (url) do update
+    set
+        original_hash = excluded.original_hash,
+        image_id = excluded.image_id,
+        last_checked_at = current_timestamp;
+$$ language sql;
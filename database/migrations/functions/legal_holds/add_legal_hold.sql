@@ -0,0 +1,56 @@
+-- add_legal_hold places a legal hold on the provided package or repository,
+-- returning the id of the hold created. While the hold is active, the resource
+-- held and its versions cannot be deleted. Only site admins are allowed to
+-- place legal holds.
+create or replace function add_legal_hold(p_user_id uuid, p_legal_hold jsonb)
+returns uuid as $$
+declare
+    v_package_id uuid := (p_legal_hold->>'package_id')::uuid;
+    v_repository_id uuid := (p_legal_hold->>'repository_id')::uuid;
+    v_legal_hold_id uuid;
+begin
+    if not user_is_site_admin(p_user_id) then
+        raise insufficient_privilege;
+    end if;
+    if v_package_id is not null and not exists (
+        select 1 from package where package_id = v_package_id
+    ) then
+        raise 'package not found';
+    end if;
+    if v_repository_id is not null and not exists (
+        select 1 from repository where repository_id = v_repository_id
+    ) then
+        raise 'repository not found';
+    end if;
+    if exists (
+        select 1 from legal_hold
+        where package_id = v_package_id
+        or repository_id = v_repository_id
+    ) then
+        raise 'legal hold already exists';
+    end if;
+
+    insert into legal_hold (
+        package_id,
+        repository_id,
+        reason,
+        user_id
+    ) values (
+        v_package_id,
+        v_repository_id,
+        p_legal_hold->>'reason',
+        p_user_id
+    ) returning legal_hold_id into v_legal_hold_id;
+
+    -- Record action in audit log
+    insert into audit_log (user_id, action, details)
+    values (p_user_id, 'add-legal-hold', jsonb_build_object(
+        'legal_hold_id', v_legal_hold_id,
+        'package_id', v_package_id,
+        'repository_id', v_repository_id,
+        'reason', p_legal_hold->>'reason'
+    ));
+
+    return v_legal_hold_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,28 @@
+-- get_legal_holds returns all the legal holds currently active as a json
+-- array. Only site admins are allowed to get the legal holds.
+create or replace function get_legal_holds(p_user_id uuid)
+returns setof json as $$
+begin
+    if not user_is_site_admin(p_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'legal_hold_id', lh.legal_hold_id,
+        'reason', lh.reason,
+        'created_at', floor(extract(epoch from lh.created_at)),
+        'created_by', u.alias,
+        'package', (
+            select get_package_summary(jsonb_build_object('package_id', lh.package_id))
+            where lh.package_id is not null
+        ),
+        'repository', (
+            select get_repository_summary(lh.repository_id)
+            where lh.repository_id is not null
+        )
+    )) order by lh.created_at desc), '[]')
+    from legal_hold lh
+    left join "user" u using (user_id);
+end
+$$ language plpgsql;
@@ -0,0 +1,27 @@
+-- release_legal_hold releases the provided legal hold. Only site admins are
+-- allowed to release legal holds.
+create or replace function release_legal_hold(p_user_id uuid, p_legal_hold_id uuid)
+returns void as $$
+declare
+    v_package_id uuid;
+    v_repository_id uuid;
+begin
+    if not user_is_site_admin(p_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from legal_hold where legal_hold_id = p_legal_hold_id
+    returning package_id, repository_id into v_package_id, v_repository_id;
+    if not found then
+        raise 'legal hold not found';
+    end if;
+
+    -- Record action in audit log
+    insert into audit_log (user_id, action, details)
+    values (p_user_id, 'release-legal-hold', jsonb_build_object(
+        'legal_hold_id', p_legal_hold_id,
+        'package_id', v_package_id,
+        'repository_id', v_repository_id
+    ));
+end
+$$ language plpgsql;
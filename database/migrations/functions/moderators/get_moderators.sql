@@ -0,0 +1,19 @@
+-- get_moderators returns all the users holding the moderator role as a json
+-- array. Only site admins are allowed to get the moderators.
+create or replace function get_moderators(p_user_id uuid)
+returns setof json as $$
+begin
+    if not user_is_site_admin(p_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'alias', u.alias,
+        'first_name', u.first_name,
+        'last_name', u.last_name
+    )) order by u.alias asc), '[]')
+    from "user" u
+    where u.moderator = true;
+end
+$$ language plpgsql;
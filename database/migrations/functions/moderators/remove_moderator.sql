@@ -0,0 +1,26 @@
+-- remove_moderator revokes the moderator role from the provided user. Only
+-- site admins are allowed to manage moderators.
+create or replace function remove_moderator(p_user_id uuid, p_moderator_alias text)
+returns void as $$
+declare
+    v_moderator_user_id uuid;
+begin
+    if not user_is_site_admin(p_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    update "user" set moderator = false
+    where alias = p_moderator_alias
+    and moderator = true
+    returning user_id into v_moderator_user_id;
+    if not found then
+        raise 'user not found';
+    end if;
+
+    -- Record action in audit log
+    insert into audit_log (user_id, action, details)
+    values (p_user_id, 'remove-moderator', jsonb_build_object(
+        'user_id', v_moderator_user_id
+    ));
+end
+$$ language plpgsql;
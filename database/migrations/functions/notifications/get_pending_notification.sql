@@ -22,9 +22,10 @@ returns setof json as $$
                 'url', wh.url,
                 'secret', wh.secret,
                 'content_type', wh.content_type,
-                'template', wh.template
+                'template', wh.template,
+                'kind', wh.kind
             ),
-            '{"name": null, "url": null, "secret": null, "content_type": null, "template": null}'::jsonb
+            '{"name": null, "url": null, "secret": null, "content_type": null, "template": null, "kind": null}'::jsonb
         ))
     ))
     from notification n
@@ -10,17 +10,21 @@ begin
         display_name,
         description,
         home_url,
-        logo_image_id
+        logo_image_id,
+        profile,
+        profile_html
     ) values (
         p_org->>'name',
         nullif(p_org->>'display_name', ''),
         nullif(p_org->>'description', ''),
         nullif(p_org->>'home_url', ''),
-        nullif(p_org->>'logo_image_id', '')::uuid
+        nullif(p_org->>'logo_image_id', '')::uuid,
+        nullif(p_org->>'profile', ''),
+        nullif(p_org->>'profile_html', '')
     ) returning organization_id into v_org_id;
 
     -- Add user who created the organization to it
-    insert into user__organization (user_id, organization_id, confirmed)
-    values (p_user_id, v_org_id, true);
+    insert into user__organization (user_id, organization_id, confirmed, role)
+    values (p_user_id, v_org_id, true, 'owner');
 end
 $$ language plpgsql;
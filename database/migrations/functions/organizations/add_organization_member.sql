@@ -1,8 +1,11 @@
--- add_organization_member adds a member to the provided organization.
+-- add_organization_member adds a member to the provided organization. The
+-- invitation to join the organization will expire after the ttl provided (in
+-- seconds).
 create or replace function add_organization_member(
     p_requesting_user_id uuid,
     p_org_name text,
-    p_user_alias text
+    p_user_alias text,
+    p_invitation_ttl int
 ) returns void as $$
 begin
     if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
@@ -10,10 +13,11 @@ begin
     end if;
 
     insert into user__organization (
-        user_id, organization_id
+        user_id, organization_id, invitation_expires_at
     ) values (
-        (select user_id from "user" where alias = p_user_alias),
-        (select organization_id from organization where name = p_org_name)
+        (select user_id from "user" where alias = p_user_alias and service_account_organization_id is null),
+        (select organization_id from organization where name = p_org_name),
+        current_timestamp + make_interval(secs => p_invitation_ttl)
     );
 end
 $$ language plpgsql;
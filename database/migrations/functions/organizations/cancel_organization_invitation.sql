@@ -0,0 +1,22 @@
+-- cancel_organization_invitation cancels a pending invitation to join the
+-- provided organization.
+create or replace function cancel_organization_invitation(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_user_alias text
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from user__organization
+    where user_id = (select user_id from "user" where alias = p_user_alias)
+    and organization_id = (select organization_id from organization where name = p_org_name)
+    and confirmed = false;
+
+    if not found then
+        raise 'invitation not found';
+    end if;
+end
+$$ language plpgsql;
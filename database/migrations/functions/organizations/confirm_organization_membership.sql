@@ -4,10 +4,13 @@ create or replace function confirm_organization_membership(p_user_id uuid, p_org
 returns void as $$
 begin
     update user__organization
-    set confirmed = true
+    set
+        confirmed = true,
+        invitation_expires_at = null
     where user_id = p_user_id
     and organization_id = (select organization_id from organization where name = p_org_name)
-    and confirmed = false;
+    and confirmed = false
+    and (invitation_expires_at is null or invitation_expires_at > current_timestamp);
 
     if not found then
         raise 'organization membership confirmation failed';
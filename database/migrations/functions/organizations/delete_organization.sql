@@ -1,11 +1,81 @@
 -- delete_organization deletes the provided organization from the database.
-create or replace function delete_organization(p_requesting_user_id uuid, p_org_name text)
-returns void as $$
+-- When the organization owns some repositories, the input must indicate if
+-- they should be deleted or transferred to the requesting user (or to another
+-- organization the user belongs to). Memberships, invitations and webhooks
+-- are purged and the action is recorded in the audit log.
+create or replace function delete_organization(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_input jsonb
+) returns void as $$
+declare
+    v_organization_id uuid;
+    v_repositories_action text := p_input->>'repositories_action';
+    v_transfer_to_org text := nullif(p_input->>'transfer_to_org', '');
+    v_target_organization_id uuid;
+    v_target_user_id uuid;
+    v_repositories text[];
 begin
     if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
         raise insufficient_privilege;
     end if;
 
-    delete from organization where name = p_org_name;
+    -- Get organization and the repositories it owns
+    select organization_id into v_organization_id
+    from organization
+    where name = p_org_name;
+    select coalesce(array_agg(name order by name), '{}') into v_repositories
+    from repository
+    where organization_id = v_organization_id;
+
+    -- Delete or transfer the repositories owned by the organization
+    if cardinality(v_repositories) > 0 then
+        if v_repositories_action is null then
+            raise 'organization has repositories';
+        end if;
+        if v_repositories_action = 'transfer' then
+            if v_transfer_to_org is not null then
+                select organization_id into v_target_organization_id
+                from organization
+                where name = v_transfer_to_org;
+                if not found or v_target_organization_id = v_organization_id then
+                    raise 'target organization not found';
+                end if;
+                if not user_belongs_to_organization(p_requesting_user_id, v_transfer_to_org) then
+                    raise insufficient_privilege;
+                end if;
+            else
+                v_target_user_id = p_requesting_user_id;
+            end if;
+            delete from repository_transfer
+            where repository_id in (
+                select repository_id from repository where organization_id = v_organization_id
+            );
+            update repository set
+                user_id = v_target_user_id,
+                organization_id = v_target_organization_id
+            where organization_id = v_organization_id;
+        else
+            delete from repository where organization_id = v_organization_id;
+        end if;
+    end if;
+
+    -- Purge memberships and webhooks (other dependent data like pending
+    -- transfers or identity provider groups mappings is deleted in cascade)
+    delete from user__organization where organization_id = v_organization_id;
+    delete from webhook where organization_id = v_organization_id;
+
+    -- Record action in audit log
+    insert into audit_log (user_id, action, details)
+    values (p_requesting_user_id, 'delete-organization', jsonb_build_object(
+        'organization_id', v_organization_id,
+        'organization_name', p_org_name,
+        'repositories', v_repositories,
+        'repositories_action', v_repositories_action,
+        'transfer_to_org', v_transfer_to_org
+    ));
+
+    -- Delete organization
+    delete from organization where organization_id = v_organization_id;
 end
 $$ language plpgsql;
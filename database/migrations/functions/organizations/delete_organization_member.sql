@@ -6,6 +6,8 @@ create or replace function delete_organization_member(
 ) returns void as $$
 declare
     v_users_in_organization int;
+    v_member_role text;
+    v_owners_in_organization int;
 begin
     if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
         raise insufficient_privilege;
@@ -20,6 +22,30 @@ begin
         raise 'last member of an organization cannot leave it';
     end if;
 
+    -- Owners can only be removed by other owners and the last one cannot leave
+    select uo.role into v_member_role
+    from user__organization uo
+    join organization o using (organization_id)
+    join "user" u using (user_id)
+    where o.name = p_org_name
+    and u.alias = p_user_alias;
+    if v_member_role = 'owner' then
+        -- Only owners can remove other owners
+        if p_requesting_user_id <> (select user_id from "user" where alias = p_user_alias)
+        and coalesce(get_user_organization_role(p_requesting_user_id, p_org_name), '') <> 'owner' then
+            raise insufficient_privilege;
+        end if;
+
+        select count(*) into v_owners_in_organization
+        from user__organization uo
+        join organization o using (organization_id)
+        where o.name = p_org_name
+        and uo.role = 'owner';
+        if v_owners_in_organization = 1 then
+            raise 'last owner of an organization cannot leave it';
+        end if;
+    end if;
+
     -- Delete member from organization
     delete from user__organization
     where user_id = (select user_id from "user" where alias = p_user_alias)
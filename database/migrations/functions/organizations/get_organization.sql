@@ -6,7 +6,17 @@ returns setof json as $$
         'display_name', o.display_name,
         'description', o.description,
         'home_url', o.home_url,
-        'logo_image_id', o.logo_image_id
+        'logo_image_id', o.logo_image_id,
+        'profile', o.profile,
+        'profile_html', o.profile_html,
+        'links', (
+            select json_agg(json_build_object(
+                'url', l.url,
+                'verified', l.verified
+            ) order by l.url asc)
+            from organization_link l
+            where l.organization_id = o.organization_id
+        )
     ))
     from organization o
     where o.name = p_org_name;
@@ -0,0 +1,19 @@
+-- get_organization_idp_groups returns the identity provider groups mapped to
+-- the organization provided as a json array.
+create or replace function get_organization_idp_groups(p_requesting_user_id uuid, p_org_name text)
+returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_build_object(
+        'group', g.idp_group,
+        'role', g.role
+    ) order by g.idp_group asc), '[]')
+    from organization_idp_group g
+    join organization o using (organization_id)
+    where o.name = p_org_name;
+end
+$$ language plpgsql;
@@ -0,0 +1,46 @@
+-- get_organization_invitations returns the pending invitations to join the
+-- organization provided as a json array.
+create or replace function get_organization_invitations(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_limit int,
+    p_offset int
+) returns table(data json, total_count bigint) as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with organization_invitations as (
+        select
+            u.alias,
+            u.first_name,
+            u.last_name,
+            uo.role,
+            uo.invitation_expires_at
+        from "user" u
+        join user__organization uo using (user_id)
+        join organization o using (organization_id)
+        where o.name = p_org_name
+        and uo.confirmed = false
+    )
+    select
+        coalesce(json_agg(json_strip_nulls(json_build_object(
+            'alias', alias,
+            'first_name', first_name,
+            'last_name', last_name,
+            'role', role,
+            'expires_at', floor(extract(epoch from invitation_expires_at)),
+            'expired', coalesce(invitation_expires_at <= current_timestamp, false)
+        ))), '[]'),
+        (select count(*) from organization_invitations)
+    from (
+        select *
+        from organization_invitations
+        order by first_name, last_name asc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) i;
+end
+$$ language plpgsql;
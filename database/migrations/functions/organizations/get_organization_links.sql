@@ -0,0 +1,20 @@
+-- get_organization_links returns the links of the organization provided,
+-- including the tokens needed to verify them, as a json array.
+create or replace function get_organization_links(p_requesting_user_id uuid, p_org_name text)
+returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_build_object(
+        'url', l.url,
+        'verified', l.verified,
+        'verification_token', l.verification_token
+    ) order by l.url asc), '[]')
+    from organization_link l
+    join organization o using (organization_id)
+    where o.name = p_org_name;
+end
+$$ language plpgsql;
@@ -1,5 +1,5 @@
 -- get_organization_members returns the members of the organization provided as
--- a json array.
+-- a json array. Service accounts are not included.
 create or replace function get_organization_members(
     p_requesting_user_id uuid,
     p_org_name text,
@@ -13,18 +13,20 @@ begin
 
     return query
     with organization_members as (
-        select u.alias, u.first_name, u.last_name, uo.confirmed
+        select u.alias, u.first_name, u.last_name, uo.confirmed, uo.role
         from "user" u
         join user__organization uo using (user_id)
         join organization o using (organization_id)
         where o.name = p_org_name
+        and u.service_account_organization_id is null
     )
     select
         coalesce(json_agg(json_strip_nulls(json_build_object(
             'alias', alias,
             'first_name', first_name,
             'last_name', last_name,
-            'confirmed', confirmed
+            'confirmed', confirmed,
+            'role', role
         ))), '[]'),
         (select count(*) from organization_members)
     from (
@@ -0,0 +1,46 @@
+-- get_organization_quotas returns the quotas overridden for the organization
+-- provided as well as its current usage as a json object.
+create or replace function get_organization_quotas(p_requesting_user_id uuid, p_org_name text)
+returns setof json as $$
+declare
+    v_organization_id uuid;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+    select organization_id into v_organization_id
+    from organization where name = p_org_name;
+
+    return query
+    select json_build_object(
+        'quotas', coalesce((
+            select json_strip_nulls(json_build_object(
+                'max_repositories', q.max_repositories,
+                'max_webhooks', q.max_webhooks,
+                'max_api_keys', q.max_api_keys,
+                'tracker_min_interval', q.tracker_min_interval
+            ))
+            from organization_quota q
+            where q.organization_id = v_organization_id
+        ), '{}'),
+        'usage', json_build_object(
+            'repositories', (
+                select count(*)
+                from repository
+                where organization_id = v_organization_id
+            ),
+            'webhooks', (
+                select count(*)
+                from webhook
+                where organization_id = v_organization_id
+            ),
+            'api_keys', (
+                select count(*)
+                from api_key ak
+                join "user" u using (user_id)
+                where u.service_account_organization_id = v_organization_id
+            )
+        )
+    );
+end
+$$ language plpgsql;
@@ -0,0 +1,11 @@
+-- get_user_organization_role returns the role the provided user has in the
+-- organization. Null is returned when the user is not a confirmed member.
+create or replace function get_user_organization_role(p_user_id uuid, p_org_name text)
+returns text as $$
+    select uo.role
+    from organization o
+    join user__organization uo using (organization_id)
+    where o.name = p_org_name
+    and uo.user_id = p_user_id
+    and uo.confirmed = true;
+$$ language sql;
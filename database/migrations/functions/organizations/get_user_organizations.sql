@@ -3,7 +3,7 @@
 create or replace function get_user_organizations(p_user_id uuid, p_limit int, p_offset int)
 returns table(data json, total_count bigint) as $$
     with user_organizations as (
-        select o.*, uo.confirmed
+        select o.*, uo.confirmed, uo.role
         from organization o
         join user__organization uo using (organization_id)
         where uo.user_id = p_user_id
@@ -16,6 +16,7 @@ returns table(data json, total_count bigint) as $$
             'home_url', home_url,
             'logo_image_id', logo_image_id,
             'confirmed', o.confirmed,
+            'role', o.role,
             'members_count', (
                 select count(*)
                 from user__organization
@@ -0,0 +1,24 @@
+-- resend_organization_invitation renews the expiration of a pending invitation
+-- to join the provided organization using the ttl provided (in seconds).
+create or replace function resend_organization_invitation(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_user_alias text,
+    p_invitation_ttl int
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    update user__organization
+    set invitation_expires_at = current_timestamp + make_interval(secs => p_invitation_ttl)
+    where user_id = (select user_id from "user" where alias = p_user_alias)
+    and organization_id = (select organization_id from organization where name = p_org_name)
+    and confirmed = false;
+
+    if not found then
+        raise 'invitation not found';
+    end if;
+end
+$$ language plpgsql;
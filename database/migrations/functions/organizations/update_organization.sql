@@ -12,7 +12,9 @@ begin
         display_name = nullif(p_org->>'display_name', ''),
         description = nullif(p_org->>'description', ''),
         home_url = nullif(p_org->>'home_url', ''),
-        logo_image_id = nullif(p_org->>'logo_image_id', '')::uuid
+        logo_image_id = nullif(p_org->>'logo_image_id', '')::uuid,
+        profile = nullif(p_org->>'profile', ''),
+        profile_html = nullif(p_org->>'profile_html', '')
     where name = p_org_name;
 end
 $$ language plpgsql;
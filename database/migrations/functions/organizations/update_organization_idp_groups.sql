@@ -0,0 +1,26 @@
+-- update_organization_idp_groups replaces the identity provider groups mapped
+-- to the organization provided if the requesting user belongs to it. Members
+-- of the groups will join the organization with the role of the group the next
+-- time they log in.
+create or replace function update_organization_idp_groups(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_groups jsonb
+) returns void as $$
+declare
+    v_organization_id uuid;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    select organization_id into v_organization_id
+    from organization
+    where name = p_org_name;
+
+    delete from organization_idp_group where organization_id = v_organization_id;
+    insert into organization_idp_group (organization_id, idp_group, role)
+    select v_organization_id, g->>'group', g->>'role'
+    from jsonb_array_elements(p_groups) g;
+end
+$$ language plpgsql;
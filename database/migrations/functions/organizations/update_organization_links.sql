@@ -0,0 +1,29 @@
+-- update_organization_links replaces the links of the organization provided if
+-- the requesting user belongs to it. Links already registered keep their
+-- verification token and status.
+create or replace function update_organization_links(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_links jsonb
+) returns void as $$
+declare
+    v_organization_id uuid;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    select organization_id into v_organization_id
+    from organization
+    where name = p_org_name;
+
+    delete from organization_link
+    where organization_id = v_organization_id
+    and url not in (select l->>'url' from jsonb_array_elements(p_links) l);
+
+    insert into organization_link (organization_id, url)
+    select v_organization_id, l->>'url'
+    from jsonb_array_elements(p_links) l
+    on conflict (organization_id, url) do nothing;
+end
+$$ language plpgsql;
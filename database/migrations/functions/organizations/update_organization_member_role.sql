@@ -0,0 +1,52 @@
+-- update_organization_member_role updates the role of a member of the provided
+-- organization.
+create or replace function update_organization_member_role(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_user_alias text,
+    p_role text
+) returns void as $$
+declare
+    v_org_id uuid;
+    v_user_id uuid;
+    v_member_role text;
+    v_owners_in_organization int;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    select organization_id into v_org_id from organization where name = p_org_name;
+    select user_id into v_user_id from "user" where alias = p_user_alias;
+
+    -- Only owners can manage other owners or promote members to owners
+    select role into v_member_role
+    from user__organization
+    where organization_id = v_org_id
+    and user_id = v_user_id;
+    if (v_member_role = 'owner' or p_role = 'owner')
+    and coalesce(get_user_organization_role(p_requesting_user_id, p_org_name), '') <> 'owner' then
+        raise insufficient_privilege;
+    end if;
+
+    -- Last owner of an organization cannot be demoted
+    if p_role <> 'owner' then
+        select count(*) into v_owners_in_organization
+        from user__organization
+        where organization_id = v_org_id
+        and role = 'owner'
+        and user_id <> v_user_id;
+        if v_owners_in_organization = 0 then
+            raise 'last owner of an organization cannot be demoted';
+        end if;
+    end if;
+
+    -- Update member role
+    update user__organization set role = p_role
+    where user_id = v_user_id
+    and organization_id = v_org_id;
+    if not found then
+        raise 'user is not a member of the organization';
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,20 @@
+-- verify_organization_links marks as verified the links of the organization
+-- provided whose urls are in the list, if the requesting user belongs to it.
+create or replace function verify_organization_links(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_urls text[]
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    update organization_link set
+        verified = true,
+        verified_at = current_timestamp
+    where organization_id = (select organization_id from organization where name = p_org_name)
+    and url = any(p_urls)
+    and verified = false;
+end
+$$ language plpgsql;
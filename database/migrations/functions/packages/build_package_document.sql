@@ -0,0 +1,58 @@
+-- build_package_document builds the json document with the details of the
+-- package version provided. The document is stored in the package_document
+-- table so that it does not need to be built every time the package is
+-- requested. It only includes information specific to the version, so that
+-- registering or unregistering other versions does not make it stale. Package
+-- level fields are added by get_package when the package is requested.
+-- Schema migrations that change the content of the document must clear the
+-- stored documents, as get_package keeps serving them until they are rebuilt.
+create or replace function build_package_document(p_package_id uuid, p_version text)
+returns jsonb as $$
+    select jsonb_strip_nulls(jsonb_build_object(
+        'display_name', s.display_name,
+        'alias', s.alias,
+        'description', s.description,
+        'logo_image_id', s.logo_image_id,
+        'logo_generated', s.logo_generated,
+        'keywords', s.keywords,
+        'home_url', s.home_url,
+        'readme', s.readme,
+        'install', s.install,
+        'links', s.links,
+        'crds', s.crds,
+        'crds_examples', s.crds_examples,
+        'capabilities', s.capabilities,
+        'security_report_summary', s.security_report_summary,
+        'security_report_created_at', floor(extract(epoch from s.security_report_created_at)),
+        'data', s.data,
+        'version', s.version,
+        'app_version', s.app_version,
+        'digest', s.digest,
+        'deprecated', s.deprecated,
+        'contains_security_updates', s.contains_security_updates,
+        'prerelease', s.prerelease,
+        'license', s.license,
+        'signed', s.signed,
+        'signature_verified', s.signature_verified,
+        'signature_kind', s.signature_kind,
+        'signature_identity', s.signature_identity,
+        'has_attestations', s.has_attestations,
+        'content_url', s.content_url,
+        'containers_images', s.containers_images,
+        'provider', s.provider,
+        'has_values_schema', (s.values_schema is not null and s.values_schema <> '{}'),
+        'values_schema_inferred', s.values_schema_inferred,
+        'has_values_docs', (s.values_docs is not null),
+        'has_dependencies_tree', (s.dependencies_tree is not null),
+        'has_sbom', (s.sbom_format is not null),
+        'test_results', s.test_results,
+        'chart_diagnostics', s.chart_diagnostics,
+        'changes', s.changes,
+        'ts', floor(extract(epoch from s.ts)),
+        'recommendations', s.recommendations,
+        'sign_key', s.sign_key
+    ))
+    from snapshot s
+    where s.package_id = p_package_id
+    and s.version = p_version;
+$$ language sql;
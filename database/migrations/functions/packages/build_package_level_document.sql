@@ -0,0 +1,31 @@
+-- build_package_level_document builds the json document with the details of
+-- the package provided that depend on all its versions or on its maintainers.
+-- The document is stored in the package table so that it does not need to be
+-- built every time any of the package versions is requested.
+create or replace function build_package_level_document(p_package_id uuid)
+returns jsonb as $$
+    select jsonb_strip_nulls(jsonb_build_object(
+        'available_versions', (
+            select jsonb_agg(jsonb_build_object(
+                'version', version,
+                'contains_security_updates', contains_security_updates,
+                'prerelease', prerelease,
+                'ts', floor(extract(epoch from ts))
+            ))
+            from snapshot
+            where package_id = p_package_id
+        ),
+        'has_changelog', (select exists (
+            select 1 from snapshot where package_id = p_package_id and changes is not null
+        )),
+        'maintainers', (
+            select jsonb_agg(jsonb_build_object(
+                'name', m.name,
+                'email', m.email
+            ))
+            from maintainer m
+            join package__maintainer pm using (maintainer_id)
+            where pm.package_id = p_package_id
+        )
+    ));
+$$ language sql;
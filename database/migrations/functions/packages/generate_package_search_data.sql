@@ -0,0 +1,24 @@
+-- generate_package_search_data generates the package's document holding the
+-- kind specific data fields that can be used to filter packages in searches.
+-- Each field holds an array of values.
+create or replace function generate_package_search_data(
+    p_repository_kind_id int,
+    p_data jsonb
+) returns jsonb as $$
+    select nullif(jsonb_strip_nulls(
+        case p_repository_kind_id
+        when 0 then jsonb_build_object(
+            'kube_version', case when p_data->>'kubeVersion' <> '' then jsonb_build_array(p_data->>'kubeVersion') end,
+            'kube_version_range', nullif(p_data->'kubeVersionRanges', 'null'),
+            'chart_type', case when p_data->>'type' <> '' then jsonb_build_array(p_data->>'type') end
+        )
+        when 5 then jsonb_build_object(
+            'platform', nullif(p_data->'platforms', 'null')
+        )
+        when 7 then jsonb_build_object(
+            'task_param', nullif(p_data->'params', 'null')
+        )
+        else '{}'::jsonb
+        end
+    ), '{}');
+$$ language sql immutable;
@@ -6,11 +6,13 @@ create or replace function generate_package_tsdoc(
     p_description text,
     p_keywords text[],
     p_repository text[],
-    p_publisher text[]
+    p_publisher text[],
+    p_alias text default null
 ) returns tsvector as $$
     select
         setweight(to_tsvector(p_name), 'A') ||
         setweight(to_tsvector(coalesce(p_display_name, '')), 'A') ||
+        setweight(to_tsvector(coalesce(p_alias, '')), 'A') ||
         setweight(to_tsvector(coalesce(p_description, '')), 'B') ||
         setweight(to_tsvector(array_to_string(coalesce(p_keywords, '{}'), ' ')), 'C') ||
         setweight(to_tsvector(array_to_string(coalesce(p_repository, '{}'), ' ')), 'B') ||
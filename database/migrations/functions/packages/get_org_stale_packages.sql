@@ -0,0 +1,31 @@
+-- get_org_stale_packages returns the stale packages from the repositories
+-- owned by the organization provided as a json array, if the requesting user
+-- belongs to it.
+create or replace function get_org_stale_packages(p_user_id uuid, p_org_name text, p_limit int, p_offset int)
+returns table(data json, total_count bigint) as $$
+    with org_stale_packages as (
+        select p.package_id, p.last_release_ts
+        from package p
+        join repository r using (repository_id)
+        join organization o using (organization_id)
+        join user__organization uo using (organization_id)
+        where o.name = p_org_name
+        and uo.user_id = p_user_id
+        and uo.confirmed = true
+        and p.stale = true
+    )
+    select
+        coalesce(json_agg(pkgJSON), '[]'),
+        (select count(*) from org_stale_packages)
+    from (
+        select (
+            pkgJSON::jsonb ||
+            jsonb_build_object('last_release_ts', floor(extract(epoch from osp.last_release_ts)))
+        ) as pkgJSON
+        from org_stale_packages osp
+        cross join get_package_summary(jsonb_build_object('package_id', osp.package_id)) as pkgJSON
+        order by osp.last_release_ts asc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) ps
+$$ language sql;
@@ -17,77 +17,52 @@ begin
         and r.name = v_repository_name;
     end if;
 
+    -- The heavy part of the package version details is precomputed at
+    -- registration time, as well as the fields that depend on other versions
+    -- or on the package maintainers. Documents not available yet are built on
+    -- the fly. The remaining fields are cheap to get and are computed here.
     return query
-    select json_strip_nulls(json_build_object(
-        'package_id', p.package_id,
-        'name', p.name,
-        'normalized_name', p.normalized_name,
-        'is_operator', p.is_operator,
-        'official', p.official,
-        'channels', p.channels,
-        'default_channel', p.default_channel,
-        'display_name', s.display_name,
-        'description', s.description,
-        'logo_image_id', s.logo_image_id,
-        'keywords', s.keywords,
-        'home_url', s.home_url,
-        'readme', s.readme,
-        'install', s.install,
-        'links', s.links,
-        'crds', s.crds,
-        'crds_examples', s.crds_examples,
-        'capabilities', s.capabilities,
-        'security_report_summary', s.security_report_summary,
-        'security_report_created_at', floor(extract(epoch from s.security_report_created_at)),
-        'data', s.data,
-        'version', s.version,
-        'available_versions', (
-            select json_agg(json_build_object(
-                'version', version,
-                'contains_security_updates', contains_security_updates,
-                'prerelease', prerelease,
-                'ts', floor(extract(epoch from ts))
-            ))
-            from snapshot
-            where package_id = v_package_id
-        ),
-        'app_version', s.app_version,
-        'digest', s.digest,
-        'deprecated', s.deprecated,
-        'contains_security_updates', s.contains_security_updates,
-        'prerelease', s.prerelease,
-        'license', s.license,
-        'signed', s.signed,
-        'content_url', s.content_url,
-        'containers_images', s.containers_images,
-        'all_containers_images_whitelisted', are_all_containers_images_whitelisted(s.containers_images),
-        'provider', s.provider,
-        'has_values_schema', (s.values_schema is not null and s.values_schema <> '{}'),
-        'has_changelog', (select exists (
-            select 1 from snapshot where package_id = v_package_id and changes is not null
-        )),
-        'changes', s.changes,
-        'ts', floor(extract(epoch from s.ts)),
-        'maintainers', (
-            select json_agg(json_build_object(
-                'name', m.name,
-                'email', m.email
-            ))
-            from maintainer m
-            join package__maintainer pm using (maintainer_id)
-            where pm.package_id = v_package_id
-        ),
-        'recommendations', s.recommendations,
-        'sign_key', s.sign_key,
-        'repository', (select get_repository_summary(r.repository_id)),
-        'stats', json_build_object(
-            'subscriptions', (select count(*) from subscription where package_id = v_package_id),
-            'webhooks', (select count(*) from webhook__package where package_id = v_package_id)
-        )
-    ))
+    select (
+        coalesce(pd.document, build_package_document(p.package_id, s.version)) ||
+        coalesce(p.document, build_package_level_document(p.package_id)) ||
+        jsonb_build_object(
+            'repository', (select get_repository_summary(r.repository_id)),
+            'stats', jsonb_build_object(
+                'subscriptions', (select count(*) from subscription where package_id = v_package_id),
+                'webhooks', (select count(*) from webhook__package where package_id = v_package_id)
+            )
+        ) ||
+        jsonb_strip_nulls(jsonb_build_object(
+            'package_id', p.package_id,
+            'name', p.name,
+            'normalized_name', p.normalized_name,
+            'is_operator', p.is_operator,
+            'official', p.official,
+            'all_containers_images_whitelisted', are_all_containers_images_whitelisted(s.containers_images),
+            'channels', p.channels,
+            'default_channel', p.default_channel,
+            'labels', r.labels,
+            'category', coalesce(p.category, s.category),
+            'upstream', s.upstream,
+            'promoted', (
+                select true from promoted_package pp
+                where pp.package_id = p.package_id
+                and current_timestamp between pp.starts_at and pp.ends_at
+                limit 1
+            ),
+            'freshness', case when p.last_release_ts is not null then jsonb_build_object(
+                'last_release_ts', floor(extract(epoch from p.last_release_ts)),
+                'days_since_last_release', extract(day from current_timestamp - p.last_release_ts),
+                'kind_median_release_cadence_days', extract(day from rk.median_release_cadence),
+                'stale', p.stale
+            ) end
+        ))
+    )::json
     from package p
     join snapshot s using (package_id)
     join repository r using (repository_id)
+    join repository_kind rk using (repository_kind_id)
+    left join package_document pd using (package_id, version)
     where p.package_id = v_package_id
     and
         case when p_input->>'version' <> '' then
@@ -0,0 +1,26 @@
+-- get_package_by_digest returns some details of the package version that
+-- matches the digest provided as a json object. When several packages versions
+-- share the same digest, the one registered first is returned. Packages from
+-- private or disabled repositories are not considered.
+create or replace function get_package_by_digest(p_digest text)
+returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'package_id', p.package_id,
+        'name', p.name,
+        'normalized_name', p.normalized_name,
+        'version', s.version,
+        'digest', s.digest,
+        'upstream', s.upstream,
+        'repository', (select get_repository_summary(r.repository_id))
+    ))
+    from snapshot s
+    join package p using (package_id)
+    join repository r using (repository_id)
+    where s.digest = p_digest
+    and r.disabled = false
+    and r.auth_user is null
+    and r.auth_pass is null
+    and r.ssh_key is null
+    order by s.created_at asc
+    limit 1;
+$$ language sql;
@@ -0,0 +1,24 @@
+-- get_package_events returns the timeline of events (new releases, security
+-- alerts, deprecations and repository ownership claims) of the package
+-- identified by the id provided as a json array, sorted from newest to oldest.
+create or replace function get_package_events(p_package_id uuid)
+returns setof json as $$
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'event_kind', event_kind_id,
+        'version', package_version,
+        'ts', floor(extract(epoch from created_at))
+    ))), '[]')
+    from (
+        select e.event_kind_id, e.package_version, e.created_at
+        from event e
+        where e.package_id = p_package_id
+        and e.event_kind_id in (0, 1, 5)
+        union all
+        select e.event_kind_id, null, e.created_at
+        from event e
+        join package p using (repository_id)
+        where p.package_id = p_package_id
+        and e.event_kind_id = 3
+        order by created_at desc
+    ) pe;
+$$ language sql;
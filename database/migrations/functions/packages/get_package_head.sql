@@ -0,0 +1,21 @@
+-- get_package_head returns some information that allows checking if the
+-- package version identified by the input provided has changed, like its
+-- digest or when it was last modified, as a json object.
+create or replace function get_package_head(p_input jsonb)
+returns setof json as $$
+    select json_build_object(
+        'digest', s.digest,
+        'last_modified', floor(extract(epoch from s.created_at))
+    )
+    from package p
+    join snapshot s using (package_id)
+    join repository r using (repository_id)
+    where p.normalized_name = p_input->>'package_name'
+    and r.name = p_input->>'repository_name'
+    and
+        case when p_input->>'version' <> '' then
+            s.version = p_input->>'version'
+        else
+            s.version = p.latest_version
+        end;
+$$ language sql;
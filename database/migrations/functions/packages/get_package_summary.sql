@@ -32,9 +32,17 @@ begin
         'license', s.license,
         'deprecated', s.deprecated,
         'signed', s.signed,
+        'signature_verified', s.signature_verified,
+        'signature_kind', s.signature_kind,
         'security_report_summary', s.security_report_summary,
         'all_containers_images_whitelisted', are_all_containers_images_whitelisted(s.containers_images),
         'ts', floor(extract(epoch from s.ts)),
+        'promoted', (
+            select true from promoted_package pp
+            where pp.package_id = p.package_id
+            and current_timestamp between pp.starts_at and pp.ends_at
+            limit 1
+        ),
         'repository', (select get_repository_summary(r.repository_id))
     ))
     from package p
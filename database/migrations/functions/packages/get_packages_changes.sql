@@ -0,0 +1,24 @@
+-- get_packages_changes returns the packages changes (added, updated or
+-- removed) registered that match the criteria in the input provided, sorted
+-- by sequence number, as a json array.
+create or replace function get_packages_changes(p_input jsonb)
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'sequence', package_change_id,
+        'kind', change_kind,
+        'package_id', package_id,
+        'package_name', package_name,
+        'repository_id', repository_id,
+        'repository_name', repository_name,
+        'repository_kind', repository_kind_id,
+        'ts', floor(extract(epoch from created_at))
+    ) order by package_change_id), '[]')
+    from (
+        select *
+        from package_change
+        where package_change_id > coalesce((p_input->>'after')::bigint, 0)
+        and created_at >= to_timestamp(coalesce((p_input->>'since')::bigint, 0))
+        order by package_change_id asc
+        limit (p_input->>'limit')::int
+    ) pc;
+$$ language sql;
@@ -0,0 +1,35 @@
+-- get_packages_comparison returns a json array with some normalized details of
+-- the packages provided, so that they can be compared side by side. Packages
+-- are returned in the same order they were provided.
+create or replace function get_packages_comparison(p_packages_ids uuid[])
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'package_id', p.package_id,
+        'name', p.name,
+        'normalized_name', p.normalized_name,
+        'display_name', s.display_name,
+        'logo_image_id', s.logo_image_id,
+        'latest_version', p.latest_version,
+        'app_version', s.app_version,
+        'license', s.license,
+        'capabilities', s.capabilities,
+        'security_rating', case
+            when s.security_report_summary is null then null
+            when coalesce((s.security_report_summary->>'critical')::int, 0) > 0 then 'F'
+            when coalesce((s.security_report_summary->>'high')::int, 0) > 0 then 'D'
+            when coalesce((s.security_report_summary->>'medium')::int, 0) > 0 then 'C'
+            when coalesce((s.security_report_summary->>'low')::int, 0) > 0
+                or coalesce((s.security_report_summary->>'unknown')::int, 0) > 0 then 'B'
+            else 'A'
+        end,
+        'security_report_summary', s.security_report_summary,
+        'stars', p.stars,
+        'kubernetes_version', nullif(s.data->>'kubeVersion', ''),
+        'repository', (select get_repository_summary(r.repository_id))
+    ) order by array_position(p_packages_ids, p.package_id)), '[]')
+    from package p
+    join snapshot s using (package_id)
+    join repository r using (repository_id)
+    where p.package_id = any(p_packages_ids)
+    and s.version = p.latest_version;
+$$ language sql;
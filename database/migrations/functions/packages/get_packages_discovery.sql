@@ -0,0 +1,9 @@
+-- get_packages_discovery returns the packages listed in the discovery category
+-- provided as a json array.
+create or replace function get_packages_discovery(p_category text)
+returns setof json as $$
+    select coalesce(json_agg(pkgJSON order by pd.position), '[]')
+    from package_discovery pd
+    cross join get_package_summary(jsonb_build_object('package_id', pd.package_id)) as pkgJSON
+    where pd.category = p_category;
+$$ language sql;
@@ -1,16 +1,33 @@
--- get_random_packages returns some random packages as a json object.
+-- get_random_packages returns some random packages as a json object. Packages
+-- are picked from the ones listed in the discovery categories when available.
 create or replace function get_random_packages()
 returns setof json as $$
+begin
+    if exists (select 1 from package_discovery) then
+        return query
+        select coalesce(json_agg(pkgJSON), '[]')
+        from (
+            select package_id
+            from (select distinct package_id from package_discovery) pd
+            order by random() limit 10
+        ) rp
+        cross join get_package_summary(jsonb_build_object('package_id', rp.package_id)) as pkgJSON;
+        return;
+    end if;
+
+    return query
     select coalesce(json_agg(pkgJSON), '[]')
     from (
         select p.package_id
         from package p tablesample system_rows(1000)
         join snapshot s using (package_id)
         where s.version = p.latest_version
+        and p.hidden = false
         and (s.deprecated is null or s.deprecated = false)
         and s.readme is not null
         and s.ts between current_timestamp - '6 months'::interval and current_timestamp
         order by random() limit 10
     ) rp
     cross join get_package_summary(jsonb_build_object('package_id', rp.package_id)) as pkgJSON;
-$$ language sql;
+end
+$$ language plpgsql;
@@ -0,0 +1,18 @@
+-- get_security_reports_to_migrate returns up to the number of security reports
+-- provided that are stored in the database and should be moved to the reports
+-- store as a json array.
+create or replace function get_security_reports_to_migrate(p_limit int)
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'package_id', package_id,
+        'version', version,
+        'images_reports', security_report
+    )), '[]')
+    from (
+        select package_id, version, security_report
+        from snapshot
+        where security_report is not null
+        order by security_report_created_at asc
+        limit p_limit
+    ) s;
+$$ language sql;
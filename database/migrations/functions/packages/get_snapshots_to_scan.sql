@@ -25,7 +25,7 @@ returns setof json as $$
         where containers_images is not null
         and r.scanner_disabled = false
         and (
-            security_report is null
+            (security_report is null and security_report_location is null)
             or (security_report_created_at < (current_timestamp - '1 day'::interval) and s.version = p.latest_version )
             or security_report_created_at < (current_timestamp - '1 week'::interval)
         )
@@ -0,0 +1,23 @@
+-- get_snapshots_without_logo returns up to the number of snapshots provided
+-- of the latest version of packages that have no logo as a json array.
+create or replace function get_snapshots_without_logo(p_limit int)
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'repository_id', repository_id,
+        'package_id', package_id,
+        'package_name', package_name,
+        'version', version
+    )), '[]')
+    from (
+        select
+            p.repository_id,
+            s.package_id,
+            p.name as package_name,
+            s.version
+        from snapshot s
+        join package p on p.package_id = s.package_id and p.latest_version = s.version
+        where s.logo_image_id is null
+        order by s.created_at desc
+        limit p_limit
+    ) s;
+$$ language sql;
@@ -0,0 +1,26 @@
+-- get_user_stale_packages returns the stale packages from the repositories
+-- owned by the user as a json array.
+create or replace function get_user_stale_packages(p_user_id uuid, p_limit int, p_offset int)
+returns table(data json, total_count bigint) as $$
+    with user_stale_packages as (
+        select p.package_id, p.last_release_ts
+        from package p
+        join repository r using (repository_id)
+        where r.user_id = p_user_id
+        and p.stale = true
+    )
+    select
+        coalesce(json_agg(pkgJSON), '[]'),
+        (select count(*) from user_stale_packages)
+    from (
+        select (
+            pkgJSON::jsonb ||
+            jsonb_build_object('last_release_ts', floor(extract(epoch from usp.last_release_ts)))
+        ) as pkgJSON
+        from user_stale_packages usp
+        cross join get_package_summary(jsonb_build_object('package_id', usp.package_id)) as pkgJSON
+        order by usp.last_release_ts asc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) ps
+$$ language sql;
@@ -0,0 +1,15 @@
+-- refresh_package_documents rebuilds the json documents of the package
+-- provided. When a version is provided, only the document of that version is
+-- rebuilt.
+create or replace function refresh_package_documents(p_package_id uuid, p_version text default null)
+returns void as $$
+    insert into package_document (package_id, version, document)
+    select package_id, version, build_package_document(package_id, version)
+    from snapshot
+    where package_id = p_package_id
+    and (p_version is null or version = p_version)
+    on conflict (package_id, version) do update
+    set
+        document = excluded.document,
+        updated_at = current_timestamp;
+$$ language sql;
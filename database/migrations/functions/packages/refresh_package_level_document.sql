@@ -0,0 +1,7 @@
+-- refresh_package_level_document rebuilds the json document with the package
+-- level details of the package provided.
+create or replace function refresh_package_level_document(p_package_id uuid)
+returns void as $$
+    update package set document = build_package_level_document(p_package_id)
+    where package_id = p_package_id;
+$$ language sql;
@@ -0,0 +1,79 @@
+-- refresh_packages_discovery recomputes the packages listed in each of the
+-- discovery categories: packages added during the last week, packages from
+-- publishers verified during the last month and hidden gems (packages with a
+-- high quality score that haven't got much attention yet). Deprecated packages
+-- are never listed.
+create or replace function refresh_packages_discovery(
+    p_limit int,
+    p_hidden_gems_max_stars int,
+    p_hidden_gems_min_quality_score int
+)
+returns void as $$
+begin
+    delete from package_discovery;
+
+    -- New this week
+    insert into package_discovery (category, package_id, position)
+    select 'new-this-week', package_id, row_number() over (order by created_at desc, package_id)
+    from (
+        select p.package_id, p.created_at
+        from package p
+        join snapshot s using (package_id)
+        where s.version = p.latest_version
+        and (s.deprecated is null or s.deprecated = false)
+        and p.created_at > current_timestamp - '7 days'::interval
+        order by p.created_at desc, p.package_id
+        limit p_limit
+    ) pn;
+
+    -- Recently verified publishers (most starred package of each repository)
+    insert into package_discovery (category, package_id, position)
+    select 'recently-verified', package_id, row_number() over (order by verified_publisher_at desc, stars desc, package_id)
+    from (
+        select distinct on (r.repository_id) p.package_id, p.stars, r.verified_publisher_at
+        from package p
+        join repository r using (repository_id)
+        join snapshot s using (package_id)
+        where s.version = p.latest_version
+        and (s.deprecated is null or s.deprecated = false)
+        and r.verified_publisher = true
+        and r.verified_publisher_at > current_timestamp - '30 days'::interval
+        order by r.repository_id, p.stars desc, p.package_id
+    ) pv
+    order by verified_publisher_at desc, stars desc, package_id
+    limit p_limit;
+
+    -- Hidden gems (a point is given for each quality signal found)
+    insert into package_discovery (category, package_id, position)
+    select 'hidden-gems', package_id, row_number() over (order by quality_score desc, stars asc, package_id)
+    from (
+        select
+            p.package_id,
+            p.stars,
+            (s.readme is not null)::int
+            + (s.license is not null)::int
+            + coalesce(s.signed, false)::int
+            + (coalesce(p.official, false) or r.official or r.verified_publisher)::int
+            + (not p.stale)::int
+            + (
+                s.security_report_summary is not null
+                and coalesce((s.security_report_summary->>'critical')::int, 0) = 0
+                and coalesce((s.security_report_summary->>'high')::int, 0) = 0
+            )::int
+            + (
+                s.test_results is not null
+                and coalesce((s.test_results->>'failed')::int, 0) = 0
+                and coalesce((s.test_results->>'passed')::int, 0) > 0
+            )::int as quality_score
+        from package p
+        join repository r using (repository_id)
+        join snapshot s using (package_id)
+        where s.version = p.latest_version
+        and (s.deprecated is null or s.deprecated = false)
+        and p.stars <= p_hidden_gems_max_stars
+    ) pq
+    where quality_score >= p_hidden_gems_min_quality_score
+    order by quality_score desc, stars asc, package_id
+    limit p_limit;
+end
+$$ language plpgsql;
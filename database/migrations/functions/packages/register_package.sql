@@ -10,6 +10,7 @@ declare
     v_package_id uuid;
     v_name text := p_pkg->>'name';
     v_display_name text := nullif(p_pkg->>'display_name', '');
+    v_alias text := nullif(p_pkg->>'alias', '');
     v_description text := nullif(p_pkg->>'description', '');
     v_keywords text[] := (select nullif(array(select jsonb_array_elements_text(nullif(p_pkg->'keywords', 'null'::jsonb))), '{}'));
     v_version text := p_pkg->>'version';
@@ -20,11 +21,13 @@ declare
     v_provider text := nullif(p_pkg->>'provider', '');
     v_ts_repository text[];
     v_ts_publisher text[];
+    v_repository_kind_id int;
     v_repository_disabled boolean;
+    v_previous_deprecated boolean;
 begin
     -- Get some repository information (some of it for tsdoc)
-    select r.disabled, array[r.name, r.display_name], array[u.alias, o.name, o.display_name, v_provider]
-    into v_repository_disabled, v_ts_repository, v_ts_publisher
+    select r.repository_kind_id, r.disabled, array[r.name, r.display_name], array[u.alias, o.name, o.display_name, v_provider]
+    into v_repository_kind_id, v_repository_disabled, v_ts_repository, v_ts_publisher
     from repository r
     left join "user" u using (user_id)
     left join organization o using (organization_id)
@@ -48,6 +51,7 @@ begin
         name,
         latest_version,
         tsdoc,
+        search_data,
         is_operator,
         channels,
         default_channel,
@@ -55,7 +59,8 @@ begin
     ) values (
         v_name,
         v_version,
-        generate_package_tsdoc(v_name, v_display_name, v_description, v_keywords, v_ts_repository, v_ts_publisher),
+        generate_package_tsdoc(v_name, v_display_name, v_description, v_keywords, v_ts_repository, v_ts_publisher, v_alias),
+        generate_package_search_data(v_repository_kind_id, nullif(p_pkg->'data', 'null')),
         (p_pkg->>'is_operator')::boolean,
         nullif(p_pkg->'channels', 'null'),
         nullif(p_pkg->>'default_channel', ''),
@@ -65,7 +70,8 @@ begin
     set
         name = excluded.name,
         latest_version = excluded.latest_version,
-        tsdoc = generate_package_tsdoc(v_name, v_display_name, v_description, v_keywords, v_ts_repository, v_ts_publisher),
+        tsdoc = generate_package_tsdoc(v_name, v_display_name, v_description, v_keywords, v_ts_repository, v_ts_publisher, v_alias),
+        search_data = excluded.search_data,
         is_operator = excluded.is_operator,
         channels = excluded.channels,
         default_channel = excluded.default_channel
@@ -90,6 +96,13 @@ begin
                 select maintainer_id into v_maintainer_id
                 from maintainer
                 where email = v_maintainer->>'email';
+            else
+                -- The maintainer name may have been updated, refresh the
+                -- documents of other packages maintained by it
+                perform refresh_package_level_document(pm.package_id)
+                from package__maintainer pm
+                where pm.maintainer_id = v_maintainer_id
+                and pm.package_id <> v_package_id;
             end if;
 
             -- Bind package to maintainer
@@ -120,6 +133,12 @@ begin
         and repository_id = v_repository_id;
     end if;
 
+    -- Get package version deprecated flag before registration, if available
+    select deprecated into v_previous_deprecated
+    from snapshot
+    where package_id = v_package_id
+    and version = v_version;
+
     -- Package snapshot
     v_ts := to_timestamp((p_pkg->>'ts')::int);
     if v_ts is null then
@@ -129,9 +148,11 @@ begin
         package_id,
         version,
         display_name,
+        alias,
         description,
         logo_url,
         logo_image_id,
+        logo_generated,
         keywords,
         home_url,
         app_version,
@@ -145,24 +166,40 @@ begin
         data,
         deprecated,
         license,
+        category,
         signed,
+        signature_verified,
+        signature_kind,
+        signature_identity,
+        has_attestations,
         content_url,
         containers_images,
         provider,
         values_schema,
+        values_schema_inferred,
+        values_docs,
+        dependencies_tree,
         changes,
         contains_security_updates,
         prerelease,
         recommendations,
         sign_key,
+        sbom,
+        sbom_format,
+        sbom_location,
+        test_results,
+        upstream,
+        chart_diagnostics,
         ts
     ) values (
         v_package_id,
         v_version,
         v_display_name,
+        v_alias,
         v_description,
         nullif(p_pkg->>'logo_url', ''),
         nullif(p_pkg->>'logo_image_id', '')::uuid,
+        coalesce((p_pkg->>'logo_generated')::boolean, false),
         v_keywords,
         nullif(p_pkg->>'home_url', ''),
         nullif(p_pkg->>'app_version', ''),
@@ -176,24 +213,40 @@ begin
         nullif(p_pkg->'data', 'null'),
         (p_pkg->>'deprecated')::boolean,
         nullif(p_pkg->>'license', ''),
+        nullif(p_pkg->>'category', ''),
         (p_pkg->>'signed')::boolean,
+        (p_pkg->>'signature_verified')::boolean,
+        nullif(p_pkg->>'signature_kind', ''),
+        nullif(p_pkg->>'signature_identity', ''),
+        (p_pkg->>'has_attestations')::boolean,
         nullif(p_pkg->>'content_url', ''),
         nullif(p_pkg->'containers_images', 'null'),
         v_provider,
         nullif(p_pkg->'values_schema', 'null'),
+        coalesce((p_pkg->>'values_schema_inferred')::boolean, false),
+        nullif(p_pkg->'values_docs', 'null'),
+        nullif(p_pkg->'dependencies_tree', 'null'),
         nullif(p_pkg->'changes', 'null'),
         (p_pkg->>'contains_security_updates')::boolean,
         (p_pkg->>'prerelease')::boolean,
         nullif(p_pkg->'recommendations', 'null'),
         nullif(p_pkg->'sign_key', 'null'),
+        nullif(p_pkg->'sbom'->'data', 'null'),
+        nullif(p_pkg->'sbom'->>'format', ''),
+        nullif(p_pkg->'sbom'->>'location', ''),
+        nullif(p_pkg->'test_results', 'null'),
+        nullif(p_pkg->'upstream', 'null'),
+        nullif(p_pkg->'chart_diagnostics', 'null'),
         v_ts
     )
     on conflict (package_id, version) do update
     set
         display_name = excluded.display_name,
+        alias = excluded.alias,
         description = excluded.description,
         logo_url = excluded.logo_url,
         logo_image_id = excluded.logo_image_id,
+        logo_generated = excluded.logo_generated,
         keywords = excluded.keywords,
         home_url = excluded.home_url,
         app_version = excluded.app_version,
@@ -207,22 +260,53 @@ begin
         data = excluded.data,
         deprecated = excluded.deprecated,
         license = excluded.license,
+        category = excluded.category,
         signed = excluded.signed,
+        signature_verified = excluded.signature_verified,
+        signature_kind = excluded.signature_kind,
+        signature_identity = excluded.signature_identity,
+        has_attestations = excluded.has_attestations,
         content_url = excluded.content_url,
         containers_images = excluded.containers_images,
         provider = excluded.provider,
         values_schema = excluded.values_schema,
+        values_schema_inferred = excluded.values_schema_inferred,
+        values_docs = excluded.values_docs,
+        dependencies_tree = excluded.dependencies_tree,
         changes = excluded.changes,
         contains_security_updates = excluded.contains_security_updates,
         prerelease = excluded.prerelease,
         recommendations = excluded.recommendations,
         sign_key = excluded.sign_key,
+        sbom = excluded.sbom,
+        sbom_format = excluded.sbom_format,
+        sbom_location = excluded.sbom_location,
+        test_results = coalesce(excluded.test_results, snapshot.test_results),
+        upstream = case
+            when excluded.digest = snapshot.digest then coalesce(excluded.upstream, snapshot.upstream)
+            else excluded.upstream
+        end,
+        chart_diagnostics = excluded.chart_diagnostics,
         ts = v_ts;
 
+    -- Refresh package version and package level documents
+    perform refresh_package_documents(v_package_id, v_version);
+    perform refresh_package_level_document(v_package_id);
+
     -- Register new release event if package's latest version has been updated
     if semver_gt(v_version, v_previous_latest_version) then
         insert into event (package_id, package_version, event_kind_id)
         values (v_package_id, v_version, 0);
     end if;
+
+    -- Register package deprecated event if package's latest version has just
+    -- been deprecated
+    if (p_pkg->>'deprecated')::boolean = true
+    and coalesce(v_previous_deprecated, false) = false
+    and v_previous_latest_version is not null
+    and semver_gte(v_version, v_previous_latest_version) then
+        insert into event (package_id, package_version, event_kind_id)
+        values (v_package_id, v_version, 5);
+    end if;
 end
 $$ language plpgsql;
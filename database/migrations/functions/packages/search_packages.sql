@@ -8,7 +8,14 @@ declare
     v_orgs text[];
     v_repositories text[];
     v_licenses text[];
+    v_categories text[];
     v_capabilities text[];
+    v_labels text[];
+    v_kube_versions text[];
+    v_chart_types text[];
+    v_platforms text[];
+    v_task_params text[];
+    v_compatible_kube_version int[] := string_to_array(p_input->>'compatible_kube_version', '.')::int[];
     v_facets boolean := (p_input->>'facets')::boolean;
     v_tsquery_web tsquery := websearch_to_tsquery(p_input->>'ts_query_web');
     v_tsquery_web_with_prefix_matching tsquery;
@@ -26,8 +33,20 @@ begin
     from jsonb_array_elements_text(p_input->'repositories') e;
     select array_agg(e::text) into v_licenses
     from jsonb_array_elements_text(p_input->'licenses') e;
+    select array_agg(e::text) into v_categories
+    from jsonb_array_elements_text(p_input->'categories') e;
     select array_agg(e::text) into v_capabilities
     from jsonb_array_elements_text(p_input->'capabilities') e;
+    select array_agg(e::text) into v_labels
+    from jsonb_array_elements_text(p_input->'labels') e;
+    select array_agg(e::text) into v_kube_versions
+    from jsonb_array_elements_text(p_input->'kube_versions') e;
+    select array_agg(e::text) into v_chart_types
+    from jsonb_array_elements_text(p_input->'chart_types') e;
+    select array_agg(e::text) into v_platforms
+    from jsonb_array_elements_text(p_input->'platforms') e;
+    select array_agg(e::text) into v_task_params
+    from jsonb_array_elements_text(p_input->'task_params') e;
 
     -- Prepare v_tsquery_web_with_prefix_matching
     if v_tsquery_web is not null then
@@ -51,13 +70,16 @@ begin
             p.normalized_name,
             p.stars,
             p.tsdoc,
+            p.search_data,
             p.official as package_official,
+            p.last_release_ts,
             s.display_name,
             s.description,
             s.logo_image_id,
             s.version,
             s.app_version,
             s.license,
+            coalesce(p.category, s.category) as category,
             s.capabilities,
             s.deprecated,
             s.signed,
@@ -73,6 +95,8 @@ begin
             r.verified_publisher,
             r.official as repository_official,
             r.scanner_disabled as repository_scanner_disabled,
+            r.archived as repository_archived,
+            r.labels as repository_labels,
             u.alias as user_alias,
             o.name as organization_name,
             o.display_name as organization_display_name
@@ -83,6 +107,7 @@ begin
         left join "user" u using (user_id)
         left join organization o using (organization_id)
         where s.version = p.latest_version
+        and p.hidden = false
         and
             case when v_tsquery_web is not null then
                 v_tsquery_web_with_prefix_matching @@ p.tsdoc
@@ -115,6 +140,12 @@ begin
             else
                 (s.deprecated is null or s.deprecated = false)
             end
+        and
+            case when p_input ? 'active' and (p_input->>'active')::boolean = true then
+                p.stale = false
+            else
+                true
+            end
     ), packages_applying_all_filters as (
         select * from packages_applying_minimum_filters
         where
@@ -136,9 +167,40 @@ begin
         and
             case when cardinality(v_licenses) > 0
             then license = any(v_licenses) else true end
+        and
+            case when cardinality(v_categories) > 0
+            then category = any(v_categories) else true end
         and
             case when cardinality(v_capabilities) > 0
             then capabilities = any(v_capabilities) else true end
+        and
+            case when cardinality(v_labels) > 0
+            then repository_labels && v_labels else true end
+        and
+            case when cardinality(v_kube_versions) > 0
+            then search_data->'kube_version' ?| v_kube_versions else true end
+        and
+            case when v_compatible_kube_version is not null then
+                search_data->'kube_version_range' is null
+                or exists (
+                    select 1
+                    from jsonb_array_elements(search_data->'kube_version_range') r
+                    where string_to_array(r->>'min', '.')::int[] <= v_compatible_kube_version
+                    and (
+                        r->>'max' is null
+                        or string_to_array(r->>'max', '.')::int[] >= v_compatible_kube_version
+                    )
+                )
+            else true end
+        and
+            case when cardinality(v_chart_types) > 0
+            then search_data->'chart_type' ?| v_chart_types else true end
+        and
+            case when cardinality(v_platforms) > 0
+            then search_data->'platform' ?| v_platforms else true end
+        and
+            case when cardinality(v_task_params) > 0
+            then search_data->'task_param' ?| v_task_params else true end
     )
     select
         json_strip_nulls(json_build_object(
@@ -160,6 +222,7 @@ begin
                     'security_report_summary', security_report_summary,
                     'all_containers_images_whitelisted', are_all_containers_images_whitelisted(containers_images),
                     'ts', floor(extract(epoch from ts)),
+                    'labels', repository_labels,
                     'repository', jsonb_build_object(
                         'repository_id', repository_id,
                         'kind', repository_kind_id,
@@ -169,6 +232,8 @@ begin
                         'verified_publisher', verified_publisher,
                         'official', repository_official,
                         'scanner_disabled', repository_scanner_disabled,
+                        'archived', nullif(repository_archived, false),
+                        'labels', repository_labels,
                         'user_alias', user_alias,
                         'organization_name', organization_name,
                         'organization_display_name', organization_display_name
@@ -191,6 +256,7 @@ begin
                     order by
                         case when v_sort = 'relevance' then (relevance, stars) end desc,
                         case when v_sort = 'stars' then (stars, relevance) end desc,
+                        case when v_sort = 'last_release' then last_release_ts end desc nulls last,
                         official desc,
                         verified_publisher desc,
                         name asc
@@ -346,6 +412,26 @@ begin
                             )
                         )
                     ),
+                    (
+                        select json_build_object(
+                            'title', 'Category',
+                            'filter_key', 'category',
+                            'options', (
+                                select coalesce(json_agg(json_build_object(
+                                    'id', category,
+                                    'name', category,
+                                    'total', total
+                                )), '[]')
+                                from (
+                                    select category, count(*) as total
+                                    from packages_applying_minimum_filters
+                                    where category is not null
+                                    group by category
+                                    order by total desc, category asc
+                                ) as categories_breakdown
+                            )
+                        )
+                    ),
                     (
                         select json_build_object(
                             'title', 'Operator capabilities',
@@ -0,0 +1,22 @@
+-- set_snapshot_generated_logo sets the generated logo image provided to the
+-- package version provided, as long as it still has no logo.
+create or replace function set_snapshot_generated_logo(
+    p_package_id uuid,
+    p_version text,
+    p_logo_image_id uuid
+)
+returns void as $$
+begin
+    update snapshot set
+        logo_image_id = p_logo_image_id,
+        logo_generated = true
+    where package_id = p_package_id
+    and version = p_version
+    and logo_image_id is null;
+    if not found then
+        return;
+    end if;
+
+    perform refresh_package_documents(p_package_id, p_version);
+end
+$$ language plpgsql;
@@ -0,0 +1,28 @@
+-- set_snapshot_test_results sets the test results of the package version
+-- provided, returning whether the package version was found or not.
+create or replace function set_snapshot_test_results(
+    p_repository_name text,
+    p_package_name text,
+    p_version text,
+    p_test_results jsonb
+)
+returns boolean as $$
+declare
+    v_package_id uuid;
+begin
+    update snapshot s set test_results = p_test_results
+    from package p
+    join repository r using (repository_id)
+    where s.package_id = p.package_id
+    and r.name = p_repository_name
+    and p.name = p_package_name
+    and s.version = p_version
+    returning s.package_id into v_package_id;
+    if not found then
+        return false;
+    end if;
+
+    perform refresh_package_documents(v_package_id, p_version);
+    return true;
+end
+$$ language plpgsql;
@@ -51,6 +51,9 @@ begin
 
         -- Delete version snapshot
         delete from snapshot where package_id = v_package_id and version = p_pkg->>'version';
+
+        -- Refresh package level document
+        perform refresh_package_level_document(v_package_id);
     end if;
 end
 $$ language plpgsql;
@@ -0,0 +1,49 @@
+-- update_packages_freshness updates the median release cadence of each
+-- repository kind as well as when each package was last released. Packages
+-- whose last release is older than the median cadence of their kind multiplied
+-- by the factor provided (or the minimum number of days given, whichever is
+-- greater) are flagged as stale.
+create or replace function update_packages_freshness(p_stale_factor real, p_stale_min_days int)
+returns void as $$
+begin
+    -- Update repository kinds median release cadence
+    with package_release_cadence as (
+        select
+            r.repository_kind_id,
+            (max(s.ts) - min(s.ts)) / (count(s.ts) - 1) as cadence
+        from package p
+        join snapshot s using (package_id)
+        join repository r using (repository_id)
+        where s.ts is not null
+        group by p.package_id, r.repository_kind_id
+        having count(s.ts) > 1
+    )
+    update repository_kind rk set median_release_cadence = (
+        select percentile_cont(0.5) within group (order by cadence)
+        from package_release_cadence prc
+        where prc.repository_kind_id = rk.repository_kind_id
+    );
+
+    -- Update packages last release timestamp and stale flag
+    with package_freshness as (
+        select
+            p.package_id,
+            max(s.ts) as last_release_ts,
+            coalesce(current_timestamp - max(s.ts) > greatest(
+                rk.median_release_cadence * p_stale_factor,
+                make_interval(days => p_stale_min_days)
+            ), false) as stale
+        from package p
+        join snapshot s using (package_id)
+        join repository r using (repository_id)
+        join repository_kind rk using (repository_kind_id)
+        group by p.package_id, rk.median_release_cadence
+    )
+    update package p set
+        last_release_ts = pf.last_release_ts,
+        stale = pf.stale
+    from package_freshness pf
+    where p.package_id = pf.package_id
+    and (p.last_release_ts is distinct from pf.last_release_ts or p.stale <> pf.stale);
+end
+$$ language plpgsql;
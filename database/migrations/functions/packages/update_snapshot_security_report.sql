@@ -27,11 +27,15 @@ begin
 
     -- Update security report info in snapshot
     update snapshot set
-        security_report = p_report->'images_reports',
+        security_report = nullif(p_report->'images_reports', 'null'),
+        security_report_location = nullif(p_report->>'images_reports_location', ''),
         security_report_alert_digest = v_alert_digest,
         security_report_summary = p_report->'summary',
         security_report_created_at = current_timestamp
     where package_id = v_package_id
     and version = v_version;
+
+    -- Refresh package version document
+    perform refresh_package_documents(v_package_id, v_version);
 end
 $$ language plpgsql;
@@ -0,0 +1,31 @@
+-- add_promoted_package promotes the provided package during the period given,
+-- returning the id of the promotion created. Only site admins are allowed to
+-- promote packages.
+create or replace function add_promoted_package(p_user_id uuid, p_promoted_package jsonb)
+returns uuid as $$
+declare
+    v_promoted_package_id uuid;
+begin
+    if not user_is_site_admin(p_user_id) then
+        raise insufficient_privilege;
+    end if;
+    if not exists (
+        select 1 from package
+        where package_id = (p_promoted_package->>'package_id')::uuid
+    ) then
+        raise 'package not found';
+    end if;
+
+    insert into promoted_package (
+        package_id,
+        starts_at,
+        ends_at
+    ) values (
+        (p_promoted_package->>'package_id')::uuid,
+        coalesce(to_timestamp(nullif(p_promoted_package->>'starts_at', '0')::bigint), current_timestamp),
+        to_timestamp((p_promoted_package->>'ends_at')::bigint)
+    ) returning promoted_package_id into v_promoted_package_id;
+
+    return v_promoted_package_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,15 @@
+-- delete_promoted_package deletes the provided package promotion. Only site
+-- admins are allowed to delete promotions.
+create or replace function delete_promoted_package(p_user_id uuid, p_promoted_package_id uuid)
+returns void as $$
+begin
+    if not user_is_site_admin(p_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from promoted_package where promoted_package_id = p_promoted_package_id;
+    if not found then
+        raise 'promoted package not found';
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,12 @@
+-- get_promoted_packages returns the packages currently promoted as a json
+-- array.
+create or replace function get_promoted_packages()
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'promoted_package_id', pp.promoted_package_id,
+        'package', pkgJSON
+    ) order by pp.starts_at desc), '[]')
+    from promoted_package pp
+    cross join get_package_summary(jsonb_build_object('package_id', pp.package_id)) as pkgJSON
+    where current_timestamp between pp.starts_at and pp.ends_at;
+$$ language sql;
@@ -0,0 +1,24 @@
+-- get_promoted_packages_stats returns all the package promotions, including
+-- their impressions and clicks, as a json array. Only site admins are allowed
+-- to get the promotions stats.
+create or replace function get_promoted_packages_stats(p_user_id uuid)
+returns setof json as $$
+begin
+    if not user_is_site_admin(p_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_build_object(
+        'promoted_package_id', pp.promoted_package_id,
+        'package', pkgJSON,
+        'starts_at', floor(extract(epoch from pp.starts_at)),
+        'ends_at', floor(extract(epoch from pp.ends_at)),
+        'active', current_timestamp between pp.starts_at and pp.ends_at,
+        'impressions', pp.impressions,
+        'clicks', pp.clicks
+    ) order by pp.starts_at desc), '[]')
+    from promoted_package pp
+    cross join get_package_summary(jsonb_build_object('package_id', pp.package_id)) as pkgJSON;
+end
+$$ language plpgsql;
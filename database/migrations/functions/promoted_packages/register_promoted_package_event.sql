@@ -0,0 +1,15 @@
+-- register_promoted_package_event registers an impression or a click on the
+-- provided package promotion, as long as it's currently active.
+create or replace function register_promoted_package_event(p_promoted_package_id uuid, p_event text)
+returns void as $$
+begin
+    update promoted_package set
+        impressions = impressions + (case when p_event = 'impression' then 1 else 0 end),
+        clicks = clicks + (case when p_event = 'click' then 1 else 0 end)
+    where promoted_package_id = p_promoted_package_id
+    and current_timestamp between starts_at and ends_at;
+    if not found then
+        raise 'promoted package not found';
+    end if;
+end
+$$ language plpgsql;
@@ -24,8 +24,14 @@ begin
         branch,
         auth_user,
         auth_pass,
+        ssh_key,
         disabled,
         scanner_disabled,
+        signing_policy,
+        sign_keyring,
+        sign_keyring_url,
+        tracking_schedule,
+        labels,
         repository_kind_id,
         user_id,
         organization_id
@@ -36,8 +42,14 @@ begin
         nullif(p_repository->>'branch', ''),
         nullif(p_repository->>'auth_user', ''),
         nullif(p_repository->>'auth_pass', ''),
+        nullif(p_repository->>'ssh_key', ''),
         (p_repository->>'disabled')::boolean,
         (p_repository->>'scanner_disabled')::boolean,
+        nullif(p_repository->>'signing_policy', ''),
+        nullif(p_repository->>'sign_keyring', ''),
+        nullif(p_repository->>'sign_keyring_url', ''),
+        nullif(p_repository->>'tracking_schedule', ''),
+        (select array_agg(e) from jsonb_array_elements_text(p_repository->'labels') e),
         (p_repository->>'kind')::int,
         v_owner_user_id,
         v_owner_organization_id
@@ -0,0 +1,45 @@
+-- add_repository_publish_token adds the provided publish token to the
+-- repository given, if the requesting user is the owner or belongs to the
+-- organization which owns it.
+create or replace function add_repository_publish_token(
+    p_user_id uuid,
+    p_repository_name text,
+    p_publish_token jsonb
+)
+returns uuid as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+    v_publish_token_id uuid;
+begin
+    -- Get user or organization owning the repository
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    insert into repository_publish_token (
+        repository_id,
+        name,
+        secret
+    ) values (
+        v_repository_id,
+        p_publish_token->>'name',
+        p_publish_token->>'secret'
+    ) returning publish_token_id into v_publish_token_id;
+
+    return v_publish_token_id;
+end
+$$ language plpgsql;
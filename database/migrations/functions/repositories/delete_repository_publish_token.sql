@@ -0,0 +1,36 @@
+-- delete_repository_publish_token deletes the provided publish token from the
+-- repository given, if the requesting user is the owner or belongs to the
+-- organization which owns it.
+create or replace function delete_repository_publish_token(
+    p_user_id uuid,
+    p_repository_name text,
+    p_publish_token_id uuid
+)
+returns void as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get user or organization owning the repository
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    delete from repository_publish_token
+    where publish_token_id = p_publish_token_id
+    and repository_id = v_repository_id;
+end
+$$ language plpgsql;
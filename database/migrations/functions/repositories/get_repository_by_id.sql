@@ -13,23 +13,38 @@ begin
             'branch', r.branch,
             'auth_user', r.auth_user,
             'auth_pass', r.auth_pass,
+            'ssh_key', r.ssh_key,
             'kind', r.repository_kind_id,
             'verified_publisher', verified_publisher,
+            'domain_verified', r.domain_verified,
+            'domain_verification_token', r.domain_verification_token,
             'official', r.official,
             'disabled', r.disabled,
             'scanner_disabled', r.scanner_disabled,
+            'signing_policy', r.signing_policy,
+            'sign_keyring', r.sign_keyring,
+            'sign_keyring_url', r.sign_keyring_url,
+            'tracking_schedule', r.tracking_schedule,
+            'tracking_paused', r.tracking_paused,
+            'archived', r.archived,
+            'labels', r.labels,
+            'tracking_requested', r.tracking_requested,
             'digest', r.digest,
+            'index_etag', r.index_etag,
+            'index_last_modified', r.index_last_modified,
             'last_scanning_ts', floor(extract(epoch from last_scanning_ts)),
             'last_scanning_errors', r.last_scanning_errors,
             'last_tracking_ts', floor(extract(epoch from last_tracking_ts)),
             'last_tracking_errors', r.last_tracking_errors,
             'user_alias', u.alias,
             'organization_name', o.name,
-            'organization_display_name', o.display_name
+            'organization_display_name', o.display_name,
+            'tracker_min_interval', q.tracker_min_interval
         ))
         from repository r
         left join "user" u using (user_id)
         left join organization o using (organization_id)
+        left join organization_quota q using (organization_id)
         where repository_id = p_repository_id;
     else
         return query
@@ -41,9 +56,18 @@ begin
             'branch', r.branch,
             'kind', r.repository_kind_id,
             'verified_publisher', verified_publisher,
+            'domain_verified', r.domain_verified,
             'official', r.official,
             'disabled', r.disabled,
             'scanner_disabled', r.scanner_disabled,
+            'signing_policy', r.signing_policy,
+            'sign_keyring', r.sign_keyring,
+            'sign_keyring_url', r.sign_keyring_url,
+            'tracking_schedule', r.tracking_schedule,
+            'tracking_paused', r.tracking_paused,
+            'archived', r.archived,
+            'labels', r.labels,
+            'tracking_requested', r.tracking_requested,
             'digest', r.digest,
             'last_scanning_ts', floor(extract(epoch from last_scanning_ts)),
             'last_scanning_errors', r.last_scanning_errors,
@@ -0,0 +1,12 @@
+-- get_repository_head returns some information that allows checking if the
+-- repository identified by the name provided has changed, like its digest or
+-- when it was last tracked, as a json object.
+create or replace function get_repository_head(p_name text)
+returns setof json as $$
+    select json_build_object(
+        'digest', digest,
+        'last_modified', floor(extract(epoch from last_tracking_ts))
+    )
+    from repository
+    where name = p_name;
+$$ language sql;
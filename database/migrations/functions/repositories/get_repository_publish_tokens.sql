@@ -0,0 +1,37 @@
+-- get_repository_publish_tokens returns the publish tokens of the provided
+-- repository as a json array, if the requesting user is the owner or belongs
+-- to the organization which owns it. Tokens secrets are never returned.
+create or replace function get_repository_publish_tokens(p_user_id uuid, p_repository_name text)
+returns setof json as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get user or organization owning the repository
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_build_object(
+        'publish_token_id', publish_token_id,
+        'name', name,
+        'created_at', floor(extract(epoch from created_at))
+    ) order by created_at desc), '[]')
+    from repository_publish_token
+    where repository_id = v_repository_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,70 @@
+-- get_repository_stats returns some stats about the repository provided, like
+-- the number of packages and versions tracked, the tracking error rate and
+-- average duration in the last 30 days or the storage used by its images, as
+-- a json object. Only the owner of the repository or the members of the
+-- organization owning it are allowed to get them.
+create or replace function get_repository_stats(p_requesting_user_id uuid, p_name text)
+returns setof json as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get repository and user or organization owning it
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_name;
+    if not found then
+        return;
+    end if;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_requesting_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_requesting_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with tracking_runs as (
+        select duration_ms, errors
+        from repository_tracking_run
+        where repository_id = v_repository_id
+        and tracked_at >= current_timestamp - '30 days'::interval
+    ), images as (
+        select distinct s.logo_image_id as image_id
+        from snapshot s
+        join package p using (package_id)
+        where p.repository_id = v_repository_id
+        and s.logo_image_id is not null
+    )
+    select json_build_object(
+        'packages_count', (
+            select count(*) from package where repository_id = v_repository_id
+        ),
+        'versions_count', (
+            select count(*)
+            from snapshot s
+            join package p using (package_id)
+            where p.repository_id = v_repository_id
+        ),
+        'tracking_runs_count', (select count(*) from tracking_runs),
+        'tracking_error_rate', (
+            select round(avg(errors::int), 4) from tracking_runs
+        ),
+        'tracking_avg_duration_ms', (
+            select round(avg(duration_ms)) from tracking_runs
+        ),
+        'images_storage_bytes', (
+            select coalesce(sum(octet_length(iv.data)), 0)
+            from image_version iv
+            join images i using (image_id)
+        )
+    );
+end
+$$ language plpgsql;
@@ -8,13 +8,16 @@ returns setof json as $$
         'display_name', r.display_name,
         'url', r.url,
         'private', (
-            case when r.auth_user is not null or r.auth_pass is not null then true
+            case when r.auth_user is not null or r.auth_pass is not null or r.ssh_key is not null then true
             else false end
         ),
         'kind', r.repository_kind_id,
         'verified_publisher', verified_publisher,
+        'domain_verified', r.domain_verified,
         'official', r.official,
         'scanner_disabled', r.scanner_disabled,
+        'archived', nullif(r.archived, false),
+        'labels', r.labels,
         'user_alias', u.alias,
         'organization_name', o.name,
         'organization_display_name', o.display_name
@@ -0,0 +1,59 @@
+-- get_repository_tracking_runs returns the tracking runs of the repository
+-- provided, sorted from the most recent, as a json array. Only the owner of
+-- the repository or the members of the organization owning it are allowed to
+-- get them.
+create or replace function get_repository_tracking_runs(
+    p_requesting_user_id uuid,
+    p_name text,
+    p_limit int,
+    p_offset int
+)
+returns table(data json, total_count bigint) as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get repository and user or organization owning it
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_name;
+    if not found then
+        return;
+    end if;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_requesting_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_requesting_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select
+        coalesce(json_agg(json_strip_nulls(json_build_object(
+            'repository_tracking_run_id', rtr.repository_tracking_run_id,
+            'started_at', floor(extract(epoch from rtr.started_at)),
+            'finished_at', floor(extract(epoch from rtr.tracked_at)),
+            'duration_ms', rtr.duration_ms,
+            'packages_added', rtr.packages_added,
+            'packages_updated', rtr.packages_updated,
+            'packages_deleted', rtr.packages_deleted,
+            'errors', rtr.tracking_errors
+        )) order by rtr.tracked_at desc), '[]'),
+        (select count(*) from repository_tracking_run where repository_id = v_repository_id)
+    from (
+        select *
+        from repository_tracking_run
+        where repository_id = v_repository_id
+        order by tracked_at desc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) rtr;
+end
+$$ language plpgsql;
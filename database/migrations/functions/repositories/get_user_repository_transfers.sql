@@ -0,0 +1,31 @@
+-- get_user_repository_transfers returns the pending repository transfers
+-- targeting the provided user or any of the organizations the user belongs
+-- to as a json array.
+create or replace function get_user_repository_transfers(p_user_id uuid)
+returns setof json as $$
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'repository_name', r.name,
+        'repository_display_name', r.display_name,
+        'repository_kind_id', r.repository_kind_id,
+        'requesting_user_alias', ru.alias,
+        'user_alias', tu.alias,
+        'organization_name', o.name,
+        'organization_display_name', o.display_name,
+        'expires_at', floor(extract(epoch from t.expires_at))
+    )) order by r.name asc), '[]')
+    from repository_transfer t
+    join repository r using (repository_id)
+    join "user" ru on ru.user_id = t.requesting_user_id
+    left join "user" tu on tu.user_id = t.user_id
+    left join organization o on o.organization_id = t.organization_id
+    where t.expires_at > current_timestamp
+    and (
+        t.user_id = p_user_id
+        or t.organization_id in (
+            select organization_id
+            from user__organization
+            where user_id = p_user_id
+            and confirmed = true
+        )
+    );
+$$ language sql;
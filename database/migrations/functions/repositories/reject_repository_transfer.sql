@@ -0,0 +1,38 @@
+-- reject_repository_transfer rejects a pending transfer of the provided
+-- repository. The requesting user must be the target user or belong to the
+-- target organization.
+create or replace function reject_repository_transfer(
+    p_user_id uuid,
+    p_repository_name text
+) returns void as $$
+declare
+    v_repository_id uuid;
+    v_target_user_id uuid;
+    v_target_organization_name text;
+begin
+    -- Get pending repository transfer
+    select t.repository_id, t.user_id, o.name
+    into v_repository_id, v_target_user_id, v_target_organization_name
+    from repository_transfer t
+    join repository r using (repository_id)
+    left join organization o on o.organization_id = t.organization_id
+    where r.name = p_repository_name
+    and t.expires_at > current_timestamp;
+    if not found then
+        raise 'repository transfer not found';
+    end if;
+
+    -- Check if the user doing the request is the target user or belongs to
+    -- the target organization
+    if v_target_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_target_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_target_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Delete repository transfer
+    delete from repository_transfer where repository_id = v_repository_id;
+end
+$$ language plpgsql;
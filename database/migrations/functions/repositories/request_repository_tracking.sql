@@ -0,0 +1,24 @@
+-- request_repository_tracking requests the tracking of the provided repository.
+-- The repository digest is reset and the request is flagged, so that it is
+-- processed even if nothing has changed on it or its tracking schedule hasn't
+-- been activated yet, and the tracker is notified so that it can be processed
+-- immediately. Archived repositories are ignored.
+create or replace function request_repository_tracking(p_repository_name text)
+returns void as $$
+declare
+    v_repository_id uuid;
+begin
+    update repository set
+        digest = null,
+        index_etag = null,
+        index_last_modified = null,
+        tracking_requested = true
+    where name = p_repository_name
+    and archived = false
+    returning repository_id into v_repository_id;
+
+    if v_repository_id is not null then
+        perform pg_notify('repository_tracking_requested', v_repository_id::text);
+    end if;
+end
+$$ language plpgsql;
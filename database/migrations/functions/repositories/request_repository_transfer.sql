@@ -0,0 +1,81 @@
+-- request_repository_transfer registers a request to transfer the provided
+-- repository to the given user or organization. The user doing the request
+-- must own the repository or belong to the organization which owns it. The
+-- transfer will take place once the target user or organization accepts it,
+-- and it will expire if it has not been accepted within the ttl provided
+-- (seconds). Repositories can only have one pending transfer, so a new request
+-- replaces any previous one.
+create or replace function request_repository_transfer(
+    p_requesting_user_id uuid,
+    p_repository_name text,
+    p_user_alias text,
+    p_org_name text,
+    p_ttl int
+) returns void as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_id uuid;
+    v_owner_organization_name text;
+    v_target_user_id uuid;
+    v_target_organization_id uuid;
+begin
+    -- Get user or organization owning the repository
+    select r.repository_id, r.user_id, r.organization_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+    if not found then
+        raise 'repository not found';
+    end if;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_requesting_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_requesting_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Get target user or organization
+    if p_org_name is not null then
+        select organization_id into v_target_organization_id
+        from organization
+        where name = p_org_name;
+    else
+        select user_id into v_target_user_id
+        from "user"
+        where alias = p_user_alias;
+    end if;
+    if v_target_user_id is null and v_target_organization_id is null then
+        raise 'target not found';
+    end if;
+    if v_target_user_id = v_owner_user_id or v_target_organization_id = v_owner_organization_id then
+        raise 'repository already owned by target';
+    end if;
+
+    -- Register repository transfer
+    insert into repository_transfer (
+        repository_id,
+        requesting_user_id,
+        user_id,
+        organization_id,
+        expires_at
+    ) values (
+        v_repository_id,
+        p_requesting_user_id,
+        v_target_user_id,
+        v_target_organization_id,
+        current_timestamp + make_interval(secs => p_ttl)
+    )
+    on conflict (repository_id) do update set
+        requesting_user_id = excluded.requesting_user_id,
+        user_id = excluded.user_id,
+        organization_id = excluded.organization_id,
+        expires_at = excluded.expires_at,
+        created_at = current_timestamp;
+end
+$$ language plpgsql;
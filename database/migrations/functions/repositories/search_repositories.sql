@@ -7,6 +7,7 @@ declare
     v_kinds int[];
     v_users text[];
     v_orgs text[];
+    v_labels text[];
     v_include_credentials boolean := (p_input->>'include_credentials')::boolean;
 begin
     -- Prepare filters for later use
@@ -16,6 +17,8 @@ begin
     from jsonb_array_elements_text(p_input->'users') e;
     select array_agg(e::text) into v_orgs
     from jsonb_array_elements_text(p_input->'orgs') e;
+    select array_agg(e::text) into v_labels
+    from jsonb_array_elements_text(p_input->'labels') e;
 
     return query
     with filtered_repositories as (
@@ -28,6 +31,9 @@ begin
         and
             case when cardinality(v_kinds) > 0
             then r.repository_kind_id = any(v_kinds) else true end
+        and
+            case when cardinality(v_labels) > 0
+            then r.labels && v_labels else true end
         and
             case
                 when cardinality(v_orgs) > 0 and cardinality(v_users) > 0 then
@@ -1,8 +1,11 @@
 -- set_last_tracking_results updates the timestamp and errors of the last
--- tracking.
+-- tracking, registering the tracking run so that it is taken into account in
+-- the repository stats and tracking history. Any pending tracking request is
+-- cleared as well.
 create or replace function set_last_tracking_results(
     p_repository_id uuid,
     p_last_tracking_errors text,
+    p_tracking_run jsonb,
     p_tracking_errors_event_enabled boolean
 )
 returns void as $$
@@ -24,7 +27,32 @@ begin
     -- Update repository with last tracking results
     update repository set
 		last_tracking_ts = current_timestamp,
-		last_tracking_errors = v_last_tracking_errors
+		last_tracking_errors = v_last_tracking_errors,
+		tracking_requested = false
 	where repository_id = p_repository_id;
+
+    -- Register tracking run and clean up the ones no longer needed for stats
+    insert into repository_tracking_run (
+        repository_id,
+        started_at,
+        duration_ms,
+        packages_added,
+        packages_updated,
+        packages_deleted,
+        errors,
+        tracking_errors
+    ) values (
+        p_repository_id,
+        to_timestamp(nullif((p_tracking_run->>'started_at')::bigint, 0)),
+        nullif((p_tracking_run->>'duration_ms')::int, 0),
+        coalesce((p_tracking_run->>'packages_added')::int, 0),
+        coalesce((p_tracking_run->>'packages_updated')::int, 0),
+        coalesce((p_tracking_run->>'packages_deleted')::int, 0),
+        v_last_tracking_errors is not null,
+        v_last_tracking_errors
+    );
+    delete from repository_tracking_run
+    where repository_id = p_repository_id
+    and tracked_at < current_timestamp - '30 days'::interval;
 end
 $$ language plpgsql;
@@ -0,0 +1,17 @@
+-- set_packages_overrides sets the category and hidden flag of the packages in
+-- the repository provided using the overrides given. Packages not included in
+-- the overrides are reset to their defaults.
+create or replace function set_packages_overrides(p_repository_id uuid, p_overrides jsonb)
+returns void as $$
+    update package p set
+        category = nullif(o.value->>'category', ''),
+        hidden = coalesce((o.value->>'hidden')::boolean, false)
+    from package p2
+    left join jsonb_array_elements(p_overrides) o on o.value->>'name' = p2.name
+    where p.package_id = p2.package_id
+    and p2.repository_id = p_repository_id
+    and (
+        p.category is distinct from nullif(o.value->>'category', '')
+        or p.hidden <> coalesce((o.value->>'hidden')::boolean, false)
+    );
+$$ language sql;
@@ -0,0 +1,34 @@
+-- set_repository_archived archives or unarchives the provided repository, if
+-- the requesting user is the owner or belongs to the organization which owns
+-- it. Archived repositories are not tracked anymore, but their packages remain
+-- available.
+create or replace function set_repository_archived(
+    p_user_id uuid,
+    p_repository_name text,
+    p_archived boolean
+)
+returns void as $$
+declare
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get user or organization owning the repository
+    select r.user_id, o.name into v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    update repository set archived = p_archived
+    where name = p_repository_name;
+end
+$$ language plpgsql;
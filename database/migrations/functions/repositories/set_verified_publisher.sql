@@ -1,8 +1,13 @@
 -- set_verified_publisher updates the verified publisher flag of the provided
--- repository.
+-- repository, keeping track of when the publisher was verified.
 create or replace function set_verified_publisher(p_repository_id uuid, p_verified boolean)
 returns void as $$
     update repository set
-        verified_publisher = p_verified
+        verified_publisher = p_verified,
+        verified_publisher_at = case
+            when not p_verified then null
+            when verified_publisher then verified_publisher_at
+            else current_timestamp
+        end
     where repository_id = p_repository_id;
 $$ language sql;
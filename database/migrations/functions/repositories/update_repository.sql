@@ -7,9 +7,12 @@ declare
     v_owner_organization_name text;
     v_disabled boolean;
     v_scanner_disabled boolean;
+    v_sign_keyring text;
+    v_sign_keyring_url text;
 begin
     -- Get some information about the repository
-    select repository_id, disabled, scanner_disabled into v_repository_id, v_disabled, v_scanner_disabled
+    select repository_id, disabled, scanner_disabled, sign_keyring, sign_keyring_url
+    into v_repository_id, v_disabled, v_scanner_disabled, v_sign_keyring, v_sign_keyring_url
     from repository r
     where r.name = p_repository->>'name'
     for update;
@@ -30,15 +33,26 @@ begin
         raise insufficient_privilege;
     end if;
 
-    -- Update repository
+    -- Update repository (domain verification and the index file validators
+    -- are reset if the url changes)
     update repository set
         display_name = nullif(p_repository->>'display_name', ''),
         url = p_repository->>'url',
+        domain_verified = case when url = p_repository->>'url' then domain_verified else false end,
+        domain_verified_at = case when url = p_repository->>'url' then domain_verified_at else null end,
+        index_etag = case when url = p_repository->>'url' then index_etag else null end,
+        index_last_modified = case when url = p_repository->>'url' then index_last_modified else null end,
         branch = nullif(p_repository->>'branch', ''),
         auth_user = nullif(p_repository->>'auth_user', ''),
         auth_pass = nullif(p_repository->>'auth_pass', ''),
+        ssh_key = nullif(p_repository->>'ssh_key', ''),
         disabled = (p_repository->>'disabled')::boolean,
-        scanner_disabled = (p_repository->>'scanner_disabled')::boolean
+        scanner_disabled = (p_repository->>'scanner_disabled')::boolean,
+        signing_policy = nullif(p_repository->>'signing_policy', ''),
+        sign_keyring = nullif(p_repository->>'sign_keyring', ''),
+        sign_keyring_url = nullif(p_repository->>'sign_keyring_url', ''),
+        tracking_schedule = nullif(p_repository->>'tracking_schedule', ''),
+        labels = (select array_agg(e) from jsonb_array_elements_text(p_repository->'labels') e)
     where repository_id = v_repository_id;
 
     -- If the repository has been disabled, remove packages belonging to it and
@@ -46,7 +60,29 @@ begin
     -- nothing has changed on it
     if (p_repository->>'disabled')::boolean = true and v_disabled = false then
         delete from package where repository_id = v_repository_id;
-        update repository set digest = null where repository_id = v_repository_id;
+        update repository set
+            digest = null,
+            index_etag = null,
+            index_last_modified = null
+        where repository_id = v_repository_id;
+    end if;
+
+    -- If the sign keyring has changed, reset the repository and its packages
+    -- versions digests so that the signatures are verified again in the next
+    -- tracking run
+    if nullif(p_repository->>'sign_keyring', '') is distinct from v_sign_keyring
+    or nullif(p_repository->>'sign_keyring_url', '') is distinct from v_sign_keyring_url then
+        update repository set
+            digest = null,
+            index_etag = null,
+            index_last_modified = null
+        where repository_id = v_repository_id;
+        update snapshot set digest = null
+        where package_id in (
+            select package_id from package where repository_id = v_repository_id
+        );
+        perform refresh_package_documents(package_id)
+        from package where repository_id = v_repository_id;
     end if;
 
     -- If security scanning has been disabled, remove existing security reports
@@ -58,6 +94,8 @@ begin
         where package_id in (
             select package_id from package where repository_id = v_repository_id
         );
+        perform refresh_package_documents(package_id)
+        from package where repository_id = v_repository_id;
     end if;
 end
 $$ language plpgsql;
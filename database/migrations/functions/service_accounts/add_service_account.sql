@@ -0,0 +1,46 @@
+-- add_service_account adds the provided service account to the organization
+-- given, returning its id. Service accounts are stored as users without email
+-- nor password, so they can only authenticate using api keys.
+create or replace function add_service_account(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_service_account jsonb
+) returns uuid as $$
+declare
+    v_organization_id uuid;
+    v_service_account_id uuid;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+    select organization_id into v_organization_id
+    from organization where name = p_org_name;
+
+    -- Check the service account name is available
+    if exists (select 1 from "user" where alias = p_service_account->>'name') then
+        raise 'service account name not available';
+    end if;
+
+    -- Register service account and add it to the organization
+    insert into "user" (
+        alias,
+        service_account_organization_id
+    ) values (
+        p_service_account->>'name',
+        v_organization_id
+    ) returning user_id into v_service_account_id;
+    insert into user__organization (
+        user_id,
+        organization_id,
+        confirmed,
+        role
+    ) values (
+        v_service_account_id,
+        v_organization_id,
+        true,
+        coalesce(nullif(p_service_account->>'role', ''), 'maintainer')
+    );
+
+    return v_service_account_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,31 @@
+-- get_org_service_accounts returns the service accounts of the organization
+-- provided, including their api keys, as a json array.
+create or replace function get_org_service_accounts(p_requesting_user_id uuid, p_org_name text)
+returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_build_object(
+        'service_account_id', u.user_id,
+        'name', u.alias,
+        'role', uo.role,
+        'created_at', floor(extract(epoch from u.created_at)),
+        'api_keys', (
+            select coalesce(json_agg(json_build_object(
+                'api_key_id', ak.api_key_id,
+                'name', ak.name,
+                'created_at', floor(extract(epoch from ak.created_at))
+            ) order by ak.name asc), '[]')
+            from api_key ak
+            where ak.user_id = u.user_id
+        )
+    ) order by u.alias asc), '[]')
+    from "user" u
+    join organization o on o.organization_id = u.service_account_organization_id
+    join user__organization uo on uo.user_id = u.user_id and uo.organization_id = o.organization_id
+    where o.name = p_org_name;
+end
+$$ language plpgsql;
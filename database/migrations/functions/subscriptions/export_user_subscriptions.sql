@@ -0,0 +1,35 @@
+-- export_user_subscriptions returns the subscriptions and stars of the
+-- provided user as a portable json document. Packages are identified by their
+-- repository and package names, so that the document can be imported into
+-- other accounts.
+create or replace function export_user_subscriptions(p_user_id uuid)
+returns setof json as $$
+    select json_build_object(
+        'version', 1,
+        'subscriptions', (
+            select coalesce(json_agg(json_build_object(
+                'repository_name', r.name,
+                'package_name', p.normalized_name,
+                'event_kinds', s.event_kinds
+            ) order by r.name asc, p.normalized_name asc), '[]')
+            from (
+                select package_id, array_agg(event_kind_id order by event_kind_id) as event_kinds
+                from subscription
+                where user_id = p_user_id
+                group by package_id
+            ) s
+            join package p using (package_id)
+            join repository r using (repository_id)
+        ),
+        'stars', (
+            select coalesce(json_agg(json_build_object(
+                'repository_name', r.name,
+                'package_name', p.normalized_name
+            ) order by r.name asc, p.normalized_name asc), '[]')
+            from user_starred_package usp
+            join package p using (package_id)
+            join repository r using (repository_id)
+            where usp.user_id = p_user_id
+        )
+    );
+$$ language sql;
@@ -1,5 +1,6 @@
 -- get_package_subscriptors returns the users subscribed to the package
--- provided for the given event kind.
+-- provided for the given event kind. Service accounts are never notified, and
+-- nobody is notified about packages belonging to archived repositories.
 create or replace function get_package_subscriptors(p_package_id uuid, p_event_kind int)
 returns setof json as $$
     select coalesce(json_agg(json_build_object(
@@ -8,5 +9,13 @@ returns setof json as $$
     from subscription s
     join "user" u using (user_id)
     where s.package_id = p_package_id
-    and s.event_kind_id = p_event_kind;
+    and s.event_kind_id = p_event_kind
+    and u.service_account_organization_id is null
+    and not exists (
+        select 1
+        from package p
+        join repository r using (repository_id)
+        where p.package_id = p_package_id
+        and r.archived = true
+    );
 $$ language sql;
@@ -0,0 +1,116 @@
+-- import_user_subscriptions imports the subscriptions and stars in the
+-- document provided into the account of the given user, returning a report of
+-- the changes made. Packages the user is already subscribed to are handled as
+-- conflicts, which are resolved using the strategy requested (merge, skip or
+-- replace). When running in dry-run mode the report is generated, but no
+-- changes are applied.
+create or replace function import_user_subscriptions(p_user_id uuid, p_input jsonb)
+returns setof json as $$
+declare
+    v_on_conflict text := coalesce(nullif(p_input->>'on_conflict', ''), 'merge');
+    v_dry_run boolean := coalesce((p_input->>'dry_run')::boolean, false);
+    v_subscriptions_added int := 0;
+    v_subscriptions_removed int := 0;
+    v_stars_added int := 0;
+    v_conflicts jsonb := '[]';
+    v_not_found jsonb := '[]';
+    v_entry jsonb;
+    v_package_id uuid;
+    v_event_kinds int[];
+    v_existing_event_kinds int[];
+begin
+    -- Subscriptions
+    for v_entry in select * from jsonb_array_elements(coalesce(p_input->'document'->'subscriptions', '[]'))
+    loop
+        select p.package_id into v_package_id
+        from package p
+        join repository r using (repository_id)
+        where r.name = v_entry->>'repository_name'
+        and p.normalized_name = v_entry->>'package_name';
+        if not found then
+            v_not_found := v_not_found || jsonb_build_array(jsonb_build_object(
+                'repository_name', v_entry->>'repository_name',
+                'package_name', v_entry->>'package_name'
+            ));
+            continue;
+        end if;
+
+        select array(
+            select distinct(jsonb_array_elements_text(v_entry->'event_kinds')::int)
+        ) into v_event_kinds;
+        select array(
+            select event_kind_id from subscription
+            where user_id = p_user_id and package_id = v_package_id
+        ) into v_existing_event_kinds;
+
+        -- Handle conflicts
+        if cardinality(v_existing_event_kinds) > 0 then
+            v_conflicts := v_conflicts || jsonb_build_array(jsonb_build_object(
+                'repository_name', v_entry->>'repository_name',
+                'package_name', v_entry->>'package_name'
+            ));
+            if v_on_conflict = 'skip' then
+                continue;
+            end if;
+            if v_on_conflict = 'replace' then
+                v_subscriptions_removed := v_subscriptions_removed + cardinality(array(
+                    select unnest(v_existing_event_kinds) except select unnest(v_event_kinds)
+                ));
+                if not v_dry_run then
+                    delete from subscription
+                    where user_id = p_user_id
+                    and package_id = v_package_id
+                    and event_kind_id <> all(v_event_kinds);
+                end if;
+            end if;
+        end if;
+
+        -- Add missing subscriptions
+        v_subscriptions_added := v_subscriptions_added + cardinality(array(
+            select unnest(v_event_kinds) except select unnest(v_existing_event_kinds)
+        ));
+        if not v_dry_run then
+            insert into subscription (user_id, package_id, event_kind_id)
+            select p_user_id, v_package_id, event_kind_id
+            from unnest(v_event_kinds) as event_kind_id
+            on conflict do nothing;
+        end if;
+    end loop;
+
+    -- Stars
+    for v_entry in select * from jsonb_array_elements(coalesce(p_input->'document'->'stars', '[]'))
+    loop
+        select p.package_id into v_package_id
+        from package p
+        join repository r using (repository_id)
+        where r.name = v_entry->>'repository_name'
+        and p.normalized_name = v_entry->>'package_name';
+        if not found then
+            v_not_found := v_not_found || jsonb_build_array(jsonb_build_object(
+                'repository_name', v_entry->>'repository_name',
+                'package_name', v_entry->>'package_name'
+            ));
+            continue;
+        end if;
+
+        if not exists (
+            select * from user_starred_package
+            where user_id = p_user_id and package_id = v_package_id
+        ) then
+            v_stars_added := v_stars_added + 1;
+            if not v_dry_run then
+                perform toggle_star(p_user_id, v_package_id);
+            end if;
+        end if;
+    end loop;
+
+    return query select json_build_object(
+        'dry_run', v_dry_run,
+        'subscriptions_added', v_subscriptions_added,
+        'subscriptions_removed', v_subscriptions_removed,
+        'stars_added', v_stars_added,
+        'conflicts', v_conflicts,
+        'not_found', v_not_found
+    );
+end
+$$ language plpgsql;
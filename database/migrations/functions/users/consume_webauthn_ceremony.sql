@@ -0,0 +1,19 @@
+-- consume_webauthn_ceremony returns the session data of the WebAuthn ceremony
+-- provided. The ceremony must exist and not have expired. Ceremonies can only
+-- be consumed once.
+create or replace function consume_webauthn_ceremony(p_ceremony_id text)
+returns jsonb as $$
+declare
+    v_session_data jsonb;
+begin
+    delete from webauthn_ceremony
+    where webauthn_ceremony_id = p_ceremony_id
+    and created_at + '5 minute'::interval > current_timestamp
+    returning session_data into v_session_data;
+    if not found then
+        raise 'invalid webauthn ceremony';
+    end if;
+
+    return v_session_data;
+end
+$$ language plpgsql;
@@ -8,7 +8,9 @@ returns setof json as $$
         'email', u.email,
         'profile_image_id', u.profile_image_id,
         'password_set', (select u.password is not null),
-        'tfa_enabled', u.tfa_enabled
+        'tfa_enabled', u.tfa_enabled,
+        'site_admin', u.site_admin,
+        'moderator', u.moderator
     ))
     from "user" u
     where u.user_id = p_user_id;
@@ -0,0 +1,12 @@
+-- get_user_site_role returns the role the provided user has in the site, if
+-- any. Site admins are reported as such even when they are moderators too.
+create or replace function get_user_site_role(p_user_id uuid)
+returns text as $$
+    select
+        case
+            when site_admin = true then 'admin'
+            when moderator = true then 'moderator'
+        end
+    from "user"
+    where user_id = p_user_id;
+$$ language sql;
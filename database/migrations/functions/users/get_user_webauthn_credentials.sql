@@ -0,0 +1,13 @@
+-- get_user_webauthn_credentials returns the WebAuthn credentials registered by
+-- the provided user as a json array.
+create or replace function get_user_webauthn_credentials(p_user_id uuid)
+returns setof json as $$
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'webauthn_credential_id', wc.webauthn_credential_id,
+        'name', wc.name,
+        'created_at', floor(extract(epoch from wc.created_at)),
+        'last_used_at', floor(extract(epoch from wc.last_used_at))
+    )) order by wc.created_at asc), '[]')
+    from webauthn_credential wc
+    where wc.user_id = p_user_id;
+$$ language sql;
@@ -0,0 +1,23 @@
+-- get_user_webauthn_info returns the information needed to perform WebAuthn
+-- registration and login ceremonies for the provided user.
+create or replace function get_user_webauthn_info(p_user_id uuid)
+returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'user_id', u.user_id,
+        'alias', u.alias,
+        'email', u.email,
+        'credentials', (
+            select coalesce(json_agg(json_strip_nulls(json_build_object(
+                'id', encode(wc.credential_id, 'base64'),
+                'public_key', encode(wc.public_key, 'base64'),
+                'attestation_type', wc.attestation_type,
+                'aaguid', encode(wc.aaguid, 'base64'),
+                'sign_count', wc.sign_count
+            )) order by wc.created_at asc), '[]')
+            from webauthn_credential wc
+            where wc.user_id = u.user_id
+        )
+    ))
+    from "user" u
+    where u.user_id = p_user_id;
+$$ language sql;
@@ -0,0 +1,29 @@
+-- register_login_code registers a login code for the user identified by the
+-- email provided. The code can only be used from the device that requested it.
+-- Users can have up to 3 active login codes at the same time.
+create or replace function register_login_code(p_email text, p_code text, p_device_id text)
+returns void as $$
+declare
+    v_user_id uuid;
+begin
+    select user_id into v_user_id
+    from "user"
+    where email = p_email and email_verified = true;
+    if not found then
+        raise 'invalid email';
+    end if;
+
+    -- Delete user's expired login codes
+    delete from login_code
+    where user_id = v_user_id
+    and created_at + '15 minute'::interval <= current_timestamp;
+
+    -- Limit the number of active login codes per user
+    if (select count(*) from login_code where user_id = v_user_id) >= 3 then
+        raise 'too many login codes requested';
+    end if;
+
+    insert into login_code (login_code_id, user_id, device_id)
+    values (p_code, v_user_id, p_device_id);
+end
+$$ language plpgsql;
@@ -5,13 +5,26 @@ declare
     v_approved boolean;
 begin
     -- Check if the session requires approval or not. When the user has enabled
-    -- TFA, the session will be created as non-approved as it requires user's
-    -- approval by providing a TFA passcode.
-    select
-        case when (tfa_enabled is null or tfa_enabled = false) then true else false end
-        into v_approved
-    from "user"
-    where user_id = (p_session->>'user_id')::uuid;
+    -- TFA or has registered any WebAuthn credential, the session will be
+    -- created as non-approved as it requires user's approval by providing a
+    -- TFA passcode or using a security key. Sessions already approved (i.e.
+    -- when the user logged in using a security key) don't require approval.
+    if (p_session->>'approved')::boolean is true then
+        v_approved = true;
+    else
+        select
+            case when (tfa_enabled is null or tfa_enabled = false) then true else false end
+            into v_approved
+        from "user"
+        where user_id = (p_session->>'user_id')::uuid;
+
+        if v_approved and exists (
+            select 1 from webauthn_credential
+            where user_id = (p_session->>'user_id')::uuid
+        ) then
+            v_approved = false;
+        end if;
+    end if;
 
     -- Register session
     insert into session (
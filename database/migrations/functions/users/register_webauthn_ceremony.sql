@@ -0,0 +1,11 @@
+-- register_webauthn_ceremony registers the session data of a WebAuthn ceremony
+-- that has just begun, so that it can be verified when it is finished.
+create or replace function register_webauthn_ceremony(p_ceremony_id text, p_session_data jsonb)
+returns void as $$
+    -- Delete expired ceremonies
+    delete from webauthn_ceremony
+    where created_at + '5 minute'::interval <= current_timestamp;
+
+    insert into webauthn_ceremony (webauthn_ceremony_id, session_data)
+    values (p_ceremony_id, p_session_data);
+$$ language sql;
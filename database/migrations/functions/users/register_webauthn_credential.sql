@@ -0,0 +1,22 @@
+-- register_webauthn_credential registers the provided WebAuthn credential for
+-- the given user.
+create or replace function register_webauthn_credential(p_user_id uuid, p_credential jsonb)
+returns void as $$
+    insert into webauthn_credential (
+        user_id,
+        name,
+        credential_id,
+        public_key,
+        attestation_type,
+        aaguid,
+        sign_count
+    ) values (
+        p_user_id,
+        p_credential->>'name',
+        decode(p_credential->>'id', 'base64'),
+        decode(p_credential->>'public_key', 'base64'),
+        nullif(p_credential->>'attestation_type', ''),
+        decode(nullif(p_credential->>'aaguid', ''), 'base64'),
+        coalesce((p_credential->>'sign_count')::bigint, 0)
+    );
+$$ language sql;
@@ -0,0 +1,73 @@
+-- sync_user_idp_groups synchronizes the organizations the user provided is a
+-- member of with the groups the user belongs to in the identity provider.
+-- The user joins the organizations mapped to any of the groups provided with
+-- the highest role available among them, and leaves the ones no longer backed
+-- by any group. Only memberships managed by the identity provider are updated
+-- or removed, so memberships added manually are never modified. The last
+-- member of an organization is never removed and its last owner is never
+-- removed nor downgraded, so that organizations are not left orphaned.
+create or replace function sync_user_idp_groups(p_user_id uuid, p_groups text[])
+returns void as $$
+    -- Remove memberships no longer backed by any of the user's groups
+    delete from user__organization uo
+    where uo.user_id = p_user_id
+    and uo.idp_managed = true
+    and not exists (
+        select 1
+        from organization_idp_group g
+        where g.organization_id = uo.organization_id
+        and g.idp_group = any(p_groups)
+    )
+    -- Last member of an organization cannot leave it
+    and exists (
+        select 1
+        from user__organization m
+        where m.organization_id = uo.organization_id
+        and m.user_id <> p_user_id
+    )
+    -- Last owner of an organization cannot leave it
+    and (
+        uo.role <> 'owner'
+        or exists (
+            select 1
+            from user__organization m
+            where m.organization_id = uo.organization_id
+            and m.user_id <> p_user_id
+            and m.role = 'owner'
+        )
+    );
+
+    -- Add or update memberships backed by the user's groups. Pending
+    -- invitations are converted into memberships managed by the IdP.
+    insert into user__organization (user_id, organization_id, confirmed, role, idp_managed)
+    select distinct on (g.organization_id)
+        p_user_id,
+        g.organization_id,
+        true,
+        g.role,
+        true
+    from organization_idp_group g
+    where g.idp_group = any(p_groups)
+    order by g.organization_id, array_position(array['owner', 'admin', 'maintainer', 'viewer'], g.role)
+    on conflict (user_id, organization_id) do update set
+        confirmed = true,
+        role = excluded.role,
+        invitation_expires_at = null,
+        idp_managed = true
+    where (
+        user__organization.idp_managed = true
+        or user__organization.confirmed = false
+    )
+    -- Last owner of an organization cannot be demoted
+    and (
+        user__organization.role <> 'owner'
+        or excluded.role = 'owner'
+        or exists (
+            select 1
+            from user__organization m
+            where m.organization_id = user__organization.organization_id
+            and m.user_id <> p_user_id
+            and m.role = 'owner'
+        )
+    );
+$$ language sql;
@@ -0,0 +1,20 @@
+-- verify_login_code verifies if the login code provided is valid for the given
+-- device, returning the id of the user it belongs to. The code must exist and
+-- not have expired. Login codes can only be used once.
+create or replace function verify_login_code(p_code text, p_device_id text)
+returns uuid as $$
+declare
+    v_user_id uuid;
+begin
+    delete from login_code
+    where login_code_id = p_code
+    and device_id = p_device_id
+    and created_at + '15 minute'::interval > current_timestamp
+    returning user_id into v_user_id;
+    if not found then
+        raise 'invalid login code';
+    end if;
+
+    return v_user_id;
+end
+$$ language plpgsql;
@@ -10,6 +10,7 @@ declare
     v_webhook_id uuid;
     v_event_kind integer;
     v_package jsonb;
+    v_repository jsonb;
 begin
     if p_org_name <> '' then
         if not user_belongs_to_organization(p_user_id, p_org_name) then
@@ -24,21 +25,25 @@ begin
     insert into webhook (
         name,
         description,
+        kind,
         url,
         secret,
         content_type,
         template,
         active,
+        org_default,
         user_id,
         organization_id
     ) values (
         p_webhook->>'name',
         nullif(p_webhook->>'description', ''),
+        nullif(p_webhook->>'kind', ''),
         p_webhook->>'url',
         nullif(p_webhook->>'secret', ''),
         nullif(p_webhook->>'content_type', ''),
         nullif(p_webhook->>'template', ''),
         (p_webhook->>'active')::boolean,
+        coalesce((p_webhook->>'org_default')::boolean, false),
         v_owner_user_id,
         v_owner_organization_id
     )
@@ -57,5 +62,19 @@ begin
         insert into webhook__package (webhook_id, package_id)
         values (v_webhook_id, (v_package->>'package_id')::uuid);
     end loop;
+
+    -- Repositories overrides (only repositories owned by the webhook owner)
+    for v_repository in select * from jsonb_array_elements(nullif(p_webhook->'repositories', 'null'::jsonb))
+    loop
+        if not exists (
+            select 1 from repository
+            where repository_id = (v_repository->>'repository_id')::uuid
+            and (user_id = v_owner_user_id or organization_id = v_owner_organization_id)
+        ) then
+            raise insufficient_privilege;
+        end if;
+        insert into webhook__repository (webhook_id, repository_id, enabled)
+        values (v_webhook_id, (v_repository->>'repository_id')::uuid, (v_repository->>'enabled')::boolean);
+    end loop;
 end
 $$ language plpgsql;
@@ -11,11 +11,13 @@ begin
         'webhook_id', wh.webhook_id,
         'name', wh.name,
         'description', wh.description,
+        'kind', wh.kind,
         'url', wh.url,
         'secret', wh.secret,
         'content_type', wh.content_type,
         'template', wh.template,
         'active', wh.active,
+        'org_default', wh.org_default,
         'event_kinds', (
             select json_agg(event_kind_id)
             from webhook__event_kind wek
@@ -32,6 +34,16 @@ begin
             ) wp
             cross join get_package_summary(jsonb_build_object('package_id', wp.package_id)) as pkgJSON
         ),
+        'repositories', (
+            select json_agg(json_build_object(
+                'repository_id', r.repository_id,
+                'name', r.name,
+                'enabled', wr.enabled
+            ) order by r.name asc)
+            from webhook__repository wr
+            join repository r using (repository_id)
+            where wr.webhook_id = wh.webhook_id
+        ),
         'last_notifications', (
             select json_agg(json_build_object(
                 'notification_id', notification_id,
@@ -0,0 +1,29 @@
+-- get_webhook_template_errors returns the most recent errors rendering the
+-- payload template of the webhook provided as a json array.
+create or replace function get_webhook_template_errors(p_user_id uuid, p_webhook_id uuid)
+returns setof json as $$
+begin
+    if p_user_id is not null and not user_has_access_to_webhook(p_user_id, p_webhook_id) then
+        raise insufficient_privilege;
+    end if;
+
+    return query select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'event_id', e.event_id,
+        'event_kind', e.event_kind_id,
+        'package_id', e.package_id,
+        'package_version', e.package_version,
+        'error', wte.error,
+        'line', wte.line,
+        'column', wte.col,
+        'created_at', floor(extract(epoch from wte.created_at))
+    )) order by wte.created_at desc), '[]')
+    from (
+        select *
+        from webhook_template_error
+        where webhook_id = p_webhook_id
+        order by created_at desc
+        limit 20
+    ) wte
+    join event e using (event_id);
+end
+$$ language plpgsql;
@@ -1,13 +1,42 @@
 -- get_webhooks_subscribed_to_package returns the webhooks subscribed to the
--- event kind and package provided.
+-- event kind and package provided. Webhooks can be subscribed to the package
+-- directly, to the package's repository or, when they are organization
+-- defaults, to all the repositories of the organization that have not opted
+-- out of them.
 create or replace function get_webhooks_subscribed_to_package(p_event_kind_id integer, p_package_id uuid)
 returns setof json as $$
     select coalesce(json_agg(wh), '[]')
-    from webhook
-    join webhook__event_kind wek using (webhook_id)
-    join webhook__package wp using (webhook_id)
-    cross join get_webhook(null::uuid, webhook_id) as wh
-    where wek.event_kind_id = p_event_kind_id
-    and wp.package_id = p_package_id
-    and active = true;
+    from (
+        select w.webhook_id
+        from webhook w
+        join webhook__event_kind wek using (webhook_id)
+        join package p on p.package_id = p_package_id
+        join repository r using (repository_id)
+        where wek.event_kind_id = p_event_kind_id
+        and w.active = true
+        and (
+            exists (
+                select 1 from webhook__package wp
+                where wp.webhook_id = w.webhook_id
+                and wp.package_id = p_package_id
+            )
+            or exists (
+                select 1 from webhook__repository wr
+                where wr.webhook_id = w.webhook_id
+                and wr.repository_id = r.repository_id
+                and wr.enabled = true
+            )
+            or (
+                w.org_default = true
+                and w.organization_id = r.organization_id
+                and not exists (
+                    select 1 from webhook__repository wr
+                    where wr.webhook_id = w.webhook_id
+                    and wr.repository_id = r.repository_id
+                    and wr.enabled = false
+                )
+            )
+        )
+    ) sw
+    cross join get_webhook(null::uuid, sw.webhook_id) as wh;
 $$ language sql;
@@ -0,0 +1,23 @@
+-- register_webhook_template_error registers the provided error rendering the
+-- payload template of a webhook. Only the most recent errors are kept.
+create or replace function register_webhook_template_error(p_error jsonb)
+returns void as $$
+    insert into webhook_template_error (webhook_id, event_id, error, line, col)
+    values (
+        (p_error->>'webhook_id')::uuid,
+        (p_error->>'event_id')::uuid,
+        p_error->>'error',
+        nullif((p_error->>'line')::int, 0),
+        nullif((p_error->>'column')::int, 0)
+    );
+
+    delete from webhook_template_error
+    where webhook_id = (p_error->>'webhook_id')::uuid
+    and webhook_template_error_id not in (
+        select webhook_template_error_id
+        from webhook_template_error
+        where webhook_id = (p_error->>'webhook_id')::uuid
+        order by created_at desc
+        limit 50
+    );
+$$ language sql;
@@ -4,23 +4,34 @@ returns void as $$
 declare
     v_webhook_id uuid := (p_webhook->>'webhook_id')::uuid;
     v_owner_user_id uuid;
-    v_owner_organization_name text;
+    v_owner_organization_id uuid;
     v_event_kind integer;
     v_package jsonb;
+    v_repository jsonb;
 begin
     if not user_has_access_to_webhook(p_user_id, v_webhook_id) then
         raise insufficient_privilege;
     end if;
+    if (p_webhook->>'org_default')::boolean = true and exists (
+        select 1 from webhook where webhook_id = v_webhook_id and organization_id is null
+    ) then
+        raise 'only organization webhooks can be defaults';
+    end if;
+    select user_id, organization_id into v_owner_user_id, v_owner_organization_id
+    from webhook
+    where webhook_id = v_webhook_id;
 
     -- Webhook
     update webhook set
         name = p_webhook->>'name',
         description = nullif(p_webhook->>'description', ''),
+        kind = nullif(p_webhook->>'kind', ''),
         url = p_webhook->>'url',
         secret = nullif(p_webhook->>'secret', ''),
         content_type = nullif(p_webhook->>'content_type', ''),
         template = nullif(p_webhook->>'template', ''),
-        active = (p_webhook->>'active')::boolean
+        active = (p_webhook->>'active')::boolean,
+        org_default = coalesce((p_webhook->>'org_default')::boolean, false)
     where webhook_id = v_webhook_id;
 
     -- Bind webhook with event kinds if needed
@@ -54,5 +65,20 @@ begin
         select (value->>'package_id')::uuid
         from jsonb_array_elements(nullif(p_webhook->'packages', 'null'::jsonb))
     );
+
+    -- Replace repositories overrides (only repositories owned by the webhook owner)
+    delete from webhook__repository where webhook_id = v_webhook_id;
+    for v_repository in select * from jsonb_array_elements(nullif(p_webhook->'repositories', 'null'::jsonb))
+    loop
+        if not exists (
+            select 1 from repository
+            where repository_id = (v_repository->>'repository_id')::uuid
+            and (user_id = v_owner_user_id or organization_id = v_owner_organization_id)
+        ) then
+            raise insufficient_privilege;
+        end if;
+        insert into webhook__repository (webhook_id, repository_id, enabled)
+        values (v_webhook_id, (v_repository->>'repository_id')::uuid, (v_repository->>'enabled')::boolean);
+    end loop;
 end
 $$ language plpgsql;
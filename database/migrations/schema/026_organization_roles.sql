@@ -0,0 +1,7 @@
+alter table user__organization add column role text not null default 'maintainer'
+    check (role in ('owner', 'admin', 'maintainer', 'viewer'));
+update user__organization set role = 'owner';
+
+---- create above / drop below ----
+
+alter table user__organization drop column role;
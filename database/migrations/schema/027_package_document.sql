@@ -0,0 +1,15 @@
+create table if not exists package_document (
+    package_id uuid not null,
+    version text not null,
+    document jsonb not null,
+    updated_at timestamptz default current_timestamp not null,
+    primary key (package_id, version),
+    foreign key (package_id, version) references snapshot on delete cascade
+);
+
+alter table package add column document jsonb;
+
+---- create above / drop below ----
+
+alter table package drop column if exists document;
+drop table if exists package_document;
@@ -0,0 +1,52 @@
+create table if not exists image_source (
+    url text primary key,
+    original_hash bytea not null,
+    image_id uuid not null references image on delete cascade,
+    last_checked_at timestamptz default current_timestamp not null
+);
+
+-- Deduplicate images whose versions contain exactly the same data, pointing
+-- all references to the duplicates to a single image
+create temporary table image_duplicate on commit drop as
+with image_fingerprint as (
+    select
+        image_id,
+        digest(
+            string_agg(version || ':' || encode(digest(data, 'sha256'), 'hex'), ',' order by version),
+            'sha256'
+        ) as fingerprint
+    from image_version
+    group by image_id
+)
+select image_id, canonical_image_id
+from (
+    select
+        image_id,
+        first_value(image_id) over (partition by fingerprint order by image_id) as canonical_image_id
+    from image_fingerprint
+) f
+where image_id <> canonical_image_id;
+
+update snapshot s set logo_image_id = d.canonical_image_id
+from image_duplicate d where s.logo_image_id = d.image_id;
+update organization o set logo_image_id = d.canonical_image_id
+from image_duplicate d where o.logo_image_id = d.image_id;
+update "user" u set profile_image_id = d.canonical_image_id
+from image_duplicate d where u.profile_image_id = d.image_id;
+update package_document pd set document = jsonb_set(document, '{logo_image_id}', to_jsonb(d.canonical_image_id::text))
+from image_duplicate d where pd.document->>'logo_image_id' = d.image_id::text;
+delete from image where image_id in (select image_id from image_duplicate);
+
+-- Backfill image sources from the logos already registered. They are marked as
+-- not checked recently, so they'll be checked again the next time they are
+-- processed, but they won't be stored again if their content hasn't changed.
+insert into image_source (url, original_hash, image_id, last_checked_at)
+select distinct on (s.logo_url) s.logo_url, i.original_hash, i.image_id, to_timestamp(0)
+from snapshot s
+join image i on s.logo_image_id = i.image_id
+where s.logo_url not like 'data:%'
+order by s.logo_url, s.ts desc;
+
+---- create above / drop below ----
+
+drop table if exists image_source;
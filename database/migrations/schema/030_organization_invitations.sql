@@ -0,0 +1,7 @@
+alter table user__organization add column invitation_expires_at timestamptz;
+
+drop function if exists add_organization_member(uuid, text, text);
+
+---- create above / drop below ----
+
+alter table user__organization drop column invitation_expires_at;
@@ -0,0 +1,11 @@
+create table if not exists login_code (
+    login_code_id text primary key,
+    user_id uuid not null references "user" on delete cascade,
+    device_id text not null,
+    created_at timestamptz default current_timestamp not null
+);
+create index login_code_user_id_idx on login_code (user_id);
+
+---- create above / drop below ----
+
+drop table if exists login_code;
@@ -0,0 +1,15 @@
+create table if not exists repository_transfer (
+    repository_id uuid primary key references repository on delete cascade,
+    requesting_user_id uuid not null references "user" on delete cascade,
+    user_id uuid references "user" on delete cascade,
+    organization_id uuid references organization on delete cascade,
+    expires_at timestamptz not null,
+    created_at timestamptz default current_timestamp not null,
+    check ((user_id is null) <> (organization_id is null))
+);
+create index repository_transfer_user_id_idx on repository_transfer (user_id);
+create index repository_transfer_organization_id_idx on repository_transfer (organization_id);
+
+---- create above / drop below ----
+
+drop table if exists repository_transfer;
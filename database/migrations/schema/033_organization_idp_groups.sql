@@ -0,0 +1,14 @@
+create table if not exists organization_idp_group (
+    organization_id uuid not null references organization on delete cascade,
+    idp_group text not null check (idp_group <> ''),
+    role text not null default 'maintainer' check (role in ('owner', 'admin', 'maintainer', 'viewer')),
+    primary key (organization_id, idp_group)
+);
+create index organization_idp_group_idp_group_idx on organization_idp_group (idp_group);
+
+alter table user__organization add column idp_managed boolean not null default false;
+
+---- create above / drop below ----
+
+alter table user__organization drop column idp_managed;
+drop table if exists organization_idp_group;
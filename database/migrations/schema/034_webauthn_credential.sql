@@ -0,0 +1,25 @@
+create table if not exists webauthn_credential (
+    webauthn_credential_id uuid primary key default gen_random_uuid(),
+    user_id uuid not null references "user" on delete cascade,
+    name text not null check (name <> ''),
+    credential_id bytea not null unique,
+    public_key bytea not null,
+    attestation_type text,
+    aaguid bytea,
+    sign_count bigint not null default 0,
+    created_at timestamptz default current_timestamp not null,
+    last_used_at timestamptz
+);
+create index webauthn_credential_user_id_idx on webauthn_credential (user_id);
+
+create table if not exists webauthn_ceremony (
+    webauthn_ceremony_id text primary key,
+    session_data jsonb not null,
+    created_at timestamptz default current_timestamp not null
+);
+create index webauthn_ceremony_created_at_idx on webauthn_ceremony (created_at);
+
+---- create above / drop below ----
+
+drop table if exists webauthn_ceremony;
+drop table if exists webauthn_credential;
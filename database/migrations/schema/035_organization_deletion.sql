@@ -0,0 +1,15 @@
+create table if not exists audit_log (
+    audit_log_id uuid primary key default gen_random_uuid(),
+    user_id uuid references "user" on delete set null,
+    action text not null check (action <> ''),
+    details jsonb,
+    created_at timestamptz default current_timestamp not null
+);
+create index audit_log_user_id_idx on audit_log (user_id);
+
+drop function if exists delete_organization(uuid, text);
+
+---- create above / drop below ----
+
+drop function if exists delete_organization(uuid, text, jsonb);
+drop table if exists audit_log;
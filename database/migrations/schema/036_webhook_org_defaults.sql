@@ -0,0 +1,17 @@
+alter table webhook add column org_default boolean not null default false;
+alter table webhook add constraint webhook_org_default_check
+    check (org_default = false or organization_id is not null);
+
+create table if not exists webhook__repository (
+    webhook_id uuid not null references webhook on delete cascade,
+    repository_id uuid not null references repository on delete cascade,
+    enabled boolean not null,
+    primary key (webhook_id, repository_id)
+);
+create index webhook__repository_repository_id_idx on webhook__repository (repository_id);
+
+---- create above / drop below ----
+
+drop table if exists webhook__repository;
+alter table webhook drop constraint if exists webhook_org_default_check;
+alter table webhook drop column if exists org_default;
@@ -0,0 +1,17 @@
+alter table organization add column profile text check (profile <> '');
+alter table organization add column profile_html text check (profile_html <> '');
+
+create table if not exists organization_link (
+    organization_id uuid not null references organization on delete cascade,
+    url text not null check (url <> ''),
+    verification_token text not null default encode(gen_random_bytes(16), 'hex'),
+    verified boolean not null default false,
+    verified_at timestamptz,
+    primary key (organization_id, url)
+);
+
+---- create above / drop below ----
+
+drop table if exists organization_link;
+alter table organization drop column if exists profile_html;
+alter table organization drop column if exists profile;
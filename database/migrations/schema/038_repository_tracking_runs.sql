@@ -0,0 +1,15 @@
+create table if not exists repository_tracking_run (
+    repository_tracking_run_id uuid primary key default gen_random_uuid(),
+    repository_id uuid not null references repository on delete cascade,
+    tracked_at timestamptz default current_timestamp not null,
+    duration_ms int check (duration_ms >= 0),
+    errors boolean not null
+);
+create index repository_tracking_run_repository_id_tracked_at_idx on repository_tracking_run (repository_id, tracked_at);
+
+drop function if exists set_last_tracking_results(uuid, text, boolean);
+
+---- create above / drop below ----
+
+drop function if exists set_last_tracking_results(uuid, text, int, boolean);
+drop table if exists repository_tracking_run;
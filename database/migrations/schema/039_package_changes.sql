@@ -0,0 +1,118 @@
+create table if not exists package_change (
+    package_change_id bigserial primary key,
+    package_id uuid not null,
+    package_name text not null check (package_name <> ''),
+    repository_id uuid not null,
+    repository_name text check (repository_name <> ''),
+    repository_kind_id integer,
+    change_kind text not null check (change_kind in ('added', 'updated', 'removed')),
+    created_at timestamptz default current_timestamp not null
+);
+create index package_change_created_at_idx on package_change (created_at);
+create index package_change_package_id_idx on package_change (package_id);
+
+-- register_package_change registers a change of the kind provided for the
+-- package provided. Packages removed may belong to a repository that has
+-- already been deleted, so the repository details of the last change
+-- registered for the package are used in that case.
+create or replace function register_package_change(p_package_id uuid, p_change_kind text)
+returns void as $$
+    insert into package_change (
+        package_id,
+        package_name,
+        repository_id,
+        repository_name,
+        repository_kind_id,
+        change_kind
+    )
+    select
+        p_package_id,
+        coalesce(p.name, c.package_name),
+        coalesce(p.repository_id, c.repository_id),
+        coalesce(r.name, c.repository_name),
+        coalesce(r.repository_kind_id, c.repository_kind_id),
+        p_change_kind
+    from (select p_package_id as package_id) i
+    left join package p using (package_id)
+    left join repository r using (repository_id)
+    left join lateral (
+        select *
+        from package_change
+        where package_id = p_package_id
+        order by package_change_id desc
+        limit 1
+    ) c on true
+    where coalesce(p.name, c.package_name) is not null;
+$$ language sql;
+
+create or replace function track_package_changes()
+returns trigger as $$
+begin
+    if tg_op = 'INSERT' then
+        perform register_package_change(new.package_id, 'added');
+    elsif tg_op = 'DELETE' then
+        perform register_package_change(old.package_id, 'removed');
+    end if;
+    return null;
+end
+$$ language plpgsql;
+
+create or replace function track_snapshot_changes()
+returns trigger as $$
+declare
+    v_package_id uuid := coalesce(new.package_id, old.package_id);
+begin
+    -- Snapshots deleted along with their package are tracked as a package
+    -- removal, so they are ignored here
+    if not exists (select 1 from package where package_id = v_package_id) then
+        return null;
+    end if;
+
+    -- Only one change per package is registered in a given transaction
+    -- (current_timestamp is the transaction start time)
+    if exists (
+        select 1 from package_change
+        where package_id = v_package_id
+        and created_at = current_timestamp
+    ) then
+        return null;
+    end if;
+
+    perform register_package_change(v_package_id, 'updated');
+    return null;
+end
+$$ language plpgsql;
+
+create trigger trigger_package_changes
+after insert or delete on package
+for each row
+execute procedure track_package_changes();
+
+create trigger trigger_snapshot_changes
+after insert or delete on snapshot
+for each row
+execute procedure track_snapshot_changes();
+
+-- Register the packages already available, so that the changes feed can be
+-- used to get the full catalog
+insert into package_change (
+    package_id,
+    package_name,
+    repository_id,
+    repository_name,
+    repository_kind_id,
+    change_kind
+)
+select p.package_id, p.name, r.repository_id, r.name, r.repository_kind_id, 'added'
+from package p
+join repository r using (repository_id)
+order by p.package_id;
+
+---- create above / drop below ----
+
+drop trigger if exists trigger_snapshot_changes on snapshot;
+drop trigger if exists trigger_package_changes on package;
+drop function if exists track_snapshot_changes;
+drop function if exists track_package_changes;
+drop function if exists register_package_change;
+drop table if exists package_change;
@@ -0,0 +1,11 @@
+create table if not exists organization_quota (
+    organization_id uuid primary key references organization on delete cascade,
+    max_repositories integer check (max_repositories > 0),
+    max_webhooks integer check (max_webhooks > 0),
+    max_api_keys integer check (max_api_keys > 0),
+    tracker_min_interval integer check (tracker_min_interval > 0)
+);
+
+---- create above / drop below ----
+
+drop table if exists organization_quota;
@@ -0,0 +1,10 @@
+insert into event_kind values (5, 'Package deprecated');
+create index event_package_id_idx on event (package_id);
+create index event_repository_id_idx on event (repository_id);
+
+---- create above / drop below ----
+
+drop index if exists event_package_id_idx;
+drop index if exists event_repository_id_idx;
+delete from event where event_kind_id = 5;
+delete from event_kind where event_kind_id = 5;
@@ -0,0 +1,5 @@
+alter table repository add column ssh_key text check (ssh_key <> '');
+
+---- create above / drop below ----
+
+alter table repository drop column ssh_key;
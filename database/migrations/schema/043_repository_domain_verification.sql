@@ -0,0 +1,9 @@
+alter table repository add column domain_verification_token text not null default encode(gen_random_bytes(16), 'hex');
+alter table repository add column domain_verified boolean not null default false;
+alter table repository add column domain_verified_at timestamptz;
+
+---- create above / drop below ----
+
+alter table repository drop column domain_verified_at;
+alter table repository drop column domain_verified;
+alter table repository drop column domain_verification_token;
@@ -0,0 +1,13 @@
+alter table "user" alter column email drop not null;
+alter table "user" add column service_account_organization_id uuid references organization on delete cascade;
+alter table "user" add constraint user_service_account_email_check
+    check (email is not null or service_account_organization_id is not null);
+create index user_service_account_organization_id_idx on "user" (service_account_organization_id);
+
+---- create above / drop below ----
+
+delete from "user" where service_account_organization_id is not null;
+drop index if exists user_service_account_organization_id_idx;
+alter table "user" drop constraint user_service_account_email_check;
+alter table "user" drop column service_account_organization_id;
+alter table "user" alter column email set not null;
@@ -0,0 +1,12 @@
+create table if not exists repository_publish_token (
+    publish_token_id uuid primary key default gen_random_uuid(),
+    repository_id uuid not null references repository on delete cascade,
+    name text not null check (name <> ''),
+    secret text not null check (secret <> ''),
+    created_at timestamptz default current_timestamp not null
+);
+create index repository_publish_token_repository_id_idx on repository_publish_token (repository_id);
+
+---- create above / drop below ----
+
+drop table if exists repository_publish_token;
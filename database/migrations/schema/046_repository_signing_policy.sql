@@ -0,0 +1,5 @@
+alter table repository add column signing_policy text check (signing_policy in ('flag', 'reject'));
+
+---- create above / drop below ----
+
+alter table repository drop column if exists signing_policy;
@@ -0,0 +1,5 @@
+alter table repository add column tracking_schedule text check (tracking_schedule <> '');
+
+---- create above / drop below ----
+
+alter table repository drop column if exists tracking_schedule;
@@ -0,0 +1,9 @@
+alter table package add column last_release_ts timestamptz;
+alter table package add column stale boolean not null default false;
+alter table repository_kind add column median_release_cadence interval;
+
+---- create above / drop below ----
+
+alter table package drop column if exists last_release_ts;
+alter table package drop column if exists stale;
+alter table repository_kind drop column if exists median_release_cadence;
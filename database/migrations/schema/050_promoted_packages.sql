@@ -0,0 +1,19 @@
+alter table "user" add column site_admin boolean not null default false;
+
+create table if not exists promoted_package (
+    promoted_package_id uuid primary key default gen_random_uuid(),
+    package_id uuid not null references package on delete cascade,
+    starts_at timestamptz default current_timestamp not null,
+    ends_at timestamptz not null,
+    impressions bigint not null default 0,
+    clicks bigint not null default 0,
+    created_at timestamptz default current_timestamp not null,
+    check (ends_at > starts_at)
+);
+create index promoted_package_package_id_idx on promoted_package (package_id);
+create index promoted_package_starts_at_ends_at_idx on promoted_package (starts_at, ends_at);
+
+---- create above / drop below ----
+
+drop table if exists promoted_package;
+alter table "user" drop column if exists site_admin;
@@ -0,0 +1,7 @@
+alter table repository add column webhook_secret text;
+alter table repository add column tracking_requested boolean not null default false;
+
+---- create above / drop below ----
+
+alter table repository drop column if exists tracking_requested;
+alter table repository drop column if exists webhook_secret;
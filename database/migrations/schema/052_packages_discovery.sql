@@ -0,0 +1,13 @@
+alter table repository add column verified_publisher_at timestamptz;
+
+create table if not exists package_discovery (
+    category text not null check (category in ('new-this-week', 'recently-verified', 'hidden-gems')),
+    package_id uuid not null references package on delete cascade,
+    position integer not null,
+    primary key (category, package_id)
+);
+
+---- create above / drop below ----
+
+drop table if exists package_discovery;
+alter table repository drop column if exists verified_publisher_at;
@@ -0,0 +1,16 @@
+alter table repository_tracking_run add column started_at timestamptz;
+alter table repository_tracking_run add column packages_added int not null default 0 check (packages_added >= 0);
+alter table repository_tracking_run add column packages_updated int not null default 0 check (packages_updated >= 0);
+alter table repository_tracking_run add column packages_deleted int not null default 0 check (packages_deleted >= 0);
+alter table repository_tracking_run add column tracking_errors text;
+
+drop function if exists set_last_tracking_results(uuid, text, int, boolean);
+
+---- create above / drop below ----
+
+drop function if exists set_last_tracking_results(uuid, text, jsonb, boolean);
+alter table repository_tracking_run drop column started_at;
+alter table repository_tracking_run drop column packages_added;
+alter table repository_tracking_run drop column packages_updated;
+alter table repository_tracking_run drop column packages_deleted;
+alter table repository_tracking_run drop column tracking_errors;
@@ -0,0 +1,59 @@
+create table if not exists legal_hold (
+    legal_hold_id uuid primary key default gen_random_uuid(),
+    package_id uuid references package on delete cascade,
+    repository_id uuid references repository on delete cascade,
+    reason text not null check (reason <> ''),
+    user_id uuid references "user" on delete set null,
+    created_at timestamptz default current_timestamp not null,
+    check ((package_id is null) <> (repository_id is null))
+);
+create unique index legal_hold_package_id_idx on legal_hold (package_id);
+create unique index legal_hold_repository_id_idx on legal_hold (repository_id);
+
+create or replace function check_legal_hold()
+returns trigger as $$
+declare
+    v_held boolean;
+begin
+    if tg_table_name = 'repository' then
+        select exists (
+            select 1 from legal_hold
+            where repository_id = old.repository_id
+            or package_id in (select package_id from package where repository_id = old.repository_id)
+        ) into v_held;
+    else
+        select exists (
+            select 1 from legal_hold
+            where package_id = old.package_id
+            or repository_id = (select repository_id from package where package_id = old.package_id)
+        ) into v_held;
+    end if;
+    if v_held then
+        raise 'resource under legal hold';
+    end if;
+    return old;
+end
+$$ language plpgsql;
+
+create trigger trigger_repository_legal_hold
+before delete on repository
+for each row
+execute procedure check_legal_hold();
+
+create trigger trigger_package_legal_hold
+before delete on package
+for each row
+execute procedure check_legal_hold();
+
+create trigger trigger_snapshot_legal_hold
+before delete on snapshot
+for each row
+execute procedure check_legal_hold();
+
+---- create above / drop below ----
+
+drop trigger if exists trigger_snapshot_legal_hold on snapshot;
+drop trigger if exists trigger_package_legal_hold on package;
+drop trigger if exists trigger_repository_legal_hold on repository;
+drop function if exists check_legal_hold;
+drop table if exists legal_hold;
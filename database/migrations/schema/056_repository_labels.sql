@@ -0,0 +1,7 @@
+alter table repository add column labels text[] check (cardinality(labels) > 0);
+create index repository_labels_idx on repository using gin (labels);
+
+---- create above / drop below ----
+
+drop index if exists repository_labels_idx;
+alter table repository drop column if exists labels;
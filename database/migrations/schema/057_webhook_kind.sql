@@ -0,0 +1,5 @@
+alter table webhook add column kind text check (kind in ('pagerduty', 'opsgenie'));
+
+---- create above / drop below ----
+
+alter table webhook drop column if exists kind;
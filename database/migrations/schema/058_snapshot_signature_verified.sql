@@ -0,0 +1,10 @@
+alter table snapshot add column signature_verified boolean;
+
+-- Clear the stored packages documents, so that they include the signature
+-- verification status when they are built again
+update package set document = null;
+delete from package_document;
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists signature_verified;
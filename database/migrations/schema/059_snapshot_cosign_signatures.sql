@@ -0,0 +1,14 @@
+alter table snapshot add column signature_kind text check (signature_kind <> '');
+alter table snapshot add column signature_identity text check (signature_identity <> '');
+alter table snapshot add column has_attestations boolean;
+
+-- Clear the stored packages documents, so that they include the cosign
+-- signatures details when they are built again
+update package set document = null;
+delete from package_document;
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists signature_kind;
+alter table snapshot drop column if exists signature_identity;
+alter table snapshot drop column if exists has_attestations;
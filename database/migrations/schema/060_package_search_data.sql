@@ -0,0 +1,24 @@
+alter table package add column search_data jsonb;
+create index package_search_data_idx on package using gin (search_data);
+
+-- Index the data fields of the packages already registered
+update package p set search_data = nullif(jsonb_strip_nulls(
+    case r.repository_kind_id
+    when 0 then jsonb_build_object(
+        'kube_version', case when s.data->>'kubeVersion' <> '' then jsonb_build_array(s.data->>'kubeVersion') end,
+        'chart_type', case when s.data->>'type' <> '' then jsonb_build_array(s.data->>'type') end
+    )
+    when 5 then jsonb_build_object(
+        'platform', nullif(s.data->'platforms', 'null')
+    )
+    else '{}'::jsonb
+    end
+), '{}')
+from snapshot s, repository r
+where s.package_id = p.package_id
+and s.version = p.latest_version
+and r.repository_id = p.repository_id;
+
+---- create above / drop below ----
+
+alter table package drop column if exists search_data;
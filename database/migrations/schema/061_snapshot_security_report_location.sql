@@ -0,0 +1,5 @@
+alter table snapshot add column security_report_location text check (security_report_location <> '');
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists security_report_location;
@@ -0,0 +1,14 @@
+alter table snapshot add column sbom jsonb;
+alter table snapshot add column sbom_format text check (sbom_format <> '');
+alter table snapshot add column sbom_location text check (sbom_location <> '');
+
+-- Clear the stored packages documents, so that they include whether an SBOM
+-- is available when they are built again
+update package set document = null;
+delete from package_document;
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists sbom;
+alter table snapshot drop column if exists sbom_format;
+alter table snapshot drop column if exists sbom_location;
@@ -0,0 +1,10 @@
+alter table snapshot add column values_docs jsonb;
+
+-- Clear the stored packages documents, so that they include whether values
+-- docs are available when they are built again
+update package set document = null;
+delete from package_document;
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists values_docs;
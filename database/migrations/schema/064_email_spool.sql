@@ -0,0 +1,16 @@
+create table if not exists email_spool (
+    email_spool_id uuid primary key default gen_random_uuid(),
+    recipient text not null check (recipient <> ''),
+    subject text not null,
+    body text not null,
+    text_body text,
+    attempts integer not null default 0,
+    last_error text,
+    next_attempt_at timestamptz default current_timestamp not null,
+    created_at timestamptz default current_timestamp not null
+);
+create index email_spool_next_attempt_at_idx on email_spool (next_attempt_at);
+
+---- create above / drop below ----
+
+drop table if exists email_spool;
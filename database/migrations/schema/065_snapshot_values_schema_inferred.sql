@@ -0,0 +1,10 @@
+alter table snapshot add column values_schema_inferred boolean not null default false;
+
+-- Clear the stored packages documents, so that they include whether the
+-- values schema was inferred when they are built again
+update package set document = null;
+delete from package_document;
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists values_schema_inferred;
@@ -0,0 +1,10 @@
+alter table snapshot add column dependencies_tree jsonb;
+
+-- Clear the stored packages documents, so that they include whether a
+-- dependencies tree is available when they are built again
+update package set document = null;
+delete from package_document;
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists dependencies_tree;
@@ -0,0 +1,21 @@
+create table if not exists image_upload (
+    image_upload_id uuid primary key default gen_random_uuid(),
+    user_id uuid not null references "user" on delete cascade,
+    size integer not null check (size > 0),
+    checksum text not null check (checksum <> ''),
+    received integer not null default 0,
+    data bytea not null default '',
+    image_id uuid references image on delete set null,
+    moderation_status text not null default 'pending' check (moderation_status in ('pending', 'approved', 'rejected')),
+    moderated_by uuid references "user" on delete set null,
+    moderated_at timestamptz,
+    created_at timestamptz default current_timestamp not null,
+    updated_at timestamptz default current_timestamp not null
+);
+create index image_upload_user_id_idx on image_upload (user_id);
+create index image_upload_image_id_idx on image_upload (image_id);
+create index image_upload_moderation_status_idx on image_upload (moderation_status) where image_id is not null;
+
+---- create above / drop below ----
+
+drop table if exists image_upload;
@@ -0,0 +1,16 @@
+alter table package add column category text check (category in (
+    'ai-machine-learning',
+    'database',
+    'integration-delivery',
+    'monitoring-logging',
+    'networking',
+    'security',
+    'storage',
+    'streaming-messaging'
+));
+alter table package add column hidden boolean not null default false;
+
+---- create above / drop below ----
+
+alter table package drop column if exists category;
+alter table package drop column if exists hidden;
@@ -0,0 +1,10 @@
+alter table snapshot add column test_results jsonb;
+
+-- Clear the stored packages documents, so that they include the charts test
+-- results when they are built again
+update package set document = null;
+delete from package_document;
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists test_results;
@@ -0,0 +1,14 @@
+alter table snapshot add column category text check (category in (
+    'ai-machine-learning',
+    'database',
+    'integration-delivery',
+    'monitoring-logging',
+    'networking',
+    'security',
+    'storage',
+    'streaming-messaging'
+));
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists category;
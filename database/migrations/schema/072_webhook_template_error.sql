@@ -0,0 +1,15 @@
+create table if not exists webhook_template_error (
+    webhook_template_error_id uuid primary key default gen_random_uuid(),
+    webhook_id uuid not null references webhook on delete cascade,
+    event_id uuid not null references event on delete cascade,
+    error text not null check (error <> ''),
+    line integer,
+    col integer,
+    created_at timestamptz default current_timestamp not null
+);
+create index webhook_template_error_webhook_id_created_at_idx on webhook_template_error (webhook_id, created_at);
+create index webhook_template_error_event_id_idx on webhook_template_error (event_id);
+
+---- create above / drop below ----
+
+drop table if exists webhook_template_error;
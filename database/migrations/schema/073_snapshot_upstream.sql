@@ -0,0 +1,7 @@
+alter table snapshot add column upstream jsonb;
+create index snapshot_digest_idx on snapshot (digest);
+
+---- create above / drop below ----
+
+drop index if exists snapshot_digest_idx;
+alter table snapshot drop column if exists upstream;
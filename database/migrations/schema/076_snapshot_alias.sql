@@ -0,0 +1,13 @@
+alter table snapshot add column alias text;
+
+-- The package alias is now included in the full text search document
+drop function if exists generate_package_tsdoc(text, text, text, text[], text[], text[]);
+
+-- Clear the stored packages documents, so that they include the display
+-- name and alias when they are built again
+update package set document = null;
+delete from package_document;
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists alias;
@@ -0,0 +1,7 @@
+alter table repository add column sign_keyring text;
+alter table repository add column sign_keyring_url text;
+
+---- create above / drop below ----
+
+alter table repository drop column if exists sign_keyring;
+alter table repository drop column if exists sign_keyring_url;
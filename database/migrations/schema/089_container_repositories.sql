@@ -0,0 +1,5 @@
+insert into repository_kind values (22, 'Container images');
+
+---- create above / drop below ----
+
+delete from repository_kind where repository_kind_id = 22;
@@ -0,0 +1,217 @@
+-- get_kube_version_ranges normalizes the kubeVersion constraint provided into
+-- a list of ranges of compatible Kubernetes minor versions, the same way the
+-- tracker does it for the charts versions it registers. A minor version is
+-- compatible when any of its patch releases satisfies the constraint. Null is
+-- returned when the constraint cannot be parsed.
+create or replace function get_kube_version_ranges(p_kube_version text)
+returns jsonb as $$
+declare
+    v_constraint text;
+    v_group text;
+    v_comparator text;
+    v_parts text[];
+    v_op text;
+    v_major int;
+    v_minor int;
+    v_patch int;
+    v_version int[];
+    v_next int[];
+    v_c_lower int[];
+    v_c_lower_incl boolean;
+    v_c_upper int[];
+    v_c_upper_incl boolean;
+    v_lower int[];
+    v_lower_incl boolean;
+    v_upper int[];
+    v_upper_incl boolean;
+    v_candidate int[];
+    v_compatible boolean[] := array_fill(false, array[100]);
+    v_ranges jsonb := '[]';
+    v_range_start int;
+begin
+    -- Attach operators to their versions, expand hyphen ranges and use spaces
+    -- as the only separator between the comparators of a group
+    v_constraint := regexp_replace(p_kube_version, '([=!<>~^]+)\s+', '\1', 'g');
+    v_constraint := regexp_replace(v_constraint, '(\S+)\s+-\s+(\S+)', '>=\1 <=\2', 'g');
+    v_constraint := replace(v_constraint, ',', ' ');
+
+    foreach v_group in array string_to_array(v_constraint, '||') loop
+        v_group := trim(v_group);
+        if v_group = '' then
+            return null;
+        end if;
+
+        -- Intersect the bounds of all the comparators in the group
+        v_lower := null;
+        v_lower_incl := true;
+        v_upper := null;
+        v_upper_incl := true;
+        foreach v_comparator in array regexp_split_to_array(v_group, '\s+') loop
+            v_parts := regexp_match(
+                v_comparator,
+                '^(!=|>=|<=|~>|=|>|<|~|\^)?v?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$'
+            );
+            if v_parts is null then
+                return null;
+            end if;
+            v_op := coalesce(v_parts[1], '=');
+            if v_op = '!=' or v_parts[2] !~ '^\d+$' then
+                continue;
+            end if;
+            v_major := v_parts[2]::int;
+            v_minor := case when v_parts[3] ~ '^\d+$' then v_parts[3]::int end;
+            v_patch := case when v_minor is not null and v_parts[4] ~ '^\d+$' then v_parts[4]::int end;
+
+            -- Versions are represented as major, minor, patch and a flag that
+            -- sorts prereleases before their release
+            v_version := array[
+                v_major,
+                coalesce(v_minor, 0),
+                coalesce(v_patch, 0),
+                case when v_parts[5] is not null then 0 else 1 end
+            ];
+            if v_minor is null then
+                v_next := array[v_major + 1, 0, 0, 0];
+            elsif v_patch is null then
+                v_next := array[v_major, v_minor + 1, 0, 0];
+            end if;
+
+            v_c_lower := null;
+            v_c_lower_incl := true;
+            v_c_upper := null;
+            v_c_upper_incl := true;
+            case v_op
+            when '=' then
+                v_c_lower := v_version;
+                if v_patch is null then
+                    v_c_upper := v_next;
+                    v_c_upper_incl := false;
+                else
+                    v_c_upper := v_version;
+                end if;
+            when '>' then
+                if v_patch is null then
+                    v_c_lower := v_next;
+                else
+                    v_c_lower := v_version;
+                    v_c_lower_incl := false;
+                end if;
+            when '>=' then
+                v_c_lower := v_version;
+            when '<' then
+                v_c_upper := v_version;
+                v_c_upper_incl := false;
+            when '<=' then
+                if v_patch is null then
+                    v_c_upper := v_next;
+                    v_c_upper_incl := false;
+                else
+                    v_c_upper := v_version;
+                end if;
+            when '~', '~>' then
+                v_c_lower := v_version;
+                v_c_upper := case
+                    when v_minor is null then array[v_major + 1, 0, 0, 0]
+                    else array[v_major, v_minor + 1, 0, 0]
+                end;
+                v_c_upper_incl := false;
+            when '^' then
+                v_c_lower := v_version;
+                v_c_upper := case
+                    when v_major > 0 then array[v_major + 1, 0, 0, 0]
+                    when coalesce(v_minor, 0) > 0 then array[0, v_minor + 1, 0, 0]
+                    else array[0, 0, coalesce(v_patch, 0) + 1, 0]
+                end;
+                v_c_upper_incl := false;
+            end case;
+
+            if v_c_lower is not null and (
+                v_lower is null
+                or v_c_lower > v_lower
+                or (v_c_lower = v_lower and not v_c_lower_incl)
+            ) then
+                v_lower := v_c_lower;
+                v_lower_incl := v_c_lower_incl;
+            end if;
+            if v_c_upper is not null and (
+                v_upper is null
+                or v_c_upper < v_upper
+                or (v_c_upper = v_upper and not v_c_upper_incl)
+            ) then
+                v_upper := v_c_upper;
+                v_upper_incl := v_c_upper_incl;
+            end if;
+        end loop;
+
+        -- Check if the first patch release of each minor version within the
+        -- group bounds satisfies them
+        for v_i in 0..99 loop
+            if v_lower is null or v_lower[1:2] < array[1, v_i] then
+                v_candidate := array[1, v_i, 0, 1];
+            elsif v_lower[1:2] > array[1, v_i] then
+                continue;
+            elsif v_lower[4] = 0 or v_lower_incl then
+                v_candidate := array[1, v_i, v_lower[3], 1];
+            else
+                v_candidate := array[1, v_i, v_lower[3] + 1, 1];
+            end if;
+            if v_upper is null
+            or v_candidate < v_upper
+            or (v_candidate = v_upper and v_upper_incl) then
+                v_compatible[v_i + 1] := true;
+            end if;
+        end loop;
+    end loop;
+
+    -- Build the ranges from the compatible minor versions
+    for v_i in 0..100 loop
+        if v_i < 100 and v_compatible[v_i + 1] then
+            if v_range_start is null then
+                v_range_start := v_i;
+            end if;
+        elsif v_range_start is not null then
+            v_ranges := v_ranges || jsonb_build_array(jsonb_strip_nulls(jsonb_build_object(
+                'min', '1.' || v_range_start,
+                'max', case when v_i < 100 then '1.' || (v_i - 1) end
+            )));
+            v_range_start := null;
+        end if;
+    end loop;
+
+    return v_ranges;
+end
+$$ language plpgsql immutable;
+
+-- Normalize the kubeVersion constraints of the charts versions already
+-- registered. Constraints that cannot be parsed are left as they were, so
+-- those charts versions are still considered compatible with any version.
+update snapshot s set data = jsonb_set(s.data, '{kubeVersionRanges}', k.ranges)
+from (
+    select s.package_id, s.version, get_kube_version_ranges(s.data->>'kubeVersion') as ranges
+    from snapshot s
+    join package p using (package_id)
+    join repository r using (repository_id)
+    where r.repository_kind_id = 0
+    and s.data->>'kubeVersion' <> ''
+) k
+where s.package_id = k.package_id
+and s.version = k.version
+and k.ranges is not null;
+
+-- Index the ranges of the latest version of the packages
+update package p set search_data = coalesce(p.search_data, '{}') || jsonb_build_object(
+    'kube_version_range', s.data->'kubeVersionRanges'
+)
+from snapshot s
+where s.package_id = p.package_id
+and s.version = p.latest_version
+and s.data ? 'kubeVersionRanges';
+
+-- The stored packages documents include the versions data, so they are
+-- cleared to be built again with the ranges
+update package set document = null;
+delete from package_document;
+
+drop function if exists get_kube_version_ranges;
+
+---- create above / drop below ----
@@ -0,0 +1,59 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into email_spool (recipient, subject, body, attempts, next_attempt_at)
+values ('user3@email.com', 'subject', 'body', 3, current_timestamp + '1 hour'::interval);
+insert into email_spool (recipient, subject, body, attempts, last_error)
+values ('user4@email.com', 'subject', 'body', 10, 'fake error');
+
+-- Run some tests
+select throws_ok(
+    $$ select flush_email_spool('00000000-0000-0000-0000-000000000002') $$,
+    42501,
+    'insufficient_privilege',
+    'Only site admins should be able to flush the email spool'
+);
+select is(
+    flush_email_spool(:'user1ID'),
+    2,
+    'Two spooled emails should have been flushed'
+);
+select results_eq(
+    $$
+        select recipient, attempts, next_attempt_at <= current_timestamp
+        from email_spool
+        order by recipient asc
+    $$,
+    $$
+        values
+            ('user3@email.com', 0, true),
+            ('user4@email.com', 0, true)
+    $$,
+    'Spooled emails should be due for delivery'
+);
+select results_eq(
+    $$
+        select user_id, action, details->>'flushed'
+        from audit_log
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'flush-email-spool',
+            '2'
+        )
+    $$,
+    'Email spool flush should have been recorded in the audit log'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
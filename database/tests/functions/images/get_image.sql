@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(8);
+select plan(11);
 
 -- Try getting a non existent image
 select is_empty(
@@ -65,6 +65,46 @@ select results_eq(
     'image2 svg data should be returned when requesting a non existent version'
 );
 
+-- Upload image2 (pending moderation)
+insert into "user" (user_id, alias, email)
+values ('00000000-0000-0000-0000-000000000001', 'user1', 'user1@email.com');
+insert into image_upload (image_upload_id, user_id, size, checksum, received, image_id)
+values (
+    '00000000-0000-0000-0000-000000000001',
+    '00000000-0000-0000-0000-000000000001',
+    13,
+    'checksum',
+    13,
+    '00000000-0000-0000-0000-000000000002'
+);
+
+-- Check pending image is not returned
+select is_empty(
+    $$ select get_image('00000000-0000-0000-0000-000000000002', 'svg') $$,
+    'image2 is pending moderation, nothing should be returned'
+);
+
+-- Approve upload of image2
+update image_upload set moderation_status = 'approved'
+where image_upload_id = '00000000-0000-0000-0000-000000000001';
+
+-- Check approved image is returned
+select results_eq(
+    $$ select get_image('00000000-0000-0000-0000-000000000002', 'svg') $$,
+    $$ values ('image2SvgData'::bytea) $$,
+    'image2 has been approved, its data should be returned'
+);
+
+-- Reject upload of image2
+update image_upload set moderation_status = 'rejected'
+where image_upload_id = '00000000-0000-0000-0000-000000000001';
+
+-- Check rejected image is not returned
+select is_empty(
+    $$ select get_image('00000000-0000-0000-0000-000000000002', 'svg') $$,
+    'image2 has been rejected, nothing should be returned'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
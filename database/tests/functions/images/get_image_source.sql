@@ -0,0 +1,35 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set image1ID '00000000-0000-0000-0000-000000000001'
+\set image2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some images and their sources
+insert into image (image_id, original_hash) values (:'image1ID', 'image1Hash');
+insert into image (image_id, original_hash) values (:'image2ID', 'image2Hash');
+insert into image_source (url, original_hash, image_id)
+values ('https://image1.url', 'image1Hash', :'image1ID');
+insert into image_source (url, original_hash, image_id, last_checked_at)
+values ('https://image2.url', 'image2Hash', :'image2ID', current_timestamp - '2 hours'::interval);
+
+-- Run some tests
+select results_eq(
+    $$ select * from get_image_source('https://image1.url', 3600) $$,
+    $$ values ('00000000-0000-0000-0000-000000000001'::uuid, 'image1Hash'::bytea, true) $$,
+    'Image1 source was checked recently'
+);
+select results_eq(
+    $$ select * from get_image_source('https://image2.url', 3600) $$,
+    $$ values ('00000000-0000-0000-0000-000000000002'::uuid, 'image2Hash'::bytea, false) $$,
+    'Image2 source was not checked recently'
+);
+select is_empty(
+    $$ select * from get_image_source('https://image3.url', 3600) $$,
+    'Image3 source does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
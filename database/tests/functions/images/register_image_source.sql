@@ -0,0 +1,35 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set image1ID '00000000-0000-0000-0000-000000000001'
+\set image2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some images
+insert into image (image_id, original_hash) values (:'image1ID', 'image1Hash');
+insert into image (image_id, original_hash) values (:'image2ID', 'image2Hash');
+
+-- Register image source
+select register_image_source('https://image.url', 'image1Hash', :'image1ID');
+select results_eq(
+    $$ select url, original_hash, image_id from image_source $$,
+    $$ values ('https://image.url', 'image1Hash'::bytea, '00000000-0000-0000-0000-000000000001'::uuid) $$,
+    'Image source should have been registered'
+);
+
+-- Register image source again with a different image
+update image_source set last_checked_at = current_timestamp - '1 day'::interval;
+select register_image_source('https://image.url', 'image2Hash', :'image2ID');
+select results_eq(
+    $$
+        select url, original_hash, image_id, last_checked_at = current_timestamp
+        from image_source
+    $$,
+    $$ values ('https://image.url', 'image2Hash'::bytea, '00000000-0000-0000-0000-000000000002'::uuid, true) $$,
+    'Image source should have been updated'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,99 @@
+-- Start transaction and plan tests
+begin;
+select plan(9);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '2.0.0', :'repo1ID');
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '1.0.0', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '2.0.0', '2020-06-17 11:20:34+02');
+
+-- Run some tests
+select throws_ok(
+    $$ select add_legal_hold('00000000-0000-0000-0000-000000000002', '{"package_id": "00000000-0000-0000-0000-000000000001", "reason": "reason"}') $$,
+    42501,
+    'insufficient_privilege',
+    'Only site admins should be able to place legal holds'
+);
+select throws_ok(
+    $$ select add_legal_hold('00000000-0000-0000-0000-000000000001', '{"package_id": "00000000-0000-0000-0000-000000000009", "reason": "reason"}') $$,
+    'P0001',
+    'package not found',
+    'Package to hold must exist'
+);
+select throws_ok(
+    $$ select add_legal_hold('00000000-0000-0000-0000-000000000001', '{"repository_id": "00000000-0000-0000-0000-000000000009", "reason": "reason"}') $$,
+    'P0001',
+    'repository not found',
+    'Repository to hold must exist'
+);
+select add_legal_hold(:'user1ID', '{"package_id": "00000000-0000-0000-0000-000000000001", "reason": "reason"}');
+select results_eq(
+    $$
+        select package_id, repository_id, reason, user_id
+        from legal_hold
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            null::uuid,
+            'reason',
+            '00000000-0000-0000-0000-000000000001'::uuid
+        )
+    $$,
+    'Legal hold should have been placed on the package'
+);
+select results_eq(
+    $$
+        select user_id, action, details->>'package_id', details->>'reason'
+        from audit_log
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'add-legal-hold',
+            '00000000-0000-0000-0000-000000000001',
+            'reason'
+        )
+    $$,
+    'Legal hold placement should have been recorded in the audit log'
+);
+select throws_ok(
+    $$ select add_legal_hold('00000000-0000-0000-0000-000000000001', '{"package_id": "00000000-0000-0000-0000-000000000001", "reason": "reason"}') $$,
+    'P0001',
+    'legal hold already exists',
+    'Only one legal hold can be placed on a given package'
+);
+select throws_ok(
+    $$ select unregister_package('{"name": "package1", "version": "1.0.0", "repository": {"repository_id": "00000000-0000-0000-0000-000000000001"}}') $$,
+    'P0001',
+    'resource under legal hold',
+    'Versions of a package held cannot be deleted'
+);
+select throws_ok(
+    $$ select delete_repository('00000000-0000-0000-0000-000000000001', 'repo1') $$,
+    'P0001',
+    'resource under legal hold',
+    'Repositories containing packages held cannot be deleted'
+);
+select results_eq(
+    $$ select count(*) from snapshot $$,
+    $$ values (2::bigint) $$,
+    'Package versions held should still be available'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,89 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set legalHold1ID '00000000-0000-0000-0000-000000000001'
+\set legalHold2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '1.0.0', '2020-06-16 11:20:34+02');
+insert into legal_hold (legal_hold_id, package_id, reason, user_id, created_at)
+values (:'legalHold1ID', :'package1ID', 'reason1', :'user1ID', '2021-01-01 00:00:00+00');
+insert into legal_hold (legal_hold_id, repository_id, reason, user_id, created_at)
+values (:'legalHold2ID', :'repo1ID', 'reason2', :'user1ID', '2021-02-01 00:00:00+00');
+
+-- Run some tests
+select throws_ok(
+    $$ select get_legal_holds('00000000-0000-0000-0000-000000000002') $$,
+    42501,
+    'insufficient_privilege',
+    'Only site admins should be able to get the legal holds'
+);
+select is(
+    get_legal_holds(:'user1ID')::jsonb,
+    '[
+        {
+            "legal_hold_id": "00000000-0000-0000-0000-000000000002",
+            "reason": "reason2",
+            "created_at": 1612137600,
+            "created_by": "user1",
+            "repository": {
+                "repository_id": "00000000-0000-0000-0000-000000000001",
+                "kind": 0,
+                "name": "repo1",
+                "display_name": "Repo 1",
+                "url": "https://repo1.com",
+                "private": false,
+                "verified_publisher": false,
+                "domain_verified": false,
+                "official": false,
+                "scanner_disabled": false,
+                "user_alias": "user1"
+            }
+        },
+        {
+            "legal_hold_id": "00000000-0000-0000-0000-000000000001",
+            "reason": "reason1",
+            "created_at": 1609459200,
+            "created_by": "user1",
+            "package": {
+                "package_id": "00000000-0000-0000-0000-000000000001",
+                "name": "package1",
+                "normalized_name": "package1",
+                "stars": 0,
+                "version": "1.0.0",
+                "ts": 1592299234,
+                "repository": {
+                    "repository_id": "00000000-0000-0000-0000-000000000001",
+                    "kind": 0,
+                    "name": "repo1",
+                    "display_name": "Repo 1",
+                    "url": "https://repo1.com",
+                    "private": false,
+                    "verified_publisher": false,
+                    "domain_verified": false,
+                    "official": false,
+                    "scanner_disabled": false,
+                    "user_alias": "user1"
+                }
+            }
+        }
+    ]'::jsonb,
+    'All legal holds should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
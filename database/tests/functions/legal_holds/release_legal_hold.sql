@@ -0,0 +1,59 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set legalHold1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into legal_hold (legal_hold_id, repository_id, reason, user_id)
+values (:'legalHold1ID', :'repo1ID', 'reason', :'user1ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select release_legal_hold('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001') $$,
+    42501,
+    'insufficient_privilege',
+    'Only site admins should be able to release legal holds'
+);
+select throws_ok(
+    $$ select release_legal_hold('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000009') $$,
+    'P0001',
+    'legal hold not found',
+    'Legal hold to release must exist'
+);
+select release_legal_hold(:'user1ID', :'legalHold1ID');
+select is_empty(
+    $$ select * from legal_hold $$,
+    'Legal hold should have been released'
+);
+select results_eq(
+    $$
+        select user_id, action, details->>'legal_hold_id', details->>'repository_id'
+        from audit_log
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'release-legal-hold',
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    'Legal hold release should have been recorded in the audit log'
+);
+select lives_ok(
+    $$ select delete_repository('00000000-0000-0000-0000-000000000001', 'repo1') $$,
+    'Repository should be deletable once the legal hold has been released'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
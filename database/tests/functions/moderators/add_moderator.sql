@@ -0,0 +1,49 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+
+-- Run some tests
+select throws_ok(
+    $$ select add_moderator('00000000-0000-0000-0000-000000000002', 'user2') $$,
+    42501,
+    'insufficient_privilege',
+    'Only site admins should be able to add moderators'
+);
+select throws_ok(
+    $$ select add_moderator('00000000-0000-0000-0000-000000000001', 'user9') $$,
+    'P0001',
+    'user not found',
+    'User to grant the moderator role to must exist'
+);
+select add_moderator(:'user1ID', 'user2');
+select results_eq(
+    $$ select alias from "user" where moderator = true $$,
+    $$ values ('user2') $$,
+    'User2 should be a moderator'
+);
+select results_eq(
+    $$
+        select user_id, action, details->>'user_id'
+        from audit_log
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'add-moderator',
+            '00000000-0000-0000-0000-000000000002'
+        )
+    $$,
+    'Moderator addition should have been recorded in the audit log'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,41 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+
+-- Run some tests
+select throws_ok(
+    $$ select get_moderators('00000000-0000-0000-0000-000000000002') $$,
+    42501,
+    'insufficient_privilege',
+    'Only site admins should be able to get the moderators'
+);
+select is(
+    get_moderators(:'user1ID')::jsonb,
+    '[]'::jsonb,
+    'No moderators expected'
+);
+insert into "user" (user_id, alias, email, first_name, moderator)
+values (:'user3ID', 'user3', 'user3@email.com', 'first name', true);
+select is(
+    get_moderators(:'user1ID')::jsonb,
+    '[
+        {
+            "alias": "user3",
+            "first_name": "first name"
+        }
+    ]'::jsonb,
+    'One moderator expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
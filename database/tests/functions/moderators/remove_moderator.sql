@@ -0,0 +1,48 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email, moderator) values (:'user2ID', 'user2', 'user2@email.com', true);
+
+-- Run some tests
+select throws_ok(
+    $$ select remove_moderator('00000000-0000-0000-0000-000000000002', 'user2') $$,
+    42501,
+    'insufficient_privilege',
+    'Only site admins should be able to remove moderators'
+);
+select throws_ok(
+    $$ select remove_moderator('00000000-0000-0000-0000-000000000001', 'user1') $$,
+    'P0001',
+    'user not found',
+    'User to revoke the moderator role from must be a moderator'
+);
+select remove_moderator(:'user1ID', 'user2');
+select is_empty(
+    $$ select alias from "user" where moderator = true $$,
+    'No moderators expected'
+);
+select results_eq(
+    $$
+        select user_id, action, details->>'user_id'
+        from audit_log
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'remove-moderator',
+            '00000000-0000-0000-0000-000000000002'
+        )
+    $$,
+    'Moderator removal should have been recorded in the audit log'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
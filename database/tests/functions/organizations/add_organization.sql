@@ -16,7 +16,9 @@ select add_organization(:'user1ID', '
     "display_name": "Organization 1",
     "description": "Description 1",
     "home_url": "https://org1.com",
-    "logo_image_id": "00000000-0000-0000-0000-000000000001"
+    "logo_image_id": "00000000-0000-0000-0000-000000000001",
+    "profile": "# Org1",
+    "profile_html": "<h1>Org1</h1>"
 }
 '::jsonb);
 
@@ -28,7 +30,9 @@ select results_eq(
             display_name,
             description,
             home_url,
-            logo_image_id
+            logo_image_id,
+            profile,
+            profile_html
         from organization
     $$,
     $$
@@ -37,22 +41,24 @@ select results_eq(
             'Organization 1',
             'Description 1',
             'https://org1.com',
-            '00000000-0000-0000-0000-000000000001'::uuid
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '# Org1',
+            '<h1>Org1</h1>'
         )
     $$,
     'Organization should exist'
 );
 select results_eq(
     $$
-        select uo.user_id
+        select uo.user_id, uo.role
         from user__organization uo
         join organization o using (organization_id)
         where o.name = 'org1'
     $$,
     $$
-        values ('00000000-0000-0000-0000-000000000001'::uuid)
+        values ('00000000-0000-0000-0000-000000000001'::uuid, 'owner')
     $$,
-    'User who created the organization should have joined it'
+    'User who created the organization should have joined it as owner'
 );
 
 -- Finish tests and rollback transaction
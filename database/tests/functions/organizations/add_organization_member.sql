@@ -20,23 +20,26 @@ values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com'
 insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
 
 -- Add organization member and check it succeeded
-select add_organization_member(:'user1ID', 'org1', 'user2');
+select add_organization_member(:'user1ID', 'org1', 'user2', 3600);
 select results_eq(
     $$
-        select user_id, confirmed
+        select
+            user_id,
+            confirmed,
+            invitation_expires_at between current_timestamp + '59 minutes'::interval and current_timestamp + '61 minutes'::interval
         from user__organization
         where user_id = '00000000-0000-0000-0000-000000000002'
         and organization_id = '00000000-0000-0000-0000-000000000001'
     $$,
     $$
-        values ('00000000-0000-0000-0000-000000000002'::uuid, false)
+        values ('00000000-0000-0000-0000-000000000002'::uuid, false, true)
     $$,
     'User2 should have been added to organization1'
 );
 
 -- Try adding an organization member without the required privileges
 select throws_ok(
-    $$ select add_organization_member('00000000-0000-0000-0000-000000000003', 'org1', 'user2') $$,
+    $$ select add_organization_member('00000000-0000-0000-0000-000000000003', 'org1', 'user2', 3600) $$,
     42501,
     'insufficient_privilege',
     'User3 should not be able to add members to organization1'
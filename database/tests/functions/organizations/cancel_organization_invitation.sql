@@ -0,0 +1,52 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some users and organizations
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@email.com');
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user2ID', 'user2', 'firstname2', 'lastname2', 'user2@email.com');
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user3ID', 'user3', 'firstname3', 'lastname3', 'user3@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed, invitation_expires_at)
+values(:'user2ID', :'org1ID', false, current_timestamp - '1 hour'::interval);
+
+-- Cancel invitation and check it succeeded
+select cancel_organization_invitation(:'user1ID', 'org1', 'user2');
+select is_empty(
+    $$
+        select *
+        from user__organization
+        where user_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    'User2 invitation should have been cancelled'
+);
+
+-- Try cancelling an invitation for a confirmed member
+select throws_ok(
+    $$ select cancel_organization_invitation('00000000-0000-0000-0000-000000000001', 'org1', 'user1') $$,
+    'invitation not found',
+    'Confirmed memberships cannot be cancelled as invitations'
+);
+
+-- Try cancelling an invitation without the required privileges
+select throws_ok(
+    $$ select cancel_organization_invitation('00000000-0000-0000-0000-000000000003', 'org1', 'user2') $$,
+    42501,
+    'insufficient_privilege',
+    'User3 should not be able to cancel invitations in organization1'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,9 +1,10 @@
 -- Start transaction and plan tests
 begin;
-select plan(4);
+select plan(5);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
 \set org1ID '00000000-0000-0000-0000-000000000001'
 
 -- Seed user and organization
@@ -11,7 +12,11 @@ insert into "user" (user_id, alias, first_name, last_name, email)
 values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@email.com');
 insert into organization (organization_id, name, display_name, description, home_url)
 values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user2ID', 'user2', 'firstname2', 'lastname2', 'user2@email.com');
 insert into user__organization (user_id, organization_id) values(:'user1ID', :'org1ID');
+insert into user__organization (user_id, organization_id, invitation_expires_at)
+values(:'user2ID', :'org1ID', current_timestamp - '1 hour'::interval);
 
 -- User and organization have been seeded
 select results_eq(
@@ -44,6 +49,16 @@ select throws_ok(
     'organization membership confirmation failed',
     'Organization does not exist, confirmation should fail'
 );
+select throws_ok(
+    $$
+        select confirm_organization_membership(
+            '00000000-0000-0000-0000-000000000002',
+            'org1'
+        )
+    $$,
+    'organization membership confirmation failed',
+    'Invitation has expired, confirmation should fail'
+);
 
 -- Confirm organization membership and check it succeeded
 select confirm_organization_membership(:'user1ID'::uuid, 'org1'::text);
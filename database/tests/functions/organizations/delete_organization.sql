@@ -1,37 +1,178 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(14);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
 \set user2ID '00000000-0000-0000-0000-000000000002'
 \set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set org3ID '00000000-0000-0000-0000-000000000003'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
 
--- Seed user and organization
+-- Seed some data
 insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
 insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
 insert into organization (organization_id, name, display_name, description, home_url)
 values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org3ID', 'org3', 'Organization 3', 'Description 3', 'https://org3.com');
 insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org1ID', false);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org2ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org3ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org2ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org3ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 0, :'org3ID');
+insert into repository_transfer (repository_id, requesting_user_id, user_id, expires_at)
+values (:'repo2ID', :'user1ID', :'user2ID', current_timestamp + '1 day'::interval);
+insert into webhook (webhook_id, name, url, organization_id)
+values ('00000000-0000-0000-0000-000000000001', 'webhook1', 'https://webhook1.url', :'org1ID');
 
 -- User not belonging to an organization tries to delete it
 select throws_ok(
     $$
-        select delete_organization('00000000-0000-0000-0000-000000000002', 'org1')
+        select delete_organization('00000000-0000-0000-0000-000000000002', 'org1', '{}')
     $$,
     42501,
     'insufficient_privilege',
     'Organization delete should fail because requesting user does not belong to it'
 );
 
--- User belonging to an organiation deletes it
-select delete_organization(:'user1ID', 'org1');
+-- User belonging to an organization deletes it
+select delete_organization(:'user1ID', 'org1', '{}');
 select is_empty(
     $$
         select name from organization where name = 'org1'
     $$,
     'Organization should have been deleted by user who belongs to it'
 );
+select is_empty(
+    $$
+        select * from user__organization where organization_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    'Organization memberships and invitations should have been purged'
+);
+select is_empty(
+    $$
+        select * from webhook where organization_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    'Organization webhooks should have been purged'
+);
+select results_eq(
+    $$
+        select user_id, action, details from audit_log
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'delete-organization',
+            '{
+                "organization_id": "00000000-0000-0000-0000-000000000001",
+                "organization_name": "org1",
+                "repositories": [],
+                "repositories_action": null,
+                "transfer_to_org": null
+            }'::jsonb
+        )
+    $$,
+    'Organization deletion should have been recorded in the audit log'
+);
+
+-- Try to delete organization owning repositories without indicating what to
+-- do with them
+select throws_ok(
+    $$
+        select delete_organization('00000000-0000-0000-0000-000000000001', 'org2', '{}')
+    $$,
+    'organization has repositories',
+    'Organization delete should fail because it owns repositories'
+);
+
+-- Delete organization and its repositories
+select delete_organization(:'user1ID', 'org2', '{"repositories_action": "delete"}');
+select is_empty(
+    $$
+        select name from organization where name = 'org2'
+    $$,
+    'Organization org2 should have been deleted'
+);
+select is_empty(
+    $$
+        select name from repository where name = 'repo1'
+    $$,
+    'Repository repo1 owned by org2 should have been deleted'
+);
+
+-- Try to transfer repositories to an organization that does not exist
+select throws_ok(
+    $$
+        select delete_organization(
+            '00000000-0000-0000-0000-000000000001',
+            'org3',
+            '{"repositories_action": "transfer", "transfer_to_org": "org4"}'
+        )
+    $$,
+    'target organization not found',
+    'Organization delete should fail because the target organization does not exist'
+);
+
+-- Try to transfer repositories to an organization the user does not belong to
+insert into organization (organization_id, name) values ('00000000-0000-0000-0000-000000000004', 'org4');
+select throws_ok(
+    $$
+        select delete_organization(
+            '00000000-0000-0000-0000-000000000001',
+            'org3',
+            '{"repositories_action": "transfer", "transfer_to_org": "org4"}'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Organization delete should fail because the user does not belong to the target organization'
+);
+
+-- Delete organization transferring its repositories to the requesting user
+select delete_organization(:'user1ID', 'org3', '{"repositories_action": "transfer"}');
+select is_empty(
+    $$
+        select name from organization where name = 'org3'
+    $$,
+    'Organization org3 should have been deleted'
+);
+select results_eq(
+    $$
+        select name, user_id, organization_id from repository order by name
+    $$,
+    $$
+        values
+            ('repo2', '00000000-0000-0000-0000-000000000001'::uuid, null::uuid),
+            ('repo3', '00000000-0000-0000-0000-000000000001'::uuid, null::uuid)
+    $$,
+    'Repositories owned by org3 should have been transferred to the requesting user'
+);
+select is_empty(
+    $$
+        select * from repository_transfer
+    $$,
+    'Pending transfers of the repositories transferred should have been deleted'
+);
+select results_eq(
+    $$
+        select details->'repositories' from audit_log where details->>'organization_name' = 'org3'
+    $$,
+    $$
+        values ('["repo2", "repo3"]'::jsonb)
+    $$,
+    'Repositories transferred should have been recorded in the audit log'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -1,10 +1,11 @@
 -- Start transaction and plan tests
 begin;
-select plan(7);
+select plan(10);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
 \set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
 \set org1ID '00000000-0000-0000-0000-000000000001'
 \set org2ID '00000000-0000-0000-0000-000000000002'
 \set repo1ID '00000000-0000-0000-0000-000000000001'
@@ -104,6 +105,45 @@ select throws_ok(
     'User1 should not be able to leave organization1'
 );
 
+-- Last owner of the organization cannot leave it
+update user__organization set role = 'owner'
+where user_id = :'user1ID' and organization_id = :'org1ID';
+insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org1ID', true);
+select throws_ok(
+    $$ select delete_organization_member('00000000-0000-0000-0000-000000000001', 'org1', 'user1') $$,
+    'last owner of an organization cannot leave it',
+    'User1 should not be able to leave organization1 as it is its only owner'
+);
+
+-- Owners can only be removed by other owners
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user3ID', 'user3', 'firstname3', 'lastname3', 'user3@email.com');
+update user__organization set role = 'admin'
+where user_id = :'user2ID' and organization_id = :'org1ID';
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user3ID', :'org1ID', true, 'owner');
+select throws_ok(
+    $$ select delete_organization_member('00000000-0000-0000-0000-000000000002', 'org1', 'user1') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to remove an owner as it is only an admin'
+);
+select delete_organization_member(:'user3ID', 'org1', 'user1');
+select results_eq(
+    $$
+        select user_id
+        from user__organization
+        where organization_id = '00000000-0000-0000-0000-000000000001'
+        order by user_id
+    $$,
+    $$
+        values
+        ('00000000-0000-0000-0000-000000000002'::uuid),
+        ('00000000-0000-0000-0000-000000000003'::uuid)
+    $$,
+    'User1 should have been removed from organization1 by user3 as both are owners'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
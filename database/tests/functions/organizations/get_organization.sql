@@ -7,8 +7,12 @@ select plan(2);
 \set image1ID '00000000-0000-0000-0000-000000000001'
 
 -- Seed some users and organizations
-insert into organization (organization_id, name, display_name, description, home_url, logo_image_id)
-values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com', :'image1ID');
+insert into organization (organization_id, name, display_name, description, home_url, logo_image_id, profile, profile_html)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com', :'image1ID', '# Org1', '<h1>Org1</h1>');
+insert into organization_link (organization_id, url, verification_token, verified)
+values (:'org1ID', 'https://org1.com', 'token1', true);
+insert into organization_link (organization_id, url, verification_token)
+values (:'org1ID', 'https://blog.org1.com', 'token2');
 
 -- Run some tests
 select is(
@@ -18,7 +22,13 @@ select is(
         "display_name": "Organization 1",
         "description": "Description 1",
         "home_url": "https://org1.com",
-        "logo_image_id": "00000000-0000-0000-0000-000000000001"
+        "logo_image_id": "00000000-0000-0000-0000-000000000001",
+        "profile": "# Org1",
+        "profile_html": "<h1>Org1</h1>",
+        "links": [
+            {"url": "https://blog.org1.com", "verified": false},
+            {"url": "https://org1.com", "verified": true}
+        ]
     }
     '::jsonb,
     'Organization1 should exist'
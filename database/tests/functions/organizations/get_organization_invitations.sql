@@ -0,0 +1,91 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some users and organizations
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@email.com');
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user2ID', 'user2', 'firstname2', 'lastname2', 'user2@email.com');
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user3ID', 'user3', 'firstname3', 'lastname3', 'user3@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into user__organization (user_id, organization_id, confirmed, role) values(:'user1ID', :'org1ID', true, 'owner');
+insert into user__organization (user_id, organization_id, confirmed, invitation_expires_at)
+values(:'user2ID', :'org1ID', false, '2100-01-01 00:00:00+00');
+insert into user__organization (user_id, organization_id, confirmed, invitation_expires_at)
+values(:'user3ID', :'org1ID', false, '2000-01-01 00:00:00+00');
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_organization_invitations('00000000-0000-0000-0000-000000000001', 'org1', 0, 0)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "alias": "user2",
+                    "first_name": "firstname2",
+                    "last_name": "lastname2",
+                    "role": "maintainer",
+                    "expires_at": 4102444800,
+                    "expired": false
+                },
+                {
+                    "alias": "user3",
+                    "first_name": "firstname3",
+                    "last_name": "lastname3",
+                    "role": "maintainer",
+                    "expires_at": 946684800,
+                    "expired": true
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'No limit or offset used, invitations for user2 and user3 returned'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_organization_invitations('00000000-0000-0000-0000-000000000001', 'org1', 1, 1)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "alias": "user3",
+                    "first_name": "firstname3",
+                    "last_name": "lastname3",
+                    "role": "maintainer",
+                    "expires_at": 946684800,
+                    "expired": true
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'Limit and offset of 1 used, invitation for user3 returned'
+);
+select throws_ok(
+    $$ select * from get_organization_invitations('00000000-0000-0000-0000-000000000001', 'org2', 0, 0) $$,
+    42501,
+    'insufficient_privilege',
+    'User1 should not be able to get organization2 invitations'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
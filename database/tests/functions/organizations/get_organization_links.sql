@@ -0,0 +1,43 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+
+-- Run some tests
+select is(
+    get_organization_links(:'user1ID', 'org1')::jsonb,
+    '[]'::jsonb,
+    'No links expected'
+);
+insert into organization_link (organization_id, url, verification_token, verified)
+values (:'org1ID', 'https://org1.com', 'token1', true);
+insert into organization_link (organization_id, url, verification_token)
+values (:'org1ID', 'https://blog.org1.com', 'token2');
+select is(
+    get_organization_links(:'user1ID', 'org1')::jsonb,
+    '[
+        {"url": "https://blog.org1.com", "verified": false, "verification_token": "token2"},
+        {"url": "https://org1.com", "verified": true, "verification_token": "token1"}
+    ]'::jsonb,
+    'Organization links are returned as a json array'
+);
+select throws_ok(
+    $$ select get_organization_links('00000000-0000-0000-0000-000000000002', 'org1') $$,
+    42501,
+    'insufficient_privilege',
+    'User not belonging to the organization should not be able to get its links'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -17,7 +17,7 @@ insert into organization (organization_id, name, display_name, description, home
 values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
 insert into organization (organization_id, name, display_name, description, home_url)
 values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
-insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed, role) values(:'user1ID', :'org1ID', true, 'owner');
 insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org1ID', false);
 
 -- Users and organizations have just been seeded
@@ -33,13 +33,15 @@ select results_eq(
                     "alias": "user1",
                     "first_name": "firstname1",
                     "last_name": "lastname1",
-                    "confirmed": true
+                    "confirmed": true,
+                    "role": "owner"
                 },
                 {
                     "alias": "user2",
                     "first_name": "firstname2",
                     "last_name": "lastname2",
-                    "confirmed": false
+                    "confirmed": false,
+                    "role": "maintainer"
                 }
             ]'::jsonb,
             2
@@ -59,7 +61,8 @@ select results_eq(
                     "alias": "user2",
                     "first_name": "firstname2",
                     "last_name": "lastname2",
-                    "confirmed": false
+                    "confirmed": false,
+                    "role": "maintainer"
                 }
             ]'::jsonb,
             2
@@ -0,0 +1,66 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'service-account1', :'org1ID');
+insert into user__organization (user_id, organization_id, confirmed) values(:'serviceAccount1ID', :'org1ID', true);
+
+-- Run some tests
+select is(
+    get_organization_quotas(:'user1ID', 'org1')::jsonb,
+    '{
+        "quotas": {},
+        "usage": {
+            "repositories": 0,
+            "webhooks": 0,
+            "api_keys": 0
+        }
+    }'::jsonb,
+    'No quotas overridden nor resources used expected'
+);
+insert into organization_quota (organization_id, max_repositories, tracker_min_interval)
+values (:'org1ID', 10, 60);
+insert into repository (name, display_name, url, repository_kind_id, organization_id)
+values ('repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into webhook (name, url, organization_id)
+values ('webhook1', 'https://webhook1.url', :'org1ID');
+insert into api_key (name, secret, user_id) values ('apikey1', 'secret1', :'user1ID');
+insert into api_key (name, secret, user_id) values ('apikey2', 'secret2', :'user2ID');
+insert into api_key (name, secret, user_id) values ('apikey3', 'secret3', :'serviceAccount1ID');
+select is(
+    get_organization_quotas(:'user1ID', 'org1')::jsonb,
+    '{
+        "quotas": {
+            "max_repositories": 10,
+            "tracker_min_interval": 60
+        },
+        "usage": {
+            "repositories": 1,
+            "webhooks": 1,
+            "api_keys": 1
+        }
+    }'::jsonb,
+    'Quotas overridden and resources used expected'
+);
+select throws_ok(
+    $$ select get_organization_quotas('00000000-0000-0000-0000-000000000002', 'org1') $$,
+    42501,
+    'insufficient_privilege',
+    'User not belonging to the organization should not be able to get its quotas'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,42 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some users and an organization
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into user__organization (user_id, organization_id, confirmed, role) values(:'user1ID', :'org1ID', true, 'admin');
+insert into user__organization (user_id, organization_id, confirmed, role) values(:'user2ID', :'org1ID', false, 'viewer');
+
+-- Run some tests
+select is(
+    get_user_organization_role(:'user1ID', 'org1'),
+    'admin',
+    'User1 is an admin of Org1'
+);
+select is(
+    get_user_organization_role(:'user2ID', 'org1'),
+    null,
+    'User2 has no role in Org1 as its membership is not confirmed yet'
+);
+select is(
+    get_user_organization_role('00000000-0000-0000-0000-000000000009', 'org1'),
+    null,
+    'Non existing user has no role in Org1'
+);
+select is(
+    get_user_organization_role(:'user1ID', 'org9'),
+    null,
+    'User1 has no role in non existing org'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
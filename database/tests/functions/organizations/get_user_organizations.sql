@@ -52,6 +52,7 @@ select results_eq(
                     "home_url": "https://org1.com",
                     "logo_image_id": "00000000-0000-0000-0000-000000000001",
                     "confirmed": true,
+                    "role": "maintainer",
                     "members_count": 2
                 },
                 {
@@ -61,6 +62,7 @@ select results_eq(
                     "home_url": "https://org2.com",
                     "logo_image_id": "00000000-0000-0000-0000-000000000002",
                     "confirmed": false,
+                    "role": "maintainer",
                     "members_count": 0
                 }
             ]'::jsonb,
@@ -84,6 +86,7 @@ select results_eq(
                     "home_url": "https://org2.com",
                     "logo_image_id": "00000000-0000-0000-0000-000000000002",
                     "confirmed": false,
+                    "role": "maintainer",
                     "members_count": 0
                 }
             ]'::jsonb,
@@ -0,0 +1,53 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some users and organizations
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@email.com');
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user2ID', 'user2', 'firstname2', 'lastname2', 'user2@email.com');
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user3ID', 'user3', 'firstname3', 'lastname3', 'user3@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed, invitation_expires_at)
+values(:'user2ID', :'org1ID', false, current_timestamp - '1 hour'::interval);
+
+-- Resend invitation and check its expiration has been renewed
+select resend_organization_invitation(:'user1ID', 'org1', 'user2', 3600);
+select results_eq(
+    $$
+        select invitation_expires_at between current_timestamp + '59 minutes'::interval and current_timestamp + '61 minutes'::interval
+        from user__organization
+        where user_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$ values (true) $$,
+    'User2 invitation expiration should have been renewed'
+);
+
+-- Try resending an invitation for a confirmed member
+select throws_ok(
+    $$ select resend_organization_invitation('00000000-0000-0000-0000-000000000001', 'org1', 'user1', 3600) $$,
+    'invitation not found',
+    'Invitations cannot be resent to confirmed members'
+);
+
+-- Try resending an invitation without the required privileges
+select throws_ok(
+    $$ select resend_organization_invitation('00000000-0000-0000-0000-000000000003', 'org1', 'user2', 3600) $$,
+    42501,
+    'insufficient_privilege',
+    'User3 should not be able to resend invitations in organization1'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
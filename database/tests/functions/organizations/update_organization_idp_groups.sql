@@ -0,0 +1,48 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into organization_idp_group (organization_id, idp_group, role) values (:'org1ID', 'group0', 'owner');
+
+-- Run some tests
+select update_organization_idp_groups(:'user1ID', 'org1', '[
+    {"group": "group1", "role": "admin"},
+    {"group": "group2", "role": "viewer"}
+]');
+select results_eq(
+    $$
+        select idp_group, role
+        from organization_idp_group
+        where organization_id = '00000000-0000-0000-0000-000000000001'
+        order by idp_group
+    $$,
+    $$
+        values ('group1', 'admin'), ('group2', 'viewer')
+    $$,
+    'Organization groups should have been replaced'
+);
+select update_organization_idp_groups(:'user1ID', 'org1', '[]');
+select is_empty(
+    $$ select * from organization_idp_group $$,
+    'Organization groups should have been deleted'
+);
+select throws_ok(
+    $$ select update_organization_idp_groups('00000000-0000-0000-0000-000000000002', 'org1', '[]') $$,
+    42501,
+    'insufficient_privilege',
+    'User not belonging to the organization should not be able to update its groups'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
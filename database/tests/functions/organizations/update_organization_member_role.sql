@@ -0,0 +1,102 @@
+-- Start transaction and plan tests
+begin;
+select plan(8);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some users and an organization
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@email.com');
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user2ID', 'user2', 'firstname2', 'lastname2', 'user2@email.com');
+insert into "user" (user_id, alias, first_name, last_name, email)
+values (:'user3ID', 'user3', 'firstname3', 'lastname3', 'user3@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed, role) values(:'user1ID', :'org1ID', true, 'owner');
+insert into user__organization (user_id, organization_id, confirmed, role) values(:'user2ID', :'org1ID', true, 'maintainer');
+
+-- Update organization member role and check it succeeded
+select update_organization_member_role(:'user1ID', 'org1', 'user2', 'admin');
+select results_eq(
+    $$
+        select role
+        from user__organization
+        where user_id = '00000000-0000-0000-0000-000000000002'
+        and organization_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('admin')
+    $$,
+    'User2 should be an admin of organization1'
+);
+
+-- Try updating a member role without the required privileges
+select throws_ok(
+    $$ select update_organization_member_role('00000000-0000-0000-0000-000000000003', 'org1', 'user2', 'viewer') $$,
+    42501,
+    'insufficient_privilege',
+    'User3 should not be able to update members roles in organization1'
+);
+
+-- Try managing owners without being an owner
+insert into user__organization (user_id, organization_id, confirmed, role) values(:'user3ID', :'org1ID', true, 'viewer');
+select throws_ok(
+    $$ select update_organization_member_role('00000000-0000-0000-0000-000000000002', 'org1', 'user1', 'viewer') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to change the role of an owner as it is only an admin'
+);
+select throws_ok(
+    $$ select update_organization_member_role('00000000-0000-0000-0000-000000000002', 'org1', 'user3', 'owner') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to promote members to owners as it is only an admin'
+);
+delete from user__organization where user_id = :'user3ID';
+
+-- Try updating the role of a user not belonging to the organization
+select throws_ok(
+    $$ select update_organization_member_role('00000000-0000-0000-0000-000000000001', 'org1', 'user3', 'viewer') $$,
+    'user is not a member of the organization',
+    'User3 role cannot be updated as it does not belong to organization1'
+);
+
+-- Promote a member to owner being an owner
+select update_organization_member_role(:'user1ID', 'org1', 'user2', 'owner');
+select results_eq(
+    $$
+        select role
+        from user__organization
+        where user_id = '00000000-0000-0000-0000-000000000002'
+        and organization_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('owner')
+    $$,
+    'User2 should be an owner of organization1'
+);
+update user__organization set role = 'admin' where user_id = :'user2ID';
+
+-- Try demoting the last owner of the organization
+select throws_ok(
+    $$ select update_organization_member_role('00000000-0000-0000-0000-000000000001', 'org1', 'user1', 'admin') $$,
+    'last owner of an organization cannot be demoted',
+    'User1 should not be demoted as it is the only owner of organization1'
+);
+
+-- Try using an invalid role
+select throws_ok(
+    $$ select update_organization_member_role('00000000-0000-0000-0000-000000000001', 'org1', 'user2', 'invalid') $$,
+    23514,
+    'new row for relation "user__organization" violates check constraint "user__organization_role_check"',
+    'Invalid roles should not be accepted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
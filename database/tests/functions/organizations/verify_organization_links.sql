@@ -0,0 +1,42 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into organization_link (organization_id, url) values (:'org1ID', 'https://org1.com');
+insert into organization_link (organization_id, url) values (:'org1ID', 'https://blog.org1.com');
+
+-- Run some tests
+select verify_organization_links(:'user1ID', 'org1', '{https://org1.com}');
+select results_eq(
+    $$
+        select url, verified, verified_at is not null
+        from organization_link
+        order by url
+    $$,
+    $$
+        values
+            ('https://blog.org1.com', false, false),
+            ('https://org1.com', true, true)
+    $$,
+    'Only the link provided should have been verified'
+);
+select throws_ok(
+    $$ select verify_organization_links('00000000-0000-0000-0000-000000000002', 'org1', '{https://blog.org1.com}') $$,
+    42501,
+    'insufficient_privilege',
+    'User not belonging to the organization should not be able to verify its links'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,39 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Test function
+select is(
+    generate_package_search_data(0, '{"apiVersion": "v2", "kubeVersion": ">=1.16.0-0", "type": "application"}'),
+    '{"kube_version": [">=1.16.0-0"], "chart_type": ["application"]}'::jsonb,
+    'Helm chart: kube version and chart type expected'
+);
+select is(
+    generate_package_search_data(0, '{"kubeVersion": ">=1.19.0-0", "kubeVersionRanges": [{"min": "1.19"}]}'),
+    '{"kube_version": [">=1.19.0-0"], "kube_version_range": [{"min": "1.19"}]}'::jsonb,
+    'Helm chart: kube version and kube version ranges expected'
+);
+select is(
+    generate_package_search_data(0, '{"apiVersion": "v2", "kubeVersion": ""}'),
+    null::jsonb,
+    'Helm chart without kube version and chart type: null expected'
+);
+select is(
+    generate_package_search_data(5, '{"platforms": ["darwin/amd64", "linux/amd64"]}'),
+    '{"platform": ["darwin/amd64", "linux/amd64"]}'::jsonb,
+    'Krew plugin: platforms expected'
+);
+select is(
+    generate_package_search_data(7, '{"params": ["url", "revision"]}'),
+    '{"task_param": ["url", "revision"]}'::jsonb,
+    'Tekton task: params expected'
+);
+select is(
+    generate_package_search_data(1, '{"rules": []}'),
+    null::jsonb,
+    'Falco rules: null expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
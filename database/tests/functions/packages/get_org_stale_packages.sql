@@ -0,0 +1,154 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+\set package4ID '00000000-0000-0000-0000-000000000004'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name)
+values (:'org1ID', 'org1', 'Organization 1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id, last_release_ts, stale)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID', '2020-01-01 00:00:00+00', true);
+insert into package (package_id, name, latest_version, repository_id, last_release_ts, stale)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID', '2020-06-16 11:20:34+02', false);
+insert into package (package_id, name, latest_version, repository_id, last_release_ts, stale)
+values (:'package3ID', 'package3', '1.0.0', :'repo2ID', '2019-06-01 00:00:00+00', true);
+insert into package (package_id, name, latest_version, repository_id, last_release_ts, stale)
+values (:'package4ID', 'package4', '1.0.0', :'repo2ID', '2019-01-01 00:00:00+00', true);
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '1.0.0', '2020-01-01 00:00:00+00');
+insert into snapshot (package_id, version, ts)
+values (:'package2ID', '1.0.0', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, ts)
+values (:'package3ID', '1.0.0', '2019-06-01 00:00:00+00');
+insert into snapshot (package_id, version, ts)
+values (:'package4ID', '1.0.0', '2019-01-01 00:00:00+00');
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_org_stale_packages('00000000-0000-0000-0000-000000000001', 'org1', 0, 0)
+    $$,
+    $$
+        values(
+            '[
+                {
+                    "package_id": "00000000-0000-0000-0000-000000000004",
+                    "name": "package4",
+                    "normalized_name": "package4",
+                    "stars": 0,
+                    "version": "1.0.0",
+                    "ts": 1546300800,
+                    "last_release_ts": 1546300800,
+                    "repository": {
+                        "repository_id": "00000000-0000-0000-0000-000000000002",
+                        "kind": 0,
+                        "name": "repo2",
+                        "display_name": "Repo 2",
+                        "url": "https://repo2.com",
+                        "private": false,
+                        "verified_publisher": false,
+                        "domain_verified": false,
+                        "official": false,
+                        "scanner_disabled": false,
+                        "organization_name": "org1",
+                        "organization_display_name": "Organization 1"
+                    }
+                },
+                {
+                    "package_id": "00000000-0000-0000-0000-000000000003",
+                    "name": "package3",
+                    "normalized_name": "package3",
+                    "stars": 0,
+                    "version": "1.0.0",
+                    "ts": 1559347200,
+                    "last_release_ts": 1559347200,
+                    "repository": {
+                        "repository_id": "00000000-0000-0000-0000-000000000002",
+                        "kind": 0,
+                        "name": "repo2",
+                        "display_name": "Repo 2",
+                        "url": "https://repo2.com",
+                        "private": false,
+                        "verified_publisher": false,
+                        "domain_verified": false,
+                        "official": false,
+                        "scanner_disabled": false,
+                        "organization_name": "org1",
+                        "organization_display_name": "Organization 1"
+                    }
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'Stale packages from repositories owned by the organization should be returned (oldest first)'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_org_stale_packages('00000000-0000-0000-0000-000000000001', 'org1', 1, 1)
+    $$,
+    $$
+        values(
+            '[
+                {
+                    "package_id": "00000000-0000-0000-0000-000000000003",
+                    "name": "package3",
+                    "normalized_name": "package3",
+                    "stars": 0,
+                    "version": "1.0.0",
+                    "ts": 1559347200,
+                    "last_release_ts": 1559347200,
+                    "repository": {
+                        "repository_id": "00000000-0000-0000-0000-000000000002",
+                        "kind": 0,
+                        "name": "repo2",
+                        "display_name": "Repo 2",
+                        "url": "https://repo2.com",
+                        "private": false,
+                        "verified_publisher": false,
+                        "domain_verified": false,
+                        "official": false,
+                        "scanner_disabled": false,
+                        "organization_name": "org1",
+                        "organization_display_name": "Organization 1"
+                    }
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'Only the second stale package of the organization should be returned (limit: 1, offset: 1)'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_org_stale_packages('00000000-0000-0000-0000-000000000002', 'org1', 0, 0)
+    $$,
+    $$
+        values('[]'::jsonb, 0)
+    $$,
+    'No stale packages expected as user2 does not belong to the organization'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(5);
+select plan(7);
 
 -- Declare some variables
 \set org1ID '00000000-0000-0000-0000-000000000001'
@@ -246,6 +246,7 @@ select is(
         "display_name": "Package 1",
         "description": "description",
         "logo_image_id": "00000000-0000-0000-0000-000000000001",
+        "logo_generated": false,
         "keywords": ["kw1", "kw2"],
         "home_url": "home_url",
         "readme": "readme-version-1.0.0",
@@ -307,7 +308,11 @@ select is(
         "all_containers_images_whitelisted": true,
         "provider": "Org Inc",
         "has_values_schema": true,
+        "values_schema_inferred": false,
+        "has_values_docs": false,
+        "has_dependencies_tree": false,
         "has_changelog": true,
+        "has_sbom": false,
         "changes": [
             {
                 "kind": "added",
@@ -348,6 +353,7 @@ select is(
             "url": "https://repo1.com",
             "private": false,
             "verified_publisher": false,
+            "domain_verified": false,
             "official": false,
             "scanner_disabled": false,
             "user_alias": "user1"
@@ -384,6 +390,7 @@ select is(
         "display_name": "Package 1",
         "description": "description",
         "logo_image_id": "00000000-0000-0000-0000-000000000001",
+        "logo_generated": false,
         "keywords": ["kw1", "kw2"],
         "home_url": "home_url",
         "readme": "readme-version-1.0.0",
@@ -445,7 +452,11 @@ select is(
         "all_containers_images_whitelisted": true,
         "provider": "Org Inc",
         "has_values_schema": true,
+        "values_schema_inferred": false,
+        "has_values_docs": false,
+        "has_dependencies_tree": false,
         "has_changelog": true,
+        "has_sbom": false,
         "changes": [
             {
                 "kind": "added",
@@ -486,6 +497,7 @@ select is(
             "url": "https://repo1.com",
             "private": false,
             "verified_publisher": false,
+            "domain_verified": false,
             "official": false,
             "scanner_disabled": false,
             "user_alias": "user1"
@@ -523,6 +535,7 @@ select is(
         "display_name": "Package 1 (older)",
         "description": "description (older)",
         "logo_image_id": "00000000-0000-0000-0000-000000000001",
+        "logo_generated": false,
         "keywords": ["kw1", "kw2", "older"],
         "home_url": "home_url (older)",
         "readme": "readme-version-0.0.9",
@@ -561,7 +574,11 @@ select is(
         "contains_security_updates": false,
         "prerelease": false,
         "has_values_schema": false,
+        "values_schema_inferred": false,
+        "has_values_docs": false,
+        "has_dependencies_tree": false,
         "has_changelog": true,
+        "has_sbom": false,
         "ts": 1592299233,
         "maintainers": [
             {
@@ -581,6 +598,7 @@ select is(
             "url": "https://repo1.com",
             "private": false,
             "verified_publisher": false,
+            "domain_verified": false,
             "official": false,
             "scanner_disabled": false,
             "user_alias": "user1"
@@ -604,6 +622,7 @@ select is(
         "display_name": "Package 2",
         "description": "description",
         "logo_image_id": "00000000-0000-0000-0000-000000000002",
+        "logo_generated": false,
         "keywords": ["kw1", "kw2"],
         "readme": "readme-version-1.0.0",
         "install": "install-version-1.0.0",
@@ -611,7 +630,11 @@ select is(
             "key": "value"
         },
         "has_values_schema": false,
+        "values_schema_inferred": false,
+        "has_values_docs": false,
+        "has_dependencies_tree": false,
         "has_changelog": false,
+        "has_sbom": false,
         "ts": 1592299234,
         "version": "1.0.0",
         "available_versions": [
@@ -628,6 +651,7 @@ select is(
             "url": "https://repo2.com",
             "private": false,
             "verified_publisher": false,
+            "domain_verified": false,
             "official": false,
             "scanner_disabled": false,
             "organization_name": "org1",
@@ -641,6 +665,52 @@ select is(
     'Last package2 version is returned as a json object'
 );
 
+-- Package level fields are up to date even when the document was built before
+select refresh_package_documents(:'package2ID');
+update package set official = true where package_id = :'package2ID';
+insert into package__maintainer (package_id, maintainer_id)
+values (:'package2ID', :'maintainer2ID');
+select is(
+    (
+        select jsonb_build_object('official', p->'official', 'maintainers', p->'maintainers')
+        from (
+            select get_package('{
+                "package_name": "package2",
+                "repository_name": "repo2"
+            }')::jsonb as p
+        ) as pkg
+    ),
+    '{
+        "official": true,
+        "maintainers": [
+            {
+                "name": "name2",
+                "email": "email2"
+            }
+        ]
+    }'::jsonb,
+    'Package level fields are not taken from the stored document'
+);
+
+-- Freshness information is included once it has been computed
+update repository_kind set median_release_cadence = '30 days' where repository_kind_id = 0;
+update package set
+    last_release_ts = current_timestamp - '10 days'::interval,
+    stale = false
+where package_id = :'package2ID';
+select is(
+    (get_package('{
+        "package_name": "package2",
+        "repository_name": "repo2"
+    }')::jsonb->'freshness') - 'last_release_ts',
+    '{
+        "days_since_last_release": 10,
+        "kind_median_release_cadence_days": 30,
+        "stale": false
+    }'::jsonb,
+    'Package freshness information is returned'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
@@ -0,0 +1,79 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into repository (repository_id, name, display_name, url, auth_user, auth_pass, repository_kind_id, organization_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 'user', 'pass', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package1', '1.0.0', :'repo2ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'package3', '1.0.0', :'repo3ID');
+insert into snapshot (package_id, version, digest, created_at)
+values (:'package1ID', '1.0.0', 'digest1', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, digest, upstream, created_at)
+values (:'package2ID', '1.0.0', 'digest1', '{"instance_url": "https://artifacthub.io"}', '2020-06-17 11:20:34+02');
+insert into snapshot (package_id, version, digest)
+values (:'package3ID', '1.0.0', 'digest3');
+
+-- Run some tests
+select is(
+    get_package_by_digest('digest1')::jsonb,
+    '{
+        "package_id": "00000000-0000-0000-0000-000000000001",
+        "name": "package1",
+        "normalized_name": "package1",
+        "version": "1.0.0",
+        "digest": "digest1",
+        "repository": {
+            "repository_id": "00000000-0000-0000-0000-000000000001",
+            "kind": 0,
+            "name": "repo1",
+            "display_name": "Repo 1",
+            "url": "https://repo1.com",
+            "private": false,
+            "verified_publisher": false,
+            "domain_verified": false,
+            "official": false,
+            "scanner_disabled": false,
+            "organization_name": "org1",
+            "organization_display_name": "Organization 1"
+        }
+    }'::jsonb,
+    'Package version registered first with the digest provided should be returned'
+);
+delete from snapshot where package_id = :'package1ID';
+select is(
+    get_package_by_digest('digest1')::jsonb->'upstream',
+    '{"instance_url": "https://artifacthub.io"}'::jsonb,
+    'Upstream attribution of the package version found should be included'
+);
+select is_empty(
+    $$ select get_package_by_digest('digest3') $$,
+    'No results expected for packages in private repositories'
+);
+select is_empty(
+    $$ select get_package_by_digest('digest4') $$,
+    'No results expected for inexisting digest'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
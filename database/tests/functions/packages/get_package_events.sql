@@ -0,0 +1,76 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '2.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+insert into event (package_id, package_version, event_kind_id, created_at)
+values (:'package1ID', '1.0.0', 0, '2020-06-16 11:20:30+02');
+insert into event (package_id, package_version, event_kind_id, created_at)
+values (:'package1ID', '1.0.0', 1, '2020-06-16 11:20:31+02');
+insert into event (repository_id, event_kind_id, data, created_at)
+values (:'repo1ID', 3, '{"subscriptors": []}', '2020-06-16 11:20:32+02');
+insert into event (package_id, package_version, event_kind_id, created_at)
+values (:'package1ID', '2.0.0', 0, '2020-06-16 11:20:33+02');
+insert into event (package_id, package_version, event_kind_id, created_at)
+values (:'package1ID', '2.0.0', 5, '2020-06-16 11:20:34+02');
+insert into event (repository_id, event_kind_id, created_at)
+values (:'repo1ID', 2, '2020-06-16 11:20:35+02');
+insert into event (package_id, package_version, event_kind_id, created_at)
+values (:'package2ID', '1.0.0', 0, '2020-06-16 11:20:36+02');
+
+-- Run some tests
+select is(
+    get_package_events(:'package1ID')::jsonb,
+    '[
+        {
+            "event_kind": 5,
+            "version": "2.0.0",
+            "ts": 1592299234
+        },
+        {
+            "event_kind": 0,
+            "version": "2.0.0",
+            "ts": 1592299233
+        },
+        {
+            "event_kind": 3,
+            "ts": 1592299232
+        },
+        {
+            "event_kind": 1,
+            "version": "1.0.0",
+            "ts": 1592299231
+        },
+        {
+            "event_kind": 0,
+            "version": "1.0.0",
+            "ts": 1592299230
+        }
+    ]'::jsonb,
+    'Package events should be returned'
+);
+select is(
+    get_package_events('00000000-0000-0000-0000-000000000003')::jsonb,
+    '[]'::jsonb,
+    'Empty list of events should be returned for inexistent package'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
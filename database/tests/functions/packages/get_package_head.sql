@@ -0,0 +1,68 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, digest, created_at)
+values (:'package1ID', '1.0.0', 'digest-package1-1.0.0', '2020-06-16 11:20:33+02');
+insert into snapshot (package_id, version, digest, created_at)
+values (:'package1ID', '0.0.9', 'digest-package1-0.0.9', '2020-06-16 11:20:34+02');
+
+-- Run some tests
+select is(
+    get_package_head('{
+        "repository_name": "repo1",
+        "package_name": "package-1"
+    }')::jsonb,
+    '{
+        "digest": "digest-package1-1.0.0",
+        "last_modified": 1592299233
+    }'::jsonb,
+    'Head of latest package version is returned as a json object'
+);
+select is(
+    get_package_head('{
+        "repository_name": "repo1",
+        "package_name": "package-1",
+        "version": "0.0.9"
+    }')::jsonb,
+    '{
+        "digest": "digest-package1-0.0.9",
+        "last_modified": 1592299234
+    }'::jsonb,
+    'Head of package version 0.0.9 is returned as a json object'
+);
+select is_empty(
+    $$
+        select get_package_head('{
+            "repository_name": "repo1",
+            "package_name": "package-1",
+            "version": "2.0.0"
+        }')
+    $$,
+    'No rows are returned when the package version does not exist'
+);
+select is_empty(
+    $$
+        select get_package_head('{
+            "repository_name": "repo2",
+            "package_name": "package-1"
+        }')
+    $$,
+    'No rows are returned when the package does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -89,6 +89,7 @@ select is(
             "url": "https://repo1.com",
             "private": false,
             "verified_publisher": false,
+            "domain_verified": false,
             "official": false,
             "scanner_disabled": false,
             "organization_name": "org1",
@@ -130,6 +131,7 @@ select is(
             "url": "https://repo1.com",
             "private": false,
             "verified_publisher": false,
+            "domain_verified": false,
             "official": false,
             "scanner_disabled": false,
             "organization_name": "org1",
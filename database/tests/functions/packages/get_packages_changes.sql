@@ -0,0 +1,100 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- No changes at this point
+select is(
+    get_packages_changes('{"limit": 10}')::jsonb,
+    '[]'::jsonb,
+    'No changes registered yet, empty list expected'
+);
+
+-- Seed some data
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version)
+values (:'package1ID', '1.0.0');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID');
+
+-- Run some tests
+select is(
+    (select
+        jsonb_agg(jsonb_build_object(
+            'kind', c->>'kind',
+            'package_name', c->>'package_name',
+            'repository_name', c->>'repository_name',
+            'repository_kind', (c->>'repository_kind')::int
+        ))
+    from jsonb_array_elements(get_packages_changes('{"limit": 10}')::jsonb) c),
+    '[
+        {
+            "kind": "added",
+            "package_name": "package1",
+            "repository_name": "repo1",
+            "repository_kind": 0
+        },
+        {
+            "kind": "added",
+            "package_name": "package2",
+            "repository_name": "repo1",
+            "repository_kind": 0
+        }
+    ]'::jsonb,
+    'Two added changes expected, snapshot in same transaction ignored'
+);
+select is(
+    jsonb_array_length(get_packages_changes('{"limit": 1}')::jsonb),
+    1,
+    'Only one change expected when limit is 1'
+);
+select is(
+    (select jsonb_agg(c->>'package_name') from jsonb_array_elements(
+        get_packages_changes(jsonb_build_object(
+            'after', (select min(package_change_id) from package_change),
+            'limit', 10
+        ))::jsonb
+    ) c),
+    '["package2"]'::jsonb,
+    'Only changes after the sequence provided expected'
+);
+select is(
+    get_packages_changes(jsonb_build_object(
+        'since', floor(extract(epoch from current_timestamp + '1 day'::interval)),
+        'limit', 10
+    ))::jsonb,
+    '[]'::jsonb,
+    'No changes expected since a timestamp in the future'
+);
+
+-- Remove a package and check the removal is registered
+delete from package where package_id = :'package1ID';
+select is(
+    (select jsonb_build_object(
+        'kind', c->>'kind',
+        'package_id', c->>'package_id',
+        'repository_name', c->>'repository_name'
+    ) from jsonb_array_elements(get_packages_changes('{"limit": 10}')::jsonb) c
+    order by (c->>'sequence')::bigint desc
+    limit 1),
+    jsonb_build_object(
+        'kind', 'removed',
+        'package_id', :'package1ID',
+        'repository_name', 'repo1'
+    ),
+    'Package removal expected as last change'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,120 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- No packages at this point
+select is(
+    get_packages_comparison(array[:'package1ID', :'package2ID']::uuid[])::jsonb,
+    '[]'::jsonb,
+    'No packages in db yet, no packages expected'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, stars, repository_id) values
+    (:'package1ID', 'package1', '1.0.0', 10, :'repo1ID'),
+    (:'package2ID', 'package2', '2.0.0', 3, :'repo1ID'),
+    (:'package3ID', 'package3', '1.0.0', 0, :'repo1ID');
+insert into snapshot (
+    package_id,
+    version,
+    app_version,
+    license,
+    capabilities,
+    security_report_summary,
+    data
+) values
+    (:'package1ID', '0.9.0', '11.0.0', 'MIT', null, null, null),
+    (:'package1ID', '1.0.0', '12.0.0', 'Apache-2.0', 'basic install', '{"high": 2, "medium": 1}', '{"kubeVersion": ">=1.19.0"}'),
+    (:'package2ID', '2.0.0', null, 'MIT', null, '{"low": 0, "medium": 0}', null),
+    (:'package3ID', '1.0.0', null, null, null, null, null);
+
+-- Run some tests
+select is(
+    get_packages_comparison(array[:'package2ID', :'package1ID']::uuid[])::jsonb,
+    '[
+        {
+            "package_id": "00000000-0000-0000-0000-000000000002",
+            "name": "package2",
+            "normalized_name": "package2",
+            "display_name": null,
+            "logo_image_id": null,
+            "latest_version": "2.0.0",
+            "app_version": null,
+            "license": "MIT",
+            "capabilities": null,
+            "security_rating": "A",
+            "security_report_summary": {"low": 0, "medium": 0},
+            "stars": 3,
+            "kubernetes_version": null,
+            "repository": {
+                "repository_id": "00000000-0000-0000-0000-000000000001",
+                "kind": 0,
+                "name": "repo1",
+                "display_name": "Repo 1",
+                "url": "https://repo1.com",
+                "private": false,
+                "verified_publisher": false,
+                "domain_verified": false,
+                "official": false,
+                "scanner_disabled": false,
+                "user_alias": "user1"
+            }
+        },
+        {
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "name": "package1",
+            "normalized_name": "package1",
+            "display_name": null,
+            "logo_image_id": null,
+            "latest_version": "1.0.0",
+            "app_version": "12.0.0",
+            "license": "Apache-2.0",
+            "capabilities": "basic install",
+            "security_rating": "D",
+            "security_report_summary": {"high": 2, "medium": 1},
+            "stars": 10,
+            "kubernetes_version": ">=1.19.0",
+            "repository": {
+                "repository_id": "00000000-0000-0000-0000-000000000001",
+                "kind": 0,
+                "name": "repo1",
+                "display_name": "Repo 1",
+                "url": "https://repo1.com",
+                "private": false,
+                "verified_publisher": false,
+                "domain_verified": false,
+                "official": false,
+                "scanner_disabled": false,
+                "user_alias": "user1"
+            }
+        }
+    ]'::jsonb,
+    'Packages comparison details should be returned in the order provided'
+);
+select is(
+    get_packages_comparison(array[:'package3ID']::uuid[])::jsonb->0->'security_rating',
+    'null'::jsonb,
+    'Packages without security report should not have a security rating'
+);
+select is(
+    jsonb_array_length(get_packages_comparison(array[
+        :'package1ID',
+        '00000000-0000-0000-0000-000000000009'
+    ]::uuid[])::jsonb),
+    1,
+    'Packages not found should be ignored'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,51 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- No packages at this point
+select is(
+    get_packages_discovery('hidden-gems')::jsonb,
+    '[]'::jsonb,
+    'No packages in db yet, no packages expected'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version) values
+    (:'package1ID', '1.0.0'),
+    (:'package2ID', '1.0.0');
+insert into package_discovery (category, package_id, position) values
+    ('hidden-gems', :'package2ID', 1),
+    ('hidden-gems', :'package1ID', 2),
+    ('new-this-week', :'package1ID', 1);
+
+-- Run some tests
+select is(
+    array(
+        select e->>'name'
+        from jsonb_array_elements(get_packages_discovery('hidden-gems')::jsonb) e
+    ),
+    array['package2', 'package1'],
+    'Packages in the hidden gems category should be returned in order'
+);
+select is(
+    get_packages_discovery('recently-verified')::jsonb,
+    '[]'::jsonb,
+    'No packages in the recently verified category, no packages expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
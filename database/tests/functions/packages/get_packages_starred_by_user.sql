@@ -179,6 +179,7 @@ select results_eq(
                         "url": "https://repo1.com",
                         "private": false,
                         "verified_publisher": false,
+                        "domain_verified": false,
                         "official": false,
                         "scanner_disabled": false,
                         "organization_name": "org1",
@@ -204,6 +205,7 @@ select results_eq(
                         "url": "https://repo1.com",
                         "private": false,
                         "verified_publisher": false,
+                        "domain_verified": false,
                         "official": false,
                         "scanner_disabled": false,
                         "organization_name": "org1",
@@ -242,6 +244,7 @@ select results_eq(
                     "url": "https://repo1.com",
                     "private": false,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "scanner_disabled": false,
                     "organization_name": "org1",
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(3);
 
 -- Declare some variables
 \set org1ID '00000000-0000-0000-0000-000000000001'
@@ -202,6 +202,7 @@ select jsonb_set('[
             "url": "https://repo2.com",
             "private": false,
             "verified_publisher": false,
+            "domain_verified": false,
             "official": false,
             "scanner_disabled": false,
             "organization_name": "org1",
@@ -214,6 +215,12 @@ select is(
     :'expected_random_packages',
     'One random package expected (package1)'
 );
+insert into package_discovery (category, package_id, position) values ('hidden-gems', :'package2ID', 1);
+select is(
+    array(select e->>'name' from jsonb_array_elements(get_random_packages()::jsonb) e),
+    array['package2'],
+    'Packages listed in the discovery categories expected when available (package2)'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
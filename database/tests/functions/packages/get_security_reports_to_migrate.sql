@@ -0,0 +1,61 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- No security reports at this point
+select is(
+    get_security_reports_to_migrate(10)::jsonb,
+    '[]'::jsonb,
+    'No security reports to migrate expected'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, security_report, security_report_created_at)
+values (:'package1ID', '1.0.0', '{"quay.io/org/pkg1:1.0.0": {}}', '2021-01-02');
+insert into snapshot (package_id, version, security_report, security_report_created_at)
+values (:'package1ID', '0.0.9', '{"quay.io/org/pkg1:0.0.9": {}}', '2021-01-01');
+insert into snapshot (package_id, version, security_report_location, security_report_created_at)
+values (:'package1ID', '0.0.8', 's3://bucket/security-reports/pkg1/0.0.8.json', '2020-12-31');
+
+-- Run some tests
+select is(
+    get_security_reports_to_migrate(1)::jsonb,
+    '[
+        {
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "version": "0.0.9",
+            "images_reports": {"quay.io/org/pkg1:0.0.9": {}}
+        }
+    ]'::jsonb,
+    'Oldest security report stored in the database expected'
+);
+select is(
+    get_security_reports_to_migrate(10)::jsonb,
+    '[
+        {
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "version": "0.0.9",
+            "images_reports": {"quay.io/org/pkg1:0.0.9": {}}
+        },
+        {
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "version": "1.0.0",
+            "images_reports": {"quay.io/org/pkg1:1.0.0": {}}
+        }
+    ]'::jsonb,
+    'Security reports stored in the database expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,75 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+\set image1ID '00000000-0000-0000-0000-000000000001'
+
+-- No snapshots at this point
+select is(
+    get_snapshots_without_logo(10)::jsonb,
+    '[]'::jsonb,
+    'No snapshots without logo expected'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, created_at)
+values (:'package1ID', '1.0.0', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, created_at)
+values (:'package1ID', '0.0.9', '2020-06-16 11:20:33+02');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, logo_image_id)
+values (:'package2ID', '1.0.0', :'image1ID');
+insert into snapshot (package_id, version)
+values (:'package2ID', '0.0.9');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'package3', '2.0.0', :'repo1ID');
+insert into snapshot (package_id, version, created_at)
+values (:'package3ID', '2.0.0', '2020-06-16 11:20:35+02');
+
+-- Run some tests
+select is(
+    get_snapshots_without_logo(10)::jsonb,
+    '[
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000001",
+            "package_id": "00000000-0000-0000-0000-000000000003",
+            "package_name": "package3",
+            "version": "2.0.0"
+        },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000001",
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "package_name": "package1",
+            "version": "1.0.0"
+        }
+    ]'::jsonb,
+    'Latest versions of packages without logo expected'
+);
+select is(
+    get_snapshots_without_logo(1)::jsonb,
+    '[
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000001",
+            "package_id": "00000000-0000-0000-0000-000000000003",
+            "package_name": "package3",
+            "version": "2.0.0"
+        }
+    ]'::jsonb,
+    'Only one snapshot expected when limit is 1'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,104 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+\set package4ID '00000000-0000-0000-0000-000000000004'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name)
+values (:'org1ID', 'org1', 'Organization 1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id, last_release_ts, stale)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID', '2020-01-01 00:00:00+00', true);
+insert into package (package_id, name, latest_version, repository_id, last_release_ts, stale)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID', '2020-06-16 11:20:34+02', false);
+insert into package (package_id, name, latest_version, repository_id, last_release_ts, stale)
+values (:'package3ID', 'package3', '1.0.0', :'repo2ID', '2019-06-01 00:00:00+00', true);
+insert into package (package_id, name, latest_version, repository_id, last_release_ts, stale)
+values (:'package4ID', 'package4', '1.0.0', :'repo2ID', '2019-01-01 00:00:00+00', true);
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '1.0.0', '2020-01-01 00:00:00+00');
+insert into snapshot (package_id, version, ts)
+values (:'package2ID', '1.0.0', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, ts)
+values (:'package3ID', '1.0.0', '2019-06-01 00:00:00+00');
+insert into snapshot (package_id, version, ts)
+values (:'package4ID', '1.0.0', '2019-01-01 00:00:00+00');
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_user_stale_packages('00000000-0000-0000-0000-000000000001', 0, 0)
+    $$,
+    $$
+        values(
+            '[
+                {
+                    "package_id": "00000000-0000-0000-0000-000000000001",
+                    "name": "package1",
+                    "normalized_name": "package1",
+                    "stars": 0,
+                    "version": "1.0.0",
+                    "ts": 1577836800,
+                    "last_release_ts": 1577836800,
+                    "repository": {
+                        "repository_id": "00000000-0000-0000-0000-000000000001",
+                        "kind": 0,
+                        "name": "repo1",
+                        "display_name": "Repo 1",
+                        "url": "https://repo1.com",
+                        "private": false,
+                        "verified_publisher": false,
+                        "domain_verified": false,
+                        "official": false,
+                        "scanner_disabled": false,
+                        "user_alias": "user1"
+                    }
+                }
+            ]'::jsonb,
+            1
+        )
+    $$,
+    'Only stale packages from repositories owned by the user should be returned'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_user_stale_packages('00000000-0000-0000-0000-000000000002', 0, 0)
+    $$,
+    $$
+        values('[]'::jsonb, 0)
+    $$,
+    'No stale packages expected for user2'
+);
+update package set stale = false where package_id = :'package1ID';
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_user_stale_packages('00000000-0000-0000-0000-000000000001', 0, 0)
+    $$,
+    $$
+        values('[]'::jsonb, 0)
+    $$,
+    'No stale packages expected once package1 is not stale anymore'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
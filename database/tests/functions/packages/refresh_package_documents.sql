@@ -0,0 +1,54 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, description, ts)
+values (:'package1ID', '1.0.0', 'description', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, description, ts)
+values (:'package1ID', '0.0.9', 'description', '2020-06-16 11:20:33+02');
+
+-- No documents at this point
+select is_empty(
+    $$ select * from package_document $$,
+    'No documents should exist yet'
+);
+
+-- Refresh documents of a single version
+select refresh_package_documents(:'package1ID', '1.0.0');
+select results_eq(
+    $$ select version, document->>'description' from package_document $$,
+    $$ values ('1.0.0', 'description') $$,
+    'Only the document of version 1.0.0 should exist'
+);
+
+-- Refresh documents of all versions
+update snapshot set description = 'description updated' where package_id = :'package1ID';
+select refresh_package_documents(:'package1ID');
+select results_eq(
+    $$ select version, document->>'description' from package_document order by version $$,
+    $$ values ('0.0.9', 'description updated'), ('1.0.0', 'description updated') $$,
+    'Documents of all versions should exist and be up to date'
+);
+select is_empty(
+    $$
+        select *
+        from package_document
+        where document ?| array['name', 'official', 'available_versions', 'has_changelog', 'maintainers']
+    $$,
+    'Documents should not include fields that depend on the package or other versions'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,82 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set maintainer1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, contains_security_updates, prerelease, ts)
+values (:'package1ID', '1.0.0', false, false, '2020-06-16 11:20:34+02');
+insert into maintainer (maintainer_id, name, email)
+values (:'maintainer1ID', 'name1', 'email1');
+insert into package__maintainer (package_id, maintainer_id)
+values (:'package1ID', :'maintainer1ID');
+
+-- No document at this point
+select results_eq(
+    $$ select document from package $$,
+    $$ values (null::jsonb) $$,
+    'No document should exist yet'
+);
+
+-- Refresh document
+select refresh_package_level_document(:'package1ID');
+select is(
+    (select document from package),
+    '{
+        "available_versions": [
+            {
+                "version": "1.0.0",
+                "contains_security_updates": false,
+                "prerelease": false,
+                "ts": 1592299234
+            }
+        ],
+        "has_changelog": false,
+        "maintainers": [
+            {
+                "name": "name1",
+                "email": "email1"
+            }
+        ]
+    }'::jsonb,
+    'Document should include the package versions and maintainers'
+);
+
+-- Refresh document after adding a version and updating the maintainer
+insert into snapshot (package_id, version, changes, ts)
+values (:'package1ID', '1.1.0', '[{"description": "feature"}]', '2020-06-17 11:20:34+02');
+update maintainer set name = 'name1 updated' where maintainer_id = :'maintainer1ID';
+select refresh_package_level_document(:'package1ID');
+select results_eq(
+    $$
+        select
+            jsonb_array_length(document->'available_versions'),
+            (document->>'has_changelog')::boolean,
+            document->'maintainers'->0->>'name'
+        from package
+    $$,
+    $$ values (2, true, 'name1 updated') $$,
+    'Document should be up to date'
+);
+select is_empty(
+    $$
+        select *
+        from package
+        where document ?| array['name', 'version', 'description']
+    $$,
+    'Document should not include fields specific to a version'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
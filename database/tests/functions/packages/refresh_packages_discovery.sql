@@ -0,0 +1,76 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+\set package4ID '00000000-0000-0000-0000-000000000004'
+\set package5ID '00000000-0000-0000-0000-000000000005'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, verified_publisher, verified_publisher_at)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID', true, current_timestamp - '5 days'::interval);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, verified_publisher, verified_publisher_at)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 0, :'user1ID', true, current_timestamp - '60 days'::interval);
+insert into package (package_id, name, latest_version, stars, repository_id)
+values (:'package1ID', 'package1', '1.0.0', 0, :'repo1ID');
+insert into package (package_id, name, latest_version, stars, repository_id, created_at)
+values (:'package2ID', 'package2', '1.0.0', 100, :'repo1ID', current_timestamp - '30 days'::interval);
+insert into package (package_id, name, latest_version, stars, repository_id, created_at)
+values (:'package3ID', 'package3', '1.0.0', 5, :'repo2ID', current_timestamp - '30 days'::interval);
+insert into package (package_id, name, latest_version, stars, repository_id, created_at)
+values (:'package4ID', 'package4', '1.0.0', 10, :'repo2ID', current_timestamp - '30 days'::interval);
+insert into package (package_id, name, latest_version, stars, repository_id, created_at)
+values (:'package5ID', 'package5', '1.0.0', 0, :'repo3ID', current_timestamp - '30 days'::interval);
+insert into snapshot (package_id, version, readme, license, signed, deprecated, security_report_summary) values
+    (:'package1ID', '1.0.0', 'readme', 'Apache-2.0', true, false, null),
+    (:'package2ID', '1.0.0', 'readme', 'Apache-2.0', true, false, null),
+    (:'package3ID', '1.0.0', 'readme', null, false, false, null),
+    (:'package4ID', '1.0.0', 'readme', 'Apache-2.0', true, true, null),
+    (:'package5ID', '1.0.0', 'readme', 'Apache-2.0', false, false, '{"medium": 1}');
+
+-- Run some tests
+select refresh_packages_discovery(10, 10, 4);
+select results_eq(
+    $$
+        select category, package_id, position
+        from package_discovery
+        order by category, position
+    $$,
+    $$
+        values
+            ('hidden-gems', '00000000-0000-0000-0000-000000000005'::uuid, 1),
+            ('hidden-gems', '00000000-0000-0000-0000-000000000001'::uuid, 2),
+            ('new-this-week', '00000000-0000-0000-0000-000000000001'::uuid, 1),
+            ('recently-verified', '00000000-0000-0000-0000-000000000003'::uuid, 1)
+    $$,
+    'Packages in each discovery category should have been computed'
+);
+update package set created_at = current_timestamp - '30 days'::interval where package_id = :'package1ID';
+update repository set verified_publisher_at = current_timestamp - '60 days'::interval where repository_id = :'repo2ID';
+select refresh_packages_discovery(1, 10, 4);
+select results_eq(
+    $$
+        select category, package_id, position
+        from package_discovery
+        order by category, position
+    $$,
+    $$
+        values
+            ('hidden-gems', '00000000-0000-0000-0000-000000000005'::uuid, 1)
+    $$,
+    'Packages in each discovery category should have been recomputed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
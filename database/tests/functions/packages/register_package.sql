@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(14);
+select plan(17);
 
 -- Declare some variables
 \set org1ID '00000000-0000-0000-0000-000000000001'
@@ -20,6 +20,7 @@ select register_package('
     "name": "package1",
     "logo_url": "logo_url",
     "logo_image_id": "00000000-0000-0000-0000-000000000001",
+    "logo_generated": true,
     "channels": [
         {
             "name": "stable",
@@ -154,6 +155,7 @@ select results_eq(
             s.description,
             s.logo_url,
             s.logo_image_id,
+            s.logo_generated,
             s.keywords,
             s.home_url,
             s.app_version,
@@ -172,6 +174,7 @@ select results_eq(
             s.containers_images,
             s.provider,
             s.values_schema,
+            s.values_schema_inferred,
             s.changes,
             s.contains_security_updates,
             s.prerelease,
@@ -190,6 +193,7 @@ select results_eq(
             'description',
             'logo_url',
             '00000000-0000-0000-0000-000000000001'::uuid,
+            true,
             '{kw1,kw2}'::text[],
             'home_url',
             '12.1.0',
@@ -208,6 +212,7 @@ select results_eq(
             '[{"image": "quay.io/org/img:1.0.0"}]'::jsonb,
             'Org Inc',
             '{"key": "value"}'::jsonb,
+            false,
             '[
                 {
                     "kind": "added",
@@ -269,6 +274,14 @@ select register_package('
     "digest": "digest-package1-2.0.0",
     "deprecated": true,
     "signed": true,
+    "signature_verified": true,
+    "signature_kind": "cosign",
+    "signature_identity": "user@email.com",
+    "has_attestations": true,
+    "sbom": {
+        "format": "spdx",
+        "location": "s3://bucket/sbom.json"
+    },
     "is_operator": false,
     "capabilities": "seamless upgrades",
     "containers_images": [
@@ -278,6 +291,21 @@ select register_package('
     ],
     "provider": "Org Inc 2",
     "values_schema": null,
+    "values_docs": [
+        {
+            "path": "replicaCount",
+            "type": "int",
+            "default": 1
+        }
+    ],
+    "dependencies_tree": [
+        {
+            "name": "dep1",
+            "version": "1.0.0",
+            "app_version": "1.1.0",
+            "resolved": true
+        }
+    ],
     "ts": 1592299235,
     "maintainers": [
         {
@@ -317,9 +345,18 @@ select results_eq(
             s.capabilities,
             s.deprecated,
             s.signed,
+            s.signature_verified,
+            s.signature_kind,
+            s.signature_identity,
+            s.has_attestations,
+            s.sbom,
+            s.sbom_format,
+            s.sbom_location,
             s.containers_images,
             s.provider,
             s.values_schema,
+            s.values_docs,
+            s.dependencies_tree,
             s.changes,
             s.contains_security_updates,
             s.prerelease,
@@ -346,9 +383,18 @@ select results_eq(
             'seamless upgrades',
             true,
             true,
+            true,
+            'cosign',
+            'user@email.com',
+            true,
+            null::jsonb,
+            'spdx',
+            's3://bucket/sbom.json',
             '[{"image": "quay.io/org/img:2.0.0"}]'::jsonb,
             'Org Inc 2',
             null::jsonb,
+            '[{"path": "replicaCount", "type": "int", "default": 1}]'::jsonb,
+            '[{"name": "dep1", "version": "1.0.0", "app_version": "1.1.0", "resolved": true}]'::jsonb,
             null::jsonb,
             null::boolean,
             null::boolean,
@@ -371,6 +417,17 @@ select results_eq(
     $$ values ('name1 updated', 'email1') $$,
     'Package maintainers should have been updated'
 );
+select results_eq(
+    $$
+        select
+            jsonb_array_length(document->'available_versions'),
+            document->'maintainers'
+        from package
+        where name = 'package1'
+    $$,
+    $$ values (2, '[{"name": "name1 updated", "email": "email1"}]'::jsonb) $$,
+    'Package level document should have been refreshed'
+);
 select is_empty(
     $$
         select *
@@ -391,6 +448,17 @@ select isnt_empty(
     $$,
     'New release event should exist for package1 version 2.0.0'
 );
+select isnt_empty(
+    $$
+        select *
+        from event e
+        join package p using (package_id)
+        where p.name = 'package1'
+        and e.package_version = '2.0.0'
+        and e.event_kind_id = 5
+    $$,
+    'Package deprecated event should exist for package1 version 2.0.0'
+);
 
 -- Register an old version of the package previously registered
 select register_package('
@@ -522,6 +590,25 @@ select is_empty(
     'No new release event should exist for package1 version 0.0.9'
 );
 
+-- Check documents have been built for all package versions
+select results_eq(
+    $$
+        select pd.version, pd.document->>'version'
+        from package_document pd
+        join package p using (package_id)
+        where p.name = 'package1'
+        order by pd.version
+    $$,
+    $$
+        select s.version, s.version
+        from snapshot s
+        join package p using (package_id)
+        where p.name = 'package1'
+        order by s.version
+    $$,
+    'Documents should exist for all package1 versions'
+);
+
 -- Disable repository and check that trying to register a package raises an error
 update repository set disabled = true where repository_id = :'repo1ID';
 select throws_ok(
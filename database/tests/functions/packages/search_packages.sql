@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(29);
+select plan(35);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -260,6 +260,7 @@ select results_eq(
                             "display_name": "Repo 2",
                             "url": "https://repo2.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -286,6 +287,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -313,6 +315,7 @@ select results_eq(
                             "display_name": "Repo 3",
                             "url": "https://repo3.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -378,6 +381,11 @@ select results_eq(
                             "total": 1
                         }]
                     },
+                    {
+                        "title": "Category",
+                        "filter_key": "category",
+                        "options": []
+                    },
                     {
                         "title": "Operator capabilities",
                         "filter_key": "capabilities",
@@ -427,6 +435,7 @@ select results_eq(
                             "display_name": "Repo 2",
                             "url": "https://repo2.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -453,6 +462,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -480,6 +490,7 @@ select results_eq(
                             "display_name": "Repo 3",
                             "url": "https://repo3.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -523,6 +534,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -565,6 +577,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -610,6 +623,7 @@ select results_eq(
                             "display_name": "Repo 2",
                             "url": "https://repo2.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -636,6 +650,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -682,6 +697,7 @@ select results_eq(
                             "display_name": "Repo 2",
                             "url": "https://repo2.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -708,6 +724,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -752,6 +769,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -779,6 +797,7 @@ select results_eq(
                             "display_name": "Repo 2",
                             "url": "https://repo2.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -836,6 +855,11 @@ select results_eq(
                             "total": 1
                         }]
                     },
+                    {
+                        "title": "Category",
+                        "filter_key": "category",
+                        "options": []
+                    },
                     {
                         "title": "Operator capabilities",
                         "filter_key": "capabilities",
@@ -883,6 +907,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -931,6 +956,11 @@ select results_eq(
                             "total": 1
                         }]
                     },
+                    {
+                        "title": "Category",
+                        "filter_key": "category",
+                        "options": []
+                    },
                     {
                         "title": "Operator capabilities",
                         "filter_key": "capabilities",
@@ -997,6 +1027,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -1046,6 +1077,7 @@ select results_eq(
                             "display_name": "Repo 2",
                             "url": "https://repo2.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -1103,6 +1135,11 @@ select results_eq(
                             "total": 1
                         }]
                     },
+                    {
+                        "title": "Category",
+                        "filter_key": "category",
+                        "options": []
+                    },
                     {
                         "title": "Operator capabilities",
                         "filter_key": "capabilities",
@@ -1176,6 +1213,11 @@ select results_eq(
                             "total": 1
                         }]
                     },
+                    {
+                        "title": "Category",
+                        "filter_key": "category",
+                        "options": []
+                    },
                     {
                         "title": "Operator capabilities",
                         "filter_key": "capabilities",
@@ -1248,6 +1290,11 @@ select results_eq(
                             "total": 1
                         }]
                     },
+                    {
+                        "title": "Category",
+                        "filter_key": "category",
+                        "options": []
+                    },
                     {
                         "title": "Operator capabilities",
                         "filter_key": "capabilities",
@@ -1320,6 +1367,11 @@ select results_eq(
                             "total": 1
                         }]
                     },
+                    {
+                        "title": "Category",
+                        "filter_key": "category",
+                        "options": []
+                    },
                     {
                         "title": "Operator capabilities",
                         "filter_key": "capabilities",
@@ -1390,6 +1442,7 @@ select results_eq(
                             "display_name": "Repo 3",
                             "url": "https://repo3.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -1435,6 +1488,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -1482,6 +1536,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -1509,6 +1564,7 @@ select results_eq(
                             "display_name": "Repo 3",
                             "url": "https://repo3.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -1556,6 +1612,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -1602,6 +1659,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -1649,6 +1707,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -1676,6 +1735,7 @@ select results_eq(
                             "display_name": "Repo 2",
                             "url": "https://repo2.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -1722,6 +1782,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -1788,6 +1849,7 @@ select results_eq(
                             "display_name": "Repo 2",
                             "url": "https://repo2.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -1884,6 +1946,11 @@ select results_eq(
                             "total": 1
                         }]
                     },
+                    {
+                        "title": "Category",
+                        "filter_key": "category",
+                        "options": []
+                    },
                     {
                         "title": "Operator capabilities",
                         "filter_key": "capabilities",
@@ -1934,6 +2001,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -1961,6 +2029,7 @@ select results_eq(
                             "display_name": "Repo 2",
                             "url": "https://repo2.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -2008,6 +2077,7 @@ select results_eq(
                             "display_name": "Repo 2",
                             "url": "https://repo2.com",
                             "verified_publisher": false,
+                            "domain_verified": false,
                             "official": false,
                             "scanner_disabled": false,
                             "organization_name": "org1",
@@ -2034,6 +2104,7 @@ select results_eq(
                             "display_name": "Repo 1",
                             "url": "https://repo1.com",
                             "verified_publisher": true,
+                            "domain_verified": false,
                             "official": true,
                             "scanner_disabled": false,
                             "user_alias": "user1"
@@ -2047,6 +2118,212 @@ select results_eq(
     'Sort: stars TSQueryWeb: kw1 | Packages 2 and 1 expected'
 );
 
+-- Tests with freshness information
+update package set last_release_ts = '2021-01-01 00:00:00+00', stale = true where package_id = :'package1ID';
+update package set last_release_ts = '2022-01-01 00:00:00+00' where package_id = :'package2ID';
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer from search_packages('{
+            "ts_query_web": "kw1",
+            "sort": "last_release",
+            "deprecated": true
+        }')
+    $$,
+    $$
+        values (
+            '{
+                "packages": [
+                    {
+                        "package_id": "00000000-0000-0000-0000-000000000002",
+                        "name": "package2",
+                        "normalized_name": "package2",
+                        "stars": 11,
+                        "official": true,
+                        "display_name": "Package 2",
+                        "description": "description",
+                        "logo_image_id": "00000000-0000-0000-0000-000000000002",
+                        "version": "1.0.0",
+                        "app_version": "12.1.0",
+                        "deprecated": true,
+                        "signed": true,
+                        "all_containers_images_whitelisted": false,
+                        "ts": 1592299234,
+                        "repository": {
+                            "repository_id": "00000000-0000-0000-0000-000000000002",
+                            "kind": 0,
+                            "name": "repo2",
+                            "display_name": "Repo 2",
+                            "url": "https://repo2.com",
+                            "verified_publisher": false,
+                            "domain_verified": false,
+                            "official": false,
+                            "scanner_disabled": false,
+                            "organization_name": "org1",
+                            "organization_display_name": "Organization 1"
+                        }
+                    },
+                    {
+                        "package_id": "00000000-0000-0000-0000-000000000001",
+                        "name": "package1",
+                        "normalized_name": "package1",
+                        "stars": 10,
+                        "official": false,
+                        "display_name": "Package 1",
+                        "description": "description",
+                        "logo_image_id": "00000000-0000-0000-0000-000000000001",
+                        "version": "1.0.0",
+                        "app_version": "12.1.0",
+                        "license": "Apache-2.0",
+                        "ts": 1592299234,
+                        "repository": {
+                            "repository_id": "00000000-0000-0000-0000-000000000001",
+                            "kind": 0,
+                            "name": "repo1",
+                            "display_name": "Repo 1",
+                            "url": "https://repo1.com",
+                            "verified_publisher": true,
+                            "domain_verified": false,
+                            "official": true,
+                            "scanner_disabled": false,
+                            "user_alias": "user1"
+                        }
+                    }
+                ]
+            }'::jsonb,
+            2
+        )
+    $$,
+    'Sort: last_release TSQueryWeb: kw1 | Packages 2 and 1 expected'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer from search_packages('{
+            "ts_query_web": "kw1",
+            "active": true,
+            "deprecated": true
+        }')
+    $$,
+    $$
+        values (
+            '{
+                "packages": [
+                    {
+                        "package_id": "00000000-0000-0000-0000-000000000002",
+                        "name": "package2",
+                        "normalized_name": "package2",
+                        "stars": 11,
+                        "official": true,
+                        "display_name": "Package 2",
+                        "description": "description",
+                        "logo_image_id": "00000000-0000-0000-0000-000000000002",
+                        "version": "1.0.0",
+                        "app_version": "12.1.0",
+                        "deprecated": true,
+                        "signed": true,
+                        "all_containers_images_whitelisted": false,
+                        "ts": 1592299234,
+                        "repository": {
+                            "repository_id": "00000000-0000-0000-0000-000000000002",
+                            "kind": 0,
+                            "name": "repo2",
+                            "display_name": "Repo 2",
+                            "url": "https://repo2.com",
+                            "verified_publisher": false,
+                            "domain_verified": false,
+                            "official": false,
+                            "scanner_disabled": false,
+                            "organization_name": "org1",
+                            "organization_display_name": "Organization 1"
+                        }
+                    }
+                ]
+            }'::jsonb,
+            1
+        )
+    $$,
+    'Active: true TSQueryWeb: kw1 | Package 2 expected (package 1 is stale)'
+);
+
+-- Packages inherit the labels of the repository they belong to
+update repository set labels = '{databases,observability}' where repository_id = :'repo3ID';
+select results_eq(
+    $$
+        select p->>'name', (p->'labels')::jsonb, (p->'repository'->'labels')::jsonb, s.total_count::integer
+        from search_packages('{
+            "labels": ["observability", "security"],
+            "deprecated": true
+        }') s, json_array_elements(s.data->'packages') p
+    $$,
+    $$
+        values (
+            'package3',
+            '["databases", "observability"]'::jsonb,
+            '["databases", "observability"]'::jsonb,
+            1
+        )
+    $$,
+    'Labels: observability, security | Package 3 expected'
+);
+
+-- Packages can be filtered by some kind specific data fields
+update package set search_data = '{"kube_version": [">=1.16.0-0"], "chart_type": ["application"]}'
+where package_id = :'package1ID';
+update package set search_data = '{"chart_type": ["library"]}'
+where package_id = :'package2ID';
+select results_eq(
+    $$
+        select p->>'name', s.total_count::integer
+        from search_packages('{
+            "kube_versions": [">=1.16.0-0"],
+            "chart_types": ["application", "library"],
+            "deprecated": true
+        }') s, json_array_elements(s.data->'packages') p
+    $$,
+    $$
+        values ('package1', 1)
+    $$,
+    'KubeVersions: >=1.16.0-0 ChartTypes: application, library | Package 1 expected'
+);
+
+-- Packages declaring an incompatible kube version constraint can be filtered out
+update package set search_data = '{"kube_version_range": [{"min": "1.19", "max": "1.25"}]}'
+where package_id = :'package1ID';
+update package set search_data = '{"kube_version_range": [{"min": "1.16", "max": "1.18"}, {"min": "1.26"}]}'
+where package_id = :'package2ID';
+select results_eq(
+    $$
+        select p->>'name'
+        from search_packages('{
+            "compatible_kube_version": "1.27",
+            "deprecated": true
+        }') s, json_array_elements(s.data->'packages') p
+        order by 1
+    $$,
+    $$
+        values ('package2'), ('package3')
+    $$,
+    'CompatibleKubeVersion: 1.27 | Packages 2 and 3 expected'
+);
+
+-- Packages declaring a kube version constraint not matching any version are
+-- not compatible with any
+update package set search_data = '{"kube_version_range": []}'
+where package_id = :'package3ID';
+select results_eq(
+    $$
+        select p->>'name'
+        from search_packages('{
+            "compatible_kube_version": "1.27",
+            "deprecated": true
+        }') s, json_array_elements(s.data->'packages') p
+        order by 1
+    $$,
+    $$
+        values ('package2')
+    $$,
+    'CompatibleKubeVersion: 1.27 | Package 2 expected'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
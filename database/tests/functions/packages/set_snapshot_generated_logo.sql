@@ -0,0 +1,70 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set image1ID '00000000-0000-0000-0000-000000000001'
+\set image2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version)
+values (:'package1ID', '1.0.0');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, logo_image_id)
+values (:'package2ID', '1.0.0', :'image1ID');
+
+-- Run some tests
+select set_snapshot_generated_logo(:'package1ID', '1.0.0', :'image2ID');
+select results_eq(
+    $$
+        select logo_image_id, logo_generated
+        from snapshot
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        and version = '1.0.0'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000002'::uuid, true)
+    $$,
+    'Generated logo should have been set'
+);
+select is(
+    document->>'logo_image_id',
+    :'image2ID',
+    'Package document should have been refreshed'
+)
+from package_document where package_id = :'package1ID' and version = '1.0.0';
+select set_snapshot_generated_logo(:'package2ID', '1.0.0', :'image2ID');
+select results_eq(
+    $$
+        select logo_image_id, logo_generated
+        from snapshot
+        where package_id = '00000000-0000-0000-0000-000000000002'
+        and version = '1.0.0'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000001'::uuid, false)
+    $$,
+    'Existing logo should not have been replaced'
+);
+select is_empty(
+    $$
+        select * from package_document
+        where package_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    'Package document should not have been refreshed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,46 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version)
+values (:'package1ID', '1.0.0');
+
+-- Run some tests
+select is(
+    set_snapshot_test_results('repo1', 'package1', '2.0.0', '{"passed": 1, "failed": 0, "skipped": 0}'),
+    false,
+    'Package version not found should return false'
+);
+select is(
+    set_snapshot_test_results('repo1', 'package1', '1.0.0', '{"tool": "junit", "passed": 3, "failed": 1, "skipped": 0}'),
+    true,
+    'Package version found should return true'
+);
+select is(
+    test_results,
+    '{"tool": "junit", "passed": 3, "failed": 1, "skipped": 0}'::jsonb,
+    'Test results should have been set'
+)
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select is(
+    document->'test_results',
+    '{"tool": "junit", "passed": 3, "failed": 1, "skipped": 0}'::jsonb,
+    'Package document should have been refreshed'
+)
+from package_document where package_id = :'package1ID' and version = '1.0.0';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
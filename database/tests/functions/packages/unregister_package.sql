@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(10);
+select plan(11);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -53,6 +53,15 @@ select is_empty(
     $$ select * from snapshot where version='1.0.0' $$,
     'Package snapshot version 1.0.0 should have been deleted'
 );
+select results_eq(
+    $$
+        select jsonb_array_length(document->'available_versions')
+        from package
+        where name='package1'
+    $$,
+    $$ values (3) $$,
+    'Package level document should not include version 1.0.0'
+);
 select unregister_package('
 {
     "kind": 0,
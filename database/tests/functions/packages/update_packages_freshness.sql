@@ -0,0 +1,94 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.2.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.1.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'package3', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, ts) values
+    (:'package1ID', '1.0.0', current_timestamp - '100 days'::interval),
+    (:'package1ID', '1.1.0', current_timestamp - '90 days'::interval),
+    (:'package1ID', '1.2.0', current_timestamp - '80 days'::interval),
+    (:'package2ID', '1.0.0', current_timestamp - '30 days'::interval),
+    (:'package2ID', '1.1.0', current_timestamp - '10 days'::interval),
+    (:'package3ID', '1.0.0', current_timestamp - '400 days'::interval);
+
+-- Run some tests
+select update_packages_freshness(3, 30);
+select results_eq(
+    $$
+        select repository_kind_id, median_release_cadence
+        from repository_kind
+        where repository_kind_id in (0, 1)
+        order by repository_kind_id
+    $$,
+    $$
+        values
+            (0, '15 days'::interval),
+            (1, null::interval)
+    $$,
+    'Median release cadence should have been computed for the Helm kind'
+);
+select results_eq(
+    $$
+        select name, current_timestamp - last_release_ts, stale
+        from package
+        order by name
+    $$,
+    $$
+        values
+            ('package1', '80 days'::interval, true),
+            ('package2', '10 days'::interval, false),
+            ('package3', '400 days'::interval, true)
+    $$,
+    'Packages not released in longer than 3 times the kind median cadence should be stale'
+);
+select update_packages_freshness(3, 500);
+select results_eq(
+    $$
+        select name, stale
+        from package
+        order by name
+    $$,
+    $$
+        values
+            ('package1', false),
+            ('package2', false),
+            ('package3', false)
+    $$,
+    'No packages should be stale when the minimum number of days has not been reached'
+);
+update repository set repository_kind_id = 1 where repository_id = :'repo1ID';
+select update_packages_freshness(3, 30);
+select results_eq(
+    $$
+        select repository_kind_id, median_release_cadence
+        from repository_kind
+        where repository_kind_id in (0, 1)
+        order by repository_kind_id
+    $$,
+    $$
+        values
+            (0, null::interval),
+            (1, '15 days'::interval)
+    $$,
+    'Median release cadence should have been recomputed for the Helm and Falco kinds'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(14);
+select plan(16);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -99,6 +99,22 @@ select is(security_report_summary, '{
 }', 'Security report summary should exist')
 from snapshot where package_id = :'package1ID' and version = '1.0.0';
 
+-- Test security report placed in the reports store
+select update_snapshot_security_report('{
+    "package_id": "00000000-0000-0000-0000-000000000001",
+    "version": "1.0.0",
+    "alert_digest": "digest",
+    "images_reports_location": "s3://bucket/security-reports/00000000-0000-0000-0000-000000000001/1.0.0.json"
+}');
+select is(security_report, null, 'Security report should not be stored in the database')
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select is(
+    security_report_location,
+    's3://bucket/security-reports/00000000-0000-0000-0000-000000000001/1.0.0.json',
+    'Security report location should exist'
+)
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
+
 -- Test security alert events
 select update_snapshot_security_report('{
     "package_id": "00000000-0000-0000-0000-000000000002",
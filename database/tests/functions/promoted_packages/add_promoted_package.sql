@@ -0,0 +1,81 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '1.0.0', '2020-06-16 11:20:34+02');
+
+-- Run some tests
+select throws_ok(
+    $$
+        select add_promoted_package(
+            '00000000-0000-0000-0000-000000000002',
+            '{"package_id": "00000000-0000-0000-0000-000000000001", "ends_at": 4102444800}'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Only site admins should be able to promote packages'
+);
+select throws_ok(
+    $$
+        select add_promoted_package(
+            '00000000-0000-0000-0000-000000000001',
+            '{"package_id": "00000000-0000-0000-0000-000000000009", "ends_at": 4102444800}'
+        )
+    $$,
+    'P0001',
+    'package not found',
+    'Package to promote must exist'
+);
+select add_promoted_package(:'user1ID', '
+{
+    "package_id": "00000000-0000-0000-0000-000000000001",
+    "starts_at": 1577836800,
+    "ends_at": 4102444800
+}
+'::jsonb);
+select results_eq(
+    $$
+        select package_id, starts_at, ends_at, impressions, clicks
+        from promoted_package
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '2020-01-01 00:00:00+00'::timestamptz,
+            '2100-01-01 00:00:00+00'::timestamptz,
+            0::bigint,
+            0::bigint
+        )
+    $$,
+    'Package promotion should have been added'
+);
+select throws_ok(
+    $$
+        select add_promoted_package(
+            '00000000-0000-0000-0000-000000000001',
+            '{"package_id": "00000000-0000-0000-0000-000000000001", "starts_at": 4102444800, "ends_at": 1577836800}'
+        )
+    $$,
+    23514,
+    NULL,
+    'Promotion end must be after its start'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,45 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set promotion1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '1.0.0', '2020-06-16 11:20:34+02');
+insert into promoted_package (promoted_package_id, package_id, ends_at)
+values (:'promotion1ID', :'package1ID', current_timestamp + '1 week'::interval);
+
+-- Run some tests
+select throws_ok(
+    $$ select delete_promoted_package('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001') $$,
+    42501,
+    'insufficient_privilege',
+    'Only site admins should be able to delete package promotions'
+);
+select throws_ok(
+    $$ select delete_promoted_package('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000009') $$,
+    'P0001',
+    'promoted package not found',
+    'Promotion to delete must exist'
+);
+select delete_promoted_package(:'user1ID', :'promotion1ID');
+select is_empty(
+    $$ select * from promoted_package $$,
+    'Package promotion should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,73 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set promotion1ID '00000000-0000-0000-0000-000000000001'
+\set promotion2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '1.0.0', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, ts)
+values (:'package2ID', '1.0.0', '2020-06-16 11:20:34+02');
+insert into promoted_package (promoted_package_id, package_id, starts_at, ends_at, impressions, clicks)
+values (:'promotion1ID', :'package1ID', '2020-01-01 00:00:00+00', '2100-01-01 00:00:00+00', 10, 2);
+insert into promoted_package (promoted_package_id, package_id, starts_at, ends_at, impressions, clicks)
+values (:'promotion2ID', :'package2ID', '2019-01-01 00:00:00+00', '2019-02-01 00:00:00+00', 5, 1);
+
+-- Run some tests
+select is(
+    get_promoted_packages()::jsonb,
+    '[
+        {
+            "promoted_package_id": "00000000-0000-0000-0000-000000000001",
+            "package": {
+                "package_id": "00000000-0000-0000-0000-000000000001",
+                "name": "package1",
+                "normalized_name": "package1",
+                "stars": 0,
+                "version": "1.0.0",
+                "ts": 1592299234,
+                "promoted": true,
+                "repository": {
+                    "repository_id": "00000000-0000-0000-0000-000000000001",
+                    "kind": 0,
+                    "name": "repo1",
+                    "display_name": "Repo 1",
+                    "url": "https://repo1.com",
+                    "private": false,
+                    "verified_publisher": false,
+                    "domain_verified": false,
+                    "official": false,
+                    "scanner_disabled": false,
+                    "user_alias": "user1"
+                }
+            }
+        }
+    ]'::jsonb,
+    'Only packages currently promoted should be returned'
+);
+delete from promoted_package where promoted_package_id = :'promotion1ID';
+select is(
+    get_promoted_packages()::jsonb,
+    '[]'::jsonb,
+    'No promoted packages expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,62 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set promotion1ID '00000000-0000-0000-0000-000000000001'
+\set promotion2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '1.0.0', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, ts)
+values (:'package2ID', '1.0.0', '2020-06-16 11:20:34+02');
+insert into promoted_package (promoted_package_id, package_id, starts_at, ends_at, impressions, clicks)
+values (:'promotion1ID', :'package1ID', '2020-01-01 00:00:00+00', '2100-01-01 00:00:00+00', 10, 2);
+insert into promoted_package (promoted_package_id, package_id, starts_at, ends_at, impressions, clicks)
+values (:'promotion2ID', :'package2ID', '2019-01-01 00:00:00+00', '2019-02-01 00:00:00+00', 5, 1);
+
+-- Run some tests
+select register_promoted_package_event(:'promotion1ID', 'impression');
+select register_promoted_package_event(:'promotion1ID', 'impression');
+select register_promoted_package_event(:'promotion1ID', 'click');
+select results_eq(
+    $$
+        select impressions, clicks
+        from promoted_package
+        where promoted_package_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (12::bigint, 3::bigint)
+    $$,
+    'Impressions and clicks should have been registered'
+);
+select throws_ok(
+    $$ select register_promoted_package_event('00000000-0000-0000-0000-000000000002', 'click') $$,
+    'P0001',
+    'promoted package not found',
+    'Events cannot be registered on promotions not active'
+);
+select throws_ok(
+    $$ select register_promoted_package_event('00000000-0000-0000-0000-000000000009', 'click') $$,
+    'P0001',
+    'promoted package not found',
+    'Events cannot be registered on promotions that do not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
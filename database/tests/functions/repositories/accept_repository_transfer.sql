@@ -0,0 +1,103 @@
+-- Start transaction and plan tests
+begin;
+select plan(8);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 0, :'user1ID');
+insert into repository_transfer (repository_id, requesting_user_id, user_id, expires_at)
+values (:'repo1ID', :'user1ID', :'user2ID', current_timestamp + '1 hour'::interval);
+insert into repository_transfer (repository_id, requesting_user_id, organization_id, expires_at)
+values (:'repo2ID', :'user1ID', :'org1ID', current_timestamp + '1 hour'::interval);
+insert into repository_transfer (repository_id, requesting_user_id, user_id, expires_at)
+values (:'repo3ID', :'user1ID', :'user2ID', current_timestamp - '1 hour'::interval);
+
+-- Run some tests
+select throws_ok(
+    $$ select accept_repository_transfer('00000000-0000-0000-0000-000000000002', 'repo4') $$,
+    'P0001',
+    'repository transfer not found',
+    'Accept should fail because there is no transfer for the repository'
+);
+select throws_ok(
+    $$ select accept_repository_transfer('00000000-0000-0000-0000-000000000002', 'repo3') $$,
+    'P0001',
+    'repository transfer not found',
+    'Accept should fail because the transfer has expired'
+);
+select throws_ok(
+    $$ select accept_repository_transfer('00000000-0000-0000-0000-000000000001', 'repo1') $$,
+    42501,
+    'insufficient_privilege',
+    'Accept should fail because requesting user is not the target user'
+);
+select throws_ok(
+    $$ select accept_repository_transfer('00000000-0000-0000-0000-000000000001', 'repo2') $$,
+    42501,
+    'insufficient_privilege',
+    'Accept should fail because requesting user does not belong to the target organization'
+);
+select accept_repository_transfer(:'user2ID', 'repo1');
+select results_eq(
+    $$
+        select user_id, organization_id
+        from repository
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000002'::uuid, null::uuid)
+    $$,
+    'Repository repo1 should now be owned by user2'
+);
+select accept_repository_transfer(:'user2ID', 'repo2');
+select results_eq(
+    $$
+        select user_id, organization_id
+        from repository
+        where repository_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$
+        values (null::uuid, '00000000-0000-0000-0000-000000000001'::uuid)
+    $$,
+    'Repository repo2 should now be owned by org1'
+);
+select is_empty(
+    $$
+        select * from repository_transfer
+        where repository_id in (
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000002'
+        )
+    $$,
+    'Accepted transfers should have been deleted'
+);
+select is(
+    user_id,
+    :'user1ID',
+    'Repository repo3 should still be owned by user1'
+)
+from repository
+where repository_id = :'repo3ID';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -22,8 +22,12 @@ select add_repository(:'user1ID', null, '
     "branch": "main",
     "auth_user": "user1",
     "auth_pass": "pass1",
+    "ssh_key": "ssh_key",
     "disabled": false,
     "scanner_disabled": false,
+    "signing_policy": "reject",
+    "tracking_schedule": "@hourly",
+    "labels": ["databases", "observability"],
     "kind": 0
 }
 '::jsonb);
@@ -36,8 +40,12 @@ select results_eq(
             branch,
             auth_user,
             auth_pass,
+            ssh_key,
             disabled,
             scanner_disabled,
+            signing_policy,
+            tracking_schedule,
+            labels,
             repository_kind_id,
             user_id,
             organization_id
@@ -52,8 +60,12 @@ select results_eq(
             'main',
             'user1',
             'pass1',
+            'ssh_key',
             false,
             false,
+            'reject',
+            '@hourly',
+            '{databases,observability}'::text[],
             0,
             '00000000-0000-0000-0000-000000000001'::uuid,
             null::uuid
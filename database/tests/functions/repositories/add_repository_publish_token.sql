@@ -0,0 +1,66 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+
+-- Run some tests
+select throws_ok(
+    $$
+        select add_repository_publish_token(
+            '00000000-0000-0000-0000-000000000002',
+            'repo2',
+            '{"name": "token1", "secret": "hashedSecret"}'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User not belonging to the owning organization should not be able to add a publish token'
+);
+select add_repository_publish_token(:'user1ID', 'repo1', '{"name": "token1", "secret": "hashedSecret1"}');
+select add_repository_publish_token(:'user1ID', 'repo2', '{"name": "token2", "secret": "hashedSecret2"}');
+select results_eq(
+    $$
+        select r.name, t.name, t.secret
+        from repository_publish_token t
+        join repository r using (repository_id)
+        order by r.name
+    $$,
+    $$
+        values
+            ('repo1', 'token1', 'hashedSecret1'),
+            ('repo2', 'token2', 'hashedSecret2')
+    $$,
+    'Publish tokens should have been added to the repositories'
+);
+select throws_ok(
+    $$
+        select add_repository_publish_token(
+            '00000000-0000-0000-0000-000000000001',
+            'repo1',
+            '{"name": "", "secret": "hashedSecret"}'
+        )
+    $$,
+    23514,
+    'new row for relation "repository_publish_token" violates check constraint "repository_publish_token_name_check"',
+    'Publish token name cannot be empty'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
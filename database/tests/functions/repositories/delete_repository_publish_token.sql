@@ -0,0 +1,53 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set token1ID '00000000-0000-0000-0000-000000000001'
+\set token2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID');
+insert into repository_publish_token (publish_token_id, repository_id, name, secret)
+values (:'token1ID', :'repo1ID', 'token1', 'hashedSecret1');
+insert into repository_publish_token (publish_token_id, repository_id, name, secret)
+values (:'token2ID', :'repo2ID', 'token2', 'hashedSecret2');
+
+-- Run some tests
+select throws_ok(
+    $$
+        select delete_repository_publish_token(
+            '00000000-0000-0000-0000-000000000002',
+            'repo1',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User who does not own the repository should not be able to delete its publish tokens'
+);
+select delete_repository_publish_token(:'user1ID', 'repo1', :'token2ID');
+select results_eq(
+    'select count(*) from repository_publish_token',
+    $$ values (2::bigint) $$,
+    'Publish tokens belonging to other repositories should not be deleted'
+);
+select delete_repository_publish_token(:'user1ID', 'repo1', :'token1ID');
+select results_eq(
+    $$ select name from repository_publish_token $$,
+    $$ values ('token2') $$,
+    'Publish token should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
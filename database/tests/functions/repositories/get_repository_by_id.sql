@@ -23,7 +23,11 @@ insert into repository (
     branch,
     auth_user,
     auth_pass,
+    ssh_key,
+    domain_verification_token,
     digest,
+    signing_policy,
+    tracking_schedule,
     repository_kind_id,
     user_id,
     last_scanning_ts,
@@ -39,7 +43,11 @@ values (
     'main',
     'user1',
     'pass1',
+    'ssh_key',
+    'token1',
     'digest',
+    'flag',
+    '0 */6 * * *',
     0,
     :'user1ID',
     '2020-06-16 11:20:34+02',
@@ -59,9 +67,15 @@ select is(
         "branch": "main",
         "kind": 0,
         "verified_publisher": false,
+        "domain_verified": false,
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_paused": false,
+        "archived": false,
+        "tracking_requested": false,
+        "signing_policy": "flag",
+        "tracking_schedule": "0 */6 * * *",
         "digest": "digest",
         "last_scanning_ts": 1592299234,
         "last_scanning_errors": "error1\\nerror2\\n",
@@ -81,11 +95,19 @@ select is(
         "branch": "main",
         "auth_user": "user1",
         "auth_pass": "pass1",
+        "ssh_key": "ssh_key",
         "kind": 0,
         "verified_publisher": false,
+        "domain_verified": false,
+        "domain_verification_token": "token1",
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_paused": false,
+        "archived": false,
+        "tracking_requested": false,
+        "signing_policy": "flag",
+        "tracking_schedule": "0 */6 * * *",
         "digest": "digest",
         "last_scanning_ts": 1592299234,
         "last_scanning_errors": "error1\\nerror2\\n",
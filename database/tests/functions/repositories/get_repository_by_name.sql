@@ -28,9 +28,13 @@ select is(
         "url": "https://repo1.com",
         "kind": 0,
         "verified_publisher": false,
+        "domain_verified": false,
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_paused": false,
+        "archived": false,
+        "tracking_requested": false,
         "user_alias": "user1"
     }'::jsonb,
     'Repository just seeded is returned as a json object'
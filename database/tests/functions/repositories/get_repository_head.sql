@@ -0,0 +1,44 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, digest, last_tracking_ts)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID', 'digest', '2020-06-16 11:20:33+02');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID');
+
+-- Run some tests
+select is(
+    get_repository_head('repo1')::jsonb,
+    '{
+        "digest": "digest",
+        "last_modified": 1592299233
+    }'::jsonb,
+    'Head of repository repo1 is returned as a json object'
+);
+select is(
+    get_repository_head('repo2')::jsonb,
+    '{
+        "digest": null,
+        "last_modified": null
+    }'::jsonb,
+    'Head of repository repo2 not tracked yet is returned as a json object'
+);
+select is_empty(
+    $$
+        select get_repository_head('repo3')
+    $$,
+    'No rows are returned when the repository does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
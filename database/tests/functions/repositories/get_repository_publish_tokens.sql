@@ -0,0 +1,56 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set token1ID '00000000-0000-0000-0000-000000000001'
+\set token2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select get_repository_publish_tokens('00000000-0000-0000-0000-000000000002', 'repo1') $$,
+    42501,
+    'insufficient_privilege',
+    'User not belonging to the owning organization should not be able to get the publish tokens'
+);
+select is(
+    get_repository_publish_tokens(:'user1ID', 'repo1')::jsonb,
+    '[]'::jsonb,
+    'An empty array should be returned when the repository has no publish tokens'
+);
+insert into repository_publish_token (publish_token_id, repository_id, name, secret, created_at)
+values (:'token1ID', :'repo1ID', 'token1', 'hashedSecret1', '2021-01-01 00:00:00+00');
+insert into repository_publish_token (publish_token_id, repository_id, name, secret, created_at)
+values (:'token2ID', :'repo1ID', 'token2', 'hashedSecret2', '2021-01-02 00:00:00+00');
+select is(
+    get_repository_publish_tokens(:'user1ID', 'repo1')::jsonb,
+    '[
+        {
+            "publish_token_id": "00000000-0000-0000-0000-000000000002",
+            "name": "token2",
+            "created_at": 1609545600
+        },
+        {
+            "publish_token_id": "00000000-0000-0000-0000-000000000001",
+            "name": "token1",
+            "created_at": 1609459200
+        }
+    ]'::jsonb,
+    'Publish tokens should be returned without their secrets'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
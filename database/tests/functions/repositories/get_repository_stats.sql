@@ -0,0 +1,91 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set image1ID '00000000-0000-0000-0000-000000000001'
+\set image2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into image (image_id, original_hash) values (:'image1ID', 'hash1');
+insert into image_version (image_id, version, data) values (:'image1ID', '1x', '\x0102');
+insert into image_version (image_id, version, data) values (:'image1ID', '2x', '\x01020304');
+insert into image (image_id, original_hash) values (:'image2ID', 'hash2');
+insert into image_version (image_id, version, data) values (:'image2ID', '1x', '\x0102030405');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, logo_image_id)
+values (:'package1ID', '1.0.0', :'image1ID');
+insert into snapshot (package_id, version, logo_image_id)
+values (:'package1ID', '0.9.0', :'image1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version, logo_image_id)
+values (:'package2ID', '1.0.0', :'image2ID');
+insert into repository_tracking_run (repository_id, duration_ms, errors)
+values (:'repo1ID', 1000, false);
+insert into repository_tracking_run (repository_id, duration_ms, errors)
+values (:'repo1ID', 2000, true);
+insert into repository_tracking_run (repository_id, duration_ms, errors, tracked_at)
+values (:'repo1ID', 9000, true, current_timestamp - '31 days'::interval);
+
+-- Run some tests
+select is(
+    get_repository_stats(:'user1ID', 'repo1')::jsonb,
+    '{
+        "packages_count": 1,
+        "versions_count": 2,
+        "tracking_runs_count": 2,
+        "tracking_error_rate": 0.5,
+        "tracking_avg_duration_ms": 1500,
+        "images_storage_bytes": 6
+    }'::jsonb,
+    'Stats of repository owned by user1 should be returned'
+);
+select is(
+    get_repository_stats(:'user2ID', 'repo2')::jsonb,
+    '{
+        "packages_count": 1,
+        "versions_count": 1,
+        "tracking_runs_count": 0,
+        "tracking_error_rate": null,
+        "tracking_avg_duration_ms": null,
+        "images_storage_bytes": 5
+    }'::jsonb,
+    'Stats of repository owned by org1 should be returned to its members'
+);
+select throws_ok(
+    $$ select get_repository_stats('00000000-0000-0000-0000-000000000002', 'repo1') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to get stats of repository owned by user1'
+);
+select throws_ok(
+    $$ select get_repository_stats('00000000-0000-0000-0000-000000000001', 'repo2') $$,
+    42501,
+    'insufficient_privilege',
+    'User1 should not be able to get stats of repository owned by org1'
+);
+select is_empty(
+    $$ select get_repository_stats('00000000-0000-0000-0000-000000000001', 'repo3') $$,
+    'No stats should be returned for a repository that does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
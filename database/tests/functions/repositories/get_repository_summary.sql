@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(3);
+select plan(4);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -66,6 +66,7 @@ select is(
         "private": false,
         "kind": 0,
         "verified_publisher": false,
+        "domain_verified": false,
         "official": false,
         "scanner_disabled": false,
         "user_alias": "user1"
@@ -82,12 +83,19 @@ select is(
         "private": true,
         "kind": 0,
         "verified_publisher": false,
+        "domain_verified": false,
         "official": false,
         "scanner_disabled": true,
         "user_alias": "user1"
     }'::jsonb,
     'Repository 2 is returned as a json object'
 );
+update repository set archived = true where repository_id = :'repo1ID';
+select is(
+    get_repository_summary('00000000-0000-0000-0000-000000000001')::jsonb->'archived',
+    'true'::jsonb,
+    'Repository 1 is flagged as archived once it has been archived'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
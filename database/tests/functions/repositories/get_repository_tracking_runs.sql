@@ -0,0 +1,123 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set run1ID '00000000-0000-0000-0000-000000000001'
+\set run2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into repository_tracking_run (
+    repository_tracking_run_id,
+    repository_id,
+    started_at,
+    tracked_at,
+    duration_ms,
+    packages_added,
+    packages_updated,
+    packages_deleted,
+    errors,
+    tracking_errors
+) values (
+    :'run1ID',
+    :'repo1ID',
+    '2020-06-16 11:20:32+02',
+    '2020-06-16 11:20:34+02',
+    2000,
+    2,
+    1,
+    0,
+    true,
+    'some errors'
+), (
+    :'run2ID',
+    :'repo1ID',
+    '2020-06-17 11:20:33+02',
+    '2020-06-17 11:20:34+02',
+    1000,
+    0,
+    0,
+    1,
+    false,
+    null
+);
+
+-- Run some tests
+select is(
+    data::jsonb,
+    '[
+        {
+            "repository_tracking_run_id": "00000000-0000-0000-0000-000000000002",
+            "started_at": 1592385633,
+            "finished_at": 1592385634,
+            "duration_ms": 1000,
+            "packages_added": 0,
+            "packages_updated": 0,
+            "packages_deleted": 1
+        },
+        {
+            "repository_tracking_run_id": "00000000-0000-0000-0000-000000000001",
+            "started_at": 1592299232,
+            "finished_at": 1592299234,
+            "duration_ms": 2000,
+            "packages_added": 2,
+            "packages_updated": 1,
+            "packages_deleted": 0,
+            "errors": "some errors"
+        }
+    ]'::jsonb,
+    'Tracking runs of repository owned by user1 should be returned, most recent first'
+) from get_repository_tracking_runs(:'user1ID', 'repo1', 0, 0);
+select results_eq(
+    $$
+        select data::jsonb->0->>'repository_tracking_run_id', total_count
+        from get_repository_tracking_runs('00000000-0000-0000-0000-000000000001', 'repo1', 1, 1)
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000001', 2::bigint)
+    $$,
+    'Tracking runs should be paginated, keeping the total count'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count
+        from get_repository_tracking_runs('00000000-0000-0000-0000-000000000002', 'repo2', 0, 0)
+    $$,
+    $$
+        values ('[]'::jsonb, 0::bigint)
+    $$,
+    'Empty list of tracking runs of repository owned by org1 should be returned to its members'
+);
+select throws_ok(
+    $$ select * from get_repository_tracking_runs('00000000-0000-0000-0000-000000000002', 'repo1', 0, 0) $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to get tracking runs of repository owned by user1'
+);
+select throws_ok(
+    $$ select * from get_repository_tracking_runs('00000000-0000-0000-0000-000000000001', 'repo2', 0, 0) $$,
+    42501,
+    'insufficient_privilege',
+    'User1 should not be able to get tracking runs of repository owned by org1'
+);
+select is_empty(
+    $$ select * from get_repository_tracking_runs('00000000-0000-0000-0000-000000000001', 'repo3', 0, 0) $$,
+    'No tracking runs should be returned for a repository that does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,76 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+
+-- No transfers at this point
+select is(
+    get_user_repository_transfers(:'user2ID')::jsonb,
+    '[]'::jsonb,
+    'No transfers expected'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user3ID', 'user3', 'user3@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 0, :'user1ID');
+insert into repository_transfer (repository_id, requesting_user_id, user_id, expires_at)
+values (:'repo1ID', :'user1ID', :'user2ID', '2050-01-01 00:00:00+00');
+insert into repository_transfer (repository_id, requesting_user_id, organization_id, expires_at)
+values (:'repo2ID', :'user1ID', :'org1ID', '2050-01-01 00:00:00+00');
+insert into repository_transfer (repository_id, requesting_user_id, user_id, expires_at)
+values (:'repo3ID', :'user1ID', :'user2ID', current_timestamp - '1 hour'::interval);
+
+-- Run some tests
+select is(
+    get_user_repository_transfers(:'user2ID')::jsonb,
+    '[
+        {
+            "repository_name": "repo1",
+            "repository_display_name": "Repo 1",
+            "repository_kind_id": 0,
+            "requesting_user_alias": "user1",
+            "user_alias": "user2",
+            "expires_at": 2524608000
+        },
+        {
+            "repository_name": "repo2",
+            "repository_display_name": "Repo 2",
+            "repository_kind_id": 0,
+            "requesting_user_alias": "user1",
+            "organization_name": "org1",
+            "organization_display_name": "Organization 1",
+            "expires_at": 2524608000
+        }
+    ]'::jsonb,
+    'Pending transfers targeting user2 or org1 should be returned'
+);
+select is(
+    get_user_repository_transfers(:'user3ID')::jsonb,
+    '[]'::jsonb,
+    'No transfers expected for user3'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,33 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, digest)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID', 'digest1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, digest, archived)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID', 'digest2', true);
+
+-- Run some tests
+select request_repository_tracking('repo1');
+select results_eq(
+    $$ select name, digest, tracking_requested from repository where name = 'repo1' $$,
+    $$ values ('repo1', null::text, true) $$,
+    'Tracking of repo1 should have been requested and its digest reset'
+);
+select request_repository_tracking('repo2');
+select results_eq(
+    $$ select name, digest, tracking_requested from repository where name = 'repo2' $$,
+    $$ values ('repo2', 'digest2', false) $$,
+    'Tracking of repo2 should not have been requested as it is archived'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
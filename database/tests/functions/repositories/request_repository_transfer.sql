@@ -0,0 +1,107 @@
+-- Start transaction and plan tests
+begin;
+select plan(9);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select request_repository_transfer('00000000-0000-0000-0000-000000000001', 'repo3', 'user2', null, 3600) $$,
+    'P0001',
+    'repository not found',
+    'Transfer request should fail because the repository does not exist'
+);
+select throws_ok(
+    $$ select request_repository_transfer('00000000-0000-0000-0000-000000000002', 'repo1', 'user2', null, 3600) $$,
+    42501,
+    'insufficient_privilege',
+    'Transfer request should fail because requesting user does not own the repository'
+);
+select throws_ok(
+    $$ select request_repository_transfer('00000000-0000-0000-0000-000000000002', 'repo2', 'user2', null, 3600) $$,
+    42501,
+    'insufficient_privilege',
+    'Transfer request should fail because requesting user does not belong to the owning organization'
+);
+select throws_ok(
+    $$ select request_repository_transfer('00000000-0000-0000-0000-000000000001', 'repo1', 'user3', null, 3600) $$,
+    'P0001',
+    'target not found',
+    'Transfer request should fail because the target user does not exist'
+);
+select throws_ok(
+    $$ select request_repository_transfer('00000000-0000-0000-0000-000000000001', 'repo1', null, 'org3', 3600) $$,
+    'P0001',
+    'target not found',
+    'Transfer request should fail because the target organization does not exist'
+);
+select throws_ok(
+    $$ select request_repository_transfer('00000000-0000-0000-0000-000000000001', 'repo2', null, 'org1', 3600) $$,
+    'P0001',
+    'repository already owned by target',
+    'Transfer request should fail because the target organization already owns the repository'
+);
+select request_repository_transfer(:'user1ID', 'repo1', 'user2', null, 3600);
+select results_eq(
+    $$
+        select requesting_user_id, user_id, organization_id
+        from repository_transfer
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            null::uuid
+        )
+    $$,
+    'Transfer of repo1 to user2 should be registered'
+);
+select request_repository_transfer(:'user1ID', 'repo1', null, 'org2', 3600);
+select results_eq(
+    $$
+        select requesting_user_id, user_id, organization_id
+        from repository_transfer
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            null::uuid,
+            '00000000-0000-0000-0000-000000000002'::uuid
+        )
+    $$,
+    'Transfer of repo1 to org2 should replace the previous request'
+);
+select is(
+    user_id,
+    :'user1ID',
+    'Repository repo1 should still be owned by user1 until the transfer is accepted'
+)
+from repository
+where repository_id = :'repo1ID';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(9);
+select plan(10);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -40,6 +40,7 @@ insert into repository (
     url,
     auth_user,
     auth_pass,
+    domain_verification_token,
     last_tracking_ts,
     last_tracking_errors,
     repository_kind_id,
@@ -51,6 +52,7 @@ insert into repository (
     'https://repo1.com',
     'user',
     'pass',
+    'token1',
     '1970-01-01 00:00:00 UTC',
     'error1\nerror2\nerror3',
     0,
@@ -77,14 +79,16 @@ insert into repository (
     display_name,
     url,
     repository_kind_id,
-    user_id
+    user_id,
+    labels
 ) values (
     :'repo3ID',
     'repo3',
     'Repo 3',
     'https://repo3.com',
     1,
-    :'user1ID'
+    :'user1ID',
+    '{databases,observability}'
 );
 insert into repository (
     repository_id,
@@ -117,9 +121,13 @@ select results_eq(
                     "url": "https://repo1.com",
                     "kind": 0,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "tracking_requested": false,
                     "last_tracking_ts": 0,
                     "last_tracking_errors": "error1\\nerror2\\nerror3",
                     "organization_name": "org1",
@@ -132,9 +140,13 @@ select results_eq(
                     "url": "https://repo2.com",
                     "kind": 0,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "tracking_requested": false,
                     "organization_name": "org1",
                     "organization_display_name": "Organization 1"
                 },
@@ -145,9 +157,14 @@ select results_eq(
                     "url": "https://repo3.com",
                     "kind": 1,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "labels": ["databases", "observability"],
+                    "tracking_requested": false,
                     "user_alias": "user1"
                 },
                 {
@@ -157,9 +174,13 @@ select results_eq(
                     "url": "https://repo4.com",
                     "kind": 1,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "tracking_requested": false,
                     "organization_name": "org2",
                     "organization_display_name": "Organization 2"
                 }
@@ -185,9 +206,13 @@ select results_eq(
                     "url": "https://repo2.com",
                     "kind": 0,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "tracking_requested": false,
                     "organization_name": "org1",
                     "organization_display_name": "Organization 1"
                 }
@@ -212,9 +237,13 @@ select results_eq(
                     "url": "https://repo2.com",
                     "kind": 0,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "tracking_requested": false,
                     "organization_name": "org1",
                     "organization_display_name": "Organization 1"
                 }
@@ -239,9 +268,14 @@ select results_eq(
                     "url": "https://repo3.com",
                     "kind": 1,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "labels": ["databases", "observability"],
+                    "tracking_requested": false,
                     "user_alias": "user1"
                 },
                 {
@@ -251,9 +285,13 @@ select results_eq(
                     "url": "https://repo4.com",
                     "kind": 1,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "tracking_requested": false,
                     "organization_name": "org2",
                     "organization_display_name": "Organization 2"
                 }
@@ -279,9 +317,13 @@ select results_eq(
                     "url": "https://repo4.com",
                     "kind": 1,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "tracking_requested": false,
                     "organization_name": "org2",
                     "organization_display_name": "Organization 2"
                 }
@@ -306,9 +348,14 @@ select results_eq(
                     "url": "https://repo3.com",
                     "kind": 1,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "labels": ["databases", "observability"],
+                    "tracking_requested": false,
                     "user_alias": "user1"
                 }
             ]'::jsonb,
@@ -328,6 +375,37 @@ select results_eq(
     $$,
     'Filtering by kind 1 and org1, no repositories returned'
 );
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer from search_repositories('{
+            "labels": ["observability", "security"]
+        }')
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "repository_id": "00000000-0000-0000-0000-000000000003",
+                    "name": "repo3",
+                    "display_name": "Repo 3",
+                    "url": "https://repo3.com",
+                    "kind": 1,
+                    "verified_publisher": false,
+                    "domain_verified": false,
+                    "official": false,
+                    "disabled": false,
+                    "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "labels": ["databases", "observability"],
+                    "tracking_requested": false,
+                    "user_alias": "user1"
+                }
+            ]'::jsonb,
+            1)
+    $$,
+    'Filtering by observability or security labels, repository 3 returned'
+);
 select results_eq(
     $$
         select data::jsonb, total_count::integer from search_repositories('{
@@ -347,9 +425,14 @@ select results_eq(
                     "auth_pass": "pass",
                     "kind": 0,
                     "verified_publisher": false,
+                    "domain_verified": false,
+                    "domain_verification_token": "token1",
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "tracking_paused": false,
+                    "archived": false,
+                    "tracking_requested": false,
                     "last_tracking_ts": 0,
                     "last_tracking_errors": "error1\\nerror2\\nerror3",
                     "organization_name": "org1",
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(15);
+select plan(19);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -21,47 +21,108 @@ select is(count(*), 0::bigint, 'No tracking errors events should have been regis
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results and run some more tests
-select set_last_tracking_results(:'repo1ID', '', true);
+update repository set tracking_requested = true where name = 'repo1';
+select set_last_tracking_results(:'repo1ID', '', '{"duration_ms": 1000}', true);
 select isnt(last_tracking_ts, null, 'Last tracking ts should have been set')
 from repository where name = 'repo1';
+select is(tracking_requested, false, 'Tracking request should have been cleared')
+from repository where name = 'repo1';
 select is(last_tracking_errors, null, 'Last tracking errors should have been set to null')
 from repository where name = 'repo1';
 select is(count(*), 0::bigint, 'No tracking errors events should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some errors', true);
+select set_last_tracking_results(:'repo1ID', 'some errors', '{"duration_ms": 1000}', true);
 select is(last_tracking_errors, 'some errors', 'Last tracking errors should have been set to some errors')
 from repository where name = 'repo1';
 select is(count(*), 1::bigint, 'One tracking error event should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with the same error and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some errors', true);
+select set_last_tracking_results(:'repo1ID', 'some errors', '{"duration_ms": 1000}', true);
 select is(count(*), 1::bigint, 'No more tracking error events should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with another error and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some new errors', true);
+select set_last_tracking_results(:'repo1ID', 'some new errors', '{"duration_ms": 1000}', true);
 select is(last_tracking_errors, 'some new errors', 'Last tracking errors should have been set to some new errors')
 from repository where name = 'repo1';
 select is(count(*), 2::bigint, 'One more tracking error event should have been registered (total 2 now)')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with no errors and run some more tests
-select set_last_tracking_results(:'repo1ID', '', true);
+select set_last_tracking_results(:'repo1ID', '', '{"duration_ms": 1000}', true);
 select is(last_tracking_errors, null, 'Last tracking errors should have been set to null')
 from repository where name = 'repo1';
 select is(count(*), 2::bigint, 'No more tracking error events should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with another error and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some new errors', false);
+select set_last_tracking_results(:'repo1ID', 'some new errors', '{"duration_ms": 1000}', false);
 select is(last_tracking_errors, 'some new errors', 'Last tracking errors should have been set to some new errors')
 from repository where name = 'repo1';
 select is(count(*), 2::bigint, 'No more tracking error events should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
+-- Check tracking runs were registered and old ones cleaned up
+select results_eq(
+    $$
+        select duration_ms, errors
+        from repository_tracking_run
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+        order by tracked_at, errors
+    $$,
+    $$
+        values
+            (1000, false),
+            (1000, false),
+            (1000, true),
+            (1000, true),
+            (1000, true),
+            (1000, true)
+    $$,
+    'Tracking runs should have been registered'
+);
+update repository_tracking_run set tracked_at = current_timestamp - '31 days'::interval;
+select set_last_tracking_results(:'repo1ID', '', '{}', false);
+select results_eq(
+    $$
+        select duration_ms, errors
+        from repository_tracking_run
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (null::int, false)
+    $$,
+    'Tracking runs older than 30 days should have been deleted'
+);
+select set_last_tracking_results(:'repo1ID', 'some errors', '{
+    "started_at": 1592299232,
+    "duration_ms": 2000,
+    "packages_added": 2,
+    "packages_updated": 1,
+    "packages_deleted": 3
+}', false);
+select results_eq(
+    $$
+        select
+            floor(extract(epoch from started_at))::bigint,
+            duration_ms,
+            packages_added,
+            packages_updated,
+            packages_deleted,
+            tracking_errors
+        from repository_tracking_run
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+        and errors = true
+    $$,
+    $$
+        values (1592299232::bigint, 2000, 2, 1, 3, 'some errors')
+    $$,
+    'Tracking run details should have been registered'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
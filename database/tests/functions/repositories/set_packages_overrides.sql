@@ -0,0 +1,76 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id, category, hidden)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID', 'storage', true);
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id, hidden)
+values (:'package3ID', 'package2', '1.0.0', :'repo2ID', true);
+
+-- Set overrides and run some tests
+select set_packages_overrides(:'repo1ID', '[
+    {"name": "package2", "category": "database", "hidden": true}
+]');
+select results_eq(
+    $$
+        select name, category, hidden
+        from package
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+        order by name
+    $$,
+    $$
+        values
+            ('package1', null, false),
+            ('package2', 'database', true)
+    $$,
+    'Package overrides should have been set and previous ones cleared'
+);
+select results_eq(
+    $$
+        select category, hidden
+        from package
+        where repository_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$
+        values (null, true)
+    $$,
+    'Packages in other repositories should not have been updated'
+);
+
+-- Clear overrides and check the packages have been reset
+select set_packages_overrides(:'repo1ID', '[]');
+select results_eq(
+    $$
+        select name, category, hidden
+        from package
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+        order by name
+    $$,
+    $$
+        values
+            ('package1', null, false),
+            ('package2', null, false)
+    $$,
+    'Package overrides should have been cleared'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
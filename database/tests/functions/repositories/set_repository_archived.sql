@@ -0,0 +1,56 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select set_repository_archived('00000000-0000-0000-0000-000000000002', 'repo1', true) $$,
+    42501,
+    'insufficient_privilege',
+    'User who does not own the repository should not be able to archive it'
+);
+select throws_ok(
+    $$ select set_repository_archived('00000000-0000-0000-0000-000000000002', 'repo2', true) $$,
+    42501,
+    'insufficient_privilege',
+    'User not belonging to the owning organization should not be able to archive the repository'
+);
+select set_repository_archived(:'user1ID', 'repo1', true);
+select results_eq(
+    $$ select name, archived from repository order by name $$,
+    $$ values ('repo1', true), ('repo2', false) $$,
+    'Repository owned by the user should have been archived'
+);
+select set_repository_archived(:'user1ID', 'repo2', true);
+select results_eq(
+    $$ select name, archived from repository order by name $$,
+    $$ values ('repo1', true), ('repo2', true) $$,
+    'Repository owned by the organization should have been archived'
+);
+select set_repository_archived(:'user1ID', 'repo1', false);
+select results_eq(
+    $$ select name, archived from repository order by name $$,
+    $$ values ('repo1', false), ('repo2', true) $$,
+    'Repository owned by the user should have been unarchived'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(5);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -20,6 +20,19 @@ from repository where name = 'repo1';
 select set_verified_publisher(:'repo1ID', true);
 select is(verified_publisher, true, 'Verified publisher should be now true')
 from repository where name = 'repo1';
+select isnt(verified_publisher_at, null, 'Verified publisher timestamp should have been set')
+from repository where name = 'repo1';
+
+-- Set verified publisher again and check the timestamp is kept
+update repository set verified_publisher_at = '2020-06-16 11:20:34+02' where name = 'repo1';
+select set_verified_publisher(:'repo1ID', true);
+select is(verified_publisher_at, '2020-06-16 11:20:34+02'::timestamptz, 'Verified publisher timestamp should not have changed')
+from repository where name = 'repo1';
+
+-- Unset verified publisher and run some more tests
+select set_verified_publisher(:'repo1ID', false);
+select is(verified_publisher_at, null, 'Verified publisher timestamp should have been cleared')
+from repository where name = 'repo1';
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(7);
+select plan(11);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -17,10 +17,10 @@ values (:'user1ID', 'user1', 'user1@email.com');
 insert into organization (organization_id, name, display_name, description, home_url)
 values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
 insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
-insert into repository (repository_id, name, display_name, url, digest, repository_kind_id, user_id)
-values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 'digest', 0, :'user1ID');
-insert into repository (repository_id, name, display_name, url, branch, repository_kind_id, organization_id)
-values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 'main', 0, :'org1ID');
+insert into repository (repository_id, name, display_name, url, digest, domain_verified, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 'digest', true, 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, branch, digest, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 'main', 'digest', 0, :'org1ID');
 insert into package (
     package_id,
     name,
@@ -46,16 +46,19 @@ insert into package (
 insert into snapshot (
     package_id,
     version,
+    digest,
     security_report,
     security_report_created_at,
     security_report_summary
 ) values (
     :'package2ID',
     '1.0.0',
+    'pkg2-digest',
     '{"k": "v"}',
     '2020-06-16 11:20:38+02',
     '{"k": "v"}'
 );
+select refresh_package_documents(:'package2ID');
 
 -- Try to update repository owned by a user by other user
 select throws_ok(
@@ -114,12 +117,12 @@ select update_repository(:'user1ID', '
 '::jsonb);
 select results_eq(
     $$
-        select name, display_name, url, branch, auth_user, auth_pass, disabled, digest
+        select name, display_name, url, branch, auth_user, auth_pass, disabled, digest, domain_verified
         from repository
         where name = 'repo1'
     $$,
     $$
-        values ('repo1', 'Repo 1 updated', 'https://repo1.com/updated', 'main', 'user1', 'pass1', true, null)
+        values ('repo1', 'Repo 1 updated', 'https://repo1.com/updated', 'main', 'user1', 'pass1', true, null, false)
     $$,
     'Repository should have been updated by user who owns it'
 );
@@ -138,17 +141,20 @@ select update_repository(:'user1ID', '
     "auth_user": "user1",
     "auth_pass": "pass1",
     "disabled": false,
-    "scanner_disabled": true
+    "scanner_disabled": true,
+    "signing_policy": "flag",
+    "tracking_schedule": "@daily",
+    "labels": ["databases"]
 }
 '::jsonb);
 select results_eq(
     $$
-        select name, display_name, url, branch, auth_user, auth_pass, disabled
+        select name, display_name, url, branch, auth_user, auth_pass, disabled, signing_policy, tracking_schedule, labels
         from repository
         where name = 'repo2'
     $$,
     $$
-        values ('repo2', 'Repo 2 updated', 'https://repo2.com/updated', null, 'user1', 'pass1', false)
+        values ('repo2', 'Repo 2 updated', 'https://repo2.com/updated', null, 'user1', 'pass1', false, 'flag', '@daily', '{databases}'::text[])
     $$,
     'Repository should have been updated by user who belongs to owning organization'
 );
@@ -167,6 +173,62 @@ select results_eq(
     $$,
     'Security reports in packages belonging to repo2 should have been deleted'
 );
+select results_eq(
+    $$
+        select document ? 'security_report_summary'
+        from package_document
+        where package_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$
+        values (false)
+    $$,
+    'Documents of packages belonging to repo2 should not include the security report summary'
+);
+
+-- Update repository setting a sign keyring
+select update_repository(:'user1ID', '
+{
+    "name": "repo2",
+    "display_name": "Repo 2 updated",
+    "url": "https://repo2.com/updated",
+    "disabled": false,
+    "scanner_disabled": true,
+    "sign_keyring_url": "https://repo2.com/keyring.asc"
+}
+'::jsonb);
+select results_eq(
+    $$
+        select sign_keyring, sign_keyring_url, digest
+        from repository
+        where name = 'repo2'
+    $$,
+    $$
+        values (null::text, 'https://repo2.com/keyring.asc', null::text)
+    $$,
+    'Repository sign keyring should have been updated and its digest reset'
+);
+select results_eq(
+    $$
+        select digest
+        from snapshot
+        where package_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$
+        values (null::text)
+    $$,
+    'Digests of packages belonging to repo2 should have been reset'
+);
+select results_eq(
+    $$
+        select document ? 'digest'
+        from package_document
+        where package_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$
+        values (false)
+    $$,
+    'Documents of packages belonging to repo2 should not include the digest'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -0,0 +1,61 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+
+-- Run some tests
+select throws_ok(
+    $$ select add_service_account('00000000-0000-0000-0000-000000000002', 'org1', '{"name": "sa1"}') $$,
+    42501,
+    'insufficient_privilege',
+    'User not belonging to the organization should not be able to add service accounts'
+);
+select throws_ok(
+    $$ select add_service_account('00000000-0000-0000-0000-000000000001', 'org1', '{"name": "user2"}') $$,
+    'P0001',
+    'service account name not available',
+    'Service account name should not be in use by other user'
+);
+select lives_ok(
+    $$ select add_service_account('00000000-0000-0000-0000-000000000001', 'org1', '{"name": "sa1"}') $$,
+    'Service account should be added by user belonging to the organization'
+);
+select results_eq(
+    $$
+        select u.alias, u.email, u.password, uo.confirmed, uo.role
+        from "user" u
+        join user__organization uo using (user_id)
+        where u.service_account_organization_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('sa1', null::text, null::text, true, 'maintainer')
+    $$,
+    'Service account should exist and be a confirmed member of the organization'
+);
+select add_service_account(:'user1ID', 'org1', '{"name": "sa2", "role": "viewer"}');
+select results_eq(
+    $$
+        select uo.role
+        from "user" u
+        join user__organization uo using (user_id)
+        where u.alias = 'sa2'
+    $$,
+    $$
+        values ('viewer')
+    $$,
+    'Service account should have been added with the role provided'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,45 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set sa1ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into "user" (user_id, alias, service_account_organization_id) values (:'sa1ID', 'sa1', :'org1ID');
+insert into user__organization (user_id, organization_id, confirmed) values(:'sa1ID', :'org1ID', true);
+insert into api_key (name, secret, user_id) values ('apikey1', 'secret', :'sa1ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select delete_service_account('00000000-0000-0000-0000-000000000002', 'org1', '00000000-0000-0000-0000-000000000003') $$,
+    42501,
+    'insufficient_privilege',
+    'User not belonging to the organization should not be able to delete its service accounts'
+);
+select throws_ok(
+    $$ select delete_service_account('00000000-0000-0000-0000-000000000001', 'org1', '00000000-0000-0000-0000-000000000002') $$,
+    'P0001',
+    'service account not found',
+    'Regular users cannot be deleted as service accounts'
+);
+select delete_service_account(:'user1ID', 'org1', :'sa1ID');
+select is_empty(
+    $$ select * from "user" where user_id = '00000000-0000-0000-0000-000000000003' $$,
+    'Service account should have been deleted'
+);
+select is_empty(
+    $$ select * from api_key where user_id = '00000000-0000-0000-0000-000000000003' $$,
+    'Service account api keys should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,57 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set sa1ID '00000000-0000-0000-0000-000000000003'
+\set apiKey1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+
+-- Run some tests
+select throws_ok(
+    $$ select get_org_service_accounts('00000000-0000-0000-0000-000000000002', 'org1') $$,
+    42501,
+    'insufficient_privilege',
+    'User not belonging to the organization should not be able to get its service accounts'
+);
+select is(
+    get_org_service_accounts(:'user1ID', 'org1')::jsonb,
+    '[]'::jsonb,
+    'No service accounts expected'
+);
+insert into "user" (user_id, alias, service_account_organization_id, created_at)
+values (:'sa1ID', 'sa1', :'org1ID', '2020-06-16 11:20:34+02');
+insert into user__organization (user_id, organization_id, confirmed, role) values(:'sa1ID', :'org1ID', true, 'maintainer');
+insert into api_key (api_key_id, name, secret, user_id, created_at)
+values (:'apiKey1ID', 'apikey1', 'secret', :'sa1ID', '2020-06-16 11:20:34+02');
+select is(
+    get_org_service_accounts(:'user1ID', 'org1')::jsonb,
+    '[
+        {
+            "service_account_id": "00000000-0000-0000-0000-000000000003",
+            "name": "sa1",
+            "role": "maintainer",
+            "created_at": 1592299234,
+            "api_keys": [
+                {
+                    "api_key_id": "00000000-0000-0000-0000-000000000001",
+                    "name": "apikey1",
+                    "created_at": 1592299234
+                }
+            ]
+        }
+    ]'::jsonb,
+    'Service account and its api keys expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,76 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'Package 2', '1.0.0', :'repo2ID');
+insert into subscription (user_id, package_id, event_kind_id)
+values (:'user1ID', :'package1ID', 1);
+insert into subscription (user_id, package_id, event_kind_id)
+values (:'user1ID', :'package1ID', 0);
+insert into subscription (user_id, package_id, event_kind_id)
+values (:'user1ID', :'package2ID', 0);
+insert into user_starred_package (user_id, package_id)
+values (:'user1ID', :'package2ID');
+
+-- Run some tests
+select is(
+    export_user_subscriptions(:'user1ID')::jsonb,
+    '{
+        "version": 1,
+        "subscriptions": [
+            {
+                "repository_name": "repo1",
+                "package_name": "package-1",
+                "event_kinds": [0, 1]
+            },
+            {
+                "repository_name": "repo2",
+                "package_name": "package-2",
+                "event_kinds": [0]
+            }
+        ],
+        "stars": [
+            {
+                "repository_name": "repo2",
+                "package_name": "package-2"
+            }
+        ]
+    }'::jsonb,
+    'Subscriptions and stars of user1 should be exported'
+);
+select is(
+    export_user_subscriptions(:'user2ID')::jsonb,
+    '{
+        "version": 1,
+        "subscriptions": [],
+        "stars": []
+    }'::jsonb,
+    'Empty document should be exported for user2'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,11 +1,13 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(3);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
 \set user2ID '00000000-0000-0000-0000-000000000002'
 \set user3ID '00000000-0000-0000-0000-000000000003'
+\set user4ID '00000000-0000-0000-0000-000000000004'
+\set org1ID '00000000-0000-0000-0000-000000000001'
 \set repo1ID '00000000-0000-0000-0000-000000000001'
 \set package1ID '00000000-0000-0000-0000-000000000001'
 \set package2ID '00000000-0000-0000-0000-000000000002'
@@ -17,6 +19,9 @@ insert into "user" (user_id, alias, email)
 values (:'user2ID', 'user2', 'user2@email.com');
 insert into "user" (user_id, alias, email)
 values (:'user3ID', 'user3', 'user3@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'user4ID', 'sa1', :'org1ID');
 insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
 values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
 insert into package (package_id, name, latest_version, repository_id)
@@ -27,6 +32,8 @@ insert into subscription (user_id, package_id, event_kind_id)
 values (:'user2ID', :'package1ID', 0);
 insert into subscription (user_id, package_id, event_kind_id)
 values (:'user3ID', :'package1ID', 1);
+insert into subscription (user_id, package_id, event_kind_id)
+values (:'user4ID', :'package1ID', 0);
 
 -- Run some tests
 select is(
@@ -46,6 +53,12 @@ select is(
     '[]'::jsonb,
     'No subscriptors expected for package2 and kind new releases'
 );
+update repository set archived = true where repository_id = :'repo1ID';
+select is(
+    get_package_subscriptors(:'package1ID', 0)::jsonb,
+    '[]'::jsonb,
+    'No subscriptors expected for package1 once its repository has been archived'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
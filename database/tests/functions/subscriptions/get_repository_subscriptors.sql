@@ -8,6 +8,7 @@ select plan(2);
 \set user3ID '00000000-0000-0000-0000-000000000003'
 \set user4ID '00000000-0000-0000-0000-000000000004'
 \set user5ID '00000000-0000-0000-0000-000000000005'
+\set user6ID '00000000-0000-0000-0000-000000000006'
 \set org1ID '00000000-0000-0000-0000-000000000001'
 \set repo1ID '00000000-0000-0000-0000-000000000001'
 \set repo2ID '00000000-0000-0000-0000-000000000002'
@@ -29,6 +30,9 @@ insert into user__organization (user_id, organization_id, confirmed) values(:'us
 insert into user__organization (user_id, organization_id, confirmed) values(:'user3ID', :'org1ID', true);
 insert into user__organization (user_id, organization_id, confirmed) values(:'user4ID', :'org1ID', false);
 insert into user__organization (user_id, organization_id, confirmed) values(:'user5ID', :'org1ID', true);
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'user6ID', 'sa1', :'org1ID');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user6ID', :'org1ID', true);
 insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
 values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
 insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
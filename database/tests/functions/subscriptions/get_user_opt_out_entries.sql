@@ -55,6 +55,7 @@ select results_eq(
                         "private": false,
                         "kind": 0,
                         "verified_publisher": false,
+                        "domain_verified": false,
                         "official": false,
                         "scanner_disabled": false,
                         "user_alias": "user1"
@@ -71,6 +72,7 @@ select results_eq(
                         "private": false,
                         "kind": 0,
                         "verified_publisher": false,
+                        "domain_verified": false,
                         "official": false,
                         "scanner_disabled": false,
                         "user_alias": "user1"
@@ -87,6 +89,7 @@ select results_eq(
                         "private": false,
                         "kind": 0,
                         "verified_publisher": false,
+                        "domain_verified": false,
                         "official": false,
                         "scanner_disabled": false,
                         "organization_name": "org1",
@@ -118,6 +121,7 @@ select results_eq(
                         "private": false,
                         "kind": 0,
                         "verified_publisher": false,
+                        "domain_verified": false,
                         "official": false,
                         "scanner_disabled": false,
                         "user_alias": "user1"
@@ -148,6 +152,7 @@ select results_eq(
                         "private": false,
                         "kind": 0,
                         "verified_publisher": false,
+                        "domain_verified": false,
                         "official": false,
                         "scanner_disabled": false,
                         "user_alias": "user1"
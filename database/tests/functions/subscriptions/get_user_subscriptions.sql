@@ -61,6 +61,7 @@ select results_eq(
                         "private": false,
                         "kind": 0,
                         "verified_publisher": false,
+                        "domain_verified": false,
                         "official": false,
                         "scanner_disabled": false,
                         "user_alias": "user1"
@@ -80,6 +81,7 @@ select results_eq(
                         "private": false,
                         "kind": 0,
                         "verified_publisher": false,
+                        "domain_verified": false,
                         "official": false,
                         "scanner_disabled": false,
                         "organization_name": "org1",
@@ -114,6 +116,7 @@ select results_eq(
                         "private": false,
                         "kind": 0,
                         "verified_publisher": false,
+                        "domain_verified": false,
                         "official": false,
                         "scanner_disabled": false,
                         "organization_name": "org1",
@@ -0,0 +1,156 @@
+-- Start transaction and plan tests
+begin;
+select plan(8);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'Package 2', '1.0.0', :'repo1ID');
+insert into subscription (user_id, package_id, event_kind_id)
+values (:'user1ID', :'package1ID', 0);
+
+-- Run some tests
+select is(
+    import_user_subscriptions(:'user1ID', '{
+        "dry_run": true,
+        "document": {
+            "version": 1,
+            "subscriptions": [
+                {"repository_name": "repo1", "package_name": "package-1", "event_kinds": [1]},
+                {"repository_name": "repo1", "package_name": "package-2", "event_kinds": [0, 1]},
+                {"repository_name": "repo2", "package_name": "package-3", "event_kinds": [0]}
+            ],
+            "stars": [
+                {"repository_name": "repo1", "package_name": "package-2"}
+            ]
+        }
+    }')::jsonb,
+    '{
+        "dry_run": true,
+        "subscriptions_added": 3,
+        "subscriptions_removed": 0,
+        "stars_added": 1,
+        "conflicts": [
+            {"repository_name": "repo1", "package_name": "package-1"}
+        ],
+        "not_found": [
+            {"repository_name": "repo2", "package_name": "package-3"}
+        ]
+    }'::jsonb,
+    'Dry run report should be returned'
+);
+select results_eq(
+    $$ select count(*) from subscription $$,
+    $$ values (1::bigint) $$,
+    'No subscriptions should have been added in dry run mode'
+);
+select is(
+    import_user_subscriptions(:'user1ID', '{
+        "on_conflict": "skip",
+        "document": {
+            "version": 1,
+            "subscriptions": [
+                {"repository_name": "repo1", "package_name": "package-1", "event_kinds": [1]}
+            ]
+        }
+    }')::jsonb,
+    '{
+        "dry_run": false,
+        "subscriptions_added": 0,
+        "subscriptions_removed": 0,
+        "stars_added": 0,
+        "conflicts": [
+            {"repository_name": "repo1", "package_name": "package-1"}
+        ],
+        "not_found": []
+    }'::jsonb,
+    'Conflicting package should be skipped'
+);
+select is(
+    import_user_subscriptions(:'user1ID', '{
+        "document": {
+            "version": 1,
+            "subscriptions": [
+                {"repository_name": "repo1", "package_name": "package-1", "event_kinds": [1]},
+                {"repository_name": "repo1", "package_name": "package-2", "event_kinds": [0]}
+            ],
+            "stars": [
+                {"repository_name": "repo1", "package_name": "package-2"}
+            ]
+        }
+    }')::jsonb,
+    '{
+        "dry_run": false,
+        "subscriptions_added": 2,
+        "subscriptions_removed": 0,
+        "stars_added": 1,
+        "conflicts": [
+            {"repository_name": "repo1", "package_name": "package-1"}
+        ],
+        "not_found": []
+    }'::jsonb,
+    'Subscriptions should be merged and stars added'
+);
+select results_eq(
+    $$
+        select package_id, event_kind_id from subscription
+        order by package_id, event_kind_id
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, 0),
+            ('00000000-0000-0000-0000-000000000001'::uuid, 1),
+            ('00000000-0000-0000-0000-000000000002'::uuid, 0)
+    $$,
+    'Subscriptions should exist after merging'
+);
+select results_eq(
+    $$ select stars from package where package_id = '00000000-0000-0000-0000-000000000002' $$,
+    $$ values (1) $$,
+    'Package 2 should have been starred'
+);
+select is(
+    import_user_subscriptions(:'user1ID', '{
+        "on_conflict": "replace",
+        "document": {
+            "version": 1,
+            "subscriptions": [
+                {"repository_name": "repo1", "package_name": "package-1", "event_kinds": [1]}
+            ]
+        }
+    }')::jsonb,
+    '{
+        "dry_run": false,
+        "subscriptions_added": 0,
+        "subscriptions_removed": 1,
+        "stars_added": 0,
+        "conflicts": [
+            {"repository_name": "repo1", "package_name": "package-1"}
+        ],
+        "not_found": []
+    }'::jsonb,
+    'Conflicting subscriptions should be replaced'
+);
+select results_eq(
+    $$
+        select event_kind_id from subscription
+        where package_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values (1) $$,
+    'Only the imported subscription should exist for package 1'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
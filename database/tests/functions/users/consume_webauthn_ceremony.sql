@@ -0,0 +1,42 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Seed some data
+insert into webauthn_ceremony (webauthn_ceremony_id, session_data, created_at)
+values ('ceremony1', '{"challenge": "challenge1"}', current_timestamp - '1 minute'::interval);
+insert into webauthn_ceremony (webauthn_ceremony_id, session_data, created_at)
+values ('ceremony2', '{"challenge": "challenge2"}', current_timestamp - '30 minute'::interval);
+
+-- Ceremony consumption should fail in the following cases
+select throws_ok(
+    $$ select consume_webauthn_ceremony('ceremony3') $$,
+    'P0001',
+    'invalid webauthn ceremony',
+    'Consume ceremony failed because ceremony did not exist'
+);
+select throws_ok(
+    $$ select consume_webauthn_ceremony('ceremony2') $$,
+    'P0001',
+    'invalid webauthn ceremony',
+    'Consume ceremony failed because ceremony has expired'
+);
+
+-- Ceremony consumption should succeed
+select is(
+    consume_webauthn_ceremony('ceremony1'),
+    '{"challenge": "challenge1"}'::jsonb,
+    'Consume ceremony succeeded and session data was returned'
+);
+
+-- Ceremonies can only be consumed once
+select throws_ok(
+    $$ select consume_webauthn_ceremony('ceremony1') $$,
+    'P0001',
+    'invalid webauthn ceremony',
+    'Consume ceremony failed because ceremony was already consumed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
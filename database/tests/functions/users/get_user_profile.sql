@@ -36,7 +36,9 @@ select is(
         "email": "user1@email.com",
         "profile_image_id": "00000000-0000-0000-0000-000000000001",
         "password_set": true,
-        "tfa_enabled": true
+        "tfa_enabled": true,
+        "site_admin": false,
+        "moderator": false
     }
     '::jsonb,
     'User1 should exist'
@@ -0,0 +1,39 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some users
+insert into "user" (user_id, alias, email, site_admin, moderator) values (:'user1ID', 'user1', 'user1@email.com', true, true);
+insert into "user" (user_id, alias, email, moderator) values (:'user2ID', 'user2', 'user2@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user3ID', 'user3', 'user3@email.com');
+
+-- Run some tests
+select is(
+    get_user_site_role(:'user1ID'),
+    'admin',
+    'User1 is a site admin'
+);
+select is(
+    get_user_site_role(:'user2ID'),
+    'moderator',
+    'User2 is a moderator'
+);
+select is(
+    get_user_site_role(:'user3ID'),
+    null,
+    'User3 does not have any site role'
+);
+select is(
+    get_user_site_role('00000000-0000-0000-0000-000000000009'),
+    null,
+    'Users that do not exist do not have any site role'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,73 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set credential1ID '00000000-0000-0000-0000-000000000001'
+\set credential2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into webauthn_credential (
+    webauthn_credential_id,
+    user_id,
+    name,
+    credential_id,
+    public_key,
+    created_at,
+    last_used_at
+) values (
+    :'credential1ID',
+    :'user1ID',
+    'key1',
+    '\x01',
+    '\x01',
+    '2021-08-01 10:00:00+00',
+    '2021-08-02 10:00:00+00'
+);
+insert into webauthn_credential (
+    webauthn_credential_id,
+    user_id,
+    name,
+    credential_id,
+    public_key,
+    created_at
+) values (
+    :'credential2ID',
+    :'user1ID',
+    'key2',
+    '\x02',
+    '\x02',
+    '2021-08-03 10:00:00+00'
+);
+
+-- Run some tests
+select is(
+    get_user_webauthn_credentials(:'user1ID')::jsonb,
+    '[
+        {
+            "webauthn_credential_id": "00000000-0000-0000-0000-000000000001",
+            "name": "key1",
+            "created_at": 1627812000,
+            "last_used_at": 1627898400
+        },
+        {
+            "webauthn_credential_id": "00000000-0000-0000-0000-000000000002",
+            "name": "key2",
+            "created_at": 1627984800
+        }
+    ]'::jsonb,
+    'Credentials registered by user1 are returned as a json array'
+);
+select is(
+    get_user_webauthn_credentials(:'user2ID')::jsonb,
+    '[]'::jsonb,
+    'No credentials expected for user2'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,66 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into webauthn_credential (
+    user_id,
+    name,
+    credential_id,
+    public_key,
+    attestation_type,
+    aaguid,
+    sign_count
+) values (
+    :'user1ID',
+    'key1',
+    '\x0102',
+    '\x0304',
+    'none',
+    '\x0506',
+    10
+);
+
+-- Run some tests
+select is(
+    get_user_webauthn_info(:'user1ID')::jsonb,
+    '{
+        "user_id": "00000000-0000-0000-0000-000000000001",
+        "alias": "user1",
+        "email": "user1@email.com",
+        "credentials": [
+            {
+                "id": "AQI=",
+                "public_key": "AwQ=",
+                "attestation_type": "none",
+                "aaguid": "BQY=",
+                "sign_count": 10
+            }
+        ]
+    }'::jsonb,
+    'User1 information including its credentials is returned as a json object'
+);
+select is(
+    get_user_webauthn_info(:'user2ID')::jsonb,
+    '{
+        "user_id": "00000000-0000-0000-0000-000000000002",
+        "alias": "user2",
+        "email": "user2@email.com",
+        "credentials": []
+    }'::jsonb,
+    'User2 information without credentials is returned as a json object'
+);
+select is_empty(
+    $$ select get_user_webauthn_info('00000000-0000-0000-0000-000000000003') $$,
+    'No information expected for user that does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
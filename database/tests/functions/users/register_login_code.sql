@@ -0,0 +1,68 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed users
+insert into "user" (user_id, alias, email, email_verified)
+values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email, email_verified)
+values (:'user2ID', 'user2', 'user2@email.com', false);
+insert into login_code (login_code_id, user_id, device_id, created_at)
+values ('expiredCode', :'user1ID', 'device1', current_timestamp - '30 minute'::interval);
+
+-- Register login code
+select register_login_code('user1@email.com', 'code1', 'device1');
+select results_eq(
+    $$
+        select login_code_id, device_id
+        from login_code
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('code1', 'device1')
+    $$,
+    'Login code for user1 should be registered and expired ones deleted'
+);
+
+-- Register some more login codes for the same user
+select register_login_code('user1@email.com', 'code2', 'device1');
+select register_login_code('user1@email.com', 'code3', 'device2');
+select is(
+    count(*)::int,
+    3,
+    'User1 should have 3 login codes registered'
+)
+from login_code
+where user_id = :'user1ID';
+
+-- Try registering more login codes than allowed
+select throws_ok(
+    $$ select register_login_code('user1@email.com', 'code4', 'device1') $$,
+    'P0001',
+    'too many login codes requested',
+    'No more login codes should be registered for user1 until some expire'
+);
+
+-- Try registering login code using non verified email
+select throws_ok(
+    $$ select register_login_code('user2@email.com', 'code', 'device') $$,
+    'P0001',
+    'invalid email',
+    'No login code should be registered for non verified email user2@email.com'
+);
+
+-- Try registering login code using unregistered email
+select throws_ok(
+    $$ select register_login_code('user3@email.com', 'code', 'device') $$,
+    'P0001',
+    'invalid email',
+    'No login code should be registered for unregistered email user3@email.com'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,12 +1,16 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(4);
 
 -- Seed user
 insert into "user" (user_id, alias, email)
 values ('00000000-0000-0000-0000-000000000001', 'user1', 'user1@email.com');
 insert into "user" (user_id, alias, email, tfa_enabled)
 values ('00000000-0000-0000-0000-000000000002', 'user2', 'user2@email.com', true);
+insert into "user" (user_id, alias, email)
+values ('00000000-0000-0000-0000-000000000003', 'user3', 'user3@email.com');
+insert into webauthn_credential (user_id, name, credential_id, public_key)
+values ('00000000-0000-0000-0000-000000000003', 'key1', '\x01', '\x02');
 
 -- Register session for user with tfa disabled
 select register_session('
@@ -68,6 +72,59 @@ select results_eq(
     'Session for user2 should exist'
 );
 
+-- Register session for user with a WebAuthn credential
+select register_session('
+{
+    "session_id": "hashed-session-id-user3",
+    "user_id": "00000000-0000-0000-0000-000000000003"
+}
+') as approved \gset
+
+-- Check if session registration succeeded
+select results_eq(
+    $$
+        select
+            session_id,
+            approved
+        from session
+        where user_id = '00000000-0000-0000-0000-000000000003'
+    $$,
+    $$
+        values (
+            'hashed-session-id-user3',
+            false
+        )
+    $$,
+    'Session for user3 should exist and require approval'
+);
+
+-- Register session already approved for user with tfa enabled
+select register_session('
+{
+    "session_id": "hashed-session-id-user2-approved",
+    "user_id": "00000000-0000-0000-0000-000000000002",
+    "approved": true
+}
+') as approved \gset
+
+-- Check if session registration succeeded
+select results_eq(
+    $$
+        select
+            session_id,
+            approved
+        from session
+        where session_id = 'hashed-session-id-user2-approved'
+    $$,
+    $$
+        values (
+            'hashed-session-id-user2-approved',
+            true
+        )
+    $$,
+    'Session already approved for user2 should exist'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
@@ -0,0 +1,32 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Seed some data
+insert into webauthn_ceremony (webauthn_ceremony_id, session_data, created_at)
+values ('ceremony1', '{"challenge": "challenge1"}', current_timestamp - '30 minute'::interval);
+
+-- Register ceremony
+select register_webauthn_ceremony('ceremony2', '{"challenge": "challenge2"}');
+
+-- Check if ceremony registration succeeded
+select results_eq(
+    $$
+        select webauthn_ceremony_id, session_data
+        from webauthn_ceremony
+    $$,
+    $$
+        values ('ceremony2', '{"challenge": "challenge2"}'::jsonb)
+    $$,
+    'Ceremony should be registered and expired ones deleted'
+);
+select throws_ok(
+    $$ select register_webauthn_ceremony('ceremony2', '{}') $$,
+    '23505',
+    'duplicate key value violates unique constraint "webauthn_ceremony_pkey"',
+    'Ceremony ids must be unique'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,63 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+
+-- Run some tests
+select register_webauthn_credential(:'user1ID', '
+{
+    "name": "key1",
+    "id": "AQI=",
+    "public_key": "AwQ=",
+    "attestation_type": "none",
+    "aaguid": "BQY=",
+    "sign_count": 1
+}
+');
+select results_eq(
+    $$
+        select
+            name,
+            credential_id,
+            public_key,
+            attestation_type,
+            aaguid,
+            sign_count
+        from webauthn_credential
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (
+            'key1',
+            '\x0102'::bytea,
+            '\x0304'::bytea,
+            'none',
+            '\x0506'::bytea,
+            1::bigint
+        )
+    $$,
+    'Credential should have been registered'
+);
+select throws_ok(
+    $$
+        select register_webauthn_credential('00000000-0000-0000-0000-000000000001', '
+        {
+            "name": "key2",
+            "id": "AQI=",
+            "public_key": "AwQ="
+        }
+        ')
+    $$,
+    23505,
+    'duplicate key value violates unique constraint "webauthn_credential_credential_id_key"',
+    'Credential ids must be unique'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
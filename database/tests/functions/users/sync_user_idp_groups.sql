@@ -0,0 +1,141 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set org3ID '00000000-0000-0000-0000-000000000003'
+\set org4ID '00000000-0000-0000-0000-000000000004'
+\set org5ID '00000000-0000-0000-0000-000000000005'
+\set org6ID '00000000-0000-0000-0000-000000000006'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into organization (organization_id, name) values (:'org3ID', 'org3');
+insert into organization (organization_id, name) values (:'org4ID', 'org4');
+insert into organization (organization_id, name) values (:'org5ID', 'org5');
+insert into organization (organization_id, name) values (:'org6ID', 'org6');
+insert into organization_idp_group (organization_id, idp_group, role) values (:'org1ID', 'group1', 'viewer');
+insert into organization_idp_group (organization_id, idp_group, role) values (:'org1ID', 'group2', 'admin');
+insert into organization_idp_group (organization_id, idp_group, role) values (:'org2ID', 'group3', 'maintainer');
+insert into organization_idp_group (organization_id, idp_group, role) values (:'org3ID', 'group4', 'owner');
+insert into organization_idp_group (organization_id, idp_group, role) values (:'org5ID', 'group5', 'owner');
+insert into organization_idp_group (organization_id, idp_group, role) values (:'org5ID', 'group6', 'viewer');
+insert into organization_idp_group (organization_id, idp_group, role) values (:'org6ID', 'group7', 'viewer');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user1ID', :'org3ID', true, 'viewer');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user1ID', :'org4ID', true, 'owner');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user2ID', :'org1ID', true, 'maintainer');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user2ID', :'org2ID', true, 'maintainer');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user2ID', :'org5ID', true, 'maintainer');
+
+-- User joins organizations mapped to its groups with the highest role
+select sync_user_idp_groups(:'user1ID', '{group1,group2,group3,group4}');
+select results_eq(
+    $$
+        select o.name, uo.confirmed, uo.role, uo.idp_managed
+        from user__organization uo
+        join organization o using (organization_id)
+        where uo.user_id = '00000000-0000-0000-0000-000000000001'
+        order by o.name
+    $$,
+    $$
+        values
+            ('org1', true, 'admin', true),
+            ('org2', true, 'maintainer', true),
+            ('org3', true, 'viewer', false),
+            ('org4', true, 'owner', false)
+    $$,
+    'User should join org1 and org2, manual memberships should not change'
+);
+
+-- User leaves organizations no longer backed by any group
+select sync_user_idp_groups(:'user1ID', '{group1}');
+select results_eq(
+    $$
+        select o.name, uo.role, uo.idp_managed
+        from user__organization uo
+        join organization o using (organization_id)
+        where uo.user_id = '00000000-0000-0000-0000-000000000001'
+        order by o.name
+    $$,
+    $$
+        values
+            ('org1', 'viewer', true),
+            ('org3', 'viewer', false),
+            ('org4', 'owner', false)
+    $$,
+    'User should leave org2 and be downgraded in org1'
+);
+
+-- No groups
+select sync_user_idp_groups(:'user1ID', '{}');
+select results_eq(
+    $$
+        select o.name
+        from user__organization uo
+        join organization o using (organization_id)
+        where uo.user_id = '00000000-0000-0000-0000-000000000001'
+        order by o.name
+    $$,
+    $$
+        values ('org3'), ('org4')
+    $$,
+    'Only manual memberships should remain'
+);
+
+-- Last owner of an organization is neither downgraded nor removed
+select sync_user_idp_groups(:'user1ID', '{group5}');
+select sync_user_idp_groups(:'user1ID', '{group6}');
+select results_eq(
+    $$
+        select uo.role, uo.idp_managed
+        from user__organization uo
+        join organization o using (organization_id)
+        where uo.user_id = '00000000-0000-0000-0000-000000000001'
+        and o.name = 'org5'
+    $$,
+    $$
+        values ('owner', true)
+    $$,
+    'User should not be downgraded in org5 as it is its last owner'
+);
+select sync_user_idp_groups(:'user1ID', '{}');
+select isnt_empty(
+    $$
+        select *
+        from user__organization uo
+        join organization o using (organization_id)
+        where uo.user_id = '00000000-0000-0000-0000-000000000001'
+        and o.name = 'org5'
+    $$,
+    'User should not leave org5 as it is its last owner'
+);
+
+-- Last member of an organization is not removed
+select sync_user_idp_groups(:'user1ID', '{group7}');
+select sync_user_idp_groups(:'user1ID', '{}');
+select isnt_empty(
+    $$
+        select *
+        from user__organization uo
+        join organization o using (organization_id)
+        where uo.user_id = '00000000-0000-0000-0000-000000000001'
+        and o.name = 'org6'
+    $$,
+    'User should not leave org6 as it is its last member'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,32 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some users
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+
+-- Run some tests
+select is(
+    user_is_site_admin(:'user1ID'),
+    true,
+    'User1 is a site admin'
+);
+select is(
+    user_is_site_admin(:'user2ID'),
+    false,
+    'User2 is not a site admin'
+);
+select is(
+    user_is_site_admin('00000000-0000-0000-0000-000000000009'),
+    false,
+    'Users that do not exist are not site admins'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,53 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, email_verified)
+values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into login_code (login_code_id, user_id, device_id, created_at)
+values ('code1', :'user1ID', 'device1', current_timestamp - '5 minute'::interval);
+insert into login_code (login_code_id, user_id, device_id, created_at)
+values ('code2', :'user1ID', 'device1', current_timestamp - '30 minute'::interval);
+
+-- Login code verification should fail in the following cases
+select throws_ok(
+    $$ select verify_login_code('code3', 'device1') $$,
+    'P0001',
+    'invalid login code',
+    'Verify login code failed because code did not exist'
+);
+select throws_ok(
+    $$ select verify_login_code('code2', 'device1') $$,
+    'P0001',
+    'invalid login code',
+    'Verify login code failed because code has expired'
+);
+select throws_ok(
+    $$ select verify_login_code('code1', 'device2') $$,
+    'P0001',
+    'invalid login code',
+    'Verify login code failed because device does not match'
+);
+
+-- Login code verification should succeed
+select is(
+    verify_login_code('code1', 'device1'),
+    :'user1ID'::uuid,
+    'Verify login code succeeded and user id was returned'
+);
+
+-- Login codes can only be used once
+select throws_ok(
+    $$ select verify_login_code('code1', 'device1') $$,
+    'P0001',
+    'invalid login code',
+    'Verify login code failed because code was already used'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
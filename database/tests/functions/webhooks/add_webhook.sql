@@ -1,11 +1,12 @@
 -- Start transaction and plan tests
 begin;
-select plan(5);
+select plan(10);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
 \set org1ID '00000000-0000-0000-0000-000000000001'
 \set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
 \set package1ID '00000000-0000-0000-0000-000000000001'
 
 -- Seed some data
@@ -16,6 +17,8 @@ values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com'
 insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
 insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
 values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
 insert into package (package_id, name, latest_version, repository_id)
 values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
 
@@ -123,6 +126,74 @@ select results_eq(
     'Webhook2 owned by org1 should exist'
 );
 
+-- Add organization default webhook with some repositories overrides
+select add_webhook(:'user1ID', 'org1', '
+{
+    "name": "webhook4",
+    "url": "http://webhook4.url",
+    "active": true,
+    "org_default": true,
+    "event_kinds": [0],
+    "repositories": [
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000002",
+            "enabled": false
+        }
+    ]
+}
+'::jsonb);
+select results_eq(
+    $$
+        select org_default, organization_id
+        from webhook
+        where name = 'webhook4'
+    $$,
+    $$
+        values (true, '00000000-0000-0000-0000-000000000001'::uuid)
+    $$,
+    'Webhook4 should be an org1 default webhook'
+);
+select results_eq(
+    $$
+        select repository_id, enabled
+        from webhook__repository wr
+        join webhook w using (webhook_id)
+        where w.name = 'webhook4'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000002'::uuid, false)
+    $$,
+    'Webhook4 should be disabled for repo2'
+);
+
+-- Add PagerDuty webhook
+select add_webhook(:'user1ID', null, '
+{
+    "name": "webhook5",
+    "kind": "pagerduty",
+    "url": "https://events.pagerduty.com/v2/enqueue",
+    "secret": "routing-key",
+    "active": true,
+    "event_kinds": [1],
+    "packages": [
+        {
+            "package_id": "00000000-0000-0000-0000-000000000001"
+        }
+    ]
+}
+'::jsonb);
+select results_eq(
+    $$
+        select kind, url, secret
+        from webhook
+        where name = 'webhook5'
+    $$,
+    $$
+        values ('pagerduty', 'https://events.pagerduty.com/v2/enqueue', 'routing-key')
+    $$,
+    'Webhook5 should be a PagerDuty webhook'
+);
+
 -- Add webhook owned by organization, but user does not belong to it
 select throws_ok(
     $$
@@ -139,6 +210,49 @@ select throws_ok(
     'User not belonging to organization should not be able to webhooks in its name'
 );
 
+-- Repositories overrides can only reference repositories owned by the webhook owner
+select throws_ok(
+    $$
+        select add_webhook('00000000-0000-0000-0000-000000000001', 'org1', '
+        {
+            "name": "webhook6",
+            "url": "http://webhook6.url",
+            "active": true,
+            "org_default": true,
+            "repositories": [
+                {
+                    "repository_id": "00000000-0000-0000-0000-000000000001",
+                    "enabled": false
+                }
+            ]
+        }
+        '::jsonb)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Organization webhooks should not include overrides for repositories owned by others'
+);
+select throws_ok(
+    $$
+        select add_webhook('00000000-0000-0000-0000-000000000001', null, '
+        {
+            "name": "webhook7",
+            "url": "http://webhook7.url",
+            "active": true,
+            "repositories": [
+                {
+                    "repository_id": "00000000-0000-0000-0000-000000000002",
+                    "enabled": true
+                }
+            ]
+        }
+        '::jsonb)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User webhooks should not include overrides for repositories owned by others'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
@@ -106,6 +106,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "org_default": false,
                     "event_kinds": [0],
                     "packages": [
                         {
@@ -124,6 +125,7 @@ select results_eq(
                                 "url": "https://repo1.com",
                                 "private": false,
                                 "verified_publisher": false,
+                                "domain_verified": false,
                                 "official": false,
                                 "scanner_disabled": false,
                                 "user_alias": "user1"
@@ -140,6 +142,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "org_default": false,
                     "event_kinds": [1],
                     "packages": [
                         {
@@ -158,6 +161,7 @@ select results_eq(
                                 "url": "https://repo1.com",
                                 "private": false,
                                 "verified_publisher": false,
+                                "domain_verified": false,
                                 "official": false,
                                 "scanner_disabled": false,
                                 "user_alias": "user1"
@@ -187,6 +191,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "org_default": false,
                     "event_kinds": [1],
                     "packages": [
                         {
@@ -205,6 +210,7 @@ select results_eq(
                                 "url": "https://repo1.com",
                                 "private": false,
                                 "verified_publisher": false,
+                                "domain_verified": false,
                                 "official": false,
                                 "scanner_disabled": false,
                                 "user_alias": "user1"
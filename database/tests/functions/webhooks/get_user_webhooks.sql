@@ -102,6 +102,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "org_default": false,
                     "event_kinds": [0],
                     "packages": [
                         {
@@ -120,6 +121,7 @@ select results_eq(
                                 "url": "https://repo1.com",
                                 "private": false,
                                 "verified_publisher": false,
+                                "domain_verified": false,
                                 "official": false,
                                 "scanner_disabled": false,
                                 "user_alias": "user1"
@@ -136,6 +138,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "org_default": false,
                     "event_kinds": [1],
                     "packages": [
                         {
@@ -154,6 +157,7 @@ select results_eq(
                                 "url": "https://repo1.com",
                                 "private": false,
                                 "verified_publisher": false,
+                                "domain_verified": false,
                                 "official": false,
                                 "scanner_disabled": false,
                                 "user_alias": "user1"
@@ -183,6 +187,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "org_default": false,
                     "event_kinds": [1],
                     "packages": [
                         {
@@ -201,6 +206,7 @@ select results_eq(
                                 "url": "https://repo1.com",
                                 "private": false,
                                 "verified_publisher": false,
+                                "domain_verified": false,
                                 "official": false,
                                 "scanner_disabled": false,
                                 "user_alias": "user1"
@@ -130,6 +130,7 @@ select is(
         "content_type": "application/json",
         "template": "custom payload",
         "active": true,
+        "org_default": false,
         "event_kinds": [0],
         "packages": [
             {
@@ -148,6 +149,7 @@ select is(
                     "url": "https://repo1.com",
                     "private": false,
                     "verified_publisher": false,
+                    "domain_verified": false,
                     "official": false,
                     "scanner_disabled": false,
                     "user_alias": "user1"
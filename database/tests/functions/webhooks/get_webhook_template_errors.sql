@@ -0,0 +1,79 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set webhook2ID '00000000-0000-0000-0000-000000000002'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set event2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.1', :'repo1ID');
+insert into webhook (webhook_id, name, url, active, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', true, :'user1ID');
+insert into webhook (webhook_id, name, url, active, user_id)
+values (:'webhook2ID', 'webhook2', 'http://webhook2.url', true, :'user1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event2ID', '1.0.1', :'package1ID', 0);
+insert into webhook_template_error (webhook_id, event_id, error, line, col, created_at)
+values (:'webhook1ID', :'event1ID', 'template: :1: unclosed action', 1, null, '2020-06-16 11:20:34+02');
+insert into webhook_template_error (webhook_id, event_id, error, line, col, created_at)
+values (:'webhook1ID', :'event2ID', 'template: :2:14: executing "" at <.Package.Foo>: error', 2, 14, '2020-06-16 11:20:35+02');
+
+-- Run some tests
+select throws_ok(
+    $$
+        select get_webhook_template_errors(
+            '00000000-0000-0000-0000-000000000002',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Webhook template errors get should fail because requesting user is not the owner'
+);
+select is(
+    get_webhook_template_errors(:'user1ID', :'webhook1ID')::jsonb,
+    '[
+        {
+            "event_id": "00000000-0000-0000-0000-000000000002",
+            "event_kind": 0,
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "package_version": "1.0.1",
+            "error": "template: :2:14: executing \"\" at <.Package.Foo>: error",
+            "line": 2,
+            "column": 14,
+            "created_at": 1592299235
+        },
+        {
+            "event_id": "00000000-0000-0000-0000-000000000001",
+            "event_kind": 0,
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "package_version": "1.0.0",
+            "error": "template: :1: unclosed action",
+            "line": 1,
+            "created_at": 1592299234
+        }
+    ]'::jsonb,
+    'Template errors should be returned sorted by creation date (newest first)'
+);
+select is(
+    get_webhook_template_errors(:'user1ID', :'webhook2ID')::jsonb,
+    '[]'::jsonb,
+    'No template errors expected for webhook2'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,15 +1,21 @@
 -- Start transaction and plan tests
 begin;
-select plan(3);
+select plan(5);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
 \set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
 \set package1ID '00000000-0000-0000-0000-000000000001'
 \set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+\set package4ID '00000000-0000-0000-0000-000000000004'
 \set image1ID '00000000-0000-0000-0000-000000000001'
 \set webhook1ID '00000000-0000-0000-0000-000000000001'
 \set webhook2ID '00000000-0000-0000-0000-000000000002'
+\set webhook3ID '00000000-0000-0000-0000-000000000003'
 
 -- Seed some data
 insert into "user" (user_id, alias, email)
@@ -76,6 +82,19 @@ insert into webhook (
 );
 insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook2ID', 0);
 insert into webhook__package (webhook_id, package_id) values (:'webhook2ID', :'package1ID');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'Package 3', '1.0.0', :'repo2ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package4ID', 'Package 4', '1.0.0', :'repo3ID');
+insert into webhook (webhook_id, name, url, active, org_default, organization_id)
+values (:'webhook3ID', 'webhook3', 'http://webhook3.url', true, true, :'org1ID');
+insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook3ID', 0);
+insert into webhook__repository (webhook_id, repository_id, enabled) values (:'webhook3ID', :'repo3ID', false);
 
 -- Run some tests
 select is(
@@ -90,6 +109,7 @@ select is(
             "content_type": "application/json",
             "template": "custom payload",
             "active": true,
+            "org_default": false,
             "event_kinds": [0],
             "packages": [
                 {
@@ -108,6 +128,7 @@ select is(
                         "url": "https://repo1.com",
                         "private": false,
                         "verified_publisher": false,
+                        "domain_verified": false,
                         "official": false,
                         "scanner_disabled": false,
                         "user_alias": "user1"
@@ -128,6 +149,32 @@ select is(
     '[]',
     'No webhooks should be returned for kind0 and package2'
 );
+select is(
+    get_webhooks_subscribed_to_package(0, :'package3ID')::jsonb,
+    '[
+        {
+            "webhook_id": "00000000-0000-0000-0000-000000000003",
+            "name": "webhook3",
+            "url": "http://webhook3.url",
+            "active": true,
+            "org_default": true,
+            "event_kinds": [0],
+            "repositories": [
+                {
+                    "repository_id": "00000000-0000-0000-0000-000000000003",
+                    "name": "repo3",
+                    "enabled": false
+                }
+            ]
+        }
+    ]'::jsonb,
+    'Org1 default webhook3 should be returned when asking for kind0 and package3'
+);
+select is(
+    get_webhooks_subscribed_to_package(0, :'package4ID')::jsonb,
+    '[]',
+    'No webhooks should be returned for kind0 and package4 (repo3 opted out)'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
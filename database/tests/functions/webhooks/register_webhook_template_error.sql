@@ -0,0 +1,63 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into webhook (webhook_id, name, url, active, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', true, :'user1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into webhook_template_error (webhook_id, event_id, error, created_at)
+select :'webhook1ID', :'event1ID', 'old error', current_timestamp - (i || ' minutes')::interval
+from generate_series(1, 50) i;
+
+-- Run some tests
+select lives_ok(
+    $$
+        select register_webhook_template_error('
+        {
+            "webhook_id": "00000000-0000-0000-0000-000000000001",
+            "event_id": "00000000-0000-0000-0000-000000000001",
+            "error": "template: :2:14: executing \"\" at <.Package.Foo>: error",
+            "line": 2,
+            "column": 14
+        }
+        ')
+    $$,
+    'Template error should be registered'
+);
+select results_eq(
+    $$
+        select error, line, col
+        from webhook_template_error
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+        order by created_at desc
+        limit 1
+    $$,
+    $$
+        values ('template: :2:14: executing "" at <.Package.Foo>: error', 2, 14)
+    $$,
+    'Registered template error should exist'
+);
+select is(
+    (select count(*) from webhook_template_error where webhook_id = :'webhook1ID'),
+    50::bigint,
+    'Only the 50 most recent template errors should be kept'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,12 +1,13 @@
 -- Start transaction and plan tests
 begin;
-select plan(6);
+select plan(11);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
 \set user2ID '00000000-0000-0000-0000-000000000002'
 \set org1ID '00000000-0000-0000-0000-000000000001'
 \set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
 \set package1ID '00000000-0000-0000-0000-000000000001'
 \set package2ID '00000000-0000-0000-0000-000000000002'
 \set webhook1ID '00000000-0000-0000-0000-000000000001'
@@ -20,6 +21,8 @@ values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com'
 insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
 insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
 values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
 insert into package (package_id, name, latest_version, repository_id)
 values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
 insert into package (package_id, name, latest_version, repository_id)
@@ -69,6 +72,7 @@ select update_webhook('00000000-0000-0000-0000-000000000001', '
     "webhook_id": "00000000-0000-0000-0000-000000000001",
     "name": "webhook1 updated",
     "description": "description updated",
+    "kind": "opsgenie",
     "url": "http://webhook1.url/updated",
     "secret": "very updated",
     "content_type": "text/xml",
@@ -87,6 +91,7 @@ select results_eq(
         select
             name,
             description,
+            kind,
             url,
             secret,
             content_type,
@@ -101,6 +106,7 @@ select results_eq(
         values (
             'webhook1 updated',
             'description updated',
+            'opsgenie',
             'http://webhook1.url/updated',
             'very updated',
             'text/xml',
@@ -162,6 +168,108 @@ select results_eq(
     'Webhook2 owned by org1 should have been updated'
 );
 
+-- Try to make a webhook owned by a user an organization default
+select throws_ok(
+    $$
+        select update_webhook('00000000-0000-0000-0000-000000000001', '
+        {
+            "webhook_id": "00000000-0000-0000-0000-000000000001",
+            "name": "webhook1",
+            "url": "http://webhook1.url",
+            "active": true,
+            "org_default": true
+        }
+        '::jsonb)
+    $$,
+    'P0001',
+    'only organization webhooks can be defaults',
+    'Webhooks owned by users cannot be organization defaults'
+);
+
+-- Make webhook owned by organization a default one with repositories overrides
+select update_webhook('00000000-0000-0000-0000-000000000001', '
+{
+    "webhook_id": "00000000-0000-0000-0000-000000000002",
+    "name": "webhook2 updated",
+    "url": "http://webhook2.url/updated",
+    "active": true,
+    "org_default": true,
+    "repositories": [
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000002",
+            "enabled": false
+        }
+    ]
+}
+'::jsonb);
+select results_eq(
+    $$
+        select org_default
+        from webhook
+        where webhook_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$
+        values (true)
+    $$,
+    'Webhook2 should now be an organization default'
+);
+select results_eq(
+    $$
+        select repository_id, enabled
+        from webhook__repository
+        where webhook_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000002'::uuid, false)
+    $$,
+    'Webhook2 should now be disabled for repo2'
+);
+
+-- Try to add overrides for repositories not owned by the webhook owner
+select throws_ok(
+    $$
+        select update_webhook('00000000-0000-0000-0000-000000000001', '
+        {
+            "webhook_id": "00000000-0000-0000-0000-000000000002",
+            "name": "webhook2 updated",
+            "url": "http://webhook2.url/updated",
+            "active": true,
+            "org_default": true,
+            "repositories": [
+                {
+                    "repository_id": "00000000-0000-0000-0000-000000000001",
+                    "enabled": false
+                }
+            ]
+        }
+        '::jsonb)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Organization webhooks should not include overrides for repositories owned by others'
+);
+select throws_ok(
+    $$
+        select update_webhook('00000000-0000-0000-0000-000000000001', '
+        {
+            "webhook_id": "00000000-0000-0000-0000-000000000001",
+            "name": "webhook1 updated",
+            "url": "http://webhook1.url/updated",
+            "active": true,
+            "repositories": [
+                {
+                    "repository_id": "00000000-0000-0000-0000-000000000002",
+                    "enabled": true
+                }
+            ]
+        }
+        '::jsonb)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User webhooks should not include overrides for repositories owned by others'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
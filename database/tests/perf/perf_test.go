@@ -0,0 +1,332 @@
+//go:build dbperf
+// +build dbperf
+
+package perf
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// baselineFile represents the file where the queries plans baseline is
+	// stored. It must be updated (using the update flag) and committed when a
+	// change in a plan is expected.
+	baselineFile = "testdata/baseline.json"
+
+	// runs represents the number of times each query is run to measure its
+	// latency (the median is used).
+	runs = 5
+)
+
+var (
+	update    = flag.Bool("update", false, "Write queries plans to baseline file")
+	tolerance = flag.Float64("tolerance", 0.25, "Buffers increase tolerated over the baseline (fraction)")
+
+	// largeRelations represents the relations that are expected to hold lots
+	// of rows in production. New sequential scans on them are considered a
+	// regression.
+	largeRelations = []string{
+		"package",
+		"snapshot",
+		"repository",
+		"maintainer",
+		"package__maintainer",
+		"user_starred_package",
+	}
+)
+
+// queryCase represents a query to check, along with its latency budget.
+type queryCase struct {
+	name   string
+	query  string
+	args   []interface{}
+	budget time.Duration
+}
+
+// queryCases represents the hot path queries checked.
+var queryCases = []queryCase{
+	{
+		name:   "search_packages_text_facets",
+		query:  `select * from search_packages($1::jsonb)`,
+		args:   []interface{}{`{"ts_query_web": "package keyword1", "facets": true, "limit": 20, "offset": 0}`},
+		budget: 300 * time.Millisecond,
+	},
+	{
+		name:   "search_packages_filters",
+		query:  `select * from search_packages($1::jsonb)`,
+		args:   []interface{}{`{"repository_kinds": [0], "verified_publisher": true, "sort": "stars", "facets": false, "limit": 60, "offset": 60}`},
+		budget: 200 * time.Millisecond,
+	},
+	{
+		name:   "search_packages_deprecated_licenses",
+		query:  `select * from search_packages($1::jsonb)`,
+		args:   []interface{}{`{"deprecated": true, "licenses": ["MIT"], "facets": true, "limit": 20, "offset": 0}`},
+		budget: 300 * time.Millisecond,
+	},
+	{
+		name:   "get_package",
+		query:  `select get_package($1::jsonb)`,
+		args:   []interface{}{`{"repository_name": "repo10", "package_name": "package5-10"}`},
+		budget: 100 * time.Millisecond,
+	},
+	{
+		name:   "get_package_version",
+		query:  `select get_package($1::jsonb)`,
+		args:   []interface{}{`{"repository_name": "repo10", "package_name": "package5-10", "version": "1.0.0"}`},
+		budget: 100 * time.Millisecond,
+	},
+	{
+		name:   "get_package_summary",
+		query:  `select get_package_summary($1::jsonb)`,
+		args:   []interface{}{`{"repository_name": "repo10", "package_name": "package5-10"}`},
+		budget: 50 * time.Millisecond,
+	},
+	{
+		name:   "get_random_packages",
+		query:  `select get_random_packages()`,
+		budget: 200 * time.Millisecond,
+	},
+	{
+		name:   "get_packages_stats",
+		query:  `select get_packages_stats()`,
+		budget: 100 * time.Millisecond,
+	},
+}
+
+// queryPlan represents some information about the execution of a query used
+// to detect regressions.
+type queryPlan struct {
+	Buffers int64    `json:"buffers"`
+	Nodes   []string `json:"nodes"`
+}
+
+// planNode represents a node in a plan as returned by auto_explain in json
+// format.
+type planNode struct {
+	NodeType         string     `json:"Node Type"`
+	RelationName     string     `json:"Relation Name"`
+	SharedHitBlocks  int64      `json:"Shared Hit Blocks"`
+	SharedReadBlocks int64      `json:"Shared Read Blocks"`
+	Plans            []planNode `json:"Plans"`
+}
+
+// plansCollector collects the plans of the statements executed in the
+// database connection, including the ones executed inside functions, sent
+// by auto_explain as notices.
+type plansCollector struct {
+	mu    sync.Mutex
+	plans []*planNode
+	err   error
+}
+
+// onNotice is used as the notices handler of the database connection.
+func (c *plansCollector) onNotice(_ *pgconn.PgConn, n *pgconn.Notice) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := strings.Index(n.Message, "{")
+	if !strings.HasPrefix(n.Message, "duration:") || i == -1 {
+		return
+	}
+	var entry struct {
+		Plan *planNode `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(n.Message[i:]), &entry); err != nil {
+		c.err = fmt.Errorf("error parsing plan: %w", err)
+		return
+	}
+	c.plans = append(c.plans, entry.Plan)
+}
+
+// reset discards the plans collected so far.
+func (c *plansCollector) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.plans = nil
+	c.err = nil
+}
+
+// queryPlan returns the information about the execution of the last query
+// collected. The top level statement is the last plan logged, and its buffers
+// include the ones used by the nested statements.
+func (c *plansCollector) queryPlan() (*queryPlan, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+	if len(c.plans) == 0 {
+		return nil, fmt.Errorf("no plans collected (is auto_explain available?)")
+	}
+	top := c.plans[len(c.plans)-1]
+	qp := &queryPlan{
+		Buffers: top.SharedHitBlocks + top.SharedReadBlocks,
+	}
+	nodes := make(map[string]struct{})
+	for _, p := range c.plans {
+		collectNodes(p, nodes)
+	}
+	for node := range nodes {
+		qp.Nodes = append(qp.Nodes, node)
+	}
+	sort.Strings(qp.Nodes)
+	return qp, nil
+}
+
+// collectNodes collects the scan nodes in the plan provided.
+func collectNodes(p *planNode, nodes map[string]struct{}) {
+	if strings.HasSuffix(p.NodeType, "Scan") && p.RelationName != "" {
+		nodes[fmt.Sprintf("%s on %s", p.NodeType, p.RelationName)] = struct{}{}
+	}
+	for i := range p.Plans {
+		collectNodes(&p.Plans[i], nodes)
+	}
+}
+
+func TestQueriesPlans(t *testing.T) {
+	ctx := context.Background()
+
+	// Setup database connection
+	dbURL := os.Getenv("DBPERF_DB_URL")
+	if dbURL == "" {
+		t.Fatal("DBPERF_DB_URL not set (i.e. postgres://tests@localhost:5432/tests)")
+	}
+	cfg, err := pgx.ParseConfig(dbURL)
+	require.NoError(t, err)
+	pc := &plansCollector{}
+	cfg.OnNotice = pc.onNotice
+	conn, err := pgx.ConnectConfig(ctx, cfg)
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+
+	// Seed database (only once)
+	var seeded bool
+	err = conn.QueryRow(ctx, `select exists (select 1 from "user" where alias = 'perf')`).Scan(&seeded)
+	require.NoError(t, err)
+	if !seeded {
+		seed, err := ioutil.ReadFile("seed.sql")
+		require.NoError(t, err)
+		_, err = conn.Exec(ctx, string(seed))
+		require.NoError(t, err)
+	}
+
+	// Setup auto_explain to collect the plans of the statements executed
+	// inside the functions as well
+	_, err = conn.Exec(ctx, `
+		load 'auto_explain';
+		set auto_explain.log_min_duration = 0;
+		set auto_explain.log_analyze = on;
+		set auto_explain.log_buffers = on;
+		set auto_explain.log_timing = off;
+		set auto_explain.log_nested_statements = on;
+		set auto_explain.log_format = json;
+		set auto_explain.log_level = notice;
+	`)
+	require.NoError(t, err)
+
+	// Load baseline
+	baseline := make(map[string]*queryPlan)
+	if data, err := ioutil.ReadFile(baselineFile); err == nil {
+		require.NoError(t, json.Unmarshal(data, &baseline))
+	} else if !os.IsNotExist(err) {
+		require.NoError(t, err)
+	}
+
+	// Check queries
+	plans := make(map[string]*queryPlan)
+	for _, qc := range queryCases {
+		qc := qc
+		t.Run(qc.name, func(t *testing.T) {
+			var durations []time.Duration
+			var qp *queryPlan
+			for i := 0; i < runs+1; i++ {
+				pc.reset()
+				start := time.Now()
+				rows, err := conn.Query(ctx, qc.query, qc.args...)
+				require.NoError(t, err)
+				for rows.Next() {
+				}
+				require.NoError(t, rows.Err())
+				rows.Close()
+				if i == 0 {
+					// Warm up run, not measured
+					continue
+				}
+				durations = append(durations, time.Since(start))
+				qp, err = pc.queryPlan()
+				require.NoError(t, err)
+			}
+			plans[qc.name] = qp
+			sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+			median := durations[len(durations)/2]
+			t.Logf("latency: %s (budget: %s) buffers: %d nodes: %v", median, qc.budget, qp.Buffers, qp.Nodes)
+
+			// Check latency budget
+			if median > qc.budget {
+				t.Errorf("latency budget exceeded: %s > %s", median, qc.budget)
+			}
+
+			// Compare with baseline
+			if *update {
+				return
+			}
+			b, ok := baseline[qc.name]
+			if !ok {
+				t.Logf("no baseline available, run with -update to capture it")
+				return
+			}
+			maxBuffers := int64(float64(b.Buffers) * (1 + *tolerance))
+			if qp.Buffers > maxBuffers {
+				t.Errorf("buffers increased over the tolerance: %d > %d (baseline: %d)", qp.Buffers, maxBuffers, b.Buffers)
+			}
+			for _, node := range newSeqScans(b.Nodes, qp.Nodes) {
+				t.Errorf("new sequential scan on large relation: %s", node)
+			}
+		})
+	}
+
+	// Update baseline if requested
+	if *update {
+		data, err := json.MarshalIndent(plans, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Dir(baselineFile), 0755))
+		require.NoError(t, ioutil.WriteFile(baselineFile, append(data, '\n'), 0644))
+	}
+}
+
+// newSeqScans returns the sequential scans on large relations that are not
+// present in the baseline nodes provided.
+func newSeqScans(baselineNodes, nodes []string) []string {
+	known := make(map[string]struct{}, len(baselineNodes))
+	for _, node := range baselineNodes {
+		known[node] = struct{}{}
+	}
+	var newNodes []string
+	for _, node := range nodes {
+		if _, ok := known[node]; ok {
+			continue
+		}
+		for _, rel := range largeRelations {
+			if node == "Seq Scan on "+rel {
+				newNodes = append(newNodes, node)
+			}
+		}
+	}
+	return newNodes
+}
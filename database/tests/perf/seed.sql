@@ -0,0 +1,64 @@
+-- Seed some data used to check the queries plans and latencies. The amount of
+-- data does not need to match the one in production, but it must be large
+-- enough for the planner to pick the same kind of plans.
+do $$
+declare
+    v_repositories int := 50;
+    v_packages_per_repository int := 40;
+    v_versions_per_package int := 3;
+    v_user_id uuid;
+    v_org_id uuid;
+    v_repository_id uuid;
+    v_kind int;
+begin
+    insert into "user" (alias, email)
+    values ('perf', 'perf@email.com')
+    returning user_id into v_user_id;
+    insert into organization (name, display_name, description, home_url)
+    values ('perf', 'Perf', 'Organization used in the queries plans tests', 'https://perf.org')
+    returning organization_id into v_org_id;
+
+    for r in 1..v_repositories loop
+        v_kind := r % 3;
+        insert into repository (name, display_name, url, repository_kind_id, user_id, organization_id, verified_publisher, official)
+        values (
+            'repo' || r,
+            'Repository ' || r,
+            'https://repo' || r || '.perf.org',
+            v_kind,
+            case when r % 2 = 0 then v_user_id end,
+            case when r % 2 = 1 then v_org_id end,
+            r % 5 = 0,
+            r % 10 = 0
+        )
+        returning repository_id into v_repository_id;
+
+        for p in 1..v_packages_per_repository loop
+            for v in 1..v_versions_per_package loop
+                perform register_package(jsonb_build_object(
+                    'name', 'package' || p || '-' || r,
+                    'display_name', 'Package ' || p,
+                    'description', 'Sample package ' || p || ' used to check the queries plans',
+                    'keywords', jsonb_build_array('keyword' || (p % 20), 'kind' || v_kind, 'perf'),
+                    'version', v || '.0.0',
+                    'app_version', v || '.0.0',
+                    'digest', md5(r || '-' || p || '-' || v),
+                    'license', case when p % 2 = 0 then 'Apache-2.0' else 'MIT' end,
+                    'deprecated', p % 25 = 0,
+                    'signed', p % 3 = 0,
+                    'is_operator', p % 7 = 0,
+                    'readme', repeat('Package readme ', 50),
+                    'ts', extract(epoch from current_timestamp - ((v_versions_per_package - v) || ' days')::interval)::int,
+                    'maintainers', jsonb_build_array(jsonb_build_object(
+                        'name', 'maintainer' || (p % 10),
+                        'email', 'maintainer' || (p % 10) || '@perf.org'
+                    )),
+                    'repository', jsonb_build_object('repository_id', v_repository_id)
+                ));
+            end loop;
+        end loop;
+    end loop;
+end
+$$;
+
+analyze;
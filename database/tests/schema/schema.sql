@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(146);
+select plan(267);
 
 -- Check default_text_search_config is correct
 select results_eq(
@@ -17,21 +17,37 @@ select has_extension('tsm_system_rows');
 -- Check expected tables exist
 select tables_are(array[
     'api_key',
+    'audit_log',
     'delete_user_code',
+    'email_spool',
     'email_verification_code',
     'event',
     'event_kind',
     'image',
+    'image_source',
+    'image_upload',
     'image_version',
+    'legal_hold',
+    'login_code',
     'maintainer',
     'notification',
     'opt_out',
     'organization',
+    'organization_idp_group',
+    'organization_link',
+    'organization_quota',
     'package',
     'package__maintainer',
+    'package_change',
+    'package_discovery',
+    'package_document',
     'password_reset_code',
+    'promoted_package',
     'repository',
     'repository_kind',
+    'repository_publish_token',
+    'repository_tracking_run',
+    'repository_transfer',
     'session',
     'snapshot',
     'subscription',
@@ -40,9 +56,13 @@ select tables_are(array[
     'user__organization',
     'version_functions',
     'version_schema',
+    'webauthn_ceremony',
+    'webauthn_credential',
     'webhook',
     'webhook__event_kind',
-    'webhook__package'
+    'webhook__package',
+    'webhook__repository',
+    'webhook_template_error'
 ]);
 
 -- Check tables have expected columns
@@ -53,11 +73,29 @@ select columns_are('api_key', array[
     'user_id',
     'created_at'
 ]);
+select columns_are('audit_log', array[
+    'audit_log_id',
+    'user_id',
+    'action',
+    'details',
+    'created_at'
+]);
 select columns_are('delete_user_code', array[
     'delete_user_code_id',
     'user_id',
     'created_at'
 ]);
+select columns_are('email_spool', array[
+    'email_spool_id',
+    'recipient',
+    'subject',
+    'body',
+    'text_body',
+    'attempts',
+    'last_error',
+    'next_attempt_at',
+    'created_at'
+]);
 select columns_are('email_verification_code', array[
     'email_verification_code_id',
     'user_id',
@@ -82,11 +120,45 @@ select columns_are('image', array[
     'image_id',
     'original_hash'
 ]);
+select columns_are('image_source', array[
+    'url',
+    'original_hash',
+    'image_id',
+    'last_checked_at'
+]);
+select columns_are('image_upload', array[
+    'image_upload_id',
+    'user_id',
+    'size',
+    'checksum',
+    'received',
+    'data',
+    'image_id',
+    'moderation_status',
+    'moderated_by',
+    'moderated_at',
+    'created_at',
+    'updated_at'
+]);
 select columns_are('image_version', array[
     'image_id',
     'version',
     'data'
 ]);
+select columns_are('legal_hold', array[
+    'legal_hold_id',
+    'package_id',
+    'repository_id',
+    'reason',
+    'user_id',
+    'created_at'
+]);
+select columns_are('login_code', array[
+    'login_code_id',
+    'user_id',
+    'device_id',
+    'created_at'
+]);
 select columns_are('maintainer', array[
     'maintainer_id',
     'name',
@@ -119,7 +191,28 @@ select columns_are('organization', array[
     'authorization_enabled',
     'predefined_policy',
     'custom_policy',
-    'policy_data'
+    'policy_data',
+    'profile',
+    'profile_html'
+]);
+select columns_are('organization_idp_group', array[
+    'organization_id',
+    'idp_group',
+    'role'
+]);
+select columns_are('organization_link', array[
+    'organization_id',
+    'url',
+    'verification_token',
+    'verified',
+    'verified_at'
+]);
+select columns_are('organization_quota', array[
+    'organization_id',
+    'max_repositories',
+    'max_webhooks',
+    'max_api_keys',
+    'tracker_min_interval'
 ]);
 select columns_are('package', array[
     'package_id',
@@ -128,22 +221,58 @@ select columns_are('package', array[
     'latest_version',
     'stars',
     'tsdoc',
+    'search_data',
     'is_operator',
     'official',
     'channels',
     'default_channel',
     'created_at',
-    'repository_id'
+    'repository_id',
+    'last_release_ts',
+    'stale',
+    'category',
+    'hidden',
+    'document'
 ]);
 select columns_are('package__maintainer', array[
     'package_id',
     'maintainer_id'
 ]);
+select columns_are('package_change', array[
+    'package_change_id',
+    'package_id',
+    'package_name',
+    'repository_id',
+    'repository_name',
+    'repository_kind_id',
+    'change_kind',
+    'created_at'
+]);
+select columns_are('package_discovery', array[
+    'category',
+    'package_id',
+    'position'
+]);
+select columns_are('package_document', array[
+    'package_id',
+    'version',
+    'document',
+    'updated_at'
+]);
 select columns_are('password_reset_code', array[
     'password_reset_code_id',
     'user_id',
     'created_at'
 ]);
+select columns_are('promoted_package', array[
+    'promoted_package_id',
+    'package_id',
+    'starts_at',
+    'ends_at',
+    'impressions',
+    'clicks',
+    'created_at'
+]);
 select columns_are('repository', array[
     'repository_id',
     'name',
@@ -164,11 +293,55 @@ select columns_are('repository', array[
     'created_at',
     'repository_kind_id',
     'user_id',
-    'organization_id'
+    'organization_id',
+    'ssh_key',
+    'domain_verification_token',
+    'domain_verified',
+    'domain_verified_at',
+    'signing_policy',
+    'tracking_schedule',
+    'tracking_paused',
+    'webhook_secret',
+    'tracking_requested',
+    'verified_publisher_at',
+    'archived',
+    'labels',
+    'index_etag',
+    'index_last_modified',
+    'sign_keyring',
+    'sign_keyring_url'
 ]);
 select columns_are('repository_kind', array[
     'repository_kind_id',
-    'name'
+    'name',
+    'median_release_cadence'
+]);
+select columns_are('repository_publish_token', array[
+    'publish_token_id',
+    'repository_id',
+    'name',
+    'secret',
+    'created_at'
+]);
+select columns_are('repository_tracking_run', array[
+    'repository_tracking_run_id',
+    'repository_id',
+    'tracked_at',
+    'duration_ms',
+    'errors',
+    'started_at',
+    'packages_added',
+    'packages_updated',
+    'packages_deleted',
+    'tracking_errors'
+]);
+select columns_are('repository_transfer', array[
+    'repository_id',
+    'requesting_user_id',
+    'user_id',
+    'organization_id',
+    'expires_at',
+    'created_at'
 ]);
 select columns_are('session', array[
     'session_id',
@@ -198,22 +371,39 @@ select columns_are('snapshot', array[
     'security_report_alert_digest',
     'security_report_created_at',
     'security_report_summary',
+    'security_report_location',
     'capabilities',
     'data',
     'deprecated',
     'license',
     'signed',
+    'signature_verified',
+    'signature_kind',
+    'signature_identity',
+    'has_attestations',
     'content_url',
     'containers_images',
     'provider',
     'values_schema',
+    'values_schema_inferred',
+    'values_docs',
+    'dependencies_tree',
     'changes',
     'contains_security_updates',
     'prerelease',
     'ts',
     'created_at',
     'recommendations',
-    'sign_key'
+    'sign_key',
+    'sbom',
+    'sbom_format',
+    'sbom_location',
+    'test_results',
+    'category',
+    'upstream',
+    'chart_diagnostics',
+    'alias',
+    'logo_generated'
 ]);
 select columns_are('subscription', array[
     'user_id',
@@ -232,7 +422,10 @@ select columns_are('user', array[
     'created_at',
     'tfa_enabled',
     'tfa_recovery_codes',
-    'tfa_url'
+    'tfa_url',
+    'service_account_organization_id',
+    'site_admin',
+    'moderator'
 ]);
 select columns_are('user_starred_package', array[
     'user_id',
@@ -241,7 +434,10 @@ select columns_are('user_starred_package', array[
 select columns_are('user__organization', array[
     'user_id',
     'organization_id',
-    'confirmed'
+    'confirmed',
+    'role',
+    'invitation_expires_at',
+    'idp_managed'
 ]);
 select columns_are('version_functions', array[
     'version'
@@ -249,6 +445,23 @@ select columns_are('version_functions', array[
 select columns_are('version_schema', array[
     'version'
 ]);
+select columns_are('webauthn_ceremony', array[
+    'webauthn_ceremony_id',
+    'session_data',
+    'created_at'
+]);
+select columns_are('webauthn_credential', array[
+    'webauthn_credential_id',
+    'user_id',
+    'name',
+    'credential_id',
+    'public_key',
+    'attestation_type',
+    'aaguid',
+    'sign_count',
+    'created_at',
+    'last_used_at'
+]);
 select columns_are('webhook', array[
     'webhook_id',
     'name',
@@ -261,7 +474,9 @@ select columns_are('webhook', array[
     'created_at',
     'updated_at',
     'user_id',
-    'organization_id'
+    'organization_id',
+    'org_default',
+    'kind'
 ]);
 select columns_are('webhook__event_kind', array[
     'webhook_id',
@@ -271,30 +486,72 @@ select columns_are('webhook__package', array[
     'webhook_id',
     'package_id'
 ]);
+select columns_are('webhook__repository', array[
+    'webhook_id',
+    'repository_id',
+    'enabled'
+]);
+select columns_are('webhook_template_error', array[
+    'webhook_template_error_id',
+    'webhook_id',
+    'event_id',
+    'error',
+    'line',
+    'col',
+    'created_at'
+]);
 
 -- Check tables have expected indexes
 select indexes_are('api_key', array[
     'api_key_pkey'
 ]);
+select indexes_are('audit_log', array[
+    'audit_log_pkey',
+    'audit_log_user_id_idx'
+]);
 select indexes_are('delete_user_code', array[
     'delete_user_code_pkey',
     'delete_user_code_user_id_key'
 ]);
+select indexes_are('email_spool', array[
+    'email_spool_pkey',
+    'email_spool_next_attempt_at_idx'
+]);
 select indexes_are('email_verification_code', array[
     'email_verification_code_pkey',
     'email_verification_code_user_id_key'
 ]);
 select indexes_are('event', array[
     'event_pkey',
-    'event_not_processed_idx'
+    'event_not_processed_idx',
+    'event_package_id_idx',
+    'event_repository_id_idx'
 ]);
 select indexes_are('image', array[
     'image_pkey',
     'image_original_hash_key'
 ]);
+select indexes_are('image_source', array[
+    'image_source_pkey'
+]);
+select indexes_are('image_upload', array[
+    'image_upload_pkey',
+    'image_upload_user_id_idx',
+    'image_upload_image_id_idx',
+    'image_upload_moderation_status_idx'
+]);
 select indexes_are('image_version', array[
     'image_version_pkey'
 ]);
+select indexes_are('legal_hold', array[
+    'legal_hold_pkey',
+    'legal_hold_package_id_idx',
+    'legal_hold_repository_id_idx'
+]);
+select indexes_are('login_code', array[
+    'login_code_pkey',
+    'login_code_user_id_idx'
+]);
 select indexes_are('maintainer', array[
     'maintainer_pkey',
     'maintainer_email_key'
@@ -314,19 +571,46 @@ select indexes_are('organization', array[
     'organization_pkey',
     'organization_name_key'
 ]);
+select indexes_are('organization_idp_group', array[
+    'organization_idp_group_pkey',
+    'organization_idp_group_idp_group_idx'
+]);
+select indexes_are('organization_link', array[
+    'organization_link_pkey'
+]);
+select indexes_are('organization_quota', array[
+    'organization_quota_pkey'
+]);
 select indexes_are('package', array[
     'package_pkey',
     'package_tsdoc_idx',
+    'package_search_data_idx',
     'package_repository_id_idx',
     'package_repository_id_name_key'
 ]);
 select indexes_are('package__maintainer', array[
     'package__maintainer_pkey'
 ]);
+select indexes_are('package_change', array[
+    'package_change_pkey',
+    'package_change_created_at_idx',
+    'package_change_package_id_idx'
+]);
+select indexes_are('package_discovery', array[
+    'package_discovery_pkey'
+]);
+select indexes_are('package_document', array[
+    'package_document_pkey'
+]);
 select indexes_are('password_reset_code', array[
     'password_reset_code_pkey',
     'password_reset_code_user_id_key'
 ]);
+select indexes_are('promoted_package', array[
+    'promoted_package_pkey',
+    'promoted_package_package_id_idx',
+    'promoted_package_starts_at_ends_at_idx'
+]);
 select indexes_are('repository', array[
     'repository_pkey',
     'repository_name_idx',
@@ -334,18 +618,33 @@ select indexes_are('repository', array[
     'repository_url_idx',
     'repository_repository_kind_id_idx',
     'repository_user_id_idx',
-    'repository_organization_id_idx'
+    'repository_organization_id_idx',
+    'repository_labels_idx'
 ]);
 select indexes_are('repository_kind', array[
     'repository_kind_pkey'
 ]);
+select indexes_are('repository_publish_token', array[
+    'repository_publish_token_pkey',
+    'repository_publish_token_repository_id_idx'
+]);
+select indexes_are('repository_tracking_run', array[
+    'repository_tracking_run_pkey',
+    'repository_tracking_run_repository_id_tracked_at_idx'
+]);
+select indexes_are('repository_transfer', array[
+    'repository_transfer_pkey',
+    'repository_transfer_user_id_idx',
+    'repository_transfer_organization_id_idx'
+]);
 select indexes_are('session', array[
     'session_pkey'
 ]);
 select indexes_are('snapshot', array[
     'snapshot_pkey',
     'snapshot_package_id_digest_key',
-    'snapshot_not_deprecated_with_readme_idx'
+    'snapshot_not_deprecated_with_readme_idx',
+    'snapshot_digest_idx'
 ]);
 select indexes_are('subscription', array[
     'subscription_pkey',
@@ -354,7 +653,8 @@ select indexes_are('subscription', array[
 select indexes_are('user', array[
     'user_pkey',
     'user_alias_key',
-    'user_email_key'
+    'user_email_key',
+    'user_service_account_organization_id_idx'
 ]);
 select indexes_are('user__organization', array[
     'user__organization_pkey'
@@ -362,6 +662,15 @@ select indexes_are('user__organization', array[
 select indexes_are('user_starred_package', array[
     'user_starred_package_pkey'
 ]);
+select indexes_are('webauthn_ceremony', array[
+    'webauthn_ceremony_pkey',
+    'webauthn_ceremony_created_at_idx'
+]);
+select indexes_are('webauthn_credential', array[
+    'webauthn_credential_pkey',
+    'webauthn_credential_credential_id_key',
+    'webauthn_credential_user_id_idx'
+]);
 select indexes_are('webhook', array[
     'webhook_pkey',
     'webhook_user_id_idx',
@@ -374,6 +683,15 @@ select indexes_are('webhook__package', array[
     'webhook__package_pkey',
     'webhook__package_package_id_idx'
 ]);
+select indexes_are('webhook__repository', array[
+    'webhook__repository_pkey',
+    'webhook__repository_repository_id_idx'
+]);
+select indexes_are('webhook_template_error', array[
+    'webhook_template_error_pkey',
+    'webhook_template_error_webhook_id_created_at_idx',
+    'webhook_template_error_event_id_idx'
+]);
 
 -- Check expected functions exist
 -- API keys
@@ -384,11 +702,24 @@ select has_function('get_user_api_keys');
 select has_function('update_api_key');
 -- Authz
 select has_function('notify_authorization_policies_updates');
+-- Email spool
+select has_function('flush_email_spool');
 -- Events
 select has_function('get_pending_event');
 -- Images
 select has_function('get_image');
+select has_function('get_image_source');
 select has_function('register_image');
+select has_function('register_image_source');
+-- Legal holds
+select has_function('add_legal_hold');
+select has_function('check_legal_hold');
+select has_function('get_legal_holds');
+select has_function('release_legal_hold');
+-- Moderators
+select has_function('add_moderator');
+select has_function('get_moderators');
+select has_function('remove_moderator');
 -- Notifications
 select has_function('add_notification');
 select has_function('get_pending_notification');
@@ -396,50 +727,109 @@ select has_function('update_notification_status');
 -- Organizations
 select has_function('add_organization');
 select has_function('add_organization_member');
+select has_function('cancel_organization_invitation');
 select has_function('confirm_organization_membership');
 select has_function('delete_organization');
 select has_function('delete_organization_member');
 select has_function('get_authorization_policies');
 select has_function('get_authorization_policy');
 select has_function('get_organization');
+select has_function('get_organization_idp_groups');
+select has_function('get_organization_invitations');
+select has_function('get_organization_links');
 select has_function('get_organization_members');
+select has_function('get_organization_quotas');
+select has_function('get_user_organization_role');
 select has_function('get_user_organizations');
+select has_function('resend_organization_invitation');
 select has_function('update_authorization_policy');
 select has_function('update_organization');
+select has_function('update_organization_idp_groups');
+select has_function('update_organization_links');
+select has_function('update_organization_member_role');
 select has_function('user_belongs_to_organization');
+select has_function('verify_organization_links');
 -- Packages
 select has_function('are_all_containers_images_whitelisted');
+select has_function('build_package_document');
+select has_function('build_package_level_document');
+select has_function('generate_package_search_data');
 select has_function('generate_package_tsdoc');
 select has_function('get_harbor_replication_dump');
+select has_function('get_org_stale_packages');
 select has_function('get_package');
+select has_function('get_package_by_digest');
 select has_function('get_package_changelog');
+select has_function('get_package_events');
+select has_function('get_package_head');
 select has_function('get_package_summary');
+select has_function('get_packages_changes');
+select has_function('get_packages_comparison');
+select has_function('get_packages_discovery');
 select has_function('get_packages_starred_by_user');
 select has_function('get_package_stars');
 select has_function('get_packages_stats');
 select has_function('get_random_packages');
+select has_function('get_security_reports_to_migrate');
 select has_function('get_snapshots_to_scan');
+select has_function('get_snapshots_without_logo');
+select has_function('get_user_stale_packages');
+select has_function('refresh_package_documents');
+select has_function('refresh_package_level_document');
+select has_function('refresh_packages_discovery');
 select has_function('register_package');
+select has_function('register_package_change');
 select has_function('search_packages');
 select has_function('search_packages_monocular');
 select has_function('semver_gt');
+select has_function('set_snapshot_generated_logo');
+select has_function('set_snapshot_test_results');
 select has_function('semver_gte');
 select has_function('toggle_star');
+select has_function('track_package_changes');
+select has_function('track_snapshot_changes');
 select has_function('update_snapshot_security_report');
+select has_function('update_packages_freshness');
 select has_function('unregister_package');
+-- Promoted packages
+select has_function('add_promoted_package');
+select has_function('delete_promoted_package');
+select has_function('get_promoted_packages');
+select has_function('get_promoted_packages_stats');
+select has_function('register_promoted_package_event');
 -- Repositories
+select has_function('accept_repository_transfer');
 select has_function('add_repository');
+select has_function('add_repository_publish_token');
 select has_function('delete_repository');
+select has_function('delete_repository_publish_token');
 select has_function('get_repository_by_id');
 select has_function('get_repository_by_name');
+select has_function('get_repository_head');
 select has_function('get_repository_packages_digest');
+select has_function('get_repository_publish_tokens');
+select has_function('get_repository_stats');
+select has_function('get_repository_tracking_runs');
 select has_function('get_repository_summary');
+select has_function('get_user_repository_transfers');
+select has_function('reject_repository_transfer');
+select has_function('request_repository_tracking');
+select has_function('request_repository_transfer');
 select has_function('search_repositories');
 select has_function('set_last_scanning_results');
 select has_function('set_last_tracking_results');
+select has_function('set_repository_archived');
+select has_function('set_repository_tracking_paused');
+select has_function('set_packages_overrides');
+select has_function('set_repository_webhook_secret');
 select has_function('set_verified_publisher');
 select has_function('transfer_repository');
 select has_function('update_repository');
+select has_function('verify_repository_domain');
+-- Service accounts
+select has_function('add_service_account');
+select has_function('delete_service_account');
+select has_function('get_org_service_accounts');
 -- Stats
 select has_function('get_stats');
 -- Subscriptions
@@ -447,33 +837,47 @@ select has_function('add_opt_out');
 select has_function('add_subscription');
 select has_function('delete_opt_out');
 select has_function('delete_subscription');
+select has_function('export_user_subscriptions');
 select has_function('get_package_subscriptors');
 select has_function('get_repository_subscriptors');
 select has_function('get_user_opt_out_entries');
 select has_function('get_user_package_subscriptions');
 select has_function('get_user_subscriptions');
+select has_function('import_user_subscriptions');
 -- Users
 select has_function('approve_session');
 select has_function('check_user_alias_availability');
+select has_function('consume_webauthn_ceremony');
 select has_function('delete_user');
 select has_function('get_user_profile');
+select has_function('get_user_site_role');
 select has_function('get_user_tfa_config');
+select has_function('get_user_webauthn_credentials');
+select has_function('get_user_webauthn_info');
 select has_function('register_delete_user_code');
+select has_function('register_login_code');
 select has_function('register_password_reset_code');
 select has_function('register_session');
 select has_function('register_user');
+select has_function('register_webauthn_ceremony');
+select has_function('register_webauthn_credential');
 select has_function('reset_user_password');
+select has_function('sync_user_idp_groups');
 select has_function('update_user_password');
 select has_function('update_user_profile');
+select has_function('user_is_site_admin');
 select has_function('verify_email');
+select has_function('verify_login_code');
 select has_function('verify_password_reset_code');
 -- Webhooks
 select has_function('add_webhook');
 select has_function('delete_webhook');
 select has_function('get_webhook');
+select has_function('get_webhook_template_errors');
 select has_function('get_org_webhooks');
 select has_function('get_user_webhooks');
 select has_function('get_webhooks_subscribed_to_package');
+select has_function('register_webhook_template_error');
 select has_function('update_webhook');
 select has_function('user_has_access_to_webhook');
 
@@ -491,7 +895,19 @@ select results_eq(
         (7, 'Tekton tasks'),
         (8, 'KEDA scalers'),
         (9, 'CoreDNS plugins'),
-        (10, 'Keptn integrations')
+        (10, 'Keptn integrations'),
+        (11, 'Kustomize bases and components'),
+        (12, 'Terraform modules'),
+        (13, 'Crossplane packages'),
+        (14, 'Kyverno policies'),
+        (15, 'Gatekeeper policies'),
+        (16, 'Argo Workflows templates'),
+        (17, 'Backstage plugins'),
+        (18, 'WebAssembly modules'),
+        (19, 'Dev Container features and templates'),
+        (20, 'Headlamp plugins'),
+        (21, 'Knative functions and eventing sources'),
+        (22, 'Container images')
     $$,
     'Repository kinds should exist'
 );
@@ -1,10 +1,15 @@
 module github.com/artifacthub/hub
 
-go 1.16
+go 1.21
 
 require (
+	cloud.google.com/go/storage v1.15.0
 	github.com/Masterminds/semver/v3 v3.1.1
 	github.com/aquasecurity/trivy v0.19.2
+	github.com/aws/aws-sdk-go-v2 v1.16.16
+	github.com/aws/aws-sdk-go-v2/config v1.15.15
+	github.com/aws/aws-sdk-go-v2/feature/s3/manager v1.11.14
+	github.com/aws/aws-sdk-go-v2/service/s3 v1.26.10
 	github.com/containerd/containerd v1.4.9
 	github.com/coreos/go-oidc v2.2.1+incompatible
 	github.com/deislabs/oras v0.11.1
@@ -13,7 +18,8 @@ require (
 	github.com/ghodss/yaml v1.0.0
 	github.com/go-chi/chi/v5 v5.0.3
 	github.com/go-git/go-git/v5 v5.4.2
-	github.com/google/go-containerregistry v0.5.1
+	github.com/go-webauthn/webauthn v0.2.2
+	github.com/google/go-containerregistry v0.19.1
 	github.com/google/go-github v17.0.0+incompatible
 	github.com/gorilla/csrf v1.7.1
 	github.com/gorilla/feeds v1.1.1
@@ -21,6 +27,7 @@ require (
 	github.com/h2non/go-is-svg v0.0.0-20160927212452-35e8c4b0612c
 	github.com/hashicorp/go-multierror v1.1.1
 	github.com/hashicorp/golang-lru v0.5.4
+	github.com/hashicorp/hcl/v2 v2.10.0
 	github.com/jackc/pgconn v1.10.0
 	github.com/jackc/pgx/v4 v4.13.0
 	github.com/mailru/easyjson v0.7.7 // indirect
@@ -31,8 +38,11 @@ require (
 	github.com/prometheus/client_golang v1.11.0
 	github.com/rs/cors v1.8.0
 	github.com/rs/zerolog v1.23.0
+	github.com/russross/blackfriday/v2 v2.1.0
 	github.com/sabhiram/go-gitignore v0.0.0-20201211210132-54b8a0bf510f
 	github.com/satori/uuid v1.2.0
+	github.com/sigstore/cosign/v2 v2.2.4
+	github.com/sigstore/sigstore v1.8.3
 	github.com/spf13/cobra v1.2.1
 	github.com/spf13/viper v1.8.1
 	github.com/stretchr/testify v1.7.0
@@ -40,7 +50,7 @@ require (
 	github.com/unrolled/secure v1.0.9
 	github.com/vincent-petithory/dataurl v0.0.0-20191104211930-d1553a71de50
 	github.com/wagslane/go-password-validator v0.3.0
-	golang.org/x/crypto v0.0.0-20210711020723-a769d52b0f97
+	golang.org/x/crypto v0.0.0-20220214200702-86341886e292
 	golang.org/x/image v0.0.0-20210628002857-a66eb6448b8d // indirect
 	golang.org/x/oauth2 v0.0.0-20210805134026-6f1e6394065a
 	golang.org/x/time v0.0.0-20210723032227-1f47c861a9ac
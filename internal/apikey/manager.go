@@ -10,9 +10,11 @@ import (
 	"fmt"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/org"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/jackc/pgx/v4"
 	"github.com/satori/uuid"
+	"github.com/spf13/viper"
 )
 
 const (
@@ -21,19 +23,22 @@ const (
 	deleteAPIKeyDBQ    = `select delete_api_key($1::uuid, $2::uuid)`
 	getAPIKeyDBQ       = `select get_api_key($1::uuid, $2::uuid)`
 	getAPIKeyUserIDDBQ = `select user_id, secret from api_key where api_key_id = $1`
+	getKeyOwnerOrgDBQ  = `select o.name from "user" u left join organization o on o.organization_id = u.service_account_organization_id where u.user_id = $1`
 	getUserAPIKeysDBQ  = `select * from get_user_api_keys($1::uuid, $2::int, $3::int)`
 	updateAPIKeyDBQ    = `select update_api_key($1::jsonb)`
 )
 
 // Manager provides an API to manage api keys.
 type Manager struct {
-	db hub.DB
+	cfg *viper.Viper
+	db  hub.DB
 }
 
 // NewManager creates a new Manager instance.
-func NewManager(db hub.DB) *Manager {
+func NewManager(cfg *viper.Viper, db hub.DB) *Manager {
 	return &Manager{
-		db: db,
+		cfg: cfg,
+		db:  db,
 	}
 }
 
@@ -46,6 +51,18 @@ func (m *Manager) Add(ctx context.Context, ak *hub.APIKey) (*hub.APIKey, error)
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
 	}
 
+	// Check the quota of the organization the key is being created for, if
+	// any (only service accounts keys belong to an organization)
+	var orgName *string
+	if err := m.db.QueryRow(ctx, getKeyOwnerOrgDBQ, ak.UserID).Scan(&orgName); err != nil {
+		return nil, err
+	}
+	if orgName != nil {
+		if err := org.CheckQuota(ctx, m.db, m.cfg, ak.UserID, *orgName, org.APIKeysQuota); err != nil {
+			return nil, err
+		}
+	}
+
 	// Generate API key secret
 	randomBytes := make([]byte, 32)
 	if _, err := rand.Read(randomBytes); err != nil {
@@ -11,18 +11,22 @@ import (
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/jackc/pgx/v4"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 const apiKeyID = "00000000-0000-0000-0000-000000000001"
 
+var cfg = viper.New()
+
 func TestAdd(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	orgName := "org1"
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil)
 		assert.Panics(t, func() {
 			ak := &hub.APIKey{
 				Name:   "apikey1",
@@ -48,7 +52,7 @@ func TestAdd(t *testing.T) {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
-				m := NewManager(nil)
+				m := NewManager(cfg, nil)
 
 				keyInfoJSON, err := m.Add(ctx, tc.ak)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
@@ -65,8 +69,25 @@ func TestAdd(t *testing.T) {
 			UserID: "userID",
 		}
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getKeyOwnerOrgDBQ, "userID").Return(nil, nil)
 		db.On("QueryRow", ctx, addAPIKeyDBQ, mock.Anything).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
+
+		output, err := m.Add(ctx, ak)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, output)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error getting key owner organization", func(t *testing.T) {
+		t.Parallel()
+		ak := &hub.APIKey{
+			Name:   "apikey1",
+			UserID: "userID",
+		}
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getKeyOwnerOrgDBQ, "userID").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db)
 
 		output, err := m.Add(ctx, ak)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -74,15 +95,62 @@ func TestAdd(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 
-	t.Run("add api key succeeded", func(t *testing.T) {
+	t.Run("api keys quota exceeded", func(t *testing.T) {
+		t.Parallel()
+		ak := &hub.APIKey{
+			Name:   "apikey1",
+			UserID: "userID",
+		}
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getKeyOwnerOrgDBQ, "userID").Return(&orgName, nil)
+		db.On("QueryRow", ctx, mock.Anything, "userID", "org1").Return([]byte(`
+		{
+			"quotas": {"max_api_keys": 2},
+			"usage": {"api_keys": 2}
+		}
+		`), nil)
+		m := NewManager(cfg, db)
+
+		output, err := m.Add(ctx, ak)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Nil(t, output)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("add personal api key succeeded", func(t *testing.T) {
+		t.Parallel()
+		ak := &hub.APIKey{
+			Name:   "apikey1",
+			UserID: "userID",
+		}
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getKeyOwnerOrgDBQ, "userID").Return(nil, nil)
+		db.On("QueryRow", ctx, addAPIKeyDBQ, mock.Anything).Return("apiKeyID", nil)
+		m := NewManager(cfg, db)
+
+		output, err := m.Add(ctx, ak)
+		assert.NoError(t, err)
+		assert.Equal(t, "apiKeyID", output.APIKeyID)
+		assert.NotEmpty(t, output.Secret)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("add service account api key succeeded", func(t *testing.T) {
 		t.Parallel()
 		ak := &hub.APIKey{
 			Name:   "apikey1",
 			UserID: "userID",
 		}
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getKeyOwnerOrgDBQ, "userID").Return(&orgName, nil)
+		db.On("QueryRow", ctx, mock.Anything, "userID", "org1").Return([]byte(`
+		{
+			"quotas": {},
+			"usage": {"api_keys": 2}
+		}
+		`), nil)
 		db.On("QueryRow", ctx, addAPIKeyDBQ, mock.Anything).Return("apiKeyID", nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		output, err := m.Add(ctx, ak)
 		assert.NoError(t, err)
@@ -116,7 +184,7 @@ func TestCheck(t *testing.T) {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
-				m := NewManager(nil)
+				m := NewManager(cfg, nil)
 				_, err := m.Check(ctx, tc.apiKeyID, tc.apiKeySecret)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
@@ -128,7 +196,7 @@ func TestCheck(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return(nil, pgx.ErrNoRows)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		output, err := m.Check(ctx, "keyID", "secret")
 		assert.NoError(t, err)
@@ -141,7 +209,7 @@ func TestCheck(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		output, err := m.Check(ctx, "keyID", "secret")
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -154,7 +222,7 @@ func TestCheck(t *testing.T) {
 		db := &tests.DBMock{}
 		secretHashed := fmt.Sprintf("%x", sha512.Sum512([]byte("secret")))
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return([]interface{}{"userID", secretHashed}, nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		output, err := m.Check(ctx, "keyID", "invalid-secret")
 		assert.NoError(t, err)
@@ -168,7 +236,7 @@ func TestCheck(t *testing.T) {
 		db := &tests.DBMock{}
 		secretHashed := fmt.Sprintf("%x", sha512.Sum512([]byte("secret")))
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return([]interface{}{"userID", secretHashed}, nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		output, err := m.Check(ctx, "keyID", "secret")
 		assert.NoError(t, err)
@@ -183,7 +251,7 @@ func TestDelete(t *testing.T) {
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil)
 		assert.Panics(t, func() {
 			_ = m.Delete(context.Background(), apiKeyID)
 		})
@@ -191,7 +259,7 @@ func TestDelete(t *testing.T) {
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil)
 		err := m.Delete(ctx, "")
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
@@ -200,7 +268,7 @@ func TestDelete(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, deleteAPIKeyDBQ, "userID", apiKeyID).Return(tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		err := m.Delete(ctx, apiKeyID)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -211,7 +279,7 @@ func TestDelete(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, deleteAPIKeyDBQ, "userID", apiKeyID).Return(nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		err := m.Delete(ctx, apiKeyID)
 		assert.NoError(t, err)
@@ -224,7 +292,7 @@ func TestGetJSON(t *testing.T) {
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil)
 		assert.Panics(t, func() {
 			_, _ = m.GetJSON(context.Background(), apiKeyID)
 		})
@@ -232,7 +300,7 @@ func TestGetJSON(t *testing.T) {
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil)
 		_, err := m.GetJSON(ctx, "")
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
@@ -241,7 +309,7 @@ func TestGetJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getAPIKeyDBQ, "userID", apiKeyID).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		dataJSON, err := m.GetJSON(ctx, apiKeyID)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -253,7 +321,7 @@ func TestGetJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getAPIKeyDBQ, "userID", apiKeyID).Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		dataJSON, err := m.GetJSON(ctx, apiKeyID)
 		assert.NoError(t, err)
@@ -268,7 +336,7 @@ func TestGetOwnedByUserJSON(t *testing.T) {
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil)
 		assert.Panics(t, func() {
 			_, _ = m.GetOwnedByUserJSON(context.Background(), p)
 		})
@@ -278,7 +346,7 @@ func TestGetOwnedByUserJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getUserAPIKeysDBQ, "userID", 10, 1).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		result, err := m.GetOwnedByUserJSON(ctx, p)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -290,7 +358,7 @@ func TestGetOwnedByUserJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getUserAPIKeysDBQ, "userID", 10, 1).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		result, err := m.GetOwnedByUserJSON(ctx, p)
 		assert.NoError(t, err)
@@ -305,7 +373,7 @@ func TestUpdate(t *testing.T) {
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil)
 		assert.Panics(t, func() {
 			ak := &hub.APIKey{
 				APIKeyID: apiKeyID,
@@ -339,7 +407,7 @@ func TestUpdate(t *testing.T) {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
-				m := NewManager(nil)
+				m := NewManager(cfg, nil)
 
 				err := m.Update(ctx, tc.ak)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
@@ -358,7 +426,7 @@ func TestUpdate(t *testing.T) {
 		akJSON, _ := json.Marshal(ak)
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, updateAPIKeyDBQ, akJSON).Return(tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		err := m.Update(ctx, ak)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -375,7 +443,7 @@ func TestUpdate(t *testing.T) {
 		akJSON, _ := json.Marshal(ak)
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, updateAPIKeyDBQ, akJSON).Return(nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db)
 
 		err := m.Update(ctx, ak)
 		assert.NoError(t, err)
@@ -27,6 +27,8 @@ const (
 	// Database queries
 	getAuthzPoliciesDBQ = `select get_authorization_policies()`
 	getUserAliasDBQ     = `select alias from "user" where user_id = $1`
+	getUserRoleDBQ      = `select get_user_organization_role($1::uuid, $2::text)`
+	getUserSiteRoleDBQ  = `select get_user_site_role($1::uuid)`
 
 	pauseOnError = 10 * time.Second
 )
@@ -42,6 +44,52 @@ var (
 		hub.GetAuthorizationPolicy,
 		hub.UpdateAuthorizationPolicy,
 	}
+
+	// rolesAllowedActions represents the actions each of the organization
+	// roles is allowed to perform when the organization hasn't enabled an
+	// authorization policy.
+	rolesAllowedActions = map[hub.OrganizationRole][]hub.Action{
+		hub.OrganizationOwner: {
+			hub.Action("all"),
+		},
+		hub.OrganizationAdmin: {
+			hub.AddOrganizationMember,
+			hub.AddOrganizationRepository,
+			hub.AddOrganizationServiceAccount,
+			hub.AddOrganizationWebhook,
+			hub.DeleteOrganizationMember,
+			hub.DeleteOrganizationRepository,
+			hub.DeleteOrganizationServiceAccount,
+			hub.DeleteOrganizationWebhook,
+			hub.GetAuthorizationPolicy,
+			hub.TransferOrganizationRepository,
+			hub.UpdateOrganization,
+			hub.UpdateOrganizationRepository,
+			hub.UpdateOrganizationWebhook,
+		},
+		hub.OrganizationMaintainer: {
+			hub.AddOrganizationRepository,
+			hub.AddOrganizationWebhook,
+			hub.DeleteOrganizationWebhook,
+			hub.UpdateOrganizationRepository,
+			hub.UpdateOrganizationWebhook,
+		},
+		hub.OrganizationViewer: {},
+	}
+
+	// siteRolesAllowedActions represents the site actions each of the site
+	// roles is allowed to perform.
+	siteRolesAllowedActions = map[hub.SiteRole][]hub.Action{
+		hub.SiteAdmin: {
+			hub.Action("all"),
+		},
+		hub.SiteModerator: {
+			hub.ModerateAbuseReports,
+			hub.ModerateImageUploads,
+			hub.ModerateOfficialRequests,
+			hub.ModerateQuarantineApprovals,
+		},
+	}
 )
 
 // Authorizer is in charge of authorizing actions that users intend to perform.
@@ -161,6 +209,23 @@ func (a *Authorizer) Authorize(ctx context.Context, input *hub.AuthorizeInput) e
 	return nil
 }
 
+// AuthorizeSiteAction allows or denies if a site action (i.e. an action not
+// bound to any organization, like moderating content) can be performed by
+// the user provided, based on the site role the user has.
+func (a *Authorizer) AuthorizeSiteAction(ctx context.Context, userID string, action hub.Action) error {
+	var role *string
+	if err := a.db.QueryRow(ctx, getUserSiteRoleDBQ, userID).Scan(&role); err != nil {
+		return fmt.Errorf("%w: error getting site role: %s", hub.ErrInsufficientPrivilege, err.Error())
+	}
+	if role == nil {
+		return hub.ErrInsufficientPrivilege
+	}
+	if !IsActionAllowed(siteRolesAllowedActions[hub.SiteRole(*role)], action) {
+		return hub.ErrInsufficientPrivilege
+	}
+	return nil
+}
+
 // GetAllowedActions returns the actions a given user is allowed to perform in
 // the provided organization. We'll obtain them querying the organization
 // authorization policy.
@@ -170,10 +235,10 @@ func (a *Authorizer) GetAllowedActions(ctx context.Context, userID, orgName stri
 	query, ok := a.allowedActionsQueries[orgName]
 	if !ok {
 		// If the organization hasn't defined an authorization policy yet, the
-		// user is allowed to perform all actions available in the organizations
-		// he belongs to.
+		// user is allowed to perform the actions allowed for the role he has
+		// in the organization.
 		a.mu.RUnlock()
-		return []hub.Action{"all"}, nil
+		return a.getRoleAllowedActions(ctx, userID, orgName)
 	}
 	a.mu.RUnlock()
 
@@ -273,6 +338,24 @@ func (a *Authorizer) WillUserBeLockedOut(
 	return false, nil
 }
 
+// getRoleAllowedActions returns the actions the user is allowed to perform in
+// the provided organization based on the role he has in it. Users who are not
+// confirmed members of the organization are not allowed to perform any action.
+func (a *Authorizer) getRoleAllowedActions(ctx context.Context, userID, orgName string) ([]hub.Action, error) {
+	var role *string
+	if err := a.db.QueryRow(ctx, getUserRoleDBQ, userID, orgName).Scan(&role); err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return []hub.Action{}, nil
+	}
+	allowedActions, ok := rolesAllowedActions[hub.OrganizationRole(*role)]
+	if !ok {
+		return nil, fmt.Errorf("invalid organization role: %s", *role)
+	}
+	return allowedActions, nil
+}
+
 // getUserAlias is a helper function that returns the alias of a user
 // identified by the ID provided.
 func (a *Authorizer) getUserAlias(ctx context.Context, userID string) (string, error) {
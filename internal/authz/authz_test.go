@@ -28,6 +28,13 @@ const (
 	org1Name   = "org1"
 	org2Name   = "org2"
 	org3Name   = "org3"
+	org4Name   = "org4"
+)
+
+var (
+	ownerRole  = string(hub.OrganizationOwner)
+	adminRole  = string(hub.OrganizationAdmin)
+	viewerRole = string(hub.OrganizationViewer)
 )
 
 var testsAuthorizationPoliciesJSON = []byte(`{
@@ -118,6 +125,10 @@ func TestAuthorize(t *testing.T) {
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user2ID).Return(user2Alias, nil).Maybe()
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user3ID).Return(user3Alias, nil).Maybe()
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user5ID).Return("", tests.ErrFakeDB).Maybe()
+	db.On("QueryRow", context.Background(), getUserRoleDBQ, user1ID, org3Name).Return(&ownerRole, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserRoleDBQ, user2ID, org3Name).Return(&adminRole, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserRoleDBQ, user3ID, org3Name).Return(&viewerRole, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserRoleDBQ, user4ID, org3Name).Return(nil, nil).Maybe()
 	db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
 	az, err := NewAuthorizer(db)
 	require.NoError(t, err)
@@ -222,13 +233,29 @@ func TestAuthorize(t *testing.T) {
 			},
 			true,
 		},
+		{
+			&hub.AuthorizeInput{
+				OrganizationName: org3Name,
+				UserID:           user2ID,
+				Action:           hub.DeleteOrganization,
+			},
+			false,
+		},
 		{
 			&hub.AuthorizeInput{
 				OrganizationName: org3Name,
 				UserID:           user3ID,
 				Action:           hub.AddOrganizationMember,
 			},
-			true,
+			false,
+		},
+		{
+			&hub.AuthorizeInput{
+				OrganizationName: org3Name,
+				UserID:           user4ID,
+				Action:           hub.AddOrganizationMember,
+			},
+			false,
 		},
 	}
 	for i, tc := range testCases {
@@ -247,6 +274,54 @@ func TestAuthorize(t *testing.T) {
 	db.AssertExpectations(t)
 }
 
+func TestAuthorizeSiteAction(t *testing.T) {
+	siteAdminRole := string(hub.SiteAdmin)
+	moderatorRole := string(hub.SiteModerator)
+	invalidRole := "invalid"
+
+	db := &tests.DBMock{}
+	db.On("QueryRow", context.Background(), getAuthzPoliciesDBQ).Return(testsAuthorizationPoliciesJSON, nil)
+	db.On("QueryRow", context.Background(), getUserSiteRoleDBQ, user1ID).Return(&siteAdminRole, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserSiteRoleDBQ, user2ID).Return(&moderatorRole, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserSiteRoleDBQ, user3ID).Return(nil, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserSiteRoleDBQ, user4ID).Return(&invalidRole, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserSiteRoleDBQ, user5ID).Return(nil, tests.ErrFakeDB).Maybe()
+	db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
+	az, err := NewAuthorizer(db)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		userID string
+		action hub.Action
+		allow  bool
+	}{
+		{user1ID, hub.ModerateAbuseReports, true},
+		{user1ID, hub.Action("someOtherSiteAction"), true},
+		{user2ID, hub.ModerateAbuseReports, true},
+		{user2ID, hub.ModerateImageUploads, true},
+		{user2ID, hub.ModerateOfficialRequests, true},
+		{user2ID, hub.ModerateQuarantineApprovals, true},
+		{user2ID, hub.Action("someOtherSiteAction"), false},
+		{user3ID, hub.ModerateAbuseReports, false},
+		{user4ID, hub.ModerateAbuseReports, false},
+		{user5ID, hub.ModerateAbuseReports, false},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			err := az.AuthorizeSiteAction(context.Background(), tc.userID, tc.action)
+			if tc.allow {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, hub.ErrInsufficientPrivilege))
+			}
+		})
+	}
+
+	db.AssertExpectations(t)
+}
+
 func TestGetAllowedActions(t *testing.T) {
 	db := &tests.DBMock{}
 	db.On("QueryRow", context.Background(), getAuthzPoliciesDBQ).Return(testsAuthorizationPoliciesJSON, nil)
@@ -255,6 +330,11 @@ func TestGetAllowedActions(t *testing.T) {
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user3ID).Return(user3Alias, nil).Maybe()
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user4ID).Return(user4Alias, nil).Maybe()
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user5ID).Return("", tests.ErrFakeDB).Maybe()
+	db.On("QueryRow", context.Background(), getUserRoleDBQ, user1ID, org3Name).Return(&ownerRole, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserRoleDBQ, user2ID, org3Name).Return(&adminRole, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserRoleDBQ, user3ID, org3Name).Return(&viewerRole, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserRoleDBQ, user4ID, org3Name).Return(nil, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserRoleDBQ, user1ID, org4Name).Return(nil, tests.ErrFakeDB).Maybe()
 	db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
 	az, err := NewAuthorizer(db)
 	require.NoError(t, err)
@@ -313,12 +393,25 @@ func TestGetAllowedActions(t *testing.T) {
 				hub.Action("all"),
 			},
 		},
+		{
+			user2ID,
+			org3Name,
+			rolesAllowedActions[hub.OrganizationAdmin],
+		},
 		{
 			user3ID,
 			org3Name,
-			[]hub.Action{
-				hub.Action("all"),
-			},
+			[]hub.Action{},
+		},
+		{
+			user4ID,
+			org3Name,
+			[]hub.Action{},
+		},
+		{
+			user1ID,
+			org4Name,
+			nil,
 		},
 	}
 	for i, tc := range testCases {
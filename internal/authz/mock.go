@@ -18,6 +18,12 @@ func (m *AuthorizerMock) Authorize(ctx context.Context, input *hub.AuthorizeInpu
 	return args.Error(0)
 }
 
+// AuthorizeSiteAction implements the Authorizer interface.
+func (m *AuthorizerMock) AuthorizeSiteAction(ctx context.Context, userID string, action hub.Action) error {
+	args := m.Called(ctx, userID, action)
+	return args.Error(0)
+}
+
 // GetAllowedActions implements the Authorizer interface.
 func (m *AuthorizerMock) GetAllowedActions(ctx context.Context, userID, orgName string) ([]hub.Action, error) {
 	args := m.Called(ctx, userID, orgName)
@@ -0,0 +1,268 @@
+package chartscache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/spf13/viper"
+)
+
+const (
+	// defaultMaxSize represents the default maximum size of the disk cache.
+	defaultMaxSize = 1 << 30
+
+	// evictionTarget represents the fraction of the maximum size the disk
+	// cache is shrunk to when it's full, so that evictions do not happen on
+	// every write once the limit is reached.
+	evictionTarget = 0.9
+
+	archiveExt = ".tgz"
+)
+
+var (
+	// digestRE is a regexp used to validate the digests used as cache keys.
+	digestRE = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+	// errInvalidDigest indicates that the digest provided is not valid.
+	errInvalidDigest = errors.New("invalid digest")
+)
+
+// NewCache creates a new charts cache based on the configuration provided.
+// Nil is returned when the charts cache is disabled (default).
+func NewCache(cfg *viper.Viper) (hub.ChartsCache, error) {
+	switch cfg.GetString("tracker.chartsCache.store") {
+	case "":
+		return nil, nil
+	case "disk":
+		maxSize := int64(cfg.GetSizeInBytes("tracker.chartsCache.maxSize"))
+		c, err := NewDiskCache(cfg.GetString("tracker.chartsCache.path"), maxSize)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	case "objectStorage":
+		c, err := NewObjectStorageCache(cfg.GetString("tracker.chartsCache.objectStorageURL"))
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	default:
+		return nil, errors.New("invalid charts cache store")
+	}
+}
+
+// DiskCache is a hub.ChartsCache implementation that keeps the charts
+// archives in a local directory. When the size of the archives stored exceeds
+// the maximum configured, the least recently used ones are evicted.
+type DiskCache struct {
+	dir     string
+	maxSize int64
+
+	mu   sync.Mutex
+	size int64
+}
+
+// NewDiskCache creates a new DiskCache instance. The directory provided will
+// be created if it does not exist yet.
+func NewDiskCache(dir string, maxSize int64) (*DiskCache, error) {
+	if dir == "" {
+		return nil, errors.New("charts cache path not provided")
+	}
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating charts cache directory: %w", err)
+	}
+	c := &DiskCache{
+		dir:     dir,
+		maxSize: maxSize,
+	}
+	entries, err := c.entries()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		c.size += e.Size()
+	}
+	return c, nil
+}
+
+// Get implements the ChartsCache interface.
+func (c *DiskCache) Get(ctx context.Context, digest string) ([]byte, error) {
+	if !digestRE.MatchString(digest) {
+		return nil, errInvalidDigest
+	}
+	p := c.path(digest)
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, hub.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Track usage of the archive to evict the least recently used ones first
+	now := time.Now()
+	_ = os.Chtimes(p, now, now)
+
+	return data, nil
+}
+
+// Put implements the ChartsCache interface.
+func (c *DiskCache) Put(ctx context.Context, digest string, data []byte) error {
+	if !digestRE.MatchString(digest) {
+		return errInvalidDigest
+	}
+	size := int64(len(data))
+	if size > c.maxSize {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Write archive to a temporary file first, so that partial archives are
+	// never read
+	p := c.path(digest)
+	if _, err := os.Stat(p); err == nil {
+		return nil
+	}
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	c.size += size
+
+	// Evict least recently used archives if the cache is full
+	if c.size > c.maxSize {
+		return c.evict(int64(float64(c.maxSize) * evictionTarget))
+	}
+	return nil
+}
+
+// evict removes the least recently used archives from the cache until its
+// size is below the target provided.
+func (c *DiskCache) evict(target int64) error {
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+	c.size = 0
+	for _, e := range entries {
+		c.size += e.Size()
+	}
+	for _, e := range entries {
+		if c.size <= target {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, e.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		c.size -= e.Size()
+	}
+	return nil
+}
+
+// entries returns information about the archives stored in the cache.
+func (c *DiskCache) entries() ([]os.FileInfo, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]os.FileInfo, 0, len(dirEntries))
+	for _, de := range dirEntries {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), archiveExt) {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		entries = append(entries, info)
+	}
+	return entries, nil
+}
+
+// path returns the path of the archive with the digest provided.
+func (c *DiskCache) path(digest string) string {
+	return filepath.Join(c.dir, digest+archiveExt)
+}
+
+// ObjectStorageCache is a hub.ChartsCache implementation that keeps the
+// charts archives in an object storage bucket (S3 or GCS). Evicting archives
+// from the bucket is expected to be handled by the lifecycle rules configured
+// on it.
+type ObjectStorageCache struct {
+	baseURL *url.URL
+}
+
+// NewObjectStorageCache creates a new ObjectStorageCache instance. Archives
+// will be stored under the url provided (i.e. s3://bucket/charts).
+func NewObjectStorageCache(baseURL string) (*ObjectStorageCache, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return nil, errors.New("invalid charts cache object storage url")
+	}
+	if !repo.SchemeIsObjectStorage(u) {
+		return nil, fmt.Errorf("invalid charts cache object storage url: %w", repo.ErrSchemeNotSupported)
+	}
+	return &ObjectStorageCache{baseURL: u}, nil
+}
+
+// Get implements the ChartsCache interface.
+func (c *ObjectStorageCache) Get(ctx context.Context, digest string) ([]byte, error) {
+	if !digestRE.MatchString(digest) {
+		return nil, errInvalidDigest
+	}
+	obj, err := repo.GetObject(ctx, c.url(digest))
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+	return io.ReadAll(obj)
+}
+
+// Put implements the ChartsCache interface.
+func (c *ObjectStorageCache) Put(ctx context.Context, digest string, data []byte) error {
+	if !digestRE.MatchString(digest) {
+		return errInvalidDigest
+	}
+	return repo.PutObject(ctx, c.url(digest), data)
+}
+
+// url returns the url of the archive with the digest provided.
+func (c *ObjectStorageCache) url(digest string) *url.URL {
+	u := *c.baseURL
+	u.Path = path.Join("/", u.Path, digest+archiveExt)
+	return &u
+}
@@ -0,0 +1,155 @@
+package chartscache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	digest1 = strings.Repeat("1", 64)
+	digest2 = strings.Repeat("2", 64)
+	digest3 = strings.Repeat("3", 64)
+)
+
+func TestNewCache(t *testing.T) {
+	t.Run("charts cache disabled", func(t *testing.T) {
+		t.Parallel()
+		c, err := NewCache(viper.New())
+		assert.NoError(t, err)
+		assert.Nil(t, c)
+	})
+
+	t.Run("invalid charts cache store", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("tracker.chartsCache.store", "invalid")
+		_, err := NewCache(cfg)
+		assert.EqualError(t, err, "invalid charts cache store")
+	})
+
+	t.Run("disk cache", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("tracker.chartsCache.store", "disk")
+		cfg.Set("tracker.chartsCache.path", t.TempDir())
+		cfg.Set("tracker.chartsCache.maxSize", "10MB")
+		c, err := NewCache(cfg)
+		require.NoError(t, err)
+		require.IsType(t, &DiskCache{}, c)
+		assert.Equal(t, int64(10<<20), c.(*DiskCache).maxSize)
+	})
+
+	t.Run("object storage cache", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("tracker.chartsCache.store", "objectStorage")
+		cfg.Set("tracker.chartsCache.objectStorageURL", "s3://bucket/charts")
+		c, err := NewCache(cfg)
+		require.NoError(t, err)
+		assert.IsType(t, &ObjectStorageCache{}, c)
+	})
+}
+
+func TestDiskCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("path not provided", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewDiskCache("", 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid digest", func(t *testing.T) {
+		t.Parallel()
+		c, err := NewDiskCache(t.TempDir(), 0)
+		require.NoError(t, err)
+		_, err = c.Get(ctx, "../digest")
+		assert.Equal(t, errInvalidDigest, err)
+		err = c.Put(ctx, "../digest", []byte("data"))
+		assert.Equal(t, errInvalidDigest, err)
+	})
+
+	t.Run("archive not found", func(t *testing.T) {
+		t.Parallel()
+		c, err := NewDiskCache(t.TempDir(), 0)
+		require.NoError(t, err)
+		_, err = c.Get(ctx, digest1)
+		assert.Equal(t, hub.ErrNotFound, err)
+	})
+
+	t.Run("archive stored and read successfully", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		c, err := NewDiskCache(dir, 0)
+		require.NoError(t, err)
+		require.NoError(t, c.Put(ctx, digest1, []byte("data")))
+		data, err := c.Get(ctx, digest1)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data"), data)
+
+		// Size of the archives already stored is restored on start
+		c, err = NewDiskCache(dir, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), c.size)
+	})
+
+	t.Run("archive larger than the cache not stored", func(t *testing.T) {
+		t.Parallel()
+		c, err := NewDiskCache(t.TempDir(), 2)
+		require.NoError(t, err)
+		require.NoError(t, c.Put(ctx, digest1, []byte("data")))
+		_, err = c.Get(ctx, digest1)
+		assert.Equal(t, hub.ErrNotFound, err)
+	})
+
+	t.Run("least recently used archives evicted when the cache is full", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		c, err := NewDiskCache(dir, 10)
+		require.NoError(t, err)
+		require.NoError(t, c.Put(ctx, digest1, []byte("1111")))
+		require.NoError(t, c.Put(ctx, digest2, []byte("2222")))
+
+		// Make the first archive the most recently used one
+		past := time.Now().Add(-1 * time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, digest1+archiveExt), past, past))
+		require.NoError(t, os.Chtimes(filepath.Join(dir, digest2+archiveExt), past.Add(-1*time.Minute), past))
+		_, err = c.Get(ctx, digest1)
+		require.NoError(t, err)
+
+		require.NoError(t, c.Put(ctx, digest3, []byte("3333")))
+		_, err = c.Get(ctx, digest1)
+		assert.NoError(t, err)
+		_, err = c.Get(ctx, digest2)
+		assert.Equal(t, hub.ErrNotFound, err)
+		_, err = c.Get(ctx, digest3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(8), c.size)
+	})
+}
+
+func TestNewObjectStorageCache(t *testing.T) {
+	t.Run("invalid url", func(t *testing.T) {
+		t.Parallel()
+		for _, u := range []string{"", "s3://", "https://bucket/charts", "ftp://bucket/charts", "az://account/container"} {
+			_, err := NewObjectStorageCache(u)
+			assert.Error(t, err, u)
+		}
+	})
+
+	t.Run("valid url", func(t *testing.T) {
+		t.Parallel()
+		c, err := NewObjectStorageCache("s3://bucket/charts")
+		require.NoError(t, err)
+		assert.Equal(t, "s3://bucket/charts/"+digest1+archiveExt, c.url(digest1).String())
+	})
+}
@@ -0,0 +1,25 @@
+package chartscache
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// CacheMock is a mock implementation of the ChartsCache interface.
+type CacheMock struct {
+	mock.Mock
+}
+
+// Get implements the ChartsCache interface.
+func (m *CacheMock) Get(ctx context.Context, digest string) ([]byte, error) {
+	args := m.Called(ctx, digest)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Put implements the ChartsCache interface.
+func (m *CacheMock) Put(ctx context.Context, digest string, data []byte) error {
+	args := m.Called(ctx, digest, data)
+	return args.Error(0)
+}
@@ -0,0 +1,128 @@
+package demo
+
+import "github.com/artifacthub/hub/internal/hub"
+
+// user represents a demo user.
+type user struct {
+	alias     string
+	firstName string
+	lastName  string
+}
+
+// organization represents a demo organization. The first member provided
+// will be the owner of the organization.
+type organization struct {
+	name        string
+	displayName string
+	description string
+	members     []string
+}
+
+// repository represents a demo repository. Repositories belong to the user
+// or the organization provided.
+type repository struct {
+	name              string
+	displayName       string
+	kind              hub.RepositoryKind
+	user              string
+	org               string
+	verifiedPublisher bool
+	official          bool
+	packages          int
+}
+
+// users represents the users registered in the demo instance. The first one
+// is the demo user.
+var users = []*user{
+	{"demo", "Demo", "User"},
+	{"alice", "Alice", "Smith"},
+	{"bob", "Bob", "Jones"},
+}
+
+// organizations represents the organizations registered in the demo
+// instance.
+var organizations = []*organization{
+	{
+		name:        "acme",
+		displayName: "Acme",
+		description: "Acme builds all kinds of cloud native gadgets",
+		members:     []string{"demo", "alice"},
+	},
+	{
+		name:        "cloud-tools",
+		displayName: "Cloud Tools",
+		description: "Community maintained tools for Kubernetes clusters",
+		members:     []string{"bob", "demo"},
+	},
+}
+
+// repositories represents the repositories registered in the demo instance.
+var repositories = []*repository{
+	{
+		name:              "acme-charts",
+		displayName:       "Acme charts",
+		kind:              hub.Helm,
+		org:               "acme",
+		verifiedPublisher: true,
+		official:          true,
+		packages:          12,
+	},
+	{
+		name:              "acme-operators",
+		displayName:       "Acme operators",
+		kind:              hub.OLM,
+		org:               "acme",
+		verifiedPublisher: true,
+		packages:          4,
+	},
+	{
+		name:        "cloud-tools-charts",
+		displayName: "Cloud Tools charts",
+		kind:        hub.Helm,
+		org:         "cloud-tools",
+		packages:    8,
+	},
+	{
+		name:        "cloud-tools-policies",
+		displayName: "Cloud Tools policies",
+		kind:        hub.OPA,
+		org:         "cloud-tools",
+		packages:    3,
+	},
+	{
+		name:        "falco-rules",
+		displayName: "Falco rules",
+		kind:        hub.Falco,
+		user:        "alice",
+		packages:    3,
+	},
+	{
+		name:        "tekton-tasks",
+		displayName: "Tekton tasks",
+		kind:        hub.TektonTask,
+		user:        "bob",
+		packages:    4,
+	},
+	{
+		name:        "krew-plugins",
+		displayName: "Krew plugins",
+		kind:        hub.Krew,
+		user:        "demo",
+		packages:    3,
+	},
+}
+
+// Words used to generate the packages names, descriptions and keywords.
+var (
+	nouns = []string{
+		"gateway", "cache", "exporter", "operator", "scheduler", "proxy",
+		"registry", "monitor", "backup", "vault", "broker", "dashboard",
+		"ingress", "logger", "mesh", "queue",
+	}
+	adjectives = []string{
+		"fast", "secure", "tiny", "smart", "cloud", "edge", "simple", "global",
+	}
+	licenses = []string{
+		"Apache-2.0", "MIT", "BSD-3-Clause", "GPL-3.0",
+	}
+)
@@ -0,0 +1,285 @@
+package demo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// Database queries
+	addUserDBQ = `
+	insert into "user" (alias, email, email_verified, password, first_name, last_name)
+	values ($1, $2, true, $3, $4, $5)
+	returning user_id
+	`
+	addOrganizationDBQ       = `select add_organization($1::uuid, $2::jsonb)`
+	addOrganizationMemberDBQ = `
+	insert into user__organization (user_id, organization_id, confirmed, role)
+	select $1, organization_id, true, 'member'
+	from organization where name = $2
+	`
+	addRepositoryDBQ = `
+	insert into repository (
+		name,
+		display_name,
+		url,
+		repository_kind_id,
+		user_id,
+		organization_id,
+		verified_publisher,
+		official,
+		tracking_paused
+	) values (
+		$1,
+		$2,
+		$3,
+		$4,
+		nullif($5, '')::uuid,
+		(select organization_id from organization where name = nullif($6, '')),
+		$7,
+		$8,
+		true
+	)
+	returning repository_id
+	`
+	instanceIsEmptyDBQ = `
+	select not exists (select 1 from "user")
+	and not exists (select 1 from repository)
+	`
+	setPackagesStarsDBQ = `update package set stars = abs(hashtext(name)) % 500`
+
+	// randSeed represents the seed used to generate the demo data, so that
+	// the same data is generated on every run.
+	randSeed = 1
+
+	// maxVersions represents the maximum number of versions generated for
+	// each package.
+	maxVersions = 4
+)
+
+// ErrInstanceNotEmpty indicates that the instance cannot be seeded because it
+// already contains some users or repositories.
+var ErrInstanceNotEmpty = errors.New("instance not empty: demo data can only be seeded in a fresh instance")
+
+// Summary represents the demo data seeded.
+type Summary struct {
+	Users         int
+	Organizations int
+	Repositories  int
+	Packages      int
+	Versions      int
+}
+
+// Seeder populates a fresh instance with some representative generated data
+// (users, organizations, repositories and packages), so that realistic local
+// environments can be set up without reaching any external service. The
+// tracking of the repositories seeded is paused, as they do not exist.
+type Seeder struct {
+	db  hub.DB
+	pm  hub.PackageManager
+	is  img.Store
+	rnd *rand.Rand
+}
+
+// NewSeeder creates a new Seeder instance.
+func NewSeeder(db hub.DB, pm hub.PackageManager, is img.Store) *Seeder {
+	return &Seeder{
+		db:  db,
+		pm:  pm,
+		is:  is,
+		rnd: rand.New(rand.NewSource(randSeed)), // #nosec
+	}
+}
+
+// Seed populates the instance with the demo data. All users seeded will use
+// the password provided.
+func (s *Seeder) Seed(ctx context.Context, password string) (*Summary, error) {
+	// Validate input
+	if password == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "password not provided")
+	}
+
+	// Check the instance is empty
+	var empty bool
+	if err := s.db.QueryRow(ctx, instanceIsEmptyDBQ).Scan(&empty); err != nil {
+		return nil, err
+	}
+	if !empty {
+		return nil, ErrInstanceNotEmpty
+	}
+	summary := &Summary{}
+
+	// Users
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	usersIDs := make(map[string]string, len(users))
+	for _, u := range users {
+		var userID string
+		email := fmt.Sprintf("%s@artifacthub.demo", u.alias)
+		err := s.db.QueryRow(ctx, addUserDBQ, u.alias, email, string(hashedPassword), u.firstName, u.lastName).
+			Scan(&userID)
+		if err != nil {
+			return nil, fmt.Errorf("error adding user %s: %w", u.alias, err)
+		}
+		usersIDs[u.alias] = userID
+		summary.Users++
+	}
+
+	// Organizations
+	for _, o := range organizations {
+		orgJSON, _ := json.Marshal(map[string]string{
+			"name":         o.name,
+			"display_name": o.displayName,
+			"description":  o.description,
+			"home_url":     fmt.Sprintf("https://%s.example.com", o.name),
+		})
+		if _, err := s.db.Exec(ctx, addOrganizationDBQ, usersIDs[o.members[0]], orgJSON); err != nil {
+			return nil, fmt.Errorf("error adding organization %s: %w", o.name, err)
+		}
+		for _, member := range o.members[1:] {
+			if _, err := s.db.Exec(ctx, addOrganizationMemberDBQ, usersIDs[member], o.name); err != nil {
+				return nil, fmt.Errorf("error adding organization %s member: %w", o.name, err)
+			}
+		}
+		summary.Organizations++
+	}
+
+	// Repositories and packages
+	for _, r := range repositories {
+		var repositoryID string
+		err := s.db.QueryRow(ctx, addRepositoryDBQ,
+			r.name,
+			r.displayName,
+			fmt.Sprintf("https://%s.example.com", r.name),
+			int(r.kind),
+			usersIDs[r.user],
+			r.org,
+			r.verifiedPublisher,
+			r.official,
+		).Scan(&repositoryID)
+		if err != nil {
+			return nil, fmt.Errorf("error adding repository %s: %w", r.name, err)
+		}
+		summary.Repositories++
+
+		repo := &hub.Repository{
+			RepositoryID: repositoryID,
+			Kind:         r.kind,
+			Name:         r.name,
+		}
+		for _, pkgs := range s.generatePackages(ctx, repo, r.packages) {
+			for _, p := range pkgs {
+				if err := s.pm.Register(ctx, p); err != nil {
+					return nil, fmt.Errorf("error registering package %s: %w", p.Name, err)
+				}
+				summary.Versions++
+			}
+			summary.Packages++
+		}
+	}
+
+	// Give the packages some stars so that sorting by stars is meaningful
+	if _, err := s.db.Exec(ctx, setPackagesStarsDBQ); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// generatePackages generates the number of packages provided for the given
+// repository. Each package is returned as a list of versions, sorted from the
+// oldest to the newest one.
+func (s *Seeder) generatePackages(ctx context.Context, r *hub.Repository, n int) [][]*hub.Package {
+	kind := hub.GetKindName(r.Kind)
+	pkgs := make([][]*hub.Package, 0, n)
+	nounsIdx := s.rnd.Perm(len(nouns))
+	for i := 0; i < n && i < len(nouns); i++ {
+		noun := nouns[nounsIdx[i]]
+		adjective := adjectives[s.rnd.Intn(len(adjectives))]
+		name := fmt.Sprintf("%s-%s", adjective, noun)
+		description := fmt.Sprintf("A %s %s for your Kubernetes clusters", adjective, noun)
+		license := licenses[s.rnd.Intn(len(licenses))]
+		deprecated := s.rnd.Intn(10) == 0
+		logoImageID := s.generateLogo(ctx, r, name)
+
+		versions := s.rnd.Intn(maxVersions) + 1
+		pkgVersions := make([]*hub.Package, 0, versions)
+		for v := 0; v < versions; v++ {
+			version := fmt.Sprintf("1.%d.0", v)
+			ts := time.Now().AddDate(0, 0, -30*(versions-v)-s.rnd.Intn(30))
+			pkgVersions = append(pkgVersions, &hub.Package{
+				Name:        name,
+				DisplayName: strings.Title(strings.ReplaceAll(name, "-", " ")),
+				Description: description,
+				Keywords:    []string{noun, adjective, kind, "demo"},
+				HomeURL:     fmt.Sprintf("https://%s.example.com", name),
+				Readme:      generateReadme(name, description, kind),
+				Links: []*hub.Link{
+					{Name: "source", URL: fmt.Sprintf("https://git.example.com/%s/%s", r.Name, name)},
+				},
+				Version:     version,
+				AppVersion:  fmt.Sprintf("%d.%d.%d", s.rnd.Intn(3)+1, v, s.rnd.Intn(10)),
+				Digest:      fmt.Sprintf("%x", sha256.Sum256([]byte(r.Name+"/"+name+"@"+version))),
+				License:     license,
+				Deprecated:  deprecated && v == versions-1,
+				Signed:      s.rnd.Intn(2) == 0,
+				IsOperator:  r.Kind == hub.OLM,
+				LogoImageID: logoImageID,
+				Maintainers: []*hub.Maintainer{
+					{Name: "Demo Maintainer", Email: "maintainer@artifacthub.demo"},
+				},
+				TS:         ts.Unix(),
+				Repository: r,
+			})
+		}
+		pkgs = append(pkgs, pkgVersions)
+	}
+	return pkgs
+}
+
+// generateLogo generates an identicon for the package provided and stores it,
+// returning the id of the image. Packages are registered without logo if it
+// cannot be generated.
+func (s *Seeder) generateLogo(ctx context.Context, r *hub.Repository, pkgName string) string {
+	if s.is == nil {
+		return ""
+	}
+	data, err := img.GenerateIdenticon(r.RepositoryID + "/" + pkgName)
+	if err != nil {
+		return ""
+	}
+	imageID, err := s.is.SaveImage(ctx, data)
+	if err != nil {
+		return ""
+	}
+	return imageID
+}
+
+// generateReadme generates a readme file for the package provided.
+func generateReadme(name, description, kind string) string {
+	return fmt.Sprintf(`# %s
+
+%s.
+
+This package has been generated to populate a demo instance. It does not exist
+anywhere else, so it cannot be installed.
+
+## Features
+
+- Easy to configure
+- Production ready (not really)
+- Works with any %s compatible tool
+`, name, description, kind)
+}
@@ -0,0 +1,132 @@
+package demo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeed(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("password not provided", func(t *testing.T) {
+		t.Parallel()
+		s := NewSeeder(nil, nil, nil)
+		_, err := s.Seed(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("error checking if the instance is empty", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, instanceIsEmptyDBQ).Return(nil, tests.ErrFakeDB)
+		s := NewSeeder(db, nil, nil)
+
+		_, err := s.Seed(ctx, "password")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("instance not empty", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, instanceIsEmptyDBQ).Return(false, nil)
+		s := NewSeeder(db, nil, nil)
+
+		_, err := s.Seed(ctx, "password")
+		assert.Equal(t, ErrInstanceNotEmpty, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error registering package", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, instanceIsEmptyDBQ).Return(true, nil)
+		db.On("QueryRow", ctx, addUserDBQ, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return("userID", nil)
+		db.On("Exec", ctx, addOrganizationDBQ, mock.Anything, mock.Anything).Return(nil)
+		db.On("Exec", ctx, addOrganizationMemberDBQ, mock.Anything, mock.Anything).Return(nil)
+		db.On("QueryRow", ctx, addRepositoryDBQ,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		).Return("repositoryID", nil)
+		pm := &pkg.ManagerMock{}
+		pm.On("Register", ctx, mock.Anything).Return(tests.ErrFakeDB)
+		s := NewSeeder(db, pm, nil)
+
+		_, err := s.Seed(ctx, "password")
+		assert.True(t, errors.Is(err, tests.ErrFakeDB))
+		db.AssertExpectations(t)
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("demo data seeded successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, instanceIsEmptyDBQ).Return(true, nil)
+		db.On("QueryRow", ctx, addUserDBQ, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return("userID", nil)
+		db.On("Exec", ctx, addOrganizationDBQ, mock.Anything, mock.Anything).Return(nil)
+		db.On("Exec", ctx, addOrganizationMemberDBQ, mock.Anything, mock.Anything).Return(nil)
+		db.On("QueryRow", ctx, addRepositoryDBQ,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		).Return("repositoryID", nil)
+		db.On("Exec", ctx, setPackagesStarsDBQ).Return(nil)
+		pm := &pkg.ManagerMock{}
+		pm.On("Register", ctx, mock.Anything).Return(nil)
+		is := &img.StoreMock{}
+		is.On("SaveImage", ctx, mock.Anything).Return("imageID", nil)
+		s := NewSeeder(db, pm, is)
+
+		summary, err := s.Seed(ctx, "password")
+		require.NoError(t, err)
+		assert.Equal(t, len(users), summary.Users)
+		assert.Equal(t, len(organizations), summary.Organizations)
+		assert.Equal(t, len(repositories), summary.Repositories)
+		expectedPackages := 0
+		for _, r := range repositories {
+			expectedPackages += r.packages
+		}
+		assert.Equal(t, expectedPackages, summary.Packages)
+		assert.GreaterOrEqual(t, summary.Versions, summary.Packages)
+		assert.LessOrEqual(t, summary.Versions, summary.Packages*maxVersions)
+		pm.AssertNumberOfCalls(t, "Register", summary.Versions)
+		is.AssertNumberOfCalls(t, "SaveImage", summary.Packages)
+		db.AssertExpectations(t)
+		pm.AssertExpectations(t)
+		is.AssertExpectations(t)
+	})
+}
+
+func TestGeneratePackages(t *testing.T) {
+	ctx := context.Background()
+	r := &hub.Repository{RepositoryID: "repositoryID", Name: "repo1", Kind: hub.Helm}
+
+	// The same packages are generated on every run
+	pkgs1 := NewSeeder(nil, nil, nil).generatePackages(ctx, r, 5)
+	pkgs2 := NewSeeder(nil, nil, nil).generatePackages(ctx, r, 5)
+	require.Len(t, pkgs1, 5)
+	require.Len(t, pkgs2, 5)
+	names := make(map[string]struct{})
+	for i := range pkgs1 {
+		assert.Equal(t, pkgs1[i][0].Name, pkgs2[i][0].Name)
+		assert.Equal(t, len(pkgs1[i]), len(pkgs2[i]))
+		names[pkgs1[i][0].Name] = struct{}{}
+		for _, p := range pkgs1[i] {
+			assert.Equal(t, pkgs1[i][0].Name, p.Name)
+			assert.Equal(t, r, p.Repository)
+		}
+	}
+
+	// Packages names are unique in the repository
+	assert.Len(t, names, 5)
+}
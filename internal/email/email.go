@@ -21,11 +21,14 @@ var BaseTmpl string
 // set up.
 var ErrSenderNotAvailable = errors.New("email sender not available")
 
-// Data describes the different pieces of data used to compose an email.
+// Data describes the different pieces of data used to compose an email. Body
+// contains the html version of the email and Text its plain text alternative,
+// which is only included in the email when provided.
 type Data struct {
 	To      string
 	Subject string
 	Body    []byte
+	Text    []byte
 }
 
 // Sender is in charge of sending emails.
@@ -80,5 +83,10 @@ func (s *Sender) SendEmail(d *Data) error {
 	if _, err := email.HTML().Write(d.Body); err != nil {
 		return err
 	}
+	if d.Text != nil {
+		if _, err := email.Plain().Write(d.Text); err != nil {
+			return err
+		}
+	}
 	return email.Send()
 }
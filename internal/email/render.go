@@ -0,0 +1,25 @@
+package email
+
+import (
+	"bytes"
+	"io"
+)
+
+// Template represents an email template. Both html/template and text/template
+// templates satisfy this interface.
+type Template interface {
+	Execute(wr io.Writer, data interface{}) error
+}
+
+// Render renders the html and plain text versions of an email by executing
+// the templates provided with the data given.
+func Render(bodyTmpl, textTmpl Template, data interface{}) (body, text []byte, err error) {
+	var bodyBuf, textBuf bytes.Buffer
+	if err := bodyTmpl.Execute(&bodyBuf, data); err != nil {
+		return nil, nil, err
+	}
+	if err := textTmpl.Execute(&textBuf, data); err != nil {
+		return nil, nil, err
+	}
+	return bodyBuf.Bytes(), textBuf.Bytes(), nil
+}
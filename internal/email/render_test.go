@@ -0,0 +1,46 @@
+package email
+
+import (
+	htmltemplate "html/template"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	data := map[string]interface{}{
+		"Name": "Tom & Jerry",
+	}
+
+	t.Run("error executing html template", func(t *testing.T) {
+		t.Parallel()
+		bodyTmpl := htmltemplate.Must(htmltemplate.New("").Parse(`<p>{{ .Name.Invalid }}</p>`))
+		textTmpl := template.Must(template.New("").Parse(`{{ .Name }}`))
+		body, text, err := Render(bodyTmpl, textTmpl, data)
+		assert.Error(t, err)
+		assert.Nil(t, body)
+		assert.Nil(t, text)
+	})
+
+	t.Run("error executing text template", func(t *testing.T) {
+		t.Parallel()
+		bodyTmpl := htmltemplate.Must(htmltemplate.New("").Parse(`<p>{{ .Name }}</p>`))
+		textTmpl := template.Must(template.New("").Parse(`{{ .Name.Invalid }}`))
+		body, text, err := Render(bodyTmpl, textTmpl, data)
+		assert.Error(t, err)
+		assert.Nil(t, body)
+		assert.Nil(t, text)
+	})
+
+	t.Run("email rendered successfully", func(t *testing.T) {
+		t.Parallel()
+		bodyTmpl := htmltemplate.Must(htmltemplate.New("").Parse(`<p>{{ .Name }}</p>`))
+		textTmpl := template.Must(template.New("").Parse(`Name: {{ .Name }}`))
+		body, text, err := Render(bodyTmpl, textTmpl, data)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("<p>Tom &amp; Jerry</p>"), body)
+		assert.Equal(t, []byte("Name: Tom & Jerry"), text)
+	})
+}
@@ -0,0 +1,18 @@
+package emailspool
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// SpoolMock is a mock implementation of the EmailSpoolManager interface.
+type SpoolMock struct {
+	mock.Mock
+}
+
+// Flush implements the EmailSpoolManager interface.
+func (m *SpoolMock) Flush(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,271 @@
+package emailspool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/email"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Database queries
+	deleteSpooledEmailDBQ = `delete from email_spool where email_spool_id = $1`
+	flushEmailSpoolDBQ    = `select flush_email_spool($1::uuid)`
+	getEmailSpoolStatsDBQ = `
+	select
+		count(*) filter (where attempts < $1),
+		count(*) filter (where attempts >= $1)
+	from email_spool
+	`
+	getPendingSpooledEmailDBQ = `
+	select email_spool_id, recipient, subject, body, text_body, attempts
+	from email_spool
+	where next_attempt_at <= current_timestamp
+	and attempts < $1
+	order by next_attempt_at asc
+	limit 1
+	for update skip locked
+	`
+	spoolEmailDBQ = `
+	insert into email_spool (recipient, subject, body, text_body, attempts, last_error, next_attempt_at)
+	values ($1, $2, $3, $4, 1, $5, current_timestamp + make_interval(secs => $6))
+	`
+	updateSpooledEmailDBQ = `
+	update email_spool set
+		attempts = attempts + 1,
+		last_error = $2,
+		next_attempt_at = current_timestamp + make_interval(secs => $3)
+	where email_spool_id = $1
+	`
+
+	// defaultMaxAttempts represents the default number of delivery attempts
+	// of a spooled email before giving up on it.
+	defaultMaxAttempts = 10
+
+	// minBackoff and maxBackoff represent the bounds of the delay applied
+	// between delivery attempts of a spooled email.
+	minBackoff = 1 * time.Minute
+	maxBackoff = 1 * time.Hour
+
+	pauseOnEmptyQueue = 30 * time.Second
+	pauseOnError      = 10 * time.Second
+)
+
+var (
+	pendingGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "email_spool_pending",
+		Help: "Number of spooled emails pending delivery.",
+	})
+	deadGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "email_spool_dead",
+		Help: "Number of spooled emails that exhausted their delivery attempts.",
+	})
+	enqueuedCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "email_spool_enqueued_total",
+		Help: "Total number of emails spooled after a failed delivery.",
+	})
+	sentCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "email_spool_sent_total",
+		Help: "Total number of spooled emails delivered successfully.",
+	})
+	registerMetricsOnce sync.Once
+)
+
+// Spool is an email sender that wraps another one, persisting in the
+// database the emails that could not be delivered so that they can be
+// retried later instead of being lost.
+type Spool struct {
+	db          hub.DB
+	es          hub.EmailSender
+	maxAttempts int
+}
+
+// NewSpool creates a new Spool instance.
+func NewSpool(cfg *viper.Viper, db hub.DB, es hub.EmailSender) *Spool {
+	registerMetricsOnce.Do(func() {
+		prometheus.MustRegister(pendingGauge, deadGauge, enqueuedCounter, sentCounter)
+	})
+	maxAttempts := cfg.GetInt("email.spool.maxAttempts")
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	return &Spool{
+		db:          db,
+		es:          es,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// SendEmail implements the hub.EmailSender interface. It tries to deliver
+// the email using the underlying sender, spooling it for a later retry when
+// that fails.
+func (s *Spool) SendEmail(d *email.Data) error {
+	err := s.es.SendEmail(d)
+	if err == nil {
+		return nil
+	}
+
+	// Spool email so that it's retried later
+	var text *string
+	if d.Text != nil {
+		t := string(d.Text)
+		text = &t
+	}
+	_, dbErr := s.db.Exec(
+		context.Background(),
+		spoolEmailDBQ,
+		d.To,
+		d.Subject,
+		string(d.Body),
+		text,
+		err.Error(),
+		getBackoff(1).Seconds(),
+	)
+	if dbErr != nil {
+		log.Error().Err(dbErr).Msg("error spooling email")
+		return err
+	}
+	enqueuedCounter.Inc()
+	log.Warn().Err(err).Msg("error sending email, spooled for later delivery")
+	return nil
+}
+
+// Flush makes all the spooled emails (including the ones that exhausted
+// their delivery attempts) eligible for delivery right away, returning the
+// number of emails flushed. Only site admins are allowed to flush the spool.
+func (s *Spool) Flush(ctx context.Context) (int, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	var flushed int
+	err := s.db.QueryRow(ctx, flushEmailSpoolDBQ, userID).Scan(&flushed)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return 0, hub.ErrInsufficientPrivilege
+		}
+		return 0, err
+	}
+	return flushed, nil
+}
+
+// Run is the main loop of the spool worker. It calls processEmail
+// periodically until it's asked to stop via the context provided.
+func (s *Spool) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		err := s.processEmail(ctx)
+		s.updateMetrics(ctx)
+		switch {
+		case err == nil:
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+		case errors.Is(err, pgx.ErrNoRows):
+			select {
+			case <-time.After(pauseOnEmptyQueue):
+			case <-ctx.Done():
+				return
+			}
+		default:
+			select {
+			case <-time.After(pauseOnError):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// processEmail gets a spooled email due for delivery from the database and
+// tries to deliver it. When the delivery fails, the email is rescheduled
+// applying an exponential backoff and the error is returned, so that the
+// worker pauses while the email backend is unavailable.
+func (s *Spool) processEmail(ctx context.Context) error {
+	var sendErr error
+	err := util.DBTransact(ctx, s.db, func(tx pgx.Tx) error {
+		// Get spooled email due for delivery
+		var (
+			emailSpoolID string
+			d            email.Data
+			body         string
+			text         *string
+			attempts     int
+		)
+		err := tx.QueryRow(ctx, getPendingSpooledEmailDBQ, s.maxAttempts).Scan(
+			&emailSpoolID,
+			&d.To,
+			&d.Subject,
+			&body,
+			&text,
+			&attempts,
+		)
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				log.Error().Err(err).Msg("processEmail: error getting spooled email")
+			}
+			return err
+		}
+		d.Body = []byte(body)
+		if text != nil {
+			d.Text = []byte(*text)
+		}
+
+		// Deliver email, rescheduling it if something goes wrong
+		if sendErr = s.es.SendEmail(&d); sendErr != nil {
+			log.Warn().Err(sendErr).Str("id", emailSpoolID).Msg("processEmail: error sending spooled email")
+			_, err = tx.Exec(ctx, updateSpooledEmailDBQ, emailSpoolID, sendErr.Error(), getBackoff(attempts+1).Seconds())
+			if err != nil {
+				log.Error().Err(err).Msg("processEmail: error rescheduling spooled email")
+			}
+			return err
+		}
+		_, err = tx.Exec(ctx, deleteSpooledEmailDBQ, emailSpoolID)
+		if err != nil {
+			log.Error().Err(err).Msg("processEmail: error deleting spooled email")
+			return err
+		}
+		sentCounter.Inc()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// updateMetrics refreshes the spool gauges using the current content of the
+// spool.
+func (s *Spool) updateMetrics(ctx context.Context) {
+	var pending, dead int
+	if err := s.db.QueryRow(ctx, getEmailSpoolStatsDBQ, s.maxAttempts).Scan(&pending, &dead); err != nil {
+		return
+	}
+	pendingGauge.Set(float64(pending))
+	deadGauge.Set(float64(dead))
+}
+
+// getBackoff returns the delay to apply before the next delivery attempt of
+// an email that has already been attempted the number of times provided.
+func getBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 7 {
+		return maxBackoff
+	}
+	backoff := minBackoff << (attempts - 1)
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,210 @@
+package emailspool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/email"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const emailSpoolID = "00000000-0000-0000-0000-000000000001"
+
+func TestSendEmail(t *testing.T) {
+	d := &email.Data{
+		To:      "user1@email.com",
+		Subject: "subject",
+		Body:    []byte("body"),
+	}
+
+	t.Run("email sent successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		es := &email.SenderMock{}
+		es.On("SendEmail", d).Return(nil)
+		s := NewSpool(viper.New(), db, es)
+
+		err := s.SendEmail(d)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("error sending email, email spooled", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", mock.Anything, spoolEmailDBQ,
+			"user1@email.com", "subject", "body", (*string)(nil), email.ErrFakeSenderFailure.Error(), float64(60),
+		).Return(nil)
+		es := &email.SenderMock{}
+		es.On("SendEmail", d).Return(email.ErrFakeSenderFailure)
+		s := NewSpool(viper.New(), db, es)
+
+		err := s.SendEmail(d)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("error sending email and spooling it", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", mock.Anything, spoolEmailDBQ,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		).Return(tests.ErrFakeDB)
+		es := &email.SenderMock{}
+		es.On("SendEmail", d).Return(email.ErrFakeSenderFailure)
+		s := NewSpool(viper.New(), db, es)
+
+		err := s.SendEmail(d)
+		assert.Equal(t, email.ErrFakeSenderFailure, err)
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+}
+
+func TestFlush(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		s := NewSpool(viper.New(), nil, nil)
+		assert.Panics(t, func() {
+			_, _ = s.Flush(context.Background())
+		})
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, flushEmailSpoolDBQ, "userID").Return(0, tc.dbErr)
+				s := NewSpool(viper.New(), db, nil)
+
+				_, err := s.Flush(ctx)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("spool flushed successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, flushEmailSpoolDBQ, "userID").Return(3, nil)
+		s := NewSpool(viper.New(), db, nil)
+
+		flushed, err := s.Flush(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, flushed)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestProcessEmail(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	cfg.Set("email.spool.maxAttempts", 5)
+	d := &email.Data{
+		To:      "user1@email.com",
+		Subject: "subject",
+		Body:    []byte("body"),
+	}
+	spooledEmail := []interface{}{emailSpoolID, "user1@email.com", "subject", "body", nil, 2}
+
+	t.Run("no spooled emails due for delivery", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, getPendingSpooledEmailDBQ, 5).Return(nil, pgx.ErrNoRows)
+		tx.On("Rollback", ctx).Return(nil)
+		s := NewSpool(cfg, db, nil)
+
+		err := s.processEmail(ctx)
+		assert.True(t, errors.Is(err, pgx.ErrNoRows))
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("error sending email, email rescheduled", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		es := &email.SenderMock{}
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, getPendingSpooledEmailDBQ, 5).Return(spooledEmail, nil)
+		es.On("SendEmail", d).Return(email.ErrFakeSenderFailure)
+		tx.On("Exec", ctx, updateSpooledEmailDBQ,
+			emailSpoolID, email.ErrFakeSenderFailure.Error(), float64(240),
+		).Return(nil)
+		tx.On("Commit", ctx).Return(nil)
+		s := NewSpool(cfg, db, es)
+
+		err := s.processEmail(ctx)
+		assert.Equal(t, email.ErrFakeSenderFailure, err)
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("email sent successfully, email removed from spool", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		es := &email.SenderMock{}
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, getPendingSpooledEmailDBQ, 5).Return(spooledEmail, nil)
+		es.On("SendEmail", d).Return(nil)
+		tx.On("Exec", ctx, deleteSpooledEmailDBQ, emailSpoolID).Return(nil)
+		tx.On("Commit", ctx).Return(nil)
+		s := NewSpool(cfg, db, es)
+
+		err := s.processEmail(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+}
+
+func TestGetBackoff(t *testing.T) {
+	testCases := []struct {
+		attempts        int
+		expectedBackoff time.Duration
+	}{
+		{0, 1 * time.Minute},
+		{1, 1 * time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{6, 32 * time.Minute},
+		{7, 1 * time.Hour},
+		{100, 1 * time.Hour},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedBackoff, getBackoff(tc.attempts))
+	}
+}
@@ -0,0 +1,41 @@
+package emailspool
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling email
+// spool operations.
+type Handlers struct {
+	emailSpoolManager hub.EmailSpoolManager
+	logger            zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(emailSpoolManager hub.EmailSpoolManager) *Handlers {
+	return &Handlers{
+		emailSpoolManager: emailSpoolManager,
+		logger:            log.With().Str("handlers", "emailspool").Logger(),
+	}
+}
+
+// Flush is an http handler that makes all the spooled emails eligible for
+// delivery right away.
+func (h *Handlers) Flush(w http.ResponseWriter, r *http.Request) {
+	flushed, err := h.emailSpoolManager.Flush(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Flush").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(map[string]int{
+		"flushed": flushed,
+	})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
@@ -0,0 +1,93 @@
+package emailspool
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/emailspool"
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestFlush(t *testing.T) {
+	t.Run("error flushing email spool", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.esm.On("Flush", r.Context()).Return(0, tc.err)
+				hw.h.Flush(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.esm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("email spool flushed successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.esm.On("Flush", r.Context()).Return(3, nil)
+		hw.h.Flush(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.JSONEq(t, `{"flushed": 3}`, string(data))
+		hw.esm.AssertExpectations(t)
+	})
+}
+
+type handlersWrapper struct {
+	esm *emailspool.SpoolMock
+	h   *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	esm := &emailspool.SpoolMock{}
+
+	return &handlersWrapper{
+		esm: esm,
+		h:   NewHandlers(esm),
+	}
+}
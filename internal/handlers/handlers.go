@@ -10,10 +10,16 @@ import (
 	"time"
 
 	"github.com/artifacthub/hub/internal/handlers/apikey"
+	"github.com/artifacthub/hub/internal/handlers/emailspool"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/handlers/imageupload"
+	"github.com/artifacthub/hub/internal/handlers/legalhold"
+	"github.com/artifacthub/hub/internal/handlers/moderator"
 	"github.com/artifacthub/hub/internal/handlers/org"
 	"github.com/artifacthub/hub/internal/handlers/pkg"
+	"github.com/artifacthub/hub/internal/handlers/promotion"
 	"github.com/artifacthub/hub/internal/handlers/repo"
+	"github.com/artifacthub/hub/internal/handlers/serviceaccount"
 	"github.com/artifacthub/hub/internal/handlers/static"
 	"github.com/artifacthub/hub/internal/handlers/stats"
 	"github.com/artifacthub/hub/internal/handlers/subscription"
@@ -21,6 +27,7 @@ import (
 	"github.com/artifacthub/hub/internal/handlers/webhook"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/util"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/gorilla/csrf"
@@ -34,21 +41,47 @@ import (
 
 const csrfHeader = "X-CSRF-Token"
 
-var xForwardedFor = http.CanonicalHeaderKey("X-Forwarded-For")
+var (
+	xForwardedFor = http.CanonicalHeaderKey("X-Forwarded-For")
+
+	// anonymousAccessAllowList contains the paths that can be accessed
+	// anonymously when the login required mode is enabled. Entries ending
+	// with a slash match any path starting with them.
+	anonymousAccessAllowList = []string{
+		"/",
+		"/api/v1/csrf",
+		"/api/v1/repositories/publish/",
+		"/api/v1/repositories/webhook/",
+		"/api/v1/users",
+		"/api/v1/users/",
+		"/artifacthub-widget.js",
+		"/docs/",
+		"/health",
+		"/manifest.json",
+		"/oauth/",
+		"/static/",
+	}
+)
 
 // Services is a wrapper around several internal services used by the handlers.
 type Services struct {
-	OrganizationManager hub.OrganizationManager
-	UserManager         hub.UserManager
-	RepositoryManager   hub.RepositoryManager
-	PackageManager      hub.PackageManager
-	SubscriptionManager hub.SubscriptionManager
-	WebhookManager      hub.WebhookManager
-	APIKeyManager       hub.APIKeyManager
-	StatsManager        hub.StatsManager
-	ImageStore          img.Store
-	Authorizer          hub.Authorizer
-	HTTPClient          hub.HTTPClient
+	OrganizationManager    hub.OrganizationManager
+	UserManager            hub.UserManager
+	RepositoryManager      hub.RepositoryManager
+	PackageManager         hub.PackageManager
+	SubscriptionManager    hub.SubscriptionManager
+	WebhookManager         hub.WebhookManager
+	APIKeyManager          hub.APIKeyManager
+	ServiceAccountManager  hub.ServiceAccountManager
+	PromotedPackageManager hub.PromotedPackageManager
+	LegalHoldManager       hub.LegalHoldManager
+	EmailSpoolManager      hub.EmailSpoolManager
+	ModeratorManager       hub.ModeratorManager
+	StatsManager           hub.StatsManager
+	ImageUploadManager     hub.ImageUploadManager
+	ImageStore             img.Store
+	Authorizer             hub.Authorizer
+	HTTPClient             hub.HTTPClient
 }
 
 // Metrics groups some metrics collected from a Handlers instance.
@@ -65,15 +98,24 @@ type Handlers struct {
 	logger  zerolog.Logger
 	Router  http.Handler
 
-	Organizations *org.Handlers
-	Users         *user.Handlers
-	Packages      *pkg.Handlers
-	Repositories  *repo.Handlers
-	Subscriptions *subscription.Handlers
-	Webhooks      *webhook.Handlers
-	APIKeys       *apikey.Handlers
-	Static        *static.Handlers
-	Stats         *stats.Handlers
+	trustedProxies *util.TrustedProxies
+	cookieCfg      *helpers.CookieConfig
+
+	Organizations    *org.Handlers
+	Users            *user.Handlers
+	Packages         *pkg.Handlers
+	Repositories     *repo.Handlers
+	Subscriptions    *subscription.Handlers
+	Webhooks         *webhook.Handlers
+	APIKeys          *apikey.Handlers
+	ServiceAccounts  *serviceaccount.Handlers
+	PromotedPackages *promotion.Handlers
+	LegalHolds       *legalhold.Handlers
+	EmailSpool       *emailspool.Handlers
+	Moderators       *moderator.Handlers
+	ImageUploads     *imageupload.Handlers
+	Static           *static.Handlers
+	Stats            *stats.Handlers
 }
 
 // Setup creates a new Handlers instance.
@@ -82,21 +124,37 @@ func Setup(ctx context.Context, cfg *viper.Viper, svc *Services) (*Handlers, err
 	if err != nil {
 		return nil, err
 	}
+	trustedProxies, err := util.NewTrustedProxies(cfg.GetStringSlice("server.trustedProxies"))
+	if err != nil {
+		return nil, err
+	}
+	cookieCfg, err := helpers.GetCookieConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cookie configuration: %w", err)
+	}
 	h := &Handlers{
-		cfg:     cfg,
-		svc:     svc,
-		metrics: setupMetrics(),
-		logger:  log.With().Str("handlers", "root").Logger(),
-
-		Organizations: org.NewHandlers(svc.OrganizationManager, svc.Authorizer, cfg),
-		Users:         userHandlers,
-		Repositories:  repo.NewHandlers(cfg, svc.RepositoryManager),
-		Packages:      pkg.NewHandlers(svc.PackageManager, svc.RepositoryManager, cfg, svc.HTTPClient),
-		Subscriptions: subscription.NewHandlers(svc.SubscriptionManager),
-		Webhooks:      webhook.NewHandlers(svc.WebhookManager, svc.HTTPClient),
-		APIKeys:       apikey.NewHandlers(svc.APIKeyManager),
-		Static:        static.NewHandlers(cfg, svc.ImageStore),
-		Stats:         stats.NewHandlers(svc.StatsManager),
+		cfg:            cfg,
+		svc:            svc,
+		metrics:        setupMetrics(),
+		logger:         log.With().Str("handlers", "root").Logger(),
+		trustedProxies: trustedProxies,
+		cookieCfg:      cookieCfg,
+
+		Organizations:    org.NewHandlers(svc.OrganizationManager, svc.Authorizer, cfg),
+		Users:            userHandlers,
+		Repositories:     repo.NewHandlers(cfg, svc.RepositoryManager),
+		Packages:         pkg.NewHandlers(svc.PackageManager, svc.RepositoryManager, cfg, svc.HTTPClient),
+		Subscriptions:    subscription.NewHandlers(svc.SubscriptionManager),
+		Webhooks:         webhook.NewHandlers(svc.WebhookManager, svc.HTTPClient),
+		APIKeys:          apikey.NewHandlers(svc.APIKeyManager),
+		ServiceAccounts:  serviceaccount.NewHandlers(svc.ServiceAccountManager),
+		PromotedPackages: promotion.NewHandlers(svc.PromotedPackageManager),
+		LegalHolds:       legalhold.NewHandlers(svc.LegalHoldManager),
+		EmailSpool:       emailspool.NewHandlers(svc.EmailSpoolManager),
+		Moderators:       moderator.NewHandlers(svc.ModeratorManager),
+		ImageUploads:     imageupload.NewHandlers(svc.ImageUploadManager),
+		Static:           static.NewHandlers(cfg, svc.ImageStore),
+		Stats:            stats.NewHandlers(svc.StatsManager),
 	}
 	h.setupRouter()
 	return h, nil
@@ -130,7 +188,7 @@ func (h *Handlers) setupRouter() {
 		AllowCredentials: false,
 	}).Handler
 	r.Use(middleware.Recoverer)
-	r.Use(realIP(h.cfg.GetInt("server.xffIndex")))
+	r.Use(realIP(h.cfg.GetInt("server.xffIndex"), h.trustedProxies))
 	r.Use(logger)
 	r.Use(h.MetricsCollector)
 	r.Use(secure.New(secure.Options{
@@ -142,18 +200,16 @@ func (h *Handlers) setupRouter() {
 	if h.cfg.GetBool("server.basicAuth.enabled") {
 		r.Use(h.Users.BasicAuth)
 	}
+	if h.cfg.GetBool("server.loginRequired") {
+		r.Use(h.loginRequired)
+	}
 	r.NotFound(h.Static.Index)
 
 	// API
 	r.Route("/api/v1", func(r chi.Router) {
 		// CSRF
 		r.Use(csrfSkipper)
-		r.Use(csrf.Protect(
-			[]byte(h.cfg.GetString("server.csrf.authKey")),
-			csrf.Secure(h.cfg.GetBool("server.csrf.secure")),
-			csrf.Path("/api/v1"),
-			csrf.CookieName("csrf"),
-		))
+		r.Use(h.cookieCfg.CSRFProtect([]byte(h.cfg.GetString("server.csrf.authKey")), "csrf", "/api/v1"))
 		r.Get("/csrf", func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Cache-Control", "no-store")
 			w.Header().Set(csrfHeader, csrf.Token(r))
@@ -164,11 +220,17 @@ func (h *Handlers) setupRouter() {
 			r.Post("/", h.Users.RegisterUser)
 			r.Post("/check-password-strength", h.Users.CheckPasswordStrength)
 			r.Post("/login", h.Users.Login)
+			r.Post("/login-code", h.Users.RegisterLoginCode)
+			r.Post("/login-with-code", h.Users.LoginWithCode)
 			r.Put("/approve-session", h.Users.ApproveSession)
 			r.Post("/password-reset-code", h.Users.RegisterPasswordResetCode)
 			r.Put("/reset-password", h.Users.ResetPassword)
 			r.Post("/verify-email", h.Users.VerifyEmail)
 			r.Post("/verify-password-reset-code", h.Users.VerifyPasswordResetCode)
+			r.Post("/webauthn/login/begin", h.Users.BeginWebAuthnLogin)
+			r.Post("/webauthn/login/finish", h.Users.FinishWebAuthnLogin)
+			r.Post("/webauthn/approve-session/begin", h.Users.BeginWebAuthnSessionApproval)
+			r.Put("/webauthn/approve-session/finish", h.Users.ApproveSessionWithWebAuthn)
 			r.Group(func(r chi.Router) {
 				r.Use(h.Users.RequireLogin)
 				r.Delete("/", h.Users.DeleteUser)
@@ -182,9 +244,37 @@ func (h *Handlers) setupRouter() {
 				r.Get("/profile", h.Users.GetProfile)
 				r.Put("/profile", h.Users.UpdateProfile)
 				r.Put("/password", h.Users.UpdatePassword)
+				r.Route("/webauthn/credentials", func(r chi.Router) {
+					r.Get("/", h.Users.GetWebAuthnCredentials)
+					r.Post("/begin", h.Users.BeginWebAuthnRegistration)
+					r.Post("/finish", h.Users.FinishWebAuthnRegistration)
+					r.Delete("/{credentialID}", h.Users.DeleteWebAuthnCredential)
+				})
 			})
 		})
 
+		// Legal holds
+		r.Route("/legal-holds", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin)
+			r.Get("/", h.LegalHolds.Get)
+			r.Post("/", h.LegalHolds.Add)
+			r.Delete("/{legalHoldID}", h.LegalHolds.Release)
+		})
+
+		// Email spool
+		r.Route("/email-spool", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin)
+			r.Post("/flush", h.EmailSpool.Flush)
+		})
+
+		// Moderators
+		r.Route("/moderators", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin)
+			r.Get("/", h.Moderators.Get)
+			r.Put("/{userAlias}", h.Moderators.Add)
+			r.Delete("/{userAlias}", h.Moderators.Remove)
+		})
+
 		// Organizations
 		r.Route("/orgs", func(r chi.Router) {
 			r.Group(func(r chi.Router) {
@@ -203,10 +293,38 @@ func (h *Handlers) setupRouter() {
 						r.Put("/", h.Organizations.UpdateAuthorizationPolicy)
 					})
 					r.Get("/accept-invitation", h.Organizations.ConfirmMembership)
+					r.Route("/idp-groups", func(r chi.Router) {
+						r.Get("/", h.Organizations.GetIdPGroups)
+						r.Put("/", h.Organizations.UpdateIdPGroups)
+					})
+					r.Route("/invitations", func(r chi.Router) {
+						r.Get("/", h.Organizations.GetInvitations)
+						r.Post("/", h.Organizations.AddMembers)
+					})
+					r.Route("/invitation/{userAlias}", func(r chi.Router) {
+						r.Delete("/", h.Organizations.CancelInvitation)
+						r.Post("/resend", h.Organizations.ResendInvitation)
+					})
+					r.Route("/links", func(r chi.Router) {
+						r.Get("/", h.Organizations.GetLinks)
+						r.Put("/", h.Organizations.UpdateLinks)
+						r.Put("/verify", h.Organizations.VerifyLinks)
+					})
 					r.Get("/members", h.Organizations.GetMembers)
+					r.Get("/quotas", h.Organizations.GetQuotas)
 					r.Route("/member/{userAlias}", func(r chi.Router) {
 						r.Post("/", h.Organizations.AddMember)
 						r.Delete("/", h.Organizations.DeleteMember)
+						r.Put("/role", h.Organizations.UpdateMemberRole)
+					})
+					r.Route("/service-accounts", func(r chi.Router) {
+						r.Get("/", h.ServiceAccounts.GetByOrg)
+						r.Post("/", h.ServiceAccounts.Add)
+						r.Route("/{serviceAccountID}", func(r chi.Router) {
+							r.Delete("/", h.ServiceAccounts.Delete)
+							r.Post("/api-keys", h.ServiceAccounts.AddAPIKey)
+							r.Delete("/api-keys/{apiKeyID}", h.ServiceAccounts.DeleteAPIKey)
+						})
 					})
 					r.Get("/user-allowed-actions", h.Organizations.GetUserAllowedActions)
 				})
@@ -215,48 +333,113 @@ func (h *Handlers) setupRouter() {
 
 		// Repositories
 		r.Route("/repositories", func(r chi.Router) {
-			r.Use(h.Users.RequireLogin)
-			r.Get("/search", h.Repositories.Search)
-			r.Route("/user", func(r chi.Router) {
-				r.Post("/", h.Repositories.Add)
-				r.Route("/{repoName}", func(r chi.Router) {
-					r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
-					r.Put("/transfer", h.Repositories.Transfer)
-					r.Put("/", h.Repositories.Update)
-					r.Delete("/", h.Repositories.Delete)
-				})
+			r.Head("/{repoName}", h.Repositories.Head)
+			r.Post("/webhook/{repoName}", h.Repositories.TrackingWebhook)
+			r.Route("/publish/{repoName}", func(r chi.Router) {
+				r.Use(h.Repositories.RequirePublishToken)
+				r.Put("/", h.Repositories.UpdatePublishMetadata)
+				r.Put("/tracking", h.Repositories.TriggerTracking)
+				r.Put("/packages/{packageName}/{version}/test-results", h.Packages.SetTestResults)
 			})
-			r.Route("/org/{orgName}", func(r chi.Router) {
-				r.Post("/", h.Repositories.Add)
-				r.Route("/{repoName}", func(r chi.Router) {
-					r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
-					r.Put("/transfer", h.Repositories.Transfer)
-					r.Put("/", h.Repositories.Update)
-					r.Delete("/", h.Repositories.Delete)
+			r.Group(func(r chi.Router) {
+				r.Use(h.Users.RequireLogin)
+				r.Post("/check", h.Repositories.Check)
+				r.Get("/search", h.Repositories.Search)
+				r.Get("/{repoName}/stats", h.Repositories.GetStats)
+				r.Get("/{repoName}/tracking-runs", h.Repositories.GetTrackingRuns)
+				r.Route("/transfers", func(r chi.Router) {
+					r.Get("/", h.Repositories.GetTransfers)
+					r.Put("/{repoName}/accept", h.Repositories.AcceptTransfer)
+					r.Put("/{repoName}/reject", h.Repositories.RejectTransfer)
+				})
+				r.Route("/user", func(r chi.Router) {
+					r.Post("/", h.Repositories.Add)
+					r.Route("/{repoName}", func(r chi.Router) {
+						r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
+						r.Put("/transfer", h.Repositories.Transfer)
+						r.Post("/transfer-request", h.Repositories.RequestTransfer)
+						r.Put("/verify-domain", h.Repositories.VerifyDomain)
+						r.Put("/pause-tracking", h.Repositories.PauseTracking)
+						r.Put("/resume-tracking", h.Repositories.ResumeTracking)
+						r.Put("/archive", h.Repositories.Archive)
+						r.Put("/unarchive", h.Repositories.Unarchive)
+						r.Put("/webhook-secret", h.Repositories.GenerateWebhookSecret)
+						r.Route("/publish-tokens", func(r chi.Router) {
+							r.Get("/", h.Repositories.GetPublishTokens)
+							r.Post("/", h.Repositories.AddPublishToken)
+							r.Delete("/{publishTokenID}", h.Repositories.DeletePublishToken)
+						})
+						r.Put("/", h.Repositories.Update)
+						r.Delete("/", h.Repositories.Delete)
+					})
+				})
+				r.Route("/org/{orgName}", func(r chi.Router) {
+					r.Post("/", h.Repositories.Add)
+					r.Route("/{repoName}", func(r chi.Router) {
+						r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
+						r.Put("/transfer", h.Repositories.Transfer)
+						r.Post("/transfer-request", h.Repositories.RequestTransfer)
+						r.Put("/verify-domain", h.Repositories.VerifyDomain)
+						r.Put("/pause-tracking", h.Repositories.PauseTracking)
+						r.Put("/resume-tracking", h.Repositories.ResumeTracking)
+						r.Put("/archive", h.Repositories.Archive)
+						r.Put("/unarchive", h.Repositories.Unarchive)
+						r.Put("/webhook-secret", h.Repositories.GenerateWebhookSecret)
+						r.Route("/publish-tokens", func(r chi.Router) {
+							r.Get("/", h.Repositories.GetPublishTokens)
+							r.Post("/", h.Repositories.AddPublishToken)
+							r.Delete("/{publishTokenID}", h.Repositories.DeletePublishToken)
+						})
+						r.Put("/", h.Repositories.Update)
+						r.Delete("/", h.Repositories.Delete)
+					})
 				})
 			})
 		})
 
 		// Packages
 		r.Route("/packages", func(r chi.Router) {
+			r.With(corsMW).Get("/changes", h.Packages.GetChanges)
+			r.Get("/compare", h.Packages.GetComparison)
+			r.With(corsMW).Get("/digest/{digest}", h.Packages.GetByDigest)
+			r.Get("/discovery/{category}", h.Packages.GetDiscovery)
 			r.Get("/random", h.Packages.GetRandom)
 			r.Get("/stats", h.Packages.GetStats)
 			r.With(corsMW).Get("/search", h.Packages.Search)
+			r.Route("/promoted", func(r chi.Router) {
+				r.Get("/", h.PromotedPackages.Get)
+				r.With(h.Users.RequireLogin).Post("/", h.PromotedPackages.Add)
+				r.With(h.Users.RequireLogin).Get("/stats", h.PromotedPackages.GetStats)
+				r.With(h.Users.RequireLogin).Delete("/{promotedPackageID}", h.PromotedPackages.Delete)
+				r.Post("/{promotedPackageID}/impression", h.PromotedPackages.RegisterImpression)
+				r.Post("/{promotedPackageID}/click", h.PromotedPackages.RegisterClick)
+			})
+			r.Route("/stale", func(r chi.Router) {
+				r.Use(h.Users.RequireLogin)
+				r.Get("/user", h.Packages.GetStaleByUser)
+				r.Get("/org/{orgName}", h.Packages.GetStaleByOrg)
+			})
 			r.With(h.Users.RequireLogin).Get("/starred", h.Packages.GetStarredByUser)
-			r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^coredns|^keptn$}/{repoName}/{packageName}", func(r chi.Router) {
+			r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^coredns|^keptn$|^kustomize$|^terraform$|^crossplane$|^kyverno$|^gatekeeper$|^argo-workflow-template$|^backstage$|^wasm$|^devcontainer$|^headlamp$|^knative$|^container$}/{repoName}/{packageName}", func(r chi.Router) {
 				r.Get("/feed/rss", h.Packages.RssFeed)
 				r.With(corsMW).Get("/summary", h.Packages.GetSummary)
 				r.Get("/{version}", h.Packages.Get)
+				r.Head("/{version}", h.Packages.Head)
 				r.Get("/", h.Packages.Get)
+				r.Head("/", h.Packages.Head)
 			})
 			r.Route("/{packageID}/stars", func(r chi.Router) {
 				r.With(h.Users.InjectUserID).Get("/", h.Packages.GetStars)
 				r.With(h.Users.RequireLogin).Put("/", h.Packages.ToggleStar)
 			})
+			r.Get("/{packageID}/{version}/dependencies-tree", h.Packages.GetDependenciesTree)
+			r.Get("/{packageID}/{version}/sbom", h.Packages.GetSnapshotSBOM)
 			r.Get("/{packageID}/{version}/security-report", h.Packages.GetSnapshotSecurityReport)
+			r.Get("/{packageID}/{version}/values-docs", h.Packages.GetValuesDocs)
 			r.Get("/{packageID}/{version}/values-schema", h.Packages.GetValuesSchema)
 			r.Get("/{packageID}/{version}/templates", h.Packages.GetChartTemplates)
 			r.Get("/{packageID}/changelog", h.Packages.GetChangeLog)
+			r.Get("/{packageID}/events", h.Packages.GetEvents)
 		})
 
 		// Subscriptions
@@ -267,6 +450,8 @@ func (h *Handlers) setupRouter() {
 				r.Post("/", h.Subscriptions.AddOptOut)
 				r.Delete("/{optOutID}", h.Subscriptions.DeleteOptOut)
 			})
+			r.Get("/export", h.Subscriptions.Export)
+			r.Post("/import", h.Subscriptions.Import)
 			r.Get("/{packageID}", h.Subscriptions.GetByPackage)
 			r.Get("/", h.Subscriptions.GetByUser)
 			r.Post("/", h.Subscriptions.Add)
@@ -283,6 +468,7 @@ func (h *Handlers) setupRouter() {
 					r.Get("/", h.Webhooks.Get)
 					r.Put("/", h.Webhooks.Update)
 					r.Delete("/", h.Webhooks.Delete)
+					r.Get("/template-errors", h.Webhooks.GetTemplateErrors)
 				})
 			})
 			r.Route("/org/{orgName}", func(r chi.Router) {
@@ -292,8 +478,10 @@ func (h *Handlers) setupRouter() {
 					r.Get("/", h.Webhooks.Get)
 					r.Put("/", h.Webhooks.Update)
 					r.Delete("/", h.Webhooks.Delete)
+					r.Get("/template-errors", h.Webhooks.GetTemplateErrors)
 				})
 			})
+			r.Get("/template-functions", h.Webhooks.GetTemplateFunctions)
 			r.Post("/test", h.Webhooks.TriggerTest)
 		})
 
@@ -318,6 +506,20 @@ func (h *Handlers) setupRouter() {
 
 		// Images
 		r.With(h.Users.RequireLogin).Post("/images", h.Static.SaveImage)
+		r.Route("/images/uploads", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin)
+			r.Post("/", h.ImageUploads.Add)
+			r.Get("/pending-moderation", h.ImageUploads.GetPendingModeration)
+			r.Route("/{imageUploadID}", func(r chi.Router) {
+				r.Get("/", h.ImageUploads.Get)
+				r.Patch("/", h.ImageUploads.AppendChunk)
+				r.Get("/image", h.ImageUploads.GetImage)
+				r.Put("/moderation", h.ImageUploads.Moderate)
+			})
+		})
+
+		// Config
+		r.Get("/config", h.Static.Config)
 
 		// Stats
 		r.Get("/stats", h.Stats.Get)
@@ -382,7 +584,7 @@ func (h *Handlers) setupRouter() {
 
 	// Index special entry points
 	r.Route("/packages", func(r chi.Router) {
-		r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^coredns|^keptn$}/{repoName}/{packageName}", func(r chi.Router) {
+		r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^coredns|^keptn$|^kustomize$|^terraform$|^crossplane$|^kyverno$|^gatekeeper$|^argo-workflow-template$|^backstage$|^wasm$|^devcontainer$|^headlamp$|^knative$|^container$}/{repoName}/{packageName}", func(r chi.Router) {
 			r.With(h.Packages.InjectIndexMeta).Get("/{version}", h.Static.Index)
 			r.With(h.Packages.InjectIndexMeta).Get("/", h.Static.Index)
 		})
@@ -391,6 +593,12 @@ func (h *Handlers) setupRouter() {
 	// Badges
 	r.Get("/badge/repository/{repoName}", h.Repositories.Badge)
 
+	// Health check
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(0))
+		w.WriteHeader(http.StatusOK)
+	})
+
 	// Static files and index
 	webBuildPath := h.cfg.GetString("server.webBuildPath")
 	webStaticFilesPath := path.Join(webBuildPath, "static")
@@ -430,6 +638,36 @@ func (h *Handlers) MetricsCollector(next http.Handler) http.Handler {
 	})
 }
 
+// loginRequired is an http middleware that, when the login required mode is
+// enabled, requires requests to be authenticated unless the path requested is
+// in the anonymous access allow list. Users related endpoints are allowed so
+// that users can sign up or log in, but the ones that need to be protected
+// still require login explicitly.
+func (h *Handlers) loginRequired(next http.Handler) http.Handler {
+	requireLogin := h.Users.RequireLoginForAnonymousAccess(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAnonymousAccessAllowed(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		requireLogin.ServeHTTP(w, r)
+	})
+}
+
+// isAnonymousAccessAllowed checks if the path provided can be accessed
+// anonymously when the login required mode is enabled.
+func isAnonymousAccessAllowed(p string) bool {
+	for _, entry := range anonymousAccessAllowList {
+		if p == entry {
+			return true
+		}
+		if entry != "/" && strings.HasSuffix(entry, "/") && strings.HasPrefix(p, entry) {
+			return true
+		}
+	}
+	return false
+}
+
 // csrfSkipper is an http middleware that skips CSRF checks for requests that
 // match certain criteria.
 func csrfSkipper(next http.Handler) http.Handler {
@@ -438,6 +676,15 @@ func csrfSkipper(next http.Handler) http.Handler {
 		if r.Header.Get(user.APIKeyIDHeader) != "" && r.Header.Get(user.APIKeySecretHeader) != "" {
 			r = csrf.UnsafeSkipCheck(r)
 		}
+		// Skip checks for requests authenticated using publish tokens
+		if r.Header.Get(repo.PublishTokenIDHeader) != "" && r.Header.Get(repo.PublishTokenSecretHeader) != "" {
+			r = csrf.UnsafeSkipCheck(r)
+		}
+		// Skip checks for repositories tracking webhooks, which are verified
+		// using their signature
+		if r.Method == "POST" && strings.HasPrefix(r.URL.Path, "/api/v1/repositories/webhook/") {
+			r = csrf.UnsafeSkipCheck(r)
+		}
 		// Skip checks for requests using GET or HEAD methods, except requests
 		// to /api/v1/csrf, which is the endpoint used to get the token that
 		// should be provided on subsequent POST, PUT or DELETE API requests.
@@ -454,7 +701,7 @@ func logger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
-		host, port, _ := net.SplitHostPort(r.RemoteAddr)
+		ip := helpers.ClientIP(r)
 		msg := r.URL.Path
 		if r.URL.Path == "/api/v1/packages/search" || r.URL.Path == "/api/chartsvc/v1/charts/search" {
 			msg += "?" + r.URL.RawQuery
@@ -468,8 +715,7 @@ func logger(next http.Handler) http.Handler {
 			}
 			event.
 				Fields(map[string]interface{}{
-					"host":      host,
-					"port":      port,
+					"host":      ip,
 					"method":    r.Method,
 					"status":    ww.Status(),
 					"took":      float64(time.Since(start)) / 1e6,
@@ -483,23 +729,60 @@ func logger(next http.Handler) http.Handler {
 	})
 }
 
-// realIP is an http middleware that sets the request remote addr to the result
-// of extracting the IP in the requested index from the X-Forwarded-For header.
-// Positives indexes start by 0 and work like usual slice indexes. Negative
-// indexes are allowed being -1 the last entry in the slice, -2 the next, etc.
-func realIP(i int) func(next http.Handler) http.Handler {
+// realIP is an http middleware that sets the request remote addr to the IP of
+// the client that made the request, so that helpers.ClientIP can get it.
+//
+// When some trusted proxies have been configured, the X-Forwarded-For header
+// is only used if the request was received from one of them. In that case,
+// its entries are processed from right to left, skipping the ones belonging
+// to trusted proxies, and the first untrusted one is used as the client IP.
+//
+// Otherwise, the IP in the requested index of the X-Forwarded-For header is
+// used. Positives indexes start by 0 and work like usual slice indexes.
+// Negative indexes are allowed being -1 the last entry in the slice, -2 the
+// next, etc.
+func realIP(i int, tp *util.TrustedProxies) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if xff := r.Header.Get(xForwardedFor); xff != "" {
-				ips := strings.Split(xff, ",")
-				if i >= 0 && len(ips) > i {
-					r.RemoteAddr = strings.TrimSpace(ips[i]) + ":"
+			xffValues := r.Header.Values(xForwardedFor)
+			if len(xffValues) > 0 && xffValues[0] != "" {
+				var ip net.IP
+				if tp.Enabled() {
+					ip = getTrustedXFFIP(r, strings.Split(strings.Join(xffValues, ","), ","), tp)
+				} else {
+					ips := strings.Split(xffValues[0], ",")
+					if i >= 0 && len(ips) > i {
+						ip = net.ParseIP(strings.TrimSpace(ips[i]))
+					}
+					if i < 0 && len(ips)+i >= 0 {
+						ip = net.ParseIP(strings.TrimSpace(ips[len(ips)+i]))
+					}
 				}
-				if i < 0 && len(ips)+i >= 0 {
-					r.RemoteAddr = strings.TrimSpace(ips[len(ips)+i]) + ":"
+				if ip != nil {
+					r.RemoteAddr = net.JoinHostPort(ip.String(), "")
 				}
 			}
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// getTrustedXFFIP returns the client IP from the X-Forwarded-For entries
+// provided, skipping the ones that belong to trusted proxies. Nil is returned
+// when the request was not received from a trusted proxy or when an invalid
+// entry is found.
+func getTrustedXFFIP(r *http.Request, ips []string, tp *util.TrustedProxies) net.IP {
+	if !tp.Contains(net.ParseIP(helpers.ClientIP(r))) {
+		return nil
+	}
+	for j := len(ips) - 1; j >= 0; j-- {
+		ip := net.ParseIP(strings.TrimSpace(ips[j]))
+		if ip == nil {
+			return nil
+		}
+		if j == 0 || !tp.Contains(ip) {
+			return ip
+		}
+	}
+	return nil
+}
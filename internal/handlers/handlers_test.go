@@ -6,9 +6,43 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/artifacthub/hub/internal/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func TestIsAnonymousAccessAllowed(t *testing.T) {
+	testCases := []struct {
+		path            string
+		expectedAllowed bool
+	}{
+		{"/", true},
+		{"/health", true},
+		{"/static/js/main.js", true},
+		{"/docs/", true},
+		{"/oauth/github", true},
+		{"/api/v1/csrf", true},
+		{"/api/v1/users", true},
+		{"/api/v1/users/login", true},
+		{"/api/v1/repositories/publish/repo1/tracking", true},
+		{"/api/v1/repositories/webhook/repo1", true},
+		{"/packages/helm/repo1/pkg1", false},
+		{"/api/v1/packages/search", false},
+		{"/api/v1/usersx", false},
+		{"/api/v1/repositories/search", false},
+		{"/badge/repository/repo1", false},
+		{"/image/image1", false},
+		{"/healthz", false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedAllowed, isAnonymousAccessAllowed(tc.path))
+		})
+	}
+}
+
 func TestRealIP(t *testing.T) {
 	checkRemoteAddr := func(expectedRemoteAddr string) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
@@ -104,7 +138,69 @@ func TestRealIP(t *testing.T) {
 					xForwardedFor: []string{tc.xForwardedFor},
 				},
 			}
-			realIP(tc.xffIndex)(checkRemoteAddr(tc.expectedRemoteAddr)).ServeHTTP(w, r)
+			realIP(tc.xffIndex, nil)(checkRemoteAddr(tc.expectedRemoteAddr)).ServeHTTP(w, r)
 		})
 	}
+
+	t.Run("trusted proxies", func(t *testing.T) {
+		tp, err := util.NewTrustedProxies([]string{"10.0.0.0/8", "2001:db8::/32"})
+		require.NoError(t, err)
+
+		testCases := []struct {
+			remoteAddr         string
+			xForwardedFor      []string
+			expectedRemoteAddr string
+		}{
+			{
+				"1.1.1.1:1234",
+				[]string{"2.2.2.2"},
+				"1.1.1.1:1234",
+			},
+			{
+				"10.0.0.1:1234",
+				[]string{"2.2.2.2"},
+				"2.2.2.2:",
+			},
+			{
+				"10.0.0.1:1234",
+				[]string{"3.3.3.3, 2.2.2.2, 10.0.0.2"},
+				"2.2.2.2:",
+			},
+			{
+				"10.0.0.1:1234",
+				[]string{"3.3.3.3", "2.2.2.2, 10.0.0.2"},
+				"2.2.2.2:",
+			},
+			{
+				"10.0.0.1:1234",
+				[]string{"10.0.0.3, 10.0.0.2"},
+				"10.0.0.3:",
+			},
+			{
+				"10.0.0.1:1234",
+				[]string{"invalid, 10.0.0.2"},
+				"10.0.0.1:1234",
+			},
+			{
+				"[2001:db8::1]:1234",
+				[]string{"2001:db9::1, 2001:db8::2"},
+				"[2001:db9::1]:",
+			},
+			{
+				"[::ffff:10.0.0.1]:1234",
+				[]string{"::ffff:2.2.2.2"},
+				"2.2.2.2:",
+			},
+		}
+		for _, tc := range testCases {
+			w := httptest.NewRecorder()
+			r := &http.Request{
+				RemoteAddr: tc.remoteAddr,
+				Header: http.Header{
+					xForwardedFor: tc.xForwardedFor,
+				},
+			}
+			realIP(0, tp)(checkRemoteAddr(tc.expectedRemoteAddr)).ServeHTTP(w, r)
+		}
+	})
 }
@@ -5,12 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/gorilla/csrf"
+	"github.com/spf13/viper"
 )
 
 const (
@@ -28,14 +32,182 @@ const (
 	// PaginationTotalCount represents a header used to indicate the number of
 	// entries available for pagination purposes.
 	PaginationTotalCount = "Pagination-Total-Count"
+
+	// hostCookiePrefix represents the prefix added to the cookies names when
+	// the host prefix is enabled. Browsers only accept cookies using this
+	// prefix when they are secure, have no domain and their path is /.
+	hostCookiePrefix = "__Host-"
 )
 
+// errInvalidHostCookiePath indicates that a cookie using the host prefix does
+// not use / as its path, so browsers would reject it.
+var errInvalidHostCookiePath = errors.New("cookies using the host prefix must use / as path")
+
+// CookieConfig represents the attributes applied to the cookies set by the
+// hub, as defined in the server.cookie configuration section.
+type CookieConfig struct {
+	Secure      bool
+	Domain      string
+	Partitioned bool
+	HostPrefix  bool
+
+	// SameSite overrides the SameSite mode of all cookies when set. When not
+	// set, each cookie keeps the mode it is created with.
+	SameSite http.SameSite
+}
+
+// GetCookieConfig returns the cookies configuration from the config provided,
+// checking that browsers will accept the combination of attributes set. When
+// not set explicitly, cookies are secure if the base url uses https.
+func GetCookieConfig(cfg *viper.Viper) (*CookieConfig, error) {
+	c := &CookieConfig{
+		Domain:      cfg.GetString("server.cookie.domain"),
+		Partitioned: cfg.GetBool("server.cookie.partitioned"),
+		HostPrefix:  cfg.GetBool("server.cookie.hostPrefix"),
+	}
+	if cfg.IsSet("server.cookie.secure") {
+		c.Secure = cfg.GetBool("server.cookie.secure")
+	} else {
+		c.Secure = strings.HasPrefix(cfg.GetString("server.baseURL"), "https://")
+	}
+	switch sameSite := cfg.GetString("server.cookie.sameSite"); strings.ToLower(sameSite) {
+	case "":
+	case "lax":
+		c.SameSite = http.SameSiteLaxMode
+	case "strict":
+		c.SameSite = http.SameSiteStrictMode
+	case "none":
+		c.SameSite = http.SameSiteNoneMode
+	default:
+		return nil, fmt.Errorf("invalid cookie sameSite mode: %s", sameSite)
+	}
+
+	// Validate attributes combination
+	if c.SameSite == http.SameSiteNoneMode && !c.Secure {
+		return nil, errors.New("cookies using sameSite mode none must be secure")
+	}
+	if c.Partitioned && !c.Secure {
+		return nil, errors.New("partitioned cookies must be secure")
+	}
+	if c.HostPrefix && !c.Secure {
+		return nil, errors.New("cookies using the host prefix must be secure")
+	}
+	if c.HostPrefix && c.Domain != "" {
+		return nil, errors.New("cookies using the host prefix cannot set a domain")
+	}
+
+	return c, nil
+}
+
+// Name returns the name a cookie is set with, which includes the host prefix
+// when it is enabled.
+func (c *CookieConfig) Name(name string) string {
+	if c.HostPrefix {
+		return hostCookiePrefix + name
+	}
+	return name
+}
+
+// SetCookie adds a Set-Cookie header to the http response writer provided for
+// the given cookie, after applying to it the configured attributes. An error
+// is returned when browsers would reject the resulting cookie.
+func (c *CookieConfig) SetCookie(w http.ResponseWriter, cookie *http.Cookie) error {
+	cookie.Name = c.Name(cookie.Name)
+	cookie.Secure = c.Secure
+	cookie.Domain = c.Domain
+	if c.SameSite != 0 {
+		cookie.SameSite = c.SameSite
+	}
+	if c.HostPrefix && cookie.Path != "/" {
+		return errInvalidHostCookiePath
+	}
+	v := cookie.String()
+	if v == "" {
+		return nil
+	}
+	if c.Partitioned {
+		// The standard library does not support this attribute yet
+		v += "; Partitioned"
+	}
+	w.Header().Add("Set-Cookie", v)
+	return nil
+}
+
+// CSRFProtect returns a middleware that protects the handlers it wraps from
+// CSRF attacks, storing the token in a cookie with the name and path provided.
+// The configured attributes are applied to the cookie as well, and its path is
+// set to / when the host prefix is enabled, as browsers would reject it
+// otherwise.
+func (c *CookieConfig) CSRFProtect(authKey []byte, name, path string) func(http.Handler) http.Handler {
+	if c.HostPrefix {
+		path = "/"
+	}
+	opts := []csrf.Option{
+		csrf.CookieName(c.Name(name)),
+		csrf.Path(path),
+		csrf.Domain(c.Domain),
+		csrf.Secure(c.Secure),
+		csrf.ErrorHandler(c.partitionCookies(http.HandlerFunc(csrfFailureHandler))),
+	}
+	if c.SameSite != 0 {
+		// The csrf package modes match the ones in the standard library
+		opts = append(opts, csrf.SameSite(csrf.SameSiteMode(c.SameSite)))
+	}
+	protect := csrf.Protect(authKey, opts...)
+	return func(next http.Handler) http.Handler {
+		return protect(c.partitionCookies(next))
+	}
+}
+
+// partitionCookies returns an http handler that adds the Partitioned attribute
+// to the cookies already set in the response before calling the next handler,
+// when partitioned cookies are enabled. This allows applying it to cookies not
+// set using SetCookie, like the one set by the CSRF middleware.
+func (c *CookieConfig) partitionCookies(next http.Handler) http.Handler {
+	if !c.Partitioned {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookies := w.Header()["Set-Cookie"]
+		for i, v := range cookies {
+			if !strings.HasSuffix(v, "; Partitioned") {
+				cookies[i] = v + "; Partitioned"
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// csrfFailureHandler is an http handler used to reply to requests that did not
+// pass the CSRF checks.
+func csrfFailureHandler(w http.ResponseWriter, r *http.Request) {
+	msg := fmt.Sprintf("%s - %s", http.StatusText(http.StatusForbidden), csrf.FailureReason(r))
+	http.Error(w, msg, http.StatusForbidden)
+}
+
 // BuildCacheControlHeader builds an http cache header using the max age
 // duration provided.
 func BuildCacheControlHeader(cacheMaxAge time.Duration) string {
 	return fmt.Sprintf("max-age=%d", int64(cacheMaxAge.Seconds()))
 }
 
+// ClientIP returns the IP address of the client that made the request provided,
+// as resolved by the realIP middleware from the connection, the PROXY protocol
+// header or the X-Forwarded-For header. Both IPv4 and IPv6 addresses are
+// supported, and IPv4-mapped IPv6 addresses are returned in IPv4 form. An
+// empty string is returned when the address cannot be resolved.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
 // GetPagination is a helper that extracts the pagination information from the
 // query string values provided.
 func GetPagination(qs url.Values, defaultLimit, maxLimit int) (*hub.Pagination, error) {
@@ -116,6 +288,19 @@ func RenderErrorWithCodeJSON(w http.ResponseWriter, err error, code int) {
 }
 
 // writeErrorJSON buids the error payload and writes it to the writer provided.
+// RenderResourceHead is a helper to write the resource head information
+// provided to the given http response writer as headers, without a body.
+func RenderResourceHead(w http.ResponseWriter, h *hub.ResourceHead) {
+	w.Header().Set("Cache-Control", BuildCacheControlHeader(0))
+	if h.Digest != "" {
+		w.Header().Set("ETag", strconv.Quote(h.Digest))
+	}
+	if h.LastModified != 0 {
+		w.Header().Set("Last-Modified", time.Unix(h.LastModified, 0).UTC().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
 func writeErrorJSON(w io.Writer, msg string) {
 	data := map[string]interface{}{
 		"message": msg,
@@ -14,7 +14,9 @@ import (
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuildCacheControlHeader(t *testing.T) {
@@ -45,6 +47,244 @@ func TestBuildCacheControlHeader(t *testing.T) {
 	}
 }
 
+func TestCookieConfig(t *testing.T) {
+	t.Run("cookie keeps its own attributes when no overrides are configured", func(t *testing.T) {
+		t.Parallel()
+		c := &CookieConfig{}
+		w := httptest.NewRecorder()
+		err := c.SetCookie(w, &http.Cookie{
+			Name:     "sid",
+			Value:    "value",
+			Path:     "/",
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sid=value; Path=/; HttpOnly; SameSite=Lax"}, w.Header()["Set-Cookie"])
+	})
+
+	t.Run("configured attributes are applied to the cookie", func(t *testing.T) {
+		t.Parallel()
+		c := &CookieConfig{
+			Secure:      true,
+			Domain:      "example.com",
+			Partitioned: true,
+			SameSite:    http.SameSiteNoneMode,
+		}
+		w := httptest.NewRecorder()
+		err := c.SetCookie(w, &http.Cookie{
+			Name:     "sid",
+			Value:    "value",
+			Path:     "/",
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"sid=value; Path=/; Domain=example.com; HttpOnly; Secure; SameSite=None; Partitioned",
+		}, w.Header()["Set-Cookie"])
+	})
+
+	t.Run("host prefix is added to the cookie name", func(t *testing.T) {
+		t.Parallel()
+		c := &CookieConfig{
+			Secure:     true,
+			HostPrefix: true,
+		}
+		w := httptest.NewRecorder()
+		err := c.SetCookie(w, &http.Cookie{
+			Name:  "sid",
+			Value: "value",
+			Path:  "/",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "__Host-sid", c.Name("sid"))
+		assert.Equal(t, []string{"__Host-sid=value; Path=/; Secure"}, w.Header()["Set-Cookie"])
+	})
+
+	t.Run("host prefixed cookie not using / as path is rejected", func(t *testing.T) {
+		t.Parallel()
+		c := &CookieConfig{
+			Secure:     true,
+			HostPrefix: true,
+		}
+		w := httptest.NewRecorder()
+		err := c.SetCookie(w, &http.Cookie{
+			Name:  "sid",
+			Value: "value",
+			Path:  "/api/v1",
+		})
+
+		assert.Equal(t, errInvalidHostCookiePath, err)
+		assert.Empty(t, w.Header()["Set-Cookie"])
+	})
+}
+
+func TestCookieConfigCSRFProtect(t *testing.T) {
+	authKey := []byte("0123456789abcdef0123456789abcdef")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("configured attributes are applied to the csrf cookie", func(t *testing.T) {
+		t.Parallel()
+		c := &CookieConfig{
+			Secure:      true,
+			Domain:      "example.com",
+			Partitioned: true,
+			SameSite:    http.SameSiteNoneMode,
+		}
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/api/v1/csrf", nil)
+		c.CSRFProtect(authKey, "csrf", "/api/v1")(handler).ServeHTTP(w, r)
+
+		cookies := w.Header()["Set-Cookie"]
+		require.Len(t, cookies, 1)
+		assert.Regexp(t, "^csrf=", cookies[0])
+		assert.Contains(t, cookies[0], "; Path=/api/v1; Domain=example.com;")
+		assert.Contains(t, cookies[0], "; Secure; SameSite=None; Partitioned")
+	})
+
+	t.Run("host prefixed csrf cookie uses / as path", func(t *testing.T) {
+		t.Parallel()
+		c := &CookieConfig{
+			Secure:     true,
+			HostPrefix: true,
+		}
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/api/v1/csrf", nil)
+		c.CSRFProtect(authKey, "csrf", "/api/v1")(handler).ServeHTTP(w, r)
+
+		cookies := w.Header()["Set-Cookie"]
+		require.Len(t, cookies, 1)
+		assert.Regexp(t, "^__Host-csrf=", cookies[0])
+		assert.Contains(t, cookies[0], "; Path=/;")
+		assert.NotContains(t, cookies[0], "Domain=")
+	})
+}
+
+func TestGetCookieConfig(t *testing.T) {
+	t.Run("invalid config", func(t *testing.T) {
+		testCases := []struct {
+			cfg    map[string]interface{}
+			errMsg string
+		}{
+			{
+				map[string]interface{}{
+					"server.cookie.sameSite": "invalid",
+				},
+				"invalid cookie sameSite mode",
+			},
+			{
+				map[string]interface{}{
+					"server.cookie.sameSite": "none",
+				},
+				"sameSite mode none must be secure",
+			},
+			{
+				map[string]interface{}{
+					"server.cookie.partitioned": true,
+				},
+				"partitioned cookies must be secure",
+			},
+			{
+				map[string]interface{}{
+					"server.cookie.hostPrefix": true,
+				},
+				"host prefix must be secure",
+			},
+			{
+				map[string]interface{}{
+					"server.cookie.secure":     true,
+					"server.cookie.hostPrefix": true,
+					"server.cookie.domain":     "example.com",
+				},
+				"host prefix cannot set a domain",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				cfg := viper.New()
+				for k, v := range tc.cfg {
+					cfg.Set(k, v)
+				}
+				c, err := GetCookieConfig(cfg)
+				assert.Nil(t, c)
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("valid config", func(t *testing.T) {
+		testCases := []struct {
+			cfg            map[string]interface{}
+			expectedConfig *CookieConfig
+		}{
+			{
+				map[string]interface{}{
+					"server.baseURL": "http://localhost:8000",
+				},
+				&CookieConfig{},
+			},
+			{
+				map[string]interface{}{
+					"server.baseURL": "https://artifacthub.io",
+				},
+				&CookieConfig{Secure: true},
+			},
+			{
+				map[string]interface{}{
+					"server.baseURL":       "https://artifacthub.io",
+					"server.cookie.secure": false,
+				},
+				&CookieConfig{},
+			},
+			{
+				map[string]interface{}{
+					"server.cookie.secure":      true,
+					"server.cookie.sameSite":    "None",
+					"server.cookie.partitioned": true,
+					"server.cookie.hostPrefix":  true,
+				},
+				&CookieConfig{
+					Secure:      true,
+					Partitioned: true,
+					HostPrefix:  true,
+					SameSite:    http.SameSiteNoneMode,
+				},
+			},
+			{
+				map[string]interface{}{
+					"server.cookie.domain":   "example.com",
+					"server.cookie.sameSite": "strict",
+				},
+				&CookieConfig{
+					Domain:   "example.com",
+					SameSite: http.SameSiteStrictMode,
+				},
+			},
+		}
+		for i, tc := range testCases {
+			tc := tc
+			t.Run(strconv.Itoa(i), func(t *testing.T) {
+				t.Parallel()
+				cfg := viper.New()
+				for k, v := range tc.cfg {
+					cfg.Set(k, v)
+				}
+				c, err := GetCookieConfig(cfg)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedConfig, c)
+			})
+		}
+	})
+}
+
 func TestGetPagination(t *testing.T) {
 	testCases := []struct {
 		qs                 url.Values
@@ -264,3 +504,43 @@ func TestRenderErrorWithCodeJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderResourceHead(t *testing.T) {
+	testCases := []struct {
+		head                 *hub.ResourceHead
+		expectedETag         string
+		expectedLastModified string
+	}{
+		{
+			&hub.ResourceHead{},
+			"",
+			"",
+		},
+		{
+			&hub.ResourceHead{
+				Digest:       "digest",
+				LastModified: 1592299233,
+			},
+			`"digest"`,
+			"Tue, 16 Jun 2020 09:20:33 GMT",
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			RenderResourceHead(w, tc.head)
+			resp := w.Result()
+			defer resp.Body.Close()
+			h := resp.Header
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "max-age=0", h.Get("Cache-Control"))
+			assert.Equal(t, tc.expectedETag, h.Get("ETag"))
+			assert.Equal(t, tc.expectedLastModified, h.Get("Last-Modified"))
+			assert.Empty(t, data)
+		})
+	}
+}
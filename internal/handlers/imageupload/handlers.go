@@ -0,0 +1,186 @@
+package imageupload
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	svg "github.com/h2non/go-is-svg"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// UploadOffsetHeader represents the header used to provide the offset at
+	// which a chunk must be appended, as well as to return the number of
+	// bytes of the image received so far.
+	UploadOffsetHeader = "Upload-Offset"
+
+	// UploadLengthHeader represents the header used to return the total size
+	// of the image being uploaded.
+	UploadLengthHeader = "Upload-Length"
+
+	// chunkMaxSize represents the maximum size of a chunk.
+	chunkMaxSize = 1 << 20
+)
+
+// Handlers represents a group of http handlers in charge of handling images
+// uploads operations.
+type Handlers struct {
+	imageUploadManager hub.ImageUploadManager
+	logger             zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(imageUploadManager hub.ImageUploadManager) *Handlers {
+	return &Handlers{
+		imageUploadManager: imageUploadManager,
+		logger:             log.With().Str("handlers", "imageUpload").Logger(),
+	}
+}
+
+// Add is an http handler that registers a new image upload.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Size     int    `json:"size"`
+		Checksum string `json:"checksum"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	imageUploadID, err := h.imageUploadManager.Add(r.Context(), input.Size, input.Checksum)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(map[string]string{
+		"image_upload_id": imageUploadID,
+	})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
+}
+
+// AppendChunk is an http handler that appends the chunk provided in the
+// request body to the image upload, at the offset set in the Upload-Offset
+// header.
+func (h *Handlers) AppendChunk(w http.ResponseWriter, r *http.Request) {
+	imageUploadID := chi.URLParam(r, "imageUploadID")
+	offset, err := strconv.Atoi(r.Header.Get(UploadOffsetHeader))
+	if err != nil || offset < 0 {
+		err := fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid upload offset")
+		h.logger.Error().Err(err).Str("method", "AppendChunk").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	chunk, err := ioutil.ReadAll(io.LimitReader(r.Body, chunkMaxSize+1))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "AppendChunk").Msg("error reading body data")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if len(chunk) > chunkMaxSize {
+		err := fmt.Errorf("%w: chunk exceeds the maximum size allowed (%d bytes)", hub.ErrInvalidInput, chunkMaxSize)
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	u, err := h.imageUploadManager.AppendChunk(r.Context(), imageUploadID, offset, chunk)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "AppendChunk").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	h.renderImageUpload(w, u)
+}
+
+// Get is an http handler that returns the requested image upload, including
+// the number of bytes received so far, so that it can be resumed.
+func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
+	imageUploadID := chi.URLParam(r, "imageUploadID")
+	u, err := h.imageUploadManager.Get(r.Context(), imageUploadID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	h.renderImageUpload(w, u)
+}
+
+// GetImage is an http handler that returns the image of the provided image
+// upload, so that it can be reviewed before being approved.
+func (h *Handlers) GetImage(w http.ResponseWriter, r *http.Request) {
+	imageUploadID := chi.URLParam(r, "imageUploadID")
+	data, err := h.imageUploadManager.GetImage(r.Context(), imageUploadID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetImage").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "private, no-store")
+	if svg.Is(data) {
+		w.Header().Set("Content-Type", "image/svg+xml")
+	} else {
+		w.Header().Set("Content-Type", http.DetectContentType(data))
+	}
+
+	// Images have not been approved yet, so scripts in svg images must not
+	// be run when they are opened directly
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	_, _ = w.Write(data)
+}
+
+// GetPendingModeration is an http handler that returns the images uploads
+// pending to be reviewed by a moderator.
+func (h *Handlers) GetPendingModeration(w http.ResponseWriter, r *http.Request) {
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetPendingModeration").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	result, err := h.imageUploadManager.GetPendingModerationJSON(r.Context(), p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetPendingModeration").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
+// Moderate is an http handler that approves or rejects the provided image
+// upload.
+func (h *Handlers) Moderate(w http.ResponseWriter, r *http.Request) {
+	imageUploadID := chi.URLParam(r, "imageUploadID")
+	var input struct {
+		ModerationStatus string `json:"moderation_status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "Moderate").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.imageUploadManager.Moderate(r.Context(), imageUploadID, input.ModerationStatus); err != nil {
+		h.logger.Error().Err(err).Str("method", "Moderate").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// renderImageUpload renders the image upload provided, setting the upload
+// headers as well.
+func (h *Handlers) renderImageUpload(w http.ResponseWriter, u *hub.ImageUpload) {
+	w.Header().Set(UploadOffsetHeader, strconv.Itoa(u.Offset))
+	w.Header().Set(UploadLengthHeader, strconv.Itoa(u.Size))
+	dataJSON, _ := json.Marshal(u)
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
@@ -0,0 +1,514 @@
+package imageupload
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/imageupload"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+var rctx = &chi.Context{
+	URLParams: chi.RouteParams{
+		Keys:   []string{"imageUploadID"},
+		Values: []string{"imageUploadID"},
+	},
+}
+
+func TestAdd(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{invalid json"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.h.Add(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error adding image upload", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"size": 10, "checksum": "checksum"}`))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.um.On("Add", r.Context(), 10, "checksum").Return("", tc.err)
+				hw.h.Add(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("image upload added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"size": 10, "checksum": "checksum"}`))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("Add", r.Context(), 10, "checksum").Return("imageUploadID", nil)
+		hw.h.Add(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, []byte(`{"image_upload_id":"imageUploadID"}`), data)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestAppendChunk(t *testing.T) {
+	t.Run("invalid upload offset", func(t *testing.T) {
+		for _, offset := range []string{"", "invalid", "-1"} {
+			offset := offset
+			t.Run(offset, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PATCH", "/", strings.NewReader("chunk"))
+				r.Header.Set(UploadOffsetHeader, offset)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.h.AppendChunk(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			})
+		}
+	})
+
+	t.Run("chunk too big", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PATCH", "/", bytes.NewReader(make([]byte, chunkMaxSize+1)))
+		r.Header.Set(UploadOffsetHeader, "0")
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.AppendChunk(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error appending chunk", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PATCH", "/", strings.NewReader("chunk"))
+				r.Header.Set(UploadOffsetHeader, "5")
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.um.On("AppendChunk", r.Context(), "imageUploadID", 5, []byte("chunk")).Return(nil, tc.err)
+				hw.h.AppendChunk(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("chunk appended successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PATCH", "/", strings.NewReader("chunk"))
+		r.Header.Set(UploadOffsetHeader, "5")
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.um.On("AppendChunk", r.Context(), "imageUploadID", 5, []byte("chunk")).Return(&hub.ImageUpload{
+			ImageUploadID:    "imageUploadID",
+			Size:             20,
+			Checksum:         "checksum",
+			Offset:           10,
+			ModerationStatus: hub.ImageUploadPending,
+		}, nil)
+		hw.h.AppendChunk(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "10", h.Get(UploadOffsetHeader))
+		assert.Equal(t, "20", h.Get(UploadLengthHeader))
+		assert.JSONEq(t, `{
+			"image_upload_id": "imageUploadID",
+			"size": 20,
+			"checksum": "checksum",
+			"offset": 10,
+			"moderation_status": "pending"
+		}`, string(data))
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("error getting image upload", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.um.On("Get", r.Context(), "imageUploadID").Return(nil, tc.err)
+				hw.h.Get(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("image upload returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.um.On("Get", r.Context(), "imageUploadID").Return(&hub.ImageUpload{
+			ImageUploadID: "imageUploadID",
+			Size:          20,
+			Offset:        10,
+		}, nil)
+		hw.h.Get(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "10", h.Get(UploadOffsetHeader))
+		assert.Equal(t, "20", h.Get(UploadLengthHeader))
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestGetImage(t *testing.T) {
+	t.Run("error getting image upload image", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.um.On("GetImage", r.Context(), "imageUploadID").Return(nil, tc.err)
+				hw.h.GetImage(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("image upload image returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+		data := []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"></svg>`)
+
+		hw := newHandlersWrapper()
+		hw.um.On("GetImage", r.Context(), "imageUploadID").Return(data, nil)
+		hw.h.GetImage(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/svg+xml", h.Get("Content-Type"))
+		assert.Equal(t, "private, no-store", h.Get("Cache-Control"))
+		assert.Equal(t, "sandbox", h.Get("Content-Security-Policy"))
+		assert.Equal(t, data, body)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestGetPendingModeration(t *testing.T) {
+	t.Run("invalid pagination", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=invalid", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.h.GetPendingModeration(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error getting pending images uploads", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.um.On("GetPendingModerationJSON", r.Context(), &hub.Pagination{Limit: 10, Offset: 1}).
+					Return(nil, tc.err)
+				hw.h.GetPendingModeration(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("pending images uploads returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("GetPendingModerationJSON", r.Context(), &hub.Pagination{Limit: 10, Offset: 1}).
+			Return(&hub.JSONQueryResult{Data: []byte("dataJSON"), TotalCount: 1}, nil)
+		hw.h.GetPendingModeration(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, "1", h.Get(helpers.PaginationTotalCount))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestModerate(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("{invalid json"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Moderate(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error moderating image upload", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"moderation_status": "rejected"}`))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.um.On("Moderate", r.Context(), "imageUploadID", hub.ImageUploadRejected).Return(tc.err)
+				hw.h.Moderate(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("image upload moderated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"moderation_status": "rejected"}`))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.um.On("Moderate", r.Context(), "imageUploadID", hub.ImageUploadRejected).Return(nil)
+		hw.h.Moderate(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+type handlersWrapper struct {
+	um *imageupload.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	um := &imageupload.ManagerMock{}
+
+	return &handlersWrapper{
+		um: um,
+		h:  NewHandlers(um),
+	}
+}
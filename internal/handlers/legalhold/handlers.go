@@ -0,0 +1,70 @@
+package legalhold
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling legal
+// holds operations.
+type Handlers struct {
+	legalHoldManager hub.LegalHoldManager
+	logger           zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(legalHoldManager hub.LegalHoldManager) *Handlers {
+	return &Handlers{
+		legalHoldManager: legalHoldManager,
+		logger:           log.With().Str("handlers", "legalhold").Logger(),
+	}
+}
+
+// Add is an http handler that places a legal hold on the provided package or
+// repository.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	lh := &hub.LegalHold{}
+	if err := json.NewDecoder(r.Body).Decode(&lh); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	legalHoldID, err := h.legalHoldManager.Add(r.Context(), lh)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(map[string]string{
+		"legal_hold_id": legalHoldID,
+	})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
+}
+
+// Get is an http handler that returns all the legal holds currently active.
+func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.legalHoldManager.GetJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Release is an http handler that releases the provided legal hold.
+func (h *Handlers) Release(w http.ResponseWriter, r *http.Request) {
+	legalHoldID := chi.URLParam(r, "legalHoldID")
+	if err := h.legalHoldManager.Release(r.Context(), legalHoldID); err != nil {
+		h.logger.Error().Err(err).Str("method", "Release").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
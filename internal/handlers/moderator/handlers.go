@@ -0,0 +1,61 @@
+package moderator
+
+import (
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling
+// moderators operations.
+type Handlers struct {
+	moderatorManager hub.ModeratorManager
+	logger           zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(moderatorManager hub.ModeratorManager) *Handlers {
+	return &Handlers{
+		moderatorManager: moderatorManager,
+		logger:           log.With().Str("handlers", "moderator").Logger(),
+	}
+}
+
+// Add is an http handler that grants the moderator role to the provided user.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.moderatorManager.Add(r.Context(), userAlias); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Get is an http handler that returns all the users holding the moderator
+// role.
+func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.moderatorManager.GetJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Remove is an http handler that revokes the moderator role from the provided
+// user.
+func (h *Handlers) Remove(w http.ResponseWriter, r *http.Request) {
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.moderatorManager.Remove(r.Context(), userAlias); err != nil {
+		h.logger.Error().Err(err).Str("method", "Remove").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -62,6 +62,43 @@ func (h *Handlers) AddMember(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// AddMembers is an http handler that invites the users identified by the
+// emails provided to join the organization.
+func (h *Handlers) AddMembers(w http.ResponseWriter, r *http.Request) {
+	input := &struct {
+		Emails []string `json:"emails"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddMembers").Msg("invalid emails")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	results, err := h.orgManager.AddMembers(r.Context(), orgName, input.Emails)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "AddMembers").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(map[string][]*hub.OrganizationInvitationResult{
+		"results": results,
+	})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
+}
+
+// CancelInvitation is an http handler that cancels a pending invitation to
+// join the provided organization.
+func (h *Handlers) CancelInvitation(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.orgManager.CancelInvitation(r.Context(), orgName, userAlias); err != nil {
+		h.logger.Error().Err(err).Str("method", "CancelInvitation").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // CheckAvailability is an http handler that checks the availability of a given
 // value for the provided resource kind.
 func (h *Handlers) CheckAvailability(w http.ResponseWriter, r *http.Request) {
@@ -95,8 +132,14 @@ func (h *Handlers) ConfirmMembership(w http.ResponseWriter, r *http.Request) {
 
 // Delete is an http handler that deletes an organization.
 func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
+	input := &hub.DeleteOrganizationInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "Delete").Msg("invalid input")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
 	orgName := chi.URLParam(r, "orgName")
-	if err := h.orgManager.Delete(r.Context(), orgName); err != nil {
+	if err := h.orgManager.Delete(r.Context(), orgName, input); err != nil {
 		h.logger.Error().Err(err).Str("method", "Delete").Send()
 		helpers.RenderErrorJSON(w, err)
 		return
@@ -162,6 +205,53 @@ func (h *Handlers) GetByUser(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
 }
 
+// GetIdPGroups is an http handler that returns the identity provider groups
+// mapped to the provided organization.
+func (h *Handlers) GetIdPGroups(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.orgManager.GetIdPGroupsJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetIdPGroups").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetInvitations is an http handler that returns the pending invitations to
+// join the provided organization.
+func (h *Handlers) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetInvitations").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	result, err := h.orgManager.GetInvitationsJSON(r.Context(), orgName, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetInvitations").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
+// GetLinks is an http handler that returns the links of the provided
+// organization, including the tokens needed to verify them.
+func (h *Handlers) GetLinks(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.orgManager.GetLinksJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetLinks").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // GetMembers is an http handler that returns the members of the provided
 // organization.
 func (h *Handlers) GetMembers(w http.ResponseWriter, r *http.Request) {
@@ -183,6 +273,32 @@ func (h *Handlers) GetMembers(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
 }
 
+// GetQuotas is an http handler that returns the quotas that apply to the
+// provided organization along with its current usage.
+func (h *Handlers) GetQuotas(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.orgManager.GetQuotasJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetQuotas").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// ResendInvitation is an http handler that renews a pending invitation to join
+// the provided organization, sending the invitation email again.
+func (h *Handlers) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.orgManager.ResendInvitation(r.Context(), orgName, userAlias); err != nil {
+		h.logger.Error().Err(err).Str("method", "ResendInvitation").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Update is an http handler that updates the provided organization in the
 // database.
 func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
@@ -219,6 +335,63 @@ func (h *Handlers) UpdateAuthorizationPolicy(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UpdateIdPGroups is an http handler that updates the identity provider groups
+// mapped to the provided organization.
+func (h *Handlers) UpdateIdPGroups(w http.ResponseWriter, r *http.Request) {
+	var groups []*hub.IdPGroupMapping
+	if err := json.NewDecoder(r.Body).Decode(&groups); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateIdPGroups").Msg("invalid groups")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	if err := h.orgManager.UpdateIdPGroups(r.Context(), orgName, groups); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateIdPGroups").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateLinks is an http handler that updates the links of the provided
+// organization.
+func (h *Handlers) UpdateLinks(w http.ResponseWriter, r *http.Request) {
+	var links []*hub.OrganizationLink
+	if err := json.NewDecoder(r.Body).Decode(&links); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateLinks").Msg("invalid links")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	if err := h.orgManager.UpdateLinks(r.Context(), orgName, links); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateLinks").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateMemberRole is an http handler that updates the role of a member of the
+// provided organization.
+func (h *Handlers) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	input := &struct {
+		Role hub.OrganizationRole `json:"role"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateMemberRole").Msg("invalid role")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.orgManager.UpdateMemberRole(r.Context(), orgName, userAlias, input.Role); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateMemberRole").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // GetUserAllowedActions is an http handler that returns the actions the
 // requesting user is allowed to perform in the provided organization.
 func (h *Handlers) GetUserAllowedActions(w http.ResponseWriter, r *http.Request) {
@@ -233,3 +406,15 @@ func (h *Handlers) GetUserAllowedActions(w http.ResponseWriter, r *http.Request)
 	dataJSON, _ := json.Marshal(actions)
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
+
+// VerifyLinks is an http handler that verifies the links of the provided
+// organization using the DNS TXT records of their domains.
+func (h *Handlers) VerifyLinks(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	if err := h.orgManager.VerifyLinks(r.Context(), orgName); err != nil {
+		h.logger.Error().Err(err).Str("method", "VerifyLinks").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
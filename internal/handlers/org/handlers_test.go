@@ -179,6 +179,148 @@ func TestAddMember(t *testing.T) {
 	}
 }
 
+func TestAddMembers(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.AddMembers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.om.AssertExpectations(t)
+	})
+
+	t.Run("error adding members", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"emails": ["user1@email.com"]}`))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("AddMembers", r.Context(), "org1", []string{"user1@email.com"}).Return(nil, tc.omErr)
+				hw.h.AddMembers(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("add members succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"emails": ["user1@email.com", "user2@email.com"]}`))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("AddMembers", r.Context(), "org1", []string{"user1@email.com", "user2@email.com"}).
+			Return([]*hub.OrganizationInvitationResult{
+				{Email: "user1@email.com", Status: hub.InvitationStatusPending},
+				{Email: "user2@email.com", Status: hub.InvitationStatusAlreadyMember},
+			}, nil)
+		hw.h.AddMembers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, []byte(`{"results":[{"email":"user1@email.com","status":"pending"},{"email":"user2@email.com","status":"already_member"}]}`), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestCancelInvitation(t *testing.T) {
+	testCases := []struct {
+		omErr              error
+		expectedStatusCode int
+	}{
+		{
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		var desc string
+		if tc.omErr != nil {
+			desc = tc.omErr.Error()
+		}
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("DELETE", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			rctx := &chi.Context{
+				URLParams: chi.RouteParams{
+					Keys:   []string{"orgName", "userAlias"},
+					Values: []string{"org1", "userAlias"},
+				},
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.om.On("CancelInvitation", r.Context(), "org1", "userAlias").Return(tc.omErr)
+			hw.h.CancelInvitation(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.om.AssertExpectations(t)
+		})
+	}
+}
+
 func TestCheckAvailability(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
@@ -323,6 +465,29 @@ func TestConfirmMembership(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Delete(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.om.AssertExpectations(t)
+	})
+
 	testCases := []struct {
 		omErr              error
 		expectedStatusCode int
@@ -353,18 +518,16 @@ func TestDelete(t *testing.T) {
 		t.Run(desc, func(t *testing.T) {
 			t.Parallel()
 			w := httptest.NewRecorder()
-			r, _ := http.NewRequest("DELETE", "/", nil)
+			body := strings.NewReader(`{"confirmation": "org1", "repositories_action": "delete"}`)
+			r, _ := http.NewRequest("DELETE", "/", body)
 			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-			rctx := &chi.Context{
-				URLParams: chi.RouteParams{
-					Keys:   []string{"orgName"},
-					Values: []string{"org1"},
-				},
-			}
 			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 			hw := newHandlersWrapper()
-			hw.om.On("Delete", r.Context(), "org1").Return(tc.omErr)
+			hw.om.On("Delete", r.Context(), "org1", &hub.DeleteOrganizationInput{
+				Confirmation:       "org1",
+				RepositoriesAction: hub.DeleteRepositories,
+			}).Return(tc.omErr)
 			hw.h.Delete(w, r)
 			resp := w.Result()
 			defer resp.Body.Close()
@@ -609,7 +772,7 @@ func TestGetByUser(t *testing.T) {
 	})
 }
 
-func TestGetMembers(t *testing.T) {
+func TestGetIdPGroups(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
 			Keys:   []string{"orgName"},
@@ -617,7 +780,77 @@ func TestGetMembers(t *testing.T) {
 		},
 	}
 
-	t.Run("error getting organization members", func(t *testing.T) {
+	t.Run("error getting identity provider groups", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("GetIdPGroupsJSON", r.Context(), "org1").Return(nil, tc.omErr)
+				hw.h.GetIdPGroups(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get identity provider groups succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetIdPGroupsJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetIdPGroups(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestGetInvitations(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error getting organization invitations", func(t *testing.T) {
 		testCases := []struct {
 			omErr              error
 			expectedStatusCode int
@@ -641,11 +874,11 @@ func TestGetMembers(t *testing.T) {
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.om.On("GetMembersJSON", r.Context(), "org1", &hub.Pagination{
+				hw.om.On("GetInvitationsJSON", r.Context(), "org1", &hub.Pagination{
 					Limit:  10,
 					Offset: 1,
 				}).Return(nil, tc.omErr)
-				hw.h.GetMembers(w, r)
+				hw.h.GetInvitations(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -655,7 +888,7 @@ func TestGetMembers(t *testing.T) {
 		}
 	})
 
-	t.Run("get organization members succeeded", func(t *testing.T) {
+	t.Run("get organization invitations succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
@@ -663,14 +896,14 @@ func TestGetMembers(t *testing.T) {
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.om.On("GetMembersJSON", r.Context(), "org1", &hub.Pagination{
+		hw.om.On("GetInvitationsJSON", r.Context(), "org1", &hub.Pagination{
 			Limit:  10,
 			Offset: 1,
 		}).Return(&hub.JSONQueryResult{
 			Data:       []byte("dataJSON"),
 			TotalCount: 1,
 		}, nil)
-		hw.h.GetMembers(w, r)
+		hw.h.GetInvitations(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 		h := resp.Header
@@ -685,7 +918,7 @@ func TestGetMembers(t *testing.T) {
 	})
 }
 
-func TestUpdate(t *testing.T) {
+func TestGetLinks(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
 			Keys:   []string{"orgName"},
@@ -693,61 +926,438 @@ func TestUpdate(t *testing.T) {
 		},
 	}
 
-	t.Run("invalid organization provided", func(t *testing.T) {
+	t.Run("error getting organization links", func(t *testing.T) {
 		testCases := []struct {
-			description string
-			orgJSON     string
-			omErr       error
+			omErr              error
+			expectedStatusCode int
 		}{
 			{
-				"no organization provided",
-				"",
-				nil,
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
 			},
 			{
-				"invalid json",
-				"-",
-				nil,
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
 			},
 			{
-				"invalid logo image id",
-				`{"name": "org1", "logo_image_id": "invalid"}`,
-				hub.ErrInvalidInput,
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
 			},
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.description, func(t *testing.T) {
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.orgJSON))
+				r, _ := http.NewRequest("GET", "/", nil)
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				if tc.omErr != nil {
-					hw.om.On("Update", r.Context(), "org1", mock.Anything).Return(tc.omErr)
-				}
-				hw.h.Update(w, r)
+				hw.om.On("GetLinksJSON", r.Context(), "org1").Return(nil, tc.omErr)
+				hw.h.GetLinks(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
-				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
 				hw.om.AssertExpectations(t)
 			})
 		}
 	})
 
-	t.Run("valid organization provided", func(t *testing.T) {
-		orgJSON := `
-		{
-			"name": "org1",
+	t.Run("get organization links succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetLinksJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetLinks(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestGetMembers(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error getting organization members", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("GetMembersJSON", r.Context(), "org1", &hub.Pagination{
+					Limit:  10,
+					Offset: 1,
+				}).Return(nil, tc.omErr)
+				hw.h.GetMembers(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get organization members succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetMembersJSON", r.Context(), "org1", &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetMembers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestGetQuotas(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error getting organization quotas", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("GetQuotasJSON", r.Context(), "org1").Return(nil, tc.omErr)
+				hw.h.GetQuotas(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get organization quotas succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetQuotasJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetQuotas(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestResendInvitation(t *testing.T) {
+	testCases := []struct {
+		omErr              error
+		expectedStatusCode int
+	}{
+		{
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		var desc string
+		if tc.omErr != nil {
+			desc = tc.omErr.Error()
+		}
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("POST", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			rctx := &chi.Context{
+				URLParams: chi.RouteParams{
+					Keys:   []string{"orgName", "userAlias"},
+					Values: []string{"org1", "userAlias"},
+				},
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.om.On("ResendInvitation", r.Context(), "org1", "userAlias").Return(tc.omErr)
+			hw.h.ResendInvitation(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.om.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("invalid organization provided", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			orgJSON     string
+			omErr       error
+		}{
+			{
+				"no organization provided",
+				"",
+				nil,
+			},
+			{
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"invalid logo image id",
+				`{"name": "org1", "logo_image_id": "invalid"}`,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.orgJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				if tc.omErr != nil {
+					hw.om.On("Update", r.Context(), "org1", mock.Anything).Return(tc.omErr)
+				}
+				hw.h.Update(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("valid organization provided", func(t *testing.T) {
+		orgJSON := `
+		{
+			"name": "org1",
 			"display_name": "Organization 1 updated",
 			"description": "description updated"
 		}
 		`
-		o := &hub.Organization{}
-		_ = json.Unmarshal([]byte(orgJSON), &o)
+		o := &hub.Organization{}
+		_ = json.Unmarshal([]byte(orgJSON), &o)
+
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"organization update succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error updating organization (insufficiente privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error updating organization (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(orgJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("Update", r.Context(), "org1", o).Return(tc.err)
+				hw.h.Update(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestUpdateAuthorizationPolicy(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("invalid authorization policy provided", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			policyJSON  string
+			omErr       error
+		}{
+			{
+				"no authorization policy provided",
+				"",
+				nil,
+			},
+			{
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"invalid policy data",
+				`{"policy_data": "invalid"}`,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.policyJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				if tc.omErr != nil {
+					hw.om.On("UpdateAuthorizationPolicy", r.Context(), "org1", mock.Anything).Return(tc.omErr)
+				}
+				hw.h.UpdateAuthorizationPolicy(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("valid authorization policy provided", func(t *testing.T) {
+		policyJSON := `
+		{
+			"authorization_enabled": true,
+			"predefined_policy": "rbac.v1",
+			"custom_policy": "",
+			"policy_data": "{\"k\": \"v\"}"
+		}
+		`
+		policy := &hub.AuthorizationPolicy{}
+		_ = json.Unmarshal([]byte(policyJSON), &policy)
 
 		testCases := []struct {
 			description        string
@@ -755,17 +1365,17 @@ func TestUpdate(t *testing.T) {
 			expectedStatusCode int
 		}{
 			{
-				"organization update succeeded",
+				"authorization policy update succeeded",
 				nil,
 				http.StatusNoContent,
 			},
 			{
-				"error updating organization (insufficiente privilege)",
+				"error updating organization policy (insufficiente privilege)",
 				hub.ErrInsufficientPrivilege,
 				http.StatusForbidden,
 			},
 			{
-				"error updating organization (db error)",
+				"error updating organization policy (db error)",
 				tests.ErrFakeDB,
 				http.StatusInternalServerError,
 			},
@@ -775,13 +1385,19 @@ func TestUpdate(t *testing.T) {
 			t.Run(tc.description, func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("PUT", "/", strings.NewReader(orgJSON))
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(policyJSON))
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				rctx := &chi.Context{
+					URLParams: chi.RouteParams{
+						Keys:   []string{"orgName"},
+						Values: []string{"org1"},
+					},
+				}
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.om.On("Update", r.Context(), "org1", o).Return(tc.err)
-				hw.h.Update(w, r)
+				hw.om.On("UpdateAuthorizationPolicy", r.Context(), "org1", policy).Return(tc.err)
+				hw.h.UpdateAuthorizationPolicy(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -792,7 +1408,7 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
-func TestUpdateAuthorizationPolicy(t *testing.T) {
+func TestUpdateIdPGroups(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
 			Keys:   []string{"orgName"},
@@ -800,14 +1416,14 @@ func TestUpdateAuthorizationPolicy(t *testing.T) {
 		},
 	}
 
-	t.Run("invalid authorization policy provided", func(t *testing.T) {
+	t.Run("invalid groups provided", func(t *testing.T) {
 		testCases := []struct {
 			description string
-			policyJSON  string
+			groupsJSON  string
 			omErr       error
 		}{
 			{
-				"no authorization policy provided",
+				"no groups provided",
 				"",
 				nil,
 			},
@@ -817,8 +1433,8 @@ func TestUpdateAuthorizationPolicy(t *testing.T) {
 				nil,
 			},
 			{
-				"invalid policy data",
-				`{"policy_data": "invalid"}`,
+				"invalid role",
+				`[{"group": "group1", "role": "invalid"}]`,
 				hub.ErrInvalidInput,
 			},
 		}
@@ -827,15 +1443,15 @@ func TestUpdateAuthorizationPolicy(t *testing.T) {
 			t.Run(tc.description, func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.policyJSON))
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.groupsJSON))
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
 				if tc.omErr != nil {
-					hw.om.On("UpdateAuthorizationPolicy", r.Context(), "org1", mock.Anything).Return(tc.omErr)
+					hw.om.On("UpdateIdPGroups", r.Context(), "org1", mock.Anything).Return(tc.omErr)
 				}
-				hw.h.UpdateAuthorizationPolicy(w, r)
+				hw.h.UpdateIdPGroups(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -845,17 +1461,109 @@ func TestUpdateAuthorizationPolicy(t *testing.T) {
 		}
 	})
 
-	t.Run("valid authorization policy provided", func(t *testing.T) {
-		policyJSON := `
-		{
-			"authorization_enabled": true,
-			"predefined_policy": "rbac.v1",
-			"custom_policy": "",
-			"policy_data": "{\"k\": \"v\"}"
+	t.Run("valid groups provided", func(t *testing.T) {
+		groupsJSON := `[{"group": "group1", "role": "admin"}]`
+		groups := []*hub.IdPGroupMapping{{Group: "group1", Role: hub.OrganizationAdmin}}
+
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"identity provider groups update succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error updating identity provider groups (insufficient privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error updating identity provider groups (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
 		}
-		`
-		policy := &hub.AuthorizationPolicy{}
-		_ = json.Unmarshal([]byte(policyJSON), &policy)
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(groupsJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("UpdateIdPGroups", r.Context(), "org1", groups).Return(tc.err)
+				hw.h.UpdateIdPGroups(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestUpdateLinks(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("invalid links provided", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			linksJSON   string
+			omErr       error
+		}{
+			{
+				"no links provided",
+				"",
+				nil,
+			},
+			{
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"invalid link url",
+				`[{"url": "invalid"}]`,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.linksJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				if tc.omErr != nil {
+					hw.om.On("UpdateLinks", r.Context(), "org1", mock.Anything).Return(tc.omErr)
+				}
+				hw.h.UpdateLinks(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("valid links provided", func(t *testing.T) {
+		linksJSON := `[{"url": "https://org1.com"}]`
+		links := []*hub.OrganizationLink{{URL: "https://org1.com"}}
 
 		testCases := []struct {
 			description        string
@@ -863,17 +1571,17 @@ func TestUpdateAuthorizationPolicy(t *testing.T) {
 			expectedStatusCode int
 		}{
 			{
-				"authorization policy update succeeded",
+				"organization links update succeeded",
 				nil,
 				http.StatusNoContent,
 			},
 			{
-				"error updating organization policy (insufficiente privilege)",
+				"error updating organization links (insufficient privilege)",
 				hub.ErrInsufficientPrivilege,
 				http.StatusForbidden,
 			},
 			{
-				"error updating organization policy (db error)",
+				"error updating organization links (db error)",
 				tests.ErrFakeDB,
 				http.StatusInternalServerError,
 			},
@@ -883,19 +1591,110 @@ func TestUpdateAuthorizationPolicy(t *testing.T) {
 			t.Run(tc.description, func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("PUT", "/", strings.NewReader(policyJSON))
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(linksJSON))
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-				rctx := &chi.Context{
-					URLParams: chi.RouteParams{
-						Keys:   []string{"orgName"},
-						Values: []string{"org1"},
-					},
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("UpdateLinks", r.Context(), "org1", links).Return(tc.err)
+				hw.h.UpdateLinks(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestUpdateMemberRole(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "userAlias"},
+			Values: []string{"org1", "user1"},
+		},
+	}
+
+	t.Run("invalid role provided", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			roleJSON    string
+			omErr       error
+		}{
+			{
+				"no role provided",
+				"",
+				nil,
+			},
+			{
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"invalid role",
+				`{"role": "invalid"}`,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.roleJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				if tc.omErr != nil {
+					hw.om.On("UpdateMemberRole", r.Context(), "org1", "user1", mock.Anything).Return(tc.omErr)
 				}
+				hw.h.UpdateMemberRole(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("valid role provided", func(t *testing.T) {
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"member role update succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error updating member role (insufficient privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error updating member role (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"role": "maintainer"}`))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.om.On("UpdateAuthorizationPolicy", r.Context(), "org1", policy).Return(tc.err)
-				hw.h.UpdateAuthorizationPolicy(w, r)
+				hw.om.On("UpdateMemberRole", r.Context(), "org1", "user1", hub.OrganizationMaintainer).Return(tc.err)
+				hw.h.UpdateMemberRole(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -957,6 +1756,56 @@ func TestGetUserAllowedActions(t *testing.T) {
 	})
 }
 
+func TestVerifyLinks(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	testCases := []struct {
+		description        string
+		err                error
+		expectedStatusCode int
+	}{
+		{
+			"organization links verification succeeded",
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			"error verifying organization links (insufficient privilege)",
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			"error verifying organization links (db error)",
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("PUT", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.om.On("VerifyLinks", r.Context(), "org1").Return(tc.err)
+			hw.h.VerifyLinks(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.om.AssertExpectations(t)
+		})
+	}
+}
+
 type handlersWrapper struct {
 	cfg *viper.Viper
 	om  *org.ManagerMock
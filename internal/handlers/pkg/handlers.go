@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"sort"
@@ -13,7 +14,9 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/testresults"
 	"github.com/artifacthub/hub/internal/tracker/source/helm"
+	"github.com/artifacthub/hub/internal/util"
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/feeds"
 	"github.com/rs/zerolog"
@@ -22,7 +25,8 @@ import (
 )
 
 const (
-	searchDefaultLimit = 20
+	changesDefaultLimit = 100
+	searchDefaultLimit  = 20
 )
 
 // Handlers represents a group of http handlers in charge of handling packages
@@ -33,6 +37,8 @@ type Handlers struct {
 	cfg         *viper.Viper
 	logger      zerolog.Logger
 	hc          hub.HTTPClient
+
+	kindsSettings map[hub.RepositoryKind]*hub.RepositoryKindSettings
 }
 
 // NewHandlers creates a new Handlers instance.
@@ -42,13 +48,22 @@ func NewHandlers(
 	cfg *viper.Viper,
 	hc hub.HTTPClient,
 ) *Handlers {
-	return &Handlers{
-		pkgManager:  pkgManager,
-		repoManager: repoManager,
-		cfg:         cfg,
-		logger:      log.With().Str("handlers", "pkg").Logger(),
-		hc:          hc,
+	h := &Handlers{
+		pkgManager:    pkgManager,
+		repoManager:   repoManager,
+		cfg:           cfg,
+		logger:        log.With().Str("handlers", "pkg").Logger(),
+		hc:            hc,
+		kindsSettings: make(map[hub.RepositoryKind]*hub.RepositoryKindSettings),
+	}
+	kindsSettings, err := util.GetRepositoryKindsSettings(cfg)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("error getting repository kinds settings, they won't be used")
+	}
+	for _, s := range kindsSettings {
+		h.kindsSettings[s.Kind] = s
 	}
+	return h
 }
 
 // Get is an http handler used to get a package details.
@@ -67,6 +82,20 @@ func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetByDigest is an http handler used to get some details of the package
+// version that matches the digest provided. It allows other hub instances to
+// resolve the canonical source of the packages they mirror.
+func (h *Handlers) GetByDigest(w http.ResponseWriter, r *http.Request) {
+	digest := chi.URLParam(r, "digest")
+	dataJSON, err := h.pkgManager.GetByDigestJSON(r.Context(), digest)
+	if err != nil {
+		h.logger.Error().Err(err).Str("digest", digest).Str("method", "GetByDigest").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // GetChangeLog is an http handler used to get a package's changelog.
 func (h *Handlers) GetChangeLog(w http.ResponseWriter, r *http.Request) {
 	packageID := chi.URLParam(r, "packageID")
@@ -79,6 +108,25 @@ func (h *Handlers) GetChangeLog(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetChanges is an http handler used to get the changes registered in the
+// packages catalog, allowing clients to keep a copy of it in sync.
+func (h *Handlers) GetChanges(w http.ResponseWriter, r *http.Request) {
+	input, err := buildChangesInput(r.URL.Query())
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetChanges").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, err := h.pkgManager.GetChangesJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetChanges").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // GetChartTemplates is an http handler used to get the templates for a given
 // given Helm chart package snapshot.
 func (h *Handlers) GetChartTemplates(w http.ResponseWriter, r *http.Request) {
@@ -118,6 +166,7 @@ func (h *Handlers) GetChartTemplates(w http.ResponseWriter, r *http.Request) {
 		r.Context(),
 		u,
 		&helm.LoadChartArchiveOptions{
+			Cfg:      h.cfg,
 			HC:       h.hc,
 			Username: username,
 			Password: password,
@@ -138,6 +187,58 @@ func (h *Handlers) GetChartTemplates(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 24*time.Hour, http.StatusOK)
 }
 
+// GetComparison is an http handler used to get a comparison matrix of the
+// packages provided.
+func (h *Handlers) GetComparison(w http.ResponseWriter, r *http.Request) {
+	pkgsIDs := r.URL.Query()["package_id"]
+	dataJSON, err := h.pkgManager.GetComparisonJSON(r.Context(), pkgsIDs)
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetComparison").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetDependenciesTree is an http handler used to get the dependencies tree of
+// a package's snapshot.
+func (h *Handlers) GetDependenciesTree(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	dataJSON, err := h.pkgManager.GetDependenciesTreeJSON(r.Context(), packageID, version)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetDependenciesTreeJSON").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetDiscovery is an http handler used to get the packages included in a
+// given discovery category.
+func (h *Handlers) GetDiscovery(w http.ResponseWriter, r *http.Request) {
+	category := chi.URLParam(r, "category")
+	dataJSON, err := h.pkgManager.GetDiscoveryJSON(r.Context(), category)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetDiscovery").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetEvents is an http handler used to get a package's events timeline.
+func (h *Handlers) GetEvents(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	dataJSON, err := h.pkgManager.GetEventsJSON(r.Context(), packageID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetEventsJSON").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // GetHarborReplicationDump is an http handler used to get a summary of all
 // available packages versions of kind Helm in the hub database so that they
 // can be synchronized in Harbor.
@@ -163,6 +264,21 @@ func (h *Handlers) GetRandom(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetSnapshotSBOM is an http handler used to get the SBOM of a package's
+// snapshot, in the format requested.
+func (h *Handlers) GetSnapshotSBOM(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	format := hub.SBOMFormat(r.FormValue("format"))
+	dataJSON, err := h.pkgManager.GetSnapshotSBOM(r.Context(), packageID, version, format)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSnapshotSBOM").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // GetSnapshotSecurityReport is an http handler used to get the security report
 // of a package's snapshot.
 func (h *Handlers) GetSnapshotSecurityReport(w http.ResponseWriter, r *http.Request) {
@@ -177,6 +293,47 @@ func (h *Handlers) GetSnapshotSecurityReport(w http.ResponseWriter, r *http.Requ
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetStaleByOrg is an http handler used to get the stale packages of the
+// organization provided.
+func (h *Handlers) GetStaleByOrg(w http.ResponseWriter, r *http.Request) {
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetStaleByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	result, err := h.pkgManager.GetStaleByOrgJSON(r.Context(), orgName, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetStaleByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
+// GetStaleByUser is an http handler used to get the stale packages of the
+// user doing the request.
+func (h *Handlers) GetStaleByUser(w http.ResponseWriter, r *http.Request) {
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetStaleByUser").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	result, err := h.pkgManager.GetStaleByUserJSON(r.Context(), p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetStaleByUser").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
 // GetStarredByUser is an http handler used to get the packages starred by the
 // user doing the request.
 func (h *Handlers) GetStarredByUser(w http.ResponseWriter, r *http.Request) {
@@ -237,6 +394,20 @@ func (h *Handlers) GetSummary(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetValuesDocs is an http handler used to get the values documentation of a
+// package's snapshot.
+func (h *Handlers) GetValuesDocs(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	dataJSON, err := h.pkgManager.GetValuesDocsJSON(r.Context(), packageID, version)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetValuesDocsJSON").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // GetValuesSchema is an http handler used to get the values schema of a
 // package's snapshot.
 func (h *Handlers) GetValuesSchema(w http.ResponseWriter, r *http.Request) {
@@ -251,6 +422,23 @@ func (h *Handlers) GetValuesSchema(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// Head is an http handler used to check if a package version exists, returning
+// its digest and when it was last modified as headers.
+func (h *Handlers) Head(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GetPackageInput{
+		RepositoryName: chi.URLParam(r, "repoName"),
+		PackageName:    chi.URLParam(r, "packageName"),
+		Version:        chi.URLParam(r, "version"),
+	}
+	head, err := h.pkgManager.GetHead(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Interface("input", input).Str("method", "Head").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderResourceHead(w, head)
+}
+
 // InjectIndexMeta is a middleware that injects the some index metadata related
 // to a given package,
 func (h *Handlers) InjectIndexMeta(next http.Handler) http.Handler {
@@ -352,6 +540,12 @@ func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
 		helpers.RenderErrorJSON(w, err)
 		return
 	}
+	if err := h.applyKindsSettings(input); err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Msg("invalid query")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
 	result, err := h.pkgManager.SearchJSON(r.Context(), input)
 	if err != nil {
 		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Send()
@@ -362,6 +556,36 @@ func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, result.Data, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// applyKindsSettings adjusts the search input provided using the repository
+// kinds settings. Packages of disabled kinds are excluded from the results
+// and, when the search is restricted to a single kind and no sort has been
+// requested, the default sort configured for that kind is used.
+func (h *Handlers) applyKindsSettings(input *hub.SearchPackageInput) error {
+	if len(input.RepositoryKinds) == 0 {
+		var enabledKinds []hub.RepositoryKind
+		for kind := hub.Helm; kind <= hub.Container; kind++ {
+			if s, ok := h.kindsSettings[kind]; !ok || s.Enabled {
+				enabledKinds = append(enabledKinds, kind)
+			}
+		}
+		if len(enabledKinds) < len(h.kindsSettings) {
+			input.RepositoryKinds = enabledKinds
+		}
+	} else {
+		for _, kind := range input.RepositoryKinds {
+			if s, ok := h.kindsSettings[kind]; ok && !s.Enabled {
+				return fmt.Errorf("kind disabled: %s", s.Name)
+			}
+		}
+	}
+	if input.Sort == "" && len(input.RepositoryKinds) == 1 {
+		if s, ok := h.kindsSettings[input.RepositoryKinds[0]]; ok {
+			input.Sort = s.DefaultSort
+		}
+	}
+	return nil
+}
+
 // SearchMonocular is an http handler used to search for packages in the hub
 // database that is compatible with the Monocular search API.
 func (h *Handlers) SearchMonocular(w http.ResponseWriter, r *http.Request) {
@@ -376,6 +600,32 @@ func (h *Handlers) SearchMonocular(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// SetTestResults is an http handler used to attach some test results (JSON
+// summary or JUnit XML report) to the provided package version.
+func (h *Handlers) SetTestResults(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(io.LimitReader(r.Body, testresults.MaxSize+1))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "SetTestResults").Msg("error reading body")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	tr, err := testresults.Parse(data)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "SetTestResults").Msg("invalid test results")
+		helpers.RenderErrorJSON(w, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error()))
+		return
+	}
+	repoName := chi.URLParam(r, "repoName")
+	pkgName := chi.URLParam(r, "packageName")
+	version := chi.URLParam(r, "version")
+	if err := h.pkgManager.SetTestResults(r.Context(), repoName, pkgName, version, tr); err != nil {
+		h.logger.Error().Err(err).Str("method", "SetTestResults").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // ToggleStar is an http handler used to toggle the star on a given package.
 func (h *Handlers) ToggleStar(w http.ResponseWriter, r *http.Request) {
 	packageID := chi.URLParam(r, "packageID")
@@ -388,6 +638,36 @@ func (h *Handlers) ToggleStar(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// buildChangesInput builds a packages changes query from a map of query
+// string values, validating them as they are extracted.
+func buildChangesInput(qs url.Values) (*hub.GetPackagesChangesInput, error) {
+	input := &hub.GetPackagesChangesInput{
+		Limit: changesDefaultLimit,
+	}
+	if qs.Get("since") != "" {
+		since, err := strconv.ParseInt(qs.Get("since"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since: %s", qs.Get("since"))
+		}
+		input.Since = since
+	}
+	if qs.Get("after") != "" {
+		after, err := strconv.ParseInt(qs.Get("after"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid after: %s", qs.Get("after"))
+		}
+		input.After = after
+	}
+	if qs.Get("limit") != "" {
+		limit, err := strconv.Atoi(qs.Get("limit"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit: %s", qs.Get("limit"))
+		}
+		input.Limit = limit
+	}
+	return input, nil
+}
+
 // buildSearchInput builds a packages search query from a map of query string
 // values, validating them as they are extracted.
 func buildSearchInput(qs url.Values) (*hub.SearchPackageInput, error) {
@@ -473,23 +753,51 @@ func buildSearchInput(qs url.Values) (*hub.SearchPackageInput, error) {
 		}
 	}
 
+	// Include only active (not stale) packages
+	var active bool
+	if qs.Get("active") != "" {
+		var err error
+		active, err = strconv.ParseBool(qs.Get("active"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid active: %s", qs.Get("active"))
+		}
+	}
+
+	// Only display packages compatible with the Kubernetes version provided
+	var compatibleKubeVersion string
+	if qs.Get("compatible_kube_version") != "" {
+		v, err := semver.NewVersion(qs.Get("compatible_kube_version"))
+		if err != nil || v.Major() != 1 {
+			return nil, fmt.Errorf("invalid compatible kube version: %s", qs.Get("compatible_kube_version"))
+		}
+		compatibleKubeVersion = fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+	}
+
 	return &hub.SearchPackageInput{
-		Limit:             limit,
-		Offset:            offset,
-		Facets:            facets,
-		TSQueryWeb:        qs.Get("ts_query_web"),
-		TSQuery:           qs.Get("ts_query"),
-		Users:             qs["user"],
-		Orgs:              qs["org"],
-		Repositories:      qs["repo"],
-		RepositoryKinds:   kinds,
-		VerifiedPublisher: verifiedPublisher,
-		Official:          official,
-		Operators:         operators,
-		Deprecated:        deprecated,
-		Licenses:          qs["license"],
-		Capabilities:      qs["capabilities"],
-		Sort:              qs.Get("sort"),
+		Limit:                 limit,
+		Offset:                offset,
+		Facets:                facets,
+		TSQueryWeb:            qs.Get("ts_query_web"),
+		TSQuery:               qs.Get("ts_query"),
+		Users:                 qs["user"],
+		Orgs:                  qs["org"],
+		Repositories:          qs["repo"],
+		RepositoryKinds:       kinds,
+		VerifiedPublisher:     verifiedPublisher,
+		Official:              official,
+		Operators:             operators,
+		Deprecated:            deprecated,
+		Active:                active,
+		Licenses:              qs["license"],
+		Categories:            qs["category"],
+		Capabilities:          qs["capabilities"],
+		Labels:                qs["label"],
+		KubeVersions:          qs["kube_version"],
+		CompatibleKubeVersion: compatibleKubeVersion,
+		ChartTypes:            qs["chart_type"],
+		Platforms:             qs["platform"],
+		TaskParams:            qs["task_param"],
+		Sort:                  qs.Get("sort"),
 	}, nil
 }
 
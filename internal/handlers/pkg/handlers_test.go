@@ -103,6 +103,70 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestGetByDigest(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"digest"},
+			Values: []string{"digest1"},
+		},
+	}
+
+	t.Run("get package by digest succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetByDigestJSON", r.Context(), "digest1").Return([]byte("dataJSON"), nil)
+		hw.h.GetByDigest(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting package by digest", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetByDigestJSON", r.Context(), "digest1").Return(nil, tc.err)
+				hw.h.GetByDigest(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestGetChangeLog(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -149,6 +213,73 @@ func TestGetChangeLog(t *testing.T) {
 	})
 }
 
+func TestGetChanges(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []string{
+			"since=a",
+			"after=b",
+			"limit=c",
+		}
+		for _, qs := range testCases {
+			qs := qs
+			t.Run(qs, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?"+qs, nil)
+
+				hw := newHandlersWrapper()
+				hw.h.GetChanges(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get changes succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?since=1592299233&after=10", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetChangesJSON", r.Context(), &hub.GetPackagesChangesInput{
+			Since: 1592299233,
+			After: 10,
+			Limit: changesDefaultLimit,
+		}).Return([]byte("dataJSON"), nil)
+		hw.h.GetChanges(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting changes", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetChangesJSON", r.Context(), &hub.GetPackagesChangesInput{
+			Limit: 10,
+		}).Return(nil, tests.ErrFakeDB)
+		hw.h.GetChanges(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
 func TestGetChartTemplates(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -358,15 +489,17 @@ func TestGetChartTemplates(t *testing.T) {
 	})
 }
 
-func TestGetHarborReplicationDump(t *testing.T) {
-	t.Run("get harbor replication dump succeeded", func(t *testing.T) {
+func TestGetComparison(t *testing.T) {
+	pkgsIDs := []string{"pkg1", "pkg2"}
+
+	t.Run("get packages comparison succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
+		r, _ := http.NewRequest("GET", "/?package_id=pkg1&package_id=pkg2", nil)
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetHarborReplicationDumpJSON", r.Context()).Return([]byte("dataJSON"), nil)
-		hw.h.GetHarborReplicationDump(w, r)
+		hw.pm.On("GetComparisonJSON", r.Context(), pkgsIDs).Return([]byte("dataJSON"), nil)
+		hw.h.GetComparison(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 		h := resp.Header
@@ -374,36 +507,62 @@ func TestGetHarborReplicationDump(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 		assert.Equal(t, "application/json", h.Get("Content-Type"))
-		assert.Equal(t, helpers.BuildCacheControlHeader(1*time.Hour), h.Get("Cache-Control"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
 		assert.Equal(t, []byte("dataJSON"), data)
 		hw.assertExpectations(t)
 	})
 
-	t.Run("error getting harbor replication dump", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
+	t.Run("error getting packages comparison", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?package_id=pkg1&package_id=pkg2", nil)
 
-		hw := newHandlersWrapper()
-		hw.pm.On("GetHarborReplicationDumpJSON", r.Context()).Return(nil, tests.ErrFakeDB)
-		hw.h.GetHarborReplicationDump(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
+				hw := newHandlersWrapper()
+				hw.pm.On("GetComparisonJSON", r.Context(), pkgsIDs).Return(nil, tc.err)
+				hw.h.GetComparison(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
 
-		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
-		hw.assertExpectations(t)
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
 	})
 }
 
-func TestGetRandom(t *testing.T) {
-	t.Run("get random packages succeeded", func(t *testing.T) {
+func TestGetDependenciesTree(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("get dependencies tree succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetRandomJSON", r.Context()).Return([]byte("dataJSON"), nil)
-		hw.h.GetRandom(w, r)
+		hw.pm.On("GetDependenciesTreeJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
+		hw.h.GetDependenciesTree(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 		h := resp.Header
@@ -416,39 +575,122 @@ func TestGetRandom(t *testing.T) {
 		hw.assertExpectations(t)
 	})
 
-	t.Run("error getting random packages", func(t *testing.T) {
+	t.Run("error getting dependencies tree", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetDependenciesTreeJSON", r.Context(), "pkg1", "1.0.0").Return(nil, tc.err)
+				hw.h.GetDependenciesTree(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetDiscovery(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"category"},
+			Values: []string{"hidden-gems"},
+		},
+	}
+
+	t.Run("get discovery packages succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetRandomJSON", r.Context()).Return(nil, tests.ErrFakeDB)
-		hw.h.GetRandom(w, r)
+		hw.pm.On("GetDiscoveryJSON", r.Context(), "hidden-gems").Return([]byte("dataJSON"), nil)
+		hw.h.GetDiscovery(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
 
-		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
 		hw.assertExpectations(t)
 	})
+
+	t.Run("error getting discovery packages", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetDiscoveryJSON", r.Context(), "hidden-gems").Return(nil, tc.err)
+				hw.h.GetDiscovery(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
 }
 
-func TestGetSnapshotSecurityReport(t *testing.T) {
+func TestGetEvents(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
-			Keys:   []string{"packageID", "version"},
-			Values: []string{"pkg1", "1.0.0"},
+			Keys:   []string{"packageID"},
+			Values: []string{"pkg1"},
 		},
 	}
 
-	t.Run("get snapshot security report succeeded", func(t *testing.T) {
+	t.Run("get events succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetSnapshotSecurityReportJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
-		hw.h.GetSnapshotSecurityReport(w, r)
+		hw.pm.On("GetEventsJSON", r.Context(), "pkg1").Return([]byte("dataJSON"), nil)
+		hw.h.GetEvents(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 		h := resp.Header
@@ -461,15 +703,15 @@ func TestGetSnapshotSecurityReport(t *testing.T) {
 		hw.assertExpectations(t)
 	})
 
-	t.Run("error getting snapshot security report", func(t *testing.T) {
+	t.Run("error getting events", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetSnapshotSecurityReportJSON", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
-		hw.h.GetSnapshotSecurityReport(w, r)
+		hw.pm.On("GetEventsJSON", r.Context(), "pkg1").Return(nil, tests.ErrFakeDB)
+		hw.h.GetEvents(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
@@ -478,23 +720,348 @@ func TestGetSnapshotSecurityReport(t *testing.T) {
 	})
 }
 
-func TestGetStarredByUser(t *testing.T) {
-	t.Run("get packages starred by user succeeded", func(t *testing.T) {
+func TestGetHarborReplicationDump(t *testing.T) {
+	t.Run("get harbor replication dump succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
-		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r, _ := http.NewRequest("GET", "/", nil)
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetStarredByUserJSON", r.Context(), &hub.Pagination{
-			Limit:  10,
-			Offset: 1,
-		}).Return(&hub.JSONQueryResult{
-			Data:       []byte("dataJSON"),
-			TotalCount: 1,
-		}, nil)
-		hw.h.GetStarredByUser(w, r)
-		resp := w.Result()
+		hw.pm.On("GetHarborReplicationDumpJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetHarborReplicationDump(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(1*time.Hour), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting harbor replication dump", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetHarborReplicationDumpJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetHarborReplicationDump(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetRandom(t *testing.T) {
+	t.Run("get random packages succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetRandomJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetRandom(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting random packages", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetRandomJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetRandom(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetSnapshotSBOM(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("get snapshot sbom succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?format=cyclonedx", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotSBOM", r.Context(), "pkg1", "1.0.0", hub.CycloneDX).Return([]byte("dataJSON"), nil)
+		hw.h.GetSnapshotSBOM(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting snapshot sbom", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSnapshotSBOM", r.Context(), "pkg1", "1.0.0", hub.SBOMFormat("")).Return(nil, tc.err)
+				hw.h.GetSnapshotSBOM(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetSnapshotSecurityReport(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("get snapshot security report succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotSecurityReportJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
+		hw.h.GetSnapshotSecurityReport(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting snapshot security report", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotSecurityReportJSON", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		hw.h.GetSnapshotSecurityReport(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetStaleByOrg(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("invalid pagination", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=z", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.GetStaleByOrg(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("get organization stale packages succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetStaleByOrgJSON", r.Context(), "org1", &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetStaleByOrg(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting organization stale packages", func(t *testing.T) {
+		testCases := []struct {
+			err            error
+			expectedStatus int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetStaleByOrgJSON", r.Context(), "org1", &hub.Pagination{
+					Limit:  10,
+					Offset: 1,
+				}).Return(nil, tc.err)
+				hw.h.GetStaleByOrg(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetStaleByUser(t *testing.T) {
+	t.Run("get user stale packages succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetStaleByUserJSON", r.Context(), &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetStaleByUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting user stale packages", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetStaleByUserJSON", r.Context(), &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(nil, tests.ErrFakeDB)
+		hw.h.GetStaleByUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetStarredByUser(t *testing.T) {
+	t.Run("get packages starred by user succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetStarredByUserJSON", r.Context(), &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetStarredByUser(w, r)
+		resp := w.Result()
 		defer resp.Body.Close()
 		h := resp.Header
 		data, _ := ioutil.ReadAll(resp.Body)
@@ -702,6 +1269,52 @@ func TestGetSummary(t *testing.T) {
 	})
 }
 
+func TestGetValuesDocs(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("get values docs succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetValuesDocsJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
+		hw.h.GetValuesDocs(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting values docs", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetValuesDocsJSON", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		hw.h.GetValuesDocs(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
 func TestGetValuesSchema(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -716,34 +1329,109 @@ func TestGetValuesSchema(t *testing.T) {
 		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
-		hw := newHandlersWrapper()
-		hw.pm.On("GetValuesSchemaJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
-		hw.h.GetValuesSchema(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
-		h := resp.Header
-		data, _ := ioutil.ReadAll(resp.Body)
+		hw := newHandlersWrapper()
+		hw.pm.On("GetValuesSchemaJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
+		hw.h.GetValuesSchema(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting values schema", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetValuesSchemaJSON", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		hw.h.GetValuesSchema(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestHead(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName", "packageName", "version"},
+			Values: []string{"repo1", "pkg1", "1.0.0"},
+		},
+	}
+	getPkgInput := &hub.GetPackageInput{
+		RepositoryName: "repo1",
+		PackageName:    "pkg1",
+		Version:        "1.0.0",
+	}
+
+	t.Run("get package head failed", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("HEAD", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetHead", r.Context(), getPkgInput).Return(nil, tc.pmErr)
+				hw.h.Head(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
 
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		assert.Equal(t, "application/json", h.Get("Content-Type"))
-		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
-		assert.Equal(t, []byte("dataJSON"), data)
-		hw.assertExpectations(t)
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
 	})
 
-	t.Run("error getting values schema", func(t *testing.T) {
+	t.Run("get package head succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
+		r, _ := http.NewRequest("HEAD", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetValuesSchemaJSON", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
-		hw.h.GetValuesSchema(w, r)
+		hw.pm.On("GetHead", r.Context(), getPkgInput).Return(&hub.ResourceHead{
+			Digest:       "digest",
+			LastModified: 1592299233,
+		}, nil)
+		hw.h.Head(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
+		h := resp.Header
 
-		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, `"digest"`, h.Get("ETag"))
+		assert.Equal(t, "Tue, 16 Jun 2020 09:20:33 GMT", h.Get("Last-Modified"))
 		hw.assertExpectations(t)
 	})
 }
@@ -963,6 +1651,9 @@ func TestSearch(t *testing.T) {
 			{"invalid official", "official=z"},
 			{"invalid operators", "operators=z"},
 			{"invalid deprecated", "deprecated=z"},
+			{"invalid active", "active=z"},
+			{"invalid compatible kube version", "compatible_kube_version=z"},
+			{"invalid compatible kube version (major)", "compatible_kube_version=2.0"},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -1016,31 +1707,47 @@ func TestSearch(t *testing.T) {
 		v.Set("official", "true")
 		v.Set("operators", "true")
 		v.Set("deprecated", "true")
+		v.Set("active", "true")
 		v.Add("license", "l1")
 		v.Add("license", "l2")
+		v.Add("category", "database")
 		v.Add("capabilities", "c1")
 		v.Add("capabilities", "c2")
+		v.Add("label", "l1")
+		v.Add("kube_version", ">=1.16.0-0")
+		v.Set("compatible_kube_version", "v1.27.3")
+		v.Add("chart_type", "application")
+		v.Add("platform", "linux/amd64")
+		v.Add("task_param", "url")
 		v.Set("sort", "stars")
 		r, _ := http.NewRequest("GET", "/?"+v.Encode(), nil)
 
 		hw := newHandlersWrapper()
 		hw.pm.On("SearchJSON", r.Context(), &hub.SearchPackageInput{
-			Limit:             10,
-			Offset:            10,
-			Facets:            true,
-			TSQueryWeb:        "q1",
-			TSQuery:           "q2",
-			Users:             []string{"u1", "u2"},
-			Orgs:              []string{"o1", "o2"},
-			Repositories:      []string{"r1", "r2"},
-			RepositoryKinds:   []hub.RepositoryKind{hub.Helm, hub.OLM},
-			VerifiedPublisher: true,
-			Official:          true,
-			Operators:         true,
-			Deprecated:        true,
-			Licenses:          []string{"l1", "l2"},
-			Capabilities:      []string{"c1", "c2"},
-			Sort:              "stars",
+			Limit:                 10,
+			Offset:                10,
+			Facets:                true,
+			TSQueryWeb:            "q1",
+			TSQuery:               "q2",
+			Users:                 []string{"u1", "u2"},
+			Orgs:                  []string{"o1", "o2"},
+			Repositories:          []string{"r1", "r2"},
+			RepositoryKinds:       []hub.RepositoryKind{hub.Helm, hub.OLM},
+			VerifiedPublisher:     true,
+			Official:              true,
+			Operators:             true,
+			Deprecated:            true,
+			Active:                true,
+			Licenses:              []string{"l1", "l2"},
+			Categories:            []string{"database"},
+			Capabilities:          []string{"c1", "c2"},
+			Labels:                []string{"l1"},
+			KubeVersions:          []string{">=1.16.0-0"},
+			CompatibleKubeVersion: "1.27",
+			ChartTypes:            []string{"application"},
+			Platforms:             []string{"linux/amd64"},
+			TaskParams:            []string{"url"},
+			Sort:                  "stars",
 		}).Return(&hub.JSONQueryResult{
 			Data:       []byte("dataJSON"),
 			TotalCount: 1,
@@ -1059,6 +1766,92 @@ func TestSearch(t *testing.T) {
 		hw.assertExpectations(t)
 	})
 
+	t.Run("disabled kind requested", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?kind=1", nil)
+
+		hw := newHandlersWrapper()
+		hw.cfg.Set("packages.kinds.falco.enabled", false)
+		hw.h = NewHandlers(hw.pm, hw.rm, hw.cfg, hw.hc)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("disabled kinds are excluded when no kind is requested", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.cfg.Set("packages.kinds.falco.enabled", false)
+		hw.h = NewHandlers(hw.pm, hw.rm, hw.cfg, hw.hc)
+		hw.pm.On("SearchJSON", r.Context(), &hub.SearchPackageInput{
+			Limit: searchDefaultLimit,
+			RepositoryKinds: []hub.RepositoryKind{
+				hub.Helm,
+				hub.OPA,
+				hub.OLM,
+				hub.TBAction,
+				hub.Krew,
+				hub.HelmPlugin,
+				hub.TektonTask,
+				hub.KedaScaler,
+				hub.CoreDNS,
+				hub.Keptn,
+				hub.Kustomize,
+				hub.Terraform,
+				hub.Crossplane,
+				hub.Kyverno,
+				hub.Gatekeeper,
+				hub.ArgoWorkflowTemplate,
+				hub.Backstage,
+				hub.Wasm,
+				hub.Devcontainer,
+				hub.Headlamp,
+				hub.Knative,
+				hub.Container,
+			},
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("kind default sort is used when no sort is requested", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?kind=3", nil)
+
+		hw := newHandlersWrapper()
+		hw.cfg.Set("packages.kinds.olm.defaultSort", "stars")
+		hw.h = NewHandlers(hw.pm, hw.rm, hw.cfg, hw.hc)
+		hw.pm.On("SearchJSON", r.Context(), &hub.SearchPackageInput{
+			Limit:           searchDefaultLimit,
+			RepositoryKinds: []hub.RepositoryKind{hub.OLM},
+			Sort:            "stars",
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
 	t.Run("error searching packages", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
@@ -1112,6 +1905,86 @@ func TestSearchMonocular(t *testing.T) {
 	})
 }
 
+func TestSetTestResults(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName", "packageName", "version"},
+			Values: []string{"repo1", "pkg1", "1.0.0"},
+		},
+	}
+	trJSON := `{"tool": "helm-unittest", "passed": 10, "failed": 0, "skipped": 1}`
+	tr := &hub.TestResults{Tool: "helm-unittest", Passed: 10, Skipped: 1}
+
+	t.Run("invalid test results provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("invalid"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.SetTestResults(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error setting test results", func(t *testing.T) {
+		testCases := []struct {
+			err            error
+			expectedStatus int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(trJSON))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("SetTestResults", r.Context(), "repo1", "pkg1", "1.0.0", tr).Return(tc.err)
+				hw.h.SetTestResults(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("test results set successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(trJSON))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("SetTestResults", r.Context(), "repo1", "pkg1", "1.0.0", tr).Return(nil)
+		hw.h.SetTestResults(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
 func TestToggleStar(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -1312,6 +2185,138 @@ func TestBuildPackageURL(t *testing.T) {
 			"2.0.0",
 			baseURL + "/packages/keptn/repo1/pkg1/2.0.0",
 		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Kustomize,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/kustomize/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Terraform,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/terraform/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Crossplane,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/crossplane/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Kyverno,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/kyverno/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Gatekeeper,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/gatekeeper/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.ArgoWorkflowTemplate,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/argo-workflow-template/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Backstage,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/backstage/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Wasm,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/wasm/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Devcontainer,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/devcontainer/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Headlamp,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/headlamp/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Knative,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/knative/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Container,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/container/repo1/pkg1/2.0.0",
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -1323,10 +2328,11 @@ func TestBuildPackageURL(t *testing.T) {
 }
 
 type handlersWrapper struct {
-	pm *pkg.ManagerMock
-	rm *repo.ManagerMock
-	hc *tests.HTTPClientMock
-	h  *Handlers
+	cfg *viper.Viper
+	pm  *pkg.ManagerMock
+	rm  *repo.ManagerMock
+	hc  *tests.HTTPClientMock
+	h   *Handlers
 }
 
 func newHandlersWrapper() *handlersWrapper {
@@ -1337,10 +2343,11 @@ func newHandlersWrapper() *handlersWrapper {
 	hc := &tests.HTTPClientMock{}
 
 	return &handlersWrapper{
-		pm: pm,
-		rm: rm,
-		hc: hc,
-		h:  NewHandlers(pm, rm, cfg, hc),
+		cfg: cfg,
+		pm:  pm,
+		rm:  rm,
+		hc:  hc,
+		h:   NewHandlers(pm, rm, cfg, hc),
 	}
 }
 
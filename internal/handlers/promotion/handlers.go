@@ -0,0 +1,111 @@
+package promotion
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling promoted
+// packages operations.
+type Handlers struct {
+	promotedPkgManager hub.PromotedPackageManager
+	logger             zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(promotedPkgManager hub.PromotedPackageManager) *Handlers {
+	return &Handlers{
+		promotedPkgManager: promotedPkgManager,
+		logger:             log.With().Str("handlers", "promotion").Logger(),
+	}
+}
+
+// Add is an http handler that promotes the provided package during the period
+// given.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	pp := &hub.PromotedPackage{}
+	if err := json.NewDecoder(r.Body).Decode(&pp); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	promotedPackageID, err := h.promotedPkgManager.Add(r.Context(), pp)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(map[string]string{
+		"promoted_package_id": promotedPackageID,
+	})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
+}
+
+// Delete is an http handler that deletes the provided package promotion.
+func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
+	promotedPackageID := chi.URLParam(r, "promotedPackageID")
+	if err := h.promotedPkgManager.Delete(r.Context(), promotedPackageID); err != nil {
+		h.logger.Error().Err(err).Str("method", "Delete").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Get is an http handler that returns the packages currently promoted.
+func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.promotedPkgManager.GetJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetStats is an http handler that returns all the package promotions,
+// including the impressions and clicks registered on them.
+func (h *Handlers) GetStats(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.promotedPkgManager.GetStatsJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetStats").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// RegisterClick is an http handler that registers a click on the provided
+// package promotion.
+func (h *Handlers) RegisterClick(w http.ResponseWriter, r *http.Request) {
+	h.registerEvent(w, r, hub.PromotedPackageClick, "RegisterClick")
+}
+
+// RegisterImpression is an http handler that registers an impression on the
+// provided package promotion.
+func (h *Handlers) RegisterImpression(w http.ResponseWriter, r *http.Request) {
+	h.registerEvent(w, r, hub.PromotedPackageImpression, "RegisterImpression")
+}
+
+// registerEvent registers an event of the kind provided on the package
+// promotion given.
+func (h *Handlers) registerEvent(
+	w http.ResponseWriter,
+	r *http.Request,
+	event hub.PromotedPackageEvent,
+	method string,
+) {
+	promotedPackageID := chi.URLParam(r, "promotedPackageID")
+	if err := h.promotedPkgManager.RegisterEvent(r.Context(), promotedPackageID, event); err != nil {
+		h.logger.Error().Err(err).Str("method", method).Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,7 +2,10 @@ package repo
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -17,11 +20,34 @@ import (
 )
 
 const (
+	// PublishTokenIDHeader represents the header used to provide a publish
+	// token ID.
+	PublishTokenIDHeader = "X-PUBLISH-TOKEN-ID"
+
+	// PublishTokenSecretHeader represents the header used to provide a
+	// publish token secret.
+	PublishTokenSecretHeader = "X-PUBLISH-TOKEN-SECRET" // #nosec
+
+	githubEventHeader     = "X-GitHub-Event"
+	githubSignatureHeader = "X-Hub-Signature-256"
+	gitlabTokenHeader     = "X-Gitlab-Token" // #nosec
+	webhookMaxPayloadSize = 1 << 20
+
 	logoSVG            = `<svg xmlns="http://www.w3.org/2000/svg" width="14" height="14" viewBox="0 0 24 24" fill="none" stroke="#ffffff" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="feather feather-hexagon"><path d="M21 16V8a2 2 0 0 0-1-1.73l-7-4a2 2 0 0 0-2 0l-7 4A2 2 0 0 0 3 8v8a2 2 0 0 0 1 1.73l7 4a2 2 0 0 0 2 0l7-4A2 2 0 0 0 21 16z"></path></svg>`
 	searchDefaultLimit = 20
 	searchMaxLimit     = 60
 )
 
+var (
+	// errInvalidPublishToken error indicates that the publish token provided
+	// is not valid.
+	errInvalidPublishToken = errors.New("invalid publish token")
+
+	// errInvalidWebhookSignature error indicates that the signature of the
+	// webhook received is not valid.
+	errInvalidWebhookSignature = errors.New("invalid webhook signature")
+)
+
 // Handlers represents a group of http handlers in charge of handling
 // repositories operations.
 type Handlers struct {
@@ -39,6 +65,18 @@ func NewHandlers(cfg *viper.Viper, repoManager hub.RepositoryManager) *Handlers
 	}
 }
 
+// AcceptTransfer is an http handler that accepts a pending transfer of the
+// provided repository.
+func (h *Handlers) AcceptTransfer(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.AcceptTransfer(r.Context(), repoName); err != nil {
+		h.logger.Error().Err(err).Str("method", "AcceptTransfer").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Add is an http handler that adds the provided repository to the database.
 func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
 	orgName := chi.URLParam(r, "orgName")
@@ -56,6 +94,36 @@ func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// AddPublishToken is an http handler that adds a new publish token to the
+// provided repository.
+func (h *Handlers) AddPublishToken(w http.ResponseWriter, r *http.Request) {
+	t := &hub.RepositoryPublishToken{}
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddPublishToken").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	t, err := h.repoManager.AddPublishToken(r.Context(), chi.URLParam(r, "repoName"), t)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "AddPublishToken").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(t)
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
+}
+
+// Archive is an http handler that archives the provided repository.
+func (h *Handlers) Archive(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.SetArchived(r.Context(), repoName, true); err != nil {
+		h.logger.Error().Err(err).Str("method", "Archive").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Badge is an http handler that returns the information needed to render the
 // repository badge.
 func (h *Handlers) Badge(w http.ResponseWriter, r *http.Request) {
@@ -78,6 +146,25 @@ func (h *Handlers) Badge(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// Check is an http handler that performs a dry validation of the repository
+// provided, returning the diagnostics of the checks performed.
+func (h *Handlers) Check(w http.ResponseWriter, r *http.Request) {
+	repo := &hub.Repository{}
+	if err := json.NewDecoder(r.Body).Decode(&repo); err != nil {
+		h.logger.Error().Err(err).Str("method", "Check").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	rc, err := h.repoManager.Check(r.Context(), repo)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Check").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(rc)
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // CheckAvailability is an http handler that checks the availability of a given
 // value for the provided resource kind.
 func (h *Handlers) CheckAvailability(w http.ResponseWriter, r *http.Request) {
@@ -123,6 +210,175 @@ func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// DeletePublishToken is an http handler that deletes the provided publish
+// token from the repository given.
+func (h *Handlers) DeletePublishToken(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	publishTokenID := chi.URLParam(r, "publishTokenID")
+	if err := h.repoManager.DeletePublishToken(r.Context(), repoName, publishTokenID); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeletePublishToken").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GenerateWebhookSecret is an http handler that generates a new secret for
+// the webhooks used to trigger the tracking of the provided repository.
+func (h *Handlers) GenerateWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	secret, err := h.repoManager.GenerateWebhookSecret(r.Context(), chi.URLParam(r, "repoName"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GenerateWebhookSecret").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(map[string]string{"secret": secret})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetPublishTokens is an http handler that returns the publish tokens of the
+// provided repository.
+func (h *Handlers) GetPublishTokens(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.repoManager.GetPublishTokensJSON(r.Context(), chi.URLParam(r, "repoName"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetPublishTokens").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetStats is an http handler that returns some stats about the provided
+// repository, like the number of versions tracked or the tracking error rate.
+func (h *Handlers) GetStats(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.repoManager.GetStatsJSON(r.Context(), chi.URLParam(r, "repoName"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetStats").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetTrackingRuns is an http handler that returns the tracking runs of the
+// provided repository, which can be used to debug why a given package version
+// is not available.
+func (h *Handlers) GetTrackingRuns(w http.ResponseWriter, r *http.Request) {
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetTrackingRuns").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	result, err := h.repoManager.GetTrackingRunsJSON(r.Context(), chi.URLParam(r, "repoName"), p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetTrackingRuns").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
+// GetTransfers is an http handler that returns the pending repository
+// transfers targeting the requesting user or the organizations the user
+// belongs to.
+func (h *Handlers) GetTransfers(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.repoManager.GetTransfersJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetTransfers").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Head is an http handler used to check if a repository exists, returning its
+// digest and when it was last tracked as headers.
+func (h *Handlers) Head(w http.ResponseWriter, r *http.Request) {
+	head, err := h.repoManager.GetHead(r.Context(), chi.URLParam(r, "repoName"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Head").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderResourceHead(w, head)
+}
+
+// PauseTracking is an http handler that pauses the tracking of the provided
+// repository.
+func (h *Handlers) PauseTracking(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.SetTrackingPaused(r.Context(), repoName, true); err != nil {
+		h.logger.Error().Err(err).Str("method", "PauseTracking").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RejectTransfer is an http handler that rejects a pending transfer of the
+// provided repository.
+func (h *Handlers) RejectTransfer(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.RejectTransfer(r.Context(), repoName); err != nil {
+		h.logger.Error().Err(err).Str("method", "RejectTransfer").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequestTransfer is an http handler that registers a request to transfer the
+// provided repository to a different user or organization.
+func (h *Handlers) RequestTransfer(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	userAlias := r.FormValue("user")
+	orgName := r.FormValue("org")
+	if err := h.repoManager.RequestTransfer(r.Context(), repoName, userAlias, orgName); err != nil {
+		h.logger.Error().Err(err).Str("method", "RequestTransfer").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// RequirePublishToken is a middleware that verifies that a valid publish
+// token for the repository in the request has been provided.
+func (h *Handlers) RequirePublishToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		valid, err := h.repoManager.CheckPublishToken(
+			r.Context(),
+			chi.URLParam(r, "repoName"),
+			r.Header.Get(PublishTokenIDHeader),
+			r.Header.Get(PublishTokenSecretHeader),
+		)
+		if err != nil && !errors.Is(err, hub.ErrInvalidInput) {
+			h.logger.Error().Err(err).Str("method", "RequirePublishToken").Send()
+			helpers.RenderErrorWithCodeJSON(w, nil, http.StatusInternalServerError)
+			return
+		}
+		if !valid {
+			helpers.RenderErrorWithCodeJSON(w, errInvalidPublishToken, http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ResumeTracking is an http handler that resumes the tracking of the provided
+// repository.
+func (h *Handlers) ResumeTracking(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.SetTrackingPaused(r.Context(), repoName, false); err != nil {
+		h.logger.Error().Err(err).Str("method", "ResumeTracking").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Search is an http handler used to search for repositories in the hub
 // database.
 func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
@@ -156,6 +412,80 @@ func (h *Handlers) Transfer(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// TriggerTracking is an http handler that requests the tracking of the
+// provided repository. It's meant to be used with a publish token.
+func (h *Handlers) TriggerTracking(w http.ResponseWriter, r *http.Request) {
+	if err := h.repoManager.TriggerTracking(r.Context(), chi.URLParam(r, "repoName")); err != nil {
+		h.logger.Error().Err(err).Str("method", "TriggerTracking").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TrackingWebhook is an http handler that triggers the tracking of the
+// provided repository when a webhook sent by GitHub, GitLab or Harbor is
+// received and its signature is valid.
+func (h *Handlers) TrackingWebhook(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	payload, err := ioutil.ReadAll(io.LimitReader(r.Body, webhookMaxPayloadSize))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "TrackingWebhook").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+
+	// Check webhook signature
+	var provider hub.RepositoryWebhookProvider
+	var signature string
+	switch {
+	case r.Header.Get(githubSignatureHeader) != "":
+		provider, signature = hub.GitHubWebhook, r.Header.Get(githubSignatureHeader)
+	case r.Header.Get(gitlabTokenHeader) != "":
+		provider, signature = hub.GitLabWebhook, r.Header.Get(gitlabTokenHeader)
+	case r.Header.Get("Authorization") != "":
+		provider, signature = hub.HarborWebhook, r.Header.Get("Authorization")
+	default:
+		helpers.RenderErrorWithCodeJSON(w, errInvalidWebhookSignature, http.StatusUnauthorized)
+		return
+	}
+	valid, err := h.repoManager.CheckWebhookSignature(r.Context(), repoName, provider, signature, payload)
+	if err != nil && !errors.Is(err, hub.ErrInvalidInput) {
+		h.logger.Error().Err(err).Str("method", "TrackingWebhook").Send()
+		helpers.RenderErrorWithCodeJSON(w, nil, http.StatusInternalServerError)
+		return
+	}
+	if !valid {
+		helpers.RenderErrorWithCodeJSON(w, errInvalidWebhookSignature, http.StatusUnauthorized)
+		return
+	}
+
+	// GitHub sends a ping event when the webhook is created, nothing to do
+	if r.Header.Get(githubEventHeader) == "ping" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// Request repository tracking
+	if err := h.repoManager.TriggerTracking(r.Context(), repoName); err != nil {
+		h.logger.Error().Err(err).Str("method", "TrackingWebhook").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Unarchive is an http handler that unarchives the provided repository.
+func (h *Handlers) Unarchive(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.SetArchived(r.Context(), repoName, false); err != nil {
+		h.logger.Error().Err(err).Str("method", "Unarchive").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Update is an http handler that updates the provided repository in the
 // database.
 func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
@@ -174,6 +504,36 @@ func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UpdatePublishMetadata is an http handler that updates the metadata of the
+// provided repository that can be managed using a publish token.
+func (h *Handlers) UpdatePublishMetadata(w http.ResponseWriter, r *http.Request) {
+	md := &hub.RepositoryPublishMetadata{}
+	if err := json.NewDecoder(r.Body).Decode(&md); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdatePublishMetadata").Msg("invalid repository metadata")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.UpdatePublishMetadata(r.Context(), repoName, md); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdatePublishMetadata").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyDomain is an http handler that verifies the ownership of the domain
+// hosting the provided repository.
+func (h *Handlers) VerifyDomain(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.VerifyDomain(r.Context(), repoName); err != nil {
+		h.logger.Error().Err(err).Str("method", "VerifyDomain").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // buildSearchInput builds a packages search query from a map of query string
 // values, validating them as they are extracted.
 func buildSearchInput(qs url.Values) (*hub.SearchRepositoryInput, error) {
@@ -217,6 +577,7 @@ func buildSearchInput(qs url.Values) (*hub.SearchRepositoryInput, error) {
 		Kinds:              kinds,
 		Orgs:               qs["org"],
 		Users:              qs["user"],
+		Labels:             qs["label"],
 		IncludeCredentials: false,
 		Limit:              limit,
 		Offset:             offset,
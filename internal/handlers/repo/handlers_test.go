@@ -28,6 +28,75 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+func TestAcceptTransfer(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("accept repository transfer succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("AcceptTransfer", r.Context(), "repo1").Return(nil)
+		hw.h.AcceptTransfer(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error accepting repository transfer", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("AcceptTransfer", r.Context(), "repo1").Return(tc.rmErr)
+				hw.h.AcceptTransfer(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestAdd(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -135,6 +204,168 @@ func TestAdd(t *testing.T) {
 	})
 }
 
+func TestAddPublishToken(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+	ptJSON := `{"name": "token1"}`
+	pt := &hub.RepositoryPublishToken{Name: "token1"}
+
+	t.Run("invalid publish token provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.AddPublishToken(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error adding publish token", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(ptJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("AddPublishToken", r.Context(), "repo1", pt).Return(nil, tc.rmErr)
+				hw.h.AddPublishToken(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("publish token added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(ptJSON))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		ptOUT := &hub.RepositoryPublishToken{
+			PublishTokenID: "publishTokenID",
+			Name:           "token1",
+			Secret:         "secret",
+		}
+		hw.rm.On("AddPublishToken", r.Context(), "repo1", pt).Return(ptOUT, nil)
+		hw.h.AddPublishToken(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.JSONEq(t, `{"publish_token_id": "publishTokenID", "name": "token1", "secret": "secret"}`, string(data))
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestArchive(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("repository archived", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("SetArchived", r.Context(), "repo1", true).Return(nil)
+		hw.h.Archive(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error archiving repository", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("SetArchived", r.Context(), "repo1", true).Return(tc.rmErr)
+				hw.h.Archive(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestBadge(t *testing.T) {
 	t.Run("badge info returned successfully", func(t *testing.T) {
 		t.Parallel()
@@ -163,6 +394,94 @@ func TestBadge(t *testing.T) {
 	})
 }
 
+func TestCheck(t *testing.T) {
+	repoJSON := `{"kind": 0, "url": "https://repo1.com"}`
+	repo := &hub.Repository{Kind: hub.Helm, URL: "https://repo1.com"}
+
+	t.Run("invalid repository provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.h.Check(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error checking repository", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFake,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(repoJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("Check", r.Context(), repo).Return(nil, tc.rmErr)
+				hw.h.Check(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("repository checked successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(repoJSON))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		rc := &hub.RepositoryCheck{
+			Valid: false,
+			Diagnostics: []*hub.RepositoryCheckDiagnostic{
+				{Check: "url", Status: hub.RepositoryCheckPassed},
+				{Check: "access", Status: hub.RepositoryCheckFailed, Message: "error"},
+			},
+		}
+		hw.rm.On("Check", r.Context(), repo).Return(rc, nil)
+		hw.h.Check(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.JSONEq(t, `{
+			"valid": false,
+			"diagnostics": [
+				{"check": "url", "status": "passed"},
+				{"check": "access", "status": "failed", "message": "error"}
+			]
+		}`, string(data))
+		hw.rm.AssertExpectations(t)
+	})
+}
+
 func TestCheckAvailability(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
@@ -393,99 +712,1199 @@ func TestDelete(t *testing.T) {
 	})
 }
 
-func TestSearch(t *testing.T) {
-	t.Run("invalid request params", func(t *testing.T) {
-		testCases := []struct {
+func TestDeletePublishToken(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName", "publishTokenID"},
+			Values: []string{"repo1", "publishTokenID"},
+		},
+	}
+
+	t.Run("publish token deleted", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("DeletePublishToken", r.Context(), "repo1", "publishTokenID").Return(nil)
+		hw.h.DeletePublishToken(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error deleting publish token", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("DeletePublishToken", r.Context(), "repo1", "publishTokenID").Return(tc.rmErr)
+				hw.h.DeletePublishToken(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGenerateWebhookSecret(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("error generating webhook secret", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("GenerateWebhookSecret", r.Context(), "repo1").Return("", tc.rmErr)
+				hw.h.GenerateWebhookSecret(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("webhook secret generated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GenerateWebhookSecret", r.Context(), "repo1").Return("secret", nil)
+		hw.h.GenerateWebhookSecret(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.JSONEq(t, `{"secret": "secret"}`, string(data))
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestGetPublishTokens(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("error getting publish tokens", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("GetPublishTokensJSON", r.Context(), "repo1").Return(nil, tc.rmErr)
+				hw.h.GetPublishTokens(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get publish tokens succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetPublishTokensJSON", r.Context(), "repo1").Return([]byte("dataJSON"), nil)
+		hw.h.GetPublishTokens(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestGetStats(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("error getting repository stats", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("GetStatsJSON", r.Context(), "repo1").Return(nil, tc.rmErr)
+				hw.h.GetStats(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get repository stats succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetStatsJSON", r.Context(), "repo1").Return([]byte("dataJSON"), nil)
+		hw.h.GetStats(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestGetTrackingRuns(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("invalid pagination", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=z", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.GetTrackingRuns(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error getting repository tracking runs", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("GetTrackingRunsJSON", r.Context(), "repo1", &hub.Pagination{
+					Limit:  10,
+					Offset: 1,
+				}).Return(nil, tc.rmErr)
+				hw.h.GetTrackingRuns(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get repository tracking runs succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetTrackingRunsJSON", r.Context(), "repo1", &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetTrackingRuns(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestGetTransfers(t *testing.T) {
+	t.Run("get transfers succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetTransfersJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetTransfers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error getting transfers", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetTransfersJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetTransfers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestHead(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("get repository head failed", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("HEAD", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("GetHead", r.Context(), "repo1").Return(nil, tc.rmErr)
+				hw.h.Head(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get repository head succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("HEAD", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetHead", r.Context(), "repo1").Return(&hub.ResourceHead{
+			Digest:       "digest",
+			LastModified: 1592299233,
+		}, nil)
+		hw.h.Head(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, `"digest"`, h.Get("ETag"))
+		assert.Equal(t, "Tue, 16 Jun 2020 09:20:33 GMT", h.Get("Last-Modified"))
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestPauseTracking(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("repository tracking paused", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("SetTrackingPaused", r.Context(), "repo1", true).Return(nil)
+		hw.h.PauseTracking(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error setting repository tracking paused flag", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("SetTrackingPaused", r.Context(), "repo1", true).Return(tc.rmErr)
+				hw.h.PauseTracking(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestRejectTransfer(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("reject repository transfer succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("RejectTransfer", r.Context(), "repo1").Return(nil)
+		hw.h.RejectTransfer(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error rejecting repository transfer", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("RejectTransfer", r.Context(), "repo1").Return(tc.rmErr)
+				hw.h.RejectTransfer(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestRequestTransfer(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("request repository transfer succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/?user=user2", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("RequestTransfer", r.Context(), "repo1", "user2", "").Return(nil)
+		hw.h.RequestTransfer(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error requesting repository transfer", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/?org=org2", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("RequestTransfer", r.Context(), "repo1", "", "org2").Return(tc.rmErr)
+				hw.h.RequestTransfer(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestRequirePublishToken(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("publish token not provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("CheckPublishToken", r.Context(), "repo1", "", "").Return(false, hub.ErrInvalidInput)
+		hw.h.RequirePublishToken(nextHandler).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error checking publish token", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r.Header.Set(PublishTokenIDHeader, "publishTokenID")
+		r.Header.Set(PublishTokenSecretHeader, "secret")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("CheckPublishToken", r.Context(), "repo1", "publishTokenID", "secret").Return(false, tests.ErrFakeDB)
+		hw.h.RequirePublishToken(nextHandler).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("invalid publish token", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r.Header.Set(PublishTokenIDHeader, "publishTokenID")
+		r.Header.Set(PublishTokenSecretHeader, "secret")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("CheckPublishToken", r.Context(), "repo1", "publishTokenID", "secret").Return(false, nil)
+		hw.h.RequirePublishToken(nextHandler).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("valid publish token", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r.Header.Set(PublishTokenIDHeader, "publishTokenID")
+		r.Header.Set(PublishTokenSecretHeader, "secret")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("CheckPublishToken", r.Context(), "repo1", "publishTokenID", "secret").Return(true, nil)
+		hw.h.RequirePublishToken(nextHandler).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestResumeTracking(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("repository tracking resumed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("SetTrackingPaused", r.Context(), "repo1", false).Return(nil)
+		hw.h.ResumeTracking(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error setting repository tracking paused flag", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("SetTrackingPaused", r.Context(), "repo1", false).Return(tc.rmErr)
+				hw.h.ResumeTracking(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("invalid request params", func(t *testing.T) {
+		testCases := []struct {
 			desc   string
 			params string
 		}{
-			{"invalid limit", "limit=z"},
-			{"invalid limit", "limit=100"},
-			{"invalid offset", "offset=z"},
-			{"invalid kind", "kind=z"},
-			{"invalid kind (one of them)", "kind=0&kind=z"},
+			{"invalid limit", "limit=z"},
+			{"invalid limit", "limit=100"},
+			{"invalid offset", "offset=z"},
+			{"invalid kind", "kind=z"},
+			{"invalid kind (one of them)", "kind=0&kind=z"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(fmt.Sprintf("%s: %s", tc.desc, tc.params), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?"+tc.params, nil)
+
+				hw := newHandlersWrapper()
+				hw.h.Search(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			})
+		}
+	})
+
+	t.Run("invalid search input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.rm.On("SearchJSON", r.Context(), mock.Anything).Return(nil, hub.ErrInvalidInput)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("valid request, search succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		v := url.Values{}
+		v.Set("name", "n1")
+		v.Add("user", "u1")
+		v.Add("user", "u2")
+		v.Add("org", "o1")
+		v.Add("org", "o2")
+		v.Add("kind", "0")
+		v.Add("kind", "3")
+		v.Set("limit", "10")
+		v.Set("offset", "10")
+		r, _ := http.NewRequest("GET", "/?"+v.Encode(), nil)
+
+		hw := newHandlersWrapper()
+		hw.rm.On("SearchJSON", r.Context(), &hub.SearchRepositoryInput{
+			Name:               "n1",
+			Kinds:              []hub.RepositoryKind{hub.Helm, hub.OLM},
+			Orgs:               []string{"o1", "o2"},
+			Users:              []string{"u1", "u2"},
+			IncludeCredentials: false,
+			Limit:              10,
+			Offset:             10,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error searching repositories", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.rm.On("SearchJSON", r.Context(), mock.Anything).Return(nil, tests.ErrFakeDB)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestTransfer(t *testing.T) {
+	t.Run("invalid input - missing repo name", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("Transfer", r.Context(), "", "", false).Return(hub.ErrInvalidInput)
+		hw.h.Transfer(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"repository transfer succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error transferring repository (insufficient privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error transferring repository (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/?org=org1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				rctx := &chi.Context{
+					URLParams: chi.RouteParams{
+						Keys:   []string{"repoName"},
+						Values: []string{"repo1"},
+					},
+				}
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("Transfer", r.Context(), "repo1", "org1", false).Return(tc.err)
+				hw.h.Transfer(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestTrackingWebhook(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+	payload := `{"ref": "refs/heads/master"}`
+
+	t.Run("signature not provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(payload))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.TrackingWebhook(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error checking webhook signature", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(payload))
+		r.Header.Set("X-Hub-Signature-256", "sha256=signature")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("CheckWebhookSignature", r.Context(), "repo1", hub.GitHubWebhook, "sha256=signature", []byte(payload)).
+			Return(false, tests.ErrFakeDB)
+		hw.h.TrackingWebhook(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("invalid webhook signature", func(t *testing.T) {
+		testCases := []struct {
+			header   string
+			provider hub.RepositoryWebhookProvider
+		}{
+			{"X-Hub-Signature-256", hub.GitHubWebhook},
+			{"X-Gitlab-Token", hub.GitLabWebhook},
+			{"Authorization", hub.HarborWebhook},
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(fmt.Sprintf("%s: %s", tc.desc, tc.params), func(t *testing.T) {
+			t.Run(string(tc.provider), func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("GET", "/?"+tc.params, nil)
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(payload))
+				r.Header.Set(tc.header, "invalid")
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.h.Search(w, r)
+				hw.rm.On("CheckWebhookSignature", r.Context(), "repo1", tc.provider, "invalid", []byte(payload)).
+					Return(false, nil)
+				hw.h.TrackingWebhook(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
-				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
 			})
 		}
 	})
 
-	t.Run("invalid search input", func(t *testing.T) {
+	t.Run("github ping event", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(payload))
+		r.Header.Set("X-Hub-Signature-256", "sha256=signature")
+		r.Header.Set("X-GitHub-Event", "ping")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.rm.On("SearchJSON", r.Context(), mock.Anything).Return(nil, hub.ErrInvalidInput)
-		hw.h.Search(w, r)
+		hw.rm.On("CheckWebhookSignature", r.Context(), "repo1", hub.GitHubWebhook, "sha256=signature", []byte(payload)).
+			Return(true, nil)
+		hw.h.TrackingWebhook(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error triggering repository tracking", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(payload))
+		r.Header.Set("X-Gitlab-Token", "secret")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("CheckWebhookSignature", r.Context(), "repo1", hub.GitLabWebhook, "secret", []byte(payload)).
+			Return(true, nil)
+		hw.rm.On("TriggerTracking", r.Context(), "repo1").Return(tests.ErrFakeDB)
+		hw.h.TrackingWebhook(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("repository archived", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(payload))
+		r.Header.Set("Authorization", "secret")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("CheckWebhookSignature", r.Context(), "repo1", hub.HarborWebhook, "secret", []byte(payload)).
+			Return(true, nil)
+		hw.rm.On("TriggerTracking", r.Context(), "repo1").Return(hub.ErrInvalidInput)
+		hw.h.TrackingWebhook(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
 	})
 
-	t.Run("valid request, search succeeded", func(t *testing.T) {
+	t.Run("repository tracking requested", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		v := url.Values{}
-		v.Set("name", "n1")
-		v.Add("user", "u1")
-		v.Add("user", "u2")
-		v.Add("org", "o1")
-		v.Add("org", "o2")
-		v.Add("kind", "0")
-		v.Add("kind", "3")
-		v.Set("limit", "10")
-		v.Set("offset", "10")
-		r, _ := http.NewRequest("GET", "/?"+v.Encode(), nil)
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(payload))
+		r.Header.Set("X-Hub-Signature-256", "sha256=signature")
+		r.Header.Set("X-GitHub-Event", "push")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.rm.On("SearchJSON", r.Context(), &hub.SearchRepositoryInput{
-			Name:               "n1",
-			Kinds:              []hub.RepositoryKind{hub.Helm, hub.OLM},
-			Orgs:               []string{"o1", "o2"},
-			Users:              []string{"u1", "u2"},
-			IncludeCredentials: false,
-			Limit:              10,
-			Offset:             10,
-		}).Return(&hub.JSONQueryResult{
-			Data:       []byte("dataJSON"),
-			TotalCount: 1,
-		}, nil)
-		hw.h.Search(w, r)
+		hw.rm.On("CheckWebhookSignature", r.Context(), "repo1", hub.GitHubWebhook, "sha256=signature", []byte(payload)).
+			Return(true, nil)
+		hw.rm.On("TriggerTracking", r.Context(), "repo1").Return(nil)
+		hw.h.TrackingWebhook(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
-		h := resp.Header
-		data, _ := ioutil.ReadAll(resp.Body)
 
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
-		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
-		assert.Equal(t, "application/json", h.Get("Content-Type"))
-		assert.Equal(t, []byte("dataJSON"), data)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
 		hw.rm.AssertExpectations(t)
 	})
+}
 
-	t.Run("error searching repositories", func(t *testing.T) {
+func TestTriggerTracking(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("repository tracking triggered", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.rm.On("SearchJSON", r.Context(), mock.Anything).Return(nil, tests.ErrFakeDB)
-		hw.h.Search(w, r)
+		hw.rm.On("TriggerTracking", r.Context(), "repo1").Return(nil)
+		hw.h.TriggerTracking(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error triggering repository tracking", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("TriggerTracking", r.Context(), "repo1").Return(tests.ErrFakeDB)
+		hw.h.TriggerTracking(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
@@ -494,63 +1913,65 @@ func TestSearch(t *testing.T) {
 	})
 }
 
-func TestTransfer(t *testing.T) {
-	t.Run("invalid input - missing repo name", func(t *testing.T) {
+func TestUnarchive(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("repository unarchived", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("PUT", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.rm.On("Transfer", r.Context(), "", "", false).Return(hub.ErrInvalidInput)
-		hw.h.Transfer(w, r)
+		hw.rm.On("SetArchived", r.Context(), "repo1", false).Return(nil)
+		hw.h.Unarchive(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
-		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 		hw.rm.AssertExpectations(t)
 	})
 
-	t.Run("valid input", func(t *testing.T) {
+	t.Run("error unarchiving repository", func(t *testing.T) {
 		testCases := []struct {
-			description        string
-			err                error
+			rmErr              error
 			expectedStatusCode int
 		}{
 			{
-				"repository transfer succeeded",
-				nil,
-				http.StatusNoContent,
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
 			},
 			{
-				"error transferring repository (insufficient privilege)",
 				hub.ErrInsufficientPrivilege,
 				http.StatusForbidden,
 			},
 			{
-				"error transferring repository (db error)",
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
 				tests.ErrFakeDB,
 				http.StatusInternalServerError,
 			},
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.description, func(t *testing.T) {
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("PUT", "/?org=org1", nil)
+				r, _ := http.NewRequest("PUT", "/", nil)
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-				rctx := &chi.Context{
-					URLParams: chi.RouteParams{
-						Keys:   []string{"repoName"},
-						Values: []string{"repo1"},
-					},
-				}
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.rm.On("Transfer", r.Context(), "repo1", "org1", false).Return(tc.err)
-				hw.h.Transfer(w, r)
+				hw.rm.On("SetArchived", r.Context(), "repo1", false).Return(tc.rmErr)
+				hw.h.Unarchive(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -658,6 +2079,134 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestUpdatePublishMetadata(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+	mdJSON := `{"display_name": "Repository 1", "branch": "main"}`
+	displayName := "Repository 1"
+	branch := "main"
+	md := &hub.RepositoryPublishMetadata{
+		DisplayName: &displayName,
+		Branch:      &branch,
+	}
+
+	t.Run("invalid metadata provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.UpdatePublishMetadata(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error updating repository metadata", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(mdJSON))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("UpdatePublishMetadata", r.Context(), "repo1", md).Return(tests.ErrFakeDB)
+		hw.h.UpdatePublishMetadata(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("repository metadata updated", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(mdJSON))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("UpdatePublishMetadata", r.Context(), "repo1", md).Return(nil)
+		hw.h.UpdatePublishMetadata(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestVerifyDomain(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("repository domain verified", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("VerifyDomain", r.Context(), "repo1").Return(nil)
+		hw.h.VerifyDomain(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error verifying repository domain", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("VerifyDomain", r.Context(), "repo1").Return(tc.rmErr)
+				hw.h.VerifyDomain(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 type handlersWrapper struct {
 	cfg *viper.Viper
 	rm  *repo.ManagerMock
@@ -0,0 +1,110 @@
+package serviceaccount
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling service
+// accounts operations.
+type Handlers struct {
+	serviceAccountManager hub.ServiceAccountManager
+	logger                zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(serviceAccountManager hub.ServiceAccountManager) *Handlers {
+	return &Handlers{
+		serviceAccountManager: serviceAccountManager,
+		logger:                log.With().Str("handlers", "serviceaccount").Logger(),
+	}
+}
+
+// Add is an http handler that adds the provided service account to the
+// organization given.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	sa := &hub.ServiceAccount{}
+	if err := json.NewDecoder(r.Body).Decode(&sa); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	serviceAccountID, err := h.serviceAccountManager.Add(r.Context(), orgName, sa)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(map[string]string{
+		"service_account_id": serviceAccountID,
+	})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
+}
+
+// AddAPIKey is an http handler that adds a new api key to the provided
+// service account.
+func (h *Handlers) AddAPIKey(w http.ResponseWriter, r *http.Request) {
+	akIN := &hub.APIKey{}
+	if err := json.NewDecoder(r.Body).Decode(&akIN); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddAPIKey").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	serviceAccountID := chi.URLParam(r, "serviceAccountID")
+	akOUT, err := h.serviceAccountManager.AddAPIKey(r.Context(), orgName, serviceAccountID, akIN)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "AddAPIKey").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	akOUTJSON, _ := json.Marshal(akOUT)
+	helpers.RenderJSON(w, akOUTJSON, 0, http.StatusCreated)
+}
+
+// Delete is an http handler that deletes the provided service account.
+func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	serviceAccountID := chi.URLParam(r, "serviceAccountID")
+	if err := h.serviceAccountManager.Delete(r.Context(), orgName, serviceAccountID); err != nil {
+		h.logger.Error().Err(err).Str("method", "Delete").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteAPIKey is an http handler that deletes the provided api key from the
+// service account given.
+func (h *Handlers) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	serviceAccountID := chi.URLParam(r, "serviceAccountID")
+	apiKeyID := chi.URLParam(r, "apiKeyID")
+	err := h.serviceAccountManager.DeleteAPIKey(r.Context(), orgName, serviceAccountID, apiKeyID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteAPIKey").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetByOrg is an http handler that returns the service accounts of the
+// provided organization.
+func (h *Handlers) GetByOrg(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.serviceAccountManager.GetByOrgJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
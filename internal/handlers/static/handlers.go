@@ -16,6 +16,7 @@ import (
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/util"
 	"github.com/go-chi/chi/v5"
 	svg "github.com/h2non/go-is-svg"
 	"github.com/rs/zerolog"
@@ -77,6 +78,34 @@ func (h *Handlers) setupIndexTemplate() {
 	h.indexTmpl = template.Must(template.New("").Parse(string(text)))
 }
 
+// Config is an http handler that returns some configuration settings that
+// the UI can use to customize how packages are browsed, like the repository
+// kinds enabled and the default sort and facets to use for each of them.
+func (h *Handlers) Config(w http.ResponseWriter, r *http.Request) {
+	kindsSettings, err := util.GetRepositoryKindsSettings(h.cfg)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Config").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	oauthProvidersSettings, err := util.GetOauthProvidersSettings(h.cfg)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Config").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, err := json.Marshal(map[string]interface{}{
+		"kinds":           kindsSettings,
+		"oauth_providers": oauthProvidersSettings,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Config").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // Image is an http handler that serves images stored in the database.
 func (h *Handlers) Image(w http.ResponseWriter, r *http.Request) {
 	// Extract image id and version
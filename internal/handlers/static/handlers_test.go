@@ -2,6 +2,7 @@ package static
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -27,6 +28,72 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+func TestConfig(t *testing.T) {
+	t.Run("invalid kinds settings", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.cfg.Set("packages.kinds.helm.defaultSort", "invalid")
+		hw.h.Config(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+
+	t.Run("invalid oauth providers settings", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.cfg.Set("server.oauth.ghe.type", "invalid")
+		hw.h.Config(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+
+	t.Run("config returned", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.cfg.Set("packages.kinds.falco.enabled", false)
+		hw.cfg.Set("server.oauth.ghe.type", "github")
+		hw.cfg.Set("server.oauth.ghe.displayName", "GitHub Enterprise")
+		hw.h.Config(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+		var config struct {
+			Kinds          []*hub.RepositoryKindSettings `json:"kinds"`
+			OauthProviders []*hub.OauthProviderSettings  `json:"oauth_providers"`
+		}
+		require.NoError(t, json.Unmarshal(data, &config))
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		require.Len(t, config.Kinds, int(hub.Container)+1)
+		assert.Equal(t, "helm", config.Kinds[hub.Helm].Name)
+		assert.True(t, config.Kinds[hub.Helm].Enabled)
+		assert.False(t, config.Kinds[hub.Falco].Enabled)
+		assert.Equal(t, []*hub.OauthProviderSettings{
+			{
+				Name:        "ghe",
+				Type:        "github",
+				DisplayName: "GitHub Enterprise",
+			},
+		}, config.OauthProviders)
+	})
+}
+
 func TestImage(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -94,6 +94,18 @@ func (h *Handlers) DeleteOptOut(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Export is an http handler that returns the subscriptions and stars of the
+// user doing the request as a portable json document.
+func (h *Handlers) Export(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.subscriptionManager.ExportJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Export").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // GetByPackage is an http handler that returns the subscriptions a user has
 // for a given package.
 func (h *Handlers) GetByPackage(w http.ResponseWriter, r *http.Request) {
@@ -146,3 +158,34 @@ func (h *Handlers) GetOptOutList(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
 	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
 }
+
+// Import is an http handler that imports the subscriptions document provided
+// into the account of the user doing the request.
+func (h *Handlers) Import(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	input := &hub.SubscriptionsImportInput{
+		OnConflict: qs.Get("on_conflict"),
+	}
+	if v := qs.Get("dry_run"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			errMsg := "invalid dry run value"
+			h.logger.Error().Err(err).Str("method", "Import").Msg(errMsg)
+			helpers.RenderErrorJSON(w, fmt.Errorf("%w: %s", hub.ErrInvalidInput, errMsg))
+			return
+		}
+		input.DryRun = dryRun
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input.Document); err != nil {
+		h.logger.Error().Err(err).Str("method", "Import").Msg("invalid subscriptions document")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	dataJSON, err := h.subscriptionManager.ImportJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Import").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
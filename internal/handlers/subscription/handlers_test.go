@@ -354,6 +354,44 @@ func TestDeleteOptOut(t *testing.T) {
 	}
 }
 
+func TestExport(t *testing.T) {
+	t.Run("error exporting subscriptions", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("ExportJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.Export(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("export subscriptions succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("ExportJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.Export(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
 func TestGetByPackage(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -518,6 +556,103 @@ func TestGetOptOutList(t *testing.T) {
 	})
 }
 
+func TestImport(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			query       string
+			body        string
+		}{
+			{
+				"invalid dry run value",
+				"?dry_run=invalid",
+				`{"version": 1}`,
+			},
+			{
+				"invalid document",
+				"",
+				"-",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/"+tc.query, strings.NewReader(tc.body))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.h.Import(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error importing subscriptions", func(t *testing.T) {
+		testCases := []struct {
+			smErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.smErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"version": 1}`))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.sm.On("ImportJSON", r.Context(), mock.Anything).Return(nil, tc.smErr)
+				hw.h.Import(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("import subscriptions succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/?dry_run=true&on_conflict=replace", strings.NewReader(`{"version": 1}`))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		expectedInput := &hub.SubscriptionsImportInput{
+			Document:   &hub.SubscriptionsDocument{Version: 1},
+			OnConflict: "replace",
+			DryRun:     true,
+		}
+		hw.sm.On("ImportJSON", r.Context(), expectedInput).Return([]byte("dataJSON"), nil)
+		hw.h.Import(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
 type handlersWrapper struct {
 	sm *subscription.ManagerMock
 	h  *Handlers
@@ -9,9 +9,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/big"
-	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/user"
+	"github.com/artifacthub/hub/internal/util"
 	"github.com/coreos/go-oidc"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/go-github/github"
@@ -31,6 +33,7 @@ import (
 	"golang.org/x/oauth2"
 	oagithub "golang.org/x/oauth2/github"
 	"golang.org/x/oauth2/google"
+	"golang.org/x/time/rate"
 	"google.golang.org/api/option"
 	"google.golang.org/api/people/v1"
 )
@@ -49,10 +52,26 @@ const (
 	// endpoint. If TFA is not enabled, sessions will be approved on creation.
 	SessionApprovedHeader = "X-SESSION-APPROVED"
 
-	sessionCookieName    = "sid"
-	oauthStateCookieName = "oas"
-	sessionDuration      = 30 * 24 * time.Hour
-	oauthFailedURL       = "/oauth-failed"
+	sessionCookieName          = "sid"
+	oauthStateCookieName       = "oas"
+	loginDeviceCookieName      = "lgd"
+	webAuthnCeremonyCookieName = "wac"
+	sessionDuration            = 30 * 24 * time.Hour
+	loginCodeDuration          = 15 * time.Minute
+	webAuthnCeremonyDuration   = 5 * time.Minute
+	oauthFailedURL             = "/oauth-failed"
+
+	// defaultOIDCGroupsClaim represents the id token claim used to get the
+	// groups the user belongs to when none has been configured.
+	defaultOIDCGroupsClaim = "groups"
+
+	// loginCodesPerIPPerHour represents the number of login codes that can be
+	// requested per hour from the same IP address.
+	loginCodesPerIPPerHour = 10
+
+	// loginCodesPerEmailPerHour represents the number of login codes that can
+	// be requested per hour for the same email address.
+	loginCodesPerEmailPerHour = 3
 )
 
 var (
@@ -61,18 +80,33 @@ var (
 
 	// errInvalidSession error indicates that the session provided is not valid.
 	errInvalidSession = errors.New("invalid session")
+
+	// errLoginRequired error indicates that the request must be authenticated.
+	errLoginRequired = errors.New("login required: sign in or provide an api key to access this resource")
 )
 
 // Handlers represents a group of http handlers in charge of handling
 // users operations.
 type Handlers struct {
-	userManager   hub.UserManager
-	apiKeyManager hub.APIKeyManager
-	cfg           *viper.Viper
-	sc            *securecookie.SecureCookie
-	oauthConfig   map[string]*oauth2.Config
-	oidcProvider  *oidc.Provider
-	logger        zerolog.Logger
+	userManager    hub.UserManager
+	apiKeyManager  hub.APIKeyManager
+	cfg            *viper.Viper
+	sc             *securecookie.SecureCookie
+	cookieCfg      *helpers.CookieConfig
+	oauthProviders map[string]*oauthProvider
+	logger         zerolog.Logger
+
+	loginCodeIPLimiter    *util.KeyedLimiter
+	loginCodeEmailLimiter *util.KeyedLimiter
+}
+
+// oauthProvider represents an oauth provider configured in the hub.
+type oauthProvider struct {
+	name         string
+	typ          string
+	config       *oauth2.Config
+	oidcProvider *oidc.Provider
+	githubAPIURL string
 }
 
 // NewHandlers creates a new Handlers instance.
@@ -85,46 +119,65 @@ func NewHandlers(
 	// Setup secure cookie instance
 	sc := securecookie.New([]byte(cfg.GetString("server.cookie.hashKey")), nil)
 	sc.MaxAge(int(sessionDuration.Seconds()))
+	cookieCfg, err := helpers.GetCookieConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cookie configuration: %w", err)
+	}
 
 	// Setup oauth providers configuration
-	oauthConfig := make(map[string]*oauth2.Config)
-	var oidcProvider *oidc.Provider
-	for provider := range cfg.GetStringMap("server.oauth") {
-		baseCfgKey := fmt.Sprintf("server.oauth.%s.", provider)
+	providersSettings, err := util.GetOauthProvidersSettings(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid oauth configuration: %w", err)
+	}
+	oauthProviders := make(map[string]*oauthProvider, len(providersSettings))
+	for _, s := range providersSettings {
+		baseCfgKey := fmt.Sprintf("server.oauth.%s.", s.Name)
+		p := &oauthProvider{
+			name: s.Name,
+			typ:  s.Type,
+		}
 		var endpoint oauth2.Endpoint
-		switch provider {
+		switch s.Type {
 		case "github":
 			endpoint = oagithub.Endpoint
+			if enterpriseURL := strings.TrimSuffix(cfg.GetString(baseCfgKey+"enterpriseURL"), "/"); enterpriseURL != "" {
+				endpoint = oauth2.Endpoint{
+					AuthURL:  enterpriseURL + "/login/oauth/authorize",
+					TokenURL: enterpriseURL + "/login/oauth/access_token",
+				}
+				p.githubAPIURL = enterpriseURL + "/api/v3/"
+			}
 		case "google":
 			endpoint = google.Endpoint
 		case "oidc":
 			issuerURL := cfg.GetString(baseCfgKey + "issuerURL")
-			var err error
-			oidcProvider, err = oidc.NewProvider(ctx, issuerURL)
+			p.oidcProvider, err = oidc.NewProvider(ctx, issuerURL)
 			if err != nil {
-				return nil, fmt.Errorf("error setting up oidc provider: %w", err)
+				return nil, fmt.Errorf("error setting up %s oidc provider: %w", s.Name, err)
 			}
-			endpoint = oidcProvider.Endpoint()
-		default:
-			continue
+			endpoint = p.oidcProvider.Endpoint()
 		}
-		oauthConfig[provider] = &oauth2.Config{
+		p.config = &oauth2.Config{
 			ClientID:     cfg.GetString(baseCfgKey + "clientID"),
 			ClientSecret: cfg.GetString(baseCfgKey + "clientSecret"),
 			Endpoint:     endpoint,
 			Scopes:       cfg.GetStringSlice(baseCfgKey + "scopes"),
 			RedirectURL:  cfg.GetString(baseCfgKey + "redirectURL"),
 		}
+		oauthProviders[s.Name] = p
 	}
 
 	return &Handlers{
-		userManager:   userManager,
-		apiKeyManager: apiKeyManager,
-		cfg:           cfg,
-		sc:            sc,
-		oauthConfig:   oauthConfig,
-		oidcProvider:  oidcProvider,
-		logger:        log.With().Str("handlers", "user").Logger(),
+		userManager:    userManager,
+		apiKeyManager:  apiKeyManager,
+		cfg:            cfg,
+		sc:             sc,
+		cookieCfg:      cookieCfg,
+		oauthProviders: oauthProviders,
+		logger:         log.With().Str("handlers", "user").Logger(),
+
+		loginCodeIPLimiter:    util.NewKeyedLimiter(rate.Every(time.Hour/loginCodesPerIPPerHour), loginCodesPerIPPerHour),
+		loginCodeEmailLimiter: util.NewKeyedLimiter(rate.Every(time.Hour/loginCodesPerEmailPerHour), loginCodesPerEmailPerHour),
 	}, nil
 }
 
@@ -144,15 +197,9 @@ func (h *Handlers) ApproveSession(w http.ResponseWriter, r *http.Request) {
 	passcode := input["passcode"]
 
 	// Extract sessionID from cookie
-	var sessionID string
-	cookie, err := r.Cookie(sessionCookieName)
+	sessionID, err := h.getSessionID(r)
 	if err != nil {
-		h.logger.Error().Err(err).Str("method", "ApproveSession").Msg("session cookie not found")
-		helpers.RenderErrorWithCodeJSON(w, errInvalidSession, http.StatusUnauthorized)
-		return
-	}
-	if err = h.sc.Decode(sessionCookieName, cookie.Value, &sessionID); err != nil {
-		h.logger.Error().Err(err).Str("method", "ApproveSession").Msg("sessionID decoding failed")
+		h.logger.Error().Err(err).Str("method", "ApproveSession").Msg("sessionID not available")
 		helpers.RenderErrorWithCodeJSON(w, errInvalidSession, http.StatusUnauthorized)
 		return
 	}
@@ -167,6 +214,49 @@ func (h *Handlers) ApproveSession(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ApproveSessionWithWebAuthn is an http handler used to approve a session
+// using a security key, completing the WebAuthn ceremony started by
+// BeginWebAuthnSessionApproval.
+func (h *Handlers) ApproveSessionWithWebAuthn(w http.ResponseWriter, r *http.Request) {
+	// Extract sessionID and ceremony id from cookies
+	sessionID, err := h.getSessionID(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "ApproveSessionWithWebAuthn").Msg("sessionID not available")
+		helpers.RenderErrorWithCodeJSON(w, errInvalidSession, http.StatusUnauthorized)
+		return
+	}
+	ceremonyID, err := h.getWebAuthnCeremonyID(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "ApproveSessionWithWebAuthn").Msg("ceremony id not available")
+		helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
+		return
+	}
+
+	// Approve session using the assertion provided
+	response, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "ApproveSessionWithWebAuthn").Msg("error reading body")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.userManager.ApproveSessionWithWebAuthn(r.Context(), sessionID, ceremonyID, response); err != nil {
+		if errors.Is(err, user.ErrInvalidWebAuthnLogin) {
+			helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error().Err(err).Str("method", "ApproveSessionWithWebAuthn").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if err := h.deleteWebAuthnCeremonyCookie(w); err != nil {
+		h.logger.Error().Err(err).Str("method", "ApproveSessionWithWebAuthn").Msg("ceremony cookie deletion failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // BasicAuth is a middleware that provides basic auth support.
 func (h *Handlers) BasicAuth(next http.Handler) http.Handler {
 	validUser := []byte(h.cfg.GetString("server.basicAuth.username"))
@@ -195,6 +285,73 @@ func (h *Handlers) BasicAuth(next http.Handler) http.Handler {
 	})
 }
 
+// BeginWebAuthnLogin is an http handler used to start a WebAuthn login
+// ceremony for the user with the email provided.
+func (h *Handlers) BeginWebAuthnLogin(w http.ResponseWriter, r *http.Request) {
+	var input map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "BeginWebAuthnLogin").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	c, err := h.userManager.BeginWebAuthnLogin(r.Context(), input["email"])
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "BeginWebAuthnLogin").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if err := h.setWebAuthnCeremonyCookie(w, c.ID); err != nil {
+		h.logger.Error().Err(err).Str("method", "BeginWebAuthnLogin").Msg("ceremony id encoding failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, c.Options, 0, http.StatusOK)
+}
+
+// BeginWebAuthnRegistration is an http handler used to start a WebAuthn
+// registration ceremony for the user doing the request.
+func (h *Handlers) BeginWebAuthnRegistration(w http.ResponseWriter, r *http.Request) {
+	c, err := h.userManager.BeginWebAuthnRegistration(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "BeginWebAuthnRegistration").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if err := h.setWebAuthnCeremonyCookie(w, c.ID); err != nil {
+		h.logger.Error().Err(err).Str("method", "BeginWebAuthnRegistration").Msg("ceremony id encoding failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, c.Options, 0, http.StatusOK)
+}
+
+// BeginWebAuthnSessionApproval is an http handler used to start a WebAuthn
+// login ceremony to approve the session of the user doing the request.
+func (h *Handlers) BeginWebAuthnSessionApproval(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := h.getSessionID(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "BeginWebAuthnSessionApproval").Msg("sessionID not available")
+		helpers.RenderErrorWithCodeJSON(w, errInvalidSession, http.StatusUnauthorized)
+		return
+	}
+	c, err := h.userManager.BeginWebAuthnSessionApproval(r.Context(), sessionID)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidWebAuthnLogin) {
+			helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error().Err(err).Str("method", "BeginWebAuthnSessionApproval").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if err := h.setWebAuthnCeremonyCookie(w, c.ID); err != nil {
+		h.logger.Error().Err(err).Str("method", "BeginWebAuthnSessionApproval").Msg("ceremony id encoding failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, c.Options, 0, http.StatusOK)
+}
+
 // CheckPasswordStrength is an http handler that checks the strength of the
 // password provided
 func (h *Handlers) CheckPasswordStrength(w http.ResponseWriter, r *http.Request) {
@@ -258,7 +415,23 @@ func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		Path:    "/",
 		Expires: time.Now().Add(-24 * time.Hour),
 	}
-	http.SetCookie(w, cookie)
+	if err := h.cookieCfg.SetCookie(w, cookie); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteUser").Msg("session cookie deletion failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteWebAuthnCredential is an http handler used to delete a WebAuthn
+// credential registered by the user doing the request.
+func (h *Handlers) DeleteWebAuthnCredential(w http.ResponseWriter, r *http.Request) {
+	credentialID := chi.URLParam(r, "credentialID")
+	if err := h.userManager.DeleteWebAuthnCredential(r.Context(), credentialID); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteWebAuthnCredential").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -296,6 +469,96 @@ func (h *Handlers) EnableTFA(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// FinishWebAuthnLogin is an http handler used to log a user in using a
+// passkey or security key, completing the WebAuthn ceremony started by
+// BeginWebAuthnLogin.
+func (h *Handlers) FinishWebAuthnLogin(w http.ResponseWriter, r *http.Request) {
+	// Extract ceremony id from cookie
+	ceremonyID, err := h.getWebAuthnCeremonyID(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "FinishWebAuthnLogin").Msg("ceremony id not available")
+		helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
+		return
+	}
+
+	// Check if the assertion provided is valid
+	response, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "FinishWebAuthnLogin").Msg("error reading body")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	userID, err := h.userManager.FinishWebAuthnLogin(r.Context(), ceremonyID, response)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidWebAuthnLogin) {
+			helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error().Err(err).Str("method", "FinishWebAuthnLogin").Msg("finishWebAuthnLogin failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if err := h.deleteWebAuthnCeremonyCookie(w); err != nil {
+		h.logger.Error().Err(err).Str("method", "FinishWebAuthnLogin").Msg("ceremony cookie deletion failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Register user session (already approved, as the user has been
+	// authenticated using a security key)
+	ip := helpers.ClientIP(r)
+	session, err := h.userManager.RegisterSession(r.Context(), &hub.Session{
+		UserID:    userID,
+		IP:        ip,
+		UserAgent: r.UserAgent(),
+		Approved:  true,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "FinishWebAuthnLogin").Msg("registerSession failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Generate and set session cookie
+	if err := h.setSessionCookie(w, session.SessionID); err != nil {
+		h.logger.Error().Err(err).Str("method", "FinishWebAuthnLogin").Msg("sessionID encoding failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(SessionApprovedHeader, strconv.FormatBool(session.Approved))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// FinishWebAuthnRegistration is an http handler used to register a new
+// WebAuthn credential for the user doing the request, completing the ceremony
+// started by BeginWebAuthnRegistration.
+func (h *Handlers) FinishWebAuthnRegistration(w http.ResponseWriter, r *http.Request) {
+	ceremonyID, err := h.getWebAuthnCeremonyID(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "FinishWebAuthnRegistration").Msg("ceremony id not available")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	response, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "FinishWebAuthnRegistration").Msg("error reading body")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	name := r.URL.Query().Get("name")
+	if err := h.userManager.FinishWebAuthnRegistration(r.Context(), name, ceremonyID, response); err != nil {
+		h.logger.Error().Err(err).Str("method", "FinishWebAuthnRegistration").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if err := h.deleteWebAuthnCeremonyCookie(w); err != nil {
+		h.logger.Error().Err(err).Str("method", "FinishWebAuthnRegistration").Msg("ceremony cookie deletion failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
 // GetProfile is an http handler used to get a logged in user profile.
 func (h *Handlers) GetProfile(w http.ResponseWriter, r *http.Request) {
 	dataJSON, err := h.userManager.GetProfileJSON(r.Context())
@@ -307,6 +570,18 @@ func (h *Handlers) GetProfile(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
 
+// GetWebAuthnCredentials is an http handler that returns the WebAuthn
+// credentials registered by the user doing the request.
+func (h *Handlers) GetWebAuthnCredentials(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.userManager.GetWebAuthnCredentialsJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetWebAuthnCredentials").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // InjectUserID is a middleware that injects the id of the user doing the
 // request into the request context when a valid session id is provided.
 func (h *Handlers) InjectUserID(next http.Handler) http.Handler {
@@ -324,7 +599,7 @@ func (h *Handlers) InjectUserID(next http.Handler) http.Handler {
 		}()
 
 		// Extract and validate cookie from request
-		cookie, err := r.Cookie(sessionCookieName)
+		cookie, err := r.Cookie(h.cookieCfg.Name(sessionCookieName))
 		if err != nil {
 			return
 		}
@@ -369,7 +644,7 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Register user session
-	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+	ip := helpers.ClientIP(r)
 	session, err := h.userManager.RegisterSession(r.Context(), &hub.Session{
 		UserID:    checkCredentialsOutput.UserID,
 		IP:        ip,
@@ -382,24 +657,81 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate and set session cookie
-	encodedSessionID, err := h.sc.Encode(sessionCookieName, session.SessionID)
-	if err != nil {
+	if err := h.setSessionCookie(w, session.SessionID); err != nil {
 		h.logger.Error().Err(err).Str("method", "Login").Msg("sessionID encoding failed")
 		helpers.RenderErrorJSON(w, err)
 		return
 	}
-	cookie := &http.Cookie{
-		Name:     sessionCookieName,
-		Value:    encodedSessionID,
-		Path:     "/",
-		Expires:  time.Now().Add(sessionDuration),
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
+	w.Header().Set(SessionApprovedHeader, strconv.FormatBool(session.Approved))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// LoginWithCode is an http handler used to log a user in using a login code
+// previously emailed to the user. The code must be used from the same device
+// that requested it.
+func (h *Handlers) LoginWithCode(w http.ResponseWriter, r *http.Request) {
+	// Extract login code from request
+	var input map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "LoginWithCode").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+
+	// Get device id from the cookie set when the code was requested
+	var deviceID string
+	cookie, err := r.Cookie(h.cookieCfg.Name(loginDeviceCookieName))
+	if err != nil {
+		helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
+		return
+	}
+	if err = h.sc.Decode(loginDeviceCookieName, cookie.Value, &deviceID); err != nil {
+		h.logger.Error().Err(err).Str("method", "LoginWithCode").Msg("deviceID decoding failed")
+		helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
+		return
+	}
+
+	// Check if the login code provided is valid
+	userID, err := h.userManager.VerifyLoginCode(r.Context(), input["code"], deviceID)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidLoginCode) {
+			helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error().Err(err).Str("method", "LoginWithCode").Msg("verifyLoginCode failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	err = h.cookieCfg.SetCookie(w, &http.Cookie{
+		Name:    loginDeviceCookieName,
+		Path:    "/",
+		Expires: time.Now().Add(-24 * time.Hour),
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "LoginWithCode").Msg("device cookie deletion failed")
+		helpers.RenderErrorJSON(w, err)
+		return
 	}
-	if h.cfg.GetBool("server.cookie.secure") {
-		cookie.Secure = true
+
+	// Register user session
+	ip := helpers.ClientIP(r)
+	session, err := h.userManager.RegisterSession(r.Context(), &hub.Session{
+		UserID:    userID,
+		IP:        ip,
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "LoginWithCode").Msg("registerSession failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Generate and set session cookie
+	if err := h.setSessionCookie(w, session.SessionID); err != nil {
+		h.logger.Error().Err(err).Str("method", "LoginWithCode").Msg("sessionID encoding failed")
+		helpers.RenderErrorJSON(w, err)
+		return
 	}
-	http.SetCookie(w, cookie)
 	w.Header().Set(SessionApprovedHeader, strconv.FormatBool(session.Approved))
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -407,7 +739,7 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 // Logout is an http handler used to log a user out.
 func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) {
 	// Delete user session
-	cookie, err := r.Cookie(sessionCookieName)
+	cookie, err := r.Cookie(h.cookieCfg.Name(sessionCookieName))
 	if err == nil {
 		var sessionID string
 		err = h.sc.Decode(sessionCookieName, cookie.Value, &sessionID)
@@ -425,7 +757,11 @@ func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) {
 		Path:    "/",
 		Expires: time.Now().Add(-24 * time.Hour),
 	}
-	http.SetCookie(w, cookie)
+	if err := h.cookieCfg.SetCookie(w, cookie); err != nil {
+		h.logger.Error().Err(err).Str("method", "Logout").Msg("session cookie deletion failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -447,7 +783,7 @@ func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
 		return
 	}
-	stateCookie, err := r.Cookie(oauthStateCookieName)
+	stateCookie, err := r.Cookie(h.cookieCfg.Name(oauthStateCookieName))
 	if err != nil {
 		logger.Error().Err(err).Msg("state cookie not provided")
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
@@ -463,18 +799,21 @@ func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 		Path:    "/",
 		Expires: time.Now().Add(-24 * time.Hour),
 	}
-	http.SetCookie(w, stateCookie)
+	if err := h.cookieCfg.SetCookie(w, stateCookie); err != nil {
+		logger.Error().Err(err).Msg("state cookie deletion failed")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
+	}
 
 	// Register user if needed, or return his id if already registered
-	provider := chi.URLParam(r, "provider")
-	providerConfig := h.oauthConfig[provider]
-	oauthToken, err := providerConfig.Exchange(r.Context(), code)
+	provider := h.oauthProviders[chi.URLParam(r, "provider")]
+	oauthToken, err := provider.config.Exchange(r.Context(), code)
 	if err != nil {
 		logger.Error().Err(err).Msg("oauth code exchange failed")
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
 		return
 	}
-	userID, err := h.registerUserWithOauth(r.Context(), provider, providerConfig, oauthToken)
+	userID, err := h.registerUserWithOauth(r.Context(), provider, oauthToken)
 	if err != nil {
 		logger.Error().Err(err).Msg("oauth code exchange failed")
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
@@ -482,7 +821,7 @@ func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Register user session and set session cookie
-	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+	ip := helpers.ClientIP(r)
 	session, err := h.userManager.RegisterSession(r.Context(), &hub.Session{
 		UserID:    userID,
 		IP:        ip,
@@ -493,24 +832,11 @@ func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
 		return
 	}
-	encodedSessionID, err := h.sc.Encode(sessionCookieName, session.SessionID)
-	if err != nil {
+	if err := h.setSessionCookie(w, session.SessionID); err != nil {
 		logger.Error().Err(err).Msg("sessionID encoding failed")
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
 		return
 	}
-	sessionCookie := &http.Cookie{
-		Name:     sessionCookieName,
-		Value:    encodedSessionID,
-		Path:     "/",
-		Expires:  time.Now().Add(sessionDuration),
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-	}
-	if h.cfg.GetBool("server.cookie.secure") {
-		sessionCookie.Secure = true
-	}
-	http.SetCookie(w, sessionCookie)
 	http.Redirect(w, r, state.RedirectURL, http.StatusSeeOther)
 }
 
@@ -526,10 +852,11 @@ func (h *Handlers) OauthRedirect(w http.ResponseWriter, r *http.Request) {
 		Path:     "/",
 		HttpOnly: true,
 	}
-	if h.cfg.GetBool("server.cookie.secure") {
-		cookie.Secure = true
+	if err := h.cookieCfg.SetCookie(w, cookie); err != nil {
+		h.logger.Error().Err(err).Str("method", "OauthRedirect").Msg("state cookie setup failed")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
 	}
-	http.SetCookie(w, cookie)
 
 	// Prepare oauth state and redirect user to oauth provider
 	redirectURL := r.FormValue("redirect_url")
@@ -539,12 +866,12 @@ func (h *Handlers) OauthRedirect(w http.ResponseWriter, r *http.Request) {
 	if redirectURL == "" {
 		redirectURL = "/"
 	}
-	providerConfig := h.oauthConfig[chi.URLParam(r, "provider")]
+	provider := h.oauthProviders[chi.URLParam(r, "provider")]
 	state := &OauthState{
 		Random:      random,
 		RedirectURL: redirectURL,
 	}
-	authCodeURL := providerConfig.AuthCodeURL(state.String())
+	authCodeURL := provider.config.AuthCodeURL(state.String())
 	http.Redirect(w, r, authCodeURL, http.StatusSeeOther)
 }
 
@@ -560,6 +887,64 @@ func (h *Handlers) RegisterDeleteUserCode(w http.ResponseWriter, r *http.Request
 	w.WriteHeader(http.StatusCreated)
 }
 
+// RegisterLoginCode is an http handler used to register a code that allows a
+// user to log in without a password. A link containing the code will be
+// emailed to the user. The code will only be valid for the device that
+// requested it, which is identified by a cookie set in this response.
+func (h *Handlers) RegisterLoginCode(w http.ResponseWriter, r *http.Request) {
+	var input map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterLoginCode").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+
+	// Limit the login codes requested from the same IP address, so that this
+	// endpoint cannot be used to send emails massively
+	ip := helpers.ClientIP(r)
+	if !h.loginCodeIPLimiter.Allow(ip) {
+		h.logger.Warn().Str("method", "RegisterLoginCode").Str("ip", ip).Msg("too many login codes requested")
+		helpers.RenderErrorWithCodeJSON(w, nil, http.StatusTooManyRequests)
+		return
+	}
+
+	// Generate device id and store it in the browser
+	deviceID := uuid.NewV4().String()
+	encodedDeviceID, err := h.sc.Encode(loginDeviceCookieName, deviceID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterLoginCode").Msg("deviceID encoding failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	cookie := &http.Cookie{
+		Name:     loginDeviceCookieName,
+		Value:    encodedDeviceID,
+		Path:     "/",
+		Expires:  time.Now().Add(loginCodeDuration),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if err := h.cookieCfg.SetCookie(w, cookie); err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterLoginCode").Msg("device cookie setup failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Register login code (errors are not returned to avoid disclosing if an
+	// email is registered or not). Requests exceeding the limit per email are
+	// ignored for the same reason.
+	if !h.loginCodeEmailLimiter.Allow(strings.ToLower(input["email"])) {
+		h.logger.Warn().Str("method", "RegisterLoginCode").Msg("too many login codes requested for email")
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	err = h.userManager.RegisterLoginCode(r.Context(), input["email"], deviceID)
+	if err != nil && !errors.Is(err, user.ErrInvalidEmail) {
+		h.logger.Error().Err(err).Str("method", "RegisterLoginCode").Send()
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
 // RegisterPasswordResetCode is an http handler used to register a code to
 // reset the password. The code will be emailed to the address provided.
 func (h *Handlers) RegisterPasswordResetCode(w http.ResponseWriter, r *http.Request) {
@@ -603,22 +988,22 @@ func (h *Handlers) RegisterUser(w http.ResponseWriter, r *http.Request) {
 // the user id.
 func (h *Handlers) registerUserWithOauth(
 	ctx context.Context,
-	provider string,
-	providerConfig *oauth2.Config,
+	provider *oauthProvider,
 	oauthToken *oauth2.Token,
 ) (string, error) {
 	// Build user from profile from oauth provider
 	var u *hub.User
+	var groups []string
 	var err error
-	switch provider {
+	switch provider.typ {
 	case "github":
-		u, err = h.newUserFromGithubProfile(ctx, oauthToken)
+		u, err = h.newUserFromGithubProfile(ctx, provider, oauthToken)
 	case "google":
-		u, err = h.newUserFromGoogleProfile(ctx, providerConfig, oauthToken)
+		u, err = h.newUserFromGoogleProfile(ctx, provider, oauthToken)
 	case "oidc":
-		u, err = h.newUserFromOIDProfile(ctx, oauthToken)
+		u, groups, err = h.newUserFromOIDProfile(ctx, provider, oauthToken)
 	default:
-		err = fmt.Errorf("invalid provider: %s", provider)
+		err = fmt.Errorf("invalid provider type: %s", provider.typ)
 	}
 	if err != nil {
 		return "", err
@@ -656,18 +1041,35 @@ func (h *Handlers) registerUserWithOauth(
 		}
 	}
 
+	// Sync user organizations memberships with the identity provider groups.
+	// Errors are logged but they don't prevent the user from logging in.
+	if groups != nil {
+		if err := h.userManager.SyncIdPGroups(ctx, userID, groups); err != nil {
+			h.logger.Error().Err(err).Str("method", "registerUserWithOauth").Msg("syncIdPGroups failed")
+		}
+	}
+
 	return userID, nil
 }
 
 // newUserFromGithubProfile builds a new hub.User instance from the user's
-// Github profile.
+// Github profile. When the provider is a GitHub Enterprise server, its API is
+// used to get the user's profile.
 func (h *Handlers) newUserFromGithubProfile(
 	ctx context.Context,
+	provider *oauthProvider,
 	oauthToken *oauth2.Token,
 ) (*hub.User, error) {
 	// Get user profile and emails
 	httpClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(oauthToken))
 	githubClient := github.NewClient(httpClient)
+	if provider.githubAPIURL != "" {
+		apiURL, err := url.Parse(provider.githubAPIURL)
+		if err != nil {
+			return nil, err
+		}
+		githubClient.BaseURL = apiURL
+	}
 	profile, _, err := githubClient.Users.Get(ctx, "")
 	if err != nil {
 		return nil, err
@@ -699,11 +1101,11 @@ func (h *Handlers) newUserFromGithubProfile(
 // Google profile.
 func (h *Handlers) newUserFromGoogleProfile(
 	ctx context.Context,
-	providerConfig *oauth2.Config,
+	provider *oauthProvider,
 	oauthToken *oauth2.Token,
 ) (*hub.User, error) {
 	// Get user profile
-	opt := option.WithTokenSource(providerConfig.TokenSource(ctx, oauthToken))
+	opt := option.WithTokenSource(provider.config.TokenSource(ctx, oauthToken))
 	peopleService, err := people.NewService(ctx, opt)
 	if err != nil {
 		return nil, err
@@ -736,24 +1138,26 @@ func (h *Handlers) newUserFromGoogleProfile(
 }
 
 // newUserFromOIDProfile builds a new hub.User instance from the user's OpenID
-// profile.
+// profile. The identity provider groups the user belongs to are returned as
+// well when the groups claim is present in the id token.
 func (h *Handlers) newUserFromOIDProfile(
 	ctx context.Context,
+	provider *oauthProvider,
 	oauthToken *oauth2.Token,
-) (*hub.User, error) {
+) (*hub.User, []string, error) {
 	// Extract the id token from oauth token
 	rawIDToken, ok := oauthToken.Extra("id_token").(string)
 	if !ok {
-		return nil, errors.New("id token not available")
+		return nil, nil, errors.New("id token not available")
 	}
 
 	// Parse and verify id token payload
-	verifier := h.oidcProvider.Verifier(&oidc.Config{
-		ClientID: h.cfg.GetString("server.oauth.oidc.clientID"),
+	verifier := provider.oidcProvider.Verifier(&oidc.Config{
+		ClientID: provider.config.ClientID,
 	})
 	idToken, err := verifier.Verify(ctx, rawIDToken)
 	if err != nil {
-		return nil, fmt.Errorf("invalid id token: %w", err)
+		return nil, nil, fmt.Errorf("invalid id token: %w", err)
 	}
 
 	// Extract claims
@@ -765,22 +1169,56 @@ func (h *Handlers) newUserFromOIDProfile(
 		PreferredUsername string `json:"preferred_username"`
 	}
 	if err := idToken.Claims(&claims); err != nil {
-		return nil, fmt.Errorf("error extracting claims from id token: %w", err)
+		return nil, nil, fmt.Errorf("error extracting claims from id token: %w", err)
 	}
 	if claims.Email == "" || !claims.EmailVerified {
-		return nil, errors.New("no valid email available for use")
+		return nil, nil, errors.New("no valid email available for use")
 	}
 	alias := claims.PreferredUsername
 	if alias == "" {
 		alias = strings.Split(claims.Email, "@")[0]
 	}
 
+	// Extract groups claim
+	var allClaims map[string]interface{}
+	if err := idToken.Claims(&allClaims); err != nil {
+		return nil, nil, fmt.Errorf("error extracting claims from id token: %w", err)
+	}
+	groupsClaim := h.cfg.GetString("server.oauth." + provider.name + ".groupsClaim")
+	if groupsClaim == "" {
+		groupsClaim = defaultOIDCGroupsClaim
+	}
+	groups := getGroupsFromClaim(allClaims[groupsClaim])
+
 	return &hub.User{
 		Alias:     alias,
 		Email:     claims.Email,
 		FirstName: claims.GivenName,
 		LastName:  claims.FamilyName,
-	}, nil
+	}, groups, nil
+}
+
+// getGroupsFromClaim returns the groups contained in the groups claim value
+// provided. Nil is returned when the claim is not present, so that the user's
+// memberships are not synchronized.
+func getGroupsFromClaim(value interface{}) []string {
+	switch v := value.(type) {
+	case []interface{}:
+		groups := make([]string, 0, len(v))
+		for _, e := range v {
+			if group, ok := e.(string); ok && group != "" {
+				groups = append(groups, group)
+			}
+		}
+		return groups
+	case string:
+		if v == "" {
+			return []string{}
+		}
+		return []string{v}
+	default:
+		return nil
+	}
 }
 
 // RequireLogin is a middleware that verifies if a user is logged in.
@@ -809,7 +1247,7 @@ func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 			userID = checkAPIKeyOutput.UserID
 		} else {
 			// Use cookie based authentication
-			cookie, err := r.Cookie(sessionCookieName)
+			cookie, err := r.Cookie(h.cookieCfg.Name(sessionCookieName))
 			if err == nil {
 				// Extract and validate cookie from request
 				var sessionID string
@@ -847,6 +1285,34 @@ func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 	})
 }
 
+// RequireLoginForAnonymousAccess is a middleware used when the hub runs in
+// login required mode. Anonymous requests are rejected with a hint about how
+// to log in: web pages requests are redirected to the login page and the rest
+// get an unauthorized response. Requests providing credentials are handed
+// over to RequireLogin to be verified.
+func (h *Handlers) RequireLoginForAnonymousAccess(next http.Handler) http.Handler {
+	requireLogin := h.RequireLogin(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Verify credentials if the request provides some
+		hasAPIKey := r.Header.Get(APIKeyIDHeader) != "" && r.Header.Get(APIKeySecretHeader) != ""
+		_, err := r.Cookie(h.cookieCfg.Name(sessionCookieName))
+		hasSessionCookie := err == nil
+		if hasAPIKey || hasSessionCookie {
+			requireLogin.ServeHTTP(w, r)
+			return
+		}
+
+		// Anonymous request, redirect to the login page or reject it
+		if r.Method == "GET" && strings.Contains(r.Header.Get("Accept"), "text/html") {
+			loginURL := "/?modal=login&redirect=" + url.QueryEscape(r.URL.RequestURI())
+			http.Redirect(w, r, loginURL, http.StatusFound)
+			return
+		}
+		w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(0))
+		helpers.RenderErrorWithCodeJSON(w, errLoginRequired, http.StatusUnauthorized)
+	})
+}
+
 // ResetPassword is an http handler used to reset the user's password.
 func (h *Handlers) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	var input map[string]string
@@ -962,6 +1428,78 @@ func (h *Handlers) VerifyPasswordResetCode(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusOK)
 }
 
+// setSessionCookie encodes the session id provided and sets the session cookie.
+func (h *Handlers) setSessionCookie(w http.ResponseWriter, sessionID string) error {
+	encodedSessionID, err := h.sc.Encode(sessionCookieName, sessionID)
+	if err != nil {
+		return err
+	}
+	cookie := &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    encodedSessionID,
+		Path:     "/",
+		Expires:  time.Now().Add(sessionDuration),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	return h.cookieCfg.SetCookie(w, cookie)
+}
+
+// getSessionID returns the session id stored in the session cookie.
+func (h *Handlers) getSessionID(r *http.Request) (string, error) {
+	cookie, err := r.Cookie(h.cookieCfg.Name(sessionCookieName))
+	if err != nil {
+		return "", err
+	}
+	var sessionID string
+	if err := h.sc.Decode(sessionCookieName, cookie.Value, &sessionID); err != nil {
+		return "", err
+	}
+	return sessionID, nil
+}
+
+// setWebAuthnCeremonyCookie stores the WebAuthn ceremony id provided in a
+// cookie, so that it is available when the ceremony is finished.
+func (h *Handlers) setWebAuthnCeremonyCookie(w http.ResponseWriter, ceremonyID string) error {
+	encodedCeremonyID, err := h.sc.Encode(webAuthnCeremonyCookieName, ceremonyID)
+	if err != nil {
+		return err
+	}
+	cookie := &http.Cookie{
+		Name:     webAuthnCeremonyCookieName,
+		Value:    encodedCeremonyID,
+		Path:     "/",
+		Expires:  time.Now().Add(webAuthnCeremonyDuration),
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+	return h.cookieCfg.SetCookie(w, cookie)
+}
+
+// getWebAuthnCeremonyID returns the WebAuthn ceremony id stored in the
+// ceremony cookie.
+func (h *Handlers) getWebAuthnCeremonyID(r *http.Request) (string, error) {
+	cookie, err := r.Cookie(h.cookieCfg.Name(webAuthnCeremonyCookieName))
+	if err != nil {
+		return "", err
+	}
+	var ceremonyID string
+	if err := h.sc.Decode(webAuthnCeremonyCookieName, cookie.Value, &ceremonyID); err != nil {
+		return "", err
+	}
+	return ceremonyID, nil
+}
+
+// deleteWebAuthnCeremonyCookie deletes the WebAuthn ceremony cookie, as the
+// ceremony cannot be used again.
+func (h *Handlers) deleteWebAuthnCeremonyCookie(w http.ResponseWriter) error {
+	return h.cookieCfg.SetCookie(w, &http.Cookie{
+		Name:    webAuthnCeremonyCookieName,
+		Path:    "/",
+		Expires: time.Now().Add(-24 * time.Hour),
+	})
+}
+
 // OauthState represents the state of an oauth authorization session, used to
 // increase the security of the process and to restore the state of the
 // application.
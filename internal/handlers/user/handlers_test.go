@@ -138,6 +138,102 @@ func TestApproveSession(t *testing.T) {
 	})
 }
 
+func TestApproveSessionWithWebAuthn(t *testing.T) {
+	sessionID := "sessionID"
+	ceremonyID := "ceremonyID"
+	response := []byte("response")
+
+	newRequest := func(hw *handlersWrapper, withSession, withCeremonyID bool) *http.Request {
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(string(response)))
+		if withSession {
+			encodedSessionID, _ := hw.h.sc.Encode(sessionCookieName, sessionID)
+			r.AddCookie(&http.Cookie{Name: sessionCookieName, Value: encodedSessionID})
+		}
+		if withCeremonyID {
+			encodedCeremonyID, _ := hw.h.sc.Encode(webAuthnCeremonyCookieName, ceremonyID)
+			r.AddCookie(&http.Cookie{Name: webAuthnCeremonyCookieName, Value: encodedCeremonyID})
+		}
+		return r
+	}
+
+	t.Run("session cookie not provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		hw := newHandlersWrapper()
+		r := newRequest(hw, false, true)
+
+		hw.h.ApproveSessionWithWebAuthn(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("ceremony cookie not provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		hw := newHandlersWrapper()
+		r := newRequest(hw, true, false)
+
+		hw.h.ApproveSessionWithWebAuthn(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("error approving session", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				user.ErrInvalidWebAuthnLogin,
+				http.StatusUnauthorized,
+			},
+			{
+				tests.ErrFake,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				hw := newHandlersWrapper()
+				r := newRequest(hw, true, true)
+				hw.um.On("ApproveSessionWithWebAuthn", r.Context(), sessionID, ceremonyID, response).Return(tc.err)
+
+				hw.h.ApproveSessionWithWebAuthn(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("session approval succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		hw := newHandlersWrapper()
+		r := newRequest(hw, true, true)
+		hw.um.On("ApproveSessionWithWebAuthn", r.Context(), sessionID, ceremonyID, response).Return(nil)
+
+		hw.h.ApproveSessionWithWebAuthn(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Len(t, resp.Cookies(), 1)
+		assert.Equal(t, webAuthnCeremonyCookieName, resp.Cookies()[0].Name)
+		assert.True(t, resp.Cookies()[0].Expires.Before(time.Now()))
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestBasicAuth(t *testing.T) {
 	hw := newHandlersWrapper()
 	hw.cfg.Set("server.basicAuth.enabled", true)
@@ -168,6 +264,199 @@ func TestBasicAuth(t *testing.T) {
 	})
 }
 
+func TestBeginWebAuthnLogin(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"email": "email" ...`))
+
+		hw := newHandlersWrapper()
+		hw.h.BeginWebAuthnLogin(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error starting login ceremony", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFake,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"email": "email"}`))
+
+				hw := newHandlersWrapper()
+				hw.um.On("BeginWebAuthnLogin", r.Context(), "email").Return(nil, tc.err)
+				hw.h.BeginWebAuthnLogin(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("login ceremony started successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"email": "email"}`))
+
+		hw := newHandlersWrapper()
+		hw.um.On("BeginWebAuthnLogin", r.Context(), "email").Return(&hub.WebAuthnCeremony{
+			ID:      "ceremonyID",
+			Options: []byte("options"),
+		}, nil)
+		hw.h.BeginWebAuthnLogin(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []byte("options"), data)
+		require.Len(t, resp.Cookies(), 1)
+		cookie := resp.Cookies()[0]
+		assert.Equal(t, webAuthnCeremonyCookieName, cookie.Name)
+		assert.True(t, cookie.HttpOnly)
+		var ceremonyID string
+		err := hw.h.sc.Decode(webAuthnCeremonyCookieName, cookie.Value, &ceremonyID)
+		require.NoError(t, err)
+		assert.Equal(t, "ceremonyID", ceremonyID)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestBeginWebAuthnRegistration(t *testing.T) {
+	t.Run("error starting registration ceremony", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("BeginWebAuthnRegistration", r.Context()).Return(nil, tests.ErrFake)
+		hw.h.BeginWebAuthnRegistration(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("registration ceremony started successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("BeginWebAuthnRegistration", r.Context()).Return(&hub.WebAuthnCeremony{
+			ID:      "ceremonyID",
+			Options: []byte("options"),
+		}, nil)
+		hw.h.BeginWebAuthnRegistration(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []byte("options"), data)
+		require.Len(t, resp.Cookies(), 1)
+		assert.Equal(t, webAuthnCeremonyCookieName, resp.Cookies()[0].Name)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestBeginWebAuthnSessionApproval(t *testing.T) {
+	sessionID := "sessionID"
+
+	t.Run("session cookie not provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.BeginWebAuthnSessionApproval(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("error starting login ceremony", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				user.ErrInvalidWebAuthnLogin,
+				http.StatusUnauthorized,
+			},
+			{
+				tests.ErrFake,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", nil)
+
+				hw := newHandlersWrapper()
+				encodedSessionID, _ := hw.h.sc.Encode(sessionCookieName, sessionID)
+				r.AddCookie(&http.Cookie{Name: sessionCookieName, Value: encodedSessionID})
+				hw.um.On("BeginWebAuthnSessionApproval", r.Context(), sessionID).Return(nil, tc.err)
+				hw.h.BeginWebAuthnSessionApproval(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("login ceremony started successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+
+		hw := newHandlersWrapper()
+		encodedSessionID, _ := hw.h.sc.Encode(sessionCookieName, sessionID)
+		r.AddCookie(&http.Cookie{Name: sessionCookieName, Value: encodedSessionID})
+		hw.um.On("BeginWebAuthnSessionApproval", r.Context(), sessionID).Return(&hub.WebAuthnCeremony{
+			ID:      "ceremonyID",
+			Options: []byte("options"),
+		}, nil)
+		hw.h.BeginWebAuthnSessionApproval(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []byte("options"), data)
+		require.Len(t, resp.Cookies(), 1)
+		assert.Equal(t, webAuthnCeremonyCookieName, resp.Cookies()[0].Name)
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestCheckPasswordStrength(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
@@ -398,6 +687,67 @@ func TestDeleteUser(t *testing.T) {
 	})
 }
 
+func TestDeleteWebAuthnCredential(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"credentialID"},
+			Values: []string{"credentialID"},
+		},
+	}
+
+	t.Run("error deleting credential", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.um.On("DeleteWebAuthnCredential", r.Context(), "credentialID").Return(tc.err)
+				hw.h.DeleteWebAuthnCredential(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("credential deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.um.On("DeleteWebAuthnCredential", r.Context(), "credentialID").Return(nil)
+		hw.h.DeleteWebAuthnCredential(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestDisableTFA(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
@@ -528,16 +878,280 @@ func TestEnableTFA(t *testing.T) {
 	})
 }
 
-func TestGetProfile(t *testing.T) {
-	t.Run("error getting profile", func(t *testing.T) {
+func TestFinishWebAuthnLogin(t *testing.T) {
+	sessionID := "sessionID"
+	ceremonyID := "ceremonyID"
+	response := []byte("response")
+
+	newRequest := func(hw *handlersWrapper) *http.Request {
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(string(response)))
+		encodedCeremonyID, _ := hw.h.sc.Encode(webAuthnCeremonyCookieName, ceremonyID)
+		r.AddCookie(&http.Cookie{Name: webAuthnCeremonyCookieName, Value: encodedCeremonyID})
+		return r
+	}
+
+	t.Run("ceremony cookie not provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(string(response)))
+
+		hw := newHandlersWrapper()
+		hw.h.FinishWebAuthnLogin(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("error finishing login ceremony", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				user.ErrInvalidWebAuthnLogin,
+				http.StatusUnauthorized,
+			},
+			{
+				tests.ErrFake,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				hw := newHandlersWrapper()
+				r := newRequest(hw)
+				hw.um.On("FinishWebAuthnLogin", r.Context(), ceremonyID, response).Return("", tc.err)
+
+				hw.h.FinishWebAuthnLogin(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error registering session", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		hw := newHandlersWrapper()
+		r := newRequest(hw)
+		hw.um.On("FinishWebAuthnLogin", r.Context(), ceremonyID, response).Return("userID", nil)
+		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID", Approved: true}).
+			Return(nil, tests.ErrFakeDB)
+
+		hw.h.FinishWebAuthnLogin(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("login succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		hw := newHandlersWrapper()
+		r := newRequest(hw)
+		hw.um.On("FinishWebAuthnLogin", r.Context(), ceremonyID, response).Return("userID", nil)
+		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID", Approved: true}).
+			Return(&hub.Session{SessionID: sessionID, Approved: true}, nil)
+
+		hw.h.FinishWebAuthnLogin(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Len(t, resp.Cookies(), 2)
+		assert.Equal(t, webAuthnCeremonyCookieName, resp.Cookies()[0].Name)
+		assert.True(t, resp.Cookies()[0].Expires.Before(time.Now()))
+		cookie := resp.Cookies()[1]
+		assert.Equal(t, sessionCookieName, cookie.Name)
+		var cookieSessionID string
+		err := hw.h.sc.Decode(sessionCookieName, cookie.Value, &cookieSessionID)
+		require.NoError(t, err)
+		assert.Equal(t, sessionID, cookieSessionID)
+		assert.Equal(t, "true", h.Get(SessionApprovedHeader))
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestFinishWebAuthnRegistration(t *testing.T) {
+	ceremonyID := "ceremonyID"
+	response := []byte("response")
+
+	newRequest := func(hw *handlersWrapper) *http.Request {
+		r, _ := http.NewRequest("POST", "/?name=key1", strings.NewReader(string(response)))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		encodedCeremonyID, _ := hw.h.sc.Encode(webAuthnCeremonyCookieName, ceremonyID)
+		r.AddCookie(&http.Cookie{Name: webAuthnCeremonyCookieName, Value: encodedCeremonyID})
+		return r
+	}
+
+	t.Run("ceremony cookie not provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/?name=key1", strings.NewReader(string(response)))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.h.FinishWebAuthnRegistration(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error finishing registration ceremony", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				hw := newHandlersWrapper()
+				r := newRequest(hw)
+				hw.um.On("FinishWebAuthnRegistration", r.Context(), "key1", ceremonyID, response).Return(tc.err)
+
+				hw.h.FinishWebAuthnRegistration(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("credential registered successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		hw := newHandlersWrapper()
+		r := newRequest(hw)
+		hw.um.On("FinishWebAuthnRegistration", r.Context(), "key1", ceremonyID, response).Return(nil)
+
+		hw.h.FinishWebAuthnRegistration(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestGetProfile(t *testing.T) {
+	t.Run("error getting profile", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("GetProfileJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetProfile(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("profile get succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("GetProfileJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetProfile(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestGetGroupsFromClaim(t *testing.T) {
+	testCases := []struct {
+		description    string
+		value          interface{}
+		expectedGroups []string
+	}{
+		{
+			"claim not present",
+			nil,
+			nil,
+		},
+		{
+			"claim of unsupported type",
+			10.0,
+			nil,
+		},
+		{
+			"single group",
+			"group1",
+			[]string{"group1"},
+		},
+		{
+			"empty string",
+			"",
+			[]string{},
+		},
+		{
+			"list of groups",
+			[]interface{}{"group1", "", 1.0, "group2"},
+			[]string{"group1", "group2"},
+		},
+		{
+			"empty list",
+			[]interface{}{},
+			[]string{},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedGroups, getGroupsFromClaim(tc.value))
+		})
+	}
+}
+
+func TestGetWebAuthnCredentials(t *testing.T) {
+	t.Run("error getting credentials", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 
 		hw := newHandlersWrapper()
-		hw.um.On("GetProfileJSON", r.Context()).Return(nil, tests.ErrFakeDB)
-		hw.h.GetProfile(w, r)
+		hw.um.On("GetWebAuthnCredentialsJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetWebAuthnCredentials(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
@@ -545,15 +1159,15 @@ func TestGetProfile(t *testing.T) {
 		hw.um.AssertExpectations(t)
 	})
 
-	t.Run("profile get succeeded", func(t *testing.T) {
+	t.Run("get credentials succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 
 		hw := newHandlersWrapper()
-		hw.um.On("GetProfileJSON", r.Context()).Return([]byte("dataJSON"), nil)
-		hw.h.GetProfile(w, r)
+		hw.um.On("GetWebAuthnCredentialsJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetWebAuthnCredentials(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 		h := resp.Header
@@ -828,6 +1442,154 @@ func TestLogin(t *testing.T) {
 	})
 }
 
+func TestLoginWithCode(t *testing.T) {
+	sessionID := "sessionID"
+	deviceID := "deviceID"
+
+	newRequest := func(hw *handlersWrapper, body string) *http.Request {
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+		encodedDeviceID, _ := hw.h.sc.Encode(loginDeviceCookieName, deviceID)
+		r.AddCookie(&http.Cookie{
+			Name:  loginDeviceCookieName,
+			Value: encodedDeviceID,
+		})
+		return r
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		hw := newHandlersWrapper()
+		r := newRequest(hw, `{"code": "code" ...`)
+
+		hw.h.LoginWithCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("device cookie not provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"code": "code"}`)
+		r, _ := http.NewRequest("POST", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.h.LoginWithCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("invalid device cookie provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"code": "code"}`)
+		r, _ := http.NewRequest("POST", "/", body)
+		r.AddCookie(&http.Cookie{
+			Name:  loginDeviceCookieName,
+			Value: "invalidValue",
+		})
+
+		hw := newHandlersWrapper()
+		hw.h.LoginWithCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("error verifying login code", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				user.ErrInvalidLoginCode,
+				http.StatusUnauthorized,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				hw := newHandlersWrapper()
+				r := newRequest(hw, `{"code": "code"}`)
+
+				hw.um.On("VerifyLoginCode", r.Context(), "code", deviceID).Return("", tc.err)
+				hw.h.LoginWithCode(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error registering session", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		hw := newHandlersWrapper()
+		r := newRequest(hw, `{"code": "code"}`)
+
+		hw.um.On("VerifyLoginCode", r.Context(), "code", deviceID).Return("userID", nil)
+		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID"}).
+			Return(nil, tests.ErrFakeDB)
+		hw.h.LoginWithCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("login succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		hw := newHandlersWrapper()
+		r := newRequest(hw, `{"code": "code"}`)
+
+		hw.um.On("VerifyLoginCode", r.Context(), "code", deviceID).Return("userID", nil)
+		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID"}).
+			Return(&hub.Session{
+				SessionID: sessionID,
+				Approved:  true,
+			}, nil)
+		hw.h.LoginWithCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Len(t, resp.Cookies(), 2)
+		deviceCookie := resp.Cookies()[0]
+		assert.Equal(t, loginDeviceCookieName, deviceCookie.Name)
+		assert.True(t, deviceCookie.Expires.Before(time.Now()))
+		cookie := resp.Cookies()[1]
+		assert.Equal(t, sessionCookieName, cookie.Name)
+		assert.Equal(t, "/", cookie.Path)
+		assert.True(t, cookie.HttpOnly)
+		var cookieSessionID string
+		err := hw.h.sc.Decode(sessionCookieName, cookie.Value, &cookieSessionID)
+		require.NoError(t, err)
+		assert.Equal(t, sessionID, cookieSessionID)
+		assert.Equal(t, "true", h.Get(SessionApprovedHeader))
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestLogout(t *testing.T) {
 	t.Run("invalid or no session cookie provided", func(t *testing.T) {
 		testCases := []struct {
@@ -914,6 +1676,48 @@ func TestLogout(t *testing.T) {
 	})
 }
 
+func TestNewHandlers(t *testing.T) {
+	t.Run("invalid cookie configuration", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.cookie.hostPrefix", true)
+		h, err := NewHandlers(context.Background(), nil, nil, cfg)
+		assert.Nil(t, h)
+		assert.Contains(t, err.Error(), "invalid cookie configuration")
+	})
+
+	t.Run("cookies are set using the configured attributes", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.baseURL", "https://hub.test")
+		cfg.Set("server.cookie.hostPrefix", true)
+		cfg.Set("server.cookie.sameSite", "strict")
+		um := &user.ManagerMock{}
+		h, err := NewHandlers(context.Background(), um, nil, cfg)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		um.On("DeleteSession", r.Context(), "sessionID").Return(nil)
+		encodedSessionID, _ := h.sc.Encode(sessionCookieName, "sessionID")
+		r.AddCookie(&http.Cookie{
+			Name:  "__Host-" + sessionCookieName,
+			Value: encodedSessionID,
+		})
+		h.Logout(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Len(t, resp.Cookies(), 1)
+		cookie := resp.Cookies()[0]
+		assert.Equal(t, "__Host-"+sessionCookieName, cookie.Name)
+		assert.True(t, cookie.Secure)
+		assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+		um.AssertExpectations(t)
+	})
+}
+
 func TestOauthCallback(t *testing.T) {
 	t.Run("invalid oauth code or state", func(t *testing.T) {
 		state := &OauthState{
@@ -1002,7 +1806,7 @@ func TestOauthRedirect(t *testing.T) {
 		Random:      resp.Cookies()[0].Value,
 		RedirectURL: "/",
 	}
-	expectedRedirectURL := hw.h.oauthConfig["github"].AuthCodeURL(state.String())
+	expectedRedirectURL := hw.h.oauthProviders["github"].config.AuthCodeURL(state.String())
 	redirectURL, err := resp.Location()
 	require.NoError(t, err)
 	assert.Equal(t, expectedRedirectURL, redirectURL.String())
@@ -1042,6 +1846,103 @@ func TestRegisterDeleteUserCode(t *testing.T) {
 	})
 }
 
+func TestRegisterLoginCode(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`email`)
+		r, _ := http.NewRequest("POST", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.h.RegisterLoginCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("register login code failed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"email": "email"}`)
+		r, _ := http.NewRequest("POST", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.um.On("RegisterLoginCode", r.Context(), "email", mock.Anything).Return(tests.ErrFakeDB)
+		hw.h.RegisterLoginCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("register login code succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"email": "email"}`)
+		r, _ := http.NewRequest("POST", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.um.On("RegisterLoginCode", r.Context(), "email", mock.Anything).Return(nil)
+		hw.h.RegisterLoginCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Len(t, resp.Cookies(), 1)
+		cookie := resp.Cookies()[0]
+		assert.Equal(t, loginDeviceCookieName, cookie.Name)
+		assert.Equal(t, "/", cookie.Path)
+		assert.True(t, cookie.HttpOnly)
+		var deviceID string
+		err := hw.h.sc.Decode(loginDeviceCookieName, cookie.Value, &deviceID)
+		require.NoError(t, err)
+		hw.um.AssertCalled(t, "RegisterLoginCode", r.Context(), "email", deviceID)
+	})
+
+	t.Run("too many login codes requested from the same ip", func(t *testing.T) {
+		t.Parallel()
+		hw := newHandlersWrapper()
+		hw.um.On("RegisterLoginCode", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		for i := 0; i <= loginCodesPerIPPerHour; i++ {
+			w := httptest.NewRecorder()
+			body := strings.NewReader(fmt.Sprintf(`{"email": "email%d"}`, i))
+			r, _ := http.NewRequest("POST", "/", body)
+			r.RemoteAddr = "192.0.2.1:1234"
+			hw.h.RegisterLoginCode(w, r)
+			resp := w.Result()
+			resp.Body.Close()
+
+			if i < loginCodesPerIPPerHour {
+				assert.Equal(t, http.StatusCreated, resp.StatusCode)
+			} else {
+				assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+			}
+		}
+		hw.um.AssertNumberOfCalls(t, "RegisterLoginCode", loginCodesPerIPPerHour)
+	})
+
+	t.Run("too many login codes requested for the same email", func(t *testing.T) {
+		t.Parallel()
+		hw := newHandlersWrapper()
+		hw.um.On("RegisterLoginCode", mock.Anything, "email", mock.Anything).Return(nil)
+		for i := 0; i <= loginCodesPerEmailPerHour; i++ {
+			w := httptest.NewRecorder()
+			body := strings.NewReader(`{"email": "email"}`)
+			r, _ := http.NewRequest("POST", "/", body)
+			r.RemoteAddr = fmt.Sprintf("192.0.2.%d:1234", i)
+			hw.h.RegisterLoginCode(w, r)
+			resp := w.Result()
+			resp.Body.Close()
+
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		}
+		hw.um.AssertNumberOfCalls(t, "RegisterLoginCode", loginCodesPerEmailPerHour)
+	})
+}
+
 func TestRegisterPasswordResetCode(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
@@ -1420,6 +2321,62 @@ func TestRequireLogin(t *testing.T) {
 	})
 }
 
+func TestRequireLoginForAnonymousAccess(t *testing.T) {
+	t.Run("anonymous web page request redirected to login page", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/packages/helm/repo1/pkg1?tab=values", nil)
+		r.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+		hw := newHandlersWrapper()
+		hw.h.RequireLoginForAnonymousAccess(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+		assert.Equal(t, "/?modal=login&redirect=%2Fpackages%2Fhelm%2Frepo1%2Fpkg1%3Ftab%3Dvalues", h.Get("Location"))
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("anonymous api request rejected", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/api/v1/packages/search", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.RequireLoginForAnonymousAccess(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, buildError(errLoginRequired.Error()), data)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("request with credentials verified", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/api/v1/packages/search", nil)
+		r.Header.Set(APIKeyIDHeader, "keyID")
+		r.Header.Set(APIKeySecretHeader, "secret")
+
+		hw := newHandlersWrapper()
+		hw.am.On("Check", r.Context(), "keyID", "secret").
+			Return(&hub.CheckAPIKeyOutput{UserID: "userID", Valid: true}, nil)
+		hw.h.RequireLoginForAnonymousAccess(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
 func TestResetPassword(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
@@ -115,6 +115,26 @@ func (h *Handlers) GetOwnedByUser(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
 }
 
+// GetTemplateFunctions is an http handler that returns the documentation of
+// the functions available in the webhooks payloads templates.
+func (h *Handlers) GetTemplateFunctions(w http.ResponseWriter, r *http.Request) {
+	dataJSON, _ := json.Marshal(notification.WebhookTemplateFuncs())
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetTemplateErrors is an http handler that returns the most recent errors
+// rendering the payload template of the provided webhook.
+func (h *Handlers) GetTemplateErrors(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "webhookID")
+	dataJSON, err := h.webhookManager.GetTemplateErrorsJSON(r.Context(), webhookID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetTemplateErrors").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // TriggerTest is an http handler used to test a webhook before adding or
 // updating it.
 func (h *Handlers) TriggerTest(w http.ResponseWriter, r *http.Request) {
@@ -125,11 +145,22 @@ func (h *Handlers) TriggerTest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Alerting webhooks are tested using a sample security alert
+	if notification.IsAlertingWebhook(wh) {
+		req, err := notification.NewAlertRequest(wh, webhookTestAlertTemplateData)
+		if err != nil {
+			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+			return
+		}
+		h.callTestWebhook(w, req)
+		return
+	}
+
 	// Prepare payload
 	var tmpl *template.Template
 	if wh.Template != "" {
 		var err error
-		tmpl, err = template.New("").Parse(wh.Template)
+		tmpl, err = notification.ParseWebhookTemplate(wh.Template)
 		if err != nil {
 			err = fmt.Errorf("error parsing template: %w", err)
 			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
@@ -153,6 +184,12 @@ func (h *Handlers) TriggerTest(w http.ResponseWriter, r *http.Request) {
 	}
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("X-ArtifactHub-Secret", wh.Secret)
+	h.callTestWebhook(w, req)
+}
+
+// callTestWebhook sends the test webhook request provided, rendering the
+// result of the call.
+func (h *Handlers) callTestWebhook(w http.ResponseWriter, req *http.Request) {
 	resp, err := h.hc.Do(req)
 	if err != nil {
 		err = fmt.Errorf("error doing request: %w", err)
@@ -195,9 +232,11 @@ var webhookTestTemplateData = &hub.PackageNotificationTemplateData{
 		"Kind": "package.new-release",
 	},
 	Package: map[string]interface{}{
-		"Name":    "sample-package",
-		"Version": "1.0.0",
-		"URL":     "https://artifacthub.io/packages/helm/artifacthub/sample-package/1.0.0",
+		"Name":        "sample-package",
+		"Version":     "1.0.0",
+		"Description": "Sample package description",
+		"TS":          int64(1609459200),
+		"URL":         "https://artifacthub.io/packages/helm/artifacthub/sample-package/1.0.0",
 		"Changes": []*hub.Change{
 			{
 				Description: "Cool feature",
@@ -215,3 +254,27 @@ var webhookTestTemplateData = &hub.PackageNotificationTemplateData{
 		},
 	},
 }
+
+// webhookTestAlertTemplateData represents the notification template data used
+// by TriggerTest handler to test alerting webhooks.
+var webhookTestAlertTemplateData = &hub.PackageNotificationTemplateData{
+	BaseURL: "https://baseURL",
+	Event: map[string]interface{}{
+		"ID":   "00000000-0000-0000-0000-000000000001",
+		"Kind": "package.security-alert",
+	},
+	Package: map[string]interface{}{
+		"Name":    "sample-package",
+		"Version": "1.0.0",
+		"URL":     "https://artifacthub.io/packages/helm/artifacthub/sample-package/1.0.0",
+		"Repository": map[string]interface{}{
+			"Kind":      "helm",
+			"Name":      "repo1",
+			"Publisher": "org1",
+		},
+		"SecurityReportSummary": &hub.SecurityReportSummary{
+			Critical: 1,
+			High:     2,
+		},
+	},
+}
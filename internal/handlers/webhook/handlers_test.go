@@ -398,6 +398,95 @@ func TestGetOwnedByUser(t *testing.T) {
 	})
 }
 
+func TestGetTemplateFunctions(t *testing.T) {
+	t.Parallel()
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/", nil)
+
+	hw := newHandlersWrapper()
+	hw.h.GetTemplateFunctions(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	h := resp.Header
+	data, _ := ioutil.ReadAll(resp.Body)
+	expectedData, _ := json.Marshal(notification.WebhookTemplateFuncs())
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", h.Get("Content-Type"))
+	assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+	assert.Equal(t, expectedData, data)
+}
+
+func TestGetTemplateErrors(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"webhookID"},
+			Values: []string{"000000001"},
+		},
+	}
+
+	t.Run("error getting webhook template errors", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("GetTemplateErrorsJSON", r.Context(), "000000001").Return(nil, tc.err)
+				hw.h.GetTemplateErrors(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("webhook template errors get succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("GetTemplateErrorsJSON", r.Context(), "000000001").Return([]byte("dataJSON"), nil)
+		hw.h.GetTemplateErrors(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.wm.AssertExpectations(t)
+	})
+}
+
 func TestTriggerTest(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
@@ -563,6 +652,13 @@ func TestTriggerTest(t *testing.T) {
 				"very",
 				[]byte("Package sample-package 1.0.0 updated!"),
 			},
+			{
+				"3",
+				"application/json",
+				`{"text": {{ printf "%s released on %s" .Package.Name (date "2006-01-02" .Package.TS) | toJson }}}`,
+				"",
+				[]byte(`{"text": "sample-package released on 2021-01-01"}`),
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -17,6 +17,14 @@ const (
 	// to an organization.
 	AddOrganizationRepository Action = "addOrganizationRepository"
 
+	// AddOrganizationServiceAccount represents the action of adding a service
+	// account (or an api key for it) to an organization.
+	AddOrganizationServiceAccount Action = "addOrganizationServiceAccount"
+
+	// AddOrganizationWebhook represents the action of adding a webhook to an
+	// organization.
+	AddOrganizationWebhook Action = "addOrganizationWebhook"
+
 	// DeleteOrganization represents the action of deleting an organization.
 	DeleteOrganization Action = "deleteOrganization"
 
@@ -28,10 +36,34 @@ const (
 	// repository from an organization.
 	DeleteOrganizationRepository Action = "deleteOrganizationRepository"
 
+	// DeleteOrganizationServiceAccount represents the action of deleting a
+	// service account (or any of its api keys) from an organization.
+	DeleteOrganizationServiceAccount Action = "deleteOrganizationServiceAccount"
+
+	// DeleteOrganizationWebhook represents the action of deleting a webhook
+	// that belongs to an organization.
+	DeleteOrganizationWebhook Action = "deleteOrganizationWebhook"
+
 	// GetAuthorizationPolicy represents the action of getting an organization
 	// authorization policy.
 	GetAuthorizationPolicy Action = "getAuthorizationPolicy"
 
+	// ModerateAbuseReports represents the action of reviewing and resolving
+	// the abuse reports submitted by users.
+	ModerateAbuseReports Action = "moderateAbuseReports"
+
+	// ModerateImageUploads represents the action of approving or rejecting
+	// the images uploaded by publishers.
+	ModerateImageUploads Action = "moderateImageUploads"
+
+	// ModerateOfficialRequests represents the action of reviewing the requests
+	// to mark packages or repositories as official.
+	ModerateOfficialRequests Action = "moderateOfficialRequests"
+
+	// ModerateQuarantineApprovals represents the action of approving or
+	// rejecting the release of quarantined packages.
+	ModerateQuarantineApprovals Action = "moderateQuarantineApprovals"
+
 	// TransferOrganizationRepository represents the action of transferring a
 	// repository that belongs to an organization.
 	TransferOrganizationRepository Action = "transferOrganizationRepository"
@@ -44,9 +76,17 @@ const (
 	// organization.
 	UpdateOrganization Action = "updateOrganization"
 
+	// UpdateOrganizationMemberRole represents the action of updating the role
+	// of a member of an organization.
+	UpdateOrganizationMemberRole Action = "updateOrganizationMemberRole"
+
 	// UpdateOrganizationRepository represents the action of updating a
 	// repository that belongs to an organization.
 	UpdateOrganizationRepository Action = "updateOrganizationRepository"
+
+	// UpdateOrganizationWebhook represents the action of updating a webhook
+	// that belongs to an organization.
+	UpdateOrganizationWebhook Action = "updateOrganizationWebhook"
 )
 
 // AuthorizationPolicy represents some information about the authorization
@@ -61,6 +101,7 @@ type AuthorizationPolicy struct {
 // Authorizer describes the methods an Authorizer implementation must provide.
 type Authorizer interface {
 	Authorize(ctx context.Context, input *AuthorizeInput) error
+	AuthorizeSiteAction(ctx context.Context, userID string, action Action) error
 	GetAllowedActions(ctx context.Context, userID, orgName string) ([]Action, error)
 	WillUserBeLockedOut(ctx context.Context, newPolicy *AuthorizationPolicy, userID string) (bool, error)
 }
@@ -77,3 +118,17 @@ type AuthorizeInput struct {
 	// Action represents the action to perform.
 	Action Action
 }
+
+// SiteRole represents a role a user has in the site, not bound to any
+// organization.
+type SiteRole string
+
+const (
+	// SiteAdmin represents the site admin role. Site admins are allowed to
+	// perform all site actions.
+	SiteAdmin SiteRole = "admin"
+
+	// SiteModerator represents the moderator role. Moderators are only
+	// allowed to work on the moderation queues.
+	SiteModerator SiteRole = "moderator"
+)
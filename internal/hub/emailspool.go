@@ -0,0 +1,9 @@
+package hub
+
+import "context"
+
+// EmailSpoolManager describes the methods an EmailSpoolManager implementation
+// must provide.
+type EmailSpoolManager interface {
+	Flush(ctx context.Context) (int, error)
+}
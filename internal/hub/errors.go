@@ -18,6 +18,7 @@ var (
 // implementation should provide.
 type ErrorsCollector interface {
 	Append(repositoryID string, err string)
+	Finish(repositoryID string, run *RepositoryTrackingRun)
 	Flush()
 	Init(repositoryID string)
 }
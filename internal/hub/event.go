@@ -37,6 +37,10 @@ const (
 	// RepositoryScanningErrors represents an event for errors that occur while
 	// a repository is being scanned.
 	RepositoryScanningErrors EventKind = 4
+
+	// PackageDeprecated represents an event for a package whose latest version
+	// has been deprecated.
+	PackageDeprecated EventKind = 5
 )
 
 // EventManager describes the methods an EventManager implementation must
@@ -28,6 +28,11 @@ type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// TXTResolver defines the methods a TXTResolver implementation must provide.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
 // JSONQueryResult represents the result of a database query that returns json
 // data alongside some metadata.
 type JSONQueryResult struct {
@@ -40,3 +45,10 @@ type Pagination struct {
 	Limit  int `json:"limit"`
 	Offset int `json:"offset"`
 }
+
+// ResourceHead represents some information about a resource that allows
+// clients to check cheaply if it has changed.
+type ResourceHead struct {
+	Digest       string `json:"digest"`
+	LastModified int64  `json:"last_modified"`
+}
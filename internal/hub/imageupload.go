@@ -0,0 +1,32 @@
+package hub
+
+import "context"
+
+// ImageUpload represents an image being uploaded in chunks by a publisher.
+type ImageUpload struct {
+	ImageUploadID    string `json:"image_upload_id"`
+	Size             int    `json:"size"`
+	Checksum         string `json:"checksum"`
+	Offset           int    `json:"offset"`
+	ImageID          string `json:"image_id,omitempty"`
+	ModerationStatus string `json:"moderation_status"`
+}
+
+// ImageUploadManager describes the methods an ImageUploadManager
+// implementation must provide.
+type ImageUploadManager interface {
+	Add(ctx context.Context, size int, checksum string) (string, error)
+	AppendChunk(ctx context.Context, imageUploadID string, offset int, chunk []byte) (*ImageUpload, error)
+	DeleteAbandoned(ctx context.Context) error
+	Get(ctx context.Context, imageUploadID string) (*ImageUpload, error)
+	GetImage(ctx context.Context, imageUploadID string) ([]byte, error)
+	GetPendingModerationJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
+	Moderate(ctx context.Context, imageUploadID, status string) error
+}
+
+// Image uploads moderation statuses.
+const (
+	ImageUploadPending  = "pending"
+	ImageUploadApproved = "approved"
+	ImageUploadRejected = "rejected"
+)
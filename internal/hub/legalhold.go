@@ -0,0 +1,21 @@
+package hub
+
+import "context"
+
+// LegalHold represents a legal hold placed on a package or a repository for
+// compliance reasons. While the hold is active, the resource held and its
+// versions cannot be deleted.
+type LegalHold struct {
+	LegalHoldID  string `json:"legal_hold_id,omitempty"`
+	PackageID    string `json:"package_id,omitempty"`
+	RepositoryID string `json:"repository_id,omitempty"`
+	Reason       string `json:"reason"`
+}
+
+// LegalHoldManager describes the methods a LegalHoldManager implementation
+// must provide.
+type LegalHoldManager interface {
+	Add(ctx context.Context, lh *LegalHold) (string, error)
+	GetJSON(ctx context.Context) ([]byte, error)
+	Release(ctx context.Context, legalHoldID string) error
+}
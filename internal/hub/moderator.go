@@ -0,0 +1,11 @@
+package hub
+
+import "context"
+
+// ModeratorManager describes the methods a ModeratorManager implementation
+// must provide.
+type ModeratorManager interface {
+	Add(ctx context.Context, userAlias string) error
+	GetJSON(ctx context.Context) ([]byte, error)
+	Remove(ctx context.Context, userAlias string) error
+}
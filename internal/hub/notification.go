@@ -19,6 +19,7 @@ type Notification struct {
 type NotificationManager interface {
 	Add(ctx context.Context, tx pgx.Tx, n *Notification) error
 	GetPending(ctx context.Context, tx pgx.Tx) (*Notification, error)
+	RegisterWebhookTemplateError(ctx context.Context, tx pgx.Tx, e *WebhookTemplateError) error
 	UpdateStatus(
 		ctx context.Context,
 		tx pgx.Tx,
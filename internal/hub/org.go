@@ -13,6 +13,120 @@ type Organization struct {
 	Description    string `json:"description"`
 	HomeURL        string `json:"home_url"`
 	LogoImageID    string `json:"logo_image_id"`
+	Profile        string `json:"profile"`
+	ProfileHTML    string `json:"profile_html"`
+}
+
+// OrganizationInvitationResult represents the result of inviting the user
+// identified by the email provided to join an organization. Emails that do not
+// belong to a registered user are reported as pending as well, so that the
+// registered ones cannot be discovered this way.
+type OrganizationInvitationResult struct {
+	Email  string `json:"email"`
+	Status string `json:"status"`
+}
+
+// Organization invitation results statuses.
+const (
+	InvitationStatusPending       = "pending"
+	InvitationStatusAlreadyMember = "already_member"
+)
+
+// OrganizationLink represents a link published in the organization profile.
+// Links are verified by adding a DNS TXT record containing the verification
+// token to the domain they point to.
+type OrganizationLink struct {
+	URL               string `json:"url"`
+	Verified          bool   `json:"verified"`
+	VerificationToken string `json:"verification_token,omitempty"`
+}
+
+// OrganizationQuotas represents the limits that apply to the resources an
+// organization can use. A zero value means no limit. The tracker minimum
+// interval is expressed in minutes.
+type OrganizationQuotas struct {
+	MaxRepositories    int `json:"max_repositories"`
+	MaxWebhooks        int `json:"max_webhooks"`
+	MaxAPIKeys         int `json:"max_api_keys"`
+	TrackerMinInterval int `json:"tracker_min_interval"`
+}
+
+// OrganizationUsage represents the number of resources of each kind limited
+// by the quotas an organization is currently using. API keys owned by any of
+// the organization members count towards the organization usage.
+type OrganizationUsage struct {
+	Repositories int `json:"repositories"`
+	Webhooks     int `json:"webhooks"`
+	APIKeys      int `json:"api_keys"`
+}
+
+// OrganizationQuotasUsage represents the quotas of an organization along with
+// its current usage.
+type OrganizationQuotasUsage struct {
+	Quotas *OrganizationQuotas `json:"quotas"`
+	Usage  *OrganizationUsage  `json:"usage"`
+}
+
+// DeleteOrganizationInput represents the input used to delete an
+// organization. When the organization owns some repositories, it must be
+// indicated if they should be deleted or transferred. Repositories are
+// transferred to the requesting user unless a target organization is provided.
+type DeleteOrganizationInput struct {
+	Confirmation       string             `json:"confirmation"`
+	RepositoriesAction RepositoriesAction `json:"repositories_action"`
+	TransferToOrg      string             `json:"transfer_to_org"`
+}
+
+// RepositoriesAction represents the action to apply to the repositories owned
+// by an organization that is being deleted.
+type RepositoriesAction string
+
+const (
+	// DeleteRepositories indicates that the repositories must be deleted.
+	DeleteRepositories RepositoriesAction = "delete"
+
+	// TransferRepositories indicates that the repositories must be
+	// transferred to the requesting user or to another organization.
+	TransferRepositories RepositoriesAction = "transfer"
+)
+
+// IdPGroupMapping represents a mapping between an identity provider group and
+// the role its members get in an organization.
+type IdPGroupMapping struct {
+	Group string           `json:"group"`
+	Role  OrganizationRole `json:"role"`
+}
+
+// OrganizationRole represents the role a member has in an organization.
+type OrganizationRole string
+
+const (
+	// OrganizationOwner represents the owner role. Owners are allowed to
+	// perform all actions in the organization.
+	OrganizationOwner OrganizationRole = "owner"
+
+	// OrganizationAdmin represents the admin role. Admins can manage members,
+	// repositories and webhooks, but they cannot delete the organization or
+	// manage its authorization policy and members roles.
+	OrganizationAdmin OrganizationRole = "admin"
+
+	// OrganizationMaintainer represents the maintainer role. Maintainers can
+	// add and update repositories and manage webhooks.
+	OrganizationMaintainer OrganizationRole = "maintainer"
+
+	// OrganizationViewer represents the viewer role. Viewers have read only
+	// access to the organization.
+	OrganizationViewer OrganizationRole = "viewer"
+)
+
+// IsValid checks if the organization role is valid.
+func (r OrganizationRole) IsValid() bool {
+	switch r {
+	case OrganizationOwner, OrganizationAdmin, OrganizationMaintainer, OrganizationViewer:
+		return true
+	default:
+		return false
+	}
 }
 
 // OrganizationManager describes the methods an OrganizationManager
@@ -20,14 +134,25 @@ type Organization struct {
 type OrganizationManager interface {
 	Add(ctx context.Context, org *Organization) error
 	AddMember(ctx context.Context, orgName, userAlias string) error
+	AddMembers(ctx context.Context, orgName string, emails []string) ([]*OrganizationInvitationResult, error)
+	CancelInvitation(ctx context.Context, orgName, userAlias string) error
 	CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error)
 	ConfirmMembership(ctx context.Context, orgName string) error
-	Delete(ctx context.Context, orgName string) error
+	Delete(ctx context.Context, orgName string, input *DeleteOrganizationInput) error
 	DeleteMember(ctx context.Context, orgName, userAlias string) error
 	GetJSON(ctx context.Context, orgName string) ([]byte, error)
 	GetByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
 	GetAuthorizationPolicyJSON(ctx context.Context, orgName string) ([]byte, error)
+	GetIdPGroupsJSON(ctx context.Context, orgName string) ([]byte, error)
+	GetInvitationsJSON(ctx context.Context, orgName string, p *Pagination) (*JSONQueryResult, error)
+	GetLinksJSON(ctx context.Context, orgName string) ([]byte, error)
 	GetMembersJSON(ctx context.Context, orgName string, p *Pagination) (*JSONQueryResult, error)
+	GetQuotasJSON(ctx context.Context, orgName string) ([]byte, error)
+	ResendInvitation(ctx context.Context, orgName, userAlias string) error
 	Update(ctx context.Context, orgName string, org *Organization) error
 	UpdateAuthorizationPolicy(ctx context.Context, orgName string, policy *AuthorizationPolicy) error
+	UpdateIdPGroups(ctx context.Context, orgName string, groups []*IdPGroupMapping) error
+	UpdateLinks(ctx context.Context, orgName string, links []*OrganizationLink) error
+	UpdateMemberRole(ctx context.Context, orgName, userAlias string, role OrganizationRole) error
+	VerifyLinks(ctx context.Context, orgName string) error
 }
@@ -13,6 +13,29 @@ const (
 	PackageMetadataFile = "artifacthub-pkg"
 )
 
+// PackageCategories represents the categories a package can be assigned to,
+// either by the package itself or by the repository publisher.
+var PackageCategories = []string{
+	"ai-machine-learning",
+	"database",
+	"integration-delivery",
+	"monitoring-logging",
+	"networking",
+	"security",
+	"storage",
+	"streaming-messaging",
+}
+
+// IsValidPackageCategory checks if the provided package category is valid.
+func IsValidPackageCategory(category string) bool {
+	for _, validCategory := range PackageCategories {
+		if category == validCategory {
+			return true
+		}
+	}
+	return false
+}
+
 // Change represents a change introduced in a package version.
 type Change struct {
 	Kind        string  `json:"kind,omitempty"`
@@ -26,6 +49,14 @@ type Channel struct {
 	Version string `json:"version"`
 }
 
+// GetPackagesChangesInput represents the input used to get the changes
+// registered in the packages catalog.
+type GetPackagesChangesInput struct {
+	Since int64 `json:"since,omitempty"`
+	After int64 `json:"after,omitempty"`
+	Limit int   `json:"limit"`
+}
+
 // GetPackageInput represents the input used to get a specific package.
 type GetPackageInput struct {
 	PackageID      string `json:"package_id"`
@@ -40,10 +71,33 @@ type Link struct {
 	URL  string `json:"url" yaml:"url"`
 }
 
+// ChartDependency represents a dependency of a Helm chart. Dependencies
+// resolved from the sub-charts included in the chart archive provide some
+// extra details about their content, including their own dependencies.
+type ChartDependency struct {
+	Name             string             `json:"name"`
+	Version          string             `json:"version"`
+	Repository       string             `json:"repository,omitempty"`
+	AppVersion       string             `json:"app_version,omitempty"`
+	License          string             `json:"license,omitempty"`
+	ContainersImages []string           `json:"containers_images,omitempty"`
+	Resolved         bool               `json:"resolved"`
+	Dependencies     []*ChartDependency `json:"dependencies,omitempty"`
+}
+
+// ChartDiagnostic represents a problem found when linting a Helm chart or
+// rendering its templates with the default values.
+type ChartDiagnostic struct {
+	Severity string `json:"severity"`
+	Path     string `json:"path,omitempty"`
+	Message  string `json:"message"`
+}
+
 // ContainerImage represents a container image associated with a package.
 type ContainerImage struct {
 	Name        string `json:"name" yaml:"name"`
 	Image       string `json:"image" yaml:"image"`
+	Digest      string `json:"digest,omitempty" yaml:"digest,omitempty"`
 	Whitelisted bool   `json:"whitelisted" yaml:"whitelisted"`
 }
 
@@ -61,13 +115,16 @@ type Package struct {
 	NormalizedName                 string                 `json:"normalized_name"`
 	LogoURL                        string                 `json:"logo_url"`
 	LogoImageID                    string                 `json:"logo_image_id"`
+	LogoGenerated                  bool                   `json:"logo_generated"`
 	IsOperator                     bool                   `json:"is_operator"`
 	Official                       bool                   `json:"official"`
 	Channels                       []*Channel             `json:"channels"`
 	DefaultChannel                 string                 `json:"default_channel"`
 	DisplayName                    string                 `json:"display_name"`
+	Alias                          string                 `json:"alias,omitempty"`
 	Description                    string                 `json:"description"`
 	Keywords                       []string               `json:"keywords"`
+	Labels                         []string               `json:"labels,omitempty"`
 	HomeURL                        string                 `json:"home_url"`
 	Readme                         string                 `json:"readme"`
 	Install                        string                 `json:"install"`
@@ -84,14 +141,29 @@ type Package struct {
 	Digest                         string                 `json:"digest"`
 	Deprecated                     bool                   `json:"deprecated"`
 	License                        string                 `json:"license"`
+	Category                       string                 `json:"category,omitempty"`
 	Signed                         bool                   `json:"signed"`
+	SignatureVerified              bool                   `json:"signature_verified"`
+	SignatureKind                  SignatureKind          `json:"signature_kind,omitempty"`
+	SignatureIdentity              string                 `json:"signature_identity,omitempty"`
+	HasAttestations                bool                   `json:"has_attestations"`
 	ContentURL                     string                 `json:"content_url"`
 	ContainersImages               []*ContainerImage      `json:"containers_images"`
 	AllContainersImagesWhitelisted bool                   `json:"all_containers_images_whitelisted"`
 	Provider                       string                 `json:"provider"`
 	HasValuesSchema                bool                   `json:"has_values_schema"`
 	ValuesSchema                   json.RawMessage        `json:"values_schema,omitempty"`
+	ValuesSchemaInferred           bool                   `json:"values_schema_inferred"`
+	HasValuesDocs                  bool                   `json:"has_values_docs"`
+	ValuesDocs                     []*ValueDoc            `json:"values_docs,omitempty"`
+	HasDependenciesTree            bool                   `json:"has_dependencies_tree"`
+	DependenciesTree               []*ChartDependency     `json:"dependencies_tree,omitempty"`
 	HasChangeLog                   bool                   `json:"has_changelog"`
+	HasSBOM                        bool                   `json:"has_sbom"`
+	SBOM                           *SBOM                  `json:"sbom,omitempty"`
+	TestResults                    *TestResults           `json:"test_results,omitempty"`
+	ChartDiagnostics               []*ChartDiagnostic     `json:"chart_diagnostics,omitempty"`
+	Upstream                       *PackageUpstream       `json:"upstream,omitempty"`
 	Changes                        []*Change              `json:"changes"`
 	ContainsSecurityUpdates        bool                   `json:"contains_security_updates"`
 	Prerelease                     bool                   `json:"prerelease"`
@@ -101,29 +173,57 @@ type Package struct {
 	Repository                     *Repository            `json:"repository"`
 	TS                             int64                  `json:"ts,omitempty"`
 	Stats                          *PackageStats          `json:"stats"`
+	Freshness                      *PackageFreshness      `json:"freshness"`
+	Promoted                       bool                   `json:"promoted"`
+}
+
+// PackageFreshness represents some information about how recently a package
+// has been released, compared to the usual release cadence of its kind.
+type PackageFreshness struct {
+	LastReleaseTS                int64 `json:"last_release_ts"`
+	DaysSinceLastRelease         int   `json:"days_since_last_release"`
+	KindMedianReleaseCadenceDays int   `json:"kind_median_release_cadence_days"`
+	Stale                        bool  `json:"stale"`
 }
 
 // PackageManager describes the methods a PackageManager implementation must
 // provide.
 type PackageManager interface {
 	Get(ctx context.Context, input *GetPackageInput) (*Package, error)
+	GetByDigestJSON(ctx context.Context, digest string) ([]byte, error)
 	GetChangeLogJSON(ctx context.Context, pkgID string) ([]byte, error)
+	GetChangesJSON(ctx context.Context, input *GetPackagesChangesInput) ([]byte, error)
+	GetComparisonJSON(ctx context.Context, pkgsIDs []string) ([]byte, error)
+	GetDependenciesTreeJSON(ctx context.Context, pkgID, version string) ([]byte, error)
+	GetDiscoveryJSON(ctx context.Context, category string) ([]byte, error)
+	GetEventsJSON(ctx context.Context, pkgID string) ([]byte, error)
 	GetHarborReplicationDumpJSON(ctx context.Context) ([]byte, error)
+	GetHead(ctx context.Context, input *GetPackageInput) (*ResourceHead, error)
 	GetJSON(ctx context.Context, input *GetPackageInput) ([]byte, error)
 	GetRandomJSON(ctx context.Context) ([]byte, error)
+	GetSnapshotSBOM(ctx context.Context, pkgID, version string, format SBOMFormat) ([]byte, error)
 	GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error)
 	GetSnapshotsToScan(ctx context.Context) ([]*SnapshotToScan, error)
+	GetSnapshotsWithoutLogo(ctx context.Context, limit int) ([]*SnapshotWithoutLogo, error)
+	GetStaleByOrgJSON(ctx context.Context, orgName string, p *Pagination) (*JSONQueryResult, error)
+	GetStaleByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
 	GetStarredByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
 	GetStarsJSON(ctx context.Context, packageID string) ([]byte, error)
 	GetStatsJSON(ctx context.Context) ([]byte, error)
 	GetSummaryJSON(ctx context.Context, input *GetPackageInput) ([]byte, error)
+	GetValuesDocsJSON(ctx context.Context, pkgID, version string) ([]byte, error)
 	GetValuesSchemaJSON(ctx context.Context, pkgID, version string) ([]byte, error)
+	MigrateSecurityReports(ctx context.Context, limit int) (int, error)
+	RefreshDiscovery(ctx context.Context) error
 	Register(ctx context.Context, pkg *Package) error
 	SearchJSON(ctx context.Context, input *SearchPackageInput) (*JSONQueryResult, error)
+	SetSnapshotGeneratedLogo(ctx context.Context, pkgID, version, logoImageID string) error
+	SetTestResults(ctx context.Context, repoName, pkgName, version string, tr *TestResults) error
 	SearchMonocularJSON(ctx context.Context, baseURL, tsQueryWeb string) ([]byte, error)
 	ToggleStar(ctx context.Context, packageID string) error
 	UpdateSnapshotSecurityReport(ctx context.Context, r *SnapshotSecurityReport) error
 	Unregister(ctx context.Context, pkg *Package) error
+	UpdateFreshness(ctx context.Context) error
 }
 
 // PackageMetadata represents some metadata about a given package. It's usually
@@ -133,12 +233,14 @@ type PackageMetadata struct {
 	Version                 string            `yaml:"version"`
 	Name                    string            `yaml:"name"`
 	DisplayName             string            `yaml:"displayName"`
+	Alias                   string            `yaml:"alias"`
 	CreatedAt               string            `yaml:"createdAt"`
 	Description             string            `yaml:"description"`
 	LogoPath                string            `yaml:"logoPath"`
 	LogoURL                 string            `yaml:"logoURL"`
 	Digest                  string            `yaml:"digest"`
 	License                 string            `yaml:"license"`
+	Category                string            `yaml:"category"`
 	HomeURL                 string            `yaml:"homeURL"`
 	AppVersion              string            `yaml:"appVersion"`
 	PublisherID             string            `yaml:"publisherID"`
@@ -159,6 +261,18 @@ type PackageMetadata struct {
 	Annotations             map[string]string `yaml:"annotations"`
 }
 
+// PackageUpstream represents an attribution link to the canonical source of a
+// package version mirrored from another hub instance, resolved by matching
+// the package version digest.
+type PackageUpstream struct {
+	InstanceURL    string `json:"instance_url"`
+	PackageID      string `json:"package_id"`
+	PackageName    string `json:"package_name"`
+	Version        string `json:"version"`
+	RepositoryName string `json:"repository_name"`
+	URL            string `json:"url"`
+}
+
 // PackageStats represents some statistics about a package.
 type PackageStats struct {
 	Subscriptions int `json:"subscriptions"`
@@ -175,6 +289,38 @@ type Recommendation struct {
 	URL string `json:"url" yaml:"url"`
 }
 
+// SBOM represents a software bill of materials shipped with a package
+// version.
+type SBOM struct {
+	Format SBOMFormat      `json:"format"`
+	Data   json.RawMessage `json:"data,omitempty"`
+
+	// Location is set when the SBOM has been placed in an external reports
+	// store instead of in the database.
+	Location string `json:"location,omitempty"`
+}
+
+// SBOMFormat represents the format of a software bill of materials.
+type SBOMFormat string
+
+const (
+	// CycloneDX represents the CycloneDX SBOM format (json encoding).
+	CycloneDX SBOMFormat = "cyclonedx"
+
+	// SPDX represents the SPDX SBOM format (json encoding).
+	SPDX SBOMFormat = "spdx"
+)
+
+// TestResults represents a summary of the results of the tests run on a
+// package version (i.e. by helm-unittest or chart-testing), provided by the
+// publisher as a quality signal.
+type TestResults struct {
+	Tool    string `json:"tool,omitempty"`
+	Passed  int    `json:"passed"`
+	Failed  int    `json:"failed"`
+	Skipped int    `json:"skipped"`
+}
+
 // SnapshotSecurityReport represents some information about the security
 // vulnerabilities the images used by a given package's snapshot may have.
 type SnapshotSecurityReport struct {
@@ -183,6 +329,18 @@ type SnapshotSecurityReport struct {
 	AlertDigest   string                         `json:"alert_digest"`
 	ImagesReports map[string]*trivyreport.Report `json:"images_reports"`
 	Summary       *SecurityReportSummary         `json:"summary"`
+
+	// ImagesReportsLocation is set when the images reports have been placed
+	// in an external reports store instead of in the database.
+	ImagesReportsLocation string `json:"images_reports_location,omitempty"`
+}
+
+// SecurityReportToMigrate represents a security report stored in the database
+// that must be moved to the reports store.
+type SecurityReportToMigrate struct {
+	PackageID     string          `json:"package_id"`
+	Version       string          `json:"version"`
+	ImagesReports json.RawMessage `json:"images_reports"`
 }
 
 // SecurityReportSummary represents a summary of the security report.
@@ -194,12 +352,37 @@ type SecurityReportSummary struct {
 	Unknown  int `json:"unknown"`
 }
 
+// SignatureKind represents the kind of signature of a package version.
+type SignatureKind string
+
+const (
+	// CosignSignature represents a cosign signature attached to an artifact
+	// stored in a OCI registry.
+	CosignSignature SignatureKind = "cosign"
+
+	// OPABundleSignature represents a signature provided in an OPA bundle
+	// signatures file.
+	OPABundleSignature SignatureKind = "opa"
+
+	// ProvenanceSignature represents a signature provided in a Helm chart
+	// provenance file.
+	ProvenanceSignature SignatureKind = "prov"
+)
+
 // SignKey represents a key used to sign a package version.
 type SignKey struct {
 	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
 	URL         string `json:"url" yaml:"url"`
 }
 
+// CosignIdentity represents the identity expected to have signed keyless the
+// packages versions of a repository, as recorded by Fulcio in the signing
+// certificate (subject and OIDC issuer).
+type CosignIdentity struct {
+	Subject string `json:"subject" yaml:"subject"`
+	Issuer  string `json:"issuer" yaml:"issuer"`
+}
+
 // SnapshotToScan represents some information about a package's snapshot that
 // needs to be scanned for security vulnerabilities.
 type SnapshotToScan struct {
@@ -210,24 +393,50 @@ type SnapshotToScan struct {
 	ContainersImages []*ContainerImage `json:"containers_images"`
 }
 
+// SnapshotWithoutLogo represents some information about the snapshot of the
+// latest version of a package that has no logo.
+type SnapshotWithoutLogo struct {
+	RepositoryID string `json:"repository_id"`
+	PackageID    string `json:"package_id"`
+	PackageName  string `json:"package_name"`
+	Version      string `json:"version"`
+}
+
 // SearchPackageInput represents the query input when searching for packages.
 type SearchPackageInput struct {
-	Limit             int              `json:"limit,omitempty"`
-	Offset            int              `json:"offset,omitempty"`
-	Facets            bool             `json:"facets"`
-	TSQueryWeb        string           `json:"ts_query_web,omitempty"`
-	TSQuery           string           `json:"ts_query,omitempty"`
-	Users             []string         `json:"users,omitempty"`
-	Orgs              []string         `json:"orgs,omitempty"`
-	Repositories      []string         `json:"repositories,omitempty"`
-	RepositoryKinds   []RepositoryKind `json:"repository_kinds,omitempty"`
-	VerifiedPublisher bool             `json:"verified_publisher"`
-	Official          bool             `json:"official"`
-	Operators         bool             `json:"operators"`
-	Deprecated        bool             `json:"deprecated"`
-	Licenses          []string         `json:"licenses,omitempty"`
-	Capabilities      []string         `json:"capabilities,omitempty"`
-	Sort              string           `json:"sort,omitempty"`
+	Limit                 int              `json:"limit,omitempty"`
+	Offset                int              `json:"offset,omitempty"`
+	Facets                bool             `json:"facets"`
+	TSQueryWeb            string           `json:"ts_query_web,omitempty"`
+	TSQuery               string           `json:"ts_query,omitempty"`
+	Users                 []string         `json:"users,omitempty"`
+	Orgs                  []string         `json:"orgs,omitempty"`
+	Repositories          []string         `json:"repositories,omitempty"`
+	RepositoryKinds       []RepositoryKind `json:"repository_kinds,omitempty"`
+	VerifiedPublisher     bool             `json:"verified_publisher"`
+	Official              bool             `json:"official"`
+	Operators             bool             `json:"operators"`
+	Deprecated            bool             `json:"deprecated"`
+	Active                bool             `json:"active"`
+	Licenses              []string         `json:"licenses,omitempty"`
+	Categories            []string         `json:"categories,omitempty"`
+	Capabilities          []string         `json:"capabilities,omitempty"`
+	Labels                []string         `json:"labels,omitempty"`
+	KubeVersions          []string         `json:"kube_versions,omitempty"`
+	CompatibleKubeVersion string           `json:"compatible_kube_version,omitempty"`
+	ChartTypes            []string         `json:"chart_types,omitempty"`
+	Platforms             []string         `json:"platforms,omitempty"`
+	TaskParams            []string         `json:"task_params,omitempty"`
+	Sort                  string           `json:"sort,omitempty"`
+}
+
+// ValueDoc represents the documentation of a value defined in a chart values
+// file.
+type ValueDoc struct {
+	Path        string      `json:"path"`
+	Type        string      `json:"type"`
+	Default     interface{} `json:"default"`
+	Description string      `json:"description,omitempty"`
 }
 
 // Version represents a package's version.
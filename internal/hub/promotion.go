@@ -0,0 +1,36 @@
+package hub
+
+import "context"
+
+// PromotedPackage represents a package promoted (featured) in the hub during
+// a given period of time.
+type PromotedPackage struct {
+	PromotedPackageID string `json:"promoted_package_id"`
+	PackageID         string `json:"package_id"`
+	StartsAt          int64  `json:"starts_at"`
+	EndsAt            int64  `json:"ends_at"`
+}
+
+// PromotedPackageEvent represents the kind of an event registered on a
+// promoted package.
+type PromotedPackageEvent string
+
+const (
+	// PromotedPackageImpression represents an event registered when a
+	// promoted package is displayed.
+	PromotedPackageImpression PromotedPackageEvent = "impression"
+
+	// PromotedPackageClick represents an event registered when a promoted
+	// package is clicked.
+	PromotedPackageClick PromotedPackageEvent = "click"
+)
+
+// PromotedPackageManager describes the methods a PromotedPackageManager
+// implementation must provide.
+type PromotedPackageManager interface {
+	Add(ctx context.Context, pp *PromotedPackage) (string, error)
+	Delete(ctx context.Context, promotedPackageID string) error
+	GetJSON(ctx context.Context) ([]byte, error)
+	GetStatsJSON(ctx context.Context) ([]byte, error)
+	RegisterEvent(ctx context.Context, promotedPackageID string, event PromotedPackageEvent) error
+}
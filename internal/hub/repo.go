@@ -12,9 +12,23 @@ const (
 	// Artifact Hub metadata for a given repository is stored.
 	RepositoryMetadataFile = "artifacthub-repo"
 
+	// RepositoryMetadataV2 represents the version 2 of the repository metadata
+	// file, which supports packages overrides and signing the file itself.
+	RepositoryMetadataV2 = 2
+
 	// RepositoryOCIPrefix represents the prefix expected in the url when the
 	// repository is stored in a OCI registry.
 	RepositoryOCIPrefix = "oci://"
+
+	// RepositoryGitPrefix represents the prefix expected in the url when the
+	// repository is a git repository containing unpackaged Helm charts (i.e.
+	// git+https://github.com/org/repo/charts).
+	RepositoryGitPrefix = "git+"
+
+	// RepositoryTerraformRegistryPrefix represents the prefix expected in the
+	// url when the repository is a Terraform module published in a registry
+	// (i.e. tfr://registry.terraform.io/namespace/name/provider).
+	RepositoryTerraformRegistryPrefix = "tfr://"
 )
 
 // RepositoryKind represents the kind of a given repository.
@@ -54,15 +68,71 @@ const (
 
 	// Keptn represents a repository with Keptn integrations.
 	Keptn RepositoryKind = 10
+
+	// Kustomize represents a repository with Kustomize bases and components.
+	Kustomize RepositoryKind = 11
+
+	// Terraform represents a repository with a Terraform module.
+	Terraform RepositoryKind = 12
+
+	// Crossplane represents a repository with a Crossplane package stored in
+	// a OCI registry.
+	Crossplane RepositoryKind = 13
+
+	// Kyverno represents a repository with Kyverno policies.
+	Kyverno RepositoryKind = 14
+
+	// Gatekeeper represents a repository with Gatekeeper constraint templates.
+	Gatekeeper RepositoryKind = 15
+
+	// ArgoWorkflowTemplate represents a repository with Argo workflow
+	// templates.
+	ArgoWorkflowTemplate RepositoryKind = 16
+
+	// Backstage represents a repository with Backstage plugins.
+	Backstage RepositoryKind = 17
+
+	// Wasm represents a repository with a WebAssembly module stored in a OCI
+	// registry.
+	Wasm RepositoryKind = 18
+
+	// Devcontainer represents a repository with Dev Container features or
+	// templates stored in a OCI registry.
+	Devcontainer RepositoryKind = 19
+
+	// Headlamp represents a repository with Headlamp plugins.
+	Headlamp RepositoryKind = 20
+
+	// Knative represents a repository with Knative functions and eventing
+	// sources.
+	Knative RepositoryKind = 21
+
+	// Container represents a repository with a container image stored in a
+	// OCI registry.
+	Container RepositoryKind = 22
 )
 
 // GetKindName returns the name of the provided repository kind.
 func GetKindName(kind RepositoryKind) string {
 	switch kind {
+	case ArgoWorkflowTemplate:
+		return "argo-workflow-template"
+	case Backstage:
+		return "backstage"
+	case Container:
+		return "container"
 	case CoreDNS:
 		return "coredns"
+	case Crossplane:
+		return "crossplane"
+	case Devcontainer:
+		return "devcontainer"
 	case Falco:
 		return "falco"
+	case Gatekeeper:
+		return "gatekeeper"
+	case Headlamp:
+		return "headlamp"
 	case Helm:
 		return "helm"
 	case HelmPlugin:
@@ -71,8 +141,14 @@ func GetKindName(kind RepositoryKind) string {
 		return "keda-scaler"
 	case Keptn:
 		return "keptn"
+	case Knative:
+		return "knative"
 	case Krew:
 		return "krew"
+	case Kustomize:
+		return "kustomize"
+	case Kyverno:
+		return "kyverno"
 	case OLM:
 		return "olm"
 	case OPA:
@@ -81,6 +157,10 @@ func GetKindName(kind RepositoryKind) string {
 		return "tbaction"
 	case TektonTask:
 		return "tekton-task"
+	case Terraform:
+		return "terraform"
+	case Wasm:
+		return "wasm"
 	default:
 		return ""
 	}
@@ -90,10 +170,24 @@ func GetKindName(kind RepositoryKind) string {
 // provided.
 func GetKindFromName(kind string) (RepositoryKind, error) {
 	switch kind {
+	case "argo-workflow-template":
+		return ArgoWorkflowTemplate, nil
+	case "backstage":
+		return Backstage, nil
+	case "container":
+		return Container, nil
 	case "coredns":
 		return CoreDNS, nil
+	case "crossplane":
+		return Crossplane, nil
+	case "devcontainer":
+		return Devcontainer, nil
 	case "falco":
 		return Falco, nil
+	case "gatekeeper":
+		return Gatekeeper, nil
+	case "headlamp":
+		return Headlamp, nil
 	case "helm":
 		return Helm, nil
 	case "helm-plugin":
@@ -102,8 +196,14 @@ func GetKindFromName(kind string) (RepositoryKind, error) {
 		return KedaScaler, nil
 	case "keptn":
 		return Keptn, nil
+	case "knative":
+		return Knative, nil
 	case "krew":
 		return Krew, nil
+	case "kustomize":
+		return Kustomize, nil
+	case "kyverno":
+		return Kyverno, nil
 	case "olm":
 		return OLM, nil
 	case "opa":
@@ -112,15 +212,32 @@ func GetKindFromName(kind string) (RepositoryKind, error) {
 		return TBAction, nil
 	case "tekton-task":
 		return TektonTask, nil
+	case "terraform":
+		return Terraform, nil
+	case "wasm":
+		return Wasm, nil
 	default:
 		return -1, errors.New("invalid kind name")
 	}
 }
 
+// RepositoryKindSettings represents some settings used to customize how the
+// packages of a given repository kind are browsed and presented in the UI.
+type RepositoryKindSettings struct {
+	Kind        RepositoryKind `json:"kind"`
+	Name        string         `json:"name"`
+	Enabled     bool           `json:"enabled"`
+	DisplayName string         `json:"display_name"`
+	Icon        string         `json:"icon,omitempty"`
+	DefaultSort string         `json:"default_sort"`
+	Facets      []string       `json:"facets"`
+}
+
 // HelmIndexLoader interface defines the methods a Helm index loader
 // implementation should provide.
 type HelmIndexLoader interface {
 	LoadIndex(r *Repository) (*helmrepo.IndexFile, string, error)
+	LoadIndexIfModified(r *Repository) (*helmrepo.IndexFile, string, error)
 }
 
 // OCITagsGetter is the interface that wraps the Tags method, used to get all
@@ -129,12 +246,151 @@ type OCITagsGetter interface {
 	Tags(ctx context.Context, r *Repository) ([]string, error)
 }
 
+// OCIRepositoriesLister is the interface that wraps the List method, used to
+// discover the repositories available under a namespace in a OCI registry.
+type OCIRepositoriesLister interface {
+	List(ctx context.Context, r *Repository) ([]string, error)
+}
+
+// OCISBOMGetter is the interface that wraps the GetSBOM method, used to get
+// the SBOM attached to an artifact stored in a OCI registry.
+type OCISBOMGetter interface {
+	GetSBOM(ctx context.Context, r *Repository, ref string) ([]byte, error)
+}
+
+// OCISignatureChecker is the interface that wraps the Check method, used to
+// detect and verify the signatures and attestations attached to an artifact
+// stored in a OCI registry.
+type OCISignatureChecker interface {
+	Check(
+		ctx context.Context,
+		r *Repository,
+		ref string,
+		cosignKey *SignKey,
+		cosignIdentity *CosignIdentity,
+	) (*OCISignatureCheck, error)
+}
+
+// OCIImageDigestResolver is the interface that wraps the Resolve method, used
+// to get the digest a container image reference currently points to.
+type OCIImageDigestResolver interface {
+	Resolve(ctx context.Context, r *Repository, image string) (string, error)
+}
+
+// OCISignatureCheck represents the result of checking the signatures attached
+// to an artifact stored in a OCI registry.
+type OCISignatureCheck struct {
+	Signed          bool
+	Verified        bool
+	Identity        string
+	HasAttestations bool
+}
+
+// Xpkg represents the content of a Crossplane package (xpkg) stored in a OCI
+// registry.
+type Xpkg struct {
+	Digest   string
+	Package  []byte
+	Examples map[string][]byte
+}
+
+// XpkgPuller is the interface that wraps the Pull method, used to get the
+// content of a Crossplane package stored in a OCI registry.
+type XpkgPuller interface {
+	Pull(ctx context.Context, r *Repository, ref string) (*Xpkg, error)
+}
+
+// WasmArtifact represents a WebAssembly module stored in a OCI registry as a
+// wasm OCI artifact.
+type WasmArtifact struct {
+	Digest          string
+	ConfigMediaType string
+	Config          []byte
+	LayerMediaType  string
+	LayerSize       int64
+	Annotations     map[string]string
+}
+
+// WasmArtifactGetter is the interface that wraps the Get method, used to get
+// the manifest and config of a WebAssembly module stored in a OCI registry.
+type WasmArtifactGetter interface {
+	Get(ctx context.Context, r *Repository, ref string) (*WasmArtifact, error)
+}
+
+// DevcontainerArtifact represents the content of a Dev Container feature,
+// template or collection metadata artifact stored in a OCI registry.
+type DevcontainerArtifact struct {
+	Digest string
+	Files  map[string][]byte
+}
+
+// DevcontainerArtifactPuller is the interface that wraps the Pull method, used
+// to get the content of a Dev Container artifact stored in a OCI registry.
+type DevcontainerArtifactPuller interface {
+	Pull(ctx context.Context, r *Repository, ref string) (*DevcontainerArtifact, error)
+}
+
+// ContainerImageDetails represents some details of a container image stored
+// in a OCI registry, like its annotations or the readme attached to it.
+type ContainerImageDetails struct {
+	Digest      string
+	Annotations map[string]string
+	Platforms   []string
+	Readme      []byte
+}
+
+// ContainerImageDetailsGetter is the interface that wraps the Get method, used
+// to get the details of a container image stored in a OCI registry.
+type ContainerImageDetailsGetter interface {
+	Get(ctx context.Context, r *Repository, ref string) (*ContainerImageDetails, error)
+}
+
+// TektonBundle represents the content of a Tekton bundle stored in a OCI
+// registry.
+type TektonBundle struct {
+	Digest    string
+	Resources []*TektonBundleResource
+}
+
+// TektonBundleResource represents a Tekton resource (i.e. a task or pipeline)
+// available in a Tekton bundle.
+type TektonBundleResource struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	Data       []byte
+}
+
+// TektonBundlePuller is the interface that wraps the Pull method, used to get
+// the content of a Tekton bundle stored in a OCI registry.
+type TektonBundlePuller interface {
+	Pull(ctx context.Context, r *Repository, ref string) (*TektonBundle, error)
+}
+
 // OLMOCIExporter describes the methods an OLMOCIExporter implementation must
 // must provide.
 type OLMOCIExporter interface {
 	ExportRepository(ctx context.Context, r *Repository) (tmpDir string, err error)
 }
 
+// SigningPolicy represents the policy applied by the tracker to the unsigned
+// package versions found in a repository.
+type SigningPolicy string
+
+const (
+	// SigningPolicyNone represents that unsigned package versions are
+	// registered as usual.
+	SigningPolicyNone SigningPolicy = ""
+
+	// SigningPolicyFlag represents that unsigned package versions are
+	// registered, but they are reported to the repository owners.
+	SigningPolicyFlag SigningPolicy = "flag"
+
+	// SigningPolicyReject represents that unsigned package versions are not
+	// registered and they are reported to the repository owners.
+	SigningPolicyReject SigningPolicy = "reject"
+)
+
 // Owner represents some details about a repository's owner.
 type Owner struct {
 	Name  string `yaml:"name"`
@@ -151,7 +407,10 @@ type Repository struct {
 	Private                 bool           `json:"private"`
 	AuthUser                string         `json:"auth_user"`
 	AuthPass                string         `json:"auth_pass"`
+	SSHKey                  string         `json:"ssh_key"`
 	Digest                  string         `json:"digest"`
+	IndexETag               string         `json:"index_etag,omitempty"`
+	IndexLastModified       string         `json:"index_last_modified,omitempty"`
 	Kind                    RepositoryKind `json:"kind"`
 	UserID                  string         `json:"user_id"`
 	UserAlias               string         `json:"user_alias"`
@@ -159,11 +418,80 @@ type Repository struct {
 	OrganizationName        string         `json:"organization_name"`
 	OrganizationDisplayName string         `json:"organization_display_name"`
 	LastScanningErrors      string         `json:"last_scanning_errors"`
+	LastTrackingTS          int64          `json:"last_tracking_ts"`
 	LastTrackingErrors      string         `json:"last_tracking_errors"`
 	VerifiedPublisher       bool           `json:"verified_publisher"`
+	DomainVerified          bool           `json:"domain_verified"`
+	DomainVerificationToken string         `json:"domain_verification_token"`
 	Official                bool           `json:"official"`
 	Disabled                bool           `json:"disabled"`
 	ScannerDisabled         bool           `json:"scanner_disabled"`
+	SigningPolicy           SigningPolicy  `json:"signing_policy"`
+	SignKeyring             string         `json:"sign_keyring,omitempty"`
+	SignKeyringURL          string         `json:"sign_keyring_url,omitempty"`
+	TrackingSchedule        string         `json:"tracking_schedule"`
+	TrackingPaused          bool           `json:"tracking_paused"`
+	TrackingRequested       bool           `json:"tracking_requested"`
+	TrackerMinInterval      int            `json:"tracker_min_interval"`
+	Archived                bool           `json:"archived"`
+	Labels                  []string       `json:"labels,omitempty"`
+}
+
+// RepositoryWebhookProvider represents the provider of a webhook used to
+// trigger the tracking of a repository.
+type RepositoryWebhookProvider string
+
+const (
+	// GitHubWebhook represents a webhook sent by GitHub. Its payload is signed
+	// using the repository webhook secret (HMAC SHA256).
+	GitHubWebhook RepositoryWebhookProvider = "github"
+
+	// GitLabWebhook represents a webhook sent by GitLab, which includes the
+	// repository webhook secret as a token.
+	GitLabWebhook RepositoryWebhookProvider = "gitlab"
+
+	// HarborWebhook represents a webhook sent by Harbor, which includes the
+	// repository webhook secret in the authorization header.
+	HarborWebhook RepositoryWebhookProvider = "harbor"
+)
+
+// RepositoryCheckStatus represents the status of one of the checks performed
+// when validating a repository before adding it.
+type RepositoryCheckStatus string
+
+const (
+	// RepositoryCheckPassed represents that the check succeeded.
+	RepositoryCheckPassed RepositoryCheckStatus = "passed"
+
+	// RepositoryCheckFailed represents that the check failed.
+	RepositoryCheckFailed RepositoryCheckStatus = "failed"
+
+	// RepositoryCheckSkipped represents that the check was not performed,
+	// usually because a previous one failed or it doesn't apply to the
+	// repository provided.
+	RepositoryCheckSkipped RepositoryCheckStatus = "skipped"
+)
+
+// RepositoryCheck represents the result of validating a repository before
+// adding it (i.e. url format, credentials, access, metadata and packages).
+type RepositoryCheck struct {
+	Valid       bool                         `json:"valid"`
+	Diagnostics []*RepositoryCheckDiagnostic `json:"diagnostics"`
+}
+
+// RepositoryCheckDiagnostic represents the outcome of a single check performed
+// when validating a repository.
+type RepositoryCheckDiagnostic struct {
+	Check   string                `json:"check"`
+	Status  RepositoryCheckStatus `json:"status"`
+	Message string                `json:"message,omitempty"`
+}
+
+// GithubTokenSource describes the methods a GithubTokenSource implementation
+// must provide. Implementations are expected to take care of refreshing the
+// tokens when they are about to expire.
+type GithubTokenSource interface {
+	Token(ctx context.Context) (string, error)
 }
 
 // RepositoryCloner describes the methods a RepositoryCloner implementation
@@ -179,32 +507,69 @@ type RepositoryCloner interface {
 // RepositoryManager describes the methods an RepositoryManager
 // implementation must provide.
 type RepositoryManager interface {
+	AcceptTransfer(ctx context.Context, name string) error
 	Add(ctx context.Context, orgName string, r *Repository) error
+	AddPublishToken(ctx context.Context, name string, t *RepositoryPublishToken) (*RepositoryPublishToken, error)
+	Check(ctx context.Context, r *Repository) (*RepositoryCheck, error)
 	CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error)
+	CheckPublishToken(ctx context.Context, name, publishTokenID, secret string) (bool, error)
+	CheckWebhookSignature(ctx context.Context, name string, provider RepositoryWebhookProvider, signature string, payload []byte) (bool, error)
 	ClaimOwnership(ctx context.Context, name, orgName string) error
 	Delete(ctx context.Context, name string) error
+	DeletePublishToken(ctx context.Context, name, publishTokenID string) error
+	GenerateWebhookSecret(ctx context.Context, name string) (string, error)
 	GetByID(ctx context.Context, repositoryID string, includeCredentials bool) (*Repository, error)
 	GetByName(ctx context.Context, name string, includeCredentials bool) (*Repository, error)
+	GetHead(ctx context.Context, name string) (*ResourceHead, error)
 	GetMetadata(mdFile string) (*RepositoryMetadata, error)
 	GetPackagesDigest(ctx context.Context, repositoryID string) (map[string]string, error)
+	GetPublishTokensJSON(ctx context.Context, name string) ([]byte, error)
 	GetRemoteDigest(ctx context.Context, r *Repository) (string, error)
+	GetStatsJSON(ctx context.Context, name string) ([]byte, error)
+	GetTrackingRunsJSON(ctx context.Context, name string, p *Pagination) (*JSONQueryResult, error)
+	GetTransfersJSON(ctx context.Context) ([]byte, error)
+	RejectTransfer(ctx context.Context, name string) error
+	RequestTransfer(ctx context.Context, name, userAlias, orgName string) error
 	Search(ctx context.Context, input *SearchRepositoryInput) (*SearchRepositoryResult, error)
 	SearchJSON(ctx context.Context, input *SearchRepositoryInput) (*JSONQueryResult, error)
+	SetArchived(ctx context.Context, name string, archived bool) error
 	SetLastScanningResults(ctx context.Context, repositoryID, errs string) error
-	SetLastTrackingResults(ctx context.Context, repositoryID, errs string) error
+	SetLastTrackingResults(ctx context.Context, repositoryID, errs string, run *RepositoryTrackingRun) error
+	SetPackagesOverrides(ctx context.Context, repositoryID string, overrides []*RepositoryPackageOverride) error
+	SetTrackingPaused(ctx context.Context, name string, paused bool) error
 	SetVerifiedPublisher(ctx context.Context, repositoryID string, verified bool) error
 	Transfer(ctx context.Context, name, orgName string, ownershipClaim bool) error
+	TriggerTracking(ctx context.Context, name string) error
 	Update(ctx context.Context, r *Repository) error
 	UpdateDigest(ctx context.Context, repositoryID, digest string) error
+	UpdateIndexValidators(ctx context.Context, repositoryID, etag, lastModified string) error
+	UpdatePublishMetadata(ctx context.Context, name string, md *RepositoryPublishMetadata) error
+	VerifyDomain(ctx context.Context, name string) error
 }
 
 // RepositoryMetadata represents some metadata about a given repository. It's
 // usually provided by repositories publishers, to provide some extra context
 // about the repository they'd like to publish.
 type RepositoryMetadata struct {
-	RepositoryID string                   `yaml:"repositoryID"`
-	Owners       []*Owner                 `yaml:"owners"`
-	Ignore       []*RepositoryIgnoreEntry `yaml:"ignore"`
+	Version        int                          `yaml:"version"`
+	RepositoryID   string                       `yaml:"repositoryID"`
+	Owners         []*Owner                     `yaml:"owners"`
+	Ignore         []*RepositoryIgnoreEntry     `yaml:"ignore"`
+	SignKey        *SignKey                     `yaml:"signKey"`
+	CosignKey      *SignKey                     `yaml:"cosignKey"`
+	CosignIdentity *CosignIdentity              `yaml:"cosignIdentity"`
+	Signed         bool                         `yaml:"signed"`
+	Packages       []*RepositoryPackageOverride `yaml:"packages"`
+}
+
+// RepositoryPackageOverride represents some package details overridden by the
+// repository publisher in the repository metadata file (version 2 or later).
+// The name corresponds to a package name, and it must be an exact match.
+type RepositoryPackageOverride struct {
+	Name        string `json:"name" yaml:"name"`
+	DisplayName string `json:"display_name,omitempty" yaml:"displayName"`
+	Category    string `json:"category,omitempty" yaml:"category"`
+	Hidden      bool   `json:"hidden,omitempty" yaml:"hidden"`
 }
 
 // RepositoryIgnoreEntry represents an entry in the ignore list. This list is
@@ -216,12 +581,41 @@ type RepositoryIgnoreEntry struct {
 	Version string `yaml:"version"`
 }
 
+// RepositoryPublishMetadata represents the repository metadata publishers can
+// manage using a publish token. Fields not provided are left untouched.
+type RepositoryPublishMetadata struct {
+	DisplayName *string `json:"display_name,omitempty"`
+	Branch      *string `json:"branch,omitempty"`
+}
+
+// RepositoryPublishToken represents a token that allows publishers to trigger
+// the tracking of a single repository and update some of its metadata, with
+// no access to anything else. They are meant to be used from CI pipelines.
+type RepositoryPublishToken struct {
+	PublishTokenID string `json:"publish_token_id"`
+	Name           string `json:"name"`
+	Secret         string `json:"secret,omitempty"`
+	CreatedAt      int64  `json:"created_at,omitempty"`
+}
+
+// RepositoryTrackingRun represents some details about a tracker run of a given
+// repository, like when it started, how long it took or the number of
+// packages versions added, updated or deleted.
+type RepositoryTrackingRun struct {
+	StartedAt       int64 `json:"started_at"`
+	DurationMs      int64 `json:"duration_ms"`
+	PackagesAdded   int   `json:"packages_added"`
+	PackagesUpdated int   `json:"packages_updated"`
+	PackagesDeleted int   `json:"packages_deleted"`
+}
+
 // SearchRepositoryInput represents the query input when searching for repositories.
 type SearchRepositoryInput struct {
 	Name               string           `json:"name,omitempty"`
 	Kinds              []RepositoryKind `json:"kinds,omitempty"`
 	Orgs               []string         `json:"orgs,omitempty"`
 	Users              []string         `json:"users,omitempty"`
+	Labels             []string         `json:"labels,omitempty"`
 	IncludeCredentials bool             `json:"include_credentials"`
 	Limit              int              `json:"limit,omitempty"`
 	Offset             int              `json:"offset,omitempty"`
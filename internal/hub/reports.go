@@ -0,0 +1,11 @@
+package hub
+
+import "context"
+
+// ReportsStore defines the methods a ReportsStore implementation must provide.
+// Reports stores are used to place large documents, like the packages security
+// reports, outside the database.
+type ReportsStore interface {
+	Get(ctx context.Context, location string) ([]byte, error)
+	Put(ctx context.Context, key string, data []byte) (string, error)
+}
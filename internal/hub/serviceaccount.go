@@ -0,0 +1,25 @@
+package hub
+
+import "context"
+
+// ServiceAccount represents an account owned by an organization meant to be
+// used for automation purposes (i.e. publishing from CI pipelines). Service
+// accounts don't have an email or password, so they can only authenticate
+// using api keys, and they never receive notifications.
+type ServiceAccount struct {
+	ServiceAccountID string           `json:"service_account_id"`
+	Name             string           `json:"name"`
+	Role             OrganizationRole `json:"role"`
+	CreatedAt        int64            `json:"created_at"`
+	APIKeys          []*APIKey        `json:"api_keys"`
+}
+
+// ServiceAccountManager describes the methods a ServiceAccountManager
+// implementation must provide.
+type ServiceAccountManager interface {
+	Add(ctx context.Context, orgName string, sa *ServiceAccount) (string, error)
+	AddAPIKey(ctx context.Context, orgName, serviceAccountID string, ak *APIKey) (*APIKey, error)
+	Delete(ctx context.Context, orgName, serviceAccountID string) error
+	DeleteAPIKey(ctx context.Context, orgName, serviceAccountID, apiKeyID string) error
+	GetByOrgJSON(ctx context.Context, orgName string) ([]byte, error)
+}
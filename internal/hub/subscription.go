@@ -19,6 +19,32 @@ type Subscription struct {
 	EventKind EventKind `json:"event_kind"`
 }
 
+// SubscriptionsDocument represents a portable document holding the
+// subscriptions and stars of a user. Packages are identified by their
+// repository and package names, so that the document can be imported into a
+// different account.
+type SubscriptionsDocument struct {
+	Version       int                           `json:"version"`
+	Subscriptions []*SubscriptionsDocumentEntry `json:"subscriptions"`
+	Stars         []*SubscriptionsDocumentEntry `json:"stars"`
+}
+
+// SubscriptionsDocumentEntry represents a package entry in a subscriptions
+// document. Event kinds are only used in subscriptions entries.
+type SubscriptionsDocumentEntry struct {
+	RepositoryName string      `json:"repository_name"`
+	PackageName    string      `json:"package_name"`
+	EventKinds     []EventKind `json:"event_kinds,omitempty"`
+}
+
+// SubscriptionsImportInput represents the input used to import a subscriptions
+// document into the account of the user doing the request.
+type SubscriptionsImportInput struct {
+	Document   *SubscriptionsDocument `json:"document"`
+	OnConflict string                 `json:"on_conflict"`
+	DryRun     bool                   `json:"dry_run"`
+}
+
 // SubscriptionManager describes the methods a SubscriptionManager
 // implementation must provide.
 type SubscriptionManager interface {
@@ -26,8 +52,10 @@ type SubscriptionManager interface {
 	AddOptOut(ctx context.Context, o *OptOut) error
 	Delete(ctx context.Context, s *Subscription) error
 	DeleteOptOut(ctx context.Context, optOutID string) error
+	ExportJSON(ctx context.Context) ([]byte, error)
 	GetByPackageJSON(ctx context.Context, packageID string) ([]byte, error)
 	GetByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
 	GetOptOutListJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
 	GetSubscriptors(ctx context.Context, e *Event) ([]*User, error)
+	ImportJSON(ctx context.Context, input *SubscriptionsImportInput) ([]byte, error)
 }
@@ -6,9 +6,23 @@ import (
 	"github.com/artifacthub/hub/internal/img"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
-	"golang.org/x/time/rate"
 )
 
+// ChartsCache describes the methods a ChartsCache implementation must
+// provide. It's used to keep the charts archives downloaded by the tracker
+// across runs, indexed by their sha256 digest.
+type ChartsCache interface {
+	Get(ctx context.Context, digest string) ([]byte, error)
+	Put(ctx context.Context, digest string, data []byte) error
+}
+
+// RequestsLimiter describes the methods a RequestsLimiter implementation must
+// provide. It's used to honor the requests rate limits and the downloads
+// concurrency configured for some hosts or repositories.
+type RequestsLimiter interface {
+	Acquire(ctx context.Context, repoName, host string) (release func(), err error)
+}
+
 // TrackerServices represents a set of services that must be provided to a
 // Tracker instance so that it can perform its tasks.
 type TrackerServices struct {
@@ -21,7 +35,9 @@ type TrackerServices struct {
 	Ec                 ErrorsCollector
 	Hc                 HTTPClient
 	Is                 img.Store
-	GithubRL           *rate.Limiter
+	Rl                 RequestsLimiter
+	Cc                 ChartsCache
+	Dr                 OCIImageDigestResolver
 	SetupTrackerSource TrackerSourceLoader
 }
 
@@ -41,6 +57,7 @@ type TrackerSource interface {
 // the packages available in a repository when tracking it.
 type TrackerSourceInput struct {
 	Repository         *Repository
+	Metadata           *RepositoryMetadata
 	PackagesRegistered map[string]string
 	BasePath           string
 	Svc                *TrackerSourceServices
@@ -53,11 +70,12 @@ type TrackerSourceLoader func(i *TrackerSourceInput) TrackerSource
 // TrackerSourceServices represents a set of services that will be provided to
 // a TrackerSource instance so that it can perform its tasks.
 type TrackerSourceServices struct {
-	Ctx      context.Context
-	Cfg      *viper.Viper
-	Ec       ErrorsCollector
-	Hc       HTTPClient
-	Is       img.Store
-	Logger   zerolog.Logger
-	GithubRL *rate.Limiter
+	Ctx    context.Context
+	Cfg    *viper.Viper
+	Ec     ErrorsCollector
+	Hc     HTTPClient
+	Is     img.Store
+	Logger zerolog.Logger
+	Rl     RequestsLimiter
+	Cc     ChartsCache
 }
@@ -18,6 +18,15 @@ type CheckSessionOutput struct {
 	UserID string `json:"user_id"`
 }
 
+// OauthProviderSettings represents the settings of an oauth provider
+// configured in the hub. Several providers of the same type can be configured
+// (i.e. GitHub and a GitHub Enterprise server), each identified by its name.
+type OauthProviderSettings struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	DisplayName string `json:"display_name"`
+}
+
 // Session represents some information about a user session.
 type Session struct {
 	SessionID string `json:"session_id"`
@@ -55,6 +64,15 @@ type User struct {
 	TFAEnabled     bool   `json:"tfa_enabled"`
 }
 
+// WebAuthnCeremony represents the information generated when a WebAuthn
+// registration or login ceremony begins. The options must be provided to the
+// WebAuthn API in the browser, whereas the ceremony id must be kept until the
+// ceremony is finished. The ceremony session data is stored server side.
+type WebAuthnCeremony struct {
+	ID      string
+	Options []byte
+}
+
 type userIDKey struct{}
 
 // UserIDKey represents the key used for the userID value inside a context.
@@ -63,24 +81,35 @@ var UserIDKey = userIDKey{}
 // UserManager describes the methods a UserManager implementation must provide.
 type UserManager interface {
 	ApproveSession(ctx context.Context, sessionID, passcode string) error
+	ApproveSessionWithWebAuthn(ctx context.Context, sessionID, ceremonyID string, response []byte) error
+	BeginWebAuthnLogin(ctx context.Context, email string) (*WebAuthnCeremony, error)
+	BeginWebAuthnRegistration(ctx context.Context) (*WebAuthnCeremony, error)
+	BeginWebAuthnSessionApproval(ctx context.Context, sessionID string) (*WebAuthnCeremony, error)
 	CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error)
 	CheckCredentials(ctx context.Context, email, password string) (*CheckCredentialsOutput, error)
 	CheckSession(ctx context.Context, sessionID string, duration time.Duration) (*CheckSessionOutput, error)
 	DeleteSession(ctx context.Context, sessionID string) error
 	DeleteUser(ctx context.Context, code string) error
+	DeleteWebAuthnCredential(ctx context.Context, credentialID string) error
 	DisableTFA(ctx context.Context, passcode string) error
 	EnableTFA(ctx context.Context, passcode string) error
+	FinishWebAuthnLogin(ctx context.Context, ceremonyID string, response []byte) (string, error)
+	FinishWebAuthnRegistration(ctx context.Context, name, ceremonyID string, response []byte) error
 	GetProfile(ctx context.Context) (*User, error)
 	GetProfileJSON(ctx context.Context) ([]byte, error)
 	GetUserID(ctx context.Context, email string) (string, error)
+	GetWebAuthnCredentialsJSON(ctx context.Context) ([]byte, error)
 	RegisterDeleteUserCode(ctx context.Context) error
+	RegisterLoginCode(ctx context.Context, userEmail, deviceID string) error
 	RegisterPasswordResetCode(ctx context.Context, userEmail string) error
 	RegisterSession(ctx context.Context, session *Session) (*Session, error)
 	RegisterUser(ctx context.Context, user *User) error
 	ResetPassword(ctx context.Context, code, newPassword string) error
 	SetupTFA(ctx context.Context) ([]byte, error)
+	SyncIdPGroups(ctx context.Context, userID string, groups []string) error
 	UpdatePassword(ctx context.Context, old, new string) error
 	UpdateProfile(ctx context.Context, user *User) error
 	VerifyEmail(ctx context.Context, code string) (bool, error)
+	VerifyLoginCode(ctx context.Context, code, deviceID string) (string, error)
 	VerifyPasswordResetCode(ctx context.Context, code string) error
 }
@@ -5,16 +5,73 @@ import "context"
 // Webhook represents the configuration of a webhook where notifications will
 // be posted to.
 type Webhook struct {
-	WebhookID   string      `json:"webhook_id"`
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	URL         string      `json:"url"`
-	Secret      string      `json:"secret"`
-	ContentType string      `json:"content_type"`
-	Template    string      `json:"template"`
-	Active      bool        `json:"active"`
-	EventKinds  []EventKind `json:"event_kinds"`
-	Packages    []*Package  `json:"packages"`
+	WebhookID    string                       `json:"webhook_id"`
+	Name         string                       `json:"name"`
+	Description  string                       `json:"description"`
+	Kind         WebhookKind                  `json:"kind"`
+	URL          string                       `json:"url"`
+	Secret       string                       `json:"secret"`
+	ContentType  string                       `json:"content_type"`
+	Template     string                       `json:"template"`
+	Active       bool                         `json:"active"`
+	OrgDefault   bool                         `json:"org_default"`
+	EventKinds   []EventKind                  `json:"event_kinds"`
+	Packages     []*Package                   `json:"packages"`
+	Repositories []*WebhookRepositoryOverride `json:"repositories"`
+}
+
+// WebhookKind represents the kind of a webhook, which defines how the
+// notifications are delivered to it.
+type WebhookKind string
+
+const (
+	// WebhookKindGeneric represents a webhook that receives the payload built
+	// from its template (or the default one) for each notification.
+	WebhookKindGeneric WebhookKind = ""
+
+	// WebhookKindPagerDuty represents a webhook that triggers PagerDuty
+	// alerts using the Events API v2. The webhook secret is used as the
+	// integration routing key.
+	WebhookKindPagerDuty WebhookKind = "pagerduty"
+
+	// WebhookKindOpsgenie represents a webhook that creates Opsgenie alerts
+	// using the Alert API. The webhook secret is used as the API key.
+	WebhookKindOpsgenie WebhookKind = "opsgenie"
+)
+
+// WebhookKindsDefaultURLs represents the endpoints used by default by the
+// alerting webhooks kinds when no url is provided.
+var WebhookKindsDefaultURLs = map[WebhookKind]string{
+	WebhookKindOpsgenie:  "https://api.opsgenie.com/v2/alerts",
+	WebhookKindPagerDuty: "https://events.pagerduty.com/v2/enqueue",
+}
+
+// WebhookRepositoryOverride represents a per repository setting of a webhook.
+// It allows enabling a webhook for all the packages in a repository, or
+// disabling an organization default webhook for one of its repositories.
+type WebhookRepositoryOverride struct {
+	RepositoryID string `json:"repository_id"`
+	Name         string `json:"name,omitempty"`
+	Enabled      bool   `json:"enabled"`
+}
+
+// WebhookTemplateError represents an error rendering the payload template of
+// a webhook while delivering a notification.
+type WebhookTemplateError struct {
+	WebhookID string `json:"webhook_id"`
+	EventID   string `json:"event_id"`
+	Error     string `json:"error"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+}
+
+// WebhookTemplateFunc represents the documentation of a function available
+// in the webhooks payloads templates.
+type WebhookTemplateFunc struct {
+	Name        string `json:"name"`
+	Signature   string `json:"signature"`
+	Description string `json:"description"`
+	Example     string `json:"example"`
 }
 
 // WebhookManager describes the methods a WebhookManager implementation must
@@ -26,5 +83,6 @@ type WebhookManager interface {
 	GetOwnedByOrgJSON(ctx context.Context, orgName string, p *Pagination) (*JSONQueryResult, error)
 	GetOwnedByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
 	GetSubscribedTo(ctx context.Context, e *Event) ([]*Webhook, error)
+	GetTemplateErrorsJSON(ctx context.Context, webhookID string) ([]byte, error)
 	Update(ctx context.Context, wh *Webhook) error
 }
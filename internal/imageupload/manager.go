@@ -0,0 +1,395 @@
+package imageupload
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/disintegration/imaging"
+	svg "github.com/h2non/go-is-svg"
+	"github.com/jackc/pgx/v4"
+	"github.com/satori/uuid"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Database queries
+	addImageUploadDBQ = `
+	insert into image_upload (user_id, size, checksum)
+	values ($1, $2, $3)
+	returning image_upload_id
+	`
+	appendImageUploadChunkDBQ = `
+	update image_upload set
+		data = data || $2,
+		received = received + $3,
+		updated_at = current_timestamp
+	where image_upload_id = $1
+	returning data
+	`
+	completeImageUploadDBQ = `
+	update image_upload set
+		data = '',
+		image_id = $2,
+		updated_at = current_timestamp
+	where image_upload_id = $1
+	`
+	deleteAbandonedImageUploadsDBQ = `
+	delete from image_upload
+	where image_id is null
+	and updated_at < current_timestamp - make_interval(secs => $1)
+	`
+	getImageUploadDBQ = `
+	select image_upload_id, size, checksum, received, coalesce(image_id::text, ''), moderation_status
+	from image_upload
+	where image_upload_id = $1
+	and user_id = $2
+	`
+	getImageUploadForUpdateDBQ = getImageUploadDBQ + `for update`
+	getImageUploadImageDBQ     = `
+	select iu.user_id, iv.data
+	from image_upload iu
+	join image_version iv using (image_id)
+	where iu.image_upload_id = $1
+	order by iv.version asc
+	limit 1
+	`
+	getPendingImageUploadsDBQ = `
+	select
+		coalesce(json_agg(json_build_object(
+			'image_upload_id', iu.image_upload_id,
+			'image_id', iu.image_id,
+			'user_alias', u.alias,
+			'created_at', floor(extract(epoch from iu.created_at))
+		) order by iu.created_at asc), '[]'),
+		(
+			select count(*) from image_upload
+			where image_id is not null
+			and moderation_status = 'pending'
+		)
+	from (
+		select * from image_upload
+		where image_id is not null
+		and moderation_status = 'pending'
+		order by created_at asc
+		limit $1 offset $2
+	) iu
+	join "user" u using (user_id)
+	`
+	moderateImageUploadDBQ = `
+	update image_upload set
+		moderation_status = $2,
+		moderated_by = $3,
+		moderated_at = current_timestamp
+	where image_upload_id = $1
+	and image_id is not null
+	returning image_upload_id
+	`
+	resetImageUploadDBQ = `
+	update image_upload set
+		data = '',
+		received = 0,
+		updated_at = current_timestamp
+	where image_upload_id = $1
+	`
+
+	// defaultMaxSize represents the default maximum size of the images that
+	// can be uploaded.
+	defaultMaxSize = 5 << 20
+
+	// defaultTTL represents the default period of time after which images
+	// uploads that have not been completed are deleted.
+	defaultTTL = 24 * time.Hour
+)
+
+// checksumRE is a regexp used to validate the checksum of the images uploads.
+var checksumRE = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+// Manager provides an API to manage the images uploaded in chunks by the
+// publishers. Once all chunks of an image have been received and its
+// checksum has been verified, the image is stored using the image store,
+// pending to be reviewed by a moderator. Images uploaded are not served until
+// they have been approved.
+type Manager struct {
+	db      hub.DB
+	az      hub.Authorizer
+	is      img.Store
+	maxSize int
+	ttl     time.Duration
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(cfg *viper.Viper, db hub.DB, az hub.Authorizer, is img.Store) *Manager {
+	maxSize := int(cfg.GetSizeInBytes("images.uploads.maxSize"))
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+	ttl := cfg.GetDuration("images.uploads.ttl")
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	return &Manager{
+		db:      db,
+		az:      az,
+		is:      is,
+		maxSize: maxSize,
+		ttl:     ttl,
+	}
+}
+
+// Add registers a new image upload, returning its id. The size and the
+// sha256 checksum of the whole image must be provided in advance.
+func (m *Manager) Add(ctx context.Context, size int, checksum string) (string, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if size <= 0 {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid size")
+	}
+	if size > m.maxSize {
+		return "", fmt.Errorf("%w: image size exceeds the maximum allowed (%d bytes)", hub.ErrInvalidInput, m.maxSize)
+	}
+	if !checksumRE.MatchString(checksum) {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid checksum (sha256 hex digest expected)")
+	}
+
+	// Register image upload in database
+	var imageUploadID string
+	err := m.db.QueryRow(ctx, addImageUploadDBQ, userID, size, checksum).Scan(&imageUploadID)
+	return imageUploadID, err
+}
+
+// AppendChunk appends the chunk provided to the image upload at the offset
+// given, which must match the number of bytes received so far. When the last
+// chunk is received, the checksum of the image is verified and the image is
+// stored. Uploaded images get their own image id, even if the same image is
+// already available, so that they can be moderated independently. If the
+// checksum does not match, the upload is reset so that it can be started
+// again.
+func (m *Manager) AppendChunk(
+	ctx context.Context,
+	imageUploadID string,
+	offset int,
+	chunk []byte,
+) (*hub.ImageUpload, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(imageUploadID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid image upload id")
+	}
+	if len(chunk) == 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "empty chunk")
+	}
+
+	var u *hub.ImageUpload
+	var uploadErr error
+	err := util.DBTransact(ctx, m.db, func(tx pgx.Tx) error {
+		// Get image upload, locking it until the chunk has been processed
+		var err error
+		u, err = getImageUpload(ctx, tx, getImageUploadForUpdateDBQ, imageUploadID, userID)
+		if err != nil {
+			return err
+		}
+		if u.ImageID != "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "image upload already completed")
+		}
+		if offset != u.Offset {
+			return fmt.Errorf("%w: invalid offset (expected %d)", hub.ErrInvalidInput, u.Offset)
+		}
+		if u.Offset+len(chunk) > u.Size {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "chunk exceeds image upload size")
+		}
+
+		// Append chunk
+		var data []byte
+		err = tx.QueryRow(ctx, appendImageUploadChunkDBQ, imageUploadID, chunk, len(chunk)).Scan(&data)
+		if err != nil {
+			return err
+		}
+		u.Offset += len(chunk)
+		if u.Offset < u.Size {
+			return nil
+		}
+
+		// All chunks received, verify checksum and store image. The upload
+		// is reset when the image received is not valid, so that it can be
+		// started again.
+		switch {
+		case fmt.Sprintf("%x", sha256.Sum256(data)) != u.Checksum:
+			uploadErr = fmt.Errorf("%w: %s", hub.ErrInvalidInput, "checksum mismatch, image upload reset")
+		case !isValidImage(data):
+			uploadErr = fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid image, image upload reset")
+		}
+		if uploadErr != nil {
+			_, err := tx.Exec(ctx, resetImageUploadDBQ, imageUploadID)
+			return err
+		}
+		imageID, err := m.is.SaveUploadedImage(ctx, imageUploadID, data)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, completeImageUploadDBQ, imageUploadID, imageID); err != nil {
+			return err
+		}
+		u.ImageID = imageID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if uploadErr != nil {
+		return nil, uploadErr
+	}
+	return u, nil
+}
+
+// DeleteAbandoned deletes the images uploads that have not been completed and
+// have not received any chunk during the configured period of time
+// (images.uploads.ttl), so that their partial data is not kept forever.
+func (m *Manager) DeleteAbandoned(ctx context.Context) error {
+	_, err := m.db.Exec(ctx, deleteAbandonedImageUploadsDBQ, int(m.ttl.Seconds()))
+	return err
+}
+
+// Get returns the image upload identified by the id provided. Only the user
+// who registered the upload is allowed to get it.
+func (m *Manager) Get(ctx context.Context, imageUploadID string) (*hub.ImageUpload, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(imageUploadID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid image upload id")
+	}
+
+	return getImageUpload(ctx, m.db, getImageUploadDBQ, imageUploadID, userID)
+}
+
+// GetImage returns the image of the completed image upload provided, whatever
+// its moderation status is. Only the user who registered the upload and the
+// users allowed to moderate images uploads can get it.
+func (m *Manager) GetImage(ctx context.Context, imageUploadID string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(imageUploadID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid image upload id")
+	}
+
+	// Get image upload image from database
+	var ownerID string
+	var data []byte
+	err := m.db.QueryRow(ctx, getImageUploadImageDBQ, imageUploadID).Scan(&ownerID, &data)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, hub.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Authorize action
+	if ownerID != userID {
+		if err := m.az.AuthorizeSiteAction(ctx, userID, hub.ModerateImageUploads); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// GetPendingModerationJSON returns the completed image uploads pending to be
+// reviewed as a json array. Only users allowed to moderate images uploads can
+// get them.
+func (m *Manager) GetPendingModerationJSON(ctx context.Context, p *hub.Pagination) (*hub.JSONQueryResult, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Authorize action
+	if err := m.az.AuthorizeSiteAction(ctx, userID, hub.ModerateImageUploads); err != nil {
+		return nil, err
+	}
+
+	return util.DBQueryJSONWithPagination(ctx, m.db, getPendingImageUploadsDBQ, p.Limit, p.Offset)
+}
+
+// Moderate sets the moderation status of the image upload provided. Only
+// approved images are served. Only users allowed to moderate images
+// uploads can do it.
+func (m *Manager) Moderate(ctx context.Context, imageUploadID, status string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(imageUploadID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid image upload id")
+	}
+	switch status {
+	case hub.ImageUploadApproved, hub.ImageUploadRejected:
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid moderation status")
+	}
+
+	// Authorize action
+	if err := m.az.AuthorizeSiteAction(ctx, userID, hub.ModerateImageUploads); err != nil {
+		return err
+	}
+
+	// Update image upload moderation status in database
+	var id string
+	err := m.db.QueryRow(ctx, moderateImageUploadDBQ, imageUploadID, status, userID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return hub.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// querier describes the methods needed to query the database, implemented by
+// both the database handler and the transactions.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// getImageUpload gets the image upload provided from the database using the
+// query given.
+func getImageUpload(
+	ctx context.Context,
+	q querier,
+	query string,
+	imageUploadID string,
+	userID string,
+) (*hub.ImageUpload, error) {
+	u := &hub.ImageUpload{}
+	err := q.QueryRow(ctx, query, imageUploadID, userID).Scan(
+		&u.ImageUploadID,
+		&u.Size,
+		&u.Checksum,
+		&u.Offset,
+		&u.ImageID,
+		&u.ModerationStatus,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, hub.ErrNotFound
+		}
+		return nil, err
+	}
+	return u, nil
+}
+
+// isValidImage checks if the data provided is an image that can be processed
+// by the image store.
+func isValidImage(data []byte) bool {
+	if svg.Is(data) {
+		return true
+	}
+	_, err := imaging.Decode(bytes.NewReader(data))
+	return err == nil
+}
@@ -0,0 +1,443 @@
+package imageupload
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const imageUploadID = "00000000-0000-0000-0000-000000000001"
+
+var (
+	imageData     = []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"></svg>`)
+	imageChecksum = fmt.Sprintf("%x", sha256.Sum256(imageData))
+)
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(viper.New(), nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.Add(context.Background(), 10, imageChecksum)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			size     int
+			checksum string
+		}{
+			{0, imageChecksum},
+			{defaultMaxSize + 1, imageChecksum},
+			{10, ""},
+			{10, "invalid"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(fmt.Sprintf("%d-%s", tc.size, tc.checksum), func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(viper.New(), nil, nil, nil)
+				_, err := m.Add(ctx, tc.size, tc.checksum)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, addImageUploadDBQ, "userID", 10, imageChecksum).Return(nil, tests.ErrFakeDB)
+		m := NewManager(viper.New(), db, nil, nil)
+
+		_, err := m.Add(ctx, 10, imageChecksum)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("image upload added successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, addImageUploadDBQ, "userID", 10, imageChecksum).Return(imageUploadID, nil)
+		m := NewManager(viper.New(), db, nil, nil)
+
+		id, err := m.Add(ctx, 10, imageChecksum)
+		assert.NoError(t, err)
+		assert.Equal(t, imageUploadID, id)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestAppendChunk(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	size := len(imageData)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(viper.New(), nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.AppendChunk(context.Background(), imageUploadID, 0, imageData)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(viper.New(), nil, nil, nil)
+		_, err := m.AppendChunk(ctx, "invalid", 0, imageData)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		_, err = m.AppendChunk(ctx, imageUploadID, 0, nil)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("image upload not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, getImageUploadForUpdateDBQ, imageUploadID, "userID").Return(nil, pgx.ErrNoRows)
+		tx.On("Rollback", ctx).Return(nil)
+		m := NewManager(viper.New(), db, nil, nil)
+
+		_, err := m.AppendChunk(ctx, imageUploadID, 0, imageData)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("invalid chunk", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			offset      int
+			received    int
+			imageID     string
+			chunk       []byte
+		}{
+			{"upload already completed", size, size, "imageID", imageData},
+			{"offset mismatch", 0, 5, "", imageData},
+			{"chunk too big", 5, 5, "", imageData},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				tx := &tests.TXMock{}
+				db.On("Begin", ctx).Return(tx, nil)
+				tx.On("QueryRow", ctx, getImageUploadForUpdateDBQ, imageUploadID, "userID").Return([]interface{}{
+					imageUploadID, size, imageChecksum, tc.received, tc.imageID, hub.ImageUploadPending,
+				}, nil)
+				tx.On("Rollback", ctx).Return(nil)
+				m := NewManager(viper.New(), db, nil, nil)
+
+				_, err := m.AppendChunk(ctx, imageUploadID, tc.offset, tc.chunk)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				db.AssertExpectations(t)
+				tx.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("partial chunk appended successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, getImageUploadForUpdateDBQ, imageUploadID, "userID").Return([]interface{}{
+			imageUploadID, size, imageChecksum, 0, "", hub.ImageUploadPending,
+		}, nil)
+		tx.On("QueryRow", ctx, appendImageUploadChunkDBQ, imageUploadID, imageData[:5], 5).Return(imageData[:5], nil)
+		tx.On("Commit", ctx).Return(nil)
+		m := NewManager(viper.New(), db, nil, nil)
+
+		u, err := m.AppendChunk(ctx, imageUploadID, 0, imageData[:5])
+		require.NoError(t, err)
+		assert.Equal(t, 5, u.Offset)
+		assert.Empty(t, u.ImageID)
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("checksum mismatch, image upload reset", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, getImageUploadForUpdateDBQ, imageUploadID, "userID").Return([]interface{}{
+			imageUploadID, 4, imageChecksum, 0, "", hub.ImageUploadPending,
+		}, nil)
+		tx.On("QueryRow", ctx, appendImageUploadChunkDBQ, imageUploadID, []byte("data"), 4).Return([]byte("data"), nil)
+		tx.On("Exec", ctx, resetImageUploadDBQ, imageUploadID).Return(nil)
+		tx.On("Commit", ctx).Return(nil)
+		m := NewManager(viper.New(), db, nil, nil)
+
+		_, err := m.AppendChunk(ctx, imageUploadID, 0, []byte("data"))
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("last chunk appended successfully, image stored", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		is := &img.StoreMock{}
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, getImageUploadForUpdateDBQ, imageUploadID, "userID").Return([]interface{}{
+			imageUploadID, size, imageChecksum, 5, "", hub.ImageUploadPending,
+		}, nil)
+		tx.On("QueryRow", ctx, appendImageUploadChunkDBQ, imageUploadID, imageData[5:], size-5).Return(imageData, nil)
+		is.On("SaveUploadedImage", ctx, imageUploadID, imageData).Return("imageID", nil)
+		tx.On("Exec", ctx, completeImageUploadDBQ, imageUploadID, "imageID").Return(nil)
+		tx.On("Commit", ctx).Return(nil)
+		m := NewManager(viper.New(), db, nil, is)
+
+		u, err := m.AppendChunk(ctx, imageUploadID, 5, imageData[5:])
+		require.NoError(t, err)
+		assert.Equal(t, size, u.Offset)
+		assert.Equal(t, "imageID", u.ImageID)
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+		is.AssertExpectations(t)
+	})
+}
+
+func TestDeleteAbandoned(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteAbandonedImageUploadsDBQ, 86400).Return(tests.ErrFakeDB)
+		m := NewManager(viper.New(), db, nil, nil)
+
+		err := m.DeleteAbandoned(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("abandoned images uploads deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.uploads.ttl", "1h")
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteAbandonedImageUploadsDBQ, 3600).Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.DeleteAbandoned(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGet(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(viper.New(), nil, nil, nil)
+		_, err := m.Get(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("image upload not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageUploadDBQ, imageUploadID, "userID").Return(nil, pgx.ErrNoRows)
+		m := NewManager(viper.New(), db, nil, nil)
+
+		_, err := m.Get(ctx, imageUploadID)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("image upload returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageUploadDBQ, imageUploadID, "userID").Return([]interface{}{
+			imageUploadID, 10, imageChecksum, 5, "", hub.ImageUploadPending,
+		}, nil)
+		m := NewManager(viper.New(), db, nil, nil)
+
+		u, err := m.Get(ctx, imageUploadID)
+		require.NoError(t, err)
+		assert.Equal(t, &hub.ImageUpload{
+			ImageUploadID:    imageUploadID,
+			Size:             10,
+			Checksum:         imageChecksum,
+			Offset:           5,
+			ModerationStatus: hub.ImageUploadPending,
+		}, u)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetImage(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(viper.New(), nil, nil, nil)
+		_, err := m.GetImage(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("image upload not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageUploadImageDBQ, imageUploadID).Return(nil, pgx.ErrNoRows)
+		m := NewManager(viper.New(), db, nil, nil)
+
+		_, err := m.GetImage(ctx, imageUploadID)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("image returned to the user who uploaded it", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageUploadImageDBQ, imageUploadID).Return([]interface{}{"userID", imageData}, nil)
+		m := NewManager(viper.New(), db, nil, nil)
+
+		data, err := m.GetImage(ctx, imageUploadID)
+		require.NoError(t, err)
+		assert.Equal(t, imageData, data)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("other user not allowed to moderate images uploads", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageUploadImageDBQ, imageUploadID).Return([]interface{}{"otherUserID", imageData}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("AuthorizeSiteAction", ctx, "userID", hub.ModerateImageUploads).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(viper.New(), db, az, nil)
+
+		_, err := m.GetImage(ctx, imageUploadID)
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("image returned to moderator", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageUploadImageDBQ, imageUploadID).Return([]interface{}{"otherUserID", imageData}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("AuthorizeSiteAction", ctx, "userID", hub.ModerateImageUploads).Return(nil)
+		m := NewManager(viper.New(), db, az, nil)
+
+		data, err := m.GetImage(ctx, imageUploadID)
+		require.NoError(t, err)
+		assert.Equal(t, imageData, data)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestGetPendingModerationJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user not allowed to moderate images uploads", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("AuthorizeSiteAction", ctx, "userID", hub.ModerateImageUploads).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(viper.New(), nil, az, nil)
+
+		_, err := m.GetPendingModerationJSON(ctx, p)
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("pending images uploads returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPendingImageUploadsDBQ, 10, 1).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("AuthorizeSiteAction", ctx, "userID", hub.ModerateImageUploads).Return(nil)
+		m := NewManager(viper.New(), db, az, nil)
+
+		result, err := m.GetPendingModerationJSON(ctx, p)
+		require.NoError(t, err)
+		assert.Equal(t, &hub.JSONQueryResult{Data: []byte("dataJSON"), TotalCount: 1}, result)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestModerate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			imageUploadID string
+			status        string
+		}{
+			{"invalid", hub.ImageUploadApproved},
+			{imageUploadID, hub.ImageUploadPending},
+			{imageUploadID, "invalid"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.status, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(viper.New(), nil, nil, nil)
+				err := m.Moderate(ctx, tc.imageUploadID, tc.status)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+			})
+		}
+	})
+
+	t.Run("user not allowed to moderate images uploads", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("AuthorizeSiteAction", ctx, "userID", hub.ModerateImageUploads).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(viper.New(), nil, az, nil)
+
+		err := m.Moderate(ctx, imageUploadID, hub.ImageUploadRejected)
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("image upload not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, moderateImageUploadDBQ, imageUploadID, hub.ImageUploadRejected, "userID").
+			Return(nil, pgx.ErrNoRows)
+		az := &authz.AuthorizerMock{}
+		az.On("AuthorizeSiteAction", ctx, "userID", hub.ModerateImageUploads).Return(nil)
+		m := NewManager(viper.New(), db, az, nil)
+
+		err := m.Moderate(ctx, imageUploadID, hub.ImageUploadRejected)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("image upload moderated successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, moderateImageUploadDBQ, imageUploadID, hub.ImageUploadRejected, "userID").
+			Return(imageUploadID, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("AuthorizeSiteAction", ctx, "userID", hub.ModerateImageUploads).Return(nil)
+		m := NewManager(viper.New(), db, az, nil)
+
+		err := m.Moderate(ctx, imageUploadID, hub.ImageUploadRejected)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
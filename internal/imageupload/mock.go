@@ -0,0 +1,64 @@
+package imageupload
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the ImageUploadManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the ImageUploadManager interface.
+func (m *ManagerMock) Add(ctx context.Context, size int, checksum string) (string, error) {
+	args := m.Called(ctx, size, checksum)
+	return args.String(0), args.Error(1)
+}
+
+// AppendChunk implements the ImageUploadManager interface.
+func (m *ManagerMock) AppendChunk(
+	ctx context.Context,
+	imageUploadID string,
+	offset int,
+	chunk []byte,
+) (*hub.ImageUpload, error) {
+	args := m.Called(ctx, imageUploadID, offset, chunk)
+	u, _ := args.Get(0).(*hub.ImageUpload)
+	return u, args.Error(1)
+}
+
+// DeleteAbandoned implements the ImageUploadManager interface.
+func (m *ManagerMock) DeleteAbandoned(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+// Get implements the ImageUploadManager interface.
+func (m *ManagerMock) Get(ctx context.Context, imageUploadID string) (*hub.ImageUpload, error) {
+	args := m.Called(ctx, imageUploadID)
+	u, _ := args.Get(0).(*hub.ImageUpload)
+	return u, args.Error(1)
+}
+
+// GetImage implements the ImageUploadManager interface.
+func (m *ManagerMock) GetImage(ctx context.Context, imageUploadID string) ([]byte, error) {
+	args := m.Called(ctx, imageUploadID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetPendingModerationJSON implements the ImageUploadManager interface.
+func (m *ManagerMock) GetPendingModerationJSON(ctx context.Context, p *hub.Pagination) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// Moderate implements the ImageUploadManager interface.
+func (m *ManagerMock) Moderate(ctx context.Context, imageUploadID, status string) error {
+	args := m.Called(ctx, imageUploadID, status)
+	return args.Error(0)
+}
@@ -0,0 +1,68 @@
+package img
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+)
+
+const (
+	// identiconCells represents the number of cells per row and column in
+	// the identicon grid.
+	identiconCells = 5
+
+	// identiconCellSize represents the size in pixels of each of the cells
+	// in the identicon grid.
+	identiconCellSize = 40
+
+	// identiconMargin represents the size in pixels of the margin around
+	// the identicon grid.
+	identiconMargin = identiconCellSize / 2
+)
+
+// identiconBackground represents the background color used in identicons.
+var identiconBackground = color.NRGBA{R: 240, G: 240, B: 240, A: 255}
+
+// GenerateIdenticon generates a deterministic identicon-style PNG image from
+// the seed provided. The same seed always produces the same image.
+func GenerateIdenticon(seed string) ([]byte, error) {
+	hash := sha256.Sum256([]byte(seed))
+
+	// Foreground color is derived from the hash, keeping it away from the
+	// background color extremes so that the pattern is always visible
+	fg := color.NRGBA{
+		R: 40 + hash[0]%160,
+		G: 40 + hash[1]%160,
+		B: 40 + hash[2]%160,
+		A: 255,
+	}
+
+	// Draw grid, which is mirrored horizontally to make it symmetric
+	size := identiconCells*identiconCellSize + 2*identiconMargin
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: identiconBackground}, image.Point{}, draw.Src)
+	half := (identiconCells + 1) / 2
+	for row := 0; row < identiconCells; row++ {
+		for col := 0; col < half; col++ {
+			if hash[3+row*half+col]%2 != 0 {
+				continue
+			}
+			for _, c := range []int{col, identiconCells - 1 - col} {
+				x := identiconMargin + c*identiconCellSize
+				y := identiconMargin + row*identiconCellSize
+				cell := image.Rect(x, y, x+identiconCellSize, y+identiconCellSize)
+				draw.Draw(img, cell, &image.Uniform{C: fg}, image.Point{}, draw.Src)
+			}
+		}
+	}
+
+	// Encode image as PNG
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
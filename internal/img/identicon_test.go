@@ -0,0 +1,34 @@
+package img
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateIdenticon(t *testing.T) {
+	t.Parallel()
+
+	// Same seed must always produce the same image
+	data1, err := GenerateIdenticon("repo1/pkg1")
+	require.NoError(t, err)
+	data2, err := GenerateIdenticon("repo1/pkg1")
+	require.NoError(t, err)
+	assert.Equal(t, data1, data2)
+
+	// Different seeds must produce different images
+	data3, err := GenerateIdenticon("repo1/pkg2")
+	require.NoError(t, err)
+	assert.NotEqual(t, data1, data3)
+
+	// Generated image must be a valid PNG image usable to generate versions
+	cfg, err := png.DecodeConfig(bytes.NewReader(data1))
+	require.NoError(t, err)
+	assert.Equal(t, 240, cfg.Width)
+	assert.Equal(t, 240, cfg.Height)
+	_, err = GenerateVersions(data1)
+	require.NoError(t, err)
+}
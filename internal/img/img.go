@@ -30,6 +30,11 @@ type Store interface {
 
 	// SaveImage stores an image returning the image ID.
 	SaveImage(ctx context.Context, data []byte) (imageID string, err error)
+
+	// SaveUploadedImage stores an image uploaded by a publisher returning the
+	// image ID. The image is not shared with other images with the same
+	// content, so it can be moderated on its own.
+	SaveUploadedImage(ctx context.Context, imageUploadID string, data []byte) (imageID string, err error)
 }
 
 // Version represents a specific size version of an image.
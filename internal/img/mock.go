@@ -29,3 +29,9 @@ func (m *StoreMock) SaveImage(ctx context.Context, data []byte) (string, error)
 	args := m.Called(ctx, data)
 	return args.String(0), args.Error(1)
 }
+
+// SaveUploadedImage implements the img.Store interface.
+func (m *StoreMock) SaveUploadedImage(ctx context.Context, imageUploadID string, data []byte) (string, error) {
+	args := m.Called(ctx, imageUploadID, data)
+	return args.String(0), args.Error(1)
+}
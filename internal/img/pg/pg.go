@@ -1,15 +1,19 @@
 package pg
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"errors"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/img"
 	svg "github.com/h2non/go-is-svg"
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/viper"
 	"golang.org/x/time/rate"
@@ -17,16 +21,23 @@ import (
 
 const (
 	// Database queries
-	getImageDBQ      = `select get_image($1::uuid, $2::text)`
-	getImageIDDBQ    = `select image_id from image where original_hash = $1`
-	registerImageDBQ = `select register_image($1::bytea, $2::text, $3::bytea)`
+	getImageDBQ            = `select get_image($1::uuid, $2::text)`
+	getImageIDDBQ          = `select image_id from image where original_hash = $1`
+	getImageSourceDBQ      = `select * from get_image_source($1::text, $2::int)`
+	registerImageDBQ       = `select register_image($1::bytea, $2::text, $3::bytea)`
+	registerImageSourceDBQ = `select register_image_source($1::text, $2::bytea, $3::uuid)`
 
 	// Cache
 	cacheSize = 250
+
+	// defaultSourceCacheTTL represents the default period of time during
+	// which an image downloaded from a given url won't be downloaded again.
+	defaultSourceCacheTTL = 24 * time.Hour
 )
 
 // DB defines the methods the database handler must provide.
 type DB interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 }
 
@@ -69,9 +80,24 @@ func (s *ImageStore) DownloadAndSaveImage(ctx context.Context, imageURL string)
 	imageMu.Lock()
 	defer imageMu.Unlock()
 
+	// Check if the image has already been downloaded from the same url. If
+	// it was checked recently, we can reuse it without hitting the source.
+	// Data urls are not tracked as they already contain the image.
+	isDataURL := strings.HasPrefix(imageURL, "data:")
+	var cachedSource *imageSource
+	var err error
+	if !isDataURL {
+		cachedSource, err = s.getImageSource(ctx, imageURL)
+		if err != nil {
+			return "", err
+		}
+		if cachedSource != nil && cachedSource.fresh {
+			return cachedSource.imageID, nil
+		}
+	}
+
 	// Try to get image data from the cache to avoid hitting the source
 	var data []byte
-	var err error
 	cachedImage, ok := s.imagesCache.Get(imageURL)
 	if ok {
 		data = cachedImage.([]byte)
@@ -92,9 +118,30 @@ func (s *ImageStore) DownloadAndSaveImage(ctx context.Context, imageURL string)
 		}
 		s.imagesCache.Add(imageURL, data)
 	}
+	if isDataURL {
+		return s.SaveImage(ctx, data)
+	}
+
+	// If the image content hasn't changed since the last time it was
+	// downloaded, we reuse the image already stored. Otherwise we store it.
+	sum := sha256.Sum256(data)
+	originalHash := sum[:]
+	var imageID string
+	if cachedSource != nil && bytes.Equal(cachedSource.originalHash, originalHash) {
+		imageID = cachedSource.imageID
+	} else {
+		imageID, err = s.SaveImage(ctx, data)
+		if err != nil {
+			return "", err
+		}
+	}
 
-	// Store image in the database
-	return s.SaveImage(ctx, data)
+	// Register image source so that it can be reused next time
+	_, err = s.db.Exec(ctx, registerImageSourceDBQ, imageURL, originalHash, imageID)
+	if err != nil {
+		return "", err
+	}
+	return imageID, nil
 }
 
 // GetImage returns an image stored in the database.
@@ -114,8 +161,21 @@ func (s *ImageStore) GetImage(ctx context.Context, imageID, version string) ([]b
 func (s *ImageStore) SaveImage(ctx context.Context, data []byte) (string, error) {
 	// Compute image hash using sha256
 	sum := sha256.Sum256(data)
-	originalHash := sum[:]
+	return s.saveImage(ctx, sum[:], data)
+}
+
+// SaveUploadedImage implements the image.Store interface.
+func (s *ImageStore) SaveUploadedImage(ctx context.Context, imageUploadID string, data []byte) (string, error) {
+	// The image upload id is included in the hash, so that the image is
+	// registered on its own even if the same image is already available
+	h := sha256.New()
+	h.Write([]byte(imageUploadID))
+	h.Write(data)
+	return s.saveImage(ctx, h.Sum(nil), data)
+}
 
+// saveImage stores the image provided using the hash given to identify it.
+func (s *ImageStore) saveImage(ctx context.Context, originalHash, data []byte) (string, error) {
 	// If image is already registered we just return its id
 	imageID, err := s.getImageID(ctx, originalHash)
 	if err != nil {
@@ -146,6 +206,36 @@ func (s *ImageStore) SaveImage(ctx context.Context, data []byte) (string, error)
 	return imageID, nil
 }
 
+// imageSource represents some information about an image previously
+// downloaded from a given url.
+type imageSource struct {
+	imageID      string
+	originalHash []byte
+	fresh        bool
+}
+
+// getImageSource returns the information available about the image previously
+// downloaded from the url provided, if any.
+func (s *ImageStore) getImageSource(ctx context.Context, imageURL string) (*imageSource, error) {
+	ttl := defaultSourceCacheTTL
+	if s.cfg.IsSet("images.sourceCacheTTL") {
+		ttl = s.cfg.GetDuration("images.sourceCacheTTL")
+	}
+	is := &imageSource{}
+	err := s.db.QueryRow(ctx, getImageSourceDBQ, imageURL, int(ttl.Seconds())).Scan(
+		&is.imageID,
+		&is.originalHash,
+		&is.fresh,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return is, nil
+}
+
 // getImageID checks if the database contains an image with the hash provided,
 // returning its id when found.
 func (s *ImageStore) getImageID(ctx context.Context, hash []byte) (string, error) {
@@ -36,12 +36,15 @@ func TestDownloadAndSaveImage(t *testing.T) {
 	require.NoError(t, err)
 	sumSvgImg := sha256.Sum256(svgImgData)
 	svgImgHash := sumSvgImg[:]
+	sourceCacheTTL := int(defaultSourceCacheTTL.Seconds())
 
 	t.Run("image not found in cache, it needs to be downloaded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageSourceDBQ, svgImgURL, sourceCacheTTL).Return(nil, pgx.ErrNoRows)
 		db.On("QueryRow", ctx, getImageIDDBQ, svgImgHash).Return(nil, pgx.ErrNoRows)
 		db.On("QueryRow", ctx, registerImageDBQ, svgImgHash, "svg", svgImgData).Return("svgImgID", nil)
+		db.On("Exec", ctx, registerImageSourceDBQ, svgImgURL, svgImgHash, "svgImgID").Return(nil)
 		hc := &tests.HTTPClientMock{}
 		req, _ := http.NewRequest("GET", svgImgURL, nil)
 		hc.On("Do", req).Return(&http.Response{
@@ -60,6 +63,7 @@ func TestDownloadAndSaveImage(t *testing.T) {
 	t.Run("error downloading image", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageSourceDBQ, svgImgURL, sourceCacheTTL).Return(nil, pgx.ErrNoRows)
 		hc := &tests.HTTPClientMock{}
 		req, _ := http.NewRequest("GET", svgImgURL, nil)
 		hc.On("Do", req).Return(nil, tests.ErrFake)
@@ -75,8 +79,10 @@ func TestDownloadAndSaveImage(t *testing.T) {
 	t.Run("image found in cache, no need to download it", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageSourceDBQ, svgImgURL, sourceCacheTTL).Return(nil, pgx.ErrNoRows)
 		db.On("QueryRow", ctx, getImageIDDBQ, svgImgHash).Return(nil, pgx.ErrNoRows)
 		db.On("QueryRow", ctx, registerImageDBQ, svgImgHash, "svg", svgImgData).Return("svgImgID", nil)
+		db.On("Exec", ctx, registerImageSourceDBQ, svgImgURL, svgImgHash, "svgImgID").Return(nil)
 		hc := &tests.HTTPClientMock{}
 		s := NewImageStore(cfg, db, hc, nil)
 		s.imagesCache.Add(svgImgURL, svgImgData)
@@ -91,9 +97,11 @@ func TestDownloadAndSaveImage(t *testing.T) {
 	t.Run("multiple goroutines calling simultaneously, image is downloaded and saved once", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageSourceDBQ, svgImgURL, sourceCacheTTL).Return(nil, pgx.ErrNoRows)
 		db.On("QueryRow", ctx, getImageIDDBQ, svgImgHash).Return("svgImgID", nil).Times(2)
 		db.On("QueryRow", ctx, getImageIDDBQ, svgImgHash).Return(nil, pgx.ErrNoRows).Once()
 		db.On("QueryRow", ctx, registerImageDBQ, svgImgHash, "svg", svgImgData).Return("svgImgID", nil).Once()
+		db.On("Exec", ctx, registerImageSourceDBQ, svgImgURL, svgImgHash, "svgImgID").Return(nil)
 		hc := &tests.HTTPClientMock{}
 		req, _ := http.NewRequest("GET", svgImgURL, nil)
 		hc.On("Do", req).Return(&http.Response{
@@ -120,6 +128,7 @@ func TestDownloadAndSaveImage(t *testing.T) {
 	t.Run("multiple goroutines calling simultaneously, image download failed (only tried once)", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageSourceDBQ, svgImgURL, sourceCacheTTL).Return(nil, pgx.ErrNoRows)
 		hc := &tests.HTTPClientMock{}
 		req, _ := http.NewRequest("GET", svgImgURL, nil)
 		hc.On("Do", req).Return(nil, tests.ErrFake).Once()
@@ -139,6 +148,71 @@ func TestDownloadAndSaveImage(t *testing.T) {
 		db.AssertExpectations(t)
 		hc.AssertExpectations(t)
 	})
+
+	t.Run("error getting image source", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageSourceDBQ, svgImgURL, sourceCacheTTL).Return(nil, tests.ErrFakeDB)
+		s := NewImageStore(cfg, db, nil, nil)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Equal(t, "", imageID)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("image source checked recently, no need to download it", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageSourceDBQ, svgImgURL, sourceCacheTTL).
+			Return([]interface{}{"svgImgID", svgImgHash, true}, nil)
+		hc := &tests.HTTPClientMock{}
+		s := NewImageStore(cfg, db, hc, nil)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "svgImgID", imageID)
+		db.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("image source not checked recently, content has not changed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageSourceDBQ, svgImgURL, sourceCacheTTL).
+			Return([]interface{}{"svgImgID", svgImgHash, false}, nil)
+		db.On("Exec", ctx, registerImageSourceDBQ, svgImgURL, svgImgHash, "svgImgID").Return(nil)
+		hc := &tests.HTTPClientMock{}
+		req, _ := http.NewRequest("GET", svgImgURL, nil)
+		hc.On("Do", req).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(svgImgData)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		s := NewImageStore(cfg, db, hc, nil)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "svgImgID", imageID)
+		db.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("error registering image source", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageSourceDBQ, svgImgURL, sourceCacheTTL).Return(nil, pgx.ErrNoRows)
+		db.On("QueryRow", ctx, getImageIDDBQ, svgImgHash).Return("svgImgID", nil)
+		db.On("Exec", ctx, registerImageSourceDBQ, svgImgURL, svgImgHash, "svgImgID").Return(tests.ErrFakeDB)
+		hc := &tests.HTTPClientMock{}
+		s := NewImageStore(cfg, db, hc, nil)
+		s.imagesCache.Add(svgImgURL, svgImgData)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Equal(t, "", imageID)
+		db.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
 }
 
 func TestGetImage(t *testing.T) {
@@ -245,3 +319,29 @@ func TestSaveImage(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 }
+
+func TestSaveUploadedImage(t *testing.T) {
+	svgImgData, err := ioutil.ReadFile("testdata/image.svg")
+	require.NoError(t, err)
+	sumSvgImg := sha256.Sum256(svgImgData)
+	svgImgHash := sumSvgImg[:]
+	h := sha256.New()
+	h.Write([]byte("imageUploadID"))
+	h.Write(svgImgData)
+	uploadedSvgImgHash := h.Sum(nil)
+	ctx := context.Background()
+
+	t.Run("uploaded image registered on its own", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageIDDBQ, uploadedSvgImgHash).Return(nil, pgx.ErrNoRows)
+		db.On("QueryRow", ctx, registerImageDBQ, uploadedSvgImgHash, "svg", svgImgData).Return("uploadedSvgImgID", nil)
+		s := NewImageStore(nil, db, nil, nil)
+
+		imageID, err := s.SaveUploadedImage(ctx, "imageUploadID", svgImgData)
+		require.NoError(t, err)
+		assert.Equal(t, "uploadedSvgImgID", imageID)
+		assert.NotEqual(t, svgImgHash, uploadedSvgImgHash)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,124 @@
+package legalhold
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	addLegalHoldDBQ     = `select add_legal_hold($1::uuid, $2::jsonb)`
+	getLegalHoldsDBQ    = `select get_legal_holds($1::uuid)`
+	releaseLegalHoldDBQ = `select release_legal_hold($1::uuid, $2::uuid)`
+)
+
+var (
+	// errLegalHoldAlreadyExistsDB represents the error returned from the
+	// database when the resource provided is already held.
+	errLegalHoldAlreadyExistsDB = errors.New("ERROR: legal hold already exists (SQLSTATE P0001)")
+
+	// errLegalHoldNotFoundDB represents the error returned from the database
+	// when the legal hold provided does not exist.
+	errLegalHoldNotFoundDB = errors.New("ERROR: legal hold not found (SQLSTATE P0001)")
+
+	// errPackageNotFoundDB represents the error returned from the database
+	// when the package to hold does not exist.
+	errPackageNotFoundDB = errors.New("ERROR: package not found (SQLSTATE P0001)")
+
+	// errRepositoryNotFoundDB represents the error returned from the database
+	// when the repository to hold does not exist.
+	errRepositoryNotFoundDB = errors.New("ERROR: repository not found (SQLSTATE P0001)")
+)
+
+// Manager provides an API to manage legal holds.
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// Add places a legal hold on the provided package or repository, returning
+// the id of the hold created. Only site admins are allowed to place legal
+// holds.
+func (m *Manager) Add(ctx context.Context, lh *hub.LegalHold) (string, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if (lh.PackageID == "") == (lh.RepositoryID == "") {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "either a package or a repository must be provided")
+	}
+	if lh.PackageID != "" {
+		if _, err := uuid.FromString(lh.PackageID); err != nil {
+			return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+		}
+	}
+	if lh.RepositoryID != "" {
+		if _, err := uuid.FromString(lh.RepositoryID); err != nil {
+			return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+		}
+	}
+	if lh.Reason == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "reason not provided")
+	}
+
+	// Add legal hold to the database
+	var legalHoldID string
+	lhJSON, _ := json.Marshal(lh)
+	err := m.db.QueryRow(ctx, addLegalHoldDBQ, userID, lhJSON).Scan(&legalHoldID)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return "", hub.ErrInsufficientPrivilege
+		case errPackageNotFoundDB.Error():
+			return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package not found")
+		case errRepositoryNotFoundDB.Error():
+			return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository not found")
+		case errLegalHoldAlreadyExistsDB.Error():
+			return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "legal hold already exists")
+		default:
+			return "", err
+		}
+	}
+	return legalHoldID, nil
+}
+
+// GetJSON returns all the legal holds currently active as a json array. Only
+// site admins are allowed to get the legal holds.
+func (m *Manager) GetJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	return util.DBQueryJSON(ctx, m.db, getLegalHoldsDBQ, userID)
+}
+
+// Release releases the provided legal hold. Only site admins are allowed to
+// release legal holds.
+func (m *Manager) Release(ctx context.Context, legalHoldID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(legalHoldID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid legal hold id")
+	}
+
+	// Release legal hold in database
+	_, err := m.db.Exec(ctx, releaseLegalHoldDBQ, userID, legalHoldID)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case errLegalHoldNotFoundDB.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
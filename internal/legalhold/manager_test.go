@@ -0,0 +1,241 @@
+package legalhold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	pkgID  = "00000000-0000-0000-0000-000000000001"
+	repoID = "00000000-0000-0000-0000-000000000002"
+	lhID   = "00000000-0000-0000-0000-000000000003"
+)
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	lh := &hub.LegalHold{PackageID: pkgID, Reason: "reason"}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.Add(context.Background(), lh)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			lh     *hub.LegalHold
+		}{
+			{
+				"either a package or a repository must be provided",
+				&hub.LegalHold{Reason: "reason"},
+			},
+			{
+				"either a package or a repository must be provided",
+				&hub.LegalHold{PackageID: pkgID, RepositoryID: repoID, Reason: "reason"},
+			},
+			{
+				"invalid package id",
+				&hub.LegalHold{PackageID: "invalid", Reason: "reason"},
+			},
+			{
+				"invalid repository id",
+				&hub.LegalHold{RepositoryID: "invalid", Reason: "reason"},
+			},
+			{
+				"reason not provided",
+				&hub.LegalHold{PackageID: pkgID},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.Add(ctx, tc.lh)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errPackageNotFoundDB,
+				hub.ErrInvalidInput,
+			},
+			{
+				errRepositoryNotFoundDB,
+				hub.ErrInvalidInput,
+			},
+			{
+				errLegalHoldAlreadyExistsDB,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, addLegalHoldDBQ, "userID", mock.Anything).Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				_, err := m.Add(ctx, lh)
+				assert.True(t, errors.Is(err, tc.expectedError))
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("legal hold added successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, addLegalHoldDBQ, "userID", mock.Anything).Return(lhID, nil)
+		m := NewManager(db)
+
+		legalHoldID, err := m.Add(ctx, lh)
+		assert.NoError(t, err)
+		assert.Equal(t, lhID, legalHoldID)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetJSON(context.Background())
+		})
+	})
+
+	t.Run("legal holds returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getLegalHoldsDBQ, "userID").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error getting legal holds", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getLegalHoldsDBQ, "userID").Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				dataJSON, err := m.GetJSON(ctx)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestRelease(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Release(context.Background(), lhID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.Release(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errLegalHoldNotFoundDB,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(fmt.Sprintf("%v", tc.dbErr), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, releaseLegalHoldDBQ, "userID", lhID).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Release(ctx, lhID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("legal hold released successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, releaseLegalHoldDBQ, "userID", lhID).Return(nil)
+		m := NewManager(db)
+
+		err := m.Release(ctx, lhID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,32 @@
+package legalhold
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the LegalHoldManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the LegalHoldManager interface.
+func (m *ManagerMock) Add(ctx context.Context, lh *hub.LegalHold) (string, error) {
+	args := m.Called(ctx, lh)
+	return args.String(0), args.Error(1)
+}
+
+// GetJSON implements the LegalHoldManager interface.
+func (m *ManagerMock) GetJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Release implements the LegalHoldManager interface.
+func (m *ManagerMock) Release(ctx context.Context, legalHoldID string) error {
+	args := m.Called(ctx, legalHoldID)
+	return args.Error(0)
+}
@@ -0,0 +1,88 @@
+package moderator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+)
+
+const (
+	// Database queries
+	addModeratorDBQ    = `select add_moderator($1::uuid, $2::text)`
+	getModeratorsDBQ   = `select get_moderators($1::uuid)`
+	removeModeratorDBQ = `select remove_moderator($1::uuid, $2::text)`
+)
+
+var (
+	// errUserNotFoundDB represents the error returned from the database when
+	// the user provided does not exist.
+	errUserNotFoundDB = errors.New("ERROR: user not found (SQLSTATE P0001)")
+)
+
+// Manager provides an API to manage the users holding the moderator role.
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// Add grants the moderator role to the provided user. Only site admins are
+// allowed to manage moderators.
+func (m *Manager) Add(ctx context.Context, userAlias string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Grant moderator role in database
+	_, err := m.db.Exec(ctx, addModeratorDBQ, userID, userAlias)
+	return translateDBError(err)
+}
+
+// GetJSON returns all the users holding the moderator role as a json array.
+// Only site admins are allowed to get the moderators.
+func (m *Manager) GetJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	return util.DBQueryJSON(ctx, m.db, getModeratorsDBQ, userID)
+}
+
+// Remove revokes the moderator role from the provided user. Only site admins
+// are allowed to manage moderators.
+func (m *Manager) Remove(ctx context.Context, userAlias string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Revoke moderator role in database
+	_, err := m.db.Exec(ctx, removeModeratorDBQ, userID, userAlias)
+	return translateDBError(err)
+}
+
+// translateDBError translates the errors returned from the database when
+// managing moderators into the corresponding hub errors.
+func translateDBError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch err.Error() {
+	case util.ErrDBInsufficientPrivilege.Error():
+		return hub.ErrInsufficientPrivilege
+	case errUserNotFoundDB.Error():
+		return hub.ErrNotFound
+	default:
+		return err
+	}
+}
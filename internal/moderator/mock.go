@@ -0,0 +1,31 @@
+package moderator
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the ModeratorManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the ModeratorManager interface.
+func (m *ManagerMock) Add(ctx context.Context, userAlias string) error {
+	args := m.Called(ctx, userAlias)
+	return args.Error(0)
+}
+
+// GetJSON implements the ModeratorManager interface.
+func (m *ManagerMock) GetJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Remove implements the ModeratorManager interface.
+func (m *ManagerMock) Remove(ctx context.Context, userAlias string) error {
+	args := m.Called(ctx, userAlias)
+	return args.Error(0)
+}
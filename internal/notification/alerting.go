@@ -0,0 +1,161 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// opsgenieMessageMaxLength represents the maximum length of the message of an
+// Opsgenie alert.
+const opsgenieMessageMaxLength = 130
+
+var (
+	// opsgeniePriorities represents how security alerts severities are mapped
+	// to Opsgenie alerts priorities.
+	opsgeniePriorities = map[string]string{
+		"critical": "P1",
+		"high":     "P2",
+		"medium":   "P3",
+		"low":      "P4",
+		"unknown":  "P5",
+	}
+
+	// pagerDutySeverities represents how security alerts severities are mapped
+	// to PagerDuty events severities.
+	pagerDutySeverities = map[string]string{
+		"critical": "critical",
+		"high":     "error",
+		"medium":   "warning",
+		"low":      "info",
+		"unknown":  "info",
+	}
+)
+
+// IsAlertingWebhook checks if the webhook provided is of one of the alerting
+// kinds (PagerDuty or Opsgenie).
+func IsAlertingWebhook(wh *hub.Webhook) bool {
+	return wh.Kind == hub.WebhookKindPagerDuty || wh.Kind == hub.WebhookKindOpsgenie
+}
+
+// NewAlertRequest builds the request used to deliver a security alert about
+// the package in the template data provided to the alerting webhook given.
+// Alerts about the same package version share the same deduplication key, so
+// that they are grouped by the alerting service.
+func NewAlertRequest(wh *hub.Webhook, d *hub.PackageNotificationTemplateData) (*http.Request, error) {
+	// Prepare alert details
+	name, _ := d.Package["Name"].(string)
+	version, _ := d.Package["Version"].(string)
+	pkgURL, _ := d.Package["URL"].(string)
+	var repoName, publisher string
+	if r, ok := d.Package["Repository"].(map[string]interface{}); ok {
+		repoName, _ = r["Name"].(string)
+		publisher, _ = r["Publisher"].(string)
+	}
+	summary, _ := d.Package["SecurityReportSummary"].(*hub.SecurityReportSummary)
+	if summary == nil {
+		summary = &hub.SecurityReportSummary{}
+	}
+	severity := getSecurityAlertSeverity(summary)
+	message := fmt.Sprintf("Security vulnerabilities found in %s version %s images", name, version)
+	dedupKey := fmt.Sprintf("artifacthub/%s/%s@%s", repoName, name, version)
+	u := wh.URL
+	if u == "" {
+		u = hub.WebhookKindsDefaultURLs[wh.Kind]
+	}
+
+	// Prepare payload for the corresponding alerting service
+	var payload interface{}
+	var authorization string
+	switch wh.Kind {
+	case hub.WebhookKindPagerDuty:
+		payload = map[string]interface{}{
+			"routing_key":  wh.Secret,
+			"event_action": "trigger",
+			"dedup_key":    dedupKey,
+			"payload": map[string]interface{}{
+				"summary":   message,
+				"source":    d.BaseURL,
+				"severity":  pagerDutySeverities[severity],
+				"component": name,
+				"group":     repoName,
+				"class":     "security-alert",
+				"custom_details": map[string]interface{}{
+					"package":         name,
+					"version":         version,
+					"repository":      repoName,
+					"publisher":       publisher,
+					"vulnerabilities": summary,
+				},
+			},
+			"links": []map[string]string{
+				{"href": pkgURL, "text": "Package details"},
+			},
+		}
+	case hub.WebhookKindOpsgenie:
+		if len(message) > opsgenieMessageMaxLength {
+			message = message[:opsgenieMessageMaxLength]
+		}
+		payload = map[string]interface{}{
+			"message":     message,
+			"alias":       dedupKey,
+			"description": fmt.Sprintf("%s\n\n%s", message, pkgURL),
+			"priority":    opsgeniePriorities[severity],
+			"source":      d.BaseURL,
+			"entity":      name,
+			"tags":        []string{"artifacthub", "security-alert", "severity:" + severity},
+			"details": map[string]string{
+				"package":    name,
+				"version":    version,
+				"repository": repoName,
+				"publisher":  publisher,
+				"url":        pkgURL,
+				"critical":   strconv.Itoa(summary.Critical),
+				"high":       strconv.Itoa(summary.High),
+				"medium":     strconv.Itoa(summary.Medium),
+				"low":        strconv.Itoa(summary.Low),
+				"unknown":    strconv.Itoa(summary.Unknown),
+			},
+		}
+		authorization = "GenieKey " + wh.Secret
+	default:
+		return nil, errors.New("webhook kind does not support alerts")
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare request
+	req, err := http.NewRequest("POST", u, bytes.NewReader(payloadJSON))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return req, nil
+}
+
+// getSecurityAlertSeverity returns the severity of a security alert, which is
+// the highest severity of the vulnerabilities in the summary provided.
+func getSecurityAlertSeverity(s *hub.SecurityReportSummary) string {
+	switch {
+	case s.Critical > 0:
+		return "critical"
+	case s.High > 0:
+		return "high"
+	case s.Medium > 0:
+		return "medium"
+	case s.Low > 0:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,105 @@
+package notification
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAlertRequest(t *testing.T) {
+	d := &hub.PackageNotificationTemplateData{
+		BaseURL: "http://baseURL",
+		Package: map[string]interface{}{
+			"Name":    "package1",
+			"Version": "1.0.0",
+			"URL":     "http://baseURL/packages/helm/repo1/package1/1.0.0",
+			"SecurityReportSummary": &hub.SecurityReportSummary{
+				High: 2,
+				Low:  1,
+			},
+			"Repository": map[string]interface{}{
+				"Kind":      "helm",
+				"Name":      "repo1",
+				"Publisher": "org1",
+			},
+		},
+	}
+
+	t.Run("generic webhook", func(t *testing.T) {
+		t.Parallel()
+		wh := &hub.Webhook{URL: "http://webhook1.url"}
+		assert.False(t, IsAlertingWebhook(wh))
+		_, err := NewAlertRequest(wh, d)
+		assert.Error(t, err)
+	})
+
+	t.Run("pagerduty webhook", func(t *testing.T) {
+		t.Parallel()
+		wh := &hub.Webhook{
+			Kind:   hub.WebhookKindPagerDuty,
+			Secret: "integrationKey",
+		}
+		assert.True(t, IsAlertingWebhook(wh))
+		req, err := NewAlertRequest(wh, d)
+		require.NoError(t, err)
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, hub.WebhookKindsDefaultURLs[hub.WebhookKindPagerDuty], req.URL.String())
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Empty(t, req.Header.Get("Authorization"))
+
+		var payload map[string]interface{}
+		body, _ := ioutil.ReadAll(req.Body)
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "integrationKey", payload["routing_key"])
+		assert.Equal(t, "trigger", payload["event_action"])
+		assert.Equal(t, "artifacthub/repo1/package1@1.0.0", payload["dedup_key"])
+		assert.Equal(t, "error", payload["payload"].(map[string]interface{})["severity"])
+	})
+
+	t.Run("opsgenie webhook", func(t *testing.T) {
+		t.Parallel()
+		wh := &hub.Webhook{
+			Kind:   hub.WebhookKindOpsgenie,
+			URL:    "https://api.eu.opsgenie.com/v2/alerts",
+			Secret: "apiKey",
+		}
+		assert.True(t, IsAlertingWebhook(wh))
+		req, err := NewAlertRequest(wh, d)
+		require.NoError(t, err)
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "https://api.eu.opsgenie.com/v2/alerts", req.URL.String())
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Equal(t, "GenieKey apiKey", req.Header.Get("Authorization"))
+
+		var payload map[string]interface{}
+		body, _ := ioutil.ReadAll(req.Body)
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "artifacthub/repo1/package1@1.0.0", payload["alias"])
+		assert.Equal(t, "P2", payload["priority"])
+		assert.Equal(t, "2", payload["details"].(map[string]interface{})["high"])
+	})
+}
+
+func TestGetSecurityAlertSeverity(t *testing.T) {
+	testCases := []struct {
+		summary          *hub.SecurityReportSummary
+		expectedSeverity string
+	}{
+		{&hub.SecurityReportSummary{Critical: 1, High: 3}, "critical"},
+		{&hub.SecurityReportSummary{High: 1, Low: 3}, "high"},
+		{&hub.SecurityReportSummary{Medium: 1, Unknown: 2}, "medium"},
+		{&hub.SecurityReportSummary{Low: 1}, "low"},
+		{&hub.SecurityReportSummary{}, "unknown"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.expectedSeverity, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedSeverity, getSecurityAlertSeverity(tc.summary))
+		})
+	}
+}
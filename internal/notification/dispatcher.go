@@ -34,17 +34,32 @@ var (
 	//go:embed template/new_release_email.tmpl
 	newReleaseEmailTmpl string
 
+	//go:embed template/new_release_email.txt.tmpl
+	newReleaseEmailTextTmpl string
+
 	//go:embed template/ownership_claim_email.tmpl
 	ownershipClaimEmailTmpl string
 
+	//go:embed template/ownership_claim_email.txt.tmpl
+	ownershipClaimEmailTextTmpl string
+
 	//go:embed template/scanning_errors_email.tmpl
 	scanningErrorsEmailTmpl string
 
+	//go:embed template/scanning_errors_email.txt.tmpl
+	scanningErrorsEmailTextTmpl string
+
 	//go:embed template/security_alert_email.tmpl
 	securityAlertEmailTmpl string
 
+	//go:embed template/security_alert_email.txt.tmpl
+	securityAlertEmailTextTmpl string
+
 	//go:embed template/tracking_errors_email.tmpl
 	trackingErrorsEmailTmpl string
+
+	//go:embed template/tracking_errors_email.txt.tmpl
+	trackingErrorsEmailTextTmpl string
 )
 
 // Services is a wrapper around several internal services used to handle
@@ -84,12 +99,19 @@ func NewDispatcher(svc *Services, opts ...func(d *Dispatcher)) *Dispatcher {
 		securityAlertEmail:  template.Must(template.New("").Parse(email.BaseTmpl + securityAlertEmailTmpl)),
 		trackingErrorsEmail: template.Must(template.New("").Parse(email.BaseTmpl + trackingErrorsEmailTmpl)),
 	}
+	textTmpl := map[templateID]*template.Template{
+		newReleaseEmail:     template.Must(template.New("").Parse(newReleaseEmailTextTmpl)),
+		ownershipClaimEmail: template.Must(template.New("").Parse(ownershipClaimEmailTextTmpl)),
+		scanningErrorsEmail: template.Must(template.New("").Parse(scanningErrorsEmailTextTmpl)),
+		securityAlertEmail:  template.Must(template.New("").Parse(securityAlertEmailTextTmpl)),
+		trackingErrorsEmail: template.Must(template.New("").Parse(trackingErrorsEmailTextTmpl)),
+	}
 
 	// Setup and launch workers
 	c := cache.New(cacheDefaultExpiration, cacheCleanupInterval)
 	d.workers = make([]*Worker, 0, d.numWorkers)
 	for i := 0; i < d.numWorkers; i++ {
-		d.workers = append(d.workers, NewWorker(svc, c, tmpl))
+		d.workers = append(d.workers, NewWorker(svc, c, tmpl, textTmpl))
 	}
 
 	return d
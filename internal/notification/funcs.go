@@ -0,0 +1,192 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+var (
+	// webhookTemplateFuncs represents the functions available in the webhooks
+	// payloads templates, along with their documentation. All of them are
+	// pure functions: they don't have side effects nor access any resource.
+	webhookTemplateFuncs = []struct {
+		doc *hub.WebhookTemplateFunc
+		fn  interface{}
+	}{
+		{
+			doc: &hub.WebhookTemplateFunc{
+				Name:        "date",
+				Signature:   "date LAYOUT TIME",
+				Description: "Formats the time provided (a time, an unix timestamp or a RFC3339 string) using the Go layout given, in UTC.",
+				Example:     `{{ date "2006-01-02" .Package.TS }}`,
+			},
+			fn: formatDate,
+		},
+		{
+			doc: &hub.WebhookTemplateFunc{
+				Name:        "markdownToText",
+				Signature:   "markdownToText TEXT",
+				Description: "Removes the markdown formatting from the text provided, keeping the text of links and images.",
+				Example:     `{{ markdownToText .Package.Description }}`,
+			},
+			fn: markdownToText,
+		},
+		{
+			doc: &hub.WebhookTemplateFunc{
+				Name:        "semverCompare",
+				Signature:   "semverCompare CONSTRAINT VERSION",
+				Description: "Checks if the version provided satisfies the semver constraint given. An error is returned when the constraint or the version are not valid.",
+				Example:     `{{ if semverCompare ">=1.0.0" .Package.Version }}stable{{ end }}`,
+			},
+			fn: semverCompare,
+		},
+		{
+			doc: &hub.WebhookTemplateFunc{
+				Name:        "toJson",
+				Signature:   "toJson VALUE",
+				Description: "Encodes the value provided as JSON. Strings are quoted and escaped, so it's the safest way of embedding values in JSON payloads.",
+				Example:     `{"name": {{ toJson .Package.Name }}}`,
+			},
+			fn: toJSON,
+		},
+		{
+			doc: &hub.WebhookTemplateFunc{
+				Name:        "truncate",
+				Signature:   "truncate LENGTH TEXT",
+				Description: "Truncates the text provided to the number of characters given, adding an ellipsis when it's truncated.",
+				Example:     `{{ .Package.Name | truncate 20 }}`,
+			},
+			fn: truncate,
+		},
+	}
+
+	// webhookTemplateFuncMap represents the functions available in the
+	// webhooks payloads templates, indexed by name.
+	webhookTemplateFuncMap = func() template.FuncMap {
+		funcMap := make(template.FuncMap, len(webhookTemplateFuncs))
+		for _, f := range webhookTemplateFuncs {
+			funcMap[f.doc.Name] = f.fn
+		}
+		return funcMap
+	}()
+
+	// markdownRules represents the regular expressions (and their
+	// replacements) applied to remove the markdown formatting from a text.
+	markdownRules = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{regexp.MustCompile("(?m)^[ \\t]*(```|~~~).*$\\n?"), ""},
+		{regexp.MustCompile(`<[^>]+>`), ""},
+		{regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`), "$1"},
+		{regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`), "$1"},
+		{regexp.MustCompile(`(?m)^[ \t]{0,3}#{1,6}[ \t]+`), ""},
+		{regexp.MustCompile(`(?m)^[ \t]{0,3}>[ \t]?`), ""},
+		{regexp.MustCompile(`(?m)^[ \t]*([-*_][ \t]*){3,}$`), ""},
+		{regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*`), "$1"},
+		{regexp.MustCompile(`(^|\W)__(\S(?:.*?\S)?)__(\W|$)`), "$1$2$3"},
+		{regexp.MustCompile(`\*(\S(?:.*?\S)?)\*`), "$1"},
+		{regexp.MustCompile(`(^|\W)_(\S(?:.*?\S)?)_(\W|$)`), "$1$2$3"},
+		{regexp.MustCompile("`([^`]*)`"), "$1"},
+		{regexp.MustCompile(`~~(.*?)~~`), "$1"},
+	}
+)
+
+// ParseWebhookTemplate parses the webhook payload template provided, making
+// the webhooks templates functions available to it.
+func ParseWebhookTemplate(text string) (*template.Template, error) {
+	return template.New("").Funcs(webhookTemplateFuncMap).Parse(text)
+}
+
+// WebhookTemplateFuncs returns the documentation of the functions available
+// in the webhooks payloads templates.
+func WebhookTemplateFuncs() []*hub.WebhookTemplateFunc {
+	docs := make([]*hub.WebhookTemplateFunc, 0, len(webhookTemplateFuncs))
+	for _, f := range webhookTemplateFuncs {
+		docs = append(docs, f.doc)
+	}
+	return docs
+}
+
+// formatDate formats the time provided using the layout given.
+func formatDate(layout string, v interface{}) (string, error) {
+	var t time.Time
+	switch v := v.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return "", nil
+		}
+		t = *v
+	case int64:
+		t = time.Unix(v, 0)
+	case int:
+		t = time.Unix(int64(v), 0)
+	case float64:
+		t = time.Unix(int64(v), 0)
+	case string:
+		var err error
+		t, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return "", fmt.Errorf("invalid time: %s", v)
+		}
+	default:
+		return "", fmt.Errorf("unsupported time type: %T", v)
+	}
+	return t.UTC().Format(layout), nil
+}
+
+// markdownToText removes the markdown formatting from the text provided.
+func markdownToText(text string) string {
+	for _, rule := range markdownRules {
+		text = rule.re.ReplaceAllString(text, rule.repl)
+	}
+	return strings.TrimSpace(text)
+}
+
+// semverCompare checks if the version provided satisfies the constraint given.
+func semverCompare(constraint, version string) (bool, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, fmt.Errorf("invalid semver constraint: %s", constraint)
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false, fmt.Errorf("invalid semver version: %s", version)
+	}
+	return c.Check(v), nil
+}
+
+// toJSON encodes the value provided as JSON.
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// truncate truncates the text provided to the length given, adding an
+// ellipsis when the text is truncated.
+func truncate(length int, text string) (string, error) {
+	if length < 1 {
+		return "", errors.New("truncate length must be positive")
+	}
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text, nil
+	}
+	if length == 1 {
+		return "…", nil
+	}
+	return string(runes[:length-1]) + "…", nil
+}
@@ -0,0 +1,151 @@
+package notification
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWebhookTemplate(t *testing.T) {
+	t.Run("invalid template", func(t *testing.T) {
+		t.Parallel()
+		_, err := ParseWebhookTemplate("{{ unknownFunc .Package.Name }}")
+		assert.Error(t, err)
+	})
+
+	t.Run("template using functions executed successfully", func(t *testing.T) {
+		t.Parallel()
+		tmpl, err := ParseWebhookTemplate(
+			`{"name": {{ toJson .Name }}, "date": "{{ date "2006-01-02" .TS }}", "stable": {{ semverCompare ">=1.0.0" .Version }}, "description": {{ .Description | markdownToText | truncate 10 | toJson }}}`,
+		)
+		require.NoError(t, err)
+		var payload bytes.Buffer
+		err = tmpl.Execute(&payload, map[string]interface{}{
+			"Name":        `pkg "1"`,
+			"TS":          int64(1609459200),
+			"Version":     "1.2.0",
+			"Description": "**Package** description",
+		})
+		require.NoError(t, err)
+		assert.Equal(t,
+			`{"name": "pkg \"1\"", "date": "2021-01-01", "stable": true, "description": "Package d…"}`,
+			payload.String(),
+		)
+	})
+}
+
+func TestWebhookTemplateFuncs(t *testing.T) {
+	docs := WebhookTemplateFuncs()
+	require.Len(t, docs, len(webhookTemplateFuncMap))
+	for _, doc := range docs {
+		assert.Contains(t, webhookTemplateFuncMap, doc.Name)
+		assert.NotEmpty(t, doc.Signature)
+		assert.NotEmpty(t, doc.Description)
+		_, err := ParseWebhookTemplate(doc.Example)
+		assert.NoError(t, err)
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	ts := time.Date(2021, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	t.Run("valid input", func(t *testing.T) {
+		testCases := []interface{}{
+			ts,
+			&ts,
+			ts.Unix(),
+			int(ts.Unix()),
+			float64(ts.Unix()),
+			"2021-01-01T11:30:00+01:00",
+		}
+		for _, tc := range testCases {
+			s, err := formatDate("2006-01-02 15:04", tc)
+			require.NoError(t, err)
+			assert.Equal(t, "2021-01-01 10:30", s)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []interface{}{
+			"invalid",
+			true,
+		}
+		for _, tc := range testCases {
+			_, err := formatDate("2006-01-02", tc)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestMarkdownToText(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expectedOutput string
+	}{
+		{"", ""},
+		{"Plain text", "Plain text"},
+		{"# Title\n\nSome **bold** and *italic* text", "Title\n\nSome bold and italic text"},
+		{"Check [the docs](https://docs.url) and ![logo](https://logo.url)", "Check the docs and logo"},
+		{"Use `snake_case_names` and __underline__", "Use snake_case_names and underline"},
+		{"> Quoted ~~text~~", "Quoted text"},
+		{"```yaml\nkey: value\n```", "key: value"},
+		{"Line<br/>break", "Linebreak"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedOutput, markdownToText(tc.input))
+		})
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		_, err := semverCompare("invalid", "1.0.0")
+		assert.Error(t, err)
+		_, err = semverCompare(">=1.0.0", "invalid")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		t.Parallel()
+		ok, err := semverCompare(">=1.0.0", "1.1.0")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = semverCompare("^2", "1.1.0")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	t.Run("invalid length", func(t *testing.T) {
+		t.Parallel()
+		_, err := truncate(0, "text")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid length", func(t *testing.T) {
+		testCases := []struct {
+			length         int
+			input          string
+			expectedOutput string
+		}{
+			{10, "text", "text"},
+			{4, "text", "text"},
+			{3, "text", "te…"},
+			{1, "text", "…"},
+			{2, "ñandú", "ñ…"},
+		}
+		for _, tc := range testCases {
+			s, err := truncate(tc.length, tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, s)
+		}
+	})
+}
@@ -12,9 +12,10 @@ import (
 
 const (
 	// Database queries
-	addNotificationDBQ          = `select add_notification($1::jsonb)`
-	getPendingNotificationDBQ   = `select get_pending_notification()`
-	updateNotificationStatusDBQ = `select update_notification_status($1::uuid, $2::boolean, $3::text)`
+	addNotificationDBQ              = `select add_notification($1::jsonb)`
+	getPendingNotificationDBQ       = `select get_pending_notification()`
+	registerWebhookTemplateErrorDBQ = `select register_webhook_template_error($1::jsonb)`
+	updateNotificationStatusDBQ     = `select update_notification_status($1::uuid, $2::boolean, $3::text)`
 )
 
 // Manager provides an API to manage notifications.
@@ -64,6 +65,23 @@ func (m *Manager) GetPending(ctx context.Context, tx pgx.Tx) (*hub.Notification,
 	return n, nil
 }
 
+// RegisterWebhookTemplateError registers the webhook template error provided
+// in the database.
+func (m *Manager) RegisterWebhookTemplateError(ctx context.Context, tx pgx.Tx, e *hub.WebhookTemplateError) error {
+	if _, err := uuid.FromString(e.WebhookID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+	if _, err := uuid.FromString(e.EventID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid event id")
+	}
+	if e.Error == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "error not provided")
+	}
+	eJSON, _ := json.Marshal(e)
+	_, err := tx.Exec(ctx, registerWebhookTemplateErrorDBQ, eJSON)
+	return err
+}
+
 // UpdateStatus the provided notification status in the database.
 func (m *Manager) UpdateStatus(
 	ctx context.Context,
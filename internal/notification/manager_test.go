@@ -156,6 +156,76 @@ func TestGetPending(t *testing.T) {
 	})
 }
 
+func TestRegisterWebhookTemplateError(t *testing.T) {
+	ctx := context.Background()
+	e := &hub.WebhookTemplateError{
+		WebhookID: "00000000-0000-0000-0000-000000000001",
+		EventID:   "00000000-0000-0000-0000-000000000001",
+		Error:     "template: :1: unclosed action",
+		Line:      1,
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			e      *hub.WebhookTemplateError
+		}{
+			{
+				"invalid webhook id",
+				&hub.WebhookTemplateError{
+					WebhookID: "invalid",
+				},
+			},
+			{
+				"invalid event id",
+				&hub.WebhookTemplateError{
+					WebhookID: "00000000-0000-0000-0000-000000000001",
+					EventID:   "invalid",
+				},
+			},
+			{
+				"error not provided",
+				&hub.WebhookTemplateError{
+					WebhookID: "00000000-0000-0000-0000-000000000001",
+					EventID:   "00000000-0000-0000-0000-000000000001",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager()
+				err := m.RegisterWebhookTemplateError(ctx, nil, tc.e)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, registerWebhookTemplateErrorDBQ, mock.Anything).Return(tests.ErrFakeDB)
+		m := NewManager()
+
+		err := m.RegisterWebhookTemplateError(ctx, tx, e)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, registerWebhookTemplateErrorDBQ, mock.Anything).Return(nil)
+		m := NewManager()
+
+		err := m.RegisterWebhookTemplateError(ctx, tx, e)
+		assert.NoError(t, err)
+		tx.AssertExpectations(t)
+	})
+}
+
 func TestUpdateStatus(t *testing.T) {
 	ctx := context.Background()
 	notificationID := "00000000-0000-0000-0000-000000000001"
@@ -26,6 +26,12 @@ func (m *ManagerMock) GetPending(ctx context.Context, tx pgx.Tx) (*hub.Notificat
 	return data, args.Error(1)
 }
 
+// RegisterWebhookTemplateError implements the NotificationManager interface.
+func (m *ManagerMock) RegisterWebhookTemplateError(ctx context.Context, tx pgx.Tx, e *hub.WebhookTemplateError) error {
+	args := m.Called(ctx, tx, e)
+	return args.Error(0)
+}
+
 // UpdateStatus implements the NotificationManager interface.
 func (m *ManagerMock) UpdateStatus(
 	ctx context.Context,
@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/rs/zerolog/log"
+)
+
+// webhookPayloadTemplate represents the name used to identify the webhooks
+// payloads templates in the template errors.
+const webhookPayloadTemplate = "webhook_payload"
+
+// emailTemplatesNames represents the names used to identify the emails
+// templates in the template errors.
+var emailTemplatesNames = map[templateID]string{
+	newReleaseEmail:     "new_release_email",
+	ownershipClaimEmail: "ownership_claim_email",
+	scanningErrorsEmail: "scanning_errors_email",
+	securityAlertEmail:  "security_alert_email",
+	trackingErrorsEmail: "tracking_errors_email",
+}
+
+// templateErrorPositionRE is a regexp used to extract the position of the
+// error (line and, when available, column) from the errors returned by the
+// text/template package (i.e. template: :3:12: executing "" at <.Foo>: ...).
+var templateErrorPositionRE = regexp.MustCompile(`^template: [^:]*:(\d+)(?::(\d+))?:`)
+
+// TemplateError represents an error parsing or executing a notification
+// template (webhook payload or email).
+type TemplateError struct {
+	Template string
+	Line     int
+	Column   int
+	Err      error
+}
+
+// newTemplateError creates a new TemplateError instance from the error
+// provided, extracting the position of the error when available.
+func newTemplateError(template string, err error) *TemplateError {
+	e := &TemplateError{
+		Template: template,
+		Err:      err,
+	}
+	if m := templateErrorPositionRE.FindStringSubmatch(err.Error()); m != nil {
+		e.Line, _ = strconv.Atoi(m[1])
+		e.Column, _ = strconv.Atoi(m[2])
+	}
+	return e
+}
+
+// Error implements the error interface.
+func (e *TemplateError) Error() string {
+	return "error rendering template: " + e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// logTemplateError logs the template error provided, including some details
+// about the notification being processed.
+func logTemplateError(n *hub.Notification, e *TemplateError) {
+	l := log.Error().Err(e.Err).
+		Str("template", e.Template).
+		Int("line", e.Line).
+		Int("column", e.Column).
+		Str("eventID", n.Event.EventID).
+		Int64("eventKind", int64(n.Event.EventKind))
+	if n.Webhook != nil {
+		l = l.Str("webhookID", n.Webhook.WebhookID)
+	}
+	l.Msg("error rendering notification template")
+}
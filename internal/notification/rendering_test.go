@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"errors"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTemplateError(t *testing.T) {
+	testCases := []struct {
+		tmpl           string
+		data           interface{}
+		expectedLine   int
+		expectedColumn int
+	}{
+		{
+			"{{ .Name ",
+			nil,
+			1,
+			0,
+		},
+		{
+			"line1\nline2 {{ .Name.Missing }}",
+			map[string]interface{}{"Name": "name"},
+			2,
+			14,
+		},
+		{
+			"{{ fail }}",
+			nil,
+			1,
+			0,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.tmpl, func(t *testing.T) {
+			t.Parallel()
+			tmpl, err := template.New("").Parse(tc.tmpl)
+			if err == nil {
+				err = tmpl.Execute(&discardWriter{}, tc.data)
+			}
+			if !assert.Error(t, err) {
+				return
+			}
+			tmplErr := newTemplateError(webhookPayloadTemplate, err)
+			assert.Equal(t, webhookPayloadTemplate, tmplErr.Template)
+			assert.Equal(t, tc.expectedLine, tmplErr.Line)
+			assert.Equal(t, tc.expectedColumn, tmplErr.Column)
+			assert.True(t, errors.Is(tmplErr, err))
+			assert.Contains(t, tmplErr.Error(), "error rendering template: ")
+		})
+	}
+}
+
+// discardWriter is a writer that discards all the data written to it.
+type discardWriter struct{}
+
+func (w *discardWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
@@ -37,9 +37,10 @@ var (
 
 // Worker is in charge of delivering notifications to their intended recipients.
 type Worker struct {
-	svc   *Services
-	cache *cache.Cache
-	tmpl  map[templateID]*template.Template
+	svc      *Services
+	cache    *cache.Cache
+	tmpl     map[templateID]*template.Template
+	textTmpl map[templateID]*template.Template
 }
 
 // NewWorker creates a new Worker instance.
@@ -47,11 +48,13 @@ func NewWorker(
 	svc *Services,
 	c *cache.Cache,
 	tmpl map[templateID]*template.Template,
+	textTmpl map[templateID]*template.Template,
 ) *Worker {
 	return &Worker{
-		svc:   svc,
-		cache: c,
-		tmpl:  tmpl,
+		svc:      svc,
+		cache:    c,
+		tmpl:     tmpl,
+		textTmpl: textTmpl,
 	}
 }
 
@@ -108,6 +111,10 @@ func (w *Worker) processNotification(ctx context.Context) error {
 			}
 		case n.Webhook != nil:
 			err = w.deliverWebhookNotification(ctx, n)
+			var tmplErr *TemplateError
+			if errors.As(err, &tmplErr) {
+				w.registerWebhookTemplateError(ctx, tx, n, tmplErr)
+			}
 		}
 		if errors.Is(err, ErrRetryable) {
 			log.Error().Err(err).Msg("processNotification: error delivering notification")
@@ -135,6 +142,10 @@ func (w *Worker) deliverEmailNotification(ctx context.Context, n *hub.Notificati
 		var err error
 		emailData, err = w.prepareEmailData(ctx, n.Event)
 		if err != nil {
+			var tmplErr *TemplateError
+			if errors.As(err, &tmplErr) {
+				logTemplateError(n, tmplErr)
+			}
 			return fmt.Errorf("%w: error preparing email data: %v", ErrRetryable, err)
 		}
 		w.cache.SetDefault(cKey, emailData)
@@ -153,20 +164,32 @@ func (w *Worker) deliverWebhookNotification(ctx context.Context, n *hub.Notifica
 		return fmt.Errorf("%w: %v", ErrRetryable, err)
 	}
 
+	// Alerting webhooks (PagerDuty and Opsgenie) only handle security alerts
+	if IsAlertingWebhook(n.Webhook) {
+		if n.Event.EventKind != hub.SecurityAlert {
+			return errors.New("event kind not supported by alerting webhook")
+		}
+		req, err := NewAlertRequest(n.Webhook, tmplData)
+		if err != nil {
+			return err
+		}
+		return w.callWebhook(req)
+	}
+
 	// Prepare payload
 	var tmpl *template.Template
 	if n.Webhook.Template != "" {
 		var err error
-		tmpl, err = template.New("").Parse(n.Webhook.Template)
+		tmpl, err = ParseWebhookTemplate(n.Webhook.Template)
 		if err != nil {
-			return err
+			return newTemplateError(webhookPayloadTemplate, err)
 		}
 	} else {
 		tmpl = DefaultWebhookPayloadTmpl
 	}
 	var payload bytes.Buffer
 	if err := tmpl.Execute(&payload, tmplData); err != nil {
-		return err
+		return newTemplateError(webhookPayloadTemplate, err)
 	}
 	contentType := n.Webhook.ContentType
 	if contentType == "" {
@@ -177,6 +200,33 @@ func (w *Worker) deliverWebhookNotification(ctx context.Context, n *hub.Notifica
 	req, _ := http.NewRequest("POST", n.Webhook.URL, &payload)
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("X-ArtifactHub-Secret", n.Webhook.Secret)
+	return w.callWebhook(req)
+}
+
+// registerWebhookTemplateError logs the webhook template error provided and
+// registers it, so that it can be inspected later by the webhook owners.
+func (w *Worker) registerWebhookTemplateError(
+	ctx context.Context,
+	tx pgx.Tx,
+	n *hub.Notification,
+	tmplErr *TemplateError,
+) {
+	logTemplateError(n, tmplErr)
+	err := w.svc.NotificationManager.RegisterWebhookTemplateError(ctx, tx, &hub.WebhookTemplateError{
+		WebhookID: n.Webhook.WebhookID,
+		EventID:   n.Event.EventID,
+		Error:     tmplErr.Err.Error(),
+		Line:      tmplErr.Line,
+		Column:    tmplErr.Column,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("registerWebhookTemplateError: error registering webhook template error")
+	}
+}
+
+// callWebhook sends the webhook request provided, checking the response
+// status code received.
+func (w *Worker) callWebhook(req *http.Request) error {
 	resp, err := w.svc.HTTPClient.Do(req)
 	if err != nil {
 		return err
@@ -191,7 +241,7 @@ func (w *Worker) deliverWebhookNotification(ctx context.Context, n *hub.Notifica
 // prepareEmailData prepares the email data corresponding to the event provided.
 func (w *Worker) prepareEmailData(ctx context.Context, e *hub.Event) (email.Data, error) {
 	var subject string
-	var emailBody bytes.Buffer
+	var emailBody, emailText []byte
 
 	switch e.EventKind {
 	case hub.NewRelease:
@@ -200,8 +250,8 @@ func (w *Worker) prepareEmailData(ctx context.Context, e *hub.Event) (email.Data
 			return email.Data{}, err
 		}
 		subject = fmt.Sprintf("%s version %s released", tmplData.Package["Name"], tmplData.Package["Version"])
-		if err := w.tmpl[newReleaseEmail].Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
+		if emailBody, emailText, err = email.Render(w.tmpl[newReleaseEmail], w.textTmpl[newReleaseEmail], tmplData); err != nil {
+			return email.Data{}, newTemplateError(emailTemplatesNames[newReleaseEmail], err)
 		}
 	case hub.SecurityAlert:
 		tmplData, err := w.preparePkgNotificationTemplateData(ctx, e)
@@ -210,8 +260,8 @@ func (w *Worker) prepareEmailData(ctx context.Context, e *hub.Event) (email.Data
 		}
 		subject = fmt.Sprintf("Security vulnerabilities found in %s version %s images",
 			tmplData.Package["Name"], tmplData.Package["Version"])
-		if err := w.tmpl[securityAlertEmail].Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
+		if emailBody, emailText, err = email.Render(w.tmpl[securityAlertEmail], w.textTmpl[securityAlertEmail], tmplData); err != nil {
+			return email.Data{}, newTemplateError(emailTemplatesNames[securityAlertEmail], err)
 		}
 	case hub.RepositoryScanningErrors:
 		tmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
@@ -219,8 +269,8 @@ func (w *Worker) prepareEmailData(ctx context.Context, e *hub.Event) (email.Data
 			return email.Data{}, err
 		}
 		subject = fmt.Sprintf("Something went wrong scanning repository %s", tmplData.Repository["Name"])
-		if err := w.tmpl[scanningErrorsEmail].Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
+		if emailBody, emailText, err = email.Render(w.tmpl[scanningErrorsEmail], w.textTmpl[scanningErrorsEmail], tmplData); err != nil {
+			return email.Data{}, newTemplateError(emailTemplatesNames[scanningErrorsEmail], err)
 		}
 	case hub.RepositoryTrackingErrors:
 		tmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
@@ -228,8 +278,8 @@ func (w *Worker) prepareEmailData(ctx context.Context, e *hub.Event) (email.Data
 			return email.Data{}, err
 		}
 		subject = fmt.Sprintf("Something went wrong tracking repository %s", tmplData.Repository["Name"])
-		if err := w.tmpl[trackingErrorsEmail].Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
+		if emailBody, emailText, err = email.Render(w.tmpl[trackingErrorsEmail], w.textTmpl[trackingErrorsEmail], tmplData); err != nil {
+			return email.Data{}, newTemplateError(emailTemplatesNames[trackingErrorsEmail], err)
 		}
 	case hub.RepositoryOwnershipClaim:
 		tmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
@@ -237,14 +287,15 @@ func (w *Worker) prepareEmailData(ctx context.Context, e *hub.Event) (email.Data
 			return email.Data{}, err
 		}
 		subject = fmt.Sprintf("%s repository ownership has been claimed", tmplData.Repository["Name"])
-		if err := w.tmpl[ownershipClaimEmail].Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
+		if emailBody, emailText, err = email.Render(w.tmpl[ownershipClaimEmail], w.textTmpl[ownershipClaimEmail], tmplData); err != nil {
+			return email.Data{}, newTemplateError(emailTemplatesNames[ownershipClaimEmail], err)
 		}
 	}
 
 	return email.Data{
 		Subject: subject,
-		Body:    emailBody.Bytes(),
+		Body:    emailBody,
+		Text:    emailText,
 	}, nil
 }
 
@@ -295,11 +346,14 @@ func (w *Worker) preparePkgNotificationTemplateData(
 		Package: map[string]interface{}{
 			"Name":                    p.Name,
 			"Version":                 p.Version,
+			"Description":             p.Description,
+			"TS":                      p.TS,
 			"LogoImageID":             p.LogoImageID,
 			"URL":                     pkg.BuildURL(baseURL, p, e.PackageVersion),
 			"Changes":                 p.Changes,
 			"ContainsSecurityUpdates": p.ContainsSecurityUpdates,
 			"Prerelease":              p.Prerelease,
+			"SecurityReportSummary":   p.SecurityReportSummary,
 			"Repository": map[string]interface{}{
 				"Kind":      hub.GetKindName(p.Repository.Kind),
 				"Name":      p.Repository.Name,
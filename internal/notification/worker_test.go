@@ -95,6 +95,13 @@ func TestWorker(t *testing.T) {
 		securityAlertEmail:  template.Must(template.New("").Parse(email.BaseTmpl + securityAlertEmailTmpl)),
 		trackingErrorsEmail: template.Must(template.New("").Parse(email.BaseTmpl + trackingErrorsEmailTmpl)),
 	}
+	textTmpl := map[templateID]*template.Template{
+		newReleaseEmail:     template.Must(template.New("").Parse(newReleaseEmailTextTmpl)),
+		ownershipClaimEmail: template.Must(template.New("").Parse(ownershipClaimEmailTextTmpl)),
+		scanningErrorsEmail: template.Must(template.New("").Parse(scanningErrorsEmailTextTmpl)),
+		securityAlertEmail:  template.Must(template.New("").Parse(securityAlertEmailTextTmpl)),
+		trackingErrorsEmail: template.Must(template.New("").Parse(trackingErrorsEmailTextTmpl)),
+	}
 
 	t.Run("error getting pending notification", func(t *testing.T) {
 		t.Parallel()
@@ -103,7 +110,7 @@ func TestWorker(t *testing.T) {
 		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(nil, tests.ErrFake)
 		sw.tx.On("Rollback", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -116,7 +123,7 @@ func TestWorker(t *testing.T) {
 		sw.pm.On("Get", sw.ctx, gpi).Return(nil, tests.ErrFake)
 		sw.tx.On("Rollback", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -129,7 +136,7 @@ func TestWorker(t *testing.T) {
 		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(nil, tests.ErrFake)
 		sw.tx.On("Rollback", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -144,7 +151,7 @@ func TestWorker(t *testing.T) {
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, tests.ErrFake).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -159,7 +166,7 @@ func TestWorker(t *testing.T) {
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n3.NotificationID, true, tests.ErrFake).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -170,11 +177,13 @@ func TestWorker(t *testing.T) {
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
 		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n1, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
-		sw.es.On("SendEmail", mock.Anything).Return(nil)
+		sw.es.On("SendEmail", mock.MatchedBy(func(d *email.Data) bool {
+			return d.Body != nil && d.Text != nil
+		})).Return(nil)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, nil).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -185,11 +194,13 @@ func TestWorker(t *testing.T) {
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
 		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n3, nil)
 		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(r, nil)
-		sw.es.On("SendEmail", mock.Anything).Return(nil)
+		sw.es.On("SendEmail", mock.MatchedBy(func(d *email.Data) bool {
+			return d.Body != nil && d.Text != nil
+		})).Return(nil)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n3.NotificationID, true, nil).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -202,7 +213,7 @@ func TestWorker(t *testing.T) {
 		sw.pm.On("Get", sw.ctx, gpi).Return(nil, tests.ErrFake)
 		sw.tx.On("Rollback", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -217,7 +228,7 @@ func TestWorker(t *testing.T) {
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, tests.ErrFake).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -235,7 +246,36 @@ func TestWorker(t *testing.T) {
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, mock.Anything).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("webhook template error registered", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(&hub.Notification{
+			NotificationID: "notificationID",
+			Event:          e1,
+			Webhook: &hub.Webhook{
+				WebhookID: "webhookID",
+				Name:      "webhook1",
+				URL:       "http://webhook1.url",
+				Template:  "{\n  \"name\": \"{{ .Package.Name }\"\n}",
+			},
+		}, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.nm.On("RegisterWebhookTemplateError", sw.ctx, sw.tx, mock.MatchedBy(func(e *hub.WebhookTemplateError) bool {
+			return e.WebhookID == "webhookID" &&
+				e.EventID == e1.EventID &&
+				e.Line == 2 &&
+				e.Error != ""
+		})).Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, "notificationID", true, mock.Anything).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -253,7 +293,7 @@ func TestWorker(t *testing.T) {
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -338,7 +378,7 @@ func TestWorker(t *testing.T) {
 				sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
 				sw.tx.On("Commit", sw.ctx).Return(nil)
 
-				w := NewWorker(sw.svc, sw.cache, tmpl)
+				w := NewWorker(sw.svc, sw.cache, tmpl, textTmpl)
 				go w.Run(sw.ctx, sw.wg)
 				sw.assertExpectations(t)
 			})
@@ -1,13 +1,17 @@
 package org
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"net"
+	"net/url"
 	"regexp"
 	"strconv"
+	texttemplate "text/template"
+	"time"
 
 	_ "embed" // Used by templates
 
@@ -15,27 +19,63 @@ import (
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
 	"github.com/open-policy-agent/opa/ast"
+	"github.com/rs/zerolog/log"
+	"github.com/russross/blackfriday/v2"
 	"github.com/satori/uuid"
 	"github.com/spf13/viper"
 )
 
 const (
 	// Database queries
-	addOrgDBQ            = `select add_organization($1::uuid, $2::jsonb)`
-	addOrgMemberDBQ      = `select add_organization_member($1::uuid, $2::text, $3::text)`
-	checkOrgNameAvailDBQ = `select organization_id from organization where name = $1`
-	confirmMembershipDBQ = `select confirm_organization_membership($1::uuid, $2::text)`
-	deleteOrgDBQ         = `select delete_organization($1::uuid, $2::text)`
-	deleteOrgMemberDBQ   = `select delete_organization_member($1::uuid, $2::text, $3::text)`
-	getAuthzPolicyDBQ    = `select get_authorization_policy($1::uuid, $2::text)`
-	getOrgDBQ            = `select get_organization($1::text)`
-	getOrgMembersDBQ     = `select * from get_organization_members($1::uuid, $2::text, $3::int, $4::int)`
-	getUserAliasDBQ      = `select alias from "user" where user_id = $1`
-	getUserEmailDBQ      = `select email from "user" where alias = $1`
-	getUserOrgsDBQ       = `select * from get_user_organizations($1::uuid, $2::int, $3::int)`
-	updateAuthzPolicyDBQ = `select update_authorization_policy($1::uuid, $2::text, $3::jsonb)`
-	updateOrgDBQ         = `select update_organization($1::uuid, $2::text, $3::jsonb)`
+	addOrgDBQ              = `select add_organization($1::uuid, $2::jsonb)`
+	addOrgMemberDBQ        = `select add_organization_member($1::uuid, $2::text, $3::text, $4::int)`
+	cancelInvitationDBQ    = `select cancel_organization_invitation($1::uuid, $2::text, $3::text)`
+	checkOrgNameAvailDBQ   = `select organization_id from organization where name = $1`
+	confirmMembershipDBQ   = `select confirm_organization_membership($1::uuid, $2::text)`
+	deleteOrgDBQ           = `select delete_organization($1::uuid, $2::text, $3::jsonb)`
+	deleteOrgMemberDBQ     = `select delete_organization_member($1::uuid, $2::text, $3::text)`
+	getAuthzPolicyDBQ      = `select get_authorization_policy($1::uuid, $2::text)`
+	getMembersRolesDBQ     = `select coalesce(get_user_organization_role($1::uuid, $2::text), ''), coalesce(get_user_organization_role((select user_id from "user" where alias = $3), $2::text), '')`
+	getOrgDBQ              = `select get_organization($1::text)`
+	getOrgIdPGroupsDBQ     = `select get_organization_idp_groups($1::uuid, $2::text)`
+	getOrgInvitationsDBQ   = `select * from get_organization_invitations($1::uuid, $2::text, $3::int, $4::int)`
+	getOrgLinksDBQ         = `select get_organization_links($1::uuid, $2::text)`
+	getOrgMembersDBQ       = `select * from get_organization_members($1::uuid, $2::text, $3::int, $4::int)`
+	getUserAliasDBQ        = `select alias from "user" where user_id = $1`
+	getUserAliasByEmailDBQ = `select alias from "user" where email = $1`
+	getUserEmailDBQ        = `select email from "user" where alias = $1`
+	getUserOrgsDBQ         = `select * from get_user_organizations($1::uuid, $2::int, $3::int)`
+	resendInvitationDBQ    = `select resend_organization_invitation($1::uuid, $2::text, $3::text, $4::int)`
+	updateAuthzPolicyDBQ   = `select update_authorization_policy($1::uuid, $2::text, $3::jsonb)`
+	updateOrgIdPGroupsDBQ  = `select update_organization_idp_groups($1::uuid, $2::text, $3::jsonb)`
+	updateOrgDBQ           = `select update_organization($1::uuid, $2::text, $3::jsonb)`
+	updateOrgLinksDBQ      = `select update_organization_links($1::uuid, $2::text, $3::jsonb)`
+	updateOrgMemberRoleDBQ = `select update_organization_member_role($1::uuid, $2::text, $3::text, $4::text)`
+	verifyOrgLinksDBQ      = `select verify_organization_links($1::uuid, $2::text, $3::text[])`
+)
+
+const (
+	// defaultInvitationTTL represents the default period of time during which
+	// an invitation to join an organization is valid.
+	defaultInvitationTTL = 7 * 24 * time.Hour
+
+	// maxInvitationsPerRequest represents the maximum number of invitations
+	// that can be issued in a single bulk invite request.
+	maxInvitationsPerRequest = 50
+
+	// maxProfileLength represents the maximum length of the markdown profile
+	// of an organization.
+	maxProfileLength = 64 * 1024
+
+	// maxLinks represents the maximum number of links an organization can
+	// publish in its profile.
+	maxLinks = 10
+
+	// linkVerificationPrefix represents the prefix of the DNS TXT record
+	// value used to verify the links of an organization.
+	linkVerificationPrefix = "artifacthub-verification="
 )
 
 type templateID int
@@ -47,28 +87,72 @@ const (
 //go:embed template/invitation_email.tmpl
 var invitationEmailTmpl string
 
-// organizationNameRE is a regexp used to validate an organization name.
-var organizationNameRE = regexp.MustCompile(`^[a-z0-9-]+$`)
+//go:embed template/invitation_email.txt.tmpl
+var invitationEmailTextTmpl string
+
+var (
+	// organizationNameRE is a regexp used to validate an organization name.
+	organizationNameRE = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+	// errOrgHasRepositoriesDB represents the error returned from the database
+	// when deleting an organization that owns repositories without indicating
+	// what to do with them.
+	errOrgHasRepositoriesDB = errors.New("ERROR: organization has repositories (SQLSTATE P0001)")
+
+	// errTargetOrgNotFoundDB represents the error returned from the database
+	// when the organization repositories should be transferred to does not
+	// exist.
+	errTargetOrgNotFoundDB = errors.New("ERROR: target organization not found (SQLSTATE P0001)")
+
+	// errAlreadyMemberDB represents the error returned from the database when
+	// adding a user who is already a member of the organization or has a
+	// pending invitation.
+	errAlreadyMemberDB = errors.New(`ERROR: duplicate key value violates unique constraint "user__organization_pkey" (SQLSTATE 23505)`)
+)
 
 // Manager provides an API to manage organizations.
 type Manager struct {
-	cfg  *viper.Viper
-	db   hub.DB
-	es   hub.EmailSender
-	az   hub.Authorizer
-	tmpl map[templateID]*template.Template
+	cfg      *viper.Viper
+	db       hub.DB
+	es       hub.EmailSender
+	az       hub.Authorizer
+	rs       hub.TXTResolver
+	tmpl     map[templateID]*template.Template
+	textTmpl map[templateID]*texttemplate.Template
 }
 
 // NewManager creates a new Manager instance.
-func NewManager(cfg *viper.Viper, db hub.DB, es hub.EmailSender, az hub.Authorizer) *Manager {
-	return &Manager{
+func NewManager(
+	cfg *viper.Viper,
+	db hub.DB,
+	es hub.EmailSender,
+	az hub.Authorizer,
+	opts ...func(m *Manager),
+) *Manager {
+	m := &Manager{
 		cfg: cfg,
 		db:  db,
 		es:  es,
 		az:  az,
+		rs:  net.DefaultResolver,
 		tmpl: map[templateID]*template.Template{
 			invitationEmail: template.Must(template.New("").Parse(email.BaseTmpl + invitationEmailTmpl)),
 		},
+		textTmpl: map[templateID]*texttemplate.Template{
+			invitationEmail: texttemplate.Must(texttemplate.New("").Parse(invitationEmailTextTmpl)),
+		},
+	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m
+}
+
+// WithTXTResolver allows providing a specific TXTResolver implementation for
+// a Manager instance.
+func WithTXTResolver(rs hub.TXTResolver) func(m *Manager) {
+	return func(m *Manager) {
+		m.rs = rs
 	}
 }
 
@@ -82,6 +166,7 @@ func (m *Manager) Add(ctx context.Context, org *hub.Organization) error {
 	}
 
 	// Add org to database
+	org.ProfileHTML = renderProfile(org.Profile)
 	orgJSON, _ := json.Marshal(org)
 	_, err := m.db.Exec(ctx, addOrgDBQ, userID, orgJSON)
 	return err
@@ -111,46 +196,112 @@ func (m *Manager) AddMember(ctx context.Context, orgName, userAlias string) erro
 		return err
 	}
 
-	// Add organization member to database
-	if _, err := m.db.Exec(ctx, addOrgMemberDBQ, userID, orgName, userAlias); err != nil {
-		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
-			return hub.ErrInsufficientPrivilege
-		}
+	// Add organization member and send invitation email
+	if err := m.addMember(ctx, userID, orgName, userAlias); err != nil {
 		return err
 	}
+	return m.sendInvitationEmail(ctx, orgName, userAlias)
+}
 
-	// Send organization invitation email
-	if m.es != nil {
-		var userEmail string
-		if err := m.db.QueryRow(ctx, getUserEmailDBQ, userAlias).Scan(&userEmail); err != nil {
-			return err
-		}
-		baseURL := m.cfg.GetString("server.baseURL")
-		templateData := map[string]interface{}{
-			"BaseURL": baseURL,
-			"Link":    fmt.Sprintf("%s/accept-invitation?org=%s", baseURL, orgName),
-			"OrgName": orgName,
-			"Theme": map[string]string{
-				"PrimaryColor":   m.cfg.GetString("theme.colors.primary"),
-				"SecondaryColor": m.cfg.GetString("theme.colors.secondary"),
-				"SiteName":       m.cfg.GetString("theme.siteName"),
-			},
+// AddMembers invites the users identified by the emails provided to join the
+// organization. A result is returned for each of the emails provided. Emails
+// that do not belong to a registered user are reported as pending, like the
+// ones invited, and failures sending the invitation emails are only logged, so
+// that the results do not reveal which emails belong to a registered user.
+func (m *Manager) AddMembers(
+	ctx context.Context,
+	orgName string,
+	emails []string,
+) ([]*hub.OrganizationInvitationResult, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "emails not provided")
+	}
+	if len(emails) > maxInvitationsPerRequest {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "too many emails provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.AddOrganizationMember,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Add organization members and send invitation emails
+	results := make([]*hub.OrganizationInvitationResult, 0, len(emails))
+	for _, userEmail := range emails {
+		result := &hub.OrganizationInvitationResult{
+			Email:  userEmail,
+			Status: hub.InvitationStatusPending,
 		}
-		var emailBody bytes.Buffer
-		if err := m.tmpl[invitationEmail].Execute(&emailBody, templateData); err != nil {
-			return err
+		results = append(results, result)
+		var userAlias string
+		if err := m.db.QueryRow(ctx, getUserAliasByEmailDBQ, userEmail).Scan(&userAlias); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return nil, err
 		}
-		emailData := &email.Data{
-			To:      userEmail,
-			Subject: fmt.Sprintf("Invitation to join %s on Artifact Hub", orgName),
-			Body:    emailBody.Bytes(),
+		if err := m.addMember(ctx, userID, orgName, userAlias); err != nil {
+			if err.Error() == errAlreadyMemberDB.Error() {
+				result.Status = hub.InvitationStatusAlreadyMember
+				continue
+			}
+			return nil, err
 		}
-		if err := m.es.SendEmail(emailData); err != nil {
-			return err
+		if err := m.sendInvitationEmail(ctx, orgName, userAlias); err != nil {
+			log.Error().Err(err).Str("org", orgName).Str("user", userAlias).Msg("error sending invitation email")
 		}
 	}
 
-	return nil
+	return results, nil
+}
+
+// addMember adds a new member to the provided organization in the database.
+func (m *Manager) addMember(ctx context.Context, userID, orgName, userAlias string) error {
+	_, err := m.db.Exec(ctx, addOrgMemberDBQ, userID, orgName, userAlias, m.invitationTTL())
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// CancelInvitation cancels a pending invitation to join the provided
+// organization.
+func (m *Manager) CancelInvitation(ctx context.Context, orgName, userAlias string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.DeleteOrganizationMember,
+	}); err != nil {
+		return err
+	}
+
+	// Cancel invitation in database
+	_, err := m.db.Exec(ctx, cancelInvitationDBQ, userID, orgName, userAlias)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
 }
 
 // CheckAvailability checks the availability of a given value for the provided
@@ -203,14 +354,31 @@ func (m *Manager) ConfirmMembership(ctx context.Context, orgName string) error {
 	return err
 }
 
-// Delete deletes the provided organization from the database.
-func (m *Manager) Delete(ctx context.Context, orgName string) error {
+// Delete deletes the provided organization from the database. The
+// organization name must be provided again as confirmation. Repositories
+// owned by the organization are deleted or transferred as requested.
+func (m *Manager) Delete(ctx context.Context, orgName string, input *hub.DeleteOrganizationInput) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
 
 	// Validate input
 	if orgName == "" {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
 	}
+	if input == nil || input.Confirmation != orgName {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "confirmation does not match organization name")
+	}
+	switch input.RepositoriesAction {
+	case "", hub.DeleteRepositories:
+		if input.TransferToOrg != "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "target organization only allowed when transferring repositories")
+		}
+	case hub.TransferRepositories:
+		if input.TransferToOrg == orgName {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "target organization must be a different one")
+		}
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repositories action")
+	}
 
 	// Authorize action
 	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
@@ -222,9 +390,17 @@ func (m *Manager) Delete(ctx context.Context, orgName string) error {
 	}
 
 	// Delete organization from database
-	_, err := m.db.Exec(ctx, deleteOrgDBQ, userID, orgName)
-	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
-		return hub.ErrInsufficientPrivilege
+	inputJSON, _ := json.Marshal(input)
+	_, err := m.db.Exec(ctx, deleteOrgDBQ, userID, orgName, inputJSON)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case errOrgHasRepositoriesDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization has repositories, they must be deleted or transferred")
+		case errTargetOrgNotFoundDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "target organization not found")
+		}
 	}
 	return err
 }
@@ -255,6 +431,9 @@ func (m *Manager) DeleteMember(ctx context.Context, orgName, userAlias string) e
 		}); err != nil {
 			return err
 		}
+		if err := m.checkOwnersManagement(ctx, userID, orgName, userAlias, ""); err != nil {
+			return err
+		}
 	}
 
 	// Delete organization member from database
@@ -295,6 +474,38 @@ func (m *Manager) GetByUserJSON(ctx context.Context, p *hub.Pagination) (*hub.JS
 	return util.DBQueryJSONWithPagination(ctx, m.db, getUserOrgsDBQ, userID, p.Limit, p.Offset)
 }
 
+// GetIdPGroupsJSON returns the identity provider groups mapped to the provided
+// organization as a json array.
+func (m *Manager) GetIdPGroupsJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get organization identity provider groups from database
+	return util.DBQueryJSON(ctx, m.db, getOrgIdPGroupsDBQ, userID, orgName)
+}
+
+// GetInvitationsJSON returns the pending invitations to join the provided
+// organization as a json object.
+func (m *Manager) GetInvitationsJSON(
+	ctx context.Context,
+	orgName string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get organization invitations from database
+	return util.DBQueryJSONWithPagination(ctx, m.db, getOrgInvitationsDBQ, userID, orgName, p.Limit, p.Offset)
+}
+
 // GetJSON returns the organization requested as a json object.
 func (m *Manager) GetJSON(ctx context.Context, orgName string) ([]byte, error) {
 	// Validate input
@@ -306,6 +517,20 @@ func (m *Manager) GetJSON(ctx context.Context, orgName string) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getOrgDBQ, orgName)
 }
 
+// GetLinksJSON returns the links of the provided organization, including the
+// tokens needed to verify them, as a json array.
+func (m *Manager) GetLinksJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get organization links from database
+	return util.DBQueryJSON(ctx, m.db, getOrgLinksDBQ, userID, orgName)
+}
+
 // GetMembersJSON returns the members of the provided organization as a json
 // object.
 func (m *Manager) GetMembersJSON(
@@ -324,6 +549,59 @@ func (m *Manager) GetMembersJSON(
 	return util.DBQueryJSONWithPagination(ctx, m.db, getOrgMembersDBQ, userID, orgName, p.Limit, p.Offset)
 }
 
+// GetQuotasJSON returns the quotas that apply to the provided organization
+// along with its current usage as a json object.
+func (m *Manager) GetQuotasJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get organization quotas and usage
+	qu, err := GetQuotasUsage(ctx, m.db, m.cfg, userID, orgName)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(qu)
+}
+
+// ResendInvitation renews a pending invitation to join the provided
+// organization and sends the invitation email again.
+func (m *Manager) ResendInvitation(ctx context.Context, orgName, userAlias string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.AddOrganizationMember,
+	}); err != nil {
+		return err
+	}
+
+	// Renew invitation in database
+	_, err := m.db.Exec(ctx, resendInvitationDBQ, userID, orgName, userAlias, m.invitationTTL())
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return hub.ErrInsufficientPrivilege
+		}
+		return err
+	}
+
+	// Send organization invitation email
+	return m.sendInvitationEmail(ctx, orgName, userAlias)
+}
+
 // Update updates the provided organization in the database.
 func (m *Manager) Update(ctx context.Context, orgName string, org *hub.Organization) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -343,6 +621,7 @@ func (m *Manager) Update(ctx context.Context, orgName string, org *hub.Organizat
 	}
 
 	// Update organization in database
+	org.ProfileHTML = renderProfile(org.Profile)
 	orgJSON, _ := json.Marshal(org)
 	_, err := m.db.Exec(ctx, updateOrgDBQ, userID, orgName, orgJSON)
 	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
@@ -418,6 +697,263 @@ func (m *Manager) UpdateAuthorizationPolicy(
 	return err
 }
 
+// UpdateIdPGroups updates the identity provider groups mapped to the provided
+// organization. Users logging in with a group listed will automatically join
+// the organization with the role configured.
+func (m *Manager) UpdateIdPGroups(ctx context.Context, orgName string, groups []*hub.IdPGroupMapping) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	seen := make(map[string]struct{}, len(groups))
+	for _, g := range groups {
+		if g == nil || g.Group == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "group not provided")
+		}
+		if !g.Role.IsValid() {
+			return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid role", g.Role)
+		}
+		if _, ok := seen[g.Group]; ok {
+			return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "duplicated group", g.Group)
+		}
+		seen[g.Group] = struct{}{}
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateOrganizationMemberRole,
+	}); err != nil {
+		return err
+	}
+
+	// Update organization identity provider groups in database
+	if groups == nil {
+		groups = []*hub.IdPGroupMapping{}
+	}
+	groupsJSON, _ := json.Marshal(groups)
+	_, err := m.db.Exec(ctx, updateOrgIdPGroupsDBQ, userID, orgName, groupsJSON)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// UpdateLinks updates the links published in the profile of the provided
+// organization. Links already registered keep their verification status.
+func (m *Manager) UpdateLinks(ctx context.Context, orgName string, links []*hub.OrganizationLink) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if len(links) > maxLinks {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "too many links provided")
+	}
+	seen := make(map[string]struct{}, len(links))
+	for _, l := range links {
+		if l == nil || l.URL == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "link url not provided")
+		}
+		if _, err := linkHost(l.URL); err != nil {
+			return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid link url", l.URL)
+		}
+		if _, ok := seen[l.URL]; ok {
+			return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "duplicated link url", l.URL)
+		}
+		seen[l.URL] = struct{}{}
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateOrganization,
+	}); err != nil {
+		return err
+	}
+
+	// Update organization links in database
+	if links == nil {
+		links = []*hub.OrganizationLink{}
+	}
+	linksJSON, _ := json.Marshal(links)
+	_, err := m.db.Exec(ctx, updateOrgLinksDBQ, userID, orgName, linksJSON)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// UpdateMemberRole updates the role of a member of the provided organization.
+func (m *Manager) UpdateMemberRole(
+	ctx context.Context,
+	orgName string,
+	userAlias string,
+	role hub.OrganizationRole,
+) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+	if !role.IsValid() {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid role")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateOrganizationMemberRole,
+	}); err != nil {
+		return err
+	}
+	if err := m.checkOwnersManagement(ctx, userID, orgName, userAlias, role); err != nil {
+		return err
+	}
+
+	// Update organization member role in database
+	_, err := m.db.Exec(ctx, updateOrgMemberRoleDBQ, userID, orgName, userAlias, string(role))
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// checkOwnersManagement checks that the user doing the request is an owner of
+// the organization when the member affected by the action is an owner or is
+// about to become one. Only owners are allowed to manage other owners.
+func (m *Manager) checkOwnersManagement(
+	ctx context.Context,
+	userID string,
+	orgName string,
+	userAlias string,
+	newRole hub.OrganizationRole,
+) error {
+	var requestingUserRole, memberRole string
+	err := m.db.QueryRow(ctx, getMembersRolesDBQ, userID, orgName, userAlias).Scan(&requestingUserRole, &memberRole)
+	if err != nil {
+		return err
+	}
+	owner := string(hub.OrganizationOwner)
+	if (memberRole == owner || newRole == hub.OrganizationOwner) && requestingUserRole != owner {
+		return hub.ErrInsufficientPrivilege
+	}
+	return nil
+}
+
+// VerifyLinks checks if the domains of the organization links not verified
+// yet have a DNS TXT record with the corresponding verification token, marking
+// as verified the ones that do.
+func (m *Manager) VerifyLinks(ctx context.Context, orgName string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateOrganization,
+	}); err != nil {
+		return err
+	}
+
+	// Get organization links from database
+	var links []*hub.OrganizationLink
+	if err := util.DBQueryUnmarshal(ctx, m.db, &links, getOrgLinksDBQ, userID, orgName); err != nil {
+		return err
+	}
+
+	// Check verification records of the links not verified yet
+	verified := make([]string, 0, len(links))
+	for _, l := range links {
+		if l.Verified {
+			continue
+		}
+		host, err := linkHost(l.URL)
+		if err != nil {
+			continue
+		}
+		records, err := m.rs.LookupTXT(ctx, host)
+		if err != nil {
+			continue
+		}
+		for _, record := range records {
+			if record == linkVerificationPrefix+l.VerificationToken {
+				verified = append(verified, l.URL)
+				break
+			}
+		}
+	}
+	if len(verified) == 0 {
+		return nil
+	}
+
+	// Mark verified links in database
+	_, err := m.db.Exec(ctx, verifyOrgLinksDBQ, userID, orgName, verified)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// invitationTTL returns the period of time, in seconds, during which an
+// invitation to join an organization is valid.
+func (m *Manager) invitationTTL() int {
+	ttl := m.cfg.GetDuration("orgs.invitationTTL")
+	if ttl == 0 {
+		ttl = defaultInvitationTTL
+	}
+	return int(ttl.Seconds())
+}
+
+// sendInvitationEmail sends an email to the user provided with an invitation
+// to join the organization.
+func (m *Manager) sendInvitationEmail(ctx context.Context, orgName, userAlias string) error {
+	if m.es == nil {
+		return nil
+	}
+	var userEmail string
+	if err := m.db.QueryRow(ctx, getUserEmailDBQ, userAlias).Scan(&userEmail); err != nil {
+		return err
+	}
+	baseURL := m.cfg.GetString("server.baseURL")
+	templateData := map[string]interface{}{
+		"BaseURL": baseURL,
+		"Link":    fmt.Sprintf("%s/accept-invitation?org=%s", baseURL, orgName),
+		"OrgName": orgName,
+		"Theme": map[string]string{
+			"PrimaryColor":   m.cfg.GetString("theme.colors.primary"),
+			"SecondaryColor": m.cfg.GetString("theme.colors.secondary"),
+			"SiteName":       m.cfg.GetString("theme.siteName"),
+		},
+	}
+	emailBody, emailText, err := email.Render(m.tmpl[invitationEmail], m.textTmpl[invitationEmail], templateData)
+	if err != nil {
+		return err
+	}
+	emailData := &email.Data{
+		To:      userEmail,
+		Subject: fmt.Sprintf("Invitation to join %s on Artifact Hub", orgName),
+		Body:    emailBody,
+		Text:    emailText,
+	}
+	return m.es.SendEmail(emailData)
+}
+
 // validateOrg checks if the organization provided is valid.
 func validateOrg(org *hub.Organization) error {
 	if org.Name == "" {
@@ -431,5 +967,38 @@ func validateOrg(org *hub.Organization) error {
 			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid logo image id")
 		}
 	}
+	if len(org.Profile) > maxProfileLength {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "profile too long")
+	}
 	return nil
 }
+
+// linkHost returns the host of the link url provided, which must be an
+// absolute http or https url.
+func linkHost(linkURL string) (string, error) {
+	u, err := url.Parse(linkURL)
+	if err != nil {
+		return "", err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return "", errors.New("invalid url")
+	}
+	return u.Hostname(), nil
+}
+
+// renderProfile renders the markdown profile provided as html. Raw html in the
+// markdown source is discarded and only safe links are rendered.
+func renderProfile(profile string) string {
+	if profile == "" {
+		return ""
+	}
+	renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.SkipHTML |
+			blackfriday.Safelink |
+			blackfriday.NofollowLinks |
+			blackfriday.NoreferrerLinks |
+			blackfriday.NoopenerLinks |
+			blackfriday.HrefTargetBlank,
+	})
+	return string(blackfriday.Run([]byte(profile), blackfriday.WithRenderer(renderer)))
+}
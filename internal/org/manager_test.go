@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/artifacthub/hub/internal/authz"
@@ -11,12 +12,16 @@ import (
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-var cfg = viper.New()
+var (
+	cfg           = viper.New()
+	invitationTTL = int(defaultInvitationTTL.Seconds())
+)
 
 func TestAdd(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
@@ -169,7 +174,7 @@ func TestAddMember(t *testing.T) {
 			t.Run(tc.description, func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("Exec", ctx, addOrgMemberDBQ, "userID", "orgName", "userAlias").Return(nil)
+				db.On("Exec", ctx, addOrgMemberDBQ, "userID", "orgName", "userAlias", invitationTTL).Return(nil)
 				db.On("QueryRow", ctx, getUserEmailDBQ, mock.Anything).Return("email", nil)
 				es := &email.SenderMock{}
 				es.On("SendEmail", mock.Anything).Return(tc.emailSenderResponse)
@@ -209,7 +214,7 @@ func TestAddMember(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("Exec", ctx, addOrgMemberDBQ, "userID", "orgName", "userAlias").Return(tc.dbErr)
+				db.On("Exec", ctx, addOrgMemberDBQ, "userID", "orgName", "userAlias", invitationTTL).Return(tc.dbErr)
 				az := &authz.AuthorizerMock{}
 				az.On("Authorize", ctx, &hub.AuthorizeInput{
 					OrganizationName: "orgName",
@@ -227,24 +232,38 @@ func TestAddMember(t *testing.T) {
 	})
 }
 
-func TestCheckAvailability(t *testing.T) {
-	ctx := context.Background()
+func TestAddMembers(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.AddMembers(context.Background(), "orgName", []string{"user1@email.com"})
+		})
+	})
 
 	t.Run("invalid input", func(t *testing.T) {
+		tooManyEmails := make([]string, maxInvitationsPerRequest+1)
 		testCases := []struct {
-			errMsg       string
-			resourceKind string
-			value        string
+			errMsg  string
+			orgName string
+			emails  []string
 		}{
 			{
-				"invalid resource kind",
-				"invalid",
-				"value",
+				"organization name not provided",
+				"",
+				[]string{"user1@email.com"},
 			},
 			{
-				"invalid value",
-				"organizationName",
-				"",
+				"emails not provided",
+				"org1",
+				nil,
+			},
+			{
+				"too many emails provided",
+				"org1",
+				tooManyEmails,
 			},
 		}
 		for _, tc := range testCases {
@@ -252,175 +271,142 @@ func TestCheckAvailability(t *testing.T) {
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
 				m := NewManager(cfg, nil, nil, nil)
-				_, err := m.CheckAvailability(context.Background(), tc.resourceKind, tc.value)
+				_, err := m.AddMembers(ctx, tc.orgName, tc.emails)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
 			})
 		}
 	})
 
-	t.Run("database query succeeded", func(t *testing.T) {
-		testCases := []struct {
-			resourceKind string
-			dbQuery      string
-			available    bool
-		}{
-			{
-				"organizationName",
-				checkOrgNameAvailDBQ,
-				true,
-			},
-		}
-		for _, tc := range testCases {
-			tc := tc
-			t.Run(fmt.Sprintf("resource kind: %s", tc.resourceKind), func(t *testing.T) {
-				t.Parallel()
-				tc.dbQuery = fmt.Sprintf("select not exists (%s)", tc.dbQuery)
-				db := &tests.DBMock{}
-				db.On("QueryRow", ctx, tc.dbQuery, "value").Return(tc.available, nil)
-				m := NewManager(cfg, db, nil, nil)
-
-				available, err := m.CheckAvailability(ctx, tc.resourceKind, "value")
-				assert.NoError(t, err)
-				assert.Equal(t, tc.available, available)
-				db.AssertExpectations(t)
-			})
-		}
-	})
-
-	t.Run("database error", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		dbQuery := fmt.Sprintf(`select not exists (%s)`, checkOrgNameAvailDBQ)
-		db.On("QueryRow", ctx, dbQuery, "value").Return(false, tests.ErrFakeDB)
-		m := NewManager(cfg, db, nil, nil)
-
-		available, err := m.CheckAvailability(context.Background(), "organizationName", "value")
-		assert.Equal(t, tests.ErrFakeDB, err)
-		assert.False(t, available)
-		db.AssertExpectations(t)
-	})
-}
-
-func TestConfirmMembership(t *testing.T) {
-	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
-
-	t.Run("user id not found in ctx", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(cfg, nil, nil, nil)
-		assert.Panics(t, func() {
-			_ = m.ConfirmMembership(context.Background(), "orgName")
-		})
-	})
-
-	t.Run("invalid input", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(cfg, nil, nil, nil)
-		err := m.ConfirmMembership(ctx, "")
-		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
-	})
-
-	t.Run("database query succeeded", func(t *testing.T) {
+	t.Run("authorization failed", func(t *testing.T) {
 		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("Exec", ctx, confirmMembershipDBQ, "userID", "orgName").Return(nil)
-		m := NewManager(cfg, db, nil, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationMember,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
 
-		err := m.ConfirmMembership(ctx, "orgName")
-		assert.NoError(t, err)
-		db.AssertExpectations(t)
+		_, err := m.AddMembers(ctx, "orgName", []string{"user1@email.com"})
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
 	})
 
-	t.Run("database error", func(t *testing.T) {
+	t.Run("error getting user alias", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, confirmMembershipDBQ, "userID", "orgName").Return(tests.ErrFakeDB)
-		m := NewManager(cfg, db, nil, nil)
+		db.On("QueryRow", ctx, getUserAliasByEmailDBQ, "user1@email.com").Return(nil, tests.ErrFakeDB)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationMember,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
 
-		err := m.ConfirmMembership(ctx, "orgName")
+		results, err := m.AddMembers(ctx, "orgName", []string{"user1@email.com"})
 		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, results)
 		db.AssertExpectations(t)
-	})
-}
-
-func TestDelete(t *testing.T) {
-	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
-
-	t.Run("user id not found in ctx", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(cfg, nil, nil, nil)
-		assert.Panics(t, func() {
-			_ = m.Delete(context.Background(), "org1")
-		})
-	})
-
-	t.Run("invalid input", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(cfg, nil, nil, nil)
-		err := m.Delete(ctx, "")
-		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
-		assert.Contains(t, err.Error(), "name not provided")
+		az.AssertExpectations(t)
 	})
 
-	t.Run("authorization failed", func(t *testing.T) {
+	t.Run("error adding member with insufficient privileges", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAliasByEmailDBQ, "user1@email.com").Return("user1", nil)
+		db.On("Exec", ctx, addOrgMemberDBQ, "userID", "orgName", "user1", invitationTTL).
+			Return(util.ErrDBInsufficientPrivilege)
 		az := &authz.AuthorizerMock{}
 		az.On("Authorize", ctx, &hub.AuthorizeInput{
-			OrganizationName: "org1",
+			OrganizationName: "orgName",
 			UserID:           "userID",
-			Action:           hub.DeleteOrganization,
-		}).Return(tests.ErrFake)
+			Action:           hub.AddOrganizationMember,
+		}).Return(nil)
 		m := NewManager(cfg, db, nil, az)
 
-		err := m.Delete(ctx, "org1")
-		assert.Equal(t, tests.ErrFake, err)
+		results, err := m.AddMembers(ctx, "orgName", []string{"user1@email.com"})
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		assert.Nil(t, results)
+		db.AssertExpectations(t)
 		az.AssertExpectations(t)
 	})
 
-	t.Run("database query succeeded", func(t *testing.T) {
+	t.Run("error adding member", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, deleteOrgDBQ, "userID", "org1").Return(nil)
+		db.On("QueryRow", ctx, getUserAliasByEmailDBQ, "user1@email.com").Return("user1", nil)
+		db.On("Exec", ctx, addOrgMemberDBQ, "userID", "orgName", "user1", invitationTTL).Return(tests.ErrFakeDB)
 		az := &authz.AuthorizerMock{}
 		az.On("Authorize", ctx, &hub.AuthorizeInput{
-			OrganizationName: "org1",
+			OrganizationName: "orgName",
 			UserID:           "userID",
-			Action:           hub.DeleteOrganization,
+			Action:           hub.AddOrganizationMember,
 		}).Return(nil)
 		m := NewManager(cfg, db, nil, az)
 
-		err := m.Delete(ctx, "org1")
-		assert.NoError(t, err)
+		results, err := m.AddMembers(ctx, "orgName", []string{"user1@email.com"})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, results)
 		db.AssertExpectations(t)
+		az.AssertExpectations(t)
 	})
 
-	t.Run("database error", func(t *testing.T) {
+	t.Run("members invited", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, deleteOrgDBQ, "userID", "org1").Return(tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getUserAliasByEmailDBQ, "user1@email.com").Return("user1", nil)
+		db.On("QueryRow", ctx, getUserAliasByEmailDBQ, "user2@email.com").Return(nil, pgx.ErrNoRows)
+		db.On("QueryRow", ctx, getUserAliasByEmailDBQ, "user3@email.com").Return("user3", nil)
+		db.On("QueryRow", ctx, getUserAliasByEmailDBQ, "user4@email.com").Return("user4", nil)
+		db.On("Exec", ctx, addOrgMemberDBQ, "userID", "orgName", "user1", invitationTTL).Return(nil)
+		db.On("Exec", ctx, addOrgMemberDBQ, "userID", "orgName", "user3", invitationTTL).Return(errAlreadyMemberDB)
+		db.On("Exec", ctx, addOrgMemberDBQ, "userID", "orgName", "user4", invitationTTL).Return(nil)
+		db.On("QueryRow", ctx, getUserEmailDBQ, "user1").Return("user1@email.com", nil)
+		db.On("QueryRow", ctx, getUserEmailDBQ, "user4").Return("user4@email.com", nil)
+		es := &email.SenderMock{}
+		es.On("SendEmail", mock.MatchedBy(func(d *email.Data) bool {
+			return d.To == "user1@email.com"
+		})).Return(nil)
+		es.On("SendEmail", mock.MatchedBy(func(d *email.Data) bool {
+			return d.To == "user4@email.com"
+		})).Return(email.ErrFakeSenderFailure)
 		az := &authz.AuthorizerMock{}
 		az.On("Authorize", ctx, &hub.AuthorizeInput{
-			OrganizationName: "org1",
+			OrganizationName: "orgName",
 			UserID:           "userID",
-			Action:           hub.DeleteOrganization,
+			Action:           hub.AddOrganizationMember,
 		}).Return(nil)
-		m := NewManager(cfg, db, nil, az)
+		m := NewManager(cfg, db, es, az)
 
-		err := m.Delete(ctx, "org1")
-		assert.Equal(t, tests.ErrFakeDB, err)
+		results, err := m.AddMembers(ctx, "orgName", []string{
+			"user1@email.com",
+			"user2@email.com",
+			"user3@email.com",
+			"user4@email.com",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.OrganizationInvitationResult{
+			{Email: "user1@email.com", Status: hub.InvitationStatusPending},
+			{Email: "user2@email.com", Status: hub.InvitationStatusPending},
+			{Email: "user3@email.com", Status: hub.InvitationStatusAlreadyMember},
+			{Email: "user4@email.com", Status: hub.InvitationStatusPending},
+		}, results)
 		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+		az.AssertExpectations(t)
 	})
 }
 
-func TestDeleteMember(t *testing.T) {
+func TestCancelInvitation(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
 		assert.Panics(t, func() {
-			_ = m.DeleteMember(context.Background(), "orgName", "userAlias")
+			_ = m.CancelInvitation(context.Background(), "orgName", "userAlias")
 		})
 	})
 
@@ -446,46 +432,32 @@ func TestDeleteMember(t *testing.T) {
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
 				m := NewManager(cfg, nil, nil, nil)
-				err := m.DeleteMember(ctx, tc.orgName, tc.userAlias)
+				err := m.CancelInvitation(ctx, tc.orgName, tc.userAlias)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
 			})
 		}
 	})
 
-	t.Run("get requesting user alias failed", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("", tests.ErrFakeDB)
-		m := NewManager(cfg, db, nil, nil)
-
-		err := m.DeleteMember(ctx, "orgName", "userAlias")
-		assert.Error(t, err)
-		db.AssertExpectations(t)
-	})
-
 	t.Run("authorization failed", func(t *testing.T) {
 		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
 		az := &authz.AuthorizerMock{}
 		az.On("Authorize", ctx, &hub.AuthorizeInput{
 			OrganizationName: "orgName",
 			UserID:           "userID",
 			Action:           hub.DeleteOrganizationMember,
 		}).Return(tests.ErrFake)
-		m := NewManager(cfg, db, nil, az)
+		m := NewManager(cfg, nil, nil, az)
 
-		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		err := m.CancelInvitation(ctx, "orgName", "userAlias")
 		assert.Equal(t, tests.ErrFake, err)
 		az.AssertExpectations(t)
 	})
 
-	t.Run("member deleted successfully", func(t *testing.T) {
+	t.Run("invitation cancelled successfully", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
-		db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(nil)
+		db.On("Exec", ctx, cancelInvitationDBQ, "userID", "orgName", "userAlias").Return(nil)
 		az := &authz.AuthorizerMock{}
 		az.On("Authorize", ctx, &hub.AuthorizeInput{
 			OrganizationName: "orgName",
@@ -494,25 +466,13 @@ func TestDeleteMember(t *testing.T) {
 		}).Return(nil)
 		m := NewManager(cfg, db, nil, az)
 
-		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		err := m.CancelInvitation(ctx, "orgName", "userAlias")
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 		az.AssertExpectations(t)
 	})
 
-	t.Run("user left organization successfully", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("userAlias", nil)
-		db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(nil)
-		m := NewManager(cfg, db, nil, nil)
-
-		err := m.DeleteMember(ctx, "orgName", "userAlias")
-		assert.NoError(t, err)
-		db.AssertExpectations(t)
-	})
-
-	t.Run("error deleting member", func(t *testing.T) {
+	t.Run("database error", func(t *testing.T) {
 		testCases := []struct {
 			dbErr         error
 			expectedError error
@@ -531,8 +491,7 @@ func TestDeleteMember(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
-				db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(tc.dbErr)
+				db.On("Exec", ctx, cancelInvitationDBQ, "userID", "orgName", "userAlias").Return(tc.dbErr)
 				az := &authz.AuthorizerMock{}
 				az.On("Authorize", ctx, &hub.AuthorizeInput{
 					OrganizationName: "orgName",
@@ -541,7 +500,7 @@ func TestDeleteMember(t *testing.T) {
 				}).Return(nil)
 				m := NewManager(cfg, db, nil, az)
 
-				err := m.DeleteMember(ctx, "orgName", "userAlias")
+				err := m.CancelInvitation(ctx, "orgName", "userAlias")
 				assert.Equal(t, tc.expectedError, err)
 				db.AssertExpectations(t)
 				az.AssertExpectations(t)
@@ -550,55 +509,1353 @@ func TestDeleteMember(t *testing.T) {
 	})
 }
 
-func TestGetAuthorizationPolicyJSON(t *testing.T) {
-	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
-
-	t.Run("user id not found in ctx", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(cfg, nil, nil, nil)
-		assert.Panics(t, func() {
-			_, _ = m.GetAuthorizationPolicyJSON(context.Background(), "org1")
-		})
-	})
+func TestCheckAvailability(t *testing.T) {
+	ctx := context.Background()
 
 	t.Run("invalid input", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(cfg, nil, nil, nil)
-		_, err := m.GetAuthorizationPolicyJSON(ctx, "")
-		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		testCases := []struct {
+			errMsg       string
+			resourceKind string
+			value        string
+		}{
+			{
+				"invalid resource kind",
+				"invalid",
+				"value",
+			},
+			{
+				"invalid value",
+				"organizationName",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				_, err := m.CheckAvailability(context.Background(), tc.resourceKind, tc.value)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
 	})
 
-	t.Run("authorization failed", func(t *testing.T) {
-		t.Parallel()
-		az := &authz.AuthorizerMock{}
-		az.On("Authorize", ctx, &hub.AuthorizeInput{
-			OrganizationName: "org1",
-			UserID:           "userID",
-			Action:           hub.GetAuthorizationPolicy,
-		}).Return(tests.ErrFake)
-		m := NewManager(cfg, nil, nil, az)
-
+	t.Run("database query succeeded", func(t *testing.T) {
+		testCases := []struct {
+			resourceKind string
+			dbQuery      string
+			available    bool
+		}{
+			{
+				"organizationName",
+				checkOrgNameAvailDBQ,
+				true,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(fmt.Sprintf("resource kind: %s", tc.resourceKind), func(t *testing.T) {
+				t.Parallel()
+				tc.dbQuery = fmt.Sprintf("select not exists (%s)", tc.dbQuery)
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, tc.dbQuery, "value").Return(tc.available, nil)
+				m := NewManager(cfg, db, nil, nil)
+
+				available, err := m.CheckAvailability(ctx, tc.resourceKind, "value")
+				assert.NoError(t, err)
+				assert.Equal(t, tc.available, available)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		dbQuery := fmt.Sprintf(`select not exists (%s)`, checkOrgNameAvailDBQ)
+		db.On("QueryRow", ctx, dbQuery, "value").Return(false, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		available, err := m.CheckAvailability(context.Background(), "organizationName", "value")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.False(t, available)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestConfirmMembership(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.ConfirmMembership(context.Background(), "orgName")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		err := m.ConfirmMembership(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, confirmMembershipDBQ, "userID", "orgName").Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.ConfirmMembership(ctx, "orgName")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, confirmMembershipDBQ, "userID", "orgName").Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.ConfirmMembership(ctx, "orgName")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	input := &hub.DeleteOrganizationInput{
+		Confirmation:       "org1",
+		RepositoriesAction: hub.TransferRepositories,
+		TransferToOrg:      "org2",
+	}
+	inputJSON := []byte(`{"confirmation":"org1","repositories_action":"transfer","transfer_to_org":"org2"}`)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.Delete(context.Background(), "org1", input)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			input   *hub.DeleteOrganizationInput
+		}{
+			{
+				"name not provided",
+				"",
+				input,
+			},
+			{
+				"confirmation does not match organization name",
+				"org1",
+				nil,
+			},
+			{
+				"confirmation does not match organization name",
+				"org1",
+				&hub.DeleteOrganizationInput{Confirmation: "org2"},
+			},
+			{
+				"invalid repositories action",
+				"org1",
+				&hub.DeleteOrganizationInput{Confirmation: "org1", RepositoriesAction: "invalid"},
+			},
+			{
+				"target organization only allowed when transferring repositories",
+				"org1",
+				&hub.DeleteOrganizationInput{
+					Confirmation:       "org1",
+					RepositoriesAction: hub.DeleteRepositories,
+					TransferToOrg:      "org2",
+				},
+			},
+			{
+				"target organization must be a different one",
+				"org1",
+				&hub.DeleteOrganizationInput{
+					Confirmation:       "org1",
+					RepositoriesAction: hub.TransferRepositories,
+					TransferToOrg:      "org1",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.Delete(ctx, tc.orgName, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganization,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.Delete(ctx, "org1", input)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteOrgDBQ, "userID", "org1", inputJSON).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganization,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.Delete(ctx, "org1", input)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errOrgHasRepositoriesDB,
+				hub.ErrInvalidInput,
+			},
+			{
+				errTargetOrgNotFoundDB,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, deleteOrgDBQ, "userID", "org1", inputJSON).Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.DeleteOrganization,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				err := m.Delete(ctx, "org1", input)
+				assert.True(t, errors.Is(err, tc.expectedError))
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestDeleteMember(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.DeleteMember(context.Background(), "orgName", "userAlias")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			orgName   string
+			userAlias string
+		}{
+			{
+				"organization name not provided",
+				"",
+				"user1",
+			},
+			{
+				"user alias not provided",
+				"org1",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.DeleteMember(ctx, tc.orgName, tc.userAlias)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("get requesting user alias failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("", tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		assert.Error(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationMember,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("get members roles failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
+		db.On("QueryRow", ctx, getMembersRolesDBQ, "userID", "orgName", "userAlias").Return(nil, tests.ErrFakeDB)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationMember,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("non owners cannot remove owners", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
+		db.On("QueryRow", ctx, getMembersRolesDBQ, "userID", "orgName", "userAlias").
+			Return([]interface{}{"admin", "owner"}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationMember,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("member deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
+		db.On("QueryRow", ctx, getMembersRolesDBQ, "userID", "orgName", "userAlias").
+			Return([]interface{}{"admin", "maintainer"}, nil)
+		db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationMember,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("user left organization successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("userAlias", nil)
+		db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error deleting member", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
+				db.On("QueryRow", ctx, getMembersRolesDBQ, "userID", "orgName", "userAlias").
+					Return([]interface{}{"admin", "maintainer"}, nil)
+				db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "orgName",
+					UserID:           "userID",
+					Action:           hub.DeleteOrganizationMember,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				err := m.DeleteMember(ctx, "orgName", "userAlias")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetAuthorizationPolicyJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetAuthorizationPolicyJSON(context.Background(), "org1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetAuthorizationPolicyJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAuthorizationPolicy,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		dataJSON, err := m.GetAuthorizationPolicyJSON(ctx, "org1")
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, dataJSON)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAuthzPolicyDBQ, "userID", "org1").Return([]byte("dataJSON"), nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAuthorizationPolicy,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
 		dataJSON, err := m.GetAuthorizationPolicyJSON(ctx, "org1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getAuthzPolicyDBQ, "userID", "org1").Return(nil, tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.GetAuthorizationPolicy,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				dataJSON, err := m.GetAuthorizationPolicyJSON(ctx, "org1")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetByUserJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetByUserJSON(context.Background(), p)
+		})
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserOrgsDBQ, "userID", 10, 1).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		result, err := m.GetByUserJSON(ctx, p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserOrgsDBQ, "userID", 10, 1).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetByUserJSON(ctx, p)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetIdPGroupsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetIdPGroupsJSON(context.Background(), "orgName")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetIdPGroupsJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgIdPGroupsDBQ, "userID", "orgName").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetIdPGroupsJSON(ctx, "orgName")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgIdPGroupsDBQ, "userID", "orgName").Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				dataJSON, err := m.GetIdPGroupsJSON(ctx, "orgName")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetInvitationsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetInvitationsJSON(context.Background(), "orgName", p)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetInvitationsJSON(ctx, "", p)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgInvitationsDBQ, "userID", "orgName", 10, 1).
+			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		result, err := m.GetInvitationsJSON(ctx, "orgName", p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgInvitationsDBQ, "userID", "orgName", 10, 1).Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				result, err := m.GetInvitationsJSON(ctx, "orgName", p)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, result)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetJSON(context.Background(), "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgDBQ, "orgName").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetJSON(ctx, "orgName")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgDBQ, "orgName").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetJSON(ctx, "orgName")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetLinksJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetLinksJSON(context.Background(), "orgName")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetLinksJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgLinksDBQ, "userID", "orgName").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetLinksJSON(ctx, "orgName")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgLinksDBQ, "userID", "orgName").Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				dataJSON, err := m.GetLinksJSON(ctx, "orgName")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetMembersJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetMembersJSON(context.Background(), "orgName", p)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetMembersJSON(ctx, "", p)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgMembersDBQ, "userID", "orgName", 10, 1).
+			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		result, err := m.GetMembersJSON(ctx, "orgName", p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgMembersDBQ, "userID", "orgName", 10, 1).Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				result, err := m.GetMembersJSON(ctx, "orgName", p)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, result)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetQuotasJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetQuotasJSON(context.Background(), "orgName")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetQuotasJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgQuotasDBQ, "userID", "orgName").Return([]byte(`
+		{
+			"quotas": {"max_webhooks": 5},
+			"usage": {"repositories": 1, "webhooks": 2, "api_keys": 3}
+		}
+		`), nil)
+		cfg := viper.New()
+		cfg.Set("quotas.maxRepositories", 10)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetQuotasJSON(ctx, "orgName")
+		assert.NoError(t, err)
+		assert.JSONEq(t, `
+		{
+			"quotas": {
+				"max_repositories": 10,
+				"max_webhooks": 5,
+				"max_api_keys": 0,
+				"tracker_min_interval": 0
+			},
+			"usage": {"repositories": 1, "webhooks": 2, "api_keys": 3}
+		}
+		`, string(dataJSON))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgQuotasDBQ, "userID", "orgName").Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				dataJSON, err := m.GetQuotasJSON(ctx, "orgName")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestResendInvitation(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.ResendInvitation(context.Background(), "orgName", "userAlias")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			orgName   string
+			userAlias string
+		}{
+			{
+				"organization name not provided",
+				"",
+				"user1",
+			},
+			{
+				"user alias not provided",
+				"org1",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.ResendInvitation(ctx, tc.orgName, tc.userAlias)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationMember,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		err := m.ResendInvitation(ctx, "orgName", "userAlias")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("invitation renewed and email sent", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, resendInvitationDBQ, "userID", "orgName", "userAlias", invitationTTL).Return(nil)
+		db.On("QueryRow", ctx, getUserEmailDBQ, "userAlias").Return("email", nil)
+		es := &email.SenderMock{}
+		es.On("SendEmail", mock.Anything).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationMember,
+		}).Return(nil)
+		m := NewManager(cfg, db, es, az)
+
+		err := m.ResendInvitation(ctx, "orgName", "userAlias")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, resendInvitationDBQ, "userID", "orgName", "userAlias", invitationTTL).
+					Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "orgName",
+					UserID:           "userID",
+					Action:           hub.AddOrganizationMember,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				err := m.ResendInvitation(ctx, "orgName", "userAlias")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.Update(context.Background(), "org1", &hub.Organization{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			org    *hub.Organization
+		}{
+			{
+				"name not provided",
+				&hub.Organization{
+					Name: "",
+				},
+			},
+			{
+				"invalid name",
+				&hub.Organization{
+					Name: "_org1",
+				},
+			},
+			{
+				"invalid name",
+				&hub.Organization{
+					Name: "UPPERCASE",
+				},
+			},
+			{
+				"invalid logo image id",
+				&hub.Organization{
+					Name:        "org1",
+					LogoImageID: "invalid",
+				},
+			},
+			{
+				"profile too long",
+				&hub.Organization{
+					Name:    "org1",
+					Profile: strings.Repeat("a", maxProfileLength+1),
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.Update(ctx, "org1", tc.org)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganization,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		err := m.Update(ctx, "org1", &hub.Organization{
+			Name:        "org1",
+			DisplayName: "Organization 1 updated",
+		})
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateOrgDBQ, "userID", "org1", mock.Anything).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganization,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.Update(ctx, "org1", &hub.Organization{
+			Name:        "org1",
+			DisplayName: "Organization 1 updated",
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, updateOrgDBQ, "userID", "org1", mock.Anything).Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.UpdateOrganization,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				err := m.Update(ctx, "org1", &hub.Organization{
+					Name:        "org1",
+					DisplayName: "Organization 1 updated",
+				})
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestUpdateAuthorizationPolicy(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	validPolicy := &hub.AuthorizationPolicy{
+		AuthorizationEnabled: true,
+		PredefinedPolicy:     "rbac.v1",
+		PolicyData:           []byte(`"{\"k\": \"v\"}"`),
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.UpdateAuthorizationPolicy(context.Background(), "org1", &hub.AuthorizationPolicy{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			policy  *hub.AuthorizationPolicy
+		}{
+			{
+				"organization name not provided",
+				"",
+				nil,
+			},
+			{
+				"authorization policy not provided",
+				"org1",
+				nil,
+			},
+			{
+				"both predefined and custom policies were provided",
+				"org1",
+				&hub.AuthorizationPolicy{
+					AuthorizationEnabled: true,
+					PredefinedPolicy:     "policy",
+					CustomPolicy:         "policy",
+				},
+			},
+			{
+				"a predefined or custom policy must be provided",
+				"org1",
+				&hub.AuthorizationPolicy{
+					AuthorizationEnabled: true,
+					PredefinedPolicy:     "",
+					CustomPolicy:         "",
+				},
+			},
+			{
+				"invalid predefined policy",
+				"org1",
+				&hub.AuthorizationPolicy{
+					PredefinedPolicy: "invalid",
+				},
+			},
+			{
+				"invalid custom policy",
+				"org1",
+				&hub.AuthorizationPolicy{
+					CustomPolicy: "invalid",
+				},
+			},
+			{
+				"allowed actions rule not found in custom policy",
+				"org1",
+				&hub.AuthorizationPolicy{
+					CustomPolicy: `package artifacthub.authz`,
+				},
+			},
+			{
+				"invalid policy data",
+				"org1",
+				&hub.AuthorizationPolicy{
+					CustomPolicy: `
+					package artifacthub.authz
+
+					allowed_actions[action] { action := "all" }
+					`,
+					PolicyData: []byte("{invalidJSON"),
+				},
+			},
+			{
+				"invalid policy data",
+				"org1",
+				&hub.AuthorizationPolicy{
+					CustomPolicy: `
+					package artifacthub.authz
+
+					allowed_actions[action] { action := "all" }
+					`,
+					PolicyData: []byte(`"[]"`),
+				},
+			},
+			{
+				"editing user will be locked out with this policy",
+				"org1",
+				&hub.AuthorizationPolicy{
+					CustomPolicy: `
+					package artifacthub.authz
+
+					allowed_actions = ["all"]
+					`,
+					PolicyData: []byte(`"{}"`),
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				az := &authz.AuthorizerMock{}
+				az.On("WillUserBeLockedOut", ctx, tc.policy, "userID").Return(true, nil).Maybe()
+				m := NewManager(cfg, nil, nil, az)
+				err := m.UpdateAuthorizationPolicy(ctx, tc.orgName, tc.policy)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("WillUserBeLockedOut", ctx, validPolicy, "userID").Return(false, nil).Maybe()
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateAuthorizationPolicy,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		err := m.UpdateAuthorizationPolicy(ctx, "org1", validPolicy)
 		assert.Equal(t, tests.ErrFake, err)
-		assert.Nil(t, dataJSON)
 		az.AssertExpectations(t)
 	})
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getAuthzPolicyDBQ, "userID", "org1").Return([]byte("dataJSON"), nil)
+		db.On("Exec", ctx, updateAuthzPolicyDBQ, "userID", "org1", mock.Anything).Return(nil)
 		az := &authz.AuthorizerMock{}
+		az.On("WillUserBeLockedOut", ctx, validPolicy, "userID").Return(false, nil).Maybe()
 		az.On("Authorize", ctx, &hub.AuthorizeInput{
 			OrganizationName: "org1",
 			UserID:           "userID",
-			Action:           hub.GetAuthorizationPolicy,
+			Action:           hub.UpdateAuthorizationPolicy,
 		}).Return(nil)
 		m := NewManager(cfg, db, nil, az)
 
-		dataJSON, err := m.GetAuthorizationPolicyJSON(ctx, "org1")
+		err := m.UpdateAuthorizationPolicy(ctx, "org1", validPolicy)
 		assert.NoError(t, err)
-		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
 		az.AssertExpectations(t)
 	})
@@ -622,18 +1879,18 @@ func TestGetAuthorizationPolicyJSON(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("QueryRow", ctx, getAuthzPolicyDBQ, "userID", "org1").Return(nil, tc.dbErr)
+				db.On("Exec", ctx, updateAuthzPolicyDBQ, "userID", "org1", mock.Anything).Return(tc.dbErr)
 				az := &authz.AuthorizerMock{}
+				az.On("WillUserBeLockedOut", ctx, validPolicy, "userID").Return(false, nil).Maybe()
 				az.On("Authorize", ctx, &hub.AuthorizeInput{
 					OrganizationName: "org1",
 					UserID:           "userID",
-					Action:           hub.GetAuthorizationPolicy,
+					Action:           hub.UpdateAuthorizationPolicy,
 				}).Return(nil)
 				m := NewManager(cfg, db, nil, az)
 
-				dataJSON, err := m.GetAuthorizationPolicyJSON(ctx, "org1")
+				err := m.UpdateAuthorizationPolicy(ctx, "org1", validPolicy)
 				assert.Equal(t, tc.expectedError, err)
-				assert.Nil(t, dataJSON)
 				db.AssertExpectations(t)
 				az.AssertExpectations(t)
 			})
@@ -641,110 +1898,237 @@ func TestGetAuthorizationPolicyJSON(t *testing.T) {
 	})
 }
 
-func TestGetByUserJSON(t *testing.T) {
+func TestUpdateIdPGroups(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
-	p := &hub.Pagination{Limit: 10, Offset: 1}
+	groups := []*hub.IdPGroupMapping{
+		{Group: "group1", Role: hub.OrganizationAdmin},
+		{Group: "group2", Role: hub.OrganizationViewer},
+	}
+	groupsJSON := []byte(`[{"group":"group1","role":"admin"},{"group":"group2","role":"viewer"}]`)
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
 		assert.Panics(t, func() {
-			_, _ = m.GetByUserJSON(context.Background(), p)
+			_ = m.UpdateIdPGroups(context.Background(), "org1", groups)
 		})
 	})
 
-	t.Run("database query succeeded", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserOrgsDBQ, "userID", 10, 1).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
-		m := NewManager(cfg, db, nil, nil)
-
-		result, err := m.GetByUserJSON(ctx, p)
-		assert.NoError(t, err)
-		assert.Equal(t, []byte("dataJSON"), result.Data)
-		assert.Equal(t, 1, result.TotalCount)
-		db.AssertExpectations(t)
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			groups  []*hub.IdPGroupMapping
+		}{
+			{
+				"organization name not provided",
+				"",
+				groups,
+			},
+			{
+				"group not provided",
+				"org1",
+				[]*hub.IdPGroupMapping{{Group: "", Role: hub.OrganizationAdmin}},
+			},
+			{
+				"invalid role",
+				"org1",
+				[]*hub.IdPGroupMapping{{Group: "group1", Role: hub.OrganizationRole("invalid")}},
+			},
+			{
+				"duplicated group",
+				"org1",
+				[]*hub.IdPGroupMapping{
+					{Group: "group1", Role: hub.OrganizationAdmin},
+					{Group: "group1", Role: hub.OrganizationViewer},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.UpdateIdPGroups(ctx, tc.orgName, tc.groups)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
 	})
 
-	t.Run("database error", func(t *testing.T) {
+	t.Run("authorization failed", func(t *testing.T) {
 		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserOrgsDBQ, "userID", 10, 1).Return(nil, tests.ErrFakeDB)
-		m := NewManager(cfg, db, nil, nil)
-
-		dataJSON, err := m.GetByUserJSON(ctx, p)
-		assert.Equal(t, tests.ErrFakeDB, err)
-		assert.Nil(t, dataJSON)
-		db.AssertExpectations(t)
-	})
-}
-
-func TestGetJSON(t *testing.T) {
-	ctx := context.Background()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationMemberRole,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
 
-	t.Run("invalid input", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(cfg, nil, nil, nil)
-		_, err := m.GetJSON(context.Background(), "")
-		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		err := m.UpdateIdPGroups(ctx, "org1", groups)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
 	})
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getOrgDBQ, "orgName").Return([]byte("dataJSON"), nil)
-		m := NewManager(cfg, db, nil, nil)
+		db.On("Exec", ctx, updateOrgIdPGroupsDBQ, "userID", "org1", groupsJSON).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationMemberRole,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
 
-		dataJSON, err := m.GetJSON(ctx, "orgName")
+		err := m.UpdateIdPGroups(ctx, "org1", groups)
 		assert.NoError(t, err)
-		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
+		az.AssertExpectations(t)
 	})
 
 	t.Run("database error", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getOrgDBQ, "orgName").Return(nil, tests.ErrFakeDB)
-		m := NewManager(cfg, db, nil, nil)
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, updateOrgIdPGroupsDBQ, "userID", "org1", groupsJSON).Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.UpdateOrganizationMemberRole,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
 
-		dataJSON, err := m.GetJSON(ctx, "orgName")
-		assert.Equal(t, tests.ErrFakeDB, err)
-		assert.Nil(t, dataJSON)
-		db.AssertExpectations(t)
+				err := m.UpdateIdPGroups(ctx, "org1", groups)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
 	})
 }
 
-func TestGetMembersJSON(t *testing.T) {
+func TestUpdateLinks(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
-	p := &hub.Pagination{Limit: 10, Offset: 1}
+	links := []*hub.OrganizationLink{
+		{URL: "https://org1.com"},
+		{URL: "https://blog.org1.com/about"},
+	}
+	linksJSON := []byte(`[{"url":"https://org1.com","verified":false},{"url":"https://blog.org1.com/about","verified":false}]`)
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
 		assert.Panics(t, func() {
-			_, _ = m.GetMembersJSON(context.Background(), "orgName", p)
+			_ = m.UpdateLinks(context.Background(), "org1", links)
 		})
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
+		tooManyLinks := make([]*hub.OrganizationLink, 0, maxLinks+1)
+		for i := 0; i <= maxLinks; i++ {
+			tooManyLinks = append(tooManyLinks, &hub.OrganizationLink{URL: fmt.Sprintf("https://org%d.com", i)})
+		}
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			links   []*hub.OrganizationLink
+		}{
+			{
+				"organization name not provided",
+				"",
+				links,
+			},
+			{
+				"too many links provided",
+				"org1",
+				tooManyLinks,
+			},
+			{
+				"link url not provided",
+				"org1",
+				[]*hub.OrganizationLink{{URL: ""}},
+			},
+			{
+				"invalid link url",
+				"org1",
+				[]*hub.OrganizationLink{{URL: "ftp://org1.com"}},
+			},
+			{
+				"invalid link url",
+				"org1",
+				[]*hub.OrganizationLink{{URL: "org1.com"}},
+			},
+			{
+				"duplicated link url",
+				"org1",
+				[]*hub.OrganizationLink{
+					{URL: "https://org1.com"},
+					{URL: "https://org1.com"},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.UpdateLinks(ctx, tc.orgName, tc.links)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(cfg, nil, nil, nil)
-		_, err := m.GetMembersJSON(ctx, "", p)
-		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganization,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		err := m.UpdateLinks(ctx, "org1", links)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
 	})
 
 	t.Run("database query succeeded", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getOrgMembersDBQ, "userID", "orgName", 10, 1).
-			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
-		m := NewManager(cfg, db, nil, nil)
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateOrgLinksDBQ, "userID", "org1", linksJSON).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganization,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
 
-		result, err := m.GetMembersJSON(ctx, "orgName", p)
+		err := m.UpdateLinks(ctx, "org1", links)
 		assert.NoError(t, err)
-		assert.Equal(t, []byte("dataJSON"), result.Data)
-		assert.Equal(t, 1, result.TotalCount)
 		db.AssertExpectations(t)
+		az.AssertExpectations(t)
 	})
 
 	t.Run("database error", func(t *testing.T) {
@@ -766,58 +2150,59 @@ func TestGetMembersJSON(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("QueryRow", ctx, getOrgMembersDBQ, "userID", "orgName", 10, 1).Return(nil, tc.dbErr)
-				m := NewManager(cfg, db, nil, nil)
+				db.On("Exec", ctx, updateOrgLinksDBQ, "userID", "org1", linksJSON).Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.UpdateOrganization,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
 
-				result, err := m.GetMembersJSON(ctx, "orgName", p)
+				err := m.UpdateLinks(ctx, "org1", links)
 				assert.Equal(t, tc.expectedError, err)
-				assert.Nil(t, result)
 				db.AssertExpectations(t)
+				az.AssertExpectations(t)
 			})
 		}
 	})
 }
 
-func TestUpdate(t *testing.T) {
+func TestUpdateMemberRole(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
 		assert.Panics(t, func() {
-			_ = m.Update(context.Background(), "org1", &hub.Organization{})
+			_ = m.UpdateMemberRole(context.Background(), "org1", "user1", hub.OrganizationAdmin)
 		})
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
-			errMsg string
-			org    *hub.Organization
+			errMsg    string
+			orgName   string
+			userAlias string
+			role      hub.OrganizationRole
 		}{
 			{
-				"name not provided",
-				&hub.Organization{
-					Name: "",
-				},
-			},
-			{
-				"invalid name",
-				&hub.Organization{
-					Name: "_org1",
-				},
+				"organization name not provided",
+				"",
+				"user1",
+				hub.OrganizationAdmin,
 			},
 			{
-				"invalid name",
-				&hub.Organization{
-					Name: "UPPERCASE",
-				},
+				"user alias not provided",
+				"org1",
+				"",
+				hub.OrganizationAdmin,
 			},
 			{
-				"invalid logo image id",
-				&hub.Organization{
-					Name:        "org1",
-					LogoImageID: "invalid",
-				},
+				"invalid role",
+				"org1",
+				"user1",
+				hub.OrganizationRole("invalid"),
 			},
 		}
 		for _, tc := range testCases {
@@ -825,7 +2210,7 @@ func TestUpdate(t *testing.T) {
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
 				m := NewManager(cfg, nil, nil, nil)
-				err := m.Update(ctx, "org1", tc.org)
+				err := m.UpdateMemberRole(ctx, tc.orgName, tc.userAlias, tc.role)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
 			})
@@ -838,34 +2223,70 @@ func TestUpdate(t *testing.T) {
 		az.On("Authorize", ctx, &hub.AuthorizeInput{
 			OrganizationName: "org1",
 			UserID:           "userID",
-			Action:           hub.UpdateOrganization,
+			Action:           hub.UpdateOrganizationMemberRole,
 		}).Return(tests.ErrFake)
 		m := NewManager(cfg, nil, nil, az)
 
-		err := m.Update(ctx, "org1", &hub.Organization{
-			Name:        "org1",
-			DisplayName: "Organization 1 updated",
-		})
+		err := m.UpdateMemberRole(ctx, "org1", "user1", hub.OrganizationAdmin)
 		assert.Equal(t, tests.ErrFake, err)
 		az.AssertExpectations(t)
 	})
 
+	t.Run("non owners cannot manage owners", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			memberRole string
+			newRole    hub.OrganizationRole
+		}{
+			{
+				"change owner role",
+				"owner",
+				hub.OrganizationAdmin,
+			},
+			{
+				"promote member to owner",
+				"maintainer",
+				hub.OrganizationOwner,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getMembersRolesDBQ, "userID", "org1", "user1").
+					Return([]interface{}{"admin", tc.memberRole}, nil)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.UpdateOrganizationMemberRole,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				err := m.UpdateMemberRole(ctx, "org1", "user1", tc.newRole)
+				assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, updateOrgDBQ, "userID", "org1", mock.Anything).Return(nil)
+		db.On("QueryRow", ctx, getMembersRolesDBQ, "userID", "org1", "user1").
+			Return([]interface{}{"owner", "maintainer"}, nil)
+		db.On("Exec", ctx, updateOrgMemberRoleDBQ, "userID", "org1", "user1", "admin").Return(nil)
 		az := &authz.AuthorizerMock{}
 		az.On("Authorize", ctx, &hub.AuthorizeInput{
 			OrganizationName: "org1",
 			UserID:           "userID",
-			Action:           hub.UpdateOrganization,
+			Action:           hub.UpdateOrganizationMemberRole,
 		}).Return(nil)
 		m := NewManager(cfg, db, nil, az)
 
-		err := m.Update(ctx, "org1", &hub.Organization{
-			Name:        "org1",
-			DisplayName: "Organization 1 updated",
-		})
+		err := m.UpdateMemberRole(ctx, "org1", "user1", hub.OrganizationAdmin)
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 		az.AssertExpectations(t)
@@ -890,19 +2311,18 @@ func TestUpdate(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("Exec", ctx, updateOrgDBQ, "userID", "org1", mock.Anything).Return(tc.dbErr)
+				db.On("QueryRow", ctx, getMembersRolesDBQ, "userID", "org1", "user1").
+					Return([]interface{}{"owner", "maintainer"}, nil)
+				db.On("Exec", ctx, updateOrgMemberRoleDBQ, "userID", "org1", "user1", "admin").Return(tc.dbErr)
 				az := &authz.AuthorizerMock{}
 				az.On("Authorize", ctx, &hub.AuthorizeInput{
 					OrganizationName: "org1",
 					UserID:           "userID",
-					Action:           hub.UpdateOrganization,
+					Action:           hub.UpdateOrganizationMemberRole,
 				}).Return(nil)
 				m := NewManager(cfg, db, nil, az)
 
-				err := m.Update(ctx, "org1", &hub.Organization{
-					Name:        "org1",
-					DisplayName: "Organization 1 updated",
-				})
+				err := m.UpdateMemberRole(ctx, "org1", "user1", hub.OrganizationAdmin)
 				assert.Equal(t, tc.expectedError, err)
 				db.AssertExpectations(t)
 				az.AssertExpectations(t)
@@ -911,168 +2331,87 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
-func TestUpdateAuthorizationPolicy(t *testing.T) {
+func TestVerifyLinks(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
-	validPolicy := &hub.AuthorizationPolicy{
-		AuthorizationEnabled: true,
-		PredefinedPolicy:     "rbac.v1",
-		PolicyData:           []byte(`"{\"k\": \"v\"}"`),
-	}
+	linksJSON := []byte(`[
+		{"url": "https://org1.com", "verified": true, "verification_token": "token1"},
+		{"url": "https://blog.org1.com/about", "verified": false, "verification_token": "token2"},
+		{"url": "https://org1.dev", "verified": false, "verification_token": "token3"},
+		{"url": "https://org1.io", "verified": false, "verification_token": "token4"}
+	]`)
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
 		assert.Panics(t, func() {
-			_ = m.UpdateAuthorizationPolicy(context.Background(), "org1", &hub.AuthorizationPolicy{})
+			_ = m.VerifyLinks(context.Background(), "org1")
 		})
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
-		testCases := []struct {
-			errMsg  string
-			orgName string
-			policy  *hub.AuthorizationPolicy
-		}{
-			{
-				"organization name not provided",
-				"",
-				nil,
-			},
-			{
-				"authorization policy not provided",
-				"org1",
-				nil,
-			},
-			{
-				"both predefined and custom policies were provided",
-				"org1",
-				&hub.AuthorizationPolicy{
-					AuthorizationEnabled: true,
-					PredefinedPolicy:     "policy",
-					CustomPolicy:         "policy",
-				},
-			},
-			{
-				"a predefined or custom policy must be provided",
-				"org1",
-				&hub.AuthorizationPolicy{
-					AuthorizationEnabled: true,
-					PredefinedPolicy:     "",
-					CustomPolicy:         "",
-				},
-			},
-			{
-				"invalid predefined policy",
-				"org1",
-				&hub.AuthorizationPolicy{
-					PredefinedPolicy: "invalid",
-				},
-			},
-			{
-				"invalid custom policy",
-				"org1",
-				&hub.AuthorizationPolicy{
-					CustomPolicy: "invalid",
-				},
-			},
-			{
-				"allowed actions rule not found in custom policy",
-				"org1",
-				&hub.AuthorizationPolicy{
-					CustomPolicy: `package artifacthub.authz`,
-				},
-			},
-			{
-				"invalid policy data",
-				"org1",
-				&hub.AuthorizationPolicy{
-					CustomPolicy: `
-					package artifacthub.authz
-
-					allowed_actions[action] { action := "all" }
-					`,
-					PolicyData: []byte("{invalidJSON"),
-				},
-			},
-			{
-				"invalid policy data",
-				"org1",
-				&hub.AuthorizationPolicy{
-					CustomPolicy: `
-					package artifacthub.authz
-
-					allowed_actions[action] { action := "all" }
-					`,
-					PolicyData: []byte(`"[]"`),
-				},
-			},
-			{
-				"editing user will be locked out with this policy",
-				"org1",
-				&hub.AuthorizationPolicy{
-					CustomPolicy: `
-					package artifacthub.authz
-
-					allowed_actions = ["all"]
-					`,
-					PolicyData: []byte(`"{}"`),
-				},
-			},
-		}
-		for _, tc := range testCases {
-			tc := tc
-			t.Run(tc.errMsg, func(t *testing.T) {
-				t.Parallel()
-				az := &authz.AuthorizerMock{}
-				az.On("WillUserBeLockedOut", ctx, tc.policy, "userID").Return(true, nil).Maybe()
-				m := NewManager(cfg, nil, nil, az)
-				err := m.UpdateAuthorizationPolicy(ctx, tc.orgName, tc.policy)
-				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
-				assert.Contains(t, err.Error(), tc.errMsg)
-			})
-		}
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		err := m.VerifyLinks(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
 
 	t.Run("authorization failed", func(t *testing.T) {
 		t.Parallel()
 		az := &authz.AuthorizerMock{}
-		az.On("WillUserBeLockedOut", ctx, validPolicy, "userID").Return(false, nil).Maybe()
 		az.On("Authorize", ctx, &hub.AuthorizeInput{
 			OrganizationName: "org1",
 			UserID:           "userID",
-			Action:           hub.UpdateAuthorizationPolicy,
+			Action:           hub.UpdateOrganization,
 		}).Return(tests.ErrFake)
 		m := NewManager(cfg, nil, nil, az)
 
-		err := m.UpdateAuthorizationPolicy(ctx, "org1", validPolicy)
+		err := m.VerifyLinks(ctx, "org1")
 		assert.Equal(t, tests.ErrFake, err)
 		az.AssertExpectations(t)
 	})
 
-	t.Run("database query succeeded", func(t *testing.T) {
+	t.Run("error getting links", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, updateAuthzPolicyDBQ, "userID", "org1", mock.Anything).Return(nil)
+		db.On("QueryRow", ctx, getOrgLinksDBQ, "userID", "org1").Return(nil, tests.ErrFakeDB)
 		az := &authz.AuthorizerMock{}
-		az.On("WillUserBeLockedOut", ctx, validPolicy, "userID").Return(false, nil).Maybe()
-		az.On("Authorize", ctx, &hub.AuthorizeInput{
-			OrganizationName: "org1",
-			UserID:           "userID",
-			Action:           hub.UpdateAuthorizationPolicy,
-		}).Return(nil)
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
 		m := NewManager(cfg, db, nil, az)
 
-		err := m.UpdateAuthorizationPolicy(ctx, "org1", validPolicy)
+		err := m.VerifyLinks(ctx, "org1")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("no links verified", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgLinksDBQ, "userID", "org1").Return(linksJSON, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		rs := &tests.TXTResolverMock{}
+		rs.On("LookupTXT", ctx, "blog.org1.com").Return(nil, tests.ErrFake)
+		rs.On("LookupTXT", ctx, "org1.dev").Return([]string{"other"}, nil)
+		rs.On("LookupTXT", ctx, "org1.io").Return([]string{"artifacthub-verification=token3"}, nil)
+		m := NewManager(cfg, db, nil, az, WithTXTResolver(rs))
+
+		err := m.VerifyLinks(ctx, "org1")
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 		az.AssertExpectations(t)
+		rs.AssertExpectations(t)
 	})
 
-	t.Run("database error", func(t *testing.T) {
+	t.Run("some links verified", func(t *testing.T) {
 		testCases := []struct {
 			dbErr         error
 			expectedError error
 		}{
+			{
+				nil,
+				nil,
+			},
 			{
 				tests.ErrFakeDB,
 				tests.ErrFakeDB,
@@ -1084,24 +2423,63 @@ func TestUpdateAuthorizationPolicy(t *testing.T) {
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+			t.Run(fmt.Sprintf("db error: %v", tc.dbErr), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("Exec", ctx, updateAuthzPolicyDBQ, "userID", "org1", mock.Anything).Return(tc.dbErr)
+				db.On("QueryRow", ctx, getOrgLinksDBQ, "userID", "org1").Return(linksJSON, nil)
+				db.On("Exec", ctx, verifyOrgLinksDBQ, "userID", "org1", []string{
+					"https://blog.org1.com/about",
+					"https://org1.io",
+				}).Return(tc.dbErr)
 				az := &authz.AuthorizerMock{}
-				az.On("WillUserBeLockedOut", ctx, validPolicy, "userID").Return(false, nil).Maybe()
-				az.On("Authorize", ctx, &hub.AuthorizeInput{
-					OrganizationName: "org1",
-					UserID:           "userID",
-					Action:           hub.UpdateAuthorizationPolicy,
-				}).Return(nil)
-				m := NewManager(cfg, db, nil, az)
-
-				err := m.UpdateAuthorizationPolicy(ctx, "org1", validPolicy)
+				az.On("Authorize", ctx, mock.Anything).Return(nil)
+				rs := &tests.TXTResolverMock{}
+				rs.On("LookupTXT", ctx, "blog.org1.com").Return([]string{"artifacthub-verification=token2"}, nil)
+				rs.On("LookupTXT", ctx, "org1.dev").Return([]string{}, nil)
+				rs.On("LookupTXT", ctx, "org1.io").Return([]string{"other", "artifacthub-verification=token4"}, nil)
+				m := NewManager(cfg, db, nil, az, WithTXTResolver(rs))
+
+				err := m.VerifyLinks(ctx, "org1")
 				assert.Equal(t, tc.expectedError, err)
 				db.AssertExpectations(t)
 				az.AssertExpectations(t)
+				rs.AssertExpectations(t)
 			})
 		}
 	})
 }
+
+func TestRenderProfile(t *testing.T) {
+	testCases := []struct {
+		profile      string
+		expectedHTML string
+	}{
+		{
+			"",
+			"",
+		},
+		{
+			"# Title",
+			"<h1>Title</h1>\n",
+		},
+		{
+			"Hello <script>alert(1)</script>",
+			"<p>Hello alert(1)</p>\n",
+		},
+		{
+			"[link](javascript:alert(1))",
+			"<p><tt>link</tt>)</p>\n",
+		},
+		{
+			"[link](https://org1.com)",
+			`<p><a href="https://org1.com" target="_blank" rel="nofollow noreferrer noopener">link</a></p>` + "\n",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.profile, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedHTML, renderProfile(tc.profile))
+		})
+	}
+}
@@ -24,6 +24,23 @@ func (m *ManagerMock) AddMember(ctx context.Context, orgName, userAlias string)
 	return args.Error(0)
 }
 
+// AddMembers implements the OrganizationManager interface.
+func (m *ManagerMock) AddMembers(
+	ctx context.Context,
+	orgName string,
+	emails []string,
+) ([]*hub.OrganizationInvitationResult, error) {
+	args := m.Called(ctx, orgName, emails)
+	results, _ := args.Get(0).([]*hub.OrganizationInvitationResult)
+	return results, args.Error(1)
+}
+
+// CancelInvitation implements the OrganizationManager interface.
+func (m *ManagerMock) CancelInvitation(ctx context.Context, orgName, userAlias string) error {
+	args := m.Called(ctx, orgName, userAlias)
+	return args.Error(0)
+}
+
 // CheckAvailability implements the OrganizationManager interface.
 func (m *ManagerMock) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
 	args := m.Called(ctx, resourceKind, value)
@@ -37,8 +54,8 @@ func (m *ManagerMock) ConfirmMembership(ctx context.Context, orgName string) err
 }
 
 // Delete implements the OrganizationManager interface.
-func (m *ManagerMock) Delete(ctx context.Context, orgName string) error {
-	args := m.Called(ctx, orgName)
+func (m *ManagerMock) Delete(ctx context.Context, orgName string, input *hub.DeleteOrganizationInput) error {
+	args := m.Called(ctx, orgName, input)
 	return args.Error(0)
 }
 
@@ -69,6 +86,31 @@ func (m *ManagerMock) GetByUserJSON(ctx context.Context, p *hub.Pagination) (*hu
 	return data, args.Error(1)
 }
 
+// GetIdPGroupsJSON implements the OrganizationManager interface.
+func (m *ManagerMock) GetIdPGroupsJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetInvitationsJSON implements the OrganizationManager interface.
+func (m *ManagerMock) GetInvitationsJSON(
+	ctx context.Context,
+	orgName string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, orgName, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// GetLinksJSON implements the OrganizationManager interface.
+func (m *ManagerMock) GetLinksJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetMembersJSON implements the OrganizationManager interface.
 func (m *ManagerMock) GetMembersJSON(
 	ctx context.Context,
@@ -80,6 +122,19 @@ func (m *ManagerMock) GetMembersJSON(
 	return data, args.Error(1)
 }
 
+// GetQuotasJSON implements the OrganizationManager interface.
+func (m *ManagerMock) GetQuotasJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// ResendInvitation implements the OrganizationManager interface.
+func (m *ManagerMock) ResendInvitation(ctx context.Context, orgName, userAlias string) error {
+	args := m.Called(ctx, orgName, userAlias)
+	return args.Error(0)
+}
+
 // Update implements the OrganizationManager interface.
 func (m *ManagerMock) Update(ctx context.Context, orgName string, org *hub.Organization) error {
 	args := m.Called(ctx, orgName, org)
@@ -95,3 +150,32 @@ func (m *ManagerMock) UpdateAuthorizationPolicy(
 	args := m.Called(ctx, orgName, policy)
 	return args.Error(0)
 }
+
+// UpdateIdPGroups implements the OrganizationManager interface.
+func (m *ManagerMock) UpdateIdPGroups(ctx context.Context, orgName string, groups []*hub.IdPGroupMapping) error {
+	args := m.Called(ctx, orgName, groups)
+	return args.Error(0)
+}
+
+// UpdateLinks implements the OrganizationManager interface.
+func (m *ManagerMock) UpdateLinks(ctx context.Context, orgName string, links []*hub.OrganizationLink) error {
+	args := m.Called(ctx, orgName, links)
+	return args.Error(0)
+}
+
+// UpdateMemberRole implements the OrganizationManager interface.
+func (m *ManagerMock) UpdateMemberRole(
+	ctx context.Context,
+	orgName string,
+	userAlias string,
+	role hub.OrganizationRole,
+) error {
+	args := m.Called(ctx, orgName, userAlias, role)
+	return args.Error(0)
+}
+
+// VerifyLinks implements the OrganizationManager interface.
+func (m *ManagerMock) VerifyLinks(ctx context.Context, orgName string) error {
+	args := m.Called(ctx, orgName)
+	return args.Error(0)
+}
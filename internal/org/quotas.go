@@ -0,0 +1,91 @@
+package org
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Database queries
+	getOrgQuotasDBQ = `select get_organization_quotas($1::uuid, $2::text)`
+)
+
+// QuotaKind represents a kind of resource limited by the organizations quotas.
+type QuotaKind string
+
+const (
+	// RepositoriesQuota represents the quota on the number of repositories.
+	RepositoriesQuota QuotaKind = "repositories"
+
+	// WebhooksQuota represents the quota on the number of webhooks.
+	WebhooksQuota QuotaKind = "webhooks"
+
+	// APIKeysQuota represents the quota on the number of api keys owned by
+	// the organization service accounts.
+	APIKeysQuota QuotaKind = "api keys"
+)
+
+// DefaultQuotas returns the quotas that apply to the organizations that don't
+// have them overridden, as defined in the configuration provided.
+func DefaultQuotas(cfg *viper.Viper) *hub.OrganizationQuotas {
+	return &hub.OrganizationQuotas{
+		MaxRepositories:    cfg.GetInt("quotas.maxRepositories"),
+		MaxWebhooks:        cfg.GetInt("quotas.maxWebhooks"),
+		MaxAPIKeys:         cfg.GetInt("quotas.maxAPIKeys"),
+		TrackerMinInterval: cfg.GetInt("quotas.trackerMinInterval"),
+	}
+}
+
+// GetQuotasUsage returns the quotas that apply to the organization provided
+// as well as its current usage. Quotas not overridden for the organization in
+// the database default to the ones defined in the configuration provided.
+func GetQuotasUsage(
+	ctx context.Context,
+	db hub.DB,
+	cfg *viper.Viper,
+	userID,
+	orgName string,
+) (*hub.OrganizationQuotasUsage, error) {
+	qu := &hub.OrganizationQuotasUsage{
+		Quotas: DefaultQuotas(cfg),
+	}
+	if err := util.DBQueryUnmarshal(ctx, db, qu, getOrgQuotasDBQ, userID, orgName); err != nil {
+		return nil, err
+	}
+	return qu, nil
+}
+
+// CheckQuota checks if the organization provided can use one more resource of
+// the kind provided without exceeding its quota.
+func CheckQuota(
+	ctx context.Context,
+	db hub.DB,
+	cfg *viper.Viper,
+	userID,
+	orgName string,
+	kind QuotaKind,
+) error {
+	qu, err := GetQuotasUsage(ctx, db, cfg, userID, orgName)
+	if err != nil {
+		return err
+	}
+	var limit, used int
+	switch kind {
+	case RepositoriesQuota:
+		limit, used = qu.Quotas.MaxRepositories, qu.Usage.Repositories
+	case WebhooksQuota:
+		limit, used = qu.Quotas.MaxWebhooks, qu.Usage.Webhooks
+	case APIKeysQuota:
+		limit, used = qu.Quotas.MaxAPIKeys, qu.Usage.APIKeys
+	default:
+		return fmt.Errorf("invalid quota kind: %s", kind)
+	}
+	if limit > 0 && used >= limit {
+		return fmt.Errorf("%w: organization %s %s quota exceeded (limit: %d)", hub.ErrInvalidInput, orgName, kind, limit)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckQuota(t *testing.T) {
+	ctx := context.Background()
+	usageJSON := []byte(`
+	{
+		"quotas": {"max_webhooks": 2},
+		"usage": {"repositories": 3, "webhooks": 2, "api_keys": 1}
+	}
+	`)
+
+	t.Run("quota checks", func(t *testing.T) {
+		testCases := []struct {
+			kind        QuotaKind
+			cfgKey      string
+			cfgValue    int
+			expectedErr error
+		}{
+			{
+				RepositoriesQuota,
+				"quotas.maxRepositories",
+				0,
+				nil,
+			},
+			{
+				RepositoriesQuota,
+				"quotas.maxRepositories",
+				4,
+				nil,
+			},
+			{
+				RepositoriesQuota,
+				"quotas.maxRepositories",
+				3,
+				hub.ErrInvalidInput,
+			},
+			{
+				WebhooksQuota,
+				"quotas.maxWebhooks",
+				10,
+				hub.ErrInvalidInput,
+			},
+			{
+				APIKeysQuota,
+				"quotas.maxAPIKeys",
+				2,
+				nil,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(string(tc.kind), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgQuotasDBQ, "userID", "org1").Return(usageJSON, nil)
+				cfg := viper.New()
+				cfg.Set(tc.cfgKey, tc.cfgValue)
+
+				err := CheckQuota(ctx, db, cfg, "userID", "org1", tc.kind)
+				if tc.expectedErr == nil {
+					assert.NoError(t, err)
+				} else {
+					assert.True(t, errors.Is(err, tc.expectedErr))
+				}
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgQuotasDBQ, "userID", "org1").Return(nil, tests.ErrFakeDB)
+
+		err := CheckQuota(ctx, db, viper.New(), "userID", "org1", RepositoriesQuota)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
@@ -3,37 +3,89 @@ package pkg
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/sbom"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
 	"github.com/satori/uuid"
 )
 
 const (
 	// Database queries
+	getDependenciesTreeDBQ          = `select dependencies_tree from snapshot where package_id = $1 and version = $2`
 	getHarborReplicationDumpDBQ     = `select get_harbor_replication_dump()`
+	getOrgStalePkgsDBQ              = `select * from get_org_stale_packages($1::uuid, $2::text, $3::int, $4::int)`
 	getPkgDBQ                       = `select get_package($1::jsonb)`
+	getPkgByDigestDBQ               = `select get_package_by_digest($1::text)`
 	getPkgChangeLogDBQ              = `select get_package_changelog($1::uuid)`
+	getPkgEventsDBQ                 = `select get_package_events($1::uuid)`
+	getPkgHeadDBQ                   = `select get_package_head($1::jsonb)`
 	getPkgStarsDBQ                  = `select get_package_stars($1::uuid, $2::uuid)`
 	getPkgSummaryDBQ                = `select get_package_summary($1::jsonb)`
+	getPkgsChangesDBQ               = `select get_packages_changes($1::jsonb)`
+	getPkgsComparisonDBQ            = `select get_packages_comparison($1::uuid[])`
+	getPkgsDiscoveryDBQ             = `select get_packages_discovery($1::text)`
 	getPkgsStarredByUserDBQ         = `select * from get_packages_starred_by_user($1::uuid, $2::int, $3::int)`
 	getPkgsStatsDBQ                 = `select get_packages_stats()`
-	getSnapshotSecurityReportDBQ    = `select security_report from snapshot where package_id = $1 and version = $2`
+	getSecurityReportsToMigrateDBQ  = `select get_security_reports_to_migrate($1::int)`
+	getSnapshotSBOMDBQ              = `select sbom, sbom_format, sbom_location from snapshot where package_id = $1 and version = $2`
+	getSnapshotSecurityReportDBQ    = `select security_report, security_report_location from snapshot where package_id = $1 and version = $2`
 	getSnapshotsToScanDBQ           = `select get_snapshots_to_scan()`
+	getSnapshotsWithoutLogoDBQ      = `select get_snapshots_without_logo($1::int)`
+	getUserStalePkgsDBQ             = `select * from get_user_stale_packages($1::uuid, $2::int, $3::int)`
 	getRandomPkgsDBQ                = `select get_random_packages()`
+	getValuesDocsDBQ                = `select values_docs from snapshot where package_id = $1 and version = $2`
 	getValuesSchemaDBQ              = `select values_schema from snapshot where package_id = $1 and version = $2`
+	refreshPkgsDiscoveryDBQ         = `select refresh_packages_discovery($1::int, $2::int, $3::int)`
 	registerPkgDBQ                  = `select register_package($1::jsonb)`
 	searchPkgsDBQ                   = `select * from search_packages($1::jsonb)`
 	searchPkgsMonocularDBQ          = `select search_packages_monocular($1::text, $2::text)`
+	setSnapshotGeneratedLogoDBQ     = `select set_snapshot_generated_logo($1::uuid, $2::text, $3::uuid)`
+	setSnapshotTestResultsDBQ       = `select set_snapshot_test_results($1::text, $2::text, $3::text, $4::jsonb)`
 	togglePkgStarDBQ                = `select toggle_star($1::uuid, $2::uuid)`
+	updatePkgsFreshnessDBQ          = `select update_packages_freshness($1::real, $2::int)`
 	updateSnapshotSecurityReportDBQ = `select update_snapshot_security_report($1::jsonb)`
+	updateSecurityReportLocDBQ      = `update snapshot set security_report = null, security_report_location = $3 where package_id = $1 and version = $2`
 	unregisterPkgDBQ                = `select unregister_package($1::jsonb)`
 )
 
+const (
+	// MaxChangesLimit represents the maximum number of changes that can be
+	// requested at once when getting the packages changes.
+	MaxChangesLimit = 1000
+
+	// MaxComparisonPackages represents the maximum number of packages that can
+	// be compared at once.
+	MaxComparisonPackages = 5
+
+	// staleReleaseCadenceFactor represents how many times the median release
+	// cadence of a repository kind must elapse without new releases for a
+	// package of that kind to be considered stale.
+	staleReleaseCadenceFactor = 3
+
+	// staleMinDays represents the minimum number of days without new releases
+	// required for a package to be considered stale.
+	staleMinDays = 90
+
+	// discoveryLimit represents the maximum number of packages included in
+	// each of the discovery categories.
+	discoveryLimit = 24
+
+	// hiddenGemsMaxStars represents the maximum number of stars a package can
+	// have to be considered a hidden gem.
+	hiddenGemsMaxStars = 5
+
+	// hiddenGemsMinQualityScore represents the minimum quality score (out of
+	// 7) a package must have to be considered a hidden gem.
+	hiddenGemsMinQualityScore = 4
+)
+
 var (
 	validCapabilities = []string{
 		"basic install",
@@ -42,18 +94,42 @@ var (
 		"deep insights",
 		"auto pilot",
 	}
+	validDiscoveryCategories = []string{
+		"new-this-week",
+		"recently-verified",
+		"hidden-gems",
+	}
+)
+
+var (
+	// errReportsStoreNotConfigured indicates that a report is located in the
+	// reports store but no reports store has been configured.
+	errReportsStoreNotConfigured = errors.New("reports store not configured")
 )
 
 // Manager provides an API to manage packages.
 type Manager struct {
 	db hub.DB
+	rs hub.ReportsStore
 }
 
 // NewManager creates a new Manager instance.
-func NewManager(db hub.DB) *Manager {
-	return &Manager{
+func NewManager(db hub.DB, opts ...func(m *Manager)) *Manager {
+	m := &Manager{
 		db: db,
 	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m
+}
+
+// WithReportsStore allows providing a reports store where the security reports
+// will be placed instead of in the database.
+func WithReportsStore(rs hub.ReportsStore) func(m *Manager) {
+	return func(m *Manager) {
+		m.rs = rs
+	}
 }
 
 // Get returns the package identified by the input provided.
@@ -69,18 +145,117 @@ func (m *Manager) Get(ctx context.Context, input *hub.GetPackageInput) (*hub.Pac
 	return p, nil
 }
 
+// GetByDigestJSON returns some details of the package version that matches
+// the digest provided as a json object. The json object is built by the
+// database.
+func (m *Manager) GetByDigestJSON(ctx context.Context, digest string) ([]byte, error) {
+	// Validate input
+	if digest == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "digest not provided")
+	}
+
+	// Get package from database
+	return util.DBQueryJSON(ctx, m.db, getPkgByDigestDBQ, digest)
+}
+
 // GetChangeLogJSON returns the changelog for the package identified by the id
 // provided.
 func (m *Manager) GetChangeLogJSON(ctx context.Context, pkgID string) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getPkgChangeLogDBQ, pkgID)
 }
 
+// GetChangesJSON returns the changes registered in the packages catalog
+// (packages added, updated or removed) that match the input provided, sorted
+// by sequence number. The json array is built by the database.
+func (m *Manager) GetChangesJSON(ctx context.Context, input *hub.GetPackagesChangesInput) ([]byte, error) {
+	// Validate input
+	if input.Since < 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid since")
+	}
+	if input.After < 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid after")
+	}
+	if input.Limit <= 0 || input.Limit > MaxChangesLimit {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid limit")
+	}
+
+	// Get packages changes from database
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSON(ctx, m.db, getPkgsChangesDBQ, inputJSON)
+}
+
+// GetComparisonJSON returns a json array with some normalized details of the
+// packages provided (latest version, license, capabilities, security rating,
+// stars, etc), so that they can be compared side by side. The json array is
+// built by the database.
+func (m *Manager) GetComparisonJSON(ctx context.Context, pkgsIDs []string) ([]byte, error) {
+	// Validate input
+	if len(pkgsIDs) < 2 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "at least two packages must be provided")
+	}
+	if len(pkgsIDs) > MaxComparisonPackages {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "too many packages")
+	}
+	seen := make(map[string]struct{}, len(pkgsIDs))
+	for _, pkgID := range pkgsIDs {
+		if _, err := uuid.FromString(pkgID); err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+		}
+		if _, ok := seen[pkgID]; ok {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "duplicated package id")
+		}
+		seen[pkgID] = struct{}{}
+	}
+
+	// Get packages details from database
+	return util.DBQueryJSON(ctx, m.db, getPkgsComparisonDBQ, pkgsIDs)
+}
+
+// GetDependenciesTreeJSON returns the dependencies tree of the package's
+// snapshot identified by the package id and version provided.
+func (m *Manager) GetDependenciesTreeJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
+	return util.DBQueryJSON(ctx, m.db, getDependenciesTreeDBQ, pkgID, version)
+}
+
+// GetDiscoveryJSON returns a json array with the packages included in the
+// discovery category provided. The json array is built by the database.
+func (m *Manager) GetDiscoveryJSON(ctx context.Context, category string) ([]byte, error) {
+	// Validate input
+	if !isValidDiscoveryCategory(category) {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid category")
+	}
+
+	// Get packages from database
+	return util.DBQueryJSON(ctx, m.db, getPkgsDiscoveryDBQ, category)
+}
+
+// GetEventsJSON returns the timeline of events (new releases, security alerts,
+// deprecations, etc) of the package identified by the id provided.
+func (m *Manager) GetEventsJSON(ctx context.Context, pkgID string) ([]byte, error) {
+	return util.DBQueryJSON(ctx, m.db, getPkgEventsDBQ, pkgID)
+}
+
 // GetHarborReplicationDumpJSON returns a json list with all packages versions
 // of kind Helm available so that they can be synchronized in Harbor.
 func (m *Manager) GetHarborReplicationDumpJSON(ctx context.Context) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getHarborReplicationDumpDBQ)
 }
 
+// GetHead returns some information about the package version identified by
+// the input provided that allows checking cheaply if it has changed.
+func (m *Manager) GetHead(ctx context.Context, input *hub.GetPackageInput) (*hub.ResourceHead, error) {
+	// Validate input
+	if input.PackageName == "" || input.RepositoryName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package name not provided")
+	}
+
+	// Get package head from database
+	inputJSON, _ := json.Marshal(input)
+	var h *hub.ResourceHead
+	err := util.DBQueryUnmarshal(ctx, m.db, &h, getPkgHeadDBQ, inputJSON)
+	return h, err
+}
+
 // GetJSON returns the package identified by the input provided as a json
 // object. The json object is built by the database.
 func (m *Manager) GetJSON(ctx context.Context, input *hub.GetPackageInput) ([]byte, error) {
@@ -100,10 +275,74 @@ func (m *Manager) GetRandomJSON(ctx context.Context) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getRandomPkgsDBQ)
 }
 
+// GetSnapshotSBOM returns the SBOM of the package's snapshot identified by
+// the package id and version provided, converted to the format requested when
+// needed. When no format is provided, the SBOM is returned in the format it
+// was originally provided.
+func (m *Manager) GetSnapshotSBOM(
+	ctx context.Context,
+	pkgID,
+	version string,
+	format hub.SBOMFormat,
+) ([]byte, error) {
+	// Validate input
+	if format != "" && format != hub.SPDX && format != hub.CycloneDX {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid format (spdx|cyclonedx)")
+	}
+
+	// Get SBOM from database
+	var data []byte
+	var sbomFormat, location *string
+	err := m.db.QueryRow(ctx, getSnapshotSBOMDBQ, pkgID, version).Scan(&data, &sbomFormat, &location)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, hub.ErrNotFound
+		}
+		return nil, err
+	}
+	if sbomFormat == nil {
+		return nil, hub.ErrNotFound
+	}
+
+	// Load SBOM from the reports store when it has been placed there
+	if location != nil {
+		if m.rs == nil {
+			return nil, errReportsStoreNotConfigured
+		}
+		data, err = m.rs.Get(ctx, *location)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Convert SBOM to the format requested if needed
+	if format == "" || format == hub.SBOMFormat(*sbomFormat) {
+		return data, nil
+	}
+	return sbom.Convert(data, format)
+}
+
 // GetSnapshotSecurityReportJSON returns the security report of the package's
 // snapshot identified by the package id and version provided.
 func (m *Manager) GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
-	return util.DBQueryJSON(ctx, m.db, getSnapshotSecurityReportDBQ, pkgID, version)
+	var reportJSON []byte
+	var location *string
+	err := m.db.QueryRow(ctx, getSnapshotSecurityReportDBQ, pkgID, version).Scan(&reportJSON, &location)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, hub.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Load report from the reports store when it has been placed there
+	if location != nil {
+		if m.rs == nil {
+			return nil, errReportsStoreNotConfigured
+		}
+		return m.rs.Get(ctx, *location)
+	}
+	return reportJSON, nil
 }
 
 // GetSnapshotsToScan returns the packages' snapshots that need to be scanned
@@ -114,6 +353,46 @@ func (m *Manager) GetSnapshotsToScan(ctx context.Context) ([]*hub.SnapshotToScan
 	return s, err
 }
 
+// GetSnapshotsWithoutLogo returns up to limit snapshots of the latest version
+// of packages that have no logo.
+func (m *Manager) GetSnapshotsWithoutLogo(ctx context.Context, limit int) ([]*hub.SnapshotWithoutLogo, error) {
+	// Validate input
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid limit (l > 0)")
+	}
+
+	// Get snapshots from database
+	var s []*hub.SnapshotWithoutLogo
+	err := util.DBQueryUnmarshal(ctx, m.db, &s, getSnapshotsWithoutLogoDBQ, limit)
+	return s, err
+}
+
+// GetStaleByOrgJSON returns a json object with the stale packages of the
+// organization provided. The user doing the request must belong to the
+// organization. The json object is built by the database.
+func (m *Manager) GetStaleByOrgJSON(
+	ctx context.Context,
+	orgName string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get stale packages from database
+	return util.DBQueryJSONWithPagination(ctx, m.db, getOrgStalePkgsDBQ, userID, orgName, p.Limit, p.Offset)
+}
+
+// GetStaleByUserJSON returns a json object with the stale packages of the
+// user doing the request. The json object is built by the database.
+func (m *Manager) GetStaleByUserJSON(ctx context.Context, p *hub.Pagination) (*hub.JSONQueryResult, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	return util.DBQueryJSONWithPagination(ctx, m.db, getUserStalePkgsDBQ, userID, p.Limit, p.Offset)
+}
+
 // GetStarredByUserJSON returns a json object with packages starred by the user
 // doing the request. The json object is built by the database.
 func (m *Manager) GetStarredByUserJSON(ctx context.Context, p *hub.Pagination) (*hub.JSONQueryResult, error) {
@@ -156,12 +435,25 @@ func (m *Manager) GetSummaryJSON(ctx context.Context, input *hub.GetPackageInput
 	return util.DBQueryJSON(ctx, m.db, getPkgSummaryDBQ, inputJSON)
 }
 
+// GetValuesDocsJSON returns the values documentation of the package's
+// snapshot identified by the package id and version provided.
+func (m *Manager) GetValuesDocsJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
+	return util.DBQueryJSON(ctx, m.db, getValuesDocsDBQ, pkgID, version)
+}
+
 // GetValuesSchemaJSON returns the values schema of the package's snapshot
 // identified by the package id and version provided.
 func (m *Manager) GetValuesSchemaJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getValuesSchemaDBQ, pkgID, version)
 }
 
+// RefreshDiscovery refreshes the packages included in each of the discovery
+// categories (new this week, recently verified publishers and hidden gems).
+func (m *Manager) RefreshDiscovery(ctx context.Context) error {
+	_, err := m.db.Exec(ctx, refreshPkgsDiscoveryDBQ, discoveryLimit, hiddenGemsMaxStars, hiddenGemsMinQualityScore)
+	return err
+}
+
 // Register registers the package provided in the database.
 func (m *Manager) Register(ctx context.Context, pkg *hub.Package) error {
 	// Validate input
@@ -220,6 +512,27 @@ func (m *Manager) Register(ctx context.Context, pkg *hub.Package) error {
 		}
 	}
 
+	if pkg.SBOM != nil {
+		if pkg.SBOM.Format != hub.SPDX && pkg.SBOM.Format != hub.CycloneDX {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid sbom format")
+		}
+		if len(pkg.SBOM.Data) == 0 {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "sbom data not provided")
+		}
+	}
+
+	// Place SBOM in the reports store when available
+	if m.rs != nil && pkg.SBOM != nil {
+		key := getSBOMKey(pkg.Repository.RepositoryID, pkg.Name, pkg.Version)
+		location, err := m.rs.Put(ctx, key, pkg.SBOM.Data)
+		if err != nil {
+			return fmt.Errorf("error storing sbom: %w", err)
+		}
+		pkgCopy := *pkg
+		pkgCopy.SBOM = &hub.SBOM{Format: pkg.SBOM.Format, Location: location}
+		pkg = &pkgCopy
+	}
+
 	// Register package in database
 	pkgJSON, err := json.Marshal(pkg)
 	if err != nil {
@@ -239,8 +552,8 @@ func (m *Manager) SearchJSON(ctx context.Context, input *hub.SearchPackageInput)
 	if input.Offset < 0 {
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset (o >= 0)")
 	}
-	if input.Sort != "" && input.Sort != "relevance" && input.Sort != "stars" {
-		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid sort (relevance|stars)")
+	if input.Sort != "" && input.Sort != "relevance" && input.Sort != "stars" && input.Sort != "last_release" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid sort (relevance|stars|last_release)")
 	}
 	for _, alias := range input.Users {
 		if alias == "" {
@@ -257,6 +570,11 @@ func (m *Manager) SearchJSON(ctx context.Context, input *hub.SearchPackageInput)
 			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository name")
 		}
 	}
+	for _, category := range input.Categories {
+		if !hub.IsValidPackageCategory(category) {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid category")
+		}
+	}
 
 	// Search packages in database
 	inputJSON, _ := json.Marshal(input)
@@ -270,6 +588,62 @@ func (m *Manager) SearchMonocularJSON(ctx context.Context, baseURL, tsQueryWeb s
 	return util.DBQueryJSON(ctx, m.db, searchPkgsMonocularDBQ, baseURL, tsQueryWeb)
 }
 
+// SetSnapshotGeneratedLogo sets the generated logo image provided to the
+// package version identified by the package id and version provided, as long
+// as it still has no logo.
+func (m *Manager) SetSnapshotGeneratedLogo(ctx context.Context, pkgID, version, logoImageID string) error {
+	// Validate input
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+	if _, err := uuid.FromString(logoImageID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid logo image id")
+	}
+
+	// Set generated logo in database
+	_, err := m.db.Exec(ctx, setSnapshotGeneratedLogoDBQ, pkgID, version, logoImageID)
+	return err
+}
+
+// SetTestResults sets the test results of the package version identified by
+// the repository name, package name and version provided.
+func (m *Manager) SetTestResults(
+	ctx context.Context,
+	repoName,
+	pkgName,
+	version string,
+	tr *hub.TestResults,
+) error {
+	// Validate input
+	if repoName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+	if pkgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package name not provided")
+	}
+	if _, err := semver.NewVersion(version); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid version")
+	}
+	if tr == nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "test results not provided")
+	}
+
+	// Set test results in database
+	trJSON, _ := json.Marshal(tr)
+	var found bool
+	err := m.db.QueryRow(ctx, setSnapshotTestResultsDBQ, repoName, pkgName, version, trJSON).Scan(&found)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return hub.ErrNotFound
+	}
+	return nil
+}
+
 // ToggleStar stars or unstars a given package for the provided user.
 func (m *Manager) ToggleStar(ctx context.Context, packageID string) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -301,12 +675,57 @@ func (m *Manager) UpdateSnapshotSecurityReport(ctx context.Context, r *hub.Snaps
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
 	}
 
+	// Place images reports in the reports store when available
+	if m.rs != nil && r.ImagesReports != nil {
+		imagesReportsJSON, _ := json.Marshal(r.ImagesReports)
+		location, err := m.rs.Put(ctx, getSecurityReportKey(r.PackageID, r.Version), imagesReportsJSON)
+		if err != nil {
+			return fmt.Errorf("error storing security report: %w", err)
+		}
+		rCopy := *r
+		rCopy.ImagesReports = nil
+		rCopy.ImagesReportsLocation = location
+		r = &rCopy
+	}
+
 	// Update snapshot security report in database
 	rJSON, _ := json.Marshal(r)
 	_, err := m.db.Exec(ctx, updateSnapshotSecurityReportDBQ, rJSON)
 	return err
 }
 
+// MigrateSecurityReports moves up to limit security reports stored in the
+// database to the reports store, returning the number of reports migrated.
+func (m *Manager) MigrateSecurityReports(ctx context.Context, limit int) (int, error) {
+	// Validate input
+	if m.rs == nil {
+		return 0, errReportsStoreNotConfigured
+	}
+	if limit <= 0 {
+		return 0, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid limit (l > 0)")
+	}
+
+	// Get security reports to migrate from database
+	var reports []*hub.SecurityReportToMigrate
+	if err := util.DBQueryUnmarshal(ctx, m.db, &reports, getSecurityReportsToMigrateDBQ, limit); err != nil {
+		return 0, err
+	}
+
+	// Place reports in the reports store and update their location
+	var migrated int
+	for _, r := range reports {
+		location, err := m.rs.Put(ctx, getSecurityReportKey(r.PackageID, r.Version), r.ImagesReports)
+		if err != nil {
+			return migrated, fmt.Errorf("error storing security report: %w", err)
+		}
+		if _, err := m.db.Exec(ctx, updateSecurityReportLocDBQ, r.PackageID, r.Version, location); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+	return migrated, nil
+}
+
 // Unregister unregisters the package provided from the database.
 func (m *Manager) Unregister(ctx context.Context, pkg *hub.Package) error {
 	// Validate input
@@ -326,6 +745,13 @@ func (m *Manager) Unregister(ctx context.Context, pkg *hub.Package) error {
 	return err
 }
 
+// UpdateFreshness refreshes the release cadence of each repository kind as
+// well as the last release and stale status of all packages.
+func (m *Manager) UpdateFreshness(ctx context.Context) error {
+	_, err := m.db.Exec(ctx, updatePkgsFreshnessDBQ, staleReleaseCadenceFactor, staleMinDays)
+	return err
+}
+
 // BuildKey returns a key that identifies a concrete package version.
 func BuildKey(p *hub.Package) string {
 	return p.Name + "@" + p.Version
@@ -357,3 +783,25 @@ func areValidCapabilities(capabilities string) bool {
 	}
 	return false
 }
+
+// isValidDiscoveryCategory checks if the provided discovery category is valid.
+func isValidDiscoveryCategory(category string) bool {
+	for _, validCategory := range validDiscoveryCategories {
+		if category == validCategory {
+			return true
+		}
+	}
+	return false
+}
+
+// getSBOMKey returns the key used to place the SBOM of the package version
+// provided in the reports store.
+func getSBOMKey(repositoryID, name, version string) string {
+	return fmt.Sprintf("sboms/%s/%s/%s.json", repositoryID, name, version)
+}
+
+// getSecurityReportKey returns the key used to place the security report of
+// the package version provided in the reports store.
+func getSecurityReportKey(pkgID, version string) string {
+	return fmt.Sprintf("security-reports/%s/%s.json", pkgID, version)
+}
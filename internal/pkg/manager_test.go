@@ -8,6 +8,7 @@ import (
 
 	trivyreport "github.com/aquasecurity/trivy/pkg/report"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/reports"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -266,6 +267,41 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestGetByDigestJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetByDigestJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgByDigestDBQ, "digest").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetByDigestJSON(ctx, "digest")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgByDigestDBQ, "digest").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetByDigestJSON(ctx, "digest")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetChangeLogJSON(t *testing.T) {
 	ctx := context.Background()
 
@@ -294,6 +330,236 @@ func TestGetChangeLogJSON(t *testing.T) {
 	})
 }
 
+func TestGetChangesJSON(t *testing.T) {
+	ctx := context.Background()
+	input := &hub.GetPackagesChangesInput{
+		Since: 1592299233,
+		After: 10,
+		Limit: 100,
+	}
+	inputJSON, _ := json.Marshal(input)
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.GetPackagesChangesInput
+		}{
+			{
+				"invalid since",
+				&hub.GetPackagesChangesInput{Since: -1, Limit: 10},
+			},
+			{
+				"invalid after",
+				&hub.GetPackagesChangesInput{After: -1, Limit: 10},
+			},
+			{
+				"invalid limit",
+				&hub.GetPackagesChangesInput{},
+			},
+			{
+				"invalid limit",
+				&hub.GetPackagesChangesInput{Limit: MaxChangesLimit + 1},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				dataJSON, err := m.GetChangesJSON(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				assert.Nil(t, dataJSON)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgsChangesDBQ, inputJSON).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetChangesJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgsChangesDBQ, inputJSON).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetChangesJSON(ctx, input)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetComparisonJSON(t *testing.T) {
+	ctx := context.Background()
+	pkg1ID := "00000000-0000-0000-0000-000000000001"
+	pkg2ID := "00000000-0000-0000-0000-000000000002"
+	pkgsIDs := []string{pkg1ID, pkg2ID}
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			pkgsIDs []string
+		}{
+			{
+				"at least two packages must be provided",
+				nil,
+			},
+			{
+				"at least two packages must be provided",
+				[]string{pkg1ID},
+			},
+			{
+				"too many packages",
+				[]string{pkg1ID, pkg2ID, pkg1ID, pkg2ID, pkg1ID, pkg2ID},
+			},
+			{
+				"invalid package id",
+				[]string{pkg1ID, "invalid"},
+			},
+			{
+				"duplicated package id",
+				[]string{pkg1ID, pkg1ID},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				dataJSON, err := m.GetComparisonJSON(ctx, tc.pkgsIDs)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				assert.Nil(t, dataJSON)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgsComparisonDBQ, pkgsIDs).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetComparisonJSON(ctx, pkgsIDs)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgsComparisonDBQ, pkgsIDs).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetComparisonJSON(ctx, pkgsIDs)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetDependenciesTreeJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getDependenciesTreeDBQ, "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetDependenciesTreeJSON(ctx, "pkg1", "1.0.0")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getDependenciesTreeDBQ, "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetDependenciesTreeJSON(ctx, "pkg1", "1.0.0")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetDiscoveryJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid category", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetDiscoveryJSON(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgsDiscoveryDBQ, "hidden-gems").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetDiscoveryJSON(ctx, "hidden-gems")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgsDiscoveryDBQ, "new-this-week").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetDiscoveryJSON(ctx, "new-this-week")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetEventsJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgEventsDBQ, "pkg1").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetEventsJSON(ctx, "pkg1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgEventsDBQ, "pkg1").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetEventsJSON(ctx, "pkg1")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetHarborReplicationDumpJSON(t *testing.T) {
 	ctx := context.Background()
 
@@ -322,6 +588,55 @@ func TestGetHarborReplicationDumpJSON(t *testing.T) {
 	})
 }
 
+func TestGetHead(t *testing.T) {
+	ctx := context.Background()
+	input := &hub.GetPackageInput{
+		RepositoryName: "repo1",
+		PackageName:    "pkg1",
+		Version:        "1.0.0",
+	}
+	inputJSON, _ := json.Marshal(input)
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetHead(ctx, &hub.GetPackageInput{PackageID: "pkgID"})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgHeadDBQ, inputJSON).Return([]byte(`
+		{
+			"digest": "digest",
+			"last_modified": 1592299233
+		}
+		`), nil)
+		m := NewManager(db)
+
+		h, err := m.GetHead(ctx, input)
+		require.NoError(t, err)
+		assert.Equal(t, &hub.ResourceHead{
+			Digest:       "digest",
+			LastModified: 1592299233,
+		}, h)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgHeadDBQ, inputJSON).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		h, err := m.GetHead(ctx, input)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, h)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetJSON(t *testing.T) {
 	ctx := context.Background()
 	input := &hub.GetPackageInput{
@@ -390,6 +705,86 @@ func TestGetRandomJSON(t *testing.T) {
 	})
 }
 
+func TestGetSnapshotSBOM(t *testing.T) {
+	ctx := context.Background()
+	spdxDoc := []byte(`{"spdxVersion": "SPDX-2.3", "SPDXID": "SPDXRef-DOCUMENT", "name": "pkg1"}`)
+	spdxFormat := string(hub.SPDX)
+
+	t.Run("invalid format", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetSnapshotSBOM(ctx, "pkg1", "1.0.0", "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotSBOMDBQ, "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		data, err := m.GetSnapshotSBOM(ctx, "pkg1", "1.0.0", "")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, data)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("snapshot does not have a sbom", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotSBOMDBQ, "pkg1", "1.0.0").Return([]interface{}{nil, nil, nil}, nil)
+		m := NewManager(db)
+
+		data, err := m.GetSnapshotSBOM(ctx, "pkg1", "1.0.0", "")
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, data)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("sbom returned in its original format", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotSBOMDBQ, "pkg1", "1.0.0").
+			Return([]interface{}{spdxDoc, &spdxFormat, nil}, nil)
+		m := NewManager(db)
+
+		data, err := m.GetSnapshotSBOM(ctx, "pkg1", "1.0.0", hub.SPDX)
+		assert.NoError(t, err)
+		assert.Equal(t, spdxDoc, data)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("sbom converted to the format requested", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotSBOMDBQ, "pkg1", "1.0.0").
+			Return([]interface{}{spdxDoc, &spdxFormat, nil}, nil)
+		m := NewManager(db)
+
+		data, err := m.GetSnapshotSBOM(ctx, "pkg1", "1.0.0", hub.CycloneDX)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `"bomFormat":"CycloneDX"`)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("sbom loaded from reports store", func(t *testing.T) {
+		t.Parallel()
+		location := "s3://bucket/sboms/repo1/pkg1/1.0.0.json"
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotSBOMDBQ, "pkg1", "1.0.0").
+			Return([]interface{}{nil, &spdxFormat, &location}, nil)
+		rs := &reports.StoreMock{}
+		rs.On("Get", ctx, location).Return(spdxDoc, nil)
+		m := NewManager(db, WithReportsStore(rs))
+
+		data, err := m.GetSnapshotSBOM(ctx, "pkg1", "1.0.0", "")
+		assert.NoError(t, err)
+		assert.Equal(t, spdxDoc, data)
+		db.AssertExpectations(t)
+		rs.AssertExpectations(t)
+	})
+}
+
 func TestGetSnapshotSecurityReportJSON(t *testing.T) {
 	ctx := context.Background()
 
@@ -416,50 +811,218 @@ func TestGetSnapshotSecurityReportJSON(t *testing.T) {
 		assert.Nil(t, dataJSON)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("report located in reports store, reports store not configured", func(t *testing.T) {
+		t.Parallel()
+		location := "s3://bucket/security-reports/pkg1/1.0.0.json"
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotSecurityReportDBQ, "pkg1", "1.0.0").
+			Return([]interface{}{nil, &location}, nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetSnapshotSecurityReportJSON(ctx, "pkg1", "1.0.0")
+		assert.Equal(t, errReportsStoreNotConfigured, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("report loaded from reports store", func(t *testing.T) {
+		t.Parallel()
+		location := "s3://bucket/security-reports/pkg1/1.0.0.json"
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotSecurityReportDBQ, "pkg1", "1.0.0").
+			Return([]interface{}{nil, &location}, nil)
+		rs := &reports.StoreMock{}
+		rs.On("Get", ctx, location).Return([]byte("dataJSON"), nil)
+		m := NewManager(db, WithReportsStore(rs))
+
+		dataJSON, err := m.GetSnapshotSecurityReportJSON(ctx, "pkg1", "1.0.0")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+		rs.AssertExpectations(t)
+	})
 }
 
 func TestGetSnapshotsToScan(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("database error", func(t *testing.T) {
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotsToScanDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		s, err := m.GetSnapshotsToScan(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, s)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotsToScanDBQ).Return([]byte(`
+		[
+			{
+				"package_id": "00000000-0000-0000-0000-000000000001",
+				"version": "1.0.0",
+				"containers_images": [
+					{
+						"name": "image1",
+						"image": "organization/image:tag"
+					}
+				]
+			}
+		]
+		`), nil)
+		m := NewManager(db)
+
+		s, err := m.GetSnapshotsToScan(ctx)
+		assert.NoError(t, err)
+		require.Len(t, s, 1)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000001", s[0].PackageID)
+		assert.Equal(t, "1.0.0", s[0].Version)
+		require.Len(t, s[0].ContainersImages, 1)
+		assert.Equal(t, "image1", s[0].ContainersImages[0].Name)
+		assert.Equal(t, "organization/image:tag", s[0].ContainersImages[0].Image)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetSnapshotsWithoutLogo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid limit", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+
+		s, err := m.GetSnapshotsWithoutLogo(ctx, 0)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Nil(t, s)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotsWithoutLogoDBQ, 10).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		s, err := m.GetSnapshotsWithoutLogo(ctx, 10)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, s)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotsWithoutLogoDBQ, 10).Return([]byte(`
+		[
+			{
+				"repository_id": "00000000-0000-0000-0000-000000000001",
+				"package_id": "00000000-0000-0000-0000-000000000001",
+				"package_name": "pkg1",
+				"version": "1.0.0"
+			}
+		]
+		`), nil)
+		m := NewManager(db)
+
+		s, err := m.GetSnapshotsWithoutLogo(ctx, 10)
+		assert.NoError(t, err)
+		require.Len(t, s, 1)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000001", s[0].RepositoryID)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000001", s[0].PackageID)
+		assert.Equal(t, "pkg1", s[0].PackageName)
+		assert.Equal(t, "1.0.0", s[0].Version)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetStaleByOrgJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetStaleByOrgJSON(context.Background(), "orgName", p)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		result, err := m.GetStaleByOrgJSON(ctx, "", p)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "organization name not provided")
+		assert.Nil(t, result)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgStalePkgsDBQ, "userID", "orgName", 10, 1).
+			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(db)
+
+		result, err := m.GetStaleByOrgJSON(ctx, "orgName", p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgStalePkgsDBQ, "userID", "orgName", 10, 1).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		result, err := m.GetStaleByOrgJSON(ctx, "orgName", p)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, result)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetStaleByUserJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetStaleByUserJSON(context.Background(), p)
+		})
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getSnapshotsToScanDBQ).Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getUserStalePkgsDBQ, "userID", 10, 1).
+			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
 		m := NewManager(db)
 
-		s, err := m.GetSnapshotsToScan(ctx)
-		assert.Equal(t, tests.ErrFakeDB, err)
-		assert.Nil(t, s)
+		result, err := m.GetStaleByUserJSON(ctx, p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
 		db.AssertExpectations(t)
 	})
 
-	t.Run("database query succeeded", func(t *testing.T) {
+	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getSnapshotsToScanDBQ).Return([]byte(`
-		[
-			{
-				"package_id": "00000000-0000-0000-0000-000000000001",
-				"version": "1.0.0",
-				"containers_images": [
-					{
-						"name": "image1",
-						"image": "organization/image:tag"
-					}
-				]
-			}
-		]
-		`), nil)
+		db.On("QueryRow", ctx, getUserStalePkgsDBQ, "userID", 10, 1).Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
-		s, err := m.GetSnapshotsToScan(ctx)
-		assert.NoError(t, err)
-		require.Len(t, s, 1)
-		assert.Equal(t, "00000000-0000-0000-0000-000000000001", s[0].PackageID)
-		assert.Equal(t, "1.0.0", s[0].Version)
-		require.Len(t, s[0].ContainersImages, 1)
-		assert.Equal(t, "image1", s[0].ContainersImages[0].Name)
-		assert.Equal(t, "organization/image:tag", s[0].ContainersImages[0].Image)
+		result, err := m.GetStaleByUserJSON(ctx, p)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, result)
 		db.AssertExpectations(t)
 	})
 }
@@ -619,6 +1182,34 @@ func TestGetSummaryJSON(t *testing.T) {
 	})
 }
 
+func TestGetValuesDocsJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getValuesDocsDBQ, "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetValuesDocsJSON(ctx, "pkg1", "1.0.0")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getValuesDocsDBQ, "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetValuesDocsJSON(ctx, "pkg1", "1.0.0")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetValuesSchemaJSON(t *testing.T) {
 	ctx := context.Background()
 
@@ -647,6 +1238,104 @@ func TestGetValuesSchemaJSON(t *testing.T) {
 	})
 }
 
+func TestMigrateSecurityReports(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reports store not configured", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+
+		_, err := m.MigrateSecurityReports(ctx, 10)
+		assert.Equal(t, errReportsStoreNotConfigured, err)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, WithReportsStore(&reports.StoreMock{}))
+
+		_, err := m.MigrateSecurityReports(ctx, 0)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error getting reports", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSecurityReportsToMigrateDBQ, 10).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, WithReportsStore(&reports.StoreMock{}))
+
+		migrated, err := m.MigrateSecurityReports(ctx, 10)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Equal(t, 0, migrated)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error storing report", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSecurityReportsToMigrateDBQ, 10).Return([]byte(`
+		[{"package_id": "pkg1", "version": "1.0.0", "images_reports": {"image": {}}}]
+		`), nil)
+		rs := &reports.StoreMock{}
+		rs.On("Put", ctx, "security-reports/pkg1/1.0.0.json", mock.Anything).Return("", tests.ErrFake)
+		m := NewManager(db, WithReportsStore(rs))
+
+		migrated, err := m.MigrateSecurityReports(ctx, 10)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		assert.Equal(t, 0, migrated)
+		db.AssertExpectations(t)
+		rs.AssertExpectations(t)
+	})
+
+	t.Run("reports migrated successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSecurityReportsToMigrateDBQ, 10).Return([]byte(`
+		[
+			{"package_id": "pkg1", "version": "1.0.0", "images_reports": {"image1": {}}},
+			{"package_id": "pkg2", "version": "2.0.0", "images_reports": {"image2": {}}}
+		]
+		`), nil)
+		db.On("Exec", ctx, updateSecurityReportLocDBQ, "pkg1", "1.0.0", "s3://bucket/pkg1").Return(nil)
+		db.On("Exec", ctx, updateSecurityReportLocDBQ, "pkg2", "2.0.0", "s3://bucket/pkg2").Return(nil)
+		rs := &reports.StoreMock{}
+		rs.On("Put", ctx, "security-reports/pkg1/1.0.0.json", []byte(`{"image1": {}}`)).Return("s3://bucket/pkg1", nil)
+		rs.On("Put", ctx, "security-reports/pkg2/2.0.0.json", []byte(`{"image2": {}}`)).Return("s3://bucket/pkg2", nil)
+		m := NewManager(db, WithReportsStore(rs))
+
+		migrated, err := m.MigrateSecurityReports(ctx, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, migrated)
+		db.AssertExpectations(t)
+		rs.AssertExpectations(t)
+	})
+}
+
+func TestRefreshDiscovery(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful discovery refresh", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, refreshPkgsDiscoveryDBQ, discoveryLimit, hiddenGemsMaxStars, hiddenGemsMinQualityScore).Return(nil)
+		m := NewManager(db)
+
+		err := m.RefreshDiscovery(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, refreshPkgsDiscoveryDBQ, discoveryLimit, hiddenGemsMaxStars, hiddenGemsMinQualityScore).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.RefreshDiscovery(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestRegister(t *testing.T) {
 	ctx := context.Background()
 
@@ -806,6 +1495,32 @@ func TestRegister(t *testing.T) {
 					Capabilities: "invalid",
 				},
 			},
+			{
+				"invalid sbom format",
+				&hub.Package{
+					Name:    "package1",
+					Version: "1.0.0",
+					Repository: &hub.Repository{
+						RepositoryID: "00000000-0000-0000-0000-000000000001",
+					},
+					SBOM: &hub.SBOM{
+						Format: "invalid",
+					},
+				},
+			},
+			{
+				"sbom data not provided",
+				&hub.Package{
+					Name:    "package1",
+					Version: "1.0.0",
+					Repository: &hub.Repository{
+						RepositoryID: "00000000-0000-0000-0000-000000000001",
+					},
+					SBOM: &hub.SBOM{
+						Format: hub.SPDX,
+					},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -840,6 +1555,41 @@ func TestRegister(t *testing.T) {
 		assert.Equal(t, tests.ErrFakeDB, err)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("sbom placed in reports store", func(t *testing.T) {
+		t.Parallel()
+		p := newTestPkg()
+		p.Maintainers = p.Maintainers[:2]
+		p.SBOM = &hub.SBOM{Format: hub.SPDX, Data: []byte(`{"spdxVersion": "SPDX-2.3"}`)}
+		rs := &reports.StoreMock{}
+		key := "sboms/00000000-0000-0000-0000-000000000001/package1/1.0.0.json"
+		rs.On("Put", ctx, key, []byte(p.SBOM.Data)).Return("s3://bucket/sbom.json", nil)
+		pCopy := *p
+		pCopy.SBOM = &hub.SBOM{Format: hub.SPDX, Location: "s3://bucket/sbom.json"}
+		pCopyJSON, _ := json.Marshal(pCopy)
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerPkgDBQ, pCopyJSON).Return(nil)
+		m := NewManager(db, WithReportsStore(rs))
+
+		err := m.Register(ctx, p)
+		assert.NoError(t, err)
+		assert.NotNil(t, p.SBOM.Data)
+		db.AssertExpectations(t)
+		rs.AssertExpectations(t)
+	})
+
+	t.Run("error placing sbom in reports store", func(t *testing.T) {
+		t.Parallel()
+		p := newTestPkg()
+		p.SBOM = &hub.SBOM{Format: hub.SPDX, Data: []byte(`{"spdxVersion": "SPDX-2.3"}`)}
+		rs := &reports.StoreMock{}
+		rs.On("Put", ctx, mock.Anything, mock.Anything).Return("", tests.ErrFake)
+		m := NewManager(nil, WithReportsStore(rs))
+
+		err := m.Register(ctx, p)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		rs.AssertExpectations(t)
+	})
 }
 
 func TestSearchJSON(t *testing.T) {
@@ -881,7 +1631,7 @@ func TestSearchJSON(t *testing.T) {
 				},
 			},
 			{
-				"invalid sort (relevance|stars)",
+				"invalid sort (relevance|stars|last_release)",
 				&hub.SearchPackageInput{
 					Limit: 10,
 					Sort:  "invalid",
@@ -908,6 +1658,13 @@ func TestSearchJSON(t *testing.T) {
 					Repositories: []string{""},
 				},
 			},
+			{
+				"invalid category",
+				&hub.SearchPackageInput{
+					Limit:      10,
+					Categories: []string{"invalid"},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -978,6 +1735,121 @@ func TestSearchMonocularJSON(t *testing.T) {
 	})
 }
 
+func TestSetSnapshotGeneratedLogo(t *testing.T) {
+	ctx := context.Background()
+	pkgID := "00000000-0000-0000-0000-000000000001"
+	logoImageID := "00000000-0000-0000-0000-000000000002"
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg      string
+			pkgID       string
+			version     string
+			logoImageID string
+		}{
+			{"invalid package id", "invalid", "1.0.0", logoImageID},
+			{"version not provided", pkgID, "", logoImageID},
+			{"invalid logo image id", pkgID, "1.0.0", "invalid"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.SetSnapshotGeneratedLogo(ctx, tc.pkgID, tc.version, tc.logoImageID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, setSnapshotGeneratedLogoDBQ, pkgID, "1.0.0", logoImageID).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.SetSnapshotGeneratedLogo(ctx, pkgID, "1.0.0", logoImageID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("generated logo set successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, setSnapshotGeneratedLogoDBQ, pkgID, "1.0.0", logoImageID).Return(nil)
+		m := NewManager(db)
+
+		err := m.SetSnapshotGeneratedLogo(ctx, pkgID, "1.0.0", logoImageID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestSetTestResults(t *testing.T) {
+	ctx := context.Background()
+	tr := &hub.TestResults{Tool: "junit", Passed: 3, Failed: 1}
+	trJSON, _ := json.Marshal(tr)
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg   string
+			repoName string
+			pkgName  string
+			version  string
+			tr       *hub.TestResults
+		}{
+			{"repository name not provided", "", "pkg1", "1.0.0", tr},
+			{"package name not provided", "repo1", "", "1.0.0", tr},
+			{"invalid version", "repo1", "pkg1", "invalid", tr},
+			{"test results not provided", "repo1", "pkg1", "1.0.0", nil},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.SetTestResults(ctx, tc.repoName, tc.pkgName, tc.version, tc.tr)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("package version not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, setSnapshotTestResultsDBQ, "repo1", "pkg1", "1.0.0", trJSON).Return(false, nil)
+		m := NewManager(db)
+
+		err := m.SetTestResults(ctx, "repo1", "pkg1", "1.0.0", tr)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, setSnapshotTestResultsDBQ, "repo1", "pkg1", "1.0.0", trJSON).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.SetTestResults(ctx, "repo1", "pkg1", "1.0.0", tr)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("test results set successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, setSnapshotTestResultsDBQ, "repo1", "pkg1", "1.0.0", trJSON).Return(true, nil)
+		m := NewManager(db)
+
+		err := m.SetTestResults(ctx, "repo1", "pkg1", "1.0.0", tr)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestToggleStar(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 	pkgID := "00000000-0000-0000-0000-000000000001"
@@ -1112,6 +1984,26 @@ func TestUpdateSnapshotSecurityReport(t *testing.T) {
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("images reports placed in reports store", func(t *testing.T) {
+		t.Parallel()
+		imagesReportsJSON, _ := json.Marshal(r.ImagesReports)
+		rs := &reports.StoreMock{}
+		rs.On("Put", ctx, "security-reports/pkgID/1.0.0.json", imagesReportsJSON).Return("s3://bucket/report.json", nil)
+		rCopy := *r
+		rCopy.ImagesReports = nil
+		rCopy.ImagesReportsLocation = "s3://bucket/report.json"
+		rCopyJSON, _ := json.Marshal(rCopy)
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateSnapshotSecurityReportDBQ, rCopyJSON).Return(nil)
+		m := NewManager(db, WithReportsStore(rs))
+
+		err := m.UpdateSnapshotSecurityReport(ctx, r)
+		assert.NoError(t, err)
+		assert.NotNil(t, r.ImagesReports)
+		db.AssertExpectations(t)
+		rs.AssertExpectations(t)
+	})
 }
 
 func TestUnregister(t *testing.T) {
@@ -1182,3 +2074,29 @@ func TestUnregister(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 }
+
+func TestUpdateFreshness(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful freshness update", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updatePkgsFreshnessDBQ, staleReleaseCadenceFactor, staleMinDays).Return(nil)
+		m := NewManager(db)
+
+		err := m.UpdateFreshness(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updatePkgsFreshnessDBQ, staleReleaseCadenceFactor, staleMinDays).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.UpdateFreshness(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
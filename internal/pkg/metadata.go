@@ -68,6 +68,7 @@ func PreparePackageFromMetadata(md *hub.PackageMetadata) (*hub.Package, error) {
 		Name:                    md.Name,
 		IsOperator:              md.Operator,
 		DisplayName:             md.DisplayName,
+		Alias:                   md.Alias,
 		Description:             md.Description,
 		Keywords:                md.Keywords,
 		HomeURL:                 md.HomeURL,
@@ -82,6 +83,7 @@ func PreparePackageFromMetadata(md *hub.PackageMetadata) (*hub.Package, error) {
 		Digest:                  md.Digest,
 		Deprecated:              md.Deprecated,
 		License:                 md.License,
+		Category:                md.Category,
 		ContainersImages:        md.ContainersImages,
 		Maintainers:             md.Maintainers,
 		Recommendations:         md.Recommendations,
@@ -128,6 +130,9 @@ func ValidatePackageMetadata(md *hub.PackageMetadata) error {
 			return fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
 		}
 	}
+	if md.Category != "" && !hub.IsValidPackageCategory(md.Category) {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidMetadata, "invalid category", md.Category)
+	}
 	if err := ValidateContainersImages(md.ContainersImages); err != nil {
 		return fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
 	}
@@ -264,6 +264,17 @@ func TestValidatePackageMetadata(t *testing.T) {
 				},
 				"invalid change: invalid kind: test",
 			},
+			{
+				&hub.PackageMetadata{
+					Version:     "1.0.0",
+					Name:        "pkg1",
+					DisplayName: "Package 1",
+					CreatedAt:   "2006-01-02T15:04:05Z",
+					Description: "description",
+					Category:    "invalid",
+				},
+				"invalid category: invalid",
+			},
 			{
 				&hub.PackageMetadata{
 					Version:     "1.0.0",
@@ -19,6 +19,13 @@ func (m *ManagerMock) Get(ctx context.Context, input *hub.GetPackageInput) (*hub
 	return data, args.Error(1)
 }
 
+// GetByDigestJSON implements the PackageManager interface.
+func (m *ManagerMock) GetByDigestJSON(ctx context.Context, digest string) ([]byte, error) {
+	args := m.Called(ctx, digest)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetChangeLogJSON implements the PackageManager interface.
 func (m *ManagerMock) GetChangeLogJSON(ctx context.Context, pkgID string) ([]byte, error) {
 	args := m.Called(ctx, pkgID)
@@ -26,6 +33,44 @@ func (m *ManagerMock) GetChangeLogJSON(ctx context.Context, pkgID string) ([]byt
 	return data, args.Error(1)
 }
 
+// GetChangesJSON implements the PackageManager interface.
+func (m *ManagerMock) GetChangesJSON(
+	ctx context.Context,
+	input *hub.GetPackagesChangesInput,
+) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetComparisonJSON implements the PackageManager interface.
+func (m *ManagerMock) GetComparisonJSON(ctx context.Context, pkgsIDs []string) ([]byte, error) {
+	args := m.Called(ctx, pkgsIDs)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetDependenciesTreeJSON implements the PackageManager interface.
+func (m *ManagerMock) GetDependenciesTreeJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
+	args := m.Called(ctx, pkgID, version)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetDiscoveryJSON implements the PackageManager interface.
+func (m *ManagerMock) GetDiscoveryJSON(ctx context.Context, category string) ([]byte, error) {
+	args := m.Called(ctx, category)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetEventsJSON implements the PackageManager interface.
+func (m *ManagerMock) GetEventsJSON(ctx context.Context, pkgID string) ([]byte, error) {
+	args := m.Called(ctx, pkgID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetHarborReplicationDumpJSON implements the PackageManager interface.
 func (m *ManagerMock) GetHarborReplicationDumpJSON(ctx context.Context) ([]byte, error) {
 	args := m.Called(ctx)
@@ -33,6 +78,13 @@ func (m *ManagerMock) GetHarborReplicationDumpJSON(ctx context.Context) ([]byte,
 	return data, args.Error(1)
 }
 
+// GetHead implements the PackageManager interface.
+func (m *ManagerMock) GetHead(ctx context.Context, input *hub.GetPackageInput) (*hub.ResourceHead, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).(*hub.ResourceHead)
+	return data, args.Error(1)
+}
+
 // GetJSON implements the PackageManager interface.
 func (m *ManagerMock) GetJSON(ctx context.Context, input *hub.GetPackageInput) ([]byte, error) {
 	args := m.Called(ctx, input)
@@ -47,6 +99,18 @@ func (m *ManagerMock) GetRandomJSON(ctx context.Context) ([]byte, error) {
 	return data, args.Error(1)
 }
 
+// GetSnapshotSBOM implements the PackageManager interface.
+func (m *ManagerMock) GetSnapshotSBOM(
+	ctx context.Context,
+	pkgID,
+	version string,
+	format hub.SBOMFormat,
+) ([]byte, error) {
+	args := m.Called(ctx, pkgID, version, format)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetSnapshotSecurityReportJSON implements the PackageManager interface.
 func (m *ManagerMock) GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
 	args := m.Called(ctx, pkgID, version)
@@ -61,6 +125,31 @@ func (m *ManagerMock) GetSnapshotsToScan(ctx context.Context) ([]*hub.SnapshotTo
 	return data, args.Error(1)
 }
 
+// GetSnapshotsWithoutLogo implements the PackageManager interface.
+func (m *ManagerMock) GetSnapshotsWithoutLogo(ctx context.Context, limit int) ([]*hub.SnapshotWithoutLogo, error) {
+	args := m.Called(ctx, limit)
+	data, _ := args.Get(0).([]*hub.SnapshotWithoutLogo)
+	return data, args.Error(1)
+}
+
+// GetStaleByOrgJSON implements the PackageManager interface.
+func (m *ManagerMock) GetStaleByOrgJSON(
+	ctx context.Context,
+	orgName string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, orgName, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// GetStaleByUserJSON implements the PackageManager interface.
+func (m *ManagerMock) GetStaleByUserJSON(ctx context.Context, p *hub.Pagination) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
 // GetStarredByUserJSON implements the PackageManager interface.
 func (m *ManagerMock) GetStarredByUserJSON(ctx context.Context, p *hub.Pagination) (*hub.JSONQueryResult, error) {
 	args := m.Called(ctx, p)
@@ -89,6 +178,13 @@ func (m *ManagerMock) GetSummaryJSON(ctx context.Context, input *hub.GetPackageI
 	return data, args.Error(1)
 }
 
+// GetValuesDocsJSON implements the PackageManager interface.
+func (m *ManagerMock) GetValuesDocsJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
+	args := m.Called(ctx, pkgID, version)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetValuesSchemaJSON implements the PackageManager interface.
 func (m *ManagerMock) GetValuesSchemaJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
 	args := m.Called(ctx, pkgID, version)
@@ -96,6 +192,12 @@ func (m *ManagerMock) GetValuesSchemaJSON(ctx context.Context, pkgID, version st
 	return data, args.Error(1)
 }
 
+// RefreshDiscovery implements the PackageManager interface.
+func (m *ManagerMock) RefreshDiscovery(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 // Register implements the PackageManager interface.
 func (m *ManagerMock) Register(ctx context.Context, pkg *hub.Package) error {
 	args := m.Called(ctx, pkg)
@@ -116,12 +218,36 @@ func (m *ManagerMock) SearchMonocularJSON(ctx context.Context, baseURL, tsQueryW
 	return data, args.Error(1)
 }
 
+// SetSnapshotGeneratedLogo implements the PackageManager interface.
+func (m *ManagerMock) SetSnapshotGeneratedLogo(ctx context.Context, pkgID, version, logoImageID string) error {
+	args := m.Called(ctx, pkgID, version, logoImageID)
+	return args.Error(0)
+}
+
+// SetTestResults implements the PackageManager interface.
+func (m *ManagerMock) SetTestResults(
+	ctx context.Context,
+	repoName,
+	pkgName,
+	version string,
+	tr *hub.TestResults,
+) error {
+	args := m.Called(ctx, repoName, pkgName, version, tr)
+	return args.Error(0)
+}
+
 // ToggleStar implements the PackageManager interface.
 func (m *ManagerMock) ToggleStar(ctx context.Context, packageID string) error {
 	args := m.Called(ctx, packageID)
 	return args.Error(0)
 }
 
+// MigrateSecurityReports implements the PackageManager interface.
+func (m *ManagerMock) MigrateSecurityReports(ctx context.Context, limit int) (int, error) {
+	args := m.Called(ctx, limit)
+	return args.Int(0), args.Error(1)
+}
+
 // UpdateSnapshotSecurityReport implements the PackageManager interface.
 func (m *ManagerMock) UpdateSnapshotSecurityReport(ctx context.Context, r *hub.SnapshotSecurityReport) error {
 	args := m.Called(ctx, r)
@@ -133,3 +259,9 @@ func (m *ManagerMock) Unregister(ctx context.Context, pkg *hub.Package) error {
 	args := m.Called(ctx, pkg)
 	return args.Error(0)
 }
+
+// UpdateFreshness implements the PackageManager interface.
+func (m *ManagerMock) UpdateFreshness(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
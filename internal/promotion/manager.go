@@ -0,0 +1,141 @@
+package promotion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	addPromotedPkgDBQ           = `select add_promoted_package($1::uuid, $2::jsonb)`
+	deletePromotedPkgDBQ        = `select delete_promoted_package($1::uuid, $2::uuid)`
+	getPromotedPkgsDBQ          = `select get_promoted_packages()`
+	getPromotedPkgsStatsDBQ     = `select get_promoted_packages_stats($1::uuid)`
+	registerPromotedPkgEventDBQ = `select register_promoted_package_event($1::uuid, $2::text)`
+)
+
+var (
+	// errPackageNotFoundDB represents the error returned from the database
+	// when the package to promote does not exist.
+	errPackageNotFoundDB = errors.New("ERROR: package not found (SQLSTATE P0001)")
+
+	// errPromotedPackageNotFoundDB represents the error returned from the
+	// database when the promoted package provided does not exist or it is
+	// not active.
+	errPromotedPackageNotFoundDB = errors.New("ERROR: promoted package not found (SQLSTATE P0001)")
+)
+
+// Manager provides an API to manage promoted packages.
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// Add promotes the provided package during the period given, returning the id
+// of the promotion created. Only site admins are allowed to promote packages.
+func (m *Manager) Add(ctx context.Context, pp *hub.PromotedPackage) (string, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(pp.PackageID); err != nil {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if pp.StartsAt < 0 {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid start")
+	}
+	if pp.EndsAt == 0 {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "end not provided")
+	}
+	if pp.EndsAt <= pp.StartsAt || pp.EndsAt <= time.Now().Unix() {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid end (must be after start and in the future)")
+	}
+
+	// Add promoted package to the database
+	var promotedPackageID string
+	ppJSON, _ := json.Marshal(pp)
+	err := m.db.QueryRow(ctx, addPromotedPkgDBQ, userID, ppJSON).Scan(&promotedPackageID)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return "", hub.ErrInsufficientPrivilege
+		case errPackageNotFoundDB.Error():
+			return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package not found")
+		default:
+			return "", err
+		}
+	}
+	return promotedPackageID, nil
+}
+
+// Delete deletes the provided package promotion. Only site admins are allowed
+// to delete promotions.
+func (m *Manager) Delete(ctx context.Context, promotedPackageID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(promotedPackageID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid promoted package id")
+	}
+
+	// Delete promoted package from database
+	_, err := m.db.Exec(ctx, deletePromotedPkgDBQ, userID, promotedPackageID)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case errPromotedPackageNotFoundDB.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
+
+// GetJSON returns the packages currently promoted as a json array. The json
+// array is built by the database.
+func (m *Manager) GetJSON(ctx context.Context) ([]byte, error) {
+	return util.DBQueryJSON(ctx, m.db, getPromotedPkgsDBQ)
+}
+
+// GetStatsJSON returns all the package promotions, including the number of
+// impressions and clicks registered on them, as a json array. Only site admins
+// are allowed to get the promotions stats.
+func (m *Manager) GetStatsJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	return util.DBQueryJSON(ctx, m.db, getPromotedPkgsStatsDBQ, userID)
+}
+
+// RegisterEvent registers an event (impression or click) on the provided
+// package promotion.
+func (m *Manager) RegisterEvent(
+	ctx context.Context,
+	promotedPackageID string,
+	event hub.PromotedPackageEvent,
+) error {
+	// Validate input
+	if _, err := uuid.FromString(promotedPackageID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid promoted package id")
+	}
+	if event != hub.PromotedPackageImpression && event != hub.PromotedPackageClick {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid event")
+	}
+
+	// Register event in database
+	_, err := m.db.Exec(ctx, registerPromotedPkgEventDBQ, promotedPackageID, string(event))
+	if err != nil && err.Error() == errPromotedPackageNotFoundDB.Error() {
+		return hub.ErrNotFound
+	}
+	return err
+}
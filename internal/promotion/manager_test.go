@@ -0,0 +1,335 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	pkgID = "00000000-0000-0000-0000-000000000001"
+	ppID  = "00000000-0000-0000-0000-000000000002"
+)
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	endsAt := time.Now().Add(24 * time.Hour).Unix()
+	pp := &hub.PromotedPackage{PackageID: pkgID, EndsAt: endsAt}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.Add(context.Background(), pp)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			pp     *hub.PromotedPackage
+		}{
+			{
+				"invalid package id",
+				&hub.PromotedPackage{PackageID: "invalid", EndsAt: endsAt},
+			},
+			{
+				"invalid start",
+				&hub.PromotedPackage{PackageID: pkgID, StartsAt: -1, EndsAt: endsAt},
+			},
+			{
+				"end not provided",
+				&hub.PromotedPackage{PackageID: pkgID},
+			},
+			{
+				"invalid end",
+				&hub.PromotedPackage{PackageID: pkgID, StartsAt: endsAt + 1, EndsAt: endsAt},
+			},
+			{
+				"invalid end",
+				&hub.PromotedPackage{PackageID: pkgID, EndsAt: time.Now().Add(-1 * time.Hour).Unix()},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.Add(ctx, tc.pp)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errPackageNotFoundDB,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, addPromotedPkgDBQ, "userID", mock.Anything).Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				_, err := m.Add(ctx, pp)
+				assert.True(t, errors.Is(err, tc.expectedError))
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("promoted package added successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, addPromotedPkgDBQ, "userID", mock.Anything).Return(ppID, nil)
+		m := NewManager(db)
+
+		promotedPackageID, err := m.Add(ctx, pp)
+		assert.NoError(t, err)
+		assert.Equal(t, ppID, promotedPackageID)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Delete(context.Background(), ppID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.Delete(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errPromotedPackageNotFoundDB,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(fmt.Sprintf("%v", tc.dbErr), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, deletePromotedPkgDBQ, "userID", ppID).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Delete(ctx, ppID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("promoted package deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deletePromotedPkgDBQ, "userID", ppID).Return(nil)
+		m := NewManager(db)
+
+		err := m.Delete(ctx, ppID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPromotedPkgsDBQ).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPromotedPkgsDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetStatsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetStatsJSON(context.Background())
+		})
+	})
+
+	t.Run("promoted packages stats returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPromotedPkgsStatsDBQ, "userID").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetStatsJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error getting promoted packages stats", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getPromotedPkgsStatsDBQ, "userID").Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				dataJSON, err := m.GetStatsJSON(ctx)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestRegisterEvent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg            string
+			promotedPackageID string
+			event             hub.PromotedPackageEvent
+		}{
+			{
+				"invalid promoted package id",
+				"invalid",
+				hub.PromotedPackageClick,
+			},
+			{
+				"invalid event",
+				ppID,
+				"invalid",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.RegisterEvent(ctx, tc.promotedPackageID, tc.event)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				errPromotedPackageNotFoundDB,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, registerPromotedPkgEventDBQ, ppID, "click").Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.RegisterEvent(ctx, ppID, hub.PromotedPackageClick)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("event registered successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerPromotedPkgEventDBQ, ppID, "impression").Return(nil)
+		m := NewManager(db)
+
+		err := m.RegisterEvent(ctx, ppID, hub.PromotedPackageImpression)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
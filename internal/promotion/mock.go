@@ -0,0 +1,50 @@
+package promotion
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the PromotedPackageManager
+// interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the PromotedPackageManager interface.
+func (m *ManagerMock) Add(ctx context.Context, pp *hub.PromotedPackage) (string, error) {
+	args := m.Called(ctx, pp)
+	return args.String(0), args.Error(1)
+}
+
+// Delete implements the PromotedPackageManager interface.
+func (m *ManagerMock) Delete(ctx context.Context, promotedPackageID string) error {
+	args := m.Called(ctx, promotedPackageID)
+	return args.Error(0)
+}
+
+// GetJSON implements the PromotedPackageManager interface.
+func (m *ManagerMock) GetJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetStatsJSON implements the PromotedPackageManager interface.
+func (m *ManagerMock) GetStatsJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// RegisterEvent implements the PromotedPackageManager interface.
+func (m *ManagerMock) RegisterEvent(
+	ctx context.Context,
+	promotedPackageID string,
+	event hub.PromotedPackageEvent,
+) error {
+	args := m.Called(ctx, promotedPackageID, event)
+	return args.Error(0)
+}
@@ -0,0 +1,185 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	helmrepo "helm.sh/helm/v3/pkg/repo"
+)
+
+// errPackageFound is used to stop walking the repository files once the first
+// package has been found.
+var errPackageFound = errors.New("package found")
+
+// repositoryChecker performs the checks used to validate a repository before
+// adding it, collecting the diagnostics of each of them.
+type repositoryChecker struct {
+	m   *Manager
+	ctx context.Context
+	r   *hub.Repository
+	rc  *hub.RepositoryCheck
+
+	idx      *helmrepo.IndexFile
+	tmpDir   string
+	basePath string
+}
+
+// run runs the check provided and records its outcome. Once a check fails,
+// the remaining ones are skipped.
+func (c *repositoryChecker) run(check string, fn func() (hub.RepositoryCheckStatus, string)) {
+	status, msg := hub.RepositoryCheckSkipped, "previous check failed"
+	if c.rc.Valid {
+		status, msg = fn()
+	}
+	if status == hub.RepositoryCheckFailed {
+		c.rc.Valid = false
+	}
+	c.rc.Diagnostics = append(c.rc.Diagnostics, &hub.RepositoryCheckDiagnostic{
+		Check:   check,
+		Status:  status,
+		Message: msg,
+	})
+}
+
+// cleanup deletes the temporary directory where the repository was cloned, if
+// any.
+func (c *repositoryChecker) cleanup() {
+	if c.tmpDir != "" {
+		os.RemoveAll(c.tmpDir)
+	}
+}
+
+// checkURL verifies that the repository url is valid for its kind.
+func (c *repositoryChecker) checkURL() (hub.RepositoryCheckStatus, string) {
+	if err := c.m.validateURLFormat(c.r); err != nil {
+		return hub.RepositoryCheckFailed, err.Error()
+	}
+	return hub.RepositoryCheckPassed, ""
+}
+
+// checkCredentials verifies that the credentials provided, if any, are valid
+// and allowed.
+func (c *repositoryChecker) checkCredentials() (hub.RepositoryCheckStatus, string) {
+	if err := c.m.validateCredentials(c.r); err != nil {
+		return hub.RepositoryCheckFailed, err.Error()
+	}
+	if err := c.m.prepareSSHKey(c.r); err != nil {
+		return hub.RepositoryCheckFailed, err.Error()
+	}
+	return hub.RepositoryCheckPassed, ""
+}
+
+// checkAccess verifies that the repository can be accessed using the
+// credentials provided, loading its index or cloning it depending on its kind.
+func (c *repositoryChecker) checkAccess() (hub.RepositoryCheckStatus, string) {
+	if strings.HasPrefix(c.r.URL, hub.RepositoryOCIPrefix) {
+		return hub.RepositoryCheckSkipped, "not available for oci repositories"
+	}
+	if c.r.Kind == hub.Helm && !IsHelmGitRepository(c.r) {
+		idx, _, err := c.m.helmIndexLoader.LoadIndex(c.r)
+		if err != nil {
+			return hub.RepositoryCheckFailed, fmt.Sprintf("error loading repository index: %v", err)
+		}
+		c.idx = idx
+		return hub.RepositoryCheckPassed, ""
+	}
+	tmpDir, packagesPath, err := c.m.rc.CloneRepository(c.ctx, c.r)
+	if err != nil {
+		return hub.RepositoryCheckFailed, fmt.Sprintf("error cloning repository: %v", err)
+	}
+	c.tmpDir = tmpDir
+	c.basePath = filepath.Join(tmpDir, packagesPath)
+	return hub.RepositoryCheckPassed, ""
+}
+
+// checkMetadata verifies that the repository metadata file, when available,
+// can be parsed and is valid.
+func (c *repositoryChecker) checkMetadata() (hub.RepositoryCheckStatus, string) {
+	if strings.HasPrefix(c.r.URL, hub.RepositoryOCIPrefix) {
+		return hub.RepositoryCheckSkipped, "not available for oci repositories"
+	}
+	var mdFile string
+	if c.r.Kind == hub.Helm && !IsHelmGitRepository(c.r) {
+		u, _ := url.Parse(c.r.URL)
+		u.Path = path.Join(u.Path, hub.RepositoryMetadataFile)
+		mdFile = u.String()
+	} else {
+		mdFile = filepath.Join(c.basePath, hub.RepositoryMetadataFile)
+	}
+	if _, err := c.m.GetMetadata(mdFile); err != nil {
+		if errors.Is(err, ErrInvalidMetadata) {
+			return hub.RepositoryCheckFailed, err.Error()
+		}
+		return hub.RepositoryCheckPassed, "metadata file not found (it is optional)"
+	}
+	return hub.RepositoryCheckPassed, ""
+}
+
+// checkPackages verifies that at least one package can be found in the
+// repository.
+func (c *repositoryChecker) checkPackages() (hub.RepositoryCheckStatus, string) {
+	if strings.HasPrefix(c.r.URL, hub.RepositoryOCIPrefix) {
+		return hub.RepositoryCheckSkipped, "not available for oci repositories"
+	}
+	var found bool
+	if c.r.Kind == hub.Helm && !IsHelmGitRepository(c.r) {
+		found = c.idx != nil && len(c.idx.Entries) > 0
+	} else {
+		found = hasPackages(c.basePath, c.r.Kind)
+	}
+	if !found {
+		return hub.RepositoryCheckFailed, "no packages found in repository"
+	}
+	return hub.RepositoryCheckPassed, ""
+}
+
+// hasPackages checks if the path provided contains at least one package of
+// the kind given, looking for the files the tracker uses to detect them.
+func hasPackages(basePath string, kind hub.RepositoryKind) bool {
+	err := filepath.WalkDir(basePath, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isPackageFile(d.Name(), kind) {
+			return errPackageFound
+		}
+		return nil
+	})
+	return errors.Is(err, errPackageFound)
+}
+
+// isPackageFile checks if the file name provided corresponds to a file used
+// to define a package of the kind given.
+func isPackageFile(name string, kind hub.RepositoryKind) bool {
+	if strings.HasPrefix(name, hub.RepositoryMetadataFile+".") {
+		return false
+	}
+	switch kind {
+	case hub.Helm:
+		return name == "Chart.yaml"
+	case hub.Falco, hub.Gatekeeper, hub.Krew:
+		return filepath.Ext(name) == ".yaml"
+	case hub.HelmPlugin:
+		return name == "plugin.yaml"
+	case hub.OLM:
+		return strings.HasSuffix(name, "package.yaml") ||
+			strings.HasSuffix(name, ".clusterserviceversion.yaml") ||
+			name == "annotations.yaml"
+	default:
+		return name == hub.PackageMetadataFile+".yml" || name == hub.PackageMetadataFile+".yaml"
+	}
+}
@@ -4,74 +4,146 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/spf13/viper"
 )
 
 const (
 	// DefaultBranch represents the branch used by default when cloning a
 	// repository if no branch has been configured on it.
 	DefaultBranch = "master"
+
+	// defaultSSHUser represents the user used by default when accessing a
+	// repository using ssh if none has been provided in the url.
+	defaultSSHUser = "git"
 )
 
 // Cloner is a hub.RepositoryCloner implementation.
-type Cloner struct{}
+type Cloner struct {
+	cfg *viper.Viper
+	gts hub.GithubTokenSource
+}
+
+// NewCloner creates a new Cloner instance. When a GithubTokenSource is
+// provided, the tokens it provides will be used to authenticate the clones of
+// GitHub repositories that don't have any credentials set.
+func NewCloner(cfg *viper.Viper, gts hub.GithubTokenSource) *Cloner {
+	return &Cloner{
+		cfg: cfg,
+		gts: gts,
+	}
+}
 
 // CloneRepository implements the hub.RepositoryCloner interface.
 func (c *Cloner) CloneRepository(ctx context.Context, r *hub.Repository) (string, string, error) {
 	// Parse repository url
-	var repoBaseURL, packagesPath string
+	repoURL := r.URL
 	switch r.Kind {
+	case hub.Helm:
+		// Only Helm charts stored unpackaged in git repositories are cloned
+		if !IsHelmGitRepository(r) {
+			return "", "", errors.New("repository kind not supported")
+		}
+		repoURL = strings.TrimPrefix(r.URL, hub.RepositoryGitPrefix)
 	case hub.Falco,
 		hub.HelmPlugin,
 		hub.Krew,
 		hub.OLM,
 		hub.OPA,
 		hub.TBAction,
-		hub.TektonTask,
 		hub.KedaScaler,
 		hub.CoreDNS,
-		hub.Keptn:
-		matches := GitRepoURLRE.FindStringSubmatch(r.URL)
-		if len(matches) < 2 {
-			return "", "", fmt.Errorf("invalid repository url")
-		}
-		if len(matches) >= 3 {
-			repoBaseURL = matches[1]
+		hub.Keptn,
+		hub.Kustomize,
+		hub.Kyverno,
+		hub.Gatekeeper,
+		hub.ArgoWorkflowTemplate,
+		hub.Backstage,
+		hub.Headlamp,
+		hub.Knative:
+	case hub.Terraform:
+		// Only modules stored in git repositories are cloned
+		if strings.HasPrefix(r.URL, hub.RepositoryTerraformRegistryPrefix) {
+			return "", "", errors.New("repository kind not supported")
 		}
-		if len(matches) == 4 {
-			packagesPath = strings.TrimSuffix(matches[3], "/")
+	case hub.TektonTask:
+		// Tekton bundles stored in OCI registries are not cloned
+		if strings.HasPrefix(r.URL, hub.RepositoryOCIPrefix) {
+			return "", "", errors.New("repository kind not supported")
 		}
 	default:
 		return "", "", errors.New("repository kind not supported")
 	}
+	var repoBaseURL, packagesPath string
+	matches := GitRepoURLRE.FindStringSubmatch(repoURL)
+	if matches == nil {
+		matches = SSHGitRepoURLRE.FindStringSubmatch(repoURL)
+	}
+	if len(matches) < 2 {
+		return "", "", fmt.Errorf("invalid repository url")
+	}
+	if len(matches) >= 3 {
+		repoBaseURL = matches[1]
+	}
+	if len(matches) == 4 {
+		packagesPath = strings.TrimSuffix(matches[3], "/")
+	}
 
 	// Clone git repository
+	auth, err := getGitAuth(ctx, c.cfg, c.gts, r, repoBaseURL)
+	if err != nil {
+		return "", "", err
+	}
 	tmpDir, err := ioutil.TempDir("", "artifact-hub")
 	if err != nil {
 		return "", "", fmt.Errorf("error creating temp dir: %w", err)
 	}
 	cloneOptions := &git.CloneOptions{
 		URL:           repoBaseURL,
+		Auth:          auth,
 		ReferenceName: plumbing.NewBranchReferenceName(GetBranch(r)),
 		SingleBranch:  true,
-		Depth:         1,
 	}
-	if r.AuthPass != "" {
-		cloneOptions.Auth = &http.BasicAuth{
-			Username: "artifact-hub",
-			Password: r.AuthPass,
+	sparse := false
+	switch {
+	case r.Kind == hub.Helm, r.Kind == hub.Kustomize, r.Kind == hub.Terraform:
+		// Charts, kustomizations and modules versions are loaded from the
+		// tags as well, so all of them must be fetched and the clone cannot
+		// be shallow
+		cloneOptions.Tags = git.AllTags
+	case !c.cfg.GetBool("tracker.fullClones"):
+		// Use shallow clones and, when the packages are located in a
+		// subdirectory of the repository, check out only that path
+		cloneOptions.Depth = 1
+		if packagesPath != "" {
+			cloneOptions.NoCheckout = true
+			sparse = true
 		}
 	}
-	_, err = git.PlainCloneContext(ctx, tmpDir, false, cloneOptions)
+	gr, err := git.PlainCloneContext(ctx, tmpDir, false, cloneOptions)
 	if err != nil {
 		return "", "", err
 	}
+	if sparse {
+		if err := sparseCheckout(gr, tmpDir, packagesPath); err != nil {
+			return "", "", fmt.Errorf("error checking out packages path: %w", err)
+		}
+	}
 
 	return tmpDir, packagesPath, nil
 }
@@ -85,3 +157,110 @@ func GetBranch(r *hub.Repository) string {
 	}
 	return branch
 }
+
+// getGitAuth returns the authentication method that should be used to access
+// the git repository provided (located at the base url given), if any.
+func getGitAuth(
+	ctx context.Context,
+	cfg *viper.Viper,
+	gts hub.GithubTokenSource,
+	r *hub.Repository,
+	repoBaseURL string,
+) (transport.AuthMethod, error) {
+	switch {
+	case strings.HasPrefix(repoBaseURL, "ssh://"):
+		if r.SSHKey == "" {
+			return nil, errors.New("ssh key not provided")
+		}
+		key, err := util.Decrypt(cfg.GetString("db.encryptionKey"), r.SSHKey)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting ssh key: %w", err)
+		}
+		user := defaultSSHUser
+		if u, err := url.Parse(repoBaseURL); err == nil && u.User != nil && u.User.Username() != "" {
+			user = u.User.Username()
+		}
+		auth, err := gitssh.NewPublicKeys(user, []byte(key), "")
+		if err != nil {
+			return nil, fmt.Errorf("error preparing ssh auth: %w", err)
+		}
+		if knownHosts := cfg.GetString("creds.sshKnownHosts"); knownHosts != "" {
+			auth.HostKeyCallback, err = gitssh.NewKnownHostsCallback(knownHosts)
+			if err != nil {
+				return nil, fmt.Errorf("error loading ssh known hosts: %w", err)
+			}
+		}
+		return auth, nil
+	case r.AuthPass != "":
+		return &http.BasicAuth{
+			Username: "artifact-hub",
+			Password: r.AuthPass,
+		}, nil
+	case gts != nil && strings.HasPrefix(repoBaseURL, "https://github.com/"):
+		token, err := gts.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting github token: %w", err)
+		}
+		return &http.BasicAuth{
+			Username: "x-access-token",
+			Password: token,
+		}, nil
+	}
+	return nil, nil
+}
+
+// sparseCheckout writes to the directory provided the files of the HEAD commit
+// located under the path given, so that only the part of the repository where
+// the packages are located is checked out.
+func sparseCheckout(gr *git.Repository, dir, path string) error {
+	head, err := gr.Head()
+	if err != nil {
+		return err
+	}
+	commit, err := gr.CommitObject(head.Hash())
+	if err != nil {
+		return err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	subtree, err := tree.Tree(path)
+	if err != nil {
+		return err
+	}
+	return subtree.Files().ForEach(func(f *object.File) error {
+		dst := filepath.Join(dir, path, f.Name)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		switch f.Mode {
+		case filemode.Symlink:
+			target, err := f.Contents()
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, dst)
+		case filemode.Regular, filemode.Deprecated, filemode.Executable:
+			perm, err := f.Mode.ToOSFileMode()
+			if err != nil {
+				return err
+			}
+			src, err := f.Reader()
+			if err != nil {
+				return err
+			}
+			defer src.Close()
+			out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, src); err != nil {
+				out.Close()
+				return err
+			}
+			return out.Close()
+		}
+		return nil
+	})
+}
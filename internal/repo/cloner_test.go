@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparseCheckout(t *testing.T) {
+	// Setup git repository with some files inside and outside the packages path
+	srcDir, err := ioutil.TempDir("", "artifact-hub-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(srcDir)
+	gr, err := git.PlainInit(srcDir, false)
+	require.NoError(t, err)
+	files := map[string]string{
+		"README.md":              "readme",
+		"other/file.txt":         "other",
+		"pkgs/pkg1/policy.rego":  "pkg1",
+		"pkgs/pkg2/sub/data.txt": "pkg2",
+	}
+	wt, err := gr.Worktree()
+	require.NoError(t, err)
+	for name, content := range files {
+		p := filepath.Join(srcDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, ioutil.WriteFile(p, []byte(content), 0600))
+		_, err := wt.Add(name)
+		require.NoError(t, err)
+	}
+	_, err = wt.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@email.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	t.Run("packages path checked out", func(t *testing.T) {
+		dstDir, err := ioutil.TempDir("", "artifact-hub-test")
+		require.NoError(t, err)
+		defer os.RemoveAll(dstDir)
+
+		err = sparseCheckout(gr, dstDir, "pkgs")
+		require.NoError(t, err)
+		for name, content := range files {
+			data, err := ioutil.ReadFile(filepath.Join(dstDir, name))
+			if filepath.Dir(name) == "." || filepath.Dir(name) == "other" {
+				assert.True(t, os.IsNotExist(err), name)
+				continue
+			}
+			require.NoError(t, err)
+			assert.Equal(t, content, string(data))
+		}
+	})
+
+	t.Run("packages path not found", func(t *testing.T) {
+		dstDir, err := ioutil.TempDir("", "artifact-hub-test")
+		require.NoError(t, err)
+		defer os.RemoveAll(dstDir)
+
+		err = sparseCheckout(gr, dstDir, "missing")
+		assert.Error(t, err)
+	})
+}
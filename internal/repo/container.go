@@ -0,0 +1,182 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/spf13/viper"
+)
+
+// ContainerReadmeMaxSize represents the maximum size of the readme attached to
+// a container image.
+const ContainerReadmeMaxSize = 1024 * 1024
+
+// OCIContainerImageDetailsGetter provides a mechanism to get some details of
+// the container images stored in a OCI registry, like their annotations or
+// the readme attached to them (i.e. using oras). When a requests limiter is
+// provided, the limits configured for the repository and its registry will
+// be honored.
+type OCIContainerImageDetailsGetter struct {
+	Cfg *viper.Viper
+	Rl  hub.RequestsLimiter
+}
+
+// Get returns the details of the container image referenced by the ref
+// provided. The annotations returned include the image config labels, but
+// the manifest annotations take precedence over them. Multi-platform images
+// (image indexes) are supported as well.
+func (g *OCIContainerImageDetailsGetter) Get(
+	ctx context.Context,
+	r *hub.Repository,
+	ref string,
+) (*hub.ContainerImageDetails, error) {
+	// Prepare registry access options
+	nameRef, err := name.ParseReference(strings.TrimPrefix(ref, hub.RepositoryOCIPrefix))
+	if err != nil {
+		return nil, err
+	}
+	if g.Rl != nil {
+		release, err := g.Rl.Acquire(ctx, r.Name, nameRef.Context().RegistryStr())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	authConfig, err := GetOCIAuthConfig(g.Cfg, nameRef.Context().Registry, r.AuthUser, r.AuthPass)
+	if err != nil {
+		return nil, err
+	}
+	opts := []remote.Option{
+		remote.WithAuth(authn.FromConfig(*authConfig)),
+		remote.WithContext(ctx),
+	}
+
+	// Get image details
+	desc, err := remote.Get(nameRef, opts...)
+	if err != nil {
+		return nil, err
+	}
+	d := &hub.ContainerImageDetails{
+		Digest: desc.Digest.String(),
+	}
+	var img v1.Image
+	switch desc.MediaType {
+	case types.OCIImageIndex, types.DockerManifestList:
+		idx, err := desc.ImageIndex()
+		if err != nil {
+			return nil, err
+		}
+		idxManifest, err := idx.IndexManifest()
+		if err != nil {
+			return nil, err
+		}
+		d.Annotations = idxManifest.Annotations
+		for _, m := range idxManifest.Manifests {
+			// Skip attestations manifests (i.e. the ones created by buildx)
+			if m.Platform == nil || m.Platform.OS == "unknown" {
+				continue
+			}
+			if img == nil {
+				img, err = idx.Image(m.Digest)
+				if err != nil {
+					return nil, err
+				}
+			}
+			d.Platforms = append(d.Platforms, formatPlatform(m.Platform))
+		}
+	default:
+		img, err = desc.Image()
+		if err != nil {
+			return nil, err
+		}
+		manifest, err := img.Manifest()
+		if err != nil {
+			return nil, err
+		}
+		d.Annotations = manifest.Annotations
+	}
+	if img != nil {
+		cfg, err := img.ConfigFile()
+		if err != nil {
+			return nil, err
+		}
+		if len(d.Platforms) == 0 && cfg.OS != "" {
+			d.Platforms = []string{cfg.OS + "/" + cfg.Architecture}
+		}
+		annotations := make(map[string]string, len(cfg.Config.Labels)+len(d.Annotations))
+		for k, v := range cfg.Config.Labels {
+			annotations[k] = v
+		}
+		for k, v := range d.Annotations {
+			annotations[k] = v
+		}
+		d.Annotations = annotations
+	}
+
+	// Get readme attached to the image, if any
+	d.Readme, err = getAttachedReadme(nameRef, desc.Digest, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting readme: %w", err)
+	}
+
+	return d, nil
+}
+
+// getAttachedReadme returns the readme attached to the image with the digest
+// provided. Readme files are expected to be stored in the first layer of the
+// artifact tagged as <alg>-<hex>.readme, following the same convention used
+// for the SBOMs attached to the artifacts.
+func getAttachedReadme(nameRef name.Reference, digest v1.Hash, opts []remote.Option) ([]byte, error) {
+	tag := nameRef.Context().Tag(fmt.Sprintf("%s-%s.readme", digest.Algorithm, digest.Hex))
+	desc, err := remote.Get(tag, opts...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	manifest, err := v1.ParseManifest(bytes.NewReader(desc.Manifest))
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, nil
+	}
+	l := manifest.Layers[0]
+	if l.Size > ContainerReadmeMaxSize {
+		return nil, errors.New("readme too big")
+	}
+	layer, err := remote.Layer(nameRef.Context().Digest(l.Digest.String()), opts...)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, ContainerReadmeMaxSize))
+}
+
+// formatPlatform returns the string representation of the platform provided
+// (i.e. linux/arm64/v8).
+func formatPlatform(p *v1.Platform) string {
+	parts := []string{p.OS, p.Architecture}
+	if p.Variant != "" {
+		parts = append(parts, p.Variant)
+	}
+	return strings.Join(parts, "/")
+}
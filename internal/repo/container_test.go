@@ -0,0 +1,25 @@
+package repo
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPlatform(t *testing.T) {
+	testCases := []struct {
+		p        *v1.Platform
+		expected string
+	}{
+		{&v1.Platform{OS: "linux", Architecture: "amd64"}, "linux/amd64"},
+		{&v1.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}, "linux/arm64/v8"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.expected, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, formatPlatform(tc.p))
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package repo
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/sigstore/cosign/v2/pkg/oci"
+	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/tuf"
+	"github.com/spf13/viper"
+)
+
+// fulcioIssuerOID represents the OID of the certificate extension where Fulcio
+// stores the issuer of the identity used to sign.
+const fulcioIssuerOID = "1.3.6.1.4.1.57264.1.1"
+
+// CosignSignatureChecker provides a mechanism to detect and verify the cosign
+// signatures and attestations attached to an artifact stored in a OCI
+// registry, using the cosign verification libraries. Signatures made with a
+// key are verified using the PEM encoded public key referenced by the cosign
+// key provided. Keyless signatures are verified using the Fulcio roots and the
+// Rekor public key set in the configuration (tracker.cosign.fulcioRoots and
+// tracker.cosign.rekorPublicKey), and they are only reported as verified when
+// the signer matches the cosign identity expected for the repository. When no
+// identity is expected, they are reported as signed by the identity found in
+// the signing certificate. When the material needed to verify a signature is
+// not available, it'll be reported as signed but not verified. When a requests
+// limiter is provided, the limits configured for the repository and its
+// registry will be honored.
+type CosignSignatureChecker struct {
+	Cfg *viper.Viper
+	Hc  hub.HTTPClient
+	Rl  hub.RequestsLimiter
+
+	trustOnce    sync.Once
+	fulcioRoots  *x509.CertPool
+	rekorPubKeys *cosign.TrustedTransparencyLogPubKeys
+	trustErr     error
+}
+
+// Check implements the OCISignatureChecker interface.
+func (c *CosignSignatureChecker) Check(
+	ctx context.Context,
+	r *hub.Repository,
+	ref string,
+	cosignKey *hub.SignKey,
+	cosignIdentity *hub.CosignIdentity,
+) (*hub.OCISignatureCheck, error) {
+	result := &hub.OCISignatureCheck{}
+
+	// Prepare registry access options
+	nameRef, err := name.ParseReference(strings.TrimPrefix(ref, hub.RepositoryOCIPrefix))
+	if err != nil {
+		return nil, err
+	}
+	if c.Rl != nil {
+		release, err := c.Rl.Acquire(ctx, r.Name, nameRef.Context().RegistryStr())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	authConfig, err := GetOCIAuthConfig(c.Cfg, nameRef.Context().Registry, r.AuthUser, r.AuthPass)
+	if err != nil {
+		return nil, err
+	}
+	registryOpts := []ociremote.Option{
+		ociremote.WithRemoteOptions(
+			remote.WithAuth(authn.FromConfig(*authConfig)),
+			remote.WithContext(ctx),
+		),
+	}
+
+	// Get signatures and attestations attached to the artifact
+	se, err := ociremote.SignedEntity(nameRef, registryOpts...)
+	if err != nil {
+		return nil, err
+	}
+	signatures, err := getSignatures(se.Signatures)
+	if err != nil {
+		return nil, fmt.Errorf("error getting cosign signatures: %w", err)
+	}
+	attestations, err := getSignatures(se.Attestations)
+	if err != nil {
+		return nil, fmt.Errorf("error getting cosign attestations: %w", err)
+	}
+	result.Signed = len(signatures) > 0
+	result.HasAttestations = len(attestations) > 0
+	if !result.Signed {
+		return result, nil
+	}
+
+	// Verify signatures (any valid signature is enough)
+	co, err := c.getCheckOpts(ctx, registryOpts, cosignKey, cosignIdentity)
+	if err != nil {
+		return result, err
+	}
+	co.ClaimVerifier = cosign.SimpleClaimVerifier
+	verified, _, err := cosign.VerifyImageSignatures(ctx, nameRef, co)
+	if err != nil {
+		return result, fmt.Errorf("error verifying cosign signature: %w", err)
+	}
+	if co.SigVerifier == nil {
+		cert, err := verified[0].Cert()
+		if err == nil && cert != nil {
+			result.Identity = getCertificateIdentity(cert)
+		}
+	}
+
+	// Keyless signatures are only reported as verified when the identity of
+	// the signer has been checked against the one expected
+	result.Verified = co.SigVerifier != nil || len(co.Identities) > 0
+
+	// Verify attestations
+	if result.HasAttestations {
+		co.ClaimVerifier = cosign.IntotoSubjectClaimVerifier
+		if _, _, err := cosign.VerifyImageAttestations(ctx, nameRef, co); err != nil {
+			return result, fmt.Errorf("error verifying cosign attestation: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// getCheckOpts returns the options used to verify the cosign signatures and
+// attestations of an artifact. When the cosign key provided references a PEM
+// encoded public key, it'll be used to verify them. Otherwise they are
+// expected to be signed keyless, and the identity provided (if any) will be
+// required to match the one in the signing certificate.
+func (c *CosignSignatureChecker) getCheckOpts(
+	ctx context.Context,
+	registryOpts []ociremote.Option,
+	cosignKey *hub.SignKey,
+	cosignIdentity *hub.CosignIdentity,
+) (*cosign.CheckOpts, error) {
+	co := &cosign.CheckOpts{
+		RegistryClientOpts: registryOpts,
+	}
+
+	// Signed with key
+	if cosignKey != nil && cosignKey.URL != "" {
+		publicKey, err := c.getPublicKey(ctx, cosignKey.URL)
+		if err != nil {
+			return nil, fmt.Errorf("error getting cosign public key: %w", err)
+		}
+		if publicKey != nil {
+			co.SigVerifier, err = signature.LoadVerifier(publicKey, crypto.SHA256)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cosign public key: %w", err)
+			}
+			co.IgnoreTlog = true
+			return co, nil
+		}
+	}
+
+	// Signed keyless
+	if err := c.loadTrustMaterial(); err != nil {
+		return nil, err
+	}
+	if c.fulcioRoots == nil || c.rekorPubKeys == nil {
+		return nil, errors.New("fulcio roots or rekor public key not configured")
+	}
+	co.RootCerts = c.fulcioRoots
+	co.RekorPubKeys = c.rekorPubKeys
+	co.IgnoreSCT = true
+	co.Offline = true
+	if cosignIdentity != nil && cosignIdentity.Subject != "" && cosignIdentity.Issuer != "" {
+		co.Identities = []cosign.Identity{
+			{
+				Subject: cosignIdentity.Subject,
+				Issuer:  cosignIdentity.Issuer,
+			},
+		}
+	}
+	return co, nil
+}
+
+// loadTrustMaterial loads the Fulcio roots and the Rekor public key from the
+// configuration, when available.
+func (c *CosignSignatureChecker) loadTrustMaterial() error {
+	c.trustOnce.Do(func() {
+		if c.Cfg == nil {
+			return
+		}
+		if roots := c.Cfg.GetString("tracker.cosign.fulcioRoots"); roots != "" {
+			c.fulcioRoots = x509.NewCertPool()
+			if !c.fulcioRoots.AppendCertsFromPEM([]byte(roots)) {
+				c.trustErr = errors.New("invalid fulcio roots")
+				return
+			}
+		}
+		if key := c.Cfg.GetString("tracker.cosign.rekorPublicKey"); key != "" {
+			rekorPubKeys := cosign.NewTrustedTransparencyLogPubKeys()
+			if err := rekorPubKeys.AddTransparencyLogPubKey([]byte(key), tuf.Active); err != nil {
+				c.trustErr = fmt.Errorf("invalid rekor public key: %w", err)
+				return
+			}
+			c.rekorPubKeys = &rekorPubKeys
+		}
+	})
+	return c.trustErr
+}
+
+// getSignatures returns the list of signatures (or attestations) provided by
+// the getter given.
+func getSignatures(getter func() (oci.Signatures, error)) ([]oci.Signature, error) {
+	sigs, err := getter()
+	if err != nil {
+		return nil, err
+	}
+	return sigs.Get()
+}
+
+// getPublicKey returns the public key located at the url provided. Keys that
+// are not PEM encoded (i.e. PGP keys) are ignored, so no key is returned.
+func (c *CosignSignatureChecker) getPublicKey(ctx context.Context, u string) (crypto.PublicKey, error) {
+	req, _ := http.NewRequest("GET", u, nil)
+	req = req.WithContext(ctx)
+	resp, err := c.Hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block == nil {
+		return nil, nil
+	}
+	return parsePublicKey(data)
+}
+
+// parsePublicKey parses the PEM encoded public key provided.
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid public key: pem block not found")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// ParsePublicKeys parses the PEM encoded public keys (or certificates)
+// provided. ECDSA, RSA and ED25519 keys are supported.
+func ParsePublicKeys(data []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		var key crypto.PublicKey
+		var err error
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			cert, err = x509.ParseCertificate(block.Bytes)
+			if err == nil {
+				key = cert.PublicKey
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid sign key: %w", err)
+		}
+		switch key.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+			keys = append(keys, key)
+		default:
+			return nil, errors.New("invalid sign key: unsupported key type")
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("invalid sign key: no public keys found")
+	}
+	return keys, nil
+}
+
+// getCertificateIdentity returns the identity of the signer included in the
+// Fulcio certificate provided, including the issuer when available.
+func getCertificateIdentity(cert *x509.Certificate) string {
+	var identity string
+	switch {
+	case len(cert.EmailAddresses) > 0:
+		identity = cert.EmailAddresses[0]
+	case len(cert.URIs) > 0:
+		identity = cert.URIs[0].String()
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.String() == fulcioIssuerOID {
+			identity = fmt.Sprintf("%s (%s)", identity, string(ext.Value))
+			break
+		}
+	}
+	return identity
+}
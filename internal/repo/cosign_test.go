@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	t.Run("invalid public key", func(t *testing.T) {
+		t.Parallel()
+		_, err := parsePublicKey([]byte("invalid"))
+		assert.EqualError(t, err, "invalid public key: pem block not found")
+	})
+
+	t.Run("public key parsed successfully", func(t *testing.T) {
+		t.Parallel()
+		publicKey, err := parsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+		require.NoError(t, err)
+		assert.True(t, key.PublicKey.Equal(publicKey))
+	})
+}
+
+func TestCosignSignatureCheckerGetCheckOpts(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	certDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fulcio"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}, &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "fulcio"}}, &key.PublicKey, key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	cfg := viper.New()
+	cfg.Set("tracker.cosign.fulcioRoots", string(certPEM))
+	cfg.Set("tracker.cosign.rekorPublicKey", string(keyPEM))
+	cosignKey := &hub.SignKey{URL: "https://key.url/cosign.pub"}
+	cosignIdentity := &hub.CosignIdentity{
+		Subject: "user@example.com",
+		Issuer:  "https://accounts.google.com",
+	}
+
+	t.Run("error getting cosign public key", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+			StatusCode: http.StatusNotFound,
+		}, nil)
+		c := &CosignSignatureChecker{Cfg: cfg, Hc: hc}
+
+		co, err := c.getCheckOpts(ctx, nil, cosignKey, nil)
+		assert.EqualError(t, err, "error getting cosign public key: unexpected status code received: 404")
+		assert.Nil(t, co)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("signed with key", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(keyPEM)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		c := &CosignSignatureChecker{Cfg: cfg, Hc: hc}
+
+		co, err := c.getCheckOpts(ctx, nil, cosignKey, cosignIdentity)
+		require.NoError(t, err)
+		assert.NotNil(t, co.SigVerifier)
+		assert.True(t, co.IgnoreTlog)
+		assert.Nil(t, co.RootCerts)
+		assert.Empty(t, co.Identities)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("signed keyless, trust material not configured", func(t *testing.T) {
+		t.Parallel()
+		c := &CosignSignatureChecker{Cfg: viper.New()}
+
+		co, err := c.getCheckOpts(ctx, nil, nil, cosignIdentity)
+		assert.EqualError(t, err, "fulcio roots or rekor public key not configured")
+		assert.Nil(t, co)
+	})
+
+	t.Run("signed keyless, invalid rekor public key", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("tracker.cosign.fulcioRoots", string(certPEM))
+		cfg.Set("tracker.cosign.rekorPublicKey", "invalid")
+		c := &CosignSignatureChecker{Cfg: cfg}
+
+		co, err := c.getCheckOpts(ctx, nil, nil, cosignIdentity)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid rekor public key")
+		assert.Nil(t, co)
+	})
+
+	t.Run("signed keyless, identity not expected", func(t *testing.T) {
+		t.Parallel()
+		c := &CosignSignatureChecker{Cfg: cfg}
+
+		co, err := c.getCheckOpts(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Nil(t, co.SigVerifier)
+		assert.NotNil(t, co.RootCerts)
+		assert.NotNil(t, co.RekorPubKeys)
+		assert.True(t, co.Offline)
+		assert.Empty(t, co.Identities)
+	})
+
+	t.Run("signed keyless, identity expected", func(t *testing.T) {
+		t.Parallel()
+		c := &CosignSignatureChecker{Cfg: cfg}
+
+		co, err := c.getCheckOpts(ctx, nil, nil, cosignIdentity)
+		require.NoError(t, err)
+		assert.Nil(t, co.SigVerifier)
+		assert.Equal(t, []cosign.Identity{
+			{
+				Subject: "user@example.com",
+				Issuer:  "https://accounts.google.com",
+			},
+		}, co.Identities)
+	})
+}
+
+func TestGetCertificateIdentity(t *testing.T) {
+	u, _ := url.Parse("https://github.com/org/repo/.github/workflows/release.yml@refs/heads/main")
+	issuerExt := pkix.Extension{
+		Id:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1},
+		Value: []byte("https://token.actions.githubusercontent.com"),
+	}
+
+	testCases := []struct {
+		cert             *x509.Certificate
+		expectedIdentity string
+	}{
+		{
+			&x509.Certificate{EmailAddresses: []string{"user@example.com"}},
+			"user@example.com",
+		},
+		{
+			&x509.Certificate{URIs: []*url.URL{u}, Extensions: []pkix.Extension{issuerExt}},
+			u.String() + " (https://token.actions.githubusercontent.com)",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.expectedIdentity, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedIdentity, getCertificateIdentity(tc.cert))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package repo
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/viper"
+)
+
+const (
+	// devcontainerLayerMediaType represents the media type of the layer that
+	// holds the content of a Dev Container feature or template.
+	devcontainerLayerMediaType = "application/vnd.devcontainers.layer.v1+tar"
+
+	// devcontainerCollectionLayerMediaType represents the media type of the
+	// layer that holds the collection metadata file of a namespace.
+	devcontainerCollectionLayerMediaType = "application/vnd.devcontainers.collection.layer.v1+json"
+
+	// DevcontainerCollectionFile represents the name of the file that contains
+	// the metadata of the features and templates available in a namespace.
+	DevcontainerCollectionFile = "devcontainer-collection.json"
+)
+
+// OCIDevcontainerArtifactPuller provides a mechanism to pull Dev Container
+// artifacts (features, templates and collections metadata) stored in a OCI
+// registry, as defined in the Dev Container distribution specification. When
+// a requests limiter is provided, the limits configured for the repository
+// and its registry will be honored.
+type OCIDevcontainerArtifactPuller struct {
+	Cfg *viper.Viper
+	Rl  hub.RequestsLimiter
+}
+
+// Pull returns the content of the Dev Container artifact referenced by the ref
+// provided. The files of features and templates are read from their tar layer,
+// whereas the collection metadata is returned as the DevcontainerCollectionFile
+// file.
+func (p *OCIDevcontainerArtifactPuller) Pull(
+	ctx context.Context,
+	r *hub.Repository,
+	ref string,
+) (*hub.DevcontainerArtifact, error) {
+	// Prepare registry access options
+	nameRef, err := name.ParseReference(strings.TrimPrefix(ref, hub.RepositoryOCIPrefix))
+	if err != nil {
+		return nil, err
+	}
+	if p.Rl != nil {
+		release, err := p.Rl.Acquire(ctx, r.Name, nameRef.Context().RegistryStr())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	authConfig, err := GetOCIAuthConfig(p.Cfg, nameRef.Context().Registry, r.AuthUser, r.AuthPass)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get artifact
+	img, err := remote.Image(nameRef, remote.WithAuth(authn.FromConfig(*authConfig)), remote.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	// Read files from the artifact layer
+	for _, l := range manifest.Layers {
+		switch l.MediaType {
+		case devcontainerLayerMediaType:
+			files, err := readDevcontainerLayer(img, l.Digest, true)
+			if err != nil {
+				return nil, fmt.Errorf("error reading layer: %w", err)
+			}
+			return &hub.DevcontainerArtifact{Digest: digest.String(), Files: files}, nil
+		case devcontainerCollectionLayerMediaType:
+			files, err := readDevcontainerLayer(img, l.Digest, false)
+			if err != nil {
+				return nil, fmt.Errorf("error reading collection layer: %w", err)
+			}
+			return &hub.DevcontainerArtifact{Digest: digest.String(), Files: files}, nil
+		}
+	}
+
+	return nil, errors.New("dev container layer not found")
+}
+
+// readDevcontainerLayer returns the files available in the layer of the image
+// provided identified by the digest given. Layers holding a tar file may be
+// gzip compressed or not, so both options are supported.
+func readDevcontainerLayer(img v1.Image, digest v1.Hash, isTar bool) (map[string][]byte, error) {
+	layer, err := img.LayerByDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	if !isTar {
+		data, err := io.ReadAll(io.LimitReader(rc, xpkgMaxFileSize))
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{DevcontainerCollectionFile: data}, nil
+	}
+	return readTarFiles(rc)
+}
+
+// readTarFiles returns the regular files available in the tar stream provided,
+// indexed by their path. The stream is decompressed when needed.
+func readTarFiles(r io.Reader) (map[string][]byte, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gzr.Close()
+		return readXpkgFiles(gzr)
+	}
+	return readXpkgFiles(br)
+}
@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDevcontainerFiles(t *testing.T) {
+	t.Parallel()
+
+	// Prepare layer content
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	data := `{"id": "node", "version": "1.0.0"}`
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     "./devcontainer-feature.json",
+		Typeflag: tar.TypeReg,
+		Mode:     0644,
+		Size:     int64(len(data)),
+	}))
+	_, err := tw.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	expectedFiles := map[string][]byte{
+		"devcontainer-feature.json": []byte(data),
+	}
+
+	t.Run("uncompressed layer", func(t *testing.T) {
+		t.Parallel()
+		files, err := readTarFiles(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, expectedFiles, files)
+	})
+
+	t.Run("gzip compressed layer", func(t *testing.T) {
+		t.Parallel()
+		var gzBuf bytes.Buffer
+		gzw := gzip.NewWriter(&gzBuf)
+		_, err := gzw.Write(buf.Bytes())
+		require.NoError(t, err)
+		require.NoError(t, gzw.Close())
+		files, err := readTarFiles(&gzBuf)
+		require.NoError(t, err)
+		assert.Equal(t, expectedFiles, files)
+	})
+}
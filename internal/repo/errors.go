@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/rs/zerolog/log"
@@ -34,16 +35,20 @@ type ErrorsCollector struct {
 	rm   hub.RepositoryManager
 	kind ErrorsCollectorKind
 
-	mu     sync.Mutex
-	errors map[string][]string // K: repository id
+	mu      sync.Mutex
+	errors  map[string][]string                   // K: repository id
+	started map[string]time.Time                  // K: repository id
+	runs    map[string]*hub.RepositoryTrackingRun // K: repository id
 }
 
 // NewErrorsCollector creates a new ErrorsCollector instance.
 func NewErrorsCollector(repoManager hub.RepositoryManager, kind ErrorsCollectorKind) *ErrorsCollector {
 	return &ErrorsCollector{
-		rm:     repoManager,
-		kind:   kind,
-		errors: make(map[string][]string),
+		rm:      repoManager,
+		kind:    kind,
+		errors:  make(map[string][]string),
+		started: make(map[string]time.Time),
+		runs:    make(map[string]*hub.RepositoryTrackingRun),
 	}
 }
 
@@ -57,6 +62,23 @@ func (c *ErrorsCollector) Append(repositoryID string, err string) {
 	}
 }
 
+// Finish records the results of the run provided for the repository given,
+// including when it started and how long it took since its list of errors was
+// initialized.
+func (c *ErrorsCollector) Finish(repositoryID string, run *hub.RepositoryTrackingRun) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if run == nil {
+		run = &hub.RepositoryTrackingRun{}
+	}
+	if started, ok := c.started[repositoryID]; ok {
+		run.StartedAt = started.Unix()
+		run.DurationMs = time.Since(started).Milliseconds()
+	}
+	c.runs[repositoryID] = run
+}
+
 // Flush aggregates all errors collected per repository as a single text and
 // stores it in the database.
 func (c *ErrorsCollector) Flush() {
@@ -83,7 +105,8 @@ func (c *ErrorsCollector) Flush() {
 		case Scanner:
 			err = c.rm.SetLastScanningResults(context.Background(), repositoryID, allErrors.String())
 		case Tracker:
-			err = c.rm.SetLastTrackingResults(context.Background(), repositoryID, allErrors.String())
+			run := c.runs[repositoryID]
+			err = c.rm.SetLastTrackingResults(context.Background(), repositoryID, allErrors.String(), run)
 		}
 		if err != nil {
 			log.Error().Err(err).Str("repoID", repositoryID).Send()
@@ -101,4 +124,7 @@ func (c *ErrorsCollector) Init(repositoryID string) {
 	if _, ok := c.errors[repositoryID]; !ok {
 		c.errors[repositoryID] = nil
 	}
+	if _, ok := c.started[repositoryID]; !ok {
+		c.started[repositoryID] = time.Now()
+	}
 }
@@ -3,6 +3,9 @@ package repo
 import (
 	"context"
 	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCollector(t *testing.T) {
@@ -31,6 +34,11 @@ func TestCollector(t *testing.T) {
 			// Initialize list of errors for repo1 (repo2 will be implicitly initialized)
 			ec.Init("repo1")
 
+			// Finish processing repo1 (repo2 was never initialized, so no start
+			// time or duration will be recorded for it)
+			ec.Finish("repo1", &hub.RepositoryTrackingRun{PackagesAdded: 1})
+			ec.Finish("repo2", nil)
+
 			// Append some errors for both repositories
 			ec.Append("repo1", "error1")
 			ec.Append("repo1", "error2")
@@ -38,8 +46,17 @@ func TestCollector(t *testing.T) {
 			ec.Append("repo2", "error1")
 
 			// Flush errors and check the results were set as expected
-			rm.On(tc.expectedCall, context.Background(), "repo1", "error1\nerror2").Return(nil)
-			rm.On(tc.expectedCall, context.Background(), "repo2", "error1\nerror2").Return(nil)
+			if tc.kind == Tracker {
+				rm.On(tc.expectedCall, context.Background(), "repo1", "error1\nerror2", mock.MatchedBy(
+					func(run *hub.RepositoryTrackingRun) bool {
+						return run.StartedAt > 0 && run.PackagesAdded == 1
+					},
+				)).Return(nil)
+				rm.On(tc.expectedCall, context.Background(), "repo2", "error1\nerror2", &hub.RepositoryTrackingRun{}).Return(nil)
+			} else {
+				rm.On(tc.expectedCall, context.Background(), "repo1", "error1\nerror2").Return(nil)
+				rm.On(tc.expectedCall, context.Background(), "repo2", "error1\nerror2").Return(nil)
+			}
 			ec.Flush()
 			rm.AssertExpectations(t)
 		})
@@ -0,0 +1,183 @@
+package repo
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+)
+
+const (
+	// githubAPIURL represents the base url of the GitHub API.
+	githubAPIURL = "https://api.github.com"
+
+	// githubAppJWTLifetime represents the lifetime of the JWTs used to
+	// authenticate as a GitHub App (GitHub allows a maximum of 10 minutes).
+	githubAppJWTLifetime = 10 * time.Minute
+
+	// githubAppTokenRefreshMargin represents how long before its expiration an
+	// installation access token will be refreshed.
+	githubAppTokenRefreshMargin = 5 * time.Minute
+)
+
+var (
+	// errInvalidPrivateKey indicates that the GitHub App private key provided
+	// is not valid.
+	errInvalidPrivateKey = errors.New("invalid github app private key")
+)
+
+// GithubAppTokenSource is a hub.GithubTokenSource implementation that provides
+// installation access tokens for a GitHub App. Tokens are cached and refreshed
+// automatically when they are about to expire.
+type GithubAppTokenSource struct {
+	hc             hub.HTTPClient
+	apiURL         string
+	appID          string
+	installationID string
+	key            *rsa.PrivateKey
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewGithubAppTokenSource creates a new GithubAppTokenSource instance. The
+// private key must be provided in PEM format (PKCS1 or PKCS8).
+func NewGithubAppTokenSource(
+	hc hub.HTTPClient,
+	appID,
+	installationID string,
+	privateKey []byte,
+) (*GithubAppTokenSource, error) {
+	if appID == "" {
+		return nil, errors.New("github app id not provided")
+	}
+	if installationID == "" {
+		return nil, errors.New("github app installation id not provided")
+	}
+	key, err := parseRSAPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &GithubAppTokenSource{
+		hc:             hc,
+		apiURL:         githubAPIURL,
+		appID:          appID,
+		installationID: installationID,
+		key:            key,
+	}, nil
+}
+
+// SetupGithubAppTokenSource creates a new GithubAppTokenSource instance from
+// the configuration provided. When no GitHub App has been configured, a nil
+// token source is returned.
+func SetupGithubAppTokenSource(cfg *viper.Viper, hc hub.HTTPClient) (hub.GithubTokenSource, error) {
+	appID := cfg.GetString("creds.githubApp.appID")
+	if appID == "" {
+		return nil, nil
+	}
+	return NewGithubAppTokenSource(
+		hc,
+		appID,
+		cfg.GetString("creds.githubApp.installationID"),
+		[]byte(cfg.GetString("creds.githubApp.privateKey")),
+	)
+}
+
+// Token implements the hub.GithubTokenSource interface.
+func (s *GithubAppTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Return cached token if it's still valid
+	if s.token != "" && time.Until(s.expiresAt) > githubAppTokenRefreshMargin {
+		return s.token, nil
+	}
+
+	// Request a new installation access token
+	jwt, err := s.newJWT(time.Now())
+	if err != nil {
+		return "", err
+	}
+	u := fmt.Sprintf("%s/app/installations/%s/access_tokens", s.apiURL, s.installationID)
+	req, _ := http.NewRequestWithContext(ctx, "POST", u, nil)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	resp, err := s.hc.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("unexpected status code received from github: %d", resp.StatusCode)
+	}
+	var t struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return "", fmt.Errorf("error decoding github installation token: %w", err)
+	}
+	if t.Token == "" {
+		return "", errors.New("empty github installation token received")
+	}
+	s.token = t.Token
+	s.expiresAt = t.ExpiresAt
+
+	return s.token, nil
+}
+
+// newJWT returns a new JWT signed with the GitHub App private key that can be
+// used to authenticate as the GitHub App.
+func (s *GithubAppTokenSource) newJWT(now time.Time) (string, error) {
+	header, _ := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-1 * time.Minute).Unix(), // Allow some clock drift
+		"exp": now.Add(githubAppJWTLifetime - 1*time.Minute).Unix(),
+		"iss": s.appID,
+	})
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("error signing github app jwt: %w", err)
+	}
+	return unsigned + "." + enc.EncodeToString(signature), nil
+}
+
+// parseRSAPrivateKey parses the PEM encoded RSA private key provided.
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errInvalidPrivateKey
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errInvalidPrivateKey
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errInvalidPrivateKey
+	}
+	return rsaKey, nil
+}
@@ -0,0 +1,188 @@
+package repo
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGithubAppTokenSource(t *testing.T) {
+	key, keyPEM := generateTestKey(t)
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			appID          string
+			installationID string
+			privateKey     []byte
+		}{
+			{"", "1", keyPEM},
+			{"1", "", keyPEM},
+			{"1", "1", nil},
+			{"1", "1", []byte("invalid")},
+		}
+		for _, tc := range testCases {
+			_, err := NewGithubAppTokenSource(nil, tc.appID, tc.installationID, tc.privateKey)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("pkcs8 private key", func(t *testing.T) {
+		t.Parallel()
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+		ts, err := NewGithubAppTokenSource(nil, "1", "2", keyPEM)
+		require.NoError(t, err)
+		assert.Equal(t, key.N, ts.key.N)
+	})
+}
+
+func TestSetupGithubAppTokenSource(t *testing.T) {
+	_, keyPEM := generateTestKey(t)
+
+	t.Run("github app not configured", func(t *testing.T) {
+		t.Parallel()
+		ts, err := SetupGithubAppTokenSource(viper.New(), nil)
+		assert.NoError(t, err)
+		assert.Nil(t, ts)
+	})
+
+	t.Run("github app configured", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("creds.githubApp.appID", "1")
+		cfg.Set("creds.githubApp.installationID", "2")
+		cfg.Set("creds.githubApp.privateKey", string(keyPEM))
+		ts, err := SetupGithubAppTokenSource(cfg, nil)
+		assert.NoError(t, err)
+		assert.IsType(t, &GithubAppTokenSource{}, ts)
+	})
+}
+
+func TestGithubAppTokenSourceToken(t *testing.T) {
+	ctx := context.Background()
+	key, keyPEM := generateTestKey(t)
+	tokenURL := "https://api.github.com/app/installations/2/access_tokens"
+
+	t.Run("error requesting token", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
+		ts, _ := NewGithubAppTokenSource(hc, "1", "2", keyPEM)
+
+		token, err := ts.Token(ctx)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Empty(t, token)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("unexpected status code", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusUnauthorized,
+		}, nil)
+		ts, _ := NewGithubAppTokenSource(hc, "1", "2", keyPEM)
+
+		token, err := ts.Token(ctx)
+		assert.Error(t, err)
+		assert.Empty(t, token)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("token requested and cached", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		expiresAt := time.Now().Add(1 * time.Hour).UTC().Format(time.RFC3339)
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == "POST" &&
+				req.URL.String() == tokenURL &&
+				isValidJWT(key, "1", strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+		})).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"token": "token1", "expires_at": "%s"}`, expiresAt))),
+			StatusCode: http.StatusCreated,
+		}, nil).Once()
+		ts, _ := NewGithubAppTokenSource(hc, "1", "2", keyPEM)
+
+		token, err := ts.Token(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "token1", token)
+		token, err = ts.Token(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "token1", token)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("token about to expire refreshed", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		expiresAt := time.Now().Add(1 * time.Hour).UTC().Format(time.RFC3339)
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"token": "token2", "expires_at": "%s"}`, expiresAt))),
+			StatusCode: http.StatusCreated,
+		}, nil).Once()
+		ts, _ := NewGithubAppTokenSource(hc, "1", "2", keyPEM)
+		ts.token = "token1"
+		ts.expiresAt = time.Now().Add(1 * time.Minute)
+
+		token, err := ts.Token(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "token2", token)
+		hc.AssertExpectations(t)
+	})
+}
+
+func generateTestKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	return key, keyPEM
+}
+
+func isValidJWT(key *rsa.PrivateKey, appID, jwt string) bool {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		return false
+	}
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return false
+	}
+	return claims["iss"] == appID
+}
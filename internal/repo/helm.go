@@ -1,14 +1,19 @@
 package repo
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"path"
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
 	"helm.sh/helm/v3/pkg/getter"
 	helmrepo "helm.sh/helm/v3/pkg/repo"
 	"sigs.k8s.io/yaml"
@@ -18,12 +23,53 @@ const (
 	helmRepoIndexFile = "index.yaml"
 )
 
+// ErrIndexNotModified indicates that the Helm repository index file has not
+// been modified since the last time it was processed.
+var ErrIndexNotModified = errors.New("index file not modified")
+
 // HelmIndexLoader provides a mechanism to load a Helm repository index file,
-// verifying it is valid.
-type HelmIndexLoader struct{}
+// verifying it is valid. When a requests limiter is provided, the limits
+// configured for the repository and its host will be honored. The HTTP client
+// provided is used for conditional requests.
+type HelmIndexLoader struct {
+	Cfg *viper.Viper
+	Rl  hub.RequestsLimiter
+	Hc  hub.HTTPClient
+}
 
 // LoadIndex downloads and parses the index file of the provided repository.
 func (l *HelmIndexLoader) LoadIndex(r *hub.Repository) (*helmrepo.IndexFile, string, error) {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return nil, "", err
+	}
+	if l.Rl != nil {
+		release, err := l.Rl.Acquire(context.Background(), r.Name, u.Host)
+		if err != nil {
+			return nil, "", err
+		}
+		defer release()
+	}
+
+	// Repositories hosted in object storage buckets are accessed directly
+	if SchemeIsObjectStorage(u) {
+		u.Path = path.Join(u.Path, helmRepoIndexFile)
+		obj, err := GetRepositoryObject(context.Background(), l.Cfg, u)
+		if err != nil {
+			return nil, "", err
+		}
+		defer obj.Close()
+		indexBytes, err := ioutil.ReadAll(obj)
+		if err != nil {
+			return nil, "", err
+		}
+		indexFile, err := loadIndexFile(indexBytes)
+		if err != nil {
+			return nil, "", err
+		}
+		return indexFile, getDigest(indexBytes), nil
+	}
+
 	repoConfig := &helmrepo.Entry{
 		Name:     r.Name,
 		URL:      r.URL,
@@ -53,6 +99,74 @@ func (l *HelmIndexLoader) LoadIndex(r *hub.Repository) (*helmrepo.IndexFile, str
 	return indexFile, getDigest(indexBytes), nil
 }
 
+// LoadIndexIfModified works like LoadIndex, but it sends a conditional request
+// when the validators (ETag and Last-Modified) of the index file received the
+// last time the repository was processed are available. ErrIndexNotModified
+// is returned when the server reports that the index file has not changed.
+// The validators received are set in the repository provided, so that they
+// can be stored once it has been processed.
+func (l *HelmIndexLoader) LoadIndexIfModified(r *hub.Repository) (*helmrepo.IndexFile, string, error) {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return nil, "", err
+	}
+	if !SchemeIsHTTP(u) {
+		return l.LoadIndex(r)
+	}
+	if l.Rl != nil {
+		release, err := l.Rl.Acquire(context.Background(), r.Name, u.Host)
+		if err != nil {
+			return nil, "", err
+		}
+		defer release()
+	}
+
+	// Prepare conditional request
+	u.RawPath = path.Join(u.RawPath, helmRepoIndexFile)
+	u.Path = path.Join(u.Path, helmRepoIndexFile)
+	req, _ := http.NewRequest("GET", u.String(), nil)
+	if r.AuthUser != "" || r.AuthPass != "" {
+		req.SetBasicAuth(r.AuthUser, r.AuthPass)
+	}
+	if r.Digest != "" {
+		if r.IndexETag != "" {
+			req.Header.Set("If-None-Match", r.IndexETag)
+		}
+		if r.IndexLastModified != "" {
+			req.Header.Set("If-Modified-Since", r.IndexLastModified)
+		}
+	}
+
+	// Fetch index file content from remote location if it has been modified
+	hc := l.Hc
+	if hc == nil {
+		hc = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, "", ErrIndexNotModified
+	default:
+		return nil, "", fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	indexBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	indexFile, err := loadIndexFile(indexBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	r.IndexETag = resp.Header.Get("ETag")
+	r.IndexLastModified = resp.Header.Get("Last-Modified")
+	return indexFile, getDigest(indexBytes), nil
+}
+
 // downloadIndexFile downloads a Helm repository's index file.
 func downloadIndexFile(r *helmrepo.ChartRepository) ([]byte, error) {
 	// Prepare index file url
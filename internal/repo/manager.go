@@ -2,51 +2,105 @@ package repo
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/org"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
-	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/jackc/pgx/v4"
 	"github.com/satori/uuid"
 	"github.com/spf13/viper"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/ssh"
 	"gopkg.in/yaml.v2"
 )
 
 const (
 	// Database queries
+	acceptRepoTransferDBQ     = `select accept_repository_transfer($1::uuid, $2::text)`
 	addRepoDBQ                = `select add_repository($1::uuid, $2::text, $3::jsonb)`
+	addRepoPublishTokenDBQ    = `select add_repository_publish_token($1::uuid, $2::text, $3::jsonb)`
 	checkRepoNameAvailDBQ     = `select repository_id from repository where name = $1`
 	checkRepoURLAvailDBQ      = `select repository_id from repository where trim(trailing '/' from url) = $1`
 	deleteRepoDBQ             = `select delete_repository($1::uuid, $2::text)`
+	deleteRepoPublishTokenDBQ = `select delete_repository_publish_token($1::uuid, $2::text, $3::uuid)`
 	getRepoByIDDBQ            = `select get_repository_by_id($1::uuid, $2::boolean)`
 	getRepoByNameDBQ          = `select get_repository_by_name($1::text, $2::boolean)`
+	getRepoHeadDBQ            = `select get_repository_head($1::text)`
 	getRepoPkgsDigestDBQ      = `select get_repository_packages_digest($1::uuid)`
+	getRepoPublishTokenDBQ    = `select r.name, t.secret from repository_publish_token t join repository r using (repository_id) where t.publish_token_id = $1`
+	getRepoPublishTokensDBQ   = `select get_repository_publish_tokens($1::uuid, $2::text)`
+	getRepoStatsDBQ           = `select get_repository_stats($1::uuid, $2::text)`
+	getRepoTrackingRunsDBQ    = `select * from get_repository_tracking_runs($1::uuid, $2::text, $3::int, $4::int)`
+	getRepoTransferTargetDBQ  = `select o.name from repository_transfer t join repository r using (repository_id) left join organization o on o.organization_id = t.organization_id where r.name = $1 and t.expires_at > current_timestamp`
+	getRepoWebhookSecretDBQ   = `select webhook_secret from repository where name = $1`
 	getUserEmailDBQ           = `select email from "user" where user_id = $1`
+	getUserRepoTransfersDBQ   = `select get_user_repository_transfers($1::uuid)`
+	rejectRepoTransferDBQ     = `select reject_repository_transfer($1::uuid, $2::text)`
+	requestRepoTransferDBQ    = `select request_repository_transfer($1::uuid, $2::text, $3::text, $4::text, $5::int)`
 	searchRepositoriesDBQ     = `select * from search_repositories($1::jsonb)`
 	setLastScanningResultsDBQ = `select set_last_scanning_results($1::uuid, $2::text, $3::boolean)`
-	setLastTrackingResultsDBQ = `select set_last_tracking_results($1::uuid, $2::text, $3::boolean)`
+	setLastTrackingResultsDBQ = `select set_last_tracking_results($1::uuid, $2::text, $3::jsonb, $4::boolean)`
+	setPkgsOverridesDBQ       = `select set_packages_overrides($1::uuid, $2::jsonb)`
+	setRepoArchivedDBQ        = `select set_repository_archived($1::uuid, $2::text, $3::boolean)`
+	setRepoTrackingPausedDBQ  = `select set_repository_tracking_paused($1::uuid, $2::text, $3::boolean)`
+	setRepoWebhookSecretDBQ   = `select set_repository_webhook_secret($1::uuid, $2::text, $3::text)`
 	setVerifiedPublisherDBQ   = `select set_verified_publisher($1::uuid, $2::boolean)`
 	transferRepoDBQ           = `select transfer_repository($1::text, $2::uuid, $3::text, $4::boolean)`
+	triggerRepoTrackingDBQ    = `select request_repository_tracking($1::text)`
 	updateRepoDBQ             = `select update_repository($1::uuid, $2::jsonb)`
 	updateRepoDigestDBQ       = `update repository set digest = $2 where repository_id = $1`
+	updateRepoIndexValsDBQ    = `update repository set index_etag = nullif($2, ''), index_last_modified = nullif($3, '') where repository_id = $1`
+	updateRepoPublishMDDBQ    = `update repository set display_name = case when $2::jsonb ? 'display_name' then nullif($2::jsonb->>'display_name', '') else display_name end, branch = case when $2::jsonb ? 'branch' then nullif($2::jsonb->>'branch', '') else branch end where name = $1 and archived = false`
+	verifyRepoDomainDBQ       = `select verify_repository_domain($1::uuid, $2::text)`
+
+	// defaultTransferTTL represents the default period of time during which a
+	// repository transfer request can be accepted.
+	defaultTransferTTL = 7 * 24 * time.Hour
+
+	// domainVerificationPrefix represents the prefix of the DNS TXT record
+	// value used to verify the domain of a repository.
+	domainVerificationPrefix = "artifacthub-verification="
+
+	// domainVerificationPath represents the path of the file that can be used
+	// to verify the domain of a repository.
+	domainVerificationPath = "/.well-known/artifacthub"
+
+	// maxRepositoryLabels represents the maximum number of labels that can be
+	// attached to a repository.
+	maxRepositoryLabels = 10
 )
 
 var (
+	// repositoryLabelRE is a regexp used to validate a repository label.
+	repositoryLabelRE = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,29}$`)
+
 	// repositoryNameRE is a regexp used to validate a repository name.
 	repositoryNameRE = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
 
@@ -61,6 +115,36 @@ var (
 	// repository URL.
 	GitRepoURLRE = regexp.MustCompile(`^(https:\/\/(github|gitlab)\.com\/[A-Za-z0-9_.-]+\/[A-Za-z0-9_.-]+)\/?(.*)$`)
 
+	// SSHGitRepoURLRE is a regexp used to validate and parse a git based
+	// repository URL accessed using ssh.
+	SSHGitRepoURLRE = regexp.MustCompile(`^(ssh:\/\/(?:[A-Za-z0-9_.-]+@)?([A-Za-z0-9_.-]+)(?::[0-9]+)?\/[A-Za-z0-9_.-]+\/[A-Za-z0-9_.-]+)\/?(.*)$`)
+
+	// TerraformRegistryURLRE is a regexp used to validate and parse the url
+	// of a Terraform module published in a registry.
+	TerraformRegistryURLRE = regexp.MustCompile(`^tfr:\/\/([A-Za-z0-9.-]+(?::[0-9]+)?)\/([A-Za-z0-9_-]+)\/([A-Za-z0-9_-]+)\/([A-Za-z0-9_-]+)\/?$`)
+
+	// errRepoNotFoundDB represents the error returned from the database when
+	// the repository provided does not exist.
+	errRepoNotFoundDB = errors.New("ERROR: repository not found (SQLSTATE P0001)")
+
+	// errRepoTransferNotFoundDB represents the error returned from the
+	// database when there is no pending transfer for the repository provided.
+	errRepoTransferNotFoundDB = errors.New("ERROR: repository transfer not found (SQLSTATE P0001)")
+
+	// errRepoUnderLegalHoldDB represents the error returned from the database
+	// when the repository provided (or any of its packages) is under a legal
+	// hold and cannot be deleted.
+	errRepoUnderLegalHoldDB = errors.New("ERROR: resource under legal hold (SQLSTATE P0001)")
+
+	// errTransferTargetIsOwnerDB represents the error returned from the
+	// database when the target of a transfer already owns the repository.
+	errTransferTargetIsOwnerDB = errors.New("ERROR: repository already owned by target (SQLSTATE P0001)")
+
+	// errTransferTargetNotFoundDB represents the error returned from the
+	// database when the target user or organization of a transfer does not
+	// exist.
+	errTransferTargetNotFoundDB = errors.New("ERROR: target not found (SQLSTATE P0001)")
+
 	// validRepositoryKinds contains the repository kinds supported.
 	validRepositoryKinds = []hub.RepositoryKind{
 		hub.Falco,
@@ -74,6 +158,18 @@ var (
 		hub.KedaScaler,
 		hub.CoreDNS,
 		hub.Keptn,
+		hub.Kustomize,
+		hub.Terraform,
+		hub.Crossplane,
+		hub.Kyverno,
+		hub.Gatekeeper,
+		hub.ArgoWorkflowTemplate,
+		hub.Backstage,
+		hub.Wasm,
+		hub.Devcontainer,
+		hub.Headlamp,
+		hub.Knative,
+		hub.Container,
 	}
 )
 
@@ -85,6 +181,7 @@ type Manager struct {
 	rc              hub.RepositoryCloner
 	helmIndexLoader hub.HelmIndexLoader
 	az              hub.Authorizer
+	rs              hub.TXTResolver
 }
 
 // NewManager creates a new Manager instance.
@@ -99,9 +196,10 @@ func NewManager(
 	m := &Manager{
 		cfg:             cfg,
 		db:              db,
-		helmIndexLoader: &HelmIndexLoader{},
+		helmIndexLoader: &HelmIndexLoader{Cfg: cfg, Hc: hc},
 		az:              az,
 		hc:              hc,
+		rs:              net.DefaultResolver,
 	}
 	for _, o := range opts {
 		o(m)
@@ -109,7 +207,7 @@ func NewManager(
 
 	// Setup repository cloner
 	if m.rc == nil {
-		m.rc = &Cloner{}
+		m.rc = NewCloner(cfg, nil)
 	}
 
 	return m
@@ -123,6 +221,41 @@ func WithHelmIndexLoader(l hub.HelmIndexLoader) func(m *Manager) {
 	}
 }
 
+// WithTXTResolver allows providing a specific TXTResolver implementation for
+// a Manager instance.
+func WithTXTResolver(rs hub.TXTResolver) func(m *Manager) {
+	return func(m *Manager) {
+		m.rs = rs
+	}
+}
+
+// AcceptTransfer accepts a pending transfer of the provided repository to the
+// requesting user or to an organization the user belongs to.
+func (m *Manager) AcceptTransfer(ctx context.Context, name string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+
+	// Authorize action and check the target organization quota if the
+	// repository is being transferred to an organization
+	orgName, err := m.authorizeTransferTarget(ctx, name)
+	if err != nil {
+		return err
+	}
+	if orgName != "" {
+		if err := org.CheckQuota(ctx, m.db, m.cfg, userID, orgName, org.RepositoriesQuota); err != nil {
+			return err
+		}
+	}
+
+	// Accept repository transfer in database
+	_, err = m.db.Exec(ctx, acceptRepoTransferDBQ, userID, name)
+	return translateTransferDBErr(err)
+}
+
 // Add adds the provided repository to the database.
 func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -143,6 +276,23 @@ func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) er
 	if err := m.validateCredentials(r); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
 	}
+	if err := m.prepareSSHKey(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := validateSigningPolicy(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := validateSignKeyring(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := normalizeLabels(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if r.TrackingSchedule != "" {
+		if _, err := util.ParseCronSchedule(r.TrackingSchedule); err != nil {
+			return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid tracking schedule", err.Error())
+		}
+	}
 
 	// Authorize action if the repository will be added to an organization
 	if orgName != "" {
@@ -153,6 +303,9 @@ func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) er
 		}); err != nil {
 			return err
 		}
+		if err := org.CheckQuota(ctx, m.db, m.cfg, userID, orgName, org.RepositoriesQuota); err != nil {
+			return err
+		}
 	}
 
 	// Add repository to the database
@@ -164,6 +317,98 @@ func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) er
 	return err
 }
 
+// AddPublishToken adds a new publish token to the provided repository. The
+// token secret is only returned once, as only its hash is stored.
+func (m *Manager) AddPublishToken(
+	ctx context.Context,
+	name string,
+	t *hub.RepositoryPublishToken,
+) (*hub.RepositoryPublishToken, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	if t.Name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "token name not provided")
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return nil, err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkNotArchived(r); err != nil {
+		return nil, err
+	}
+
+	// Generate publish token secret
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return nil, err
+	}
+	secret := base64.StdEncoding.EncodeToString(randomBytes)
+
+	// Add publish token to the database
+	var publishTokenID string
+	tJSON, _ := json.Marshal(&hub.RepositoryPublishToken{
+		Name:   t.Name,
+		Secret: hashPublishTokenSecret(secret),
+	})
+	err = m.db.QueryRow(ctx, addRepoPublishTokenDBQ, userID, name, tJSON).Scan(&publishTokenID)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return nil, hub.ErrInsufficientPrivilege
+		}
+		return nil, err
+	}
+
+	return &hub.RepositoryPublishToken{
+		PublishTokenID: publishTokenID,
+		Name:           t.Name,
+		Secret:         secret,
+	}, nil
+}
+
+// Check performs a dry validation of the repository provided, checking that
+// it can be accessed, that its metadata file is valid and that it contains at
+// least one package. The repository is not added to the database.
+func (m *Manager) Check(ctx context.Context, r *hub.Repository) (*hub.RepositoryCheck, error) {
+	// Validate input
+	if !isValidKind(r.Kind) {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid kind")
+	}
+	if r.URL == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "url not provided")
+	}
+
+	// Run checks
+	c := &repositoryChecker{
+		m:   m,
+		ctx: ctx,
+		r:   r,
+		rc:  &hub.RepositoryCheck{Valid: true},
+	}
+	defer c.cleanup()
+	c.run("url", c.checkURL)
+	c.run("credentials", c.checkCredentials)
+	c.run("access", c.checkAccess)
+	c.run("metadata", c.checkMetadata)
+	c.run("packages", c.checkPackages)
+
+	return c.rc, nil
+}
+
 // CheckAvailability checks the availability of a given value for the provided
 // resource kind.
 func (m *Manager) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
@@ -203,6 +448,83 @@ func (m *Manager) CheckAvailability(ctx context.Context, resourceKind, value str
 	return available, err
 }
 
+// CheckPublishToken checks if the publish token provided is valid for the
+// given repository.
+func (m *Manager) CheckPublishToken(ctx context.Context, name, publishTokenID, secret string) (bool, error) {
+	// Validate input
+	if name == "" || publishTokenID == "" || secret == "" {
+		return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name, publish token id or secret not provided")
+	}
+	if _, err := uuid.FromString(publishTokenID); err != nil {
+		return false, nil
+	}
+
+	// Get token's repository name and secret from database
+	var repoName, secretHashed string
+	err := m.db.QueryRow(ctx, getRepoPublishTokenDBQ, publishTokenID).Scan(&repoName, &secretHashed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// Check if the token belongs to the repository and the secret is valid
+	secretMatches := subtle.ConstantTimeCompare([]byte(hashPublishTokenSecret(secret)), []byte(secretHashed)) == 1
+	if repoName != name || !secretMatches {
+		return false, nil
+	}
+	return true, nil
+}
+
+// CheckWebhookSignature checks if the signature provided is valid for the
+// webhook payload received for the given repository. Depending on the
+// provider, the signature is an HMAC SHA256 of the payload or the webhook
+// secret itself.
+func (m *Manager) CheckWebhookSignature(
+	ctx context.Context,
+	name string,
+	provider hub.RepositoryWebhookProvider,
+	signature string,
+	payload []byte,
+) (bool, error) {
+	// Validate input
+	if name == "" || signature == "" {
+		return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name or signature not provided")
+	}
+
+	// Get repository webhook secret from database
+	var encryptedSecret *string
+	err := m.db.QueryRow(ctx, getRepoWebhookSecretDBQ, name).Scan(&encryptedSecret)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	if encryptedSecret == nil {
+		return false, nil
+	}
+	secret, err := util.Decrypt(m.cfg.GetString("db.encryptionKey"), *encryptedSecret)
+	if err != nil {
+		return false, fmt.Errorf("error decrypting webhook secret: %w", err)
+	}
+
+	// Check signature
+	var expected string
+	switch provider {
+	case hub.GitHubWebhook:
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write(payload)
+		expected = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	case hub.GitLabWebhook, hub.HarborWebhook:
+		expected = secret
+	default:
+		return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook provider")
+	}
+	return hmac.Equal([]byte(signature), []byte(expected)), nil
+}
+
 // ClaimOwnership allows a user to claim the ownership of a given repository.
 // The repository will be transferred to the destination entity requested if
 // the user is listed as one of the owners in the repository metadata file.
@@ -223,21 +545,12 @@ func (m *Manager) ClaimOwnership(ctx context.Context, repoName, orgName string)
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "ownership claim not available for oci repos")
 	}
 	var mdFile string
-	switch r.Kind {
-	case hub.Helm:
+	switch {
+	case r.Kind == hub.Helm && !IsHelmGitRepository(r):
 		u, _ := url.Parse(r.URL)
 		u.Path = path.Join(u.Path, hub.RepositoryMetadataFile)
 		mdFile = u.String()
-	case hub.Falco,
-		hub.HelmPlugin,
-		hub.Krew,
-		hub.OLM,
-		hub.OPA,
-		hub.TBAction,
-		hub.TektonTask,
-		hub.KedaScaler,
-		hub.CoreDNS,
-		hub.Keptn:
+	default:
 		tmpDir, packagesPath, err := m.rc.CloneRepository(ctx, r)
 		if err != nil {
 			return err
@@ -292,12 +605,105 @@ func (m *Manager) Delete(ctx context.Context, name string) error {
 
 	// Delete repository from database
 	_, err = m.db.Exec(ctx, deleteRepoDBQ, userID, name)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case errRepoUnderLegalHoldDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository under legal hold")
+		}
+	}
+	return err
+}
+
+// DeletePublishToken deletes the provided publish token from the repository
+// given.
+func (m *Manager) DeletePublishToken(ctx context.Context, name, publishTokenID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	if _, err := uuid.FromString(publishTokenID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid publish token id")
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Delete publish token from database
+	_, err = m.db.Exec(ctx, deleteRepoPublishTokenDBQ, userID, name, publishTokenID)
 	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
 		return hub.ErrInsufficientPrivilege
 	}
 	return err
 }
 
+// GenerateWebhookSecret generates a new secret for the webhooks used to
+// trigger the tracking of the provided repository, replacing the existing one
+// if any. The secret is stored encrypted and only returned once.
+func (m *Manager) GenerateWebhookSecret(ctx context.Context, name string) (string, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return "", err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+		}); err != nil {
+			return "", err
+		}
+	}
+	if err := checkNotArchived(r); err != nil {
+		return "", err
+	}
+
+	// Generate webhook secret
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	secret := hex.EncodeToString(randomBytes)
+	encryptedSecret, err := util.Encrypt(m.cfg.GetString("db.encryptionKey"), secret)
+	if err != nil {
+		return "", err
+	}
+
+	// Update repository webhook secret in database
+	_, err = m.db.Exec(ctx, setRepoWebhookSecretDBQ, userID, name, encryptedSecret)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return "", hub.ErrInsufficientPrivilege
+		}
+		return "", err
+	}
+
+	return secret, nil
+}
+
 // GetByID returns the repository identified by the id provided.
 func (m *Manager) GetByID(
 	ctx context.Context,
@@ -335,6 +741,20 @@ func (m *Manager) GetByName(
 	return r, err
 }
 
+// GetHead returns some information about the repository identified by the
+// name provided that allows checking cheaply if it has changed.
+func (m *Manager) GetHead(ctx context.Context, name string) (*hub.ResourceHead, error) {
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Get repository head from database
+	var h *hub.ResourceHead
+	err := util.DBQueryUnmarshal(ctx, m.db, &h, getRepoHeadDBQ, name)
+	return h, err
+}
+
 // GetMetadata reads and parses the repository metadata file provided, which
 // can be a remote URL or a local file path. The .yml and .yaml extensions will
 // be implicitly appended to the given path.
@@ -345,6 +765,7 @@ func (m *Manager) GetMetadata(mdFile string) (*hub.RepositoryMetadata, error) {
 	for _, extension := range []string{".yml", ".yaml"} {
 		data, err = m.readMetadataFile(mdFile + extension)
 		if err == nil {
+			mdFile += extension
 			break
 		}
 	}
@@ -354,17 +775,107 @@ func (m *Manager) GetMetadata(mdFile string) (*hub.RepositoryMetadata, error) {
 
 	var md *hub.RepositoryMetadata
 	if err = yaml.Unmarshal(data, &md); err != nil || md == nil {
-		return nil, fmt.Errorf("error unmarshaling repository metadata file: %w", err)
+		return nil, fmt.Errorf("%w: error unmarshaling repository metadata file: %v", ErrInvalidMetadata, err)
 	}
 	if md.RepositoryID != "" {
 		if _, err := uuid.FromString(md.RepositoryID); err != nil {
 			return nil, fmt.Errorf("%w: %s", ErrInvalidMetadata, "invalid repository id")
 		}
 	}
+	if md.SignKey != nil && md.SignKey.URL == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMetadata, "sign key url not provided")
+	}
+	if md.CosignIdentity != nil && (md.CosignIdentity.Subject == "" || md.CosignIdentity.Issuer == "") {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMetadata, "cosign identity subject and issuer must be provided")
+	}
+	if err := validateMetadataV2(md); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
+	}
+	if md.Signed {
+		if err := m.verifyMetadataSignature(mdFile, data, md.SignKey); err != nil {
+			return nil, fmt.Errorf("%w: error verifying metadata file signature: %v", ErrInvalidMetadata, err)
+		}
+	}
 
 	return md, nil
 }
 
+// validateMetadataV2 checks that the fields introduced in the version 2 of the
+// repository metadata file are valid, and that they are only used when the
+// version 2 has been explicitly requested.
+func validateMetadataV2(md *hub.RepositoryMetadata) error {
+	switch md.Version {
+	case 0, 1:
+		if md.Signed || len(md.Packages) > 0 {
+			return errors.New("packages overrides and signing require metadata file version 2")
+		}
+		return nil
+	case hub.RepositoryMetadataV2:
+	default:
+		return fmt.Errorf("unsupported metadata file version: %d", md.Version)
+	}
+
+	for _, owner := range md.Owners {
+		if owner == nil || owner.Email == "" {
+			return errors.New("owner email not provided")
+		}
+	}
+	if md.Signed && md.SignKey == nil {
+		return errors.New("sign key required to verify the metadata file signature")
+	}
+	names := make(map[string]struct{}, len(md.Packages))
+	for _, o := range md.Packages {
+		if o == nil || o.Name == "" {
+			return errors.New("package override name not provided")
+		}
+		if _, ok := names[o.Name]; ok {
+			return fmt.Errorf("duplicated package override: %s", o.Name)
+		}
+		names[o.Name] = struct{}{}
+		if o.Category != "" && !hub.IsValidPackageCategory(o.Category) {
+			return fmt.Errorf("invalid package category: %s", o.Category)
+		}
+	}
+	return nil
+}
+
+// verifyMetadataSignature verifies the signature of the metadata file provided
+// using the sign key given. The signature is expected to be available next to
+// the metadata file (artifacthub-repo.yml.sig), base64 encoded (i.e. as
+// generated by cosign sign-blob).
+func (m *Manager) verifyMetadataSignature(mdFile string, data []byte, signKey *hub.SignKey) error {
+	sigData, err := m.readMetadataFile(mdFile + ".sig")
+	if err != nil {
+		return fmt.Errorf("error reading signature: %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sigData)))
+	if err != nil {
+		return fmt.Errorf("error decoding signature: %w", err)
+	}
+	u, err := url.Parse(signKey.URL)
+	if err != nil || !SchemeIsHTTP(u) {
+		return errors.New("invalid sign key url")
+	}
+	req, _ := http.NewRequest("GET", signKey.URL, nil)
+	resp, err := m.hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("error downloading sign key: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code received downloading sign key: %d", resp.StatusCode)
+	}
+	keyData, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading sign key: %w", err)
+	}
+	publicKey, err := parsePublicKey(keyData)
+	if err != nil {
+		return err
+	}
+	return verifySignature(publicKey, data, signature)
+}
+
 // readMetadataFile reads the repository metadata from the provided file.
 func (m *Manager) readMetadataFile(mdFile string) ([]byte, error) {
 	var data []byte
@@ -374,6 +885,16 @@ func (m *Manager) readMetadataFile(mdFile string) ([]byte, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error reading repository metadata file: %w", err)
 		}
+	} else if SchemeIsObjectStorage(u) {
+		obj, err := GetRepositoryObject(context.Background(), m.cfg, u)
+		if err != nil {
+			return nil, fmt.Errorf("error downloading repository metadata file: %w", err)
+		}
+		defer obj.Close()
+		data, err = ioutil.ReadAll(obj)
+		if err != nil {
+			return nil, fmt.Errorf("error reading repository metadata file: %w", err)
+		}
 	} else {
 		req, _ := http.NewRequest("GET", mdFile, nil)
 		resp, err := m.hc.Do(req)
@@ -409,16 +930,36 @@ func (m *Manager) GetPackagesDigest(
 	return pd, err
 }
 
+// GetPublishTokensJSON returns the publish tokens of the provided repository
+// as a json array. Tokens secrets are not included.
+func (m *Manager) GetPublishTokensJSON(ctx context.Context, name string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Get publish tokens from database
+	return util.DBQueryJSON(ctx, m.db, getRepoPublishTokensDBQ, userID, name)
+}
+
 // GetRemoteDigest gets the repository's digest available in the remote.
 func (m *Manager) GetRemoteDigest(ctx context.Context, r *hub.Repository) (string, error) {
 	var digest string
 	u, _ := url.Parse(r.URL)
+	gitURL := strings.TrimPrefix(r.URL, hub.RepositoryGitPrefix)
 
 	switch {
 	case r.Kind == hub.Helm && SchemeIsHTTP(u):
-		// Digest is obtained hashing the repository index.yaml file
+		// Digest is obtained hashing the repository index.yaml file, which is
+		// only downloaded when it has been modified since the last time the
+		// repository was processed
 		var err error
-		_, digest, err = m.helmIndexLoader.LoadIndex(r)
+		_, digest, err = m.helmIndexLoader.LoadIndexIfModified(r)
+		if errors.Is(err, ErrIndexNotModified) {
+			return r.Digest, nil
+		}
 		if err != nil {
 			return "", err
 		}
@@ -436,37 +977,163 @@ func (m *Manager) GetRemoteDigest(ctx context.Context, r *hub.Repository) (strin
 		}
 		digest = desc.Digest.String()
 
-	case GitRepoURLRE.MatchString(r.URL):
-		// Digest is obtained from the last commit in the repository
-		matches := GitRepoURLRE.FindStringSubmatch(r.URL)
+	case GitRepoURLRE.MatchString(gitURL) || SSHGitRepoURLRE.MatchString(gitURL):
+		// Digest is obtained from the last commit in the repository. The tags
+		// are taken into account as well in Helm git repositories, as new
+		// charts versions can be released just by tagging them.
+		matches := GitRepoURLRE.FindStringSubmatch(gitURL)
+		if matches == nil {
+			matches = SSHGitRepoURLRE.FindStringSubmatch(gitURL)
+		}
 		repoBaseURL := matches[1]
 		remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
 			URLs: []string{repoBaseURL},
 		})
-		listOptions := &git.ListOptions{}
-		if r.AuthPass != "" {
-			listOptions.Auth = &githttp.BasicAuth{
-				Username: "artifact-hub",
-				Password: r.AuthPass,
-			}
+		auth, err := getGitAuth(ctx, m.cfg, nil, r, repoBaseURL)
+		if err != nil {
+			return digest, err
 		}
-		refs, err := remote.List(listOptions)
+		refs, err := remote.List(&git.ListOptions{Auth: auth})
 		if err != nil {
 			return digest, err
 		}
 		branch := GetBranch(r)
+		var tags []string
 		for _, ref := range refs {
 			if ref.Name().IsBranch() && ref.Name().Short() == branch {
 				digest = ref.Hash().String()
 			}
+			if IsHelmGitRepository(r) && ref.Name().IsTag() {
+				tags = append(tags, ref.Name().Short()+"@"+ref.Hash().String())
+			}
+		}
+		if len(tags) > 0 {
+			sort.Strings(tags)
+			digest = fmt.Sprintf("%x", sha256.Sum256([]byte(digest+"\n"+strings.Join(tags, "\n"))))
 		}
 	}
 
 	return digest, nil
 }
 
-// Search searches for repositories in the database that the criteria defined
-// in the input provided.
+// GetStatsJSON returns some stats about the provided repository as a json
+// object. Only the owner of the repository or the members of the organization
+// owning it can get them.
+func (m *Manager) GetStatsJSON(ctx context.Context, name string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Get repository stats from database
+	return util.DBQueryJSON(ctx, m.db, getRepoStatsDBQ, userID, name)
+}
+
+// GetTrackingRunsJSON returns the tracking runs of the provided repository as
+// a json array, sorted from the most recent. Only the owner of the repository
+// or the members of the organization owning it can get them.
+func (m *Manager) GetTrackingRunsJSON(
+	ctx context.Context,
+	name string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Get tracking runs from database
+	return util.DBQueryJSONWithPagination(ctx, m.db, getRepoTrackingRunsDBQ, userID, name, p.Limit, p.Offset)
+}
+
+// GetTransfersJSON returns the pending repository transfers targeting the
+// requesting user or any of the organizations the user belongs to as a json
+// array.
+func (m *Manager) GetTransfersJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Get pending transfers from database
+	var dataJSON []byte
+	err := m.db.QueryRow(ctx, getUserRepoTransfersDBQ, userID).Scan(&dataJSON)
+	return dataJSON, err
+}
+
+// RejectTransfer rejects a pending transfer of the provided repository to the
+// requesting user or to an organization the user belongs to.
+func (m *Manager) RejectTransfer(ctx context.Context, name string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+
+	// Authorize action if the repository is being transferred to an
+	// organization
+	if _, err := m.authorizeTransferTarget(ctx, name); err != nil {
+		return err
+	}
+
+	// Reject repository transfer in database
+	_, err := m.db.Exec(ctx, rejectRepoTransferDBQ, userID, name)
+	return translateTransferDBErr(err)
+}
+
+// RequestTransfer registers a request to transfer the provided repository to
+// the given user or organization. Unlike Transfer, the target user or
+// organization does not need to be related to the requesting user, but the
+// ownership will not change until the transfer is accepted. Transfer requests
+// expire if they are not accepted in time.
+func (m *Manager) RequestTransfer(ctx context.Context, name, userAlias, orgName string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+	if userAlias == "" && orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "target user alias or organization name not provided")
+	}
+	if userAlias != "" && orgName != "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "only one target user alias or organization name allowed")
+	}
+	var userAliasP, orgNameP *string
+	if userAlias != "" {
+		userAliasP = &userAlias
+	}
+	if orgName != "" {
+		orgNameP = &orgName
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.TransferOrganizationRepository,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := checkNotArchived(r); err != nil {
+		return err
+	}
+
+	// Register repository transfer request in database
+	_, err = m.db.Exec(ctx, requestRepoTransferDBQ, userID, name, userAliasP, orgNameP, m.transferTTL())
+	return translateTransferDBErr(err)
+}
+
+// Search searches for repositories in the database that the criteria defined
+// in the input provided.
 func (m *Manager) Search(
 	ctx context.Context,
 	input *hub.SearchRepositoryInput,
@@ -509,6 +1176,40 @@ func (m *Manager) SearchJSON(
 	return util.DBQueryJSONWithPagination(ctx, m.db, searchRepositoriesDBQ, inputJSON)
 }
 
+// SetArchived archives or unarchives the provided repository. Archived
+// repositories are not tracked and their packages subscriptions don't fire,
+// but their packages remain available.
+func (m *Manager) SetArchived(ctx context.Context, name string, archived bool) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Update repository archived flag in database
+	_, err = m.db.Exec(ctx, setRepoArchivedDBQ, userID, name, archived)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
 // SetLastScanningResults updates the timestamp and errors of the last scanning
 // of the provided repository in the database.
 func (m *Manager) SetLastScanningResults(ctx context.Context, repositoryID, errs string) error {
@@ -524,16 +1225,90 @@ func (m *Manager) SetLastScanningResults(ctx context.Context, repositoryID, errs
 }
 
 // SetLastTrackingResults updates the timestamp and errors of the last tracking
-// of the provided repository in the database.
-func (m *Manager) SetLastTrackingResults(ctx context.Context, repositoryID, errs string) error {
+// of the provided repository in the database, registering the tracking run.
+func (m *Manager) SetLastTrackingResults(
+	ctx context.Context,
+	repositoryID,
+	errs string,
+	run *hub.RepositoryTrackingRun,
+) error {
 	// Validate input
 	if _, err := uuid.FromString(repositoryID); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
 	}
+	if run == nil {
+		run = &hub.RepositoryTrackingRun{}
+	}
 
 	// Update last tracking results in database
 	trackingErrorsEventsEnabled := m.cfg.GetBool("events.trackingErrors")
-	_, err := m.db.Exec(ctx, setLastTrackingResultsDBQ, repositoryID, errs, trackingErrorsEventsEnabled)
+	runJSON, _ := json.Marshal(run)
+	_, err := m.db.Exec(
+		ctx,
+		setLastTrackingResultsDBQ,
+		repositoryID,
+		errs,
+		runJSON,
+		trackingErrorsEventsEnabled,
+	)
+	return err
+}
+
+// SetPackagesOverrides stores the packages overrides provided for the given
+// repository. Overrides previously set for packages not included in the list
+// provided are cleared.
+func (m *Manager) SetPackagesOverrides(
+	ctx context.Context,
+	repositoryID string,
+	overrides []*hub.RepositoryPackageOverride,
+) error {
+	// Validate input
+	if _, err := uuid.FromString(repositoryID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+	}
+
+	// Update packages overrides in database
+	if overrides == nil {
+		overrides = []*hub.RepositoryPackageOverride{}
+	}
+	overridesJSON, _ := json.Marshal(overrides)
+	_, err := m.db.Exec(ctx, setPkgsOverridesDBQ, repositoryID, overridesJSON)
+	return err
+}
+
+// SetTrackingPaused pauses or resumes the tracking of the provided repository.
+// The tracker skips the repositories whose tracking has been paused.
+func (m *Manager) SetTrackingPaused(ctx context.Context, name string, paused bool) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := checkNotArchived(r); err != nil {
+		return err
+	}
+
+	// Update repository tracking paused flag in database
+	_, err = m.db.Exec(ctx, setRepoTrackingPausedDBQ, userID, name, paused)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
 	return err
 }
 
@@ -587,6 +1362,17 @@ func (m *Manager) Transfer(ctx context.Context, repoName, orgName string, owners
 				return err
 			}
 		}
+		if err := checkNotArchived(r); err != nil {
+			return err
+		}
+	}
+
+	// Check the target organization quota when transferring on behalf of a
+	// user
+	if orgName != "" && userID != "" {
+		if err := org.CheckQuota(ctx, m.db, m.cfg, userID, orgName, org.RepositoriesQuota); err != nil {
+			return err
+		}
 	}
 
 	// Update repository owner in database
@@ -597,6 +1383,33 @@ func (m *Manager) Transfer(ctx context.Context, repoName, orgName string, owners
 	return err
 }
 
+// TriggerTracking requests the tracking of the provided repository. The
+// repository digest is reset and the request is flagged, so that it's
+// processed even if nothing has changed on it or its tracking schedule hasn't
+// been activated yet. The database notifies the request to the trackers
+// listening for tracking requests, which will process it immediately. Callers
+// are expected to have checked the publish token or webhook signature
+// provided before calling this method.
+func (m *Manager) TriggerTracking(ctx context.Context, name string) error {
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Archived repositories are not tracked
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return err
+	}
+	if err := checkNotArchived(r); err != nil {
+		return err
+	}
+
+	// Request repository tracking in database
+	_, err = m.db.Exec(ctx, triggerRepoTrackingDBQ, name)
+	return err
+}
+
 // Update updates the provided repository in the database.
 func (m *Manager) Update(ctx context.Context, r *hub.Repository) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -611,6 +1424,23 @@ func (m *Manager) Update(ctx context.Context, r *hub.Repository) error {
 	if err := m.validateCredentials(r); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
 	}
+	if err := m.prepareSSHKey(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := validateSigningPolicy(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := validateSignKeyring(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := normalizeLabels(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if r.TrackingSchedule != "" {
+		if _, err := util.ParseCronSchedule(r.TrackingSchedule); err != nil {
+			return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid tracking schedule", err.Error())
+		}
+	}
 
 	// Authorize action if the repository is owned by an organization
 	rBefore, err := m.GetByName(ctx, r.Name, false)
@@ -626,6 +1456,9 @@ func (m *Manager) Update(ctx context.Context, r *hub.Repository) error {
 			return err
 		}
 	}
+	if err := checkNotArchived(rBefore); err != nil {
+		return err
+	}
 
 	// Update repository in database
 	rJSON, _ := json.Marshal(r)
@@ -642,8 +1475,185 @@ func (m *Manager) UpdateDigest(ctx context.Context, repositoryID, digest string)
 	return err
 }
 
-// validateURL validates the url of the repository provided.
+// UpdateIndexValidators updates the validators (ETag and Last-Modified) of the
+// index file of the provided repository in the database.
+func (m *Manager) UpdateIndexValidators(ctx context.Context, repositoryID, etag, lastModified string) error {
+	_, err := m.db.Exec(ctx, updateRepoIndexValsDBQ, repositoryID, etag, lastModified)
+	return err
+}
+
+// UpdatePublishMetadata updates the metadata publishers are allowed to manage
+// using a publish token (display name and branch) of the provided repository.
+// Only the fields provided are updated. Callers are expected to have checked
+// the publish token provided before calling this method.
+func (m *Manager) UpdatePublishMetadata(
+	ctx context.Context,
+	name string,
+	md *hub.RepositoryPublishMetadata,
+) error {
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	if md == nil || (md.DisplayName == nil && md.Branch == nil) {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "metadata not provided")
+	}
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return err
+	}
+	if err := checkNotArchived(r); err != nil {
+		return err
+	}
+	if md.Branch != nil && *md.Branch != "" && !IsGitRepository(r) {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "branch can only be set in git based repositories")
+	}
+
+	// Update repository metadata in database
+	mdJSON, _ := json.Marshal(md)
+	_, err = m.db.Exec(ctx, updateRepoPublishMDDBQ, name, mdJSON)
+	return err
+}
+
+// VerifyDomain checks if the requesting user controls the domain hosting the
+// provided repository, marking it as verified when that's the case. The
+// domain can be verified by setting up a DNS TXT record or by serving a
+// .well-known/artifacthub file containing the repository verification token.
+func (m *Manager) VerifyDomain(ctx context.Context, name string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.GetByName(ctx, name, true)
+	if err != nil {
+		return err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+		}); err != nil {
+			return err
+		}
+	}
+	if r.DomainVerified {
+		return nil
+	}
+
+	// Check repository domain verification token
+	u, err := url.Parse(r.URL)
+	if err != nil || u.Hostname() == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository url")
+	}
+	if !m.checkDomainTXTRecord(ctx, u.Hostname(), r.DomainVerificationToken) &&
+		!m.checkDomainWellKnownFile(ctx, u.Hostname(), r.DomainVerificationToken) {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "domain verification token not found")
+	}
+
+	// Mark repository domain as verified in database
+	_, err = m.db.Exec(ctx, verifyRepoDomainDBQ, userID, name)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// checkDomainTXTRecord checks if the host provided has a DNS TXT record with
+// the verification token given.
+func (m *Manager) checkDomainTXTRecord(ctx context.Context, host, token string) bool {
+	records, err := m.rs.LookupTXT(ctx, host)
+	if err != nil {
+		return false
+	}
+	for _, record := range records {
+		if record == domainVerificationPrefix+token {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDomainWellKnownFile checks if the host provided serves a well-known
+// file with the verification token given.
+func (m *Manager) checkDomainWellKnownFile(ctx context.Context, host, token string) bool {
+	req, _ := http.NewRequestWithContext(ctx, "GET", "https://"+host+domainVerificationPath, nil)
+	resp, err := m.hc.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(data)) == token
+}
+
+// authorizeTransferTarget checks if the requesting user is allowed to accept or
+// reject the pending transfer of the provided repository when the target of
+// the transfer is an organization. The name of the target organization is
+// returned, or an empty string when the target of the transfer is a user.
+func (m *Manager) authorizeTransferTarget(ctx context.Context, name string) (string, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	var orgName *string
+	if err := m.db.QueryRow(ctx, getRepoTransferTargetDBQ, name).Scan(&orgName); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", hub.ErrNotFound
+		}
+		return "", err
+	}
+	if orgName == nil {
+		return "", nil
+	}
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: *orgName,
+		UserID:           userID,
+		Action:           hub.AddOrganizationRepository,
+	}); err != nil {
+		return "", err
+	}
+	return *orgName, nil
+}
+
+// transferTTL returns the period of time, in seconds, during which a
+// repository transfer request can be accepted.
+func (m *Manager) transferTTL() int {
+	ttl := m.cfg.GetDuration("repositories.transferTTL")
+	if ttl == 0 {
+		ttl = defaultTransferTTL
+	}
+	return int(ttl.Seconds())
+}
+
+// validateURL validates the url of the repository provided. In the case of
+// Helm http based repositories, it also checks that the index can be loaded.
 func (m *Manager) validateURL(r *hub.Repository) error {
+	if err := m.validateURLFormat(r); err != nil {
+		return err
+	}
+	if r.Kind == hub.Helm {
+		u, _ := url.Parse(r.URL)
+		if SchemeIsHTTP(u) {
+			if _, _, err := m.helmIndexLoader.LoadIndex(r); err != nil {
+				return errors.New("the url provided does not point to a valid Helm repository")
+			}
+		}
+	}
+	return nil
+}
+
+// validateURLFormat validates the format of the url of the repository
+// provided, without accessing it.
+func (m *Manager) validateURLFormat(r *hub.Repository) error {
 	if r.URL == "" {
 		return errors.New("url not provided")
 	}
@@ -655,13 +1665,22 @@ func (m *Manager) validateURL(r *hub.Repository) error {
 		return ErrSchemeNotSupported
 	}
 	if u.User != nil {
-		return errors.New("urls with credentials not allowed")
+		if _, hasPassword := u.User.Password(); hasPassword || (u.Scheme != "ssh" && u.Scheme != "git+ssh") {
+			return errors.New("urls with credentials not allowed")
+		}
 	}
 	switch r.Kind {
 	case hub.Helm:
-		if SchemeIsHTTP(u) {
-			if _, _, err := m.helmIndexLoader.LoadIndex(r); err != nil {
-				return errors.New("the url provided does not point to a valid Helm repository")
+		if u.Scheme == "ssh" || SchemeIsTerraformRegistry(u) {
+			return ErrSchemeNotSupported
+		}
+		if SchemeIsGit(u) {
+			gitURL := strings.TrimPrefix(r.URL, hub.RepositoryGitPrefix)
+			if !GitRepoURLRE.MatchString(gitURL) && !SSHGitRepoURLRE.MatchString(gitURL) {
+				return errors.New("invalid url format")
+			}
+			if u.Scheme == "git+ssh" && r.SSHKey == "" {
+				return errors.New("ssh key not provided")
 			}
 		}
 	case hub.Falco,
@@ -673,10 +1692,49 @@ func (m *Manager) validateURL(r *hub.Repository) error {
 		hub.TektonTask,
 		hub.KedaScaler,
 		hub.CoreDNS,
-		hub.Keptn:
+		hub.Keptn,
+		hub.Kustomize,
+		hub.Kyverno,
+		hub.Gatekeeper,
+		hub.ArgoWorkflowTemplate,
+		hub.Backstage,
+		hub.Headlamp,
+		hub.Knative,
+		hub.Terraform:
+		if r.Kind == hub.Terraform && SchemeIsTerraformRegistry(u) {
+			if !TerraformRegistryURLRE.MatchString(r.URL) {
+				return errors.New("invalid url format")
+			}
+			return nil
+		}
+		if r.Kind == hub.TektonTask && u.Scheme == "oci" {
+			// Tekton bundles are stored in OCI registries
+			return nil
+		}
+		if SchemeIsObjectStorage(u) || SchemeIsGit(u) || SchemeIsTerraformRegistry(u) {
+			return ErrSchemeNotSupported
+		}
 		if SchemeIsHTTP(u) && !GitRepoURLRE.MatchString(r.URL) {
 			return errors.New("invalid url format")
 		}
+		if u.Scheme == "ssh" {
+			if !SSHGitRepoURLRE.MatchString(r.URL) {
+				return errors.New("invalid url format")
+			}
+			if r.SSHKey == "" {
+				return errors.New("ssh key not provided")
+			}
+		}
+	case hub.Crossplane, hub.Wasm, hub.Devcontainer, hub.Container:
+		// Crossplane packages, WebAssembly modules, Dev Container artifacts and
+		// container images can only be stored in OCI registries
+		if u.Scheme != "oci" {
+			return ErrSchemeNotSupported
+		}
+	default:
+		if u.Scheme == "ssh" || SchemeIsObjectStorage(u) || SchemeIsGit(u) || SchemeIsTerraformRegistry(u) {
+			return ErrSchemeNotSupported
+		}
 	}
 	return nil
 }
@@ -684,12 +1742,126 @@ func (m *Manager) validateURL(r *hub.Repository) error {
 // validateCredentials validates the credentials of the repository provided.
 func (m *Manager) validateCredentials(r *hub.Repository) error {
 	allowPrivateRepos := m.cfg.GetBool("server.allowPrivateRepositories")
-	if !allowPrivateRepos && (r.AuthUser != "" || r.AuthPass != "") {
+	if !allowPrivateRepos && (r.AuthUser != "" || r.AuthPass != "" || r.SSHKey != "") {
 		return errors.New("private repositories not allowed")
 	}
 	return nil
 }
 
+// validateSigningPolicy checks if the signing policy of the repository provided
+// is valid. Signing policies are only supported by the repositories kinds the
+// tracker can detect signed packages for.
+func validateSigningPolicy(r *hub.Repository) error {
+	switch r.SigningPolicy {
+	case hub.SigningPolicyNone:
+		return nil
+	case hub.SigningPolicyFlag, hub.SigningPolicyReject:
+		if r.Kind != hub.Helm {
+			return errors.New("signing policy not supported by this repository kind")
+		}
+		return nil
+	default:
+		return errors.New("invalid signing policy")
+	}
+}
+
+// validateSignKeyring checks if the sign keyring configured for the repository
+// provided is valid. The keyring can be uploaded or referenced using an url,
+// but not both. Helm repositories expect armored PGP public keys, whereas OPA
+// repositories expect PEM encoded public keys. Like signing policies, keyrings
+// are only supported by the repositories kinds the tracker can verify signed
+// packages for.
+func validateSignKeyring(r *hub.Repository) error {
+	if r.SignKeyring == "" && r.SignKeyringURL == "" {
+		return nil
+	}
+	if r.Kind != hub.Helm && r.Kind != hub.OPA {
+		return errors.New("sign keyring not supported by this repository kind")
+	}
+	if r.SignKeyring != "" && r.SignKeyringURL != "" {
+		return errors.New("sign keyring and sign keyring url cannot be used at the same time")
+	}
+	if r.SignKeyring != "" {
+		switch r.Kind {
+		case hub.Helm:
+			keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(r.SignKeyring))
+			if err != nil || len(keyring) == 0 {
+				return errors.New("invalid sign keyring")
+			}
+		case hub.OPA:
+			if _, err := ParsePublicKeys([]byte(r.SignKeyring)); err != nil {
+				return errors.New("invalid sign keyring")
+			}
+		}
+	}
+	if r.SignKeyringURL != "" {
+		u, err := url.Parse(r.SignKeyringURL)
+		if err != nil || !SchemeIsHTTP(u) || u.Host == "" {
+			return errors.New("invalid sign keyring url")
+		}
+	}
+	return nil
+}
+
+// checkNotArchived returns an error if the repository provided is archived.
+// Archived repositories are read-only: their content remains available, but
+// they cannot be modified until they are unarchived.
+func checkNotArchived(r *hub.Repository) error {
+	if r.Archived {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository is archived, it must be unarchived first")
+	}
+	return nil
+}
+
+// normalizeLabels normalizes the labels of the repository provided (trimming
+// and lowercasing them and removing duplicates) and checks they are valid.
+func normalizeLabels(r *hub.Repository) error {
+	if len(r.Labels) > maxRepositoryLabels {
+		return fmt.Errorf("too many labels (max: %d)", maxRepositoryLabels)
+	}
+	var labels []string
+	seen := make(map[string]struct{}, len(r.Labels))
+	for _, label := range r.Labels {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if !repositoryLabelRE.MatchString(label) {
+			return fmt.Errorf("invalid label: %s", label)
+		}
+		if _, ok := seen[label]; ok {
+			continue
+		}
+		seen[label] = struct{}{}
+		labels = append(labels, label)
+	}
+	r.Labels = labels
+	return nil
+}
+
+// prepareSSHKey validates and encrypts the ssh key of the repository provided
+// so that it can be stored in the database. Keys that were already encrypted
+// (i.e. the repository is being updated and the key hasn't changed) are kept
+// as they are once verified.
+func (m *Manager) prepareSSHKey(r *hub.Repository) error {
+	if r.SSHKey == "" {
+		return nil
+	}
+	encryptionKey := m.cfg.GetString("db.encryptionKey")
+	if !strings.HasPrefix(strings.TrimSpace(r.SSHKey), "-----BEGIN") {
+		if _, err := util.Decrypt(encryptionKey, r.SSHKey); err != nil {
+			return errors.New("invalid ssh key")
+		}
+		return nil
+	}
+	if _, err := ssh.ParseRawPrivateKey([]byte(r.SSHKey)); err != nil {
+		return errors.New("invalid ssh key")
+	}
+	sshKey, err := util.Encrypt(encryptionKey, r.SSHKey)
+	if err != nil {
+		return err
+	}
+	r.SSHKey = sshKey
+	return nil
+}
+
 // validateSearchInput validates the search input provided, returning an error
 // in case it's invalid.
 func validateSearchInput(input *hub.SearchRepositoryInput) error {
@@ -712,11 +1884,46 @@ func SchemeIsHTTP(u *url.URL) bool {
 	return u.Scheme == "http" || u.Scheme == "https"
 }
 
+// SchemeIsGit is a helper that checks if the scheme of the url provided
+// corresponds to a git repository containing unpackaged Helm charts.
+func SchemeIsGit(u *url.URL) bool {
+	return u.Scheme == "git+https" || u.Scheme == "git+ssh"
+}
+
+// SchemeIsTerraformRegistry is a helper that checks if the scheme of the url
+// provided corresponds to a Terraform module published in a registry.
+func SchemeIsTerraformRegistry(u *url.URL) bool {
+	return u.Scheme == "tfr"
+}
+
+// IsGitRepository checks if the packages of the repository provided are
+// loaded from a git repository, so a branch can be set for it.
+func IsGitRepository(r *hub.Repository) bool {
+	if r.Kind == hub.Helm {
+		return IsHelmGitRepository(r)
+	}
+	return GitRepoURLRE.MatchString(r.URL) || SSHGitRepoURLRE.MatchString(r.URL)
+}
+
+// IsHelmGitRepository checks if the repository provided is a Helm charts
+// repository whose charts are stored unpackaged in a git repository.
+func IsHelmGitRepository(r *hub.Repository) bool {
+	return r.Kind == hub.Helm && strings.HasPrefix(r.URL, hub.RepositoryGitPrefix)
+}
+
+// IsHelmOCINamespaceRepository checks if the repository provided is a Helm
+// charts repository pointing to a namespace in a OCI registry, where each of
+// the repositories available under it holds a chart. Namespaces urls must end
+// with a slash (i.e. oci://ghcr.io/org/charts/).
+func IsHelmOCINamespaceRepository(r *hub.Repository) bool {
+	return r.Kind == hub.Helm && strings.HasPrefix(r.URL, hub.RepositoryOCIPrefix) && strings.HasSuffix(r.URL, "/")
+}
+
 // isSchemeSupported is a helper that checks if the scheme of the url provided
 // is supported.
 func isSchemeSupported(u *url.URL) bool {
 	switch u.Scheme {
-	case "http", "https", "oci":
+	case "http", "https", "oci", "ssh", "s3", "gs", "git+https", "git+ssh", "tfr":
 		return true
 	default:
 		return false
@@ -732,3 +1939,28 @@ func isValidKind(kind hub.RepositoryKind) bool {
 	}
 	return false
 }
+
+// hashPublishTokenSecret is a helper function that creates a sha512 hash of
+// the publish token secret provided.
+func hashPublishTokenSecret(secret string) string {
+	return fmt.Sprintf("%x", sha512.Sum512([]byte(secret)))
+}
+
+// translateTransferDBErr translates some of the errors returned from the
+// database when handling repository transfers into their hub counterparts.
+func translateTransferDBErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch err.Error() {
+	case util.ErrDBInsufficientPrivilege.Error():
+		return hub.ErrInsufficientPrivilege
+	case errRepoNotFoundDB.Error(), errRepoTransferNotFoundDB.Error():
+		return hub.ErrNotFound
+	case errTransferTargetIsOwnerDB.Error():
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository already owned by target")
+	case errTransferTargetNotFoundDB.Error():
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "target user or organization not found")
+	}
+	return err
+}
@@ -29,6 +29,11 @@ func (m *ErrorsCollectorMock) Append(repositoryID string, err string) {
 	m.Called(repositoryID, err)
 }
 
+// Finish implements the ErrorsCollector interface.
+func (m *ErrorsCollectorMock) Finish(repositoryID string, run *hub.RepositoryTrackingRun) {
+	m.Called(repositoryID, run)
+}
+
 // Flush implements the ErrorsCollector interface.
 func (m *ErrorsCollectorMock) Flush() {
 	m.Called()
@@ -52,23 +57,72 @@ func (m *HelmIndexLoaderMock) LoadIndex(r *hub.Repository) (*repo.IndexFile, str
 	return indexFile, args.String(1), args.Error(2)
 }
 
+// LoadIndexIfModified implements the HelmIndexLoader interface.
+func (m *HelmIndexLoaderMock) LoadIndexIfModified(r *hub.Repository) (*repo.IndexFile, string, error) {
+	args := m.Called(r)
+	indexFile, _ := args.Get(0).(*repo.IndexFile)
+	return indexFile, args.String(1), args.Error(2)
+}
+
 // ManagerMock is a mock implementation of the RepositoryManager interface.
 type ManagerMock struct {
 	mock.Mock
 }
 
+// AcceptTransfer implements the RepositoryManager interface.
+func (m *ManagerMock) AcceptTransfer(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
 // Add implements the RepositoryManager interface.
 func (m *ManagerMock) Add(ctx context.Context, orgName string, r *hub.Repository) error {
 	args := m.Called(ctx, orgName, r)
 	return args.Error(0)
 }
 
+// AddPublishToken implements the RepositoryManager interface.
+func (m *ManagerMock) AddPublishToken(
+	ctx context.Context,
+	name string,
+	t *hub.RepositoryPublishToken,
+) (*hub.RepositoryPublishToken, error) {
+	args := m.Called(ctx, name, t)
+	data, _ := args.Get(0).(*hub.RepositoryPublishToken)
+	return data, args.Error(1)
+}
+
+// Check implements the RepositoryManager interface.
+func (m *ManagerMock) Check(ctx context.Context, r *hub.Repository) (*hub.RepositoryCheck, error) {
+	args := m.Called(ctx, r)
+	data, _ := args.Get(0).(*hub.RepositoryCheck)
+	return data, args.Error(1)
+}
+
 // CheckAvailability implements the RepositoryManager interface.
 func (m *ManagerMock) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
 	args := m.Called(ctx, resourceKind, value)
 	return args.Bool(0), args.Error(1)
 }
 
+// CheckPublishToken implements the RepositoryManager interface.
+func (m *ManagerMock) CheckPublishToken(ctx context.Context, name, publishTokenID, secret string) (bool, error) {
+	args := m.Called(ctx, name, publishTokenID, secret)
+	return args.Bool(0), args.Error(1)
+}
+
+// CheckWebhookSignature implements the RepositoryManager interface.
+func (m *ManagerMock) CheckWebhookSignature(
+	ctx context.Context,
+	name string,
+	provider hub.RepositoryWebhookProvider,
+	signature string,
+	payload []byte,
+) (bool, error) {
+	args := m.Called(ctx, name, provider, signature, payload)
+	return args.Bool(0), args.Error(1)
+}
+
 // ClaimOwnership implements the RepositoryManager interface.
 func (m *ManagerMock) ClaimOwnership(ctx context.Context, name, orgName string) error {
 	args := m.Called(ctx, name, orgName)
@@ -81,6 +135,18 @@ func (m *ManagerMock) Delete(ctx context.Context, name string) error {
 	return args.Error(0)
 }
 
+// DeletePublishToken implements the RepositoryManager interface.
+func (m *ManagerMock) DeletePublishToken(ctx context.Context, name, publishTokenID string) error {
+	args := m.Called(ctx, name, publishTokenID)
+	return args.Error(0)
+}
+
+// GenerateWebhookSecret implements the RepositoryManager interface.
+func (m *ManagerMock) GenerateWebhookSecret(ctx context.Context, name string) (string, error) {
+	args := m.Called(ctx, name)
+	return args.String(0), args.Error(1)
+}
+
 // GetByID implements the RepositoryManager interface.
 func (m *ManagerMock) GetByID(
 	ctx context.Context,
@@ -103,6 +169,13 @@ func (m *ManagerMock) GetByName(
 	return data, args.Error(1)
 }
 
+// GetHead implements the RepositoryManager interface.
+func (m *ManagerMock) GetHead(ctx context.Context, name string) (*hub.ResourceHead, error) {
+	args := m.Called(ctx, name)
+	data, _ := args.Get(0).(*hub.ResourceHead)
+	return data, args.Error(1)
+}
+
 // GetMetadata implements the RepositoryManager interface.
 func (m *ManagerMock) GetMetadata(mdFile string) (*hub.RepositoryMetadata, error) {
 	args := m.Called(mdFile)
@@ -120,12 +193,56 @@ func (m *ManagerMock) GetPackagesDigest(
 	return data, args.Error(1)
 }
 
+// GetPublishTokensJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetPublishTokensJSON(ctx context.Context, name string) ([]byte, error) {
+	args := m.Called(ctx, name)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetRemoteDigest implements the RepositoryManager interface.
 func (m *ManagerMock) GetRemoteDigest(ctx context.Context, r *hub.Repository) (string, error) {
 	args := m.Called(ctx, r)
 	return args.String(0), args.Error(1)
 }
 
+// GetStatsJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetStatsJSON(ctx context.Context, name string) ([]byte, error) {
+	args := m.Called(ctx, name)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetTrackingRunsJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetTrackingRunsJSON(
+	ctx context.Context,
+	name string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, name, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// GetTransfersJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetTransfersJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// RejectTransfer implements the RepositoryManager interface.
+func (m *ManagerMock) RejectTransfer(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+// RequestTransfer implements the RepositoryManager interface.
+func (m *ManagerMock) RequestTransfer(ctx context.Context, name, userAlias, orgName string) error {
+	args := m.Called(ctx, name, userAlias, orgName)
+	return args.Error(0)
+}
+
 // Search implements the RepositoryManager interface.
 func (m *ManagerMock) Search(
 	ctx context.Context,
@@ -146,6 +263,12 @@ func (m *ManagerMock) SearchJSON(
 	return data, args.Error(1)
 }
 
+// SetArchived implements the RepositoryManager interface.
+func (m *ManagerMock) SetArchived(ctx context.Context, name string, archived bool) error {
+	args := m.Called(ctx, name, archived)
+	return args.Error(0)
+}
+
 // SetLastScanningResults implements the RepositoryManager interface.
 func (m *ManagerMock) SetLastScanningResults(ctx context.Context, repositoryID, errs string) error {
 	args := m.Called(ctx, repositoryID, errs)
@@ -153,8 +276,29 @@ func (m *ManagerMock) SetLastScanningResults(ctx context.Context, repositoryID,
 }
 
 // SetLastTrackingResults implements the RepositoryManager interface.
-func (m *ManagerMock) SetLastTrackingResults(ctx context.Context, repositoryID, errs string) error {
-	args := m.Called(ctx, repositoryID, errs)
+func (m *ManagerMock) SetLastTrackingResults(
+	ctx context.Context,
+	repositoryID,
+	errs string,
+	run *hub.RepositoryTrackingRun,
+) error {
+	args := m.Called(ctx, repositoryID, errs, run)
+	return args.Error(0)
+}
+
+// SetPackagesOverrides implements the RepositoryManager interface.
+func (m *ManagerMock) SetPackagesOverrides(
+	ctx context.Context,
+	repositoryID string,
+	overrides []*hub.RepositoryPackageOverride,
+) error {
+	args := m.Called(ctx, repositoryID, overrides)
+	return args.Error(0)
+}
+
+// SetTrackingPaused implements the RepositoryManager interface.
+func (m *ManagerMock) SetTrackingPaused(ctx context.Context, name string, paused bool) error {
+	args := m.Called(ctx, name, paused)
 	return args.Error(0)
 }
 
@@ -170,6 +314,12 @@ func (m *ManagerMock) Transfer(ctx context.Context, name, orgName string, owners
 	return args.Error(0)
 }
 
+// TriggerTracking implements the RepositoryManager interface.
+func (m *ManagerMock) TriggerTracking(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
 // Update implements the RepositoryManager interface.
 func (m *ManagerMock) Update(ctx context.Context, r *hub.Repository) error {
 	args := m.Called(ctx, r)
@@ -182,6 +332,28 @@ func (m *ManagerMock) UpdateDigest(ctx context.Context, repositoryID, digest str
 	return args.Error(0)
 }
 
+// UpdateIndexValidators implements the RepositoryManager interface.
+func (m *ManagerMock) UpdateIndexValidators(ctx context.Context, repositoryID, etag, lastModified string) error {
+	args := m.Called(ctx, repositoryID, etag, lastModified)
+	return args.Error(0)
+}
+
+// UpdatePublishMetadata implements the RepositoryManager interface.
+func (m *ManagerMock) UpdatePublishMetadata(
+	ctx context.Context,
+	name string,
+	md *hub.RepositoryPublishMetadata,
+) error {
+	args := m.Called(ctx, name, md)
+	return args.Error(0)
+}
+
+// VerifyDomain implements the RepositoryManager interface.
+func (m *ManagerMock) VerifyDomain(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
 // OCITagsGetterMock is a mock implementation of the OCITagsGetter interface.
 type OCITagsGetterMock struct {
 	mock.Mock
@@ -194,6 +366,134 @@ func (m *OCITagsGetterMock) Tags(ctx context.Context, r *hub.Repository) ([]stri
 	return tags, args.Error(1)
 }
 
+// OCIImageDigestResolverMock is a mock implementation of the
+// OCIImageDigestResolver interface.
+type OCIImageDigestResolverMock struct {
+	mock.Mock
+}
+
+// Resolve implements the OCIImageDigestResolver interface.
+func (m *OCIImageDigestResolverMock) Resolve(ctx context.Context, r *hub.Repository, image string) (string, error) {
+	args := m.Called(ctx, r, image)
+	return args.String(0), args.Error(1)
+}
+
+// OCIRepositoriesListerMock is a mock implementation of the
+// OCIRepositoriesLister interface.
+type OCIRepositoriesListerMock struct {
+	mock.Mock
+}
+
+// List implements the OCIRepositoriesLister interface.
+func (m *OCIRepositoriesListerMock) List(ctx context.Context, r *hub.Repository) ([]string, error) {
+	args := m.Called(ctx, r)
+	refs, _ := args.Get(0).([]string)
+	return refs, args.Error(1)
+}
+
+// OCISBOMGetterMock is a mock implementation of the OCISBOMGetter interface.
+type OCISBOMGetterMock struct {
+	mock.Mock
+}
+
+// GetSBOM implements the OCISBOMGetter interface.
+func (m *OCISBOMGetterMock) GetSBOM(ctx context.Context, r *hub.Repository, ref string) ([]byte, error) {
+	args := m.Called(ctx, r, ref)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// XpkgPullerMock is a mock implementation of the XpkgPuller interface.
+type XpkgPullerMock struct {
+	mock.Mock
+}
+
+// Pull implements the XpkgPuller interface.
+func (m *XpkgPullerMock) Pull(ctx context.Context, r *hub.Repository, ref string) (*hub.Xpkg, error) {
+	args := m.Called(ctx, r, ref)
+	xpkg, _ := args.Get(0).(*hub.Xpkg)
+	return xpkg, args.Error(1)
+}
+
+// ContainerImageDetailsGetterMock is a mock implementation of the
+// ContainerImageDetailsGetter interface.
+type ContainerImageDetailsGetterMock struct {
+	mock.Mock
+}
+
+// Get implements the ContainerImageDetailsGetter interface.
+func (m *ContainerImageDetailsGetterMock) Get(
+	ctx context.Context,
+	r *hub.Repository,
+	ref string,
+) (*hub.ContainerImageDetails, error) {
+	args := m.Called(ctx, r, ref)
+	d, _ := args.Get(0).(*hub.ContainerImageDetails)
+	return d, args.Error(1)
+}
+
+// DevcontainerArtifactPullerMock is a mock implementation of the
+// DevcontainerArtifactPuller interface.
+type DevcontainerArtifactPullerMock struct {
+	mock.Mock
+}
+
+// Pull implements the DevcontainerArtifactPuller interface.
+func (m *DevcontainerArtifactPullerMock) Pull(
+	ctx context.Context,
+	r *hub.Repository,
+	ref string,
+) (*hub.DevcontainerArtifact, error) {
+	args := m.Called(ctx, r, ref)
+	a, _ := args.Get(0).(*hub.DevcontainerArtifact)
+	return a, args.Error(1)
+}
+
+// TektonBundlePullerMock is a mock implementation of the TektonBundlePuller
+// interface.
+type TektonBundlePullerMock struct {
+	mock.Mock
+}
+
+// Pull implements the TektonBundlePuller interface.
+func (m *TektonBundlePullerMock) Pull(ctx context.Context, r *hub.Repository, ref string) (*hub.TektonBundle, error) {
+	args := m.Called(ctx, r, ref)
+	b, _ := args.Get(0).(*hub.TektonBundle)
+	return b, args.Error(1)
+}
+
+// WasmArtifactGetterMock is a mock implementation of the WasmArtifactGetter
+// interface.
+type WasmArtifactGetterMock struct {
+	mock.Mock
+}
+
+// Get implements the WasmArtifactGetter interface.
+func (m *WasmArtifactGetterMock) Get(ctx context.Context, r *hub.Repository, ref string) (*hub.WasmArtifact, error) {
+	args := m.Called(ctx, r, ref)
+	wa, _ := args.Get(0).(*hub.WasmArtifact)
+	return wa, args.Error(1)
+}
+
+// OCISignatureCheckerMock is a mock implementation of the OCISignatureChecker
+// interface.
+type OCISignatureCheckerMock struct {
+	mock.Mock
+}
+
+// Check implements the OCISignatureChecker interface.
+func (m *OCISignatureCheckerMock) Check(
+	ctx context.Context,
+	r *hub.Repository,
+	ref string,
+	cosignKey *hub.SignKey,
+	cosignIdentity *hub.CosignIdentity,
+) (*hub.OCISignatureCheck, error) {
+	args := m.Called(ctx, r, ref, cosignKey, cosignIdentity)
+	data, _ := args.Get(0).(*hub.OCISignatureCheck)
+	return data, args.Error(1)
+}
+
 // OLMOCIExporterMock is a mock implementation of the OLMOCIExporter interface.
 type OLMOCIExporterMock struct {
 	mock.Mock
@@ -0,0 +1,249 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/spf13/viper"
+	"google.golang.org/api/option"
+)
+
+// s3DefaultRegion represents the region used by default to access S3 buckets
+// when none has been configured in the environment.
+const s3DefaultRegion = "us-east-1"
+
+// objectStorage is used to get and store objects in Amazon S3 and Google
+// Cloud Storage buckets, using the credentials available in the environment
+// (i.e. static credentials, IRSA or GKE workload identity).
+var objectStorage = &objectStorageClient{}
+
+// SchemeIsObjectStorage is a helper that checks if the scheme of the url
+// provided is one of the object storage ones supported (s3 or gs).
+func SchemeIsObjectStorage(u *url.URL) bool {
+	return u.Scheme == "s3" || u.Scheme == "gs"
+}
+
+// GetObject returns the content of the object located at the s3:// or gs://
+// url provided, using the credentials available in the environment. It must
+// only be used to access locations configured by the operator (i.e. the
+// reports or charts cache storage). The caller is responsible for closing the
+// reader returned.
+func GetObject(ctx context.Context, u *url.URL) (io.ReadCloser, error) {
+	return objectStorage.get(ctx, u, false)
+}
+
+// GetRepositoryObject returns the content of the object located at the s3://
+// or gs:// url provided, which belongs to a repository added by a user. The
+// object is requested anonymously unless the operator has allowed using the
+// credentials available in the environment for its bucket (see
+// ObjectStorageCredsAllowed). The caller is responsible for closing the
+// reader returned.
+func GetRepositoryObject(ctx context.Context, cfg *viper.Viper, u *url.URL) (io.ReadCloser, error) {
+	return objectStorage.get(ctx, u, !ObjectStorageCredsAllowed(cfg, u))
+}
+
+// ObjectStorageCredsAllowed checks if the credentials available in the
+// environment can be used to access the bucket of the object url provided.
+// Any user can add a repository pointing to a bucket the hub or the tracker
+// have access to, so this is only allowed when private repositories are
+// enabled and the bucket (i.e. s3://bucket) has been explicitly listed by the
+// operator in the creds.objectStorageBuckets setting.
+func ObjectStorageCredsAllowed(cfg *viper.Viper, u *url.URL) bool {
+	if cfg == nil || !cfg.GetBool("server.allowPrivateRepositories") {
+		return false
+	}
+	bucket := u.Scheme + "://" + u.Host
+	for _, allowedBucket := range cfg.GetStringSlice("creds.objectStorageBuckets") {
+		if strings.TrimSuffix(allowedBucket, "/") == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// PutObject stores the data provided in the object located at the s3:// or
+// gs:// url given, using the credentials available in the environment.
+func PutObject(ctx context.Context, u *url.URL, data []byte) error {
+	return objectStorage.put(ctx, u, data)
+}
+
+// objectStorageClient gets and stores objects in S3 and GCS buckets. The
+// clients are set up lazily the first time they are needed, as most
+// deployments do not use object storage at all.
+type objectStorageClient struct {
+	// hc is the http client used to send the requests to S3 when set. The
+	// default one provided by the AWS SDK is used otherwise.
+	hc hub.HTTPClient
+
+	s3CfgOnce sync.Once
+	s3Cfg     aws.Config
+	s3CfgErr  error
+
+	s3RegionsMu sync.Mutex
+	s3Regions   map[string]string
+
+	gcsOnce     sync.Once
+	gcs         *storage.Client
+	gcsErr      error
+	gcsAnonOnce sync.Once
+	gcsAnon     *storage.Client
+	gcsAnonErr  error
+}
+
+// get returns the content of the object located at the url provided. When
+// anonymous is true, the credentials available in the environment are not
+// used.
+func (c *objectStorageClient) get(ctx context.Context, u *url.URL, anonymous bool) (io.ReadCloser, error) {
+	bucket, key, err := parseObjectURL(u)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "s3":
+		client, err := c.getS3Client(ctx, bucket, anonymous)
+		if err != nil {
+			return nil, err
+		}
+		out, err := client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return out.Body, nil
+	default:
+		client, err := c.getGCSClient(anonymous)
+		if err != nil {
+			return nil, err
+		}
+		r, err := client.Bucket(bucket).Object(key).NewReader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+}
+
+// put stores the data provided in the object located at the url given.
+func (c *objectStorageClient) put(ctx context.Context, u *url.URL, data []byte) error {
+	bucket, key, err := parseObjectURL(u)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "s3":
+		client, err := c.getS3Client(ctx, bucket, false)
+		if err != nil {
+			return err
+		}
+		_, err = client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Body:   bytes.NewReader(data),
+		})
+		return err
+	default:
+		client, err := c.getGCSClient(false)
+		if err != nil {
+			return err
+		}
+
+		// The upload is aborted when the context is canceled before closing
+		// the writer, so no partial objects are stored on errors
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		w := client.Bucket(bucket).Object(key).NewWriter(ctx)
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		return w.Close()
+	}
+}
+
+// getS3Client returns a client to access the S3 bucket provided, configured
+// to use the region where the bucket is located. Anonymous clients do not
+// sign the requests.
+func (c *objectStorageClient) getS3Client(ctx context.Context, bucket string, anonymous bool) (*s3.Client, error) {
+	c.s3CfgOnce.Do(func() {
+		c.s3Cfg, c.s3CfgErr = config.LoadDefaultConfig(context.Background())
+		if c.s3Cfg.Region == "" {
+			c.s3Cfg.Region = s3DefaultRegion
+		}
+		if c.hc != nil {
+			c.s3Cfg.HTTPClient = c.hc
+		}
+	})
+	if c.s3CfgErr != nil {
+		return nil, fmt.Errorf("error loading aws config: %w", c.s3CfgErr)
+	}
+	region := c.getS3BucketRegion(ctx, bucket)
+	return s3.NewFromConfig(c.s3Cfg, func(o *s3.Options) {
+		o.Region = region
+		if anonymous {
+			o.Credentials = aws.AnonymousCredentials{}
+		}
+	}), nil
+}
+
+// getS3BucketRegion returns the region where the S3 bucket provided is
+// located, which is cached once it has been obtained. When it cannot be
+// obtained, the region configured in the environment is used.
+func (c *objectStorageClient) getS3BucketRegion(ctx context.Context, bucket string) string {
+	c.s3RegionsMu.Lock()
+	defer c.s3RegionsMu.Unlock()
+	if region, ok := c.s3Regions[bucket]; ok {
+		return region
+	}
+	region, err := manager.GetBucketRegion(ctx, s3.NewFromConfig(c.s3Cfg), bucket)
+	if err != nil || region == "" {
+		return c.s3Cfg.Region
+	}
+	if c.s3Regions == nil {
+		c.s3Regions = make(map[string]string)
+	}
+	c.s3Regions[bucket] = region
+	return region
+}
+
+// getGCSClient returns the client used to access GCS buckets. Anonymous
+// clients do not use the default application credentials.
+func (c *objectStorageClient) getGCSClient(anonymous bool) (*storage.Client, error) {
+	if anonymous {
+		c.gcsAnonOnce.Do(func() {
+			c.gcsAnon, c.gcsAnonErr = storage.NewClient(context.Background(), option.WithoutAuthentication())
+		})
+		return c.gcsAnon, c.gcsAnonErr
+	}
+	c.gcsOnce.Do(func() {
+		c.gcs, c.gcsErr = storage.NewClient(context.Background(), option.WithScopes(storage.ScopeReadWrite))
+	})
+	if c.gcsErr != nil {
+		return nil, fmt.Errorf("error setting up gcs client: %w", c.gcsErr)
+	}
+	return c.gcs, nil
+}
+
+// parseObjectURL returns the bucket and key of the object url provided.
+func parseObjectURL(u *url.URL) (bucket, key string, err error) {
+	if !SchemeIsObjectStorage(u) {
+		return "", "", ErrSchemeNotSupported
+	}
+	key = strings.TrimPrefix(u.Path, "/")
+	if u.Host == "" || key == "" {
+		return "", "", errors.New("invalid object url: bucket and key expected")
+	}
+	return u.Host, key, nil
+}
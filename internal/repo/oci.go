@@ -1,21 +1,87 @@
 package repo
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"sort"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/sbom"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/spf13/viper"
 )
 
+// OCIIdentityTokenUsername represents the username that must be used in the
+// repository credentials to indicate that the password provided is an identity
+// (refresh) token, following the same convention used by the Docker CLI.
+const OCIIdentityTokenUsername = "<token>"
+
+// GetOCIAuthConfig returns the authentication configuration that should be
+// used to access the OCI registry provided using the credentials given. When
+// the username is empty, the password is used as a registry bearer token (i.e.
+// Docker Hub or ECR tokens). When no credentials are provided, the registry is
+// accessed anonymously, unless the operator has allowed using the credentials
+// available in the default keychain for it (see OCIKeychainAllowed).
+func GetOCIAuthConfig(
+	cfg *viper.Viper,
+	registry name.Registry,
+	username, password string,
+) (*authn.AuthConfig, error) {
+	switch {
+	case username == OCIIdentityTokenUsername:
+		return &authn.AuthConfig{IdentityToken: password}, nil
+	case username == "" && password != "":
+		return &authn.AuthConfig{RegistryToken: password}, nil
+	case username != "":
+		return &authn.AuthConfig{Username: username, Password: password}, nil
+	}
+	if !OCIKeychainAllowed(cfg, registry) {
+		return &authn.AuthConfig{}, nil
+	}
+	auth, err := authn.DefaultKeychain.Resolve(registry)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving credentials from keychain: %w", err)
+	}
+	return auth.Authorization()
+}
+
+// OCIKeychainAllowed checks if the credentials available in the default
+// keychain (Docker config file and the credentials helpers configured on it,
+// like the ECR, ACR or GCR ones) can be used to access the registry provided.
+// Any user can add a repository pointing to a registry the hub or the tracker
+// have access to, so this is only allowed when private repositories are
+// enabled and the registry has been explicitly listed by the operator in the
+// creds.ociKeychainRegistries setting.
+func OCIKeychainAllowed(cfg *viper.Viper, registry name.Registry) bool {
+	if cfg == nil || !cfg.GetBool("server.allowPrivateRepositories") {
+		return false
+	}
+	for _, allowedRegistry := range cfg.GetStringSlice("creds.ociKeychainRegistries") {
+		if allowedRegistry == registry.RegistryStr() {
+			return true
+		}
+	}
+	return false
+}
+
 // OCITagsGetter provides a mechanism to get all the version tags available for
 // a given repository in a OCI registry. Tags that aren't valid semver versions
-// will be filtered out.
-type OCITagsGetter struct{}
+// will be filtered out. When a requests limiter is provided, the limits
+// configured for the repository and its registry will be honored.
+type OCITagsGetter struct {
+	Cfg *viper.Viper
+	Rl  hub.RequestsLimiter
+}
 
 // Tags returns a list with the tags available for the provided repository.
 func (tg *OCITagsGetter) Tags(ctx context.Context, r *hub.Repository) ([]string, error) {
@@ -24,16 +90,18 @@ func (tg *OCITagsGetter) Tags(ctx context.Context, r *hub.Repository) ([]string,
 	if err != nil {
 		return nil, err
 	}
-	var options []remote.Option
-	if r.AuthUser != "" || r.AuthPass != "" {
-		options = []remote.Option{
-			remote.WithAuth(&authn.Basic{
-				Username: r.AuthUser,
-				Password: r.AuthPass,
-			}),
+	if tg.Rl != nil {
+		release, err := tg.Rl.Acquire(ctx, r.Name, ociRepo.RegistryStr())
+		if err != nil {
+			return nil, err
 		}
+		defer release()
+	}
+	authConfig, err := GetOCIAuthConfig(tg.Cfg, ociRepo.Registry, r.AuthUser, r.AuthPass)
+	if err != nil {
+		return nil, err
 	}
-	tags, err := remote.ListWithContext(ctx, ociRepo, options...)
+	tags, err := remote.ListWithContext(ctx, ociRepo, remote.WithAuth(authn.FromConfig(*authConfig)))
 	if err != nil {
 		return nil, err
 	}
@@ -50,3 +118,160 @@ func (tg *OCITagsGetter) Tags(ctx context.Context, r *hub.Repository) ([]string,
 	})
 	return tagsFiltered, nil
 }
+
+// OCIImageDigestResolver provides a mechanism to resolve the digest a given
+// container image reference points to. Credentials available in the default
+// keychain will only be used for the registries the operator has allowed.
+// When a requests limiter is provided, the limits configured for the
+// repository where the image was found and its registry will be honored.
+type OCIImageDigestResolver struct {
+	Cfg *viper.Viper
+	Rl  hub.RequestsLimiter
+}
+
+// Resolve returns the digest of the image provided. Images already pinned by
+// digest are not looked up in the registry.
+func (dr *OCIImageDigestResolver) Resolve(ctx context.Context, r *hub.Repository, image string) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	if d, ok := ref.(name.Digest); ok {
+		return d.DigestStr(), nil
+	}
+	if dr.Rl != nil {
+		release, err := dr.Rl.Acquire(ctx, r.Name, ref.Context().RegistryStr())
+		if err != nil {
+			return "", err
+		}
+		defer release()
+	}
+	authConfig, err := GetOCIAuthConfig(dr.Cfg, ref.Context().Registry, "", "")
+	if err != nil {
+		return "", err
+	}
+	desc, err := remote.Head(ref, remote.WithAuth(authn.FromConfig(*authConfig)), remote.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return desc.Digest.String(), nil
+}
+
+// OCIRepositoriesLister provides a mechanism to discover the repositories
+// available under a given namespace in a OCI registry, using the registry
+// catalog API. When a requests limiter is provided, the limits configured for
+// the repository and its registry will be honored.
+type OCIRepositoriesLister struct {
+	Cfg *viper.Viper
+	Rl  hub.RequestsLimiter
+}
+
+// List returns the references of the repositories available under the
+// namespace of the repository provided (i.e. oci://ghcr.io/org/charts/). The
+// references are returned using the registry host provided in the namespace.
+func (l *OCIRepositoriesLister) List(ctx context.Context, r *hub.Repository) ([]string, error) {
+	ns := strings.TrimSuffix(strings.TrimPrefix(r.URL, hub.RepositoryOCIPrefix), "/")
+	nsRepo, err := name.NewRepository(ns)
+	if err != nil {
+		return nil, err
+	}
+	if l.Rl != nil {
+		release, err := l.Rl.Acquire(ctx, r.Name, nsRepo.RegistryStr())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	authConfig, err := GetOCIAuthConfig(l.Cfg, nsRepo.Registry, r.AuthUser, r.AuthPass)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := remote.Catalog(ctx, nsRepo.Registry, remote.WithAuth(authn.FromConfig(*authConfig)))
+	if err != nil {
+		return nil, fmt.Errorf("error listing registry catalog: %w", err)
+	}
+	return filterNamespaceRepositories(ns, nsRepo.RepositoryStr(), repos), nil
+}
+
+// filterNamespaceRepositories returns the references of the repositories
+// provided that are located under the namespace given, sorted by name.
+func filterNamespaceRepositories(ns, nsPath string, repos []string) []string {
+	var refs []string
+	for _, repo := range repos {
+		if strings.HasPrefix(repo, nsPath+"/") {
+			refs = append(refs, ns+strings.TrimPrefix(repo, nsPath))
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// OCISBOMGetter provides a mechanism to get the SBOM attached to an artifact
+// stored in a OCI registry (i.e. using cosign attach sbom). When a requests
+// limiter is provided, the limits configured for the repository and its
+// registry will be honored.
+type OCISBOMGetter struct {
+	Cfg *viper.Viper
+	Rl  hub.RequestsLimiter
+}
+
+// GetSBOM returns the SBOM attached to the artifact referenced by the ref
+// provided. Nil is returned when the artifact does not have a SBOM attached.
+func (g *OCISBOMGetter) GetSBOM(ctx context.Context, r *hub.Repository, ref string) ([]byte, error) {
+	// Prepare registry access options
+	nameRef, err := name.ParseReference(strings.TrimPrefix(ref, hub.RepositoryOCIPrefix))
+	if err != nil {
+		return nil, err
+	}
+	if g.Rl != nil {
+		release, err := g.Rl.Acquire(ctx, r.Name, nameRef.Context().RegistryStr())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	authConfig, err := GetOCIAuthConfig(g.Cfg, nameRef.Context().Registry, r.AuthUser, r.AuthPass)
+	if err != nil {
+		return nil, err
+	}
+	opts := []remote.Option{
+		remote.WithAuth(authn.FromConfig(*authConfig)),
+		remote.WithContext(ctx),
+	}
+
+	// Get the SBOM attached to the artifact, if any
+	desc, err := remote.Head(nameRef, opts...)
+	if err != nil {
+		return nil, err
+	}
+	tag := nameRef.Context().Tag(fmt.Sprintf("%s-%s.sbom", desc.Digest.Algorithm, desc.Digest.Hex))
+	sbomDesc, err := remote.Get(tag, opts...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	manifest, err := v1.ParseManifest(bytes.NewReader(sbomDesc.Manifest))
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, nil
+	}
+	l := manifest.Layers[0]
+	if l.Size > sbom.MaxSize {
+		return nil, errors.New("sbom too big")
+	}
+	layer, err := remote.Layer(nameRef.Context().Digest(l.Digest.String()), opts...)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, sbom.MaxSize+1))
+}
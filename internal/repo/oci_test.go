@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOCIAuthConfig(t *testing.T) {
+	registry, _ := name.NewRegistry("registry.io")
+
+	t.Run("credentials provided", func(t *testing.T) {
+		testCases := []struct {
+			username           string
+			password           string
+			expectedAuthConfig *authn.AuthConfig
+		}{
+			{
+				"user",
+				"pass",
+				&authn.AuthConfig{Username: "user", Password: "pass"},
+			},
+			{
+				OCIIdentityTokenUsername,
+				"identityToken",
+				&authn.AuthConfig{IdentityToken: "identityToken"},
+			},
+			{
+				"",
+				"registryToken",
+				&authn.AuthConfig{RegistryToken: "registryToken"},
+			},
+		}
+		for _, tc := range testCases {
+			authConfig, err := GetOCIAuthConfig(nil, registry, tc.username, tc.password)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAuthConfig, authConfig)
+		}
+	})
+
+	t.Run("no credentials provided", func(t *testing.T) {
+		dockerConfigDir, err := ioutil.TempDir("", "docker-config")
+		require.NoError(t, err)
+		defer os.RemoveAll(dockerConfigDir)
+		dockerConfig := `{"auths": {"registry.io": {"username": "user", "password": "pass"}}}`
+		err = ioutil.WriteFile(filepath.Join(dockerConfigDir, "config.json"), []byte(dockerConfig), 0600)
+		require.NoError(t, err)
+		setEnv(t, map[string]string{"DOCKER_CONFIG": dockerConfigDir})
+
+		testCases := []struct {
+			desc                      string
+			allowPrivateRepositories  bool
+			keychainRegistries        []string
+			expectedKeychainCredsUsed bool
+		}{
+			{
+				"keychain registries not configured",
+				true,
+				nil,
+				false,
+			},
+			{
+				"registry not allowed to use keychain",
+				true,
+				[]string{"other.registry.io"},
+				false,
+			},
+			{
+				"private repositories not allowed",
+				false,
+				[]string{"registry.io"},
+				false,
+			},
+			{
+				"registry allowed to use keychain",
+				true,
+				[]string{"registry.io"},
+				true,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.desc, func(t *testing.T) {
+				cfg := viper.New()
+				cfg.Set("server.allowPrivateRepositories", tc.allowPrivateRepositories)
+				cfg.Set("creds.ociKeychainRegistries", tc.keychainRegistries)
+
+				authConfig, err := GetOCIAuthConfig(cfg, registry, "", "")
+				require.NoError(t, err)
+				if tc.expectedKeychainCredsUsed {
+					assert.Equal(t, "user", authConfig.Username)
+					assert.Equal(t, "pass", authConfig.Password)
+				} else {
+					assert.Equal(t, &authn.AuthConfig{}, authConfig)
+				}
+			})
+		}
+	})
+}
+
+func TestFilterNamespaceRepositories(t *testing.T) {
+	repos := []string{
+		"org/charts/chart2",
+		"org/charts/chart1",
+		"org/charts/team/chart3",
+		"org/charts-other/chart4",
+		"org/image",
+		"other/charts/chart5",
+	}
+	refs := filterNamespaceRepositories("registry.io/org/charts", "org/charts", repos)
+	assert.Equal(t, []string{
+		"registry.io/org/charts/chart1",
+		"registry.io/org/charts/chart2",
+		"registry.io/org/charts/team/chart3",
+	}, refs)
+}
@@ -3,23 +3,56 @@ package repo
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/ghodss/yaml"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/viper"
+)
+
+const (
+	// olmFBCLabel represents the label set in the catalog index images that
+	// store their content using the file-based catalog (FBC) format.
+	olmFBCLabel = "operators.operatorframework.io.index.configs.v1"
+
+	// FBC schemas
+	olmBundleSchema  = "olm.bundle"
+	olmChannelSchema = "olm.channel"
+	olmPackageSchema = "olm.package"
+
+	// FBC bundles properties types
+	olmBundleObjectProperty = "olm.bundle.object"
+	olmCSVMetadataProperty  = "olm.csv.metadata"
+	olmPackageProperty      = "olm.package"
 )
 
 // OLMOCIExporter provides a mechanism to export the packages available in an
 // OLM repository stored in an OCI registry.
-type OLMOCIExporter struct{}
+type OLMOCIExporter struct {
+	Cfg *viper.Viper
+}
 
 // ExportRepository exports the packages available in a repository stored in a
 // OCI registry using the appregistry manifest format. It returns the temporary
 // directory where the packages will be stored. It's the caller's responsibility
 // to delete it when done.
+//
+// Both sqlite based and file-based catalog (FBC) index images are supported.
+// FBC index images are rendered and their bundles, channels and CSVs are
+// written to the temporary directory using the same format.
 func (e *OLMOCIExporter) ExportRepository(ctx context.Context, r *hub.Repository) (string, error) {
 	// Setup temporary directory to store content
 	tmpDir, err := ioutil.TempDir("", "artifact-hub")
@@ -29,17 +62,330 @@ func (e *OLMOCIExporter) ExportRepository(ctx context.Context, r *hub.Repository
 
 	// Export repository packages using opm (external tool)
 	indexRef := strings.TrimPrefix(r.URL, hub.RepositoryOCIPrefix)
-	cmd := exec.CommandContext(ctx, "opm", "index", "export", "-i", indexRef, "-f", tmpDir) // #nosec
+	ref, err := name.ParseReference(indexRef)
+	if err != nil {
+		return "", err
+	}
+	isFBC, err := e.isFileBasedCatalog(ctx, r, ref)
+	if err != nil {
+		return "", fmt.Errorf("error checking index image format (%s): %w", indexRef, err)
+	}
+
+	// opm reads the credentials available in the Docker config file, so it
+	// is pointed to an empty one unless the operator has allowed using them
+	// for the registry of the index image
+	var dockerConfigDir string
+	if !OCIKeychainAllowed(e.Cfg, ref.Context().Registry) {
+		dockerConfigDir, err = ioutil.TempDir("", "artifact-hub-docker-config")
+		if err != nil {
+			return "", fmt.Errorf("error creating temp dir: %w", err)
+		}
+		defer os.RemoveAll(dockerConfigDir)
+	}
+
+	if isFBC {
+		var stdout bytes.Buffer
+		if err := runOPM(ctx, &stdout, dockerConfigDir, "render", indexRef, "-o", "json"); err != nil {
+			return "", fmt.Errorf("error running opm render (%s): %w", indexRef, err)
+		}
+		// Bundles that cannot be exported are skipped, so that a single broken
+		// bundle does not prevent the rest of the catalog from being processed
+		if _, err := ExportFileBasedCatalog(&stdout, tmpDir); err != nil {
+			return "", fmt.Errorf("error exporting file-based catalog (%s): %w", indexRef, err)
+		}
+	} else {
+		if err := runOPM(ctx, nil, dockerConfigDir, "index", "export", "-i", indexRef, "-f", tmpDir); err != nil {
+			return "", fmt.Errorf("error running opm index export (%s): %w", indexRef, err)
+		}
+	}
+
+	return tmpDir, nil
+}
+
+// isFileBasedCatalog checks if the index image provided uses the file-based
+// catalog format, inspecting the labels set in the image config.
+func (e *OLMOCIExporter) isFileBasedCatalog(
+	ctx context.Context,
+	r *hub.Repository,
+	ref name.Reference,
+) (bool, error) {
+	authConfig, err := GetOCIAuthConfig(e.Cfg, ref.Context().Registry, r.AuthUser, r.AuthPass)
+	if err != nil {
+		return false, err
+	}
+	img, err := remote.Image(ref, remote.WithAuth(authn.FromConfig(*authConfig)), remote.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return false, err
+	}
+	_, ok := cfg.Config.Labels[olmFBCLabel]
+	return ok, nil
+}
+
+// runOPM runs the opm command with the arguments provided, writing its output
+// to the writer provided (when not nil). When a Docker config directory is
+// provided, opm will read the registries credentials from it.
+func runOPM(ctx context.Context, stdout io.Writer, dockerConfigDir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "opm", args...) // #nosec
 	var stderr bytes.Buffer
+	cmd.Stdout = stdout
 	cmd.Stderr = &stderr
 	cmd.Env = []string{
 		"PATH=" + os.Getenv("PATH"),
 		"USER=" + os.Getenv("USER"),
 		"HOME=" + os.Getenv("HOME"),
 	}
+	if dockerConfigDir != "" {
+		cmd.Env = append(cmd.Env, "DOCKER_CONFIG="+dockerConfigDir)
+	}
 	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("error running opm index export (%s): %w: %s", indexRef, err, stderr.String())
+		return fmt.Errorf("%w: %s", err, stderr.String())
 	}
+	return nil
+}
 
-	return tmpDir, nil
+// fbcMeta represents a file-based catalog blob (package, channel or bundle).
+type fbcMeta struct {
+	Schema         string `json:"schema"`
+	Name           string `json:"name"`
+	Package        string `json:"package"`
+	DefaultChannel string `json:"defaultChannel"`
+	Icon           *struct {
+		Data      string `json:"base64data"`
+		MediaType string `json:"mediatype"`
+	} `json:"icon"`
+	Entries []*struct {
+		Name     string   `json:"name"`
+		Replaces string   `json:"replaces"`
+		Skips    []string `json:"skips"`
+	} `json:"entries"`
+	Image      string `json:"image"`
+	Properties []*struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	} `json:"properties"`
+	RelatedImages []*struct {
+		Name  string `json:"name"`
+		Image string `json:"image"`
+	} `json:"relatedImages"`
+}
+
+// ExportFileBasedCatalog writes the packages available in the file-based
+// catalog provided (json stream, as rendered by opm) to the directory provided,
+// using the appregistry manifest format. Each package gets a package manifest
+// file with its channels, and a directory per bundle version containing its
+// CSV. The channels heads are obtained from their upgrade edges. Bundles whose
+// CSV cannot be prepared are skipped, and the corresponding errors returned.
+func ExportFileBasedCatalog(r io.Reader, dir string) ([]error, error) {
+	// Read catalog blobs
+	var packages, channels, bundles []*fbcMeta
+	dec := json.NewDecoder(r)
+	for {
+		var m fbcMeta
+		if err := dec.Decode(&m); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("error decoding catalog: %w", err)
+		}
+		switch m.Schema {
+		case olmPackageSchema:
+			packages = append(packages, &m)
+		case olmChannelSchema:
+			channels = append(channels, &m)
+		case olmBundleSchema:
+			bundles = append(bundles, &m)
+		}
+	}
+
+	// Write bundles CSVs
+	var skipped []error
+	bundlesVersions := make(map[string]string) // bundleName:version
+	for _, p := range packages {
+		for _, b := range bundles {
+			if b.Package != p.Name {
+				continue
+			}
+			version, csv, err := getFBCBundleCSV(p, b)
+			if err != nil {
+				skipped = append(skipped, fmt.Errorf("error preparing bundle %s csv: %w", b.Name, err))
+				continue
+			}
+			bundlesVersions[b.Name] = version
+			csvPath := filepath.Join(dir, p.Name, version, b.Name+".clusterserviceversion.yaml")
+			if err := writeYAMLFile(csvPath, csv); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Write packages manifests
+	for _, p := range packages {
+		type channel struct {
+			Name       string `json:"name"`
+			CurrentCSV string `json:"currentCSV"`
+		}
+		manifest := struct {
+			PackageName    string     `json:"packageName"`
+			DefaultChannel string     `json:"defaultChannel"`
+			Channels       []*channel `json:"channels"`
+		}{
+			PackageName:    p.Name,
+			DefaultChannel: p.DefaultChannel,
+		}
+		for _, c := range channels {
+			if c.Package != p.Name {
+				continue
+			}
+			if head := getFBCChannelHead(c, bundlesVersions); head != "" {
+				manifest.Channels = append(manifest.Channels, &channel{Name: c.Name, CurrentCSV: head})
+			}
+		}
+		manifestPath := filepath.Join(dir, p.Name, p.Name+".package.yaml")
+		if err := writeYAMLFile(manifestPath, manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	return skipped, nil
+}
+
+// getFBCBundleCSV returns the version and the CSV of the bundle provided. The
+// CSV is read from the bundle objects when available. Otherwise, it's prepared
+// from the CSV metadata property and the package icon.
+func getFBCBundleCSV(p, b *fbcMeta) (string, map[string]interface{}, error) {
+	var version string
+	var csv, csvMD map[string]interface{}
+	for _, prop := range b.Properties {
+		switch prop.Type {
+		case olmPackageProperty:
+			var v struct {
+				Version string `json:"version"`
+			}
+			if err := json.Unmarshal(prop.Value, &v); err != nil {
+				return "", nil, err
+			}
+			version = v.Version
+		case olmBundleObjectProperty:
+			var v struct {
+				Data string `json:"data"`
+			}
+			if err := json.Unmarshal(prop.Value, &v); err != nil {
+				return "", nil, err
+			}
+			data, err := base64.StdEncoding.DecodeString(v.Data)
+			if err != nil {
+				return "", nil, err
+			}
+			var obj map[string]interface{}
+			if err := json.Unmarshal(data, &obj); err != nil {
+				return "", nil, err
+			}
+			if obj["kind"] == "ClusterServiceVersion" {
+				csv = obj
+			}
+		case olmCSVMetadataProperty:
+			if err := json.Unmarshal(prop.Value, &csvMD); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+	if _, err := semver.StrictNewVersion(version); err != nil {
+		return "", nil, fmt.Errorf("invalid version (%s): %w", version, err)
+	}
+	if csv != nil {
+		return version, csv, nil
+	}
+	if csvMD == nil {
+		return "", nil, errors.New("csv not found")
+	}
+
+	// Prepare CSV from the metadata available
+	metadata := map[string]interface{}{
+		"name": b.Name,
+	}
+	for _, key := range []string{"annotations", "labels"} {
+		if v, ok := csvMD[key]; ok {
+			metadata[key] = v
+			delete(csvMD, key)
+		}
+	}
+	spec := csvMD
+	spec["version"] = version
+	if v, ok := spec["crdDescriptions"]; ok {
+		spec["customresourcedefinitions"] = v
+		delete(spec, "crdDescriptions")
+	}
+	if p.Icon != nil && p.Icon.Data != "" {
+		spec["icon"] = []interface{}{p.Icon}
+	}
+	var relatedImages []interface{}
+	for _, ri := range b.RelatedImages {
+		// The bundle image is not part of the operator images
+		if ri.Image == b.Image {
+			continue
+		}
+		relatedImages = append(relatedImages, ri)
+	}
+	if len(relatedImages) > 0 {
+		spec["relatedImages"] = relatedImages
+	}
+	csv = map[string]interface{}{
+		"apiVersion": "operators.coreos.com/v1alpha1",
+		"kind":       "ClusterServiceVersion",
+		"metadata":   metadata,
+		"spec":       spec,
+	}
+
+	return version, csv, nil
+}
+
+// getFBCChannelHead returns the name of the bundle at the head of the channel
+// provided, that is, the one not replaced or skipped by any other entry. When
+// multiple candidates are found, the one with the highest version is used.
+func getFBCChannelHead(c *fbcMeta, bundlesVersions map[string]string) string {
+	replaced := make(map[string]struct{})
+	for _, e := range c.Entries {
+		if e.Replaces != "" {
+			replaced[e.Replaces] = struct{}{}
+		}
+		for _, skip := range e.Skips {
+			replaced[skip] = struct{}{}
+		}
+	}
+	var head string
+	var headVersion *semver.Version
+	for _, e := range c.Entries {
+		if _, ok := replaced[e.Name]; ok {
+			continue
+		}
+		v, err := semver.StrictNewVersion(bundlesVersions[e.Name])
+		if err != nil {
+			continue
+		}
+		if headVersion == nil || v.GreaterThan(headVersion) {
+			head = e.Name
+			headVersion = v
+		}
+	}
+	return head
+}
+
+// writeYAMLFile writes the yaml representation of the value provided to the
+// file provided, creating its parent directory when needed.
+func writeYAMLFile(path string, v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("error marshaling %s: %w", filepath.Base(path), err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("error writing %s: %w", filepath.Base(path), err)
+	}
+	return nil
 }
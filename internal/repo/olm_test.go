@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportFileBasedCatalog(t *testing.T) {
+	t.Parallel()
+
+	// Export catalog
+	f, err := os.Open("testdata/olm-fbc-catalog.json")
+	require.NoError(t, err)
+	defer f.Close()
+	dir, err := ioutil.TempDir("", "artifact-hub-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	skipped, err := ExportFileBasedCatalog(f, dir)
+	require.NoError(t, err)
+	require.Len(t, skipped, 1)
+	assert.Contains(t, skipped[0].Error(), "error preparing bundle test-operator.vinvalid csv: invalid version (invalid)")
+
+	// Check expectations
+	readYAMLFile := func(path string) map[string]interface{} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, path))
+		require.NoError(t, err)
+		var v map[string]interface{}
+		require.NoError(t, yaml.Unmarshal(data, &v))
+		return v
+	}
+	assert.Equal(t, map[string]interface{}{
+		"packageName":    "test-operator",
+		"defaultChannel": "stable",
+		"channels": []interface{}{
+			map[string]interface{}{
+				"name":       "stable",
+				"currentCSV": "test-operator.v0.2.0",
+			},
+			map[string]interface{}{
+				"name":       "candidate",
+				"currentCSV": "test-operator.v0.1.0",
+			},
+		},
+	}, readYAMLFile("test-operator/test-operator.package.yaml"))
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "operators.coreos.com/v1alpha1",
+		"kind":       "ClusterServiceVersion",
+		"metadata": map[string]interface{}{
+			"name": "test-operator.v0.1.0",
+		},
+		"spec": map[string]interface{}{
+			"displayName": "Test Operator",
+			"version":     "0.1.0",
+		},
+	}, readYAMLFile("test-operator/0.1.0/test-operator.v0.1.0.clusterserviceversion.yaml"))
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "operators.coreos.com/v1alpha1",
+		"kind":       "ClusterServiceVersion",
+		"metadata": map[string]interface{}{
+			"name": "test-operator.v0.2.0",
+			"annotations": map[string]interface{}{
+				"capabilities": "Basic Install",
+			},
+		},
+		"spec": map[string]interface{}{
+			"displayName": "Test Operator",
+			"version":     "0.2.0",
+			"customresourcedefinitions": map[string]interface{}{
+				"owned": []interface{}{
+					map[string]interface{}{
+						"name":    "tests.example.com",
+						"version": "v1",
+						"kind":    "Test",
+					},
+				},
+			},
+			"icon": []interface{}{
+				map[string]interface{}{
+					"base64data": "aWNvbg==",
+					"mediatype":  "image/png",
+				},
+			},
+			"relatedImages": []interface{}{
+				map[string]interface{}{
+					"name":  "operator",
+					"image": "registry.url/test-operator:0.2.0",
+				},
+			},
+		},
+	}, readYAMLFile("test-operator/0.2.0/test-operator.v0.2.0.clusterserviceversion.yaml"))
+	assert.NoDirExists(t, filepath.Join(dir, "test-operator", "invalid"))
+}
@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Annotations set in the layers of Tekton bundles to describe the resource
+	// they hold
+	tektonAPIVersionAnnotation = "dev.tekton.image.apiVersion"
+	tektonKindAnnotation       = "dev.tekton.image.kind"
+	tektonNameAnnotation       = "dev.tekton.image.name"
+)
+
+// OCITektonBundlePuller provides a mechanism to pull Tekton bundles stored in
+// a OCI registry. When a requests limiter is provided, the limits configured
+// for the repository and its registry will be honored.
+type OCITektonBundlePuller struct {
+	Cfg *viper.Viper
+	Rl  hub.RequestsLimiter
+}
+
+// Pull returns the content of the Tekton bundle referenced by the ref provided.
+// Each of the bundle layers holds a single resource (i.e. a task or pipeline),
+// that is described by the layer annotations.
+func (p *OCITektonBundlePuller) Pull(ctx context.Context, r *hub.Repository, ref string) (*hub.TektonBundle, error) {
+	// Prepare registry access options
+	nameRef, err := name.ParseReference(strings.TrimPrefix(ref, hub.RepositoryOCIPrefix))
+	if err != nil {
+		return nil, err
+	}
+	if p.Rl != nil {
+		release, err := p.Rl.Acquire(ctx, r.Name, nameRef.Context().RegistryStr())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	authConfig, err := GetOCIAuthConfig(p.Cfg, nameRef.Context().Registry, r.AuthUser, r.AuthPass)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get bundle
+	img, err := remote.Image(nameRef, remote.WithAuth(authn.FromConfig(*authConfig)), remote.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	// Read resources from the bundle layers
+	b := &hub.TektonBundle{Digest: digest.String()}
+	for _, l := range manifest.Layers {
+		kind := l.Annotations[tektonKindAnnotation]
+		resourceName := l.Annotations[tektonNameAnnotation]
+		if kind == "" || resourceName == "" {
+			continue
+		}
+		layer, err := img.LayerByDigest(l.Digest)
+		if err != nil {
+			return nil, err
+		}
+		rc, err := layer.Compressed()
+		if err != nil {
+			return nil, err
+		}
+		files, err := readTarFiles(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s %s layer: %w", kind, resourceName, err)
+		}
+		if len(files) != 1 {
+			return nil, fmt.Errorf("invalid %s %s layer: expected one file, found %d", kind, resourceName, len(files))
+		}
+		for _, data := range files {
+			b.Resources = append(b.Resources, &hub.TektonBundleResource{
+				APIVersion: l.Annotations[tektonAPIVersionAnnotation],
+				Kind:       kind,
+				Name:       resourceName,
+				Data:       data,
+			})
+		}
+	}
+	if len(b.Resources) == 0 {
+		return nil, errors.New("no resources found in bundle")
+	}
+
+	return b, nil
+}
@@ -0,0 +1,136 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/viper"
+)
+
+// wasmConfigMaxSize represents the maximum size of the config of a wasm OCI
+// artifact.
+const wasmConfigMaxSize = 1024 * 1024
+
+var (
+	// errNotWasmArtifact indicates that the artifact referenced is not a
+	// WebAssembly module.
+	errNotWasmArtifact = errors.New("not a wasm artifact")
+
+	// wasmConfigMediaTypes represents the config media types used by the wasm
+	// OCI artifacts supported.
+	wasmConfigMediaTypes = []string{
+		"application/vnd.wasm.config.v0+json",
+		"application/vnd.wasm.config.v1+json",
+		"application/vnd.wasmcloud.actor.archive.config",
+	}
+
+	// wasmLayerMediaTypes represents the media types used by the layers that
+	// hold the WebAssembly module in the wasm OCI artifacts supported.
+	wasmLayerMediaTypes = []string{
+		"application/wasm",
+		"application/vnd.wasm.content.layer.v1+wasm",
+		"application/vnd.module.wasm.content.layer.v1+wasm",
+	}
+)
+
+// OCIWasmArtifactGetter provides a mechanism to get the manifest and config of
+// WebAssembly modules stored in a OCI registry as wasm OCI artifacts. When a
+// requests limiter is provided, the limits configured for the repository and
+// its registry will be honored.
+type OCIWasmArtifactGetter struct {
+	Cfg *viper.Viper
+	Rl  hub.RequestsLimiter
+}
+
+// Get returns the details of the wasm OCI artifact referenced by the ref
+// provided. An error is returned if the artifact's config or layers media
+// types do not match any of the ones used by wasm OCI artifacts.
+func (g *OCIWasmArtifactGetter) Get(ctx context.Context, r *hub.Repository, ref string) (*hub.WasmArtifact, error) {
+	// Prepare registry access options
+	nameRef, err := name.ParseReference(strings.TrimPrefix(ref, hub.RepositoryOCIPrefix))
+	if err != nil {
+		return nil, err
+	}
+	if g.Rl != nil {
+		release, err := g.Rl.Acquire(ctx, r.Name, nameRef.Context().RegistryStr())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	authConfig, err := GetOCIAuthConfig(g.Cfg, nameRef.Context().Registry, r.AuthUser, r.AuthPass)
+	if err != nil {
+		return nil, err
+	}
+	opts := []remote.Option{
+		remote.WithAuth(authn.FromConfig(*authConfig)),
+		remote.WithContext(ctx),
+	}
+
+	// Get and validate artifact manifest
+	desc, err := remote.Get(nameRef, opts...)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := v1.ParseManifest(bytes.NewReader(desc.Manifest))
+	if err != nil {
+		return nil, err
+	}
+	if !containsMediaType(wasmConfigMediaTypes, string(manifest.Config.MediaType)) {
+		return nil, fmt.Errorf("%w: unsupported config media type (%s)", errNotWasmArtifact, manifest.Config.MediaType)
+	}
+	wa := &hub.WasmArtifact{
+		Digest:          desc.Digest.String(),
+		ConfigMediaType: string(manifest.Config.MediaType),
+		Annotations:     manifest.Annotations,
+	}
+	for _, l := range manifest.Layers {
+		if containsMediaType(wasmLayerMediaTypes, string(l.MediaType)) {
+			wa.LayerMediaType = string(l.MediaType)
+			wa.LayerSize = l.Size
+			break
+		}
+	}
+	if wa.LayerMediaType == "" {
+		return nil, fmt.Errorf("%w: wasm layer not found", errNotWasmArtifact)
+	}
+
+	// Get artifact config
+	if manifest.Config.Size > wasmConfigMaxSize {
+		return nil, errors.New("config too big")
+	}
+	config, err := remote.Layer(nameRef.Context().Digest(manifest.Config.Digest.String()), opts...)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := config.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	wa.Config, err = io.ReadAll(io.LimitReader(rc, wasmConfigMaxSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return wa, nil
+}
+
+// containsMediaType checks if the media type provided is in the list given.
+func containsMediaType(mediaTypes []string, mediaType string) bool {
+	for _, mt := range mediaTypes {
+		if mt == mediaType {
+			return true
+		}
+	}
+	return false
+}
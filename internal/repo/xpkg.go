@@ -0,0 +1,173 @@
+package repo
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/viper"
+)
+
+const (
+	// xpkgLayerAnnotation represents the annotation used to identify the
+	// content of the layers of a Crossplane package image.
+	xpkgLayerAnnotation = "io.crossplane.xpkg"
+
+	// xpkgBaseLayer represents the value of the layer annotation used by the
+	// layer holding the package stream file.
+	xpkgBaseLayer = "base"
+
+	// xpkgExamplesLayer represents the value of the layer annotation used by
+	// the layer holding the package examples.
+	xpkgExamplesLayer = "examples"
+
+	// xpkgStreamFile represents the name of the file where the package meta
+	// and objects are stored.
+	xpkgStreamFile = "package.yaml"
+
+	// xpkgExamplesDir represents the directory where the examples are stored
+	// in the examples layer.
+	xpkgExamplesDir = ".up/examples/"
+
+	// xpkgMaxFileSize represents the maximum size of the files read from a
+	// Crossplane package image.
+	xpkgMaxFileSize = 10 * 1024 * 1024
+)
+
+// OCIXpkgPuller provides a mechanism to pull Crossplane packages (xpkg) stored
+// in a OCI registry. When a requests limiter is provided, the limits
+// configured for the repository and its registry will be honored.
+type OCIXpkgPuller struct {
+	Cfg *viper.Viper
+	Rl  hub.RequestsLimiter
+}
+
+// Pull returns the content of the Crossplane package referenced by the ref
+// provided. The package stream file is read from the layer annotated as the
+// base one or, for packages built without layers annotations, from the image
+// filesystem.
+func (p *OCIXpkgPuller) Pull(ctx context.Context, r *hub.Repository, ref string) (*hub.Xpkg, error) {
+	// Prepare registry access options
+	nameRef, err := name.ParseReference(strings.TrimPrefix(ref, hub.RepositoryOCIPrefix))
+	if err != nil {
+		return nil, err
+	}
+	if p.Rl != nil {
+		release, err := p.Rl.Acquire(ctx, r.Name, nameRef.Context().RegistryStr())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	authConfig, err := GetOCIAuthConfig(p.Cfg, nameRef.Context().Registry, r.AuthUser, r.AuthPass)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get package image
+	img, err := remote.Image(nameRef, remote.WithAuth(authn.FromConfig(*authConfig)), remote.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	// Read package stream and examples from the annotated layers
+	xpkg := &hub.Xpkg{
+		Digest:   digest.String(),
+		Examples: make(map[string][]byte),
+	}
+	for _, l := range manifest.Layers {
+		kind := l.Annotations[xpkgLayerAnnotation]
+		if kind != xpkgBaseLayer && kind != xpkgExamplesLayer {
+			continue
+		}
+		files, err := readXpkgLayer(img, l.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s layer: %w", kind, err)
+		}
+		switch kind {
+		case xpkgBaseLayer:
+			xpkg.Package = files[xpkgStreamFile]
+		case xpkgExamplesLayer:
+			for name, data := range files {
+				if strings.HasPrefix(name, xpkgExamplesDir) {
+					xpkg.Examples[strings.TrimPrefix(name, xpkgExamplesDir)] = data
+				}
+			}
+		}
+	}
+	if xpkg.Package == nil {
+		rc := mutate.Extract(img)
+		defer rc.Close()
+		files, err := readXpkgFiles(rc)
+		if err != nil {
+			return nil, fmt.Errorf("error reading image filesystem: %w", err)
+		}
+		xpkg.Package = files[xpkgStreamFile]
+	}
+	if xpkg.Package == nil {
+		return nil, errors.New("package stream file not found")
+	}
+
+	return xpkg, nil
+}
+
+// readXpkgLayer returns the files available in the layer of the image provided
+// identified by the digest given.
+func readXpkgLayer(img v1.Image, digest v1.Hash) (map[string][]byte, error) {
+	layer, err := img.LayerByDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return readXpkgFiles(rc)
+}
+
+// readXpkgFiles returns the regular files available in the tar stream
+// provided, indexed by their path.
+func readXpkgFiles(r io.Reader) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if hdr.Size > xpkgMaxFileSize {
+			return nil, fmt.Errorf("file %s too big", hdr.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")] = data
+	}
+	return files, nil
+}
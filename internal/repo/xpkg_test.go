@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadXpkgFiles(t *testing.T) {
+	t.Parallel()
+
+	// Prepare layer content
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	addFile := func(name string, typeflag byte, data string) {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Typeflag: typeflag,
+			Mode:     0644,
+			Size:     int64(len(data)),
+		}))
+		_, err := tw.Write([]byte(data))
+		require.NoError(t, err)
+	}
+	addFile("package.yaml", tar.TypeReg, "apiVersion: meta.pkg.crossplane.io/v1")
+	addFile(".up/", tar.TypeDir, "")
+	addFile("./.up/examples/example1.yaml", tar.TypeReg, "kind: Example")
+	require.NoError(t, tw.Close())
+
+	// Read files and check expectations
+	files, err := readXpkgFiles(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"package.yaml":               []byte("apiVersion: meta.pkg.crossplane.io/v1"),
+		".up/examples/example1.yaml": []byte("kind: Example"),
+	}, files)
+}
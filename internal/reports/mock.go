@@ -0,0 +1,25 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// StoreMock is a mock implementation of the ReportsStore interface.
+type StoreMock struct {
+	mock.Mock
+}
+
+// Get implements the ReportsStore interface.
+func (m *StoreMock) Get(ctx context.Context, location string) ([]byte, error) {
+	args := m.Called(ctx, location)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Put implements the ReportsStore interface.
+func (m *StoreMock) Put(ctx context.Context, key string, data []byte) (string, error) {
+	args := m.Called(ctx, key, data)
+	return args.String(0), args.Error(1)
+}
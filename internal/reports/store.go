@@ -0,0 +1,75 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/spf13/viper"
+)
+
+// NewStore creates a new reports store based on the configuration provided.
+// Nil is returned when reports must be kept in the database (default).
+func NewStore(cfg *viper.Viper) (hub.ReportsStore, error) {
+	switch cfg.GetString("reports.store") {
+	case "", "pg":
+		return nil, nil
+	case "objectStorage":
+		s, err := NewObjectStorageStore(cfg.GetString("reports.objectStorageURL"))
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return nil, errors.New("invalid reports store")
+	}
+}
+
+// ObjectStorageStore is a hub.ReportsStore implementation that places reports
+// in an object storage bucket (S3 or GCS).
+type ObjectStorageStore struct {
+	baseURL *url.URL
+}
+
+// NewObjectStorageStore creates a new ObjectStorageStore instance. Reports
+// will be stored under the url provided (i.e. s3://bucket/reports).
+func NewObjectStorageStore(baseURL string) (*ObjectStorageStore, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return nil, errors.New("invalid reports object storage url")
+	}
+	if !repo.SchemeIsObjectStorage(u) {
+		return nil, fmt.Errorf("invalid reports object storage url: %w", repo.ErrSchemeNotSupported)
+	}
+	return &ObjectStorageStore{baseURL: u}, nil
+}
+
+// Get implements the ReportsStore interface.
+func (s *ObjectStorageStore) Get(ctx context.Context, location string) ([]byte, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report location: %w", err)
+	}
+	obj, err := repo.GetObject(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+	return io.ReadAll(obj)
+}
+
+// Put implements the ReportsStore interface.
+func (s *ObjectStorageStore) Put(ctx context.Context, key string, data []byte) (string, error) {
+	u := *s.baseURL
+	u.Path = path.Join("/", u.Path, strings.TrimPrefix(key, "/"))
+	if err := repo.PutObject(ctx, &u, data); err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,58 @@
+package reports
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStore(t *testing.T) {
+	t.Run("reports kept in database", func(t *testing.T) {
+		t.Parallel()
+		for _, store := range []string{"", "pg"} {
+			cfg := viper.New()
+			cfg.Set("reports.store", store)
+			rs, err := NewStore(cfg)
+			assert.NoError(t, err)
+			assert.Nil(t, rs)
+		}
+	})
+
+	t.Run("invalid reports store", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("reports.store", "invalid")
+		_, err := NewStore(cfg)
+		assert.EqualError(t, err, "invalid reports store")
+	})
+
+	t.Run("object storage store", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("reports.store", "objectStorage")
+		cfg.Set("reports.objectStorageURL", "s3://bucket/reports")
+		rs, err := NewStore(cfg)
+		require.NoError(t, err)
+		assert.IsType(t, &ObjectStorageStore{}, rs)
+	})
+}
+
+func TestNewObjectStorageStore(t *testing.T) {
+	t.Run("invalid url", func(t *testing.T) {
+		t.Parallel()
+		for _, u := range []string{"", "s3://", "https://bucket/reports", "ftp://bucket/reports", "az://account/container"} {
+			_, err := NewObjectStorageStore(u)
+			assert.Error(t, err, u)
+		}
+	})
+
+	t.Run("valid url", func(t *testing.T) {
+		t.Parallel()
+		for _, u := range []string{"s3://bucket", "gs://bucket/reports"} {
+			_, err := NewObjectStorageStore(u)
+			assert.NoError(t, err, u)
+		}
+	})
+}
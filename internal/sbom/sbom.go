@@ -0,0 +1,324 @@
+package sbom
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	// MaxSize represents the maximum size of a SBOM document that will be
+	// accepted.
+	MaxSize = 10 * 1024 * 1024
+
+	// cycloneDXSpecVersion represents the version of the CycloneDX spec used
+	// in the documents generated when converting from SPDX.
+	cycloneDXSpecVersion = "1.4"
+
+	// spdxVersion represents the version of the SPDX spec used in the
+	// documents generated when converting from CycloneDX.
+	spdxVersion = "SPDX-2.3"
+
+	// noAssertion is the value used in SPDX documents when a field's value
+	// is not known.
+	noAssertion = "NOASSERTION"
+)
+
+var (
+	// ErrUnsupportedFormat indicates that the SBOM document provided is not
+	// encoded in any of the supported formats.
+	ErrUnsupportedFormat = errors.New("unsupported sbom format (only spdx and cyclonedx json documents are supported)")
+
+	// ErrInvalidDocument indicates that the SBOM document provided is not
+	// valid.
+	ErrInvalidDocument = errors.New("invalid sbom document")
+)
+
+// spdxDocument represents the subset of the fields of a SPDX json document
+// used to validate and convert it.
+type spdxDocument struct {
+	SPDXVersion       string            `json:"spdxVersion"`
+	DataLicense       string            `json:"dataLicense"`
+	SPDXID            string            `json:"SPDXID"`
+	Name              string            `json:"name"`
+	DocumentNamespace string            `json:"documentNamespace"`
+	CreationInfo      *spdxCreationInfo `json:"creationInfo"`
+	Packages          []*spdxPackage    `json:"packages"`
+}
+
+// spdxCreationInfo represents the creation information of a SPDX document.
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+// spdxPackage represents a package in a SPDX document.
+type spdxPackage struct {
+	SPDXID           string             `json:"SPDXID"`
+	Name             string             `json:"name"`
+	VersionInfo      string             `json:"versionInfo,omitempty"`
+	DownloadLocation string             `json:"downloadLocation"`
+	LicenseConcluded string             `json:"licenseConcluded,omitempty"`
+	LicenseDeclared  string             `json:"licenseDeclared,omitempty"`
+	ExternalRefs     []*spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+// spdxExternalRef represents an external reference of a SPDX package.
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// cycloneDXDocument represents the subset of the fields of a CycloneDX json
+// document used to validate and convert it.
+type cycloneDXDocument struct {
+	BOMFormat   string                `json:"bomFormat"`
+	SpecVersion string                `json:"specVersion"`
+	Version     int                   `json:"version"`
+	Metadata    *cycloneDXMetadata    `json:"metadata,omitempty"`
+	Components  []*cycloneDXComponent `json:"components"`
+}
+
+// cycloneDXMetadata represents the metadata of a CycloneDX document.
+type cycloneDXMetadata struct {
+	Timestamp string              `json:"timestamp,omitempty"`
+	Component *cycloneDXComponent `json:"component,omitempty"`
+}
+
+// cycloneDXComponent represents a component in a CycloneDX document.
+type cycloneDXComponent struct {
+	Type     string              `json:"type"`
+	Name     string              `json:"name"`
+	Version  string              `json:"version,omitempty"`
+	PURL     string              `json:"purl,omitempty"`
+	Licenses []*cycloneDXLicense `json:"licenses,omitempty"`
+}
+
+// cycloneDXLicense represents a license entry of a CycloneDX component.
+type cycloneDXLicense struct {
+	Expression string `json:"expression,omitempty"`
+	License    *struct {
+		ID   string `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"license,omitempty"`
+}
+
+// component represents a format agnostic entry of a SBOM document, used as
+// an intermediate representation when converting between formats.
+type component struct {
+	name    string
+	version string
+	purl    string
+	license string
+}
+
+// Parse validates the SBOM document provided, returning the format it's
+// encoded in.
+func Parse(data []byte) (hub.SBOMFormat, error) {
+	if len(data) > MaxSize {
+		return "", fmt.Errorf("%w: document too big", ErrInvalidDocument)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return "", ErrUnsupportedFormat
+	}
+	switch {
+	case doc["spdxVersion"] != nil:
+		var spdxDoc *spdxDocument
+		if err := json.Unmarshal(data, &spdxDoc); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+		if !strings.HasPrefix(spdxDoc.SPDXVersion, "SPDX-") {
+			return "", fmt.Errorf("%w: invalid spdx version", ErrInvalidDocument)
+		}
+		if spdxDoc.SPDXID == "" {
+			return "", fmt.Errorf("%w: spdx id not provided", ErrInvalidDocument)
+		}
+		return hub.SPDX, nil
+	case doc["bomFormat"] != nil:
+		var cdxDoc *cycloneDXDocument
+		if err := json.Unmarshal(data, &cdxDoc); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+		if cdxDoc.BOMFormat != "CycloneDX" {
+			return "", ErrUnsupportedFormat
+		}
+		if cdxDoc.SpecVersion == "" {
+			return "", fmt.Errorf("%w: spec version not provided", ErrInvalidDocument)
+		}
+		return hub.CycloneDX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Convert converts the SBOM document provided to the format requested. The
+// conversion only preserves the components (name, version, purl and license)
+// listed in the document, as the formats are not fully equivalent.
+func Convert(data []byte, format hub.SBOMFormat) ([]byte, error) {
+	srcFormat, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if srcFormat == format {
+		return data, nil
+	}
+
+	switch format {
+	case hub.SPDX:
+		var cdxDoc *cycloneDXDocument
+		_ = json.Unmarshal(data, &cdxDoc)
+		return json.Marshal(cycloneDXToSPDX(cdxDoc, data))
+	case hub.CycloneDX:
+		var spdxDoc *spdxDocument
+		_ = json.Unmarshal(data, &spdxDoc)
+		return json.Marshal(spdxToCycloneDX(spdxDoc))
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// cycloneDXToSPDX converts the CycloneDX document provided to SPDX.
+func cycloneDXToSPDX(cdxDoc *cycloneDXDocument, data []byte) *spdxDocument {
+	// Collect components
+	components := make([]*component, 0, len(cdxDoc.Components))
+	for _, c := range cdxDoc.Components {
+		components = append(components, &component{
+			name:    c.Name,
+			version: c.Version,
+			purl:    c.PURL,
+			license: getCycloneDXLicense(c),
+		})
+	}
+
+	// Build SPDX document
+	name := "sbom"
+	created := time.Now().UTC().Format(time.RFC3339)
+	if cdxDoc.Metadata != nil {
+		if cdxDoc.Metadata.Component != nil && cdxDoc.Metadata.Component.Name != "" {
+			name = cdxDoc.Metadata.Component.Name
+		}
+		if cdxDoc.Metadata.Timestamp != "" {
+			created = cdxDoc.Metadata.Timestamp
+		}
+	}
+	spdxDoc := &spdxDocument{
+		SPDXVersion:       spdxVersion,
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              name,
+		DocumentNamespace: fmt.Sprintf("https://artifacthub.io/spdxdocs/%s-%x", name, sha256.Sum256(data)),
+		CreationInfo: &spdxCreationInfo{
+			Created:  created,
+			Creators: []string{"Tool: artifacthub"},
+		},
+		Packages: make([]*spdxPackage, 0, len(components)),
+	}
+	for i, c := range components {
+		p := &spdxPackage{
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%d", i+1),
+			Name:             c.name,
+			VersionInfo:      c.version,
+			DownloadLocation: noAssertion,
+			LicenseConcluded: noAssertion,
+			LicenseDeclared:  noAssertion,
+		}
+		if c.license != "" {
+			p.LicenseDeclared = c.license
+		}
+		if c.purl != "" {
+			p.ExternalRefs = []*spdxExternalRef{
+				{
+					ReferenceCategory: "PACKAGE-MANAGER",
+					ReferenceType:     "purl",
+					ReferenceLocator:  c.purl,
+				},
+			}
+		}
+		spdxDoc.Packages = append(spdxDoc.Packages, p)
+	}
+	return spdxDoc
+}
+
+// spdxToCycloneDX converts the SPDX document provided to CycloneDX.
+func spdxToCycloneDX(spdxDoc *spdxDocument) *cycloneDXDocument {
+	// Collect components
+	components := make([]*component, 0, len(spdxDoc.Packages))
+	for _, p := range spdxDoc.Packages {
+		c := &component{
+			name:    p.Name,
+			version: p.VersionInfo,
+			license: getSPDXLicense(p),
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				c.purl = ref.ReferenceLocator
+				break
+			}
+		}
+		components = append(components, c)
+	}
+
+	// Build CycloneDX document
+	cdxDoc := &cycloneDXDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: cycloneDXSpecVersion,
+		Version:     1,
+		Metadata:    &cycloneDXMetadata{},
+		Components:  make([]*cycloneDXComponent, 0, len(components)),
+	}
+	if spdxDoc.CreationInfo != nil {
+		cdxDoc.Metadata.Timestamp = spdxDoc.CreationInfo.Created
+	}
+	if spdxDoc.Name != "" {
+		cdxDoc.Metadata.Component = &cycloneDXComponent{
+			Type: "application",
+			Name: spdxDoc.Name,
+		}
+	}
+	for _, c := range components {
+		cdxc := &cycloneDXComponent{
+			Type:    "library",
+			Name:    c.name,
+			Version: c.version,
+			PURL:    c.purl,
+		}
+		if c.license != "" {
+			cdxc.Licenses = []*cycloneDXLicense{{Expression: c.license}}
+		}
+		cdxDoc.Components = append(cdxDoc.Components, cdxc)
+	}
+	return cdxDoc
+}
+
+// getCycloneDXLicense returns the license of the CycloneDX component provided
+// as a SPDX license expression.
+func getCycloneDXLicense(c *cycloneDXComponent) string {
+	licenses := make([]string, 0, len(c.Licenses))
+	for _, l := range c.Licenses {
+		switch {
+		case l.Expression != "":
+			licenses = append(licenses, l.Expression)
+		case l.License != nil && l.License.ID != "":
+			licenses = append(licenses, l.License.ID)
+		}
+	}
+	return strings.Join(licenses, " AND ")
+}
+
+// getSPDXLicense returns the license of the SPDX package provided, when it's
+// known.
+func getSPDXLicense(p *spdxPackage) string {
+	for _, l := range []string{p.LicenseDeclared, p.LicenseConcluded} {
+		if l != "" && l != noAssertion && l != "NONE" {
+			return l
+		}
+	}
+	return ""
+}
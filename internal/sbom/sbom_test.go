@@ -0,0 +1,176 @@
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	spdxDoc = []byte(`{
+		"spdxVersion": "SPDX-2.3",
+		"dataLicense": "CC0-1.0",
+		"SPDXID": "SPDXRef-DOCUMENT",
+		"name": "pkg1",
+		"documentNamespace": "https://example.com/pkg1",
+		"creationInfo": {
+			"created": "2021-06-01T00:00:00Z",
+			"creators": ["Tool: syft"]
+		},
+		"packages": [
+			{
+				"SPDXID": "SPDXRef-Package-alpine",
+				"name": "alpine-baselayout",
+				"versionInfo": "3.2.0-r8",
+				"downloadLocation": "NOASSERTION",
+				"licenseConcluded": "NOASSERTION",
+				"licenseDeclared": "GPL-2.0-only",
+				"externalRefs": [
+					{
+						"referenceCategory": "PACKAGE-MANAGER",
+						"referenceType": "purl",
+						"referenceLocator": "pkg:apk/alpine/alpine-baselayout@3.2.0-r8"
+					}
+				]
+			}
+		]
+	}`)
+
+	cycloneDXDoc = []byte(`{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.4",
+		"version": 1,
+		"metadata": {
+			"timestamp": "2021-06-01T00:00:00Z",
+			"component": {
+				"type": "application",
+				"name": "pkg1"
+			}
+		},
+		"components": [
+			{
+				"type": "library",
+				"name": "alpine-baselayout",
+				"version": "3.2.0-r8",
+				"purl": "pkg:apk/alpine/alpine-baselayout@3.2.0-r8",
+				"licenses": [
+					{
+						"license": {
+							"id": "GPL-2.0-only"
+						}
+					}
+				]
+			}
+		]
+	}`)
+)
+
+func TestParse(t *testing.T) {
+	t.Run("unsupported format", func(t *testing.T) {
+		testCases := []string{
+			"invalid",
+			"<bom></bom>",
+			"SPDXVersion: SPDX-2.2",
+			`{"key": "value"}`,
+			`{"bomFormat": "other"}`,
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc, func(t *testing.T) {
+				t.Parallel()
+				_, err := Parse([]byte(tc))
+				assert.True(t, errors.Is(err, ErrUnsupportedFormat))
+			})
+		}
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		testCases := []string{
+			`{"spdxVersion": 1}`,
+			`{"spdxVersion": "2.2", "SPDXID": "SPDXRef-DOCUMENT"}`,
+			`{"spdxVersion": "SPDX-2.2"}`,
+			`{"bomFormat": "CycloneDX"}`,
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc, func(t *testing.T) {
+				t.Parallel()
+				_, err := Parse([]byte(tc))
+				assert.True(t, errors.Is(err, ErrInvalidDocument))
+			})
+		}
+	})
+
+	t.Run("valid documents", func(t *testing.T) {
+		t.Parallel()
+		format, err := Parse(spdxDoc)
+		require.NoError(t, err)
+		assert.Equal(t, hub.SPDX, format)
+		format, err = Parse(cycloneDXDoc)
+		require.NoError(t, err)
+		assert.Equal(t, hub.CycloneDX, format)
+	})
+}
+
+func TestConvert(t *testing.T) {
+	t.Run("invalid document", func(t *testing.T) {
+		t.Parallel()
+		_, err := Convert([]byte("invalid"), hub.SPDX)
+		assert.True(t, errors.Is(err, ErrUnsupportedFormat))
+	})
+
+	t.Run("unsupported target format", func(t *testing.T) {
+		t.Parallel()
+		_, err := Convert(spdxDoc, "other")
+		assert.True(t, errors.Is(err, ErrUnsupportedFormat))
+	})
+
+	t.Run("same format, document returned as is", func(t *testing.T) {
+		t.Parallel()
+		data, err := Convert(spdxDoc, hub.SPDX)
+		require.NoError(t, err)
+		assert.Equal(t, spdxDoc, data)
+	})
+
+	t.Run("spdx to cyclonedx", func(t *testing.T) {
+		t.Parallel()
+		data, err := Convert(spdxDoc, hub.CycloneDX)
+		require.NoError(t, err)
+		format, err := Parse(data)
+		require.NoError(t, err)
+		assert.Equal(t, hub.CycloneDX, format)
+		var doc *cycloneDXDocument
+		require.NoError(t, json.Unmarshal(data, &doc))
+		assert.Equal(t, "2021-06-01T00:00:00Z", doc.Metadata.Timestamp)
+		assert.Equal(t, "pkg1", doc.Metadata.Component.Name)
+		require.Len(t, doc.Components, 1)
+		c := doc.Components[0]
+		assert.Equal(t, "alpine-baselayout", c.Name)
+		assert.Equal(t, "3.2.0-r8", c.Version)
+		assert.Equal(t, "pkg:apk/alpine/alpine-baselayout@3.2.0-r8", c.PURL)
+		assert.Equal(t, "GPL-2.0-only", c.Licenses[0].Expression)
+	})
+
+	t.Run("cyclonedx to spdx", func(t *testing.T) {
+		t.Parallel()
+		data, err := Convert(cycloneDXDoc, hub.SPDX)
+		require.NoError(t, err)
+		format, err := Parse(data)
+		require.NoError(t, err)
+		assert.Equal(t, hub.SPDX, format)
+		var doc *spdxDocument
+		require.NoError(t, json.Unmarshal(data, &doc))
+		assert.Equal(t, "pkg1", doc.Name)
+		assert.Equal(t, "2021-06-01T00:00:00Z", doc.CreationInfo.Created)
+		require.Len(t, doc.Packages, 1)
+		p := doc.Packages[0]
+		assert.Equal(t, "alpine-baselayout", p.Name)
+		assert.Equal(t, "3.2.0-r8", p.VersionInfo)
+		assert.Equal(t, "GPL-2.0-only", p.LicenseDeclared)
+		assert.Equal(t, "pkg:apk/alpine/alpine-baselayout@3.2.0-r8", p.ExternalRefs[0].ReferenceLocator)
+	})
+}
@@ -89,7 +89,7 @@ func (s *Scanner) Scan(sn *hub.SnapshotToScan) (*hub.SnapshotSecurityReport, err
 
 	imagesReports := make(map[string]*trivyreport.Report)
 	for _, image := range sn.ContainersImages {
-		imageReportJSON, err := s.is.ScanImage(image.Image)
+		imageReportJSON, err := s.is.ScanImage(getImageRefToScan(image))
 		if err != nil {
 			err := fmt.Errorf("error scanning image %s: %w (package %s:%s)", image.Image, err, sn.PackageName, sn.Version)
 			s.ec.Append(sn.RepositoryID, err.Error())
@@ -112,6 +112,21 @@ func (s *Scanner) Scan(sn *hub.SnapshotToScan) (*hub.SnapshotSecurityReport, err
 	return report, nil
 }
 
+// getImageRefToScan returns the reference of the image that should be scanned.
+// When the digest of the image was resolved during the tracking, the image is
+// pinned to it so that the image scanned is the same one the package version
+// referenced when it was registered.
+func getImageRefToScan(image *hub.ContainerImage) string {
+	if image.Digest == "" {
+		return image.Image
+	}
+	ref, err := name.ParseReference(image.Image)
+	if err != nil {
+		return image.Image
+	}
+	return ref.Context().Name() + "@" + image.Digest
+}
+
 // generateSummary generates a summary of the security report from the images
 // reports.
 func generateSummary(imagesReports map[string]*trivyreport.Report) *hub.SecurityReportSummary {
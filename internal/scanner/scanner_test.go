@@ -113,6 +113,36 @@ func TestScan(t *testing.T) {
 		ecMock.AssertExpectations(t)
 	})
 
+	t.Run("image pinned to its digest when available", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		isMock := &ImageScannerMock{}
+		isMock.On("ScanImage", "index.docker.io/repo/image@sha256:4cd3a0c8ad2b4a3fa9e8a5e27ea8e0cb2ff5b7d5e6a3e0e4d1d2f05b5f3ee7a1").
+			Return(sampleReport1Data, nil)
+		s := New(ctx, cfg, ecMock, WithImageScanner(isMock))
+
+		report, err := s.Scan(&hub.SnapshotToScan{
+			RepositoryID: repositoryID,
+			PackageID:    packageID,
+			PackageName:  packageName,
+			Version:      version,
+			ContainersImages: []*hub.ContainerImage{
+				{
+					Image:  image,
+					Digest: "sha256:4cd3a0c8ad2b4a3fa9e8a5e27ea8e0cb2ff5b7d5e6a3e0e4d1d2f05b5f3ee7a1",
+				},
+			},
+		})
+		require.Nil(t, err)
+		assert.Equal(t, &hub.SnapshotSecurityReport{
+			PackageID: packageID,
+			Version:   version,
+		}, report)
+		isMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
 	t.Run("image report generated successfully", func(t *testing.T) {
 		t.Parallel()
 		ecMock := &repo.ErrorsCollectorMock{}
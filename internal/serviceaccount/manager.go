@@ -0,0 +1,216 @@
+package serviceaccount
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	addServiceAccountDBQ        = `select add_service_account($1::uuid, $2::text, $3::jsonb)`
+	deleteServiceAccountDBQ     = `select delete_service_account($1::uuid, $2::text, $3::uuid)`
+	getOrgServiceAccountsDBQ    = `select get_org_service_accounts($1::uuid, $2::text)`
+	getServiceAccountOrgNameDBQ = `select o.name from "user" u join organization o on o.organization_id = u.service_account_organization_id where u.user_id = $1`
+)
+
+var (
+	// serviceAccountNameRE is a regexp used to validate a service account
+	// name.
+	serviceAccountNameRE = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+	// errNameNotAvailableDB represents the error returned from the database
+	// when the service account name provided is already in use.
+	errNameNotAvailableDB = errors.New("ERROR: service account name not available (SQLSTATE P0001)")
+
+	// errServiceAccountNotFoundDB represents the error returned from the
+	// database when the service account provided does not exist.
+	errServiceAccountNotFoundDB = errors.New("ERROR: service account not found (SQLSTATE P0001)")
+)
+
+// Manager provides an API to manage service accounts.
+type Manager struct {
+	db  hub.DB
+	az  hub.Authorizer
+	akm hub.APIKeyManager
+}
+
+// NewManager creates a new Manager instance. The api key manager provided
+// will be used to manage the service accounts api keys.
+func NewManager(db hub.DB, az hub.Authorizer, akm hub.APIKeyManager) *Manager {
+	return &Manager{
+		db:  db,
+		az:  az,
+		akm: akm,
+	}
+}
+
+// Add adds the provided service account to the organization given, returning
+// the id of the service account created.
+func (m *Manager) Add(ctx context.Context, orgName string, sa *hub.ServiceAccount) (string, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if sa.Name == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	if !serviceAccountNameRE.MatchString(sa.Name) {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid name")
+	}
+	if sa.Role != "" && (!sa.Role.IsValid() || sa.Role == hub.OrganizationOwner) {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid role")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.AddOrganizationServiceAccount,
+	}); err != nil {
+		return "", err
+	}
+
+	// Add service account to the database
+	var serviceAccountID string
+	saJSON, _ := json.Marshal(sa)
+	err := m.db.QueryRow(ctx, addServiceAccountDBQ, userID, orgName, saJSON).Scan(&serviceAccountID)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return "", hub.ErrInsufficientPrivilege
+		case errNameNotAvailableDB.Error():
+			return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not available")
+		default:
+			return "", err
+		}
+	}
+	return serviceAccountID, nil
+}
+
+// AddAPIKey adds a new api key to the provided service account.
+func (m *Manager) AddAPIKey(
+	ctx context.Context,
+	orgName,
+	serviceAccountID string,
+	ak *hub.APIKey,
+) (*hub.APIKey, error) {
+	// Validate input
+	if err := validateInput(orgName, serviceAccountID); err != nil {
+		return nil, err
+	}
+
+	// Authorize action
+	if err := m.authorize(ctx, orgName, serviceAccountID, hub.AddOrganizationServiceAccount); err != nil {
+		return nil, err
+	}
+
+	// Add api key on behalf of the service account
+	return m.akm.Add(context.WithValue(ctx, hub.UserIDKey, serviceAccountID), ak)
+}
+
+// Delete deletes the provided service account from the organization given.
+func (m *Manager) Delete(ctx context.Context, orgName, serviceAccountID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateInput(orgName, serviceAccountID); err != nil {
+		return err
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.DeleteOrganizationServiceAccount,
+	}); err != nil {
+		return err
+	}
+
+	// Delete service account from database
+	_, err := m.db.Exec(ctx, deleteServiceAccountDBQ, userID, orgName, serviceAccountID)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case errServiceAccountNotFoundDB.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
+
+// DeleteAPIKey deletes the provided api key from the service account given.
+func (m *Manager) DeleteAPIKey(ctx context.Context, orgName, serviceAccountID, apiKeyID string) error {
+	// Validate input
+	if err := validateInput(orgName, serviceAccountID); err != nil {
+		return err
+	}
+
+	// Authorize action
+	if err := m.authorize(ctx, orgName, serviceAccountID, hub.DeleteOrganizationServiceAccount); err != nil {
+		return err
+	}
+
+	// Delete api key on behalf of the service account
+	return m.akm.Delete(context.WithValue(ctx, hub.UserIDKey, serviceAccountID), apiKeyID)
+}
+
+// GetByOrgJSON returns the service accounts of the provided organization as a
+// json array.
+func (m *Manager) GetByOrgJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get service accounts from database
+	return util.DBQueryJSON(ctx, m.db, getOrgServiceAccountsDBQ, userID, orgName)
+}
+
+// authorize checks that the service account provided belongs to the given
+// organization and that the requesting user is allowed to perform the action
+// provided on it.
+func (m *Manager) authorize(ctx context.Context, orgName, serviceAccountID string, action hub.Action) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	var serviceAccountOrgName string
+	err := m.db.QueryRow(ctx, getServiceAccountOrgNameDBQ, serviceAccountID).Scan(&serviceAccountOrgName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return hub.ErrNotFound
+		}
+		return err
+	}
+	if serviceAccountOrgName != orgName {
+		return hub.ErrNotFound
+	}
+	return m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           action,
+	})
+}
+
+// validateInput checks the organization name and service account id provided
+// are valid.
+func validateInput(orgName, serviceAccountID string) error {
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if _, err := uuid.FromString(serviceAccountID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid service account id")
+	}
+	return nil
+}
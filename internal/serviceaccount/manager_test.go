@@ -0,0 +1,432 @@
+package serviceaccount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/apikey"
+	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const saID = "00000000-0000-0000-0000-000000000001"
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	sa := &hub.ServiceAccount{Name: "sa1"}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.Add(context.Background(), "org1", sa)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			sa      *hub.ServiceAccount
+		}{
+			{
+				"organization name not provided",
+				"",
+				sa,
+			},
+			{
+				"name not provided",
+				"org1",
+				&hub.ServiceAccount{},
+			},
+			{
+				"invalid name",
+				"org1",
+				&hub.ServiceAccount{Name: "_sa1"},
+			},
+			{
+				"invalid role",
+				"org1",
+				&hub.ServiceAccount{Name: "sa1", Role: "invalid"},
+			},
+			{
+				"invalid role",
+				"org1",
+				&hub.ServiceAccount{Name: "sa1", Role: hub.OrganizationOwner},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil, nil)
+				_, err := m.Add(ctx, tc.orgName, tc.sa)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationServiceAccount,
+		}).Return(tests.ErrFake)
+		m := NewManager(nil, az, nil)
+
+		_, err := m.Add(ctx, "org1", sa)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errNameNotAvailableDB,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, addServiceAccountDBQ, "userID", "org1", mock.Anything).Return(nil, tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, mock.Anything).Return(nil)
+				m := NewManager(db, az, nil)
+
+				_, err := m.Add(ctx, "org1", sa)
+				assert.True(t, errors.Is(err, tc.expectedError))
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("service account added successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, addServiceAccountDBQ, "userID", "org1", mock.Anything).Return(saID, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(db, az, nil)
+
+		serviceAccountID, err := m.Add(ctx, "org1", sa)
+		assert.NoError(t, err)
+		assert.Equal(t, saID, serviceAccountID)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestAddAPIKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	ak := &hub.APIKey{Name: "apikey1"}
+	saCtx := mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Value(hub.UserIDKey).(string) == saID
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg           string
+			orgName          string
+			serviceAccountID string
+		}{
+			{
+				"organization name not provided",
+				"",
+				saID,
+			},
+			{
+				"invalid service account id",
+				"org1",
+				"invalid",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil, nil)
+				_, err := m.AddAPIKey(ctx, tc.orgName, tc.serviceAccountID, ak)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("service account not found", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			dbResponse  interface{}
+			dbErr       error
+		}{
+			{
+				"service account does not exist",
+				nil,
+				pgx.ErrNoRows,
+			},
+			{
+				"service account belongs to other organization",
+				"org2",
+				nil,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getServiceAccountOrgNameDBQ, saID).Return(tc.dbResponse, tc.dbErr)
+				m := NewManager(db, nil, nil)
+
+				_, err := m.AddAPIKey(ctx, "org1", saID, ak)
+				assert.Equal(t, hub.ErrNotFound, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getServiceAccountOrgNameDBQ, saID).Return("org1", nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationServiceAccount,
+		}).Return(tests.ErrFake)
+		m := NewManager(db, az, nil)
+
+		_, err := m.AddAPIKey(ctx, "org1", saID, ak)
+		assert.Equal(t, tests.ErrFake, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("api key added on behalf of the service account", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getServiceAccountOrgNameDBQ, saID).Return("org1", nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		akm := &apikey.ManagerMock{}
+		expectedAK := &hub.APIKey{APIKeyID: "apiKeyID", Secret: "secret"}
+		akm.On("Add", saCtx, ak).Return(expectedAK, nil)
+		m := NewManager(db, az, akm)
+
+		akOUT, err := m.AddAPIKey(ctx, "org1", saID, ak)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedAK, akOUT)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+		akm.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.Delete(context.Background(), "org1", saID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		err := m.Delete(ctx, "org1", "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationServiceAccount,
+		}).Return(tests.ErrFake)
+		m := NewManager(nil, az, nil)
+
+		err := m.Delete(ctx, "org1", saID)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("delete service account", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				nil,
+				nil,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errServiceAccountNotFoundDB,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(fmt.Sprintf("%v", tc.dbErr), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, deleteServiceAccountDBQ, "userID", "org1", saID).Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, mock.Anything).Return(nil)
+				m := NewManager(db, az, nil)
+
+				err := m.Delete(ctx, "org1", saID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	saCtx := mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Value(hub.UserIDKey).(string) == saID
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		err := m.DeleteAPIKey(ctx, "", saID, "apiKeyID")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getServiceAccountOrgNameDBQ, saID).Return("org1", nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationServiceAccount,
+		}).Return(tests.ErrFake)
+		m := NewManager(db, az, nil)
+
+		err := m.DeleteAPIKey(ctx, "org1", saID, "apiKeyID")
+		assert.Equal(t, tests.ErrFake, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("api key deleted on behalf of the service account", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getServiceAccountOrgNameDBQ, saID).Return("org1", nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		akm := &apikey.ManagerMock{}
+		akm.On("Delete", saCtx, "apiKeyID").Return(nil)
+		m := NewManager(db, az, akm)
+
+		err := m.DeleteAPIKey(ctx, "org1", saID, "apiKeyID")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+		akm.AssertExpectations(t)
+	})
+}
+
+func TestGetByOrgJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetByOrgJSON(context.Background(), "org1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		_, err := m.GetByOrgJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("service accounts data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgServiceAccountsDBQ, "userID", "org1").Return([]byte("dataJSON"), nil)
+		m := NewManager(db, nil, nil)
+
+		dataJSON, err := m.GetByOrgJSON(ctx, "org1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error getting service accounts", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgServiceAccountsDBQ, "userID", "org1").Return(nil, tc.dbErr)
+				m := NewManager(db, nil, nil)
+
+				dataJSON, err := m.GetByOrgJSON(ctx, "org1")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package serviceaccount
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the ServiceAccountManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the ServiceAccountManager interface.
+func (m *ManagerMock) Add(ctx context.Context, orgName string, sa *hub.ServiceAccount) (string, error) {
+	args := m.Called(ctx, orgName, sa)
+	return args.String(0), args.Error(1)
+}
+
+// AddAPIKey implements the ServiceAccountManager interface.
+func (m *ManagerMock) AddAPIKey(
+	ctx context.Context,
+	orgName,
+	serviceAccountID string,
+	ak *hub.APIKey,
+) (*hub.APIKey, error) {
+	args := m.Called(ctx, orgName, serviceAccountID, ak)
+	data, _ := args.Get(0).(*hub.APIKey)
+	return data, args.Error(1)
+}
+
+// Delete implements the ServiceAccountManager interface.
+func (m *ManagerMock) Delete(ctx context.Context, orgName, serviceAccountID string) error {
+	args := m.Called(ctx, orgName, serviceAccountID)
+	return args.Error(0)
+}
+
+// DeleteAPIKey implements the ServiceAccountManager interface.
+func (m *ManagerMock) DeleteAPIKey(ctx context.Context, orgName, serviceAccountID, apiKeyID string) error {
+	args := m.Called(ctx, orgName, serviceAccountID, apiKeyID)
+	return args.Error(0)
+}
+
+// GetByOrgJSON implements the ServiceAccountManager interface.
+func (m *ManagerMock) GetByOrgJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
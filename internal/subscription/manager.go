@@ -16,11 +16,23 @@ const (
 	addSubscriptionDBQ         = `select add_subscription($1::jsonb)`
 	deleteOptOutDBQ            = `select delete_opt_out($1::uuid, $2::uuid)`
 	deleteSubscriptionDBQ      = `select delete_subscription($1::jsonb)`
+	exportUserSubscriptionsDBQ = `select export_user_subscriptions($1::uuid)`
 	getPkgSubscriptorsDBQ      = `select get_package_subscriptors($1::uuid, $2::integer)`
 	getRepoSubscriptorsDBQ     = `select get_repository_subscriptors($1::uuid, $2::integer)`
 	getUserOptOutEntriesDBQ    = `select * from get_user_opt_out_entries($1::uuid, $2::int, $3::int)`
 	getUserPkgSubscriptionsDBQ = `select get_user_package_subscriptions($1::uuid, $2::uuid)`
 	getUserSubscriptionsDBQ    = `select * from get_user_subscriptions($1::uuid, $2::int, $3::int)`
+	importUserSubscriptionsDBQ = `select import_user_subscriptions($1::uuid, $2::jsonb)`
+)
+
+const (
+	// subscriptionsDocumentVersion represents the version of the subscriptions
+	// documents format supported.
+	subscriptionsDocumentVersion = 1
+
+	// maxSubscriptionsDocumentEntries represents the maximum number of entries
+	// (subscriptions and stars) a subscriptions document can contain.
+	maxSubscriptionsDocumentEntries = 5000
 )
 
 var (
@@ -29,6 +41,10 @@ var (
 		hub.NewRelease,
 		hub.SecurityAlert,
 	}
+
+	// validOnConflictStrategies contains the strategies supported to handle
+	// conflicts when importing subscriptions.
+	validOnConflictStrategies = []string{"merge", "skip", "replace"}
 )
 
 // Manager provides an API to manage subscriptions.
@@ -89,6 +105,13 @@ func (m *Manager) DeleteOptOut(ctx context.Context, optOutID string) error {
 	return err
 }
 
+// ExportJSON returns the subscriptions and stars of the user doing the request
+// as a portable json document.
+func (m *Manager) ExportJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	return util.DBQueryJSON(ctx, m.db, exportUserSubscriptionsDBQ, userID)
+}
+
 // GetByPackageJSON returns the subscriptions the user has for a given package
 // as json array of objects.
 func (m *Manager) GetByPackageJSON(ctx context.Context, packageID string) ([]byte, error) {
@@ -138,6 +161,64 @@ func (m *Manager) GetSubscriptors(ctx context.Context, e *hub.Event) ([]*hub.Use
 	return subscriptors, nil
 }
 
+// ImportJSON imports the subscriptions document provided into the account of
+// the user doing the request, returning a json report of the changes made.
+func (m *Manager) ImportJSON(ctx context.Context, input *hub.SubscriptionsImportInput) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	if err := validateImportInput(input); err != nil {
+		return nil, err
+	}
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSON(ctx, m.db, importUserSubscriptionsDBQ, userID, inputJSON)
+}
+
+// validateImportInput checks if the subscriptions import input provided is
+// valid to be used as input for some database functions calls.
+func validateImportInput(input *hub.SubscriptionsImportInput) error {
+	if input == nil || input.Document == nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "document not provided")
+	}
+	if input.OnConflict == "" {
+		input.OnConflict = "merge"
+	}
+	isValidStrategy := false
+	for _, strategy := range validOnConflictStrategies {
+		if input.OnConflict == strategy {
+			isValidStrategy = true
+			break
+		}
+	}
+	if !isValidStrategy {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid conflicts strategy")
+	}
+	d := input.Document
+	if d.Version != subscriptionsDocumentVersion {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "unsupported document version")
+	}
+	if len(d.Subscriptions)+len(d.Stars) > maxSubscriptionsDocumentEntries {
+		return fmt.Errorf("%w: %s (max: %d)", hub.ErrInvalidInput, "too many entries",
+			maxSubscriptionsDocumentEntries)
+	}
+	for _, entries := range [][]*hub.SubscriptionsDocumentEntry{d.Subscriptions, d.Stars} {
+		for _, e := range entries {
+			if e == nil || e.RepositoryName == "" || e.PackageName == "" {
+				return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid entry: repository and package names required")
+			}
+		}
+	}
+	for _, e := range d.Subscriptions {
+		if len(e.EventKinds) == 0 {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid entry: event kinds not provided")
+		}
+		for _, kind := range e.EventKinds {
+			if !isValidEventKind(kind) {
+				return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid event kind")
+			}
+		}
+	}
+	return nil
+}
+
 // validateSubscription checks if the subscription provided is valid to be used
 // as input for some database functions calls.
 func validateSubscription(s *hub.Subscription) error {
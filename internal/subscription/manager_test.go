@@ -294,6 +294,42 @@ func TestDeleteOptOut(t *testing.T) {
 	})
 }
 
+func TestExportJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.ExportJSON(context.Background())
+		})
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, exportUserSubscriptionsDBQ, userID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.ExportJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, exportUserSubscriptionsDBQ, userID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.ExportJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetByPackageJSON(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
 
@@ -416,6 +452,139 @@ func TestGetOptOutListJSON(t *testing.T) {
 	})
 }
 
+func TestImportJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+	validDocument := func() *hub.SubscriptionsDocument {
+		return &hub.SubscriptionsDocument{
+			Version: 1,
+			Subscriptions: []*hub.SubscriptionsDocumentEntry{
+				{
+					RepositoryName: "repo1",
+					PackageName:    "pkg1",
+					EventKinds:     []hub.EventKind{hub.NewRelease},
+				},
+			},
+			Stars: []*hub.SubscriptionsDocumentEntry{
+				{
+					RepositoryName: "repo1",
+					PackageName:    "pkg1",
+				},
+			},
+		}
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.ImportJSON(context.Background(), &hub.SubscriptionsImportInput{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.SubscriptionsImportInput
+		}{
+			{
+				"document not provided",
+				&hub.SubscriptionsImportInput{},
+			},
+			{
+				"invalid conflicts strategy",
+				&hub.SubscriptionsImportInput{
+					Document:   validDocument(),
+					OnConflict: "invalid",
+				},
+			},
+			{
+				"unsupported document version",
+				&hub.SubscriptionsImportInput{
+					Document: &hub.SubscriptionsDocument{
+						Version: 2,
+					},
+				},
+			},
+			{
+				"repository and package names required",
+				&hub.SubscriptionsImportInput{
+					Document: &hub.SubscriptionsDocument{
+						Version: 1,
+						Stars: []*hub.SubscriptionsDocumentEntry{
+							{RepositoryName: "repo1"},
+						},
+					},
+				},
+			},
+			{
+				"event kinds not provided",
+				&hub.SubscriptionsImportInput{
+					Document: &hub.SubscriptionsDocument{
+						Version: 1,
+						Subscriptions: []*hub.SubscriptionsDocumentEntry{
+							{RepositoryName: "repo1", PackageName: "pkg1"},
+						},
+					},
+				},
+			},
+			{
+				"invalid event kind",
+				&hub.SubscriptionsImportInput{
+					Document: &hub.SubscriptionsDocument{
+						Version: 1,
+						Subscriptions: []*hub.SubscriptionsDocumentEntry{
+							{
+								RepositoryName: "repo1",
+								PackageName:    "pkg1",
+								EventKinds:     []hub.EventKind{hub.RepositoryTrackingErrors},
+							},
+						},
+					},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.ImportJSON(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, importUserSubscriptionsDBQ, userID, mock.Anything).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		input := &hub.SubscriptionsImportInput{
+			Document: validDocument(),
+			DryRun:   true,
+		}
+		dataJSON, err := m.ImportJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		assert.Equal(t, "merge", input.OnConflict)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, importUserSubscriptionsDBQ, userID, mock.Anything).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.ImportJSON(ctx, &hub.SubscriptionsImportInput{Document: validDocument()})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetSubscriptors(t *testing.T) {
 	ctx := context.Background()
 	pkgNewReleaseEvent := &hub.Event{
@@ -36,6 +36,13 @@ func (m *ManagerMock) DeleteOptOut(ctx context.Context, optOutID string) error {
 	return args.Error(0)
 }
 
+// ExportJSON implements the SubscriptionManager interface.
+func (m *ManagerMock) ExportJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetByPackageJSON implements the SubscriptionManager interface.
 func (m *ManagerMock) GetByPackageJSON(ctx context.Context, packageID string) ([]byte, error) {
 	args := m.Called(ctx, packageID)
@@ -63,3 +70,10 @@ func (m *ManagerMock) GetSubscriptors(ctx context.Context, e *hub.Event) ([]*hub
 	data, _ := args.Get(0).([]*hub.User)
 	return data, args.Error(1)
 }
+
+// ImportJSON implements the SubscriptionManager interface.
+func (m *ManagerMock) ImportJSON(ctx context.Context, input *hub.SubscriptionsImportInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
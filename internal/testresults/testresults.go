@@ -0,0 +1,114 @@
+package testresults
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// MaxSize represents the maximum size of a test results document that will be
+// accepted.
+const MaxSize = 1024 * 1024
+
+var (
+	// ErrUnsupportedFormat indicates that the test results document provided
+	// is not encoded in any of the supported formats.
+	ErrUnsupportedFormat = errors.New("unsupported test results format (only junit xml reports and json summaries are supported)")
+
+	// ErrInvalidDocument indicates that the test results document provided is
+	// not valid.
+	ErrInvalidDocument = errors.New("invalid test results document")
+)
+
+// junitSuite represents a test suite (or a collection of them) in a JUnit xml
+// report, like the ones generated by helm-unittest (--output-type JUnit).
+type junitSuite struct {
+	XMLName  xml.Name
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+// Parse parses the test results document provided, returning a summary of the
+// results. JUnit xml reports and json summaries (i.e. {"tool": "ct", "passed":
+// 3, "failed": 0, "skipped": 0}) are supported.
+func Parse(data []byte) (*hub.TestResults, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > MaxSize {
+		return nil, ErrInvalidDocument
+	}
+	var tr *hub.TestResults
+	var err error
+	switch {
+	case bytes.HasPrefix(data, []byte("{")):
+		tr, err = parseJSON(data)
+	case bytes.HasPrefix(data, []byte("<")):
+		tr, err = parseJUnit(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tr.Passed < 0 || tr.Failed < 0 || tr.Skipped < 0 || tr.Passed+tr.Failed+tr.Skipped == 0 {
+		return nil, ErrInvalidDocument
+	}
+	return tr, nil
+}
+
+// parseJSON parses the json test results summary provided.
+func parseJSON(data []byte) (*hub.TestResults, error) {
+	var tr *hub.TestResults
+	if err := json.Unmarshal(data, &tr); err != nil {
+		return nil, ErrInvalidDocument
+	}
+	return tr, nil
+}
+
+// parseJUnit parses the JUnit xml report provided.
+func parseJUnit(data []byte) (*hub.TestResults, error) {
+	var s junitSuite
+	if err := xml.Unmarshal(data, &s); err != nil {
+		return nil, ErrInvalidDocument
+	}
+	var tests, failed, skipped int
+	switch s.XMLName.Local {
+	case "testsuites":
+		tests, failed, skipped = sumSuites(s.Suites)
+	case "testsuite":
+		tests, failed, skipped = sumSuites([]junitSuite{s})
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	passed := tests - failed - skipped
+	if passed < 0 {
+		return nil, ErrInvalidDocument
+	}
+	return &hub.TestResults{
+		Tool:    "junit",
+		Passed:  passed,
+		Failed:  failed,
+		Skipped: skipped,
+	}, nil
+}
+
+// sumSuites returns the number of tests, failed tests (failures and errors)
+// and skipped tests in the suites provided, including the nested ones.
+func sumSuites(suites []junitSuite) (tests, failed, skipped int) {
+	for _, s := range suites {
+		if len(s.Suites) > 0 {
+			t, f, sk := sumSuites(s.Suites)
+			tests, failed, skipped = tests+t, failed+f, skipped+sk
+			continue
+		}
+		tests += s.Tests
+		failed += s.Failures + s.Errors
+		skipped += s.Skipped
+	}
+	return
+}
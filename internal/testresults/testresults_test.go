@@ -0,0 +1,92 @@
+package testresults
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		data        string
+		expectedTR  *hub.TestResults
+		expectedErr error
+	}{
+		{
+			"",
+			nil,
+			ErrUnsupportedFormat,
+		},
+		{
+			"plain text output",
+			nil,
+			ErrUnsupportedFormat,
+		},
+		{
+			`{"passed": "many"}`,
+			nil,
+			ErrInvalidDocument,
+		},
+		{
+			`{"tool": "ct", "passed": 0, "failed": 0}`,
+			nil,
+			ErrInvalidDocument,
+		},
+		{
+			`{"tool": "ct", "passed": -1, "failed": 2}`,
+			nil,
+			ErrInvalidDocument,
+		},
+		{
+			`{"tool": "ct", "passed": 3, "failed": 1, "skipped": 1}`,
+			&hub.TestResults{Tool: "ct", Passed: 3, Failed: 1, Skipped: 1},
+			nil,
+		},
+		{
+			`<testsuites><testsuite tests="3"`,
+			nil,
+			ErrInvalidDocument,
+		},
+		{
+			`<html><body></body></html>`,
+			nil,
+			ErrUnsupportedFormat,
+		},
+		{
+			`<testsuite tests="1" failures="2"></testsuite>`,
+			nil,
+			ErrInvalidDocument,
+		},
+		{
+			`
+			<?xml version="1.0" encoding="UTF-8"?>
+			<testsuites>
+				<testsuite name="deployment_test.yaml" tests="4" failures="1" errors="0" skipped="1">
+					<testcase name="should pass"></testcase>
+				</testsuite>
+				<testsuite name="service_test.yaml" tests="2" failures="0" errors="1"></testsuite>
+			</testsuites>
+			`,
+			&hub.TestResults{Tool: "junit", Passed: 3, Failed: 2, Skipped: 1},
+			nil,
+		},
+		{
+			`<testsuite name="chart" tests="5" failures="0" errors="0"></testsuite>`,
+			&hub.TestResults{Tool: "junit", Passed: 5},
+			nil,
+		},
+	}
+	for _, tc := range testCases {
+		tr, err := Parse([]byte(tc.data))
+		assert.Equal(t, tc.expectedErr, err)
+		assert.Equal(t, tc.expectedTR, tr)
+	}
+
+	t.Run("document too big", func(t *testing.T) {
+		data := `{"tool": "` + strings.Repeat("a", MaxSize) + `"}`
+		_, err := Parse([]byte(data))
+		assert.Equal(t, ErrInvalidDocument, err)
+	})
+}
@@ -0,0 +1,19 @@
+package tests
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// TXTResolverMock is a mock TXTResolver implementation.
+type TXTResolverMock struct {
+	mock.Mock
+}
+
+// LookupTXT implements the TXTResolver interface.
+func (m *TXTResolverMock) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	args := m.Called(ctx, name)
+	records, _ := args.Get(0).([]string)
+	return records, args.Error(1)
+}
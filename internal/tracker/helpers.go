@@ -4,15 +4,25 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/tracker/source/container"
+	"github.com/artifacthub/hub/internal/tracker/source/crossplane"
+	"github.com/artifacthub/hub/internal/tracker/source/devcontainer"
 	"github.com/artifacthub/hub/internal/tracker/source/falco"
+	"github.com/artifacthub/hub/internal/tracker/source/gatekeeper"
 	"github.com/artifacthub/hub/internal/tracker/source/generic"
 	"github.com/artifacthub/hub/internal/tracker/source/helm"
 	"github.com/artifacthub/hub/internal/tracker/source/helmplugin"
 	"github.com/artifacthub/hub/internal/tracker/source/krew"
+	"github.com/artifacthub/hub/internal/tracker/source/kustomize"
 	"github.com/artifacthub/hub/internal/tracker/source/olm"
 	"github.com/artifacthub/hub/internal/tracker/source/tekton"
+	"github.com/artifacthub/hub/internal/tracker/source/terraform"
+	"github.com/artifacthub/hub/internal/tracker/source/wasm"
+	"github.com/artifacthub/hub/internal/util"
 	"github.com/spf13/viper"
 )
 
@@ -29,7 +39,11 @@ const (
 //   kinds will be returned.
 // - Otherwise, all the repositories will be returned.
 //
-// NOTE: disabled repositories will be filtered out.
+// NOTE: disabled repositories, the ones whose tracking has been paused, the
+// ones tracked too recently based on the tracker minimum interval quota, as
+// well as the ones whose tracking schedule hasn't been activated since they
+// were last tracked, will be filtered out. Repositories with a pending
+// tracking request (i.e. received via webhook) ignore their tracking schedule.
 func GetRepositories(
 	ctx context.Context,
 	cfg *viper.Viper,
@@ -75,10 +89,10 @@ func GetRepositories(
 		repos = result.Repositories
 	}
 
-	// Filter out disabled repositories
+	// Filter out the repositories that shouldn't be tracked now
 	var reposFiltered []*hub.Repository
 	for _, repo := range repos {
-		if !repo.Disabled {
+		if shouldTrack(cfg, repo, time.Now()) {
 			reposFiltered = append(reposFiltered, repo)
 		}
 	}
@@ -86,6 +100,44 @@ func GetRepositories(
 	return reposFiltered, nil
 }
 
+// shouldTrack checks if the repository provided should be tracked at the given
+// time. Disabled, paused or archived repositories are not tracked, as well as
+// the ones tracked more recently than allowed by the tracker minimum interval
+// quota (organizations may have it overridden, otherwise the one in the
+// configuration applies). Repositories whose tracking schedule isn't due are
+// only tracked when they have a pending tracking request.
+func shouldTrack(cfg *viper.Viper, repo *hub.Repository, now time.Time) bool {
+	if repo.Disabled || repo.TrackingPaused || repo.Archived {
+		return false
+	}
+	minInterval := cfg.GetInt("quotas.trackerMinInterval")
+	if repo.TrackerMinInterval > 0 {
+		minInterval = repo.TrackerMinInterval
+	}
+	if minInterval > 0 && repo.LastTrackingTS > 0 {
+		lastTracking := time.Unix(repo.LastTrackingTS, 0)
+		if now.Sub(lastTracking) < time.Duration(minInterval)*time.Minute {
+			return false
+		}
+	}
+	return repo.TrackingRequested || isTrackingScheduleDue(repo, now)
+}
+
+// isTrackingScheduleDue checks if the repository provided should be tracked at
+// the given time based on its tracking schedule. Repositories without a valid
+// schedule, or not tracked yet, are always due.
+func isTrackingScheduleDue(r *hub.Repository, now time.Time) bool {
+	if r.TrackingSchedule == "" || r.LastTrackingTS == 0 {
+		return true
+	}
+	s, err := util.ParseCronSchedule(r.TrackingSchedule)
+	if err != nil {
+		return true
+	}
+	next := s.Next(time.Unix(r.LastTrackingTS, 0).UTC())
+	return !next.IsZero() && !next.After(now)
+}
+
 // SetupSource returns the tracker source that should be used for the
 // repository provided.
 func SetupSource(i *hub.TrackerSourceInput) hub.TrackerSource {
@@ -101,22 +153,91 @@ func SetupSource(i *hub.TrackerSourceInput) hub.TrackerSource {
 		} else {
 			source = generic.NewTrackerSource(i)
 		}
+	case hub.Gatekeeper:
+		source = gatekeeper.NewTrackerSource(i)
 	case hub.Helm:
 		source = helm.NewTrackerSource(i)
 	case hub.HelmPlugin:
 		source = helmplugin.NewTrackerSource(i)
 	case hub.Krew:
 		source = krew.NewTrackerSource(i)
+	case hub.Kustomize:
+		source = kustomize.NewTrackerSource(i)
 	case hub.OLM:
 		source = olm.NewTrackerSource(i)
-	case hub.OPA, hub.TBAction, hub.KedaScaler, hub.CoreDNS, hub.Keptn:
+	case
+		hub.OPA,
+		hub.TBAction,
+		hub.KedaScaler,
+		hub.CoreDNS,
+		hub.Keptn,
+		hub.Kyverno,
+		hub.ArgoWorkflowTemplate,
+		hub.Backstage,
+		hub.Headlamp,
+		hub.Knative:
 		source = generic.NewTrackerSource(i)
 	case hub.TektonTask:
 		source = tekton.NewTrackerSource(i)
+	case hub.Terraform:
+		source = terraform.NewTrackerSource(i)
+	case hub.Crossplane:
+		source = crossplane.NewTrackerSource(i)
+	case hub.Wasm:
+		source = wasm.NewTrackerSource(i)
+	case hub.Devcontainer:
+		source = devcontainer.NewTrackerSource(i)
+	case hub.Container:
+		source = container.NewTrackerSource(i)
 	}
 	return source
 }
 
+// GenerateMissingIdenticons sets an identicon as the logo of up to limit
+// packages whose latest version has no logo, returning the number of packages
+// updated. This allows packages whose latest version was registered before
+// identicons were enabled to get one without being registered again.
+func GenerateMissingIdenticons(
+	ctx context.Context,
+	pm hub.PackageManager,
+	is img.Store,
+	limit int,
+) (int, error) {
+	snapshots, err := pm.GetSnapshotsWithoutLogo(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("error getting snapshots without logo: %w", err)
+	}
+	var generated int
+	for _, s := range snapshots {
+		// Return ASAP if context is cancelled
+		if err := ctx.Err(); err != nil {
+			return generated, err
+		}
+
+		logoImageID, err := generateIdenticon(ctx, is, s.RepositoryID, s.PackageName)
+		if err != nil {
+			return generated, fmt.Errorf("error generating identicon for package %s: %w", s.PackageName, err)
+		}
+		if err := pm.SetSnapshotGeneratedLogo(ctx, s.PackageID, s.Version, logoImageID); err != nil {
+			return generated, fmt.Errorf("error setting logo of package %s: %w", s.PackageName, err)
+		}
+		generated++
+	}
+	return generated, nil
+}
+
+// generateIdenticon generates the identicon of the package provided and
+// stores it, returning the id of the image. Identicons are seeded by the
+// repository id and the package name, so all the versions of a package share
+// the same image.
+func generateIdenticon(ctx context.Context, is img.Store, repositoryID, pkgName string) (string, error) {
+	data, err := img.GenerateIdenticon(repositoryID + "/" + pkgName)
+	if err != nil {
+		return "", err
+	}
+	return is.SaveImage(ctx, data)
+}
+
 // setVerifiedPublisherFlag sets the repository verified publisher flag for the
 // repository provided when needed.
 func setVerifiedPublisherFlag(
@@ -140,6 +261,21 @@ func setVerifiedPublisherFlag(
 	return nil
 }
 
+// applyPackageOverrides applies to the package provided the overrides defined
+// for it in the repository metadata, if any. Only the details stored in the
+// package version are overridden here, the rest of them are set at the
+// package level once the tracking is done.
+func applyPackageOverrides(md *hub.RepositoryMetadata, p *hub.Package) {
+	if md == nil {
+		return
+	}
+	for _, o := range md.Packages {
+		if o.Name == p.Name && o.DisplayName != "" {
+			p.DisplayName = o.DisplayName
+		}
+	}
+}
+
 // shouldIgnorePackage checks if the package provided should be ignored.
 func shouldIgnorePackage(md *hub.RepositoryMetadata, name, version string) bool {
 	if md == nil {
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/spf13/viper"
@@ -165,6 +168,179 @@ func TestGetRepositories(t *testing.T) {
 		assert.ElementsMatch(t, []*hub.Repository{repo1, repo2}, repos) // repo3 is disabled
 		rm.AssertExpectations(t)
 	})
+
+	t.Run("repositories whose tracking has been paused are filtered out", func(t *testing.T) {
+		t.Parallel()
+		repo4 := &hub.Repository{
+			Name:           "repo4",
+			Kind:           hub.Helm,
+			TrackingPaused: true,
+		}
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("Search", ctx, &hub.SearchRepositoryInput{
+			IncludeCredentials: true,
+		}).Return(&hub.SearchRepositoryResult{
+			Repositories: []*hub.Repository{repo1, repo2, repo4},
+		}, nil)
+
+		// Run test and check expectations
+		cfg := viper.New()
+		repos, err := GetRepositories(ctx, cfg, rm)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []*hub.Repository{repo1, repo2}, repos)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("archived repositories are filtered out", func(t *testing.T) {
+		t.Parallel()
+		repo4 := &hub.Repository{
+			Name:     "repo4",
+			Kind:     hub.Helm,
+			Archived: true,
+		}
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("Search", ctx, &hub.SearchRepositoryInput{
+			IncludeCredentials: true,
+		}).Return(&hub.SearchRepositoryResult{
+			Repositories: []*hub.Repository{repo1, repo2, repo4},
+		}, nil)
+
+		// Run test and check expectations
+		cfg := viper.New()
+		repos, err := GetRepositories(ctx, cfg, rm)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []*hub.Repository{repo1, repo2}, repos)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("repositories tracked too recently are filtered out", func(t *testing.T) {
+		t.Parallel()
+		recentTS := time.Now().Add(-10 * time.Minute).Unix()
+		repo4 := &hub.Repository{
+			Name:           "repo4",
+			Kind:           hub.Helm,
+			LastTrackingTS: recentTS,
+		}
+		repo5 := &hub.Repository{
+			Name:               "repo5",
+			Kind:               hub.Helm,
+			LastTrackingTS:     recentTS,
+			TrackerMinInterval: 5,
+		}
+		repo6 := &hub.Repository{
+			Name:               "repo6",
+			Kind:               hub.Helm,
+			LastTrackingTS:     recentTS,
+			TrackerMinInterval: 60,
+		}
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("Search", ctx, &hub.SearchRepositoryInput{
+			IncludeCredentials: true,
+		}).Return(&hub.SearchRepositoryResult{
+			Repositories: []*hub.Repository{repo1, repo4, repo5, repo6},
+		}, nil)
+
+		// Run test and check expectations
+		cfg := viper.New()
+		cfg.Set("quotas.trackerMinInterval", 30)
+		repos, err := GetRepositories(ctx, cfg, rm)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []*hub.Repository{repo1, repo5}, repos)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("repositories whose tracking schedule is not due are filtered out", func(t *testing.T) {
+		t.Parallel()
+		repo7 := &hub.Repository{
+			Name:             "repo7",
+			Kind:             hub.Helm,
+			LastTrackingTS:   time.Now().Add(-2 * time.Hour).Unix(),
+			TrackingSchedule: "@hourly",
+		}
+		repo8 := &hub.Repository{
+			Name:             "repo8",
+			Kind:             hub.Helm,
+			LastTrackingTS:   time.Now().Unix(),
+			TrackingSchedule: "@hourly",
+		}
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("Search", ctx, &hub.SearchRepositoryInput{
+			IncludeCredentials: true,
+		}).Return(&hub.SearchRepositoryResult{
+			Repositories: []*hub.Repository{repo1, repo7, repo8},
+		}, nil)
+
+		// Run test and check expectations
+		cfg := viper.New()
+		repos, err := GetRepositories(ctx, cfg, rm)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []*hub.Repository{repo1, repo7}, repos)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("repositories with a pending tracking request ignore their schedule", func(t *testing.T) {
+		t.Parallel()
+		repo9 := &hub.Repository{
+			Name:              "repo9",
+			Kind:              hub.Helm,
+			LastTrackingTS:    time.Now().Add(-1 * time.Minute).Unix(),
+			TrackingSchedule:  "@daily",
+			TrackingRequested: true,
+		}
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("Search", ctx, &hub.SearchRepositoryInput{
+			IncludeCredentials: true,
+		}).Return(&hub.SearchRepositoryResult{
+			Repositories: []*hub.Repository{repo1, repo9},
+		}, nil)
+
+		// Run test and check expectations
+		cfg := viper.New()
+		repos, err := GetRepositories(ctx, cfg, rm)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []*hub.Repository{repo1, repo9}, repos)
+		rm.AssertExpectations(t)
+	})
+}
+
+func TestIsTrackingScheduleDue(t *testing.T) {
+	now := time.Date(2021, 3, 15, 10, 20, 0, 0, time.UTC)
+	testCases := []struct {
+		schedule       string
+		lastTrackingTS int64
+		expectedResult bool
+	}{
+		{"", now.Add(-1 * time.Minute).Unix(), true},
+		{"@hourly", 0, true},
+		{"invalid", now.Add(-1 * time.Minute).Unix(), true},
+		{"@hourly", now.Add(-30 * time.Minute).Unix(), true},
+		{"@hourly", now.Add(-21 * time.Minute).Unix(), true},
+		{"@hourly", now.Add(-19 * time.Minute).Unix(), false},
+		{"0 */6 * * *", now.Add(-2 * time.Hour).Unix(), false},
+		{"0 */6 * * *", now.Add(-5 * time.Hour).Unix(), true},
+		{"0 0 30 2 *", now.Add(-24 * time.Hour).Unix(), false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.schedule, func(t *testing.T) {
+			t.Parallel()
+			r := &hub.Repository{
+				TrackingSchedule: tc.schedule,
+				LastTrackingTS:   tc.lastTrackingTS,
+			}
+			assert.Equal(t, tc.expectedResult, isTrackingScheduleDue(r, now))
+		})
+	}
 }
 
 func TestSetupSource(t *testing.T) {
@@ -235,6 +411,7 @@ func TestSetupSource(t *testing.T) {
 
 			i := &hub.TrackerSourceInput{
 				Repository: tc.r,
+				Svc:        &hub.TrackerSourceServices{},
 			}
 			source := SetupSource(i)
 			assert.Equal(t, tc.expectedType, reflect.TypeOf(source).String())
@@ -242,6 +419,83 @@ func TestSetupSource(t *testing.T) {
 	}
 }
 
+func TestGenerateMissingIdenticons(t *testing.T) {
+	ctx := context.Background()
+	s1 := &hub.SnapshotWithoutLogo{
+		RepositoryID: "00000000-0000-0000-0000-000000000001",
+		PackageID:    "00000000-0000-0000-0000-000000000001",
+		PackageName:  "pkg1",
+		Version:      "1.0.0",
+	}
+	s2 := &hub.SnapshotWithoutLogo{
+		RepositoryID: "00000000-0000-0000-0000-000000000001",
+		PackageID:    "00000000-0000-0000-0000-000000000002",
+		PackageName:  "pkg2",
+		Version:      "2.0.0",
+	}
+	identicon1, _ := img.GenerateIdenticon(s1.RepositoryID + "/" + s1.PackageName)
+	identicon2, _ := img.GenerateIdenticon(s2.RepositoryID + "/" + s2.PackageName)
+
+	t.Run("error getting snapshots without logo", func(t *testing.T) {
+		t.Parallel()
+		pm := &pkg.ManagerMock{}
+		pm.On("GetSnapshotsWithoutLogo", ctx, 10).Return(nil, tests.ErrFake)
+		is := &img.StoreMock{}
+
+		generated, err := GenerateMissingIdenticons(ctx, pm, is, 10)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		assert.Equal(t, 0, generated)
+		pm.AssertExpectations(t)
+		is.AssertExpectations(t)
+	})
+
+	t.Run("error saving identicon", func(t *testing.T) {
+		t.Parallel()
+		pm := &pkg.ManagerMock{}
+		pm.On("GetSnapshotsWithoutLogo", ctx, 10).Return([]*hub.SnapshotWithoutLogo{s1, s2}, nil)
+		is := &img.StoreMock{}
+		is.On("SaveImage", ctx, identicon1).Return("", tests.ErrFake)
+
+		generated, err := GenerateMissingIdenticons(ctx, pm, is, 10)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		assert.Equal(t, 0, generated)
+		pm.AssertExpectations(t)
+		is.AssertExpectations(t)
+	})
+
+	t.Run("error setting generated logo", func(t *testing.T) {
+		t.Parallel()
+		pm := &pkg.ManagerMock{}
+		pm.On("GetSnapshotsWithoutLogo", ctx, 10).Return([]*hub.SnapshotWithoutLogo{s1, s2}, nil)
+		pm.On("SetSnapshotGeneratedLogo", ctx, s1.PackageID, s1.Version, "imageID1").Return(tests.ErrFake)
+		is := &img.StoreMock{}
+		is.On("SaveImage", ctx, identicon1).Return("imageID1", nil)
+
+		generated, err := GenerateMissingIdenticons(ctx, pm, is, 10)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		assert.Equal(t, 0, generated)
+		pm.AssertExpectations(t)
+		is.AssertExpectations(t)
+	})
+
+	t.Run("identicons generated successfully", func(t *testing.T) {
+		t.Parallel()
+		pm := &pkg.ManagerMock{}
+		pm.On("GetSnapshotsWithoutLogo", ctx, 10).Return([]*hub.SnapshotWithoutLogo{s1, s2}, nil)
+		pm.On("SetSnapshotGeneratedLogo", ctx, s1.PackageID, s1.Version, "imageID1").Return(nil)
+		pm.On("SetSnapshotGeneratedLogo", ctx, s2.PackageID, s2.Version, "imageID2").Return(nil)
+		is := &img.StoreMock{}
+		is.On("SaveImage", ctx, identicon1).Return("imageID1", nil)
+		is.On("SaveImage", ctx, identicon2).Return("imageID2", nil)
+
+		generated, err := GenerateMissingIdenticons(ctx, pm, is, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, generated)
+		pm.AssertExpectations(t)
+		is.AssertExpectations(t)
+	})
+}
+
 func TestSetVerifiedPublisherFlag(t *testing.T) {
 	ctx := context.Background()
 
@@ -341,6 +595,28 @@ func TestSetVerifiedPublisherFlag(t *testing.T) {
 	})
 }
 
+func TestApplyPackageOverrides(t *testing.T) {
+	md := &hub.RepositoryMetadata{
+		Version: hub.RepositoryMetadataV2,
+		Packages: []*hub.RepositoryPackageOverride{
+			{Name: "pkg1", DisplayName: "Package 1"},
+			{Name: "pkg2", Category: "database"},
+		},
+	}
+
+	p1 := &hub.Package{Name: "pkg1", DisplayName: "pkg1"}
+	applyPackageOverrides(md, p1)
+	assert.Equal(t, "Package 1", p1.DisplayName)
+
+	p2 := &hub.Package{Name: "pkg2", DisplayName: "pkg2"}
+	applyPackageOverrides(md, p2)
+	assert.Equal(t, "pkg2", p2.DisplayName)
+
+	p3 := &hub.Package{Name: "pkg3", DisplayName: "pkg3"}
+	applyPackageOverrides(nil, p3)
+	assert.Equal(t, "pkg3", p3.DisplayName)
+}
+
 func TestShouldIgnorePackage(t *testing.T) {
 	testCases := []struct {
 		md             *hub.RepositoryMetadata
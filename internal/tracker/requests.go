@@ -0,0 +1,124 @@
+package tracker
+
+import (
+	"context"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// TrackingRequestsChannel represents the database notifications channel
+	// used to announce that the tracking of a repository has been requested.
+	// The notifications payload is the id of the repository.
+	TrackingRequestsChannel = "repository_tracking_requested"
+
+	pauseOnError = 10 * time.Second
+)
+
+// ListenForTrackingRequests tracks the repositories whose tracking has been
+// requested (i.e. via webhook) as soon as the database notifies the request,
+// using the track function provided. Repositories that already had a pending
+// request when the listener starts (or reconnects to the database) are
+// tracked first. Requests are processed one at a time, and this function
+// blocks until the context provided is cancelled.
+func ListenForTrackingRequests(
+	ctx context.Context,
+	cfg *viper.Viper,
+	db hub.DB,
+	rm hub.RepositoryManager,
+	track func(r *hub.Repository),
+) {
+	for {
+		conn, err := db.Acquire(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("error acquiring database connection")
+			if !sleep(ctx, pauseOnError) {
+				return
+			}
+			continue
+		}
+		if _, err := conn.Exec(ctx, "listen "+TrackingRequestsChannel); err != nil {
+			conn.Release()
+			log.Error().Err(err).Msg("error listening to notifications channel")
+			if !sleep(ctx, pauseOnError) {
+				return
+			}
+			continue
+		}
+		trackPendingRequests(ctx, cfg, rm, track)
+		for {
+			n, err := conn.Conn().WaitForNotification(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Error().Err(err).Msg("error waiting for notification")
+				}
+				break
+			}
+			processTrackingRequest(ctx, cfg, rm, n.Payload, track)
+		}
+		conn.Release()
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// trackPendingRequests tracks the repositories that have a pending tracking
+// request, which may have been received while no listener was running.
+func trackPendingRequests(
+	ctx context.Context,
+	cfg *viper.Viper,
+	rm hub.RepositoryManager,
+	track func(r *hub.Repository),
+) {
+	result, err := rm.Search(ctx, &hub.SearchRepositoryInput{
+		IncludeCredentials: true,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error getting repositories")
+		return
+	}
+	for _, r := range result.Repositories {
+		if ctx.Err() != nil {
+			return
+		}
+		if r.TrackingRequested && shouldTrack(cfg, r, time.Now()) {
+			track(r)
+		}
+	}
+}
+
+// processTrackingRequest tracks the repository identified by the id provided
+// if its tracking request is still pending and it should be tracked now. The
+// request is no longer pending when several notifications are received for a
+// repository and it has already been tracked.
+func processTrackingRequest(
+	ctx context.Context,
+	cfg *viper.Viper,
+	rm hub.RepositoryManager,
+	repositoryID string,
+	track func(r *hub.Repository),
+) {
+	r, err := rm.GetByID(ctx, repositoryID, true)
+	if err != nil {
+		log.Error().Err(err).Str("repoID", repositoryID).Msg("error getting repository")
+		return
+	}
+	if r.TrackingRequested && shouldTrack(cfg, r, time.Now()) {
+		track(r)
+	}
+}
+
+// sleep pauses the current goroutine for the duration provided or until the
+// context is cancelled. It returns false when the context has been cancelled.
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,110 @@
+package tracker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackPendingRequests(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("error getting repositories", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("Search", ctx, &hub.SearchRepositoryInput{
+			IncludeCredentials: true,
+		}).Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		var tracked []*hub.Repository
+		trackPendingRequests(ctx, viper.New(), rm, func(r *hub.Repository) {
+			tracked = append(tracked, r)
+		})
+		assert.Empty(t, tracked)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("only repositories with a pending request are tracked", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup expectations
+		repo1 := &hub.Repository{Name: "repo1", TrackingRequested: true}
+		repo2 := &hub.Repository{Name: "repo2"}
+		repo3 := &hub.Repository{Name: "repo3", TrackingRequested: true, TrackingPaused: true}
+		rm := &repo.ManagerMock{}
+		rm.On("Search", ctx, &hub.SearchRepositoryInput{
+			IncludeCredentials: true,
+		}).Return(&hub.SearchRepositoryResult{
+			Repositories: []*hub.Repository{repo1, repo2, repo3},
+		}, nil)
+
+		// Run test and check expectations
+		var tracked []*hub.Repository
+		trackPendingRequests(ctx, viper.New(), rm, func(r *hub.Repository) {
+			tracked = append(tracked, r)
+		})
+		assert.Equal(t, []*hub.Repository{repo1}, tracked)
+		rm.AssertExpectations(t)
+	})
+}
+
+func TestProcessTrackingRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("error getting repository", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("GetByID", ctx, "repo1ID", true).Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		var tracked []*hub.Repository
+		processTrackingRequest(ctx, viper.New(), rm, "repo1ID", func(r *hub.Repository) {
+			tracked = append(tracked, r)
+		})
+		assert.Empty(t, tracked)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("repository without a pending request is not tracked", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("GetByID", ctx, "repo1ID", true).Return(&hub.Repository{Name: "repo1"}, nil)
+
+		// Run test and check expectations
+		var tracked []*hub.Repository
+		processTrackingRequest(ctx, viper.New(), rm, "repo1ID", func(r *hub.Repository) {
+			tracked = append(tracked, r)
+		})
+		assert.Empty(t, tracked)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("repository with a pending request is tracked", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup expectations
+		repo1 := &hub.Repository{Name: "repo1", TrackingRequested: true}
+		rm := &repo.ManagerMock{}
+		rm.On("GetByID", ctx, "repo1ID", true).Return(repo1, nil)
+
+		// Run test and check expectations
+		var tracked []*hub.Repository
+		processTrackingRequest(ctx, viper.New(), rm, "repo1ID", func(r *hub.Repository) {
+			tracked = append(tracked, r)
+		})
+		assert.Equal(t, []*hub.Repository{repo1}, tracked)
+		rm.AssertExpectations(t)
+	})
+}
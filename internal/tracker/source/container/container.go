@@ -0,0 +1,209 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+)
+
+const (
+	// Annotations
+	descriptionAnnotation   = "org.opencontainers.image.description"
+	documentationAnnotation = "org.opencontainers.image.documentation"
+	licensesAnnotation      = "org.opencontainers.image.licenses"
+	readmeURLAnnotation     = "io.artifacthub.package.readme-url"
+	sourceAnnotation        = "org.opencontainers.image.source"
+	titleAnnotation         = "org.opencontainers.image.title"
+	urlAnnotation           = "org.opencontainers.image.url"
+	vendorAnnotation        = "org.opencontainers.image.vendor"
+)
+
+// TrackerSource is a hub.TrackerSource implementation for container images
+// stored in OCI registries.
+type TrackerSource struct {
+	i  *hub.TrackerSourceInput
+	tg hub.OCITagsGetter
+	dg hub.ContainerImageDetailsGetter
+}
+
+// NewTrackerSource creates a new TrackerSource instance.
+func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource)) *TrackerSource {
+	s := &TrackerSource{i: i}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.tg == nil {
+		s.tg = &repo.OCITagsGetter{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	if s.dg == nil {
+		s.dg = &repo.OCIContainerImageDetailsGetter{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	return s
+}
+
+// GetPackagesAvailable implements the TrackerSource interface.
+func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Get versions available (semver tags)
+	tags, err := s.tg.Tags(s.i.Svc.Ctx, s.i.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("error getting repository tags: %w", err)
+	}
+
+	// Prepare a package version for each of the versions available
+	bypassDigestCheck := s.i.Svc.Cfg.GetBool("tracker.bypassDigestCheck")
+	for _, tag := range tags {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			return nil, s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		// Images versions are not expected to change once published, so the
+		// ones already registered are not processed again
+		ref := fmt.Sprintf("%s:%s", s.i.Repository.URL, tag)
+		if p := s.getRegisteredPackage(tag); p != nil && !bypassDigestCheck {
+			packagesAvailable[pkg.BuildKey(p)] = p
+			continue
+		}
+
+		// Get image details and prepare package version
+		d, err := s.dg.Get(s.i.Svc.Ctx, s.i.Repository, ref)
+		if err != nil {
+			s.warn(fmt.Errorf("error getting image details %s: %w", ref, err))
+			continue
+		}
+		p := s.preparePackage(d, ref, tag)
+		packagesAvailable[pkg.BuildKey(p)] = p
+	}
+
+	return packagesAvailable, nil
+}
+
+// getRegisteredPackage returns a minimal version of the package registered
+// for the tag provided, if any. The package name is the same for all the
+// versions available in the repository.
+func (s *TrackerSource) getRegisteredPackage(tag string) *hub.Package {
+	for key, digest := range s.i.PackagesRegistered {
+		i := strings.LastIndex(key, "@")
+		if i == -1 || key[i+1:] != strings.TrimPrefix(tag, "v") {
+			continue
+		}
+		return &hub.Package{
+			Name:       key[:i],
+			Version:    key[i+1:],
+			Digest:     digest,
+			Repository: s.i.Repository,
+		}
+	}
+	return nil
+}
+
+// warn is a helper that sends the error provided to the errors collector and
+// logs it as a warning.
+func (s *TrackerSource) warn(err error) {
+	s.i.Svc.Logger.Warn().Err(err).Send()
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+}
+
+// preparePackage prepares a package version from the container image details
+// provided. The package name is the name of the repository in the registry.
+func (s *TrackerSource) preparePackage(d *hub.ContainerImageDetails, ref, tag string) *hub.Package {
+	// Prepare package from image annotations
+	a := d.Annotations
+	name := path.Base(strings.TrimPrefix(s.i.Repository.URL, hub.RepositoryOCIPrefix))
+	p := &hub.Package{
+		Name:        name,
+		Version:     strings.TrimPrefix(tag, "v"),
+		DisplayName: a[titleAnnotation],
+		Description: a[descriptionAnnotation],
+		HomeURL:     a[urlAnnotation],
+		License:     a[licensesAnnotation],
+		Provider:    a[vendorAnnotation],
+		Digest:      d.Digest,
+		ContentURL:  ref,
+		Repository:  s.i.Repository,
+		Keywords:    []string{"container", "image"},
+		ContainersImages: []*hub.ContainerImage{
+			{
+				Name:   name,
+				Image:  strings.TrimPrefix(ref, hub.RepositoryOCIPrefix),
+				Digest: d.Digest,
+			},
+		},
+	}
+	for _, link := range []struct{ name, annotation string }{
+		{"source", sourceAnnotation},
+		{"documentation", documentationAnnotation},
+	} {
+		if u := a[link.annotation]; u != "" {
+			p.Links = append(p.Links, &hub.Link{Name: link.name, URL: u})
+		}
+	}
+	if len(d.Platforms) > 0 {
+		p.Data = map[string]interface{}{
+			"platforms": d.Platforms,
+		}
+	}
+
+	// Readme (the one attached to the image takes precedence)
+	switch {
+	case len(d.Readme) > 0:
+		p.Readme = string(d.Readme)
+	case a[readmeURLAnnotation] != "":
+		readme, err := s.getReadme(a[readmeURLAnnotation])
+		if err != nil {
+			s.warn(fmt.Errorf("error getting readme %s: %w", ref, err))
+		}
+		p.Readme = string(readme)
+	}
+	if p.Readme == "" {
+		p.Readme = p.Description
+	}
+
+	return p
+}
+
+// getReadme downloads the readme file located at the url provided (set in
+// the readme-url annotation).
+func (s *TrackerSource) getReadme(readmeURL string) ([]byte, error) {
+	u, err := url.Parse(readmeURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid readme url: %s", readmeURL)
+	}
+	req, _ := http.NewRequest("GET", u.String(), nil)
+	req = req.WithContext(s.i.Svc.Ctx)
+	if s.i.Svc.Rl != nil {
+		release, err := s.i.Svc.Rl.Acquire(s.i.Svc.Ctx, s.i.Repository.Name, req.URL.Host)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	resp, err := s.i.Svc.Hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, repo.ContainerReadmeMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading readme: %w", err)
+	}
+	if len(data) > repo.ContainerReadmeMaxSize {
+		return nil, errors.New("readme too big")
+	}
+	return data, nil
+}
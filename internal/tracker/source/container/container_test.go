@@ -0,0 +1,220 @@
+package container
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerSource(t *testing.T) {
+	r := &hub.Repository{
+		Kind: hub.Container,
+		URL:  "oci://registry.url/org1/image1",
+	}
+
+	t.Run("error getting repository tags", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return(nil, tests.ErrFake)
+		dg := &repo.ContainerImageDetailsGetterMock{}
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withDetailsGetter(dg)).GetPackagesAvailable()
+		assert.Nil(t, packages)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		tg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("error getting image details", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+		ref := "oci://registry.url/org1/image1:1.0.0"
+		dg := &repo.ContainerImageDetailsGetterMock{}
+		dg.On("Get", i.Svc.Ctx, i.Repository, ref).Return(nil, tests.ErrFake)
+		expectedErr := "error getting image details oci://registry.url/org1/image1:1.0.0: fake error for tests"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withDetailsGetter(dg)).GetPackagesAvailable()
+		require.NoError(t, err)
+		assert.Empty(t, packages)
+		tg.AssertExpectations(t)
+		dg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("packages returned, registered versions are not processed again", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: r,
+			PackagesRegistered: map[string]string{
+				"image1@1.0.0": "sha256:100",
+			},
+			Svc: sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0", "v1.1.0"}, nil)
+		ref := "oci://registry.url/org1/image1:v1.1.0"
+		dg := &repo.ContainerImageDetailsGetterMock{}
+		dg.On("Get", i.Svc.Ctx, i.Repository, ref).Return(&hub.ContainerImageDetails{
+			Digest: "sha256:110",
+			Annotations: map[string]string{
+				titleAnnotation:         "Image 1",
+				descriptionAnnotation:   "Image 1 description",
+				licensesAnnotation:      "Apache-2.0",
+				urlAnnotation:           "https://image1.url",
+				sourceAnnotation:        "https://github.com/org1/image1",
+				documentationAnnotation: "https://image1.url/docs",
+				vendorAnnotation:        "Org1",
+			},
+			Platforms: []string{"linux/amd64", "linux/arm64/v8"},
+			Readme:    []byte("# Image 1"),
+		}, nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withDetailsGetter(dg)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 2)
+		v1 := packages["image1@1.0.0"]
+		require.NotNil(t, v1)
+		assert.Equal(t, "sha256:100", v1.Digest)
+		assert.Nil(t, v1.ContainersImages)
+		assert.Equal(t, &hub.Package{
+			Name:        "image1",
+			Version:     "1.1.0",
+			DisplayName: "Image 1",
+			Description: "Image 1 description",
+			Readme:      "# Image 1",
+			HomeURL:     "https://image1.url",
+			License:     "Apache-2.0",
+			Provider:    "Org1",
+			Digest:      "sha256:110",
+			ContentURL:  ref,
+			Keywords:    []string{"container", "image"},
+			Links: []*hub.Link{
+				{
+					Name: "source",
+					URL:  "https://github.com/org1/image1",
+				},
+				{
+					Name: "documentation",
+					URL:  "https://image1.url/docs",
+				},
+			},
+			ContainersImages: []*hub.ContainerImage{
+				{
+					Name:   "image1",
+					Image:  "registry.url/org1/image1:v1.1.0",
+					Digest: "sha256:110",
+				},
+			},
+			Data: map[string]interface{}{
+				"platforms": []string{"linux/amd64", "linux/arm64/v8"},
+			},
+			Repository: r,
+		}, packages["image1@1.1.0"])
+		tg.AssertExpectations(t)
+		dg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("readme fetched from the url provided in the annotations", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+		ref := "oci://registry.url/org1/image1:1.0.0"
+		dg := &repo.ContainerImageDetailsGetterMock{}
+		dg.On("Get", i.Svc.Ctx, i.Repository, ref).Return(&hub.ContainerImageDetails{
+			Digest: "sha256:100",
+			Annotations: map[string]string{
+				readmeURLAnnotation: "https://image1.url/README.md",
+			},
+		}, nil)
+		sw.Hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte("# Image 1"))),
+			StatusCode: http.StatusOK,
+		}, nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withDetailsGetter(dg)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		assert.Equal(t, "# Image 1", packages["image1@1.0.0"].Readme)
+		tg.AssertExpectations(t)
+		dg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("error fetching readme, description used instead", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+		ref := "oci://registry.url/org1/image1:1.0.0"
+		dg := &repo.ContainerImageDetailsGetterMock{}
+		dg.On("Get", i.Svc.Ctx, i.Repository, ref).Return(&hub.ContainerImageDetails{
+			Digest: "sha256:100",
+			Annotations: map[string]string{
+				descriptionAnnotation: "Image 1 description",
+				readmeURLAnnotation:   "https://image1.url/README.md",
+			},
+		}, nil)
+		sw.Hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+			StatusCode: http.StatusNotFound,
+		}, nil)
+		expectedErr := "error getting readme oci://registry.url/org1/image1:1.0.0: unexpected status code received: 404"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withDetailsGetter(dg)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		assert.Equal(t, "Image 1 description", packages["image1@1.0.0"].Readme)
+		tg.AssertExpectations(t)
+		dg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+}
+
+func withOCITagsGetter(tg hub.OCITagsGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.tg = tg
+	}
+}
+
+func withDetailsGetter(dg hub.ContainerImageDetailsGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.dg = dg
+	}
+}
@@ -0,0 +1,336 @@
+package crossplane
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Annotations
+	descriptionAnnotation  = "meta.crossplane.io/description"
+	friendlyNameAnnotation = "friendly-name.meta.crossplane.io"
+	iconURIAnnotation      = "meta.crossplane.io/iconURI"
+	licenseAnnotation      = "meta.crossplane.io/license"
+	maintainerAnnotation   = "meta.crossplane.io/maintainer"
+	readmeAnnotation       = "meta.crossplane.io/readme"
+	sourceAnnotation       = "meta.crossplane.io/source"
+
+	// metaAPIGroup represents the API group used by the packages meta
+	// documents (Configuration, Provider and Function).
+	metaAPIGroup = "meta.pkg.crossplane.io"
+)
+
+// document represents the subset of the documents available in a package
+// stream used to prepare the packages: the package meta document, CRDs and
+// XRDs (CompositeResourceDefinition).
+type document struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name        string            `yaml:"name"`
+		Annotations map[string]string `yaml:"annotations"`
+	} `yaml:"metadata"`
+	Spec struct {
+		// Meta documents
+		Crossplane struct {
+			Version string `yaml:"version"`
+		} `yaml:"crossplane"`
+		DependsOn []struct {
+			Configuration string `yaml:"configuration"`
+			Function      string `yaml:"function"`
+			Provider      string `yaml:"provider"`
+			Version       string `yaml:"version"`
+		} `yaml:"dependsOn"`
+
+		// CRDs and XRDs
+		Names struct {
+			Kind string `yaml:"kind"`
+		} `yaml:"names"`
+		ClaimNames struct {
+			Kind string `yaml:"kind"`
+		} `yaml:"claimNames"`
+		Versions []struct {
+			Name          string `yaml:"name"`
+			Served        bool   `yaml:"served"`
+			Storage       bool   `yaml:"storage"`
+			Referenceable bool   `yaml:"referenceable"`
+			Schema        struct {
+				OpenAPIV3Schema struct {
+					Description string `yaml:"description"`
+				} `yaml:"openAPIV3Schema"`
+			} `yaml:"schema"`
+		} `yaml:"versions"`
+	} `yaml:"spec"`
+}
+
+// TrackerSource is a hub.TrackerSource implementation for Crossplane packages
+// (configurations, providers and functions) stored in OCI registries.
+type TrackerSource struct {
+	i  *hub.TrackerSourceInput
+	tg hub.OCITagsGetter
+	xp hub.XpkgPuller
+}
+
+// NewTrackerSource creates a new TrackerSource instance.
+func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource)) *TrackerSource {
+	s := &TrackerSource{i: i}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.tg == nil {
+		s.tg = &repo.OCITagsGetter{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	if s.xp == nil {
+		s.xp = &repo.OCIXpkgPuller{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	return s
+}
+
+// GetPackagesAvailable implements the TrackerSource interface.
+func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Get versions available (semver tags)
+	tags, err := s.tg.Tags(s.i.Svc.Ctx, s.i.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("error getting repository tags: %w", err)
+	}
+
+	// Prepare a package version for each of the versions available
+	bypassDigestCheck := s.i.Svc.Cfg.GetBool("tracker.bypassDigestCheck")
+	for _, tag := range tags {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			return nil, s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		// Packages versions are not expected to change once published, so
+		// the ones already registered are not pulled again
+		ref := fmt.Sprintf("%s:%s", s.i.Repository.URL, tag)
+		if p := s.getRegisteredPackage(tag); p != nil && !bypassDigestCheck {
+			packagesAvailable[pkg.BuildKey(p)] = p
+			continue
+		}
+
+		// Pull package and prepare package version
+		xpkg, err := s.xp.Pull(s.i.Svc.Ctx, s.i.Repository, ref)
+		if err != nil {
+			s.warn(fmt.Errorf("error pulling package %s: %w", ref, err))
+			continue
+		}
+		p, err := s.preparePackage(xpkg, ref, tag)
+		if err != nil {
+			s.warn(fmt.Errorf("error preparing package %s: %w", ref, err))
+			continue
+		}
+		packagesAvailable[pkg.BuildKey(p)] = p
+	}
+
+	return packagesAvailable, nil
+}
+
+// getRegisteredPackage returns a minimal version of the package registered
+// for the tag provided, if any. The package name is the same for all the
+// versions available in the repository.
+func (s *TrackerSource) getRegisteredPackage(tag string) *hub.Package {
+	for key, digest := range s.i.PackagesRegistered {
+		i := strings.LastIndex(key, "@")
+		if i == -1 || key[i+1:] != strings.TrimPrefix(tag, "v") {
+			continue
+		}
+		return &hub.Package{
+			Name:       key[:i],
+			Version:    key[i+1:],
+			Digest:     digest,
+			Repository: s.i.Repository,
+		}
+	}
+	return nil
+}
+
+// warn is a helper that sends the error provided to the errors collector and
+// logs it as a warning.
+func (s *TrackerSource) warn(err error) {
+	s.i.Svc.Logger.Warn().Err(err).Send()
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+}
+
+// preparePackage prepares a package version from the Crossplane package
+// content provided.
+func (s *TrackerSource) preparePackage(xpkg *hub.Xpkg, ref, tag string) (*hub.Package, error) {
+	// Parse package stream documents
+	var meta *document
+	var crds, xrds []interface{}
+	dec := yaml.NewDecoder(bytes.NewReader(xpkg.Package))
+	for {
+		var doc document
+		if err := dec.Decode(&doc); err != nil {
+			break
+		}
+		switch {
+		case strings.HasPrefix(doc.APIVersion, metaAPIGroup+"/"):
+			if meta == nil {
+				meta = &doc
+			}
+		case doc.Kind == "CustomResourceDefinition":
+			if crd := summarizeDefinition(&doc); crd != nil {
+				crds = append(crds, crd)
+			}
+		case doc.Kind == "CompositeResourceDefinition":
+			if xrd := summarizeDefinition(&doc); xrd != nil {
+				if doc.Spec.ClaimNames.Kind != "" {
+					xrd["claimKind"] = doc.Spec.ClaimNames.Kind
+				}
+				xrds = append(xrds, xrd)
+			}
+		}
+	}
+	if meta == nil || meta.Metadata.Name == "" {
+		return nil, fmt.Errorf("package meta document not found")
+	}
+
+	// Prepare package from meta document
+	a := meta.Metadata.Annotations
+	p := &hub.Package{
+		Name:        meta.Metadata.Name,
+		Version:     strings.TrimPrefix(tag, "v"),
+		DisplayName: a[friendlyNameAnnotation],
+		Description: a[descriptionAnnotation],
+		Readme:      a[readmeAnnotation],
+		HomeURL:     a[sourceAnnotation],
+		License:     a[licenseAnnotation],
+		Digest:      xpkg.Digest,
+		ContentURL:  ref,
+		Repository:  s.i.Repository,
+		Keywords:    []string{"crossplane", strings.ToLower(meta.Kind)},
+		CRDs:        crds,
+	}
+	if p.Readme == "" {
+		p.Readme = p.Description
+	}
+	if v, ok := a[maintainerAnnotation]; ok {
+		p.Maintainers = parseMaintainers(v)
+	}
+	var dependsOn []map[string]string
+	for _, d := range meta.Spec.DependsOn {
+		dep := map[string]string{"version": d.Version}
+		switch {
+		case d.Provider != "":
+			dep["kind"], dep["package"] = "Provider", d.Provider
+		case d.Configuration != "":
+			dep["kind"], dep["package"] = "Configuration", d.Configuration
+		case d.Function != "":
+			dep["kind"], dep["package"] = "Function", d.Function
+		default:
+			continue
+		}
+		dependsOn = append(dependsOn, dep)
+	}
+	p.Data = map[string]interface{}{
+		"kind":              meta.Kind,
+		"crossplaneVersion": meta.Spec.Crossplane.Version,
+		"dependsOn":         dependsOn,
+		"xrds":              xrds,
+	}
+
+	// Logo
+	if iconURI := a[iconURIAnnotation]; iconURI != "" {
+		logoImageID, err := s.i.Svc.Is.DownloadAndSaveImage(s.i.Svc.Ctx, iconURI)
+		if err == nil {
+			p.LogoURL = iconURI
+			p.LogoImageID = logoImageID
+		} else {
+			s.warn(fmt.Errorf("error getting logo image %s: %w", iconURI, err))
+		}
+	}
+
+	// Examples
+	p.CRDsExamples = getExamples(xpkg.Examples)
+
+	return p, nil
+}
+
+// summarizeDefinition returns a summary of the CRD or XRD document provided,
+// using the same format used for the CRDs of other kinds of packages. The
+// version used is the storage (or referenceable) one, falling back to the
+// first version served when it is not set.
+func summarizeDefinition(doc *document) map[string]interface{} {
+	if doc.Metadata.Name == "" || doc.Spec.Names.Kind == "" {
+		return nil
+	}
+	var version, description string
+	for _, v := range doc.Spec.Versions {
+		if v.Storage || v.Referenceable || (version == "" && v.Served) {
+			version = v.Name
+			description = v.Schema.OpenAPIV3Schema.Description
+		}
+	}
+	if version == "" {
+		return nil
+	}
+	return map[string]interface{}{
+		"kind":        doc.Spec.Names.Kind,
+		"version":     version,
+		"name":        doc.Metadata.Name,
+		"displayName": doc.Spec.Names.Kind,
+		"description": strings.TrimSpace(description),
+	}
+}
+
+// getExamples returns the objects defined in the examples files provided.
+// Files or documents that cannot be parsed are ignored.
+func getExamples(files map[string][]byte) []interface{} {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		switch path.Ext(name) {
+		case ".yaml", ".yml":
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var examples []interface{}
+	for _, name := range names {
+		dec := yaml.NewDecoder(bytes.NewReader(files[name]))
+		for {
+			var example map[string]interface{}
+			if err := dec.Decode(&example); err != nil {
+				break
+			}
+			if example["apiVersion"] == nil || example["kind"] == nil {
+				continue
+			}
+			examples = append(examples, example)
+		}
+	}
+	return examples
+}
+
+// parseMaintainers parses the maintainers provided using the format used in
+// the maintainer annotation (i.e. "Name <email>, Name2 <email2>").
+func parseMaintainers(v string) []*hub.Maintainer {
+	var maintainers []*hub.Maintainer
+	for _, entry := range strings.Split(v, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		m := &hub.Maintainer{Name: entry}
+		if i := strings.Index(entry, "<"); i != -1 && strings.HasSuffix(entry, ">") {
+			m.Name = strings.TrimSpace(entry[:i])
+			m.Email = entry[i+1 : len(entry)-1]
+		}
+		maintainers = append(maintainers, m)
+	}
+	return maintainers
+}
@@ -0,0 +1,230 @@
+package crossplane
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const packageStream = `
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: platform-ref-aws
+  annotations:
+    meta.crossplane.io/maintainer: User1 <user1@email.com>
+    meta.crossplane.io/source: github.com/org1/platform-ref-aws
+    meta.crossplane.io/license: Apache-2.0
+    meta.crossplane.io/description: AWS reference platform
+    meta.crossplane.io/iconURI: https://icon.url/logo.svg
+    friendly-name.meta.crossplane.io: Platform Ref AWS
+spec:
+  crossplane:
+    version: ">=v1.14.0"
+  dependsOn:
+    - provider: xpkg.upbound.io/upbound/provider-aws-ec2
+      version: ">=v0.40.0"
+---
+apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xclusters.aws.platformref.org
+spec:
+  names:
+    kind: XCluster
+  claimNames:
+    kind: Cluster
+  versions:
+    - name: v1alpha1
+      served: true
+      referenceable: true
+      schema:
+        openAPIV3Schema:
+          description: A Cluster is a composite resource.
+`
+
+func TestTrackerSource(t *testing.T) {
+	r := &hub.Repository{
+		Kind: hub.Crossplane,
+		URL:  "oci://registry.url/org1/platform-ref-aws",
+	}
+
+	t.Run("error getting repository tags", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return(nil, tests.ErrFake)
+		xp := &repo.XpkgPullerMock{}
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withXpkgPuller(xp)).GetPackagesAvailable()
+		assert.Nil(t, packages)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		tg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("error pulling package", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"v1.0.0"}, nil)
+		xp := &repo.XpkgPullerMock{}
+		ref := "oci://registry.url/org1/platform-ref-aws:v1.0.0"
+		xp.On("Pull", i.Svc.Ctx, i.Repository, ref).Return(nil, tests.ErrFake)
+		expectedErr := "error pulling package " + ref + ": fake error for tests"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withXpkgPuller(xp)).GetPackagesAvailable()
+		require.NoError(t, err)
+		assert.Empty(t, packages)
+		tg.AssertExpectations(t)
+		xp.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("package meta document not found", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"v1.0.0"}, nil)
+		xp := &repo.XpkgPullerMock{}
+		ref := "oci://registry.url/org1/platform-ref-aws:v1.0.0"
+		xp.On("Pull", i.Svc.Ctx, i.Repository, ref).Return(&hub.Xpkg{
+			Digest:  "sha256:123",
+			Package: []byte("kind: Other"),
+		}, nil)
+		expectedErr := "error preparing package " + ref + ": package meta document not found"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withXpkgPuller(xp)).GetPackagesAvailable()
+		require.NoError(t, err)
+		assert.Empty(t, packages)
+		tg.AssertExpectations(t)
+		xp.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("package returned, registered versions are not pulled again", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: r,
+			PackagesRegistered: map[string]string{
+				"platform-ref-aws@1.0.0": "sha256:100",
+			},
+			Svc: sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"v1.0.0", "v1.1.0"}, nil)
+		xp := &repo.XpkgPullerMock{}
+		ref := "oci://registry.url/org1/platform-ref-aws:v1.1.0"
+		xp.On("Pull", i.Svc.Ctx, i.Repository, ref).Return(&hub.Xpkg{
+			Digest:  "sha256:110",
+			Package: []byte(packageStream),
+			Examples: map[string][]byte{
+				"cluster.yaml": []byte("apiVersion: aws.platformref.org/v1alpha1\nkind: Cluster"),
+				"README.md":    []byte("ignored"),
+			},
+		}, nil)
+		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, "https://icon.url/logo.svg").Return("logoImageID", nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withXpkgPuller(xp)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 2)
+		v1 := packages["platform-ref-aws@1.0.0"]
+		require.NotNil(t, v1)
+		assert.Equal(t, "sha256:100", v1.Digest)
+		assert.Nil(t, v1.Data)
+		v2 := packages[pkg.BuildKey(&hub.Package{Name: "platform-ref-aws", Version: "1.1.0"})]
+		require.NotNil(t, v2)
+		assert.Equal(t, &hub.Package{
+			Name:        "platform-ref-aws",
+			Version:     "1.1.0",
+			DisplayName: "Platform Ref AWS",
+			Description: "AWS reference platform",
+			Readme:      "AWS reference platform",
+			HomeURL:     "github.com/org1/platform-ref-aws",
+			License:     "Apache-2.0",
+			LogoURL:     "https://icon.url/logo.svg",
+			LogoImageID: "logoImageID",
+			Digest:      "sha256:110",
+			ContentURL:  ref,
+			Keywords:    []string{"crossplane", "configuration"},
+			Maintainers: []*hub.Maintainer{{Name: "User1", Email: "user1@email.com"}},
+			CRDsExamples: []interface{}{
+				map[string]interface{}{
+					"apiVersion": "aws.platformref.org/v1alpha1",
+					"kind":       "Cluster",
+				},
+			},
+			Data: map[string]interface{}{
+				"kind":              "Configuration",
+				"crossplaneVersion": ">=v1.14.0",
+				"dependsOn": []map[string]string{
+					{
+						"kind":    "Provider",
+						"package": "xpkg.upbound.io/upbound/provider-aws-ec2",
+						"version": ">=v0.40.0",
+					},
+				},
+				"xrds": []interface{}{
+					map[string]interface{}{
+						"kind":        "XCluster",
+						"claimKind":   "Cluster",
+						"version":     "v1alpha1",
+						"name":        "xclusters.aws.platformref.org",
+						"displayName": "XCluster",
+						"description": "A Cluster is a composite resource.",
+					},
+				},
+			},
+			Repository: r,
+		}, v2)
+		tg.AssertExpectations(t)
+		xp.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+}
+
+func TestParseMaintainers(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []*hub.Maintainer{
+		{Name: "User1", Email: "user1@email.com"},
+		{Name: "User2"},
+	}, parseMaintainers("User1 <user1@email.com>, User2"))
+}
+
+func withOCITagsGetter(tg hub.OCITagsGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.tg = tg
+	}
+}
+
+func withXpkgPuller(xp hub.XpkgPuller) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.xp = xp
+	}
+}
@@ -0,0 +1,279 @@
+package devcontainer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+)
+
+const (
+	// Artifacts kinds
+	featureKind  = "feature"
+	templateKind = "template"
+
+	// Metadata files
+	featureFile  = "devcontainer-feature.json"
+	templateFile = "devcontainer-template.json"
+	readmeFile   = "README.md"
+)
+
+// templateConfigFiles represents the locations where the dev container
+// configuration file of a template can be found, in order of preference.
+var templateConfigFiles = []string{
+	".devcontainer/devcontainer.json",
+	".devcontainer.json",
+}
+
+// collection represents the collection metadata file available in a
+// namespace, listing the features and templates published in it.
+type collection struct {
+	Features  []*metadata `json:"features"`
+	Templates []*metadata `json:"templates"`
+}
+
+// metadata represents the subset of the fields of the devcontainer-feature.json
+// and devcontainer-template.json files used to prepare the packages.
+type metadata struct {
+	ID               string                 `json:"id"`
+	Version          string                 `json:"version"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	DocumentationURL string                 `json:"documentationURL"`
+	LicenseURL       string                 `json:"licenseURL"`
+	Keywords         []string               `json:"keywords"`
+	Options          map[string]interface{} `json:"options"`
+	Deprecated       bool                   `json:"deprecated"`
+
+	// Features specific fields
+	ContainerEnv  map[string]string      `json:"containerEnv"`
+	InstallsAfter []string               `json:"installsAfter"`
+	DependsOn     map[string]interface{} `json:"dependsOn"`
+
+	// Templates specific fields
+	Publisher string   `json:"publisher"`
+	Platforms []string `json:"platforms"`
+}
+
+// TrackerSource is a hub.TrackerSource implementation for Dev Container
+// features and templates stored in OCI registries. Repositories point to a
+// namespace, where the collection metadata lists the features and templates
+// available in it.
+type TrackerSource struct {
+	i  *hub.TrackerSourceInput
+	tg hub.OCITagsGetter
+	ap hub.DevcontainerArtifactPuller
+}
+
+// NewTrackerSource creates a new TrackerSource instance.
+func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource)) *TrackerSource {
+	s := &TrackerSource{i: i}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.tg == nil {
+		s.tg = &repo.OCITagsGetter{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	if s.ap == nil {
+		s.ap = &repo.OCIDevcontainerArtifactPuller{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	return s
+}
+
+// GetPackagesAvailable implements the TrackerSource interface.
+func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Get features and templates available from the collection metadata
+	ns := strings.TrimSuffix(s.i.Repository.URL, "/")
+	ref := ns + ":latest"
+	a, err := s.ap.Pull(s.i.Svc.Ctx, s.i.Repository, ref)
+	if err != nil {
+		return nil, fmt.Errorf("error pulling collection metadata %s: %w", ref, err)
+	}
+	data, ok := a.Files[repo.DevcontainerCollectionFile]
+	if !ok {
+		return nil, errors.New("collection metadata file not found")
+	}
+	var c collection
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("error parsing collection metadata file: %w", err)
+	}
+
+	// Prepare the versions available of each of the features and templates
+	items := make(map[string]string)
+	for _, md := range c.Features {
+		items[md.ID] = featureKind
+	}
+	for _, md := range c.Templates {
+		items[md.ID] = templateKind
+	}
+	for id, kind := range items {
+		if id == "" {
+			continue
+		}
+		if err := s.preparePackages(ns, id, kind, packagesAvailable); err != nil {
+			return nil, err
+		}
+	}
+
+	return packagesAvailable, nil
+}
+
+// preparePackages prepares a package version for each of the versions
+// available of the feature or template provided, adding them to the packages
+// available map. Only errors that must stop the processing of the repository
+// are returned.
+func (s *TrackerSource) preparePackages(ns, id, kind string, packagesAvailable map[string]*hub.Package) error {
+	// Get versions available. The credentials of the tracked repository are
+	// used for all the features and templates in the namespace.
+	u := ns + "/" + id
+	r := *s.i.Repository
+	r.URL = u
+	tags, err := s.tg.Tags(s.i.Svc.Ctx, &r)
+	if err != nil {
+		s.warn(fmt.Errorf("error getting %s %s tags: %w", kind, id, err))
+		return nil
+	}
+
+	// Prepare a package version for each of the versions available. Only
+	// full versions are considered, as features and templates are also
+	// tagged with their major and minor versions (i.e. 1 and 1.2).
+	bypassDigestCheck := s.i.Svc.Cfg.GetBool("tracker.bypassDigestCheck")
+	for _, tag := range tags {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			return s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		if _, err := semver.StrictNewVersion(tag); err != nil {
+			continue
+		}
+
+		// Versions are not expected to change once published, so the ones
+		// already registered are not pulled again
+		key := pkg.BuildKey(&hub.Package{Name: id, Version: tag})
+		if digest, ok := s.i.PackagesRegistered[key]; ok && !bypassDigestCheck {
+			packagesAvailable[key] = &hub.Package{
+				Name:       id,
+				Version:    tag,
+				Digest:     digest,
+				Repository: s.i.Repository,
+			}
+			continue
+		}
+
+		// Pull artifact and prepare package version
+		ref := u + ":" + tag
+		a, err := s.ap.Pull(s.i.Svc.Ctx, s.i.Repository, ref)
+		if err != nil {
+			s.warn(fmt.Errorf("error pulling %s %s: %w", kind, ref, err))
+			continue
+		}
+		p, err := s.preparePackage(a, kind, ref, tag)
+		if err != nil {
+			s.warn(fmt.Errorf("error preparing package %s: %w", ref, err))
+			continue
+		}
+		packagesAvailable[pkg.BuildKey(p)] = p
+	}
+
+	return nil
+}
+
+// warn is a helper that sends the error provided to the errors collector and
+// logs it as a warning.
+func (s *TrackerSource) warn(err error) {
+	s.i.Svc.Logger.Warn().Err(err).Send()
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+}
+
+// preparePackage prepares a package version from the feature or template
+// artifact content provided.
+func (s *TrackerSource) preparePackage(a *hub.DevcontainerArtifact, kind, ref, tag string) (*hub.Package, error) {
+	// Parse metadata file
+	mdFile := featureFile
+	if kind == templateKind {
+		mdFile = templateFile
+	}
+	data, ok := a.Files[mdFile]
+	if !ok {
+		return nil, fmt.Errorf("%s file not found", mdFile)
+	}
+	var md *metadata
+	if err := json.Unmarshal(data, &md); err != nil {
+		return nil, fmt.Errorf("error parsing %s file: %w", mdFile, err)
+	}
+	if md == nil || md.ID == "" {
+		return nil, fmt.Errorf("id not found in %s file", mdFile)
+	}
+
+	// Prepare package from metadata
+	p := &hub.Package{
+		Name:        md.ID,
+		Version:     tag,
+		DisplayName: md.Name,
+		Description: md.Description,
+		Readme:      md.Description,
+		HomeURL:     md.DocumentationURL,
+		Deprecated:  md.Deprecated,
+		Digest:      a.Digest,
+		ContentURL:  ref,
+		Repository:  s.i.Repository,
+		Keywords:    append([]string{"devcontainer", kind}, md.Keywords...),
+	}
+	if readme, ok := a.Files[readmeFile]; ok {
+		p.Readme = string(readme)
+	}
+	if md.LicenseURL != "" {
+		p.Links = []*hub.Link{
+			{
+				Name: "license",
+				URL:  md.LicenseURL,
+			},
+		}
+	}
+
+	// Prepare package data
+	p.Data = map[string]interface{}{
+		"kind":      kind,
+		"reference": strings.TrimPrefix(ref, hub.RepositoryOCIPrefix),
+	}
+	if len(md.Options) > 0 {
+		p.Data["options"] = md.Options
+	}
+	switch kind {
+	case featureKind:
+		if len(md.ContainerEnv) > 0 {
+			p.Data["containerEnv"] = md.ContainerEnv
+		}
+		if len(md.InstallsAfter) > 0 {
+			p.Data["installsAfter"] = md.InstallsAfter
+		}
+		if len(md.DependsOn) > 0 {
+			p.Data["dependsOn"] = md.DependsOn
+		}
+	case templateKind:
+		if md.Publisher != "" {
+			p.Data["publisher"] = md.Publisher
+		}
+		if len(md.Platforms) > 0 {
+			p.Data["platforms"] = md.Platforms
+		}
+		for _, file := range templateConfigFiles {
+			if config, ok := a.Files[file]; ok {
+				p.Data["devcontainer"] = string(config)
+				break
+			}
+		}
+	}
+
+	return p, nil
+}
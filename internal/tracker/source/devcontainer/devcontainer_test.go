@@ -0,0 +1,245 @@
+package devcontainer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const featureMetadata = `
+{
+	"id": "node",
+	"version": "1.1.0",
+	"name": "Node.js",
+	"description": "Installs Node.js and npm",
+	"documentationURL": "https://github.com/org1/features/tree/main/src/node",
+	"licenseURL": "https://github.com/org1/features/blob/main/LICENSE",
+	"keywords": ["javascript"],
+	"options": {
+		"version": {
+			"type": "string",
+			"default": "lts",
+			"description": "Node.js version to install"
+		}
+	},
+	"containerEnv": {
+		"NVM_DIR": "/usr/local/share/nvm"
+	},
+	"installsAfter": ["ghcr.io/devcontainers/features/common-utils"]
+}
+`
+
+func TestTrackerSource(t *testing.T) {
+	r := &hub.Repository{
+		Kind: hub.Devcontainer,
+		URL:  "oci://registry.url/org1/features",
+	}
+	collectionRef := "oci://registry.url/org1/features:latest"
+
+	t.Run("error pulling collection metadata", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		ap := &repo.DevcontainerArtifactPullerMock{}
+		ap.On("Pull", i.Svc.Ctx, i.Repository, collectionRef).Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withArtifactPuller(ap)).GetPackagesAvailable()
+		assert.Nil(t, packages)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		ap.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("collection metadata file not found", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		ap := &repo.DevcontainerArtifactPullerMock{}
+		ap.On("Pull", i.Svc.Ctx, i.Repository, collectionRef).Return(&hub.DevcontainerArtifact{}, nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withArtifactPuller(ap)).GetPackagesAvailable()
+		assert.Nil(t, packages)
+		assert.EqualError(t, err, "collection metadata file not found")
+		ap.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("error getting feature tags", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, &hub.Repository{
+			Kind: hub.Devcontainer,
+			URL:  "oci://registry.url/org1/features/node",
+		}).Return(nil, tests.ErrFake)
+		ap := &repo.DevcontainerArtifactPullerMock{}
+		ap.On("Pull", i.Svc.Ctx, i.Repository, collectionRef).Return(&hub.DevcontainerArtifact{
+			Files: map[string][]byte{
+				repo.DevcontainerCollectionFile: []byte(`{"features": [{"id": "node"}]}`),
+			},
+		}, nil)
+		expectedErr := "error getting feature node tags: fake error for tests"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withArtifactPuller(ap)).GetPackagesAvailable()
+		require.NoError(t, err)
+		assert.Empty(t, packages)
+		tg.AssertExpectations(t)
+		ap.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("feature returned, registered versions are not pulled again", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: r,
+			PackagesRegistered: map[string]string{
+				"node@1.0.0": "sha256:100",
+			},
+			Svc: sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, &hub.Repository{
+			Kind: hub.Devcontainer,
+			URL:  "oci://registry.url/org1/features/node",
+		}).Return([]string{"1.1.0", "1.1", "1", "1.0.0"}, nil)
+		ap := &repo.DevcontainerArtifactPullerMock{}
+		ap.On("Pull", i.Svc.Ctx, i.Repository, collectionRef).Return(&hub.DevcontainerArtifact{
+			Files: map[string][]byte{
+				repo.DevcontainerCollectionFile: []byte(`{"features": [{"id": "node"}]}`),
+			},
+		}, nil)
+		ref := "oci://registry.url/org1/features/node:1.1.0"
+		ap.On("Pull", i.Svc.Ctx, i.Repository, ref).Return(&hub.DevcontainerArtifact{
+			Digest: "sha256:110",
+			Files: map[string][]byte{
+				"devcontainer-feature.json": []byte(featureMetadata),
+				"README.md":                 []byte("# Node.js"),
+			},
+		}, nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withArtifactPuller(ap)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 2)
+		v1 := packages["node@1.0.0"]
+		require.NotNil(t, v1)
+		assert.Equal(t, "sha256:100", v1.Digest)
+		assert.Nil(t, v1.Data)
+		assert.Equal(t, &hub.Package{
+			Name:        "node",
+			Version:     "1.1.0",
+			DisplayName: "Node.js",
+			Description: "Installs Node.js and npm",
+			Readme:      "# Node.js",
+			HomeURL:     "https://github.com/org1/features/tree/main/src/node",
+			Digest:      "sha256:110",
+			ContentURL:  ref,
+			Keywords:    []string{"devcontainer", "feature", "javascript"},
+			Links: []*hub.Link{
+				{
+					Name: "license",
+					URL:  "https://github.com/org1/features/blob/main/LICENSE",
+				},
+			},
+			Data: map[string]interface{}{
+				"kind":      "feature",
+				"reference": "registry.url/org1/features/node:1.1.0",
+				"options": map[string]interface{}{
+					"version": map[string]interface{}{
+						"type":        "string",
+						"default":     "lts",
+						"description": "Node.js version to install",
+					},
+				},
+				"containerEnv": map[string]string{
+					"NVM_DIR": "/usr/local/share/nvm",
+				},
+				"installsAfter": []string{"ghcr.io/devcontainers/features/common-utils"},
+			},
+			Repository: r,
+		}, packages["node@1.1.0"])
+		tg.AssertExpectations(t)
+		ap.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("template returned", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, &hub.Repository{
+			Kind: hub.Devcontainer,
+			URL:  "oci://registry.url/org1/features/go",
+		}).Return([]string{"2.0.0"}, nil)
+		ap := &repo.DevcontainerArtifactPullerMock{}
+		ap.On("Pull", i.Svc.Ctx, i.Repository, collectionRef).Return(&hub.DevcontainerArtifact{
+			Files: map[string][]byte{
+				repo.DevcontainerCollectionFile: []byte(`{"templates": [{"id": "go"}]}`),
+			},
+		}, nil)
+		ref := "oci://registry.url/org1/features/go:2.0.0"
+		ap.On("Pull", i.Svc.Ctx, i.Repository, ref).Return(&hub.DevcontainerArtifact{
+			Digest: "sha256:200",
+			Files: map[string][]byte{
+				"devcontainer-template.json":      []byte(`{"id": "go", "name": "Go", "publisher": "Org1", "platforms": ["Go"]}`),
+				".devcontainer/devcontainer.json": []byte(`{"name": "Go"}`),
+			},
+		}, nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withArtifactPuller(ap)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		p := packages["go@2.0.0"]
+		require.NotNil(t, p)
+		assert.Equal(t, "Go", p.DisplayName)
+		assert.Equal(t, []string{"devcontainer", "template"}, p.Keywords)
+		assert.Equal(t, map[string]interface{}{
+			"kind":         "template",
+			"reference":    "registry.url/org1/features/go:2.0.0",
+			"publisher":    "Org1",
+			"platforms":    []string{"Go"},
+			"devcontainer": `{"name": "Go"}`,
+		}, p.Data)
+		tg.AssertExpectations(t)
+		ap.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+}
+
+func withOCITagsGetter(tg hub.OCITagsGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.tg = tg
+	}
+}
+
+func withArtifactPuller(ap hub.DevcontainerArtifactPuller) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.ap = ap
+	}
+}
@@ -0,0 +1,322 @@
+package gatekeeper
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/ghodss/yaml"
+)
+
+const (
+	descriptionAnnotation = "description"
+	titleAnnotation       = "metadata.gatekeeper.sh/title"
+	versionAnnotation     = "metadata.gatekeeper.sh/version"
+
+	// samplesDir represents the directory, relative to the template location,
+	// where the sample constraints are stored (one per subdirectory).
+	samplesDir = "samples"
+
+	// sampleConstraintFile represents the name of the file that contains the
+	// constraint in a sample directory. The rest of the files in the sample
+	// directory are considered examples.
+	sampleConstraintFile = "constraint.yaml"
+)
+
+// constraintTemplate represents the subset of the fields of a Gatekeeper
+// ConstraintTemplate used to prepare the packages.
+type constraintTemplate struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name        string            `json:"name"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		CRD struct {
+			Spec struct {
+				Names struct {
+					Kind string `json:"kind"`
+				} `json:"names"`
+				Validation struct {
+					OpenAPIV3Schema map[string]interface{} `json:"openAPIV3Schema"`
+				} `json:"validation"`
+			} `json:"spec"`
+		} `json:"crd"`
+		Targets []*target `json:"targets"`
+	} `json:"spec"`
+}
+
+// target represents a constraint template target.
+type target struct {
+	Target string   `json:"target"`
+	Rego   string   `json:"rego"`
+	Libs   []string `json:"libs,omitempty"`
+}
+
+// sample represents a sample constraint with some examples of resources that
+// are allowed or disallowed by it.
+type sample struct {
+	Name       string            `json:"name"`
+	Constraint string            `json:"constraint"`
+	Examples   map[string]string `json:"examples,omitempty"`
+}
+
+// TrackerSource is a hub.TrackerSource implementation for Gatekeeper
+// constraint templates repositories.
+type TrackerSource struct {
+	i *hub.TrackerSourceInput
+}
+
+// NewTrackerSource creates a new TrackerSource instance.
+func NewTrackerSource(i *hub.TrackerSourceInput) *TrackerSource {
+	return &TrackerSource{i}
+}
+
+// GetPackagesAvailable implements the TrackerSource interface.
+func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Walk the path provided looking for available packages
+	err := filepath.Walk(s.i.BasePath, func(pkgPath string, info os.FileInfo, err error) error {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			return s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		// If an error is raised while visiting a path or the path is not a
+		// directory, we skip it
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+
+		// Get constraint template
+		template, templateFile, templateRaw, err := getConstraintTemplate(pkgPath)
+		if err != nil {
+			s.warn(fmt.Errorf("error getting constraint template: %w", err))
+			return nil
+		}
+		if template == nil {
+			// Constraint template not found, not a package path
+			return nil
+		}
+
+		// Parse and validate version
+		versionValue := template.Metadata.Annotations[versionAnnotation]
+		sv, err := semver.NewVersion(versionValue)
+		if err != nil {
+			s.warn(fmt.Errorf("invalid package (%s) version (%s): %w", template.Metadata.Name, versionValue, err))
+			return nil
+		}
+
+		// Prepare and store package version
+		p, err := s.preparePackage(template, templateFile, templateRaw, pkgPath, sv.String())
+		if err != nil {
+			s.warn(fmt.Errorf("error preparing package: %w", err))
+			return nil
+		}
+		packagesAvailable[pkg.BuildKey(p)] = p
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return packagesAvailable, nil
+}
+
+// preparePackage prepares a package version using the constraint template and
+// the files in the path provided.
+func (s *TrackerSource) preparePackage(
+	template *constraintTemplate,
+	templateFile string,
+	templateRaw []byte,
+	pkgPath,
+	version string,
+) (*hub.Package, error) {
+	r := s.i.Repository
+
+	// Prepare content and source urls
+	var repoBaseURL, pkgsPath, provider string
+	matches := repo.GitRepoURLRE.FindStringSubmatch(r.URL)
+	if len(matches) >= 3 {
+		repoBaseURL = matches[1]
+		provider = matches[2]
+	}
+	if len(matches) == 4 {
+		pkgsPath = strings.TrimSuffix(matches[3], "/")
+	}
+	var blobPath, rawPath string
+	switch provider {
+	case "github":
+		blobPath = "blob"
+		rawPath = "raw"
+	case "gitlab":
+		blobPath = "-/blob"
+		rawPath = "-/raw"
+	}
+	branch := repo.GetBranch(r)
+	pkgVersionPath := strings.TrimPrefix(pkgPath, s.i.BasePath)
+	contentURL := fmt.Sprintf("%s/%s/%s/%s%s/%s",
+		repoBaseURL, rawPath, branch, pkgsPath, pkgVersionPath, templateFile)
+	sourceURL := fmt.Sprintf("%s/%s/%s/%s%s/%s",
+		repoBaseURL, blobPath, branch, pkgsPath, pkgVersionPath, templateFile)
+
+	// Get sample constraints
+	samples, err := getSamples(filepath.Join(pkgPath, samplesDir))
+	if err != nil {
+		return nil, fmt.Errorf("error getting package %s version %s samples: %w", template.Metadata.Name, version, err)
+	}
+
+	// Prepare package from constraint template
+	p := &hub.Package{
+		Name:        template.Metadata.Name,
+		Version:     version,
+		DisplayName: template.Metadata.Annotations[titleAnnotation],
+		Description: strings.TrimSpace(template.Metadata.Annotations[descriptionAnnotation]),
+		Keywords:    []string{"gatekeeper", "opa", "constraint-template"},
+		Digest:      getDigest(templateRaw, samples),
+		ContentURL:  contentURL,
+		Repository:  r,
+		Links: []*hub.Link{
+			{
+				Name: "source",
+				URL:  sourceURL,
+			},
+		},
+		Data: map[string]interface{}{
+			"template":       string(templateRaw),
+			"constraintKind": template.Spec.CRD.Spec.Names.Kind,
+			"targets":        template.Spec.Targets,
+		},
+	}
+	if template.Spec.CRD.Spec.Validation.OpenAPIV3Schema != nil {
+		p.Data["parameters"] = template.Spec.CRD.Spec.Validation.OpenAPIV3Schema
+	}
+	if len(samples) > 0 {
+		p.Data["samples"] = samples
+	}
+
+	// Include readme file if available
+	readme, err := ioutil.ReadFile(filepath.Join(pkgPath, "README.md"))
+	if err == nil {
+		p.Readme = string(readme)
+	}
+
+	return p, nil
+}
+
+// warn is a helper that sends the error provided to the errors collector and
+// logs it as a warning.
+func (s *TrackerSource) warn(err error) {
+	s.i.Svc.Logger.Warn().Err(err).Send()
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+}
+
+// getConstraintTemplate returns the first constraint template found in the
+// yaml files located in the path provided, as well as the name of the file
+// where it is defined and its raw content.
+func getConstraintTemplate(pkgPath string) (*constraintTemplate, string, []byte, error) {
+	// Locate yaml files
+	matches, err := filepath.Glob(filepath.Join(pkgPath, "*.yaml"))
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("error locating template file: %w", err)
+	}
+
+	// Process matches, returning the first valid constraint template found
+	for _, match := range matches {
+		data, err := ioutil.ReadFile(match)
+		if err != nil {
+			continue
+		}
+		template := &constraintTemplate{}
+		if err = yaml.Unmarshal(data, template); err != nil {
+			continue
+		}
+		if !strings.HasPrefix(template.APIVersion, "templates.gatekeeper.sh/") ||
+			template.Kind != "ConstraintTemplate" {
+			continue
+		}
+		return template, filepath.Base(match), data, nil
+	}
+
+	return nil, "", nil, nil
+}
+
+// getSamples returns the sample constraints available in the path provided.
+// Each sample is expected to be in its own directory, containing at least a
+// constraint file.
+func getSamples(path string) ([]*sample, error) {
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var samples []*sample
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		samplePath := filepath.Join(path, entry.Name())
+		constraint, err := ioutil.ReadFile(filepath.Join(samplePath, sampleConstraintFile))
+		if err != nil {
+			continue
+		}
+		smp := &sample{
+			Name:       entry.Name(),
+			Constraint: string(constraint),
+		}
+		files, err := filepath.Glob(filepath.Join(samplePath, "*.yaml"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			name := filepath.Base(file)
+			if name == sampleConstraintFile {
+				continue
+			}
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			if smp.Examples == nil {
+				smp.Examples = make(map[string]string)
+			}
+			smp.Examples[name] = string(data)
+		}
+		samples = append(samples, smp)
+	}
+	return samples, nil
+}
+
+// getDigest returns a digest of the constraint template and samples provided,
+// so that changes in any of them are detected.
+func getDigest(templateRaw []byte, samples []*sample) string {
+	h := sha256.New()
+	_, _ = h.Write(templateRaw)
+	for _, smp := range samples {
+		_, _ = h.Write([]byte(smp.Name + smp.Constraint))
+		names := make([]string, 0, len(smp.Examples))
+		for name := range smp.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			_, _ = h.Write([]byte(name + smp.Examples[name]))
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,117 @@
+package gatekeeper
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerSource(t *testing.T) {
+	t.Run("no packages in path", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{},
+			BasePath:   "testdata/path1",
+			Svc:        sw.Svc,
+		}
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("invalid version in constraint template", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{},
+			BasePath:   "testdata/path2",
+			Svc:        sw.Svc,
+		}
+		expectedErr := "invalid package (k8sallowedrepos) version (invalid): Invalid Semantic Version"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("one package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL: "https://github.com/user/repo/library",
+			},
+			BasePath: "testdata/path3",
+			Svc:      sw.Svc,
+		}
+
+		// Run test and check expectations
+		pkgPath := "testdata/path3/allowedrepos"
+		templateRaw, _ := ioutil.ReadFile(pkgPath + "/template.yaml")
+		constraint, _ := ioutil.ReadFile(pkgPath + "/samples/repo-must-be-openpolicyagent/constraint.yaml")
+		example, _ := ioutil.ReadFile(pkgPath + "/samples/repo-must-be-openpolicyagent/example_allowed.yaml")
+		samples := []*sample{
+			{
+				Name:       "repo-must-be-openpolicyagent",
+				Constraint: string(constraint),
+				Examples: map[string]string{
+					"example_allowed.yaml": string(example),
+				},
+			},
+		}
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		p := packages[pkg.BuildKey(&hub.Package{Name: "k8sallowedrepos", Version: "1.0.1"})]
+		require.NotNil(t, p)
+		assert.Equal(t, "Allowed Repositories", p.DisplayName)
+		assert.Equal(t, "Requires container images to begin with a string from the specified list.", p.Description)
+		assert.Equal(t, "# Allowed Repositories\n", p.Readme)
+		assert.Equal(t, getDigest(templateRaw, samples), p.Digest)
+		assert.Equal(t, "https://github.com/user/repo/raw/master/library/allowedrepos/template.yaml", p.ContentURL)
+		assert.Equal(t, []*hub.Link{
+			{
+				Name: "source",
+				URL:  "https://github.com/user/repo/blob/master/library/allowedrepos/template.yaml",
+			},
+		}, p.Links)
+		assert.Equal(t, string(templateRaw), p.Data["template"])
+		assert.Equal(t, "K8sAllowedRepos", p.Data["constraintKind"])
+		targets := p.Data["targets"].([]*target)
+		require.Len(t, targets, 1)
+		assert.Equal(t, "admission.k8s.gatekeeper.sh", targets[0].Target)
+		assert.Contains(t, targets[0].Rego, "package k8sallowedrepos")
+		assert.Equal(t, map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"repos": map[string]interface{}{
+					"description": "The list of prefixes a container image is allowed to have.",
+					"type":        "array",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+		}, p.Data["parameters"])
+		assert.Equal(t, samples, p.Data["samples"])
+		sw.AssertExpectations(t)
+	})
+}
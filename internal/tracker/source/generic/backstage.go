@@ -0,0 +1,178 @@
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	// backstageVersionAnnotation represents the package metadata annotation
+	// used to define the Backstage versions supported by a plugin (semver
+	// constraint).
+	backstageVersionAnnotation = "backstage/version"
+
+	// backstagePackageJSONFile represents the name of the npm package file of
+	// a Backstage plugin.
+	backstagePackageJSONFile = "package.json"
+
+	// backstageDependenciesPrefix represents the prefix of the npm packages
+	// considered Backstage dependencies.
+	backstageDependenciesPrefix = "@backstage/"
+
+	// npmRegistryURL represents the url of the npm registry used to get the
+	// dist-tags of the Backstage plugins.
+	npmRegistryURL = "https://registry.npmjs.org"
+)
+
+// backstagePackageJSON represents the subset of the fields of a Backstage
+// plugin package.json file used to prepare the packages.
+type backstagePackageJSON struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	Description string   `json:"description"`
+	License     string   `json:"license"`
+	Homepage    string   `json:"homepage"`
+	Keywords    []string `json:"keywords"`
+	Backstage   struct {
+		Role     string `json:"role"`
+		PluginID string `json:"pluginId"`
+	} `json:"backstage"`
+	Dependencies     map[string]string `json:"dependencies"`
+	PeerDependencies map[string]string `json:"peerDependencies"`
+}
+
+// prepareBackstageData reads and formats Backstage plugins specific data
+// available in the path provided, returning the resulting data structure.
+// The package provided is enriched with the information available in the
+// plugin package.json file when it was not set in the package metadata file.
+func (s *TrackerSource) prepareBackstageData(
+	p *hub.Package,
+	md *hub.PackageMetadata,
+	pkgPath string,
+) (map[string]interface{}, error) {
+	// Validate supported Backstage versions
+	if v, ok := md.Annotations[backstageVersionAnnotation]; ok {
+		if _, err := semver.NewConstraint(v); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", backstageVersionAnnotation, err)
+		}
+	}
+
+	// Read plugin package.json file
+	data, err := ioutil.ReadFile(filepath.Join(pkgPath, backstagePackageJSONFile))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s file: %w", backstagePackageJSONFile, err)
+	}
+	pj := &backstagePackageJSON{}
+	if err := json.Unmarshal(data, pj); err != nil {
+		return nil, fmt.Errorf("error parsing %s file: %w", backstagePackageJSONFile, err)
+	}
+	if pj.Name == "" {
+		return nil, fmt.Errorf("npm package name not found in %s file", backstagePackageJSONFile)
+	}
+
+	// Enrich package with the package.json information
+	if p.Description == "" {
+		p.Description = pj.Description
+	}
+	if p.License == "" {
+		p.License = pj.License
+	}
+	if p.HomeURL == "" {
+		p.HomeURL = pj.Homepage
+	}
+	if len(p.Keywords) == 0 {
+		p.Keywords = pj.Keywords
+	}
+
+	// Prepare Backstage dependencies (peer dependencies take precedence)
+	deps := make(map[string]string)
+	for _, m := range []map[string]string{pj.Dependencies, pj.PeerDependencies} {
+		for name, version := range m {
+			if strings.HasPrefix(name, backstageDependenciesPrefix) {
+				deps[name] = version
+			}
+		}
+	}
+
+	// Prepare package data field
+	kindData := map[string]interface{}{
+		"npmPackage": pj.Name,
+	}
+	if pj.Backstage.Role != "" {
+		kindData["role"] = pj.Backstage.Role
+	}
+	if pj.Backstage.PluginID != "" {
+		kindData["pluginId"] = pj.Backstage.PluginID
+	}
+	if len(deps) > 0 {
+		kindData["backstageDependencies"] = deps
+	}
+
+	// Include the npm dist-tags pointing to this version, if any. Errors
+	// getting them are not fatal, as the package can be published anyway.
+	distTags, err := s.getNpmDistTags(pj.Name)
+	if err != nil {
+		s.warn(fmt.Errorf("error getting npm package %s dist-tags: %w", pj.Name, err))
+	}
+	var tags []string
+	for tag, version := range distTags {
+		if version == p.Version || (pj.Version != "" && version == pj.Version) {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > 0 {
+		sort.Strings(tags)
+		kindData["npmDistTags"] = tags
+	}
+
+	return kindData, nil
+}
+
+// getNpmDistTags returns the dist-tags of the npm package provided. Dist-tags
+// are cached, as all the versions of a package share them.
+func (s *TrackerSource) getNpmDistTags(name string) (map[string]string, error) {
+	if distTags, ok := s.npmDistTags[name]; ok {
+		return distTags, nil
+	}
+
+	// Get dist-tags from the npm registry
+	u := fmt.Sprintf("%s/-/package/%s/dist-tags", npmRegistryURL, strings.Replace(name, "/", "%2f", 1))
+	req, _ := http.NewRequest("GET", u, nil)
+	req = req.WithContext(s.i.Svc.Ctx)
+	resp, err := s.i.Svc.Hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var distTags map[string]string
+	switch resp.StatusCode {
+	case http.StatusOK:
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &distTags); err != nil {
+			return nil, err
+		}
+	case http.StatusNotFound:
+		// Package not published in the npm registry
+	default:
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+
+	// Cache and return dist-tags
+	if s.npmDistTags == nil {
+		s.npmDistTags = make(map[string]map[string]string)
+	}
+	s.npmDistTags[name] = distTags
+	return distTags, nil
+}
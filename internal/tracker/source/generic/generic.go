@@ -1,28 +1,53 @@
 package generic
 
 import (
+	"bytes"
+	"crypto"
 	"errors"
 	"fmt"
 	"image"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/pkg"
 	ignore "github.com/sabhiram/go-gitignore"
+	"gopkg.in/yaml.v3"
 )
 
+const (
+	// kedaVersionAnnotation represents the package metadata annotation used
+	// to define the KEDA versions supported by a scaler (semver constraint).
+	kedaVersionAnnotation = "keda/version"
+
+	// Kyverno policies annotations
+	kyvernoCategoryAnnotation = "policies.kyverno.io/category"
+	kyvernoSeverityAnnotation = "policies.kyverno.io/severity"
+
+	// Kyverno package data keys
+	kyvernoCategoryKey = "kyverno/category"
+	kyvernoSeverityKey = "kyverno/severity"
+)
+
+// errNoFilesFound indicates that no files with the suffix requested were found
+// in the package path.
+var errNoFilesFound = errors.New("no files found")
+
 // TrackerSource is a hub.TrackerSource implementation used by several kinds
 // of repositories.
 type TrackerSource struct {
-	i *hub.TrackerSourceInput
+	i           *hub.TrackerSourceInput
+	npmDistTags map[string]map[string]string
+	opaKeys     map[string][]crypto.PublicKey
 }
 
 // NewTrackerSource creates a new TrackerSource instance.
 func NewTrackerSource(i *hub.TrackerSourceInput) *TrackerSource {
-	return &TrackerSource{i}
+	return &TrackerSource{i: i}
 }
 
 // GetPackagesAvailable implements the TrackerSource interface.
@@ -93,8 +118,20 @@ func (s *TrackerSource) preparePackage(r *hub.Repository, md *hub.PackageMetadat
 	ignorer := ignore.CompileIgnoreLines(md.Ignore...)
 	var kindData map[string]interface{}
 	switch r.Kind {
+	case hub.ArgoWorkflowTemplate:
+		kindData, err = prepareArgoWorkflowTemplateData(pkgPath, ignorer)
+	case hub.Backstage:
+		kindData, err = s.prepareBackstageData(p, md, pkgPath)
 	case hub.Falco:
 		kindData, err = prepareFalcoData(pkgPath, ignorer)
+	case hub.Headlamp:
+		kindData, err = prepareHeadlampData(md)
+	case hub.KedaScaler:
+		kindData, err = prepareKedaScalerData(md, pkgPath, ignorer)
+	case hub.Knative:
+		kindData, err = prepareKnativeData(pkgPath, ignorer)
+	case hub.Kyverno:
+		kindData, err = prepareKyvernoData(pkgPath, ignorer)
 	case hub.OPA:
 		kindData, err = prepareOPAData(pkgPath, ignorer)
 	}
@@ -111,6 +148,13 @@ func (s *TrackerSource) preparePackage(r *hub.Repository, md *hub.PackageMetadat
 		}
 	}
 
+	// Check if the OPA bundle is signed and verify its signature
+	if r.Kind == hub.OPA {
+		if err := s.checkOPABundleSignatures(p, pkgPath, ignorer); err != nil {
+			s.warn(fmt.Errorf("error verifying package %s version %s bundle signatures: %w", md.Name, md.Version, err))
+		}
+	}
+
 	// Store logo image when available
 	if md.LogoPath != "" {
 		data, err := ioutil.ReadFile(filepath.Join(pkgPath, md.LogoPath))
@@ -142,6 +186,100 @@ func (s *TrackerSource) warn(err error) {
 	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
 }
 
+// argoWorkflowTemplate represents a summary of an Argo WorkflowTemplate or
+// ClusterWorkflowTemplate.
+type argoWorkflowTemplate struct {
+	Name       string                   `json:"name"`
+	Kind       string                   `json:"kind"`
+	Entrypoint string                   `json:"entrypoint,omitempty"`
+	Parameters []*argoWorkflowParameter `json:"parameters,omitempty"`
+}
+
+// argoWorkflowParameter represents an input parameter of an Argo workflow
+// template.
+type argoWorkflowParameter struct {
+	Name        string   `json:"name" yaml:"name"`
+	Value       string   `json:"value,omitempty" yaml:"value"`
+	Description string   `json:"description,omitempty" yaml:"description"`
+	Enum        []string `json:"enum,omitempty" yaml:"enum"`
+}
+
+// prepareArgoWorkflowTemplateData reads and formats Argo workflow templates
+// specific data available in the path provided, returning the resulting data
+// structure. Only the files containing at least one workflow template are
+// kept.
+func prepareArgoWorkflowTemplateData(pkgPath string, ignorer ignore.IgnoreParser) (map[string]interface{}, error) {
+	// Read manifests files
+	files, err := getFilesWithSuffix(".yaml", pkgPath, ignorer)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Keep only the files containing workflow templates
+	manifests := make(map[string]string)
+	var templates []*argoWorkflowTemplate
+	for _, name := range names {
+		fileTemplates := getArgoWorkflowTemplates(files[name])
+		if len(fileTemplates) == 0 {
+			continue
+		}
+		manifests[name] = files[name]
+		templates = append(templates, fileTemplates...)
+	}
+	if len(templates) == 0 {
+		return nil, errors.New("no workflow templates found")
+	}
+
+	// Return package data field
+	return map[string]interface{}{
+		"manifests":         manifests,
+		"workflowTemplates": templates,
+	}, nil
+}
+
+// getArgoWorkflowTemplates returns the workflow templates defined in the
+// manifest provided.
+func getArgoWorkflowTemplates(manifest string) []*argoWorkflowTemplate {
+	var templates []*argoWorkflowTemplate
+	dec := yaml.NewDecoder(bytes.NewReader([]byte(manifest)))
+	for {
+		var doc struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+			Metadata   struct {
+				Name string `yaml:"name"`
+			} `yaml:"metadata"`
+			Spec struct {
+				Entrypoint string `yaml:"entrypoint"`
+				Arguments  struct {
+					Parameters []*argoWorkflowParameter `yaml:"parameters"`
+				} `yaml:"arguments"`
+			} `yaml:"spec"`
+		}
+		if err := dec.Decode(&doc); err != nil {
+			break
+		}
+		if !strings.HasPrefix(doc.APIVersion, "argoproj.io/") {
+			continue
+		}
+		if doc.Kind != "WorkflowTemplate" && doc.Kind != "ClusterWorkflowTemplate" {
+			continue
+		}
+		templates = append(templates, &argoWorkflowTemplate{
+			Name:       doc.Metadata.Name,
+			Kind:       doc.Kind,
+			Entrypoint: doc.Spec.Entrypoint,
+			Parameters: doc.Spec.Arguments.Parameters,
+		})
+	}
+	return templates
+}
+
 // prepareFalcoData reads and formats Falco specific data available in the path
 // provided, returning the resulting data structure.
 func prepareFalcoData(pkgPath string, ignorer ignore.IgnoreParser) (map[string]interface{}, error) {
@@ -157,6 +295,202 @@ func prepareFalcoData(pkgPath string, ignorer ignore.IgnoreParser) (map[string]i
 	}, nil
 }
 
+// kedaTrigger represents a KEDA trigger used in the examples manifests of a
+// scaler package.
+type kedaTrigger struct {
+	Type     string   `json:"type"`
+	Metadata []string `json:"metadata,omitempty"`
+}
+
+// prepareKedaScalerData reads and formats KEDA scalers specific data available
+// in the path provided, returning the resulting data structure. Examples
+// manifests are optional, but the supported KEDA versions constraint must be
+// valid when provided.
+func prepareKedaScalerData(
+	md *hub.PackageMetadata,
+	pkgPath string,
+	ignorer ignore.IgnoreParser,
+) (map[string]interface{}, error) {
+	// Validate supported KEDA versions
+	if v, ok := md.Annotations[kedaVersionAnnotation]; ok {
+		if _, err := semver.NewConstraint(v); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", kedaVersionAnnotation, err)
+		}
+	}
+
+	// Read examples manifests files (ScaledObject, ScaledJob, etc)
+	files, err := getFilesWithSuffix(".yaml", pkgPath, ignorer)
+	if err != nil {
+		if errors.Is(err, errNoFilesFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	examples := make(map[string]string)
+	triggersMetadata := make(map[string]map[string]struct{})
+	for name, content := range files {
+		triggers, ok := getKedaTriggers(content)
+		if !ok {
+			continue
+		}
+		examples[name] = content
+		for _, t := range triggers {
+			if _, ok := triggersMetadata[t.Type]; !ok {
+				triggersMetadata[t.Type] = make(map[string]struct{})
+			}
+			for _, key := range t.Metadata {
+				triggersMetadata[t.Type][key] = struct{}{}
+			}
+		}
+	}
+	if len(examples) == 0 {
+		return nil, nil
+	}
+
+	// Prepare triggers list (sorted by type)
+	triggers := make([]*kedaTrigger, 0, len(triggersMetadata))
+	for triggerType, keys := range triggersMetadata {
+		t := &kedaTrigger{Type: triggerType}
+		for key := range keys {
+			t.Metadata = append(t.Metadata, key)
+		}
+		sort.Strings(t.Metadata)
+		triggers = append(triggers, t)
+	}
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].Type < triggers[j].Type
+	})
+
+	// Return package data field
+	data := map[string]interface{}{
+		"examples": examples,
+	}
+	if len(triggers) > 0 {
+		data["triggers"] = triggers
+	}
+	return data, nil
+}
+
+// getKedaTriggers returns the triggers defined in the KEDA resources found in
+// the manifest provided. The boolean returned indicates if the manifest
+// contains any KEDA resource.
+func getKedaTriggers(manifest string) ([]*kedaTrigger, bool) {
+	var triggers []*kedaTrigger
+	var found bool
+	dec := yaml.NewDecoder(bytes.NewReader([]byte(manifest)))
+	for {
+		var doc struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+			Spec       struct {
+				Triggers []struct {
+					Type     string            `yaml:"type"`
+					Metadata map[string]string `yaml:"metadata"`
+				} `yaml:"triggers"`
+			} `yaml:"spec"`
+		}
+		if err := dec.Decode(&doc); err != nil {
+			break
+		}
+		if !strings.HasPrefix(doc.APIVersion, "keda.sh/") {
+			continue
+		}
+		switch doc.Kind {
+		case "ScaledObject", "ScaledJob", "TriggerAuthentication", "ClusterTriggerAuthentication":
+			found = true
+		default:
+			continue
+		}
+		for _, t := range doc.Spec.Triggers {
+			if t.Type == "" {
+				continue
+			}
+			trigger := &kedaTrigger{Type: t.Type}
+			for key := range t.Metadata {
+				trigger.Metadata = append(trigger.Metadata, key)
+			}
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers, found
+}
+
+// prepareKyvernoData reads and formats Kyverno specific data available in the
+// path provided, returning the resulting data structure. Only the files
+// containing at least one policy are kept, and the category and severity are
+// read from the annotations of the first policy found (files are processed
+// in lexical order).
+func prepareKyvernoData(pkgPath string, ignorer ignore.IgnoreParser) (map[string]interface{}, error) {
+	// Read manifests files, skipping the package metadata file
+	files, err := getFilesWithSuffixes([]string{".yaml", ".yml"}, pkgPath, ignorer)
+	if err != nil {
+		return nil, err
+	}
+	for name := range files {
+		if strings.TrimSuffix(filepath.Base(name), filepath.Ext(name)) == hub.PackageMetadataFile {
+			delete(files, name)
+		}
+	}
+	if len(files) == 0 {
+		return nil, errNoFilesFound
+	}
+
+	// Keep only the files containing policies
+	data := make(map[string]interface{})
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	policies := make(map[string]string)
+	for _, name := range names {
+		content := files[name]
+		annotations, ok := getKyvernoPolicyAnnotations(content)
+		if !ok {
+			continue
+		}
+		policies[name] = content
+		if _, ok := data[kyvernoCategoryKey]; !ok && annotations[kyvernoCategoryAnnotation] != "" {
+			data[kyvernoCategoryKey] = annotations[kyvernoCategoryAnnotation]
+		}
+		if _, ok := data[kyvernoSeverityKey]; !ok && annotations[kyvernoSeverityAnnotation] != "" {
+			data[kyvernoSeverityKey] = annotations[kyvernoSeverityAnnotation]
+		}
+	}
+	if len(policies) == 0 {
+		return nil, errors.New("no policies found")
+	}
+	data["policies"] = policies
+
+	// Return package data field
+	return data, nil
+}
+
+// getKyvernoPolicyAnnotations returns the annotations of the first Kyverno
+// policy (ClusterPolicy or Policy) defined in the manifest provided. The
+// boolean returned indicates if a policy was found.
+func getKyvernoPolicyAnnotations(manifest string) (map[string]string, bool) {
+	dec := yaml.NewDecoder(bytes.NewReader([]byte(manifest)))
+	for {
+		var doc struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+			Metadata   struct {
+				Annotations map[string]string `yaml:"annotations"`
+			} `yaml:"metadata"`
+		}
+		if err := dec.Decode(&doc); err != nil {
+			return nil, false
+		}
+		if !strings.HasPrefix(doc.APIVersion, "kyverno.io/") {
+			continue
+		}
+		if doc.Kind == "ClusterPolicy" || doc.Kind == "Policy" {
+			return doc.Metadata.Annotations, true
+		}
+	}
+}
+
 // prepareOPAData reads and formats OPA specific data available in the path
 // provided, returning the resulting data structure.
 func prepareOPAData(pkgPath string, ignorer ignore.IgnoreParser) (map[string]interface{}, error) {
@@ -175,6 +509,12 @@ func prepareOPAData(pkgPath string, ignorer ignore.IgnoreParser) (map[string]int
 // getFilesWithSuffix returns the files with a given suffix in the path
 // provided, ignoring the ones the ignorer matches.
 func getFilesWithSuffix(suffix, pkgPath string, ignorer ignore.IgnoreParser) (map[string]string, error) {
+	return getFilesWithSuffixes([]string{suffix}, pkgPath, ignorer)
+}
+
+// getFilesWithSuffixes returns the files with any of the given suffixes in the
+// path provided, ignoring the ones the ignorer matches.
+func getFilesWithSuffixes(suffixes []string, pkgPath string, ignorer ignore.IgnoreParser) (map[string]string, error) {
 	files := make(map[string]string)
 	err := filepath.Walk(pkgPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -187,7 +527,7 @@ func getFilesWithSuffix(suffix, pkgPath string, ignorer ignore.IgnoreParser) (ma
 		if ignorer.MatchesPath(name) {
 			return nil
 		}
-		if !strings.HasSuffix(info.Name(), suffix) {
+		if !hasAnySuffix(info.Name(), suffixes) {
 			return nil
 		}
 		content, err := ioutil.ReadFile(path)
@@ -201,7 +541,17 @@ func getFilesWithSuffix(suffix, pkgPath string, ignorer ignore.IgnoreParser) (ma
 		return nil, err
 	}
 	if len(files) == 0 {
-		return nil, errors.New("no files found")
+		return nil, errNoFilesFound
 	}
 	return files, nil
 }
+
+// hasAnySuffix checks if the name provided ends with any of the suffixes.
+func hasAnySuffix(name string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
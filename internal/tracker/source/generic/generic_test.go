@@ -1,7 +1,10 @@
 package generic
 
 import (
+	"bytes"
 	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/artifacthub/hub/internal/hub"
@@ -9,6 +12,7 @@ import (
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/tracker/source"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestTrackerSource(t *testing.T) {
@@ -127,9 +131,11 @@ func TestTrackerSource(t *testing.T) {
 		sw.AssertExpectations(t)
 	})
 
-	t.Run("falco and opa packages must contain at least one data file", func(t *testing.T) {
+	t.Run("argo, falco, kyverno and opa packages must contain at least one data file", func(t *testing.T) {
 		repositories := []*hub.Repository{
+			{Kind: hub.ArgoWorkflowTemplate},
 			{Kind: hub.Falco},
+			{Kind: hub.Kyverno},
 			{Kind: hub.OPA},
 		}
 		for _, r := range repositories {
@@ -326,4 +332,427 @@ func TestTrackerSource(t *testing.T) {
 		assert.NoError(t, err)
 		sw.AssertExpectations(t)
 	})
+
+	t.Run("opa signed bundle returned, no keys available to verify it", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.OPA,
+			},
+			BasePath: "testdata/path18",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Data["policies"] = map[string]string{
+			"policy1.rego": "policy content\n",
+		}
+		p.Signed = true
+		p.SignatureKind = hub.OPABundleSignature
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("opa signed bundle returned, signature verified using repository keys", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		key, _ := ioutil.ReadFile("testdata/opa-key.pub")
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind:        hub.OPA,
+				SignKeyring: string(key),
+			},
+			BasePath: "testdata/path18",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.NoError(t, err)
+		assert.Len(t, packages, 1)
+		p := packages["pkg1@1.0.0"]
+		assert.True(t, p.Signed)
+		assert.Equal(t, hub.OPABundleSignature, p.SignatureKind)
+		assert.True(t, p.SignatureVerified)
+		assert.Equal(t, "test-key", p.SignatureIdentity)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("opa signed bundle returned, signature not verified using repository keys", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		key, _ := ioutil.ReadFile("testdata/opa-key2.pub")
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind:        hub.OPA,
+				SignKeyring: string(key),
+			},
+			BasePath: "testdata/path18",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+		expectedErr := "error verifying package pkg1 version 1.0.0 bundle signatures: repository keys: invalid signature"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.NoError(t, err)
+		assert.Len(t, packages, 1)
+		p := packages["pkg1@1.0.0"]
+		assert.False(t, p.Signed)
+		assert.Empty(t, p.SignatureKind)
+		assert.False(t, p.SignatureVerified)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("opa signed bundle returned, signature verified using metadata sign key", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		key, _ := ioutil.ReadFile("testdata/opa-key.pub")
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.OPA,
+			},
+			Metadata: &hub.RepositoryMetadata{
+				SignKey: &hub.SignKey{
+					URL: "https://key.url/opa-key.pub",
+				},
+			},
+			BasePath: "testdata/path18",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+		sw.Hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(key)),
+			StatusCode: http.StatusOK,
+		}, nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.NoError(t, err)
+		assert.Len(t, packages, 1)
+		p := packages["pkg1@1.0.0"]
+		assert.True(t, p.Signed)
+		assert.True(t, p.SignatureVerified)
+		assert.Equal(t, "test-key", p.SignatureIdentity)
+		sw.AssertExpectations(t)
+	})
+	t.Run("kyverno package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Kyverno,
+			},
+			BasePath: "testdata/path10",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		policyData, _ := ioutil.ReadFile("testdata/path10/policy.yaml")
+		policy2Data, _ := ioutil.ReadFile("testdata/path10/policy2.yml")
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Data["policies"] = map[string]string{
+			"policy.yaml": string(policyData),
+			"policy2.yml": string(policy2Data),
+		}
+		p.Data["kyverno/category"] = "Best Practices"
+		p.Data["kyverno/severity"] = "medium"
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+	t.Run("keda scaler package with invalid supported versions", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.KedaScaler,
+			},
+			BasePath: "testdata/path12",
+			Svc:      sw.Svc,
+		}
+		expectedErr := "error preparing package: error preparing package pkg1 version 1.0.0 data: invalid keda/version annotation: improper constraint: invalid"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("keda scaler package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.KedaScaler,
+			},
+			BasePath: "testdata/path11",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		exampleData, _ := ioutil.ReadFile("testdata/path11/scaledobject.yaml")
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Data["keda/version"] = ">= 2.10"
+		p.Data["examples"] = map[string]string{
+			"scaledobject.yaml": string(exampleData),
+		}
+		p.Data["triggers"] = []*kedaTrigger{
+			{
+				Type:     "external",
+				Metadata: []string{"queueName", "scalerAddress"},
+			},
+		}
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+	t.Run("argo workflow template package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.ArgoWorkflowTemplate,
+			},
+			BasePath: "testdata/path13",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		manifestData, _ := ioutil.ReadFile("testdata/path13/workflow-template.yaml")
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Data["manifests"] = map[string]string{
+			"workflow-template.yaml": string(manifestData),
+		}
+		p.Data["workflowTemplates"] = []*argoWorkflowTemplate{
+			{
+				Name:       "hello-world",
+				Kind:       "WorkflowTemplate",
+				Entrypoint: "say-hello",
+				Parameters: []*argoWorkflowParameter{
+					{
+						Name:        "message",
+						Value:       "hello world",
+						Description: "Message to print",
+					},
+					{
+						Name: "level",
+						Enum: []string{"info", "debug"},
+					},
+				},
+			},
+		}
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+	t.Run("backstage plugin package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Backstage,
+			},
+			BasePath: "testdata/path14",
+			Svc:      sw.Svc,
+		}
+		req, _ := http.NewRequest("GET", "https://registry.npmjs.org/-/package/@org%2fbackstage-plugin-pkg1/dist-tags", nil)
+		sw.Hc.On("Do", req).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"latest": "1.0.0", "next": "1.1.0-rc.0"}`)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Data["backstage/version"] = ">= 1.20.0"
+		p.Data["npmPackage"] = "@org/backstage-plugin-pkg1"
+		p.Data["role"] = "frontend-plugin"
+		p.Data["pluginId"] = "pkg1"
+		p.Data["backstageDependencies"] = map[string]string{
+			"@backstage/core-components": "^0.14.0",
+			"@backstage/core-plugin-api": "^1.9.0",
+		}
+		p.Data["npmDistTags"] = []string{"latest"}
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("headlamp plugin package with invalid archive checksum", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Headlamp,
+			},
+			BasePath: "testdata/path16",
+			Svc:      sw.Svc,
+		}
+		expectedErr := "error preparing package: error preparing package pkg1 version 1.0.0 data: invalid headlamp/plugin/archive-checksum annotation: expected format is SHA256:<hex>"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("headlamp plugin package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Headlamp,
+			},
+			BasePath: "testdata/path15",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Data["headlamp/plugin/archive-url"] = "https://github.com/org/pkg1/releases/download/v1.0.0/pkg1-1.0.0.tar.gz"
+		p.Data["headlamp/plugin/archive-checksum"] = "SHA256:f2d1c1a1e1b2f3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8"
+		p.Data["headlamp/plugin/version-compat"] = ">=0.20"
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("knative package without function or eventing resources", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Knative,
+			},
+			BasePath: "testdata/path4",
+			Svc:      sw.Svc,
+		}
+		expectedErr := "error preparing package: error preparing package pkg1 version 1.0.0 data: no function or eventing resources found"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("knative package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Knative,
+			},
+			BasePath: "testdata/path17",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		eventingData, _ := ioutil.ReadFile("testdata/path17/eventing.yaml")
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Data["runtime"] = "go"
+		p.Data["invoke"] = "cloudevent"
+		p.Data["builder"] = "pack"
+		p.Data["examples"] = map[string]string{
+			"eventing.yaml": string(eventingData),
+		}
+		p.Data["sources"] = []*knativeSource{
+			{
+				Name: "ping",
+				Kind: "PingSource",
+				Sink: "Broker/default",
+			},
+		}
+		p.Data["triggers"] = []*knativeTrigger{
+			{
+				Name:   "pkg1",
+				Broker: "default",
+				Filters: map[string]string{
+					"type": "dev.knative.sources.ping",
+				},
+				Subscriber: "Service/pkg1",
+			},
+		}
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,63 @@
+package generic
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	// headlampArchiveURLAnnotation represents the package metadata annotation
+	// used to define the url of the plugin archive (tarball).
+	headlampArchiveURLAnnotation = "headlamp/plugin/archive-url"
+
+	// headlampArchiveChecksumAnnotation represents the package metadata
+	// annotation used to define the checksum of the plugin archive.
+	headlampArchiveChecksumAnnotation = "headlamp/plugin/archive-checksum"
+
+	// headlampVersionCompatAnnotation represents the package metadata
+	// annotation used to define the Headlamp versions supported by a plugin
+	// (semver constraint).
+	headlampVersionCompatAnnotation = "headlamp/plugin/version-compat"
+)
+
+// headlampArchiveChecksumRE is a regexp used to validate the checksum of the
+// plugin archives (i.e. SHA256:<hex>).
+var headlampArchiveChecksumRE = regexp.MustCompile(`^(?i)sha256:[a-f0-9]{64}$`)
+
+// prepareHeadlampData validates the Headlamp plugins specific data available
+// in the package metadata provided. Plugins must provide the url and checksum
+// of the archive that Headlamp will install. The annotations are already part
+// of the package data, so no extra data is returned.
+func prepareHeadlampData(md *hub.PackageMetadata) (map[string]interface{}, error) {
+	// Validate plugin archive url
+	archiveURL, ok := md.Annotations[headlampArchiveURLAnnotation]
+	if !ok || archiveURL == "" {
+		return nil, fmt.Errorf("%s annotation not provided", headlampArchiveURLAnnotation)
+	}
+	u, err := url.Parse(archiveURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid %s annotation: invalid url", headlampArchiveURLAnnotation)
+	}
+
+	// Validate plugin archive checksum
+	checksum, ok := md.Annotations[headlampArchiveChecksumAnnotation]
+	if !ok || checksum == "" {
+		return nil, fmt.Errorf("%s annotation not provided", headlampArchiveChecksumAnnotation)
+	}
+	if !headlampArchiveChecksumRE.MatchString(checksum) {
+		return nil, fmt.Errorf("invalid %s annotation: expected format is SHA256:<hex>", headlampArchiveChecksumAnnotation)
+	}
+
+	// Validate supported Headlamp versions
+	if v, ok := md.Annotations[headlampVersionCompatAnnotation]; ok {
+		if _, err := semver.NewConstraint(v); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", headlampVersionCompatAnnotation, err)
+		}
+	}
+
+	return nil, nil
+}
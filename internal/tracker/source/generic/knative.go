@@ -0,0 +1,196 @@
+package generic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	ignore "github.com/sabhiram/go-gitignore"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// knativeFuncFile represents the name of the file that describes a Knative
+	// function project.
+	knativeFuncFile = "func.yaml"
+
+	// knativeDefaultInvoke represents the invocation type used by Knative
+	// functions when none is set.
+	knativeDefaultInvoke = "http"
+)
+
+// knativeFunction represents the subset of the fields of a Knative function
+// func.yaml file used to prepare the packages.
+type knativeFunction struct {
+	Name    string `yaml:"name"`
+	Runtime string `yaml:"runtime"`
+	Invoke  string `yaml:"invoke"`
+	Build   struct {
+		Builder string `yaml:"builder"`
+	} `yaml:"build"`
+}
+
+// knativeSource represents a Knative eventing source (i.e. PingSource or
+// ApiServerSource) or binding (SinkBinding) found in a package manifests.
+type knativeSource struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	Sink string `json:"sink,omitempty"`
+}
+
+// knativeTrigger represents a Knative eventing trigger found in a package
+// manifests.
+type knativeTrigger struct {
+	Name       string            `json:"name"`
+	Broker     string            `json:"broker,omitempty"`
+	Filters    map[string]string `json:"filters,omitempty"`
+	Subscriber string            `json:"subscriber,omitempty"`
+}
+
+// knativeDestination represents a Knative eventing destination, used as sink
+// by sources and as subscriber by triggers.
+type knativeDestination struct {
+	Ref struct {
+		Kind string `yaml:"kind"`
+		Name string `yaml:"name"`
+	} `yaml:"ref"`
+	URI string `yaml:"uri"`
+}
+
+// String returns a string representation of the destination.
+func (d *knativeDestination) String() string {
+	if d.Ref.Kind != "" && d.Ref.Name != "" {
+		return d.Ref.Kind + "/" + d.Ref.Name
+	}
+	return d.URI
+}
+
+// prepareKnativeData reads and formats Knative functions and eventing sources
+// specific data available in the path provided, returning the resulting data
+// structure. Packages must contain a function (func.yaml file) or at least
+// one manifest with eventing resources, which are kept as examples.
+func prepareKnativeData(pkgPath string, ignorer ignore.IgnoreParser) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+
+	// Read function details, if available
+	fn, err := getKnativeFunction(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	if fn != nil {
+		if fn.Runtime != "" {
+			data["runtime"] = fn.Runtime
+		}
+		data["invoke"] = fn.Invoke
+		if fn.Invoke == "" {
+			data["invoke"] = knativeDefaultInvoke
+		}
+		if fn.Build.Builder != "" {
+			data["builder"] = fn.Build.Builder
+		}
+	}
+
+	// Read eventing manifests files (sources, bindings and triggers)
+	files, err := getFilesWithSuffix(".yaml", pkgPath, ignorer)
+	if err != nil && !errors.Is(err, errNoFilesFound) {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	examples := make(map[string]string)
+	var sources []*knativeSource
+	var triggers []*knativeTrigger
+	for _, name := range names {
+		fileSources, fileTriggers := getKnativeEventingResources(files[name])
+		if len(fileSources) == 0 && len(fileTriggers) == 0 {
+			continue
+		}
+		examples[name] = files[name]
+		sources = append(sources, fileSources...)
+		triggers = append(triggers, fileTriggers...)
+	}
+	if fn == nil && len(examples) == 0 {
+		return nil, errors.New("no function or eventing resources found")
+	}
+	if len(examples) > 0 {
+		data["examples"] = examples
+	}
+	if len(sources) > 0 {
+		data["sources"] = sources
+	}
+	if len(triggers) > 0 {
+		data["triggers"] = triggers
+	}
+
+	// Return package data field
+	return data, nil
+}
+
+// getKnativeFunction returns the Knative function defined in the func.yaml
+// file located in the path provided, if any.
+func getKnativeFunction(pkgPath string) (*knativeFunction, error) {
+	data, err := ioutil.ReadFile(filepath.Join(pkgPath, knativeFuncFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading %s file: %w", knativeFuncFile, err)
+	}
+	fn := &knativeFunction{}
+	if err := yaml.Unmarshal(data, fn); err != nil {
+		return nil, fmt.Errorf("error parsing %s file: %w", knativeFuncFile, err)
+	}
+	return fn, nil
+}
+
+// getKnativeEventingResources returns the eventing sources (including sink
+// bindings) and triggers defined in the manifest provided.
+func getKnativeEventingResources(manifest string) ([]*knativeSource, []*knativeTrigger) {
+	var sources []*knativeSource
+	var triggers []*knativeTrigger
+	dec := yaml.NewDecoder(bytes.NewReader([]byte(manifest)))
+	for {
+		var doc struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+			Metadata   struct {
+				Name string `yaml:"name"`
+			} `yaml:"metadata"`
+			Spec struct {
+				Sink       knativeDestination `yaml:"sink"`
+				Broker     string             `yaml:"broker"`
+				Subscriber knativeDestination `yaml:"subscriber"`
+				Filter     struct {
+					Attributes map[string]string `yaml:"attributes"`
+				} `yaml:"filter"`
+			} `yaml:"spec"`
+		}
+		if err := dec.Decode(&doc); err != nil {
+			break
+		}
+		switch {
+		case strings.HasPrefix(doc.APIVersion, "sources.knative.dev/"):
+			sources = append(sources, &knativeSource{
+				Name: doc.Metadata.Name,
+				Kind: doc.Kind,
+				Sink: doc.Spec.Sink.String(),
+			})
+		case strings.HasPrefix(doc.APIVersion, "eventing.knative.dev/") && doc.Kind == "Trigger":
+			triggers = append(triggers, &knativeTrigger{
+				Name:       doc.Metadata.Name,
+				Broker:     doc.Spec.Broker,
+				Filters:    doc.Spec.Filter.Attributes,
+				Subscriber: doc.Spec.Subscriber.String(),
+			})
+		}
+	}
+	return sources, triggers
+}
@@ -0,0 +1,414 @@
+package generic
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	// Register the hash functions that can be used in OPA bundles signatures
+	_ "crypto/md5"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/repo"
+	ignore "github.com/sabhiram/go-gitignore"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// opaSignaturesFile represents the name of the file that holds the
+	// signatures of an OPA bundle.
+	opaSignaturesFile = ".signatures.json"
+
+	// opaManifestFile represents the name of the manifest file of an OPA
+	// bundle.
+	opaManifestFile = ".manifest"
+)
+
+var (
+	// errInvalidOPASignaturesFile indicates that the OPA bundle signatures
+	// file provided is not valid.
+	errInvalidOPASignaturesFile = errors.New("invalid signatures file")
+
+	// errUnsupportedSigningAlgorithm indicates that the algorithm used to sign
+	// an OPA bundle is not supported.
+	errUnsupportedSigningAlgorithm = errors.New("unsupported signing algorithm")
+
+	// opaHashes represents the hash algorithms supported in the files list of
+	// OPA bundles signatures.
+	opaHashes = map[string]crypto.Hash{
+		"MD5":         crypto.MD5,
+		"SHA-1":       crypto.SHA1,
+		"SHA-224":     crypto.SHA224,
+		"SHA-256":     crypto.SHA256,
+		"SHA-384":     crypto.SHA384,
+		"SHA-512":     crypto.SHA512,
+		"SHA-512-224": crypto.SHA512_224,
+		"SHA-512-256": crypto.SHA512_256,
+	}
+)
+
+// opaSignatureFile represents a file entry in the payload of an OPA bundle
+// signature.
+type opaSignatureFile struct {
+	Name      string `json:"name"`
+	Hash      string `json:"hash"`
+	Algorithm string `json:"algorithm"`
+}
+
+// opaSignaturePayload represents the payload of an OPA bundle signature.
+type opaSignaturePayload struct {
+	Files []*opaSignatureFile `json:"files"`
+	KeyID string              `json:"keyid"`
+	Scope string              `json:"scope"`
+}
+
+// checkOPABundleSignatures checks if the OPA bundle located in the path
+// provided is signed (has a signatures file) and verifies its signature using
+// the keys configured in the repository or, when not available, the sign key
+// set in the repository metadata file. The package is updated to reflect the
+// result of the verification. Bundles whose signature cannot be verified
+// using the repository keys are not considered signed.
+func (s *TrackerSource) checkOPABundleSignatures(p *hub.Package, pkgPath string, ignorer ignore.IgnoreParser) error {
+	// Check if the bundle is signed
+	signaturesFile, err := ioutil.ReadFile(filepath.Join(pkgPath, opaSignaturesFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("error reading signatures file: %w", err)
+	}
+	p.Signed = true
+	p.SignatureKind = hub.OPABundleSignature
+
+	// Verify signature using the keys available
+	r := s.i.Repository
+	if r.SignKeyring != "" || r.SignKeyringURL != "" {
+		identity, err := s.verifyOPABundle(pkgPath, ignorer, signaturesFile, r.SignKeyring, r.SignKeyringURL)
+		if err != nil {
+			p.Signed = false
+			p.SignatureKind = ""
+			return fmt.Errorf("repository keys: %w", err)
+		}
+		p.SignatureVerified = true
+		p.SignatureIdentity = identity
+		return nil
+	}
+	if s.i.Metadata == nil || s.i.Metadata.SignKey == nil || s.i.Metadata.SignKey.URL == "" {
+		return nil
+	}
+	identity, err := s.verifyOPABundle(pkgPath, ignorer, signaturesFile, "", s.i.Metadata.SignKey.URL)
+	if err != nil {
+		return err
+	}
+	p.SignatureVerified = true
+	p.SignatureIdentity = identity
+	return nil
+}
+
+// verifyOPABundle verifies the signature of the OPA bundle located in the path
+// provided using the PEM encoded public keys given (inline or referenced by
+// url). On success, the identity of the signer (key id) is returned.
+func (s *TrackerSource) verifyOPABundle(
+	pkgPath string,
+	ignorer ignore.IgnoreParser,
+	signaturesFile []byte,
+	keysData string,
+	keysURL string,
+) (string, error) {
+	// Load public keys (cached so that they are only loaded once per
+	// tracking run)
+	if s.opaKeys == nil {
+		s.opaKeys = make(map[string][]crypto.PublicKey)
+	}
+	cacheKey := keysURL
+	if cacheKey == "" {
+		cacheKey = keysData
+	}
+	keys, ok := s.opaKeys[cacheKey]
+	if !ok {
+		data := []byte(keysData)
+		var err error
+		if keysURL != "" {
+			data, err = s.getRemoteKeys(keysURL)
+			if err != nil {
+				return "", err
+			}
+		}
+		keys, err = repo.ParsePublicKeys(data)
+		if err != nil {
+			return "", err
+		}
+		s.opaKeys[cacheKey] = keys
+	}
+
+	// Verify signature and bundle files
+	payload, err := verifyOPASignatures(signaturesFile, keys)
+	if err != nil {
+		return "", err
+	}
+	files, err := getOPABundleFiles(pkgPath, ignorer)
+	if err != nil {
+		return "", err
+	}
+	if err := verifyOPABundleFiles(files, payload.Files); err != nil {
+		return "", err
+	}
+	return payload.KeyID, nil
+}
+
+// getRemoteKeys downloads the public keys located at the url provided.
+func (s *TrackerSource) getRemoteKeys(u string) ([]byte, error) {
+	req, _ := http.NewRequest("GET", u, nil)
+	req = req.WithContext(s.i.Svc.Ctx)
+	resp, err := s.i.Svc.Hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting sign key: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting sign key: unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading sign key: %w", err)
+	}
+	return data, nil
+}
+
+// verifyOPASignatures checks that the OPA bundle signatures file provided
+// holds a signature (JWS) created with any of the keys given, returning its
+// payload.
+func verifyOPASignatures(signaturesFile []byte, keys []crypto.PublicKey) (*opaSignaturePayload, error) {
+	var signatures struct {
+		Signatures []string `json:"signatures"`
+	}
+	if err := json.Unmarshal(signaturesFile, &signatures); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidOPASignaturesFile, err)
+	}
+	if len(signatures.Signatures) != 1 {
+		return nil, fmt.Errorf("%w: expected exactly one signature", errInvalidOPASignaturesFile)
+	}
+
+	// Decode token
+	parts := strings.Split(signatures.Signatures[0], ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", errInvalidOPASignaturesFile)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: invalid token header: %v", errInvalidOPASignaturesFile, err)
+	}
+	payload := &opaSignaturePayload{}
+	if err := decodeJWTSegment(parts[1], payload); err != nil {
+		return nil, fmt.Errorf("%w: invalid token payload: %v", errInvalidOPASignaturesFile, err)
+	}
+	if payload.KeyID == "" {
+		payload.KeyID = header.Kid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid token signature: %v", errInvalidOPASignaturesFile, err)
+	}
+
+	// Verify signature
+	signed := []byte(parts[0] + "." + parts[1])
+	for _, key := range keys {
+		err := verifyJWSSignature(header.Alg, key, signed, sig)
+		if err == nil {
+			return payload, nil
+		}
+		if errors.Is(err, errUnsupportedSigningAlgorithm) {
+			return nil, err
+		}
+	}
+	return nil, errors.New("invalid signature")
+}
+
+// decodeJWTSegment decodes the base64url encoded JSON segment of a token
+// provided into the value given.
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// verifyJWSSignature verifies the signature provided using the algorithm and
+// public key given. HMAC based algorithms are not supported, as they would
+// require sharing the secret used to sign the bundle.
+func verifyJWSSignature(alg string, key crypto.PublicKey, signed, sig []byte) error {
+	var h crypto.Hash
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		h = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		h = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		h = crypto.SHA512
+	case "EdDSA":
+		k, ok := key.(ed25519.PublicKey)
+		if !ok || !ed25519.Verify(k, signed, sig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedSigningAlgorithm, alg)
+	}
+	hasher := h.New()
+	_, _ = hasher.Write(signed)
+	digest := hasher.Sum(nil)
+
+	switch alg[:2] {
+	case "RS":
+		if k, ok := key.(*rsa.PublicKey); ok {
+			return rsa.VerifyPKCS1v15(k, h, digest, sig)
+		}
+	case "PS":
+		if k, ok := key.(*rsa.PublicKey); ok {
+			return rsa.VerifyPSS(k, h, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+		}
+	case "ES":
+		if k, ok := key.(*ecdsa.PublicKey); ok {
+			size := (k.Curve.Params().BitSize + 7) / 8
+			if len(sig) != 2*size {
+				return errors.New("invalid signature")
+			}
+			r := new(big.Int).SetBytes(sig[:size])
+			s := new(big.Int).SetBytes(sig[size:])
+			if ecdsa.Verify(k, digest, r, s) {
+				return nil
+			}
+		}
+	}
+	return errors.New("invalid signature")
+}
+
+// getOPABundleFiles returns the files of the OPA bundle located in the path
+// provided that are expected to be included in the bundle signature: policies
+// (.rego), data files (data.json and data.yaml) and the bundle manifest. The
+// files are indexed by their path relative to the bundle root.
+func getOPABundleFiles(pkgPath string, ignorer ignore.IgnoreParser) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.Walk(pkgPath, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("error reading files: %w", err)
+		}
+		if info.IsDir() {
+			return nil
+		}
+		name := filepath.ToSlash(strings.TrimPrefix(p, pkgPath+"/"))
+		if ignorer.MatchesPath(name) {
+			return nil
+		}
+		switch info.Name() {
+		case opaManifestFile, "data.json", "data.yaml", "data.yml":
+		default:
+			if filepath.Ext(info.Name()) != ".rego" {
+				return nil
+			}
+		}
+		content, err := ioutil.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("error reading file: %w", err)
+		}
+		files[name] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// verifyOPABundleFiles checks that the bundle files provided match the ones
+// listed in the signature payload, both in names and digests.
+func verifyOPABundleFiles(files map[string][]byte, signatureFiles []*opaSignatureFile) error {
+	pending := make(map[string][]byte, len(files))
+	for name, content := range files {
+		pending[name] = content
+	}
+	for _, sf := range signatureFiles {
+		name := strings.TrimPrefix(path.Clean("/"+sf.Name), "/")
+		content, ok := pending[name]
+		if !ok {
+			return fmt.Errorf("file %s included in signature not found in bundle", name)
+		}
+		delete(pending, name)
+		digest, err := hashOPABundleFile(name, content, sf.Algorithm)
+		if err != nil {
+			return fmt.Errorf("error hashing file %s: %w", name, err)
+		}
+		if !strings.EqualFold(digest, sf.Hash) {
+			return fmt.Errorf("digest mismatch for file %s", name)
+		}
+	}
+	for name := range pending {
+		return fmt.Errorf("file %s not included in signature", name)
+	}
+	return nil
+}
+
+// hashOPABundleFile returns the hex encoded digest of the bundle file provided
+// using the algorithm given. Like OPA does, JSON and YAML files are hashed in
+// their canonical JSON form (sorted keys, no insignificant whitespace).
+func hashOPABundleFile(name string, content []byte, algorithm string) (string, error) {
+	if algorithm == "" {
+		algorithm = "SHA-256"
+	}
+	h, ok := opaHashes[strings.ToUpper(algorithm)]
+	if !ok || !h.Available() {
+		return "", fmt.Errorf("unsupported hash algorithm: %s", algorithm)
+	}
+	switch {
+	case path.Base(name) == opaManifestFile, path.Ext(name) == ".json":
+		var err error
+		content, err = canonicalJSON(content)
+		if err != nil {
+			return "", err
+		}
+	case path.Ext(name) == ".yaml", path.Ext(name) == ".yml":
+		jsonContent, err := yaml.YAMLToJSON(content)
+		if err != nil {
+			return "", err
+		}
+		content, err = canonicalJSON(jsonContent)
+		if err != nil {
+			return "", err
+		}
+	}
+	hasher := h.New()
+	_, _ = hasher.Write(content)
+	return fmt.Sprintf("%x", hasher.Sum(nil)), nil
+}
+
+// canonicalJSON returns the canonical form of the JSON document provided.
+func canonicalJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
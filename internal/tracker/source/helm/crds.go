@@ -0,0 +1,105 @@
+package helm
+
+import (
+	"bytes"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+// crdDocument represents the subset of a CustomResourceDefinition document
+// used to summarize the CRDs included in a chart. Both apiextensions.k8s.io/v1
+// and v1beta1 documents are supported.
+type crdDocument struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Group string `yaml:"group"`
+		Names struct {
+			Kind string `yaml:"kind"`
+		} `yaml:"names"`
+		Version    string    `yaml:"version"`
+		Validation crdSchema `yaml:"validation"`
+		Versions   []struct {
+			Name    string    `yaml:"name"`
+			Served  bool      `yaml:"served"`
+			Storage bool      `yaml:"storage"`
+			Schema  crdSchema `yaml:"schema"`
+		} `yaml:"versions"`
+	} `yaml:"spec"`
+}
+
+// crdSchema represents the validation schema of a CRD version.
+type crdSchema struct {
+	OpenAPIV3Schema struct {
+		Description string `yaml:"description"`
+	} `yaml:"openAPIV3Schema"`
+}
+
+// extractCRDs returns a summary of the CRDs defined in the files available in
+// the crds directory of the chart provided, using the same format expected in
+// the crds annotation. Files or documents that cannot be parsed, as well as
+// documents that are not CRDs, are ignored.
+func extractCRDs(chrt *chart.Chart) []interface{} {
+	var crds []interface{}
+	processed := make(map[string]bool)
+	for _, file := range chrt.Files {
+		if !strings.HasPrefix(file.Name, "crds/") {
+			continue
+		}
+		switch path.Ext(file.Name) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(file.Data))
+		for {
+			var doc crdDocument
+			if err := dec.Decode(&doc); err != nil {
+				// Stop processing the file on EOF or on the first invalid
+				// document, as the decoder cannot recover from errors
+				break
+			}
+			crd := summarizeCRD(&doc)
+			if crd == nil || processed[doc.Metadata.Name] {
+				continue
+			}
+			processed[doc.Metadata.Name] = true
+			crds = append(crds, crd)
+		}
+	}
+	return crds
+}
+
+// summarizeCRD returns a summary of the CRD document provided, or nil if it
+// is not a valid CRD. The version used is the storage one, falling back to
+// the first version served when it is not set.
+func summarizeCRD(doc *crdDocument) map[string]interface{} {
+	if doc.Kind != "CustomResourceDefinition" || doc.Metadata.Name == "" || doc.Spec.Names.Kind == "" {
+		return nil
+	}
+	version := doc.Spec.Version
+	description := doc.Spec.Validation.OpenAPIV3Schema.Description
+	for _, v := range doc.Spec.Versions {
+		if v.Storage || (version == "" && v.Served) {
+			version = v.Name
+			if v.Schema.OpenAPIV3Schema.Description != "" {
+				description = v.Schema.OpenAPIV3Schema.Description
+			}
+		}
+	}
+	if version == "" {
+		return nil
+	}
+	return map[string]interface{}{
+		"kind":        doc.Spec.Names.Kind,
+		"version":     version,
+		"name":        doc.Metadata.Name,
+		"displayName": doc.Spec.Names.Kind,
+		"description": strings.TrimSpace(description),
+	}
+}
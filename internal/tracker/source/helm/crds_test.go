@@ -0,0 +1,112 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+func TestExtractCRDs(t *testing.T) {
+	t.Run("chart without crds", func(t *testing.T) {
+		t.Parallel()
+		chrt := &chart.Chart{
+			Metadata: &chart.Metadata{Name: "pkg1", Version: "1.0.0"},
+			Files: []*chart.File{
+				{Name: "README.md", Data: []byte("# pkg1")},
+			},
+		}
+		assert.Nil(t, extractCRDs(chrt))
+	})
+
+	t.Run("crds extracted successfully", func(t *testing.T) {
+		t.Parallel()
+		chrt := &chart.Chart{
+			Metadata: &chart.Metadata{Name: "pkg1", Version: "1.0.0"},
+			Files: []*chart.File{
+				{
+					Name: "crds/crds.yaml",
+					Data: []byte(`
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: backups.example.com
+spec:
+  group: example.com
+  names:
+    kind: Backup
+  versions:
+    - name: v1alpha1
+      served: true
+      storage: false
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          description: Backup of a database
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: restores.example.com
+spec:
+  group: example.com
+  names:
+    kind: Restore
+  version: v1beta1
+  validation:
+    openAPIV3Schema:
+      description: Restore of a database backup
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-crd
+`),
+				},
+				{
+					Name: "crds/duplicated.yml",
+					Data: []byte(`
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: backups.example.com
+spec:
+  group: example.com
+  names:
+    kind: Backup
+  versions:
+    - name: v2
+      served: true
+      storage: true
+`),
+				},
+				{
+					Name: "crds/invalid.yaml",
+					Data: []byte(`{"`),
+				},
+				{
+					Name: "crds/README.md",
+					Data: []byte("kind: CustomResourceDefinition"),
+				},
+			},
+		}
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{
+				"kind":        "Backup",
+				"version":     "v1",
+				"name":        "backups.example.com",
+				"displayName": "Backup",
+				"description": "Backup of a database",
+			},
+			map[string]interface{}{
+				"kind":        "Restore",
+				"version":     "v1beta1",
+				"name":        "restores.example.com",
+				"displayName": "Restore",
+				"description": "Restore of a database backup",
+			},
+		}, extractCRDs(chrt))
+	})
+}
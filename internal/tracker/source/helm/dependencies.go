@@ -0,0 +1,76 @@
+package helm
+
+import (
+	"sort"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/license"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+// maxDependenciesDepth represents the maximum depth of the dependencies tree
+// that will be extracted from a chart.
+const maxDependenciesDepth = 10
+
+// extractDependenciesTree builds the dependencies tree of the chart provided
+// from the sub-charts included in its archive, resolving them recursively.
+// Dependencies declared in the chart metadata that are not included in the
+// archive are listed as well, but their details cannot be resolved.
+func extractDependenciesTree(chrt *chart.Chart) []*hub.ChartDependency {
+	return buildDependenciesTree(chrt, 0)
+}
+
+// buildDependenciesTree returns the dependencies of the chart provided,
+// including the ones of each of the sub-charts.
+func buildDependenciesTree(chrt *chart.Chart, depth int) []*hub.ChartDependency {
+	if depth >= maxDependenciesDepth {
+		return nil
+	}
+
+	// Repositories are only available in the dependencies declared in the
+	// parent chart metadata
+	repositories := make(map[string]string)
+	for _, dep := range chrt.Metadata.Dependencies {
+		repositories[dep.Name] = dep.Repository
+	}
+
+	// Sub-charts included in the chart archive
+	var deps []*hub.ChartDependency
+	included := make(map[string]bool)
+	for _, subChart := range chrt.Dependencies() {
+		md := subChart.Metadata
+		dep := &hub.ChartDependency{
+			Name:       md.Name,
+			Version:    md.Version,
+			Repository: repositories[md.Name],
+			AppVersion: md.AppVersion,
+			Resolved:   true,
+		}
+		if licenseFile := getFile(subChart, "LICENSE"); licenseFile != nil {
+			dep.License = license.Detect(licenseFile.Data)
+		}
+		if images, err := extractContainersImages(subChart); err == nil && len(images) > 0 {
+			dep.ContainersImages = images
+		}
+		dep.Dependencies = buildDependenciesTree(subChart, depth+1)
+		deps = append(deps, dep)
+		included[md.Name] = true
+	}
+
+	// Dependencies declared but not included in the chart archive
+	for _, dep := range chrt.Metadata.Dependencies {
+		if included[dep.Name] {
+			continue
+		}
+		deps = append(deps, &hub.ChartDependency{
+			Name:       dep.Name,
+			Version:    dep.Version,
+			Repository: dep.Repository,
+		})
+	}
+
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].Name < deps[j].Name
+	})
+	return deps
+}
@@ -0,0 +1,102 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+func TestExtractDependenciesTree(t *testing.T) {
+	t.Run("chart without dependencies", func(t *testing.T) {
+		t.Parallel()
+		chrt := &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: "v2",
+				Name:       "pkg1",
+				Version:    "1.0.0",
+			},
+		}
+		assert.Nil(t, extractDependenciesTree(chrt))
+	})
+
+	t.Run("dependencies tree extracted successfully", func(t *testing.T) {
+		t.Parallel()
+		subSubChart := &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: "v2",
+				Name:       "sub-sub1",
+				Version:    "0.1.0",
+				AppVersion: "3.0.0",
+			},
+		}
+		subChart := &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: "v2",
+				Name:       "sub1",
+				Version:    "1.0.0",
+				AppVersion: "2.0.0",
+				Dependencies: []*chart.Dependency{
+					{
+						Name:       "sub-sub1",
+						Version:    "~0.1.0",
+						Repository: "https://sub-sub1.repo",
+					},
+				},
+			},
+			Templates: []*chart.File{
+				{
+					Name: "templates/deployment.yaml",
+					Data: []byte("containers:\n  - image: repo/img1:1.0.0\n"),
+				},
+			},
+		}
+		subChart.AddDependency(subSubChart)
+		chrt := &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: "v2",
+				Name:       "pkg1",
+				Version:    "1.0.0",
+				Dependencies: []*chart.Dependency{
+					{
+						Name:       "sub1",
+						Version:    "1.x.x",
+						Repository: "https://sub1.repo",
+					},
+					{
+						Name:       "not-included",
+						Version:    "2.0.0",
+						Repository: "https://not-included.repo",
+					},
+				},
+			},
+		}
+		chrt.AddDependency(subChart)
+
+		assert.Equal(t, []*hub.ChartDependency{
+			{
+				Name:       "not-included",
+				Version:    "2.0.0",
+				Repository: "https://not-included.repo",
+			},
+			{
+				Name:             "sub1",
+				Version:          "1.0.0",
+				Repository:       "https://sub1.repo",
+				AppVersion:       "2.0.0",
+				ContainersImages: []string{"repo/img1:1.0.0"},
+				Resolved:         true,
+				Dependencies: []*hub.ChartDependency{
+					{
+						Name:       "sub-sub1",
+						Version:    "0.1.0",
+						Repository: "https://sub-sub1.repo",
+						AppVersion: "3.0.0",
+						Resolved:   true,
+					},
+				},
+			},
+		}, extractDependenciesTree(chrt))
+	})
+}
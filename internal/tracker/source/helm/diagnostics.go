@@ -0,0 +1,99 @@
+package helm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/lint"
+	"helm.sh/helm/v3/pkg/lint/support"
+)
+
+const (
+	// maxChartDiagnostics represents the maximum number of diagnostics
+	// stored for a chart version.
+	maxChartDiagnostics = 25
+
+	diagnosticSeverityError   = "error"
+	diagnosticSeverityWarning = "warning"
+)
+
+// getChartDiagnostics lints the chart provided and renders its templates
+// using a Helm dry-run install with the default values, returning the
+// warnings and errors found. The render failure is only reported when the
+// linter didn't find any errors, as it'd usually be the same problem.
+func getChartDiagnostics(chrt *chart.Chart) []*hub.ChartDiagnostic {
+	diagnostics := lintChart(chrt)
+	hasErrors := false
+	for _, d := range diagnostics {
+		if d.Severity == diagnosticSeverityError {
+			hasErrors = true
+			break
+		}
+	}
+	if !hasErrors {
+		if d := getRenderDiagnostic(chrt); d != nil {
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	if len(diagnostics) > maxChartDiagnostics {
+		diagnostics = diagnostics[:maxChartDiagnostics]
+	}
+	return diagnostics
+}
+
+// lintChart runs the Helm linter on the chart provided. The linter works on
+// charts directories, so the chart is saved to a temporary one first.
+func lintChart(chrt *chart.Chart) []*hub.ChartDiagnostic {
+	tmpDir, err := ioutil.TempDir("", "artifact-hub")
+	if err != nil {
+		return nil
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := chartutil.SaveDir(chrt, tmpDir); err != nil {
+		return nil
+	}
+	chartDir := filepath.Join(tmpDir, chrt.Name())
+
+	var diagnostics []*hub.ChartDiagnostic
+	for _, msg := range lint.All(chartDir, nil, "default", false).Messages {
+		var severity string
+		switch msg.Severity {
+		case support.ErrorSev:
+			severity = diagnosticSeverityError
+		case support.WarningSev:
+			severity = diagnosticSeverityWarning
+		default:
+			continue
+		}
+		diagnostics = append(diagnostics, &hub.ChartDiagnostic{
+			Severity: severity,
+			Path:     msg.Path,
+			Message:  strings.ReplaceAll(msg.Err.Error(), chartDir+string(filepath.Separator), ""),
+		})
+	}
+	return diagnostics
+}
+
+// getRenderDiagnostic returns a diagnostic describing why the chart provided
+// could not be rendered, if that's the case. Charts requiring a Kubernetes
+// version not compatible with the one used to render them are skipped, as
+// that isn't a problem of the chart itself.
+func getRenderDiagnostic(chrt *chart.Chart) *hub.ChartDiagnostic {
+	kubeVersion := chrt.Metadata.KubeVersion
+	if kubeVersion != "" && !chartutil.IsCompatibleRange(kubeVersion, chartutil.DefaultCapabilities.KubeVersion.String()) {
+		return nil
+	}
+	if _, err := renderChart(chrt); err != nil {
+		return &hub.ChartDiagnostic{
+			Severity: diagnosticSeverityError,
+			Path:     "templates/",
+			Message:  err.Error(),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package helm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+func TestGetChartDiagnostics(t *testing.T) {
+	t.Run("chart rendered successfully", func(t *testing.T) {
+		t.Parallel()
+		chrt := &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: "v2",
+				Name:       "pkg1",
+				Version:    "1.0.0",
+			},
+			Templates: []*chart.File{
+				{
+					Name: "templates/configmap.yaml",
+					Data: []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+data:
+  key: value
+`),
+				},
+			},
+		}
+		for _, d := range getChartDiagnostics(chrt) {
+			assert.NotEqual(t, diagnosticSeverityError, d.Severity)
+		}
+	})
+
+	t.Run("chart with template errors", func(t *testing.T) {
+		t.Parallel()
+		chrt := &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: "v2",
+				Name:       "pkg1",
+				Version:    "1.0.0",
+			},
+			Templates: []*chart.File{
+				{
+					Name: "templates/configmap.yaml",
+					Data: []byte(`{{ .Values.missing.field }}`),
+				},
+			},
+		}
+		diagnostics := getChartDiagnostics(chrt)
+		require.NotEmpty(t, diagnostics)
+		var found bool
+		for _, d := range diagnostics {
+			if d.Severity == diagnosticSeverityError && strings.Contains(d.Message, "nil pointer") {
+				found = true
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("render skipped for incompatible kubernetes version", func(t *testing.T) {
+		t.Parallel()
+		chrt := &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion:  "v2",
+				Name:        "pkg1",
+				Version:     "1.0.0",
+				KubeVersion: "< 1.10.0",
+			},
+		}
+		assert.Nil(t, getRenderDiagnostic(chrt))
+	})
+}
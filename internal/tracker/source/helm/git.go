@@ -0,0 +1,198 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	helmrepo "helm.sh/helm/v3/pkg/repo"
+)
+
+// gitChart represents a chart loaded from a git repository.
+type gitChart struct {
+	chrt   *chart.Chart
+	digest string
+}
+
+// getChartsFromGit returns the charts available in the git repository cloned
+// in the base path provided to the tracker source. Charts are loaded from all
+// the tags in the repository as well as from the head of the branch cloned,
+// so that the versions released in the past remain available. When the same
+// chart version is found in several references, the first tag wins over the
+// branch. The charts loaded are kept in the tracker source so that they can
+// be used later when preparing the packages.
+func (s *TrackerSource) getChartsFromGit() (map[string][]*helmrepo.ChartVersion, error) {
+	// Parse repository url
+	gitURL := strings.TrimPrefix(s.i.Repository.URL, hub.RepositoryGitPrefix)
+	matches := repo.GitRepoURLRE.FindStringSubmatch(gitURL)
+	if matches == nil {
+		matches = repo.SSHGitRepoURLRE.FindStringSubmatch(gitURL)
+	}
+	if len(matches) != 4 {
+		return nil, errors.New("invalid repository url")
+	}
+	repoBaseURL := matches[1]
+	packagesPath := strings.Trim(matches[3], "/")
+
+	// Get references to load charts from (tags first, then branch head)
+	gr, err := git.PlainOpenWithOptions(s.i.BasePath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("error opening git repository: %w", err)
+	}
+	var refs []*plumbing.Reference
+	tags, err := gr.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("error getting git repository tags: %w", err)
+	}
+	_ = tags.ForEach(func(ref *plumbing.Reference) error {
+		refs = append(refs, ref)
+		return nil
+	})
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Name().Short() < refs[j].Name().Short()
+	})
+	head, err := gr.Head()
+	if err != nil {
+		return nil, fmt.Errorf("error getting git repository head: %w", err)
+	}
+	refs = append(refs, head)
+
+	// Load charts available in each of the references
+	charts := make(map[string][]*helmrepo.ChartVersion)
+	s.gitCharts = make(map[string]*gitChart)
+	seen := make(map[string]struct{})
+	for _, ref := range refs {
+		hash, err := gr.ResolveRevision(plumbing.Revision(ref.Name().String()))
+		if err != nil {
+			return nil, fmt.Errorf("error resolving git reference %s: %w", ref.Name().Short(), err)
+		}
+		commit, err := gr.CommitObject(*hash)
+		if err != nil {
+			return nil, fmt.Errorf("error getting git reference %s commit: %w", ref.Name().Short(), err)
+		}
+		tree, err := commit.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("error getting git reference %s tree: %w", ref.Name().Short(), err)
+		}
+		if packagesPath != "" {
+			tree, err = tree.Tree(packagesPath)
+			if err != nil {
+				// Packages path not available in this reference
+				continue
+			}
+		}
+		chartsDirs, err := getChartsDirs(tree)
+		if err != nil {
+			return nil, fmt.Errorf("error getting charts in git reference %s: %w", ref.Name().Short(), err)
+		}
+		for _, chartDir := range chartsDirs {
+			gc, err := loadChartFromTree(tree, chartDir)
+			if err != nil {
+				s.i.Svc.Logger.Warn().Err(err).Str("ref", ref.Name().Short()).Str("path", chartDir).
+					Msg("error loading chart from git repository")
+				continue
+			}
+			md := gc.chrt.Metadata
+			key := md.Name + "@" + md.Version
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			chartPath := path.Join(packagesPath, chartDir)
+			if chartPath == "." {
+				chartPath = ""
+			}
+			contentURL := fmt.Sprintf("%s%s/%s?ref=%s", hub.RepositoryGitPrefix, repoBaseURL, chartPath, ref.Name().Short())
+			s.gitCharts[contentURL] = gc
+			charts[md.Name] = append(charts[md.Name], &helmrepo.ChartVersion{
+				Metadata: md,
+				URLs:     []string{contentURL},
+				Created:  commit.Committer.When,
+				Digest:   gc.digest,
+			})
+		}
+	}
+
+	return charts, nil
+}
+
+// getChartsDirs returns the directories in the git tree provided that contain
+// a chart. Charts nested in other charts (i.e. sub-charts in the charts
+// directory) are not returned.
+func getChartsDirs(tree *object.Tree) ([]string, error) {
+	var dirs []string
+	err := tree.Files().ForEach(func(f *object.File) error {
+		if path.Base(f.Name) == chartutil.ChartfileName {
+			dirs = append(dirs, path.Dir(f.Name))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(dirs)
+	chartsDirs := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		nested := false
+		for _, chartDir := range chartsDirs {
+			if chartDir == "." || strings.HasPrefix(dir, chartDir+"/") {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			chartsDirs = append(chartsDirs, dir)
+		}
+	}
+	return chartsDirs, nil
+}
+
+// loadChartFromTree loads the chart located in the directory of the git tree
+// provided. The hash of the chart directory tree is used as the chart digest,
+// so it only changes when the content of the chart does.
+func loadChartFromTree(tree *object.Tree, chartDir string) (*gitChart, error) {
+	chartTree := tree
+	if chartDir != "." {
+		var err error
+		chartTree, err = tree.Tree(chartDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var files []*loader.BufferedFile
+	err := chartTree.Files().ForEach(func(f *object.File) error {
+		r, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		files = append(files, &loader.BufferedFile{Name: f.Name, Data: data})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	chrt, err := loader.LoadFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	return &gitChart{
+		chrt:   chrt,
+		digest: chartTree.Hash.String(),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package helm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChartsFromGit(t *testing.T) {
+	// Setup git repository with a chart released in a tag and a new version
+	// available in the branch head
+	dir, err := ioutil.TempDir("", "helm-git")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	gr, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := gr.Worktree()
+	require.NoError(t, err)
+	writeFile := func(name, content string) {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+	}
+	commit := func(msg string) {
+		_, err := wt.Add(".")
+		require.NoError(t, err)
+		_, err = wt.Commit(msg, &git.CommitOptions{
+			Author: &object.Signature{Name: "user1", Email: "user1@email.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+	}
+	writeFile("charts/chart1/Chart.yaml", "apiVersion: v2\nname: chart1\nversion: 0.1.0\n")
+	writeFile("charts/chart1/charts/sub1/Chart.yaml", "apiVersion: v2\nname: sub1\nversion: 1.0.0\n")
+	writeFile("other/Chart.yaml", "apiVersion: v2\nname: other\nversion: 1.0.0\n")
+	commit("chart1 0.1.0")
+	head, err := gr.Head()
+	require.NoError(t, err)
+	_, err = gr.CreateTag("chart1-0.1.0", head.Hash(), nil)
+	require.NoError(t, err)
+	writeFile("charts/chart1/Chart.yaml", "apiVersion: v2\nname: chart1\nversion: 0.2.0\n")
+	commit("chart1 0.2.0")
+
+	// Load charts from git repository
+	sw := source.NewTestsServicesWrapper()
+	i := &hub.TrackerSourceInput{
+		Repository: &hub.Repository{
+			Kind: hub.Helm,
+			URL:  "git+https://github.com/org1/repo1/charts",
+		},
+		BasePath: filepath.Join(dir, "charts"),
+		Svc:      sw.Svc,
+	}
+	s := NewTrackerSource(i)
+	charts, err := s.getChartsFromGit()
+	require.NoError(t, err)
+
+	// Check charts versions loaded
+	require.Len(t, charts, 1)
+	require.Len(t, charts["chart1"], 2)
+	v1, v2 := charts["chart1"][0], charts["chart1"][1]
+	assert.Equal(t, "0.1.0", v1.Version)
+	assert.Equal(t, []string{"git+https://github.com/org1/repo1/charts/chart1?ref=chart1-0.1.0"}, v1.URLs)
+	assert.Equal(t, "0.2.0", v2.Version)
+	assert.Equal(t, []string{"git+https://github.com/org1/repo1/charts/chart1?ref=master"}, v2.URLs)
+	assert.NotEqual(t, v1.Digest, v2.Digest)
+	require.Len(t, s.gitCharts, 2)
+	gc := s.gitCharts[v1.URLs[0]]
+	require.NotNil(t, gc)
+	require.Len(t, gc.chrt.Dependencies(), 1)
+	assert.Equal(t, "sub1", gc.chrt.Dependencies()[0].Name())
+	sw.AssertExpectations(t)
+}
@@ -3,6 +3,7 @@ package helm
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
@@ -16,17 +17,20 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
 	"github.com/artifacthub/hub/internal/license"
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/sbom"
 	"github.com/artifacthub/hub/internal/tracker/source"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/containerd/containerd/remotes/docker"
 	"github.com/deislabs/oras/pkg/content"
 	ctxo "github.com/deislabs/oras/pkg/context"
 	"github.com/deislabs/oras/pkg/oras"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/hashicorp/go-multierror"
-	"golang.org/x/time/rate"
+	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
@@ -36,11 +40,27 @@ import (
 )
 
 const (
-	concurrency = 10
-
+	// defaultConcurrency represents the default number of packages versions
+	// prepared concurrently.
+	defaultConcurrency = 10
+
+	// maxRateLimitedRetries represents the number of times the preparation
+	// of a package version is retried when the upstream server rate limits
+	// our requests.
+	maxRateLimitedRetries = 3
+
+	// maxKubeMinorVersion represents the highest Kubernetes minor version
+	// checked when normalizing the charts kubeVersion constraints. Ranges
+	// reaching it are considered open ended.
+	maxKubeMinorVersion = 99
+
+	aliasAnnotation                = "artifacthub.io/alias"
+	categoryAnnotation             = "artifacthub.io/category"
 	changesAnnotation              = "artifacthub.io/changes"
+	cosignKeyAnnotation            = "artifacthub.io/cosignKey"
 	crdsAnnotation                 = "artifacthub.io/crds"
 	crdsExamplesAnnotation         = "artifacthub.io/crdsExamples"
+	displayNameAnnotation          = "artifacthub.io/displayName"
 	imagesAnnotation               = "artifacthub.io/images"
 	licenseAnnotation              = "artifacthub.io/license"
 	linksAnnotation                = "artifacthub.io/links"
@@ -49,8 +69,10 @@ const (
 	operatorCapabilitiesAnnotation = "artifacthub.io/operatorCapabilities"
 	prereleaseAnnotation           = "artifacthub.io/prerelease"
 	recommendationsAnnotation      = "artifacthub.io/recommendations"
+	sbomAnnotation                 = "artifacthub.io/sbom"
 	securityUpdatesAnnotation      = "artifacthub.io/containsSecurityUpdates"
 	signKeyAnnotation              = "artifacthub.io/signKey"
+	testResultsAnnotation          = "artifacthub.io/testResults"
 
 	helmChartConfigMediaType       = "application/vnd.cncf.helm.config.v1+json"
 	helmChartContentLayerMediaType = "application/tar+gzip"
@@ -61,6 +83,18 @@ var (
 	// kubernetes manifests files.
 	containersImagesRE = regexp.MustCompile(`\simage:\s(\S+)`)
 
+	// kubeVersionRE is a regexp used to extract the Kubernetes versions
+	// referenced in the charts kubeVersion constraints.
+	kubeVersionRE = regexp.MustCompile(`\b1\.(\d+)\.(\d+)`)
+
+	// defaultIconFiles represents the files in the chart archive that will be
+	// used as the package logo when no icon is set in the chart metadata.
+	defaultIconFiles = []string{
+		"icon.png",
+		"icon.svg",
+		"icon.jpg",
+	}
+
 	// errInvalidAnnotation indicates that the annotation provided is not valid.
 	errInvalidAnnotation = errors.New("invalid annotation")
 
@@ -80,19 +114,39 @@ type TrackerSource struct {
 	i  *hub.TrackerSourceInput
 	il hub.HelmIndexLoader
 	tg hub.OCITagsGetter
+	rl hub.OCIRepositoriesLister
+	sc hub.OCISignatureChecker
+	bg hub.OCISBOMGetter
+	kc *signKeyringsCache
+
+	// gitCharts holds the charts loaded from git repositories, indexed by
+	// the chart url.
+	gitCharts map[string]*gitChart
 }
 
 // NewTrackerSource creates a new TrackerSource instance.
 func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource)) *TrackerSource {
-	s := &TrackerSource{i: i}
+	s := &TrackerSource{
+		i:  i,
+		kc: &signKeyringsCache{keyrings: make(map[string]*signKeyring)},
+	}
 	for _, o := range opts {
 		o(s)
 	}
 	if s.il == nil {
-		s.il = &repo.HelmIndexLoader{}
+		s.il = &repo.HelmIndexLoader{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
 	}
 	if s.tg == nil {
-		s.tg = &repo.OCITagsGetter{}
+		s.tg = &repo.OCITagsGetter{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	if s.rl == nil {
+		s.rl = &repo.OCIRepositoriesLister{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	if s.sc == nil {
+		s.sc = &repo.CosignSignatureChecker{Cfg: i.Svc.Cfg, Hc: i.Svc.Hc, Rl: i.Svc.Rl}
+	}
+	if s.bg == nil {
+		s.bg = &repo.OCISBOMGetter{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
 	}
 	return s
 }
@@ -107,49 +161,105 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 	if err != nil {
 		return nil, err
 	}
-	limiter := make(chan struct{}, concurrency)
-	var wg sync.WaitGroup
-	for _, chartVersions := range charts {
-		for _, chartVersion := range chartVersions {
-			// Return ASAP if context is cancelled
-			select {
-			case <-s.i.Svc.Ctx.Done():
-				wg.Wait()
-				return nil, s.i.Svc.Ctx.Err()
-			default:
-			}
+	var chartsVersions []*helmrepo.ChartVersion
+	for _, cvs := range charts {
+		chartsVersions = append(chartsVersions, cvs...)
+	}
 
-			// Prepare and store package version
-			limiter <- struct{}{}
-			wg.Add(1)
-			go func(chartVersion *helmrepo.ChartVersion) {
-				defer func() {
-					<-limiter
-					wg.Done()
-				}()
-				p, err := s.preparePackage(chartVersion)
+	// Prepare and store packages versions using a pool of workers
+	concurrency := s.concurrency(len(chartsVersions))
+	limiter := newAdaptiveLimiter(concurrency)
+	queue := make(chan *helmrepo.ChartVersion)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chartVersion := range queue {
+				p, err := s.preparePackageWithBackoff(limiter, chartVersion)
 				if err != nil {
-					s.warn(chartVersion.Metadata, fmt.Errorf("error preparing package: %w", err))
-					return
+					if s.i.Svc.Ctx.Err() == nil {
+						s.warn(chartVersion.Metadata, fmt.Errorf("error preparing package: %w", err))
+					}
+					continue
 				}
 				mu.Lock()
 				packagesAvailable[pkg.BuildKey(p)] = p
 				mu.Unlock()
-			}(chartVersion)
+			}
+		}()
+	}
+L:
+	for _, chartVersion := range chartsVersions {
+		// Stop ASAP if context is cancelled
+		select {
+		case queue <- chartVersion:
+		case <-s.i.Svc.Ctx.Done():
+			break L
 		}
 	}
+	close(queue)
 	wg.Wait()
+	if err := s.i.Svc.Ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	return packagesAvailable, nil
 }
 
+// concurrency returns the number of packages versions that will be prepared
+// concurrently, based on the number of versions available in the repository.
+func (s *TrackerSource) concurrency(versions int) int {
+	cfg := s.i.Svc.Cfg
+	concurrency := defaultConcurrency
+	if cfg.IsSet("tracker.helm.concurrency") {
+		concurrency = cfg.GetInt("tracker.helm.concurrency")
+	}
+	threshold := cfg.GetInt("tracker.helm.largeRepositoryThreshold")
+	if threshold > 0 && versions >= threshold && cfg.GetInt("tracker.helm.largeRepositoryConcurrency") > 0 {
+		concurrency = cfg.GetInt("tracker.helm.largeRepositoryConcurrency")
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if versions > 0 && concurrency > versions {
+		concurrency = versions
+	}
+	return concurrency
+}
+
+// preparePackageWithBackoff prepares the package version provided, honoring
+// the limiter given. When the upstream server rate limits our requests, the
+// preparation of the package is retried once the limiter allows it.
+func (s *TrackerSource) preparePackageWithBackoff(
+	limiter *adaptiveLimiter,
+	chartVersion *helmrepo.ChartVersion,
+) (*hub.Package, error) {
+	for attempt := 0; ; attempt++ {
+		if err := limiter.acquire(s.i.Svc.Ctx); err != nil {
+			return nil, err
+		}
+		p, err := s.preparePackage(chartVersion)
+		var rlErr *rateLimitedError
+		rateLimited := errors.As(err, &rlErr)
+		limiter.release(rlErr)
+		if !rateLimited || attempt == maxRateLimitedRetries {
+			return p, err
+		}
+		s.i.Svc.Logger.Debug().
+			Str("repo", s.i.Repository.Name).
+			Str("version", chartVersion.Version).
+			Msg("requests rate limited, backing off")
+	}
+}
+
 // getCharts returns the charts available in the repository.
 func (s *TrackerSource) getCharts() (map[string][]*helmrepo.ChartVersion, error) {
 	charts := make(map[string][]*helmrepo.ChartVersion)
 
 	u, _ := url.Parse(s.i.Repository.URL)
 	switch u.Scheme {
-	case "http", "https":
+	case "http", "https", "s3", "gs":
 		// Load repository index file
 		indexFile, _, err := s.il.LoadIndex(s.i.Repository)
 		if err != nil {
@@ -163,22 +273,41 @@ func (s *TrackerSource) getCharts() (map[string][]*helmrepo.ChartVersion, error)
 			}
 		}
 	case "oci":
-		// Get versions (tags) available in the repository
-		versions, err := s.tg.Tags(s.i.Svc.Ctx, s.i.Repository)
-		if err != nil {
-			return nil, fmt.Errorf("error getting repository available versions: %w", err)
+		if !repo.IsHelmOCINamespaceRepository(s.i.Repository) {
+			name, chartVersions, err := s.getOCIChartVersions(s.i.Repository.URL)
+			if err != nil {
+				return nil, fmt.Errorf("error getting repository available versions: %w", err)
+			}
+			charts[name] = chartVersions
+			break
 		}
 
-		// Prepare chart versions using the list of versions available
-		name := path.Base(s.i.Repository.URL)
-		for _, version := range versions {
-			charts[name] = append(charts[name], &helmrepo.ChartVersion{
-				Metadata: &chart.Metadata{
-					Name:    name,
-					Version: version,
-				},
-				URLs: []string{s.i.Repository.URL + ":" + version},
-			})
+		// The repository points to a namespace, so we need to discover the
+		// charts repositories available under it
+		refs, err := s.rl.List(s.i.Svc.Ctx, s.i.Repository)
+		if err != nil {
+			return nil, fmt.Errorf("error listing repositories in namespace: %w", err)
+		}
+		for _, ref := range refs {
+			chartURL := hub.RepositoryOCIPrefix + ref
+			name, chartVersions, err := s.getOCIChartVersions(chartURL)
+			if err == nil && len(charts[name]) > 0 {
+				err = fmt.Errorf("chart %s already available in namespace", name)
+			}
+			if err != nil {
+				err = fmt.Errorf("error getting chart available versions: %w (url: %s)", err, chartURL)
+				s.i.Svc.Logger.Warn().Err(err).Send()
+				s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+				continue
+			}
+			charts[name] = chartVersions
+		}
+	case "git+https", "git+ssh":
+		// Load charts stored unpackaged in the git repository
+		var err error
+		charts, err = s.getChartsFromGit()
+		if err != nil {
+			return nil, fmt.Errorf("error loading charts from git repository: %w", err)
 		}
 	default:
 		return nil, repo.ErrSchemeNotSupported
@@ -187,6 +316,38 @@ func (s *TrackerSource) getCharts() (map[string][]*helmrepo.ChartVersion, error)
 	return charts, nil
 }
 
+// getOCIChartVersions returns the name and versions of the chart stored in the
+// OCI repository provided. The chart name is expected to match the repository
+// basename, and each of the versions is expected to match a tag.
+func (s *TrackerSource) getOCIChartVersions(u string) (string, []*helmrepo.ChartVersion, error) {
+	// Get versions (tags) available in the repository. The credentials of the
+	// tracked repository are used for all the charts in a namespace.
+	r := s.i.Repository
+	if u != r.URL {
+		rCopy := *r
+		rCopy.URL = u
+		r = &rCopy
+	}
+	versions, err := s.tg.Tags(s.i.Svc.Ctx, r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Prepare chart versions using the list of versions available
+	name := path.Base(u)
+	chartVersions := make([]*helmrepo.ChartVersion, 0, len(versions))
+	for _, version := range versions {
+		chartVersions = append(chartVersions, &helmrepo.ChartVersion{
+			Metadata: &chart.Metadata{
+				Name:    name,
+				Version: version,
+			},
+			URLs: []string{u + ":" + version},
+		})
+	}
+	return name, chartVersions, nil
+}
+
 // preparePackage prepares a package version using the chart version provided.
 func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hub.Package, error) {
 	// Parse package version
@@ -233,20 +394,31 @@ func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hu
 	bypassDigestCheck := s.i.Svc.Cfg.GetBool("tracker.bypassDigestCheck")
 	digest, ok := s.i.PackagesRegistered[pkg.BuildKey(p)]
 	if !ok || chartVersion.Digest != digest || bypassDigestCheck {
-		// Load chart from remote archive
-		chrt, err := LoadChartArchive(
-			s.i.Svc.Ctx,
-			chartURL,
-			&LoadChartArchiveOptions{
-				HC:          s.i.Svc.Hc,
-				GithubToken: s.i.Svc.Cfg.GetString("creds.githubToken"),
-				GithubRL:    s.i.Svc.GithubRL,
-				Username:    s.i.Repository.AuthUser,
-				Password:    s.i.Repository.AuthPass,
-			},
-		)
-		if err != nil {
-			return nil, fmt.Errorf("error loading chart (%s): %w", chartURL.String(), err)
+		// Load chart from remote archive (or from the charts already loaded
+		// when the repository is a git one)
+		var chrt *chart.Chart
+		var chartDigest string
+		if gc, ok := s.gitCharts[chartVersion.URLs[0]]; ok {
+			chrt, chartDigest = gc.chrt, gc.digest
+		} else {
+			chrt, chartDigest, err = loadChartArchive(
+				s.i.Svc.Ctx,
+				chartURL,
+				&LoadChartArchiveOptions{
+					Cfg:            s.i.Svc.Cfg,
+					HC:             s.i.Svc.Hc,
+					GithubToken:    s.i.Svc.Cfg.GetString("creds.githubToken"),
+					Rl:             s.i.Svc.Rl,
+					RepositoryName: s.i.Repository.Name,
+					Username:       s.i.Repository.AuthUser,
+					Password:       s.i.Repository.AuthPass,
+					Cache:          s.i.Svc.Cc,
+					Digest:         chartVersion.Digest,
+				},
+			)
+			if err != nil {
+				return nil, fmt.Errorf("error loading chart (%s): %w", chartURL.String(), err)
+			}
 		}
 		md := chrt.Metadata
 
@@ -255,63 +427,108 @@ func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hu
 			return nil, fmt.Errorf("invalid metadata: %w", err)
 		}
 
-		// Store logo when available if requested
-		if md.Icon != "" {
-			logoImageID, err := s.i.Svc.Is.DownloadAndSaveImage(s.i.Svc.Ctx, md.Icon)
-			if err == nil {
-				p.LogoURL = md.Icon
-				p.LogoImageID = logoImageID
-			} else {
-				s.warn(md, fmt.Errorf("error getting logo image %s: %w", md.Icon, err))
-			}
+		// Store logo when available
+		if err := storeLogo(s.i.Svc.Ctx, s.i.Svc.Is, p, chrt, chartURL); err != nil {
+			s.warn(md, err)
 		}
 
 		// Check if the chart version is signed (has provenance file)
+		var prov []byte
 		if repo.SchemeIsHTTP(chartURL) {
-			hasProvenanceFile, err := s.chartHasProvenanceFile(chartURL.String())
+			prov, err = s.getProvenanceFile(chartURL.String())
 			if err != nil {
 				s.warn(md, fmt.Errorf("error checking provenance file: %w", err))
 			}
-			if hasProvenanceFile {
-				p.Signed = hasProvenanceFile
+			if prov != nil {
+				p.Signed = true
+				p.SignatureKind = hub.ProvenanceSignature
 			}
 		}
 
 		// Enrich package with data available in chart archive
 		EnrichPackageFromChart(p, chrt)
 
+		// Lint the chart and collect the problems found rendering its
+		// templates when requested
+		if s.i.Svc.Cfg.GetBool("tracker.helm.diagnostics") {
+			p.ChartDiagnostics = getChartDiagnostics(chrt)
+		}
+
 		// Enrich package with information from annotations
 		if err := EnrichPackageFromAnnotations(p, chrt.Metadata.Annotations); err != nil {
 			return nil, fmt.Errorf("error enriching package from annotations: %w", err)
 		}
-	}
 
-	return p, nil
-}
+		// Verify provenance file signature when a sign key is available. The
+		// keyring configured in the repository takes precedence over the key
+		// referenced in the package annotations, which takes precedence over
+		// the one set in the repository metadata file. Charts whose signature
+		// cannot be verified using the repository keyring are not considered
+		// signed.
+		if prov != nil {
+			if err := s.checkProvenanceSignature(p, prov, chartDigest); err != nil {
+				s.warn(md, fmt.Errorf("error verifying provenance file: %w", err))
+			}
+		}
 
-// chartHasProvenanceFile checks if a chart version has a provenance file
-// checking if a .prov file exists for the chart version url provided.
-func (s *TrackerSource) chartHasProvenanceFile(u string) (bool, error) {
-	req, _ := http.NewRequest("GET", u+".prov", nil)
-	if s.i.Repository.AuthUser != "" || s.i.Repository.AuthPass != "" {
-		req.SetBasicAuth(s.i.Repository.AuthUser, s.i.Repository.AuthPass)
-	}
-	resp, err := s.i.Svc.Hc.Do(req)
-	if err != nil {
-		return false, err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return false, nil
-	}
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return false, fmt.Errorf("error reading provenance file: %w", err)
-	}
-	if !bytes.Contains(data, []byte("PGP SIGNATURE")) {
-		return false, errors.New("invalid provenance file")
+		// Check if the chart version has been signed using cosign (only
+		// available for charts stored in OCI registries). The cosign key
+		// referenced in the package annotations takes precedence over the one
+		// set in the repository metadata file. Keyless signatures are only
+		// verified against the cosign identity set in the repository metadata
+		// file.
+		if chartURL.Scheme == "oci" {
+			cosignKey, err := getCosignKey(chrt.Metadata.Annotations)
+			if err != nil {
+				s.warn(md, err)
+			}
+			var cosignIdentity *hub.CosignIdentity
+			if s.i.Metadata != nil {
+				if cosignKey == nil {
+					cosignKey = s.i.Metadata.CosignKey
+				}
+				cosignIdentity = s.i.Metadata.CosignIdentity
+			}
+			check, err := s.sc.Check(s.i.Svc.Ctx, s.i.Repository, chartURL.String(), cosignKey, cosignIdentity)
+			if err != nil {
+				s.warn(md, fmt.Errorf("error checking cosign signatures: %w", err))
+			}
+			if check != nil {
+				if check.Signed {
+					p.Signed = true
+					p.SignatureKind = hub.CosignSignature
+					p.SignatureVerified = check.Verified
+					p.SignatureIdentity = check.Identity
+				}
+				p.HasAttestations = check.HasAttestations
+			}
+		}
+
+		// Get the SBOM of the chart version when available
+		sbomData, err := s.getSBOM(chartURL, chrt.Metadata.Annotations[sbomAnnotation])
+		if err == nil && sbomData != nil {
+			var format hub.SBOMFormat
+			format, err = sbom.Parse(sbomData)
+			if err == nil {
+				p.SBOM = &hub.SBOM{Format: format, Data: sbomData}
+			}
+		}
+		if err != nil {
+			s.warn(md, fmt.Errorf("error getting sbom: %w", err))
+		}
+
+		// Get the test results of the chart version when available
+		if testResultsURL := chrt.Metadata.Annotations[testResultsAnnotation]; testResultsURL != "" {
+			testResults, err := s.getTestResults(testResultsURL)
+			if err != nil {
+				s.warn(md, fmt.Errorf("error getting test results: %w", err))
+			} else {
+				p.TestResults = testResults
+			}
+		}
 	}
-	return true, nil
+
+	return p, nil
 }
 
 // warn is a helper that sends the error provided to the errors collector and
@@ -325,34 +542,86 @@ func (s *TrackerSource) warn(md *chart.Metadata, err error) {
 }
 
 // LoadChartArchiveOptions represents some options that can be provided to load
-// a chart archive from its remote location.
+// a chart archive from its remote location. When a requests limiter is
+// provided, the limits configured for the repository and the host of the
+// chart archive will be honored. When a charts cache and the expected digest
+// of the archive are provided, the archive will be read from the cache when
+// available, and stored in it after downloading it otherwise.
 type LoadChartArchiveOptions struct {
-	HC          hub.HTTPClient
-	Username    string
-	Password    string
-	GithubToken string
-	GithubRL    *rate.Limiter
+	Cfg            *viper.Viper
+	HC             hub.HTTPClient
+	Username       string
+	Password       string
+	GithubToken    string
+	Rl             hub.RequestsLimiter
+	RepositoryName string
+	Cache          hub.ChartsCache
+	Digest         string
 }
 
 // LoadChartArchive loads a chart from a remote archive located at the url
 // provided.
 func LoadChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOptions) (*chart.Chart, error) {
+	chrt, _, err := loadChartArchive(ctx, u, o)
+	return chrt, err
+}
+
+// loadChartArchive loads a chart from a remote archive located at the url
+// provided, returning as well the sha256 digest of the archive.
+func loadChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOptions) (*chart.Chart, string, error) {
+	// Try loading the chart from the cache first
+	useCache := o.Cache != nil && o.Digest != ""
+	if useCache {
+		data, err := o.Cache.Get(ctx, o.Digest)
+		if err == nil && fmt.Sprintf("%x", sha256.Sum256(data)) == o.Digest {
+			if chrt, err := loader.LoadArchive(bytes.NewReader(data)); err == nil {
+				return chrt, o.Digest, nil
+			}
+		}
+	}
+
+	// Get chart archive from its remote location and load it
+	data, err := getChartArchive(ctx, u, o)
+	if err != nil {
+		return nil, "", err
+	}
+	chrt, err := loader.LoadArchive(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	digest := fmt.Sprintf("%x", sha256.Sum256(data))
+
+	// Store the archive in the cache when it matches the digest expected
+	if useCache && digest == o.Digest {
+		_ = o.Cache.Put(ctx, digest, data)
+	}
+
+	return chrt, digest, nil
+}
+
+// getChartArchive gets the content of the chart archive located at the url
+// provided.
+func getChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOptions) ([]byte, error) {
 	var r io.Reader
 
+	// Honor the requests limits configured, if any
+	if o.Rl != nil {
+		release, err := o.Rl.Acquire(ctx, o.RepositoryName, u.Host)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
 	switch u.Scheme {
 	case "http", "https":
 		// Get chart content
 		req, _ := http.NewRequest("GET", u.String(), nil)
 		req = req.WithContext(ctx)
 		req.Header.Set("Accept-Encoding", "*")
-		if u.Host == "github.com" || u.Host == "raw.githubusercontent.com" {
-			// Authenticate and rate limit requests to Github
-			if o.GithubToken != "" {
-				req.Header.Set("Authorization", fmt.Sprintf("token %s", o.GithubToken))
-			}
-			if o.GithubRL != nil {
-				_ = o.GithubRL.Wait(ctx)
-			}
+		if (u.Host == "github.com" || u.Host == "raw.githubusercontent.com") && o.GithubToken != "" {
+			// Authenticate requests to Github
+			req.Header.Set("Authorization", fmt.Sprintf("token %s", o.GithubToken))
 		}
 		if o.Username != "" || o.Password != "" {
 			req.SetBasicAuth(o.Username, o.Password)
@@ -366,20 +635,27 @@ func LoadChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOption
 			return nil, err
 		}
 		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return nil, newRateLimitedError(resp)
+		}
 		if resp.StatusCode != http.StatusOK {
 			return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
 		}
 		r = resp.Body
+	case "s3", "gs":
+		// Get chart content from object storage bucket
+		obj, err := repo.GetRepositoryObject(ctx, o.Cfg, u)
+		if err != nil {
+			return nil, err
+		}
+		defer obj.Close()
+		r = obj
 	case "oci":
 		// Pull reference layers from OCI registry
 		ref := strings.TrimPrefix(u.String(), hub.RepositoryOCIPrefix)
-		resolverOptions := docker.ResolverOptions{}
-		if o.Username != "" || o.Password != "" {
-			resolverOptions.Authorizer = docker.NewDockerAuthorizer(
-				docker.WithAuthCreds(func(string) (string, string, error) {
-					return o.Username, o.Password, nil
-				}),
-			)
+		resolverOptions, err := newOCIResolverOptions(o.Cfg, ref, o.Username, o.Password)
+		if err != nil {
+			return nil, err
 		}
 		store := content.NewMemoryStore()
 		_, layers, err := oras.Pull(
@@ -411,12 +687,43 @@ func LoadChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOption
 		return nil, repo.ErrSchemeNotSupported
 	}
 
-	// Load chart from reader previously set up
-	chrt, err := loader.LoadArchive(r)
+	// Read chart archive from reader previously set up
+	return io.ReadAll(r)
+}
+
+// newOCIResolverOptions prepares the options of the resolver used to pull the
+// reference provided, setting up the authentication mechanism that matches the
+// credentials available for the registry (basic, identity or registry token).
+func newOCIResolverOptions(cfg *viper.Viper, ref, username, password string) (docker.ResolverOptions, error) {
+	var resolverOptions docker.ResolverOptions
+	nameRef, err := name.ParseReference(ref)
 	if err != nil {
-		return nil, err
+		return resolverOptions, err
+	}
+	authConfig, err := repo.GetOCIAuthConfig(cfg, nameRef.Context().Registry, username, password)
+	if err != nil {
+		return resolverOptions, err
+	}
+	switch {
+	case authConfig.RegistryToken != "":
+		resolverOptions.Headers = http.Header{}
+		resolverOptions.Headers.Set("Authorization", "Bearer "+authConfig.RegistryToken)
+	case authConfig.IdentityToken != "":
+		// An empty username makes the authorizer use the secret as a refresh
+		// token when requesting the registry access token
+		resolverOptions.Authorizer = docker.NewDockerAuthorizer(
+			docker.WithAuthCreds(func(string) (string, string, error) {
+				return "", authConfig.IdentityToken, nil
+			}),
+		)
+	case authConfig.Username != "" || authConfig.Password != "":
+		resolverOptions.Authorizer = docker.NewDockerAuthorizer(
+			docker.WithAuthCreds(func(string) (string, string, error) {
+				return authConfig.Username, authConfig.Password, nil
+			}),
+		)
 	}
-	return chrt, nil
+	return resolverOptions, nil
 }
 
 // EnrichPackageFromChart adds some extra information to the package from the
@@ -428,7 +735,6 @@ func EnrichPackageFromChart(p *hub.Package, chrt *chart.Chart) {
 	p.HomeURL = md.Home
 	p.AppVersion = md.AppVersion
 	p.Deprecated = md.Deprecated
-	p.ValuesSchema = chrt.Schema
 	p.Data = map[string]interface{}{}
 
 	// API version
@@ -446,6 +752,13 @@ func EnrichPackageFromChart(p *hub.Package, chrt *chart.Chart) {
 		}
 	}
 
+	// CRDs (the ones provided in the crds annotation take precedence)
+	if _, ok := md.Annotations[crdsAnnotation]; !ok {
+		if crds := extractCRDs(chrt); len(crds) > 0 {
+			p.CRDs = crds
+		}
+	}
+
 	// Dependencies
 	dependencies := make([]map[string]string, 0, len(md.Dependencies))
 	for _, dependency := range md.Dependencies {
@@ -459,8 +772,16 @@ func EnrichPackageFromChart(p *hub.Package, chrt *chart.Chart) {
 		p.Data["dependencies"] = dependencies
 	}
 
+	// Dependencies tree
+	if dependenciesTree := extractDependenciesTree(chrt); len(dependenciesTree) > 0 {
+		p.DependenciesTree = dependenciesTree
+	}
+
 	// Kubernetes version
 	p.Data["kubeVersion"] = chrt.Metadata.KubeVersion
+	if ranges := getKubeVersionRanges(chrt.Metadata.KubeVersion); ranges != nil {
+		p.Data["kubeVersionRanges"] = ranges
+	}
 
 	// License
 	licenseFile := getFile(chrt, "LICENSE")
@@ -507,30 +828,36 @@ func EnrichPackageFromChart(p *hub.Package, chrt *chart.Chart) {
 
 	// Type
 	p.Data["type"] = chrt.Metadata.Type
+
+	// Values schema (inferred from the default values when not provided)
+	if len(chrt.Schema) > 0 {
+		p.ValuesSchema = chrt.Schema
+	} else if len(chrt.Values) > 0 {
+		valuesSchema, err := inferValuesSchema(chrt.Values)
+		if err == nil {
+			p.ValuesSchema = valuesSchema
+			p.ValuesSchemaInferred = true
+		}
+	}
+
+	// Values documentation
+	valuesDocs, err := extractValuesDocs(chrt)
+	if err == nil && len(valuesDocs) > 0 {
+		p.ValuesDocs = valuesDocs
+	}
 }
 
 // extractContainersImages extracts the containers images references found in
 // the manifest generated as a result of Helm dry-run install with the default
 // values.
 func extractContainersImages(chrt *chart.Chart) ([]string, error) {
-	// Dry-run Helm install
-	install := action.NewInstall(&action.Configuration{
-		Log: func(string, ...interface{}) {},
-	})
-	install.ReleaseName = "release-name"
-	install.DryRun = true
-	install.DisableHooks = true
-	install.Replace = true
-	install.ClientOnly = true
-	install.IncludeCRDs = true
-	install.DependencyUpdate = false
-	release, err := install.Run(chrt, chartutil.Values{})
+	manifest, err := renderChart(chrt)
 	if err != nil {
 		return nil, err
 	}
 
 	// Extract containers images from release manifest
-	results := containersImagesRE.FindAllStringSubmatch(release.Manifest, -1)
+	results := containersImagesRE.FindAllStringSubmatch(manifest, -1)
 	images := make([]string, 0, len(results))
 	for _, result := range results {
 		image := strings.Trim(result[1], `"'`)
@@ -542,11 +869,45 @@ func extractContainersImages(chrt *chart.Chart) ([]string, error) {
 	return images, nil
 }
 
+// renderChart renders the chart provided using a Helm dry-run install with the
+// default values, returning the resulting release manifest.
+func renderChart(chrt *chart.Chart) (string, error) {
+	install := action.NewInstall(&action.Configuration{
+		Log: func(string, ...interface{}) {},
+	})
+	install.ReleaseName = "release-name"
+	install.DryRun = true
+	install.DisableHooks = true
+	install.Replace = true
+	install.ClientOnly = true
+	install.IncludeCRDs = true
+	install.DependencyUpdate = false
+	release, err := install.Run(chrt, chartutil.Values{})
+	if err != nil {
+		return "", err
+	}
+	return release.Manifest, nil
+}
+
 // EnrichPackageFromAnnotations adds some extra information to the package from
 // the provided annotations.
 func EnrichPackageFromAnnotations(p *hub.Package, annotations map[string]string) error {
 	var result *multierror.Error
 
+	// Alias
+	if v, ok := annotations[aliasAnnotation]; ok && v != "" {
+		p.Alias = v
+	}
+
+	// Category
+	if v, ok := annotations[categoryAnnotation]; ok && v != "" {
+		if !hub.IsValidPackageCategory(v) {
+			result = multierror.Append(result, fmt.Errorf("%w: invalid category value: %s", errInvalidAnnotation, v))
+		} else {
+			p.Category = v
+		}
+	}
+
 	// Changes
 	if v, ok := annotations[changesAnnotation]; ok {
 		changes, err := source.ParseChangesAnnotation(v)
@@ -577,6 +938,11 @@ func EnrichPackageFromAnnotations(p *hub.Package, annotations map[string]string)
 		}
 	}
 
+	// Display name
+	if v, ok := annotations[displayNameAnnotation]; ok && v != "" {
+		p.DisplayName = v
+	}
+
 	// Images
 	if v, ok := annotations[imagesAnnotation]; ok {
 		var images []*hub.ContainerImage
@@ -701,6 +1067,165 @@ func EnrichPackageFromAnnotations(p *hub.Package, annotations map[string]string)
 	return result.ErrorOrNil()
 }
 
+// getCosignKey returns the key used to verify the cosign signatures of the
+// chart version from the annotations provided, when available.
+func getCosignKey(annotations map[string]string) (*hub.SignKey, error) {
+	v, ok := annotations[cosignKeyAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var cosignKey *hub.SignKey
+	if err := yaml.Unmarshal([]byte(v), &cosignKey); err != nil {
+		return nil, fmt.Errorf("%w: invalid cosign key value", errInvalidAnnotation)
+	}
+	if cosignKey == nil || cosignKey.URL == "" {
+		return nil, fmt.Errorf("%w: cosign key url not provided", errInvalidAnnotation)
+	}
+	return cosignKey, nil
+}
+
+// isRemoteIcon checks if the icon provided references an image that must be
+// downloaded from an external location (http(s) or data url).
+func isRemoteIcon(icon string) bool {
+	icon = strings.ToLower(icon)
+	return strings.HasPrefix(icon, "http://") ||
+		strings.HasPrefix(icon, "https://") ||
+		strings.HasPrefix(icon, "data:")
+}
+
+// storeLogo stores the logo of the chart provided, setting the package's logo
+// image id. The logo can be referenced by a remote url (http(s) or data url),
+// be embedded in the chart archive or, in http based repositories, be stored
+// next to the chart archive. This applies to charts loaded from any kind of
+// repository (including OCI registries) or from a local archive.
+func storeLogo(
+	ctx context.Context,
+	is img.Store,
+	p *hub.Package,
+	chrt *chart.Chart,
+	chartURL *url.URL,
+) error {
+	icon := chrt.Metadata.Icon
+	if isRemoteIcon(icon) {
+		logoImageID, err := is.DownloadAndSaveImage(ctx, icon)
+		if err != nil {
+			return fmt.Errorf("error getting logo image %s: %w", icon, err)
+		}
+		p.LogoURL = icon
+		p.LogoImageID = logoImageID
+	} else if file := getEmbeddedIcon(chrt); file != nil {
+		logoImageID, err := is.SaveImage(ctx, file.Data)
+		if err != nil {
+			return fmt.Errorf("error saving embedded logo image %s: %w", file.Name, err)
+		}
+		p.LogoImageID = logoImageID
+	} else if iconURL := getRelativeIconURL(chartURL, icon); iconURL != "" {
+		logoImageID, err := is.DownloadAndSaveImage(ctx, iconURL)
+		if err != nil {
+			return fmt.Errorf("error getting logo image %s: %w", iconURL, err)
+		}
+		p.LogoURL = iconURL
+		p.LogoImageID = logoImageID
+	}
+	return nil
+}
+
+// getEmbeddedIcon returns the icon file embedded in the chart archive, if
+// any. When the icon in the chart metadata references a file in the archive,
+// that file is returned. When no icon is set, we fall back to the default
+// icon file names.
+func getEmbeddedIcon(chrt *chart.Chart) *chart.File {
+	icon := chrt.Metadata.Icon
+	if icon != "" {
+		if isRemoteIcon(icon) {
+			return nil
+		}
+		name := strings.TrimPrefix(icon, "file://")
+		name = strings.TrimPrefix(path.Clean("/"+name), "/")
+		return getFile(chrt, name)
+	}
+	for _, name := range defaultIconFiles {
+		if file := getFile(chrt, name); file != nil {
+			return file
+		}
+	}
+	return nil
+}
+
+// getKubeVersionRanges normalizes the kubeVersion constraint provided into a
+// list of ranges of compatible Kubernetes minor versions (i.e. 1.19 to 1.27).
+// A minor version is considered compatible when any of its patch releases
+// satisfies the constraint. Ranges without max are open ended. A valid
+// constraint not matching any minor version produces an empty list, while nil
+// is returned when the constraint is missing or invalid.
+func getKubeVersionRanges(kubeVersion string) []map[string]string {
+	if kubeVersion == "" {
+		return nil
+	}
+	c, err := semver.NewConstraint(kubeVersion)
+	if err != nil {
+		return nil
+	}
+
+	// The constraint only changes its result around the versions it
+	// references, so checking the first and last patch releases of each minor
+	// version as well as the patch releases around those referenced is enough
+	// to know if any patch release of the minor version satisfies it.
+	patches := make(map[int][]int)
+	for _, m := range kubeVersionRE.FindAllStringSubmatch(kubeVersion, -1) {
+		minor, _ := strconv.Atoi(m[1])
+		patch, _ := strconv.Atoi(m[2])
+		patches[minor] = append(patches[minor], patch, patch+1)
+		if patch > 0 {
+			patches[minor] = append(patches[minor], patch-1)
+		}
+	}
+	isCompatible := func(minor int) bool {
+		for _, patch := range append([]int{0, 999}, patches[minor]...) {
+			if c.Check(semver.MustParse(fmt.Sprintf("1.%d.%d", minor, patch))) {
+				return true
+			}
+		}
+		return false
+	}
+
+	ranges := make([]map[string]string, 0)
+	rangeStart := -1
+	for minor := 0; minor <= maxKubeMinorVersion+1; minor++ {
+		compatible := minor <= maxKubeMinorVersion && isCompatible(minor)
+		switch {
+		case compatible && rangeStart == -1:
+			rangeStart = minor
+		case !compatible && rangeStart != -1:
+			r := map[string]string{"min": fmt.Sprintf("1.%d", rangeStart)}
+			if minor <= maxKubeMinorVersion {
+				r["max"] = fmt.Sprintf("1.%d", minor-1)
+			}
+			ranges = append(ranges, r)
+			rangeStart = -1
+		}
+	}
+	return ranges
+}
+
+// getRelativeIconURL returns the url of the icon provided resolved relative to
+// the chart archive location. This allows using icons stored next to the chart
+// archives in the repository when they are not embedded in the chart. An empty
+// string is returned when the icon cannot be resolved this way.
+func getRelativeIconURL(chartURL *url.URL, icon string) string {
+	if icon == "" || isRemoteIcon(icon) || strings.HasPrefix(icon, "file://") {
+		return ""
+	}
+	if !repo.SchemeIsHTTP(chartURL) {
+		return ""
+	}
+	iconRef, err := url.Parse(icon)
+	if err != nil || iconRef.IsAbs() || iconRef.Host != "" {
+		return ""
+	}
+	return chartURL.ResolveReference(iconRef).String()
+}
+
 // getFile returns the file requested from the provided chart.
 func getFile(chrt *chart.Chart, name string) *chart.File {
 	for _, file := range chrt.Files {
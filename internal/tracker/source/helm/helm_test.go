@@ -1,20 +1,29 @@
 package helm
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/artifacthub/hub/internal/chartscache"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/tracker/source"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
@@ -60,7 +69,10 @@ func TestTrackerSource(t *testing.T) {
 		Data: map[string]interface{}{
 			"apiVersion":  "v2",
 			"kubeVersion": ">= 1.13.0 < 1.15.0",
-			"type":        "application",
+			"kubeVersionRanges": []map[string]string{
+				{"min": "1.13", "max": "1.14"},
+			},
+			"type": "application",
 		},
 		Version:    "1.0.0",
 		AppVersion: "1.0.0",
@@ -310,6 +322,7 @@ func TestTrackerSource(t *testing.T) {
 		// Run test and check expectations
 		p := source.ClonePackage(basePkg)
 		p.Repository = i.Repository
+		p.Data["kubeVersionRanges"] = basePkg.Data["kubeVersionRanges"]
 		packages, err := NewTrackerSource(i, withIndexLoader(il)).GetPackagesAvailable()
 		assert.Equal(t, map[string]*hub.Package{
 			pkg.BuildKey(p): p,
@@ -364,6 +377,7 @@ func TestTrackerSource(t *testing.T) {
 		// Run test and check expectations
 		p := source.ClonePackage(basePkg)
 		p.Repository = i.Repository
+		p.Data["kubeVersionRanges"] = basePkg.Data["kubeVersionRanges"]
 		p.LogoURL = logoImageURL
 		p.LogoImageID = "logoImageID"
 		packages, err := NewTrackerSource(i, withIndexLoader(il)).GetPackagesAvailable()
@@ -373,6 +387,781 @@ func TestTrackerSource(t *testing.T) {
 		assert.NoError(t, err)
 		sw.AssertExpectations(t)
 	})
+
+	t.Run("one package returned, signed but signature not verified", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL: "https://repo.url",
+			},
+			Svc: sw.Svc,
+		}
+		il := &repo.HelmIndexLoaderMock{}
+		il.On("LoadIndex", i.Repository).Return(&helmrepo.IndexFile{
+			Entries: map[string]helmrepo.ChartVersions{
+				"pkg1": []*helmrepo.ChartVersion{
+					{
+						Metadata: &chart.Metadata{
+							APIVersion: "v2",
+							Name:       "pkg1",
+							Version:    "1.0.0",
+						},
+						URLs: []string{
+							"https://repo.url/pkg1-1.0.0.tgz",
+						},
+					},
+				},
+			},
+		}, "", nil)
+		f, _ := os.Open("testdata/pkg1-1.0.0.tgz")
+		reqChart, _ := http.NewRequest("GET", "https://repo.url/pkg1-1.0.0.tgz", nil)
+		reqChart.Header.Set("Accept-Encoding", "*")
+		sw.Hc.On("Do", reqChart).Return(&http.Response{
+			Body:       f,
+			StatusCode: http.StatusOK,
+		}, nil)
+		fProv, _ := os.Open("testdata/pkg1-1.0.0.tgz.prov")
+		reqProv, _ := http.NewRequest("GET", "https://repo.url/pkg1-1.0.0.tgz.prov", nil)
+		sw.Hc.On("Do", reqProv).Return(&http.Response{
+			Body:       fProv,
+			StatusCode: http.StatusOK,
+		}, nil)
+		fKey, _ := os.Open("testdata/pkg1-signkey.asc")
+		reqKey, _ := http.NewRequest("GET", "https://key.url", nil)
+		sw.Hc.On("Do", reqKey).Return(&http.Response{
+			Body:       fKey,
+			StatusCode: http.StatusOK,
+		}, nil)
+		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, logoImageURL).Return("logoImageID", nil)
+		expectedErr := "error verifying provenance file: sign key fingerprint does not match (package: pkg1 version: 1.0.0)"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withIndexLoader(il)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		for _, p := range packages {
+			assert.True(t, p.Signed)
+			assert.False(t, p.SignatureVerified)
+		}
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("one package returned, signature verified using repository keyring", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		keyring, _ := ioutil.ReadFile("testdata/pkg1-signkey.asc")
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL:         "https://repo.url",
+				SignKeyring: string(keyring),
+			},
+			Svc: sw.Svc,
+		}
+		il := &repo.HelmIndexLoaderMock{}
+		il.On("LoadIndex", i.Repository).Return(&helmrepo.IndexFile{
+			Entries: map[string]helmrepo.ChartVersions{
+				"pkg1": []*helmrepo.ChartVersion{
+					{
+						Metadata: &chart.Metadata{
+							APIVersion: "v2",
+							Name:       "pkg1",
+							Version:    "1.0.0",
+						},
+						URLs: []string{
+							"https://repo.url/pkg1-1.0.0.tgz",
+						},
+					},
+				},
+			},
+		}, "", nil)
+		f, _ := os.Open("testdata/pkg1-1.0.0.tgz")
+		reqChart, _ := http.NewRequest("GET", "https://repo.url/pkg1-1.0.0.tgz", nil)
+		reqChart.Header.Set("Accept-Encoding", "*")
+		sw.Hc.On("Do", reqChart).Return(&http.Response{
+			Body:       f,
+			StatusCode: http.StatusOK,
+		}, nil)
+		fProv, _ := os.Open("testdata/pkg1-1.0.0.tgz.prov")
+		reqProv, _ := http.NewRequest("GET", "https://repo.url/pkg1-1.0.0.tgz.prov", nil)
+		sw.Hc.On("Do", reqProv).Return(&http.Response{
+			Body:       fProv,
+			StatusCode: http.StatusOK,
+		}, nil)
+		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, logoImageURL).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withIndexLoader(il)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		for _, p := range packages {
+			assert.True(t, p.Signed)
+			assert.True(t, p.SignatureVerified)
+			assert.Equal(t, "Artifact Hub Tests <tests@artifacthub.io>", p.SignatureIdentity)
+		}
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("one package returned, logo embedded in chart archive", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL: "https://repo.url",
+			},
+			Svc: sw.Svc,
+		}
+		il := &repo.HelmIndexLoaderMock{}
+		il.On("LoadIndex", i.Repository).Return(&helmrepo.IndexFile{
+			Entries: map[string]helmrepo.ChartVersions{
+				"pkg2": []*helmrepo.ChartVersion{
+					{
+						Metadata: &chart.Metadata{
+							APIVersion: "v2",
+							Name:       "pkg2",
+							Version:    "1.0.0",
+							Icon:       "./icon.png",
+						},
+						URLs: []string{
+							"https://repo.url/pkg2-1.0.0.tgz",
+						},
+					},
+				},
+			},
+		}, "", nil)
+		f, _ := os.Open("testdata/pkg2-1.0.0.tgz")
+		reqChart, _ := http.NewRequest("GET", "https://repo.url/pkg2-1.0.0.tgz", nil)
+		reqChart.Header.Set("Accept-Encoding", "*")
+		sw.Hc.On("Do", reqChart).Return(&http.Response{
+			Body:       f,
+			StatusCode: http.StatusOK,
+		}, nil)
+		reqProv, _ := http.NewRequest("GET", "https://repo.url/pkg2-1.0.0.tgz.prov", nil)
+		sw.Hc.On("Do", reqProv).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusNotFound,
+		}, nil)
+		sw.Is.On("SaveImage", sw.Svc.Ctx, []byte("icon")).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withIndexLoader(il)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		for _, p := range packages {
+			assert.Equal(t, "pkg2", p.Name)
+			assert.Equal(t, "logoImageID", p.LogoImageID)
+			assert.Empty(t, p.LogoURL)
+		}
+		sw.AssertExpectations(t)
+	})
+}
+
+func TestLoadChartArchive(t *testing.T) {
+	ctx := context.Background()
+	u, _ := url.Parse("https://repo.url/pkg1-1.0.0.tgz")
+	data, err := ioutil.ReadFile("testdata/pkg1-1.0.0.tgz")
+	require.NoError(t, err)
+	digest := fmt.Sprintf("%x", sha256.Sum256(data))
+
+	t.Run("chart loaded from cache", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		cc := &chartscache.CacheMock{}
+		cc.On("Get", ctx, digest).Return(data, nil)
+
+		chrt, chartDigest, err := loadChartArchive(ctx, u, &LoadChartArchiveOptions{
+			HC:     hc,
+			Cache:  cc,
+			Digest: digest,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "pkg1", chrt.Metadata.Name)
+		assert.Equal(t, digest, chartDigest)
+		hc.AssertExpectations(t)
+		cc.AssertExpectations(t)
+	})
+
+	t.Run("cached chart does not match digest, chart downloaded and cached", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(data)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		cc := &chartscache.CacheMock{}
+		cc.On("Get", ctx, digest).Return([]byte("corrupted"), nil)
+		cc.On("Put", ctx, digest, data).Return(nil)
+
+		chrt, chartDigest, err := loadChartArchive(ctx, u, &LoadChartArchiveOptions{
+			HC:     hc,
+			Cache:  cc,
+			Digest: digest,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "pkg1", chrt.Metadata.Name)
+		assert.Equal(t, digest, chartDigest)
+		hc.AssertExpectations(t)
+		cc.AssertExpectations(t)
+	})
+
+	t.Run("chart not cached, downloaded and cached", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(data)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		cc := &chartscache.CacheMock{}
+		cc.On("Get", ctx, digest).Return(nil, hub.ErrNotFound)
+		cc.On("Put", ctx, digest, data).Return(nil)
+
+		chrt, _, err := loadChartArchive(ctx, u, &LoadChartArchiveOptions{
+			HC:     hc,
+			Cache:  cc,
+			Digest: digest,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "pkg1", chrt.Metadata.Name)
+		hc.AssertExpectations(t)
+		cc.AssertExpectations(t)
+	})
+
+	t.Run("downloaded chart does not match digest, not cached", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(data)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		otherDigest := strings.Repeat("0", 64)
+		cc := &chartscache.CacheMock{}
+		cc.On("Get", ctx, otherDigest).Return(nil, hub.ErrNotFound)
+
+		_, chartDigest, err := loadChartArchive(ctx, u, &LoadChartArchiveOptions{
+			HC:     hc,
+			Cache:  cc,
+			Digest: otherDigest,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, digest, chartDigest)
+		hc.AssertExpectations(t)
+		cc.AssertExpectations(t)
+	})
+
+	t.Run("requests rate limited", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			Header:     http.Header{"Retry-After": []string{"5"}},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusTooManyRequests,
+		}, nil)
+
+		_, _, err := loadChartArchive(ctx, u, &LoadChartArchiveOptions{HC: hc})
+		var rlErr *rateLimitedError
+		require.True(t, errors.As(err, &rlErr))
+		assert.Equal(t, 5*time.Second, rlErr.retryAfter)
+		hc.AssertExpectations(t)
+	})
+}
+
+func TestConcurrency(t *testing.T) {
+	testCases := []struct {
+		cfg                 map[string]interface{}
+		versions            int
+		expectedConcurrency int
+	}{
+		{nil, 100, defaultConcurrency},
+		{nil, 3, 3},
+		{map[string]interface{}{"tracker.helm.concurrency": 5}, 100, 5},
+		{map[string]interface{}{"tracker.helm.concurrency": 0}, 100, 1},
+		{map[string]interface{}{
+			"tracker.helm.largeRepositoryThreshold":   500,
+			"tracker.helm.largeRepositoryConcurrency": 20,
+		}, 100, defaultConcurrency},
+		{map[string]interface{}{
+			"tracker.helm.largeRepositoryThreshold":   500,
+			"tracker.helm.largeRepositoryConcurrency": 20,
+		}, 1000, 20},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			sw := source.NewTestsServicesWrapper()
+			for k, v := range tc.cfg {
+				sw.Svc.Cfg.Set(k, v)
+			}
+			s := NewTrackerSource(&hub.TrackerSourceInput{Svc: sw.Svc})
+			assert.Equal(t, tc.expectedConcurrency, s.concurrency(tc.versions))
+		})
+	}
+}
+
+func TestGetChartsOCINamespace(t *testing.T) {
+	t.Run("error listing repositories in namespace", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Helm,
+				URL:  "oci://registry.io/org/",
+			},
+			Svc: sw.Svc,
+		}
+		rl := &repo.OCIRepositoriesListerMock{}
+		rl.On("List", i.Svc.Ctx, i.Repository).Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		charts, err := NewTrackerSource(i, withOCIRepositoriesLister(rl)).getCharts()
+		assert.Nil(t, charts)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		sw.AssertExpectations(t)
+		rl.AssertExpectations(t)
+	})
+
+	t.Run("charts discovered, some of them could not be processed", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				RepositoryID: "repo1",
+				Kind:         hub.Helm,
+				URL:          "oci://registry.io/org/",
+				AuthUser:     "user",
+				AuthPass:     "pass",
+			},
+			Svc: sw.Svc,
+		}
+		rl := &repo.OCIRepositoriesListerMock{}
+		rl.On("List", i.Svc.Ctx, i.Repository).Return([]string{
+			"registry.io/org/chart1",
+			"registry.io/org/chart2",
+			"registry.io/org/team/chart1",
+		}, nil)
+		chartRepo := func(name string) *hub.Repository {
+			r := *i.Repository
+			r.URL = "oci://registry.io/org/" + name
+			return &r
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, chartRepo("chart1")).Return([]string{"1.0.0"}, nil)
+		tg.On("Tags", i.Svc.Ctx, chartRepo("chart2")).Return(nil, tests.ErrFake)
+		tg.On("Tags", i.Svc.Ctx, chartRepo("team/chart1")).Return([]string{"2.0.0"}, nil)
+		sw.Ec.On("Append", "repo1", "error getting chart available versions: fake error for tests (url: oci://registry.io/org/chart2)").Return()
+		sw.Ec.On("Append", "repo1", "error getting chart available versions: chart chart1 already available in namespace (url: oci://registry.io/org/team/chart1)").Return()
+
+		// Run test and check expectations
+		charts, err := NewTrackerSource(i, withOCIRepositoriesLister(rl), withOCITagsGetter(tg)).getCharts()
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]*helmrepo.ChartVersion{
+			"chart1": {
+				{
+					Metadata: &chart.Metadata{
+						Name:    "chart1",
+						Version: "1.0.0",
+					},
+					URLs: []string{"oci://registry.io/org/chart1:1.0.0"},
+				},
+			},
+		}, charts)
+		sw.AssertExpectations(t)
+		rl.AssertExpectations(t)
+		tg.AssertExpectations(t)
+	})
+}
+
+func TestGetEmbeddedIcon(t *testing.T) {
+	t.Parallel()
+
+	iconPNG := &chart.File{Name: "icon.png", Data: []byte("png")}
+	iconSVG := &chart.File{Name: "img/logo.svg", Data: []byte("svg")}
+	testCases := []struct {
+		icon         string
+		files        []*chart.File
+		expectedIcon *chart.File
+	}{
+		{
+			"",
+			nil,
+			nil,
+		},
+		{
+			"",
+			[]*chart.File{iconPNG},
+			iconPNG,
+		},
+		{
+			"https://icon.url",
+			[]*chart.File{iconPNG},
+			nil,
+		},
+		{
+			"icon.png",
+			[]*chart.File{iconPNG, iconSVG},
+			iconPNG,
+		},
+		{
+			"./img/logo.svg",
+			[]*chart.File{iconPNG, iconSVG},
+			iconSVG,
+		},
+		{
+			"file://img/logo.svg",
+			[]*chart.File{iconPNG, iconSVG},
+			iconSVG,
+		},
+		{
+			"../img/logo.svg",
+			[]*chart.File{iconPNG, iconSVG},
+			iconSVG,
+		},
+		{
+			"missing.png",
+			[]*chart.File{iconPNG},
+			nil,
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			chrt := &chart.Chart{
+				Metadata: &chart.Metadata{Icon: tc.icon},
+				Files:    tc.files,
+			}
+			assert.Equal(t, tc.expectedIcon, getEmbeddedIcon(chrt))
+		})
+	}
+}
+
+func TestStoreLogo(t *testing.T) {
+	ctx := context.Background()
+	dataURI := "data:image/png;base64,aWNvbg=="
+	iconPNG := &chart.File{Name: "icon.png", Data: []byte("png")}
+
+	testCases := []struct {
+		description         string
+		chartURL            string
+		icon                string
+		files               []*chart.File
+		setupExpectations   func(is *img.StoreMock)
+		expectedLogoURL     string
+		expectedLogoImageID string
+		expectedErr         string
+	}{
+		{
+			"oci chart, remote icon",
+			"oci://registry.io/charts/pkg1:1.0.0",
+			"https://icon.url",
+			nil,
+			func(is *img.StoreMock) {
+				is.On("DownloadAndSaveImage", ctx, "https://icon.url").Return("logoImageID", nil)
+			},
+			"https://icon.url",
+			"logoImageID",
+			"",
+		},
+		{
+			"oci chart, data uri icon",
+			"oci://registry.io/charts/pkg1:1.0.0",
+			dataURI,
+			nil,
+			func(is *img.StoreMock) {
+				is.On("DownloadAndSaveImage", ctx, dataURI).Return("logoImageID", nil)
+			},
+			dataURI,
+			"logoImageID",
+			"",
+		},
+		{
+			"oci chart, error storing data uri icon",
+			"oci://registry.io/charts/pkg1:1.0.0",
+			dataURI,
+			nil,
+			func(is *img.StoreMock) {
+				is.On("DownloadAndSaveImage", ctx, dataURI).Return("", tests.ErrFake)
+			},
+			"",
+			"",
+			"error getting logo image " + dataURI + ": " + tests.ErrFake.Error(),
+		},
+		{
+			"oci chart, icon embedded in chart archive",
+			"oci://registry.io/charts/pkg1:1.0.0",
+			"icon.png",
+			[]*chart.File{iconPNG},
+			func(is *img.StoreMock) {
+				is.On("SaveImage", ctx, []byte("png")).Return("logoImageID", nil)
+			},
+			"",
+			"logoImageID",
+			"",
+		},
+		{
+			"oci chart, default icon embedded in chart archive",
+			"oci://registry.io/charts/pkg1:1.0.0",
+			"",
+			[]*chart.File{iconPNG},
+			func(is *img.StoreMock) {
+				is.On("SaveImage", ctx, []byte("png")).Return("logoImageID", nil)
+			},
+			"",
+			"logoImageID",
+			"",
+		},
+		{
+			"oci chart, error saving embedded icon",
+			"oci://registry.io/charts/pkg1:1.0.0",
+			"icon.png",
+			[]*chart.File{iconPNG},
+			func(is *img.StoreMock) {
+				is.On("SaveImage", ctx, []byte("png")).Return("", tests.ErrFake)
+			},
+			"",
+			"",
+			"error saving embedded logo image icon.png: " + tests.ErrFake.Error(),
+		},
+		{
+			"oci chart, relative icon not embedded in chart archive",
+			"oci://registry.io/charts/pkg1:1.0.0",
+			"img/logo.svg",
+			[]*chart.File{iconPNG},
+			func(is *img.StoreMock) {},
+			"",
+			"",
+			"",
+		},
+		{
+			"http chart, relative icon not embedded in chart archive",
+			"https://repo.url/charts/pkg1-1.0.0.tgz",
+			"img/logo.svg",
+			nil,
+			func(is *img.StoreMock) {
+				is.On("DownloadAndSaveImage", ctx, "https://repo.url/charts/img/logo.svg").Return("logoImageID", nil)
+			},
+			"https://repo.url/charts/img/logo.svg",
+			"logoImageID",
+			"",
+		},
+		{
+			"http chart, no icon",
+			"https://repo.url/charts/pkg1-1.0.0.tgz",
+			"",
+			nil,
+			func(is *img.StoreMock) {},
+			"",
+			"",
+			"",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup expectations
+			is := &img.StoreMock{}
+			tc.setupExpectations(is)
+			chartURL, _ := url.Parse(tc.chartURL)
+			chrt := &chart.Chart{
+				Metadata: &chart.Metadata{Icon: tc.icon},
+				Files:    tc.files,
+			}
+			p := &hub.Package{}
+
+			// Run test and check expectations
+			err := storeLogo(ctx, is, p, chrt, chartURL)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedLogoURL, p.LogoURL)
+			assert.Equal(t, tc.expectedLogoImageID, p.LogoImageID)
+			is.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetKubeVersionRanges(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		kubeVersion    string
+		expectedRanges []map[string]string
+	}{
+		{
+			"",
+			nil,
+		},
+		{
+			"invalid",
+			nil,
+		},
+		{
+			">= 2.0.0",
+			[]map[string]string{},
+		},
+		{
+			"< 1.0.0",
+			[]map[string]string{},
+		},
+		{
+			">= 1.13.0 < 1.15.0",
+			[]map[string]string{
+				{"min": "1.13", "max": "1.14"},
+			},
+		},
+		{
+			">=1.19.0-0",
+			[]map[string]string{
+				{"min": "1.19"},
+			},
+		},
+		{
+			">=1.16.3 <=1.18.2",
+			[]map[string]string{
+				{"min": "1.16", "max": "1.18"},
+			},
+		},
+		{
+			">=1.20.3 <1.20.5",
+			[]map[string]string{
+				{"min": "1.20", "max": "1.20"},
+			},
+		},
+		{
+			"<1.20.0",
+			[]map[string]string{
+				{"min": "1.0", "max": "1.19"},
+			},
+		},
+		{
+			"~1.16.0 || >=1.20.0-0 <1.22.0-0",
+			[]map[string]string{
+				{"min": "1.16", "max": "1.16"},
+				{"min": "1.20", "max": "1.21"},
+			},
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedRanges, getKubeVersionRanges(tc.kubeVersion))
+		})
+	}
+}
+
+func TestGetCosignKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		annotations       map[string]string
+		expectedCosignKey *hub.SignKey
+		expectedErr       error
+	}{
+		{
+			nil,
+			nil,
+			nil,
+		},
+		{
+			map[string]string{cosignKeyAnnotation: "{"},
+			nil,
+			errInvalidAnnotation,
+		},
+		{
+			map[string]string{cosignKeyAnnotation: ""},
+			nil,
+			errInvalidAnnotation,
+		},
+		{
+			map[string]string{cosignKeyAnnotation: "url: https://key.url/cosign.pub"},
+			&hub.SignKey{URL: "https://key.url/cosign.pub"},
+			nil,
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			cosignKey, err := getCosignKey(tc.annotations)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedCosignKey, cosignKey)
+		})
+	}
+}
+
+func TestGetRelativeIconURL(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		chartURL        string
+		icon            string
+		expectedIconURL string
+	}{
+		{
+			"https://repo.url/charts/pkg1-1.0.0.tgz",
+			"",
+			"",
+		},
+		{
+			"https://repo.url/charts/pkg1-1.0.0.tgz",
+			"https://icon.url",
+			"",
+		},
+		{
+			"https://repo.url/charts/pkg1-1.0.0.tgz",
+			"file://icon.png",
+			"",
+		},
+		{
+			"oci://registry.io/charts/pkg1:1.0.0",
+			"icon.png",
+			"",
+		},
+		{
+			"https://repo.url/charts/pkg1-1.0.0.tgz",
+			"icon.png",
+			"https://repo.url/charts/icon.png",
+		},
+		{
+			"https://repo.url/charts/pkg1-1.0.0.tgz",
+			"./img/logo.svg",
+			"https://repo.url/charts/img/logo.svg",
+		},
+		{
+			"https://repo.url/charts/pkg1-1.0.0.tgz",
+			"../img/logo.svg",
+			"https://repo.url/img/logo.svg",
+		},
+		{
+			"https://repo.url/charts/pkg1-1.0.0.tgz",
+			"/img/logo.svg",
+			"https://repo.url/img/logo.svg",
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			chartURL, _ := url.Parse(tc.chartURL)
+			assert.Equal(t, tc.expectedIconURL, getRelativeIconURL(chartURL, tc.icon))
+		})
+	}
 }
 
 func TestExtractContainersImages(t *testing.T) {
@@ -407,6 +1196,36 @@ func TestEnrichPackageFromAnnotations(t *testing.T) {
 		expectedPkg    *hub.Package
 		expectedErrMsg string
 	}{
+		// Alias
+		{
+			&hub.Package{},
+			map[string]string{
+				aliasAnnotation: "pg",
+			},
+			&hub.Package{
+				Alias: "pg",
+			},
+			"",
+		},
+		// Category
+		{
+			&hub.Package{},
+			map[string]string{
+				categoryAnnotation: "database",
+			},
+			&hub.Package{
+				Category: "database",
+			},
+			"",
+		},
+		{
+			&hub.Package{},
+			map[string]string{
+				categoryAnnotation: "invalid",
+			},
+			&hub.Package{},
+			"invalid category value: invalid",
+		},
 		// Changes
 		{
 			&hub.Package{},
@@ -506,6 +1325,31 @@ func TestEnrichPackageFromAnnotations(t *testing.T) {
 			},
 			"",
 		},
+		// Display name
+		{
+			&hub.Package{
+				DisplayName: "chart",
+			},
+			map[string]string{
+				displayNameAnnotation: "My Chart",
+			},
+			&hub.Package{
+				DisplayName: "My Chart",
+			},
+			"",
+		},
+		{
+			&hub.Package{
+				DisplayName: "chart",
+			},
+			map[string]string{
+				displayNameAnnotation: "",
+			},
+			&hub.Package{
+				DisplayName: "chart",
+			},
+			"",
+		},
 		// Images
 		{
 			&hub.Package{},
@@ -937,3 +1781,9 @@ func withOCITagsGetter(tg hub.OCITagsGetter) func(s *TrackerSource) {
 		s.tg = tg
 	}
 }
+
+func withOCIRepositoriesLister(rl hub.OCIRepositoriesLister) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.rl = rl
+	}
+}
@@ -0,0 +1,148 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// minBackoff represents the initial period of time the preparation of
+	// packages is paused when the upstream server rate limits our requests
+	// without indicating when they can be retried.
+	minBackoff = 1 * time.Second
+
+	// maxBackoff represents the maximum period of time the preparation of
+	// packages can be paused.
+	maxBackoff = 1 * time.Minute
+)
+
+// rateLimitedError represents the error returned when a request is rejected
+// by the upstream server because it is being rate limited (429).
+type rateLimitedError struct {
+	retryAfter time.Duration
+}
+
+// newRateLimitedError creates a new rateLimitedError instance from the
+// response provided, honoring its Retry-After header when it is set.
+func newRateLimitedError(resp *http.Response) *rateLimitedError {
+	e := &rateLimitedError{}
+	v := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+		e.retryAfter = time.Duration(seconds) * time.Second
+	} else if t, err := http.ParseTime(v); err == nil {
+		e.retryAfter = time.Until(t)
+	}
+	return e
+}
+
+// Error implements the error interface.
+func (e *rateLimitedError) Error() string {
+	return fmt.Sprintf("unexpected status code received: %d", http.StatusTooManyRequests)
+}
+
+// adaptiveLimiter limits the number of packages being prepared concurrently.
+// Every time the upstream server rate limits a request, the limit is halved
+// and the preparation of new packages is paused for a while (exponential
+// backoff, unless the server indicates when requests can be retried). After
+// each request that is not rate limited, the limit is increased again by one,
+// up to the maximum concurrency configured.
+type adaptiveLimiter struct {
+	max int
+
+	mu          sync.Mutex
+	limit       int
+	inFlight    int
+	backoff     time.Duration
+	pausedUntil time.Time
+	changed     chan struct{}
+}
+
+// newAdaptiveLimiter creates a new adaptiveLimiter instance.
+func newAdaptiveLimiter(max int) *adaptiveLimiter {
+	if max < 1 {
+		max = 1
+	}
+	return &adaptiveLimiter{
+		max:     max,
+		limit:   max,
+		changed: make(chan struct{}),
+	}
+}
+
+// acquire waits until a new package can be prepared, or until the context
+// provided is cancelled.
+func (l *adaptiveLimiter) acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		wait := time.Until(l.pausedUntil)
+		if l.inFlight < l.limit && wait <= 0 {
+			l.inFlight++
+			l.mu.Unlock()
+			return nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			timeout = timer.C
+		}
+		select {
+		case <-changed:
+		case <-timeout:
+		case <-ctx.Done():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// release must be called once the preparation of a package has finished.
+// When it failed because the upstream server rate limited the request, the
+// corresponding rateLimitedError must be provided.
+func (l *adaptiveLimiter) release(rlErr *rateLimitedError) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	if rlErr != nil {
+		l.limit /= 2
+		if l.limit < 1 {
+			l.limit = 1
+		}
+		if l.backoff == 0 {
+			l.backoff = minBackoff
+		} else if l.backoff < maxBackoff {
+			l.backoff *= 2
+			if l.backoff > maxBackoff {
+				l.backoff = maxBackoff
+			}
+		}
+		wait := l.backoff
+		if rlErr.retryAfter > 0 {
+			wait = rlErr.retryAfter
+		}
+		if pausedUntil := time.Now().Add(wait); pausedUntil.After(l.pausedUntil) {
+			l.pausedUntil = pausedUntil
+		}
+	} else {
+		if l.limit < l.max {
+			l.limit++
+		}
+		l.backoff = 0
+	}
+
+	// Wake up the goroutines waiting to acquire the limiter
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
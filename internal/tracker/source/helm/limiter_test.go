@@ -0,0 +1,108 @@
+package helm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimitedError(t *testing.T) {
+	t.Run("retry after not provided", func(t *testing.T) {
+		t.Parallel()
+		e := newRateLimitedError(&http.Response{Header: http.Header{}})
+		assert.Equal(t, time.Duration(0), e.retryAfter)
+	})
+
+	t.Run("retry after provided in seconds", func(t *testing.T) {
+		t.Parallel()
+		e := newRateLimitedError(&http.Response{Header: http.Header{"Retry-After": []string{"30"}}})
+		assert.Equal(t, 30*time.Second, e.retryAfter)
+	})
+
+	t.Run("retry after provided as a date", func(t *testing.T) {
+		t.Parallel()
+		date := time.Now().Add(1 * time.Minute).UTC().Format(http.TimeFormat)
+		e := newRateLimitedError(&http.Response{Header: http.Header{"Retry-After": []string{date}}})
+		assert.InDelta(t, float64(time.Minute), float64(e.retryAfter), float64(2*time.Second))
+	})
+}
+
+func TestAdaptiveLimiter(t *testing.T) {
+	t.Run("concurrency limited to the maximum provided", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		l := newAdaptiveLimiter(2)
+
+		require.NoError(t, l.acquire(ctx))
+		require.NoError(t, l.acquire(ctx))
+		assert.Equal(t, context.DeadlineExceeded, l.acquire(ctx))
+	})
+
+	t.Run("released slot can be acquired again", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		l := newAdaptiveLimiter(1)
+
+		require.NoError(t, l.acquire(ctx))
+		go l.release(nil)
+		assert.NoError(t, l.acquire(ctx))
+	})
+
+	t.Run("limit halved and backoff applied when rate limited", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		l := newAdaptiveLimiter(8)
+
+		require.NoError(t, l.acquire(ctx))
+		l.release(&rateLimitedError{})
+		assert.Equal(t, 4, l.limit)
+		assert.Equal(t, minBackoff, l.backoff)
+		assert.True(t, l.pausedUntil.After(time.Now()))
+
+		// Backoff is doubled while requests keep being rate limited
+		require.NoError(t, l.acquire(ctx))
+		l.release(&rateLimitedError{})
+		assert.Equal(t, 2, l.limit)
+		assert.Equal(t, 2*minBackoff, l.backoff)
+
+		// Acquiring waits until the backoff period has elapsed
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		assert.Equal(t, context.DeadlineExceeded, l.acquire(ctx))
+	})
+
+	t.Run("retry after provided honored", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		l := newAdaptiveLimiter(1)
+
+		require.NoError(t, l.acquire(ctx))
+		l.release(&rateLimitedError{retryAfter: 10 * time.Millisecond})
+		assert.Equal(t, 1, l.limit)
+		start := time.Now()
+		require.NoError(t, l.acquire(ctx))
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(5*time.Millisecond))
+	})
+
+	t.Run("limit restored progressively once requests succeed", func(t *testing.T) {
+		t.Parallel()
+		l := newAdaptiveLimiter(4)
+		l.limit = 1
+		l.backoff = maxBackoff
+
+		l.inFlight = 1
+		l.release(nil)
+		assert.Equal(t, 2, l.limit)
+		assert.Equal(t, time.Duration(0), l.backoff)
+		for i := 0; i < 5; i++ {
+			l.inFlight = 1
+			l.release(nil)
+		}
+		assert.Equal(t, 4, l.limit)
+	})
+}
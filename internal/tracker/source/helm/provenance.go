@@ -0,0 +1,281 @@
+package helm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/clearsign"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// errInvalidProvenanceFile indicates that the provenance file provided is
+	// not valid.
+	errInvalidProvenanceFile = errors.New("invalid provenance file")
+
+	// provenanceSectionsSeparator represents the separator used between the
+	// chart metadata and the files sections of a provenance file.
+	provenanceSectionsSeparator = []byte("\n...\n")
+)
+
+const (
+	// repositoryKeyringCacheKey represents the key used to cache the keyring
+	// configured at the repository level.
+	repositoryKeyringCacheKey = "repository"
+
+	// provenanceFileMaxSize represents the maximum size of the provenance
+	// files that will be downloaded.
+	provenanceFileMaxSize = 1024 * 1024
+
+	// signKeyringMaxSize represents the maximum size of the remote keyrings
+	// that will be downloaded.
+	signKeyringMaxSize = 1024 * 1024
+)
+
+// signKeyring represents a keyring loaded from the url of a sign key or from
+// the repository configuration, which is cached so that it's only loaded once
+// per tracking run.
+type signKeyring struct {
+	keyring openpgp.EntityList
+	err     error
+}
+
+// signKeyringsCache is a cache of the keyrings loaded by a TrackerSource,
+// indexed by the sign key url and fingerprint (or repositoryKeyringCacheKey
+// for the keyring set in the repository).
+type signKeyringsCache struct {
+	mu       sync.Mutex
+	keyrings map[string]*signKeyring
+}
+
+// getProvenanceFile returns the provenance file of the chart version located
+// at the url provided. Nil is returned when the chart version does not have a
+// provenance file.
+func (s *TrackerSource) getProvenanceFile(u string) ([]byte, error) {
+	req, _ := http.NewRequest("GET", u+".prov", nil)
+	req = req.WithContext(s.i.Svc.Ctx)
+	if s.i.Svc.Rl != nil {
+		release, err := s.i.Svc.Rl.Acquire(s.i.Svc.Ctx, s.i.Repository.Name, req.URL.Host)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	if s.i.Repository.AuthUser != "" || s.i.Repository.AuthPass != "" {
+		req.SetBasicAuth(s.i.Repository.AuthUser, s.i.Repository.AuthPass)
+	}
+	resp, err := s.i.Svc.Hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, provenanceFileMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading provenance file: %w", err)
+	}
+	if len(data) > provenanceFileMaxSize {
+		return nil, errors.New("provenance file too big")
+	}
+	if block, _ := clearsign.Decode(data); block == nil {
+		return nil, errInvalidProvenanceFile
+	}
+	return data, nil
+}
+
+// checkProvenanceSignature verifies the provenance file provided using the
+// keyring configured in the repository or, when not available, the sign key
+// of the package or the repository metadata file. The package is updated to
+// reflect the result of the verification.
+func (s *TrackerSource) checkProvenanceSignature(p *hub.Package, prov []byte, chartDigest string) error {
+	keyring, err := s.getRepositoryKeyring()
+	if err != nil || keyring != nil {
+		var identity string
+		if err == nil {
+			identity, err = verifyProvenance(prov, keyring, chartDigest)
+		}
+		if err != nil {
+			p.Signed = false
+			p.SignatureKind = ""
+			return fmt.Errorf("repository keyring: %w", err)
+		}
+		p.SignatureVerified = true
+		p.SignatureIdentity = identity
+		return nil
+	}
+
+	signKey := p.SignKey
+	if signKey == nil && s.i.Metadata != nil {
+		signKey = s.i.Metadata.SignKey
+	}
+	if signKey == nil || signKey.URL == "" {
+		return nil
+	}
+	keyring, err = s.getSignKeyring(signKey)
+	if err != nil {
+		return err
+	}
+	identity, err := verifyProvenance(prov, keyring, chartDigest)
+	if err != nil {
+		return err
+	}
+	p.SignatureVerified = true
+	p.SignatureIdentity = identity
+	return nil
+}
+
+// getSignKeyring returns the keyring holding the key referenced by the sign
+// key provided. When the sign key includes a fingerprint, only the keys that
+// match it are included in the keyring.
+func (s *TrackerSource) getSignKeyring(signKey *hub.SignKey) (openpgp.EntityList, error) {
+	cacheKey := signKey.URL + "#" + signKey.Fingerprint
+	return s.getCachedKeyring(cacheKey, func() (openpgp.EntityList, error) {
+		data, err := s.getRemoteKeyring(signKey.URL)
+		if err != nil {
+			return nil, err
+		}
+		return parseSignKeyring(data, signKey.Fingerprint)
+	})
+}
+
+// getRepositoryKeyring returns the keyring configured in the repository, if
+// any. The keyring can be provided inline or referenced by url.
+func (s *TrackerSource) getRepositoryKeyring() (openpgp.EntityList, error) {
+	r := s.i.Repository
+	if r.SignKeyring == "" && r.SignKeyringURL == "" {
+		return nil, nil
+	}
+	return s.getCachedKeyring(repositoryKeyringCacheKey, func() (openpgp.EntityList, error) {
+		data := []byte(r.SignKeyring)
+		if r.SignKeyringURL != "" {
+			var err error
+			data, err = s.getRemoteKeyring(r.SignKeyringURL)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return parseSignKeyring(data, "")
+	})
+}
+
+// getCachedKeyring returns the keyring cached with the key provided, loading
+// it using the loader function given when it's not in the cache yet.
+func (s *TrackerSource) getCachedKeyring(
+	cacheKey string,
+	load func() (openpgp.EntityList, error),
+) (openpgp.EntityList, error) {
+	s.kc.mu.Lock()
+	defer s.kc.mu.Unlock()
+	if kr, ok := s.kc.keyrings[cacheKey]; ok {
+		return kr.keyring, kr.err
+	}
+	keyring, err := load()
+	s.kc.keyrings[cacheKey] = &signKeyring{keyring: keyring, err: err}
+	return keyring, err
+}
+
+// getRemoteKeyring downloads the keyring located at the url provided.
+func (s *TrackerSource) getRemoteKeyring(u string) ([]byte, error) {
+	req, _ := http.NewRequest("GET", u, nil)
+	req = req.WithContext(s.i.Svc.Ctx)
+	resp, err := s.i.Svc.Hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting sign key: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting sign key: unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, signKeyringMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading sign key: %w", err)
+	}
+	if len(data) > signKeyringMaxSize {
+		return nil, errors.New("sign key too big")
+	}
+	return data, nil
+}
+
+// parseSignKeyring parses the keyring provided (armored or binary), keeping
+// only the keys that match the fingerprint given, when provided. The full
+// fingerprint is required, as short and long key ids can be easily forged.
+func parseSignKeyring(data []byte, fingerprint string) (openpgp.EntityList, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err != nil {
+		keyring, err = openpgp.ReadKeyRing(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid sign key: %w", err)
+		}
+	}
+	fingerprint = strings.ToUpper(strings.ReplaceAll(fingerprint, " ", ""))
+	if fingerprint == "" {
+		return keyring, nil
+	}
+	var matchingKeys openpgp.EntityList
+	for _, e := range keyring {
+		if fmt.Sprintf("%X", e.PrimaryKey.Fingerprint) == fingerprint {
+			matchingKeys = append(matchingKeys, e)
+		}
+	}
+	if len(matchingKeys) == 0 {
+		return nil, errors.New("sign key fingerprint does not match")
+	}
+	return matchingKeys, nil
+}
+
+// verifyProvenance checks that the provenance file provided has been signed by
+// any of the keys in the keyring given and that it includes the digest of the
+// chart archive. On success, the identity of the signer is returned.
+func verifyProvenance(prov []byte, keyring openpgp.EntityList, chartDigest string) (string, error) {
+	block, _ := clearsign.Decode(prov)
+	if block == nil {
+		return "", errInvalidProvenanceFile
+	}
+	signer, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(block.Bytes), block.ArmoredSignature.Body)
+	if err != nil {
+		return "", fmt.Errorf("invalid signature: %w", err)
+	}
+	sections := bytes.SplitN(block.Plaintext, provenanceSectionsSeparator, 2)
+	if len(sections) != 2 {
+		return "", fmt.Errorf("%w: files section not found", errInvalidProvenanceFile)
+	}
+	var files struct {
+		Files map[string]string `yaml:"files"`
+	}
+	if err := yaml.Unmarshal(sections[1], &files); err != nil {
+		return "", fmt.Errorf("%w: %v", errInvalidProvenanceFile, err)
+	}
+	for _, digest := range files.Files {
+		if digest == "sha256:"+chartDigest {
+			return getSignerIdentity(signer), nil
+		}
+	}
+	return "", errors.New("chart archive digest not found in provenance file")
+}
+
+// getSignerIdentity returns the identity of the entity provided. The primary
+// identity name is used when available, falling back to the key fingerprint.
+func getSignerIdentity(e *openpgp.Entity) string {
+	if e == nil {
+		return ""
+	}
+	var name string
+	for _, id := range e.Identities {
+		if name == "" || (id.SelfSignature != nil && id.SelfSignature.IsPrimaryId != nil && *id.SelfSignature.IsPrimaryId) {
+			name = id.Name
+		}
+	}
+	if name != "" {
+		return name
+	}
+	return fmt.Sprintf("%X", e.PrimaryKey.Fingerprint)
+}
@@ -0,0 +1,95 @@
+package helm
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+)
+
+func TestParseSignKeyring(t *testing.T) {
+	key, err := ioutil.ReadFile("testdata/pkg1-signkey.asc")
+	require.NoError(t, err)
+
+	t.Run("invalid key", func(t *testing.T) {
+		t.Parallel()
+		_, err := parseSignKeyring([]byte("invalid"), "")
+		assert.Error(t, err)
+	})
+
+	t.Run("fingerprint does not match", func(t *testing.T) {
+		testCases := []string{
+			"0011223344",
+			"12BDD393",
+			"28D76A4E12BDD393",
+		}
+		for _, fingerprint := range testCases {
+			fingerprint := fingerprint
+			t.Run(fingerprint, func(t *testing.T) {
+				t.Parallel()
+				_, err := parseSignKeyring(key, fingerprint)
+				assert.EqualError(t, err, "sign key fingerprint does not match")
+			})
+		}
+	})
+
+	t.Run("keyring parsed successfully", func(t *testing.T) {
+		testCases := []string{
+			"",
+			"222C83E821E080FC87EF37DC28D76A4E12BDD393",
+			"222c 83e8 21e0 80fc 87ef 37dc 28d7 6a4e 12bd d393",
+		}
+		for _, fingerprint := range testCases {
+			fingerprint := fingerprint
+			t.Run(fingerprint, func(t *testing.T) {
+				t.Parallel()
+				keyring, err := parseSignKeyring(key, fingerprint)
+				require.NoError(t, err)
+				assert.Len(t, keyring, 1)
+			})
+		}
+	})
+}
+
+func TestVerifyProvenance(t *testing.T) {
+	key, err := ioutil.ReadFile("testdata/pkg1-signkey.asc")
+	require.NoError(t, err)
+	keyring, err := parseSignKeyring(key, "")
+	require.NoError(t, err)
+	prov, err := ioutil.ReadFile("testdata/pkg1-1.0.0.tgz.prov")
+	require.NoError(t, err)
+	chartData, err := ioutil.ReadFile("testdata/pkg1-1.0.0.tgz")
+	require.NoError(t, err)
+	chartDigest := fmt.Sprintf("%x", sha256.Sum256(chartData))
+
+	t.Run("invalid provenance file", func(t *testing.T) {
+		t.Parallel()
+		_, err := verifyProvenance([]byte("invalid"), keyring, chartDigest)
+		assert.True(t, errors.Is(err, errInvalidProvenanceFile))
+	})
+
+	t.Run("signed with an unknown key", func(t *testing.T) {
+		t.Parallel()
+		_, err := verifyProvenance(prov, openpgp.EntityList{}, chartDigest)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid signature")
+	})
+
+	t.Run("chart digest does not match", func(t *testing.T) {
+		t.Parallel()
+		_, err := verifyProvenance(prov, keyring, "0123456789abcdef")
+		assert.EqualError(t, err, "chart archive digest not found in provenance file")
+	})
+
+	t.Run("provenance file verified successfully", func(t *testing.T) {
+		t.Parallel()
+		identity, err := verifyProvenance(prov, keyring, chartDigest)
+		assert.NoError(t, err)
+		assert.Equal(t, "Artifact Hub Tests <tests@artifacthub.io>", identity)
+	})
+}
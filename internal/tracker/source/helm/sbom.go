@@ -0,0 +1,56 @@
+package helm
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/artifacthub/hub/internal/sbom"
+)
+
+// getSBOM returns the SBOM of the chart version located at the url provided.
+// The SBOM referenced in the chart annotations (sbomURL) takes precedence
+// over the one attached to the chart in the OCI registry. Nil is returned
+// when the chart version does not have a SBOM.
+func (s *TrackerSource) getSBOM(chartURL *url.URL, sbomURL string) ([]byte, error) {
+	if sbomURL != "" {
+		return s.getRemoteFile(sbomURL, "sbom", sbom.MaxSize)
+	}
+	if chartURL.Scheme == "oci" {
+		return s.bg.GetSBOM(s.i.Svc.Ctx, s.i.Repository, chartURL.String())
+	}
+	return nil, nil
+}
+
+// getRemoteFile downloads the file located at the url provided, reading up
+// to maxSize+1 bytes so that callers can detect files exceeding the limit. The
+// kind of file is only used in the errors returned.
+func (s *TrackerSource) getRemoteFile(fileURL, kind string, maxSize int64) ([]byte, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid %s url: %s", kind, fileURL)
+	}
+	req, _ := http.NewRequest("GET", u.String(), nil)
+	req = req.WithContext(s.i.Svc.Ctx)
+	if s.i.Svc.Rl != nil {
+		release, err := s.i.Svc.Rl.Acquire(s.i.Svc.Ctx, s.i.Repository.Name, req.URL.Host)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	resp, err := s.i.Svc.Hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", kind, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,56 @@
+package helm
+
+import (
+	"encoding/json"
+)
+
+// jsonSchemaDraft represents the JSON schema draft used in the values
+// schemas inferred from the charts default values.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// inferValuesSchema infers a best-effort JSON schema from the default values
+// provided. The schema generated describes the types of the values and the
+// structure of the nested objects and arrays, using the values themselves as
+// defaults. It does not restrict additional properties, as the default values
+// of a chart rarely include all the settings supported.
+func inferValuesSchema(values map[string]interface{}) ([]byte, error) {
+	schema := inferSchema(values)
+	schema["$schema"] = jsonSchemaDraft
+	return json.Marshal(schema)
+}
+
+// inferSchema returns the schema that describes the value provided.
+func inferSchema(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		schema := map[string]interface{}{
+			"type": "object",
+		}
+		if len(v) > 0 {
+			properties := make(map[string]interface{}, len(v))
+			for key, value := range v {
+				properties[key] = inferSchema(value)
+			}
+			schema["properties"] = properties
+		}
+		return schema
+	case []interface{}:
+		schema := map[string]interface{}{
+			"type": "array",
+		}
+		if len(v) > 0 {
+			schema["items"] = inferSchema(v[0])
+		}
+		return schema
+	case bool:
+		return map[string]interface{}{"type": "boolean", "default": v}
+	case int, int32, int64, float32, float64:
+		return map[string]interface{}{"type": "number", "default": v}
+	case string:
+		return map[string]interface{}{"type": "string", "default": v}
+	default:
+		// Null values (or any others we don't know about) may be set to
+		// anything, so no restrictions are applied to them
+		return map[string]interface{}{}
+	}
+}
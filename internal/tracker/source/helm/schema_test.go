@@ -0,0 +1,74 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferValuesSchema(t *testing.T) {
+	t.Run("empty values", func(t *testing.T) {
+		t.Parallel()
+		schema, err := inferValuesSchema(map[string]interface{}{})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object"
+		}`, string(schema))
+	})
+
+	t.Run("schema inferred successfully", func(t *testing.T) {
+		t.Parallel()
+		values := map[string]interface{}{
+			"replicaCount": float64(1),
+			"image": map[string]interface{}{
+				"repository": "nginx",
+				"tag":        nil,
+			},
+			"ingress": map[string]interface{}{
+				"enabled": false,
+				"hosts": []interface{}{
+					map[string]interface{}{
+						"host": "chart-example.local",
+					},
+				},
+			},
+			"resources":   map[string]interface{}{},
+			"tolerations": []interface{}{},
+		}
+		schema, err := inferValuesSchema(values)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"properties": {
+				"replicaCount": {"type": "number", "default": 1},
+				"image": {
+					"type": "object",
+					"properties": {
+						"repository": {"type": "string", "default": "nginx"},
+						"tag": {}
+					}
+				},
+				"ingress": {
+					"type": "object",
+					"properties": {
+						"enabled": {"type": "boolean", "default": false},
+						"hosts": {
+							"type": "array",
+							"items": {
+								"type": "object",
+								"properties": {
+									"host": {"type": "string", "default": "chart-example.local"}
+								}
+							}
+						}
+					}
+				},
+				"resources": {"type": "object"},
+				"tolerations": {"type": "array"}
+			}
+		}`, string(schema))
+	})
+}
@@ -0,0 +1,16 @@
+package helm
+
+import (
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/testresults"
+)
+
+// getTestResults downloads and parses the test results document (JSON summary
+// or JUnit XML report) located at the url provided.
+func (s *TrackerSource) getTestResults(testResultsURL string) (*hub.TestResults, error) {
+	data, err := s.getRemoteFile(testResultsURL, "test results", testresults.MaxSize)
+	if err != nil {
+		return nil, err
+	}
+	return testresults.Parse(data)
+}
@@ -0,0 +1,146 @@
+package helm
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// maxValuesDocs represents the maximum number of values documentation entries
+// that will be extracted from a chart values file.
+const maxValuesDocs = 2000
+
+var (
+	// valueDescriptionRE is a regexp used to extract the description (and
+	// optionally the type) of a value from a helm-docs style comment, like:
+	// # -- (string) Description
+	valueDescriptionRE = regexp.MustCompile(`^#\s*--(?:\s+(?:\((\w+)\)\s*)?(.*))?$`)
+
+	// valueDefaultRE is a regexp used to extract the default value override
+	// from a helm-docs style comment, like: # @default -- Some description
+	valueDefaultRE = regexp.MustCompile(`^#\s*@default\s*--\s*(.*)$`)
+)
+
+// extractValuesDocs extracts the values documentation from the values file of
+// the chart provided, following the conventions used by helm-docs. Values
+// that have a description are documented as a whole, while the maps without
+// one are walked to document the values nested in them.
+func extractValuesDocs(chrt *chart.Chart) ([]*hub.ValueDoc, error) {
+	var valuesFile *chart.File
+	for _, file := range chrt.Raw {
+		if file.Name == chartutil.ValuesfileName {
+			valuesFile = file
+			break
+		}
+	}
+	if valuesFile == nil {
+		return nil, nil
+	}
+	return parseValuesDocs(valuesFile.Data)
+}
+
+// parseValuesDocs parses the values file data provided, returning the
+// documentation of the values defined in it.
+func parseValuesDocs(data []byte) ([]*hub.ValueDoc, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	var valuesDocs []*hub.ValueDoc
+	walkValues(doc.Content[0], "", &valuesDocs)
+	return valuesDocs, nil
+}
+
+// walkValues walks the mapping node provided, appending the documentation of
+// the values found to the list provided.
+func walkValues(node *yaml.Node, prefix string, valuesDocs *[]*hub.ValueDoc) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if len(*valuesDocs) >= maxValuesDocs {
+			return
+		}
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		path := keyNode.Value
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		vd := parseValueComment(keyNode.HeadComment)
+		if vd == nil && valueNode.Kind == yaml.MappingNode && len(valueNode.Content) > 0 {
+			walkValues(valueNode, path, valuesDocs)
+			continue
+		}
+		if vd == nil {
+			vd = &hub.ValueDoc{}
+		}
+		vd.Path = path
+		if vd.Type == "" {
+			vd.Type = getValueType(valueNode)
+		}
+		if vd.Default == nil {
+			var v interface{}
+			if err := valueNode.Decode(&v); err == nil {
+				vd.Default = v
+			}
+		}
+		*valuesDocs = append(*valuesDocs, vd)
+	}
+}
+
+// parseValueComment parses the comment provided, returning the documentation
+// of the value it belongs to. Only the lines after the last description mark
+// (# --) are taken into account. Nil is returned when the comment does not
+// follow the helm-docs conventions.
+func parseValueComment(comment string) *hub.ValueDoc {
+	var vd *hub.ValueDoc
+	var description []string
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(line)
+		if m := valueDescriptionRE.FindStringSubmatch(line); m != nil {
+			vd = &hub.ValueDoc{Type: m[1]}
+			description = []string{strings.TrimSpace(m[2])}
+			continue
+		}
+		if vd == nil {
+			continue
+		}
+		if m := valueDefaultRE.FindStringSubmatch(line); m != nil {
+			vd.Default = strings.TrimSpace(m[1])
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			description = append(description, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+		}
+	}
+	if vd != nil {
+		vd.Description = strings.TrimSpace(strings.Join(description, " "))
+	}
+	return vd
+}
+
+// getValueType returns the type of the value node provided.
+func getValueType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "list"
+	case yaml.AliasNode:
+		return getValueType(node.Alias)
+	}
+	switch node.ShortTag() {
+	case "!!bool":
+		return "bool"
+	case "!!int":
+		return "int"
+	case "!!float":
+		return "float"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,145 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValuesDocs(t *testing.T) {
+	t.Run("invalid values file", func(t *testing.T) {
+		t.Parallel()
+		_, err := parseValuesDocs([]byte("key: [invalid"))
+		assert.Error(t, err)
+	})
+
+	t.Run("empty values file", func(t *testing.T) {
+		t.Parallel()
+		valuesDocs, err := parseValuesDocs([]byte(""))
+		require.NoError(t, err)
+		assert.Nil(t, valuesDocs)
+	})
+
+	t.Run("values documentation extracted successfully", func(t *testing.T) {
+		t.Parallel()
+		data := []byte(`
+nameOverride: ""
+
+# -- Number of replicas
+replicaCount: 1
+
+image:
+  # -- Image repository
+  repository: nginx
+  # -- (string) Image tag
+  # @default -- The chart appVersion
+  tag:
+  pullPolicy: IfNotPresent
+
+# -----------------------
+# Pods settings
+# -----------------------
+
+# -- Pod annotations
+# spanning two lines
+podAnnotations:
+  key: value
+
+resources: {}
+tolerations: []
+`)
+		valuesDocs, err := parseValuesDocs(data)
+		require.NoError(t, err)
+		assert.Equal(t, []*hub.ValueDoc{
+			{
+				Path:    "nameOverride",
+				Type:    "string",
+				Default: "",
+			},
+			{
+				Path:        "replicaCount",
+				Type:        "int",
+				Default:     1,
+				Description: "Number of replicas",
+			},
+			{
+				Path:        "image.repository",
+				Type:        "string",
+				Default:     "nginx",
+				Description: "Image repository",
+			},
+			{
+				Path:        "image.tag",
+				Type:        "string",
+				Default:     "The chart appVersion",
+				Description: "Image tag",
+			},
+			{
+				Path:    "image.pullPolicy",
+				Type:    "string",
+				Default: "IfNotPresent",
+			},
+			{
+				Path:        "podAnnotations",
+				Type:        "object",
+				Default:     map[string]interface{}{"key": "value"},
+				Description: "Pod annotations spanning two lines",
+			},
+			{
+				Path:    "resources",
+				Type:    "object",
+				Default: map[string]interface{}{},
+			},
+			{
+				Path:    "tolerations",
+				Type:    "list",
+				Default: []interface{}{},
+			},
+		}, valuesDocs)
+	})
+}
+
+func TestParseValueComment(t *testing.T) {
+	testCases := []struct {
+		comment    string
+		expectedVD *hub.ValueDoc
+	}{
+		{
+			"",
+			nil,
+		},
+		{
+			"# Regular comment",
+			nil,
+		},
+		{
+			"# -----------",
+			nil,
+		},
+		{
+			"# -- Description",
+			&hub.ValueDoc{Description: "Description"},
+		},
+		{
+			"# -- (int) Description",
+			&hub.ValueDoc{Type: "int", Description: "Description"},
+		},
+		{
+			"# -- Old description\n# -- Description",
+			&hub.ValueDoc{Description: "Description"},
+		},
+		{
+			"# Regular comment\n# -- Description\n# continued\n# @default -- 3 replicas",
+			&hub.ValueDoc{Default: "3 replicas", Description: "Description continued"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.comment, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedVD, parseValueComment(tc.comment))
+		})
+	}
+}
@@ -1,10 +1,21 @@
 package krew
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/hub"
@@ -16,6 +27,7 @@ import (
 
 const (
 	// Annotations
+	aliasAnnotation           = "artifacthub.io/alias"
 	displayNameAnnotation     = "artifacthub.io/displayName"
 	keywordsAnnotation        = "artifacthub.io/keywords"
 	licenseAnnotation         = "artifacthub.io/license"
@@ -28,8 +40,24 @@ const (
 	// Platform keys
 	os   = "os"
 	arch = "arch"
+
+	// archiveMaxSize represents the maximum size of the plugin archives that
+	// will be downloaded to verify their checksum.
+	archiveMaxSize = 100 * 1024 * 1024
 )
 
+// platformArchive represents some details about the archive provided by a
+// plugin for a given platform.
+type platformArchive struct {
+	OS          string `json:"os"`
+	Arch        string `json:"arch,omitempty"`
+	URI         string `json:"uri"`
+	Sha256      string `json:"sha256"`
+	Available   bool   `json:"available"`
+	ArchiveSize int64  `json:"archive_size,omitempty"`
+	BinarySize  int64  `json:"binary_size,omitempty"`
+}
+
 // TrackerSource is a hub.TrackerSource implementation for Krew plugins
 // repositories.
 type TrackerSource struct {
@@ -46,6 +74,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 	packagesAvailable := make(map[string]*hub.Package)
 
 	// Iterate over the path provided looking for available packages
+	bypassDigestCheck := s.i.Svc.Cfg.GetBool("tracker.bypassDigestCheck")
 	pluginsPath := filepath.Join(s.i.BasePath, "plugins")
 	pluginManifestFiles, err := ioutil.ReadDir(pluginsPath)
 	if err != nil {
@@ -82,12 +111,84 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 			s.warn(fmt.Errorf("error preparing package: %w", err))
 			continue
 		}
+
+		// Verify the platforms archives only when the package version is not
+		// registered yet or when its manifest has changed
+		digest, ok := s.i.PackagesRegistered[pkg.BuildKey(p)]
+		if !ok || p.Digest != digest || bypassDigestCheck {
+			p.Data["archives"] = s.verifyArchives(p, manifest)
+		}
 		packagesAvailable[pkg.BuildKey(p)] = p
 	}
 
 	return packagesAvailable, nil
 }
 
+// verifyArchives downloads the archives of each of the platforms supported by
+// the plugin and verifies their checksum. Archives that cannot be downloaded
+// or whose checksum does not match the one in the manifest are flagged as not
+// available.
+func (s *TrackerSource) verifyArchives(p *hub.Package, manifest *index.Plugin) []*platformArchive {
+	archives := make([]*platformArchive, 0, len(manifest.Spec.Platforms))
+	for _, platform := range manifest.Spec.Platforms {
+		a := &platformArchive{
+			OS:     getPlatformOS(platform),
+			Arch:   platform.Selector.MatchLabels[arch],
+			URI:    platform.URI,
+			Sha256: strings.ToLower(platform.Sha256),
+		}
+		data, err := s.getArchive(platform.URI)
+		if err != nil {
+			s.warn(fmt.Errorf("error getting archive %s (package %s version %s): %w", platform.URI, p.Name, p.Version, err))
+			archives = append(archives, a)
+			continue
+		}
+		if checksum := fmt.Sprintf("%x", sha256.Sum256(data)); checksum != a.Sha256 {
+			s.warn(fmt.Errorf("checksum mismatch for archive %s (package %s version %s): expected %s, got %s", platform.URI, p.Name, p.Version, a.Sha256, checksum))
+			archives = append(archives, a)
+			continue
+		}
+		a.Available = true
+		a.ArchiveSize = int64(len(data))
+		a.BinarySize = getBinarySize(data, platform.Bin)
+		archives = append(archives, a)
+	}
+	return archives
+}
+
+// getArchive downloads the archive located at the url provided.
+func (s *TrackerSource) getArchive(archiveURL string) ([]byte, error) {
+	u, err := url.Parse(archiveURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, errors.New("invalid archive url")
+	}
+	req, _ := http.NewRequest("GET", u.String(), nil)
+	req = req.WithContext(s.i.Svc.Ctx)
+	if s.i.Svc.Rl != nil {
+		release, err := s.i.Svc.Rl.Acquire(s.i.Svc.Ctx, s.i.Repository.Name, req.URL.Host)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	resp, err := s.i.Svc.Hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, archiveMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading archive: %w", err)
+	}
+	if len(data) > archiveMaxSize {
+		return nil, errors.New("archive too big")
+	}
+	return data, nil
+}
+
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
 func (s *TrackerSource) warn(err error) {
@@ -129,6 +230,7 @@ func preparePackage(r *hub.Repository, manifest *index.Plugin, manifestRaw []byt
 	p := &hub.Package{
 		Name:        name,
 		Version:     version,
+		Digest:      fmt.Sprintf("%x", sha256.Sum256(manifestRaw)),
 		Description: manifest.Spec.ShortDescription,
 		HomeURL:     manifest.Spec.Homepage,
 		Readme:      manifest.Spec.Description,
@@ -147,11 +249,67 @@ func preparePackage(r *hub.Repository, manifest *index.Plugin, manifestRaw []byt
 	return p, nil
 }
 
+// getPlatformOS returns the operating system (or systems) selected by the
+// platform provided.
+func getPlatformOS(platform index.Platform) string {
+	if v := platform.Selector.MatchLabels[os]; v != "" {
+		return v
+	}
+	for _, e := range platform.Selector.MatchExpressions {
+		if e.Operator == metav1.LabelSelectorOpIn && e.Key == os {
+			return strings.Join(e.Values, ",")
+		}
+	}
+	return ""
+}
+
+// getBinarySize returns the size of the plugin binary in the archive
+// provided. Both tar.gz and zip archives are supported. Zero is returned when
+// the binary cannot be found.
+func getBinarySize(data []byte, bin string) int64 {
+	binName := path.Base(filepath.ToSlash(bin))
+	if binName == "." || binName == "/" {
+		return 0
+	}
+
+	// Zip archive
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return 0
+		}
+		for _, f := range zr.File {
+			if !f.FileInfo().IsDir() && path.Base(f.Name) == binName {
+				return int64(f.UncompressedSize64)
+			}
+		}
+		return 0
+	}
+
+	// Tar.gz archive
+	gzr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return 0
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return 0
+		}
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == binName {
+			return hdr.Size
+		}
+	}
+}
+
 // enrichPackageFromAnnotations adds some extra information to the package from
 // the provided annotations.
 func enrichPackageFromAnnotations(p *hub.Package, annotations map[string]string) error {
-	// Display name
+	// Display name and alias
 	p.DisplayName = annotations[displayNameAnnotation]
+	p.Alias = annotations[aliasAnnotation]
 
 	// Keywords
 	p.Keywords = []string{
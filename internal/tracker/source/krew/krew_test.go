@@ -1,13 +1,19 @@
 package krew
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
 	"io/ioutil"
+	"net/http"
 	"testing"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/tracker/source"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTrackerSource(t *testing.T) {
@@ -79,6 +85,11 @@ func TestTrackerSource(t *testing.T) {
 			BasePath:   "testdata/path4",
 			Svc:        sw.Svc,
 		}
+		archive, _ := ioutil.ReadFile("testdata/test-plugin.tar.gz")
+		sw.Hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(archive)),
+			StatusCode: http.StatusOK,
+		}, nil)
 
 		// Run test and check expectations
 		manifestRaw, _ := ioutil.ReadFile("testdata/path4/plugins/manifest.yaml")
@@ -90,6 +101,7 @@ func TestTrackerSource(t *testing.T) {
 			Keywords:    []string{"kubernetes", "kubectl", "plugin", "networking", "security"},
 			Readme:      "This is just a test plugin",
 			Version:     "0.1.0",
+			Digest:      fmt.Sprintf("%x", sha256.Sum256(manifestRaw)),
 			Provider:    "Some organization",
 			Repository:  i.Repository,
 			License:     "Apache-2.0",
@@ -121,6 +133,17 @@ func TestTrackerSource(t *testing.T) {
 			Data: map[string]interface{}{
 				"manifestRaw": string(manifestRaw),
 				"platforms":   []string{"linux/amd64"},
+				"archives": []*platformArchive{
+					{
+						OS:          "linux",
+						Arch:        "amd64",
+						URI:         "https://test/plugin/test-plugin-linux-amd64.tar.gz",
+						Sha256:      "907167ce217f7a6fd9bf74636ff6fbf12db477358ba4434386eab03306f859fc",
+						Available:   true,
+						ArchiveSize: int64(len(archive)),
+						BinarySize:  27,
+					},
+				},
 			},
 		}
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
@@ -130,4 +153,87 @@ func TestTrackerSource(t *testing.T) {
 		assert.NoError(t, err)
 		sw.AssertExpectations(t)
 	})
+
+	t.Run("package already registered, archives are not verified again", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		manifestRaw, _ := ioutil.ReadFile("testdata/path4/plugins/manifest.yaml")
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{},
+			BasePath:   "testdata/path4",
+			PackagesRegistered: map[string]string{
+				"test-plugin@0.1.0": fmt.Sprintf("%x", sha256.Sum256(manifestRaw)),
+			},
+			Svc: sw.Svc,
+		}
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		assert.NotContains(t, packages["test-plugin@0.1.0"].Data, "archives")
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("archive checksum mismatch, platform flagged as not available", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{},
+			BasePath:   "testdata/path5",
+			Svc:        sw.Svc,
+		}
+		archive, _ := ioutil.ReadFile("testdata/test-plugin.tar.gz")
+		sw.Hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(archive)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		expectedErr := "checksum mismatch for archive https://test/plugin/test-plugin-darwin-arm64.tar.gz (package test-plugin version 0.1.0): expected 0000000000000000000000000000000000000000000000000000000000000000, got 907167ce217f7a6fd9bf74636ff6fbf12db477358ba4434386eab03306f859fc"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		assert.Equal(t, []*platformArchive{
+			{
+				OS:     "darwin",
+				Arch:   "arm64",
+				URI:    "https://test/plugin/test-plugin-darwin-arm64.tar.gz",
+				Sha256: "0000000000000000000000000000000000000000000000000000000000000000",
+			},
+		}, packages["test-plugin@0.1.0"].Data["archives"])
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("error downloading archive, platform flagged as not available", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{},
+			BasePath:   "testdata/path5",
+			Svc:        sw.Svc,
+		}
+		sw.Hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+			StatusCode: http.StatusNotFound,
+		}, nil)
+		expectedErr := "error getting archive https://test/plugin/test-plugin-darwin-arm64.tar.gz (package test-plugin version 0.1.0): unexpected status code received: 404"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		archives := packages["test-plugin@0.1.0"].Data["archives"].([]*platformArchive)
+		require.Len(t, archives, 1)
+		assert.False(t, archives[0].Available)
+		sw.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,404 @@
+package kustomize
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Annotations
+	aliasAnnotation           = "artifacthub.io/alias"
+	displayNameAnnotation     = "artifacthub.io/displayName"
+	keywordsAnnotation        = "artifacthub.io/keywords"
+	licenseAnnotation         = "artifacthub.io/license"
+	linksAnnotation           = "artifacthub.io/links"
+	maintainersAnnotation     = "artifacthub.io/maintainers"
+	providerAnnotation        = "artifacthub.io/provider"
+	recommendationsAnnotation = "artifacthub.io/recommendations"
+
+	// componentKind represents the kind used by Kustomize components.
+	componentKind = "Component"
+
+	// kustomizationKind represents the kind used by Kustomize kustomizations.
+	kustomizationKind = "Kustomization"
+)
+
+// kustomizationFileNames represents the names of the files recognized by
+// Kustomize as kustomization files.
+var kustomizationFileNames = []string{
+	"kustomization.yaml",
+	"kustomization.yml",
+	"Kustomization",
+}
+
+// kustomization represents the subset of the content of a kustomization file
+// used to prepare the packages.
+type kustomization struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name        string            `json:"name"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Resources  []string `json:"resources"`
+	Bases      []string `json:"bases"`
+	Components []string `json:"components"`
+	Images     []struct {
+		Name    string `json:"name"`
+		NewName string `json:"newName"`
+		NewTag  string `json:"newTag"`
+		Digest  string `json:"digest"`
+	} `json:"images"`
+}
+
+// TrackerSource is a hub.TrackerSource implementation for Kustomize bases and
+// components repositories.
+type TrackerSource struct {
+	i *hub.TrackerSourceInput
+}
+
+// NewTrackerSource creates a new TrackerSource instance.
+func NewTrackerSource(i *hub.TrackerSourceInput) *TrackerSource {
+	return &TrackerSource{i}
+}
+
+// GetPackagesAvailable implements the TrackerSource interface.
+func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Parse repository url
+	matches := repo.GitRepoURLRE.FindStringSubmatch(s.i.Repository.URL)
+	if matches == nil {
+		matches = repo.SSHGitRepoURLRE.FindStringSubmatch(s.i.Repository.URL)
+	}
+	if len(matches) != 4 {
+		return nil, errors.New("invalid repository url")
+	}
+	repoBaseURL := matches[1]
+	packagesPath := strings.Trim(matches[3], "/")
+
+	// Get the tags to load the kustomizations versions from
+	gr, err := git.PlainOpenWithOptions(s.i.BasePath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("error opening git repository: %w", err)
+	}
+	tags, err := gr.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("error getting git repository tags: %w", err)
+	}
+	var refs []*plumbing.Reference
+	_ = tags.ForEach(func(ref *plumbing.Reference) error {
+		refs = append(refs, ref)
+		return nil
+	})
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Name().Short() < refs[j].Name().Short()
+	})
+
+	// Load the kustomizations available in each of the tags
+	for _, ref := range refs {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			return nil, s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		// Get version and scope from tag
+		tag := ref.Name().Short()
+		scope, version := parseTag(tag)
+		sv, err := semver.NewVersion(version)
+		if err != nil {
+			// Tags that do not represent a version are ignored
+			continue
+		}
+
+		// Get tag tree
+		hash, err := gr.ResolveRevision(plumbing.Revision(ref.Name().String()))
+		if err != nil {
+			s.warn(fmt.Errorf("error resolving git tag %s: %w", tag, err))
+			continue
+		}
+		commit, err := gr.CommitObject(*hash)
+		if err != nil {
+			s.warn(fmt.Errorf("error getting git tag %s commit: %w", tag, err))
+			continue
+		}
+		tree, err := commit.Tree()
+		if err != nil {
+			s.warn(fmt.Errorf("error getting git tag %s tree: %w", tag, err))
+			continue
+		}
+		if packagesPath != "" {
+			tree, err = tree.Tree(packagesPath)
+			if err != nil {
+				// Packages path not available in this tag
+				continue
+			}
+		}
+
+		// Prepare a package version for each kustomization found
+		dirs, err := getKustomizationsDirs(tree)
+		if err != nil {
+			s.warn(fmt.Errorf("error getting kustomizations in git tag %s: %w", tag, err))
+			continue
+		}
+		for _, dir := range dirs {
+			md := &kustomizationMetadata{
+				dir:      dir,
+				repoURL:  repoBaseURL,
+				repoPath: packagesPath,
+				tag:      tag,
+				version:  sv.String(),
+				ts:       commit.Committer.When.Unix(),
+			}
+			p, err := s.preparePackage(tree, md)
+			if err != nil {
+				s.warn(fmt.Errorf("error preparing package in %s (tag: %s): %w", dir, tag, err))
+				continue
+			}
+			if scope != "" && scope != p.Name && scope != dir {
+				continue
+			}
+			key := pkg.BuildKey(p)
+			if _, ok := packagesAvailable[key]; ok {
+				continue
+			}
+			packagesAvailable[key] = p
+		}
+	}
+
+	return packagesAvailable, nil
+}
+
+// kustomizationMetadata represents some information about where a given
+// kustomization version is located in the git repository.
+type kustomizationMetadata struct {
+	dir      string
+	repoURL  string
+	repoPath string
+	tag      string
+	version  string
+	ts       int64
+}
+
+// preparePackage prepares a package version using the kustomization located in
+// the directory of the git tree provided.
+func (s *TrackerSource) preparePackage(tree *object.Tree, md *kustomizationMetadata) (*hub.Package, error) {
+	// Read and parse kustomization file
+	dirTree := tree
+	if md.dir != "." {
+		var err error
+		dirTree, err = tree.Tree(md.dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var k *kustomization
+	for _, name := range kustomizationFileNames {
+		data, err := readFile(dirTree, name)
+		if err != nil {
+			continue
+		}
+		if err := yaml.Unmarshal(data, &k); err != nil || k == nil {
+			return nil, fmt.Errorf("error unmarshaling kustomization file: %w", err)
+		}
+		break
+	}
+	if k == nil {
+		return nil, errors.New("kustomization file not found")
+	}
+
+	// Prepare package name
+	name := k.Metadata.Name
+	if name == "" {
+		name = path.Base(md.dir)
+		if md.dir == "." {
+			name = s.i.Repository.Name
+		}
+	}
+
+	// Prepare package from kustomization
+	kind := k.Kind
+	if kind != componentKind {
+		kind = kustomizationKind
+	}
+	kPath := path.Join(md.repoPath, md.dir)
+	if kPath == "." {
+		kPath = ""
+	}
+	p := &hub.Package{
+		Name:       name,
+		Version:    md.version,
+		Digest:     dirTree.Hash.String(),
+		ContentURL: fmt.Sprintf("%s//%s?ref=%s", md.repoURL, kPath, md.tag),
+		Repository: s.i.Repository,
+		TS:         md.ts,
+		Data: map[string]interface{}{
+			"kind":       kind,
+			"path":       kPath,
+			"resources":  append(k.Resources, k.Bases...),
+			"components": k.Components,
+		},
+	}
+
+	// Readme
+	for _, f := range dirTree.Entries {
+		if strings.EqualFold(f.Name, "README.md") {
+			if readme, err := readFile(dirTree, f.Name); err == nil {
+				p.Readme = string(readme)
+			}
+			break
+		}
+	}
+
+	// Containers images
+	for _, img := range k.Images {
+		image := img.Name
+		if img.NewName != "" {
+			image = img.NewName
+		}
+		if img.NewTag != "" {
+			image += ":" + img.NewTag
+		}
+		if img.Digest != "" {
+			image += "@" + img.Digest
+		}
+		p.ContainersImages = append(p.ContainersImages, &hub.ContainerImage{
+			Name:  img.Name,
+			Image: image,
+		})
+	}
+
+	// Enrich package with information from annotations
+	if err := enrichPackageFromAnnotations(p, k.Metadata.Annotations); err != nil {
+		return nil, fmt.Errorf("error enriching package %s version %s: %w", name, md.version, err)
+	}
+
+	return p, nil
+}
+
+// warn is a helper that sends the error provided to the errors collector and
+// logs it as a warning.
+func (s *TrackerSource) warn(err error) {
+	s.i.Svc.Logger.Warn().Err(err).Send()
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+}
+
+// parseTag returns the scope and version of the git tag provided. Tags can be
+// scoped to a given kustomization by prefixing the version with its name or
+// path (i.e. base1/v1.0.0). Unscoped tags apply to all the kustomizations in
+// the repository.
+func parseTag(tag string) (string, string) {
+	i := strings.LastIndex(tag, "/")
+	if i == -1 {
+		return "", tag
+	}
+	return tag[:i], tag[i+1:]
+}
+
+// getKustomizationsDirs returns the directories in the git tree provided that
+// contain a kustomization file.
+func getKustomizationsDirs(tree *object.Tree) ([]string, error) {
+	dirsSet := make(map[string]struct{})
+	err := tree.Files().ForEach(func(f *object.File) error {
+		for _, name := range kustomizationFileNames {
+			if path.Base(f.Name) == name {
+				dirsSet[path.Dir(f.Name)] = struct{}{}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	dirs := make([]string, 0, len(dirsSet))
+	for dir := range dirsSet {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// readFile returns the content of the file with the name provided located in
+// the git tree given.
+func readFile(tree *object.Tree, name string) ([]byte, error) {
+	f, err := tree.File(name)
+	if err != nil {
+		return nil, err
+	}
+	r, err := f.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// enrichPackageFromAnnotations adds some extra information to the package from
+// the provided annotations.
+func enrichPackageFromAnnotations(p *hub.Package, annotations map[string]string) error {
+	// Display name and alias
+	p.DisplayName = annotations[displayNameAnnotation]
+	p.Alias = annotations[aliasAnnotation]
+
+	// Keywords
+	p.Keywords = []string{
+		"kubernetes",
+		"kustomize",
+	}
+	if v, ok := annotations[keywordsAnnotation]; ok {
+		var extraKeywords []string
+		if err := yaml.Unmarshal([]byte(v), &extraKeywords); err != nil {
+			return fmt.Errorf("invalid keywords value: %s", v)
+		}
+		p.Keywords = append(p.Keywords, extraKeywords...)
+	}
+
+	// License
+	p.License = annotations[licenseAnnotation]
+
+	// Links
+	if v, ok := annotations[linksAnnotation]; ok {
+		var links []*hub.Link
+		if err := yaml.Unmarshal([]byte(v), &links); err != nil {
+			return fmt.Errorf("invalid links value: %s", v)
+		}
+		p.Links = links
+	}
+
+	// Maintainers
+	if v, ok := annotations[maintainersAnnotation]; ok {
+		var maintainers []*hub.Maintainer
+		if err := yaml.Unmarshal([]byte(v), &maintainers); err != nil {
+			return fmt.Errorf("invalid maintainers value: %s", v)
+		}
+		p.Maintainers = maintainers
+	}
+
+	// Provider
+	p.Provider = annotations[providerAnnotation]
+
+	// Recommendations
+	if v, ok := annotations[recommendationsAnnotation]; ok {
+		var recommendations []*hub.Recommendation
+		if err := yaml.Unmarshal([]byte(v), &recommendations); err != nil {
+			return fmt.Errorf("invalid recommendations value: %s", v)
+		}
+		p.Recommendations = recommendations
+	}
+
+	return nil
+}
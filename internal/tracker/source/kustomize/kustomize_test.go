@@ -0,0 +1,131 @@
+package kustomize
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerSource(t *testing.T) {
+	// Setup git repository with a base and a component released in a tag
+	// and a new version of the base released in a tag scoped to it
+	dir, err := ioutil.TempDir("", "kustomize")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	gr, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := gr.Worktree()
+	require.NoError(t, err)
+	writeFile := func(name, content string) {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+	}
+	commitAndTag := func(tags ...string) {
+		_, err := wt.Add(".")
+		require.NoError(t, err)
+		hash, err := wt.Commit("commit", &git.CommitOptions{
+			Author: &object.Signature{Name: "user1", Email: "user1@email.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		for _, tag := range tags {
+			_, err = gr.CreateTag(tag, hash, nil)
+			require.NoError(t, err)
+		}
+	}
+	writeFile("bases/base1/kustomization.yaml", `
+apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+metadata:
+  annotations:
+    artifacthub.io/displayName: Base 1
+    artifacthub.io/keywords: |
+      - networking
+resources:
+  - deployment.yaml
+images:
+  - name: nginx
+    newName: registry.io/nginx
+    newTag: 1.21.0
+`)
+	writeFile("bases/base1/README.md", "# Base 1")
+	writeFile("components/comp1/kustomization.yaml", `
+apiVersion: kustomize.config.k8s.io/v1alpha1
+kind: Component
+metadata:
+  name: comp1-custom
+`)
+	commitAndTag("v1.0.0", "not-a-version")
+	writeFile("bases/base1/README.md", "# Base 1 updated")
+	commitAndTag("bases/base1/v1.1.0")
+
+	// Load packages available
+	sw := source.NewTestsServicesWrapper()
+	r := &hub.Repository{
+		Kind: hub.Kustomize,
+		Name: "repo1",
+		URL:  "https://github.com/org1/repo1",
+	}
+	i := &hub.TrackerSourceInput{
+		Repository: r,
+		BasePath:   dir,
+		Svc:        sw.Svc,
+	}
+	packages, err := NewTrackerSource(i).GetPackagesAvailable()
+	require.NoError(t, err)
+
+	// Check packages loaded
+	require.Len(t, packages, 3)
+	base1v1 := packages[pkg.BuildKey(&hub.Package{Name: "base1", Version: "1.0.0"})]
+	require.NotNil(t, base1v1)
+	assert.Equal(t, "Base 1", base1v1.DisplayName)
+	assert.Equal(t, "# Base 1", base1v1.Readme)
+	assert.Equal(t, []string{"kubernetes", "kustomize", "networking"}, base1v1.Keywords)
+	assert.Equal(t, "https://github.com/org1/repo1//bases/base1?ref=v1.0.0", base1v1.ContentURL)
+	assert.Equal(t, []*hub.ContainerImage{
+		{Name: "nginx", Image: "registry.io/nginx:1.21.0"},
+	}, base1v1.ContainersImages)
+	assert.Equal(t, "Kustomization", base1v1.Data["kind"])
+	assert.Equal(t, []string{"deployment.yaml"}, base1v1.Data["resources"])
+	base1v2 := packages[pkg.BuildKey(&hub.Package{Name: "base1", Version: "1.1.0"})]
+	require.NotNil(t, base1v2)
+	assert.Equal(t, "# Base 1 updated", base1v2.Readme)
+	assert.Equal(t, "https://github.com/org1/repo1//bases/base1?ref=bases/base1/v1.1.0", base1v2.ContentURL)
+	assert.NotEqual(t, base1v1.Digest, base1v2.Digest)
+	comp1 := packages[pkg.BuildKey(&hub.Package{Name: "comp1-custom", Version: "1.0.0"})]
+	require.NotNil(t, comp1)
+	assert.Equal(t, "Component", comp1.Data["kind"])
+	assert.Equal(t, "components/comp1", comp1.Data["path"])
+	sw.AssertExpectations(t)
+}
+
+func TestParseTag(t *testing.T) {
+	testCases := []struct {
+		tag             string
+		expectedScope   string
+		expectedVersion string
+	}{
+		{"v1.0.0", "", "v1.0.0"},
+		{"base1/v1.0.0", "base1", "v1.0.0"},
+		{"bases/base1/1.0.0", "bases/base1", "1.0.0"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.tag, func(t *testing.T) {
+			t.Parallel()
+			scope, version := parseTag(tc.tag)
+			assert.Equal(t, tc.expectedScope, scope)
+			assert.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package olm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fbcSchemaPrefix represents the prefix used by the schemas of the OLM blobs
+// in file-based catalogs (i.e. olm.package, olm.channel or olm.bundle).
+const fbcSchemaPrefix = "olm."
+
+// getFileBasedCatalog returns the OLM blobs of the file-based catalogs (FBC)
+// available in the path provided as a json stream, in the same format opm
+// renders them. Catalogs declarative config files can be written in json or
+// yaml, and each file can contain multiple blobs.
+func getFileBasedCatalog(basePath string) ([]byte, error) {
+	var catalog bytes.Buffer
+	err := filepath.Walk(basePath, func(path string, info os.FileInfo, err error) error {
+		// Skip paths that cannot be visited, directories and files that are
+		// not declarative config files
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading file %s: %w", path, err)
+		}
+		if !bytes.Contains(data, []byte(fbcSchemaPrefix)) {
+			return nil
+		}
+
+		// Decode blobs and keep the OLM ones
+		var blobs []map[string]interface{}
+		if ext == ".json" {
+			blobs, err = decodeFBCBlobs(json.NewDecoder(bytes.NewReader(data)))
+		} else {
+			blobs, err = decodeFBCBlobs(yaml.NewDecoder(bytes.NewReader(data)))
+		}
+		if err != nil {
+			// Not a declarative config file
+			return nil
+		}
+		for _, blob := range blobs {
+			schema, _ := blob["schema"].(string)
+			if !strings.HasPrefix(schema, fbcSchemaPrefix) {
+				continue
+			}
+			blobJSON, err := json.Marshal(blob)
+			if err != nil {
+				return fmt.Errorf("error encoding blob (file %s): %w", path, err)
+			}
+			catalog.Write(blobJSON)
+			catalog.WriteByte('\n')
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return catalog.Bytes(), nil
+}
+
+// decoder represents a json or yaml stream decoder.
+type decoder interface {
+	Decode(v interface{}) error
+}
+
+// decodeFBCBlobs decodes all the blobs available in the stream provided.
+func decodeFBCBlobs(dec decoder) ([]map[string]interface{}, error) {
+	var blobs []map[string]interface{}
+	for {
+		var blob map[string]interface{}
+		if err := dec.Decode(&blob); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if blob != nil {
+			blobs = append(blobs, blob)
+		}
+	}
+	return blobs, nil
+}
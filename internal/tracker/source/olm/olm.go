@@ -1,6 +1,7 @@
 package olm
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
 	"github.com/artifacthub/hub/internal/tracker/source"
 	"github.com/ghodss/yaml"
 	"github.com/operator-framework/api/pkg/manifests"
@@ -23,11 +25,14 @@ import (
 )
 
 const (
-	bundle          = "bundle"
-	packageManifest = "packageManifest"
+	bundle           = "bundle"
+	fileBasedCatalog = "fileBasedCatalog"
+	packageManifest  = "packageManifest"
 
 	// Artifact Hub special annotations
+	aliasAnnotation           = "artifacthub.io/alias"
 	changesAnnotation         = "artifacthub.io/changes"
+	displayNameAnnotation     = "artifacthub.io/displayName"
 	imagesWhitelistAnnotation = "artifacthub.io/imagesWhitelist"
 	installAnnotation         = "artifacthub.io/install"
 	licenseAnnotation         = "artifacthub.io/license"
@@ -56,8 +61,50 @@ func NewTrackerSource(i *hub.TrackerSourceInput) *TrackerSource {
 func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
 	packagesAvailable := make(map[string]*hub.Package)
 
-	// Walk the path provided looking for available packages
-	err := filepath.Walk(s.i.BasePath, func(path string, info os.FileInfo, err error) error {
+	// Load packages available in the PackageManifest and Bundle formats
+	if err := s.loadPackages(s.i.BasePath, packagesAvailable); err != nil {
+		return nil, err
+	}
+
+	// Load packages available in file-based catalogs, if any. Catalogs are
+	// exported to a temporary directory using the PackageManifest format, so
+	// that their packages can be processed as usual.
+	catalog, err := getFileBasedCatalog(s.i.BasePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file-based catalog: %w", err)
+	}
+	if len(catalog) > 0 {
+		tmpDir, err := ioutil.TempDir("", "artifact-hub")
+		if err != nil {
+			return nil, fmt.Errorf("error creating temp dir: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+		skipped, err := repo.ExportFileBasedCatalog(bytes.NewReader(catalog), tmpDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, err := range skipped {
+			s.warn(err)
+		}
+		catalogPackages := make(map[string]*hub.Package)
+		if err := s.loadPackages(tmpDir, catalogPackages); err != nil {
+			return nil, err
+		}
+		for key, p := range catalogPackages {
+			p.Data["format"] = fileBasedCatalog
+			packagesAvailable[key] = p
+		}
+	}
+
+	preparePackagesChannels(packagesAvailable)
+
+	return packagesAvailable, nil
+}
+
+// loadPackages walks the path provided looking for available packages, that
+// are added to the packages map provided.
+func (s *TrackerSource) loadPackages(basePath string, packages map[string]*hub.Package) error {
+	return filepath.Walk(basePath, func(path string, info os.FileInfo, err error) error {
 		// Return ASAP if context is cancelled
 		select {
 		case <-s.i.Svc.Ctx.Done():
@@ -94,17 +141,10 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 			s.warn(fmt.Errorf("error preparing package %s version %s: %w", md.Name, md.Version, err))
 			return nil
 		}
-		packagesAvailable[pkg.BuildKey(p)] = p
+		packages[pkg.BuildKey(p)] = p
 
 		return nil
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	preparePackagesChannels(packagesAvailable)
-
-	return packagesAvailable, nil
 }
 
 // preparePackage prepares a package version using the provided metadata.
@@ -113,6 +153,7 @@ func (s *TrackerSource) preparePackage(r *hub.Repository, md *Metadata) (*hub.Pa
 	p := &hub.Package{
 		Name:           md.Name,
 		DisplayName:    md.CSV.Spec.DisplayName,
+		Alias:          md.CSV.Annotations[aliasAnnotation],
 		Description:    md.CSV.Annotations["description"],
 		Keywords:       md.CSV.Spec.Keywords,
 		Readme:         md.CSV.Spec.Description,
@@ -127,6 +168,11 @@ func (s *TrackerSource) preparePackage(r *hub.Repository, md *Metadata) (*hub.Pa
 		Repository:     r,
 	}
 
+	// Display name (the one provided in the annotation takes precedence)
+	if v, ok := md.CSV.Annotations[displayNameAnnotation]; ok && v != "" {
+		p.DisplayName = v
+	}
+
 	// Containers images
 	containersImages, err := getContainersImages(md.CSV, md.CSVData)
 	if err != nil {
@@ -247,4 +247,53 @@ func TestTrackerSource(t *testing.T) {
 		assert.NoError(t, err)
 		sw.AssertExpectations(t)
 	})
+
+	t.Run("one package returned (file-based catalog format), no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{},
+			BasePath:   "testdata/path6",
+			Svc:        sw.Svc,
+		}
+
+		// Run test and check expectations
+		p := &hub.Package{
+			Name:         "test-operator",
+			DisplayName:  "Test Operator",
+			Description:  "This is just a test",
+			Keywords:     []string{"Test"},
+			Readme:       "Test Operator README",
+			Version:      "0.1.0",
+			IsOperator:   true,
+			Capabilities: "Basic Install",
+			ContainersImages: []*hub.ContainerImage{
+				{
+					Name:  "operator",
+					Image: "registry.io/test-operator:0.1.0",
+				},
+			},
+			Provider: "Test",
+			Channels: []*hub.Channel{
+				{
+					Name:    "stable",
+					Version: "0.1.0",
+				},
+			},
+			DefaultChannel: "stable",
+			Repository:     i.Repository,
+			Data: map[string]interface{}{
+				"format":           "fileBasedCatalog",
+				"isGlobalOperator": true,
+			},
+		}
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
 }
@@ -17,26 +17,49 @@ import (
 )
 
 const (
+	aliasAnnotation           = "artifacthub.io/alias"
 	changesAnnotation         = "artifacthub.io/changes"
+	displayNameAnnotation     = "artifacthub.io/displayName"
 	licenseAnnotation         = "artifacthub.io/license"
 	linksAnnotation           = "artifacthub.io/links"
 	maintainersAnnotation     = "artifacthub.io/maintainers"
 	providerAnnotation        = "artifacthub.io/provider"
 	recommendationsAnnotation = "artifacthub.io/recommendations"
+
+	// Kinds of the resources available in Tekton bundles
+	bundlePipelineKind = "pipeline"
+	bundleTaskKind     = "task"
 )
 
 // TrackerSource is a hub.TrackerSource implementation for Tekton repositories.
 type TrackerSource struct {
-	i *hub.TrackerSourceInput
+	i  *hub.TrackerSourceInput
+	tg hub.OCITagsGetter
+	bp hub.TektonBundlePuller
 }
 
 // NewTrackerSource creates a new TrackerSource instance.
-func NewTrackerSource(i *hub.TrackerSourceInput) *TrackerSource {
-	return &TrackerSource{i}
+func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource)) *TrackerSource {
+	s := &TrackerSource{i: i}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.tg == nil {
+		s.tg = &repo.OCITagsGetter{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	if s.bp == nil {
+		s.bp = &repo.OCITektonBundlePuller{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	return s
 }
 
 // GetPackagesAvailable implements the TrackerSource interface.
 func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	// Tasks and pipelines distributed as Tekton bundles in OCI registries
+	if strings.HasPrefix(s.i.Repository.URL, hub.RepositoryOCIPrefix) {
+		return s.getBundlesPackages()
+	}
+
 	packagesAvailable := make(map[string]*hub.Package)
 
 	// Walk the path provided looking for available packages
@@ -90,6 +113,161 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 	return packagesAvailable, nil
 }
 
+// getBundlesPackages returns the packages available in the Tekton bundles
+// stored in the repository's OCI registry. Bundles versions are read from the
+// repository tags, and each of the tasks and pipelines available in a bundle
+// version is registered as a separate package.
+func (s *TrackerSource) getBundlesPackages() (map[string]*hub.Package, error) {
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Get versions available (semver tags)
+	tags, err := s.tg.Tags(s.i.Svc.Ctx, s.i.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("error getting repository tags: %w", err)
+	}
+
+	// Prepare packages for each of the versions available
+	bypassDigestCheck := s.i.Svc.Cfg.GetBool("tracker.bypassDigestCheck")
+	for _, tag := range tags {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			return nil, s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		// Bundles versions are not expected to change once published, so the
+		// ones already registered are not pulled again
+		sv, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+		version := sv.String()
+		if registered := s.getRegisteredPackages(version); len(registered) > 0 && !bypassDigestCheck {
+			for _, p := range registered {
+				packagesAvailable[pkg.BuildKey(p)] = p
+			}
+			continue
+		}
+
+		// Pull bundle and prepare a package for each of its resources
+		ref := fmt.Sprintf("%s:%s", s.i.Repository.URL, tag)
+		b, err := s.bp.Pull(s.i.Svc.Ctx, s.i.Repository, ref)
+		if err != nil {
+			s.warn(fmt.Errorf("error pulling bundle %s: %w", ref, err))
+			continue
+		}
+		for _, res := range b.Resources {
+			kind := strings.ToLower(res.Kind)
+			if kind != bundleTaskKind && kind != bundlePipelineKind {
+				continue
+			}
+			p, err := s.prepareBundlePackage(b, res, ref, version)
+			if err != nil {
+				s.warn(fmt.Errorf("error preparing package %s (bundle %s): %w", res.Name, ref, err))
+				continue
+			}
+			packagesAvailable[pkg.BuildKey(p)] = p
+		}
+	}
+
+	return packagesAvailable, nil
+}
+
+// getRegisteredPackages returns a minimal version of the packages registered
+// for the version provided, if any.
+func (s *TrackerSource) getRegisteredPackages(version string) []*hub.Package {
+	var packages []*hub.Package
+	for key, digest := range s.i.PackagesRegistered {
+		i := strings.LastIndex(key, "@")
+		if i == -1 || key[i+1:] != version {
+			continue
+		}
+		packages = append(packages, &hub.Package{
+			Name:       key[:i],
+			Version:    version,
+			Digest:     digest,
+			Repository: s.i.Repository,
+		})
+	}
+	return packages
+}
+
+// prepareBundlePackage prepares a package version from the Tekton bundle
+// resource provided.
+func (s *TrackerSource) prepareBundlePackage(
+	b *hub.TektonBundle,
+	res *hub.TektonBundleResource,
+	ref,
+	version string,
+) (*hub.Package, error) {
+	// Parse resource manifest
+	kind := strings.ToLower(res.Kind)
+	var annotations map[string]string
+	var description string
+	var params []v1beta1.ParamSpec
+	switch kind {
+	case bundleTaskKind:
+		task := &v1beta1.Task{}
+		if err := yaml.Unmarshal(res.Data, &task); err != nil {
+			return nil, fmt.Errorf("error parsing task: %w", err)
+		}
+		annotations, description, params = task.Annotations, task.Spec.Description, task.Spec.Params
+	case bundlePipelineKind:
+		pipeline := &v1beta1.Pipeline{}
+		if err := yaml.Unmarshal(res.Data, &pipeline); err != nil {
+			return nil, fmt.Errorf("error parsing pipeline: %w", err)
+		}
+		annotations, description, params = pipeline.Annotations, pipeline.Spec.Description, pipeline.Spec.Params
+	}
+
+	// Prepare keywords
+	keywords := []string{
+		"tekton",
+		kind,
+	}
+	if tags := annotations["tekton.dev/tags"]; tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			keywords = append(keywords, strings.TrimSpace(tag))
+		}
+	}
+
+	// Prepare package from manifest
+	p := &hub.Package{
+		Name:        res.Name,
+		Version:     version,
+		DisplayName: annotations["tekton.dev/displayName"],
+		Description: description,
+		Readme:      description,
+		Keywords:    keywords,
+		Digest:      b.Digest,
+		ContentURL:  ref,
+		Repository:  s.i.Repository,
+		Data: map[string]interface{}{
+			"bundle":               strings.TrimPrefix(ref, hub.RepositoryOCIPrefix),
+			"kind":                 kind,
+			"manifestRaw":          string(res.Data),
+			"pipelines.minVersion": annotations["tekton.dev/pipelines.minVersion"],
+		},
+	}
+
+	// Include params names (used to filter packages in searches)
+	if len(params) > 0 {
+		paramsNames := make([]string, 0, len(params))
+		for _, param := range params {
+			paramsNames = append(paramsNames, param.Name)
+		}
+		p.Data["params"] = paramsNames
+	}
+
+	// Enrich package with information from annotations
+	if err := enrichPackageFromAnnotations(p, annotations); err != nil {
+		return nil, fmt.Errorf("error enriching package %s version %s: %w", res.Name, version, err)
+	}
+
+	return p, nil
+}
+
 // preparePackage prepares a package version using the package manifest and the
 // files in the path provided.
 func (s *TrackerSource) preparePackage(
@@ -156,6 +334,15 @@ func (s *TrackerSource) preparePackage(
 		},
 	}
 
+	// Include task params names (used to filter packages in searches)
+	if len(manifest.Spec.Params) > 0 {
+		params := make([]string, 0, len(manifest.Spec.Params))
+		for _, param := range manifest.Spec.Params {
+			params = append(params, param.Name)
+		}
+		p.Data["params"] = params
+	}
+
 	// Include readme file if available
 	readme, err := ioutil.ReadFile(filepath.Join(pkgPath, "README.md"))
 	if err == nil {
@@ -211,6 +398,9 @@ func getManifest(pkgPath string) (*v1beta1.Task, []byte, error) {
 // enrichPackageFromAnnotations adds some extra information to the package from
 // the provided annotations.
 func enrichPackageFromAnnotations(p *hub.Package, annotations map[string]string) error {
+	// Alias
+	p.Alias = annotations[aliasAnnotation]
+
 	// Changes
 	if v, ok := annotations[changesAnnotation]; ok {
 		changes, err := source.ParseChangesAnnotation(v)
@@ -220,6 +410,11 @@ func enrichPackageFromAnnotations(p *hub.Package, annotations map[string]string)
 		p.Changes = changes
 	}
 
+	// Display name (takes precedence over the Tekton one)
+	if v, ok := annotations[displayNameAnnotation]; ok && v != "" {
+		p.DisplayName = v
+	}
+
 	// License
 	p.License = annotations[licenseAnnotation]
 
@@ -1,15 +1,44 @@
 package tekton
 
 import (
+	"errors"
 	"io/ioutil"
 	"testing"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/tracker/source"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+const bundleTask = `
+apiVersion: tekton.dev/v1beta1
+kind: Task
+metadata:
+  name: task1
+  annotations:
+    tekton.dev/displayName: Task 1
+    tekton.dev/pipelines.minVersion: 0.17.0
+    tekton.dev/tags: tag1
+    artifacthub.io/license: Apache-2.0
+spec:
+  description: Test task
+  params:
+    - name: url
+`
+
+const bundlePipeline = `
+apiVersion: tekton.dev/v1beta1
+kind: Pipeline
+metadata:
+  name: pipeline1
+spec:
+  description: Test pipeline
+`
+
 func TestTrackerSource(t *testing.T) {
 	t.Run("no packages in path", func(t *testing.T) {
 		t.Parallel()
@@ -115,6 +144,7 @@ func TestTrackerSource(t *testing.T) {
 			Data: map[string]interface{}{
 				"manifestRaw":          string(manifestRaw),
 				"pipelines.minVersion": "0.12.1",
+				"params":               []string{"url", "revision"},
 			},
 		}
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
@@ -124,4 +154,136 @@ func TestTrackerSource(t *testing.T) {
 		assert.NoError(t, err)
 		sw.AssertExpectations(t)
 	})
+
+	t.Run("bundles: error getting repository tags", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{URL: "oci://registry.url/org1/bundle1"},
+			Svc:        sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return(nil, tests.ErrFake)
+		bp := &repo.TektonBundlePullerMock{}
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withBundlePuller(bp)).GetPackagesAvailable()
+		assert.Nil(t, packages)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		tg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("bundles: error pulling bundle", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{URL: "oci://registry.url/org1/bundle1"},
+			Svc:        sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+		bp := &repo.TektonBundlePullerMock{}
+		bp.On("Pull", i.Svc.Ctx, i.Repository, "oci://registry.url/org1/bundle1:1.0.0").Return(nil, tests.ErrFake)
+		expectedErr := "error pulling bundle oci://registry.url/org1/bundle1:1.0.0: fake error for tests"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withBundlePuller(bp)).GetPackagesAvailable()
+		require.NoError(t, err)
+		assert.Empty(t, packages)
+		tg.AssertExpectations(t)
+		bp.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("bundles: packages returned, registered versions are not pulled again", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{URL: "oci://registry.url/org1/bundle1"},
+			PackagesRegistered: map[string]string{
+				"task1@1.0.0": "sha256:100",
+			},
+			Svc: sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0", "v1.1.0"}, nil)
+		ref := "oci://registry.url/org1/bundle1:v1.1.0"
+		bp := &repo.TektonBundlePullerMock{}
+		bp.On("Pull", i.Svc.Ctx, i.Repository, ref).Return(&hub.TektonBundle{
+			Digest: "sha256:110",
+			Resources: []*hub.TektonBundleResource{
+				{
+					APIVersion: "tekton.dev/v1beta1",
+					Kind:       "task",
+					Name:       "task1",
+					Data:       []byte(bundleTask),
+				},
+				{
+					APIVersion: "tekton.dev/v1beta1",
+					Kind:       "pipeline",
+					Name:       "pipeline1",
+					Data:       []byte(bundlePipeline),
+				},
+				{
+					APIVersion: "tekton.dev/v1alpha1",
+					Kind:       "stepaction",
+					Name:       "stepaction1",
+					Data:       []byte("kind: StepAction"),
+				},
+			},
+		}, nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withBundlePuller(bp)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 3)
+		assert.Equal(t, "sha256:100", packages["task1@1.0.0"].Digest)
+		assert.Equal(t, &hub.Package{
+			Name:        "task1",
+			Version:     "1.1.0",
+			DisplayName: "Task 1",
+			Description: "Test task",
+			Readme:      "Test task",
+			Keywords:    []string{"tekton", "task", "tag1"},
+			License:     "Apache-2.0",
+			Digest:      "sha256:110",
+			ContentURL:  ref,
+			Repository:  i.Repository,
+			Data: map[string]interface{}{
+				"bundle":               "registry.url/org1/bundle1:v1.1.0",
+				"kind":                 "task",
+				"manifestRaw":          bundleTask,
+				"pipelines.minVersion": "0.17.0",
+				"params":               []string{"url"},
+			},
+		}, packages["task1@1.1.0"])
+		p2 := packages["pipeline1@1.1.0"]
+		require.NotNil(t, p2)
+		assert.Equal(t, "Test pipeline", p2.Description)
+		assert.Equal(t, []string{"tekton", "pipeline"}, p2.Keywords)
+		assert.Equal(t, "pipeline", p2.Data["kind"])
+		tg.AssertExpectations(t)
+		bp.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+}
+
+func withOCITagsGetter(tg hub.OCITagsGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.tg = tg
+	}
+}
+
+func withBundlePuller(bp hub.TektonBundlePuller) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.bp = bp
+	}
 }
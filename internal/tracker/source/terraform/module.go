@@ -0,0 +1,244 @@
+package terraform
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// module represents the information extracted from a Terraform module.
+type module struct {
+	Inputs          []*input    `json:"inputs,omitempty"`
+	Outputs         []*output   `json:"outputs,omitempty"`
+	Providers       []*provider `json:"providers,omitempty"`
+	RequiredVersion string      `json:"requiredVersion,omitempty"`
+}
+
+// input represents a Terraform module input variable.
+type input struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Required    bool   `json:"required"`
+	Sensitive   bool   `json:"sensitive,omitempty"`
+}
+
+// output represents a Terraform module output value.
+type output struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Sensitive   bool   `json:"sensitive,omitempty"`
+}
+
+// provider represents a provider required by a Terraform module.
+type provider struct {
+	Name    string `json:"name"`
+	Source  string `json:"source,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+var (
+	// moduleSchema represents the schema of the blocks of a Terraform module
+	// needed to extract its inputs, outputs and requirements.
+	moduleSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "variable", LabelNames: []string{"name"}},
+			{Type: "output", LabelNames: []string{"name"}},
+			{Type: "terraform"},
+		},
+	}
+
+	// variableSchema represents the schema of a variable block.
+	variableSchema = &hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "type"},
+			{Name: "description"},
+			{Name: "default"},
+			{Name: "sensitive"},
+		},
+	}
+
+	// outputSchema represents the schema of an output block.
+	outputSchema = &hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "description"},
+			{Name: "sensitive"},
+		},
+	}
+
+	// terraformSchema represents the schema of a terraform block.
+	terraformSchema = &hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "required_version"},
+		},
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "required_providers"},
+		},
+	}
+)
+
+// parseModule extracts the inputs, outputs and requirements of the Terraform
+// module made of the files provided (indexed by file name). Only the files
+// with the .tf extension are processed. Files that cannot be parsed are
+// ignored.
+func parseModule(files map[string][]byte) *module {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if strings.HasSuffix(name, ".tf") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	m := &module{}
+	parser := hclparse.NewParser()
+	for _, name := range names {
+		file, diags := parser.ParseHCL(files[name], name)
+		if diags.HasErrors() {
+			continue
+		}
+		content, _, _ := file.Body.PartialContent(moduleSchema)
+		for _, b := range content.Blocks {
+			switch b.Type {
+			case "variable":
+				m.Inputs = append(m.Inputs, parseInput(file, b))
+			case "output":
+				m.Outputs = append(m.Outputs, parseOutput(b))
+			case "terraform":
+				parseRequirements(m, b)
+			}
+		}
+	}
+	sort.SliceStable(m.Inputs, func(i, j int) bool {
+		if m.Inputs[i].Required != m.Inputs[j].Required {
+			return m.Inputs[i].Required
+		}
+		return m.Inputs[i].Name < m.Inputs[j].Name
+	})
+	sort.SliceStable(m.Outputs, func(i, j int) bool {
+		return m.Outputs[i].Name < m.Outputs[j].Name
+	})
+
+	return m
+}
+
+// parseInput returns the input described by the variable block provided. The
+// type and default value are returned as they were written in the file, as
+// they may use types or reference values that cannot be evaluated here.
+func parseInput(file *hcl.File, b *hcl.Block) *input {
+	content, _, _ := b.Body.PartialContent(variableSchema)
+	i := &input{
+		Name:        b.Labels[0],
+		Description: decodeString(content.Attributes["description"]),
+		Sensitive:   decodeBool(content.Attributes["sensitive"]),
+	}
+	if attr, ok := content.Attributes["type"]; ok {
+		// Legacy syntax: type keyword as a string
+		i.Type = decodeString(attr)
+		if i.Type == "" {
+			i.Type = string(attr.Expr.Range().SliceBytes(file.Bytes))
+		}
+	}
+	if attr, ok := content.Attributes["default"]; ok {
+		i.Default = string(attr.Expr.Range().SliceBytes(file.Bytes))
+	} else {
+		i.Required = true
+	}
+	return i
+}
+
+// parseOutput returns the output described by the output block provided.
+func parseOutput(b *hcl.Block) *output {
+	content, _, _ := b.Body.PartialContent(outputSchema)
+	return &output{
+		Name:        b.Labels[0],
+		Description: decodeString(content.Attributes["description"]),
+		Sensitive:   decodeBool(content.Attributes["sensitive"]),
+	}
+}
+
+// parseRequirements adds the requirements defined in the terraform block
+// provided to the module.
+func parseRequirements(m *module, b *hcl.Block) {
+	content, _, _ := b.Body.PartialContent(terraformSchema)
+	if v := decodeString(content.Attributes["required_version"]); v != "" {
+		m.RequiredVersion = v
+	}
+	for _, rp := range content.Blocks {
+		attrs, _ := rp.Body.JustAttributes()
+		names := make([]string, 0, len(attrs))
+		for name := range attrs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p := &provider{Name: name}
+			pairs, diags := hcl.ExprMap(attrs[name].Expr)
+			if diags.HasErrors() {
+				// Legacy syntax: only the version constraint
+				p.Version = decodeString(attrs[name])
+			}
+			for _, pair := range pairs {
+				var key, value string
+				if gohcl.DecodeExpression(pair.Key, nil, &key).HasErrors() {
+					continue
+				}
+				if gohcl.DecodeExpression(pair.Value, nil, &value).HasErrors() {
+					continue
+				}
+				switch key {
+				case "source":
+					p.Source = value
+				case "version":
+					p.Version = value
+				}
+			}
+			m.Providers = append(m.Providers, p)
+		}
+	}
+}
+
+// decodeString returns the value of the attribute provided when it is a
+// string that can be evaluated without any context, or an empty string
+// otherwise.
+func decodeString(attr *hcl.Attribute) string {
+	var v string
+	if attr == nil || gohcl.DecodeExpression(attr.Expr, nil, &v).HasErrors() {
+		return ""
+	}
+	return v
+}
+
+// decodeBool returns the value of the attribute provided when it is a bool
+// that can be evaluated without any context, or false otherwise.
+func decodeBool(attr *hcl.Attribute) bool {
+	var v bool
+	if attr == nil || gohcl.DecodeExpression(attr.Expr, nil, &v).HasErrors() {
+		return false
+	}
+	return v
+}
+
+// getDescription returns the description of the module from the readme file
+// provided: the first paragraph that is not a heading, badge or html tag.
+func getDescription(readme string) string {
+	var lines []string
+	for _, line := range strings.Split(readme, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		if len(lines) == 0 && strings.IndexAny(line[:1], "#[!<=-|`") == 0 {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, " ")
+}
@@ -0,0 +1,17 @@
+//go:build go1.18
+// +build go1.18
+
+package terraform
+
+import "testing"
+
+func FuzzParseModule(f *testing.F) {
+	f.Add([]byte(`variable "name" { default = {} }`))
+	f.Add([]byte(`variable "name" {`))
+	f.Add([]byte(`a = "${`))
+	f.Add([]byte("a = <<-EOT\n"))
+	f.Add([]byte(`terraform { required_providers { aws = { source = "hashicorp/aws" } } }`))
+	f.Fuzz(func(t *testing.T, src []byte) {
+		parseModule(map[string][]byte{"main.tf": src})
+	})
+}
@@ -0,0 +1,178 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseModule(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{
+		"variables.tf": []byte(`
+# Name of the resources
+variable "name" {
+  description = "Name used for the resources"
+  type        = string
+}
+
+variable "tags" {
+  description = <<-EOT
+    Tags to add to the resources
+    (in addition to the default ones)
+  EOT
+  type = map(object({
+    value = string
+  }))
+  default = {
+    "env" = "${var.name}-dev" // templates are kept as they are
+  }
+}
+
+/* Sensitive input */
+variable "password" {
+  type      = string
+  default   = null
+  sensitive = true
+}
+`),
+		"outputs.tf": []byte(`
+output "id" {
+  description = "Id of the resource"
+  value       = var.enabled ? aws_instance.this[0].id : ""
+}
+`),
+		"versions.tf": []byte(`
+terraform {
+  required_version = ">= 1.0"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 4.0, < 5.0"
+    }
+    random = "~> 3.0"
+  }
+}
+`),
+		"README.md": []byte(`variable "ignored" {}`),
+	}
+	expectedModule := &module{
+		Inputs: []*input{
+			{
+				Name:        "name",
+				Type:        "string",
+				Description: "Name used for the resources",
+				Required:    true,
+			},
+			{
+				Name:      "password",
+				Type:      "string",
+				Default:   "null",
+				Sensitive: true,
+			},
+			{
+				Name:        "tags",
+				Type:        "map(object({\n    value = string\n  }))",
+				Description: "Tags to add to the resources\n(in addition to the default ones)\n",
+				Default:     "{\n    \"env\" = \"${var.name}-dev\" // templates are kept as they are\n  }",
+			},
+		},
+		Outputs: []*output{
+			{
+				Name:        "id",
+				Description: "Id of the resource",
+			},
+		},
+		Providers: []*provider{
+			{
+				Name:    "aws",
+				Source:  "hashicorp/aws",
+				Version: ">= 4.0, < 5.0",
+			},
+			{
+				Name:    "random",
+				Version: "~> 3.0",
+			},
+		},
+		RequiredVersion: ">= 1.0",
+	}
+
+	t.Run("module parsed successfully", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, expectedModule, parseModule(files))
+	})
+
+	t.Run("malformed files are ignored", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []string{
+			"{",
+			"}",
+			"variable {",
+			`variable "name" {`,
+			`variable "name" { description = "desc"`,
+			`variable "name" { default = {`,
+			`variable "name" }`,
+			`variable "a" "b" {}`,
+			"a = {",
+			"a = ",
+			"=",
+			`"`,
+			`a = "${`,
+			"a = <<",
+			"a = <<EOT",
+			"a = <<-",
+			"/*",
+			`a = "\`,
+		}
+		for _, src := range testCases {
+			src := src
+			t.Run(src, func(t *testing.T) {
+				t.Parallel()
+				var m *module
+				assert.NotPanics(t, func() {
+					m = parseModule(map[string][]byte{
+						"main.tf":      []byte(src),
+						"variables.tf": files["variables.tf"],
+					})
+				})
+				assert.Equal(t, expectedModule.Inputs, m.Inputs)
+			})
+		}
+	})
+
+	t.Run("truncated files", func(t *testing.T) {
+		t.Parallel()
+		src := files["variables.tf"]
+		for n := 0; n <= len(src); n++ {
+			assert.NotPanics(t, func() {
+				parseModule(map[string][]byte{"variables.tf": src[:n]})
+			}, string(src[:n]))
+		}
+	})
+}
+
+func TestGetDescription(t *testing.T) {
+	testCases := []struct {
+		readme              string
+		expectedDescription string
+	}{
+		{
+			"",
+			"",
+		},
+		{
+			"# Module\n\n[![badge](https://badge.url)](https://url)\n\nModule description\nin two lines.\n\nUsage",
+			"Module description in two lines.",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.expectedDescription, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedDescription, getDescription(tc.readme))
+		})
+	}
+}
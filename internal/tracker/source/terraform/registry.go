@@ -0,0 +1,243 @@
+package terraform
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+)
+
+const (
+	// registryDiscoveryPath represents the path of the registry service
+	// discovery document.
+	registryDiscoveryPath = "/.well-known/terraform.json"
+
+	// registryModulesService represents the name of the modules service in the
+	// registry service discovery document.
+	registryModulesService = "modules.v1"
+)
+
+// errNotFound indicates that the registry resource requested was not found.
+var errNotFound = errors.New("not found")
+
+// registryModule represents the details of a module version returned by the
+// registry. These details are not part of the registry protocol, but they
+// are provided by the public Terraform registry and some compatible ones.
+type registryModule struct {
+	Description string `json:"description"`
+	Source      string `json:"source"`
+	PublishedAt string `json:"published_at"`
+	Root        struct {
+		Readme string `json:"readme"`
+		Inputs []struct {
+			Name        string `json:"name"`
+			Type        string `json:"type"`
+			Description string `json:"description"`
+			Default     string `json:"default"`
+			Required    bool   `json:"required"`
+		} `json:"inputs"`
+		Outputs []struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		} `json:"outputs"`
+		ProviderDependencies []struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+			Source    string `json:"source"`
+			Version   string `json:"version"`
+		} `json:"provider_dependencies"`
+	} `json:"root"`
+	Submodules []struct {
+		Path string `json:"path"`
+	} `json:"submodules"`
+	Examples []struct {
+		Path string `json:"path"`
+	} `json:"examples"`
+}
+
+// getPackagesAvailableFromRegistry returns the versions of the module
+// published in the registry referenced by the repository url.
+func (s *TrackerSource) getPackagesAvailableFromRegistry() (map[string]*hub.Package, error) {
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Parse repository url
+	matches := repo.TerraformRegistryURLRE.FindStringSubmatch(s.i.Repository.URL)
+	if len(matches) != 5 {
+		return nil, errors.New("invalid repository url")
+	}
+	host, namespace, name, provider := matches[1], matches[2], matches[3], matches[4]
+
+	// Get the modules service url from the registry
+	var discovery map[string]string
+	if err := s.getJSON(fmt.Sprintf("https://%s%s", host, registryDiscoveryPath), &discovery); err != nil {
+		return nil, fmt.Errorf("error getting registry services: %w", err)
+	}
+	baseURL, err := url.Parse(fmt.Sprintf("https://%s/", host))
+	if err != nil {
+		return nil, err
+	}
+	modulesURL, err := baseURL.Parse(discovery[registryModulesService])
+	if err != nil || discovery[registryModulesService] == "" {
+		return nil, errors.New("registry does not support modules")
+	}
+	moduleURL := fmt.Sprintf("%s/%s/%s/%s", strings.TrimSuffix(modulesURL.String(), "/"), namespace, name, provider)
+
+	// Get module versions available
+	var versions struct {
+		Modules []struct {
+			Versions []struct {
+				Version string `json:"version"`
+			} `json:"versions"`
+		} `json:"modules"`
+	}
+	if err := s.getJSON(moduleURL+"/versions", &versions); err != nil {
+		return nil, fmt.Errorf("error getting module versions: %w", err)
+	}
+	if len(versions.Modules) == 0 {
+		return packagesAvailable, nil
+	}
+
+	// Prepare a package version for each of the versions available
+	for _, v := range versions.Modules[0].Versions {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			return nil, s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		sv, err := semver.NewVersion(v.Version)
+		if err != nil {
+			s.warn(fmt.Errorf("invalid module version (%s): %w", v.Version, err))
+			continue
+		}
+		source := fmt.Sprintf("%s/%s/%s/%s", host, namespace, name, provider)
+		p := &hub.Package{
+			Name:       name,
+			Version:    sv.String(),
+			Digest:     fmt.Sprintf("%x", sha256.Sum256([]byte(source+"@"+v.Version))),
+			ContentURL: s.i.Repository.URL + "?version=" + v.Version,
+			Repository: s.i.Repository,
+		}
+
+		// Registry versions are immutable, so the details are only fetched
+		// the first time a version is registered
+		key := pkg.BuildKey(p)
+		if _, ok := s.i.PackagesRegistered[key]; !ok || s.i.Svc.Cfg.GetBool("tracker.bypassDigestCheck") {
+			var md *registryModule
+			err := s.getJSON(moduleURL+"/"+v.Version, &md)
+			switch {
+			case err == nil && md != nil:
+				enrichPackageFromRegistryModule(p, md)
+			case errors.Is(err, errNotFound):
+				// Module details not supported by the registry
+				p.Keywords = []string{"terraform", "module"}
+			default:
+				s.warn(fmt.Errorf("error getting module %s version %s details: %w", name, v.Version, err))
+				continue
+			}
+		}
+		packagesAvailable[key] = p
+	}
+
+	return packagesAvailable, nil
+}
+
+// getJSON gets the document located at the url provided and decodes it into
+// the value given.
+func (s *TrackerSource) getJSON(u string, v interface{}) error {
+	req, _ := http.NewRequest("GET", u, nil)
+	req = req.WithContext(s.i.Svc.Ctx)
+	if s.i.Svc.Rl != nil {
+		release, err := s.i.Svc.Rl.Acquire(s.i.Svc.Ctx, s.i.Repository.Name, req.URL.Host)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+	resp, err := s.i.Svc.Hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return errNotFound
+	default:
+		return fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// enrichPackageFromRegistryModule adds to the package provided the information
+// available in the registry module details given.
+func enrichPackageFromRegistryModule(p *hub.Package, md *registryModule) {
+	p.Description = md.Description
+	p.Readme = md.Root.Readme
+	if p.Description == "" {
+		p.Description = getDescription(p.Readme)
+	}
+	p.HomeURL = md.Source
+	p.Keywords = []string{"terraform", "module"}
+	if t, err := time.Parse(time.RFC3339, md.PublishedAt); err == nil {
+		p.TS = t.Unix()
+	}
+
+	m := &module{}
+	for _, in := range md.Root.Inputs {
+		m.Inputs = append(m.Inputs, &input{
+			Name:        in.Name,
+			Type:        in.Type,
+			Description: in.Description,
+			Default:     in.Default,
+			Required:    in.Required,
+		})
+	}
+	for _, out := range md.Root.Outputs {
+		m.Outputs = append(m.Outputs, &output{
+			Name:        out.Name,
+			Description: out.Description,
+		})
+	}
+	for _, pd := range md.Root.ProviderDependencies {
+		source := pd.Source
+		if source == "" && pd.Namespace != "" {
+			source = pd.Namespace + "/" + pd.Name
+		}
+		m.Providers = append(m.Providers, &provider{
+			Name:    pd.Name,
+			Source:  source,
+			Version: pd.Version,
+		})
+	}
+	submodules := make([]string, 0, len(md.Submodules))
+	for _, sm := range md.Submodules {
+		submodules = append(submodules, strings.TrimPrefix(sm.Path, submodulesDir+"/"))
+	}
+	examples := make([]string, 0, len(md.Examples))
+	for _, e := range md.Examples {
+		examples = append(examples, strings.TrimPrefix(e.Path, examplesDir+"/"))
+	}
+	p.Data = map[string]interface{}{
+		"inputs":     m.Inputs,
+		"outputs":    m.Outputs,
+		"providers":  m.Providers,
+		"submodules": submodules,
+		"examples":   examples,
+	}
+}
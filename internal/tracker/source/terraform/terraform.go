@@ -0,0 +1,253 @@
+package terraform
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	// examplesDir represents the directory where the examples of a module are
+	// expected to be located.
+	examplesDir = "examples"
+
+	// submodulesDir represents the directory where the nested modules of a
+	// module are expected to be located.
+	submodulesDir = "modules"
+)
+
+// TrackerSource is a hub.TrackerSource implementation for Terraform modules
+// repositories. Modules can be stored in git repositories, where the versions
+// are read from the tags, or published in a registry.
+type TrackerSource struct {
+	i *hub.TrackerSourceInput
+}
+
+// NewTrackerSource creates a new TrackerSource instance.
+func NewTrackerSource(i *hub.TrackerSourceInput) *TrackerSource {
+	return &TrackerSource{i}
+}
+
+// GetPackagesAvailable implements the TrackerSource interface.
+func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	if strings.HasPrefix(s.i.Repository.URL, hub.RepositoryTerraformRegistryPrefix) {
+		return s.getPackagesAvailableFromRegistry()
+	}
+	return s.getPackagesAvailableFromGit()
+}
+
+// getPackagesAvailableFromGit returns the versions of the module stored in the
+// git repository cloned in the base path provided to the tracker source. A new
+// version is available for each tag that is a valid semantic version.
+func (s *TrackerSource) getPackagesAvailableFromGit() (map[string]*hub.Package, error) {
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Parse repository url
+	matches := repo.GitRepoURLRE.FindStringSubmatch(s.i.Repository.URL)
+	if matches == nil {
+		matches = repo.SSHGitRepoURLRE.FindStringSubmatch(s.i.Repository.URL)
+	}
+	if len(matches) != 4 {
+		return nil, errors.New("invalid repository url")
+	}
+	repoBaseURL := matches[1]
+	modulePath := strings.Trim(matches[3], "/")
+	name := path.Base(modulePath)
+	if modulePath == "" {
+		name = path.Base(repoBaseURL)
+	}
+
+	// Get the tags to load the module versions from
+	gr, err := git.PlainOpenWithOptions(s.i.BasePath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("error opening git repository: %w", err)
+	}
+	tags, err := gr.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("error getting git repository tags: %w", err)
+	}
+	var refs []*plumbing.Reference
+	_ = tags.ForEach(func(ref *plumbing.Reference) error {
+		refs = append(refs, ref)
+		return nil
+	})
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Name().Short() < refs[j].Name().Short()
+	})
+
+	// Prepare a package version for each tag
+	for _, ref := range refs {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			return nil, s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		// Tags that do not represent a version are ignored
+		tag := ref.Name().Short()
+		sv, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+
+		// Get module tree in tag
+		hash, err := gr.ResolveRevision(plumbing.Revision(ref.Name().String()))
+		if err != nil {
+			s.warn(fmt.Errorf("error resolving git tag %s: %w", tag, err))
+			continue
+		}
+		commit, err := gr.CommitObject(*hash)
+		if err != nil {
+			s.warn(fmt.Errorf("error getting git tag %s commit: %w", tag, err))
+			continue
+		}
+		tree, err := commit.Tree()
+		if err != nil {
+			s.warn(fmt.Errorf("error getting git tag %s tree: %w", tag, err))
+			continue
+		}
+		if modulePath != "" {
+			tree, err = tree.Tree(modulePath)
+			if err != nil {
+				// Module not available in this tag
+				continue
+			}
+		}
+
+		// Prepare package version
+		p := &hub.Package{
+			Name:       name,
+			Version:    sv.String(),
+			Digest:     tree.Hash.String(),
+			ContentURL: getGitModuleSource(repoBaseURL, modulePath, tag),
+			Repository: s.i.Repository,
+			TS:         commit.Committer.When.Unix(),
+		}
+		key := pkg.BuildKey(p)
+		if _, ok := packagesAvailable[key]; ok {
+			continue
+		}
+		digest, ok := s.i.PackagesRegistered[key]
+		if !ok || digest != p.Digest || s.i.Svc.Cfg.GetBool("tracker.bypassDigestCheck") {
+			if err := enrichPackageFromTree(p, tree); err != nil {
+				s.warn(fmt.Errorf("error preparing package %s version %s: %w", p.Name, p.Version, err))
+				continue
+			}
+		}
+		packagesAvailable[key] = p
+	}
+
+	return packagesAvailable, nil
+}
+
+// warn is a helper that sends the error provided to the errors collector and
+// logs it as a warning.
+func (s *TrackerSource) warn(err error) {
+	s.i.Svc.Logger.Warn().Err(err).Send()
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+}
+
+// getGitModuleSource returns the source address that can be used to use the
+// module version located in the path and git tag provided.
+func getGitModuleSource(repoBaseURL, modulePath, tag string) string {
+	source := "git::" + repoBaseURL + ".git"
+	if modulePath != "" {
+		source += "//" + modulePath
+	}
+	return source + "?ref=" + tag
+}
+
+// enrichPackageFromTree adds to the package provided the information extracted
+// from the module located in the git tree given.
+func enrichPackageFromTree(p *hub.Package, tree *object.Tree) error {
+	files, err := readModuleFiles(tree)
+	if err != nil {
+		return err
+	}
+	m := parseModule(files)
+	for name, data := range files {
+		if strings.EqualFold(name, "README.md") {
+			p.Readme = string(data)
+			p.Description = getDescription(p.Readme)
+			break
+		}
+	}
+	p.Keywords = []string{"terraform", "module"}
+	p.Data = map[string]interface{}{
+		"inputs":          m.Inputs,
+		"outputs":         m.Outputs,
+		"providers":       m.Providers,
+		"requiredVersion": m.RequiredVersion,
+		"submodules":      getModulesInDir(tree, submodulesDir),
+		"examples":        getModulesInDir(tree, examplesDir),
+	}
+	return nil
+}
+
+// readModuleFiles returns the content of the Terraform and readme files of the
+// module located in the git tree provided, indexed by file name.
+func readModuleFiles(tree *object.Tree) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, e := range tree.Entries {
+		if !e.Mode.IsFile() {
+			continue
+		}
+		if !strings.HasSuffix(e.Name, ".tf") && !strings.EqualFold(e.Name, "README.md") {
+			continue
+		}
+		f, err := tree.TreeEntryFile(&e)
+		if err != nil {
+			return nil, err
+		}
+		r, err := f.Reader()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[e.Name] = data
+	}
+	return files, nil
+}
+
+// getModulesInDir returns the names of the modules located in the directory of
+// the git tree provided (i.e. modules/* or examples/*).
+func getModulesInDir(tree *object.Tree, dir string) []string {
+	dirTree, err := tree.Tree(dir)
+	if err != nil {
+		return nil
+	}
+	var modules []string
+	for _, e := range dirTree.Entries {
+		if e.Mode.IsFile() {
+			continue
+		}
+		moduleTree, err := dirTree.Tree(e.Name)
+		if err != nil {
+			continue
+		}
+		for _, f := range moduleTree.Entries {
+			if f.Mode.IsFile() && strings.HasSuffix(f.Name, ".tf") {
+				modules = append(modules, e.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(modules)
+	return modules
+}
@@ -0,0 +1,165 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerSourceGit(t *testing.T) {
+	// Setup git repository with two versions of a module released in tags
+	dir, err := ioutil.TempDir("", "terraform")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	gr, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := gr.Worktree()
+	require.NoError(t, err)
+	writeFile := func(name, content string) {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+	}
+	commitAndTag := func(tag string) {
+		_, err := wt.Add(".")
+		require.NoError(t, err)
+		hash, err := wt.Commit("commit", &git.CommitOptions{
+			Author: &object.Signature{Name: "user1", Email: "user1@email.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		_, err = gr.CreateTag(tag, hash, nil)
+		require.NoError(t, err)
+	}
+	writeFile("vpc/main.tf", `variable "name" {}`)
+	writeFile("vpc/README.md", "# VPC\n\nModule description")
+	writeFile("vpc/modules/subnets/main.tf", `variable "cidr" {}`)
+	writeFile("vpc/examples/complete/main.tf", `module "vpc" {}`)
+	commitAndTag("v1.0.0")
+	writeFile("vpc/outputs.tf", `output "id" {}`)
+	commitAndTag("v1.1.0")
+	commitAndTag("latest")
+
+	// Load packages available
+	sw := source.NewTestsServicesWrapper()
+	i := &hub.TrackerSourceInput{
+		Repository: &hub.Repository{
+			Kind: hub.Terraform,
+			URL:  "https://github.com/org1/repo1/vpc",
+		},
+		BasePath: filepath.Join(dir, "vpc"),
+		Svc:      sw.Svc,
+	}
+	packages, err := NewTrackerSource(i).GetPackagesAvailable()
+	require.NoError(t, err)
+
+	// Check packages loaded
+	require.Len(t, packages, 2)
+	v1 := packages[pkg.BuildKey(&hub.Package{Name: "vpc", Version: "1.0.0"})]
+	require.NotNil(t, v1)
+	assert.Equal(t, "Module description", v1.Description)
+	assert.Equal(t, "git::https://github.com/org1/repo1.git//vpc?ref=v1.0.0", v1.ContentURL)
+	assert.Equal(t, []*input{{Name: "name", Required: true}}, v1.Data["inputs"])
+	assert.Nil(t, v1.Data["outputs"])
+	assert.Equal(t, []string{"subnets"}, v1.Data["submodules"])
+	assert.Equal(t, []string{"complete"}, v1.Data["examples"])
+	v2 := packages[pkg.BuildKey(&hub.Package{Name: "vpc", Version: "1.1.0"})]
+	require.NotNil(t, v2)
+	assert.Equal(t, []*output{{Name: "id"}}, v2.Data["outputs"])
+	assert.NotEqual(t, v1.Digest, v2.Digest)
+	sw.AssertExpectations(t)
+
+	// Versions already registered with the same digest are not processed again
+	i.PackagesRegistered = map[string]string{pkg.BuildKey(v1): v1.Digest}
+	packages, err = NewTrackerSource(i).GetPackagesAvailable()
+	require.NoError(t, err)
+	require.Len(t, packages, 2)
+	assert.Nil(t, packages[pkg.BuildKey(v1)].Data)
+	assert.NotNil(t, packages[pkg.BuildKey(v2)].Data)
+}
+
+func TestTrackerSourceRegistry(t *testing.T) {
+	r := &hub.Repository{
+		Kind: hub.Terraform,
+		Name: "repo1",
+		URL:  "tfr://registry.url/org1/vpc/aws",
+	}
+	mockRequest := func(sw *source.TestsServicesWrapper, u string, status int, body string) {
+		req, _ := http.NewRequest("GET", u, nil)
+		sw.Hc.On("Do", req).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			StatusCode: status,
+		}, nil)
+	}
+
+	t.Run("registry does not support modules", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		mockRequest(sw, "https://registry.url/.well-known/terraform.json", http.StatusOK, `{"providers.v1": "/v1/providers/"}`)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.EqualError(t, err, "registry does not support modules")
+		assert.Nil(t, packages)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("module versions loaded successfully", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		mockRequest(sw, "https://registry.url/.well-known/terraform.json", http.StatusOK, `{"modules.v1": "/v1/modules/"}`)
+		mockRequest(sw, "https://registry.url/v1/modules/org1/vpc/aws/versions", http.StatusOK, `
+{"modules": [{"versions": [{"version": "1.0.0"}, {"version": "2.0.0"}]}]}
+`)
+		mockRequest(sw, "https://registry.url/v1/modules/org1/vpc/aws/1.0.0", http.StatusNotFound, "")
+		mockRequest(sw, "https://registry.url/v1/modules/org1/vpc/aws/2.0.0", http.StatusOK, `
+{
+	"description": "VPC module",
+	"source": "https://github.com/org1/terraform-aws-vpc",
+	"published_at": "2021-06-01T10:00:00Z",
+	"root": {
+		"readme": "# VPC",
+		"inputs": [{"name": "name", "type": "string", "required": true}],
+		"provider_dependencies": [{"name": "aws", "namespace": "hashicorp", "version": ">= 4.0"}]
+	},
+	"submodules": [{"path": "modules/subnets"}]
+}
+`)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 2)
+		v1 := packages[pkg.BuildKey(&hub.Package{Name: "vpc", Version: "1.0.0"})]
+		require.NotNil(t, v1)
+		assert.Equal(t, "tfr://registry.url/org1/vpc/aws?version=1.0.0", v1.ContentURL)
+		assert.Nil(t, v1.Data)
+		v2 := packages[pkg.BuildKey(&hub.Package{Name: "vpc", Version: "2.0.0"})]
+		require.NotNil(t, v2)
+		assert.Equal(t, "VPC module", v2.Description)
+		assert.Equal(t, "# VPC", v2.Readme)
+		assert.Equal(t, "https://github.com/org1/terraform-aws-vpc", v2.HomeURL)
+		assert.Equal(t, int64(1622541600), v2.TS)
+		assert.Equal(t, []*input{{Name: "name", Type: "string", Required: true}}, v2.Data["inputs"])
+		assert.Equal(t, []*provider{{Name: "aws", Source: "hashicorp/aws", Version: ">= 4.0"}}, v2.Data["providers"])
+		assert.Equal(t, []string{"subnets"}, v2.Data["submodules"])
+		sw.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,197 @@
+package wasm
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+)
+
+const (
+	// Annotations
+	descriptionAnnotation = "org.opencontainers.image.description"
+	licensesAnnotation    = "org.opencontainers.image.licenses"
+	sourceAnnotation      = "org.opencontainers.image.source"
+	titleAnnotation       = "org.opencontainers.image.title"
+	urlAnnotation         = "org.opencontainers.image.url"
+
+	// wasmCloudConfigMediaType represents the config media type used by the
+	// wasmCloud actors.
+	wasmCloudConfigMediaType = "application/vnd.wasmcloud.actor.archive.config"
+)
+
+// config represents the subset of the fields of a wasm OCI artifact config
+// used to prepare the packages.
+type config struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Component    *struct {
+		Target  string   `json:"target"`
+		Exports []string `json:"exports"`
+		Imports []string `json:"imports"`
+	} `json:"component"`
+}
+
+// TrackerSource is a hub.TrackerSource implementation for WebAssembly modules
+// stored in OCI registries as wasm OCI artifacts.
+type TrackerSource struct {
+	i  *hub.TrackerSourceInput
+	tg hub.OCITagsGetter
+	wg hub.WasmArtifactGetter
+}
+
+// NewTrackerSource creates a new TrackerSource instance.
+func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource)) *TrackerSource {
+	s := &TrackerSource{i: i}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.tg == nil {
+		s.tg = &repo.OCITagsGetter{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	if s.wg == nil {
+		s.wg = &repo.OCIWasmArtifactGetter{Cfg: i.Svc.Cfg, Rl: i.Svc.Rl}
+	}
+	return s
+}
+
+// GetPackagesAvailable implements the TrackerSource interface.
+func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Get versions available (semver tags)
+	tags, err := s.tg.Tags(s.i.Svc.Ctx, s.i.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("error getting repository tags: %w", err)
+	}
+
+	// Prepare a package version for each of the versions available
+	bypassDigestCheck := s.i.Svc.Cfg.GetBool("tracker.bypassDigestCheck")
+	for _, tag := range tags {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			return nil, s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		// Modules versions are not expected to change once published, so the
+		// ones already registered are not processed again
+		ref := fmt.Sprintf("%s:%s", s.i.Repository.URL, tag)
+		if p := s.getRegisteredPackage(tag); p != nil && !bypassDigestCheck {
+			packagesAvailable[pkg.BuildKey(p)] = p
+			continue
+		}
+
+		// Get artifact and prepare package version
+		wa, err := s.wg.Get(s.i.Svc.Ctx, s.i.Repository, ref)
+		if err != nil {
+			s.warn(fmt.Errorf("error getting wasm artifact %s: %w", ref, err))
+			continue
+		}
+		p, err := s.preparePackage(wa, ref, tag)
+		if err != nil {
+			s.warn(fmt.Errorf("error preparing package %s: %w", ref, err))
+			continue
+		}
+		packagesAvailable[pkg.BuildKey(p)] = p
+	}
+
+	return packagesAvailable, nil
+}
+
+// getRegisteredPackage returns a minimal version of the package registered
+// for the tag provided, if any. The package name is the same for all the
+// versions available in the repository.
+func (s *TrackerSource) getRegisteredPackage(tag string) *hub.Package {
+	for key, digest := range s.i.PackagesRegistered {
+		i := strings.LastIndex(key, "@")
+		if i == -1 || key[i+1:] != strings.TrimPrefix(tag, "v") {
+			continue
+		}
+		return &hub.Package{
+			Name:       key[:i],
+			Version:    key[i+1:],
+			Digest:     digest,
+			Repository: s.i.Repository,
+		}
+	}
+	return nil
+}
+
+// warn is a helper that sends the error provided to the errors collector and
+// logs it as a warning.
+func (s *TrackerSource) warn(err error) {
+	s.i.Svc.Logger.Warn().Err(err).Send()
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+}
+
+// preparePackage prepares a package version from the wasm OCI artifact
+// provided. The package name is the name of the repository in the registry,
+// as wasm OCI artifacts do not define one.
+func (s *TrackerSource) preparePackage(wa *hub.WasmArtifact, ref, tag string) (*hub.Package, error) {
+	// Parse artifact config
+	var cfg config
+	if len(wa.Config) > 0 {
+		if err := json.Unmarshal(wa.Config, &cfg); err != nil {
+			return nil, fmt.Errorf("error parsing config: %w", err)
+		}
+	}
+
+	// Prepare package from artifact annotations
+	a := wa.Annotations
+	p := &hub.Package{
+		Name:        path.Base(strings.TrimPrefix(s.i.Repository.URL, hub.RepositoryOCIPrefix)),
+		Version:     strings.TrimPrefix(tag, "v"),
+		DisplayName: a[titleAnnotation],
+		Description: a[descriptionAnnotation],
+		Readme:      a[descriptionAnnotation],
+		HomeURL:     a[urlAnnotation],
+		License:     a[licensesAnnotation],
+		Digest:      wa.Digest,
+		ContentURL:  ref,
+		Repository:  s.i.Repository,
+		Keywords:    []string{"wasm", "webassembly"},
+	}
+	if source := a[sourceAnnotation]; source != "" {
+		p.Links = []*hub.Link{
+			{
+				Name: "source",
+				URL:  source,
+			},
+		}
+	}
+
+	// Prepare package data (target runtime information)
+	p.Data = map[string]interface{}{
+		"mediaType": wa.LayerMediaType,
+		"size":      wa.LayerSize,
+	}
+	if wa.ConfigMediaType == wasmCloudConfigMediaType {
+		p.Data["runtime"] = "wasmcloud"
+		p.Keywords = append(p.Keywords, "wasmcloud")
+	}
+	if cfg.OS != "" {
+		p.Data["os"] = cfg.OS
+	}
+	if cfg.Architecture != "" {
+		p.Data["architecture"] = cfg.Architecture
+	}
+	if cfg.Component != nil {
+		if cfg.Component.Target != "" {
+			p.Data["target"] = cfg.Component.Target
+		}
+		if len(cfg.Component.Exports) > 0 {
+			p.Data["exports"] = cfg.Component.Exports
+		}
+		if len(cfg.Component.Imports) > 0 {
+			p.Data["imports"] = cfg.Component.Imports
+		}
+	}
+
+	return p, nil
+}
@@ -0,0 +1,223 @@
+package wasm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const wasmConfig = `
+{
+	"created": "2024-01-01T00:00:00Z",
+	"architecture": "wasm",
+	"os": "wasip2",
+	"layerDigests": ["sha256:abc"],
+	"component": {
+		"target": "wasi:http/proxy@0.2.0",
+		"exports": ["wasi:http/incoming-handler@0.2.0"],
+		"imports": ["wasi:io/streams@0.2.0", "wasi:http/types@0.2.0"]
+	}
+}
+`
+
+func TestTrackerSource(t *testing.T) {
+	r := &hub.Repository{
+		Kind: hub.Wasm,
+		URL:  "oci://registry.url/org1/hello-world",
+	}
+
+	t.Run("error getting repository tags", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return(nil, tests.ErrFake)
+		wg := &repo.WasmArtifactGetterMock{}
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withWasmArtifactGetter(wg)).GetPackagesAvailable()
+		assert.Nil(t, packages)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		tg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("error getting wasm artifact", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"v1.0.0"}, nil)
+		wg := &repo.WasmArtifactGetterMock{}
+		ref := "oci://registry.url/org1/hello-world:v1.0.0"
+		wg.On("Get", i.Svc.Ctx, i.Repository, ref).Return(nil, tests.ErrFake)
+		expectedErr := "error getting wasm artifact " + ref + ": fake error for tests"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withWasmArtifactGetter(wg)).GetPackagesAvailable()
+		require.NoError(t, err)
+		assert.Empty(t, packages)
+		tg.AssertExpectations(t)
+		wg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"v1.0.0"}, nil)
+		wg := &repo.WasmArtifactGetterMock{}
+		ref := "oci://registry.url/org1/hello-world:v1.0.0"
+		wg.On("Get", i.Svc.Ctx, i.Repository, ref).Return(&hub.WasmArtifact{
+			Digest: "sha256:100",
+			Config: []byte("{invalid"),
+		}, nil)
+		expectedErr := "error preparing package " + ref + ": error parsing config: invalid character 'i' looking for beginning of object key string"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withWasmArtifactGetter(wg)).GetPackagesAvailable()
+		require.NoError(t, err)
+		assert.Empty(t, packages)
+		tg.AssertExpectations(t)
+		wg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("package returned, registered versions are not processed again", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: r,
+			PackagesRegistered: map[string]string{
+				"hello-world@1.0.0": "sha256:100",
+			},
+			Svc: sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"v1.0.0", "v1.1.0"}, nil)
+		wg := &repo.WasmArtifactGetterMock{}
+		ref := "oci://registry.url/org1/hello-world:v1.1.0"
+		wg.On("Get", i.Svc.Ctx, i.Repository, ref).Return(&hub.WasmArtifact{
+			Digest:          "sha256:110",
+			ConfigMediaType: "application/vnd.wasm.config.v0+json",
+			Config:          []byte(wasmConfig),
+			LayerMediaType:  "application/wasm",
+			LayerSize:       1024,
+			Annotations: map[string]string{
+				"org.opencontainers.image.title":       "Hello World",
+				"org.opencontainers.image.description": "Hello world HTTP component",
+				"org.opencontainers.image.licenses":    "Apache-2.0",
+				"org.opencontainers.image.url":         "https://home.url",
+				"org.opencontainers.image.source":      "https://github.com/org1/hello-world",
+			},
+		}, nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withWasmArtifactGetter(wg)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 2)
+		v1 := packages["hello-world@1.0.0"]
+		require.NotNil(t, v1)
+		assert.Equal(t, "sha256:100", v1.Digest)
+		assert.Nil(t, v1.Data)
+		v2 := packages[pkg.BuildKey(&hub.Package{Name: "hello-world", Version: "1.1.0"})]
+		require.NotNil(t, v2)
+		assert.Equal(t, &hub.Package{
+			Name:        "hello-world",
+			Version:     "1.1.0",
+			DisplayName: "Hello World",
+			Description: "Hello world HTTP component",
+			Readme:      "Hello world HTTP component",
+			HomeURL:     "https://home.url",
+			License:     "Apache-2.0",
+			Digest:      "sha256:110",
+			ContentURL:  ref,
+			Keywords:    []string{"wasm", "webassembly"},
+			Links: []*hub.Link{
+				{
+					Name: "source",
+					URL:  "https://github.com/org1/hello-world",
+				},
+			},
+			Data: map[string]interface{}{
+				"mediaType":    "application/wasm",
+				"size":         int64(1024),
+				"os":           "wasip2",
+				"architecture": "wasm",
+				"target":       "wasi:http/proxy@0.2.0",
+				"exports":      []string{"wasi:http/incoming-handler@0.2.0"},
+				"imports":      []string{"wasi:io/streams@0.2.0", "wasi:http/types@0.2.0"},
+			},
+			Repository: r,
+		}, v2)
+		tg.AssertExpectations(t)
+		wg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("wasmCloud actor returned", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{Repository: r, Svc: sw.Svc}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"0.1.0"}, nil)
+		wg := &repo.WasmArtifactGetterMock{}
+		ref := "oci://registry.url/org1/hello-world:0.1.0"
+		wg.On("Get", i.Svc.Ctx, i.Repository, ref).Return(&hub.WasmArtifact{
+			Digest:          "sha256:010",
+			ConfigMediaType: "application/vnd.wasmcloud.actor.archive.config",
+			LayerMediaType:  "application/vnd.module.wasm.content.layer.v1+wasm",
+			LayerSize:       2048,
+		}, nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withWasmArtifactGetter(wg)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		p := packages[pkg.BuildKey(&hub.Package{Name: "hello-world", Version: "0.1.0"})]
+		require.NotNil(t, p)
+		assert.Equal(t, "sha256:010", p.Digest)
+		assert.Equal(t, []string{"wasm", "webassembly", "wasmcloud"}, p.Keywords)
+		assert.Equal(t, map[string]interface{}{
+			"mediaType": "application/vnd.module.wasm.content.layer.v1+wasm",
+			"size":      int64(2048),
+			"runtime":   "wasmcloud",
+		}, p.Data)
+		tg.AssertExpectations(t)
+		wg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+}
+
+func withOCITagsGetter(tg hub.OCITagsGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.tg = tg
+	}
+}
+
+func withWasmArtifactGetter(wg hub.WasmArtifactGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.wg = wg
+	}
+}
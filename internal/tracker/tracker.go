@@ -1,7 +1,9 @@
 package tracker
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -21,6 +23,9 @@ type Tracker struct {
 	r                  *hub.Repository
 	md                 *hub.RepositoryMetadata
 	packagesRegistered map[string]string
+	identicons         map[string]string
+	imagesDigests      map[string]string
+	run                *hub.RepositoryTrackingRun
 	basePath           string
 	logger             zerolog.Logger
 }
@@ -28,27 +33,33 @@ type Tracker struct {
 // New creates a new Tracker instance.
 func New(svc *hub.TrackerServices, r *hub.Repository, logger zerolog.Logger) *Tracker {
 	return &Tracker{
-		svc:    svc,
-		r:      r,
-		logger: logger,
+		svc:           svc,
+		r:             r,
+		identicons:    make(map[string]string),
+		imagesDigests: make(map[string]string),
+		logger:        logger,
 	}
 }
 
 // Run initializes the tracking of the repository provided.
 func (t *Tracker) Run() error {
 	// Check if repository has been updated since last time it was processed
+	prevETag, prevLastModified := t.r.IndexETag, t.r.IndexLastModified
 	remoteDigest, err := t.svc.Rm.GetRemoteDigest(t.svc.Ctx, t.r)
 	if err != nil {
 		return fmt.Errorf("error getting repository remote digest: %w", err)
 	}
 	bypassDigestCheck := t.svc.Cfg.GetBool("tracker.bypassDigestCheck")
 	if remoteDigest != "" && t.r.Digest == remoteDigest && !bypassDigestCheck {
+		t.updateIndexValidators(prevETag, prevLastModified)
 		return nil
 	}
 
 	// Initialize logs for this repository in the errors collector
 	t.logger.Debug().Msg("tracking repository")
 	t.svc.Ec.Init(t.r.RepositoryID)
+	t.run = &hub.RepositoryTrackingRun{}
+	defer t.svc.Ec.Finish(t.r.RepositoryID, t.run)
 
 	// Clone repository when applicable and get its metadata
 	tmpDir, packagesPath, err := t.cloneRepository()
@@ -93,10 +104,54 @@ func (t *Tracker) Run() error {
 			continue
 		}
 
+		// Enforce repository signing policy on new unsigned versions
+		if !ok && !p.Signed {
+			switch t.r.SigningPolicy {
+			case hub.SigningPolicyFlag:
+				t.warn(fmt.Errorf("unsigned package %s version %s registered", p.Name, p.Version))
+			case hub.SigningPolicyReject:
+				t.warn(fmt.Errorf("unsigned package %s version %s rejected by signing policy", p.Name, p.Version))
+				continue
+			}
+		}
+
+		// Apply the package overrides defined in the repository metadata
+		applyPackageOverrides(t.md, p)
+
+		// Use an identicon as logo for packages without one if requested,
+		// flagging it as generated so that it's not taken as the package logo
+		if p.LogoImageID == "" && t.svc.Cfg.GetBool("tracker.generateIdenticons") {
+			logoImageID, err := t.getIdenticon(p.Name)
+			if err != nil {
+				t.warn(fmt.Errorf("error generating identicon for package %s: %w", p.Name, err))
+			} else {
+				p.LogoImageID = logoImageID
+				p.LogoGenerated = true
+			}
+		}
+
+		// Resolve the upstream attribution link of the package version when
+		// some upstream instances have been configured
+		upstreamInstances := t.svc.Cfg.GetStringSlice("tracker.upstreamInstances")
+		if len(upstreamInstances) > 0 && p.Digest != "" {
+			p.Upstream = t.getUpstream(upstreamInstances, p.Digest)
+		}
+
+		// Resolve the digests of the containers images if requested
+		if t.svc.Cfg.GetBool("tracker.resolveImagesDigests") && t.svc.Dr != nil {
+			t.resolveImagesDigests(p)
+		}
+
 		// Register package
 		t.logger.Debug().Str("name", p.Name).Str("v", p.Version).Msg("registering package")
 		if err := t.svc.Pm.Register(t.svc.Ctx, p); err != nil {
 			t.warn(fmt.Errorf("error registering package %s version %s: %w", p.Name, p.Version, err))
+			continue
+		}
+		if ok {
+			t.run.PackagesUpdated++
+		} else {
+			t.run.PackagesAdded++
 		}
 	}
 
@@ -122,11 +177,20 @@ func (t *Tracker) Run() error {
 				}
 				if err := t.svc.Pm.Unregister(t.svc.Ctx, p); err != nil {
 					t.warn(fmt.Errorf("error unregistering package %s version %s: %w", name, version, err))
+					continue
 				}
+				t.run.PackagesDeleted++
 			}
 		}
 	}
 
+	// Set packages overrides defined in the repository metadata file
+	if t.md != nil {
+		if err := t.svc.Rm.SetPackagesOverrides(t.svc.Ctx, t.r.RepositoryID, t.md.Packages); err != nil {
+			t.warn(fmt.Errorf("error setting packages overrides: %w", err))
+		}
+	}
+
 	// Set verified publisher flag if needed
 	if err := setVerifiedPublisherFlag(t.svc.Ctx, t.svc.Rm, t.r, t.md); err != nil {
 		t.warn(fmt.Errorf("error setting verified publisher flag: %w", err))
@@ -139,9 +203,24 @@ func (t *Tracker) Run() error {
 		}
 	}
 
+	// Update repository index validators if needed
+	t.updateIndexValidators(prevETag, prevLastModified)
+
 	return nil
 }
 
+// updateIndexValidators stores the repository index validators obtained while
+// getting the remote digest when they are different from the previous ones.
+func (t *Tracker) updateIndexValidators(prevETag, prevLastModified string) {
+	if t.r.IndexETag == prevETag && t.r.IndexLastModified == prevLastModified {
+		return
+	}
+	err := t.svc.Rm.UpdateIndexValidators(t.svc.Ctx, t.r.RepositoryID, t.r.IndexETag, t.r.IndexLastModified)
+	if err != nil {
+		t.logger.Warn().Err(fmt.Errorf("error updating repository index validators: %w", err)).Send()
+	}
+}
+
 // cloneRepository creates a local cope of the repository provided to the
 // tracker instance when applicable to the repository kind.
 func (t *Tracker) cloneRepository() (string, string, error) {
@@ -150,23 +229,43 @@ func (t *Tracker) cloneRepository() (string, string, error) {
 
 	switch t.r.Kind {
 	case hub.Helm:
-		// Helm repositories are not cloned
+		// Helm repositories are not cloned, unless the charts are stored
+		// unpackaged in a git repository
+		if repo.IsHelmGitRepository(t.r) {
+			tmpDir, packagesPath, err = t.svc.Rc.CloneRepository(t.svc.Ctx, t.r)
+		}
 	case hub.OLM:
 		if strings.HasPrefix(t.r.URL, hub.RepositoryOCIPrefix) {
 			tmpDir, err = t.svc.Oe.ExportRepository(t.svc.Ctx, t.r)
 		} else {
 			tmpDir, packagesPath, err = t.svc.Rc.CloneRepository(t.svc.Ctx, t.r)
 		}
+	case hub.Terraform:
+		// Modules published in a registry are not cloned
+		if !strings.HasPrefix(t.r.URL, hub.RepositoryTerraformRegistryPrefix) {
+			tmpDir, packagesPath, err = t.svc.Rc.CloneRepository(t.svc.Ctx, t.r)
+		}
+	case hub.TektonTask:
+		// Tekton bundles stored in OCI registries are not cloned
+		if !strings.HasPrefix(t.r.URL, hub.RepositoryOCIPrefix) {
+			tmpDir, packagesPath, err = t.svc.Rc.CloneRepository(t.svc.Ctx, t.r)
+		}
 	case
 		hub.Falco,
 		hub.HelmPlugin,
 		hub.Krew,
 		hub.OPA,
 		hub.TBAction,
-		hub.TektonTask,
 		hub.KedaScaler,
 		hub.CoreDNS,
-		hub.Keptn:
+		hub.Keptn,
+		hub.Kustomize,
+		hub.Kyverno,
+		hub.Gatekeeper,
+		hub.ArgoWorkflowTemplate,
+		hub.Backstage,
+		hub.Headlamp,
+		hub.Knative:
 		tmpDir, packagesPath, err = t.svc.Rc.CloneRepository(t.svc.Ctx, t.r)
 	}
 
@@ -180,9 +279,12 @@ func (t *Tracker) getRepositoryMetadata() *hub.RepositoryMetadata {
 	u, _ := url.Parse(t.r.URL)
 	switch t.r.Kind {
 	case hub.Helm:
-		if repo.SchemeIsHTTP(u) {
+		switch {
+		case repo.SchemeIsHTTP(u):
 			u.Path = path.Join(u.Path, hub.RepositoryMetadataFile)
 			md, _ = t.svc.Rm.GetMetadata(u.String())
+		case repo.SchemeIsGit(u):
+			md, _ = t.svc.Rm.GetMetadata(filepath.Join(t.basePath, hub.RepositoryMetadataFile))
 		}
 	case
 		hub.Falco,
@@ -191,11 +293,25 @@ func (t *Tracker) getRepositoryMetadata() *hub.RepositoryMetadata {
 		hub.OLM,
 		hub.OPA,
 		hub.TBAction,
-		hub.TektonTask,
 		hub.KedaScaler,
 		hub.CoreDNS,
-		hub.Keptn:
+		hub.Keptn,
+		hub.Kustomize,
+		hub.Kyverno,
+		hub.Gatekeeper,
+		hub.ArgoWorkflowTemplate,
+		hub.Backstage,
+		hub.Headlamp,
+		hub.Knative:
 		md, _ = t.svc.Rm.GetMetadata(filepath.Join(t.basePath, hub.RepositoryMetadataFile))
+	case hub.Terraform:
+		if !strings.HasPrefix(t.r.URL, hub.RepositoryTerraformRegistryPrefix) {
+			md, _ = t.svc.Rm.GetMetadata(filepath.Join(t.basePath, hub.RepositoryMetadataFile))
+		}
+	case hub.TektonTask:
+		if !strings.HasPrefix(t.r.URL, hub.RepositoryOCIPrefix) {
+			md, _ = t.svc.Rm.GetMetadata(filepath.Join(t.basePath, hub.RepositoryMetadataFile))
+		}
 	}
 
 	return md
@@ -207,22 +323,134 @@ func (t *Tracker) getRepositoryMetadata() *hub.RepositoryMetadata {
 func (t *Tracker) getPackagesAvailable() (map[string]*hub.Package, error) {
 	i := &hub.TrackerSourceInput{
 		Repository:         t.r,
+		Metadata:           t.md,
 		PackagesRegistered: t.packagesRegistered,
 		BasePath:           t.basePath,
 		Svc: &hub.TrackerSourceServices{
-			Ctx:      t.svc.Ctx,
-			Cfg:      t.svc.Cfg,
-			Ec:       t.svc.Ec,
-			Hc:       t.svc.Hc,
-			Is:       t.svc.Is,
-			Logger:   t.logger,
-			GithubRL: t.svc.GithubRL,
+			Ctx:    t.svc.Ctx,
+			Cfg:    t.svc.Cfg,
+			Ec:     t.svc.Ec,
+			Hc:     t.svc.Hc,
+			Is:     t.svc.Is,
+			Logger: t.logger,
+			Rl:     t.svc.Rl,
+			Cc:     t.svc.Cc,
 		},
 	}
 	source := t.svc.SetupTrackerSource(i)
 	return source.GetPackagesAvailable()
 }
 
+// getIdenticon returns the id of the identicon image for the package
+// provided, generating and storing it when needed.
+func (t *Tracker) getIdenticon(pkgName string) (string, error) {
+	if imageID, ok := t.identicons[pkgName]; ok {
+		return imageID, nil
+	}
+	imageID, err := generateIdenticon(t.svc.Ctx, t.svc.Is, t.r.RepositoryID, pkgName)
+	if err != nil {
+		return "", err
+	}
+	t.identicons[pkgName] = imageID
+	return imageID, nil
+}
+
+// resolveImagesDigests sets the digest each of the package's containers images
+// references currently points to. Digests are cached for the whole tracking
+// run, as the same images are usually referenced by many package versions.
+// Errors are only reported once per image and they don't prevent the package
+// from being registered.
+func (t *Tracker) resolveImagesDigests(p *hub.Package) {
+	for _, image := range p.ContainersImages {
+		if image.Digest != "" {
+			continue
+		}
+		digest, ok := t.imagesDigests[image.Image]
+		if !ok {
+			var err error
+			digest, err = t.svc.Dr.Resolve(t.svc.Ctx, t.r, image.Image)
+			if err != nil {
+				t.warn(fmt.Errorf("error resolving image %s digest: %w", image.Image, err))
+			}
+			t.imagesDigests[image.Image] = digest
+		}
+		image.Digest = digest
+	}
+}
+
+// getUpstream returns the upstream attribution link of the package version
+// with the digest provided. The upstream instances are queried in order and
+// the first match found is used. When the package version found is a mirror
+// itself, its upstream link is returned instead so that the canonical source
+// is always referenced. Errors are only logged, as they shouldn't prevent the
+// package from being registered.
+func (t *Tracker) getUpstream(instances []string, digest string) *hub.PackageUpstream {
+	for _, instanceURL := range instances {
+		instanceURL = strings.TrimSuffix(instanceURL, "/")
+		p, err := t.getUpstreamPackage(instanceURL, digest)
+		if err != nil {
+			t.logger.Warn().Err(err).Str("instance", instanceURL).Str("digest", digest).Msg("error resolving package upstream")
+			continue
+		}
+		if p == nil {
+			continue
+		}
+		if p.Upstream != nil {
+			return p.Upstream
+		}
+		return &hub.PackageUpstream{
+			InstanceURL:    instanceURL,
+			PackageID:      p.PackageID,
+			PackageName:    p.Name,
+			Version:        p.Version,
+			RepositoryName: p.Repository.Name,
+			URL: fmt.Sprintf("%s/packages/%s/%s/%s/%s",
+				instanceURL,
+				hub.GetKindName(p.Repository.Kind),
+				p.Repository.Name,
+				p.NormalizedName,
+				p.Version,
+			),
+		}
+	}
+	return nil
+}
+
+// getUpstreamPackage returns the package version with the digest provided
+// registered in the hub instance given, if any.
+func (t *Tracker) getUpstreamPackage(instanceURL, digest string) (*hub.Package, error) {
+	u := fmt.Sprintf("%s/api/v1/packages/digest/%s", instanceURL, url.PathEscape(digest))
+	req, _ := http.NewRequest("GET", u, nil)
+	req = req.WithContext(t.svc.Ctx)
+	if t.svc.Rl != nil {
+		release, err := t.svc.Rl.Acquire(t.svc.Ctx, t.r.Name, req.URL.Host)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	resp, err := t.svc.Hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	var p *hub.Package
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return nil, fmt.Errorf("error decoding upstream package: %w", err)
+	}
+	if p == nil || p.Repository == nil {
+		return nil, nil
+	}
+	return p, nil
+}
+
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
 func (t *Tracker) warn(err error) {
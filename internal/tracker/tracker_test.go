@@ -3,6 +3,8 @@ package tracker
 import (
 	"context"
 	"errors"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -15,7 +17,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
-	"golang.org/x/time/rate"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestTracker(t *testing.T) {
@@ -83,6 +85,7 @@ func TestTracker(t *testing.T) {
 		sw.svc.Cfg.Set("tracker.bypassDigestCheck", true)
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r).Return(r.Digest, nil)
 		sw.ec.On("Init", r.RepositoryID)
+		sw.ec.On("Finish", r.RepositoryID, &hub.RepositoryTrackingRun{})
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r.RepositoryID).Return(nil, tests.ErrFake)
 
 		// Run test and check expectations
@@ -107,6 +110,11 @@ func TestTracker(t *testing.T) {
 				RepositoryID: "test3",
 				Kind:         hub.OPA,
 			},
+			{
+				RepositoryID: "test4",
+				Kind:         hub.Helm,
+				URL:          "git+https://github.com/org1/repo1/charts",
+			},
 		}
 		for _, r := range repositories {
 			r := r
@@ -117,6 +125,7 @@ func TestTracker(t *testing.T) {
 				sw := newServicesWrapper()
 				sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r).Return("", nil)
 				sw.ec.On("Init", r.RepositoryID)
+				sw.ec.On("Finish", r.RepositoryID, &hub.RepositoryTrackingRun{})
 				switch r.Kind {
 				case hub.OLM:
 					if strings.HasPrefix(r.URL, hub.RepositoryOCIPrefix) {
@@ -124,7 +133,7 @@ func TestTracker(t *testing.T) {
 					} else {
 						sw.rc.On("CloneRepository", sw.svc.Ctx, r).Return("", "", tests.ErrFake)
 					}
-				case hub.OPA:
+				case hub.OPA, hub.Helm:
 					sw.rc.On("CloneRepository", sw.svc.Ctx, r).Return("", "", tests.ErrFake)
 				}
 
@@ -143,6 +152,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, tests.ErrFake)
 
@@ -159,6 +169,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
 		sw.src.On("GetPackagesAvailable").Return(nil, tests.ErrFake)
@@ -176,6 +187,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{}, nil)
@@ -193,6 +205,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
@@ -215,6 +228,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(map[string]string{
 			pkg.BuildKey(p1v1): "",
@@ -236,6 +250,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(&hub.RepositoryMetadata{
 			Ignore: []*hub.RepositoryIgnoreEntry{
 				{
@@ -247,6 +262,7 @@ func TestTracker(t *testing.T) {
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
 			pkg.BuildKey(p1v1): p1v1,
 		}, nil)
+		sw.rm.On("SetPackagesOverrides", sw.svc.Ctx, r1.RepositoryID, []*hub.RepositoryPackageOverride(nil)).Return(nil)
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -261,6 +277,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{PackagesAdded: 1})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
@@ -274,6 +291,148 @@ func TestTracker(t *testing.T) {
 		sw.assertExpectations(t)
 	})
 
+	t.Run("package registered with overrides, error setting packages overrides", func(t *testing.T) {
+		t.Parallel()
+		p := &hub.Package{
+			Name:       "pkg1",
+			Version:    "1.0.0",
+			Repository: r1,
+		}
+		overrides := []*hub.RepositoryPackageOverride{
+			{
+				Name:        "pkg1",
+				DisplayName: "Package 1",
+				Category:    "database",
+				Hidden:      true,
+			},
+		}
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{PackagesAdded: 1})
+		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(&hub.RepositoryMetadata{
+			Version:  hub.RepositoryMetadataV2,
+			Packages: overrides,
+		}, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, nil)
+		sw.pm.On("Register", sw.svc.Ctx, mock.MatchedBy(func(p *hub.Package) bool {
+			return p.Name == "pkg1" && p.DisplayName == "Package 1"
+		})).Return(nil)
+		sw.rm.On("SetPackagesOverrides", sw.svc.Ctx, r1.RepositoryID, overrides).Return(tests.ErrFake)
+		expectedErr := "error setting packages overrides: fake error for tests"
+		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("unsigned package registered and flagged by signing policy", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{
+			RepositoryID:  "repo1",
+			Kind:          hub.Helm,
+			URL:           "https://repo.url",
+			SigningPolicy: hub.SigningPolicyFlag,
+		}
+		p := &hub.Package{
+			Name:       "pkg1",
+			Version:    "1.0.0",
+			Repository: r,
+		}
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r).Return("", nil)
+		sw.ec.On("Init", r.RepositoryID)
+		sw.ec.On("Finish", r.RepositoryID, &hub.RepositoryTrackingRun{PackagesAdded: 1})
+		sw.rm.On("GetMetadata", r.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, nil)
+		expectedErr := "unsigned package pkg1 version 1.0.0 registered"
+		sw.ec.On("Append", r.RepositoryID, expectedErr).Return()
+		sw.pm.On("Register", sw.svc.Ctx, p).Return(nil)
+
+		// Run test and check expectations
+		err := New(sw.svc, r, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("unsigned package not registered because it was rejected by signing policy", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{
+			RepositoryID:  "repo1",
+			Kind:          hub.Helm,
+			URL:           "https://repo.url",
+			SigningPolicy: hub.SigningPolicyReject,
+		}
+		p := &hub.Package{
+			Name:       "pkg1",
+			Version:    "1.0.0",
+			Repository: r,
+		}
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r).Return("", nil)
+		sw.ec.On("Init", r.RepositoryID)
+		sw.ec.On("Finish", r.RepositoryID, &hub.RepositoryTrackingRun{})
+		sw.rm.On("GetMetadata", r.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, nil)
+		expectedErr := "unsigned package pkg1 version 1.0.0 rejected by signing policy"
+		sw.ec.On("Append", r.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		err := New(sw.svc, r, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("signed package registered when signing policy is enforced", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{
+			RepositoryID:  "repo1",
+			Kind:          hub.Helm,
+			URL:           "https://repo.url",
+			SigningPolicy: hub.SigningPolicyReject,
+		}
+		p := &hub.Package{
+			Name:       "pkg1",
+			Version:    "1.0.0",
+			Signed:     true,
+			Repository: r,
+		}
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r).Return("", nil)
+		sw.ec.On("Init", r.RepositoryID)
+		sw.ec.On("Finish", r.RepositoryID, &hub.RepositoryTrackingRun{PackagesAdded: 1})
+		sw.rm.On("GetMetadata", r.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, nil)
+		sw.pm.On("Register", sw.svc.Ctx, p).Return(nil)
+
+		// Run test and check expectations
+		err := New(sw.svc, r, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		sw.assertExpectations(t)
+	})
+
 	t.Run("package registered again because digest has changed", func(t *testing.T) {
 		t.Parallel()
 
@@ -281,6 +440,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{PackagesUpdated: 1})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(map[string]string{
 			pkg.BuildKey(p1v1): "new digest",
@@ -303,6 +463,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{PackagesAdded: 2})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
@@ -318,6 +479,202 @@ func TestTracker(t *testing.T) {
 		sw.assertExpectations(t)
 	})
 
+	t.Run("packages registered with identicon as logo", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.svc.Cfg.Set("tracker.generateIdenticons", true)
+		p1v1 := &hub.Package{Name: "pkg1", Version: "1.0.0", Repository: r1}
+		p1v2 := &hub.Package{Name: "pkg1", Version: "2.0.0", Repository: r1}
+		p2v1 := &hub.Package{Name: "pkg2", Version: "1.0.0", Repository: r1, LogoImageID: "logoImageID"}
+		identicon, _ := img.GenerateIdenticon(r1.RepositoryID + "/" + p1v1.Name)
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{PackagesAdded: 3})
+		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p1v1): p1v1,
+			pkg.BuildKey(p1v2): p1v2,
+			pkg.BuildKey(p2v1): p2v1,
+		}, nil)
+		sw.is.On("SaveImage", sw.svc.Ctx, identicon).Return("identiconImageID", nil).Once()
+		sw.pm.On("Register", sw.svc.Ctx, p1v1).Return(nil)
+		sw.pm.On("Register", sw.svc.Ctx, p1v2).Return(nil)
+		sw.pm.On("Register", sw.svc.Ctx, p2v1).Return(nil)
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		assert.Equal(t, "identiconImageID", p1v1.LogoImageID)
+		assert.True(t, p1v1.LogoGenerated)
+		assert.Equal(t, "identiconImageID", p1v2.LogoImageID)
+		assert.True(t, p1v2.LogoGenerated)
+		assert.Equal(t, "logoImageID", p2v1.LogoImageID)
+		assert.False(t, p2v1.LogoGenerated)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error generating identicon, package registered anyway", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.svc.Cfg.Set("tracker.generateIdenticons", true)
+		p1v1 := &hub.Package{Name: "pkg1", Version: "1.0.0", Repository: r1}
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{PackagesAdded: 1})
+		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p1v1): p1v1,
+		}, nil)
+		sw.is.On("SaveImage", sw.svc.Ctx, mock.Anything).Return("", tests.ErrFake)
+		sw.ec.On("Append", r1.RepositoryID, mock.Anything).Return()
+		sw.pm.On("Register", sw.svc.Ctx, p1v1).Return(nil)
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		assert.Empty(t, p1v1.LogoImageID)
+		assert.False(t, p1v1.LogoGenerated)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("packages registered with upstream attribution link", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.svc.Cfg.Set("tracker.upstreamInstances", []string{"https://hub1.io", "https://hub2.io/"})
+		p1v1 := &hub.Package{Name: "pkg1", Version: "1.0.0", Digest: "digest1", Repository: r1}
+		p1v2 := &hub.Package{Name: "pkg1", Version: "2.0.0", Repository: r1}
+		p2v1 := &hub.Package{Name: "pkg2", Version: "1.0.0", Digest: "digest2", Repository: r1}
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{PackagesAdded: 3})
+		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p1v1): p1v1,
+			pkg.BuildKey(p1v2): p1v2,
+			pkg.BuildKey(p2v1): p2v1,
+		}, nil)
+		upstreamReq := func(u string) interface{} {
+			return mock.MatchedBy(func(req *http.Request) bool {
+				return req.URL.String() == u
+			})
+		}
+		sw.hc.On("Do", upstreamReq("https://hub1.io/api/v1/packages/digest/digest1")).Return(&http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil)
+		sw.hc.On("Do", upstreamReq("https://hub2.io/api/v1/packages/digest/digest1")).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(`{
+				"package_id": "pkgID",
+				"name": "Pkg1",
+				"normalized_name": "pkg1",
+				"version": "1.0.0",
+				"repository": {"name": "repo1", "kind": 0}
+			}`)),
+		}, nil)
+		sw.hc.On("Do", upstreamReq("https://hub1.io/api/v1/packages/digest/digest2")).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(`{
+				"package_id": "pkgID",
+				"name": "pkg2",
+				"normalized_name": "pkg2",
+				"version": "1.0.0",
+				"repository": {"name": "mirror", "kind": 0},
+				"upstream": {
+					"instance_url": "https://artifacthub.io",
+					"package_id": "upstreamPkgID",
+					"package_name": "pkg2",
+					"version": "1.0.0",
+					"repository_name": "repo2",
+					"url": "https://artifacthub.io/packages/helm/repo2/pkg2/1.0.0"
+				}
+			}`)),
+		}, nil)
+		sw.pm.On("Register", sw.svc.Ctx, p1v1).Return(nil)
+		sw.pm.On("Register", sw.svc.Ctx, p1v2).Return(nil)
+		sw.pm.On("Register", sw.svc.Ctx, p2v1).Return(nil)
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		assert.Equal(t, &hub.PackageUpstream{
+			InstanceURL:    "https://hub2.io",
+			PackageID:      "pkgID",
+			PackageName:    "Pkg1",
+			Version:        "1.0.0",
+			RepositoryName: "repo1",
+			URL:            "https://hub2.io/packages/helm/repo1/pkg1/1.0.0",
+		}, p1v1.Upstream)
+		assert.Nil(t, p1v2.Upstream)
+		assert.Equal(t, &hub.PackageUpstream{
+			InstanceURL:    "https://artifacthub.io",
+			PackageID:      "upstreamPkgID",
+			PackageName:    "pkg2",
+			Version:        "1.0.0",
+			RepositoryName: "repo2",
+			URL:            "https://artifacthub.io/packages/helm/repo2/pkg2/1.0.0",
+		}, p2v1.Upstream)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("packages registered with containers images digests resolved", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.svc.Cfg.Set("tracker.resolveImagesDigests", true)
+		p1v1 := &hub.Package{
+			Name:    "pkg1",
+			Version: "1.0.0",
+			ContainersImages: []*hub.ContainerImage{
+				{Image: "repo/img1:1.0.0"},
+				{Image: "repo/img2:1.0.0"},
+			},
+			Repository: r1,
+		}
+		p1v2 := &hub.Package{
+			Name:    "pkg1",
+			Version: "2.0.0",
+			ContainersImages: []*hub.ContainerImage{
+				{Image: "repo/img1:1.0.0"},
+				{Image: "repo/img3:1.0.0", Digest: "sha256:3"},
+			},
+			Repository: r1,
+		}
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Append", r1.RepositoryID, "error resolving image repo/img2:1.0.0 digest: fake error for tests").Once()
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{PackagesAdded: 2})
+		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p1v1): p1v1,
+			pkg.BuildKey(p1v2): p1v2,
+		}, nil)
+		sw.dr.On("Resolve", sw.svc.Ctx, r1, "repo/img1:1.0.0").Return("sha256:1", nil).Once()
+		sw.dr.On("Resolve", sw.svc.Ctx, r1, "repo/img2:1.0.0").Return("", tests.ErrFake).Once()
+		sw.pm.On("Register", sw.svc.Ctx, p1v1).Return(nil)
+		sw.pm.On("Register", sw.svc.Ctx, p1v2).Return(nil)
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		assert.Equal(t, "sha256:1", p1v1.ContainersImages[0].Digest)
+		assert.Empty(t, p1v1.ContainersImages[1].Digest)
+		assert.Equal(t, "sha256:1", p1v2.ContainersImages[0].Digest)
+		assert.Equal(t, "sha256:3", p1v2.ContainersImages[1].Digest)
+		sw.assertExpectations(t)
+	})
+
 	t.Run("error unregistering package", func(t *testing.T) {
 		t.Parallel()
 
@@ -325,6 +682,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(map[string]string{
 			pkg.BuildKey(p1v1): "",
@@ -350,6 +708,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(map[string]string{
 			pkg.BuildKey(p1v1): "",
@@ -370,6 +729,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{PackagesDeleted: 1})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(map[string]string{
 			pkg.BuildKey(p1v1): "",
@@ -393,6 +753,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{PackagesDeleted: 1})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(&hub.RepositoryMetadata{
 			Ignore: []*hub.RepositoryIgnoreEntry{
 				{
@@ -410,6 +771,7 @@ func TestTracker(t *testing.T) {
 			pkg.BuildKey(p1v2): p1v2,
 		}, nil)
 		sw.pm.On("Unregister", sw.svc.Ctx, p1v1).Return(nil)
+		sw.rm.On("SetPackagesOverrides", sw.svc.Ctx, r1.RepositoryID, []*hub.RepositoryPackageOverride(nil)).Return(nil)
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -424,11 +786,13 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(&hub.RepositoryMetadata{
 			RepositoryID: r1.RepositoryID,
 		}, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{}, nil)
+		sw.rm.On("SetPackagesOverrides", sw.svc.Ctx, r1.RepositoryID, []*hub.RepositoryPackageOverride(nil)).Return(nil)
 		sw.rm.On("SetVerifiedPublisher", sw.svc.Ctx, r1.RepositoryID, true).Return(tests.ErrFake)
 		expectedErr := "error setting verified publisher flag: error setting verified publisher flag: fake error for tests"
 		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
@@ -446,6 +810,7 @@ func TestTracker(t *testing.T) {
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("digest", nil)
 		sw.ec.On("Init", r1.RepositoryID)
+		sw.ec.On("Finish", r1.RepositoryID, &hub.RepositoryTrackingRun{})
 		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{}, nil)
@@ -466,6 +831,7 @@ type servicesWrapper struct {
 	ec  *repo.ErrorsCollectorMock
 	hc  *tests.HTTPClientMock
 	is  *img.StoreMock
+	dr  *repo.OCIImageDigestResolverMock
 	src *source.Mock
 	svc *hub.TrackerServices
 }
@@ -479,20 +845,21 @@ func newServicesWrapper() *servicesWrapper {
 	ec := &repo.ErrorsCollectorMock{}
 	hc := &tests.HTTPClientMock{}
 	is := &img.StoreMock{}
+	dr := &repo.OCIImageDigestResolverMock{}
 	src := &source.Mock{}
 
 	// Setup tracker services using mocks
 	svc := &hub.TrackerServices{
-		Ctx:      context.Background(),
-		Cfg:      viper.New(),
-		Rm:       rm,
-		Pm:       pm,
-		Rc:       rc,
-		Oe:       oe,
-		Ec:       ec,
-		Hc:       hc,
-		Is:       is,
-		GithubRL: rate.NewLimiter(rate.Inf, 0),
+		Ctx: context.Background(),
+		Cfg: viper.New(),
+		Rm:  rm,
+		Pm:  pm,
+		Rc:  rc,
+		Oe:  oe,
+		Ec:  ec,
+		Hc:  hc,
+		Is:  is,
+		Dr:  dr,
 		SetupTrackerSource: func(i *hub.TrackerSourceInput) hub.TrackerSource {
 			return src
 		},
@@ -507,6 +874,7 @@ func newServicesWrapper() *servicesWrapper {
 		ec:  ec,
 		hc:  hc,
 		is:  is,
+		dr:  dr,
 		src: src,
 		svc: svc,
 	}
@@ -520,5 +888,6 @@ func (sw *servicesWrapper) assertExpectations(t *testing.T) {
 	sw.ec.AssertExpectations(t)
 	sw.hc.AssertExpectations(t)
 	sw.is.AssertExpectations(t)
+	sw.dr.AssertExpectations(t)
 	sw.src.AssertExpectations(t)
 }
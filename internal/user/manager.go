@@ -3,7 +3,9 @@ package user
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/base64"
 	"encoding/json"
@@ -11,6 +13,9 @@ import (
 	"fmt"
 	"html/template"
 	"image/png"
+	"net/url"
+	"strings"
+	texttemplate "text/template"
 	"time"
 
 	_ "embed" // Used by templates
@@ -18,6 +23,8 @@ import (
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/jackc/pgx/v4"
 	"github.com/pquerna/otp"
 	"github.com/pquerna/otp/totp"
@@ -32,8 +39,10 @@ const (
 	approveSessionDBQ            = `select approve_session($1::text, $2::text)`
 	checkUserAliasAvailDBQ       = `select check_user_alias_availability($1::text)`
 	checkUserCredsDBQ            = `select user_id, password from "user" where email = $1 and password is not null and email_verified = true`
+	consumeWebAuthnCeremonyDBQ   = `select consume_webauthn_ceremony($1::text)`
 	deleteSessionDBQ             = `delete from session where session_id = $1`
 	deleteUserDBQ                = `select delete_user($1::uuid, $2::text)`
+	deleteWebAuthnCredentialDBQ  = `delete from webauthn_credential where user_id = $1 and webauthn_credential_id = $2`
 	disableTFADBQ                = `update "user" set tfa_enabled = false, tfa_url = null, tfa_recovery_codes = null where user_id = $1 and tfa_enabled = true`
 	enableTFADBQ                 = `update "user" set tfa_enabled = true where user_id = $1`
 	getSessionDBQ                = `select user_id, floor(extract(epoch from created_at)), approved from session where session_id = $1`
@@ -43,15 +52,23 @@ const (
 	getUserIDFromSessionIDDBQ    = `select user_id from session where session_id = $1`
 	getUserPasswordDBQ           = `select password from "user" where user_id = $1 and password is not null`
 	getUserProfileDBQ            = `select get_user_profile($1::uuid)`
+	getUserWebAuthnCredsDBQ      = `select get_user_webauthn_credentials($1::uuid)`
+	getUserWebAuthnInfoDBQ       = `select get_user_webauthn_info($1::uuid)`
+	registerLoginCodeDBQ         = `select register_login_code($1::text, $2::text, $3::text)`
 	registerPasswordResetCodeDBQ = `select register_password_reset_code($1::text, $2::text)`
 	registerSessionDBQ           = `select register_session($1::jsonb)`
 	registerUserDBQ              = `select register_user($1::jsonb)`
+	registerWebAuthnCeremonyDBQ  = `select register_webauthn_ceremony($1::text, $2::jsonb)`
+	registerWebAuthnCredDBQ      = `select register_webauthn_credential($1::uuid, $2::jsonb)`
 	registerDeleteUserCodeDBQ    = `select register_delete_user_code($1::uuid, $2::text)`
 	resetUserPasswordDBQ         = `select reset_user_password($1::text, $2::text)`
+	syncUserIdPGroupsDBQ         = `select sync_user_idp_groups($1::uuid, $2::text[])`
 	updateTFAInfoDBQ             = `update "user" set tfa_url = $2, tfa_recovery_codes = $3 where user_id = $1`
 	updateUserPasswordDBQ        = `select update_user_password($1::uuid, $2::text, $3::text)`
 	updateUserProfileDBQ         = `select update_user_profile($1::uuid, $2::jsonb)`
+	updateWebAuthnCredUsageDBQ   = `update webauthn_credential set sign_count = $2, last_used_at = current_timestamp where credential_id = $1`
 	verifyEmailDBQ               = `select verify_email($1::uuid)`
+	verifyLoginCodeDBQ           = `select verify_login_code($1::text, $2::text)`
 	verifyPasswordResetCodeDBQ   = `select verify_password_reset_code($1::text)`
 
 	numRecoveryCodes = 10
@@ -67,6 +84,7 @@ type templateID int
 
 const (
 	confirmUserDeletionEmail templateID = iota
+	loginLinkEmail
 	passwordResetEmail
 	passwordResetSuccessEmail
 	tfaDisabledEmail
@@ -79,23 +97,50 @@ var (
 	//go:embed template/confirm_user_deletion_email.tmpl
 	confirmUserDeletionEmailTmpl string
 
+	//go:embed template/confirm_user_deletion_email.txt.tmpl
+	confirmUserDeletionEmailTextTmpl string
+
+	//go:embed template/login_link_email.tmpl
+	loginLinkEmailTmpl string
+
+	//go:embed template/login_link_email.txt.tmpl
+	loginLinkEmailTextTmpl string
+
 	//go:embed template/password_reset_email.tmpl
 	passwordResetEmailTmpl string
 
+	//go:embed template/password_reset_email.txt.tmpl
+	passwordResetEmailTextTmpl string
+
 	//go:embed template/password_reset_success_email.tmpl
 	passwordResetSuccessEmailTmpl string
 
+	//go:embed template/password_reset_success_email.txt.tmpl
+	passwordResetSuccessEmailTextTmpl string
+
 	//go:embed template/tfa_disabled_email.tmpl
 	tfaDisabledEmailTmpl string
 
+	//go:embed template/tfa_disabled_email.txt.tmpl
+	tfaDisabledEmailTextTmpl string
+
 	//go:embed template/tfa_enabled_email.tmpl
 	tfaEnabledEmailTmpl string
 
+	//go:embed template/tfa_enabled_email.txt.tmpl
+	tfaEnabledEmailTextTmpl string
+
 	//go:embed template/user_deleted_email.tmpl
 	userDeletedEmailTmpl string
 
+	//go:embed template/user_deleted_email.txt.tmpl
+	userDeletedEmailTextTmpl string
+
 	//go:embed template/verification_email.tmpl
 	verificationEmailTmpl string
+
+	//go:embed template/verification_email.txt.tmpl
+	verificationEmailTextTmpl string
 )
 
 var (
@@ -103,6 +148,13 @@ var (
 	// not valid.
 	ErrInvalidDeleteUserCode = errors.New("invalid delete user code")
 
+	// ErrInvalidEmail indicates that the email provided does not belong to
+	// any user with a verified email.
+	ErrInvalidEmail = errors.New("invalid email")
+
+	// ErrInvalidLoginCode indicates that the login code provided is not valid.
+	ErrInvalidLoginCode = errors.New("invalid login code")
+
 	// ErrInvalidPassword indicates that the password provided is not valid.
 	ErrInvalidPassword = errors.New("invalid password")
 
@@ -110,14 +162,35 @@ var (
 	// provided is not valid.
 	ErrInvalidPasswordResetCode = errors.New("invalid password reset code")
 
+	// ErrInvalidWebAuthnLogin indicates that the WebAuthn login could not be
+	// completed, as the user has no credentials registered or the assertion
+	// provided is not valid.
+	ErrInvalidWebAuthnLogin = errors.New("invalid webauthn login")
+
 	// errInvalidDeleteUserCodeDB represents the error returned from the
 	// database when the delete user code is not valid.
 	errInvalidDeleteUserCodeDB = errors.New("ERROR: invalid delete user code (SQLSTATE P0001)")
 
+	// errInvalidEmailDB represents the error returned from the database when
+	// the email provided does not belong to any user with a verified email.
+	errInvalidEmailDB = errors.New("ERROR: invalid email (SQLSTATE P0001)")
+
+	// errInvalidLoginCodeDB represents the error returned from the database
+	// when the login code is not valid.
+	errInvalidLoginCodeDB = errors.New("ERROR: invalid login code (SQLSTATE P0001)")
+
 	// errInvalidPasswordResetCodeDB represents the error returned from the
 	// database when the password reset code is not valid.
 	errInvalidPasswordResetCodeDB = errors.New("ERROR: invalid password reset code (SQLSTATE P0001)")
 
+	// errInvalidWebAuthnCeremony indicates that the WebAuthn ceremony provided
+	// does not exist, has expired or has already been used.
+	errInvalidWebAuthnCeremony = errors.New("invalid webauthn ceremony")
+
+	// errInvalidWebAuthnCeremonyDB represents the error returned from the
+	// database when the WebAuthn ceremony is not valid.
+	errInvalidWebAuthnCeremonyDB = errors.New("ERROR: invalid webauthn ceremony (SQLSTATE P0001)")
+
 	// errInvalidTFAPasscode indicates that the TFA passcode provided is not
 	// valid.
 	errInvalidTFAPasscode = fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid passcode")
@@ -128,10 +201,11 @@ var (
 
 // Manager provides an API to manage users.
 type Manager struct {
-	cfg  *viper.Viper
-	db   hub.DB
-	es   hub.EmailSender
-	tmpl map[templateID]*template.Template
+	cfg      *viper.Viper
+	db       hub.DB
+	es       hub.EmailSender
+	tmpl     map[templateID]*template.Template
+	textTmpl map[templateID]*texttemplate.Template
 }
 
 // NewManager creates a new Manager instance.
@@ -142,6 +216,7 @@ func NewManager(cfg *viper.Viper, db hub.DB, es hub.EmailSender) *Manager {
 		es:  es,
 		tmpl: map[templateID]*template.Template{
 			confirmUserDeletionEmail:  template.Must(template.New("").Parse(email.BaseTmpl + confirmUserDeletionEmailTmpl)),
+			loginLinkEmail:            template.Must(template.New("").Parse(email.BaseTmpl + loginLinkEmailTmpl)),
 			passwordResetEmail:        template.Must(template.New("").Parse(email.BaseTmpl + passwordResetEmailTmpl)),
 			passwordResetSuccessEmail: template.Must(template.New("").Parse(email.BaseTmpl + passwordResetSuccessEmailTmpl)),
 			tfaDisabledEmail:          template.Must(template.New("").Parse(email.BaseTmpl + tfaDisabledEmailTmpl)),
@@ -149,6 +224,16 @@ func NewManager(cfg *viper.Viper, db hub.DB, es hub.EmailSender) *Manager {
 			userDeletedEmail:          template.Must(template.New("").Parse(email.BaseTmpl + userDeletedEmailTmpl)),
 			verificationEmail:         template.Must(template.New("").Parse(email.BaseTmpl + verificationEmailTmpl)),
 		},
+		textTmpl: map[templateID]*texttemplate.Template{
+			confirmUserDeletionEmail:  texttemplate.Must(texttemplate.New("").Parse(confirmUserDeletionEmailTextTmpl)),
+			loginLinkEmail:            texttemplate.Must(texttemplate.New("").Parse(loginLinkEmailTextTmpl)),
+			passwordResetEmail:        texttemplate.Must(texttemplate.New("").Parse(passwordResetEmailTextTmpl)),
+			passwordResetSuccessEmail: texttemplate.Must(texttemplate.New("").Parse(passwordResetSuccessEmailTextTmpl)),
+			tfaDisabledEmail:          texttemplate.Must(texttemplate.New("").Parse(tfaDisabledEmailTextTmpl)),
+			tfaEnabledEmail:           texttemplate.Must(texttemplate.New("").Parse(tfaEnabledEmailTextTmpl)),
+			userDeletedEmail:          texttemplate.Must(texttemplate.New("").Parse(userDeletedEmailTextTmpl)),
+			verificationEmail:         texttemplate.Must(texttemplate.New("").Parse(verificationEmailTextTmpl)),
+		},
 	}
 }
 
@@ -194,6 +279,132 @@ func (m *Manager) ApproveSession(ctx context.Context, sessionID, passcode string
 	return err
 }
 
+// ApproveSessionWithWebAuthn approves a given session using the WebAuthn
+// assertion provided, completing the ceremony started by
+// BeginWebAuthnSessionApproval.
+func (m *Manager) ApproveSessionWithWebAuthn(
+	ctx context.Context,
+	sessionID string,
+	ceremonyID string,
+	response []byte,
+) error {
+	// Validate input
+	if len(sessionID) == 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "sessionID not provided")
+	}
+
+	// Get id of the user the session belongs to
+	var userID string
+	err := m.db.QueryRow(ctx, getUserIDFromSessionIDDBQ, hash(sessionID)).Scan(&userID)
+	if err != nil {
+		return err
+	}
+
+	// Validate assertion provided by user's authenticator
+	assertionUserID, err := m.finishWebAuthnLogin(ctx, ceremonyID, response)
+	if err != nil {
+		return err
+	}
+	if assertionUserID != userID {
+		return ErrInvalidWebAuthnLogin
+	}
+
+	// Approve session
+	_, err = m.db.Exec(ctx, approveSessionDBQ, hash(sessionID), "")
+	return err
+}
+
+// BeginWebAuthnLogin starts a WebAuthn login ceremony for the user with the
+// email provided. This allows users to log in using a passkey or security key
+// registered previously as primary factor. When the email is not registered or
+// the user has no credentials, a ceremony that cannot be completed is returned
+// instead, so that registered emails cannot be discovered this way.
+func (m *Manager) BeginWebAuthnLogin(ctx context.Context, email string) (*hub.WebAuthnCeremony, error) {
+	// Validate input
+	if email == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "email not provided")
+	}
+
+	// Get user id from database
+	userID, err := m.GetUserID(ctx, email)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return m.beginDummyWebAuthnLogin(ctx, email)
+		}
+		return nil, err
+	}
+	u, err := m.getWebAuthnUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || len(u.Credentials) == 0 {
+		return m.beginDummyWebAuthnLogin(ctx, email)
+	}
+
+	return m.beginWebAuthnLogin(ctx, u)
+}
+
+// BeginWebAuthnRegistration starts a WebAuthn registration ceremony for the
+// requesting user.
+func (m *Manager) BeginWebAuthnRegistration(ctx context.Context) (*hub.WebAuthnCeremony, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Get user WebAuthn information from database
+	u, err := m.getWebAuthnUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Begin registration ceremony, excluding the credentials already
+	// registered by the user
+	w, err := m.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(u.Credentials))
+	for _, c := range u.Credentials {
+		exclusions = append(exclusions, protocol.CredentialDescriptor{
+			Type:         protocol.PublicKeyCredentialType,
+			CredentialID: c.ID,
+		})
+	}
+	options, sessionData, err := w.BeginRegistration(u, webauthn.WithExclusions(exclusions))
+	if err != nil {
+		return nil, err
+	}
+
+	return m.registerWebAuthnCeremony(ctx, options, sessionData)
+}
+
+// BeginWebAuthnSessionApproval starts a WebAuthn login ceremony used to
+// approve the provided session, allowing users to use a security key as
+// second factor.
+func (m *Manager) BeginWebAuthnSessionApproval(
+	ctx context.Context,
+	sessionID string,
+) (*hub.WebAuthnCeremony, error) {
+	// Validate input
+	if len(sessionID) == 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "sessionID not provided")
+	}
+
+	// Get id of the user the session belongs to
+	var userID string
+	err := m.db.QueryRow(ctx, getUserIDFromSessionIDDBQ, hash(sessionID)).Scan(&userID)
+	if err != nil {
+		return nil, err
+	}
+	u, err := m.getWebAuthnUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || len(u.Credentials) == 0 {
+		return nil, ErrInvalidWebAuthnLogin
+	}
+
+	return m.beginWebAuthnLogin(ctx, u)
+}
+
 // CheckAvailability checks the availability of a given value for the provided
 // resource kind.
 func (m *Manager) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
@@ -336,14 +547,15 @@ func (m *Manager) DeleteUser(ctx context.Context, code string) error {
 
 	// Notify user by email that the account has been deleted
 	if m.es != nil {
-		var emailBody bytes.Buffer
-		if err := m.tmpl[userDeletedEmail].Execute(&emailBody, baseTemplateData(m.cfg)); err != nil {
+		emailBody, emailText, err := email.Render(m.tmpl[userDeletedEmail], m.textTmpl[userDeletedEmail], baseTemplateData(m.cfg))
+		if err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
 			Subject: "Your account has been deleted",
-			Body:    emailBody.Bytes(),
+			Body:    emailBody,
+			Text:    emailText,
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
 			return err
@@ -353,6 +565,21 @@ func (m *Manager) DeleteUser(ctx context.Context, code string) error {
 	return nil
 }
 
+// DeleteWebAuthnCredential deletes the provided WebAuthn credential from the
+// database. Only credentials registered by the requesting user can be deleted.
+func (m *Manager) DeleteWebAuthnCredential(ctx context.Context, credentialID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(credentialID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid credential id")
+	}
+
+	// Delete credential from database
+	_, err := m.db.Exec(ctx, deleteWebAuthnCredentialDBQ, userID, credentialID)
+	return err
+}
+
 // DisableTFA disables two-factor authentication for the requesting user.
 func (m *Manager) DisableTFA(ctx context.Context, passcode string) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -388,14 +615,15 @@ func (m *Manager) DisableTFA(ctx context.Context, passcode string) error {
 		if err := m.db.QueryRow(ctx, getUserEmailDBQ, userID).Scan(&userEmail); err != nil {
 			return err
 		}
-		var emailBody bytes.Buffer
-		if err := m.tmpl[tfaDisabledEmail].Execute(&emailBody, baseTemplateData(m.cfg)); err != nil {
+		emailBody, emailText, err := email.Render(m.tmpl[tfaDisabledEmail], m.textTmpl[tfaDisabledEmail], baseTemplateData(m.cfg))
+		if err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
 			Subject: "Two-factor authentication disabled",
-			Body:    emailBody.Bytes(),
+			Body:    emailBody,
+			Text:    emailText,
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
 			return err
@@ -441,14 +669,15 @@ func (m *Manager) EnableTFA(ctx context.Context, passcode string) error {
 		if err := m.db.QueryRow(ctx, getUserEmailDBQ, userID).Scan(&userEmail); err != nil {
 			return err
 		}
-		var emailBody bytes.Buffer
-		if err := m.tmpl[tfaEnabledEmail].Execute(&emailBody, baseTemplateData(m.cfg)); err != nil {
+		emailBody, emailText, err := email.Render(m.tmpl[tfaEnabledEmail], m.textTmpl[tfaEnabledEmail], baseTemplateData(m.cfg))
+		if err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
 			Subject: "Two-factor authentication enabled",
-			Body:    emailBody.Bytes(),
+			Body:    emailBody,
+			Text:    emailText,
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
 			return err
@@ -458,6 +687,69 @@ func (m *Manager) EnableTFA(ctx context.Context, passcode string) error {
 	return nil
 }
 
+// FinishWebAuthnLogin completes the WebAuthn login ceremony started by
+// BeginWebAuthnLogin, returning the id of the user who has been authenticated.
+func (m *Manager) FinishWebAuthnLogin(ctx context.Context, ceremonyID string, response []byte) (string, error) {
+	return m.finishWebAuthnLogin(ctx, ceremonyID, response)
+}
+
+// FinishWebAuthnRegistration completes the WebAuthn registration ceremony
+// started by BeginWebAuthnRegistration, storing the new credential in the
+// database using the name provided.
+func (m *Manager) FinishWebAuthnRegistration(
+	ctx context.Context,
+	name string,
+	ceremonyID string,
+	response []byte,
+) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	sd, err := m.consumeWebAuthnCeremony(ctx, ceremonyID)
+	if err != nil {
+		if errors.Is(err, errInvalidWebAuthnCeremony) {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid ceremony")
+		}
+		return err
+	}
+	if string(sd.UserID) != userID {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid ceremony")
+	}
+	parsedResponse, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid credential")
+	}
+
+	// Validate credential created by user's authenticator
+	u, err := m.getWebAuthnUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	w, err := m.webAuthn()
+	if err != nil {
+		return err
+	}
+	c, err := w.CreateCredential(u, *sd, parsedResponse)
+	if err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid credential")
+	}
+
+	// Register credential in database
+	credentialJSON, _ := json.Marshal(&webAuthnCredential{
+		Name:            name,
+		ID:              c.ID,
+		PublicKey:       c.PublicKey,
+		AttestationType: c.AttestationType,
+		AAGUID:          c.Authenticator.AAGUID,
+		SignCount:       c.Authenticator.SignCount,
+	})
+	_, err = m.db.Exec(ctx, registerWebAuthnCredDBQ, userID, credentialJSON)
+	return err
+}
+
 // GetProfile returns the profile of the user doing the request.
 func (m *Manager) GetProfile(ctx context.Context) (*hub.User, error) {
 	dataJSON, err := m.GetProfileJSON(ctx)
@@ -499,6 +791,13 @@ func (m *Manager) GetUserID(ctx context.Context, email string) (string, error) {
 	return userID, nil
 }
 
+// GetWebAuthnCredentialsJSON returns the WebAuthn credentials registered by
+// the requesting user as a json array.
+func (m *Manager) GetWebAuthnCredentialsJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	return util.DBQueryJSON(ctx, m.db, getUserWebAuthnCredsDBQ, userID)
+}
+
 // RegisterDeleteUserCode registers a code that allows the user doing the
 // request to initiate the process to delete his account. A link containing the
 // code will be emailed to the user.
@@ -524,14 +823,64 @@ func (m *Manager) RegisterDeleteUserCode(ctx context.Context) error {
 		}
 		templateData := baseTemplateData(m.cfg)
 		templateData["Link"] = fmt.Sprintf("%s/delete-user?code=%s", templateData["BaseURL"], code)
-		var emailBody bytes.Buffer
-		if err := m.tmpl[confirmUserDeletionEmail].Execute(&emailBody, templateData); err != nil {
+		emailBody, emailText, err := email.Render(m.tmpl[confirmUserDeletionEmail], m.textTmpl[confirmUserDeletionEmail], templateData)
+		if err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
 			Subject: "Confirm account deletion",
-			Body:    emailBody.Bytes(),
+			Body:    emailBody,
+			Text:    emailText,
+		}
+		if err := m.es.SendEmail(emailData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RegisterLoginCode registers a code that allows the user identified by the
+// email provided to sign in without a password. A link containing the code
+// will be emailed to the user. The code can only be used once and from the
+// device identified by the device id provided.
+func (m *Manager) RegisterLoginCode(ctx context.Context, userEmail, deviceID string) error {
+	// Validate input
+	if userEmail == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "email not provided")
+	}
+	if deviceID == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "device id not provided")
+	}
+
+	// Register login code in database
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return err
+	}
+	code := base64.URLEncoding.EncodeToString(randomBytes)
+	_, err := m.db.Exec(ctx, registerLoginCodeDBQ, userEmail, hash(code), hash(deviceID))
+	if err != nil {
+		if err.Error() == errInvalidEmailDB.Error() {
+			return ErrInvalidEmail
+		}
+		return err
+	}
+
+	// Send login link email
+	if m.es != nil {
+		templateData := baseTemplateData(m.cfg)
+		templateData["Link"] = fmt.Sprintf("%s/login-link?code=%s", templateData["BaseURL"], code)
+		emailBody, emailText, err := email.Render(m.tmpl[loginLinkEmail], m.textTmpl[loginLinkEmail], templateData)
+		if err != nil {
+			return err
+		}
+		emailData := &email.Data{
+			To:      userEmail,
+			Subject: "Sign in link",
+			Body:    emailBody,
+			Text:    emailText,
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
 			return err
@@ -565,14 +914,15 @@ func (m *Manager) RegisterPasswordResetCode(ctx context.Context, userEmail strin
 	if m.es != nil {
 		templateData := baseTemplateData(m.cfg)
 		templateData["Link"] = fmt.Sprintf("%s/reset-password?code=%s", templateData["BaseURL"], code)
-		var emailBody bytes.Buffer
-		if err := m.tmpl[passwordResetEmail].Execute(&emailBody, templateData); err != nil {
+		emailBody, emailText, err := email.Render(m.tmpl[passwordResetEmail], m.textTmpl[passwordResetEmail], templateData)
+		if err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
 			Subject: "Password reset",
-			Body:    emailBody.Bytes(),
+			Body:    emailBody,
+			Text:    emailText,
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
 			return err
@@ -661,14 +1011,15 @@ func (m *Manager) RegisterUser(ctx context.Context, user *hub.User) error {
 	if code != nil && m.es != nil {
 		templateData := baseTemplateData(m.cfg)
 		templateData["Link"] = fmt.Sprintf("%s/verify-email?code=%s", templateData["BaseURL"], *code)
-		var emailBody bytes.Buffer
-		if err := m.tmpl[verificationEmail].Execute(&emailBody, templateData); err != nil {
+		emailBody, emailText, err := email.Render(m.tmpl[verificationEmail], m.textTmpl[verificationEmail], templateData)
+		if err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      user.Email,
 			Subject: "Verify your email address",
-			Body:    emailBody.Bytes(),
+			Body:    emailBody,
+			Text:    emailText,
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
 			return err
@@ -710,14 +1061,15 @@ func (m *Manager) ResetPassword(ctx context.Context, code, newPassword string) e
 	// Send password reset success email
 	if m.es != nil {
 		templateData := baseTemplateData(m.cfg)
-		var emailBody bytes.Buffer
-		if err := m.tmpl[passwordResetSuccessEmail].Execute(&emailBody, templateData); err != nil {
+		emailBody, emailText, err := email.Render(m.tmpl[passwordResetSuccessEmail], m.textTmpl[passwordResetSuccessEmail], templateData)
+		if err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
 			Subject: "Your password has been reset",
-			Body:    emailBody.Bytes(),
+			Body:    emailBody,
+			Text:    emailText,
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
 			return err
@@ -781,6 +1133,24 @@ func (m *Manager) SetupTFA(ctx context.Context) ([]byte, error) {
 	return json.Marshal(output)
 }
 
+// SyncIdPGroups synchronizes the user's organizations memberships with the
+// identity provider groups provided. The user will join the organizations
+// mapped to any of the groups and leave the ones joined previously through a
+// group no longer provided. Memberships added manually are not modified.
+func (m *Manager) SyncIdPGroups(ctx context.Context, userID string, groups []string) error {
+	// Validate input
+	if userID == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user id not provided")
+	}
+	if groups == nil {
+		groups = []string{}
+	}
+
+	// Sync user organizations memberships in database
+	_, err := m.db.Exec(ctx, syncUserIdPGroupsDBQ, userID, groups)
+	return err
+}
+
 // UpdatePassword updates the user password in the database.
 func (m *Manager) UpdatePassword(ctx context.Context, old, new string) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -853,6 +1223,30 @@ func (m *Manager) VerifyEmail(ctx context.Context, code string) (bool, error) {
 	return verified, err
 }
 
+// VerifyLoginCode verifies if the provided login code is valid for the given
+// device, returning the id of the user it belongs to. Once verified, the code
+// cannot be used again.
+func (m *Manager) VerifyLoginCode(ctx context.Context, code, deviceID string) (string, error) {
+	// Validate input
+	if code == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "code not provided")
+	}
+	if deviceID == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "device id not provided")
+	}
+
+	// Verify login code in database
+	var userID string
+	err := m.db.QueryRow(ctx, verifyLoginCodeDBQ, hash(code), hash(deviceID)).Scan(&userID)
+	if err != nil {
+		if err.Error() == errInvalidLoginCodeDB.Error() {
+			return "", ErrInvalidLoginCode
+		}
+		return "", err
+	}
+	return userID, nil
+}
+
 // VerifyPasswordResetCode verifies if the provided code is valid.
 func (m *Manager) VerifyPasswordResetCode(ctx context.Context, code string) error {
 	// Validate input
@@ -868,6 +1262,156 @@ func (m *Manager) VerifyPasswordResetCode(ctx context.Context, code string) erro
 	return err
 }
 
+// beginDummyWebAuthnLogin starts a WebAuthn login ceremony that cannot be
+// completed. The options returned look like the ones generated for a user with
+// a credential registered, and they are consistent across requests for the
+// same email.
+func (m *Manager) beginDummyWebAuthnLogin(ctx context.Context, email string) (*hub.WebAuthnCeremony, error) {
+	mac := hmac.New(sha256.New, []byte(m.cfg.GetString("server.cookie.hashKey")))
+	mac.Write([]byte(email))
+	u := &webAuthnUser{
+		Credentials: []*webAuthnCredential{{ID: mac.Sum(nil)}},
+	}
+	return m.beginWebAuthnLogin(ctx, u)
+}
+
+// beginWebAuthnLogin starts a WebAuthn login ceremony for the provided user.
+func (m *Manager) beginWebAuthnLogin(ctx context.Context, u *webAuthnUser) (*hub.WebAuthnCeremony, error) {
+	w, err := m.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+	options, sessionData, err := w.BeginLogin(u)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.registerWebAuthnCeremony(ctx, options, sessionData)
+}
+
+// consumeWebAuthnCeremony returns the session data of the WebAuthn ceremony
+// provided. Once consumed, the ceremony cannot be used again.
+func (m *Manager) consumeWebAuthnCeremony(ctx context.Context, ceremonyID string) (*webauthn.SessionData, error) {
+	if ceremonyID == "" {
+		return nil, errInvalidWebAuthnCeremony
+	}
+	var sessionData []byte
+	err := m.db.QueryRow(ctx, consumeWebAuthnCeremonyDBQ, hash(ceremonyID)).Scan(&sessionData)
+	if err != nil {
+		if err.Error() == errInvalidWebAuthnCeremonyDB.Error() {
+			return nil, errInvalidWebAuthnCeremony
+		}
+		return nil, err
+	}
+	var sd *webauthn.SessionData
+	if err := json.Unmarshal(sessionData, &sd); err != nil || sd == nil {
+		return nil, errInvalidWebAuthnCeremony
+	}
+	return sd, nil
+}
+
+// finishWebAuthnLogin validates the assertion provided by the user's
+// authenticator, completing a WebAuthn login ceremony. The id of the user who
+// has been authenticated is returned.
+func (m *Manager) finishWebAuthnLogin(ctx context.Context, ceremonyID string, response []byte) (string, error) {
+	// Get ceremony session data
+	sd, err := m.consumeWebAuthnCeremony(ctx, ceremonyID)
+	if err != nil {
+		if errors.Is(err, errInvalidWebAuthnCeremony) {
+			return "", ErrInvalidWebAuthnLogin
+		}
+		return "", err
+	}
+	if len(sd.UserID) == 0 {
+		return "", ErrInvalidWebAuthnLogin
+	}
+	parsedResponse, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return "", ErrInvalidWebAuthnLogin
+	}
+
+	// Validate assertion provided by user's authenticator
+	u, err := m.getWebAuthnUser(ctx, string(sd.UserID))
+	if err != nil {
+		return "", err
+	}
+	if u == nil {
+		return "", ErrInvalidWebAuthnLogin
+	}
+	w, err := m.webAuthn()
+	if err != nil {
+		return "", err
+	}
+	c, err := w.ValidateLogin(u, *sd, parsedResponse)
+	if err != nil || c.Authenticator.CloneWarning {
+		return "", ErrInvalidWebAuthnLogin
+	}
+
+	// Update credential usage in database
+	_, err = m.db.Exec(ctx, updateWebAuthnCredUsageDBQ, c.ID, int64(c.Authenticator.SignCount))
+	if err != nil {
+		return "", err
+	}
+
+	return u.UserID, nil
+}
+
+// getWebAuthnUser returns the information needed to perform WebAuthn
+// ceremonies for the provided user.
+func (m *Manager) getWebAuthnUser(ctx context.Context, userID string) (*webAuthnUser, error) {
+	var u *webAuthnUser
+	if err := util.DBQueryUnmarshal(ctx, m.db, &u, getUserWebAuthnInfoDBQ, userID); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// registerWebAuthnCeremony stores the session data of a WebAuthn ceremony that
+// has just begun, returning the ceremony id and the options to use in the
+// browser. The session data never leaves the server.
+func (m *Manager) registerWebAuthnCeremony(
+	ctx context.Context,
+	options interface{},
+	sessionData *webauthn.SessionData,
+) (*hub.WebAuthnCeremony, error) {
+	optionsJSON, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	sessionDataJSON, err := json.Marshal(sessionData)
+	if err != nil {
+		return nil, err
+	}
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return nil, err
+	}
+	ceremonyID := base64.URLEncoding.EncodeToString(randomBytes)
+	_, err = m.db.Exec(ctx, registerWebAuthnCeremonyDBQ, hash(ceremonyID), sessionDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &hub.WebAuthnCeremony{
+		ID:      ceremonyID,
+		Options: optionsJSON,
+	}, nil
+}
+
+// webAuthn returns a WebAuthn instance setup using the hub's base url as the
+// relying party origin.
+func (m *Manager) webAuthn() (*webauthn.WebAuthn, error) {
+	baseURL := strings.TrimSuffix(m.cfg.GetString("server.baseURL"), "/")
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return webauthn.New(&webauthn.Config{
+		RPDisplayName: m.cfg.GetString("theme.siteName"),
+		RPID:          u.Hostname(),
+		RPOrigin:      baseURL,
+	})
+}
+
 // hash is a helper function that creates a sha512 hash of the text provided.
 func hash(text string) string {
 	return fmt.Sprintf("%x", sha512.Sum512([]byte(text)))
@@ -895,3 +1439,59 @@ func baseTemplateData(cfg *viper.Viper) map[string]interface{} {
 		},
 	}
 }
+
+// webAuthnUser represents a user taking part in WebAuthn ceremonies. It
+// implements the webauthn.User interface.
+type webAuthnUser struct {
+	UserID      string                `json:"user_id"`
+	Alias       string                `json:"alias"`
+	Email       string                `json:"email"`
+	Credentials []*webAuthnCredential `json:"credentials"`
+}
+
+// WebAuthnID implements the webauthn.User interface.
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return []byte(u.UserID)
+}
+
+// WebAuthnName implements the webauthn.User interface.
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.Email
+}
+
+// WebAuthnDisplayName implements the webauthn.User interface.
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.Alias
+}
+
+// WebAuthnIcon implements the webauthn.User interface.
+func (u *webAuthnUser) WebAuthnIcon() string {
+	return ""
+}
+
+// WebAuthnCredentials implements the webauthn.User interface.
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.Credentials))
+	for _, c := range u.Credentials {
+		credentials = append(credentials, webauthn.Credential{
+			ID:              c.ID,
+			PublicKey:       c.PublicKey,
+			AttestationType: c.AttestationType,
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    c.AAGUID,
+				SignCount: c.SignCount,
+			},
+		})
+	}
+	return credentials
+}
+
+// webAuthnCredential represents a WebAuthn credential registered by a user.
+type webAuthnCredential struct {
+	Name            string `json:"name,omitempty"`
+	ID              []byte `json:"id"`
+	PublicKey       []byte `json:"public_key"`
+	AttestationType string `json:"attestation_type"`
+	AAGUID          []byte `json:"aaguid"`
+	SignCount       uint32 `json:"sign_count"`
+}
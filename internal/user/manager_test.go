@@ -11,6 +11,7 @@ import (
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/jackc/pgx/v4"
 	"github.com/pquerna/otp/totp"
 	"github.com/satori/uuid"
@@ -21,10 +22,37 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-var cfg *viper.Viper
+var (
+	cfg *viper.Viper
+
+	webAuthnUserJSON = []byte(`
+	{
+		"user_id": "userID",
+		"alias": "user1",
+		"email": "user1@email.com",
+		"credentials": [
+			{
+				"id": "AQI=",
+				"public_key": "AwQ=",
+				"attestation_type": "none",
+				"sign_count": 1
+			}
+		]
+	}
+	`)
+	webAuthnUserNoCredsJSON = []byte(`
+	{
+		"user_id": "userID",
+		"alias": "user1",
+		"email": "user1@email.com",
+		"credentials": []
+	}
+	`)
+)
 
 func init() {
 	cfg = viper.New()
+	cfg.Set("server.baseURL", "https://hub.test")
 	cfg.Set("theme.siteName", "Artifact Hub")
 }
 
@@ -137,6 +165,300 @@ func TestApproveSession(t *testing.T) {
 	})
 }
 
+func TestApproveSessionWithWebAuthn(t *testing.T) {
+	ctx := context.Background()
+	sessionID := "sessionID"
+	hashedSessionID := hash(sessionID)
+	ceremonyID := "ceremonyID"
+	hashedCeremonyID := hash(ceremonyID)
+	sessionData, _ := json.Marshal(&webauthn.SessionData{
+		Challenge: "challenge",
+		UserID:    []byte("userID"),
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		err := m.ApproveSessionWithWebAuthn(ctx, "", ceremonyID, []byte("response"))
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "sessionID not provided")
+	})
+
+	t.Run("error getting user id from session", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromSessionIDDBQ, hashedSessionID).Return("", tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.ApproveSessionWithWebAuthn(ctx, sessionID, ceremonyID, []byte("response"))
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error consuming ceremony", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromSessionIDDBQ, hashedSessionID).Return("userID", nil)
+		db.On("QueryRow", ctx, consumeWebAuthnCeremonyDBQ, hashedCeremonyID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.ApproveSessionWithWebAuthn(ctx, sessionID, ceremonyID, []byte("response"))
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("invalid webauthn assertion", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			sessionData []byte
+			dbErr       error
+			response    []byte
+		}{
+			{
+				"invalid ceremony",
+				nil,
+				errInvalidWebAuthnCeremonyDB,
+				[]byte("response"),
+			},
+			{
+				"invalid session data",
+				[]byte("invalid"),
+				nil,
+				[]byte("response"),
+			},
+			{
+				"invalid response",
+				sessionData,
+				nil,
+				[]byte("invalid"),
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getUserIDFromSessionIDDBQ, hashedSessionID).Return("userID", nil)
+				db.On("QueryRow", ctx, consumeWebAuthnCeremonyDBQ, hashedCeremonyID).Return(tc.sessionData, tc.dbErr)
+				m := NewManager(cfg, db, nil)
+
+				err := m.ApproveSessionWithWebAuthn(ctx, sessionID, ceremonyID, tc.response)
+				assert.Equal(t, ErrInvalidWebAuthnLogin, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestBeginWebAuthnLogin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		_, err := m.BeginWebAuthnLogin(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "email not provided")
+	})
+
+	t.Run("error getting user id", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromEmailDBQ, "email").Return("", tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		c, err := m.BeginWebAuthnLogin(ctx, "email")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, c)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error getting user webauthn info", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromEmailDBQ, "email").Return("userID", nil)
+		db.On("QueryRow", ctx, getUserWebAuthnInfoDBQ, "userID").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		c, err := m.BeginWebAuthnLogin(ctx, "email")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, c)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error registering ceremony", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromEmailDBQ, "email").Return("userID", nil)
+		db.On("QueryRow", ctx, getUserWebAuthnInfoDBQ, "userID").Return(webAuthnUserJSON, nil)
+		db.On("Exec", ctx, registerWebAuthnCeremonyDBQ, mock.Anything, mock.Anything).Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		c, err := m.BeginWebAuthnLogin(ctx, "email")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, c)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("unknown users get a ceremony that cannot be completed", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			setupDB     func(db *tests.DBMock)
+		}{
+			{
+				"user not found",
+				func(db *tests.DBMock) {
+					db.On("QueryRow", ctx, getUserIDFromEmailDBQ, "email").Return("", pgx.ErrNoRows)
+				},
+			},
+			{
+				"user has no credentials registered",
+				func(db *tests.DBMock) {
+					db.On("QueryRow", ctx, getUserIDFromEmailDBQ, "email").Return("userID", nil)
+					db.On("QueryRow", ctx, getUserWebAuthnInfoDBQ, "userID").Return(webAuthnUserNoCredsJSON, nil)
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				tc.setupDB(db)
+				db.On("Exec", ctx, registerWebAuthnCeremonyDBQ, mock.Anything, mock.MatchedBy(func(data []byte) bool {
+					var sd *webauthn.SessionData
+					_ = json.Unmarshal(data, &sd)
+					return len(sd.UserID) == 0 && len(sd.AllowedCredentialIDs) == 1
+				})).Return(nil)
+				m := NewManager(cfg, db, nil)
+
+				c, err := m.BeginWebAuthnLogin(ctx, "email")
+				require.NoError(t, err)
+				assert.NotEmpty(t, c.ID)
+				assert.Contains(t, string(c.Options), "challenge")
+				assert.Contains(t, string(c.Options), "allowCredentials")
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("login ceremony started successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromEmailDBQ, "email").Return("userID", nil)
+		db.On("QueryRow", ctx, getUserWebAuthnInfoDBQ, "userID").Return(webAuthnUserJSON, nil)
+		db.On("Exec", ctx, registerWebAuthnCeremonyDBQ, mock.Anything, mock.MatchedBy(func(data []byte) bool {
+			var sd *webauthn.SessionData
+			_ = json.Unmarshal(data, &sd)
+			return string(sd.UserID) == "userID" && assert.ObjectsAreEqual([][]byte{{1, 2}}, sd.AllowedCredentialIDs)
+		})).Return(nil)
+		m := NewManager(cfg, db, nil)
+
+		c, err := m.BeginWebAuthnLogin(ctx, "email")
+		require.NoError(t, err)
+		assert.NotEmpty(t, c.ID)
+		assert.Contains(t, string(c.Options), "challenge")
+		db.AssertExpectations(t)
+	})
+}
+
+func TestBeginWebAuthnRegistration(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.BeginWebAuthnRegistration(context.Background())
+		})
+	})
+
+	t.Run("error getting user webauthn info", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserWebAuthnInfoDBQ, "userID").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		c, err := m.BeginWebAuthnRegistration(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, c)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("registration ceremony started successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserWebAuthnInfoDBQ, "userID").Return(webAuthnUserJSON, nil)
+		db.On("Exec", ctx, registerWebAuthnCeremonyDBQ, mock.Anything, mock.MatchedBy(func(data []byte) bool {
+			var sd *webauthn.SessionData
+			_ = json.Unmarshal(data, &sd)
+			return string(sd.UserID) == "userID"
+		})).Return(nil)
+		m := NewManager(cfg, db, nil)
+
+		c, err := m.BeginWebAuthnRegistration(ctx)
+		require.NoError(t, err)
+		assert.NotEmpty(t, c.ID)
+		assert.Contains(t, string(c.Options), "challenge")
+		assert.Contains(t, string(c.Options), "excludeCredentials")
+		db.AssertExpectations(t)
+	})
+}
+
+func TestBeginWebAuthnSessionApproval(t *testing.T) {
+	ctx := context.Background()
+	sessionID := "sessionID"
+	hashedSessionID := hash(sessionID)
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		_, err := m.BeginWebAuthnSessionApproval(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "sessionID not provided")
+	})
+
+	t.Run("error getting user id from session", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromSessionIDDBQ, hashedSessionID).Return("", tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		c, err := m.BeginWebAuthnSessionApproval(ctx, sessionID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, c)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user has no credentials registered", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromSessionIDDBQ, hashedSessionID).Return("userID", nil)
+		db.On("QueryRow", ctx, getUserWebAuthnInfoDBQ, "userID").Return(webAuthnUserNoCredsJSON, nil)
+		m := NewManager(cfg, db, nil)
+
+		c, err := m.BeginWebAuthnSessionApproval(ctx, sessionID)
+		assert.Equal(t, ErrInvalidWebAuthnLogin, err)
+		assert.Nil(t, c)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("login ceremony started successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromSessionIDDBQ, hashedSessionID).Return("userID", nil)
+		db.On("QueryRow", ctx, getUserWebAuthnInfoDBQ, "userID").Return(webAuthnUserJSON, nil)
+		db.On("Exec", ctx, registerWebAuthnCeremonyDBQ, mock.Anything, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil)
+
+		c, err := m.BeginWebAuthnSessionApproval(ctx, sessionID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, c.ID)
+		assert.Contains(t, string(c.Options), "challenge")
+		db.AssertExpectations(t)
+	})
+}
+
 func TestCheckAvailability(t *testing.T) {
 	ctx := context.Background()
 
@@ -519,6 +841,56 @@ func TestDeleteUser(t *testing.T) {
 	})
 }
 
+func TestDeleteWebAuthnCredential(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	credentialID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.DeleteWebAuthnCredential(context.Background(), credentialID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		err := m.DeleteWebAuthnCredential(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid credential id")
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			dbResponse  interface{}
+		}{
+			{
+				"credential deleted successfully",
+				nil,
+			},
+			{
+				"error deleting credential from database",
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, deleteWebAuthnCredentialDBQ, "userID", credentialID).Return(tc.dbResponse)
+				m := NewManager(cfg, db, nil)
+
+				err := m.DeleteWebAuthnCredential(ctx, credentialID)
+				assert.Equal(t, tc.dbResponse, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestDisableTFA(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 	opts := totp.GenerateOpts{
@@ -727,6 +1099,171 @@ func TestEnableTFA(t *testing.T) {
 	})
 }
 
+func TestFinishWebAuthnLogin(t *testing.T) {
+	ctx := context.Background()
+	ceremonyID := "ceremonyID"
+	hashedCeremonyID := hash(ceremonyID)
+	sessionData, _ := json.Marshal(&webauthn.SessionData{
+		Challenge: "challenge",
+		UserID:    []byte("userID"),
+	})
+
+	t.Run("error consuming ceremony", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, consumeWebAuthnCeremonyDBQ, hashedCeremonyID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		userID, err := m.FinishWebAuthnLogin(ctx, ceremonyID, []byte("response"))
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Empty(t, userID)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("ceremony id not provided", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		userID, err := m.FinishWebAuthnLogin(ctx, "", []byte("response"))
+		assert.Equal(t, ErrInvalidWebAuthnLogin, err)
+		assert.Empty(t, userID)
+	})
+
+	testCases := []struct {
+		description string
+		sessionData []byte
+		dbErr       error
+		response    []byte
+	}{
+		{
+			"invalid ceremony",
+			nil,
+			errInvalidWebAuthnCeremonyDB,
+			[]byte("response"),
+		},
+		{
+			"invalid session data",
+			[]byte("invalid"),
+			nil,
+			[]byte("response"),
+		},
+		{
+			"session data without user id",
+			[]byte(`{"challenge": "challenge"}`),
+			nil,
+			[]byte("response"),
+		},
+		{
+			"invalid response",
+			sessionData,
+			nil,
+			[]byte("invalid"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("QueryRow", ctx, consumeWebAuthnCeremonyDBQ, hashedCeremonyID).Return(tc.sessionData, tc.dbErr)
+			m := NewManager(cfg, db, nil)
+
+			userID, err := m.FinishWebAuthnLogin(ctx, ceremonyID, tc.response)
+			assert.Equal(t, ErrInvalidWebAuthnLogin, err)
+			assert.Empty(t, userID)
+			db.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFinishWebAuthnRegistration(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	ceremonyID := "ceremonyID"
+	hashedCeremonyID := hash(ceremonyID)
+	sessionData, _ := json.Marshal(&webauthn.SessionData{
+		Challenge: "challenge",
+		UserID:    []byte("userID"),
+	})
+	otherUserSessionData, _ := json.Marshal(&webauthn.SessionData{
+		Challenge: "challenge",
+		UserID:    []byte("userID2"),
+	})
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.FinishWebAuthnRegistration(context.Background(), "key1", ceremonyID, []byte("response"))
+		})
+	})
+
+	t.Run("name not provided", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		err := m.FinishWebAuthnRegistration(ctx, "", ceremonyID, []byte("response"))
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "name not provided")
+	})
+
+	t.Run("error consuming ceremony", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, consumeWebAuthnCeremonyDBQ, hashedCeremonyID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.FinishWebAuthnRegistration(ctx, "key1", ceremonyID, []byte("response"))
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg      string
+			sessionData []byte
+			dbErr       error
+			response    []byte
+		}{
+			{
+				"invalid ceremony",
+				nil,
+				errInvalidWebAuthnCeremonyDB,
+				[]byte("response"),
+			},
+			{
+				"invalid ceremony",
+				[]byte("invalid"),
+				nil,
+				[]byte("response"),
+			},
+			{
+				"invalid ceremony",
+				otherUserSessionData,
+				nil,
+				[]byte("response"),
+			},
+			{
+				"invalid credential",
+				sessionData,
+				nil,
+				[]byte("invalid"),
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, consumeWebAuthnCeremonyDBQ, hashedCeremonyID).Return(tc.sessionData, tc.dbErr)
+				m := NewManager(cfg, db, nil)
+
+				err := m.FinishWebAuthnRegistration(ctx, "key1", ceremonyID, tc.response)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestGetProfile(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -854,6 +1391,42 @@ func TestGetUserID(t *testing.T) {
 	})
 }
 
+func TestGetWebAuthnCredentialsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetWebAuthnCredentialsJSON(context.Background())
+		})
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserWebAuthnCredsDBQ, "userID").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil)
+
+		dataJSON, err := m.GetWebAuthnCredentialsJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserWebAuthnCredsDBQ, "userID").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		dataJSON, err := m.GetWebAuthnCredentialsJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestRegisterDeleteUserCode(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -906,6 +1479,97 @@ func TestRegisterDeleteUserCode(t *testing.T) {
 	})
 }
 
+func TestRegisterLoginCode(t *testing.T) {
+	ctx := context.Background()
+	deviceID := "deviceID"
+	deviceIDHashed := hash(deviceID)
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			userEmail string
+			deviceID  string
+		}{
+			{
+				"email not provided",
+				"",
+				deviceID,
+			},
+			{
+				"device id not provided",
+				"email@email.com",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				es := &email.SenderMock{}
+				m := NewManager(cfg, nil, es)
+
+				err := m.RegisterLoginCode(ctx, tc.userEmail, tc.deviceID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("successful login code registration in database", func(t *testing.T) {
+		testCases := []struct {
+			description         string
+			emailSenderResponse error
+		}{
+			{
+				"login link sent successfully",
+				nil,
+			},
+			{
+				"error sending login link",
+				email.ErrFakeSenderFailure,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, registerLoginCodeDBQ, "email@email.com", mock.Anything, deviceIDHashed).Return(nil)
+				es := &email.SenderMock{}
+				es.On("SendEmail", mock.Anything).Return(tc.emailSenderResponse)
+				m := NewManager(cfg, db, es)
+
+				err := m.RegisterLoginCode(ctx, "email@email.com", deviceID)
+				assert.Equal(t, tc.emailSenderResponse, err)
+				db.AssertExpectations(t)
+				es.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database error registering login code", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerLoginCodeDBQ, "email@email.com", mock.Anything, deviceIDHashed).Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.RegisterLoginCode(ctx, "email@email.com", deviceID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerLoginCodeDBQ, "email@email.com", mock.Anything, deviceIDHashed).Return(errInvalidEmailDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.RegisterLoginCode(ctx, "email@email.com", deviceID)
+		assert.Equal(t, ErrInvalidEmail, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestRegisterPasswordResetCode(t *testing.T) {
 	ctx := context.Background()
 
@@ -1302,6 +1966,59 @@ func TestSetupTFA(t *testing.T) {
 	})
 }
 
+func TestSyncIdPGroups(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		err := m.SyncIdPGroups(ctx, "", []string{"group1"})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "user id not provided")
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			groups      []string
+			dbGroups    []string
+			dbResponse  interface{}
+		}{
+			{
+				"groups synced successfully",
+				[]string{"group1", "group2"},
+				[]string{"group1", "group2"},
+				nil,
+			},
+			{
+				"no groups provided",
+				nil,
+				[]string{},
+				nil,
+			},
+			{
+				"error syncing groups in database",
+				[]string{"group1"},
+				[]string{"group1"},
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, syncUserIdPGroupsDBQ, "userID", tc.dbGroups).Return(tc.dbResponse)
+				m := NewManager(cfg, db, nil)
+
+				err := m.SyncIdPGroups(ctx, "userID", tc.groups)
+				assert.Equal(t, tc.dbResponse, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestUpdatePassword(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 	oldHashed, _ := bcrypt.GenerateFromPassword([]byte("old"), bcrypt.DefaultCost)
@@ -1492,6 +2209,86 @@ func TestVerifyEmail(t *testing.T) {
 	})
 }
 
+func TestVerifyLoginCode(t *testing.T) {
+	ctx := context.Background()
+	code := "code"
+	codeHashed := hash(code)
+	deviceID := "deviceID"
+	deviceIDHashed := hash(deviceID)
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg   string
+			code     string
+			deviceID string
+		}{
+			{
+				"code not provided",
+				"",
+				deviceID,
+			},
+			{
+				"device id not provided",
+				code,
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil)
+				userID, err := m.VerifyLoginCode(ctx, tc.code, tc.deviceID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				assert.Empty(t, userID)
+			})
+		}
+	})
+
+	t.Run("database error verifying login code", func(t *testing.T) {
+		testCases := []struct {
+			dbErr       error
+			expectedErr error
+		}{
+			{
+				tests.ErrFake,
+				tests.ErrFake,
+			},
+			{
+				errInvalidLoginCodeDB,
+				ErrInvalidLoginCode,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, verifyLoginCodeDBQ, codeHashed, deviceIDHashed).Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil)
+
+				userID, err := m.VerifyLoginCode(ctx, code, deviceID)
+				assert.Equal(t, tc.expectedErr, err)
+				assert.Empty(t, userID)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("login code verified successfully in database", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, verifyLoginCodeDBQ, codeHashed, deviceIDHashed).Return("userID", nil)
+		m := NewManager(cfg, db, nil)
+
+		userID, err := m.VerifyLoginCode(ctx, code, deviceID)
+		assert.NoError(t, err)
+		assert.Equal(t, "userID", userID)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestVerifyPasswordResetCode(t *testing.T) {
 	ctx := context.Background()
 	code := "code"
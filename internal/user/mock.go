@@ -19,6 +19,41 @@ func (m *ManagerMock) ApproveSession(ctx context.Context, sessionID, passcode st
 	return args.Error(0)
 }
 
+// ApproveSessionWithWebAuthn implements the UserManager interface.
+func (m *ManagerMock) ApproveSessionWithWebAuthn(
+	ctx context.Context,
+	sessionID string,
+	ceremonyID string,
+	response []byte,
+) error {
+	args := m.Called(ctx, sessionID, ceremonyID, response)
+	return args.Error(0)
+}
+
+// BeginWebAuthnLogin implements the UserManager interface.
+func (m *ManagerMock) BeginWebAuthnLogin(ctx context.Context, email string) (*hub.WebAuthnCeremony, error) {
+	args := m.Called(ctx, email)
+	data, _ := args.Get(0).(*hub.WebAuthnCeremony)
+	return data, args.Error(1)
+}
+
+// BeginWebAuthnRegistration implements the UserManager interface.
+func (m *ManagerMock) BeginWebAuthnRegistration(ctx context.Context) (*hub.WebAuthnCeremony, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).(*hub.WebAuthnCeremony)
+	return data, args.Error(1)
+}
+
+// BeginWebAuthnSessionApproval implements the UserManager interface.
+func (m *ManagerMock) BeginWebAuthnSessionApproval(
+	ctx context.Context,
+	sessionID string,
+) (*hub.WebAuthnCeremony, error) {
+	args := m.Called(ctx, sessionID)
+	data, _ := args.Get(0).(*hub.WebAuthnCeremony)
+	return data, args.Error(1)
+}
+
 // CheckAvailability implements the UserManager interface.
 func (m *ManagerMock) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
 	args := m.Called(ctx, resourceKind, value)
@@ -59,6 +94,12 @@ func (m *ManagerMock) DeleteUser(ctx context.Context, code string) error {
 	return args.Error(0)
 }
 
+// DeleteWebAuthnCredential implements the UserManager interface.
+func (m *ManagerMock) DeleteWebAuthnCredential(ctx context.Context, credentialID string) error {
+	args := m.Called(ctx, credentialID)
+	return args.Error(0)
+}
+
 // DisableTFA implements the UserManager interface.
 func (m *ManagerMock) DisableTFA(ctx context.Context, passcode string) error {
 	args := m.Called(ctx, passcode)
@@ -71,6 +112,23 @@ func (m *ManagerMock) EnableTFA(ctx context.Context, passcode string) error {
 	return args.Error(0)
 }
 
+// FinishWebAuthnLogin implements the UserManager interface.
+func (m *ManagerMock) FinishWebAuthnLogin(ctx context.Context, ceremonyID string, response []byte) (string, error) {
+	args := m.Called(ctx, ceremonyID, response)
+	return args.String(0), args.Error(1)
+}
+
+// FinishWebAuthnRegistration implements the UserManager interface.
+func (m *ManagerMock) FinishWebAuthnRegistration(
+	ctx context.Context,
+	name string,
+	ceremonyID string,
+	response []byte,
+) error {
+	args := m.Called(ctx, name, ceremonyID, response)
+	return args.Error(0)
+}
+
 // GetProfile implements the UserManager interface.
 func (m *ManagerMock) GetProfile(ctx context.Context) (*hub.User, error) {
 	args := m.Called(ctx)
@@ -91,12 +149,25 @@ func (m *ManagerMock) GetUserID(ctx context.Context, email string) (string, erro
 	return args.String(0), args.Error(1)
 }
 
+// GetWebAuthnCredentialsJSON implements the UserManager interface.
+func (m *ManagerMock) GetWebAuthnCredentialsJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // RegisterDeleteUserCode implements the UserManager interface.
 func (m *ManagerMock) RegisterDeleteUserCode(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
 }
 
+// RegisterLoginCode implements the UserManager interface.
+func (m *ManagerMock) RegisterLoginCode(ctx context.Context, userEmail, deviceID string) error {
+	args := m.Called(ctx, userEmail, deviceID)
+	return args.Error(0)
+}
+
 // RegisterPasswordResetCode implements the UserManager interface.
 func (m *ManagerMock) RegisterPasswordResetCode(ctx context.Context, userEmail string) error {
 	args := m.Called(ctx, userEmail)
@@ -129,6 +200,12 @@ func (m *ManagerMock) SetupTFA(ctx context.Context) ([]byte, error) {
 	return data, args.Error(1)
 }
 
+// SyncIdPGroups implements the UserManager interface.
+func (m *ManagerMock) SyncIdPGroups(ctx context.Context, userID string, groups []string) error {
+	args := m.Called(ctx, userID, groups)
+	return args.Error(0)
+}
+
 // UpdatePassword implements the UserManager interface.
 func (m *ManagerMock) UpdatePassword(ctx context.Context, old, new string) error {
 	args := m.Called(ctx, old, new)
@@ -147,6 +224,12 @@ func (m *ManagerMock) VerifyEmail(ctx context.Context, code string) (bool, error
 	return args.Bool(0), args.Error(1)
 }
 
+// VerifyLoginCode implements the UserManager interface.
+func (m *ManagerMock) VerifyLoginCode(ctx context.Context, code, deviceID string) (string, error) {
+	args := m.Called(ctx, code, deviceID)
+	return args.String(0), args.Error(1)
+}
+
 // VerifyPasswordResetCode implements the UserManager interface.
 func (m *ManagerMock) VerifyPasswordResetCode(ctx context.Context, code string) error {
 	args := m.Called(ctx, code)
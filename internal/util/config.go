@@ -8,7 +8,8 @@ import (
 
 // SetupConfig creates a new Viper instance to handle the configuration for a
 // particular cmd. Configuration can be provided in a config file or using env
-// variables. See configs folder for some examples.
+// variables. Sensitive values can also be loaded from files (see loadSecrets
+// for more details). See configs folder for some examples.
 func SetupConfig(cmd string) (*viper.Viper, error) {
 	cfg := viper.New()
 	cfg.Set("cmd", cmd)
@@ -26,5 +27,10 @@ func SetupConfig(cmd string) (*viper.Viper, error) {
 	cfg.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
 	cfg.AutomaticEnv()
 
+	// Secrets stored in files
+	if err := loadSecrets(cfg); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
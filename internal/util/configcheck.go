@@ -0,0 +1,288 @@
+package util
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+// ValidateConfig checks that the configuration provided is valid for the cmd
+// it was set up for, verifying that the required keys for the features
+// enabled are present and that the values set have the expected type. All the
+// issues found are aggregated in the error returned, so that they can be
+// fixed at once instead of failing later when the setting is used.
+func ValidateConfig(cfg *viper.Viper) error {
+	v := &configValidator{cfg: cfg, cmd: cfg.GetString("cmd")}
+
+	// Common settings
+	v.required("db.host", "db.port", "db.database", "db.user")
+	v.isInt("db.port")
+	v.isBool("log.pretty", "restrictedHTTPClient")
+	if level := cfg.GetString("log.level"); level != "" {
+		if _, err := zerolog.ParseLevel(level); err != nil {
+			v.addError("log.level", "invalid log level: %s", level)
+		}
+	}
+
+	// Reports store (used by hub, scanner and tracker)
+	if v.cmd == "hub" || v.cmd == "scanner" || v.cmd == "tracker" {
+		v.oneOf("reports.store", "pg", "objectStorage")
+		if v.cfg.GetString("reports.store") == "objectStorage" {
+			v.required("reports.objectStorageURL")
+		}
+		v.isInt("reports.migrationBatchSize")
+	}
+
+	// Cmd specific settings
+	switch v.cmd {
+	case "hub":
+		v.validateHubConfig()
+	case "scanner":
+		v.validateScannerConfig()
+	case "tracker":
+		v.validateTrackerConfig()
+	}
+
+	return v.errs.ErrorOrNil()
+}
+
+// configValidator is a helper used to validate a configuration instance,
+// collecting all the errors found.
+type configValidator struct {
+	cfg  *viper.Viper
+	cmd  string
+	errs *multierror.Error
+}
+
+// validateHubConfig validates the settings used by the hub cmd.
+func (v *configValidator) validateHubConfig() {
+	v.required("server.addr", "server.cookie.hashKey", "server.csrf.authKey")
+	v.isURL("server.baseURL")
+	v.isInt("server.xffIndex", "quotas.maxRepositories", "quotas.maxWebhooks", "quotas.maxAPIKeys")
+	v.isBool(
+		"server.allowPrivateRepositories",
+		"server.loginRequired",
+		"server.basicAuth.enabled",
+	)
+	v.isDuration("server.shutdownTimeout", "orgs.invitationTTL", "repositories.transferTTL")
+	v.oneOf("server.motdSeverity", "info", "warning", "error")
+
+	// Trusted proxies
+	v.isBool("server.proxyProtocol")
+	trustedProxies, err := NewTrustedProxies(v.cfg.GetStringSlice("server.trustedProxies"))
+	if err != nil {
+		v.addError("server.trustedProxies", "%v", err)
+	}
+	if v.cfg.GetBool("server.proxyProtocol") && err == nil && !trustedProxies.Enabled() {
+		v.addError("server.proxyProtocol", "some trusted proxies must be set (server.trustedProxies) to use the PROXY protocol")
+	}
+
+	// Repository kinds settings
+	if _, err := GetRepositoryKindsSettings(v.cfg); err != nil {
+		v.addError("packages.kinds", "%v", err)
+	}
+
+	// Basic auth
+	if v.cfg.GetBool("server.basicAuth.enabled") {
+		v.required("server.basicAuth.username", "server.basicAuth.password")
+	}
+
+	// Cookies
+	if _, err := helpers.GetCookieConfig(v.cfg); err != nil {
+		v.addError("server.cookie", "%v", err)
+	}
+
+	// Email
+	if v.cfg.GetString("email.smtp.host") != "" {
+		v.required("email.from", "email.smtp.port")
+		v.isInt("email.smtp.port")
+	}
+	v.isInt("email.spool.maxAttempts")
+
+	// Oauth providers
+	providers, err := GetOauthProvidersSettings(v.cfg)
+	if err != nil {
+		v.addError("server.oauth", "%v", err)
+	}
+	for _, provider := range providers {
+		baseKey := "server.oauth." + provider.Name + "."
+		v.required(baseKey+"clientID", baseKey+"clientSecret", baseKey+"redirectURL")
+		v.isURL(baseKey + "redirectURL")
+		switch provider.Type {
+		case "github":
+			v.isURL(baseKey + "enterpriseURL")
+		case "oidc":
+			v.required(baseKey + "issuerURL")
+			v.isURL(baseKey + "issuerURL")
+		}
+	}
+}
+
+// validateScannerConfig validates the settings used by the scanner cmd.
+func (v *configValidator) validateScannerConfig() {
+	v.required("scanner.trivyURL")
+	v.isURL("scanner.trivyURL")
+	v.isInt("scanner.concurrency")
+	v.isBool("events.scanningErrors")
+}
+
+// validateTrackerConfig validates the settings used by the tracker cmd.
+func (v *configValidator) validateTrackerConfig() {
+	v.required("images.store")
+	v.oneOf("images.store", "pg")
+	v.isInt(
+		"tracker.concurrency",
+		"tracker.helm.concurrency",
+		"tracker.helm.largeRepositoryThreshold",
+		"tracker.helm.largeRepositoryConcurrency",
+	)
+	v.isBool(
+		"tracker.bypassDigestCheck",
+		"tracker.fullClones",
+		"tracker.helm.diagnostics",
+		"tracker.resolveImagesDigests",
+		"events.trackingErrors",
+	)
+	v.isDuration("images.uploads.ttl")
+	for _, kindName := range v.cfg.GetStringSlice("tracker.repositoriesKinds") {
+		if _, err := hub.GetKindFromName(kindName); err != nil {
+			v.addError("tracker.repositoriesKinds", "invalid repository kind: %s", kindName)
+		}
+	}
+	for _, instanceURL := range v.cfg.GetStringSlice("tracker.upstreamInstances") {
+		if u, err := url.Parse(instanceURL); err != nil || u.Scheme == "" || u.Host == "" {
+			v.addError("tracker.upstreamInstances", "absolute url expected, got %q", instanceURL)
+		}
+	}
+	if _, err := SetupLimiterRegistry(v.cfg); err != nil {
+		v.addError("tracker.limits", "%v", err)
+	}
+	v.isPEM("tracker.cosign.fulcioRoots", "tracker.cosign.rekorPublicKey")
+	v.oneOf("tracker.chartsCache.store", "disk", "objectStorage")
+	switch v.cfg.GetString("tracker.chartsCache.store") {
+	case "disk":
+		v.required("tracker.chartsCache.path")
+	case "objectStorage":
+		v.required("tracker.chartsCache.objectStorageURL")
+	}
+
+	// Github app credentials must be provided together
+	appKeys := []string{"creds.githubApp.appID", "creds.githubApp.installationID", "creds.githubApp.privateKey"}
+	for _, key := range appKeys {
+		if v.cfg.GetString(key) != "" {
+			v.required(appKeys...)
+			break
+		}
+	}
+}
+
+// addError registers a new error for the configuration key provided.
+func (v *configValidator) addError(key, format string, a ...interface{}) {
+	v.errs = multierror.Append(v.errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, a...)))
+}
+
+// required checks that the keys provided have been set to a non empty value.
+func (v *configValidator) required(keys ...string) {
+	for _, key := range keys {
+		if v.cfg.GetString(key) == "" {
+			v.addError(key, "required value not set (it can also be set using the %s env var)", v.envVar(key))
+		}
+	}
+}
+
+// isInt checks that the keys provided, when set, hold an integer value.
+func (v *configValidator) isInt(keys ...string) {
+	for _, key := range keys {
+		if value, ok := v.value(key); ok {
+			if _, err := strconv.Atoi(value); err != nil {
+				v.addError(key, "integer expected, got %q", value)
+			}
+		}
+	}
+}
+
+// isBool checks that the keys provided, when set, hold a boolean value.
+func (v *configValidator) isBool(keys ...string) {
+	for _, key := range keys {
+		if value, ok := v.value(key); ok {
+			if _, err := strconv.ParseBool(value); err != nil {
+				v.addError(key, "boolean expected, got %q", value)
+			}
+		}
+	}
+}
+
+// isDuration checks that the keys provided, when set, hold a valid duration
+// (i.e. 30s or 168h).
+func (v *configValidator) isDuration(keys ...string) {
+	for _, key := range keys {
+		if value, ok := v.value(key); ok {
+			if _, err := time.ParseDuration(value); err != nil {
+				v.addError(key, "duration expected (i.e. 30s), got %q", value)
+			}
+		}
+	}
+}
+
+// isURL checks that the key provided, when set, holds a valid absolute url.
+func (v *configValidator) isURL(key string) {
+	if value, ok := v.value(key); ok {
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+			v.addError(key, "absolute url expected, got %q", value)
+		}
+	}
+}
+
+// isPEM checks that the keys provided, when set, hold PEM encoded data.
+func (v *configValidator) isPEM(keys ...string) {
+	for _, key := range keys {
+		if value, ok := v.value(key); ok {
+			if block, _ := pem.Decode([]byte(value)); block == nil {
+				v.addError(key, "pem encoded data expected")
+			}
+		}
+	}
+}
+
+// oneOf checks that the key provided, when set, holds one of the values given.
+func (v *configValidator) oneOf(key string, values ...string) {
+	if value, ok := v.value(key); ok && !contains(values, value) {
+		v.addError(key, "invalid value %q (valid values: %s)", value, strings.Join(values, ", "))
+	}
+}
+
+// value returns the value of the key provided as a string, and a flag that
+// indicates whether it has been set or not.
+func (v *configValidator) value(key string) (string, bool) {
+	raw := v.cfg.Get(key)
+	if raw == nil {
+		return "", false
+	}
+	value := fmt.Sprint(raw)
+	return value, value != ""
+}
+
+// envVar returns the name of the environment variable that can be used to set
+// the configuration key provided.
+func (v *configValidator) envVar(key string) string {
+	return strings.ToUpper(v.cmd + "_" + strings.NewReplacer("-", "_", ".", "_").Replace(key))
+}
+
+// contains checks if the values provided contain the value given.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	newConfig := func(cmd string, values map[string]interface{}) *viper.Viper {
+		cfg := viper.New()
+		cfg.Set("cmd", cmd)
+		cfg.Set("db.host", "localhost")
+		cfg.Set("db.port", "5432")
+		cfg.Set("db.database", "hub")
+		cfg.Set("db.user", "postgres")
+		for k, v := range values {
+			cfg.Set(k, v)
+		}
+		return cfg
+	}
+
+	t.Run("valid configurations", func(t *testing.T) {
+		t.Parallel()
+		testCases := []*viper.Viper{
+			newConfig("hub", map[string]interface{}{
+				"log.level":              "debug",
+				"server.addr":            "localhost:8000",
+				"server.baseURL":         "https://artifacthub.io",
+				"server.shutdownTimeout": "10s",
+				"server.cookie.hashKey":  "key",
+				"server.csrf.authKey":    "key",
+				"server.motdSeverity":    "warning",
+				"server.oauth.github": map[string]interface{}{
+					"clientID":     "id",
+					"clientSecret": "secret",
+					"redirectURL":  "https://artifacthub.io/oauth/github/callback",
+				},
+				"server.oauth.ghe": map[string]interface{}{
+					"type":          "github",
+					"enterpriseURL": "https://github.example.com",
+					"clientID":      "id",
+					"clientSecret":  "secret",
+					"redirectURL":   "https://artifacthub.io/oauth/ghe/callback",
+				},
+			}),
+			newConfig("scanner", map[string]interface{}{
+				"scanner.trivyURL":    "http://trivy:8081",
+				"scanner.concurrency": 10,
+			}),
+			newConfig("tracker", map[string]interface{}{
+				"images.store":              "pg",
+				"tracker.concurrency":       "10",
+				"tracker.repositoriesKinds": []string{"helm", "olm"},
+			}),
+		}
+		for _, cfg := range testCases {
+			assert.NoError(t, ValidateConfig(cfg), cfg.GetString("cmd"))
+		}
+	})
+
+	t.Run("invalid configuration errors are aggregated", func(t *testing.T) {
+		t.Parallel()
+		cfg := newConfig("hub", map[string]interface{}{
+			"db.port":                    "invalid",
+			"log.level":                  "verbose",
+			"server.addr":                "localhost:8000",
+			"server.shutdownTimeout":     "10",
+			"server.basicAuth.enabled":   true,
+			"server.cookie.hostPrefix":   true,
+			"server.cookie.secure":       true,
+			"server.cookie.domain":       "artifacthub.io",
+			"server.motdSeverity":        "critical",
+			"server.oauth.oidc.clientID": "id",
+			"email.smtp.host":            "smtp.host",
+			"server.trustedProxies":      []string{"invalid"},
+		})
+		err := ValidateConfig(cfg)
+		require.Error(t, err)
+		for _, expectedMsg := range []string{
+			"db.port: integer expected",
+			"log.level: invalid log level: verbose",
+			"server.shutdownTimeout: duration expected",
+			"server.basicAuth.username: required value not set (it can also be set using the HUB_SERVER_BASICAUTH_USERNAME env var)",
+			"server.basicAuth.password: required value not set",
+			"server.cookie.hashKey: required value not set",
+			"server.csrf.authKey: required value not set",
+			"server.cookie: cookies using the host prefix cannot set a domain",
+			"server.motdSeverity: invalid value \"critical\"",
+			"server.oauth.oidc.clientSecret: required value not set",
+			"server.oauth.oidc.issuerURL: required value not set",
+			"email.from: required value not set",
+			"server.trustedProxies: invalid trusted proxy address: invalid",
+		} {
+			assert.Contains(t, err.Error(), expectedMsg)
+		}
+	})
+
+	t.Run("invalid scanner configuration", func(t *testing.T) {
+		t.Parallel()
+		cfg := newConfig("scanner", map[string]interface{}{
+			"scanner.trivyURL": "http://trivy:8081",
+			"reports.store":    "objectStorage",
+		})
+		err := ValidateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reports.objectStorageURL: required value not set")
+	})
+
+	t.Run("invalid tracker configuration", func(t *testing.T) {
+		t.Parallel()
+		cfg := newConfig("tracker", map[string]interface{}{
+			"tracker.repositoriesKinds":      []string{"invalid"},
+			"tracker.limits":                 []map[string]interface{}{{"host": "github.com", "repository": "repo1"}},
+			"tracker.cosign.rekorPublicKey":  "invalid",
+			"tracker.chartsCache.store":      "disk",
+			"tracker.helm.concurrency":       "many",
+			"tracker.upstreamInstances":      []string{"artifacthub.io"},
+			"creds.githubApp.appID":          "1",
+			"creds.githubApp.installationID": "2",
+		})
+		err := ValidateConfig(cfg)
+		require.Error(t, err)
+		for _, expectedMsg := range []string{
+			"images.store: required value not set",
+			"tracker.repositoriesKinds: invalid repository kind: invalid",
+			"tracker.limits: limits must be set for either a host or a repository",
+			"tracker.cosign.rekorPublicKey: pem encoded data expected",
+			"tracker.chartsCache.path: required value not set",
+			`tracker.helm.concurrency: integer expected, got "many"`,
+			`tracker.upstreamInstances: absolute url expected, got "artifacthub.io"`,
+			"creds.githubApp.privateKey: required value not set",
+		} {
+			assert.Contains(t, err.Error(), expectedMsg)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronDescriptors represents the predefined schedules supported, which can be
+// used instead of the standard cron expressions.
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField represents the bounds of a field in a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+// cronFields represents the fields of a standard cron expression.
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// cronMaxLookahead represents how far in the future we'll look for the next
+// activation of a schedule before giving up (i.e. 30th of February).
+const cronMaxLookahead = 5 * 366 * 24 * time.Hour
+
+// CronSchedule represents a schedule defined using a standard cron expression
+// (minute, hour, day of month, month and day of week).
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+// ParseCronSchedule parses the cron expression provided, which can be a
+// standard five fields expression or one of the predefined descriptors
+// (@yearly, @monthly, @weekly, @daily or @hourly).
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, errors.New("empty cron expression")
+	}
+	if strings.HasPrefix(expr, "@") {
+		spec, ok := cronDescriptors[expr]
+		if !ok {
+			return nil, fmt.Errorf("unsupported cron descriptor: %s", expr)
+		}
+		expr = spec
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("expected %d fields in cron expression, found %d", len(cronFields), len(fields))
+	}
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		var err error
+		bits[i], err = parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	s := &CronSchedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}
+	if s.dow&(1<<7) != 0 { // Sunday can be represented by 0 or 7
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// Next returns the first activation of the schedule after the time provided.
+// A zero time is returned if the schedule will never be activated.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronMaxLookahead)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchDay checks if the day of the time provided matches the schedule. When
+// both the day of month and the day of week are restricted, the day matches
+// if any of them does (as in the standard cron implementation).
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// parseCronField parses a cron expression field, returning a bitset with the
+// values it matches.
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		// Extract step if provided
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field: %s", f.name, part)
+			}
+			part = part[:i]
+		}
+
+		// Get range of values
+		var low, high int
+		switch {
+		case part == "*":
+			low, high = f.min, f.max
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err1, err2 error
+			low, err1 = strconv.Atoi(bounds[0])
+			high, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("invalid range in %s field: %s", f.name, part)
+			}
+		default:
+			var err error
+			low, err = strconv.Atoi(part)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value in %s field: %s", f.name, part)
+			}
+			high = low
+			if step > 1 {
+				high = f.max
+			}
+		}
+		if low < f.min || high > f.max || low > high {
+			return 0, fmt.Errorf("value out of range in %s field: %s", f.name, part)
+		}
+
+		// Set matching values
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
@@ -0,0 +1,80 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		t.Parallel()
+		testCases := []string{
+			"",
+			"@every",
+			"* * * *",
+			"* * * * * *",
+			"60 * * * *",
+			"* 24 * * *",
+			"* * 0 * *",
+			"* * * 13 *",
+			"* * * * 8",
+			"*/0 * * * *",
+			"a * * * *",
+			"5-1 * * * *",
+			"1-a * * * *",
+		}
+		for _, expr := range testCases {
+			_, err := ParseCronSchedule(expr)
+			assert.Error(t, err, expr)
+		}
+	})
+
+	t.Run("valid expressions", func(t *testing.T) {
+		t.Parallel()
+		testCases := []string{
+			"@yearly",
+			"@hourly",
+			"* * * * *",
+			"0 */6 * * *",
+			"5,10,15-20 0-12/2 1 1-6 1-5",
+			"30 2 * * 7",
+		}
+		for _, expr := range testCases {
+			_, err := ParseCronSchedule(expr)
+			assert.NoError(t, err, expr)
+		}
+	})
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2021, 3, 15, 10, 20, 30, 0, time.UTC) // Monday
+	testCases := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2021, 3, 15, 10, 21, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2021, 3, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2021, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2021, 3, 21, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 */6 * * *", time.Date(2021, 3, 15, 12, 0, 0, 0, time.UTC)},
+		{"15 10 * * *", time.Date(2021, 3, 16, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2021, 3, 16, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 3", time.Date(2021, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tc := range testCases {
+		s, err := ParseCronSchedule(tc.expr)
+		require.NoError(t, err, tc.expr)
+		assert.Equal(t, tc.expected, s.Next(from), tc.expr)
+	}
+}
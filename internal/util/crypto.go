@@ -0,0 +1,69 @@
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrEncryptionKeyNotProvided indicates that no encryption key has been
+	// provided to encrypt or decrypt some data.
+	ErrEncryptionKeyNotProvided = errors.New("encryption key not provided")
+
+	// ErrInvalidCiphertext indicates that the ciphertext provided could not be
+	// decrypted.
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+// Encrypt encrypts the plaintext provided using AES-GCM with a key derived
+// from the one given. The ciphertext returned is base64 encoded and includes
+// the nonce used.
+func Encrypt(key, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt decrypts the ciphertext provided, which must have been generated by
+// Encrypt using the same key.
+func Decrypt(key, ciphertext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(data) < gcm.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+	nonce, data := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, data, nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+	return string(plaintext), nil
+}
+
+// newGCM returns an AES-GCM cipher using a key derived from the one provided.
+func newGCM(key string) (cipher.AEAD, error) {
+	if key == "" {
+		return nil, ErrEncryptionKeyNotProvided
+	}
+	k := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(k[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("encryption key not provided", func(t *testing.T) {
+		t.Parallel()
+		_, err := Encrypt("", "plaintext")
+		assert.Equal(t, ErrEncryptionKeyNotProvided, err)
+		_, err = Decrypt("", "ciphertext")
+		assert.Equal(t, ErrEncryptionKeyNotProvided, err)
+	})
+
+	t.Run("invalid ciphertext", func(t *testing.T) {
+		t.Parallel()
+		_, err := Decrypt("key", "invalid")
+		assert.Equal(t, ErrInvalidCiphertext, err)
+		ciphertext, err := Encrypt("key", "plaintext")
+		require.NoError(t, err)
+		_, err = Decrypt("key2", ciphertext)
+		assert.Equal(t, ErrInvalidCiphertext, err)
+	})
+
+	t.Run("encrypt and decrypt succeeded", func(t *testing.T) {
+		t.Parallel()
+		ciphertext, err := Encrypt("key", "plaintext")
+		require.NoError(t, err)
+		assert.NotEqual(t, "plaintext", ciphertext)
+		plaintext, err := Decrypt("key", ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "plaintext", plaintext)
+	})
+}
@@ -0,0 +1,113 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+)
+
+var (
+	// kindsDisplayNames represents the names used by default to display the
+	// repository kinds in the UI.
+	kindsDisplayNames = map[hub.RepositoryKind]string{
+		hub.ArgoWorkflowTemplate: "Argo Workflows templates",
+		hub.Backstage:            "Backstage plugins",
+		hub.Container:            "Container images",
+		hub.CoreDNS:              "CoreDNS plugins",
+		hub.Crossplane:           "Crossplane packages",
+		hub.Devcontainer:         "Dev Container features and templates",
+		hub.Falco:                "Falco rules",
+		hub.Gatekeeper:           "Gatekeeper policies",
+		hub.Headlamp:             "Headlamp plugins",
+		hub.Helm:                 "Helm charts",
+		hub.HelmPlugin:           "Helm plugins",
+		hub.KedaScaler:           "KEDA scalers",
+		hub.Keptn:                "Keptn integrations",
+		hub.Knative:              "Knative functions and eventing sources",
+		hub.Krew:                 "Krew kubectl plugins",
+		hub.Kustomize:            "Kustomize bases and components",
+		hub.Kyverno:              "Kyverno policies",
+		hub.OLM:                  "OLM operators",
+		hub.OPA:                  "OPA policies",
+		hub.TBAction:             "Tinkerbell actions",
+		hub.TektonTask:           "Tekton tasks",
+		hub.Terraform:            "Terraform modules",
+		hub.Wasm:                 "WebAssembly modules",
+	}
+
+	// searchFacets represents the facets available when searching packages.
+	searchFacets = []string{"org", "user", "kind", "repo", "license", "capabilities"}
+
+	// searchSorts represents the sort options available when searching
+	// packages.
+	searchSorts = []string{"relevance", "stars", "last_release"}
+)
+
+// GetRepositoryKindsSettings returns the settings of all the repository kinds
+// supported. The defaults can be customized for each kind in the configuration
+// provided using the packages.kinds.<kindName> key (i.e. packages.kinds.helm).
+func GetRepositoryKindsSettings(cfg *viper.Viper) ([]*hub.RepositoryKindSettings, error) {
+	// Check all the kinds configured are valid
+	for name := range cfg.GetStringMap("packages.kinds") {
+		if _, err := hub.GetKindFromName(name); err != nil {
+			return nil, fmt.Errorf("invalid repository kind: %s", name)
+		}
+	}
+
+	// Prepare kinds settings, applying the customizations configured
+	settings := make([]*hub.RepositoryKindSettings, 0, len(kindsDisplayNames))
+	for kind := hub.Helm; kind <= hub.Container; kind++ {
+		name := hub.GetKindName(kind)
+		s := &hub.RepositoryKindSettings{
+			Kind:        kind,
+			Name:        name,
+			Enabled:     true,
+			DisplayName: kindsDisplayNames[kind],
+			DefaultSort: "relevance",
+		}
+		for _, facet := range searchFacets {
+			if facet == "capabilities" && kind != hub.OLM {
+				continue
+			}
+			s.Facets = append(s.Facets, facet)
+		}
+
+		key := "packages.kinds." + name
+		if cfg.IsSet(key + ".enabled") {
+			s.Enabled = cfg.GetBool(key + ".enabled")
+		}
+		if displayName := cfg.GetString(key + ".displayName"); displayName != "" {
+			s.DisplayName = displayName
+		}
+		s.Icon = cfg.GetString(key + ".icon")
+		if defaultSort := cfg.GetString(key + ".defaultSort"); defaultSort != "" {
+			if !contains(searchSorts, defaultSort) {
+				return nil, fmt.Errorf("invalid default sort for %s kind: %s (valid values: %s)",
+					name, defaultSort, strings.Join(searchSorts, ", "))
+			}
+			s.DefaultSort = defaultSort
+		}
+		if cfg.IsSet(key + ".facets") {
+			facets := cfg.GetStringSlice(key + ".facets")
+			for _, facet := range facets {
+				if !contains(searchFacets, facet) {
+					return nil, fmt.Errorf("invalid facet for %s kind: %s (valid values: %s)",
+						name, facet, strings.Join(searchFacets, ", "))
+				}
+			}
+			s.Facets = facets
+		}
+		settings = append(settings, s)
+	}
+
+	// At least one kind must be enabled
+	for _, s := range settings {
+		if s.Enabled {
+			return settings, nil
+		}
+	}
+	return nil, errors.New("at least one repository kind must be enabled")
+}
@@ -0,0 +1,80 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRepositoryKindsSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid settings", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			key   string
+			value interface{}
+		}{
+			{"packages.kinds.invalid.enabled", true},
+			{"packages.kinds.helm.defaultSort", "invalid"},
+			{"packages.kinds.helm.facets", []string{"kind", "invalid"}},
+		}
+		for _, tc := range testCases {
+			cfg := viper.New()
+			cfg.Set(tc.key, tc.value)
+			_, err := GetRepositoryKindsSettings(cfg)
+			assert.Error(t, err, tc.key)
+		}
+	})
+
+	t.Run("all kinds disabled", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		for kind := hub.Helm; kind <= hub.Container; kind++ {
+			cfg.Set("packages.kinds."+hub.GetKindName(kind)+".enabled", false)
+		}
+		_, err := GetRepositoryKindsSettings(cfg)
+		assert.Error(t, err)
+	})
+
+	t.Run("default settings", func(t *testing.T) {
+		t.Parallel()
+		settings, err := GetRepositoryKindsSettings(viper.New())
+		require.NoError(t, err)
+		require.Len(t, settings, int(hub.Container)+1)
+		assert.Equal(t, &hub.RepositoryKindSettings{
+			Kind:        hub.Helm,
+			Name:        "helm",
+			Enabled:     true,
+			DisplayName: "Helm charts",
+			DefaultSort: "relevance",
+			Facets:      []string{"org", "user", "kind", "repo", "license"},
+		}, settings[hub.Helm])
+		assert.Equal(t, []string{"org", "user", "kind", "repo", "license", "capabilities"}, settings[hub.OLM].Facets)
+	})
+
+	t.Run("customized settings", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("packages.kinds.falco.enabled", false)
+		cfg.Set("packages.kinds.helm.displayName", "Charts")
+		cfg.Set("packages.kinds.helm.icon", "/static/media/charts.svg")
+		cfg.Set("packages.kinds.helm.defaultSort", "stars")
+		cfg.Set("packages.kinds.helm.facets", []string{"repo", "license"})
+		settings, err := GetRepositoryKindsSettings(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, &hub.RepositoryKindSettings{
+			Kind:        hub.Helm,
+			Name:        "helm",
+			Enabled:     true,
+			DisplayName: "Charts",
+			Icon:        "/static/media/charts.svg",
+			DefaultSort: "stars",
+			Facets:      []string{"repo", "license"},
+		}, settings[hub.Helm])
+		assert.False(t, settings[hub.Falco].Enabled)
+	})
+}
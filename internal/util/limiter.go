@@ -0,0 +1,189 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
+)
+
+// Limits represents the requests rate limit and the downloads concurrency that
+// apply to the requests made to a given host or on behalf of a repository.
+type Limits struct {
+	Host              string  `mapstructure:"host"`
+	Repository        string  `mapstructure:"repository"`
+	RequestsPerSecond float64 `mapstructure:"requestsPerSecond"`
+	Burst             int     `mapstructure:"burst"`
+	Concurrency       int     `mapstructure:"concurrency"`
+}
+
+// limiter represents the rate limiter and concurrency semaphore registered for
+// a given host or repository.
+type limiter struct {
+	rl  *rate.Limiter
+	sem chan struct{}
+}
+
+// LimiterRegistry is a hub.RequestsLimiter implementation that keeps the
+// limiters used to throttle the requests made to some hosts or on behalf of
+// some repositories. Acquire can be safely called on a nil registry, in which
+// case no limits are applied.
+type LimiterRegistry struct {
+	hosts map[string]*limiter
+	repos map[string]*limiter
+}
+
+// NewLimiterRegistry creates a new LimiterRegistry instance using the limits
+// provided.
+func NewLimiterRegistry(limits []*Limits) (*LimiterRegistry, error) {
+	r := &LimiterRegistry{
+		hosts: make(map[string]*limiter),
+		repos: make(map[string]*limiter),
+	}
+	for _, l := range limits {
+		if (l.Host == "") == (l.Repository == "") {
+			return nil, errors.New("limits must be set for either a host or a repository")
+		}
+		if l.RequestsPerSecond < 0 || l.Burst < 0 || l.Concurrency < 0 {
+			return nil, fmt.Errorf("invalid limits for %s%s", l.Host, l.Repository)
+		}
+		lim := &limiter{}
+		if l.RequestsPerSecond > 0 {
+			burst := l.Burst
+			if burst == 0 {
+				burst = 1
+			}
+			lim.rl = rate.NewLimiter(rate.Limit(l.RequestsPerSecond), burst)
+		}
+		if l.Concurrency > 0 {
+			lim.sem = make(chan struct{}, l.Concurrency)
+		}
+		if l.Host != "" {
+			r.hosts[l.Host] = lim
+		} else {
+			r.repos[l.Repository] = lim
+		}
+	}
+	return r, nil
+}
+
+// SetupLimiterRegistry is a helper that sets up a limiter registry using the
+// limits defined in the tracker configuration.
+func SetupLimiterRegistry(cfg *viper.Viper) (*LimiterRegistry, error) {
+	var limits []*Limits
+	if err := cfg.UnmarshalKey("tracker.limits", &limits); err != nil {
+		return nil, err
+	}
+	return NewLimiterRegistry(limits)
+}
+
+// SetHostRateLimiter registers the rate limiter provided for the host given,
+// replacing the rate limit configured for it, if any. This must be done while
+// setting up the registry, before it's used.
+func (r *LimiterRegistry) SetHostRateLimiter(host string, rl *rate.Limiter) {
+	lim, ok := r.hosts[host]
+	if !ok {
+		lim = &limiter{}
+		r.hosts[host] = lim
+	}
+	lim.rl = rl
+}
+
+// Acquire waits until a request to the host provided can be made on behalf of
+// the repository given, honoring the limits registered for both of them. The
+// function returned must be called once the request has been completed, so
+// that the concurrency slots acquired are released.
+func (r *LimiterRegistry) Acquire(ctx context.Context, repoName, host string) (func(), error) {
+	var acquired []*limiter
+	release := func() {
+		for _, lim := range acquired {
+			<-lim.sem
+		}
+	}
+	if r == nil {
+		return release, nil
+	}
+
+	// The repository limiter is always acquired first to avoid deadlocks
+	for _, lim := range []*limiter{r.repos[repoName], r.hosts[host]} {
+		if lim == nil {
+			continue
+		}
+		if lim.sem != nil {
+			select {
+			case lim.sem <- struct{}{}:
+				acquired = append(acquired, lim)
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+		if lim.rl != nil {
+			if err := lim.rl.Wait(ctx); err != nil {
+				release()
+				return nil, err
+			}
+		}
+	}
+	return release, nil
+}
+
+// KeyedLimiter rate limits events per key (i.e. per client IP or per email
+// address), using a separate token bucket for each of them. Buckets that have
+// not been used for long enough to be full again are discarded, so keys seen
+// once do not accumulate. Limits are kept in memory, so they apply to each
+// process independently.
+type KeyedLimiter struct {
+	limit     rate.Limit
+	burst     int
+	idleTTL   time.Duration
+	mu        sync.Mutex
+	limiters  map[string]*keyedLimiterEntry
+	lastSweep time.Time
+}
+
+// keyedLimiterEntry represents the rate limiter used for a given key.
+type keyedLimiterEntry struct {
+	rl       *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewKeyedLimiter creates a new KeyedLimiter instance that allows up to burst
+// events at once per key, refilled at the rate provided.
+func NewKeyedLimiter(limit rate.Limit, burst int) *KeyedLimiter {
+	return &KeyedLimiter{
+		limit:     limit,
+		burst:     burst,
+		idleTTL:   time.Duration(float64(burst) / float64(limit) * float64(time.Second)),
+		limiters:  make(map[string]*keyedLimiterEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow reports whether an event for the key provided may happen now,
+// consuming a token from its bucket when it does.
+func (l *KeyedLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) > l.idleTTL {
+		for k, e := range l.limiters {
+			if now.Sub(e.lastSeen) > l.idleTTL {
+				delete(l.limiters, k)
+			}
+		}
+		l.lastSweep = now
+	}
+	e, ok := l.limiters[key]
+	if !ok {
+		e = &keyedLimiterEntry{rl: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[key] = e
+	}
+	e.lastSeen = now
+	return e.rl.AllowN(now, 1)
+}
@@ -0,0 +1,122 @@
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestNewLimiterRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid limits", func(t *testing.T) {
+		t.Parallel()
+		testCases := [][]*Limits{
+			{{}},
+			{{Host: "host", Repository: "repo"}},
+			{{Host: "host", RequestsPerSecond: -1}},
+			{{Repository: "repo", Concurrency: -1}},
+		}
+		for _, limits := range testCases {
+			_, err := NewLimiterRegistry(limits)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("valid limits", func(t *testing.T) {
+		t.Parallel()
+		r, err := NewLimiterRegistry([]*Limits{
+			{Host: "host", RequestsPerSecond: 10, Burst: 5},
+			{Repository: "repo", Concurrency: 2},
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, r.hosts["host"].rl)
+		assert.Nil(t, r.hosts["host"].sem)
+		assert.Nil(t, r.repos["repo"].rl)
+		assert.Equal(t, 2, cap(r.repos["repo"].sem))
+	})
+}
+
+func TestLimiterRegistryAcquire(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil registry does not apply any limits", func(t *testing.T) {
+		t.Parallel()
+		var r *LimiterRegistry
+		release, err := r.Acquire(context.Background(), "repo", "host")
+		require.NoError(t, err)
+		release()
+	})
+
+	t.Run("concurrency limit is honored until slots are released", func(t *testing.T) {
+		t.Parallel()
+		r, err := NewLimiterRegistry([]*Limits{{Host: "host", Concurrency: 1}})
+		require.NoError(t, err)
+
+		release, err := r.Acquire(context.Background(), "repo", "host")
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = r.Acquire(ctx, "repo", "host")
+		assert.Equal(t, context.DeadlineExceeded, err)
+
+		release()
+		release2, err := r.Acquire(context.Background(), "repo", "host")
+		require.NoError(t, err)
+		release2()
+	})
+
+	t.Run("repository slots are released when the host ones cannot be acquired", func(t *testing.T) {
+		t.Parallel()
+		r, err := NewLimiterRegistry([]*Limits{
+			{Host: "host", Concurrency: 1},
+			{Repository: "repo", Concurrency: 1},
+		})
+		require.NoError(t, err)
+
+		release, err := r.Acquire(context.Background(), "repo2", "host")
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = r.Acquire(ctx, "repo", "host")
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Len(t, r.repos["repo"].sem, 0)
+		release()
+	})
+
+	t.Run("host rate limiter registered is used", func(t *testing.T) {
+		t.Parallel()
+		r, err := NewLimiterRegistry(nil)
+		require.NoError(t, err)
+		r.SetHostRateLimiter("github.com", rate.NewLimiter(rate.Every(time.Hour), 1))
+
+		release, err := r.Acquire(context.Background(), "repo", "github.com")
+		require.NoError(t, err)
+		release()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = r.Acquire(ctx, "repo", "github.com")
+		assert.Error(t, err)
+	})
+}
+
+func TestKeyedLimiter(t *testing.T) {
+	t.Parallel()
+
+	l := NewKeyedLimiter(rate.Every(time.Hour), 2)
+	assert.True(t, l.Allow("key1"))
+	assert.True(t, l.Allow("key1"))
+	assert.False(t, l.Allow("key1"))
+	assert.True(t, l.Allow("key2"))
+	assert.Len(t, l.limiters, 2)
+
+	// Idle limiters are discarded
+	l.lastSweep = time.Now().Add(-3 * time.Hour)
+	l.limiters["key2"].lastSeen = time.Now().Add(-3 * time.Hour)
+	assert.False(t, l.Allow("key1"))
+	assert.Len(t, l.limiters, 1)
+}
@@ -0,0 +1,66 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+)
+
+var (
+	// oauthProvidersTypes represents the types of oauth providers supported.
+	oauthProvidersTypes = []string{"github", "google", "oidc"}
+
+	// oauthProvidersDisplayNames represents the names used by default to
+	// display the oauth providers in the UI, indexed by provider type.
+	oauthProvidersDisplayNames = map[string]string{
+		"github": "GitHub",
+		"google": "Google",
+		"oidc":   "OpenID Connect",
+	}
+
+	// oauthProviderNameRE is a regexp used to validate the oauth providers
+	// names, as they are used in the oauth endpoints paths.
+	oauthProviderNameRE = regexp.MustCompile(`^[a-z0-9-]+$`)
+)
+
+// GetOauthProvidersSettings returns the settings of the oauth providers
+// configured using the server.oauth.<providerName> key, sorted by name. The
+// type of each provider is set using its type key, and it defaults to the
+// provider name, so that github, google and oidc can be configured directly.
+func GetOauthProvidersSettings(cfg *viper.Viper) ([]*hub.OauthProviderSettings, error) {
+	names := make([]string, 0, len(cfg.GetStringMap("server.oauth")))
+	for name := range cfg.GetStringMap("server.oauth") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	settings := make([]*hub.OauthProviderSettings, 0, len(names))
+	for _, name := range names {
+		if !oauthProviderNameRE.MatchString(name) {
+			return nil, fmt.Errorf("invalid oauth provider name: %s (only lowercase letters, digits and hyphens allowed)", name)
+		}
+		key := "server.oauth." + name
+		providerType := cfg.GetString(key + ".type")
+		if providerType == "" {
+			providerType = name
+		}
+		if !contains(oauthProvidersTypes, providerType) {
+			return nil, fmt.Errorf("invalid type for %s oauth provider: %s (valid values: %s)",
+				name, providerType, strings.Join(oauthProvidersTypes, ", "))
+		}
+		displayName := cfg.GetString(key + ".displayName")
+		if displayName == "" {
+			displayName = oauthProvidersDisplayNames[providerType]
+		}
+		settings = append(settings, &hub.OauthProviderSettings{
+			Name:        name,
+			Type:        providerType,
+			DisplayName: displayName,
+		})
+	}
+	return settings, nil
+}
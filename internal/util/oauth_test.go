@@ -0,0 +1,67 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOauthProvidersSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid settings", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			key   string
+			value interface{}
+		}{
+			{"server.oauth.invalid.clientID", "id"},
+			{"server.oauth.ghe.type", "invalid"},
+			{"server.oauth.ghe_1.type", "github"},
+		}
+		for _, tc := range testCases {
+			cfg := viper.New()
+			cfg.Set(tc.key, tc.value)
+			_, err := GetOauthProvidersSettings(cfg)
+			assert.Error(t, err, tc.key)
+		}
+	})
+
+	t.Run("no providers configured", func(t *testing.T) {
+		t.Parallel()
+		settings, err := GetOauthProvidersSettings(viper.New())
+		require.NoError(t, err)
+		assert.Empty(t, settings)
+	})
+
+	t.Run("providers settings returned", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.oauth.github.clientID", "id")
+		cfg.Set("server.oauth.ghe.type", "github")
+		cfg.Set("server.oauth.ghe.displayName", "GitHub Enterprise")
+		cfg.Set("server.oauth.oidc.clientID", "id")
+		settings, err := GetOauthProvidersSettings(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, []*hub.OauthProviderSettings{
+			{
+				Name:        "ghe",
+				Type:        "github",
+				DisplayName: "GitHub Enterprise",
+			},
+			{
+				Name:        "github",
+				Type:        "github",
+				DisplayName: "GitHub",
+			},
+			{
+				Name:        "oidc",
+				Type:        "oidc",
+				DisplayName: "OpenID Connect",
+			},
+		}, settings)
+	})
+}
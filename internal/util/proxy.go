@@ -0,0 +1,250 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// proxyProtocolHeaderTimeout represents how long we wait for the PROXY
+	// protocol header to be received once a connection has been accepted.
+	proxyProtocolHeaderTimeout = 10 * time.Second
+
+	// proxyProtocolV1MaxLength represents the maximum length of a PROXY
+	// protocol v1 header, including the CRLF.
+	proxyProtocolV1MaxLength = 107
+)
+
+var (
+	// ErrInvalidProxyProtocolHeader indicates that the PROXY protocol header
+	// received is not valid or that it was expected and it wasn't received.
+	ErrInvalidProxyProtocolHeader = errors.New("invalid proxy protocol header")
+
+	// proxyProtocolV2Signature represents the signature that PROXY protocol v2
+	// headers start with.
+	proxyProtocolV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+)
+
+// TrustedProxies represents a set of networks the proxies we trust belong to.
+// A nil TrustedProxies instance does not trust any address.
+type TrustedProxies struct {
+	nets []*net.IPNet
+}
+
+// NewTrustedProxies creates a new TrustedProxies instance from the list of
+// CIDRs or IP addresses provided (both IPv4 and IPv6 are supported).
+func NewTrustedProxies(entries []string) (*TrustedProxies, error) {
+	tp := &TrustedProxies{}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy address: %s", entry)
+			}
+			if ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy cidr: %s", entry)
+		}
+		tp.nets = append(tp.nets, ipNet)
+	}
+	return tp, nil
+}
+
+// Enabled returns true when at least one trusted network has been set up.
+func (tp *TrustedProxies) Enabled() bool {
+	return tp != nil && len(tp.nets) > 0
+}
+
+// Contains checks if the IP provided belongs to any of the trusted networks.
+// IPv4-mapped IPv6 addresses are handled as IPv4 ones.
+func (tp *TrustedProxies) Contains(ip net.IP) bool {
+	if tp == nil || ip == nil {
+		return false
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	for _, ipNet := range tp.nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProxyProtocolListener is a net.Listener that parses the PROXY protocol (v1
+// and v2) header sent by the trusted proxies at the beginning of the
+// connections, so that the remote address of the connections is set to the
+// one of the client the proxy is forwarding the connection for. Connections
+// from untrusted peers are handled as regular connections.
+type ProxyProtocolListener struct {
+	net.Listener
+	TrustedProxies *TrustedProxies
+}
+
+// Accept implements the net.Listener interface.
+func (l *ProxyProtocolListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if tcpAddr, ok := conn.RemoteAddr().(*net.TCPAddr); !ok || !l.TrustedProxies.Contains(tcpAddr.IP) {
+		return conn, nil
+	}
+	return &proxyProtocolConn{
+		Conn: conn,
+		r:    bufio.NewReader(conn),
+	}, nil
+}
+
+// proxyProtocolConn represents a connection received from a trusted proxy,
+// which is expected to start with a PROXY protocol header. The header is read
+// lazily, the first time the connection is read or its remote address is
+// requested, so that slow proxies do not block accepting new connections.
+type proxyProtocolConn struct {
+	net.Conn
+	r *bufio.Reader
+
+	once       sync.Once
+	remoteAddr net.Addr
+	err        error
+}
+
+// Read implements the net.Conn interface.
+func (c *proxyProtocolConn) Read(b []byte) (int, error) {
+	c.readHeader()
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.r.Read(b)
+}
+
+// RemoteAddr implements the net.Conn interface.
+func (c *proxyProtocolConn) RemoteAddr() net.Addr {
+	c.readHeader()
+	if c.remoteAddr != nil {
+		return c.remoteAddr
+	}
+	return c.Conn.RemoteAddr()
+}
+
+// readHeader reads the PROXY protocol header from the connection, only once.
+func (c *proxyProtocolConn) readHeader() {
+	c.once.Do(func() {
+		_ = c.Conn.SetReadDeadline(time.Now().Add(proxyProtocolHeaderTimeout))
+		c.remoteAddr, c.err = readProxyProtocolHeader(c.r)
+		_ = c.Conn.SetReadDeadline(time.Time{})
+	})
+}
+
+// readProxyProtocolHeader reads a PROXY protocol header (v1 or v2) from the
+// reader provided, returning the source address it contains. A nil address is
+// returned when the header does not include the source address (i.e. health
+// checks performed by the proxy itself).
+func readProxyProtocolHeader(r *bufio.Reader) (net.Addr, error) {
+	sig, err := r.Peek(len(proxyProtocolV2Signature))
+	if err == nil && bytes.Equal(sig, proxyProtocolV2Signature) {
+		return readProxyProtocolV2Header(r)
+	}
+	prefix, err := r.Peek(6)
+	if err == nil && string(prefix) == "PROXY " {
+		return readProxyProtocolV1Header(r)
+	}
+	return nil, ErrInvalidProxyProtocolHeader
+}
+
+// readProxyProtocolV1Header reads a PROXY protocol v1 (human readable) header
+// (i.e. PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\n).
+func readProxyProtocolV1Header(r *bufio.Reader) (net.Addr, error) {
+	var line []byte
+	for len(line) < proxyProtocolV1MaxLength {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, ErrInvalidProxyProtocolHeader
+	}
+	fields := strings.Fields(string(line[:len(line)-2]))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, ErrInvalidProxyProtocolHeader
+	}
+	ip := net.ParseIP(fields[2])
+	port, err := strconv.Atoi(fields[4])
+	if ip == nil || err != nil || port < 0 || port > 65535 {
+		return nil, ErrInvalidProxyProtocolHeader
+	}
+	return &net.TCPAddr{IP: ip, Port: port}, nil
+}
+
+// readProxyProtocolV2Header reads a PROXY protocol v2 (binary) header.
+func readProxyProtocolV2Header(r *bufio.Reader) (net.Addr, error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	verCmd, family := header[12], header[13]
+	if verCmd>>4 != 2 {
+		return nil, ErrInvalidProxyProtocolHeader
+	}
+	data := make([]byte, binary.BigEndian.Uint16(header[14:16]))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+
+	// LOCAL command (connection established by the proxy itself)
+	if verCmd&0x0F == 0x00 {
+		return nil, nil
+	}
+	if verCmd&0x0F != 0x01 {
+		return nil, ErrInvalidProxyProtocolHeader
+	}
+
+	// PROXY command
+	switch family {
+	case 0x11: // TCP over IPv4
+		if len(data) < 12 {
+			return nil, ErrInvalidProxyProtocolHeader
+		}
+		return &net.TCPAddr{
+			IP:   net.IP(data[0:4]),
+			Port: int(binary.BigEndian.Uint16(data[8:10])),
+		}, nil
+	case 0x21: // TCP over IPv6
+		if len(data) < 36 {
+			return nil, ErrInvalidProxyProtocolHeader
+		}
+		return &net.TCPAddr{
+			IP:   net.IP(data[0:16]),
+			Port: int(binary.BigEndian.Uint16(data[32:34])),
+		}, nil
+	default:
+		// Unsupported address families are accepted, but the address
+		// information is ignored
+		return nil, nil
+	}
+}
@@ -0,0 +1,158 @@
+package util
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid entries", func(t *testing.T) {
+		t.Parallel()
+		for _, entry := range []string{"invalid", "10.0.0.0/33", "2001:db8::/200"} {
+			_, err := NewTrustedProxies([]string{entry})
+			assert.Error(t, err, entry)
+		}
+	})
+
+	t.Run("contains", func(t *testing.T) {
+		t.Parallel()
+		tp, err := NewTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "2001:db8::/32"})
+		require.NoError(t, err)
+		assert.True(t, tp.Enabled())
+		testCases := map[string]bool{
+			"10.1.2.3":        true,
+			"::ffff:10.1.2.3": true,
+			"192.168.1.1":     true,
+			"192.168.1.2":     false,
+			"2001:db8::1":     true,
+			"2001:db9::1":     false,
+			"1.1.1.1":         false,
+		}
+		for ip, expected := range testCases {
+			assert.Equal(t, expected, tp.Contains(net.ParseIP(ip)), ip)
+		}
+	})
+
+	t.Run("nil instance does not trust any address", func(t *testing.T) {
+		t.Parallel()
+		var tp *TrustedProxies
+		assert.False(t, tp.Enabled())
+		assert.False(t, tp.Contains(net.ParseIP("10.0.0.1")))
+	})
+}
+
+func TestReadProxyProtocolHeader(t *testing.T) {
+	t.Parallel()
+
+	v2Header := func(verCmd, family byte, addr []byte) string {
+		h := append([]byte{}, proxyProtocolV2Signature...)
+		h = append(h, verCmd, family, byte(len(addr)>>8), byte(len(addr)))
+		return string(append(h, addr...))
+	}
+
+	testCases := []struct {
+		desc         string
+		input        string
+		expectedAddr string
+		expectedErr  error
+	}{
+		{
+			"v1 tcp4",
+			"PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\nGET /",
+			"192.168.0.1:56324",
+			nil,
+		},
+		{
+			"v1 tcp6",
+			"PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\nGET /",
+			"[2001:db8::1]:56324",
+			nil,
+		},
+		{
+			"v1 unknown",
+			"PROXY UNKNOWN\r\nGET /",
+			"",
+			nil,
+		},
+		{
+			"v1 invalid",
+			"PROXY TCP4 invalid 192.168.0.11 56324 443\r\nGET /",
+			"",
+			ErrInvalidProxyProtocolHeader,
+		},
+		{
+			"v2 tcp4",
+			v2Header(0x21, 0x11, []byte{192, 168, 0, 1, 192, 168, 0, 11, 0xDC, 0x04, 0x01, 0xBB}) + "GET /",
+			"192.168.0.1:56324",
+			nil,
+		},
+		{
+			"v2 local",
+			v2Header(0x20, 0x00, nil) + "GET /",
+			"",
+			nil,
+		},
+		{
+			"no header",
+			"GET / HTTP/1.1\r\n",
+			"",
+			ErrInvalidProxyProtocolHeader,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			r := bufio.NewReader(strings.NewReader(tc.input))
+			addr, err := readProxyProtocolHeader(r)
+			assert.Equal(t, tc.expectedErr, err)
+			if tc.expectedErr != nil {
+				return
+			}
+			if tc.expectedAddr == "" {
+				assert.Nil(t, addr)
+			} else {
+				require.NotNil(t, addr)
+				assert.Equal(t, tc.expectedAddr, addr.String())
+			}
+			rest, _ := ioutil.ReadAll(r)
+			assert.Equal(t, "GET /", string(rest))
+		})
+	}
+}
+
+func TestProxyProtocolListener(t *testing.T) {
+	t.Parallel()
+
+	tp, err := NewTrustedProxies([]string{"127.0.0.1"})
+	require.NoError(t, err)
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	l := &ProxyProtocolListener{Listener: tcpListener, TrustedProxies: tp}
+	defer l.Close()
+
+	go func() {
+		conn, err := net.Dial("tcp", tcpListener.Addr().String())
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = conn.Write([]byte("PROXY TCP4 1.2.3.4 127.0.0.1 5678 80\r\nhello"))
+	}()
+
+	conn, err := l.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, "1.2.3.4:5678", conn.RemoteAddr().String())
+	data, err := ioutil.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+}
@@ -0,0 +1,160 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+// secretsKeys represents the configuration keys holding sensitive values that
+// can be loaded from files.
+var secretsKeys = []string{
+	"creds.dockerPassword",
+	"creds.githubApp.privateKey",
+	"creds.githubToken",
+	"db.encryptionKey",
+	"db.password",
+	"email.smtp.password",
+	"server.basicAuth.password",
+	"server.cookie.hashKey",
+	"server.csrf.authKey",
+	"server.oauth.github.clientSecret",
+	"server.oauth.google.clientSecret",
+	"server.oauth.oidc.clientSecret",
+}
+
+// getSecretsKeys returns the configuration keys holding sensitive values,
+// including the client secrets of all the oauth providers configured.
+func getSecretsKeys(cfg *viper.Viper) []string {
+	keys := append([]string{}, secretsKeys...)
+	for provider := range cfg.GetStringMap("server.oauth") {
+		key := "server.oauth." + provider + ".clientSecret"
+		if !contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// getSecretsFiles returns the files the secrets should be loaded from, indexed
+// by the configuration key they belong to. A secret file can be referenced
+// explicitly using the <CMD>_<KEY>_FILE env variable (i.e. HUB_DB_PASSWORD_FILE)
+// or the <key>File configuration key (i.e. db.passwordFile). When a secrets
+// directory is configured (secrets.dir), the files on it named after the
+// secrets keys (i.e. db.password) will be used as well, which plays nicely
+// with Kubernetes secrets mounted as volumes. Explicit references take
+// precedence over the files in the secrets directory.
+func getSecretsFiles(cfg *viper.Viper) map[string]string {
+	files := make(map[string]string)
+	secretsDir := cfg.GetString("secrets.dir")
+	envReplacer := strings.NewReplacer("-", "_", ".", "_")
+	for _, key := range getSecretsKeys(cfg) {
+		envVar := strings.ToUpper(cfg.GetString("cmd") + "_" + envReplacer.Replace(key) + "_FILE")
+		switch {
+		case os.Getenv(envVar) != "":
+			files[key] = os.Getenv(envVar)
+		case cfg.GetString(key+"File") != "":
+			files[key] = cfg.GetString(key + "File")
+		case secretsDir != "":
+			file := filepath.Join(secretsDir, key)
+			if _, err := os.Stat(file); err == nil {
+				files[key] = file
+			}
+		}
+	}
+	return files
+}
+
+// readSecretFile reads the secret stored in the file provided, removing any
+// trailing new lines.
+func readSecretFile(file string) (string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// loadSecrets sets the secrets stored in files in the configuration provided.
+// Secrets loaded from files override the values set in the configuration.
+func loadSecrets(cfg *viper.Viper) error {
+	for key, file := range getSecretsFiles(cfg) {
+		value, err := readSecretFile(file)
+		if err != nil {
+			return fmt.Errorf("error loading secret %s from file: %w", key, err)
+		}
+		cfg.Set(key, value)
+	}
+	return nil
+}
+
+// SecretsWatcher watches the files the secrets can be loaded from, notifying
+// when any of them changes. Secrets are only loaded when the process starts,
+// so it must be restarted to use the new values.
+type SecretsWatcher struct {
+	cfg    *viper.Viper
+	values map[string]string
+}
+
+// NewSecretsWatcher creates a new SecretsWatcher instance for the secrets
+// that can be loaded from files in the configuration provided.
+func NewSecretsWatcher(cfg *viper.Viper) *SecretsWatcher {
+	w := &SecretsWatcher{
+		cfg:    cfg,
+		values: make(map[string]string),
+	}
+	for _, key := range getSecretsKeys(cfg) {
+		w.values[key] = cfg.GetString(key)
+	}
+	return w
+}
+
+// Run checks periodically if the secrets files have changed until the context
+// provided is cancelled. When changes are detected, the function provided is
+// called with the keys of the secrets that changed. Files that cannot be read
+// (i.e. while they are being updated) are ignored until the next check.
+func (w *SecretsWatcher) Run(ctx context.Context, interval time.Duration, onChange func(keys []string)) {
+	if len(getSecretsFiles(w.cfg)) == 0 && w.cfg.GetString("secrets.dir") == "" {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if changed := w.check(); len(changed) > 0 {
+				onChange(changed)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check returns the keys of the secrets whose files content has changed since
+// the last check. The secrets directory is scanned again on each check, so
+// files added to it after the process started are detected as well.
+func (w *SecretsWatcher) check() []string {
+	var changed []string
+	for key, file := range getSecretsFiles(w.cfg) {
+		value, err := readSecretFile(file)
+		if err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("error reading secret file")
+			continue
+		}
+		if value != w.values[key] {
+			w.values[key] = value
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,100 @@
+package util
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeFile := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+		return file
+	}
+
+	t.Run("secrets are loaded from the files referenced", func(t *testing.T) {
+		os.Setenv("SECRETSTEST_DB_PASSWORD_FILE", writeFile("db-password", "dbPass\n"))
+		defer os.Unsetenv("SECRETSTEST_DB_PASSWORD_FILE")
+		cfg := viper.New()
+		cfg.Set("cmd", "secretstest")
+		cfg.Set("server.csrf.authKey", "notUsed")
+		cfg.Set("server.csrf.authKeyFile", writeFile("csrf-key", "csrfKey"))
+
+		err := loadSecrets(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, "dbPass", cfg.GetString("db.password"))
+		assert.Equal(t, "csrfKey", cfg.GetString("server.csrf.authKey"))
+	})
+
+	t.Run("secrets are loaded from the secrets directory", func(t *testing.T) {
+		writeFile("server.cookie.hashKey", "hashKey")
+		cfg := viper.New()
+		cfg.Set("cmd", "secretstest")
+		cfg.Set("secrets.dir", dir)
+		cfg.Set("server.oauth.github.clientSecretFile", writeFile("github-secret", "githubSecret"))
+		cfg.Set("server.oauth.ghe.clientSecretFile", writeFile("ghe-secret", "gheSecret"))
+
+		err := loadSecrets(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, "hashKey", cfg.GetString("server.cookie.hashKey"))
+		assert.Equal(t, "githubSecret", cfg.GetString("server.oauth.github.clientSecret"))
+		assert.Equal(t, "gheSecret", cfg.GetString("server.oauth.ghe.clientSecret"))
+		assert.Equal(t, "", cfg.GetString("db.password"))
+	})
+
+	t.Run("error loading secret file", func(t *testing.T) {
+		cfg := viper.New()
+		cfg.Set("cmd", "secretstest")
+		cfg.Set("db.passwordFile", filepath.Join(dir, "missing"))
+
+		err := loadSecrets(cfg)
+		assert.Error(t, err)
+	})
+}
+
+func TestSecretsWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "db.password")
+	require.NoError(t, ioutil.WriteFile(file, []byte("pass1"), 0600))
+	cfg := viper.New()
+	cfg.Set("cmd", "secretstest")
+	cfg.Set("secrets.dir", dir)
+	require.NoError(t, loadSecrets(cfg))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan []string, 1)
+	go NewSecretsWatcher(cfg).Run(ctx, 10*time.Millisecond, func(keys []string) {
+		changes <- keys
+	})
+	require.NoError(t, ioutil.WriteFile(file, []byte("pass2"), 0600))
+
+	select {
+	case keys := <-changes:
+		assert.Equal(t, []string{"db.password"}, keys)
+	case <-time.After(5 * time.Second):
+		t.Fatal("secrets change not detected")
+	}
+
+	// Files added to the secrets directory after starting are detected too
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "email.smtp.password"), []byte("pass"), 0600))
+	select {
+	case keys := <-changes:
+		assert.Equal(t, []string{"email.smtp.password"}, keys)
+	case <-time.After(5 * time.Second):
+		t.Fatal("secret file added not detected")
+	}
+}
@@ -3,13 +3,17 @@ package webhook
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"html/template"
 	"net/url"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/notification"
+	"github.com/artifacthub/hub/internal/org"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
 	"github.com/satori/uuid"
+	"github.com/spf13/viper"
 )
 
 const (
@@ -20,18 +24,28 @@ const (
 	getOrgWebhooksDBQ             = `select * from get_org_webhooks($1::uuid, $2::text, $3::int, $4::int)`
 	getUserWebhooksDBQ            = `select * from get_user_webhooks($1::uuid, $2::int, $3::int)`
 	getWebhookDBQ                 = `select get_webhook($1::uuid, $2::uuid)`
+	getWebhookOrgNameDBQ          = `select o.name from webhook w join organization o using (organization_id) where w.webhook_id = $1`
+	getWebhookTemplateErrorsDBQ   = `select get_webhook_template_errors($1::uuid, $2::uuid)`
 	updateWebhookDBQ              = `select update_webhook($1::uuid, $2::jsonb)`
 )
 
+// errOrgDefaultNotAllowedDB represents the error returned from the database
+// when trying to make a webhook owned by a user an organization default.
+var errOrgDefaultNotAllowedDB = errors.New("ERROR: only organization webhooks can be defaults (SQLSTATE P0001)")
+
 // Manager provides an API to manage webhooks.
 type Manager struct {
-	db hub.DB
+	cfg *viper.Viper
+	db  hub.DB
+	az  hub.Authorizer
 }
 
 // NewManager creates a new Manager instance.
-func NewManager(db hub.DB) *Manager {
+func NewManager(cfg *viper.Viper, db hub.DB, az hub.Authorizer) *Manager {
 	return &Manager{
-		db: db,
+		cfg: cfg,
+		db:  db,
+		az:  az,
 	}
 }
 
@@ -43,25 +57,37 @@ func (m *Manager) Add(ctx context.Context, orgName string, wh *hub.Webhook) erro
 	if wh.Name == "" {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
 	}
-	if wh.URL == "" {
-		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "url not provided")
+	if err := validateKind(wh); err != nil {
+		return err
 	}
 	u, err := url.Parse(wh.URL)
 	if err != nil || u.Scheme == "" || u.Host == "" {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid url")
 	}
-	if _, err := template.New("").Parse(wh.Template); err != nil {
+	if _, err := notification.ParseWebhookTemplate(wh.Template); err != nil {
 		return fmt.Errorf("%w: %s %s", hub.ErrInvalidInput, "invalid template", err)
 	}
 	if len(wh.EventKinds) == 0 {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no event kinds provided")
 	}
-	if len(wh.Packages) == 0 {
-		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no packages provided")
+	if wh.OrgDefault && orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "only organization webhooks can be defaults")
 	}
-	for _, p := range wh.Packages {
-		if _, err := uuid.FromString(p.PackageID); err != nil {
-			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	if err := validateSubscriptions(wh); err != nil {
+		return err
+	}
+
+	// Authorize action if the webhook will be added to an organization
+	if orgName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: orgName,
+			UserID:           userID,
+			Action:           hub.AddOrganizationWebhook,
+		}); err != nil {
+			return err
+		}
+		if err := org.CheckQuota(ctx, m.db, m.cfg, userID, orgName, org.WebhooksQuota); err != nil {
+			return err
 		}
 	}
 
@@ -83,6 +109,11 @@ func (m *Manager) Delete(ctx context.Context, webhookID string) error {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
 	}
 
+	// Authorize action if the webhook belongs to an organization
+	if err := m.authorizeOrgWebhookAction(ctx, webhookID, hub.DeleteOrganizationWebhook); err != nil {
+		return err
+	}
+
 	// Delete webhook from database
 	_, err := m.db.Exec(ctx, deleteWebhookDBQ, userID, webhookID)
 	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
@@ -111,6 +142,27 @@ func (m *Manager) GetJSON(ctx context.Context, webhookID string) ([]byte, error)
 	return dataJSON, nil
 }
 
+// GetTemplateErrorsJSON returns the most recent errors rendering the payload
+// template of the provided webhook as a json array.
+func (m *Manager) GetTemplateErrorsJSON(ctx context.Context, webhookID string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+
+	// Get webhook template errors from database
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getWebhookTemplateErrorsDBQ, userID, webhookID)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return nil, hub.ErrInsufficientPrivilege
+		}
+		return nil, err
+	}
+	return dataJSON, nil
+}
+
 // GetOwnedByOrgJSON returns the webhooks belonging to the provided organization
 // as a json array.
 func (m *Manager) GetOwnedByOrgJSON(
@@ -172,33 +224,113 @@ func (m *Manager) Update(ctx context.Context, wh *hub.Webhook) error {
 	if wh.Name == "" {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
 	}
-	if wh.URL == "" {
-		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "url not provided")
+	if err := validateKind(wh); err != nil {
+		return err
 	}
 	u, err := url.Parse(wh.URL)
 	if err != nil || u.Scheme == "" || u.Host == "" {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid url")
 	}
-	if _, err := template.New("").Parse(wh.Template); err != nil {
+	if _, err := notification.ParseWebhookTemplate(wh.Template); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid template")
 	}
 	if len(wh.EventKinds) == 0 {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no event kinds provided")
 	}
-	if len(wh.Packages) == 0 {
-		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no packages provided")
+	if err := validateSubscriptions(wh); err != nil {
+		return err
 	}
-	for _, p := range wh.Packages {
-		if _, err := uuid.FromString(p.PackageID); err != nil {
-			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
-		}
+
+	// Authorize action if the webhook belongs to an organization
+	if err := m.authorizeOrgWebhookAction(ctx, wh.WebhookID, hub.UpdateOrganizationWebhook); err != nil {
+		return err
 	}
 
 	// Update webhook in database
 	whJSON, _ := json.Marshal(wh)
 	_, err = m.db.Exec(ctx, updateWebhookDBQ, userID, whJSON)
-	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
-		return hub.ErrInsufficientPrivilege
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case errOrgDefaultNotAllowedDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "only organization webhooks can be defaults")
+		}
 	}
 	return err
 }
+
+// authorizeOrgWebhookAction checks if the user doing the request is allowed to
+// perform the provided action on the webhook when it belongs to an
+// organization. Webhooks owned by users are checked in the database.
+func (m *Manager) authorizeOrgWebhookAction(ctx context.Context, webhookID string, action hub.Action) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	var orgName string
+	err := m.db.QueryRow(ctx, getWebhookOrgNameDBQ, webhookID).Scan(&orgName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	return m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           action,
+	})
+}
+
+// validateKind checks the webhook kind is valid and that the webhook settings
+// are the ones it expects. Alerting webhooks (PagerDuty and Opsgenie) can only
+// be subscribed to security alerts and require the secret to be set, as it is
+// used as the integration key. When no url is provided for them, the default
+// endpoint of the corresponding service is used.
+func validateKind(wh *hub.Webhook) error {
+	switch wh.Kind {
+	case hub.WebhookKindGeneric:
+		if wh.URL == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "url not provided")
+		}
+		return nil
+	case hub.WebhookKindPagerDuty, hub.WebhookKindOpsgenie:
+		if wh.Secret == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "integration key not provided")
+		}
+		for _, kind := range wh.EventKinds {
+			if kind != hub.SecurityAlert {
+				return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "only security alerts are supported by this webhook kind")
+			}
+		}
+		if wh.URL == "" {
+			wh.URL = hub.WebhookKindsDefaultURLs[wh.Kind]
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid kind")
+	}
+}
+
+// validateSubscriptions checks the webhook is subscribed to some packages,
+// either explicitly or through its repositories settings, and that the ids
+// provided are valid.
+func validateSubscriptions(wh *hub.Webhook) error {
+	subscribed := wh.OrgDefault || len(wh.Packages) > 0
+	for _, r := range wh.Repositories {
+		if _, err := uuid.FromString(r.RepositoryID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+		}
+		if r.Enabled {
+			subscribed = true
+		}
+	}
+	if !subscribed {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no packages provided")
+	}
+	for _, p := range wh.Packages {
+		if _, err := uuid.FromString(p.PackageID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+		}
+	}
+	return nil
+}
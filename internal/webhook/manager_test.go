@@ -5,9 +5,12 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/artifacthub/hub/internal/authz"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -15,6 +18,11 @@ import (
 
 const validUUID = "00000000-0000-0000-0000-000000000001"
 
+var (
+	cfg        = viper.New()
+	quotasJSON = []byte(`{"quotas": {}, "usage": {"webhooks": 1}}`)
+)
+
 func TestAdd(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -29,7 +37,7 @@ func TestAdd(t *testing.T) {
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil, nil)
 		assert.Panics(t, func() {
 			_ = m.Add(context.Background(), "orgName", wh)
 		})
@@ -64,6 +72,33 @@ func TestAdd(t *testing.T) {
 					URL:  "invalidurl",
 				},
 			},
+			{
+				"invalid kind",
+				"org1",
+				&hub.Webhook{
+					Name: "webhook",
+					Kind: "invalid",
+					URL:  "http://webhook1.url",
+				},
+			},
+			{
+				"integration key not provided",
+				"org1",
+				&hub.Webhook{
+					Name: "webhook",
+					Kind: hub.WebhookKindPagerDuty,
+				},
+			},
+			{
+				"only security alerts are supported by this webhook kind",
+				"org1",
+				&hub.Webhook{
+					Name:       "webhook",
+					Kind:       hub.WebhookKindOpsgenie,
+					Secret:     "apiKey",
+					EventKinds: []hub.EventKind{hub.NewRelease},
+				},
+			},
 			{
 				"invalid template",
 				"org1",
@@ -102,12 +137,46 @@ func TestAdd(t *testing.T) {
 					},
 				},
 			},
+			{
+				"invalid repository id",
+				"org1",
+				&hub.Webhook{
+					Name:       "webhook",
+					URL:        "http://webhook1.url",
+					EventKinds: []hub.EventKind{hub.NewRelease},
+					Repositories: []*hub.WebhookRepositoryOverride{
+						{RepositoryID: "", Enabled: true},
+					},
+				},
+			},
+			{
+				"no packages provided",
+				"org1",
+				&hub.Webhook{
+					Name:       "webhook",
+					URL:        "http://webhook1.url",
+					EventKinds: []hub.EventKind{hub.NewRelease},
+					Repositories: []*hub.WebhookRepositoryOverride{
+						{RepositoryID: validUUID, Enabled: false},
+					},
+				},
+			},
+			{
+				"only organization webhooks can be defaults",
+				"",
+				&hub.Webhook{
+					Name:       "webhook",
+					URL:        "http://webhook1.url",
+					EventKinds: []hub.EventKind{hub.NewRelease},
+					OrgDefault: true,
+				},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
-				m := NewManager(nil)
+				m := NewManager(cfg, nil, nil)
 
 				err := m.Add(ctx, tc.orgName, tc.wh)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
@@ -135,8 +204,15 @@ func TestAdd(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, mock.Anything, "userID", "orgName").Return(quotasJSON, nil)
 				db.On("Exec", ctx, addWebhookDBQ, "userID", "orgName", mock.Anything).Return(tc.dbErr)
-				m := NewManager(db)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "orgName",
+					UserID:           "userID",
+					Action:           hub.AddOrganizationWebhook,
+				}).Return(nil)
+				m := NewManager(cfg, db, az)
 
 				err := m.Add(ctx, "orgName", wh)
 				assert.Equal(t, tc.expectedError, err)
@@ -145,15 +221,99 @@ func TestAdd(t *testing.T) {
 		}
 	})
 
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationWebhook,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, az)
+
+		err := m.Add(ctx, "orgName", wh)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("webhooks quota exceeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, mock.Anything, "userID", "orgName").Return([]byte(`
+		{
+			"quotas": {"max_webhooks": 1},
+			"usage": {"webhooks": 1}
+		}
+		`), nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationWebhook,
+		}).Return(nil)
+		m := NewManager(cfg, db, az)
+
+		err := m.Add(ctx, "orgName", wh)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
 	t.Run("add webhook succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, mock.Anything, "userID", "orgName").Return(quotasJSON, nil)
 		db.On("Exec", ctx, addWebhookDBQ, "userID", "orgName", mock.Anything).Return(nil)
-		m := NewManager(db)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationWebhook,
+		}).Return(nil)
+		m := NewManager(cfg, db, az)
 
 		err := m.Add(ctx, "orgName", wh)
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("add user webhook succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addWebhookDBQ, "userID", "", mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil)
+
+		err := m.Add(ctx, "", wh)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("add organization default webhook succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, mock.Anything, "userID", "orgName").Return(quotasJSON, nil)
+		db.On("Exec", ctx, addWebhookDBQ, "userID", "orgName", mock.Anything).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationWebhook,
+		}).Return(nil)
+		m := NewManager(cfg, db, az)
+
+		err := m.Add(ctx, "orgName", &hub.Webhook{
+			Name:       "webhook1",
+			URL:        "http://webhook1.url",
+			EventKinds: []hub.EventKind{hub.NewRelease},
+			OrgDefault: true,
+			Repositories: []*hub.WebhookRepositoryOverride{
+				{RepositoryID: validUUID, Enabled: false},
+			},
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
 	})
 }
 
@@ -162,7 +322,7 @@ func TestDelete(t *testing.T) {
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil, nil)
 		assert.Panics(t, func() {
 			_ = m.Delete(context.Background(), validUUID)
 		})
@@ -170,11 +330,40 @@ func TestDelete(t *testing.T) {
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil, nil)
 		err := m.Delete(ctx, "")
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
 
+	t.Run("error getting webhook organization", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhookOrgNameDBQ, validUUID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.Delete(ctx, validUUID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhookOrgNameDBQ, validUUID).Return("org1", nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationWebhook,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, db, az)
+
+		err := m.Delete(ctx, validUUID)
+		assert.Equal(t, tests.ErrFake, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
 	t.Run("database error", func(t *testing.T) {
 		testCases := []struct {
 			dbErr         error
@@ -194,8 +383,9 @@ func TestDelete(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getWebhookOrgNameDBQ, validUUID).Return(nil, pgx.ErrNoRows)
 				db.On("Exec", ctx, deleteWebhookDBQ, "userID", validUUID).Return(tc.dbErr)
-				m := NewManager(db)
+				m := NewManager(cfg, db, nil)
 
 				err := m.Delete(ctx, validUUID)
 				assert.Equal(t, tc.expectedError, err)
@@ -207,8 +397,9 @@ func TestDelete(t *testing.T) {
 	t.Run("delete webhook succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhookOrgNameDBQ, validUUID).Return(nil, pgx.ErrNoRows)
 		db.On("Exec", ctx, deleteWebhookDBQ, "userID", validUUID).Return(nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db, nil)
 
 		err := m.Delete(ctx, validUUID)
 		assert.NoError(t, err)
@@ -221,7 +412,7 @@ func TestGetJSON(t *testing.T) {
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil, nil)
 		assert.Panics(t, func() {
 			_, _ = m.GetJSON(context.Background(), validUUID)
 		})
@@ -229,7 +420,7 @@ func TestGetJSON(t *testing.T) {
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil, nil)
 		_, err := m.GetJSON(ctx, "")
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
@@ -254,7 +445,7 @@ func TestGetJSON(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
 				db.On("QueryRow", ctx, getWebhookDBQ, "userID", validUUID).Return(nil, tc.dbErr)
-				m := NewManager(db)
+				m := NewManager(cfg, db, nil)
 
 				dataJSON, err := m.GetJSON(ctx, validUUID)
 				assert.Equal(t, tc.expectedError, err)
@@ -268,7 +459,7 @@ func TestGetJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getWebhookDBQ, "userID", validUUID).Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db, nil)
 
 		dataJSON, err := m.GetJSON(ctx, validUUID)
 		assert.NoError(t, err)
@@ -277,13 +468,74 @@ func TestGetJSON(t *testing.T) {
 	})
 }
 
+func TestGetTemplateErrorsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetTemplateErrorsJSON(context.Background(), validUUID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		_, err := m.GetTemplateErrorsJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getWebhookTemplateErrorsDBQ, "userID", validUUID).Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil)
+
+				dataJSON, err := m.GetTemplateErrorsJSON(ctx, validUUID)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("webhook template errors returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhookTemplateErrorsDBQ, "userID", validUUID).Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil)
+
+		dataJSON, err := m.GetTemplateErrorsJSON(ctx, validUUID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetOwnedByOrgJSON(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 	p := &hub.Pagination{Limit: 10, Offset: 1}
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil, nil)
 		assert.Panics(t, func() {
 			_, _ = m.GetOwnedByOrgJSON(context.Background(), "orgName", p)
 		})
@@ -291,7 +543,7 @@ func TestGetOwnedByOrgJSON(t *testing.T) {
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil, nil)
 		_, err := m.GetOwnedByOrgJSON(ctx, "", p)
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
@@ -300,7 +552,7 @@ func TestGetOwnedByOrgJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getOrgWebhooksDBQ, "userID", "orgName", 10, 1).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(cfg, db, nil)
 
 		result, err := m.GetOwnedByOrgJSON(ctx, "orgName", p)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -313,7 +565,7 @@ func TestGetOwnedByOrgJSON(t *testing.T) {
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getOrgWebhooksDBQ, "userID", "orgName", 10, 1).
 			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db, nil)
 
 		result, err := m.GetOwnedByOrgJSON(ctx, "orgName", p)
 		assert.NoError(t, err)
@@ -329,7 +581,7 @@ func TestGetOwnedByUserJSON(t *testing.T) {
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil, nil)
 		assert.Panics(t, func() {
 			_, _ = m.GetOwnedByUserJSON(context.Background(), p)
 		})
@@ -339,7 +591,7 @@ func TestGetOwnedByUserJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getUserWebhooksDBQ, "userID", 10, 1).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(cfg, db, nil)
 
 		result, err := m.GetOwnedByUserJSON(ctx, p)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -351,7 +603,7 @@ func TestGetOwnedByUserJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getUserWebhooksDBQ, "userID", 10, 1).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db, nil)
 
 		result, err := m.GetOwnedByUserJSON(ctx, p)
 		assert.NoError(t, err)
@@ -385,7 +637,7 @@ func TestGetSubscribedTo(t *testing.T) {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
-				m := NewManager(nil)
+				m := NewManager(cfg, nil, nil)
 
 				webhooks, err := m.GetSubscribedTo(ctx, tc.e)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
@@ -399,7 +651,7 @@ func TestGetSubscribedTo(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getWebhooksSubscribedToPkgDBQ, hub.NewRelease, validUUID).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(cfg, db, nil)
 
 		webhooks, err := m.GetSubscribedTo(ctx, e)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -421,7 +673,7 @@ func TestGetSubscribedTo(t *testing.T) {
 			"url": "http://webhook2.url"
 		}]
 		`), nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db, nil)
 
 		w, err := m.GetSubscribedTo(ctx, e)
 		require.NoError(t, err)
@@ -451,7 +703,7 @@ func TestUpdate(t *testing.T) {
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(cfg, nil, nil)
 		assert.Panics(t, func() {
 			_ = m.Update(context.Background(), wh)
 		})
@@ -529,12 +781,25 @@ func TestUpdate(t *testing.T) {
 					},
 				},
 			},
+			{
+				"invalid repository id",
+				&hub.Webhook{
+					WebhookID:  validUUID,
+					Name:       "webhook",
+					URL:        "http://webhook1.url",
+					EventKinds: []hub.EventKind{hub.NewRelease},
+					OrgDefault: true,
+					Repositories: []*hub.WebhookRepositoryOverride{
+						{RepositoryID: "", Enabled: false},
+					},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
-				m := NewManager(nil)
+				m := NewManager(cfg, nil, nil)
 
 				err := m.Update(ctx, tc.wh)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
@@ -543,6 +808,35 @@ func TestUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("error getting webhook organization", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhookOrgNameDBQ, validUUID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.Update(ctx, wh)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhookOrgNameDBQ, validUUID).Return("org1", nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationWebhook,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, db, az)
+
+		err := m.Update(ctx, wh)
+		assert.Equal(t, tests.ErrFake, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
 	t.Run("database error", func(t *testing.T) {
 		testCases := []struct {
 			dbErr         error
@@ -562,8 +856,9 @@ func TestUpdate(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getWebhookOrgNameDBQ, validUUID).Return(nil, pgx.ErrNoRows)
 				db.On("Exec", ctx, updateWebhookDBQ, "userID", mock.Anything).Return(tc.dbErr)
-				m := NewManager(db)
+				m := NewManager(cfg, db, nil)
 
 				err := m.Update(ctx, wh)
 				assert.Equal(t, tc.expectedError, err)
@@ -572,11 +867,24 @@ func TestUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("only organization webhooks can be defaults", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhookOrgNameDBQ, validUUID).Return(nil, pgx.ErrNoRows)
+		db.On("Exec", ctx, updateWebhookDBQ, "userID", mock.Anything).Return(errOrgDefaultNotAllowedDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.Update(ctx, wh)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db.AssertExpectations(t)
+	})
+
 	t.Run("update webhook succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhookOrgNameDBQ, validUUID).Return(nil, pgx.ErrNoRows)
 		db.On("Exec", ctx, updateWebhookDBQ, "userID", mock.Anything).Return(nil)
-		m := NewManager(db)
+		m := NewManager(cfg, db, nil)
 
 		err := m.Update(ctx, wh)
 		assert.NoError(t, err)
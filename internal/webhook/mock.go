@@ -56,6 +56,13 @@ func (m *ManagerMock) GetJSON(ctx context.Context, webhookID string) ([]byte, er
 	return data, args.Error(1)
 }
 
+// GetTemplateErrorsJSON implements the WebhookManager interface.
+func (m *ManagerMock) GetTemplateErrorsJSON(ctx context.Context, webhookID string) ([]byte, error) {
+	args := m.Called(ctx, webhookID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // Update implements the WebhookManager interface.
 func (m *ManagerMock) Update(ctx context.Context, wh *hub.Webhook) error {
 	args := m.Called(ctx, wh)
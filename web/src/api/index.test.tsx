@@ -930,6 +930,9 @@ describe('API', () => {
         expect(fetchMock).toHaveBeenCalledTimes(1);
         expect(fetchMock.mock.calls[0][0]).toEqual('/api/v1/orgs/org1');
         expect(fetchMock.mock.calls[0][1]!.method).toBe('DELETE');
+        expect(fetchMock.mock.calls[0][1]!.body).toBe(
+          JSON.stringify({ confirmation: 'org1', repositories_action: 'delete' })
+        );
         expect(response).toBe('');
       });
     });
@@ -1261,6 +1264,7 @@ describe('API', () => {
         const formattedWebhook = renameKeysInObject(webhook, {
           contentType: 'content_type',
           eventKinds: 'event_kinds',
+          orgDefault: 'org_default',
         });
         const formattedPackages = webhook.packages.map((packageItem: Package) => ({
           package_id: packageItem.packageId,
@@ -1269,6 +1273,7 @@ describe('API', () => {
           JSON.stringify({
             ...formattedWebhook,
             packages: formattedPackages,
+            repositories: [],
           })
         );
         expect(response).toBe('');
@@ -1292,6 +1297,7 @@ describe('API', () => {
         const formattedWebhook = renameKeysInObject(webhook, {
           contentType: 'content_type',
           eventKinds: 'event_kinds',
+          orgDefault: 'org_default',
         });
         const formattedPackages = webhook.packages.map((packageItem: Package) => ({
           package_id: packageItem.packageId,
@@ -1300,6 +1306,7 @@ describe('API', () => {
           JSON.stringify({
             ...formattedWebhook,
             packages: formattedPackages,
+            repositories: [],
           })
         );
         expect(response).toBe('');
@@ -1359,6 +1366,7 @@ describe('API', () => {
         const formattedWebhook = renameKeysInObject(webhook, {
           contentType: 'content_type',
           eventKinds: 'event_kinds',
+          orgDefault: 'org_default',
         });
         const formattedPackages = webhook.packages.map((packageItem: Package) => ({
           package_id: packageItem.packageId,
@@ -1367,6 +1375,7 @@ describe('API', () => {
           JSON.stringify({
             ...formattedWebhook,
             packages: formattedPackages,
+            repositories: [],
           })
         );
         expect(response).toBe('');
@@ -1390,6 +1399,7 @@ describe('API', () => {
         const formattedWebhook = renameKeysInObject(webhook, {
           contentType: 'content_type',
           eventKinds: 'event_kinds',
+          orgDefault: 'org_default',
         });
         const formattedPackages = webhook.packages.map((packageItem: Package) => ({
           package_id: packageItem.packageId,
@@ -1398,6 +1408,7 @@ describe('API', () => {
           JSON.stringify({
             ...formattedWebhook,
             packages: formattedPackages,
+            repositories: [],
           })
         );
         expect(response).toBe('');
@@ -40,6 +40,7 @@ import {
   UserFullName,
   UserLogin,
   Webhook,
+  WebhookRepositoryOverride,
 } from '../types';
 import { TS_QUERY } from '../utils/data';
 import getHubBaseURL from '../utils/getHubBaseURL';
@@ -571,6 +572,10 @@ class API_CLASS {
       url: `${this.API_BASE_URL}/orgs/${orgName}`,
       opts: {
         method: 'DELETE',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ confirmation: orgName, repositories_action: 'delete' }),
       },
     });
   }
@@ -709,10 +714,18 @@ class API_CLASS {
   }
 
   public addWebhook(webhook: Webhook, fromOrgName?: string): Promise<null | string> {
-    const formattedWebhook = renameKeysInObject(webhook, { contentType: 'content_type', eventKinds: 'event_kinds' });
+    const formattedWebhook = renameKeysInObject(webhook, {
+      contentType: 'content_type',
+      eventKinds: 'event_kinds',
+      orgDefault: 'org_default',
+    });
     const formattedPackages = webhook.packages.map((packageItem: Package) => ({
       package_id: packageItem.packageId,
     }));
+    const formattedRepositories = (webhook.repositories || []).map((override: WebhookRepositoryOverride) => ({
+      repository_id: override.repositoryId,
+      enabled: override.enabled,
+    }));
     return this.apiFetch({
       url: `${this.API_BASE_URL}/webhooks${this.getUrlContext(fromOrgName)}`,
       opts: {
@@ -723,6 +736,7 @@ class API_CLASS {
         body: JSON.stringify({
           ...formattedWebhook,
           packages: formattedPackages,
+          repositories: formattedRepositories,
         }),
       },
     });
@@ -738,10 +752,18 @@ class API_CLASS {
   }
 
   public updateWebhook(webhook: Webhook, fromOrgName?: string): Promise<null | string> {
-    const formattedWebhook = renameKeysInObject(webhook, { contentType: 'content_type', eventKinds: 'event_kinds' });
+    const formattedWebhook = renameKeysInObject(webhook, {
+      contentType: 'content_type',
+      eventKinds: 'event_kinds',
+      orgDefault: 'org_default',
+    });
     const formattedPackages = webhook.packages.map((packageItem: Package) => ({
       package_id: packageItem.packageId,
     }));
+    const formattedRepositories = (webhook.repositories || []).map((override: WebhookRepositoryOverride) => ({
+      repository_id: override.repositoryId,
+      enabled: override.enabled,
+    }));
     return this.apiFetch({
       url: `${this.API_BASE_URL}/webhooks${this.getUrlContext(fromOrgName)}/${webhook.webhookId}`,
       opts: {
@@ -749,7 +771,7 @@ class API_CLASS {
         headers: {
           'Content-Type': 'application/json',
         },
-        body: JSON.stringify({ ...formattedWebhook, packages: formattedPackages }),
+        body: JSON.stringify({ ...formattedWebhook, packages: formattedPackages, repositories: formattedRepositories }),
       },
     });
   }
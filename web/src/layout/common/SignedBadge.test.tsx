@@ -27,6 +27,19 @@ describe('SignedBadge', () => {
     expect(screen.getByText('This chart has a provenance file')).toBeInTheDocument();
   });
 
+  it('renders label for OPA package', async () => {
+    render(<SignedBadge repositoryKind={2} signed />);
+    expect(screen.getByText('Signed')).toBeInTheDocument();
+
+    const badge = screen.getByTestId('elementWithTooltip');
+    expect(badge).toBeInTheDocument();
+    userEvent.hover(badge);
+
+    expect(await screen.findByRole('tooltip')).toBeInTheDocument();
+
+    expect(screen.getByText('This policies bundle has a signatures file')).toBeInTheDocument();
+  });
+
   it('does not render label for not helm package', () => {
     render(<SignedBadge repositoryKind={1} signed />);
     expect(screen.getByText('Signed')).toBeInTheDocument();
@@ -12,14 +12,29 @@ interface Props {
   repositoryKind?: RepositoryKind;
 }
 
-const SignedBadge = (props: Props) => (
-  <ElementWithTooltip
-    active={props.signed}
-    className={props.className}
-    element={<Label text="Signed" icon={<FaAward />} />}
-    tooltipMessage="This chart has a provenance file"
-    visibleTooltip={!isUndefined(props.repositoryKind) && props.repositoryKind === RepositoryKind.Helm}
-  />
-);
+const getTooltipMessage = (repositoryKind?: RepositoryKind): string | undefined => {
+  switch (repositoryKind) {
+    case RepositoryKind.Helm:
+      return 'This chart has a provenance file';
+    case RepositoryKind.OPA:
+      return 'This policies bundle has a signatures file';
+    default:
+      return undefined;
+  }
+};
+
+const SignedBadge = (props: Props) => {
+  const tooltipMessage = getTooltipMessage(props.repositoryKind);
+
+  return (
+    <ElementWithTooltip
+      active={props.signed}
+      className={props.className}
+      element={<Label text="Signed" icon={<FaAward />} />}
+      tooltipMessage={tooltipMessage || ''}
+      visibleTooltip={!isUndefined(tooltipMessage)}
+    />
+  );
+};
 
 export default SignedBadge;
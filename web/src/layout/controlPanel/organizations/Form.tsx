@@ -113,6 +113,10 @@ const OrganizationForm = React.forwardRef<HTMLFormElement, Props>((props, ref) =
         if (!isUndefined(imageId)) {
           organization.logoImageId = imageId;
         }
+
+        if (props.organization && props.organization.profile) {
+          organization.profile = props.organization.profile;
+        }
       }
       setIsValidated(true);
       return { isValid, organization };
@@ -176,7 +176,8 @@ const WebhookForm = (props: Props) => {
   const validateForm = (form: HTMLFormElement): FormValidation => {
     let webhook: Webhook | null = null;
     const formData = new FormData(form);
-    const isValid = form.checkValidity() && selectedPackages.length > 0;
+    const isValid =
+      form.checkValidity() && (selectedPackages.length > 0 || (!isUndefined(props.webhook) && !!props.webhook.orgDefault));
 
     if (isValid) {
       webhook = {
@@ -201,6 +202,8 @@ const WebhookForm = (props: Props) => {
         webhook = {
           ...webhook,
           webhookId: props.webhook.webhookId,
+          orgDefault: props.webhook.orgDefault,
+          repositories: props.webhook.repositories,
         };
       }
     }
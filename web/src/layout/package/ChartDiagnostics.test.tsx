@@ -0,0 +1,39 @@
+import { render, screen } from '@testing-library/react';
+import React from 'react';
+
+import { ChartDiagnostic } from '../../types';
+import ChartDiagnostics from './ChartDiagnostics';
+
+const defaultProps: { diagnostics: ChartDiagnostic[] } = {
+  diagnostics: [
+    { severity: 'warning', path: 'Chart.yaml', message: 'chart directory is missing these dependencies: pkg2' },
+    {
+      severity: 'error',
+      path: 'templates/',
+      message: 'template: pkg1/templates/cm.yaml:1:11: nil pointer evaluating interface {}.field',
+    },
+  ],
+};
+
+describe('ChartDiagnostics', () => {
+  it('renders component', () => {
+    render(<ChartDiagnostics {...defaultProps} />);
+
+    expect(screen.getByText('Chart diagnostics')).toBeInTheDocument();
+    expect(screen.getAllByTestId('chartDiagnostic')).toHaveLength(2);
+    expect(screen.getByLabelText('Warning')).toBeInTheDocument();
+    expect(screen.getByLabelText('Error')).toBeInTheDocument();
+    expect(screen.getByText('Chart.yaml:')).toBeInTheDocument();
+    expect(screen.getByText(/nil pointer evaluating/)).toBeInTheDocument();
+  });
+
+  it('does not render component when there are no diagnostics', () => {
+    const { container } = render(<ChartDiagnostics diagnostics={[]} />);
+    expect(container).toBeEmptyDOMElement();
+  });
+
+  it('does not render component when diagnostics is null', () => {
+    const { container } = render(<ChartDiagnostics diagnostics={null} />);
+    expect(container).toBeEmptyDOMElement();
+  });
+});
@@ -0,0 +1,44 @@
+import isNull from 'lodash/isNull';
+import isUndefined from 'lodash/isUndefined';
+import React from 'react';
+import { MdError, MdWarning } from 'react-icons/md';
+
+import { ChartDiagnostic } from '../../types';
+import SmallTitle from '../common/SmallTitle';
+import styles from './ChartDiagnostics.module.css';
+
+interface Props {
+  diagnostics?: ChartDiagnostic[] | null;
+}
+
+const ChartDiagnostics = (props: Props) => {
+  if (isUndefined(props.diagnostics) || isNull(props.diagnostics) || props.diagnostics.length === 0) return null;
+
+  return (
+    <>
+      <SmallTitle text="Chart diagnostics" id="chart-diagnostics-list" />
+      <div className="mb-3" role="list" aria-describedby="chart-diagnostics-list">
+        {props.diagnostics.map((diagnostic: ChartDiagnostic, index: number) => (
+          <div
+            data-testid="chartDiagnostic"
+            className="d-flex flex-row align-items-start py-1 py-sm-0"
+            key={`diagnostic-${index}`}
+            role="listitem"
+          >
+            {diagnostic.severity === 'error' ? (
+              <MdError className={`text-danger mr-2 ${styles.icon}`} aria-label="Error" />
+            ) : (
+              <MdWarning className={`text-warning mr-2 ${styles.icon}`} aria-label="Warning" />
+            )}
+            <small className={`text-break ${styles.message}`}>
+              {diagnostic.path && <span className="font-weight-bold mr-1">{diagnostic.path}:</span>}
+              {diagnostic.message}
+            </small>
+          </div>
+        ))}
+      </div>
+    </>
+  );
+};
+
+export default ChartDiagnostics;
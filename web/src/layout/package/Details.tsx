@@ -16,6 +16,7 @@ import RSSLinkTitle from '../common/RSSLinkTitle';
 import SeeAllModal from '../common/SeeAllModal';
 import SmallTitle from '../common/SmallTitle';
 import CapabilityLevel from './CapabilityLevel';
+import ChartDiagnostics from './ChartDiagnostics';
 import ContainersImages from './ContainersImages';
 import Dependencies from './Dependencies';
 import styles from './Details.module.css';
@@ -300,6 +301,10 @@ const Details = (props: Props) => {
           <Dependencies dependencies={props.package.data.dependencies} packageId={props.package.packageId} />
         )}
 
+      {props.package.repository.kind === RepositoryKind.Helm && (
+        <ChartDiagnostics diagnostics={props.package.chartDiagnostics} />
+      )}
+
       {props.package.repository.kind === RepositoryKind.Krew &&
         !isUndefined(props.package.data) &&
         !isNull(props.package.data) &&
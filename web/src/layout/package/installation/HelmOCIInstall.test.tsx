@@ -42,6 +42,18 @@ describe('HelmOCIInstall', () => {
       expect(screen.getByText('helm install my-packageName ./packageName')).toBeInTheDocument();
     });
 
+    it('renders component for repository pointing to a namespace', () => {
+      render(
+        <HelmOCIInstall
+          {...defaultProps}
+          repository={{ ...defaultProps.repository, url: 'oci://ghcr.io/artifacthub/charts/' }}
+          contentUrl="oci://ghcr.io/artifacthub/charts/team/packageName:1.0.0"
+        />
+      );
+
+      expect(screen.getAllByText(/ghcr.io\/artifacthub\/charts\/team\/packageName:1.0.0/g)).toHaveLength(2);
+    });
+
     it('renders private repo', () => {
       render(<HelmOCIInstall {...defaultProps} repository={{ ...defaultProps.repository, private: true }} />);
 
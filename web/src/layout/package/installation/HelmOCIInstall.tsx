@@ -10,10 +10,17 @@ interface Props {
   name: string;
   version?: string;
   repository: Repository;
+  contentUrl?: string;
 }
 
 const HelmOCIInstall = (props: Props) => {
-  const url = props.repository.url.replace(OCI_PREFIX, '');
+  let url = props.repository.url.replace(OCI_PREFIX, '');
+  if (url.endsWith('/')) {
+    // Repository points to a namespace holding multiple charts
+    url = props.contentUrl
+      ? props.contentUrl.replace(OCI_PREFIX, '').replace(`:${props.version}`, '')
+      : `${url}${props.name}`;
+  }
 
   return (
     <>
@@ -149,6 +149,7 @@ const InstallationModal = (props: Props) => {
                                 name={method.props.name!}
                                 version={method.props.version}
                                 repository={method.props.repository!}
+                                contentUrl={method.props.contentUrl}
                               />
                             );
                           case InstallMethodKind.OLM:
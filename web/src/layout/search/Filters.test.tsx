@@ -442,6 +442,45 @@ describe('Filters', () => {
 
       expect(screen.queryByText('Operator capabilities')).toBeNull();
     });
+
+    it('renders category facet using categories names', () => {
+      const props = {
+        ...defaultProps,
+        facets: [
+          {
+            title: 'Category',
+            filterKey: 'category',
+            options: [
+              {
+                id: 'monitoring-logging',
+                name: 'monitoring-logging',
+                total: 4,
+              },
+            ],
+          },
+        ],
+      };
+      render(<Filters {...props} />);
+
+      expect(screen.getByText('Category')).toBeInTheDocument();
+      expect(screen.getByText('Monitoring and logging')).toBeInTheDocument();
+    });
+
+    it('does not render category facet when no options', () => {
+      const props = {
+        ...defaultProps,
+        facets: [
+          {
+            title: 'Category',
+            filterKey: 'category',
+            options: [],
+          },
+        ],
+      };
+      render(<Filters {...props} />);
+
+      expect(screen.queryByText('Category')).toBeNull();
+    });
   });
 
   describe('Operator capabilities facets', () => {
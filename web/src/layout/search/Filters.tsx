@@ -10,7 +10,7 @@ import { IoMdCloseCircleOutline } from 'react-icons/io';
 import { MdInfoOutline } from 'react-icons/md';
 
 import { FacetOption, Facets, Option } from '../../types';
-import { OPERATOR_CAPABILITIES } from '../../utils/data';
+import { OPERATOR_CAPABILITIES, PACKAGE_CATEGORIES } from '../../utils/data';
 import CheckBox from '../common/Checkbox';
 import ElementWithTooltip from '../common/ElementWithTooltip';
 import InputTypeaheadWithDropdown from '../common/InputTypeaheadWithDropdown';
@@ -131,6 +131,49 @@ const Filters = (props: Props) => {
     return kindElement;
   };
 
+  const getCategoryFacets = (): JSX.Element | null => {
+    let element = null;
+    const category = getFacetsByFilterKey('category');
+    if (!isUndefined(category) && category.options.length > 0) {
+      const active = props.activeFilters.hasOwnProperty(category.filterKey)
+        ? props.activeFilters[category.filterKey]
+        : [];
+      const isChecked = (facetOptionId: string) => {
+        return active.includes(facetOptionId.toString());
+      };
+
+      element = (
+        <div role="menuitem" className={`mt-2 mt-sm-3 pt-1 ${styles.facet}`}>
+          <SmallTitle
+            text={category.title}
+            className="text-dark font-weight-bold"
+            id={`pkg-${category.filterKey}-${props.device}`}
+          />
+          <div className="mt-3" role="group" aria-labelledby={`pkg-${category.filterKey}-${props.device}`}>
+            {category.options.map((option: FacetOption) => (
+              <CheckBox
+                key={`category_${option.id.toString()}`}
+                name={category.filterKey}
+                device={props.device}
+                value={option.id.toString()}
+                className={styles.checkbox}
+                labelClassName="w-100"
+                legend={option.total}
+                label={PACKAGE_CATEGORIES[option.id.toString()] || option.name}
+                checked={isChecked(option.id.toString())}
+                onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+                  props.onChange(e.target.name, e.target.value, e.target.checked)
+                }
+              />
+            ))}
+          </div>
+        </div>
+      );
+    }
+
+    return element;
+  };
+
   const getCapabilitiesFacets = (): JSX.Element | null => {
     let element = null;
     const capabilities = getFacetsByFilterKey('capabilities');
@@ -306,6 +349,7 @@ const Filters = (props: Props) => {
       {getPublishers()}
       {getRepositoryFacets()}
       {getLicenseFacets()}
+      {getCategoryFacets()}
       {getCapabilitiesFacets()}
 
       <div role="menuitem" className={`mt-2 mt-sm-3 pt-1 ${styles.facet}`}>
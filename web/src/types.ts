@@ -12,6 +12,18 @@ export enum RepositoryKind {
   KedaScaler,
   CoreDNS,
   Keptn,
+  Kustomize,
+  Terraform,
+  Crossplane,
+  Kyverno,
+  Gatekeeper,
+  ArgoWorkflowTemplate,
+  Backstage,
+  Wasm,
+  Devcontainer,
+  Headlamp,
+  Knative,
+  Container,
 }
 
 export enum KeptnData {
@@ -66,6 +78,7 @@ export interface Package {
   packageId: string;
   name: string;
   displayName?: string;
+  alias?: string;
   normalizedName: string;
   description: string;
   logoImageId?: string;
@@ -106,6 +119,13 @@ export interface Package {
   stats?: PackageStats;
   allContainersImagesWhitelisted?: boolean;
   signKey?: HelmChartSignKey;
+  chartDiagnostics?: ChartDiagnostic[] | null;
+}
+
+export interface ChartDiagnostic {
+  severity: 'error' | 'warning';
+  path?: string;
+  message: string;
 }
 
 export interface HelmChartSignKey {
@@ -121,6 +141,7 @@ export interface PackageStats {
 export interface ContainerImage {
   image: string;
   name?: string;
+  digest?: string;
   whitelisted?: boolean;
 }
 
@@ -309,10 +330,19 @@ export interface Organization {
   homeUrl?: string;
   logoImageId?: string;
   description?: string;
+  profile?: string;
+  profileHtml?: string;
+  links?: OrganizationLink[];
   membersCount?: number | null;
   confirmed?: boolean | null;
 }
 
+export interface OrganizationLink {
+  url: string;
+  verified: boolean;
+  verificationToken?: string;
+}
+
 export interface RefInputField {
   checkIsValid: () => Promise<boolean>;
   reset: () => void;
@@ -400,10 +430,18 @@ export interface Webhook extends TestWebhook {
   description?: string;
   secret?: string;
   active: boolean;
+  orgDefault?: boolean;
   packages: Package[];
+  repositories?: WebhookRepositoryOverride[];
   lastNotifications?: null | WebhookNotification[];
 }
 
+export interface WebhookRepositoryOverride {
+  repositoryId: string;
+  name?: string;
+  enabled: boolean;
+}
+
 export interface WebhookNotification {
   notificationId: string;
   createdAt: number;
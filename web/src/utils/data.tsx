@@ -371,6 +371,17 @@ export const OPERATOR_CAPABILITIES = [
   'auto pilot',
 ];
 
+export const PACKAGE_CATEGORIES: { [key: string]: string } = {
+  'ai-machine-learning': 'AI / Machine learning',
+  database: 'Database',
+  'integration-delivery': 'Integration and delivery',
+  'monitoring-logging': 'Monitoring and logging',
+  networking: 'Networking',
+  security: 'Security',
+  storage: 'Storage',
+  'streaming-messaging': 'Streaming and messaging',
+};
+
 export const SEARH_TIPS: SearchTipItem[] = [
   {
     content: (
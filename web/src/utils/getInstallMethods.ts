@@ -99,6 +99,7 @@ export default (props: PackageInfo): InstallMethodOutput => {
               name: pkg.name,
               version: pkg.version,
               repository: pkg.repository,
+              contentUrl: pkg.contentUrl,
             },
           });
         } else {
@@ -24,6 +24,30 @@ const getRepoKind = (repoName: string): RepositoryKind | null => {
       return RepositoryKind.CoreDNS;
     case 'keptn':
       return RepositoryKind.Keptn;
+    case 'kustomize':
+      return RepositoryKind.Kustomize;
+    case 'terraform':
+      return RepositoryKind.Terraform;
+    case 'crossplane':
+      return RepositoryKind.Crossplane;
+    case 'kyverno':
+      return RepositoryKind.Kyverno;
+    case 'gatekeeper':
+      return RepositoryKind.Gatekeeper;
+    case 'argo-workflow-template':
+      return RepositoryKind.ArgoWorkflowTemplate;
+    case 'backstage':
+      return RepositoryKind.Backstage;
+    case 'wasm':
+      return RepositoryKind.Wasm;
+    case 'devcontainer':
+      return RepositoryKind.Devcontainer;
+    case 'headlamp':
+      return RepositoryKind.Headlamp;
+    case 'knative':
+      return RepositoryKind.Knative;
+    case 'container':
+      return RepositoryKind.Container;
     default:
       return null;
   }
@@ -53,6 +77,30 @@ const getRepoKindName = (repoKind: RepositoryKind): string | null => {
       return 'coredns';
     case RepositoryKind.Keptn:
       return 'keptn';
+    case RepositoryKind.Kustomize:
+      return 'kustomize';
+    case RepositoryKind.Terraform:
+      return 'terraform';
+    case RepositoryKind.Crossplane:
+      return 'crossplane';
+    case RepositoryKind.Kyverno:
+      return 'kyverno';
+    case RepositoryKind.Gatekeeper:
+      return 'gatekeeper';
+    case RepositoryKind.ArgoWorkflowTemplate:
+      return 'argo-workflow-template';
+    case RepositoryKind.Backstage:
+      return 'backstage';
+    case RepositoryKind.Wasm:
+      return 'wasm';
+    case RepositoryKind.Devcontainer:
+      return 'devcontainer';
+    case RepositoryKind.Headlamp:
+      return 'headlamp';
+    case RepositoryKind.Knative:
+      return 'knative';
+    case RepositoryKind.Container:
+      return 'container';
     default:
       return null;
   }